@@ -0,0 +1,1802 @@
+// Code generated by go run ./cmd/tsclient; DO NOT EDIT.
+// geonotify-service API 1.0
+
+export interface APIKeyUsageCurrent {
+  error?: string;
+  this_month?: number;
+  today?: number;
+}
+
+export interface APIKeyUsageResponse {
+  current?: APIKeyUsageCurrent;
+  daily_quota?: number;
+  days?: UsageDayResponse[];
+  key?: string;
+  monthly_quota?: number;
+  total?: number;
+}
+
+export interface AlertEvent {
+  check_id?: number;
+  created_at?: string;
+  incidents?: IncidentResponse[];
+  type?: string;
+  user_id?: string;
+}
+
+export interface ApprovalRejectRequest {
+  reason?: string;
+}
+
+export interface ApprovalResponse {
+  approval_id?: number;
+  decided_at?: string;
+  decided_by?: string;
+  incident_id?: number;
+  reason?: string;
+  requested_at?: string;
+  requested_by?: string;
+  severity?: "critical";
+  status?: "pending" | "approved" | "rejected";
+}
+
+export interface ApprovalsListResponse {
+  approvals?: ApprovalResponse[];
+}
+
+export interface AttachmentResponse {
+  attachment_id?: number;
+  content_type?: string;
+  created_at?: string;
+  file_name?: string;
+  incident_id?: number;
+  size_bytes?: number;
+  url?: string;
+}
+
+export interface AttachmentsListResponse {
+  attachments?: AttachmentResponse[];
+}
+
+export interface CheckResponse {
+  check_id?: number;
+  created_at?: string;
+  has_alert?: boolean;
+  latitude?: number;
+  longitude?: number;
+  user_id?: string;
+}
+
+export interface DashboardResponse {
+  active_incidents?: IncidentResponse[];
+  failed_webhooks?: FailedWebhookResponse[];
+  generated_at?: string;
+  recent_checks?: CheckResponse[];
+  webhook_queue?: WebhookQueueResponse;
+}
+
+export interface DataExportResponse {
+  attempts?: number;
+  completed_at?: string;
+  /** DownloadURL — временная подписанная ссылка на JSON-архив, есть только у готовой выгрузки */
+  download_url?: string;
+  /** ExpiresAt — когда архив будет удален из хранилища */
+  expires_at?: string;
+  export_id?: number;
+  last_error?: string;
+  requested_at?: string;
+  requested_by?: string;
+  size_bytes?: number;
+  status?: "pending" | "ready";
+  user_id?: string;
+}
+
+export interface DependencyStatus {
+  error?: string;
+  latency_ms?: number;
+  status?: string;
+}
+
+export interface ErasureResponse {
+  attempts?: number;
+  completed_at?: string;
+  confirmed_at?: string;
+  confirmed_by?: string;
+  /** Erased заполняется после выполнения заявки */
+  erased?: ErasureResultResponse;
+  erasure_id?: number;
+  last_error?: string;
+  mode?: "delete" | "anonymize";
+  requested_at?: string;
+  requested_by?: string;
+  status?: "pending" | "confirmed" | "completed" | "cancelled";
+  user_id?: string;
+}
+
+export interface ErasureResultResponse {
+  checks?: number;
+  group_memberships?: number;
+  preferences?: number;
+  webhooks?: number;
+  zones?: number;
+}
+
+export interface ErasuresListResponse {
+  erasures?: ErasureResponse[];
+}
+
+export interface ErrorResponse {
+  /** Details — нарушения по полям для ошибок проверки запроса */
+  details?: FieldError[];
+  error?: string;
+  message?: string;
+}
+
+export interface FailedWebhookResponse {
+  check_id?: number;
+  created_at?: string;
+  endpoint_id?: number;
+  event?: string;
+  failed_at?: string;
+  retry_cnt?: number;
+  webhook_id?: number;
+}
+
+export interface FieldError {
+  field?: string;
+  message?: string;
+  rule?: string;
+}
+
+export interface GroupCreateRequest {
+  descr?: string;
+  managers: string[];
+  name: string;
+  user_ids: string[];
+}
+
+export interface GroupMemberInDangerResponse {
+  user_id?: string;
+  zones?: GroupMemberZoneResponse[];
+}
+
+export interface GroupMemberZoneResponse {
+  entered_at?: string;
+  incident_id?: number;
+  name?: string;
+  severity?: "low" | "medium" | "high" | "critical";
+}
+
+export interface GroupMembersAddedResponse {
+  added?: number;
+}
+
+export interface GroupMembersRequest {
+  user_ids: string[];
+}
+
+export interface GroupMembersResponse {
+  group_id?: number;
+  user_ids?: string[];
+}
+
+export interface GroupPatchRequest {
+  descr?: string;
+  managers: string[];
+  name?: string;
+}
+
+export interface GroupResponse {
+  created_at?: string;
+  created_by?: string;
+  descr?: string;
+  group_id?: number;
+  managers?: string[];
+  member_count?: number;
+  name?: string;
+  updated_at?: string;
+  /** Webhook отсутствует, пока получатель группы не зарегистрирован */
+  webhook?: GroupWebhookResponse;
+}
+
+export interface GroupStatusResponse {
+  group_id?: number;
+  members_in_danger?: GroupMemberInDangerResponse[];
+  members_total?: number;
+  name?: string;
+}
+
+export interface GroupWebhookRequest {
+  secret?: string;
+  url: string;
+}
+
+export interface GroupWebhookResponse {
+  enabled?: boolean;
+  endpoint_id?: number;
+  has_secret?: boolean;
+  url?: string;
+}
+
+export interface GroupsListResponse {
+  groups?: GroupResponse[];
+}
+
+export interface HeatmapCellResponse {
+  /** Alerts — проверки, попавшие в зону */
+  alerts?: number;
+  /** Cell — индекс H3-ячейки в шестнадцатеричной записи */
+  cell?: string;
+  checks?: number;
+  /** Latitude и Longitude — центр ячейки, для карт без библиотеки H3 */
+  latitude?: number;
+  longitude?: number;
+}
+
+export interface HeatmapResponse {
+  cells?: HeatmapCellResponse[];
+  from?: string;
+  resolution?: number;
+  to?: string;
+}
+
+export interface IncidentBacktestRequest {
+  hours?: number;
+  radius_m?: number;
+}
+
+export interface IncidentBacktestResponse {
+  checks_matched?: number;
+  checks_scanned?: number;
+  from?: string;
+  incident_id?: number;
+  radius_m?: number;
+  to?: string;
+  truncated?: boolean;
+  users_alerted?: number;
+  users_newly_alerted?: number;
+}
+
+export interface IncidentBatchRequest {
+  action: "activate" | "deactivate" | "delete";
+  ids: number[];
+}
+
+export interface IncidentBatchResponse {
+  action?: string;
+  affected?: number;
+}
+
+export interface IncidentCheckStatsResponse {
+  checks?: number;
+  incident_id?: number;
+  name?: string;
+}
+
+export interface IncidentCommentRequest {
+  body: string;
+}
+
+export interface IncidentCommentResponse {
+  author?: string;
+  body?: string;
+  created_at?: string;
+  id?: number;
+}
+
+export interface IncidentCommentsResponse {
+  comments?: IncidentCommentResponse[];
+  incident_id?: number;
+}
+
+export interface IncidentCreateRequest {
+  /** Address геокодируется, если latitude и longitude не переданы */
+  address?: string;
+  /** CRS — система координат центра, по умолчанию EPSG:4326. Радиус всегда в метрах */
+  crs?: string;
+  descr?: string;
+  /** ExpiresAt и TTLMinutes взаимоисключающие: TTL отсчитывается от момента запроса */
+  expires_at?: string;
+  latitude?: number;
+  longitude?: number;
+  name: string;
+  radius_m?: number;
+  schedule?: string;
+  schedule_duration_minutes?: number;
+  /** Severity по умолчанию medium; critical публикуется только с одобрения второго оператора */
+  severity?: "low" | "medium" | "high" | "critical";
+  /** State по умолчанию active; planned — опасность ожидается, но еще не действует */
+  state?: "planned" | "active" | "contained";
+  /** Status по умолчанию published для публикатора без обязательного ревью, иначе draft */
+  status?: "draft" | "published";
+  ttl_minutes?: number;
+  /** Visibility по умолчанию public; restricted — зона предупреждает без названия и описания и не попадает
+в публичные ленты, internal — еще и скрыта от редакторов, выставляет ее только публикатор */
+  visibility?: "public" | "restricted" | "internal";
+}
+
+export interface IncidentCreateResponse {
+  incident_id?: number;
+}
+
+export interface IncidentLineageResponse {
+  incident_id?: number;
+  links?: IncidentLinkResponse[];
+}
+
+export interface IncidentLinkResponse {
+  actor?: string;
+  child_id?: number;
+  created_at?: string;
+  operation?: "merge" | "split";
+  parent_id?: number;
+}
+
+export interface IncidentMergeRequest {
+  descr?: string;
+  ids: number[];
+  name: string;
+}
+
+export interface IncidentOverlapResponse {
+  conflicting_incident_ids?: number[];
+  error?: string;
+  message?: string;
+}
+
+export interface IncidentPatchRequest {
+  address?: string;
+  descr?: string;
+  /** ExpiresAt со значением null снимает срок действия */
+  expires_at?: string | null;
+  /** IsActive меняет стадию так же, как в IncidentUpdateRequest */
+  is_active?: boolean;
+  latitude?: number;
+  longitude?: number;
+  name?: string;
+  radius_m?: number;
+  schedule?: string;
+  schedule_duration_minutes?: number;
+  severity?: "low" | "medium" | "high" | "critical";
+  ttl_minutes?: number;
+  visibility?: "public" | "restricted" | "internal";
+}
+
+export interface IncidentRelationRequest {
+  related_id: number;
+  type: "parent" | "supersedes";
+}
+
+export interface IncidentRelationResponse {
+  created_at?: string;
+  created_by?: string;
+  related_id?: number;
+  type?: "parent" | "child" | "supersedes" | "superseded_by";
+}
+
+export interface IncidentRelationsResponse {
+  incident_id?: number;
+  relations?: IncidentRelationResponse[];
+}
+
+export interface IncidentResponse {
+  address?: string;
+  created_at?: string;
+  created_by?: string;
+  descr?: string;
+  expires_at?: string;
+  /** Geometry — GeoJSON MultiPolygon полигональной зоны, для круглых зон не возвращается */
+  geometry?: Record<string, unknown>;
+  incident_id?: number;
+  is_active?: boolean;
+  latitude?: number;
+  longitude?: number;
+  name?: string;
+  next_activation?: string;
+  published_at?: string;
+  published_by?: string;
+  radius_m?: number;
+  /** Relations — связи с другими зонами; возвращаются при чтении зоны и списка */
+  relations?: IncidentRelationResponse[];
+  schedule?: string;
+  schedule_duration_minutes?: number;
+  severity?: "low" | "medium" | "high" | "critical";
+  source?: "manual" | "feed" | "weather";
+  state?: "planned" | "active" | "contained" | "resolved" | "archived";
+  /** StateTimes — когда зона последний раз входила в каждую из пройденных стадий */
+  state_changed_at?: string;
+  state_times?: Record<string, string>;
+  status?: "draft" | "published" | "archived";
+  updated_at?: string;
+  updated_by?: string;
+  visibility?: "public" | "restricted" | "internal";
+}
+
+export interface IncidentSplitPart {
+  /** Geometry — GeoJSON в том же формате, что и для PUT /incidents/{id}/geometry */
+  geometry: Record<string, unknown>;
+  name: string;
+}
+
+export interface IncidentSplitRequest {
+  parts: IncidentSplitPart[];
+}
+
+export interface IncidentSplitResponse {
+  incidents?: IncidentResponse[];
+}
+
+export interface IncidentStateRequest {
+  state: "planned" | "active" | "contained" | "resolved" | "archived";
+}
+
+export interface IncidentStatsResponse {
+  alert_checks?: number;
+  /** Incidents — зоны, в которые попадали проверки, начиная с самых частых; удаленные зоны не возвращаются */
+  incidents?: IncidentCheckStatsResponse[];
+  /** PeriodEnd — до какой минуты проверки сведены; отстает от текущего времени на задержку сводки */
+  period_end?: string;
+  period_start?: string;
+  total_checks?: number;
+  user_count?: number;
+  window_minutes?: number;
+}
+
+export interface IncidentTranslationRequest {
+  descr?: string;
+  name: string;
+}
+
+export interface IncidentTranslationResponse {
+  descr?: string;
+  locale?: string;
+  name?: string;
+  updated_at?: string;
+  updated_by?: string;
+}
+
+export interface IncidentTranslationsResponse {
+  incident_id?: number;
+  translations?: IncidentTranslationResponse[];
+}
+
+export interface IncidentUpdateRequest {
+  /** Address геокодируется, если latitude и longitude не переданы */
+  address?: string;
+  descr?: string;
+  /** ExpiresAt и TTLMinutes взаимоисключающие: TTL отсчитывается от момента запроса */
+  expires_at?: string;
+  /** IsActive меняет стадию: true для запланированной или завершенной зоны — active,
+false для действующей — resolved (см. POST /incidents/{id}/state) */
+  is_active?: boolean;
+  latitude?: number;
+  longitude?: number;
+  name: string;
+  radius_m?: number;
+  schedule?: string;
+  schedule_duration_minutes?: number;
+  /** Severity и Visibility не меняются, если не переданы */
+  severity?: "low" | "medium" | "high" | "critical";
+  ttl_minutes?: number;
+  visibility?: "public" | "restricted" | "internal";
+}
+
+export interface IncidentVersionResponse {
+  changed_by?: string;
+  /** Geometry — GeoJSON MultiPolygon полигональной зоны, для круглых зон не возвращается */
+  geometry?: Record<string, unknown>;
+  latitude?: number;
+  longitude?: number;
+  radius_m?: number;
+  valid_from?: string;
+  version?: number;
+}
+
+export interface IncidentVersionsResponse {
+  incident_id?: number;
+  versions?: IncidentVersionResponse[];
+}
+
+export interface IncidentsListResponse {
+  incidents?: IncidentResponse[];
+  limit?: number;
+  /** NextCursor — курсор следующей страницы при запросе с cursor, на последней странице пуст.
+В этом режиме page и total_pages равны 0 */
+  next_cursor?: string;
+  page?: number;
+  /** TotalEstimated — total_pages посчитан по оценке числа инцидентов (запрос с count=estimated) */
+  total_estimated?: boolean;
+  total_pages?: number;
+}
+
+export interface LivenessResponse {
+  status?: string;
+  timestamp?: string;
+}
+
+export interface LocationCheckBatchRequest {
+  checks: LocationCheckRequest[];
+}
+
+export interface LocationCheckBatchResponse {
+  /** Results — итоги в порядке checks из запроса */
+  results?: LocationCheckBatchResult[];
+}
+
+export interface LocationCheckBatchResult {
+  error?: string;
+  result?: V2LocationCheckResponse;
+}
+
+export interface LocationCheckRequest {
+  /** AccuracyM — радиус погрешности координат в метрах */
+  accuracy_m?: number;
+  /** Altitude — высота над уровнем моря в метрах */
+  altitude?: number;
+  /** CRS — система координат точки, по умолчанию EPSG:4326.
+Для проецированных систем latitude — northing (y), longitude — easting (x) */
+  crs?: string;
+  heading_deg?: number;
+  latitude?: number;
+  longitude?: number;
+  /** SpeedMps и HeadingDeg (градусы от севера по часовой стрелке) включают прогноз попадания в зону */
+  speed_mps?: number;
+  user_id: string;
+}
+
+export interface LocationCheckResponse {
+  has_alert?: boolean;
+  incidents?: IncidentResponse[];
+  place?: string;
+}
+
+export interface LoginRequest {
+  password: string;
+  username: string;
+}
+
+export interface LoginResponse {
+  access_token?: string;
+  expires_at?: string;
+  token_type?: string;
+}
+
+export interface MessageResponse {
+  message?: string;
+}
+
+export interface NearestZone {
+  bearing_deg?: number;
+  distance_m?: number;
+  /** DistanceToEdgeM — сколько осталось до границы зоны */
+  distance_to_edge_m?: number;
+  incident_id?: number;
+  latitude?: number;
+  longitude?: number;
+  name?: string;
+  radius_m?: number;
+  visibility?: "public" | "restricted" | "internal";
+}
+
+export interface OperatorCreateRequest {
+  oidc_subject?: string;
+  password?: string;
+  /** Role — editor (по умолчанию) или publisher */
+  role?: "editor" | "publisher";
+  username: string;
+}
+
+export interface OperatorCreateResponse {
+  id?: number;
+}
+
+export interface PublicIncidentResponse {
+  expires_at?: string;
+  /** Geometry — GeoJSON MultiPolygon полигональной зоны, для круглых зон не возвращается */
+  geometry?: Record<string, unknown>;
+  incident_id?: number;
+  latitude?: number;
+  longitude?: number;
+  name?: string;
+  published_at?: string;
+  radius_m?: number;
+  severity?: "low" | "medium" | "high" | "critical";
+  state?: "planned" | "active" | "contained" | "resolved" | "archived";
+  updated_at?: string;
+}
+
+export interface PublicIncidentsListResponse {
+  incidents?: PublicIncidentResponse[];
+}
+
+export interface QueryStatResponse {
+  buckets?: number[];
+  calls?: number;
+  errors?: number;
+  max_ms?: number;
+  mean_ms?: number;
+  sql?: string;
+  total_ms?: number;
+}
+
+export interface QueryStatsResponse {
+  /** BucketsMs — верхние границы корзин гистограммы; последняя корзина в buckets — дольше всех границ */
+  buckets_ms?: number[];
+  queries?: QueryStatResponse[];
+}
+
+export interface QueuesResponse {
+  generated_at?: string;
+  outbox?: WebhookOutboxResponse;
+  queue?: WebhookQueueDepthResponse;
+}
+
+export interface QuietHoursRequest {
+  days?: string[];
+  end: string;
+  start: string;
+}
+
+export interface QuietHoursResponse {
+  days?: ("mon" | "tue" | "wed" | "thu" | "fri" | "sat" | "sun")[];
+  end?: string;
+  start?: string;
+}
+
+export interface ReadinessResponse {
+  checks?: Record<string, DependencyStatus>;
+  /** Degraded — необязательная зависимость (Redis) недоступна, но запросы обслуживаются */
+  degraded?: boolean;
+  status?: string;
+  timestamp?: string;
+}
+
+export interface RuntimeConfigResponse {
+  cache_ttl_minutes?: number;
+  log_level?: string;
+  prediction_horizon_seconds?: number;
+  webhook_max_retries?: number;
+  webhook_retry_delay_seconds?: number;
+}
+
+export interface StatsResponse {
+  /** Estimated — user_count и total_checks приблизительные (запрос с count=estimated) */
+  estimated?: boolean;
+  period_start?: string;
+  total_checks?: number;
+  user_count?: number;
+  window_minutes?: number;
+}
+
+export interface UsageDayResponse {
+  alerts?: number;
+  checks?: number;
+  day?: string;
+  requests?: number;
+  webhooks?: number;
+}
+
+export interface UsageExportResponse {
+  from?: string;
+  rows?: UsageExportRow[];
+  to?: string;
+}
+
+export interface UsageExportRow {
+  alerts?: number;
+  api_key?: string;
+  checks?: number;
+  day?: string;
+  requests?: number;
+  webhooks?: number;
+}
+
+export interface UsageResponse {
+  enabled?: boolean;
+  from?: string;
+  keys?: APIKeyUsageResponse[];
+  to?: string;
+}
+
+export interface UserPreferencesRequest {
+  channels?: string[];
+  max_alerts_per_hour?: number;
+  min_severity?: "low" | "medium" | "high" | "critical";
+  quiet_hours?: QuietHoursRequest[];
+  timezone?: string;
+}
+
+export interface UserPreferencesResponse {
+  channels?: ("stream" | "webhook")[];
+  /** MaxAlertsPerHour 0 — без ограничения */
+  max_alerts_per_hour?: number;
+  min_severity?: "low" | "medium" | "high" | "critical";
+  quiet_hours?: QuietHoursResponse[];
+  timezone?: string;
+  /** UpdatedAt отсутствует, пока пользователь не сохранял настройки */
+  updated_at?: string;
+  user_id?: string;
+}
+
+export interface V2LocationCheckResponse {
+  /** Ahead — зоны на пути, если в запросе переданы speed_mps и heading_deg */
+  ahead?: ZoneAhead[];
+  has_alert?: boolean;
+  /** Match — inside, possibly_inside или outside с учетом accuracy_m */
+  match?: string;
+  /** Nearest — ближайшая зона, в которую точка не попала */
+  nearest?: NearestZone;
+  place?: string;
+  zones?: ZoneMatch[];
+}
+
+export interface WebhookEndpointCreateRequest {
+  backoff?: "linear" | "exponential";
+  /** BatchSize 0 — каждое событие отдельным запросом; иначе события уходят NDJSON-пачками
+до batch_size штук, ожидая не дольше batch_wait_ms */
+  batch_size?: number;
+  batch_wait_ms?: number;
+  compression?: "gzip" | "none";
+  enabled?: boolean;
+  events: string[];
+  max_retries?: number;
+  retry_delay_seconds?: number;
+  secret?: string;
+  timeout_seconds?: number;
+  url: string;
+}
+
+export interface WebhookEndpointPatchRequest {
+  backoff?: "linear" | "exponential";
+  /** batch_size 0 выключает батчинг, compression none — сжатие */
+  batch_size?: number;
+  batch_wait_ms?: number;
+  compression?: "gzip" | "none";
+  enabled?: boolean;
+  events: string[];
+  max_retries?: number;
+  retry_delay_seconds?: number;
+  secret?: string;
+  timeout_seconds?: number;
+  url?: string;
+}
+
+export interface WebhookEndpointResponse {
+  backoff?: string;
+  /** отсутствующий batch_size — каждое событие отдельным запросом, compression — без сжатия */
+  batch_size?: number;
+  batch_wait_ms?: number;
+  compression?: string;
+  created_at?: string;
+  created_by?: string;
+  enabled?: boolean;
+  endpoint_id?: number;
+  events?: string[];
+  has_secret?: boolean;
+  /** отсутствующие поля политики попыток берутся из настроек сервиса */
+  max_retries?: number;
+  retry_delay_seconds?: number;
+  timeout_seconds?: number;
+  updated_at?: string;
+  url?: string;
+}
+
+export interface WebhookEndpointsListResponse {
+  endpoints?: WebhookEndpointResponse[];
+}
+
+export interface WebhookOutboxResponse {
+  due?: number;
+  failed?: number;
+  oldest_pending_age_seconds?: number;
+  pending?: number;
+  poller_lag_seconds?: number;
+  processing?: number;
+}
+
+export interface WebhookQueueDepthResponse {
+  backend?: string;
+  error?: string;
+  ready?: number;
+  scheduled?: number;
+}
+
+export interface WebhookQueueResponse {
+  failed?: number;
+  pending?: number;
+  processing?: number;
+}
+
+export interface WebhookRedriveRequest {
+  created_from?: string;
+  created_to?: string;
+  endpoint_id?: number;
+  incident_id?: number;
+  spread_seconds?: number;
+  state?: "failed";
+}
+
+export interface WebhookRedriveResponse {
+  redriven?: number;
+}
+
+export interface WebhookReplayRequest {
+  ids?: number[];
+}
+
+export interface WebhookReplayResponse {
+  requeued?: number;
+}
+
+export interface WebhookSLOListResponse {
+  breached?: number;
+  endpoints?: WebhookSLOResponse[];
+  generated_at?: string;
+}
+
+export interface WebhookSLOResponse {
+  breached?: boolean;
+  delivered?: number;
+  enabled?: boolean;
+  endpoint_id?: number;
+  min_deliveries?: number;
+  p50_ms?: number;
+  p95_ms?: number;
+  p99_ms?: number;
+  percentile?: number;
+  target_ms?: number;
+  url?: string;
+  window_minutes?: number;
+}
+
+export interface WebhookTestRequest {
+  attempts?: number;
+  url: string;
+}
+
+export interface WebhookTestResponse {
+  attempts?: number;
+  delivered?: boolean;
+  error?: string;
+  latency_ms?: number;
+  status_code?: number;
+}
+
+export interface WorkerLockResponse {
+  acquired?: number;
+  errors?: number;
+  held?: boolean;
+  held_since?: string;
+  last_error?: string;
+  lost?: number;
+  name?: string;
+  runs?: number;
+  skipped?: number;
+}
+
+export interface WorkerLocksResponse {
+  enabled?: boolean;
+  instance?: string;
+  locks?: WorkerLockResponse[];
+}
+
+export interface ZoneAhead {
+  bearing_deg?: number;
+  distance_m?: number;
+  /** DistanceToEdgeM — сколько осталось до границы зоны */
+  distance_to_edge_m?: number;
+  eta_seconds?: number;
+  incident_id?: number;
+  latitude?: number;
+  longitude?: number;
+  name?: string;
+  radius_m?: number;
+  visibility?: "public" | "restricted" | "internal";
+}
+
+export interface ZoneMatch {
+  /** BearingDeg — азимут на центр зоны в градусах от севера по часовой стрелке */
+  bearing_deg?: number;
+  descr?: string;
+  /** DistanceM — расстояние от точки до центра зоны */
+  distance_m?: number;
+  /** DistanceToEdgeM — расстояние от точки до границы зоны изнутри */
+  distance_to_edge_m?: number;
+  expires_at?: string;
+  incident_id?: number;
+  latitude?: number;
+  longitude?: number;
+  /** Match — inside или possibly_inside, если круг погрешности пересекает границу зоны */
+  match?: string;
+  name?: string;
+  radius_m?: number;
+  /** Visibility restricted и internal — зона предупреждает, но название и описание не раскрываются (пустые) */
+  visibility?: "public" | "restricted" | "internal";
+}
+
+export interface ClientOptions {
+  /** Адрес сервиса, например http://localhost:8080 */
+  baseUrl: string;
+  /** API-ключ или JWT оператора, передается как Bearer */
+  token?: string;
+  /** Повторы идемпотентных запросов (GET, PUT, DELETE) при сетевой ошибке, 429 и 5xx; по умолчанию 2 */
+  maxRetries?: number;
+  /** Пауза перед первым повтором в миллисекундах, дальше удваивается; по умолчанию 200 */
+  retryDelayMs?: number;
+  fetch?: typeof fetch;
+}
+
+/** Ответ сервиса с кодом не 2xx */
+export class ApiError extends Error {
+  readonly status: number;
+  readonly body: ErrorResponse;
+
+  constructor(status: number, body: ErrorResponse) {
+    super(body.message ? (body.error ?? "") + ": " + body.message : body.error ?? String(status));
+    this.name = "ApiError";
+    this.status = status;
+    this.body = body;
+  }
+}
+
+export interface RequestOptions {
+  query?: Record<string, string | number | boolean | undefined>;
+  body?: unknown;
+  form?: Record<string, Blob | string>;
+  /** коды ответа, кроме 2xx, тело которых возвращается как результат */
+  accept?: number[];
+  signal?: AbortSignal;
+}
+
+const idempotentMethods = new Set(["GET", "HEAD", "PUT", "DELETE"]);
+const maxRetryAfterMs = 30000;
+
+export class BaseClient {
+  protected readonly options: ClientOptions & { maxRetries: number; retryDelayMs: number };
+
+  constructor(options: ClientOptions) {
+    this.options = {
+      maxRetries: 2,
+      retryDelayMs: 200,
+      ...options,
+      baseUrl: options.baseUrl.replace(/\/+$/, ""),
+    };
+  }
+
+  /** Копия клиента с другим токеном, например после login */
+  withToken(token: string): this {
+    const ctor = this.constructor as new (options: ClientOptions) => this;
+    return new ctor({ ...this.options, token });
+  }
+
+  protected async request<T>(method: string, path: string, init: RequestOptions = {}): Promise<T> {
+    const response = await this.send(method, path, init, "application/json");
+    const text = await response.text();
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+
+  protected async stream<T>(path: string, onEvent: (event: T) => void, signal?: AbortSignal): Promise<void> {
+    const response = await this.send("GET", path, { signal }, "text/event-stream");
+    if (!response.body) {
+      throw new Error("response streaming is not supported");
+    }
+
+    const reader = response.body.pipeThrough(new TextDecoderStream()).getReader();
+    let buffer = "";
+    for (;;) {
+      const { value, done } = await reader.read();
+      if (done) {
+        return;
+      }
+      buffer += value.replace(/\r\n/g, "\n");
+
+      let end: number;
+      while ((end = buffer.indexOf("\n\n")) >= 0) {
+        const chunk = buffer.slice(0, end);
+        buffer = buffer.slice(end + 2);
+
+        const data: string[] = [];
+        for (const line of chunk.split("\n")) {
+          if (line.startsWith("data:")) {
+            data.push(line.slice(5).replace(/^ /, ""));
+          }
+        }
+        if (data.length > 0) {
+          onEvent(JSON.parse(data.join("\n")) as T);
+        }
+      }
+    }
+  }
+
+  private async send(method: string, path: string, init: RequestOptions, accept: string): Promise<Response> {
+    const url = new URL(this.options.baseUrl + path);
+    for (const [key, value] of Object.entries(init.query ?? {})) {
+      if (value !== undefined) {
+        url.searchParams.set(key, String(value));
+      }
+    }
+
+    const headers: Record<string, string> = { Accept: accept };
+    if (this.options.token) {
+      headers.Authorization = "Bearer " + this.options.token;
+    }
+
+    let body: BodyInit | undefined;
+    if (init.form) {
+      const form = new FormData();
+      for (const [key, value] of Object.entries(init.form)) {
+        form.append(key, value);
+      }
+      body = form;
+    } else if (init.body !== undefined) {
+      headers["Content-Type"] = "application/json";
+      body = JSON.stringify(init.body);
+    }
+
+    const doFetch = this.options.fetch ?? fetch;
+    const attempts = 1 + (idempotentMethods.has(method) ? this.options.maxRetries : 0);
+    let delay = this.options.retryDelayMs;
+
+    for (let attempt = 1; ; attempt++) {
+      let wait = delay;
+      try {
+        const response = await doFetch(url, { method, headers, body, signal: init.signal });
+        if (response.ok || init.accept?.includes(response.status)) {
+          return response;
+        }
+
+        const error = await toApiError(response);
+        if (attempt >= attempts || !(response.status === 429 || response.status >= 500)) {
+          throw error;
+        }
+        wait = retryAfterMs(response) ?? delay;
+      } catch (err) {
+        if (err instanceof ApiError || init.signal?.aborted || attempt >= attempts) {
+          throw err;
+        }
+      }
+
+      await sleep(wait, init.signal);
+      delay *= 2;
+    }
+  }
+}
+
+async function toApiError(response: Response): Promise<ApiError> {
+  const text = await response.text();
+  let body: ErrorResponse | undefined;
+  try {
+    body = JSON.parse(text) as ErrorResponse;
+  } catch {
+    body = undefined;
+  }
+  if (!body || !body.error) {
+    body = { error: response.statusText, message: text };
+  }
+  return new ApiError(response.status, body);
+}
+
+function retryAfterMs(response: Response): number | undefined {
+  const value = response.headers.get("Retry-After");
+  if (!value) {
+    return undefined;
+  }
+  const seconds = Number(value);
+  const ms = Number.isNaN(seconds) ? Date.parse(value) - Date.now() : seconds * 1000;
+  if (Number.isNaN(ms)) {
+    return undefined;
+  }
+  return Math.min(Math.max(ms, 0), maxRetryAfterMs);
+}
+
+function sleep(ms: number, signal?: AbortSignal): Promise<void> {
+  return new Promise((resolve, reject) => {
+    const timer = setTimeout(resolve, ms);
+    signal?.addEventListener("abort", () => {
+      clearTimeout(timer);
+      reject(signal?.reason);
+    }, { once: true });
+  });
+}
+
+export class GeonotifyClient extends BaseClient {
+  /** Текущие значения перезагружаемых настроек (оператор) */
+  getConfig(): Promise<RuntimeConfigResponse> {
+    return this.request<RuntimeConfigResponse>("GET", "/api/v1/admin/config");
+  }
+
+  /**
+   * Перечитать конфигурацию (оператор)
+   * Перечитывает конфигурацию без рестарта: уровень логирования, TTL кэша, политику ретраев вебхуков
+   */
+  reloadConfig(): Promise<RuntimeConfigResponse> {
+    return this.request<RuntimeConfigResponse>("POST", "/api/v1/admin/config/reload");
+  }
+
+  /**
+   * Сводка для админки (оператор)
+   * Активные зоны, глубина очереди вебхуков, последние проверки и неудачные доставки
+   */
+  getDashboard(query?: { limit?: number; }): Promise<DashboardResponse> {
+    return this.request<DashboardResponse>("GET", "/api/v1/admin/dashboard", { query });
+  }
+
+  /**
+   * Блокировки периодических задач (оператор)
+   * Какими блокировками периодических задач владеет реплика, обработавшая запрос, сколько раз она их
+   * захватывала и теряла и сколько запусков задач выполнила или пропустила. Счетчики — с момента запуска реплики
+   */
+  getWorkerLocks(): Promise<WorkerLocksResponse> {
+    return this.request<WorkerLocksResponse>("GET", "/api/v1/admin/locks");
+  }
+
+  /**
+   * Создать учетную запись оператора (оператор)
+   * Заводит оператора с паролем для входа через /api/v1/auth/login и/или с субъектом OIDC. Роль по умолчанию editor; заводить операторов может только публикатор
+   */
+  createOperator(body: OperatorCreateRequest): Promise<OperatorCreateResponse> {
+    return this.request<OperatorCreateResponse>("POST", "/api/v1/admin/operators", { body });
+  }
+
+  /**
+   * Статистика запросов к БД (оператор)
+   * Число вызовов, ошибки и гистограмма длительности каждого запроса с момента запуска
+   * или последнего сброса, начиная с запросов с наибольшим суммарным временем
+   */
+  getQueryStats(query?: { limit?: number; }): Promise<QueryStatsResponse> {
+    return this.request<QueryStatsResponse>("GET", "/api/v1/admin/query-stats", { query });
+  }
+
+  /** Сбросить статистику запросов к БД (оператор) */
+  resetQueryStats(): Promise<void> {
+    return this.request<void>("DELETE", "/api/v1/admin/query-stats");
+  }
+
+  /**
+   * Заявки на публикацию критических зон (оператор)
+   * Заявки от новых к старым. Без status возвращаются заявки во всех статусах
+   */
+  listApprovals(query?: { status?: "pending" | "approved" | "rejected"; limit?: number; }): Promise<ApprovalsListResponse> {
+    return this.request<ApprovalsListResponse>("GET", "/api/v1/approvals", { query });
+  }
+
+  /**
+   * Одобрить публикацию критической зоны (публикатор)
+   * Публикует зону по заявке. Одобрить может только публикатор, который не подавал заявку
+   */
+  approveIncident(approvalId: number): Promise<ApprovalResponse> {
+    return this.request<ApprovalResponse>("POST", "/api/v1/approvals/" + encodeURIComponent(String(approvalId)) + "/approve");
+  }
+
+  /**
+   * Отклонить публикацию критической зоны (публикатор)
+   * Закрывает заявку, зона остается неопубликованной. Автор заявки может отозвать ее сам
+   */
+  rejectIncident(approvalId: number, body?: ApprovalRejectRequest): Promise<ApprovalResponse> {
+    return this.request<ApprovalResponse>("POST", "/api/v1/approvals/" + encodeURIComponent(String(approvalId)) + "/reject", { body });
+  }
+
+  /**
+   * Вход оператора
+   * Проверяет логин и пароль оператора и выдает короткоживущий JWT. Токен передается в заголовке Authorization: Bearer вместо API-ключа
+   */
+  login(body: LoginRequest): Promise<LoginResponse> {
+    return this.request<LoginResponse>("POST", "/api/v1/auth/login", { body });
+  }
+
+  /**
+   * Заявки на удаление данных пользователей (оператор)
+   * Заявки от новых к старым. Без status возвращаются заявки во всех статусах
+   */
+  listErasures(query?: { status?: "pending" | "confirmed" | "completed" | "cancelled"; limit?: number; }): Promise<ErasuresListResponse> {
+    return this.request<ErasuresListResponse>("GET", "/api/v1/erasures", { query });
+  }
+
+  /**
+   * Заявка на удаление данных пользователя (оператор)
+   * Статус заявки; у выполненной — сколько записей удалено или обезличено
+   */
+  getErasure(erasureId: number): Promise<ErasureResponse> {
+    return this.request<ErasureResponse>("GET", "/api/v1/erasures/" + encodeURIComponent(String(erasureId)));
+  }
+
+  /**
+   * Отменить удаление данных пользователя (публикатор)
+   * Закрывает заявку, ожидающую подтверждения, данные остаются. Автор заявки может отозвать ее сам
+   */
+  cancelErasure(erasureId: number): Promise<ErasureResponse> {
+    return this.request<ErasureResponse>("POST", "/api/v1/erasures/" + encodeURIComponent(String(erasureId)) + "/cancel");
+  }
+
+  /**
+   * Подтвердить удаление данных пользователя (публикатор)
+   * Ставит заявку в очередь на удаление, задача выполняет ее раз в ERASURE_INTERVAL_SECONDS.
+   * Подтвердить может только публикатор, который не подавал заявку
+   */
+  confirmErasure(erasureId: number): Promise<ErasureResponse> {
+    return this.request<ErasureResponse>("POST", "/api/v1/erasures/" + encodeURIComponent(String(erasureId)) + "/confirm");
+  }
+
+  /** Группы пользователей (оператор) */
+  listGroups(): Promise<GroupsListResponse> {
+    return this.request<GroupsListResponse>("GET", "/api/v1/groups");
+  }
+
+  /**
+   * Создать группу пользователей (оператор)
+   * Группа объединяет пользователей, например автопарк или школьный класс. Менять группу могут ее создатель,
+   * операторы из managers и публикаторы
+   */
+  createGroup(body: GroupCreateRequest): Promise<GroupResponse> {
+    return this.request<GroupResponse>("POST", "/api/v1/groups", { body });
+  }
+
+  /** Группа пользователей (оператор) */
+  getGroup(groupId: number): Promise<GroupResponse> {
+    return this.request<GroupResponse>("GET", "/api/v1/groups/" + encodeURIComponent(String(groupId)));
+  }
+
+  /**
+   * Изменить группу пользователей (менеджер группы)
+   * Меняет только переданные поля; managers заменяет список менеджеров целиком
+   */
+  updateGroup(groupId: number, body: GroupPatchRequest): Promise<GroupResponse> {
+    return this.request<GroupResponse>("PATCH", "/api/v1/groups/" + encodeURIComponent(String(groupId)), { body });
+  }
+
+  /**
+   * Удалить группу пользователей (менеджер группы)
+   * Удаляет группу вместе с участниками и получателем вебхуков группы
+   */
+  deleteGroup(groupId: number): Promise<void> {
+    return this.request<void>("DELETE", "/api/v1/groups/" + encodeURIComponent(String(groupId)));
+  }
+
+  /** Участники группы (оператор) */
+  listGroupMembers(groupId: number): Promise<GroupMembersResponse> {
+    return this.request<GroupMembersResponse>("GET", "/api/v1/groups/" + encodeURIComponent(String(groupId)) + "/members");
+  }
+
+  /**
+   * Добавить участников группы (менеджер группы)
+   * Пользователи, которые уже состоят в группе, пропускаются; added — число добавленных
+   */
+  addGroupMembers(groupId: number, body: GroupMembersRequest): Promise<GroupMembersAddedResponse> {
+    return this.request<GroupMembersAddedResponse>("POST", "/api/v1/groups/" + encodeURIComponent(String(groupId)) + "/members", { body });
+  }
+
+  /** Исключить участника группы (менеджер группы) */
+  removeGroupMember(groupId: number, userId: string): Promise<void> {
+    return this.request<void>("DELETE", "/api/v1/groups/" + encodeURIComponent(String(groupId)) + "/members/" + encodeURIComponent(String(userId)));
+  }
+
+  /**
+   * Участники группы в зонах (оператор)
+   * Участники, которые по последней проверке координат находятся в действующих зонах, и зоны каждого из них
+   */
+  getGroupStatus(groupId: number): Promise<GroupStatusResponse> {
+    return this.request<GroupStatusResponse>("GET", "/api/v1/groups/" + encodeURIComponent(String(groupId)) + "/status");
+  }
+
+  /**
+   * Задать получателя вебхуков группы (менеджер группы)
+   * Получатель группы получает только событие group.alert: один сводный вебхук на каждую проверку, в которой
+   * участник группы вошел в зоны. Повторный вызов заменяет URL и секрет и включает получателя
+   */
+  putGroupWebhook(groupId: number, body: GroupWebhookRequest): Promise<GroupResponse> {
+    return this.request<GroupResponse>("PUT", "/api/v1/groups/" + encodeURIComponent(String(groupId)) + "/webhook", { body });
+  }
+
+  /**
+   * Удалить получателя вебхуков группы (менеджер группы)
+   * Удаляет получателя вместе с его вебхуками, включая недоставленные
+   */
+  deleteGroupWebhook(groupId: number): Promise<void> {
+    return this.request<void>("DELETE", "/api/v1/groups/" + encodeURIComponent(String(groupId)) + "/webhook");
+  }
+
+  /**
+   * Получить список инцидентов с пагинацией (оператор)
+   * Получить все инциденты с поддержкой пагинации. С параметром cursor (пустой — первая страница)
+   * список листается по курсору из next_cursor без подсчета общего числа страниц — так дальние
+   * страницы не замедляются на больших таблицах. page вместе с cursor не передается
+   */
+  listIncidents(query?: { page?: number; cursor?: string; count?: "exact" | "estimated"; limit?: number; status?: "draft" | "published" | "archived"; }): Promise<IncidentsListResponse> {
+    return this.request<IncidentsListResponse>("GET", "/api/v1/incidents", { query });
+  }
+
+  /**
+   * Создать инцидент (оператор)
+   * Создать новую опасную зону (требуется API key). Вместо координат можно передать address — он будет геокодирован.
+   * Редактор создает только черновики; без status зона публикуется сразу, если это разрешено роли и ревью не обязательно
+   */
+  createIncident(body: IncidentCreateRequest, query?: { check_overlap?: boolean; }): Promise<IncidentCreateResponse> {
+    return this.request<IncidentCreateResponse>("POST", "/api/v1/incidents", { query, body });
+  }
+
+  /**
+   * Пакетное изменение инцидентов (оператор)
+   * Включить, выключить или удалить несколько зон одной транзакцией. Если хотя бы одна зона не найдена или ждет решения по заявке, ничего не меняется. Доступно только публикатору.
+   * Включение переводит запланированные и завершенные зоны в active, выключение — действующие в resolved
+   */
+  batchIncidents(body: IncidentBatchRequest): Promise<IncidentBatchResponse> {
+    return this.request<IncidentBatchResponse>("PATCH", "/api/v1/incidents/batch", { body });
+  }
+
+  /**
+   * Объединить зоны (публикатор)
+   * Создать опубликованную зону из нескольких опубликованных: форма — MultiPolygon из их полигонов
+   * (круглые зоны приближаются многоугольниками), уровень опасности — наибольший, срок действия — наибольший.
+   * Исходные зоны переводятся в архив, связь с новой зоной видна в истории /lineage
+   */
+  mergeIncidents(body: IncidentMergeRequest): Promise<IncidentResponse> {
+    return this.request<IncidentResponse>("POST", "/api/v1/incidents/merge", { body });
+  }
+
+  /**
+   * Статистика по зонам
+   * Получить статистику уникальных пользователей за последние N минут.
+   * Точный подсчет берется из поминутной сводки, если она отстает не больше чем на STATS_FRESHNESS_SECONDS:
+   * тогда окно заканчивается на последней сведенной минуте и period_start сдвинут на ее задержку.
+   * С count=estimated числа — оценки планировщика Postgres (estimated: true), а не точный подсчет
+   */
+  getStats(query?: { count?: "exact" | "estimated"; }): Promise<StatsResponse> {
+    return this.request<StatsResponse>("GET", "/api/v1/incidents/stats", { query });
+  }
+
+  /**
+   * Получить инцидент по ID (оператор)
+   * Детали конкретной зоны опасности
+   */
+  getIncident(incidentId: number): Promise<IncidentResponse> {
+    return this.request<IncidentResponse>("GET", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)));
+  }
+
+  /**
+   * Обновить инцидент (оператор)
+   * Полное обновление данных существующей опасной зоны (PUT).
+   * Повышение опубликованной зоны до critical не применяется сразу: остальные поля меняются, а на критичность создается заявка (202)
+   */
+  updateIncident(incidentId: number, body: IncidentUpdateRequest): Promise<MessageResponse> {
+    return this.request<MessageResponse>("PUT", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)), { body });
+  }
+
+  /**
+   * Частично обновить инцидент (оператор)
+   * Изменить только переданные поля опасной зоны (PATCH).
+   * Повышение опубликованной зоны до critical не применяется сразу: остальные поля меняются, а на критичность создается заявка (202).
+   * "expires_at": null снимает срок действия; без поля expires_at срок не меняется
+   */
+  patchIncident(incidentId: number, body: IncidentPatchRequest): Promise<MessageResponse> {
+    return this.request<MessageResponse>("PATCH", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)), { body });
+  }
+
+  /**
+   * Удалить инцидент (оператор)
+   * Мягкое удаление опасной зоны
+   */
+  deleteIncident(incidentId: number): Promise<MessageResponse> {
+    return this.request<MessageResponse>("DELETE", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)));
+  }
+
+  /**
+   * Архивировать инцидент (публикатор)
+   * Снять зону с публикации или отложить черновик. Архивная зона не участвует в проверках, но ее можно опубликовать снова
+   */
+  archiveIncident(incidentId: number): Promise<IncidentResponse> {
+    return this.request<IncidentResponse>("POST", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/archive");
+  }
+
+  /**
+   * Список вложений инцидента (оператор)
+   * Метаданные вложений и временные ссылки на скачивание
+   */
+  listAttachments(incidentId: number): Promise<AttachmentsListResponse> {
+    return this.request<AttachmentsListResponse>("GET", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/attachments");
+  }
+
+  /**
+   * Прикрепить файл к инциденту (оператор)
+   * Загружает изображение или PDF (карта обстановки, официальное уведомление) в объектное хранилище
+   */
+  uploadAttachment(incidentId: number, form: {
+    /** Изображение (png, jpeg, gif, webp) или PDF */
+    file: Blob;
+  }): Promise<AttachmentResponse> {
+    return this.request<AttachmentResponse>("POST", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/attachments", { form });
+  }
+
+  /**
+   * Удалить вложение (оператор)
+   * Удаляет метаданные вложения и сам файл из хранилища
+   */
+  deleteAttachment(incidentId: number, attachmentId: number): Promise<void> {
+    return this.request<void>("DELETE", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/attachments/" + encodeURIComponent(String(attachmentId)));
+  }
+
+  /**
+   * Прогнать недавние проверки через зону (оператор)
+   * Считает, сколько пользователей получили бы алерт, если бы зона действовала последние hours часов:
+   * проверки координат за период прогоняются через ее форму. Подходит для черновиков и измененных зон,
+   * radius_m подменяет радиус круглой зоны, чтобы подобрать размер до сохранения. Стадия, расписание
+   * и срок действия зоны не учитываются. users_newly_alerted — пользователи, которые в зоне не получили
+   * алертов от действовавших тогда зон. За один запрос учитывается не больше 100 000 проверок (truncated)
+   */
+  backtestIncident(incidentId: number, body: IncidentBacktestRequest): Promise<IncidentBacktestResponse> {
+    return this.request<IncidentBacktestResponse>("POST", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/backtest", { body });
+  }
+
+  /**
+   * Комментарии зоны (оператор)
+   * Заметки операторов о ходе ситуации в зоне, новые первыми
+   */
+  listIncidentComments(incidentId: number, query?: { limit?: number; }): Promise<IncidentCommentsResponse> {
+    return this.request<IncidentCommentsResponse>("GET", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/comments", { query });
+  }
+
+  /**
+   * Добавить комментарий к зоне (оператор)
+   * Заметка о ходе ситуации, до 2000 символов; автор — оператор запроса. Комментировать можно и опубликованную зону.
+   * С WEBHOOK_INCLUDE_COMMENTS=true комментарий к опубликованной зоне отправляет incident.updated
+   */
+  createIncidentComment(incidentId: number, body: IncidentCommentRequest): Promise<IncidentCommentResponse> {
+    return this.request<IncidentCommentResponse>("POST", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/comments", { body });
+  }
+
+  /**
+   * Задать форму зоны в GeoJSON (оператор)
+   * Заменить форму опасной зоны: Point с radius_m (в properties для Feature), Polygon или MultiPolygon.
+   * Внешние кольца — против часовой стрелки, дыры — по часовой, самопересечения не допускаются
+   */
+  setIncidentGeometry(incidentId: number, body: Record<string, unknown>): Promise<MessageResponse> {
+    return this.request<MessageResponse>("PUT", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/geometry", { body });
+  }
+
+  /**
+   * История слияний и разделений зоны (оператор)
+   * Связи зоны с исходными и полученными из нее зонами: кто и когда объединил или разделил зоны
+   */
+  getIncidentLineage(incidentId: number): Promise<IncidentLineageResponse> {
+    return this.request<IncidentLineageResponse>("GET", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/lineage");
+  }
+
+  /**
+   * Опубликовать инцидент (публикатор)
+   * Перевести черновик или архивную зону в статус published: с этого момента она участвует в проверках.
+   * При обязательном ревью публикатор не может быть автором или последним редактором зоны.
+   * Критическая зона не публикуется сразу: создается заявка (202), зону выпускает одобривший ее второй оператор
+   */
+  publishIncident(incidentId: number): Promise<IncidentResponse> {
+    return this.request<IncidentResponse>("POST", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/publish");
+  }
+
+  /**
+   * Связи зоны (оператор)
+   * Связи с другими зонами со стороны этой зоны: parent — она входит в related_id, child — related_id входит в нее,
+   * supersedes — она заменяет related_id, superseded_by — related_id заменяет ее. Связи с удаленными зонами не возвращаются
+   */
+  listIncidentRelations(incidentId: number): Promise<IncidentRelationsResponse> {
+    return this.request<IncidentRelationsResponse>("GET", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/relations");
+  }
+
+  /**
+   * Связать зону с другой (оператор)
+   * parent — зона входит в related_id (у зоны одна родительская), supersedes — зона заменяет related_id,
+   * например зона эвакуации заменяет прежнюю зону предупреждения. Редактор связывает только черновики.
+   * Опубликованные зоны получают incident.updated, где supersedes и superseded_by перечисляют связанные зоны
+   */
+  createIncidentRelation(incidentId: number, body: IncidentRelationRequest): Promise<IncidentRelationResponse> {
+    return this.request<IncidentRelationResponse>("POST", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/relations", { body });
+  }
+
+  /**
+   * Удалить связь зоны (оператор)
+   * Удаляет связь, заданную со стороны зоны: type — parent или supersedes
+   */
+  deleteIncidentRelation(incidentId: number, type: "parent" | "supersedes", relatedId: number): Promise<void> {
+    return this.request<void>("DELETE", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/relations/" + encodeURIComponent(String(type)) + "/" + encodeURIComponent(String(relatedId)));
+  }
+
+  /**
+   * Разделить зону (публикатор)
+   * Заменить опубликованную зону несколькими: форма каждой части задается GeoJSON, как в PUT /geometry,
+   * описание, уровень опасности, расписание и срок действия наследуются. Исходная зона переводится в архив
+   */
+  splitIncident(incidentId: number, body: IncidentSplitRequest): Promise<IncidentSplitResponse> {
+    return this.request<IncidentSplitResponse>("POST", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/split", { body });
+  }
+
+  /**
+   * Перевести зону в другую стадию (оператор)
+   * Сменить стадию жизни опасности: planned → active, resolved; active → contained, resolved;
+   * contained → active, resolved; resolved → active, archived. В проверках участвуют зоны в active и contained.
+   * Черновики переводит и редактор, остальные зоны — публикатор. Получатели вебхуков видят incident.state_changed
+   */
+  transitionIncident(incidentId: number, body: IncidentStateRequest): Promise<IncidentResponse> {
+    return this.request<IncidentResponse>("POST", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/state", { body });
+  }
+
+  /**
+   * Переводы зоны (оператор)
+   * Названия и описания зоны на других языках. Клиенты получают их в проверках координат и списках
+   * инцидентов по заголовку Accept-Language
+   */
+  listIncidentTranslations(incidentId: number): Promise<IncidentTranslationsResponse> {
+    return this.request<IncidentTranslationsResponse>("GET", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/translations");
+  }
+
+  /**
+   * Задать перевод зоны (оператор)
+   * Создать или заменить название и описание зоны на языке locale (тег BCP 47: en, pt-BR).
+   * Пустое описание перевода — клиент получит исходное. Права те же, что на изменение зоны
+   */
+  putIncidentTranslation(incidentId: number, locale: string, body: IncidentTranslationRequest): Promise<IncidentTranslationResponse> {
+    return this.request<IncidentTranslationResponse>("PUT", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/translations/" + encodeURIComponent(String(locale)), { body });
+  }
+
+  /**
+   * Удалить перевод зоны (оператор)
+   * Клиенты, предпочитающие этот язык, снова получат исходное название и описание
+   */
+  deleteIncidentTranslation(incidentId: number, locale: string): Promise<void> {
+    return this.request<void>("DELETE", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/translations/" + encodeURIComponent(String(locale)));
+  }
+
+  /**
+   * История формы зоны (оператор)
+   * Версии центра, радиуса и полигонов зоны от первой к последней: новая версия записывается при каждом изменении формы.
+   * Зоны, созданные до появления истории, имеют одну версию с формой на момент обновления и датой создания зоны
+   */
+  listIncidentVersions(incidentId: number): Promise<IncidentVersionsResponse> {
+    return this.request<IncidentVersionsResponse>("GET", "/api/v1/incidents/" + encodeURIComponent(String(incidentId)) + "/versions");
+  }
+
+  /**
+   * Проверить координаты
+   * Проверить, попадает ли точка в опасную зону (публичный эндпоинт). Устарел, используйте /api/v2/location/check
+   */
+  checkLocation(body: LocationCheckRequest, query?: { resolve_address?: boolean; dry_run?: boolean; at?: string; }): Promise<LocationCheckResponse> {
+    return this.request<LocationCheckResponse>("POST", "/api/v1/location/check", { query, body });
+  }
+
+  /**
+   * Симулировать проверку координат
+   * То же, что /api/v2/location/check?dry_run=true: точка сопоставляется с действующими зонами, но проверка
+   * не сохраняется и не попадает в статистику, вебхуки, события и алерты пользователю не отправляются.
+   * Для тестирования интеграций (публичный эндпоинт)
+   */
+  simulateLocation(body: LocationCheckRequest, query?: { resolve_address?: boolean; at?: string; }): Promise<V2LocationCheckResponse> {
+    return this.request<V2LocationCheckResponse>("POST", "/api/v1/location/simulate", { query, body });
+  }
+
+  /**
+   * Действующие зоны (публичный доступ)
+   * Действующие опубликованные зоны без авторизации: только название, геометрия, стадия и уровень опасности,
+   * без описаний, адресов и авторов. Ответ кэшируется на PUBLIC_API_CACHE_SECONDS, запросы с одного адреса
+   * ограничены PUBLIC_API_RATE_LIMIT_PER_MINUTE в минуту
+   */
+  listPublicIncidents(): Promise<PublicIncidentsListResponse> {
+    return this.request<PublicIncidentsListResponse>("GET", "/api/v1/public/incidents");
+  }
+
+  /**
+   * Тепловая карта проверок (оператор)
+   * Число проверок координат и проверок с алертом по ячейкам H3 за период, начиная с самых частых.
+   * Счетчики почасовые и сводятся фоновым воркером, поэтому период учитывается с точностью до часа,
+   * а последние проверки появляются с задержкой около минуты. Разрешение — не мельче HEATMAP_RESOLUTION сервера
+   */
+  getCheckHeatmap(query?: { resolution?: number; from?: string; to?: string; }): Promise<HeatmapResponse> {
+    return this.request<HeatmapResponse>("GET", "/api/v1/stats/heatmap", { query });
+  }
+
+  /**
+   * Проверки по зонам (оператор)
+   * Уникальные пользователи, проверки, проверки с алертом и число проверок в каждой зоне за window_minutes минут
+   * до period_end — последней минуты, до которой фоновый воркер свел проверки. Редактор не видит внутренние зоны.
+   * Проверки, сохраненные до появления сводки по зонам, в счетчики зон не попадают
+   */
+  getIncidentStats(query?: { window_minutes?: number; }): Promise<IncidentStatsResponse> {
+    return this.request<IncidentStatsResponse>("GET", "/api/v1/stats/incidents", { query });
+  }
+
+  /**
+   * Глубина и отставание очереди вебхуков (оператор)
+   * Число задач в очереди доставки, недоставленные вебхуки в outbox по состояниям, возраст самого старого
+   * из них и отставание опроса outbox. Если очередь недоступна, ее ошибка возвращается в queue.error, а outbox считается
+   */
+  getQueues(): Promise<QueuesResponse> {
+    return this.request<QueuesResponse>("GET", "/api/v1/system/queues");
+  }
+
+  /**
+   * Запросы API-ключей и квоты (оператор, клиент)
+   * Число запросов, проверок координат, алертов и вебхуков каждого API-ключа по суткам (UTC) с from по to, квоты ключей и их счетчики за текущие сутки
+   * и месяц. По суткам данные переносятся из Redis периодически, поэтому текущие сутки в days отстают; current точен.
+   * Ключ из API_KEYS видит только себя
+   */
+  getUsage(query?: { from?: string; to?: string; }): Promise<UsageResponse> {
+    return this.request<UsageResponse>("GET", "/api/v1/system/usage", { query });
+  }
+
+  /**
+   * Выгрузка использования API-ключей для биллинга (оператор, клиент)
+   * Строка на ключ и сутки (UTC) с from по to: запросы, записанные проверки координат, проверки с алертом
+   * и вебхуки, поставленные в очередь доставки. Сутки без использования не выгружаются. По умолчанию —
+   * вчерашние сутки, уже полностью перенесенные из Redis. Ключ из API_KEYS выгружает только себя
+   */
+  exportUsage(query?: { from?: string; to?: string; format?: "csv" | "json"; }): Promise<UsageExportResponse> {
+    return this.request<UsageExportResponse>("GET", "/api/v1/system/usage/export", { query });
+  }
+
+  /**
+   * Задержка доставки вебхуков по получателям (оператор)
+   * Для каждого общего получателя — перцентили p50/p95/p99 задержки от создания проверки до успешной доставки
+   * за последние WEBHOOK_SLO_WINDOW_MINUTES и нарушение цели WEBHOOK_SLO_LATENCY_MS; breached — число нарушителей.
+   * Получатели без доставок за окно возвращаются с delivered 0
+   */
+  getWebhookSLO(): Promise<WebhookSLOListResponse> {
+    return this.request<WebhookSLOListResponse>("GET", "/api/v1/system/webhook-slo");
+  }
+
+  /**
+   * Поток алертов пользователя
+   * Server-Sent Events: событие alert приходит, когда проверка пользователя попала в зону или рядом с его последней точкой создана новая зона
+   */
+  streamAlerts(userId: string, onEvent: (event: AlertEvent) => void, signal?: AbortSignal): Promise<void> {
+    return this.stream("/api/v1/users/" + encodeURIComponent(String(userId)) + "/alerts/stream", onEvent, signal);
+  }
+
+  /**
+   * Удалить данные пользователя (оператор)
+   * Заводит заявку на удаление данных пользователя по запросу на забвение. Данные удаляются фоновой задачей
+   * после подтверждения другим публикатором: проверки координат (mode=anonymize оставляет их для статистики
+   * без user_id и с координатами, округленными до 0.01°), вебхуки по ним, зоны присутствия, настройки алертов,
+   * участие в группах, последняя точка в Redis и документы в OpenSearch. Заявка остается как запись аудита
+   */
+  requestUserErasure(userId: string, query?: { mode?: "delete" | "anonymize"; }): Promise<ErasureResponse> {
+    return this.request<ErasureResponse>("DELETE", "/api/v1/users/" + encodeURIComponent(String(userId)) + "/data", { query });
+  }
+
+  /**
+   * Выгрузка данных пользователя (оператор)
+   * Все данные о пользователе одним JSON-архивом по запросу субъекта данных: проверки координат с временем,
+   * полученные алерты, настройки алертов, текущие зоны и группы. Первый запрос заводит выгрузку и отвечает 202,
+   * архив собирается в фоне; повторные запросы возвращают ее статус, а готовая выгрузка (200) — временную ссылку
+   * download_url. Архив хранится DATA_EXPORT_RETENTION_HOURS часов, после этого запрос заводит новую выгрузку
+   */
+  getUserDataExport(userId: string): Promise<DataExportResponse> {
+    return this.request<DataExportResponse>("GET", "/api/v1/users/" + encodeURIComponent(String(userId)) + "/data-export");
+  }
+
+  /**
+   * Настройки алертов пользователя
+   * Порог опасности, окна тишины и каналы доставки алертов. Пользователь без сохраненных настроек
+   * получает значения по умолчанию: все алерты в SSE-поток
+   */
+  getUserPreferences(userId: string): Promise<UserPreferencesResponse> {
+    return this.request<UserPreferencesResponse>("GET", "/api/v1/users/" + encodeURIComponent(String(userId)) + "/preferences");
+  }
+
+  /**
+   * Задать настройки алертов пользователя
+   * Заменяет настройки целиком. Алерты о зонах с уровнем опасности ниже min_severity не отправляются, в окна тишины
+   * quiet_hours (по времени timezone) отправляются только алерты о критических зонах. Сверх max_alerts_per_hour
+   * алертов за час отправляются тоже только критические. Остальные уходят в каналы channels: stream — SSE-поток
+   * /api/v1/users/{user_id}/alerts/stream, webhook — событие вебхука user.alert
+   */
+  putUserPreferences(userId: string, body: UserPreferencesRequest): Promise<UserPreferencesResponse> {
+    return this.request<UserPreferencesResponse>("PUT", "/api/v1/users/" + encodeURIComponent(String(userId)) + "/preferences", { body });
+  }
+
+  /**
+   * Получатели вебхуков (оператор)
+   * Все зарегистрированные получатели. Секреты не возвращаются
+   */
+  listWebhookEndpoints(): Promise<WebhookEndpointsListResponse> {
+    return this.request<WebhookEndpointsListResponse>("GET", "/api/v1/webhook-endpoints");
+  }
+
+  /**
+   * Зарегистрировать получателя вебхуков (публикатор)
+   * Получатель начинает получать события из events ("*" — все события) сразу после регистрации.
+   * Вебхуки подписываются секретом, если он задан. Не заданные max_retries, retry_delay_seconds,
+   * backoff и timeout_seconds берутся из настроек сервиса. С batch_size события копятся до batch_size штук
+   * или batch_wait_ms и уходят одним NDJSON-запросом (X-Geonotify-Event: batch), с compression=gzip тело
+   * сжимается (Content-Encoding: gzip); подпись считается по несжатому телу
+   */
+  createWebhookEndpoint(body: WebhookEndpointCreateRequest): Promise<WebhookEndpointResponse> {
+    return this.request<WebhookEndpointResponse>("POST", "/api/v1/webhook-endpoints", { body });
+  }
+
+  /** Получатель вебхуков (оператор) */
+  getWebhookEndpoint(endpointId: number): Promise<WebhookEndpointResponse> {
+    return this.request<WebhookEndpointResponse>("GET", "/api/v1/webhook-endpoints/" + encodeURIComponent(String(endpointId)));
+  }
+
+  /**
+   * Изменить получателя вебхуков (публикатор)
+   * Меняет только переданные поля. Выключенному получателю новые события не создаются,
+   * а уже созданные вебхуки помечаются недоставленными. Новая политика попыток применяется со следующей попытки
+   */
+  updateWebhookEndpoint(endpointId: number, body: WebhookEndpointPatchRequest): Promise<WebhookEndpointResponse> {
+    return this.request<WebhookEndpointResponse>("PATCH", "/api/v1/webhook-endpoints/" + encodeURIComponent(String(endpointId)), { body });
+  }
+
+  /**
+   * Удалить получателя вебхуков (публикатор)
+   * Удаляет получателя вместе с его вебхуками, включая недоставленные
+   */
+  deleteWebhookEndpoint(endpointId: number): Promise<void> {
+    return this.request<void>("DELETE", "/api/v1/webhook-endpoints/" + encodeURIComponent(String(endpointId)));
+  }
+
+  /**
+   * Задержка доставки получателю и цель SLO (оператор)
+   * Перцентили p50/p95/p99 задержки от создания проверки до успешной доставки вебхука за последние WEBHOOK_SLO_WINDOW_MINUTES
+   * и сравнение перцентиля WEBHOOK_SLO_PERCENTILE с целью WEBHOOK_SLO_LATENCY_MS. Недоставленные вебхуки в задержку не входят
+   */
+  getWebhookEndpointSLO(endpointId: number): Promise<WebhookSLOResponse> {
+    return this.request<WebhookSLOResponse>("GET", "/api/v1/webhook-endpoints/" + encodeURIComponent(String(endpointId)) + "/slo");
+  }
+
+  /**
+   * Повторить недоставленные вебхуки по фильтру (оператор)
+   * Возвращает в доставку недоставленные вебхуки, созданные в [created_from, created_to), по зоне и получателю,
+   * со сброшенным счетчиком и сразу ставит их в очередь. spread_seconds растягивает доставку на окно,
+   * чтобы не перегрузить получателя после простоя. Без фильтров повторяются все недоставленные вебхуки
+   */
+  redriveWebhooks(body?: WebhookRedriveRequest): Promise<WebhookRedriveResponse> {
+    return this.request<WebhookRedriveResponse>("POST", "/api/v1/webhooks/redrive", { body });
+  }
+
+  /**
+   * Повторить неотправленные вебхуки (оператор)
+   * Возвращает в очередь доставки вебхуки, исчерпавшие попытки (state=failed), со сброшенным счетчиком.
+   * Без ids повторяются все такие вебхуки
+   */
+  replayWebhooks(body?: WebhookReplayRequest): Promise<WebhookReplayResponse> {
+    return this.request<WebhookReplayResponse>("POST", "/api/v1/webhooks/replay", { body });
+  }
+
+  /**
+   * Отправить тестовый вебхук (оператор)
+   * Отправляет пример payload на указанный URL тем же механизмом подписи и ретраев, что и реальные вебхуки, и возвращает статус и задержку получателя
+   */
+  testWebhook(body: WebhookTestRequest): Promise<WebhookTestResponse> {
+    return this.request<WebhookTestResponse>("POST", "/api/v1/webhooks/test", { body });
+  }
+
+  /**
+   * Проверить координаты (v2)
+   * Проверить, попадает ли точка в опасную зону, и получить расстояния и азимуты до найденных зон и до ближайшей зоны впереди (публичный эндпоинт)
+   */
+  checkLocationV2(body: LocationCheckRequest, query?: { resolve_address?: boolean; dry_run?: boolean; at?: string; }): Promise<V2LocationCheckResponse> {
+    return this.request<V2LocationCheckResponse>("POST", "/api/v2/location/check", { query, body });
+  }
+
+  /**
+   * Проверить пачку координат
+   * Проверяет до 1000 точек за запрос; проверки сохраняются одной записью в БД, поэтому
+   * эндпоинт предназначен для трекеров и шлюзов с большим потоком координат. Отклоненная точка
+   * (например, вне области работы) возвращается с error и не мешает остальным. Адрес не определяется
+   */
+  checkLocationBatch(body: LocationCheckBatchRequest): Promise<LocationCheckBatchResponse> {
+    return this.request<LocationCheckBatchResponse>("POST", "/api/v2/location/check/batch", { body });
+  }
+
+  /**
+   * Liveness probe
+   * Проверка, что процесс запущен и отвечает на запросы
+   */
+  liveness(): Promise<LivenessResponse> {
+    return this.request<LivenessResponse>("GET", "/healthz");
+  }
+
+  /**
+   * Readiness probe
+   * Проверка готовности принимать трафик: БД, Redis, миграции, воркер вебхуков (кроме -mode api). Недоступный Redis не делает сервис неготовым: status=degraded, degraded=true
+   */
+  readiness(): Promise<ReadinessResponse> {
+    return this.request<ReadinessResponse>("GET", "/readyz", { accept: [503] });
+  }
+}
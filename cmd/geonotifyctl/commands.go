@@ -0,0 +1,1618 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/4otis/geonotify-service/pkg/client"
+)
+
+func (a *cli) incidents(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("incidents: missing subcommand")
+	}
+
+	sub, args := args[0], args[1:]
+	switch sub {
+	case "list":
+		return a.incidentList(ctx, args)
+	case "get":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("incidents get: expected one ID")
+		}
+		inc, err := a.client.GetIncident(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return a.printIncidents([]client.Incident{*inc})
+	case "create":
+		return a.incidentCreate(ctx, args)
+	case "load":
+		return a.incidentLoad(ctx, args)
+	case "publish", "archive":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("incidents %s: expected one ID", sub)
+		}
+		if sub == "archive" {
+			inc, err := a.client.ArchiveIncident(ctx, ids[0])
+			if err != nil {
+				return err
+			}
+			return a.printIncidents([]client.Incident{*inc})
+		}
+
+		inc, approval, err := a.client.PublishIncident(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		if approval != nil {
+			return a.printApprovals([]client.Approval{*approval})
+		}
+		return a.printIncidents([]client.Incident{*inc})
+	case "activate", "deactivate", "delete":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return usageError("incidents %s: expected at least one ID", sub)
+		}
+		affected, err := a.client.BatchIncidents(ctx, ids, client.BatchAction(sub))
+		if err != nil {
+			return err
+		}
+		return a.print(map[string]int{"affected": affected}, func(w io.Writer) {
+			fmt.Fprintf(w, "%s: %d of %d incidents\n", sub, affected, len(ids))
+		})
+	case "state":
+		if len(args) != 2 {
+			return usageError("incidents state: expected an ID and a state")
+		}
+		ids, err := parseIDs(args[:1])
+		if err != nil {
+			return err
+		}
+		inc, err := a.client.TransitionIncident(ctx, ids[0], client.IncidentState(args[1]))
+		if err != nil {
+			return err
+		}
+		return a.printIncidents([]client.Incident{*inc})
+	case "merge":
+		return a.incidentMerge(ctx, args)
+	case "split":
+		return a.incidentSplit(ctx, args)
+	case "lineage":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("incidents lineage: expected one ID")
+		}
+		links, err := a.client.IncidentLineage(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return a.printLineage(links)
+	case "versions":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("incidents versions: expected one ID")
+		}
+		versions, err := a.client.IncidentVersions(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return a.printVersions(versions)
+	case "relations":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("incidents relations: expected one ID")
+		}
+		relations, err := a.client.ListIncidentRelations(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return a.printRelations(relations)
+	case "relate", "unrelate":
+		return a.incidentRelate(ctx, sub, args)
+	case "backtest":
+		return a.incidentBacktest(ctx, args)
+	case "translations":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("incidents translations: expected one ID")
+		}
+		translations, err := a.client.ListIncidentTranslations(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return a.printTranslations(translations)
+	case "translate":
+		return a.incidentTranslate(ctx, args)
+	case "untranslate":
+		if len(args) != 2 {
+			return usageError("incidents untranslate: expected an ID and a locale")
+		}
+		ids, err := parseIDs(args[:1])
+		if err != nil {
+			return err
+		}
+		if err := a.client.DeleteIncidentTranslation(ctx, ids[0], args[1]); err != nil {
+			return err
+		}
+		return a.print(map[string]string{"deleted": args[1]}, func(w io.Writer) {
+			fmt.Fprintf(w, "translation %s of incident %d deleted\n", args[1], ids[0])
+		})
+	case "comments":
+		return a.incidentComments(ctx, args)
+	case "comment":
+		if len(args) != 2 {
+			return usageError("incidents comment: expected an ID and a text")
+		}
+		ids, err := parseIDs(args[:1])
+		if err != nil {
+			return err
+		}
+		comment, err := a.client.AddIncidentComment(ctx, ids[0], args[1])
+		if err != nil {
+			return err
+		}
+		return a.printComments([]client.IncidentComment{*comment})
+	default:
+		return usageError("incidents: unknown subcommand %q", sub)
+	}
+}
+
+func (a *cli) incidentList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("incidents list", flag.ExitOnError)
+	page := fs.Int("page", 1, "page number")
+	limit := fs.Int("limit", 0, "page size, 0 for the server default")
+	all := fs.Bool("all", false, "fetch every page")
+	status := fs.String("status", "", "only incidents in this status: draft, published or archived")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var incidents []client.Incident
+	// все страницы листаются по курсору, чтобы сервер не пересчитывал инциденты на каждой
+	if *all && *page == 1 {
+		cursor := ""
+		for {
+			list, err := a.client.ListIncidentsAfter(ctx, cursor, *limit, client.IncidentStatus(*status))
+			if err != nil {
+				return err
+			}
+			incidents = append(incidents, list.Incidents...)
+			if list.NextCursor == "" {
+				break
+			}
+			cursor = list.NextCursor
+		}
+		return a.printIncidents(incidents)
+	}
+
+	for p := *page; ; p++ {
+		list, err := a.client.ListIncidentsByStatus(ctx, p, *limit, client.IncidentStatus(*status))
+		if err != nil {
+			return err
+		}
+		incidents = append(incidents, list.Incidents...)
+		if !*all || p >= list.TotalPages {
+			break
+		}
+	}
+	return a.printIncidents(incidents)
+}
+
+func (a *cli) incidentCreate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("incidents create", flag.ExitOnError)
+	var in client.IncidentCreateRequest
+	fs.StringVar(&in.Name, "name", "", "incident name")
+	fs.StringVar(&in.Descr, "descr", "", "description")
+	fs.Float64Var(&in.Latitude, "lat", 0, "center latitude")
+	fs.Float64Var(&in.Longitude, "lng", 0, "center longitude")
+	fs.Float64Var(&in.Radius, "radius", 0, "radius in meters")
+	fs.StringVar(&in.Address, "address", "", "address to geocode when coordinates are not set")
+	fs.IntVar(&in.TTLMinutes, "ttl", 0, "deactivate after this many minutes")
+	fs.StringVar(&in.Schedule, "schedule", "", "cron expression of recurring activation")
+	fs.IntVar(&in.ScheduleDurationMin, "schedule-duration", 0, "minutes the zone stays active per activation")
+	status := fs.String("status", "", "draft or published, empty to let the server decide")
+	severity := fs.String("severity", "", "low, medium, high or critical, empty for medium")
+	state := fs.String("state", "", "planned, active or contained, empty for active")
+	visibility := fs.String("visibility", "", "public, restricted or internal, empty for public")
+	checkOverlap := fs.Bool("check-overlap", false, "reject the zone if it overlaps active zones (default: server setting)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	in.Status = client.IncidentStatus(*status)
+	in.Severity = client.Severity(*severity)
+	in.State = client.IncidentState(*state)
+	in.Visibility = client.Visibility(*visibility)
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "check-overlap" {
+			in.CheckOverlap = checkOverlap
+		}
+	})
+
+	id, err := a.client.CreateIncident(ctx, in)
+	if err != nil {
+		return err
+	}
+	return a.print(map[string]int{"incident_id": id}, func(w io.Writer) {
+		fmt.Fprintf(w, "created incident %d\n", id)
+	})
+}
+
+func (a *cli) incidentTranslate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("incidents translate", flag.ExitOnError)
+	name := fs.String("name", "", "translated name")
+	descr := fs.String("descr", "", "translated description, empty keeps the original one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return usageError("incidents translate: -name is required")
+	}
+	if fs.NArg() != 2 {
+		return usageError("incidents translate: expected an ID and a locale")
+	}
+	ids, err := parseIDs(fs.Args()[:1])
+	if err != nil {
+		return err
+	}
+
+	translation, err := a.client.SetIncidentTranslation(ctx, ids[0], fs.Arg(1), *name, *descr)
+	if err != nil {
+		return err
+	}
+	return a.printTranslations([]client.IncidentTranslation{*translation})
+}
+
+func (a *cli) incidentComments(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("incidents comments", flag.ExitOnError)
+	limit := fs.Int("limit", 0, "list size, 0 for the server default")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := parseIDs(fs.Args())
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return usageError("incidents comments: expected one ID")
+	}
+
+	comments, err := a.client.ListIncidentComments(ctx, ids[0], *limit)
+	if err != nil {
+		return err
+	}
+	return a.printComments(comments)
+}
+
+func (a *cli) incidentRelate(ctx context.Context, sub string, args []string) error {
+	if len(args) != 3 {
+		return usageError("incidents %s: expected an ID, parent or supersedes and a related ID", sub)
+	}
+	typ := client.RelationType(args[1])
+	if typ != client.RelationParent && typ != client.RelationSupersedes {
+		return usageError("incidents %s: relation must be parent or supersedes", sub)
+	}
+	ids, err := parseIDs([]string{args[0], args[2]})
+	if err != nil {
+		return err
+	}
+
+	if sub == "unrelate" {
+		if err := a.client.DeleteIncidentRelation(ctx, ids[0], ids[1], typ); err != nil {
+			return err
+		}
+		return a.print(map[string]interface{}{"deleted": typ, "related_id": ids[1]}, func(w io.Writer) {
+			fmt.Fprintf(w, "relation %s %d of incident %d deleted\n", typ, ids[1], ids[0])
+		})
+	}
+
+	relation, err := a.client.AddIncidentRelation(ctx, ids[0], ids[1], typ)
+	if err != nil {
+		return err
+	}
+	return a.printRelations([]client.IncidentRelation{*relation})
+}
+
+func (a *cli) incidentBacktest(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("incidents backtest", flag.ExitOnError)
+	hours := fs.Int("hours", 0, "replay checks of the last N hours, 0 for the server default")
+	radius := fs.Float64("radius", 0, "radius in meters to try instead of the circular zone's own")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := parseIDs(fs.Args())
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return usageError("incidents backtest: expected one ID")
+	}
+
+	result, err := a.client.BacktestIncident(ctx, ids[0], *hours, *radius)
+	if err != nil {
+		return err
+	}
+	return a.print(result, func(w io.Writer) {
+		fmt.Fprintf(w, "incident %d, radius %.0f m, %s - %s\n", result.IncidentID, result.Radius,
+			result.From.Local().Format(time.DateTime), result.To.Local().Format(time.DateTime))
+		fmt.Fprintf(w, "checks: %d in zone of %d scanned\n", result.ChecksMatched, result.ChecksScanned)
+		fmt.Fprintf(w, "users alerted: %d, not alerted by other zones: %d\n", result.UsersAlerted, result.UsersNewlyAlerted)
+		if result.Truncated {
+			fmt.Fprintln(w, "only the earliest checks were replayed, shorten -hours for the full period")
+		}
+	})
+}
+
+func (a *cli) incidentMerge(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("incidents merge", flag.ExitOnError)
+	name := fs.String("name", "", "name of the merged incident")
+	descr := fs.String("descr", "", "description of the merged incident")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return usageError("incidents merge: -name is required")
+	}
+
+	ids, err := parseIDs(fs.Args())
+	if err != nil {
+		return err
+	}
+	if len(ids) < 2 {
+		return usageError("incidents merge: expected at least two IDs")
+	}
+
+	inc, err := a.client.MergeIncidents(ctx, ids, *name, *descr)
+	if err != nil {
+		return err
+	}
+	return a.printIncidents([]client.Incident{*inc})
+}
+
+func (a *cli) incidentSplit(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return usageError("incidents split: expected an ID and a file name or -")
+	}
+	ids, err := parseIDs(args[:1])
+	if err != nil {
+		return err
+	}
+
+	var src io.Reader = os.Stdin
+	if name := args[1]; name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		src = f
+	}
+
+	var parts []client.IncidentPart
+	if err := json.NewDecoder(src).Decode(&parts); err != nil {
+		return fmt.Errorf("failed to decode parts: %w", err)
+	}
+
+	incidents, err := a.client.SplitIncident(ctx, ids[0], parts)
+	if err != nil {
+		return err
+	}
+	return a.printIncidents(incidents)
+}
+
+// incidentLoad создает инциденты из файла учений: JSON-массив или по объекту на строку
+func (a *cli) incidentLoad(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("incidents load", flag.ExitOnError)
+	keepGoing := fs.Bool("keep-going", false, "continue after a failed incident")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("incidents load: expected a file name or -")
+	}
+
+	var src io.Reader = os.Stdin
+	if name := fs.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		src = f
+	}
+
+	requests, err := decodeIncidents(src)
+	if err != nil {
+		return err
+	}
+
+	type result struct {
+		Index      int    `json:"index"`
+		Name       string `json:"name"`
+		IncidentID int    `json:"incident_id,omitempty"`
+		Error      string `json:"error,omitempty"`
+	}
+	results := make([]result, 0, len(requests))
+	failed := 0
+	for i, in := range requests {
+		res := result{Index: i, Name: in.Name}
+		id, err := a.client.CreateIncident(ctx, in)
+		if err != nil {
+			if ctx.Err() != nil || !*keepGoing {
+				return fmt.Errorf("incident #%d (%s): %w", i, in.Name, err)
+			}
+			res.Error = err.Error()
+			failed++
+		}
+		res.IncidentID = id
+		results = append(results, res)
+	}
+
+	err = a.print(results, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "#\tID\tNAME\tERROR")
+		for _, r := range results {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", r.Index, idOrDash(r.IncidentID), r.Name, r.Error)
+		}
+		tw.Flush()
+		fmt.Fprintf(w, "created %d of %d incidents\n", len(results)-failed, len(requests))
+	})
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d incidents failed", failed)
+	}
+	return nil
+}
+
+func decodeIncidents(r io.Reader) ([]client.IncidentCreateRequest, error) {
+	br := bufio.NewReader(r)
+	first, err := peekNonSpace(br)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("no incidents in input")
+		}
+		return nil, err
+	}
+
+	dec := json.NewDecoder(br)
+	dec.DisallowUnknownFields()
+
+	var out []client.IncidentCreateRequest
+	if first == '[' {
+		if err := dec.Decode(&out); err != nil {
+			return nil, fmt.Errorf("failed to decode incidents: %w", err)
+		}
+		return out, nil
+	}
+
+	for {
+		var in client.IncidentCreateRequest
+		if err := dec.Decode(&in); err != nil {
+			if errors.Is(err, io.EOF) {
+				return out, nil
+			}
+			return nil, fmt.Errorf("failed to decode incident #%d: %w", len(out), err)
+		}
+		out = append(out, in)
+	}
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, br.UnreadByte()
+	}
+}
+
+func (a *cli) webhooks(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("webhooks: missing subcommand")
+	}
+
+	sub, args := args[0], args[1:]
+	switch sub {
+	case "replay":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		requeued, err := a.client.ReplayWebhooks(ctx, ids...)
+		if err != nil {
+			return err
+		}
+		return a.print(map[string]int{"requeued": requeued}, func(w io.Writer) {
+			fmt.Fprintf(w, "requeued %d webhooks\n", requeued)
+		})
+	case "test":
+		fs := flag.NewFlagSet("webhooks test", flag.ExitOnError)
+		webhookURL := fs.String("url", "", "receiver URL")
+		attempts := fs.Int("attempts", 0, "delivery attempts, 0 for the service setting")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if *webhookURL == "" {
+			return usageError("webhooks test: -url is required")
+		}
+
+		res, err := a.client.TestWebhook(ctx, *webhookURL, *attempts)
+		if err != nil {
+			return err
+		}
+		return a.print(res, func(w io.Writer) {
+			tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(tw, "delivered\t%t\n", res.Delivered)
+			fmt.Fprintf(tw, "status\t%s\n", idOrDash(res.StatusCode))
+			fmt.Fprintf(tw, "attempts\t%d\n", res.Attempts)
+			fmt.Fprintf(tw, "latency\t%.1f ms\n", res.LatencyMs)
+			if res.Error != "" {
+				fmt.Fprintf(tw, "error\t%s\n", res.Error)
+			}
+			tw.Flush()
+		})
+	case "redrive":
+		fs := flag.NewFlagSet("webhooks redrive", flag.ExitOnError)
+		from := fs.String("from", "", "created at or after, RFC 3339")
+		to := fs.String("to", "", "created before, RFC 3339")
+		incidentID := fs.Int("incident", 0, "only webhooks about this incident")
+		endpointID := fs.Int("endpoint", 0, "only webhooks for this endpoint")
+		spread := fs.Duration("spread", 0, "spread deliveries over this window, e.g. 10m")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+
+		in := client.WebhookRedriveRequest{
+			IncidentID:    *incidentID,
+			EndpointID:    *endpointID,
+			SpreadSeconds: int(spread.Seconds()),
+		}
+		for _, bound := range []struct {
+			name  string
+			value string
+			dst   **time.Time
+		}{{"from", *from, &in.CreatedFrom}, {"to", *to, &in.CreatedTo}} {
+			if bound.value == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, bound.value)
+			if err != nil {
+				return usageError("webhooks redrive: invalid -%s %q, expected RFC 3339", bound.name, bound.value)
+			}
+			*bound.dst = &t
+		}
+
+		redriven, err := a.client.RedriveWebhooks(ctx, in)
+		if err != nil {
+			return err
+		}
+		return a.print(map[string]int{"redriven": redriven}, func(w io.Writer) {
+			fmt.Fprintf(w, "redriven %d webhooks\n", redriven)
+		})
+	case "endpoints":
+		return a.webhookEndpoints(ctx, args)
+	default:
+		return usageError("webhooks: unknown subcommand %q", sub)
+	}
+}
+
+func (a *cli) webhookEndpoints(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("webhooks endpoints: missing subcommand")
+	}
+
+	sub, args := args[0], args[1:]
+	switch sub {
+	case "list":
+		endpoints, err := a.client.ListWebhookEndpoints(ctx)
+		if err != nil {
+			return err
+		}
+		return a.printWebhookEndpoints(endpoints)
+	case "add":
+		fs := flag.NewFlagSet("webhooks endpoints add", flag.ExitOnError)
+		endpointURL := fs.String("url", "", "receiver URL")
+		secret := fs.String("secret", "", "HMAC signing secret, empty to send unsigned")
+		events := fs.String("events", "*", "comma-separated event types, * for all")
+		disabled := fs.Bool("disabled", false, "register the endpoint disabled")
+		policy := retryPolicyFlags(fs)
+		delivery := deliveryFlags(fs)
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if *endpointURL == "" {
+			return usageError("webhooks endpoints add: -url is required")
+		}
+
+		enabled := !*disabled
+		endpoint, err := a.client.CreateWebhookEndpoint(ctx, client.WebhookEndpointCreateRequest{
+			URL:             *endpointURL,
+			Secret:          *secret,
+			Events:          parseEvents(*events),
+			Enabled:         &enabled,
+			RetryPolicy:     policy.build(fs),
+			DeliveryOptions: delivery.options(),
+		})
+		if err != nil {
+			return err
+		}
+		return a.printWebhookEndpoints([]client.WebhookEndpoint{*endpoint})
+	case "enable", "disable":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return usageError("webhooks endpoints %s: expected endpoint IDs", sub)
+		}
+
+		enabled := sub == "enable"
+		endpoints := make([]client.WebhookEndpoint, 0, len(ids))
+		for _, id := range ids {
+			endpoint, err := a.client.PatchWebhookEndpoint(ctx, id, client.WebhookEndpointPatchRequest{Enabled: &enabled})
+			if err != nil {
+				return fmt.Errorf("endpoint %d: %w", id, err)
+			}
+			endpoints = append(endpoints, *endpoint)
+		}
+		return a.printWebhookEndpoints(endpoints)
+	case "retry":
+		fs := flag.NewFlagSet("webhooks endpoints retry", flag.ExitOnError)
+		policy := retryPolicyFlags(fs)
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		ids, err := parseIDs(fs.Args())
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("webhooks endpoints retry: expected one endpoint ID")
+		}
+
+		p := policy.build(fs)
+		patch := client.WebhookEndpointPatchRequest{
+			MaxRetries:        p.MaxRetries,
+			RetryDelaySeconds: p.RetryDelaySeconds,
+			TimeoutSeconds:    p.TimeoutSeconds,
+		}
+		if p.Backoff != "" {
+			patch.Backoff = &p.Backoff
+		}
+		endpoint, err := a.client.PatchWebhookEndpoint(ctx, ids[0], patch)
+		if err != nil {
+			return err
+		}
+		return a.printWebhookEndpoints([]client.WebhookEndpoint{*endpoint})
+	case "delivery":
+		fs := flag.NewFlagSet("webhooks endpoints delivery", flag.ExitOnError)
+		delivery := deliveryFlags(fs)
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		ids, err := parseIDs(fs.Args())
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("webhooks endpoints delivery: expected one endpoint ID")
+		}
+
+		endpoint, err := a.client.PatchWebhookEndpoint(ctx, ids[0], delivery.patch(fs))
+		if err != nil {
+			return err
+		}
+		return a.printWebhookEndpoints([]client.WebhookEndpoint{*endpoint})
+	case "slo":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		switch len(ids) {
+		case 0:
+			list, err := a.client.WebhookSLOs(ctx)
+			if err != nil {
+				return err
+			}
+			return a.printWebhookSLOs(list, list.Endpoints)
+		case 1:
+			slo, err := a.client.WebhookEndpointSLO(ctx, ids[0])
+			if err != nil {
+				return err
+			}
+			return a.printWebhookSLOs(slo, []client.WebhookSLO{*slo})
+		default:
+			return usageError("webhooks endpoints slo: expected at most one endpoint ID")
+		}
+	case "rm":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return usageError("webhooks endpoints rm: expected endpoint IDs")
+		}
+
+		for _, id := range ids {
+			if err := a.client.DeleteWebhookEndpoint(ctx, id); err != nil {
+				return fmt.Errorf("endpoint %d: %w", id, err)
+			}
+		}
+		return a.print(map[string][]int{"deleted": ids}, func(w io.Writer) {
+			fmt.Fprintf(w, "deleted %d endpoints\n", len(ids))
+		})
+	default:
+		return usageError("webhooks endpoints: unknown subcommand %q", sub)
+	}
+}
+
+type retryPolicyFlagSet struct {
+	maxRetries *int
+	retryDelay *int
+	backoff    *string
+	timeout    *int
+}
+
+func retryPolicyFlags(fs *flag.FlagSet) retryPolicyFlagSet {
+	return retryPolicyFlagSet{
+		maxRetries: fs.Int("max-retries", 0, "retries before the webhook is marked failed"),
+		retryDelay: fs.Int("retry-delay", 0, "base delay between retries, seconds"),
+		backoff:    fs.String("backoff", "", "linear or exponential"),
+		timeout:    fs.Int("timeout", 0, "request timeout, seconds"),
+	}
+}
+
+// build берет только явно заданные флаги: остальные значения остаются на настройках сервиса
+func (f retryPolicyFlagSet) build(fs *flag.FlagSet) client.RetryPolicy {
+	var policy client.RetryPolicy
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "max-retries":
+			policy.MaxRetries = f.maxRetries
+		case "retry-delay":
+			policy.RetryDelaySeconds = f.retryDelay
+		case "backoff":
+			policy.Backoff = client.WebhookBackoff(*f.backoff)
+		case "timeout":
+			policy.TimeoutSeconds = f.timeout
+		}
+	})
+	return policy
+}
+
+type deliveryFlagSet struct {
+	batch       *int
+	batchWait   *int
+	compression *string
+}
+
+func deliveryFlags(fs *flag.FlagSet) deliveryFlagSet {
+	return deliveryFlagSet{
+		batch:       fs.Int("batch", 0, "events per NDJSON batch request, 0 sends each event separately"),
+		batchWait:   fs.Int("batch-wait", 0, "how long a batch collects events, ms"),
+		compression: fs.String("compression", "", "gzip or none"),
+	}
+}
+
+func (f deliveryFlagSet) options() client.DeliveryOptions {
+	return client.DeliveryOptions{
+		BatchSize:   *f.batch,
+		BatchWaitMs: *f.batchWait,
+		Compression: client.WebhookCompression(*f.compression),
+	}
+}
+
+// patch берет только явно заданные флаги, как retryPolicyFlagSet.build
+func (f deliveryFlagSet) patch(fs *flag.FlagSet) client.WebhookEndpointPatchRequest {
+	var patch client.WebhookEndpointPatchRequest
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "batch":
+			patch.BatchSize = f.batch
+		case "batch-wait":
+			patch.BatchWaitMs = f.batchWait
+		case "compression":
+			compression := client.WebhookCompression(*f.compression)
+			patch.Compression = &compression
+		}
+	})
+	return patch
+}
+
+func parseEvents(s string) []client.WebhookEventType {
+	var events []client.WebhookEventType
+	for _, event := range strings.Split(s, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, client.WebhookEventType(event))
+		}
+	}
+	return events
+}
+
+func (a *cli) approvals(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("approvals: missing subcommand")
+	}
+
+	sub, args := args[0], args[1:]
+	switch sub {
+	case "list":
+		fs := flag.NewFlagSet("approvals list", flag.ExitOnError)
+		status := fs.String("status", string(client.ApprovalPending), "pending, approved or rejected, empty for all")
+		limit := fs.Int("limit", 0, "list size, 0 for the server default")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+
+		approvals, err := a.client.ListApprovals(ctx, client.ApprovalStatus(*status), *limit)
+		if err != nil {
+			return err
+		}
+		return a.printApprovals(approvals)
+	case "approve":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("approvals approve: expected one ID")
+		}
+		approval, err := a.client.ApproveIncident(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return a.printApprovals([]client.Approval{*approval})
+	case "reject":
+		fs := flag.NewFlagSet("approvals reject", flag.ExitOnError)
+		reason := fs.String("reason", "", "reason shown to the requester")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		ids, err := parseIDs(fs.Args())
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("approvals reject: expected one ID")
+		}
+		approval, err := a.client.RejectIncident(ctx, ids[0], *reason)
+		if err != nil {
+			return err
+		}
+		return a.printApprovals([]client.Approval{*approval})
+	default:
+		return usageError("approvals: unknown subcommand %q", sub)
+	}
+}
+
+func (a *cli) users(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("users: missing subcommand")
+	}
+
+	sub, args := args[0], args[1:]
+	switch sub {
+	case "export":
+		fs := flag.NewFlagSet("users export", flag.ExitOnError)
+		wait := fs.Bool("wait", false, "poll until the archive is ready")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usageError("users export: expected one USER_ID")
+		}
+
+		export, err := a.client.UserDataExport(ctx, fs.Arg(0))
+		for err == nil && *wait && export.Status != client.DataExportReady {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(2 * time.Second):
+			}
+			export, err = a.client.UserDataExport(ctx, fs.Arg(0))
+		}
+		if err != nil {
+			return err
+		}
+		return a.printDataExport(export)
+	default:
+		return usageError("users: unknown subcommand %q", sub)
+	}
+}
+
+func (a *cli) erasures(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("erasures: missing subcommand")
+	}
+
+	sub, args := args[0], args[1:]
+	switch sub {
+	case "request":
+		fs := flag.NewFlagSet("erasures request", flag.ExitOnError)
+		anonymize := fs.Bool("anonymize", false, "keep the checks for statistics without the user ID")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usageError("erasures request: expected one USER_ID")
+		}
+		mode := client.ErasureDelete
+		if *anonymize {
+			mode = client.ErasureAnonymize
+		}
+		erasure, err := a.client.RequestErasure(ctx, fs.Arg(0), mode)
+		if err != nil {
+			return err
+		}
+		return a.printErasures([]client.Erasure{*erasure})
+	case "list":
+		fs := flag.NewFlagSet("erasures list", flag.ExitOnError)
+		status := fs.String("status", "", "pending, confirmed, completed or cancelled, empty for all")
+		limit := fs.Int("limit", 0, "list size, 0 for the server default")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+
+		erasures, err := a.client.ListErasures(ctx, client.ErasureStatus(*status), *limit)
+		if err != nil {
+			return err
+		}
+		return a.printErasures(erasures)
+	case "get", "confirm", "cancel":
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return usageError("erasures %s: expected one ID", sub)
+		}
+
+		var erasure *client.Erasure
+		switch sub {
+		case "get":
+			erasure, err = a.client.GetErasure(ctx, ids[0])
+		case "confirm":
+			erasure, err = a.client.ConfirmErasure(ctx, ids[0])
+		default:
+			erasure, err = a.client.CancelErasure(ctx, ids[0])
+		}
+		if err != nil {
+			return err
+		}
+		return a.printErasures([]client.Erasure{*erasure})
+	default:
+		return usageError("erasures: unknown subcommand %q", sub)
+	}
+}
+
+func (a *cli) stats(ctx context.Context, args []string) error {
+	if len(args) > 0 && args[0] == "heatmap" {
+		return a.heatmap(ctx, args[1:])
+	}
+	if len(args) > 0 && args[0] == "incidents" {
+		return a.incidentStats(ctx, args[1:])
+	}
+
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	estimated := fs.Bool("estimated", false, "approximate counts from the query planner")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	get := a.client.Stats
+	if *estimated {
+		get = a.client.EstimatedStats
+	}
+	stats, err := get(ctx)
+	if err != nil {
+		return err
+	}
+
+	approx := ""
+	if stats.Estimated {
+		approx = "~"
+	}
+	return a.print(stats, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "users\t%s%d\n", approx, stats.UserCount)
+		fmt.Fprintf(tw, "checks\t%s%d\n", approx, stats.TotalChecks)
+		fmt.Fprintf(tw, "window\t%d min (since %s)\n", stats.WindowMinutes, stats.PeriodStart.Local().Format(time.DateTime))
+		tw.Flush()
+	})
+}
+
+func (a *cli) heatmap(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("stats heatmap", flag.ExitOnError)
+	resolution := fs.Int("resolution", -1, "H3 resolution (server default without it)")
+	from := fs.String("from", "", "period start, RFC 3339 (24 hours ago by default)")
+	to := fs.String("to", "", "period end, RFC 3339 (now by default)")
+	n := fs.Int("n", 20, "number of cells to show")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var in client.HeatmapRequest
+	if *resolution >= 0 {
+		in.Resolution = resolution
+	}
+	for _, bound := range []struct {
+		name  string
+		value string
+		dst   **time.Time
+	}{{"from", *from, &in.From}, {"to", *to, &in.To}} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return usageError("stats heatmap: invalid -%s %q, expected RFC 3339", bound.name, bound.value)
+		}
+		*bound.dst = &t
+	}
+
+	heatmap, err := a.client.Heatmap(ctx, in)
+	if err != nil {
+		return err
+	}
+	return a.print(heatmap, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CELL\tCENTER\tCHECKS\tALERTS")
+		for i, c := range heatmap.Cells {
+			if i == *n {
+				break
+			}
+			fmt.Fprintf(tw, "%s\t%.5f,%.5f\t%d\t%d\n", c.Cell, c.Latitude, c.Longitude, c.Checks, c.Alerts)
+		}
+		tw.Flush()
+		if len(heatmap.Cells) > *n {
+			fmt.Fprintf(w, "... %d more cells\n", len(heatmap.Cells)-*n)
+		}
+	})
+}
+
+func (a *cli) incidentStats(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("stats incidents", flag.ExitOnError)
+	window := fs.Int("window", 0, "window in minutes (server default without it)")
+	n := fs.Int("n", 20, "number of incidents to show")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	stats, err := a.client.IncidentStats(ctx, *window)
+	if err != nil {
+		return err
+	}
+	return a.print(stats, func(w io.Writer) {
+		fmt.Fprintf(w, "%d users, %d checks, %d with alerts, %d min until %s\n", stats.UserCount, stats.TotalChecks,
+			stats.AlertChecks, stats.WindowMinutes, stats.PeriodEnd.Local().Format(time.DateTime))
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tCHECKS")
+		for i, s := range stats.Incidents {
+			if i == *n {
+				break
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%d\n", s.IncidentID, s.Name, s.Checks)
+		}
+		tw.Flush()
+		if len(stats.Incidents) > *n {
+			fmt.Fprintf(w, "... %d more incidents\n", len(stats.Incidents)-*n)
+		}
+	})
+}
+
+func (a *cli) queries(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("queries", flag.ExitOnError)
+	n := fs.Int("n", 20, "number of queries to show")
+	reset := fs.Bool("reset", false, "clear the counters instead of printing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *reset {
+		if err := a.client.ResetQueryStats(ctx); err != nil {
+			return err
+		}
+		fmt.Println("query stats reset")
+		return nil
+	}
+
+	stats, err := a.client.QueryStats(ctx, *n)
+	if err != nil {
+		return err
+	}
+	return a.print(stats, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CALLS\tERRORS\tTOTAL MS\tMEAN MS\tMAX MS\tSQL")
+		for _, q := range stats.Queries {
+			fmt.Fprintf(tw, "%d\t%d\t%.0f\t%.1f\t%.1f\t%s\n",
+				q.Calls, q.Errors, q.TotalMs, q.MeanMs, q.MaxMs, truncate(q.SQL, 100))
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) queues(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return usageError("queues: unexpected arguments")
+	}
+
+	q, err := a.client.Queues(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(q, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		switch {
+		case q.Queue.Error != "":
+			fmt.Fprintf(tw, "queue (%s)\tunavailable: %s\n", q.Queue.Backend, q.Queue.Error)
+		case q.Queue.Scheduled != nil:
+			fmt.Fprintf(tw, "queue (%s)\t%d ready, %d scheduled\n", q.Queue.Backend, q.Queue.Ready, *q.Queue.Scheduled)
+		default:
+			fmt.Fprintf(tw, "queue (%s)\t%d ready\n", q.Queue.Backend, q.Queue.Ready)
+		}
+		fmt.Fprintf(tw, "outbox\t%d pending, %d processing, %d failed\n", q.Outbox.Pending, q.Outbox.Processing, q.Outbox.Failed)
+		fmt.Fprintf(tw, "due\t%d\n", q.Outbox.Due)
+		fmt.Fprintf(tw, "oldest pending\t%s\n", seconds(q.Outbox.OldestPendingAgeSeconds))
+		fmt.Fprintf(tw, "poller lag\t%s\n", seconds(q.Outbox.PollerLagSeconds))
+		tw.Flush()
+	})
+}
+
+func seconds(s float64) string {
+	return time.Duration(s * float64(time.Second)).Round(time.Second).String()
+}
+
+func (a *cli) usage(ctx context.Context, args []string) error {
+	if len(args) > 0 && args[0] == "export" {
+		return a.usageExport(ctx, args[1:])
+	}
+
+	fs := flag.NewFlagSet("usage", flag.ExitOnError)
+	from := fs.String("from", "", "first day, YYYY-MM-DD (default: start of the month)")
+	to := fs.String("to", "", "last day, YYYY-MM-DD (default: today)")
+	days := fs.Bool("days", false, "print usage per day instead of request totals")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("usage: unexpected arguments")
+	}
+
+	u, err := a.client.Usage(ctx, *from, *to)
+	if err != nil {
+		return err
+	}
+	return a.print(u, func(w io.Writer) {
+		if !u.Enabled {
+			fmt.Fprintln(w, "API key usage metering is disabled")
+			return
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if *days {
+			fmt.Fprintln(tw, "KEY\tDAY\tREQUESTS\tCHECKS\tALERTS\tWEBHOOKS")
+			for _, k := range u.Keys {
+				for _, d := range k.Days {
+					fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\n", k.Key, d.Day, d.Requests, d.Checks, d.Alerts, d.Webhooks)
+				}
+			}
+			tw.Flush()
+			return
+		}
+
+		fmt.Fprintf(tw, "KEY\tTODAY\tTHIS MONTH\t%s..%s\n", u.From, u.To)
+		for _, k := range u.Keys {
+			today, month := quotaUsage(k.Current.Today, k.DailyQuota), quotaUsage(k.Current.ThisMonth, k.MonthlyQuota)
+			if k.Current.Error != "" {
+				today, month = "?", "?"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", k.Key, today, month, k.Total)
+		}
+		tw.Flush()
+	})
+}
+
+// usageExport пишет выгрузку для биллинга в stdout или файл: CSV, с -json — JSON
+func (a *cli) usageExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("usage export", flag.ExitOnError)
+	from := fs.String("from", "", "first day, YYYY-MM-DD (default: yesterday)")
+	to := fs.String("to", "", "last day, YYYY-MM-DD (default: yesterday)")
+	out := fs.String("o", "", "write to a file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("usage export: unexpected arguments")
+	}
+
+	// выгрузка собирается целиком, чтобы оборванный запрос не оставил полфайла
+	var buf bytes.Buffer
+	if a.jsonOut {
+		export, err := a.client.ExportUsage(ctx, *from, *to)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(export); err != nil {
+			return err
+		}
+	} else if err := a.client.ExportUsageCSV(ctx, *from, *to, &buf); err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err := buf.WriteTo(os.Stdout)
+		return err
+	}
+	return os.WriteFile(*out, buf.Bytes(), 0o644)
+}
+
+// quotaUsage: "120/1000" для ключа с квотой, "120" без нее
+func quotaUsage(used, quota int64) string {
+	if quota == 0 {
+		return strconv.FormatInt(used, 10)
+	}
+	return fmt.Sprintf("%d/%d", used, quota)
+}
+
+func (a *cli) locks(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return usageError("locks: unexpected arguments")
+	}
+
+	locks, err := a.client.WorkerLocks(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(locks, func(w io.Writer) {
+		if !locks.Enabled {
+			fmt.Fprintf(w, "worker locks are disabled on %s\n", locks.Instance)
+			return
+		}
+
+		fmt.Fprintf(w, "instance %s\n", locks.Instance)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "LOCK\tHELD SINCE\tACQUIRED\tLOST\tRUNS\tSKIPPED\tERRORS\tLAST ERROR")
+		for _, l := range locks.Locks {
+			since := "-"
+			if l.HeldSince != nil {
+				since = l.HeldSince.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\n",
+				l.Name, since, l.Acquired, l.Lost, l.Runs, l.Skipped, l.Errors, truncate(l.LastError, 60))
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) alerts(ctx context.Context, args []string) error {
+	if len(args) != 2 || args[0] != "tail" {
+		return usageError("alerts: expected tail USER_ID")
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	return a.stream.StreamAlerts(ctx, args[1], func(ev client.AlertEvent) error {
+		if a.jsonOut {
+			return enc.Encode(ev)
+		}
+		for _, inc := range ev.Incidents {
+			fmt.Printf("%s  %s  user=%s  incident=%d %q\n",
+				ev.CreatedAt.Local().Format(time.DateTime), ev.Type, ev.UserID, inc.ID, inc.Name)
+		}
+		return nil
+	})
+}
+
+func (a *cli) login(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ExitOnError)
+	username := fs.String("username", "", "operator username")
+	password := fs.String("password", os.Getenv("GEONOTIFY_PASSWORD"), "operator password")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *username == "" || *password == "" {
+		return usageError("login: -username and -password are required")
+	}
+
+	token, err := a.client.Login(ctx, *username, *password)
+	if err != nil {
+		return err
+	}
+	// голый токен, чтобы его можно было сразу подставить: export GEONOTIFY_TOKEN=$(geonotifyctl login ...)
+	return a.print(token, func(w io.Writer) {
+		fmt.Fprintln(w, token.AccessToken)
+	})
+}
+
+func (a *cli) printIncidents(incidents []client.Incident) error {
+	return a.print(incidents, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tSTATUS\tSTATE\tSEVERITY\tVISIBILITY\tSOURCE\tACTIVE\tLAT\tLNG\tRADIUS_M\tEXPIRES\tUPDATED")
+		for _, inc := range incidents {
+			expires := "-"
+			if inc.ExpiresAt != nil {
+				expires = inc.ExpiresAt.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%t\t%.6f\t%.6f\t%.0f\t%s\t%s\n",
+				inc.ID, inc.Name, inc.Status, inc.State, inc.Severity, inc.Visibility, inc.Source, inc.IsActive, inc.Latitude, inc.Longitude, inc.Radius,
+				expires, inc.UpdatedAt.Local().Format(time.DateTime))
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) printLineage(links []client.IncidentLink) error {
+	return a.print(links, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "PARENT\tCHILD\tOPERATION\tACTOR\tCREATED")
+		for _, l := range links {
+			actor := l.Actor
+			if actor == "" {
+				actor = "-"
+			}
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\n",
+				l.ParentID, l.ChildID, l.Operation, actor, l.CreatedAt.Local().Format(time.DateTime))
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) printTranslations(translations []client.IncidentTranslation) error {
+	return a.print(translations, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "LOCALE\tNAME\tUPDATED_BY\tUPDATED\tDESCR")
+		for _, t := range translations {
+			updatedBy := t.UpdatedBy
+			if updatedBy == "" {
+				updatedBy = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+				t.Locale, t.Name, updatedBy, t.UpdatedAt.Local().Format(time.DateTime), t.Descr)
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) printComments(comments []client.IncidentComment) error {
+	return a.print(comments, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tAUTHOR\tCREATED\tBODY")
+		for _, c := range comments {
+			author := c.Author
+			if author == "" {
+				author = "-"
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", c.ID, author, c.CreatedAt.Local().Format(time.DateTime), c.Body)
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) printVersions(versions []client.GeometryVersion) error {
+	return a.print(versions, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tVALID_FROM\tSHAPE\tCENTER\tRADIUS_M\tCHANGED_BY")
+		for _, v := range versions {
+			shape := "circle"
+			if len(v.Geometry) > 0 {
+				shape = "polygon"
+			}
+			changedBy := v.ChangedBy
+			if changedBy == "" {
+				changedBy = "-"
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%.5f,%.5f\t%.0f\t%s\n", v.Version, v.ValidFrom.Local().Format(time.DateTime),
+				shape, v.Latitude, v.Longitude, v.Radius, changedBy)
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) printRelations(relations []client.IncidentRelation) error {
+	return a.print(relations, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TYPE\tRELATED\tCREATED_BY\tCREATED")
+		for _, r := range relations {
+			createdBy := r.CreatedBy
+			if createdBy == "" {
+				createdBy = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", r.Type, r.RelatedID, createdBy, r.CreatedAt.Local().Format(time.DateTime))
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) printApprovals(approvals []client.Approval) error {
+	return a.print(approvals, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tINCIDENT\tSTATUS\tREQUESTED_BY\tREQUESTED\tDECIDED_BY\tREASON")
+		for _, ap := range approvals {
+			decidedBy := ap.DecidedBy
+			if decidedBy == "" {
+				decidedBy = "-"
+			}
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+				ap.ID, ap.IncidentID, ap.Status, ap.RequestedBy,
+				ap.RequestedAt.Local().Format(time.DateTime), decidedBy, ap.Reason)
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) printDataExport(export *client.DataExport) error {
+	return a.print(export, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "ID\t%d\n", export.ID)
+		fmt.Fprintf(tw, "USER\t%s\n", export.UserID)
+		fmt.Fprintf(tw, "STATUS\t%s\n", export.Status)
+		fmt.Fprintf(tw, "REQUESTED\t%s by %s\n", export.RequestedAt.Local().Format(time.DateTime), export.RequestedBy)
+		if export.ExpiresAt != nil {
+			fmt.Fprintf(tw, "EXPIRES\t%s\n", export.ExpiresAt.Local().Format(time.DateTime))
+			fmt.Fprintf(tw, "SIZE\t%d bytes\n", export.SizeBytes)
+		}
+		if export.DownloadURL != "" {
+			fmt.Fprintf(tw, "URL\t%s\n", export.DownloadURL)
+		}
+		if export.LastError != "" {
+			fmt.Fprintf(tw, "LAST_ERROR\t%s (%d attempts)\n", export.LastError, export.Attempts)
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) printErasures(erasures []client.Erasure) error {
+	return a.print(erasures, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tUSER\tMODE\tSTATUS\tREQUESTED_BY\tREQUESTED\tCONFIRMED_BY\tERASED\tLAST_ERROR")
+		for _, e := range erasures {
+			confirmedBy := e.ConfirmedBy
+			if confirmedBy == "" {
+				confirmedBy = "-"
+			}
+			erased := "-"
+			if e.Erased != nil {
+				erased = fmt.Sprintf("checks=%d webhooks=%d zones=%d prefs=%d groups=%d",
+					e.Erased.Checks, e.Erased.Webhooks, e.Erased.Zones, e.Erased.Preferences, e.Erased.GroupMemberships)
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				e.ID, e.UserID, e.Mode, e.Status, e.RequestedBy,
+				e.RequestedAt.Local().Format(time.DateTime), confirmedBy, erased, e.LastError)
+		}
+		tw.Flush()
+	})
+}
+
+func (a *cli) printWebhookEndpoints(endpoints []client.WebhookEndpoint) error {
+	return a.print(endpoints, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tURL\tEVENTS\tENABLED\tSIGNED\tRETRIES\tDELAY_S\tBACKOFF\tTIMEOUT_S\tBATCH\tGZIP\tUPDATED")
+		for _, e := range endpoints {
+			events := make([]string, len(e.Events))
+			for i, event := range e.Events {
+				events[i] = string(event)
+			}
+			backoff := string(e.Backoff)
+			if backoff == "" {
+				backoff = "-"
+			}
+			batch := "-"
+			if e.BatchSize > 0 {
+				batch = fmt.Sprintf("%d/%dms", e.BatchSize, e.BatchWaitMs)
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%t\t%t\t%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
+				e.ID, e.URL, strings.Join(events, ","), e.Enabled, e.HasSecret,
+				intOrDash(e.MaxRetries), intOrDash(e.RetryDelaySeconds), backoff, intOrDash(e.TimeoutSeconds),
+				batch, e.Compression == client.CompressionGzip, e.UpdatedAt.Local().Format(time.DateTime))
+		}
+		tw.Flush()
+	})
+}
+
+// printWebhookSLOs при -json выводит v — ответ сервера целиком
+func (a *cli) printWebhookSLOs(v any, slos []client.WebhookSLO) error {
+	return a.print(v, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tURL\tDELIVERED\tP50_MS\tP95_MS\tP99_MS\tTARGET\tBREACHED")
+		for _, slo := range slos {
+			target := "-"
+			if slo.TargetMs > 0 {
+				target = fmt.Sprintf("p%d<=%dms", slo.Percentile, slo.TargetMs)
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%d\t%d\t%s\t%t\n",
+				slo.EndpointID, slo.URL, slo.Delivered, slo.P50Ms, slo.P95Ms, slo.P99Ms, target, slo.Breached)
+		}
+		tw.Flush()
+		if len(slos) > 0 {
+			fmt.Fprintf(w, "window: last %d minutes\n", slos[0].WindowMinutes)
+		}
+	})
+}
+
+// print выводит v как JSON при -json, иначе вызывает table
+func (a *cli) print(v any, table func(w io.Writer)) error {
+	if a.jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	table(os.Stdout)
+	return nil
+}
+
+func parseIDs(args []string) ([]int, error) {
+	ids := make([]int, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil || id <= 0 {
+			return nil, usageError("invalid ID %q", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// intOrDash печатает "-" для значения, взятого из настроек сервиса
+func intOrDash(v *int) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Itoa(*v)
+}
+
+func idOrDash(id int) string {
+	if id == 0 {
+		return "-"
+	}
+	return strconv.Itoa(id)
+}
+
+func truncate(s string, n int) string {
+	if len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
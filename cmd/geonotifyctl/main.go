@@ -0,0 +1,223 @@
+// geonotifyctl — консольный клиент операторов для запущенного geonotify-service:
+// инциденты, повтор вебхуков, статистика и поток алертов. Работает через pkg/client.
+//
+//	geonotifyctl -server http://localhost:8080 -token $API_KEY incidents list
+//	geonotifyctl incidents load exercise.json
+//	geonotifyctl alerts tail user-1
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/4otis/geonotify-service/pkg/client"
+)
+
+const usage = `Usage: geonotifyctl [flags] <command> [args]
+
+Commands:
+  incidents list [-page N] [-limit N] [-all] [-status draft|published|archived]
+  incidents get ID
+  incidents create -name NAME (-lat LAT -lng LNG | -address ADDR) -radius M [-descr TEXT] [-ttl MIN] [-status draft|published]
+                                   [-severity low|medium|high|critical] [-state planned|active|contained]
+                                   [-visibility public|restricted|internal]
+  incidents load FILE              create incidents from a JSON array or JSON lines ("-" for stdin)
+  incidents publish|archive ID     publishing a critical incident opens an approval request
+  incidents activate|deactivate|delete ID...
+  incidents state ID STATE         move an incident to planned, active, contained, resolved or archived
+  incidents merge -name NAME [-descr TEXT] ID...
+                                   replace published incidents with one covering all of them
+  incidents split ID FILE          replace a published incident with parts from a JSON array
+                                   of {"name", "geometry"} ("-" for stdin)
+  incidents lineage ID             merges and splits the incident took part in
+  incidents versions ID            how the zone's center, radius and polygons changed over time
+  incidents relations ID           parent, child and supersedes links of the incident
+  incidents relate|unrelate ID parent|supersedes RELATED_ID
+                                   link the incident to a larger zone or to the zone it replaces
+  incidents backtest [-hours N] [-radius M] ID
+                                   how many users the zone would have alerted in the last N hours
+  incidents translations ID        translated names and descriptions of the incident
+  incidents translate -name NAME [-descr TEXT] ID LOCALE
+                                   add or replace the translation to LOCALE (en, pt-BR)
+  incidents untranslate ID LOCALE
+  incidents comments [-limit N] ID
+                                   operator notes on the incident, newest first
+  incidents comment ID TEXT        add a note on the incident
+  approvals list [-status pending|approved|rejected] [-limit N]
+  approvals approve ID
+  approvals reject [-reason TEXT] ID
+  users export [-wait] USER_ID     JSON archive of everything stored about the user; prints
+                                   the download link once ready
+  erasures request [-anonymize] USER_ID
+                                   open a request to erase the user's data (GDPR), a second
+                                   operator confirms it
+  erasures list [-status pending|confirmed|completed|cancelled] [-limit N]
+  erasures get|confirm|cancel ID
+  webhooks replay [ID...]          requeue failed webhooks (all of them without IDs)
+  webhooks redrive [-from TIME] [-to TIME] [-incident ID] [-endpoint ID] [-spread 10m]
+                                   resend failed webhooks matching the filters right away
+  webhooks test -url URL [-attempts N]
+  webhooks endpoints list
+  webhooks endpoints add -url URL [-secret S] [-events a,b|*] [-disabled] [retry flags] [delivery flags]
+  webhooks endpoints retry [-max-retries N] [-retry-delay S] [-backoff linear|exponential] [-timeout S] ID
+                                   change the endpoint retry policy, unset flags keep their values
+  webhooks endpoints delivery [-batch N] [-batch-wait MS] [-compression gzip|none] ID
+                                   NDJSON batching and gzip for the endpoint, -batch 0 turns batching off
+  webhooks endpoints enable|disable|rm ID...
+  webhooks endpoints slo [ID]      delivery latency p50/p95/p99 per endpoint against the SLO target
+  stats [-estimated]               -estimated asks for fast approximate counts
+  stats heatmap [-resolution N] [-from TIME] [-to TIME] [-n N]
+                                   busiest H3 cells by checks and alerts (last 24 hours by default)
+  stats incidents [-window MIN] [-n N]
+                                   rolled-up users, checks and alerts with checks per incident
+  queries [-n N] [-reset]          slowest database queries by total time; -reset clears the counters
+  queues                           webhook queue depth, outbox backlog and poller lag
+  locks                            periodic worker locks as seen by the replica that answered
+  usage [-from DATE] [-to DATE] [-days]
+                                   requests per API key and quota consumption
+  usage export [-from DATE] [-to DATE] [-o FILE]
+                                   per-key daily requests, checks, alerts and webhooks for billing,
+                                   CSV or JSON with -json (defaults to yesterday)
+  alerts tail USER_ID              print alert events until interrupted
+  login -username NAME [-password PASS]
+                                   print an operator JWT (password defaults to $GEONOTIFY_PASSWORD)
+
+Flags:
+`
+
+type cli struct {
+	client  *client.Client
+	stream  *client.Client
+	jsonOut bool
+}
+
+func main() {
+	var (
+		server  = flag.String("server", envOr("GEONOTIFY_URL", "http://localhost:8080"), "base URL of the service")
+		token   = flag.String("token", envOr("GEONOTIFY_TOKEN", os.Getenv("API_KEY")), "operator API key or JWT")
+		timeout = flag.Duration("timeout", 10*time.Second, "per-request timeout")
+		retries = flag.Int("retries", client.DefaultMaxRetries, "retries of idempotent requests, 0 to disable")
+		jsonOut = flag.Bool("json", false, "print raw JSON instead of tables")
+		lang    = flag.String("lang", os.Getenv("GEONOTIFY_LANG"), "preferred languages as in Accept-Language, e.g. \"en, de;q=0.8\"")
+	)
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	maxRetries := *retries
+	if maxRetries == 0 {
+		maxRetries = -1
+	}
+	opts := client.Options{
+		HTTPClient: &http.Client{Timeout: *timeout},
+		Token:      *token,
+		MaxRetries: maxRetries,
+		UserAgent:  "geonotifyctl",
+
+		AcceptLanguage: *lang,
+	}
+	c, err := client.New(*server, opts)
+	if err != nil {
+		fatal(err)
+	}
+	// поток алертов долгоживущий, таймаут запроса его бы оборвал
+	opts.HTTPClient = &http.Client{}
+	stream, err := client.New(*server, opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app := &cli{client: c, stream: stream, jsonOut: *jsonOut}
+	if err := app.run(ctx, flag.Args()); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		fatal(err)
+	}
+}
+
+func (a *cli) run(ctx context.Context, args []string) error {
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "incidents", "incident":
+		return a.incidents(ctx, args)
+	case "approvals", "approval":
+		return a.approvals(ctx, args)
+	case "users", "user":
+		return a.users(ctx, args)
+	case "erasures", "erasure":
+		return a.erasures(ctx, args)
+	case "webhooks", "webhook":
+		return a.webhooks(ctx, args)
+	case "stats":
+		return a.stats(ctx, args)
+	case "queries":
+		return a.queries(ctx, args)
+	case "queues":
+		return a.queues(ctx, args)
+	case "locks":
+		return a.locks(ctx, args)
+	case "usage":
+		return a.usage(ctx, args)
+	case "alerts":
+		return a.alerts(ctx, args)
+	case "login":
+		return a.login(ctx, args)
+	default:
+		return usageError("unknown command %q", cmd)
+	}
+}
+
+func fatal(err error) {
+	var uerr usageErr
+	if errors.As(err, &uerr) {
+		fmt.Fprintf(os.Stderr, "geonotifyctl: %v\n\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	fmt.Fprintf(os.Stderr, "geonotifyctl: %v\n", err)
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		for _, d := range apiErr.Details {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", d.Field, d.Message)
+		}
+	}
+	os.Exit(1)
+}
+
+type usageErr struct {
+	msg string
+}
+
+func (e usageErr) Error() string {
+	return e.msg
+}
+
+func usageError(format string, args ...any) error {
+	return usageErr{msg: fmt.Sprintf(format, args...)}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
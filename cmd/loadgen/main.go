@@ -0,0 +1,342 @@
+// loadgen нагружает запущенный geonotify-service синтетическими проверками
+// координат и CRUD инцидентов с заданным RPS и печатает перцентили задержек.
+//
+//	go run ./cmd/loadgen -target http://localhost:8080 -api-key secret -rps 200 -duration 1m
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+type options struct {
+	target      string
+	apiKey      string
+	rps         int
+	duration    time.Duration
+	concurrency int
+	crudRatio   float64
+	users       int
+	incidents   int
+	centerLat   float64
+	centerLng   float64
+	spreadKm    float64
+	timeout     time.Duration
+}
+
+type result struct {
+	op      string
+	latency time.Duration
+	failed  bool
+}
+
+type loadgen struct {
+	opts    options
+	client  *http.Client
+	rnd     *rand.Rand
+	rndMu   sync.Mutex
+	results chan result
+	dropped atomic.Int64
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.target, "target", "http://localhost:8080", "base URL of the service")
+	flag.StringVar(&opts.apiKey, "api-key", os.Getenv("API_KEY"), "operator API key for incident endpoints")
+	flag.IntVar(&opts.rps, "rps", 100, "target requests per second")
+	flag.DurationVar(&opts.duration, "duration", 30*time.Second, "test duration")
+	flag.IntVar(&opts.concurrency, "concurrency", 50, "max in-flight requests")
+	flag.Float64Var(&opts.crudRatio, "crud-ratio", 0.05, "share of ticks spent on incident CRUD (0..1)")
+	flag.IntVar(&opts.users, "users", 1000, "number of synthetic user ids")
+	flag.IntVar(&opts.incidents, "incidents", 20, "incidents seeded before the run and removed after it")
+	flag.Float64Var(&opts.centerLat, "lat", 55.7558, "latitude of the load area center")
+	flag.Float64Var(&opts.centerLng, "lng", 37.6173, "longitude of the load area center")
+	flag.Float64Var(&opts.spreadKm, "spread-km", 10, "radius of the load area in km")
+	flag.DurationVar(&opts.timeout, "timeout", 5*time.Second, "per-request timeout")
+	flag.Parse()
+
+	if opts.rps <= 0 || opts.concurrency <= 0 || opts.duration <= 0 {
+		log.Fatal("rps, concurrency and duration must be positive")
+	}
+	if opts.crudRatio < 0 || opts.crudRatio > 1 {
+		log.Fatal("crud-ratio must be within [0, 1]")
+	}
+	if opts.apiKey == "" && (opts.crudRatio > 0 || opts.incidents > 0) {
+		log.Fatal("api-key is required for incident CRUD and seeding")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	g := &loadgen{
+		opts: opts,
+		client: &http.Client{
+			Timeout: opts.timeout,
+			Transport: &http.Transport{
+				MaxIdleConns:        opts.concurrency,
+				MaxIdleConnsPerHost: opts.concurrency,
+			},
+		},
+		rnd:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		results: make(chan result, opts.concurrency*4),
+	}
+
+	seeded := g.seed(ctx)
+	log.Printf("seeded %d incidents, running %d rps for %s", len(seeded), opts.rps, opts.duration)
+
+	stats := make(map[string][]time.Duration)
+	failures := make(map[string]int)
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for r := range g.results {
+			stats[r.op] = append(stats[r.op], r.latency)
+			if r.failed {
+				failures[r.op]++
+			}
+		}
+	}()
+
+	started := time.Now()
+	g.run(ctx)
+	elapsed := time.Since(started)
+
+	// чистка не должна прерываться тем же сигналом, что остановил нагрузку
+	g.cleanup(context.Background(), seeded)
+
+	close(g.results)
+	<-collected
+
+	report(os.Stdout, stats, failures, g.dropped.Load(), elapsed)
+}
+
+// run раздает тики с заданной частотой пулу воркеров; тик, для которого нет
+// свободного воркера, считается пропущенным — генератор не ставит запросы в очередь,
+// чтобы перегрузка сервиса была видна, а не размазывалась по времени
+func (g *loadgen) run(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, g.opts.duration)
+	defer cancel()
+
+	ticks := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < g.opts.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range ticks {
+				if g.random() < g.opts.crudRatio {
+					g.incidentCRUD()
+				} else {
+					g.locationCheck()
+				}
+			}
+		}()
+	}
+
+	interval := time.Second / time.Duration(g.opts.rps)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-ticker.C:
+			select {
+			case ticks <- struct{}{}:
+			default:
+				g.dropped.Add(1)
+			}
+		}
+	}
+
+	close(ticks)
+	wg.Wait()
+}
+
+func (g *loadgen) locationCheck() {
+	lat, lng := g.randomPoint()
+	body := map[string]interface{}{
+		"user_id":   "loadgen-" + strconv.Itoa(g.randomInt(g.opts.users)),
+		"latitude":  lat,
+		"longitude": lng,
+	}
+
+	g.do("location_check", http.MethodPost, "/api/v1/location/check", body, nil)
+}
+
+// incidentCRUD проходит полный цикл create → get → patch → delete одного инцидента
+func (g *loadgen) incidentCRUD() {
+	var created struct {
+		IncidentID int `json:"incident_id"`
+	}
+	if !g.do("incident_create", http.MethodPost, "/api/v1/incidents", g.randomIncident(), &created) {
+		return
+	}
+
+	path := "/api/v1/incidents/" + strconv.Itoa(created.IncidentID)
+	g.do("incident_get", http.MethodGet, path, nil, nil)
+	g.do("incident_patch", http.MethodPatch, path, map[string]interface{}{"radius_m": 100 + g.randomInt(900)}, nil)
+	g.do("incident_delete", http.MethodDelete, path, nil, nil)
+}
+
+func (g *loadgen) seed(ctx context.Context) []int {
+	var ids []int
+	for i := 0; i < g.opts.incidents && ctx.Err() == nil; i++ {
+		var created struct {
+			IncidentID int `json:"incident_id"`
+		}
+		if err := g.request(http.MethodPost, "/api/v1/incidents", g.randomIncident(), &created); err != nil {
+			log.Fatalf("failed to seed incidents: %v", err)
+		}
+		ids = append(ids, created.IncidentID)
+	}
+	return ids
+}
+
+func (g *loadgen) cleanup(ctx context.Context, ids []int) {
+	for _, id := range ids {
+		if err := g.request(http.MethodDelete, "/api/v1/incidents/"+strconv.Itoa(id), nil, nil); err != nil {
+			log.Printf("failed to delete seeded incident %d: %v", id, err)
+		}
+	}
+}
+
+// do выполняет запрос и записывает его задержку под именем op
+func (g *loadgen) do(op, method, path string, body, dest interface{}) bool {
+	start := time.Now()
+	err := g.request(method, path, body, dest)
+	g.results <- result{op: op, latency: time.Since(start), failed: err != nil}
+	return err == nil
+}
+
+func (g *loadgen) request(method, path string, body, dest interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, g.opts.target+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.opts.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+g.opts.apiKey)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("%s %s: status %d", method, path, resp.StatusCode)
+	}
+
+	if dest == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
+
+func (g *loadgen) randomIncident() map[string]interface{} {
+	lat, lng := g.randomPoint()
+	return map[string]interface{}{
+		"name":      "loadgen incident",
+		"descr":     "synthetic incident created by cmd/loadgen",
+		"latitude":  lat,
+		"longitude": lng,
+		"radius_m":  100 + g.randomInt(2000),
+	}
+}
+
+// randomPoint возвращает точку, равномерно распределенную в круге spread-km вокруг центра
+func (g *loadgen) randomPoint() (float64, float64) {
+	const kmPerDegree = 111.32
+
+	distance := g.opts.spreadKm * math.Sqrt(g.random())
+	angle := 2 * math.Pi * g.random()
+
+	lat := g.opts.centerLat + distance*math.Cos(angle)/kmPerDegree
+	lng := g.opts.centerLng + distance*math.Sin(angle)/(kmPerDegree*math.Cos(g.opts.centerLat*math.Pi/180))
+	return lat, lng
+}
+
+func (g *loadgen) random() float64 {
+	g.rndMu.Lock()
+	defer g.rndMu.Unlock()
+	return g.rnd.Float64()
+}
+
+func (g *loadgen) randomInt(n int) int {
+	g.rndMu.Lock()
+	defer g.rndMu.Unlock()
+	return g.rnd.Intn(n)
+}
+
+func report(w io.Writer, stats map[string][]time.Duration, failures map[string]int, dropped int64, elapsed time.Duration) {
+	ops := make([]string, 0, len(stats))
+	for op := range stats {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "op\tcount\terrors\trps\tp50\tp90\tp95\tp99\tmax\t")
+	for _, op := range ops {
+		latencies := stats[op]
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t%s\t\n",
+			op,
+			len(latencies),
+			failures[op],
+			float64(len(latencies))/elapsed.Seconds(),
+			percentile(latencies, 0.50),
+			percentile(latencies, 0.90),
+			percentile(latencies, 0.95),
+			percentile(latencies, 0.99),
+			latencies[len(latencies)-1].Round(time.Microsecond),
+		)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nelapsed %s, dropped ticks %d (no free worker — raise -concurrency or the service is saturated)\n",
+		elapsed.Round(time.Millisecond), dropped)
+}
+
+// percentile ожидает отсортированный непустой срез
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx].Round(time.Microsecond)
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	// часовые пояса окон тишины пользователей не зависят от zoneinfo в образе
+	_ "time/tzdata"
 
 	"github.com/4otis/geonotify-service/config"
 	"github.com/4otis/geonotify-service/internal/app"
@@ -13,29 +17,44 @@ import (
 // @title geonotify-service API
 // @version 1.0
 // @description REST API сервис, который является частью системы геооповещений
-// @host localhost:8081
 // @BasePath /
 
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
+// @description Bearer {API_KEY} или Bearer {JWT} оператора
 
 func main() {
-	cfg := config.Load()
+	configPath := flag.String("config", "", "path to YAML config file (env variables take precedence)")
+	modeFlag := flag.String("mode", "all", "what to run: api (HTTP API only), worker (webhook delivery and background jobs) or all")
+	flag.Parse()
 
-	application, err := app.New(cfg)
+	mode, err := app.ParseMode(*modeFlag)
 	if err != nil {
-		log.Fatalf("failed to create application: %v", err)
+		log.Fatalf("%v", err)
 	}
 
-	err = application.Run()
+	cfg, err := config.Load(*configPath)
 	if err != nil {
-		log.Fatalf("error while running app: %v", err)
+		log.Fatalf("failed to load config: %v", err)
 	}
 
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
-	<-stop
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("%v", err)
+	}
 
+	application, err := app.New(config.NewHolder(*configPath, cfg), mode)
+	if err != nil {
+		log.Fatalf("failed to create application: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Stop выполняется и после ошибки Run, чтобы воркеры и соединения закрылись штатно
+	err = application.Run(ctx)
 	application.Stop()
+	if err != nil {
+		log.Fatalf("error while running app: %v", err)
+	}
 }
@@ -0,0 +1,35 @@
+// openapi выгружает спецификацию OpenAPI 3, построенную из swagger-документации сервиса,
+// для генерации клиентских SDK без запуска сервиса:
+//
+//	go run ./cmd/openapi -out docs/openapi.json
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	httphandler "github.com/4otis/geonotify-service/internal/handler/http"
+)
+
+func main() {
+	out := flag.String("out", "", "output file (stdout if empty)")
+	flag.Parse()
+
+	spec, err := httphandler.OpenAPISpec()
+	if err != nil {
+		log.Fatalf("failed to build openapi spec: %v", err)
+	}
+	spec = append(spec, '\n')
+
+	if *out == "" {
+		if _, err := os.Stdout.Write(spec); err != nil {
+			log.Fatalf("failed to write openapi spec: %v", err)
+		}
+		return
+	}
+
+	if err := os.WriteFile(*out, spec, 0o644); err != nil {
+		log.Fatalf("failed to write openapi spec: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+// seed загружает YAML-сценарий в запущенный geonotify-service: создает зоны и группы, задает настройки
+// алертов пользователей и проигрывает их маршруты проверками координат. Если ответы не совпали
+// с ожиданиями сценария, завершается с кодом 1 — так сценарии служат сквозными тестами.
+//
+//	go run ./cmd/seed -server http://localhost:8080 -token $API_KEY tests/scenarios/demo.yaml
+//	go run ./cmd/seed -cleanup tests/scenarios/demo.yaml
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/fixtures"
+	"github.com/4otis/geonotify-service/pkg/client"
+)
+
+func main() {
+	var (
+		server   = flag.String("server", envOr("GEONOTIFY_URL", "http://localhost:8080"), "base URL of the service")
+		token    = flag.String("token", envOr("GEONOTIFY_TOKEN", os.Getenv("API_KEY")), "operator API key or JWT")
+		timeout  = flag.Duration("timeout", 10*time.Second, "per-request timeout")
+		noReplay = flag.Bool("no-replay", false, "only seed incidents, groups and preferences, do not replay tracks")
+		cleanup  = flag.Bool("cleanup", false, "delete seeded incidents and groups when done")
+		verbose  = flag.Bool("v", false, "print every check, not only failed ones")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: seed [flags] SCENARIO.yaml (\"-\" for stdin)\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	scenario, err := fixtures.LoadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c, err := client.New(*server, client.Options{
+		HTTPClient: &http.Client{Timeout: *timeout},
+		Token:      *token,
+		UserAgent:  "geonotify-seed",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	loader := fixtures.NewLoader(c)
+	seeded, err := loader.Seed(ctx, scenario)
+	if err != nil {
+		log.Print(err)
+		if *cleanup {
+			removeSeeded(loader, seeded)
+		}
+		os.Exit(1)
+	}
+	log.Printf("scenario %q: seeded %d incidents and %d groups", scenario.Name, len(seeded.Incidents), len(seeded.Groups))
+
+	failed := 0
+	if !*noReplay && len(scenario.Tracks) > 0 {
+		report, err := loader.Replay(ctx, scenario, seeded)
+		if err != nil {
+			log.Printf("replay interrupted: %v", err)
+		}
+		failed = len(report.Failures())
+		printReport(os.Stdout, report, *verbose)
+		log.Printf("replayed %d checks of %d tracks, %d failed", len(report.Steps), len(scenario.Tracks), failed)
+	}
+
+	// чистка не должна прерываться тем же сигналом, что остановил прогон
+	if *cleanup {
+		removeSeeded(loader, seeded)
+	}
+
+	if failed > 0 || ctx.Err() != nil {
+		os.Exit(1)
+	}
+}
+
+func removeSeeded(loader *fixtures.Loader, seeded *fixtures.Seeded) {
+	if err := loader.Cleanup(context.Background(), seeded); err != nil {
+		log.Printf("cleanup: %v", err)
+		return
+	}
+	log.Printf("deleted %d incidents and %d groups", len(seeded.Incidents), len(seeded.Groups))
+}
+
+func printReport(w io.Writer, report fixtures.Report, verbose bool) {
+	if !verbose && len(report.Failures()) == 0 {
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TRACK\tPOINT\tUSER\tLAT\tLNG\tINCIDENTS\tEXPECTED\tRESULT")
+	for _, step := range report.Steps {
+		if !verbose && !step.Failed() {
+			continue
+		}
+
+		expected, result := "-", "ok"
+		if step.Expected != nil {
+			expected = keyList(*step.Expected)
+		}
+		switch {
+		case step.Err != nil:
+			result = step.Err.Error()
+		case step.Failed():
+			result = "MISMATCH"
+		}
+
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%.6f\t%.6f\t%s\t%s\t%s\n",
+			step.Track, step.Point, step.User, step.Latitude, step.Longitude, keyList(step.Incidents), expected, result)
+	}
+	tw.Flush()
+}
+
+func keyList(keys []string) string {
+	if len(keys) == 0 {
+		return "none"
+	}
+	return strings.Join(keys, ",")
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
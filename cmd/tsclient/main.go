@@ -0,0 +1,485 @@
+// tsclient генерирует TypeScript-клиент по спецификации OpenAPI 3 сервиса:
+// интерфейсы для схем и класс GeonotifyClient с методом на каждую операцию (по operationId).
+//
+//	go run ./cmd/tsclient -out clients/typescript/src/index.ts
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	httphandler "github.com/4otis/geonotify-service/internal/handler/http"
+)
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Enum                 []any              `json:"enum"`
+	Items                *schema            `json:"items"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Required             []string           `json:"required"`
+	AllOf                []*schema          `json:"allOf"`
+	Nullable             bool               `json:"nullable"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *schema `json:"schema"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type operation struct {
+	OperationID string      `json:"operationId"`
+	Summary     string      `json:"summary"`
+	Description string      `json:"description"`
+	Parameters  []parameter `json:"parameters"`
+	RequestBody *struct {
+		Required bool                 `json:"required"`
+		Content  map[string]mediaType `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]mediaType `json:"content"`
+	} `json:"responses"`
+	Security []map[string][]string `json:"security"`
+}
+
+type document struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+var methodOrder = []string{"get", "post", "put", "patch", "delete"}
+
+func main() {
+	out := flag.String("out", "", "output file (stdout if empty)")
+	flag.Parse()
+
+	spec, err := httphandler.OpenAPISpec()
+	if err != nil {
+		log.Fatalf("failed to build openapi spec: %v", err)
+	}
+
+	var doc document
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		log.Fatalf("failed to parse openapi spec: %v", err)
+	}
+
+	code, err := generate(&doc)
+	if err != nil {
+		log.Fatalf("failed to generate client: %v", err)
+	}
+
+	if *out == "" {
+		fmt.Print(code)
+		return
+	}
+	if err := os.WriteFile(*out, []byte(code), 0o644); err != nil {
+		log.Fatalf("failed to write client: %v", err)
+	}
+}
+
+type generator struct {
+	doc   *document
+	names map[string]string
+	b     strings.Builder
+}
+
+func generate(doc *document) (string, error) {
+	g := &generator{doc: doc, names: typeNames(doc.Components.Schemas)}
+
+	g.printf("// Code generated by go run ./cmd/tsclient; DO NOT EDIT.\n")
+	g.printf("// %s %s\n\n", doc.Info.Title, doc.Info.Version)
+
+	schemaKeys := sortedKeys(doc.Components.Schemas)
+	sort.Slice(schemaKeys, func(i, j int) bool {
+		return g.names[schemaKeys[i]] < g.names[schemaKeys[j]]
+	})
+	for _, key := range schemaKeys {
+		g.writeInterface(g.names[key], doc.Components.Schemas[key])
+	}
+
+	g.printf("%s\n", runtime)
+
+	g.printf("export class GeonotifyClient extends BaseClient {\n")
+	for _, path := range sortedKeys(doc.Paths) {
+		item := doc.Paths[path]
+		for _, method := range methodOrder {
+			op, ok := item[method]
+			if !ok {
+				continue
+			}
+			if op.OperationID == "" {
+				return "", fmt.Errorf("%s %s: operationId is required", strings.ToUpper(method), path)
+			}
+			if err := g.writeOperation(method, path, op); err != nil {
+				return "", fmt.Errorf("%s: %w", op.OperationID, err)
+			}
+		}
+	}
+	code := strings.TrimSuffix(g.b.String(), "\n") + "}\n"
+	return code, nil
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.b, format, args...)
+}
+
+func (g *generator) writeInterface(name string, s *schema) {
+	g.writeDoc("", s.Description)
+	if s.Type != "object" || len(s.Properties) == 0 {
+		g.printf("export type %s = %s;\n\n", name, g.tsType(s, ""))
+		return
+	}
+
+	g.printf("export interface %s %s\n\n", name, g.objectType(s, ""))
+}
+
+func (g *generator) objectType(s *schema, indent string) string {
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, prop := range sortedKeys(s.Properties) {
+		ps := s.Properties[prop]
+		if ps.Description != "" {
+			fmt.Fprintf(&b, "%s  /** %s */\n", indent, docText(ps.Description))
+		}
+		optional := "?"
+		if required[prop] {
+			optional = ""
+		}
+		fmt.Fprintf(&b, "%s  %s%s: %s;\n", indent, propName(prop), optional, g.tsType(ps, indent+"  "))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+func (g *generator) tsType(s *schema, indent string) string {
+	if s == nil {
+		return "unknown"
+	}
+
+	var t string
+	switch {
+	case s.Ref != "":
+		t = g.names[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	case len(s.AllOf) > 0:
+		parts := make([]string, len(s.AllOf))
+		for i, sub := range s.AllOf {
+			parts[i] = g.tsType(sub, indent)
+		}
+		t = strings.Join(parts, " & ")
+	case len(s.Enum) > 0:
+		parts := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			literal, _ := json.Marshal(v)
+			parts[i] = string(literal)
+		}
+		t = strings.Join(parts, " | ")
+	case s.Type == "string" && s.Format == "binary":
+		t = "Blob"
+	case s.Type == "string":
+		t = "string"
+	case s.Type == "integer", s.Type == "number":
+		t = "number"
+	case s.Type == "boolean":
+		t = "boolean"
+	case s.Type == "array":
+		item := g.tsType(s.Items, indent)
+		if strings.ContainsAny(item, "|&") {
+			item = "(" + item + ")"
+		}
+		t = item + "[]"
+	case len(s.Properties) > 0:
+		t = g.objectType(s, indent)
+	case s.AdditionalProperties != nil:
+		t = "Record<string, " + g.tsType(s.AdditionalProperties, indent) + ">"
+	default:
+		t = "Record<string, unknown>"
+	}
+
+	if s.Nullable {
+		t += " | null"
+	}
+	return t
+}
+
+type tsParam struct {
+	name     string
+	typ      string
+	optional bool
+}
+
+func (g *generator) writeOperation(method, path string, op *operation) error {
+	var (
+		params    []tsParam
+		pathExpr  = "\"" + path + "\""
+		queryKeys []parameter
+	)
+
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			name := camelCase(p.Name)
+			params = append(params, tsParam{name: name, typ: g.tsType(p.Schema, "  ")})
+			pathExpr = strings.Replace(pathExpr, "{"+p.Name+"}",
+				"\" + encodeURIComponent(String("+name+")) + \"", 1)
+		case "query":
+			queryKeys = append(queryKeys, p)
+		case "header":
+			// заголовок Authorization задается токеном клиента
+		default:
+			return fmt.Errorf("unsupported parameter location %q", p.In)
+		}
+	}
+	pathExpr = strings.TrimSuffix(pathExpr, " + \"\"")
+
+	var bodyKind string
+	if op.RequestBody != nil {
+		contentType, media := firstMedia(op.RequestBody.Content)
+		typ := g.tsType(media.Schema, "  ")
+		switch contentType {
+		case "application/json":
+			bodyKind = "body"
+		case "multipart/form-data":
+			bodyKind = "form"
+		default:
+			return fmt.Errorf("unsupported request content type %q", contentType)
+		}
+		params = append(params, tsParam{name: bodyKind, typ: typ, optional: !op.RequestBody.Required})
+	}
+
+	if len(queryKeys) > 0 {
+		var b strings.Builder
+		b.WriteString("{ ")
+		for _, p := range queryKeys {
+			optional := "?"
+			if p.Required {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "%s%s: %s; ", propName(p.Name), optional, g.tsType(p.Schema, "  "))
+		}
+		b.WriteString("}")
+		params = append(params, tsParam{name: "query", typ: b.String(), optional: true})
+	}
+
+	result, stream, accept := g.responses(op)
+
+	var doc []string
+	if op.Summary != "" {
+		doc = append(doc, op.Summary)
+	}
+	if op.Description != "" {
+		doc = append(doc, op.Description)
+	}
+	g.writeDoc("  ", strings.Join(doc, "\n"))
+
+	signature := make([]string, 0, len(params)+2)
+	for _, p := range params {
+		optional := ""
+		if p.optional {
+			optional = "?"
+		}
+		signature = append(signature, p.name+optional+": "+p.typ)
+	}
+
+	opts := []string{}
+	if len(queryKeys) > 0 {
+		opts = append(opts, "query")
+	}
+	if bodyKind != "" {
+		opts = append(opts, bodyKind)
+	}
+	if len(accept) > 0 {
+		opts = append(opts, "accept: ["+strings.Join(accept, ", ")+"]")
+	}
+	init := ""
+	if len(opts) > 0 {
+		init = ", { " + strings.Join(opts, ", ") + " }"
+	}
+
+	if stream {
+		signature = append(signature, "onEvent: (event: "+result+") => void", "signal?: AbortSignal")
+		g.printf("  %s(%s): Promise<void> {\n", op.OperationID, strings.Join(signature, ", "))
+		g.printf("    return this.stream(%s, onEvent, signal);\n", pathExpr)
+		g.printf("  }\n\n")
+		return nil
+	}
+
+	g.printf("  %s(%s): Promise<%s> {\n", op.OperationID, strings.Join(signature, ", "), result)
+	g.printf("    return this.request<%s>(%q, %s%s);\n", result, strings.ToUpper(method), pathExpr, init)
+	g.printf("  }\n\n")
+	return nil
+}
+
+// responses возвращает тип успешного ответа, признак потока SSE и коды ошибок,
+// которые отдают ту же схему, что и успех (503 у /readyz), — они не считаются ошибкой
+func (g *generator) responses(op *operation) (string, bool, []string) {
+	var (
+		result  = "void"
+		stream  bool
+		success *schema
+	)
+	for _, code := range sortedKeys(op.Responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		contentType, media := firstMedia(op.Responses[code].Content)
+		if media.Schema == nil {
+			continue
+		}
+		success = media.Schema
+		result = g.tsType(media.Schema, "  ")
+		stream = contentType == "text/event-stream"
+		break
+	}
+
+	var accept []string
+	if success != nil && success.Ref != "" {
+		for _, code := range sortedKeys(op.Responses) {
+			if strings.HasPrefix(code, "2") {
+				continue
+			}
+			_, media := firstMedia(op.Responses[code].Content)
+			if media.Schema != nil && media.Schema.Ref == success.Ref {
+				accept = append(accept, code)
+			}
+		}
+	}
+	return result, stream, accept
+}
+
+func (g *generator) writeDoc(indent, text string) {
+	if text == "" {
+		return
+	}
+	lines := strings.Split(docText(text), "\n")
+	if len(lines) == 1 {
+		g.printf("%s/** %s */\n", indent, lines[0])
+		return
+	}
+	g.printf("%s/**\n", indent)
+	for _, l := range lines {
+		g.printf("%s * %s\n", indent, l)
+	}
+	g.printf("%s */\n", indent)
+}
+
+func firstMedia(content map[string]mediaType) (string, mediaType) {
+	for _, ct := range []string{"application/json", "multipart/form-data", "text/event-stream"} {
+		if m, ok := content[ct]; ok {
+			return ct, m
+		}
+	}
+	for _, ct := range sortedKeys(content) {
+		return ct, content[ct]
+	}
+	return "", mediaType{}
+}
+
+// typeNames дает схемам короткие имена: dto_req.LoginRequest -> LoginRequest.
+// При совпадении коротких имен добавляется значимая часть пакета: dto_v2_resp.X -> V2X
+func typeNames(schemas map[string]*schema) map[string]string {
+	short := make(map[string][]string)
+	for key := range schemas {
+		name := key[strings.LastIndex(key, ".")+1:]
+		short[name] = append(short[name], key)
+	}
+
+	names := make(map[string]string, len(schemas))
+	for name, keys := range short {
+		if len(keys) == 1 {
+			names[keys[0]] = pascalCase(name)
+			continue
+		}
+		for _, key := range keys {
+			pkg, _, _ := strings.Cut(key, ".")
+			var prefix strings.Builder
+			for _, part := range strings.Split(pkg, "_") {
+				switch part {
+				case "dto", "req", "resp", "handler", "http":
+					continue
+				}
+				prefix.WriteString(pascalCase(part))
+			}
+			names[key] = prefix.String() + pascalCase(name)
+		}
+	}
+	return names
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func propName(name string) string {
+	if identifier.MatchString(name) {
+		return name
+	}
+	quoted, _ := json.Marshal(name)
+	return string(quoted)
+}
+
+func camelCase(s string) string {
+	p := pascalCase(s)
+	if p == "" {
+		return p
+	}
+	r := []rune(p)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func pascalCase(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if r == '_' || r == '-' || r == '.' {
+			upper = true
+			continue
+		}
+		if upper {
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func docText(s string) string {
+	return strings.ReplaceAll(strings.TrimSpace(s), "*/", "*\\/")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
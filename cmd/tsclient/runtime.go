@@ -0,0 +1,189 @@
+package main
+
+// runtime — общая часть клиента: транспорт, повторы, ошибки и чтение SSE.
+// В исходнике нет обратных кавычек, чтобы он помещался в raw-строку Go
+const runtime = `export interface ClientOptions {
+  /** Адрес сервиса, например http://localhost:8080 */
+  baseUrl: string;
+  /** API-ключ или JWT оператора, передается как Bearer */
+  token?: string;
+  /** Повторы идемпотентных запросов (GET, PUT, DELETE) при сетевой ошибке, 429 и 5xx; по умолчанию 2 */
+  maxRetries?: number;
+  /** Пауза перед первым повтором в миллисекундах, дальше удваивается; по умолчанию 200 */
+  retryDelayMs?: number;
+  fetch?: typeof fetch;
+}
+
+/** Ответ сервиса с кодом не 2xx */
+export class ApiError extends Error {
+  readonly status: number;
+  readonly body: ErrorResponse;
+
+  constructor(status: number, body: ErrorResponse) {
+    super(body.message ? (body.error ?? "") + ": " + body.message : body.error ?? String(status));
+    this.name = "ApiError";
+    this.status = status;
+    this.body = body;
+  }
+}
+
+export interface RequestOptions {
+  query?: Record<string, string | number | boolean | undefined>;
+  body?: unknown;
+  form?: Record<string, Blob | string>;
+  /** коды ответа, кроме 2xx, тело которых возвращается как результат */
+  accept?: number[];
+  signal?: AbortSignal;
+}
+
+const idempotentMethods = new Set(["GET", "HEAD", "PUT", "DELETE"]);
+const maxRetryAfterMs = 30000;
+
+export class BaseClient {
+  protected readonly options: ClientOptions & { maxRetries: number; retryDelayMs: number };
+
+  constructor(options: ClientOptions) {
+    this.options = {
+      maxRetries: 2,
+      retryDelayMs: 200,
+      ...options,
+      baseUrl: options.baseUrl.replace(/\/+$/, ""),
+    };
+  }
+
+  /** Копия клиента с другим токеном, например после login */
+  withToken(token: string): this {
+    const ctor = this.constructor as new (options: ClientOptions) => this;
+    return new ctor({ ...this.options, token });
+  }
+
+  protected async request<T>(method: string, path: string, init: RequestOptions = {}): Promise<T> {
+    const response = await this.send(method, path, init, "application/json");
+    const text = await response.text();
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+
+  protected async stream<T>(path: string, onEvent: (event: T) => void, signal?: AbortSignal): Promise<void> {
+    const response = await this.send("GET", path, { signal }, "text/event-stream");
+    if (!response.body) {
+      throw new Error("response streaming is not supported");
+    }
+
+    const reader = response.body.pipeThrough(new TextDecoderStream()).getReader();
+    let buffer = "";
+    for (;;) {
+      const { value, done } = await reader.read();
+      if (done) {
+        return;
+      }
+      buffer += value.replace(/\r\n/g, "\n");
+
+      let end: number;
+      while ((end = buffer.indexOf("\n\n")) >= 0) {
+        const chunk = buffer.slice(0, end);
+        buffer = buffer.slice(end + 2);
+
+        const data: string[] = [];
+        for (const line of chunk.split("\n")) {
+          if (line.startsWith("data:")) {
+            data.push(line.slice(5).replace(/^ /, ""));
+          }
+        }
+        if (data.length > 0) {
+          onEvent(JSON.parse(data.join("\n")) as T);
+        }
+      }
+    }
+  }
+
+  private async send(method: string, path: string, init: RequestOptions, accept: string): Promise<Response> {
+    const url = new URL(this.options.baseUrl + path);
+    for (const [key, value] of Object.entries(init.query ?? {})) {
+      if (value !== undefined) {
+        url.searchParams.set(key, String(value));
+      }
+    }
+
+    const headers: Record<string, string> = { Accept: accept };
+    if (this.options.token) {
+      headers.Authorization = "Bearer " + this.options.token;
+    }
+
+    let body: BodyInit | undefined;
+    if (init.form) {
+      const form = new FormData();
+      for (const [key, value] of Object.entries(init.form)) {
+        form.append(key, value);
+      }
+      body = form;
+    } else if (init.body !== undefined) {
+      headers["Content-Type"] = "application/json";
+      body = JSON.stringify(init.body);
+    }
+
+    const doFetch = this.options.fetch ?? fetch;
+    const attempts = 1 + (idempotentMethods.has(method) ? this.options.maxRetries : 0);
+    let delay = this.options.retryDelayMs;
+
+    for (let attempt = 1; ; attempt++) {
+      let wait = delay;
+      try {
+        const response = await doFetch(url, { method, headers, body, signal: init.signal });
+        if (response.ok || init.accept?.includes(response.status)) {
+          return response;
+        }
+
+        const error = await toApiError(response);
+        if (attempt >= attempts || !(response.status === 429 || response.status >= 500)) {
+          throw error;
+        }
+        wait = retryAfterMs(response) ?? delay;
+      } catch (err) {
+        if (err instanceof ApiError || init.signal?.aborted || attempt >= attempts) {
+          throw err;
+        }
+      }
+
+      await sleep(wait, init.signal);
+      delay *= 2;
+    }
+  }
+}
+
+async function toApiError(response: Response): Promise<ApiError> {
+  const text = await response.text();
+  let body: ErrorResponse | undefined;
+  try {
+    body = JSON.parse(text) as ErrorResponse;
+  } catch {
+    body = undefined;
+  }
+  if (!body || !body.error) {
+    body = { error: response.statusText, message: text };
+  }
+  return new ApiError(response.status, body);
+}
+
+function retryAfterMs(response: Response): number | undefined {
+  const value = response.headers.get("Retry-After");
+  if (!value) {
+    return undefined;
+  }
+  const seconds = Number(value);
+  const ms = Number.isNaN(seconds) ? Date.parse(value) - Date.now() : seconds * 1000;
+  if (Number.isNaN(ms)) {
+    return undefined;
+  }
+  return Math.min(Math.max(ms, 0), maxRetryAfterMs);
+}
+
+function sleep(ms: number, signal?: AbortSignal): Promise<void> {
+  return new Promise((resolve, reject) => {
+    const timer = setTimeout(resolve, ms);
+    signal?.addEventListener("abort", () => {
+      clearTimeout(timer);
+      reject(signal?.reason);
+    }, { once: true });
+  });
+}
+`
@@ -5,41 +5,780 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
+	"github.com/4otis/geonotify-service/internal/entity"
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v2"
 )
 
 type Config struct {
-	HTTPPort               string
-	DBURL                  string
-	RedisURL               string
-	WebhookURL             string
-	APIKey                 string
-	LogLevel               string
-	StatsTimeWindowMinutes int
-	MaxRetries             int
-	RetryDelaySeconds      int
-	CacheTTLMinutes        int
-}
-
-func Load() *Config {
+	Env                        string `yaml:"env"`
+	HTTPPort                   string `yaml:"http_port"`
+	DBURL                      string `yaml:"db_url"`
+	RedisURL                   string `yaml:"redis_url"`
+	WebhookURL                 string `yaml:"webhook_url"`
+	WebhookSecret              string `yaml:"webhook_secret"`
+	APIKey                     string `yaml:"api_key"`
+	LogLevel                   string `yaml:"log_level"`
+	StatsTimeWindowMinutes     int    `yaml:"stats_time_window_minutes"`
+	MaxRetries                 int    `yaml:"webhook_max_retries"`
+	RetryDelaySeconds          int    `yaml:"webhook_retry_delay_seconds"`
+	WebhookPollIntervalSeconds int    `yaml:"webhook_poll_interval_seconds"`
+	WebhookLeaseSeconds        int    `yaml:"webhook_lease_seconds"`
+	CacheTTLMinutes            int    `yaml:"cache_ttl_minutes"`
+	CacheBackend               string `yaml:"cache_backend"`
+	QueueBackend               string `yaml:"queue_backend"`
+	AMQPURL                    string `yaml:"amqp_url"`
+	AMQPQueue                  string `yaml:"amqp_queue"`
+
+	CheckPartitionPremakeDays   int `yaml:"checks_partition_premake_days"`
+	CheckRetentionDays          int `yaml:"checks_retention_days"`
+	PartitionMaintenanceMinutes int `yaml:"partition_maintenance_interval_minutes"`
+	// CheckEncryptionKeys — ключи шифрования user_id и координат проверок вида "ID:base64 32 байт": первый шифрует
+	// новые проверки, остальные нужны до перешифровки старых. CheckEncryptionKeysFile — те же ключи построчно,
+	// например секрет из KMS, смонтированный в файл; он заменяет CheckEncryptionKeys. Без ключей проверки открытые
+	CheckEncryptionKeys     []string `yaml:"checks_encryption_keys"`
+	CheckEncryptionKeysFile string   `yaml:"checks_encryption_keys_file"`
+	// CheckReencryptIntervalSeconds — как часто открытые проверки и проверки под прежними ключами перешифровываются
+	CheckReencryptIntervalSeconds int `yaml:"checks_reencrypt_interval_seconds"`
+	// CheckLocationPrivacy огрубляет хранимые координаты проверок: round — до CheckLocationDecimals знаков,
+	// geohash — до центра ячейки геохеша длиной CheckLocationGeohashLength. Пустой — координаты хранятся точными
+	CheckLocationPrivacy       string `yaml:"checks_location_privacy"`
+	CheckLocationDecimals      int    `yaml:"checks_location_decimals"`
+	CheckLocationGeohashLength int    `yaml:"checks_location_geohash_length"`
+
+	ScheduleIntervalSeconds int `yaml:"schedule_interval_seconds"`
+	// ErasureIntervalSeconds — как часто выполняются подтвержденные заявки на удаление данных пользователей
+	ErasureIntervalSeconds int `yaml:"erasure_interval_seconds"`
+
+	WebhookTLSCertFile   string                       `yaml:"webhook_tls_cert_file"`
+	WebhookTLSKeyFile    string                       `yaml:"webhook_tls_key_file"`
+	WebhookTLSCAFile     string                       `yaml:"webhook_tls_ca_file"`
+	WebhookTLSMinVersion string                       `yaml:"webhook_tls_min_version"`
+	WebhookProxyURL      string                       `yaml:"webhook_proxy_url"`
+	WebhookHeaders       map[string]map[string]string `yaml:"webhook_headers"`
+	// WebhookURL, WebhookSecret и WebhookEvents задают первого получателя, пока в базе нет получателей;
+	// дальше получатели управляются через /api/v1/webhook-endpoints. "*" в WebhookEvents — все события
+	WebhookEvents []string `yaml:"webhook_events"`
+	// WebhookMaxIncidents — сколько найденных зон попадает в location.alert, остальные только считаются.
+	// Payload больше WebhookMaxPayloadKB урезается: сначала без геометрии полигонов, затем до ID зон
+	WebhookMaxIncidents int `yaml:"webhook_max_incidents"`
+	WebhookMaxPayloadKB int `yaml:"webhook_max_payload_kb"`
+	// WebhookIncludeComments добавляет в события зон и location.alert последние комментарии зон
+	WebhookIncludeComments bool `yaml:"webhook_include_comments"`
+
+	// S3Endpoint пустой — вложения инцидентов отключены
+	S3Endpoint                 string `yaml:"s3_endpoint"`
+	S3AccessKey                string `yaml:"s3_access_key"`
+	S3SecretKey                string `yaml:"s3_secret_key"`
+	S3Bucket                   string `yaml:"s3_bucket"`
+	S3Region                   string `yaml:"s3_region"`
+	S3UseSSL                   bool   `yaml:"s3_use_ssl"`
+	AttachmentMaxSizeMB        int    `yaml:"attachments_max_size_mb"`
+	AttachmentURLExpiryMinutes int    `yaml:"attachments_url_expiry_minutes"`
+	// DataExport* — выгрузки данных пользователей, их архивы хранятся в том же бакете
+	DataExportIntervalSeconds  int `yaml:"data_export_interval_seconds"`
+	DataExportRetentionHours   int `yaml:"data_export_retention_hours"`
+	DataExportURLExpiryMinutes int `yaml:"data_export_url_expiry_minutes"`
+
+	// GeocoderProvider пустой — создание инцидентов по адресу отключено
+	GeocoderProvider  string `yaml:"geocoder_provider"`
+	GeocoderURL       string `yaml:"geocoder_url"`
+	GeocoderAPIKey    string `yaml:"geocoder_api_key"`
+	GeocoderUserAgent string `yaml:"geocoder_user_agent"`
+
+	ReverseGeocodeCacheTTLMinutes int `yaml:"reverse_geocode_cache_ttl_minutes"`
+
+	// RegionAllowlistFile и RegionDenylistFile — GeoJSON-границы области работы сервиса, пустые — без ограничений
+	RegionAllowlistFile string `yaml:"region_allowlist_file"`
+	RegionDenylistFile  string `yaml:"region_denylist_file"`
+
+	// JWTSecret пустой — вход операторов по паролю отключен, работает только API-ключ
+	JWTSecret     string `yaml:"jwt_secret"`
+	JWTTTLMinutes int    `yaml:"jwt_ttl_minutes"`
+
+	// IncidentReviewRequired — черновик публикует оператор, который его не создавал и не правил
+	IncidentReviewRequired bool `yaml:"incident_review_required"`
+
+	// IncidentOverlapCheck — новая зона не создается, если она и активная зона перекрываются хотя бы
+	// на IncidentOverlapThresholdPercent площади меньшей из них; ?check_overlap в запросе важнее
+	IncidentOverlapCheck            bool `yaml:"incident_overlap_check"`
+	IncidentOverlapThresholdPercent int  `yaml:"incident_overlap_threshold_percent"`
+
+	// IncidentDefaultLocale — язык исходных названий и описаний зон: клиент, который предпочитает его,
+	// получает исходный текст, а не перевод
+	IncidentDefaultLocale string `yaml:"incident_default_locale"`
+
+	// FeedEnabled открывает публичную ленту действующих зон /feeds/incidents (Atom и CAP) без авторизации.
+	// FeedBaseURL — внешний адрес сервиса для ссылок ленты, пустой — адрес из запроса.
+	// FeedSender — идентификатор отправителя в сообщениях CAP
+	FeedEnabled bool   `yaml:"feed_enabled"`
+	FeedBaseURL string `yaml:"feed_base_url"`
+	FeedTitle   string `yaml:"feed_title"`
+	FeedSender  string `yaml:"feed_sender"`
+
+	// PublicAPIEnabled открывает без авторизации GET /api/v1/public/incidents — действующие зоны без служебных полей.
+	// PublicAPIRateLimitPerMinute — лимит запросов с одного IP в минуту, PublicAPICacheSeconds — сколько кэшируется ответ
+	PublicAPIEnabled            bool `yaml:"public_api_enabled"`
+	PublicAPIRateLimitPerMinute int  `yaml:"public_api_rate_limit_per_minute"`
+	PublicAPICacheSeconds       int  `yaml:"public_api_cache_seconds"`
+
+	// FeedImportSources — внешние ленты оповещений (имя -> URL документа CAP, ленты Atom или RSS с GeoRSS),
+	// которые опрашиваются раз в FeedImportIntervalSeconds; пустой — импорт выключен.
+	// FeedImportPublish — публиковать новые зоны сразу, иначе они ждут оператора черновиками.
+	// FeedImportPointRadiusM — радиус зоны для оповещений, заданных только точкой
+	FeedImportSources         map[string]string `yaml:"feed_import_sources"`
+	FeedImportIntervalSeconds int               `yaml:"feed_import_interval_seconds"`
+	FeedImportPublish         bool              `yaml:"feed_import_publish"`
+	FeedImportPointRadiusM    int               `yaml:"feed_import_point_radius_m"`
+
+	// WeatherProvider пустой — погодные предупреждения не импортируются, иначе nws или openweather.
+	// WeatherLocations — места "широта,долгота", для которых запрашиваются предупреждения.
+	// WeatherSeverityMap сопоставляет названию явления или уровню опасности провайдера уровень опасности зоны,
+	// предупреждения ниже WeatherMinSeverity пропускаются. WeatherRadiusM — радиус зоны для предупреждений без геометрии
+	WeatherProvider        string            `yaml:"weather_provider"`
+	WeatherURL             string            `yaml:"weather_url"`
+	WeatherAPIKey          string            `yaml:"weather_api_key"`
+	WeatherUserAgent       string            `yaml:"weather_user_agent"`
+	WeatherLocations       []string          `yaml:"weather_locations"`
+	WeatherIntervalSeconds int               `yaml:"weather_interval_seconds"`
+	WeatherRadiusM         int               `yaml:"weather_radius_m"`
+	WeatherSeverityMap     map[string]string `yaml:"weather_severity_map"`
+	WeatherMinSeverity     string            `yaml:"weather_min_severity"`
+	WeatherPublish         bool              `yaml:"weather_publish"`
+
+	// AuthPolicies — политики доступа по маршрутам ("[МЕТОД ]префикс" -> public, api-key, jwt или either),
+	// дополняют встроенные. OperatorIPAllowlist пустой — операторские маршруты доступны с любого адреса
+	AuthPolicies        map[string]string `yaml:"auth_policies"`
+	OperatorIPAllowlist []string          `yaml:"operator_ip_allowlist"`
+	TrustedProxies      []string          `yaml:"trusted_proxies"`
+
+	// APIKeys — API-ключи клиентов по имени, с теми же правами, что SECRET_API_KEY.
+	// UsageMeteringEnabled — учет запросов каждого ключа (SECRET_API_KEY — под именем default) в Redis с переносом
+	// в БД раз в UsageFlushSeconds; квоты ключей проверяются только при включенном учете
+	APIKeys              map[string]APIKey `yaml:"api_keys"`
+	UsageMeteringEnabled bool              `yaml:"usage_metering_enabled"`
+	UsageFlushSeconds    int               `yaml:"usage_flush_seconds"`
+
+	// CORSAllowedOrigins пустой — CORS-заголовки не отдаются и браузеры с других доменов не получают ответы
+	CORSAllowedOrigins   []string `yaml:"cors_allowed_origins"`
+	CORSAllowedMethods   []string `yaml:"cors_allowed_methods"`
+	CORSAllowedHeaders   []string `yaml:"cors_allowed_headers"`
+	CORSExposedHeaders   []string `yaml:"cors_exposed_headers"`
+	CORSAllowCredentials bool     `yaml:"cors_allow_credentials"`
+	CORSMaxAgeSeconds    int      `yaml:"cors_max_age_seconds"`
+
+	// OIDCIssuer пустой — токены внешнего провайдера не принимаются
+	OIDCIssuer             string `yaml:"oidc_issuer"`
+	OIDCAudience           string `yaml:"oidc_audience"`
+	OIDCJWKSURL            string `yaml:"oidc_jwks_url"`
+	OIDCUsernameClaim      string `yaml:"oidc_username_claim"`
+	OIDCJWKSRefreshMinutes int    `yaml:"oidc_jwks_refresh_minutes"`
+
+	// PredictionHorizonSeconds — на сколько секунд вперед проецируется путь по скорости и курсу, 0 — прогноз отключен
+	PredictionHorizonSeconds int `yaml:"prediction_horizon_seconds"`
+
+	CheckEventsEnabled      bool   `yaml:"check_events_enabled"`
+	CheckEventsStream       string `yaml:"check_events_stream"`
+	CheckEventsStreamMaxLen int    `yaml:"check_events_stream_max_len"`
+	EventRelayIntervalMs    int    `yaml:"event_relay_interval_ms"`
+	EventRelayBatchSize     int    `yaml:"event_relay_batch_size"`
+
+	// ApprovalEventsEnabled — заявки на публикацию критических зон уходят в Redis Stream для оповещения одобряющих
+	ApprovalEventsEnabled bool   `yaml:"approval_events_enabled"`
+	ApprovalEventsStream  string `yaml:"approval_events_stream"`
+
+	LocationStreamEnabled       bool   `yaml:"location_stream_enabled"`
+	LocationStream              string `yaml:"location_stream"`
+	LocationStreamGroup         string `yaml:"location_stream_group"`
+	LocationConsumerConcurrency int    `yaml:"location_consumer_concurrency"`
+	LocationStreamBatchSize     int    `yaml:"location_stream_batch_size"`
+
+	// DBReplicaURL — read-only реплика для списков, статистики и активных зон; пустой — все запросы в основную БД.
+	// После ошибки соединения реплика пропускается DBReplicaRetrySeconds.
+	// DBReplicaMaxLagSeconds — через сколько после изменения зон кэш активных зон сбрасывается повторно
+	DBReplicaURL           string `yaml:"db_replica_url"`
+	DBReplicaRetrySeconds  int    `yaml:"db_replica_retry_seconds"`
+	DBReplicaMaxLagSeconds int    `yaml:"db_replica_max_lag_seconds"`
+	// DBSlowQueryMs — запросы дольше порога пишутся в лог с нормализованным SQL; 0 — не писать
+	DBSlowQueryMs int `yaml:"db_slow_query_ms"`
+	// DBPool* — пул соединений; у основной БД и у реплики свои пулы с этими настройками. 0 — значение из URL
+	// (pool_max_conns, pool_min_conns, ...) или по умолчанию pgx: не больше max(4, число CPU) соединений, без
+	// минимума, соединение живет час и простаивает не больше 30 минут, простаивающие проверяются раз в минуту
+	DBPoolMaxConns               int `yaml:"db_pool_max_conns"`
+	DBPoolMinConns               int `yaml:"db_pool_min_conns"`
+	DBPoolMaxConnLifetimeSeconds int `yaml:"db_pool_max_conn_lifetime_seconds"`
+	DBPoolMaxConnIdleSeconds     int `yaml:"db_pool_max_conn_idle_seconds"`
+	DBPoolHealthCheckSeconds     int `yaml:"db_pool_health_check_seconds"`
+	// RedisPool* — пул соединений Redis. 0 — значение из REDIS_URL (pool_size, min_idle_conns, ...) или по умолчанию
+	// go-redis: 10 соединений на CPU, без минимума простаивающих, свободное соединение ждется 4 секунды,
+	// время жизни соединения не ограничено
+	RedisPoolSize               int `yaml:"redis_pool_size"`
+	RedisMinIdleConns           int `yaml:"redis_min_idle_conns"`
+	RedisPoolTimeoutSeconds     int `yaml:"redis_pool_timeout_seconds"`
+	RedisConnMaxLifetimeSeconds int `yaml:"redis_conn_max_lifetime_seconds"`
+	// WorkerLocksEnabled — периодические задачи (партиции, расписания, импорт, релей событий, опрос outbox)
+	// выполняются под advisory-блокировками Postgres, то есть одной репликой из всех
+	WorkerLocksEnabled bool `yaml:"worker_locks_enabled"`
+
+	// CheckBatchEnabled — проверки пишутся в БД пачками через COPY раз в CheckBatchFlushMs или по CheckBatchSize строк.
+	// До сброса в БД проверки лежат в памяти: при падении процесса теряется до CheckBatchBuffer проверок.
+	// CheckBatchOverflow — только sync: при заполненном буфере проверка пишется сразу. Поле оставлено для старых конфигов
+	CheckBatchEnabled  bool   `yaml:"check_batch_enabled"`
+	CheckBatchSize     int    `yaml:"check_batch_size"`
+	CheckBatchFlushMs  int    `yaml:"check_batch_flush_ms"`
+	CheckBatchBuffer   int    `yaml:"check_batch_buffer"`
+	CheckBatchOverflow string `yaml:"check_batch_overflow"`
+
+	// MQTTBrokerURL пустой — прием координат по MQTT отключен
+	MQTTBrokerURL string `yaml:"mqtt_broker_url"`
+	MQTTClientID  string `yaml:"mqtt_client_id"`
+	MQTTUsername  string `yaml:"mqtt_username"`
+	MQTTPassword  string `yaml:"mqtt_password"`
+	MQTTTopic     string `yaml:"mqtt_topic"`
+	MQTTQoS       int    `yaml:"mqtt_qos"`
+
+	// HTTPWriteTimeoutSeconds должен быть больше таймаута обработки запросов (30 секунд);
+	// поток алертов снимает его для своих соединений
+	HTTPReadTimeoutSeconds  int `yaml:"http_read_timeout_seconds"`
+	HTTPWriteTimeoutSeconds int `yaml:"http_write_timeout_seconds"`
+	HTTPIdleTimeoutSeconds  int `yaml:"http_idle_timeout_seconds"`
+
+	// HTTPCompressionLevel — уровень gzip/deflate для списка инцидентов и статистики (1–9), 0 — без сжатия.
+	// HTTPMaxBodyKB ограничивает тела всех запросов, кроме загрузки вложений (ATTACHMENTS_MAX_SIZE_MB)
+	HTTPCompressionLevel int `yaml:"http_compression_level"`
+	HTTPMaxBodyKB        int `yaml:"http_max_body_kb"`
+
+	// TLSCertFile и TLSKeyFile — сервер сам принимает HTTPS (и HTTP/2) на HTTP_PORT; пустые — обычный HTTP.
+	// TLSAutocertDomains вместо файлов получает сертификаты Let's Encrypt для перечисленных доменов
+	// и хранит их в TLSAutocertCacheDir; на TLSAutocertHTTPPort отвечает на проверки ACME http-01
+	// и перенаправляет остальные запросы на HTTPS
+	TLSCertFile         string   `yaml:"tls_cert_file"`
+	TLSKeyFile          string   `yaml:"tls_key_file"`
+	TLSMinVersion       string   `yaml:"tls_min_version"`
+	TLSAutocertDomains  []string `yaml:"tls_autocert_domains"`
+	TLSAutocertEmail    string   `yaml:"tls_autocert_email"`
+	TLSAutocertCacheDir string   `yaml:"tls_autocert_cache_dir"`
+	TLSAutocertHTTPPort string   `yaml:"tls_autocert_http_port"`
+
+	// LogFormat — json или console; пустой — json при ENV=production, иначе console.
+	// LogOutput — stdout, stderr и/или пути к файлам; файлы ротируются по размеру и хранятся LogFileMaxBackups штук
+	// не дольше LogFileMaxAgeDays дней (0 — без ограничения)
+	LogFormat         string   `yaml:"log_format"`
+	LogOutput         []string `yaml:"log_output"`
+	LogFileMaxSizeMB  int      `yaml:"log_file_max_size_mb"`
+	LogFileMaxBackups int      `yaml:"log_file_max_backups"`
+	LogFileMaxAgeDays int      `yaml:"log_file_max_age_days"`
+	LogFileCompress   bool     `yaml:"log_file_compress"`
+
+	// AccessLogSampleInitial и AccessLogSampleThereafter — сколько успешных запросов каждого маршрута в секунду
+	// пишется в access log полностью и каждый какой из остальных (0 — остальные не пишутся); оба 0 — писать все
+	AccessLogSampleInitial    int `yaml:"access_log_sample_initial"`
+	AccessLogSampleThereafter int `yaml:"access_log_sample_thereafter"`
+
+	// Chaos* — только для тестов устойчивости: доля попыток доставки вебхуков и операций их очереди (в процентах),
+	// которые завершаются искусственной ошибкой, и задержка перед каждой из них. При ENV=production запрещены.
+	// ChaosSeed не 0 — сбои повторяются от запуска к запуску при том же порядке вызовов
+	ChaosWebhookFailurePercent int `yaml:"chaos_webhook_failure_percent"`
+	ChaosWebhookLatencyMs      int `yaml:"chaos_webhook_latency_ms"`
+	ChaosQueueFailurePercent   int `yaml:"chaos_queue_failure_percent"`
+	ChaosQueueLatencyMs        int `yaml:"chaos_queue_latency_ms"`
+	ChaosSeed                  int `yaml:"chaos_seed"`
+
+	// OpsAlert* — оповещения дежурных о состоянии самого сервиса в Slack и/или Telegram; без каналов выключены.
+	// Раз в OpsAlertIntervalSeconds доля неуспешных попыток доставки вебхуков и ошибок кэша за интервал
+	// (если операций было не меньше OpsAlertMinSamples) и число недоставленных вебхуков сравниваются с порогами
+	// (0 — порог не проверяется). Повтор по той же проблеме — не чаще раза в OpsAlertCooldownMinutes
+	OpsAlertSlackWebhookURL       string `yaml:"ops_alert_slack_webhook_url"`
+	OpsAlertTelegramBotToken      string `yaml:"ops_alert_telegram_bot_token"`
+	OpsAlertTelegramChatID        string `yaml:"ops_alert_telegram_chat_id"`
+	OpsAlertIntervalSeconds       int    `yaml:"ops_alert_interval_seconds"`
+	OpsAlertCooldownMinutes       int    `yaml:"ops_alert_cooldown_minutes"`
+	OpsAlertMinSamples            int    `yaml:"ops_alert_min_samples"`
+	OpsAlertWebhookFailurePercent int    `yaml:"ops_alert_webhook_failure_percent"`
+	OpsAlertCacheErrorPercent     int    `yaml:"ops_alert_cache_error_percent"`
+	OpsAlertQueueDepth            int    `yaml:"ops_alert_queue_depth"`
+
+	// OpenSearchURL пустой — выгрузка зон и проверок в OpenSearch отключена. Раз в OpenSearchIntervalSeconds
+	// воркер пишет изменения пачками по OpenSearchBatchSize в индексы с префиксом OpenSearchIndexPrefix;
+	// строки моложе OpenSearchSettleSeconds ждут следующего запуска
+	OpenSearchURL             string `yaml:"opensearch_url"`
+	OpenSearchUsername        string `yaml:"opensearch_username"`
+	OpenSearchPassword        string `yaml:"opensearch_password"`
+	OpenSearchIndexPrefix     string `yaml:"opensearch_index_prefix"`
+	OpenSearchBatchSize       int    `yaml:"opensearch_batch_size"`
+	OpenSearchIntervalSeconds int    `yaml:"opensearch_interval_seconds"`
+	OpenSearchSettleSeconds   int    `yaml:"opensearch_settle_seconds"`
+
+	// Раз в HeatmapIntervalSeconds воркер сводит новые проверки пачками по HeatmapBatchSize в почасовые
+	// счетчики по H3-ячейкам разрешения HeatmapResolution — самого мелкого, доступного тепловой карте;
+	// проверки моложе HeatmapSettleSeconds ждут следующего запуска
+	HeatmapResolution      int `yaml:"heatmap_resolution"`
+	HeatmapBatchSize       int `yaml:"heatmap_batch_size"`
+	HeatmapIntervalSeconds int `yaml:"heatmap_interval_seconds"`
+	HeatmapSettleSeconds   int `yaml:"heatmap_settle_seconds"`
+
+	// Раз в StatsRollupIntervalSeconds воркер сводит проверки в поминутные счетчики; минуты, в которых могут
+	// появиться проверки моложе StatsRollupSettleSeconds, ждут следующего запуска. Статистика отвечает по сводке,
+	// пока та отстает не больше чем на StatsFreshnessSeconds; 0 — статистика всегда считается по checks
+	StatsRollupIntervalSeconds int `yaml:"stats_rollup_interval_seconds"`
+	StatsRollupSettleSeconds   int `yaml:"stats_rollup_settle_seconds"`
+	StatsFreshnessSeconds      int `yaml:"stats_freshness_seconds"`
+
+	// WebhookSLO* — цель задержки доставки вебхуков: перцентиль WebhookSLOPercentile (50, 95 или 99) задержки
+	// от создания проверки до доставки за последние WebhookSLOWindowMinutes не выше WebhookSLOLatencyMs
+	// (0 — цель не задана, перцентили только считаются). Получатель с меньшим числом доставок за окно,
+	// чем WebhookSLOMinDeliveries, нарушителем не считается
+	WebhookSLOLatencyMs     int `yaml:"webhook_slo_latency_ms"`
+	WebhookSLOPercentile    int `yaml:"webhook_slo_percentile"`
+	WebhookSLOWindowMinutes int `yaml:"webhook_slo_window_minutes"`
+	WebhookSLOMinDeliveries int `yaml:"webhook_slo_min_deliveries"`
+}
+
+// APIKey — именованный API-ключ клиента. Квоты — запросов за сутки и календарный месяц (UTC), 0 — без ограничения
+type APIKey struct {
+	Key          string `yaml:"key"`
+	DailyQuota   int    `yaml:"daily_quota"`
+	MonthlyQuota int    `yaml:"monthly_quota"`
+}
+
+// Load собирает конфигурацию: значения по умолчанию, затем YAML-файл (если задан path),
+// затем переменные окружения, которые имеют наивысший приоритет
+func Load(path string) (*Config, error) {
 	err := godotenv.Load()
 	if err != nil {
 		log.Print("No .env file found, using environment variables")
 	}
 
-	return &Config{
-		HTTPPort:               getEnv("HTTP_PORT", "8080"),
-		DBURL:                  getDBURL(),
-		RedisURL:               getEnv("REDIS_URL", "redis://localhost:6379/0"),
-		WebhookURL:             getEnv("WEBHOOK_URL", ""),
-		APIKey:                 getEnv("SECRET_API_KEY", ""),
-		LogLevel:               getEnv("LOG_LEVEL", "info"),
-		StatsTimeWindowMinutes: getEnvAsInt("STATS_TIME_WINDOWS_MINUTES", 30),
-		MaxRetries:             getEnvAsInt("WEBHOOK_MAX_RETRIES", 3),
-		RetryDelaySeconds:      getEnvAsInt("WEBHOOK_RETRY_DELAY_SECONDS", 60),
-		CacheTTLMinutes:        getEnvAsInt("CACHE_TTL_MINUTES", 10),
+	cfg := &Config{
+		Env:                        "development",
+		HTTPPort:                   "8080",
+		RedisURL:                   "redis://localhost:6379/0",
+		LogLevel:                   "info",
+		StatsTimeWindowMinutes:     30,
+		MaxRetries:                 3,
+		RetryDelaySeconds:          60,
+		WebhookPollIntervalSeconds: 5,
+		WebhookLeaseSeconds:        60,
+		CacheTTLMinutes:            10,
+		CacheBackend:               "redis",
+		QueueBackend:               "redis",
+		AMQPQueue:                  "geonotify.webhooks",
+
+		CheckPartitionPremakeDays:   3,
+		CheckRetentionDays:          0,
+		PartitionMaintenanceMinutes: 60,
+
+		CheckReencryptIntervalSeconds: 60,
+		CheckLocationDecimals:         3,
+		CheckLocationGeohashLength:    7,
+
+		ScheduleIntervalSeconds: 60,
+		ErasureIntervalSeconds:  60,
+
+		WebhookTLSMinVersion: "1.2",
+		WebhookEvents:        []string{entity.WebhookLocationAlert},
+		WebhookMaxIncidents:  50,
+		WebhookMaxPayloadKB:  256,
+
+		S3Bucket:                   "incident-attachments",
+		AttachmentMaxSizeMB:        10,
+		AttachmentURLExpiryMinutes: 15,
+		DataExportIntervalSeconds:  30,
+		DataExportRetentionHours:   72,
+		DataExportURLExpiryMinutes: 15,
+
+		GeocoderUserAgent: "geonotify-service",
+
+		ReverseGeocodeCacheTTLMinutes: 1440,
+
+		JWTTTLMinutes: 15,
+
+		IncidentOverlapThresholdPercent: 50,
+
+		IncidentDefaultLocale: "ru",
+
+		FeedTitle:  "Geonotify",
+		FeedSender: "geonotify-service",
+
+		PublicAPIRateLimitPerMinute: 60,
+		PublicAPICacheSeconds:       30,
+
+		FeedImportIntervalSeconds: 300,
+		FeedImportPointRadiusM:    1000,
+
+		WeatherUserAgent:       "geonotify-service",
+		WeatherIntervalSeconds: 600,
+		WeatherRadiusM:         10000,
+		WeatherSeverityMap: map[string]string{
+			"Extreme":  entity.SeverityCritical,
+			"Severe":   entity.SeverityHigh,
+			"Moderate": entity.SeverityMedium,
+			"Minor":    entity.SeverityLow,
+		},
+		WeatherMinSeverity: entity.SeverityHigh,
+
+		CORSAllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+		CORSAllowedHeaders: []string{"Authorization", "Content-Type"},
+		CORSExposedHeaders: []string{"Deprecation", "Sunset", "Link"},
+		CORSMaxAgeSeconds:  600,
+
+		OIDCUsernameClaim:      "preferred_username",
+		OIDCJWKSRefreshMinutes: 60,
+
+		PredictionHorizonSeconds: 60,
+
+		CheckEventsStream:       "geonotify:checks",
+		CheckEventsStreamMaxLen: 1000000,
+		EventRelayIntervalMs:    500,
+		EventRelayBatchSize:     100,
+
+		ApprovalEventsStream: "geonotify:approvals",
+
+		LocationStream:              "geonotify:locations",
+		LocationStreamGroup:         "geonotify",
+		LocationConsumerConcurrency: 4,
+		LocationStreamBatchSize:     50,
+
+		DBReplicaRetrySeconds:  5,
+		DBReplicaMaxLagSeconds: 2,
+		DBSlowQueryMs:          500,
+		UsageFlushSeconds:      60,
+		WorkerLocksEnabled:     true,
+
+		OpsAlertIntervalSeconds:       60,
+		OpsAlertCooldownMinutes:       30,
+		OpsAlertMinSamples:            20,
+		OpsAlertWebhookFailurePercent: 50,
+		OpsAlertCacheErrorPercent:     20,
+		OpsAlertQueueDepth:            1000,
+
+		OpenSearchIndexPrefix:     "geonotify",
+		OpenSearchBatchSize:       500,
+		OpenSearchIntervalSeconds: 30,
+		OpenSearchSettleSeconds:   60,
+
+		HeatmapResolution:      9,
+		HeatmapBatchSize:       1000,
+		HeatmapIntervalSeconds: 60,
+		HeatmapSettleSeconds:   60,
+
+		StatsRollupIntervalSeconds: 60,
+		StatsRollupSettleSeconds:   60,
+		StatsFreshnessSeconds:      300,
+
+		WebhookSLOPercentile:    95,
+		WebhookSLOWindowMinutes: 60,
+		WebhookSLOMinDeliveries: 20,
+
+		CheckBatchSize:     500,
+		CheckBatchFlushMs:  200,
+		CheckBatchBuffer:   20000,
+		CheckBatchOverflow: "sync",
+
+		MQTTClientID: "geonotify-service",
+		MQTTTopic:    "geonotify/+/location",
+		MQTTQoS:      1,
+
+		HTTPReadTimeoutSeconds:  30,
+		HTTPWriteTimeoutSeconds: 60,
+		HTTPIdleTimeoutSeconds:  120,
+
+		HTTPCompressionLevel: 5,
+		HTTPMaxBodyKB:        1024,
+
+		TLSMinVersion:       "1.2",
+		TLSAutocertCacheDir: "autocert-cache",
+
+		LogOutput:         []string{"stdout"},
+		LogFileMaxSizeMB:  100,
+		LogFileMaxBackups: 5,
+		LogFileMaxAgeDays: 30,
+
+		AccessLogSampleInitial:    100,
+		AccessLogSampleThereafter: 100,
+	}
+
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.Env = getEnv("ENV", cfg.Env)
+	cfg.HTTPPort = getEnv("HTTP_PORT", cfg.HTTPPort)
+	cfg.HTTPReadTimeoutSeconds = getEnvAsInt("HTTP_READ_TIMEOUT_SECONDS", cfg.HTTPReadTimeoutSeconds)
+	cfg.HTTPWriteTimeoutSeconds = getEnvAsInt("HTTP_WRITE_TIMEOUT_SECONDS", cfg.HTTPWriteTimeoutSeconds)
+	cfg.HTTPIdleTimeoutSeconds = getEnvAsInt("HTTP_IDLE_TIMEOUT_SECONDS", cfg.HTTPIdleTimeoutSeconds)
+	cfg.HTTPCompressionLevel = getEnvAsInt("HTTP_COMPRESSION_LEVEL", cfg.HTTPCompressionLevel)
+	cfg.HTTPMaxBodyKB = getEnvAsInt("HTTP_MAX_BODY_KB", cfg.HTTPMaxBodyKB)
+	cfg.TLSCertFile = getEnv("TLS_CERT_FILE", cfg.TLSCertFile)
+	cfg.TLSKeyFile = getEnv("TLS_KEY_FILE", cfg.TLSKeyFile)
+	cfg.TLSMinVersion = getEnv("TLS_MIN_VERSION", cfg.TLSMinVersion)
+	cfg.TLSAutocertDomains = getEnvAsList("TLS_AUTOCERT_DOMAINS", cfg.TLSAutocertDomains)
+	cfg.TLSAutocertEmail = getEnv("TLS_AUTOCERT_EMAIL", cfg.TLSAutocertEmail)
+	cfg.TLSAutocertCacheDir = getEnv("TLS_AUTOCERT_CACHE_DIR", cfg.TLSAutocertCacheDir)
+	cfg.TLSAutocertHTTPPort = getEnv("TLS_AUTOCERT_HTTP_PORT", cfg.TLSAutocertHTTPPort)
+	cfg.DBURL = getDBURL(cfg.DBURL)
+	cfg.DBReplicaURL = getEnv("PG_DB_REPLICA_URL", cfg.DBReplicaURL)
+	cfg.DBReplicaRetrySeconds = getEnvAsInt("PG_DB_REPLICA_RETRY_SECONDS", cfg.DBReplicaRetrySeconds)
+	cfg.DBReplicaMaxLagSeconds = getEnvAsInt("PG_DB_REPLICA_MAX_LAG_SECONDS", cfg.DBReplicaMaxLagSeconds)
+	cfg.DBSlowQueryMs = getEnvAsInt("PG_SLOW_QUERY_MS", cfg.DBSlowQueryMs)
+	cfg.DBPoolMaxConns = getEnvAsInt("PG_POOL_MAX_CONNS", cfg.DBPoolMaxConns)
+	cfg.DBPoolMinConns = getEnvAsInt("PG_POOL_MIN_CONNS", cfg.DBPoolMinConns)
+	cfg.DBPoolMaxConnLifetimeSeconds = getEnvAsInt("PG_POOL_MAX_CONN_LIFETIME_SECONDS", cfg.DBPoolMaxConnLifetimeSeconds)
+	cfg.DBPoolMaxConnIdleSeconds = getEnvAsInt("PG_POOL_MAX_CONN_IDLE_SECONDS", cfg.DBPoolMaxConnIdleSeconds)
+	cfg.DBPoolHealthCheckSeconds = getEnvAsInt("PG_POOL_HEALTH_CHECK_SECONDS", cfg.DBPoolHealthCheckSeconds)
+	cfg.WorkerLocksEnabled = getEnvAsBool("WORKER_LOCKS_ENABLED", cfg.WorkerLocksEnabled)
+	cfg.RedisURL = getEnv("REDIS_URL", cfg.RedisURL)
+	cfg.RedisPoolSize = getEnvAsInt("REDIS_POOL_SIZE", cfg.RedisPoolSize)
+	cfg.RedisMinIdleConns = getEnvAsInt("REDIS_MIN_IDLE_CONNS", cfg.RedisMinIdleConns)
+	cfg.RedisPoolTimeoutSeconds = getEnvAsInt("REDIS_POOL_TIMEOUT_SECONDS", cfg.RedisPoolTimeoutSeconds)
+	cfg.RedisConnMaxLifetimeSeconds = getEnvAsInt("REDIS_CONN_MAX_LIFETIME_SECONDS", cfg.RedisConnMaxLifetimeSeconds)
+	cfg.WebhookURL = getEnv("WEBHOOK_URL", cfg.WebhookURL)
+	cfg.WebhookSecret = getEnv("WEBHOOK_SECRET", cfg.WebhookSecret)
+	cfg.APIKey = getEnv("SECRET_API_KEY", cfg.APIKey)
+	cfg.JWTSecret = getEnv("JWT_SECRET", cfg.JWTSecret)
+	cfg.JWTTTLMinutes = getEnvAsInt("JWT_TTL_MINUTES", cfg.JWTTTLMinutes)
+	cfg.IncidentReviewRequired = getEnvAsBool("INCIDENT_REVIEW_REQUIRED", cfg.IncidentReviewRequired)
+	cfg.IncidentOverlapCheck = getEnvAsBool("INCIDENT_OVERLAP_CHECK", cfg.IncidentOverlapCheck)
+	cfg.IncidentOverlapThresholdPercent = getEnvAsInt("INCIDENT_OVERLAP_THRESHOLD_PERCENT", cfg.IncidentOverlapThresholdPercent)
+	cfg.IncidentDefaultLocale = getEnv("INCIDENT_DEFAULT_LOCALE", cfg.IncidentDefaultLocale)
+	cfg.FeedEnabled = getEnvAsBool("FEED_ENABLED", cfg.FeedEnabled)
+	cfg.FeedBaseURL = getEnv("FEED_BASE_URL", cfg.FeedBaseURL)
+	cfg.FeedTitle = getEnv("FEED_TITLE", cfg.FeedTitle)
+	cfg.FeedSender = getEnv("FEED_SENDER", cfg.FeedSender)
+	cfg.PublicAPIEnabled = getEnvAsBool("PUBLIC_API_ENABLED", cfg.PublicAPIEnabled)
+	cfg.PublicAPIRateLimitPerMinute = getEnvAsInt("PUBLIC_API_RATE_LIMIT_PER_MINUTE", cfg.PublicAPIRateLimitPerMinute)
+	cfg.PublicAPICacheSeconds = getEnvAsInt("PUBLIC_API_CACHE_SECONDS", cfg.PublicAPICacheSeconds)
+	if sources := os.Getenv("FEED_IMPORT_SOURCES"); sources != "" {
+		cfg.FeedImportSources = parseFeedSources(sources)
+	}
+	cfg.FeedImportIntervalSeconds = getEnvAsInt("FEED_IMPORT_INTERVAL_SECONDS", cfg.FeedImportIntervalSeconds)
+	cfg.FeedImportPublish = getEnvAsBool("FEED_IMPORT_PUBLISH", cfg.FeedImportPublish)
+	cfg.FeedImportPointRadiusM = getEnvAsInt("FEED_IMPORT_POINT_RADIUS_M", cfg.FeedImportPointRadiusM)
+	cfg.WeatherProvider = getEnv("WEATHER_PROVIDER", cfg.WeatherProvider)
+	cfg.WeatherURL = getEnv("WEATHER_URL", cfg.WeatherURL)
+	cfg.WeatherAPIKey = getEnv("WEATHER_API_KEY", cfg.WeatherAPIKey)
+	cfg.WeatherUserAgent = getEnv("WEATHER_USER_AGENT", cfg.WeatherUserAgent)
+	if locations := os.Getenv("WEATHER_LOCATIONS"); locations != "" {
+		cfg.WeatherLocations = parseLocations(locations)
+	}
+	cfg.WeatherIntervalSeconds = getEnvAsInt("WEATHER_INTERVAL_SECONDS", cfg.WeatherIntervalSeconds)
+	cfg.WeatherRadiusM = getEnvAsInt("WEATHER_RADIUS_M", cfg.WeatherRadiusM)
+	if severityMap := os.Getenv("WEATHER_SEVERITY_MAP"); severityMap != "" {
+		cfg.WeatherSeverityMap = parseSeverityMap(severityMap)
+	}
+	cfg.WeatherMinSeverity = getEnv("WEATHER_MIN_SEVERITY", cfg.WeatherMinSeverity)
+	cfg.WeatherPublish = getEnvAsBool("WEATHER_PUBLISH", cfg.WeatherPublish)
+	if policies := os.Getenv("AUTH_POLICIES"); policies != "" {
+		cfg.AuthPolicies = parseAuthPolicies(policies)
+	}
+	cfg.OperatorIPAllowlist = getEnvAsList("OPERATOR_IP_ALLOWLIST", cfg.OperatorIPAllowlist)
+	if keys := os.Getenv("API_KEYS"); keys != "" {
+		cfg.APIKeys = parseAPIKeys(keys)
+	}
+	cfg.UsageMeteringEnabled = getEnvAsBool("USAGE_METERING_ENABLED", cfg.UsageMeteringEnabled)
+	cfg.UsageFlushSeconds = getEnvAsInt("USAGE_FLUSH_SECONDS", cfg.UsageFlushSeconds)
+	cfg.TrustedProxies = getEnvAsList("TRUSTED_PROXIES", cfg.TrustedProxies)
+	cfg.CORSAllowedOrigins = getEnvAsList("CORS_ALLOWED_ORIGINS", cfg.CORSAllowedOrigins)
+	cfg.CORSAllowedMethods = getEnvAsList("CORS_ALLOWED_METHODS", cfg.CORSAllowedMethods)
+	cfg.CORSAllowedHeaders = getEnvAsList("CORS_ALLOWED_HEADERS", cfg.CORSAllowedHeaders)
+	cfg.CORSExposedHeaders = getEnvAsList("CORS_EXPOSED_HEADERS", cfg.CORSExposedHeaders)
+	cfg.CORSAllowCredentials = getEnvAsBool("CORS_ALLOW_CREDENTIALS", cfg.CORSAllowCredentials)
+	cfg.CORSMaxAgeSeconds = getEnvAsInt("CORS_MAX_AGE_SECONDS", cfg.CORSMaxAgeSeconds)
+	cfg.OIDCIssuer = getEnv("OIDC_ISSUER", cfg.OIDCIssuer)
+	cfg.OIDCAudience = getEnv("OIDC_AUDIENCE", cfg.OIDCAudience)
+	cfg.OIDCJWKSURL = getEnv("OIDC_JWKS_URL", cfg.OIDCJWKSURL)
+	cfg.OIDCUsernameClaim = getEnv("OIDC_USERNAME_CLAIM", cfg.OIDCUsernameClaim)
+	cfg.OIDCJWKSRefreshMinutes = getEnvAsInt("OIDC_JWKS_REFRESH_MINUTES", cfg.OIDCJWKSRefreshMinutes)
+	cfg.LogLevel = getEnv("LOG_LEVEL", cfg.LogLevel)
+	cfg.LogFormat = getEnv("LOG_FORMAT", cfg.LogFormat)
+	cfg.LogOutput = getEnvAsList("LOG_OUTPUT", cfg.LogOutput)
+	cfg.LogFileMaxSizeMB = getEnvAsInt("LOG_FILE_MAX_SIZE_MB", cfg.LogFileMaxSizeMB)
+	cfg.LogFileMaxBackups = getEnvAsInt("LOG_FILE_MAX_BACKUPS", cfg.LogFileMaxBackups)
+	cfg.LogFileMaxAgeDays = getEnvAsInt("LOG_FILE_MAX_AGE_DAYS", cfg.LogFileMaxAgeDays)
+	cfg.LogFileCompress = getEnvAsBool("LOG_FILE_COMPRESS", cfg.LogFileCompress)
+	cfg.AccessLogSampleInitial = getEnvAsInt("ACCESS_LOG_SAMPLE_INITIAL", cfg.AccessLogSampleInitial)
+	cfg.AccessLogSampleThereafter = getEnvAsInt("ACCESS_LOG_SAMPLE_THEREAFTER", cfg.AccessLogSampleThereafter)
+	cfg.ChaosWebhookFailurePercent = getEnvAsInt("CHAOS_WEBHOOK_FAILURE_PERCENT", cfg.ChaosWebhookFailurePercent)
+	cfg.ChaosWebhookLatencyMs = getEnvAsInt("CHAOS_WEBHOOK_LATENCY_MS", cfg.ChaosWebhookLatencyMs)
+	cfg.ChaosQueueFailurePercent = getEnvAsInt("CHAOS_QUEUE_FAILURE_PERCENT", cfg.ChaosQueueFailurePercent)
+	cfg.ChaosQueueLatencyMs = getEnvAsInt("CHAOS_QUEUE_LATENCY_MS", cfg.ChaosQueueLatencyMs)
+	cfg.ChaosSeed = getEnvAsInt("CHAOS_SEED", cfg.ChaosSeed)
+	cfg.OpsAlertSlackWebhookURL = getEnv("OPS_ALERT_SLACK_WEBHOOK_URL", cfg.OpsAlertSlackWebhookURL)
+	cfg.OpsAlertTelegramBotToken = getEnv("OPS_ALERT_TELEGRAM_BOT_TOKEN", cfg.OpsAlertTelegramBotToken)
+	cfg.OpsAlertTelegramChatID = getEnv("OPS_ALERT_TELEGRAM_CHAT_ID", cfg.OpsAlertTelegramChatID)
+	cfg.OpsAlertIntervalSeconds = getEnvAsInt("OPS_ALERT_INTERVAL_SECONDS", cfg.OpsAlertIntervalSeconds)
+	cfg.OpsAlertCooldownMinutes = getEnvAsInt("OPS_ALERT_COOLDOWN_MINUTES", cfg.OpsAlertCooldownMinutes)
+	cfg.OpsAlertMinSamples = getEnvAsInt("OPS_ALERT_MIN_SAMPLES", cfg.OpsAlertMinSamples)
+	cfg.OpsAlertWebhookFailurePercent = getEnvAsInt("OPS_ALERT_WEBHOOK_FAILURE_PERCENT", cfg.OpsAlertWebhookFailurePercent)
+	cfg.OpsAlertCacheErrorPercent = getEnvAsInt("OPS_ALERT_CACHE_ERROR_PERCENT", cfg.OpsAlertCacheErrorPercent)
+	cfg.OpsAlertQueueDepth = getEnvAsInt("OPS_ALERT_QUEUE_DEPTH", cfg.OpsAlertQueueDepth)
+	cfg.OpenSearchURL = getEnv("OPENSEARCH_URL", cfg.OpenSearchURL)
+	cfg.OpenSearchUsername = getEnv("OPENSEARCH_USERNAME", cfg.OpenSearchUsername)
+	cfg.OpenSearchPassword = getEnv("OPENSEARCH_PASSWORD", cfg.OpenSearchPassword)
+	cfg.OpenSearchIndexPrefix = getEnv("OPENSEARCH_INDEX_PREFIX", cfg.OpenSearchIndexPrefix)
+	cfg.OpenSearchBatchSize = getEnvAsInt("OPENSEARCH_BATCH_SIZE", cfg.OpenSearchBatchSize)
+	cfg.OpenSearchIntervalSeconds = getEnvAsInt("OPENSEARCH_INTERVAL_SECONDS", cfg.OpenSearchIntervalSeconds)
+	cfg.OpenSearchSettleSeconds = getEnvAsInt("OPENSEARCH_SETTLE_SECONDS", cfg.OpenSearchSettleSeconds)
+
+	cfg.HeatmapResolution = getEnvAsInt("HEATMAP_RESOLUTION", cfg.HeatmapResolution)
+	cfg.HeatmapBatchSize = getEnvAsInt("HEATMAP_BATCH_SIZE", cfg.HeatmapBatchSize)
+	cfg.HeatmapIntervalSeconds = getEnvAsInt("HEATMAP_INTERVAL_SECONDS", cfg.HeatmapIntervalSeconds)
+	cfg.HeatmapSettleSeconds = getEnvAsInt("HEATMAP_SETTLE_SECONDS", cfg.HeatmapSettleSeconds)
+	cfg.StatsRollupIntervalSeconds = getEnvAsInt("STATS_ROLLUP_INTERVAL_SECONDS", cfg.StatsRollupIntervalSeconds)
+	cfg.StatsRollupSettleSeconds = getEnvAsInt("STATS_ROLLUP_SETTLE_SECONDS", cfg.StatsRollupSettleSeconds)
+	cfg.StatsFreshnessSeconds = getEnvAsInt("STATS_FRESHNESS_SECONDS", cfg.StatsFreshnessSeconds)
+	cfg.WebhookSLOLatencyMs = getEnvAsInt("WEBHOOK_SLO_LATENCY_MS", cfg.WebhookSLOLatencyMs)
+	cfg.WebhookSLOPercentile = getEnvAsInt("WEBHOOK_SLO_PERCENTILE", cfg.WebhookSLOPercentile)
+	cfg.WebhookSLOWindowMinutes = getEnvAsInt("WEBHOOK_SLO_WINDOW_MINUTES", cfg.WebhookSLOWindowMinutes)
+	cfg.WebhookSLOMinDeliveries = getEnvAsInt("WEBHOOK_SLO_MIN_DELIVERIES", cfg.WebhookSLOMinDeliveries)
+	cfg.StatsTimeWindowMinutes = getEnvAsInt("STATS_TIME_WINDOWS_MINUTES", cfg.StatsTimeWindowMinutes)
+	cfg.MaxRetries = getEnvAsInt("WEBHOOK_MAX_RETRIES", cfg.MaxRetries)
+	cfg.RetryDelaySeconds = getEnvAsInt("WEBHOOK_RETRY_DELAY_SECONDS", cfg.RetryDelaySeconds)
+	cfg.WebhookPollIntervalSeconds = getEnvAsInt("WEBHOOK_POLL_INTERVAL_SECONDS", cfg.WebhookPollIntervalSeconds)
+	cfg.WebhookLeaseSeconds = getEnvAsInt("WEBHOOK_LEASE_SECONDS", cfg.WebhookLeaseSeconds)
+	cfg.CacheTTLMinutes = getEnvAsInt("CACHE_TTL_MINUTES", cfg.CacheTTLMinutes)
+	cfg.CacheBackend = getEnv("CACHE_BACKEND", cfg.CacheBackend)
+	cfg.QueueBackend = getEnv("QUEUE_BACKEND", cfg.QueueBackend)
+	cfg.AMQPURL = getEnv("AMQP_URL", cfg.AMQPURL)
+	cfg.AMQPQueue = getEnv("AMQP_QUEUE", cfg.AMQPQueue)
+
+	cfg.CheckPartitionPremakeDays = getEnvAsInt("CHECKS_PARTITION_PREMAKE_DAYS", cfg.CheckPartitionPremakeDays)
+	cfg.CheckRetentionDays = getEnvAsInt("CHECKS_RETENTION_DAYS", cfg.CheckRetentionDays)
+	cfg.PartitionMaintenanceMinutes = getEnvAsInt("PARTITION_MAINTENANCE_INTERVAL_MINUTES", cfg.PartitionMaintenanceMinutes)
+	cfg.CheckEncryptionKeys = getEnvAsList("CHECKS_ENCRYPTION_KEYS", cfg.CheckEncryptionKeys)
+	cfg.CheckEncryptionKeysFile = getEnv("CHECKS_ENCRYPTION_KEYS_FILE", cfg.CheckEncryptionKeysFile)
+	cfg.CheckReencryptIntervalSeconds = getEnvAsInt("CHECKS_REENCRYPT_INTERVAL_SECONDS", cfg.CheckReencryptIntervalSeconds)
+	cfg.CheckLocationPrivacy = getEnv("CHECKS_LOCATION_PRIVACY", cfg.CheckLocationPrivacy)
+	cfg.CheckLocationDecimals = getEnvAsInt("CHECKS_LOCATION_DECIMALS", cfg.CheckLocationDecimals)
+	cfg.CheckLocationGeohashLength = getEnvAsInt("CHECKS_LOCATION_GEOHASH_LENGTH", cfg.CheckLocationGeohashLength)
+
+	cfg.ScheduleIntervalSeconds = getEnvAsInt("SCHEDULE_INTERVAL_SECONDS", cfg.ScheduleIntervalSeconds)
+	cfg.ErasureIntervalSeconds = getEnvAsInt("ERASURE_INTERVAL_SECONDS", cfg.ErasureIntervalSeconds)
+
+	cfg.WebhookTLSCertFile = getEnv("WEBHOOK_TLS_CERT_FILE", cfg.WebhookTLSCertFile)
+	cfg.WebhookTLSKeyFile = getEnv("WEBHOOK_TLS_KEY_FILE", cfg.WebhookTLSKeyFile)
+	cfg.WebhookTLSCAFile = getEnv("WEBHOOK_TLS_CA_FILE", cfg.WebhookTLSCAFile)
+	cfg.WebhookTLSMinVersion = getEnv("WEBHOOK_TLS_MIN_VERSION", cfg.WebhookTLSMinVersion)
+	cfg.WebhookProxyURL = getEnv("WEBHOOK_PROXY_URL", cfg.WebhookProxyURL)
+	if headers := os.Getenv("WEBHOOK_HEADERS"); headers != "" {
+		cfg.WebhookHeaders = parseHeaders(headers)
+	}
+	cfg.WebhookEvents = getEnvAsList("WEBHOOK_EVENTS", cfg.WebhookEvents)
+	cfg.WebhookMaxIncidents = getEnvAsInt("WEBHOOK_MAX_INCIDENTS", cfg.WebhookMaxIncidents)
+	cfg.WebhookMaxPayloadKB = getEnvAsInt("WEBHOOK_MAX_PAYLOAD_KB", cfg.WebhookMaxPayloadKB)
+	cfg.WebhookIncludeComments = getEnvAsBool("WEBHOOK_INCLUDE_COMMENTS", cfg.WebhookIncludeComments)
+
+	cfg.S3Endpoint = getEnv("S3_ENDPOINT", cfg.S3Endpoint)
+	cfg.S3AccessKey = getEnv("S3_ACCESS_KEY", cfg.S3AccessKey)
+	cfg.S3SecretKey = getEnv("S3_SECRET_KEY", cfg.S3SecretKey)
+	cfg.S3Bucket = getEnv("S3_BUCKET", cfg.S3Bucket)
+	cfg.S3Region = getEnv("S3_REGION", cfg.S3Region)
+	cfg.S3UseSSL = getEnvAsBool("S3_USE_SSL", cfg.S3UseSSL)
+	cfg.AttachmentMaxSizeMB = getEnvAsInt("ATTACHMENTS_MAX_SIZE_MB", cfg.AttachmentMaxSizeMB)
+	cfg.AttachmentURLExpiryMinutes = getEnvAsInt("ATTACHMENTS_URL_EXPIRY_MINUTES", cfg.AttachmentURLExpiryMinutes)
+	cfg.DataExportIntervalSeconds = getEnvAsInt("DATA_EXPORT_INTERVAL_SECONDS", cfg.DataExportIntervalSeconds)
+	cfg.DataExportRetentionHours = getEnvAsInt("DATA_EXPORT_RETENTION_HOURS", cfg.DataExportRetentionHours)
+	cfg.DataExportURLExpiryMinutes = getEnvAsInt("DATA_EXPORT_URL_EXPIRY_MINUTES", cfg.DataExportURLExpiryMinutes)
+
+	cfg.GeocoderProvider = getEnv("GEOCODER_PROVIDER", cfg.GeocoderProvider)
+	cfg.GeocoderURL = getEnv("GEOCODER_URL", cfg.GeocoderURL)
+	cfg.GeocoderAPIKey = getEnv("GEOCODER_API_KEY", cfg.GeocoderAPIKey)
+	cfg.GeocoderUserAgent = getEnv("GEOCODER_USER_AGENT", cfg.GeocoderUserAgent)
+
+	cfg.ReverseGeocodeCacheTTLMinutes = getEnvAsInt("REVERSE_GEOCODE_CACHE_TTL_MINUTES", cfg.ReverseGeocodeCacheTTLMinutes)
+	cfg.RegionAllowlistFile = getEnv("REGION_ALLOWLIST_FILE", cfg.RegionAllowlistFile)
+	cfg.RegionDenylistFile = getEnv("REGION_DENYLIST_FILE", cfg.RegionDenylistFile)
+	cfg.PredictionHorizonSeconds = getEnvAsInt("PREDICTION_HORIZON_SECONDS", cfg.PredictionHorizonSeconds)
+
+	cfg.CheckEventsEnabled = getEnvAsBool("CHECK_EVENTS_ENABLED", cfg.CheckEventsEnabled)
+	cfg.CheckEventsStream = getEnv("CHECK_EVENTS_STREAM", cfg.CheckEventsStream)
+	cfg.CheckEventsStreamMaxLen = getEnvAsInt("CHECK_EVENTS_STREAM_MAX_LEN", cfg.CheckEventsStreamMaxLen)
+	cfg.EventRelayIntervalMs = getEnvAsInt("EVENT_RELAY_INTERVAL_MS", cfg.EventRelayIntervalMs)
+	cfg.EventRelayBatchSize = getEnvAsInt("EVENT_RELAY_BATCH_SIZE", cfg.EventRelayBatchSize)
+
+	cfg.ApprovalEventsEnabled = getEnvAsBool("APPROVAL_EVENTS_ENABLED", cfg.ApprovalEventsEnabled)
+	cfg.ApprovalEventsStream = getEnv("APPROVAL_EVENTS_STREAM", cfg.ApprovalEventsStream)
+
+	cfg.LocationStreamEnabled = getEnvAsBool("LOCATION_STREAM_ENABLED", cfg.LocationStreamEnabled)
+	cfg.LocationStream = getEnv("LOCATION_STREAM", cfg.LocationStream)
+	cfg.LocationStreamGroup = getEnv("LOCATION_STREAM_GROUP", cfg.LocationStreamGroup)
+	cfg.LocationConsumerConcurrency = getEnvAsInt("LOCATION_CONSUMER_CONCURRENCY", cfg.LocationConsumerConcurrency)
+	cfg.LocationStreamBatchSize = getEnvAsInt("LOCATION_STREAM_BATCH_SIZE", cfg.LocationStreamBatchSize)
+
+	cfg.CheckBatchEnabled = getEnvAsBool("CHECK_BATCH_ENABLED", cfg.CheckBatchEnabled)
+	cfg.CheckBatchSize = getEnvAsInt("CHECK_BATCH_SIZE", cfg.CheckBatchSize)
+	cfg.CheckBatchFlushMs = getEnvAsInt("CHECK_BATCH_FLUSH_MS", cfg.CheckBatchFlushMs)
+	cfg.CheckBatchBuffer = getEnvAsInt("CHECK_BATCH_BUFFER", cfg.CheckBatchBuffer)
+	cfg.CheckBatchOverflow = getEnv("CHECK_BATCH_OVERFLOW", cfg.CheckBatchOverflow)
+
+	cfg.MQTTBrokerURL = getEnv("MQTT_BROKER_URL", cfg.MQTTBrokerURL)
+	cfg.MQTTClientID = getEnv("MQTT_CLIENT_ID", cfg.MQTTClientID)
+	cfg.MQTTUsername = getEnv("MQTT_USERNAME", cfg.MQTTUsername)
+	cfg.MQTTPassword = getEnv("MQTT_PASSWORD", cfg.MQTTPassword)
+	cfg.MQTTTopic = getEnv("MQTT_TOPIC", cfg.MQTTTopic)
+	cfg.MQTTQoS = getEnvAsInt("MQTT_QOS", cfg.MQTTQoS)
+
+	return cfg, nil
+}
+
+func (c *Config) IsDevelopment() bool {
+	return c.Env == "development" || c.Env == "dev"
+}
+
+func (c *Config) IsProduction() bool {
+	return c.Env == "production" || c.Env == "prod"
+}
+
+// OpsAlertsEnabled сообщает, что задан хотя бы один канал оповещений дежурных
+func (c *Config) OpsAlertsEnabled() bool {
+	return c.OpsAlertSlackWebhookURL != "" || c.OpsAlertTelegramBotToken != ""
+}
+
+// ChaosEnabled сообщает, что задан хотя бы один искусственный сбой или задержка
+func (c *Config) ChaosEnabled() bool {
+	return c.ChaosWebhookFailurePercent != 0 || c.ChaosWebhookLatencyMs != 0 ||
+		c.ChaosQueueFailurePercent != 0 || c.ChaosQueueLatencyMs != 0
+}
+
+// CheckEncryptionKeyList возвращает ключи шифрования проверок: из CheckEncryptionKeysFile, если он задан
+// (пустые строки и строки с # пропускаются), иначе CheckEncryptionKeys
+func (c *Config) CheckEncryptionKeyList() ([]string, error) {
+	if c.CheckEncryptionKeysFile == "" {
+		return c.CheckEncryptionKeys, nil
+	}
+
+	data, err := os.ReadFile(c.CheckEncryptionKeysFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys = append(keys, line)
+	}
+
+	return keys, nil
+}
+
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
 	}
+
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -64,11 +803,188 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
-func getDBURL() string {
+func getEnvAsBool(key string, defaultValue bool) bool {
+	strValue := os.Getenv(key)
+	if strValue == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseBool(strValue)
+	if err != nil {
+		log.Printf("Invalid boolean value for %s: %s, using default: %t", key, strValue, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
+// getEnvAsList разбирает список через запятую
+func getEnvAsList(key string, defaultValue []string) []string {
+	strValue := os.Getenv(key)
+	if strValue == "" {
+		return defaultValue
+	}
+
+	var values []string
+	for _, v := range strings.Split(strValue, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// parseAuthPolicies разбирает строку вида "/api/v1/admin=api-key;GET /api/v1/incidents=public"
+func parseAuthPolicies(value string) map[string]string {
+	policies := make(map[string]string)
+
+	for _, entry := range strings.Split(value, ";") {
+		route, policy, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			log.Printf("Invalid AUTH_POLICIES entry %q, expected [METHOD ]/path=policy", entry)
+			continue
+		}
+		policies[strings.TrimSpace(route)] = strings.TrimSpace(policy)
+	}
+
+	return policies
+}
+
+// parseAPIKeys разбирает строку вида "acme=KEY|1000|30000;beta=KEY2": имя=ключ[|квота в сутки[|квота в месяц]]
+func parseAPIKeys(value string) map[string]APIKey {
+	keys := make(map[string]APIKey)
+
+	for _, entry := range strings.Split(value, ";") {
+		name, spec, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			log.Printf("Invalid API_KEYS entry %q, expected name=key[|daily[|monthly]]", entry)
+			continue
+		}
+
+		fields := strings.Split(spec, "|")
+		key := APIKey{Key: strings.TrimSpace(fields[0])}
+		quotas := []*int{&key.DailyQuota, &key.MonthlyQuota}
+		valid := len(fields) <= len(quotas)+1
+		for i, field := range fields[1:] {
+			if !valid {
+				break
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				valid = false
+				break
+			}
+			*quotas[i] = n
+		}
+		if !valid {
+			log.Printf("Invalid API_KEYS entry for %q, expected name=key[|daily[|monthly]]", name)
+			continue
+		}
+
+		keys[strings.TrimSpace(name)] = key
+	}
+
+	return keys
+}
+
+// parseFeedSources разбирает строку вида "meteo=https://example.com/cap.atom;gov=https://example.org/alerts.xml"
+func parseFeedSources(value string) map[string]string {
+	sources := make(map[string]string)
+
+	for _, entry := range strings.Split(value, ";") {
+		name, url, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			log.Printf("Invalid FEED_IMPORT_SOURCES entry %q, expected name=url", entry)
+			continue
+		}
+		sources[strings.TrimSpace(name)] = strings.TrimSpace(url)
+	}
+
+	return sources
+}
+
+// parseLocations разбирает строку вида "55.7558,37.6173;59.9386,30.3141"
+func parseLocations(value string) []string {
+	var locations []string
+	for _, entry := range strings.Split(value, ";") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			locations = append(locations, entry)
+		}
+	}
+
+	return locations
+}
+
+// ParseLocation разбирает место "широта,долгота" из WeatherLocations
+func ParseLocation(value string) (float64, float64, error) {
+	latStr, lonStr, ok := strings.Cut(value, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected latitude,longitude, got %q", value)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return 0, 0, fmt.Errorf("invalid latitude in %q", value)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(lonStr), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return 0, 0, fmt.Errorf("invalid longitude in %q", value)
+	}
+
+	return lat, lon, nil
+}
+
+// parseSeverityMap разбирает строку вида "Extreme=critical;Tornado Warning=critical;Severe=high"
+func parseSeverityMap(value string) map[string]string {
+	severityMap := make(map[string]string)
+
+	for _, entry := range strings.Split(value, ";") {
+		key, severity, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			log.Printf("Invalid WEATHER_SEVERITY_MAP entry %q, expected key=severity", entry)
+			continue
+		}
+		severityMap[strings.TrimSpace(key)] = strings.TrimSpace(severity)
+	}
+
+	return severityMap
+}
+
+// parseHeaders разбирает строку вида "host|Header=Value;*|X-Source=geonotify"
+func parseHeaders(value string) map[string]map[string]string {
+	headers := make(map[string]map[string]string)
+
+	for _, entry := range strings.Split(value, ";") {
+		host, header, ok := strings.Cut(strings.TrimSpace(entry), "|")
+		if !ok {
+			log.Printf("Invalid WEBHOOK_HEADERS entry %q, expected host|Header=Value", entry)
+			continue
+		}
+
+		name, val, ok := strings.Cut(header, "=")
+		if !ok {
+			log.Printf("Invalid WEBHOOK_HEADERS entry %q, expected host|Header=Value", entry)
+			continue
+		}
+
+		if headers[host] == nil {
+			headers[host] = make(map[string]string)
+		}
+		headers[host][strings.TrimSpace(name)] = strings.TrimSpace(val)
+	}
+
+	return headers
+}
+
+func getDBURL(fallback string) string {
 	if dbURL := os.Getenv("PG_DB_URL"); dbURL != "" {
 		return dbURL
 	}
 
+	if fallback != "" && os.Getenv("PG_DB_HOST") == "" {
+		return fallback
+	}
+
 	host := getEnv("PG_DB_HOST", "localhost")
 	port := getEnv("PG_DB_PORT", "5434")
 	user := getEnv("PG_DB_USER", "postgres")
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// Holder хранит актуальную конфигурацию и позволяет перечитать ее без рестарта.
+// В рантайме меняются только настройки, перечисленные в applyReloadable,
+// остальные (адреса БД, Redis, порт) требуют перезапуска сервиса.
+type Holder struct {
+	path     string
+	current  atomic.Pointer[Config]
+	mu       sync.Mutex
+	onReload []func(*Config)
+}
+
+func NewHolder(path string, cfg *Config) *Holder {
+	h := &Holder{path: path}
+	h.current.Store(cfg)
+	return h
+}
+
+func (h *Holder) Get() *Config {
+	return h.current.Load()
+}
+
+// OnReload регистрирует обработчик, вызываемый после успешной перезагрузки
+func (h *Holder) OnReload(fn func(*Config)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onReload = append(h.onReload, fn)
+}
+
+func (h *Holder) Reload() (*Config, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	loaded, err := Load(h.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loaded.Validate(); err != nil {
+		return nil, err
+	}
+
+	next := *h.current.Load()
+	applyReloadable(&next, loaded)
+	h.current.Store(&next)
+
+	for _, fn := range h.onReload {
+		fn(&next)
+	}
+
+	return &next, nil
+}
+
+// WatchSignals перечитывает конфигурацию по SIGHUP, пока не будет вызвана stop
+func (h *Holder) WatchSignals(report func(cfg *Config, err error)) (stop func()) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-sighup:
+				cfg, err := h.Reload()
+				report(cfg, err)
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sighup)
+		close(done)
+	}
+}
+
+func applyReloadable(dst, src *Config) {
+	dst.LogLevel = src.LogLevel
+	dst.CacheTTLMinutes = src.CacheTTLMinutes
+	dst.PredictionHorizonSeconds = src.PredictionHorizonSeconds
+	dst.MaxRetries = src.MaxRetries
+	dst.RetryDelaySeconds = src.RetryDelaySeconds
+}
@@ -0,0 +1,546 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/pkg/fieldcrypt"
+	"github.com/4otis/geonotify-service/pkg/geohash"
+	"github.com/4otis/geonotify-service/pkg/locale"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap/zapcore"
+)
+
+// indexPrefixPattern — имена индексов OpenSearch только в нижнем регистре и не начинаются с - и _
+var indexPrefixPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+type intSetting struct {
+	key   string
+	value int
+}
+
+type fileSetting struct {
+	key  string
+	path string
+}
+
+// Validate проверяет конфигурацию целиком и возвращает список всех найденных проблем
+func (c *Config) Validate() error {
+	var problems []string
+
+	if port, err := strconv.Atoi(c.HTTPPort); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("HTTP_PORT: invalid port %q", c.HTTPPort))
+	}
+
+	if u, err := url.Parse(c.DBURL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		problems = append(problems, "PG_DB_URL: must be a postgres:// URL")
+	}
+
+	if c.DBReplicaURL != "" {
+		if u, err := url.Parse(c.DBReplicaURL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+			problems = append(problems, "PG_DB_REPLICA_URL: must be a postgres:// URL")
+		}
+	}
+
+	switch c.CacheBackend {
+	case "redis", "memory":
+	default:
+		problems = append(problems, fmt.Sprintf("CACHE_BACKEND: must be redis or memory, got %q", c.CacheBackend))
+	}
+
+	switch c.QueueBackend {
+	case "redis", "postgres":
+	case "amqp":
+		if u, err := url.Parse(c.AMQPURL); err != nil || (u.Scheme != "amqp" && u.Scheme != "amqps") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("AMQP_URL: invalid amqp(s) URL %q", c.AMQPURL))
+		}
+		if c.AMQPQueue == "" {
+			problems = append(problems, "AMQP_QUEUE: is required when QUEUE_BACKEND=amqp")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("QUEUE_BACKEND: must be redis, postgres or amqp, got %q", c.QueueBackend))
+	}
+
+	// без Redis сервис может работать только с кэшем в памяти и очередью в Postgres
+	if c.RedisURL == "" {
+		if c.CacheBackend != "memory" || c.QueueBackend == "redis" {
+			problems = append(problems, "REDIS_URL: is required unless CACHE_BACKEND=memory and QUEUE_BACKEND is postgres or amqp")
+		}
+		if c.CheckEventsEnabled {
+			problems = append(problems, "REDIS_URL: is required when CHECK_EVENTS_ENABLED is set")
+		}
+		if c.LocationStreamEnabled {
+			problems = append(problems, "REDIS_URL: is required when LOCATION_STREAM_ENABLED is set")
+		}
+	} else if _, err := redis.ParseURL(c.RedisURL); err != nil {
+		problems = append(problems, fmt.Sprintf("REDIS_URL: %v", err))
+	}
+
+	// WEBHOOK_URL необязателен: получатели регистрируются через /api/v1/webhook-endpoints
+	if c.WebhookURL != "" {
+		if u, err := url.Parse(c.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("WEBHOOK_URL: invalid http(s) URL %q", c.WebhookURL))
+		}
+	}
+
+	// аренда должна переживать таймаут HTTP-запроса доставки (10 секунд)
+	if c.WebhookLeaseSeconds <= 10 {
+		problems = append(problems, fmt.Sprintf("WEBHOOK_LEASE_SECONDS: must be > 10, got %d", c.WebhookLeaseSeconds))
+	}
+
+	for _, event := range c.WebhookEvents {
+		if event != "*" && !entity.ValidWebhookEvent(event) {
+			problems = append(problems, fmt.Sprintf("WEBHOOK_EVENTS: unknown event %q, expected * or one of %s",
+				event, strings.Join(entity.WebhookEvents, ", ")))
+		}
+	}
+
+	if c.IncidentOverlapThresholdPercent < 1 || c.IncidentOverlapThresholdPercent > 100 {
+		problems = append(problems, fmt.Sprintf("INCIDENT_OVERLAP_THRESHOLD_PERCENT: must be between 1 and 100, got %d", c.IncidentOverlapThresholdPercent))
+	}
+
+	if _, ok := locale.Canonical(c.IncidentDefaultLocale); !ok {
+		problems = append(problems, fmt.Sprintf("INCIDENT_DEFAULT_LOCALE: invalid language tag %q", c.IncidentDefaultLocale))
+	}
+
+	if c.FeedBaseURL != "" {
+		if u, err := url.Parse(c.FeedBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("FEED_BASE_URL: invalid http(s) URL %q", c.FeedBaseURL))
+		}
+	}
+	// CAP запрещает в sender пробелы, запятые и символы < и &
+	if c.FeedSender == "" || strings.ContainsAny(c.FeedSender, " ,<&") {
+		problems = append(problems, fmt.Sprintf("FEED_SENDER: must be non-empty without spaces, commas, < and &, got %q", c.FeedSender))
+	}
+
+	for name, source := range c.FeedImportSources {
+		if name == "" || len(name) > 64 {
+			problems = append(problems, fmt.Sprintf("FEED_IMPORT_SOURCES: feed name must be 1 to 64 characters, got %q", name))
+		}
+		if u, err := url.Parse(source); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("FEED_IMPORT_SOURCES: feed %q has invalid http(s) URL %q", name, source))
+		}
+	}
+
+	switch c.WeatherProvider {
+	case "", "nws":
+	case "openweather":
+		if c.WeatherAPIKey == "" {
+			problems = append(problems, "WEATHER_API_KEY: is required for openweather provider")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("WEATHER_PROVIDER: must be nws or openweather, got %q", c.WeatherProvider))
+	}
+	if c.WeatherProvider != "" && len(c.WeatherLocations) == 0 {
+		problems = append(problems, "WEATHER_LOCATIONS: at least one location is required when WEATHER_PROVIDER is set")
+	}
+	for _, location := range c.WeatherLocations {
+		if _, _, err := ParseLocation(location); err != nil {
+			problems = append(problems, fmt.Sprintf("WEATHER_LOCATIONS: %v", err))
+		}
+	}
+	if c.WeatherURL != "" {
+		if u, err := url.Parse(c.WeatherURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("WEATHER_URL: invalid http(s) URL %q", c.WeatherURL))
+		}
+	}
+	for key, severity := range c.WeatherSeverityMap {
+		if !entity.ValidSeverity(severity) {
+			problems = append(problems, fmt.Sprintf("WEATHER_SEVERITY_MAP: %q maps to unknown severity %q", key, severity))
+		}
+	}
+	if !entity.ValidSeverity(c.WeatherMinSeverity) {
+		problems = append(problems, fmt.Sprintf("WEATHER_MIN_SEVERITY: must be low, medium, high or critical, got %q", c.WeatherMinSeverity))
+	}
+
+	if c.HTTPCompressionLevel < 0 || c.HTTPCompressionLevel > 9 {
+		problems = append(problems, fmt.Sprintf("HTTP_COMPRESSION_LEVEL: must be between 0 and 9, got %d", c.HTTPCompressionLevel))
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		problems = append(problems, "TLS_CERT_FILE/TLS_KEY_FILE: both must be set to serve HTTPS")
+	}
+	if c.TLSCertFile != "" && len(c.TLSAutocertDomains) > 0 {
+		problems = append(problems, "TLS_AUTOCERT_DOMAINS: cannot be combined with TLS_CERT_FILE/TLS_KEY_FILE")
+	}
+	if len(c.TLSAutocertDomains) > 0 && c.TLSAutocertCacheDir == "" {
+		problems = append(problems, "TLS_AUTOCERT_CACHE_DIR: is required when TLS_AUTOCERT_DOMAINS is set")
+	}
+	if c.TLSAutocertHTTPPort != "" {
+		if port, err := strconv.Atoi(c.TLSAutocertHTTPPort); err != nil || port < 1 || port > 65535 {
+			problems = append(problems, fmt.Sprintf("TLS_AUTOCERT_HTTP_PORT: invalid port %q", c.TLSAutocertHTTPPort))
+		}
+	}
+	if c.TLSMinVersion != "1.2" && c.TLSMinVersion != "1.3" {
+		problems = append(problems, fmt.Sprintf("TLS_MIN_VERSION: must be 1.2 or 1.3, got %q", c.TLSMinVersion))
+	}
+
+	if (c.WebhookTLSCertFile == "") != (c.WebhookTLSKeyFile == "") {
+		problems = append(problems, "WEBHOOK_TLS_CERT_FILE/WEBHOOK_TLS_KEY_FILE: both must be set for mTLS")
+	}
+
+	for _, file := range []fileSetting{
+		{"TLS_CERT_FILE", c.TLSCertFile},
+		{"TLS_KEY_FILE", c.TLSKeyFile},
+		{"WEBHOOK_TLS_CERT_FILE", c.WebhookTLSCertFile},
+		{"WEBHOOK_TLS_KEY_FILE", c.WebhookTLSKeyFile},
+		{"WEBHOOK_TLS_CA_FILE", c.WebhookTLSCAFile},
+		{"REGION_ALLOWLIST_FILE", c.RegionAllowlistFile},
+		{"REGION_DENYLIST_FILE", c.RegionDenylistFile},
+	} {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.path); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", file.key, err))
+		}
+	}
+
+	if c.WebhookTLSMinVersion != "1.2" && c.WebhookTLSMinVersion != "1.3" {
+		problems = append(problems, fmt.Sprintf("WEBHOOK_TLS_MIN_VERSION: must be 1.2 or 1.3, got %q", c.WebhookTLSMinVersion))
+	}
+
+	if c.WebhookProxyURL != "" {
+		if u, err := url.Parse(c.WebhookProxyURL); err != nil || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("WEBHOOK_PROXY_URL: invalid URL %q", c.WebhookProxyURL))
+		}
+	}
+
+	if c.S3Endpoint != "" {
+		if c.S3Bucket == "" {
+			problems = append(problems, "S3_BUCKET: is required when S3_ENDPOINT is set")
+		}
+		if c.S3AccessKey == "" || c.S3SecretKey == "" {
+			problems = append(problems, "S3_ACCESS_KEY/S3_SECRET_KEY: are required when S3_ENDPOINT is set")
+		}
+	}
+
+	switch c.GeocoderProvider {
+	case "", "nominatim":
+	case "google":
+		if c.GeocoderAPIKey == "" {
+			problems = append(problems, "GEOCODER_API_KEY: is required for google provider")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("GEOCODER_PROVIDER: must be nominatim or google, got %q", c.GeocoderProvider))
+	}
+
+	if c.GeocoderURL != "" {
+		if u, err := url.Parse(c.GeocoderURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("GEOCODER_URL: invalid http(s) URL %q", c.GeocoderURL))
+		}
+	}
+
+	if c.CheckEventsEnabled && c.CheckEventsStream == "" {
+		problems = append(problems, "CHECK_EVENTS_STREAM: is required when CHECK_EVENTS_ENABLED is set")
+	}
+
+	if c.ApprovalEventsEnabled && c.ApprovalEventsStream == "" {
+		problems = append(problems, "APPROVAL_EVENTS_STREAM: is required when APPROVAL_EVENTS_ENABLED is set")
+	}
+
+	if c.LocationStreamEnabled && (c.LocationStream == "" || c.LocationStreamGroup == "") {
+		problems = append(problems, "LOCATION_STREAM/LOCATION_STREAM_GROUP: are required when LOCATION_STREAM_ENABLED is set")
+	}
+
+	if c.MQTTBrokerURL != "" {
+		if u, err := url.Parse(c.MQTTBrokerURL); err != nil || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("MQTT_BROKER_URL: invalid URL %q", c.MQTTBrokerURL))
+		}
+		if !strings.Contains(c.MQTTTopic, "+") {
+			problems = append(problems, fmt.Sprintf("MQTT_TOPIC: must contain + for the device id, got %q", c.MQTTTopic))
+		}
+		if c.MQTTQoS < 0 || c.MQTTQoS > 2 {
+			problems = append(problems, fmt.Sprintf("MQTT_QOS: must be 0, 1 or 2, got %d", c.MQTTQoS))
+		}
+	}
+
+	switch c.CheckLocationPrivacy {
+	case "":
+	case "round":
+		if c.CheckLocationDecimals < 0 || c.CheckLocationDecimals > 6 {
+			problems = append(problems, fmt.Sprintf("CHECKS_LOCATION_DECIMALS: must be between 0 and 6, got %d", c.CheckLocationDecimals))
+		}
+	case "geohash":
+		if c.CheckLocationGeohashLength < 1 || c.CheckLocationGeohashLength > geohash.MaxLength {
+			problems = append(problems, fmt.Sprintf("CHECKS_LOCATION_GEOHASH_LENGTH: must be between 1 and %d, got %d", geohash.MaxLength, c.CheckLocationGeohashLength))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("CHECKS_LOCATION_PRIVACY: must be empty, round or geohash, got %q", c.CheckLocationPrivacy))
+	}
+
+	if keys, err := c.CheckEncryptionKeyList(); err != nil {
+		problems = append(problems, fmt.Sprintf("CHECKS_ENCRYPTION_KEYS_FILE: %v", err))
+	} else if len(keys) > 0 {
+		if _, err := fieldcrypt.Parse(keys); err != nil {
+			problems = append(problems, fmt.Sprintf("CHECKS_ENCRYPTION_KEYS: %v", err))
+		}
+	}
+
+	if c.APIKey == "" && !c.IsDevelopment() {
+		problems = append(problems, fmt.Sprintf("SECRET_API_KEY: is required in %q environment", c.Env))
+	}
+
+	keyNames := make(map[string]string, len(c.APIKeys))
+	for name, key := range c.APIKeys {
+		// имя попадает в ключи счетчиков Redis и в created_by, поэтому без разделителей
+		if name == "" || len(name) > 64 || strings.Trim(name, "abcdefghijklmnopqrstuvwxyz0123456789_-") != "" {
+			problems = append(problems, fmt.Sprintf("API_KEYS: name must be 1 to 64 characters of a-z, 0-9, _ and -, got %q", name))
+		}
+		if name == entity.DefaultAPIKey {
+			problems = append(problems, fmt.Sprintf("API_KEYS: name %q is reserved for SECRET_API_KEY", name))
+		}
+		switch {
+		case key.Key == "":
+			problems = append(problems, fmt.Sprintf("API_KEYS: key %q has no key value", name))
+		case key.Key == c.APIKey:
+			problems = append(problems, fmt.Sprintf("API_KEYS: key %q duplicates SECRET_API_KEY", name))
+		case keyNames[key.Key] != "":
+			problems = append(problems, fmt.Sprintf("API_KEYS: keys %q and %q have the same value", keyNames[key.Key], name))
+		}
+		keyNames[key.Key] = name
+		if key.DailyQuota < 0 || key.MonthlyQuota < 0 {
+			problems = append(problems, fmt.Sprintf("API_KEYS: key %q quotas must not be negative", name))
+		}
+		if (key.DailyQuota > 0 || key.MonthlyQuota > 0) && !c.UsageMeteringEnabled {
+			problems = append(problems, fmt.Sprintf("API_KEYS: key %q has quotas, but USAGE_METERING_ENABLED is off", name))
+		}
+	}
+	if c.UsageMeteringEnabled && c.RedisURL == "" {
+		problems = append(problems, "USAGE_METERING_ENABLED: requires REDIS_URL")
+	}
+
+	// HS256 требует ключ не короче размера хэша
+	if c.JWTSecret != "" && len(c.JWTSecret) < 32 {
+		problems = append(problems, "JWT_SECRET: must be at least 32 characters")
+	}
+
+	for route, policy := range c.AuthPolicies {
+		switch policy {
+		case "public", "api-key", "jwt", "either":
+		default:
+			problems = append(problems, fmt.Sprintf("AUTH_POLICIES: unknown policy %q for %q, expected public, api-key, jwt or either", policy, route))
+		}
+		if _, path, ok := strings.Cut(route, " "); !strings.HasPrefix(route, "/") && (!ok || !strings.HasPrefix(path, "/")) {
+			problems = append(problems, fmt.Sprintf("AUTH_POLICIES: route %q must be [METHOD ]/path", route))
+		}
+	}
+
+	for _, list := range []struct {
+		key    string
+		values []string
+	}{
+		{"OPERATOR_IP_ALLOWLIST", c.OperatorIPAllowlist},
+		{"TRUSTED_PROXIES", c.TrustedProxies},
+	} {
+		for _, v := range list.values {
+			_, prefixErr := netip.ParsePrefix(v)
+			_, addrErr := netip.ParseAddr(v)
+			if prefixErr != nil && addrErr != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid IP address or CIDR %q", list.key, v))
+			}
+		}
+	}
+
+	for _, origin := range c.CORSAllowedOrigins {
+		if origin == "*" {
+			// браузер отклоняет credentials для ответа с любым Origin; отражать любой Origin вместе с ними небезопасно
+			if c.CORSAllowCredentials {
+				problems = append(problems, "CORS_ALLOWED_ORIGINS: \"*\" cannot be combined with CORS_ALLOW_CREDENTIALS")
+			}
+			continue
+		}
+		if u, err := url.Parse(strings.Replace(origin, "*.", "", 1)); err != nil || (u.Scheme != "http" && u.Scheme != "https") ||
+			u.Host == "" || (u.Path != "" && u.Path != "/") {
+			problems = append(problems, fmt.Sprintf("CORS_ALLOWED_ORIGINS: invalid origin %q, expected scheme://host[:port]", origin))
+		}
+	}
+
+	if c.OIDCIssuer != "" {
+		if u, err := url.Parse(c.OIDCIssuer); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("OIDC_ISSUER: invalid http(s) URL %q", c.OIDCIssuer))
+		}
+		// без аудитории принимались бы токены любого клиента провайдера
+		if c.OIDCAudience == "" {
+			problems = append(problems, "OIDC_AUDIENCE: is required when OIDC_ISSUER is set")
+		}
+		if c.OIDCJWKSURL != "" {
+			if u, err := url.Parse(c.OIDCJWKSURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				problems = append(problems, fmt.Sprintf("OIDC_JWKS_URL: invalid http(s) URL %q", c.OIDCJWKSURL))
+			}
+		}
+	}
+
+	if _, err := zapcore.ParseLevel(c.LogLevel); err != nil {
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL: unknown level %q", c.LogLevel))
+	}
+
+	switch c.LogFormat {
+	case "", "json", "console":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_FORMAT: must be json or console, got %q", c.LogFormat))
+	}
+
+	if len(c.LogOutput) == 0 {
+		problems = append(problems, "LOG_OUTPUT: at least one output is required")
+	}
+
+	positive := []intSetting{
+		{"HTTP_READ_TIMEOUT_SECONDS", c.HTTPReadTimeoutSeconds},
+		{"HTTP_WRITE_TIMEOUT_SECONDS", c.HTTPWriteTimeoutSeconds},
+		{"HTTP_IDLE_TIMEOUT_SECONDS", c.HTTPIdleTimeoutSeconds},
+		{"HTTP_MAX_BODY_KB", c.HTTPMaxBodyKB},
+		{"LOG_FILE_MAX_SIZE_MB", c.LogFileMaxSizeMB},
+		{"STATS_TIME_WINDOWS_MINUTES", c.StatsTimeWindowMinutes},
+		{"WEBHOOK_RETRY_DELAY_SECONDS", c.RetryDelaySeconds},
+		{"WEBHOOK_POLL_INTERVAL_SECONDS", c.WebhookPollIntervalSeconds},
+		{"WEBHOOK_MAX_INCIDENTS", c.WebhookMaxIncidents},
+		{"WEBHOOK_MAX_PAYLOAD_KB", c.WebhookMaxPayloadKB},
+		{"CACHE_TTL_MINUTES", c.CacheTTLMinutes},
+		{"PARTITION_MAINTENANCE_INTERVAL_MINUTES", c.PartitionMaintenanceMinutes},
+		{"CHECKS_REENCRYPT_INTERVAL_SECONDS", c.CheckReencryptIntervalSeconds},
+		{"SCHEDULE_INTERVAL_SECONDS", c.ScheduleIntervalSeconds},
+		{"ERASURE_INTERVAL_SECONDS", c.ErasureIntervalSeconds},
+		{"ATTACHMENTS_MAX_SIZE_MB", c.AttachmentMaxSizeMB},
+		{"ATTACHMENTS_URL_EXPIRY_MINUTES", c.AttachmentURLExpiryMinutes},
+		{"DATA_EXPORT_INTERVAL_SECONDS", c.DataExportIntervalSeconds},
+		{"DATA_EXPORT_RETENTION_HOURS", c.DataExportRetentionHours},
+		{"DATA_EXPORT_URL_EXPIRY_MINUTES", c.DataExportURLExpiryMinutes},
+		{"REVERSE_GEOCODE_CACHE_TTL_MINUTES", c.ReverseGeocodeCacheTTLMinutes},
+		{"EVENT_RELAY_INTERVAL_MS", c.EventRelayIntervalMs},
+		{"EVENT_RELAY_BATCH_SIZE", c.EventRelayBatchSize},
+		{"LOCATION_CONSUMER_CONCURRENCY", c.LocationConsumerConcurrency},
+		{"LOCATION_STREAM_BATCH_SIZE", c.LocationStreamBatchSize},
+		{"CHECK_BATCH_SIZE", c.CheckBatchSize},
+		{"PG_DB_REPLICA_RETRY_SECONDS", c.DBReplicaRetrySeconds},
+		{"CHECK_BATCH_FLUSH_MS", c.CheckBatchFlushMs},
+		{"JWT_TTL_MINUTES", c.JWTTTLMinutes},
+		{"OIDC_JWKS_REFRESH_MINUTES", c.OIDCJWKSRefreshMinutes},
+		{"PUBLIC_API_RATE_LIMIT_PER_MINUTE", c.PublicAPIRateLimitPerMinute},
+		{"PUBLIC_API_CACHE_SECONDS", c.PublicAPICacheSeconds},
+		{"FEED_IMPORT_INTERVAL_SECONDS", c.FeedImportIntervalSeconds},
+		{"FEED_IMPORT_POINT_RADIUS_M", c.FeedImportPointRadiusM},
+		{"WEATHER_INTERVAL_SECONDS", c.WeatherIntervalSeconds},
+		{"WEATHER_RADIUS_M", c.WeatherRadiusM},
+		{"OPS_ALERT_INTERVAL_SECONDS", c.OpsAlertIntervalSeconds},
+		{"USAGE_FLUSH_SECONDS", c.UsageFlushSeconds},
+		{"OPENSEARCH_BATCH_SIZE", c.OpenSearchBatchSize},
+		{"OPENSEARCH_INTERVAL_SECONDS", c.OpenSearchIntervalSeconds},
+		{"HEATMAP_BATCH_SIZE", c.HeatmapBatchSize},
+		{"HEATMAP_INTERVAL_SECONDS", c.HeatmapIntervalSeconds},
+		{"STATS_ROLLUP_INTERVAL_SECONDS", c.StatsRollupIntervalSeconds},
+		{"WEBHOOK_SLO_WINDOW_MINUTES", c.WebhookSLOWindowMinutes},
+	}
+	for _, s := range positive {
+		if s.value <= 0 {
+			problems = append(problems, fmt.Sprintf("%s: must be > 0, got %d", s.key, s.value))
+		}
+	}
+
+	nonNegative := []intSetting{
+		{"WEBHOOK_MAX_RETRIES", c.MaxRetries},
+		{"CHECKS_PARTITION_PREMAKE_DAYS", c.CheckPartitionPremakeDays},
+		{"CHECKS_RETENTION_DAYS", c.CheckRetentionDays},
+		{"CHECK_EVENTS_STREAM_MAX_LEN", c.CheckEventsStreamMaxLen},
+		{"PG_DB_REPLICA_MAX_LAG_SECONDS", c.DBReplicaMaxLagSeconds},
+		{"PG_SLOW_QUERY_MS", c.DBSlowQueryMs},
+		{"PG_POOL_MAX_CONNS", c.DBPoolMaxConns},
+		{"PG_POOL_MIN_CONNS", c.DBPoolMinConns},
+		{"PG_POOL_MAX_CONN_LIFETIME_SECONDS", c.DBPoolMaxConnLifetimeSeconds},
+		{"PG_POOL_MAX_CONN_IDLE_SECONDS", c.DBPoolMaxConnIdleSeconds},
+		{"PG_POOL_HEALTH_CHECK_SECONDS", c.DBPoolHealthCheckSeconds},
+		{"REDIS_POOL_SIZE", c.RedisPoolSize},
+		{"REDIS_MIN_IDLE_CONNS", c.RedisMinIdleConns},
+		{"REDIS_POOL_TIMEOUT_SECONDS", c.RedisPoolTimeoutSeconds},
+		{"REDIS_CONN_MAX_LIFETIME_SECONDS", c.RedisConnMaxLifetimeSeconds},
+		{"PREDICTION_HORIZON_SECONDS", c.PredictionHorizonSeconds},
+		{"LOG_FILE_MAX_BACKUPS", c.LogFileMaxBackups},
+		{"LOG_FILE_MAX_AGE_DAYS", c.LogFileMaxAgeDays},
+		{"ACCESS_LOG_SAMPLE_INITIAL", c.AccessLogSampleInitial},
+		{"ACCESS_LOG_SAMPLE_THEREAFTER", c.AccessLogSampleThereafter},
+		{"CORS_MAX_AGE_SECONDS", c.CORSMaxAgeSeconds},
+		{"CHAOS_WEBHOOK_LATENCY_MS", c.ChaosWebhookLatencyMs},
+		{"CHAOS_QUEUE_LATENCY_MS", c.ChaosQueueLatencyMs},
+		{"OPS_ALERT_COOLDOWN_MINUTES", c.OpsAlertCooldownMinutes},
+		{"OPS_ALERT_MIN_SAMPLES", c.OpsAlertMinSamples},
+		{"OPS_ALERT_QUEUE_DEPTH", c.OpsAlertQueueDepth},
+		{"OPENSEARCH_SETTLE_SECONDS", c.OpenSearchSettleSeconds},
+		{"HEATMAP_SETTLE_SECONDS", c.HeatmapSettleSeconds},
+		{"STATS_ROLLUP_SETTLE_SECONDS", c.StatsRollupSettleSeconds},
+		{"STATS_FRESHNESS_SECONDS", c.StatsFreshnessSeconds},
+		{"WEBHOOK_SLO_LATENCY_MS", c.WebhookSLOLatencyMs},
+		{"WEBHOOK_SLO_MIN_DELIVERIES", c.WebhookSLOMinDeliveries},
+	}
+	for _, s := range nonNegative {
+		if s.value < 0 {
+			problems = append(problems, fmt.Sprintf("%s: must be >= 0, got %d", s.key, s.value))
+		}
+	}
+
+	for _, s := range []intSetting{
+		{"CHAOS_WEBHOOK_FAILURE_PERCENT", c.ChaosWebhookFailurePercent},
+		{"CHAOS_QUEUE_FAILURE_PERCENT", c.ChaosQueueFailurePercent},
+		{"OPS_ALERT_WEBHOOK_FAILURE_PERCENT", c.OpsAlertWebhookFailurePercent},
+		{"OPS_ALERT_CACHE_ERROR_PERCENT", c.OpsAlertCacheErrorPercent},
+	} {
+		if s.value < 0 || s.value > 100 {
+			problems = append(problems, fmt.Sprintf("%s: must be between 0 and 100, got %d", s.key, s.value))
+		}
+	}
+	if c.OpsAlertSlackWebhookURL != "" {
+		if u, err := url.Parse(c.OpsAlertSlackWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, "OPS_ALERT_SLACK_WEBHOOK_URL: invalid http(s) URL")
+		}
+	}
+	if (c.OpsAlertTelegramBotToken == "") != (c.OpsAlertTelegramChatID == "") {
+		problems = append(problems, "OPS_ALERT_TELEGRAM_BOT_TOKEN and OPS_ALERT_TELEGRAM_CHAT_ID: must be set together")
+	}
+	if c.OpenSearchURL != "" {
+		if u, err := url.Parse(c.OpenSearchURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, "OPENSEARCH_URL: invalid http(s) URL")
+		}
+		if !indexPrefixPattern.MatchString(c.OpenSearchIndexPrefix) {
+			problems = append(problems, fmt.Sprintf("OPENSEARCH_INDEX_PREFIX: expected lowercase letters, digits, - and _, got %q",
+				c.OpenSearchIndexPrefix))
+		}
+	}
+	if c.DBPoolMaxConns > 0 && c.DBPoolMinConns > c.DBPoolMaxConns {
+		problems = append(problems, fmt.Sprintf("PG_POOL_MIN_CONNS: must not exceed PG_POOL_MAX_CONNS (%d), got %d", c.DBPoolMaxConns, c.DBPoolMinConns))
+	}
+	if c.RedisPoolSize > 0 && c.RedisMinIdleConns > c.RedisPoolSize {
+		problems = append(problems, fmt.Sprintf("REDIS_MIN_IDLE_CONNS: must not exceed REDIS_POOL_SIZE (%d), got %d", c.RedisPoolSize, c.RedisMinIdleConns))
+	}
+	switch c.WebhookSLOPercentile {
+	case 50, 95, 99:
+	default:
+		problems = append(problems, fmt.Sprintf("WEBHOOK_SLO_PERCENTILE: must be 50, 95 or 99, got %d", c.WebhookSLOPercentile))
+	}
+	if c.HeatmapResolution < 0 || c.HeatmapResolution > 15 {
+		problems = append(problems, fmt.Sprintf("HEATMAP_RESOLUTION: must be an H3 resolution between 0 and 15, got %d", c.HeatmapResolution))
+	}
+	if c.ChaosEnabled() && c.IsProduction() {
+		problems = append(problems, "CHAOS_*: failure injection is not allowed with ENV=production")
+	}
+
+	if c.CheckBatchEnabled {
+		if c.CheckBatchBuffer < c.CheckBatchSize {
+			problems = append(problems, fmt.Sprintf("CHECK_BATCH_BUFFER: must be >= CHECK_BATCH_SIZE (%d), got %d",
+				c.CheckBatchSize, c.CheckBatchBuffer))
+		}
+		if c.CheckBatchOverflow != "sync" {
+			problems = append(problems, fmt.Sprintf("CHECK_BATCH_OVERFLOW: expected sync, got %q", c.CheckBatchOverflow))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+}
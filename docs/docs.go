@@ -15,31 +15,95 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/incidents": {
+        "/api/v1/admin/config": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получить все инциденты с поддержкой пагинации",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "admin"
                 ],
-                "summary": "Получить список инцидентов с пагинацией (оператор)",
-                "parameters": [
+                "summary": "Текущие значения перезагружаемых настроек (оператор)",
+                "operationId": "getConfig",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.RuntimeConfigResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/config/reload": {
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Номер страницы (по умолчанию 1)",
-                        "name": "page",
-                        "in": "query"
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Перечитывает конфигурацию без рестарта: уровень логирования, TTL кэша, политику ретраев вебхуков",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Перечитать конфигурацию (оператор)",
+                "operationId": "reloadConfig",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.RuntimeConfigResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/dashboard": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Активные зоны, глубина очереди вебхуков, последние проверки и неудачные доставки",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Сводка для админки (оператор)",
+                "operationId": "getDashboard",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "Лимит на страницу (по умолчанию 10, максимум 100)",
+                        "description": "Размер списков проверок и неудачных доставок (по умолчанию 50, максимум 500)",
                         "name": "limit",
                         "in": "query"
                     }
@@ -48,36 +112,70 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentsListResponse"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.DashboardResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверные параметры пагинации",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/admin/locks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Какими блокировками периодических задач владеет реплика, обработавшая запрос, сколько раз она их\nзахватывала и теряла и сколько запусков задач выполнила или пропустила. Счетчики — с момента запуска реплики",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Блокировки периодических задач (оператор)",
+                "operationId": "getWorkerLocks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WorkerLocksResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/operators": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создать новую опасную зону (требуется API key)",
+                "description": "Заводит оператора с паролем для входа через /api/v1/auth/login и/или с субъектом OIDC. Роль по умолчанию editor; заводить операторов может только публикатор",
                 "consumes": [
                     "application/json"
                 ],
@@ -85,17 +183,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "admin"
                 ],
-                "summary": "Создать инцидент (оператор)",
+                "summary": "Создать учетную запись оператора (оператор)",
+                "operationId": "createOperator",
                 "parameters": [
                     {
-                        "description": "Данные инцидента",
+                        "description": "Данные оператора",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentCreateRequest"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.OperatorCreateRequest"
                         }
                     }
                 ],
@@ -103,235 +202,330 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentCreateResponse"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.OperatorCreateResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/incidents/stats": {
+        "/api/v1/admin/query-stats": {
             "get": {
-                "description": "Получить статистику уникальных пользователей за последние N минут",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Число вызовов, ошибки и гистограмма длительности каждого запроса с момента запуска\nили последнего сброса, начиная с запросов с наибольшим суммарным временем",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "admin"
+                ],
+                "summary": "Статистика запросов к БД (оператор)",
+                "operationId": "getQueryStats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Сколько запросов вернуть (по умолчанию 50, максимум 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Статистика по зонам",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.StatsResponse"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.QueryStatsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Сбросить статистику запросов к БД (оператор)",
+                "operationId": "resetQueryStats",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler_http.ErrorResponse"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/incidents/{incident_id}": {
+        "/api/v1/approvals": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Детали конкретной зоны опасности",
+                "description": "Заявки от новых к старым. Без status возвращаются заявки во всех статусах",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "approvals"
                 ],
-                "summary": "Получить инцидент по ID (оператор)",
+                "summary": "Заявки на публикацию критических зон (оператор)",
+                "operationId": "listApprovals",
                 "parameters": [
                     {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
                         "type": "string",
-                        "description": "ID инцидента",
-                        "name": "incident_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Фильтр по статусу",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер списка (по умолчанию 50, максимум 500)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ApprovalsListResponse"
                         }
                     },
-                    "401": {
-                        "description": "Не авторизован",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Инцидент не найден",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/approvals/{approval_id}/approve": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Полное обновление данных существующей опасной зоны (PUT)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Публикует зону по заявке. Одобрить может только публикатор, который не подавал заявку",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "approvals"
                 ],
-                "summary": "Обновить инцидент (оператор)",
+                "summary": "Одобрить публикацию критической зоны (публикатор)",
+                "operationId": "approveIncident",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID инцидента",
-                        "name": "incident_id",
+                        "description": "ID заявки",
+                        "name": "approval_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Полные данные инцидента",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentUpdateRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Инцидент обновлен",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ApprovalResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверный ID",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Не авторизован",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав или одобрение собственной заявки",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Инцидент не найден",
+                        "description": "Заявка или зона не найдена",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Заявка уже рассмотрена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/approvals/{approval_id}/reject": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Мягкое удаление опасной зоны",
+                "description": "Закрывает заявку, зона остается неопубликованной. Автор заявки может отозвать ее сам",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "approvals"
                 ],
-                "summary": "Удалить инцидент (оператор)",
+                "summary": "Отклонить публикацию критической зоны (публикатор)",
+                "operationId": "rejectIncident",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID инцидента",
-                        "name": "incident_id",
+                        "description": "ID заявки",
+                        "name": "approval_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Причина отказа",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.ApprovalRejectRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Инцидент удален",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ApprovalResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID",
+                        "description": "Неверный ID или причина",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Не авторизован",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Инцидент не найден",
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Заявка уже рассмотрена",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/location/check": {
+        "/api/v1/auth/login": {
             "post": {
-                "description": "Проверить, попадает ли точка в опасную зону (публичный эндпоинт)",
+                "description": "Проверяет логин и пароль оператора и выдает короткоживущий JWT. Токен передается в заголовке Authorization: Bearer вместо API-ключа",
                 "consumes": [
                     "application/json"
                 ],
@@ -339,17 +533,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "location"
+                    "auth"
                 ],
-                "summary": "Проверить координаты",
+                "summary": "Вход оператора",
+                "operationId": "login",
                 "parameters": [
                     {
-                        "description": "Координаты для проверки",
+                        "description": "Учетные данные оператора",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.LocationCheckRequest"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.LoginRequest"
                         }
                     }
                 ],
@@ -357,149 +552,7143 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.LocationCheckResponse"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.LoginResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler_http.ErrorResponse"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler_http.ErrorResponse"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/system/health": {
+        "/api/v1/erasures": {
             "get": {
-                "description": "Проверка состояния системы",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Заявки от новых к старым. Без status возвращаются заявки во всех статусах",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "system"
+                    "privacy"
                 ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.HealthResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                "summary": "Заявки на удаление данных пользователей (оператор)",
+                "operationId": "listErasures",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "confirmed",
+                            "completed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Фильтр по статусу",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер списка (по умолчанию 50, максимум 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ErasuresListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.HealthResponse"
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_4otis_geonotify-service_internal_dto_req.IncidentCreateRequest": {
+        },
+        "/api/v1/erasures/{erasure_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Статус заявки; у выполненной — сколько записей удалено или обезличено",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Заявка на удаление данных пользователя (оператор)",
+                "operationId": "getErasure",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заявки",
+                        "name": "erasure_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ErasureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/erasures/{erasure_id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Закрывает заявку, ожидающую подтверждения, данные остаются. Автор заявки может отозвать ее сам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Отменить удаление данных пользователя (публикатор)",
+                "operationId": "cancelErasure",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заявки",
+                        "name": "erasure_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ErasureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Заявка уже подтверждена или отменена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/erasures/{erasure_id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Ставит заявку в очередь на удаление, задача выполняет ее раз в ERASURE_INTERVAL_SECONDS.\nПодтвердить может только публикатор, который не подавал заявку",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Подтвердить удаление данных пользователя (публикатор)",
+                "operationId": "confirmErasure",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заявки",
+                        "name": "erasure_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ErasureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав или подтверждение собственной заявки",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Заявка уже подтверждена или отменена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Группы пользователей (оператор)",
+                "operationId": "listGroups",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupsListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Группа объединяет пользователей, например автопарк или школьный класс. Менять группу могут ее создатель,\nоператоры из managers и публикаторы",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Создать группу пользователей (оператор)",
+                "operationId": "createGroup",
+                "parameters": [
+                    {
+                        "description": "Название, менеджеры и участники",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.GroupCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/{group_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Группа пользователей (оператор)",
+                "operationId": "getGroup",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID группы",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет группу вместе с участниками и получателем вебхуков группы",
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Удалить группу пользователей (менеджер группы)",
+                "operationId": "deleteGroup",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID группы",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Оператор не управляет группой",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Меняет только переданные поля; managers заменяет список менеджеров целиком",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Изменить группу пользователей (менеджер группы)",
+                "operationId": "updateGroup",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID группы",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.GroupPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Оператор не управляет группой",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/{group_id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Участники группы (оператор)",
+                "operationId": "listGroupMembers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID группы",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupMembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Пользователи, которые уже состоят в группе, пропускаются; added — число добавленных",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Добавить участников группы (менеджер группы)",
+                "operationId": "addGroupMembers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID группы",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID пользователей",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.GroupMembersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupMembersAddedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Оператор не управляет группой",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/{group_id}/members/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Исключить участника группы (менеджер группы)",
+                "operationId": "removeGroupMember",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID группы",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Оператор не управляет группой",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа не найдена или пользователь в ней не состоит",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/{group_id}/status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Участники, которые по последней проверке координат находятся в действующих зонах, и зоны каждого из них",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Участники группы в зонах (оператор)",
+                "operationId": "getGroupStatus",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID группы",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/{group_id}/webhook": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получатель группы получает только событие group.alert: один сводный вебхук на каждую проверку, в которой\nучастник группы вошел в зоны. Повторный вызов заменяет URL и секрет и включает получателя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Задать получателя вебхуков группы (менеджер группы)",
+                "operationId": "putGroupWebhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID группы",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "URL и секрет получателя",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.GroupWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или URL",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Оператор не управляет группой",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет получателя вместе с его вебхуками, включая недоставленные",
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Удалить получателя вебхуков группы (менеджер группы)",
+                "operationId": "deleteGroupWebhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID группы",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Оператор не управляет группой",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Группа или ее получатель не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получить все инциденты с поддержкой пагинации. С параметром cursor (пустой — первая страница)\nсписок листается по курсору из next_cursor без подсчета общего числа страниц — так дальние\nстраницы не замедляются на больших таблицах. page вместе с cursor не передается",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Получить список инцидентов с пагинацией (оператор)",
+                "operationId": "listIncidents",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор страницы из next_cursor предыдущего ответа",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "exact",
+                            "estimated"
+                        ],
+                        "type": "string",
+                        "description": "Точное число страниц или оценка (total_estimated: true)",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Лимит на страницу (по умолчанию 10, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "published",
+                            "archived"
+                        ],
+                        "type": "string",
+                        "description": "Фильтр по статусу",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки клиента: названия и описания отдаются в переводе",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentsListResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия набора инцидентов для этих параметров"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Список не изменился"
+                    },
+                    "400": {
+                        "description": "Неверные параметры пагинации или курсор",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создать новую опасную зону (требуется API key). Вместо координат можно передать address — он будет геокодирован.\nРедактор создает только черновики; без status зона публикуется сразу, если это разрешено роли и ревью не обязательно",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Создать инцидент (оператор)",
+                "operationId": "createIncident",
+                "parameters": [
+                    {
+                        "description": "Данные инцидента",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Отклонить зону, перекрывающую активные (по умолчанию — INCIDENT_OVERLAP_CHECK)",
+                        "name": "check_overlap",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Публикация недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Зона перекрывает активные зоны или критическую зону нельзя опубликовать без одобрения",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentOverlapResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Сервис геокодирования недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/batch": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Включить, выключить или удалить несколько зон одной транзакцией. Если хотя бы одна зона не найдена или ждет решения по заявке, ничего не меняется. Доступно только публикатору.\nВключение переводит запланированные и завершенные зоны в active, выключение — действующие в resolved",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Пакетное изменение инцидентов (оператор)",
+                "operationId": "batchIncidents",
+                "parameters": [
+                    {
+                        "description": "ID инцидентов и действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Зона в архивной стадии не включается или ждет решения по заявке",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создать опубликованную зону из нескольких опубликованных: форма — MultiPolygon из их полигонов\n(круглые зоны приближаются многоугольниками), уровень опасности — наибольший, срок действия — наибольший.\nИсходные зоны переводятся в архив, связь с новой зоной видна в истории /lineage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Объединить зоны (публикатор)",
+                "operationId": "mergeIncidents",
+                "parameters": [
+                    {
+                        "description": "ID объединяемых зон и данные новой зоны",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Одна из зон не опубликована или ждет решения по заявке",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/stats": {
+            "get": {
+                "description": "Получить статистику уникальных пользователей за последние N минут.\nТочный подсчет берется из поминутной сводки, если она отстает не больше чем на STATS_FRESHNESS_SECONDS:\nтогда окно заканчивается на последней сведенной минуте и period_start сдвинут на ее задержку.\nС count=estimated числа — оценки планировщика Postgres (estimated: true), а не точный подсчет",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Статистика по зонам",
+                "operationId": "getStats",
+                "parameters": [
+                    {
+                        "enum": [
+                            "exact",
+                            "estimated"
+                        ],
+                        "type": "string",
+                        "description": "Точный подсчет или оценка (по умолчанию exact)",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.StatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Детали конкретной зоны опасности",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Получить инцидент по ID (оператор)",
+                "operationId": "getIncident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки клиента: название и описание отдаются в переводе",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия инцидента"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Инцидент не изменился"
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Полное обновление данных существующей опасной зоны (PUT).\nПовышение опубликованной зоны до critical не применяется сразу: остальные поля меняются, а на критичность создается заявка (202)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Обновить инцидент (оператор)",
+                "operationId": "updateIncident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Полные данные инцидента",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Инцидент обновлен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.MessageResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Повышение до critical ждет одобрения",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ApprovalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Зона опубликована, изменить ее может только публикатор",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "is_active: зона в архивной стадии не включается, или зона ждет решения по заявке",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Сервис геокодирования недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Мягкое удаление опасной зоны",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Удалить инцидент (оператор)",
+                "operationId": "deleteIncident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Инцидент удален",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Зона опубликована, удалить ее может только публикатор",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Изменить только переданные поля опасной зоны (PATCH).\nПовышение опубликованной зоны до critical не применяется сразу: остальные поля меняются, а на критичность создается заявка (202).\n\"expires_at\": null снимает срок действия; без поля expires_at срок не меняется",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Частично обновить инцидент (оператор)",
+                "operationId": "patchIncident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля инцидента",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Инцидент обновлен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.MessageResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Повышение до critical ждет одобрения",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ApprovalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Зона опубликована, изменить ее может только публикатор",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "is_active: зона в архивной стадии не включается, или зона ждет решения по заявке",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Сервис геокодирования недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Снять зону с публикации или отложить черновик. Архивная зона не участвует в проверках, но ее можно опубликовать снова",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Архивировать инцидент (публикатор)",
+                "operationId": "archiveIncident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Зона уже в архиве или ждет решения по заявке",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Метаданные вложений и временные ссылки на скачивание",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Список вложений инцидента (оператор)",
+                "operationId": "listAttachments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.AttachmentsListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Загружает изображение или PDF (карта обстановки, официальное уведомление) в объектное хранилище",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Прикрепить файл к инциденту (оператор)",
+                "operationId": "uploadAttachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Изображение (png, jpeg, gif, webp) или PDF",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/attachments/{attachment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет метаданные вложения и сам файл из хранилища",
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Удалить вложение (оператор)",
+                "operationId": "deleteAttachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID вложения",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/backtest": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Считает, сколько пользователей получили бы алерт, если бы зона действовала последние hours часов:\nпроверки координат за период прогоняются через ее форму. Подходит для черновиков и измененных зон,\nradius_m подменяет радиус круглой зоны, чтобы подобрать размер до сохранения. Стадия, расписание\nи срок действия зоны не учитываются. users_newly_alerted — пользователи, которые в зоне не получили\nалертов от действовавших тогда зон. За один запрос учитывается не больше 100 000 проверок (truncated)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Прогнать недавние проверки через зону (оператор)",
+                "operationId": "backtestIncident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Период и радиус",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentBacktestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentBacktestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных или радиус для полигональной зоны",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Заметки операторов о ходе ситуации в зоне, новые первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Комментарии зоны (оператор)",
+                "operationId": "listIncidentComments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер списка (по умолчанию 50, максимум 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentCommentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или limit",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Заметка о ходе ситуации, до 2000 символов; автор — оператор запроса. Комментировать можно и опубликованную зону.\nС WEBHOOK_INCLUDE_COMMENTS=true комментарий к опубликованной зоне отправляет incident.updated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Добавить комментарий к зоне (оператор)",
+                "operationId": "createIncidentComment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentCommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/geometry": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Заменить форму опасной зоны: Point с radius_m (в properties для Feature), Polygon или MultiPolygon.\nВнешние кольца — против часовой стрелки, дыры — по часовой, самопересечения не допускаются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Задать форму зоны в GeoJSON (оператор)",
+                "operationId": "setIncidentGeometry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "GeoJSON-геометрия или Feature",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Форма зоны обновлена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный GeoJSON",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Зона опубликована, изменить ее может только публикатор",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Слишком большой GeoJSON",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/lineage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Связи зоны с исходными и полученными из нее зонами: кто и когда объединил или разделил зоны",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "История слияний и разделений зоны (оператор)",
+                "operationId": "getIncidentLineage",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentLineageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Перевести черновик или архивную зону в статус published: с этого момента она участвует в проверках.\nПри обязательном ревью публикатор не может быть автором или последним редактором зоны.\nКритическая зона не публикуется сразу: создается заявка (202), зону выпускает одобривший ее второй оператор",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Опубликовать инцидент (публикатор)",
+                "operationId": "publishIncident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Зона критическая, заявка ждет одобрения",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ApprovalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав или публикация собственной правки",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Зона уже опубликована или ждет одобрения",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/relations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Связи с другими зонами со стороны этой зоны: parent — она входит в related_id, child — related_id входит в нее,\nsupersedes — она заменяет related_id, superseded_by — related_id заменяет ее. Связи с удаленными зонами не возвращаются",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Связи зоны (оператор)",
+                "operationId": "listIncidentRelations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentRelationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "parent — зона входит в related_id (у зоны одна родительская), supersedes — зона заменяет related_id,\nнапример зона эвакуации заменяет прежнюю зону предупреждения. Редактор связывает только черновики.\nОпубликованные зоны получают incident.updated, где supersedes и superseded_by перечисляют связанные зоны",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Связать зону с другой (оператор)",
+                "operationId": "createIncidentRelation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Связь",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentRelationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentRelationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных или связь зоны с самой собой",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Связь уже есть, у зоны уже есть родительская или связь замкнет цикл",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/relations/{type}/{related_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет связь, заданную со стороны зоны: type — parent или supersedes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Удалить связь зоны (оператор)",
+                "operationId": "deleteIncidentRelation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "parent",
+                            "supersedes"
+                        ],
+                        "type": "string",
+                        "description": "Тип связи",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID связанного инцидента",
+                        "name": "related_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент или связь не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/split": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Заменить опубликованную зону несколькими: форма каждой части задается GeoJSON, как в PUT /geometry,\nописание, уровень опасности, расписание и срок действия наследуются. Исходная зона переводится в архив",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Разделить зону (публикатор)",
+                "operationId": "splitIncident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Части зоны",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentSplitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentSplitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных или GeoJSON",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Зона не опубликована или ждет решения по заявке",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/state": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Сменить стадию жизни опасности: planned → active, resolved; active → contained, resolved;\ncontained → active, resolved; resolved → active, archived. В проверках участвуют зоны в active и contained.\nЧерновики переводит и редактор, остальные зоны — публикатор. Получатели вебхуков видят incident.state_changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Перевести зону в другую стадию (оператор)",
+                "operationId": "transitionIncident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая стадия",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentStateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или стадия",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Переход из текущей стадии не разрешен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/translations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Названия и описания зоны на других языках. Клиенты получают их в проверках координат и списках\nинцидентов по заголовку Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Переводы зоны (оператор)",
+                "operationId": "listIncidentTranslations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentTranslationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/translations/{locale}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создать или заменить название и описание зоны на языке locale (тег BCP 47: en, pt-BR).\nПустое описание перевода — клиент получит исходное. Права те же, что на изменение зоны",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Задать перевод зоны (оператор)",
+                "operationId": "putIncidentTranslation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык перевода",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Перевод",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentTranslationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных или язык",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Зона опубликована, изменить ее может только публикатор",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Клиенты, предпочитающие этот язык, снова получат исходное название и описание",
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Удалить перевод зоны (оператор)",
+                "operationId": "deleteIncidentTranslation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык перевода",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный ID или язык",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Зона опубликована, изменить ее может только публикатор",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент или перевод не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/incidents/{incident_id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Версии центра, радиуса и полигонов зоны от первой к последней: новая версия записывается при каждом изменении формы.\nЗоны, созданные до появления истории, имеют одну версию с формой на момент обновления и датой создания зоны",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "История формы зоны (оператор)",
+                "operationId": "listIncidentVersions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инцидента",
+                        "name": "incident_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentVersionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Инцидент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/location/check": {
+            "post": {
+                "description": "Проверить, попадает ли точка в опасную зону (публичный эндпоинт). Устарел, используйте /api/v2/location/check",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "Проверить координаты",
+                "operationId": "checkLocation",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "description": "Координаты для проверки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.LocationCheckRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Добавить в ответ и вебхук название места (обратное геокодирование)",
+                        "name": "resolve_address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только сопоставить точку с зонами: проверка не сохраняется, вебхуки и алерты не отправляются",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Только для операторов: проверить по зонам, действовавшим в этот момент в прошлом (RFC 3339), в их тогдашней форме; проверка не сохраняется, как с dry_run",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки клиента: названия и описания зон отдаются в переводе",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.LocationCheckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "at без аутентификации оператора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/location/simulate": {
+            "post": {
+                "description": "То же, что /api/v2/location/check?dry_run=true: точка сопоставляется с действующими зонами, но проверка\nне сохраняется и не попадает в статистику, вебхуки, события и алерты пользователю не отправляются.\nДля тестирования интеграций (публичный эндпоинт)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "Симулировать проверку координат",
+                "operationId": "simulateLocation",
+                "parameters": [
+                    {
+                        "description": "Координаты для проверки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.LocationCheckRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Добавить в ответ название места (обратное геокодирование)",
+                        "name": "resolve_address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Только для операторов: проверить по зонам, действовавшим в этот момент в прошлом (RFC 3339), в их тогдашней форме",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки клиента: названия и описания зон отдаются в переводе",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_v2_resp.LocationCheckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "at без аутентификации оператора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/public/incidents": {
+            "get": {
+                "description": "Действующие опубликованные зоны без авторизации: только название, геометрия, стадия и уровень опасности,\nбез описаний, адресов и авторов. Ответ кэшируется на PUBLIC_API_CACHE_SECONDS, запросы с одного адреса\nограничены PUBLIC_API_RATE_LIMIT_PER_MINUTE в минуту",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Действующие зоны (публичный доступ)",
+                "operationId": "listPublicIncidents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки клиента: названия отдаются в переводе",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.PublicIncidentsListResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия набора зон для этих языков"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Список не изменился"
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stats/heatmap": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Число проверок координат и проверок с алертом по ячейкам H3 за период, начиная с самых частых.\nСчетчики почасовые и сводятся фоновым воркером, поэтому период учитывается с точностью до часа,\nа последние проверки появляются с задержкой около минуты. Разрешение — не мельче HEATMAP_RESOLUTION сервера",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Тепловая карта проверок (оператор)",
+                "operationId": "getCheckHeatmap",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Разрешение H3 (по умолчанию 7)",
+                        "name": "resolution",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода, RFC 3339 (по умолчанию сутки назад)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода, RFC 3339 (по умолчанию сейчас); не больше 92 дней от from",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.HeatmapResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Сервер собран без cgo, тепловая карта недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stats/incidents": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Уникальные пользователи, проверки, проверки с алертом и число проверок в каждой зоне за window_minutes минут\nдо period_end — последней минуты, до которой фоновый воркер свел проверки. Редактор не видит внутренние зоны.\nПроверки, сохраненные до появления сводки по зонам, в счетчики зон не попадают",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Проверки по зонам (оператор)",
+                "operationId": "getIncidentStats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Длина окна в минутах (по умолчанию STATS_TIME_WINDOWS_MINUTES)",
+                        "name": "window_minutes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Проверки еще не сведены",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/queues": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Число задач в очереди доставки, недоставленные вебхуки в outbox по состояниям, возраст самого старого\nиз них и отставание опроса outbox. Если очередь недоступна, ее ошибка возвращается в queue.error, а outbox считается",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Глубина и отставание очереди вебхуков (оператор)",
+                "operationId": "getQueues",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.QueuesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/usage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Число запросов, проверок координат, алертов и вебхуков каждого API-ключа по суткам (UTC) с from по to, квоты ключей и их счетчики за текущие сутки\nи месяц. По суткам данные переносятся из Redis периодически, поэтому текущие сутки в days отстают; current точен.\nКлюч из API_KEYS видит только себя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Запросы API-ключей и квоты (оператор, клиент)",
+                "operationId": "getUsage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Первые сутки, YYYY-MM-DD (по умолчанию — начало текущего месяца)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последние сутки, YYYY-MM-DD (по умолчанию — сегодня)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.UsageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/usage/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Строка на ключ и сутки (UTC) с from по to: запросы, записанные проверки координат, проверки с алертом\nи вебхуки, поставленные в очередь доставки. Сутки без использования не выгружаются. По умолчанию —\nвчерашние сутки, уже полностью перенесенные из Redis. Ключ из API_KEYS выгружает только себя",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Выгрузка использования API-ключей для биллинга (оператор, клиент)",
+                "operationId": "exportUsage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Первые сутки, YYYY-MM-DD (по умолчанию — вчера)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последние сутки, YYYY-MM-DD (по умолчанию — вчера)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Формат выгрузки",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.UsageExportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/webhook-slo": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Для каждого общего получателя — перцентили p50/p95/p99 задержки от создания проверки до успешной доставки\nза последние WEBHOOK_SLO_WINDOW_MINUTES и нарушение цели WEBHOOK_SLO_LATENCY_MS; breached — число нарушителей.\nПолучатели без доставок за окно возвращаются с delivered 0",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Задержка доставки вебхуков по получателям (оператор)",
+                "operationId": "getWebhookSLO",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookSLOListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/alerts/stream": {
+            "get": {
+                "description": "Server-Sent Events: событие alert приходит, когда проверка пользователя попала в зону или рядом с его последней точкой создана новая зона",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "alerts"
+                ],
+                "summary": "Поток алертов пользователя",
+                "operationId": "streamAlerts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.AlertEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/data": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Заводит заявку на удаление данных пользователя по запросу на забвение. Данные удаляются фоновой задачей\nпосле подтверждения другим публикатором: проверки координат (mode=anonymize оставляет их для статистики\nбез user_id и с координатами, округленными до 0.01°), вебхуки по ним, зоны присутствия, настройки алертов,\nучастие в группах, последняя точка в Redis и документы в OpenSearch. Заявка остается как запись аудита",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Удалить данные пользователя (оператор)",
+                "operationId": "requestUserErasure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "delete",
+                            "anonymize"
+                        ],
+                        "type": "string",
+                        "description": "Способ удаления (по умолчанию delete)",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ErasureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный user_id или mode",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "У пользователя уже есть незавершенная заявка",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/data-export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Все данные о пользователе одним JSON-архивом по запросу субъекта данных: проверки координат с временем,\nполученные алерты, настройки алертов, текущие зоны и группы. Первый запрос заводит выгрузку и отвечает 202,\nархив собирается в фоне; повторные запросы возвращают ее статус, а готовая выгрузка (200) — временную ссылку\ndownload_url. Архив хранится DATA_EXPORT_RETENTION_HOURS часов, после этого запрос заводит новую выгрузку",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Выгрузка данных пользователя (оператор)",
+                "operationId": "getUserDataExport",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Архив готов",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.DataExportResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Архив собирается",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.DataExportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный user_id",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/preferences": {
+            "get": {
+                "description": "Порог опасности, окна тишины и каналы доставки алертов. Пользователь без сохраненных настроек\nполучает значения по умолчанию: все алерты в SSE-поток",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alerts"
+                ],
+                "summary": "Настройки алертов пользователя",
+                "operationId": "getUserPreferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.UserPreferencesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Заменяет настройки целиком. Алерты о зонах с уровнем опасности ниже min_severity не отправляются, в окна тишины\nquiet_hours (по времени timezone) отправляются только алерты о критических зонах. Сверх max_alerts_per_hour\nалертов за час отправляются тоже только критические. Остальные уходят в каналы channels: stream — SSE-поток\n/api/v1/users/{user_id}/alerts/stream, webhook — событие вебхука user.alert",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alerts"
+                ],
+                "summary": "Задать настройки алертов пользователя",
+                "operationId": "putUserPreferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Настройки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.UserPreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.UserPreferencesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный уровень опасности, окно тишины, часовой пояс, канал или лимит",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook-endpoints": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Все зарегистрированные получатели. Секреты не возвращаются",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Получатели вебхуков (оператор)",
+                "operationId": "listWebhookEndpoints",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookEndpointsListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получатель начинает получать события из events (\"*\" — все события) сразу после регистрации.\nВебхуки подписываются секретом, если он задан. Не заданные max_retries, retry_delay_seconds,\nbackoff и timeout_seconds берутся из настроек сервиса. С batch_size события копятся до batch_size штук\nили batch_wait_ms и уходят одним NDJSON-запросом (X-Geonotify-Event: batch), с compression=gzip тело\nсжимается (Content-Encoding: gzip); подпись считается по несжатому телу",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Зарегистрировать получателя вебхуков (публикатор)",
+                "operationId": "createWebhookEndpoint",
+                "parameters": [
+                    {
+                        "description": "URL, секрет и события получателя",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.WebhookEndpointCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный URL, события, политика попыток или батчинг",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook-endpoints/{endpoint_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Получатель вебхуков (оператор)",
+                "operationId": "getWebhookEndpoint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID получателя",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Получатель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет получателя вместе с его вебхуками, включая недоставленные",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Удалить получателя вебхуков (публикатор)",
+                "operationId": "deleteWebhookEndpoint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID получателя",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Получатель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Меняет только переданные поля. Выключенному получателю новые события не создаются,\nа уже созданные вебхуки помечаются недоставленными. Новая политика попыток применяется со следующей попытки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Изменить получателя вебхуков (публикатор)",
+                "operationId": "updateWebhookEndpoint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID получателя",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.WebhookEndpointPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID, URL, события, политика попыток или батчинг",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Получатель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook-endpoints/{endpoint_id}/slo": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Перцентили p50/p95/p99 задержки от создания проверки до успешной доставки вебхука за последние WEBHOOK_SLO_WINDOW_MINUTES\nи сравнение перцентиля WEBHOOK_SLO_PERCENTILE с целью WEBHOOK_SLO_LATENCY_MS. Недоставленные вебхуки в задержку не входят",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Задержка доставки получателю и цель SLO (оператор)",
+                "operationId": "getWebhookEndpointSLO",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID получателя",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookSLOResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Получатель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/redrive": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает в доставку недоставленные вебхуки, созданные в [created_from, created_to), по зоне и получателю,\nсо сброшенным счетчиком и сразу ставит их в очередь. spread_seconds растягивает доставку на окно,\nчтобы не перегрузить получателя после простоя. Без фильтров повторяются все недоставленные вебхуки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Повторить недоставленные вебхуки по фильтру (оператор)",
+                "operationId": "redriveWebhooks",
+                "parameters": [
+                    {
+                        "description": "Фильтры",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.WebhookRedriveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookRedriveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/replay": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает в очередь доставки вебхуки, исчерпавшие попытки (state=failed), со сброшенным счетчиком.\nБез ids повторяются все такие вебхуки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Повторить неотправленные вебхуки (оператор)",
+                "operationId": "replayWebhooks",
+                "parameters": [
+                    {
+                        "description": "ID вебхуков",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.WebhookReplayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookReplayResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/test": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отправляет пример payload на указанный URL тем же механизмом подписи и ретраев, что и реальные вебхуки, и возвращает статус и задержку получателя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Отправить тестовый вебхук (оператор)",
+                "operationId": "testWebhook",
+                "parameters": [
+                    {
+                        "description": "URL получателя и число попыток",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.WebhookTestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookTestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/location/check": {
+            "post": {
+                "description": "Проверить, попадает ли точка в опасную зону, и получить расстояния и азимуты до найденных зон и до ближайшей зоны впереди (публичный эндпоинт)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "Проверить координаты (v2)",
+                "operationId": "checkLocationV2",
+                "parameters": [
+                    {
+                        "description": "Координаты для проверки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.LocationCheckRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Добавить в ответ и вебхук название места (обратное геокодирование)",
+                        "name": "resolve_address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только сопоставить точку с зонами: проверка не сохраняется, вебхуки и алерты не отправляются",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Только для операторов: проверить по зонам, действовавшим в этот момент в прошлом (RFC 3339), в их тогдашней форме; проверка не сохраняется, как с dry_run",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки клиента: названия и описания зон отдаются в переводе",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_v2_resp.LocationCheckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "at без аутентификации оператора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/location/check/batch": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Проверяет до 1000 точек за запрос; проверки сохраняются одной записью в БД, поэтому\nэндпоинт предназначен для трекеров и шлюзов с большим потоком координат. Отклоненная точка\n(например, вне области работы) возвращается с error и не мешает остальным. Адрес не определяется",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "Проверить пачку координат",
+                "operationId": "checkLocationBatch",
+                "parameters": [
+                    {
+                        "description": "Координаты для проверки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.LocationCheckBatchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки клиента: названия и описания зон отдаются в переводе",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_v2_resp.LocationCheckBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Проверка, что процесс запущен и отвечает на запросы",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Liveness probe",
+                "operationId": "liveness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.LivenessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Проверка готовности принимать трафик: БД, Redis, миграции, воркер вебхуков (кроме -mode api). Недоступный Redis не делает сервис неготовым: status=degraded, degraded=true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Readiness probe",
+                "operationId": "readiness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_4otis_geonotify-service_internal_dto_req.ApprovalRejectRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.GroupCreateRequest": {
+            "type": "object",
+            "required": [
+                "managers",
+                "name",
+                "user_ids"
+            ],
+            "properties": {
+                "descr": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "managers": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 127,
+                    "example": "Delivery fleet"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "maxItems": 10000,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.GroupMembersRequest": {
+            "type": "object",
+            "required": [
+                "user_ids"
+            ],
+            "properties": {
+                "user_ids": {
+                    "type": "array",
+                    "maxItems": 10000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.GroupPatchRequest": {
+            "type": "object",
+            "required": [
+                "managers"
+            ],
+            "properties": {
+                "descr": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "managers": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 127,
+                    "minLength": 1
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.GroupWebhookRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentBacktestRequest": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "integer",
+                    "maximum": 720,
+                    "minimum": 1,
+                    "example": 24
+                },
+                "radius_m": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentBatchRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "ids"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "activate",
+                        "deactivate",
+                        "delete"
+                    ]
+                },
+                "ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentCommentRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentCreateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "description": "Address геокодируется, если latitude и longitude не переданы",
+                    "type": "string",
+                    "maxLength": 511
+                },
+                "crs": {
+                    "description": "CRS — система координат центра, по умолчанию EPSG:4326. Радиус всегда в метрах",
+                    "type": "string"
+                },
+                "descr": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt и TTLMinutes взаимоисключающие: TTL отсчитывается от момента запроса",
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 127
+                },
+                "radius_m": {
+                    "type": "number"
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "schedule_duration_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "severity": {
+                    "description": "Severity по умолчанию medium; critical публикуется только с одобрения второго оператора",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high",
+                        "critical"
+                    ]
+                },
+                "state": {
+                    "description": "State по умолчанию active; planned — опасность ожидается, но еще не действует",
+                    "type": "string",
+                    "enum": [
+                        "planned",
+                        "active",
+                        "contained"
+                    ]
+                },
+                "status": {
+                    "description": "Status по умолчанию published для публикатора без обязательного ревью, иначе draft",
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "published"
+                    ]
+                },
+                "ttl_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "visibility": {
+                    "description": "Visibility по умолчанию public; restricted — зона предупреждает без названия и описания и не попадает\nв публичные ленты, internal — еще и скрыта от редакторов, выставляет ее только публикатор",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "restricted",
+                        "internal"
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentMergeRequest": {
+            "type": "object",
+            "required": [
+                "ids",
+                "name"
+            ],
+            "properties": {
+                "descr": {
+                    "type": "string"
+                },
+                "ids": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 2,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 127
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentPatchRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 511
+                },
+                "descr": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt со значением null снимает срок действия",
+                    "type": "string",
+                    "format": "date-time",
+                    "x-nullable": true
+                },
+                "is_active": {
+                    "description": "IsActive меняет стадию так же, как в IncidentUpdateRequest",
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 127,
+                    "minLength": 1
+                },
+                "radius_m": {
+                    "type": "number"
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "schedule_duration_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high",
+                        "critical"
+                    ]
+                },
+                "ttl_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "restricted",
+                        "internal"
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentRelationRequest": {
+            "type": "object",
+            "required": [
+                "related_id",
+                "type"
+            ],
+            "properties": {
+                "related_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "parent",
+                        "supersedes"
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentSplitPart": {
+            "type": "object",
+            "required": [
+                "geometry",
+                "name"
+            ],
+            "properties": {
+                "geometry": {
+                    "description": "Geometry — GeoJSON в том же формате, что и для PUT /incidents/{id}/geometry",
+                    "type": "object"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 127
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentSplitRequest": {
+            "type": "object",
+            "required": [
+                "parts"
+            ],
+            "properties": {
+                "parts": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.IncidentSplitPart"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentStateRequest": {
+            "type": "object",
+            "required": [
+                "state"
+            ],
+            "properties": {
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "planned",
+                        "active",
+                        "contained",
+                        "resolved",
+                        "archived"
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentTranslationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "descr": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 127
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.IncidentUpdateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "description": "Address геокодируется, если latitude и longitude не переданы",
+                    "type": "string",
+                    "maxLength": 511
+                },
+                "descr": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt и TTLMinutes взаимоисключающие: TTL отсчитывается от момента запроса",
+                    "type": "string"
+                },
+                "is_active": {
+                    "description": "IsActive меняет стадию: true для запланированной или завершенной зоны — active,\nfalse для действующей — resolved (см. POST /incidents/{id}/state)",
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 127
+                },
+                "radius_m": {
+                    "type": "number"
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "schedule_duration_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "severity": {
+                    "description": "Severity и Visibility не меняются, если не переданы",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high",
+                        "critical"
+                    ]
+                },
+                "ttl_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "restricted",
+                        "internal"
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.LocationCheckBatchRequest": {
+            "type": "object",
+            "required": [
+                "checks"
+            ],
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.LocationCheckRequest"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.LocationCheckRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "accuracy_m": {
+                    "description": "AccuracyM — радиус погрешности координат в метрах",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "altitude": {
+                    "description": "Altitude — высота над уровнем моря в метрах",
+                    "type": "number"
+                },
+                "crs": {
+                    "description": "CRS — система координат точки, по умолчанию EPSG:4326.\nДля проецированных систем latitude — northing (y), longitude — easting (x)",
+                    "type": "string"
+                },
+                "heading_deg": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "speed_mps": {
+                    "description": "SpeedMps и HeadingDeg (градусы от севера по часовой стрелке) включают прогноз попадания в зону",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 127
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.OperatorCreateRequest": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "oidc_subject": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "role": {
+                    "description": "Role — editor (по умолчанию) или publisher",
+                    "type": "string",
+                    "enum": [
+                        "editor",
+                        "publisher"
+                    ]
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 127
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.QuietHoursRequest": {
+            "type": "object",
+            "required": [
+                "end",
+                "start"
+            ],
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mon",
+                        "tue",
+                        "wed",
+                        "thu",
+                        "fri"
+                    ]
+                },
+                "end": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "22:00"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.UserPreferencesRequest": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_alerts_per_hour": {
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 0
+                },
+                "min_severity": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high",
+                        "critical"
+                    ]
+                },
+                "quiet_hours": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_req.QuietHoursRequest"
+                    }
+                },
+                "timezone": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Europe/Moscow"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.WebhookEndpointCreateRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "backoff": {
+                    "type": "string",
+                    "enum": [
+                        "linear",
+                        "exponential"
+                    ]
+                },
+                "batch_size": {
+                    "description": "BatchSize 0 — каждое событие отдельным запросом; иначе события уходят NDJSON-пачками\nдо batch_size штук, ожидая не дольше batch_wait_ms",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "batch_wait_ms": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "compression": {
+                    "type": "string",
+                    "enum": [
+                        "gzip",
+                        "none"
+                    ]
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_retries": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "retry_delay_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 1
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "timeout_seconds": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.WebhookEndpointPatchRequest": {
+            "type": "object",
+            "required": [
+                "events"
+            ],
+            "properties": {
+                "backoff": {
+                    "type": "string",
+                    "enum": [
+                        "linear",
+                        "exponential"
+                    ]
+                },
+                "batch_size": {
+                    "description": "batch_size 0 выключает батчинг, compression none — сжатие",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "batch_wait_ms": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "compression": {
+                    "type": "string",
+                    "enum": [
+                        "gzip",
+                        "none"
+                    ]
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_retries": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "retry_delay_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 1
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "timeout_seconds": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "minLength": 1
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.WebhookRedriveRequest": {
+            "type": "object",
+            "properties": {
+                "created_from": {
+                    "type": "string"
+                },
+                "created_to": {
+                    "type": "string"
+                },
+                "endpoint_id": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "incident_id": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "spread_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "failed"
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.WebhookReplayRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_req.WebhookTestRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.APIKeyUsageCurrent": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "this_month": {
+                    "type": "integer"
+                },
+                "today": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.APIKeyUsageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.APIKeyUsageCurrent"
+                },
+                "daily_quota": {
+                    "type": "integer"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.UsageDayResponse"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                },
+                "monthly_quota": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.AlertEvent": {
+            "type": "object",
+            "properties": {
+                "check_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "incidents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.ApprovalResponse": {
+            "type": "object",
+            "properties": {
+                "approval_id": {
+                    "type": "integer"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "decided_by": {
+                    "type": "string"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requested_at": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "critical"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.ApprovalsListResponse": {
+            "type": "object",
+            "properties": {
+                "approvals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ApprovalResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "attachment_id": {
+                    "type": "integer"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.AttachmentsListResponse": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.AttachmentResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.CheckResponse": {
+            "type": "object",
+            "properties": {
+                "check_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "has_alert": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.DashboardResponse": {
+            "type": "object",
+            "properties": {
+                "active_incidents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                    }
+                },
+                "failed_webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.FailedWebhookResponse"
+                    }
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "recent_checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.CheckResponse"
+                    }
+                },
+                "webhook_queue": {
+                    "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookQueueResponse"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.DataExportResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "download_url": {
+                    "description": "DownloadURL — временная подписанная ссылка на JSON-архив, есть только у готовой выгрузки",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt — когда архив будет удален из хранилища",
+                    "type": "string"
+                },
+                "export_id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "requested_at": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "ready"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.DependencyStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.ErasureResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "confirmed_at": {
+                    "type": "string"
+                },
+                "confirmed_by": {
+                    "type": "string"
+                },
+                "erased": {
+                    "description": "Erased заполняется после выполнения заявки",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ErasureResultResponse"
+                        }
+                    ]
+                },
+                "erasure_id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "delete",
+                        "anonymize"
+                    ]
+                },
+                "requested_at": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "confirmed",
+                        "completed",
+                        "cancelled"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.ErasureResultResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "integer"
+                },
+                "group_memberships": {
+                    "type": "integer"
+                },
+                "preferences": {
+                    "type": "integer"
+                },
+                "webhooks": {
+                    "type": "integer"
+                },
+                "zones": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.ErasuresListResponse": {
+            "type": "object",
+            "properties": {
+                "erasures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.ErasureResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.FailedWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "check_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "endpoint_id": {
+                    "type": "integer"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "failed_at": {
+                    "type": "string"
+                },
+                "retry_cnt": {
+                    "type": "integer"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.GroupMemberInDangerResponse": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                },
+                "zones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupMemberZoneResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.GroupMemberZoneResponse": {
+            "type": "object",
+            "properties": {
+                "entered_at": {
+                    "type": "string"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high",
+                        "critical"
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.GroupMembersAddedResponse": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.GroupMembersResponse": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "integer"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.GroupResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "descr": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "integer"
+                },
+                "managers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "member_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook": {
+                    "description": "Webhook отсутствует, пока получатель группы не зарегистрирован",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupWebhookResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.GroupStatusResponse": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "integer"
+                },
+                "members_in_danger": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupMemberInDangerResponse"
+                    }
+                },
+                "members_total": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.GroupWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "endpoint_id": {
+                    "type": "integer"
+                },
+                "has_secret": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.GroupsListResponse": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.GroupResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.HeatmapCellResponse": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "description": "Alerts — проверки, попавшие в зону",
+                    "type": "integer"
+                },
+                "cell": {
+                    "description": "Cell — индекс H3-ячейки в шестнадцатеричной записи",
+                    "type": "string"
+                },
+                "checks": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "description": "Latitude и Longitude — центр ячейки, для карт без библиотеки H3",
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.HeatmapResponse": {
+            "type": "object",
+            "properties": {
+                "cells": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.HeatmapCellResponse"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentBacktestResponse": {
+            "type": "object",
+            "properties": {
+                "checks_matched": {
+                    "type": "integer"
+                },
+                "checks_scanned": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "radius_m": {
+                    "type": "number"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "type": "boolean"
+                },
+                "users_alerted": {
+                    "type": "integer"
+                },
+                "users_newly_alerted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentBatchResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "affected": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentCheckStatsResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "integer"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentCommentResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentCommentsResponse": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentCommentResponse"
+                    }
+                },
+                "incident_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentCreateResponse": {
+            "type": "object",
+            "properties": {
+                "incident_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentLineageResponse": {
+            "type": "object",
+            "properties": {
+                "incident_id": {
+                    "type": "integer"
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentLinkResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentLinkResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "child_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string",
+                    "enum": [
+                        "merge",
+                        "split"
+                    ]
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentOverlapResponse": {
+            "type": "object",
+            "properties": {
+                "conflicting_incident_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentRelationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "related_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "parent",
+                        "child",
+                        "supersedes",
+                        "superseded_by"
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentRelationsResponse": {
+            "type": "object",
+            "properties": {
+                "incident_id": {
+                    "type": "integer"
+                },
+                "relations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentRelationResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "descr": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "geometry": {
+                    "description": "Geometry — GeoJSON MultiPolygon полигональной зоны, для круглых зон не возвращается",
+                    "type": "object"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_activation": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "published_by": {
+                    "type": "string"
+                },
+                "radius_m": {
+                    "type": "number"
+                },
+                "relations": {
+                    "description": "Relations — связи с другими зонами; возвращаются при чтении зоны и списка",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentRelationResponse"
+                    }
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "schedule_duration_minutes": {
+                    "type": "integer"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high",
+                        "critical"
+                    ]
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "manual",
+                        "feed",
+                        "weather"
+                    ]
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "planned",
+                        "active",
+                        "contained",
+                        "resolved",
+                        "archived"
+                    ]
+                },
+                "state_changed_at": {
+                    "description": "StateTimes — когда зона последний раз входила в каждую из пройденных стадий",
+                    "type": "string"
+                },
+                "state_times": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "published",
+                        "archived"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "restricted",
+                        "internal"
+                    ]
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentSplitResponse": {
+            "type": "object",
+            "properties": {
+                "incidents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentStatsResponse": {
+            "type": "object",
+            "properties": {
+                "alert_checks": {
+                    "type": "integer"
+                },
+                "incidents": {
+                    "description": "Incidents — зоны, в которые попадали проверки, начиная с самых частых; удаленные зоны не возвращаются",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentCheckStatsResponse"
+                    }
+                },
+                "period_end": {
+                    "description": "PeriodEnd — до какой минуты проверки сведены; отстает от текущего времени на задержку сводки",
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "total_checks": {
+                    "type": "integer"
+                },
+                "user_count": {
+                    "type": "integer"
+                },
+                "window_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentTranslationResponse": {
+            "type": "object",
+            "properties": {
+                "descr": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentTranslationsResponse": {
+            "type": "object",
+            "properties": {
+                "incident_id": {
+                    "type": "integer"
+                },
+                "translations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentTranslationResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentVersionResponse": {
+            "type": "object",
+            "properties": {
+                "changed_by": {
+                    "type": "string"
+                },
+                "geometry": {
+                    "description": "Geometry — GeoJSON MultiPolygon полигональной зоны, для круглых зон не возвращается",
+                    "type": "object"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "radius_m": {
+                    "type": "number"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentVersionsResponse": {
+            "type": "object",
+            "properties": {
+                "incident_id": {
+                    "type": "integer"
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentVersionResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentsListResponse": {
+            "type": "object",
+            "properties": {
+                "incidents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "NextCursor — курсор следующей страницы при запросе с cursor, на последней странице пуст.\nВ этом режиме page и total_pages равны 0",
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total_estimated": {
+                    "description": "TotalEstimated — total_pages посчитан по оценке числа инцидентов (запрос с count=estimated)",
+                    "type": "boolean"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.LivenessResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.LocationCheckResponse": {
+            "type": "object",
+            "properties": {
+                "has_alert": {
+                    "type": "boolean"
+                },
+                "incidents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
+                    }
+                },
+                "place": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.OperatorCreateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.PublicIncidentResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "geometry": {
+                    "description": "Geometry — GeoJSON MultiPolygon полигональной зоны, для круглых зон не возвращается",
+                    "type": "object"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "radius_m": {
+                    "type": "number"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high",
+                        "critical"
+                    ]
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "planned",
+                        "active",
+                        "contained",
+                        "resolved",
+                        "archived"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.PublicIncidentsListResponse": {
+            "type": "object",
+            "properties": {
+                "incidents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.PublicIncidentResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.QueryStatResponse": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "calls": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "max_ms": {
+                    "type": "number"
+                },
+                "mean_ms": {
+                    "type": "number"
+                },
+                "sql": {
+                    "type": "string"
+                },
+                "total_ms": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.QueryStatsResponse": {
+            "type": "object",
+            "properties": {
+                "buckets_ms": {
+                    "description": "BucketsMs — верхние границы корзин гистограммы; последняя корзина в buckets — дольше всех границ",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "queries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.QueryStatResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.QueuesResponse": {
+            "type": "object",
+            "properties": {
+                "generated_at": {
+                    "type": "string"
+                },
+                "outbox": {
+                    "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookOutboxResponse"
+                },
+                "queue": {
+                    "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookQueueDepthResponse"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.QuietHoursResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "mon",
+                            "tue",
+                            "wed",
+                            "thu",
+                            "fri",
+                            "sat",
+                            "sun"
+                        ]
+                    }
+                },
+                "end": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "22:00"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.DependencyStatus"
+                    }
+                },
+                "degraded": {
+                    "description": "Degraded — необязательная зависимость (Redis) недоступна, но запросы обслуживаются",
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.RuntimeConfigResponse": {
+            "type": "object",
+            "properties": {
+                "cache_ttl_minutes": {
+                    "type": "integer"
+                },
+                "log_level": {
+                    "type": "string"
+                },
+                "prediction_horizon_seconds": {
+                    "type": "integer"
+                },
+                "webhook_max_retries": {
+                    "type": "integer"
+                },
+                "webhook_retry_delay_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "estimated": {
+                    "description": "Estimated — user_count и total_checks приблизительные (запрос с count=estimated)",
+                    "type": "boolean"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "total_checks": {
+                    "type": "integer"
+                },
+                "user_count": {
+                    "type": "integer"
+                },
+                "window_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.UsageDayResponse": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "integer"
+                },
+                "checks": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "webhooks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.UsageExportResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.UsageExportRow"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.UsageExportRow": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "integer"
+                },
+                "api_key": {
+                    "type": "string"
+                },
+                "checks": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "webhooks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.UsageResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.APIKeyUsageResponse"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.UserPreferencesResponse": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "stream",
+                            "webhook"
+                        ]
+                    }
+                },
+                "max_alerts_per_hour": {
+                    "description": "MaxAlertsPerHour 0 — без ограничения",
+                    "type": "integer"
+                },
+                "min_severity": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high",
+                        "critical"
+                    ]
+                },
+                "quiet_hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.QuietHoursResponse"
+                    }
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt отсутствует, пока пользователь не сохранял настройки",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.WebhookEndpointResponse": {
+            "type": "object",
+            "properties": {
+                "backoff": {
+                    "type": "string"
+                },
+                "batch_size": {
+                    "description": "отсутствующий batch_size — каждое событие отдельным запросом, compression — без сжатия",
+                    "type": "integer"
+                },
+                "batch_wait_ms": {
+                    "type": "integer"
+                },
+                "compression": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "endpoint_id": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "has_secret": {
+                    "type": "boolean"
+                },
+                "max_retries": {
+                    "description": "отсутствующие поля политики попыток берутся из настроек сервиса",
+                    "type": "integer"
+                },
+                "retry_delay_seconds": {
+                    "type": "integer"
+                },
+                "timeout_seconds": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.WebhookEndpointsListResponse": {
+            "type": "object",
+            "properties": {
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookEndpointResponse"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.WebhookOutboxResponse": {
             "type": "object",
             "properties": {
-                "descr": {
-                    "type": "string"
+                "due": {
+                    "type": "integer"
                 },
-                "latitude": {
+                "failed": {
+                    "type": "integer"
+                },
+                "oldest_pending_age_seconds": {
                     "type": "number"
                 },
-                "longitude": {
+                "pending": {
+                    "type": "integer"
+                },
+                "poller_lag_seconds": {
                     "type": "number"
                 },
-                "name": {
+                "processing": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.WebhookQueueDepthResponse": {
+            "type": "object",
+            "properties": {
+                "backend": {
                     "type": "string"
                 },
-                "radius_m": {
-                    "type": "number"
+                "error": {
+                    "type": "string"
+                },
+                "ready": {
+                    "type": "integer"
+                },
+                "scheduled": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_4otis_geonotify-service_internal_dto_req.IncidentUpdateRequest": {
+        "github_com_4otis_geonotify-service_internal_dto_resp.WebhookQueueResponse": {
             "type": "object",
             "properties": {
-                "descr": {
+                "failed": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "processing": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.WebhookRedriveResponse": {
+            "type": "object",
+            "properties": {
+                "redriven": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.WebhookReplayResponse": {
+            "type": "object",
+            "properties": {
+                "requeued": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.WebhookSLOListResponse": {
+            "type": "object",
+            "properties": {
+                "breached": {
+                    "type": "integer"
+                },
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WebhookSLOResponse"
+                    }
+                },
+                "generated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_resp.WebhookSLOResponse": {
+            "type": "object",
+            "properties": {
+                "breached": {
+                    "type": "boolean"
                 },
-                "is_active": {
+                "delivered": {
+                    "type": "integer"
+                },
+                "enabled": {
                     "type": "boolean"
                 },
-                "latitude": {
-                    "type": "number"
+                "endpoint_id": {
+                    "type": "integer"
                 },
-                "longitude": {
-                    "type": "number"
+                "min_deliveries": {
+                    "type": "integer"
                 },
-                "name": {
+                "p50_ms": {
+                    "type": "integer"
+                },
+                "p95_ms": {
+                    "type": "integer"
+                },
+                "p99_ms": {
+                    "type": "integer"
+                },
+                "percentile": {
+                    "type": "integer"
+                },
+                "target_ms": {
+                    "type": "integer"
+                },
+                "url": {
                     "type": "string"
                 },
-                "radius_m": {
-                    "type": "number"
+                "window_minutes": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_4otis_geonotify-service_internal_dto_req.LocationCheckRequest": {
+        "github_com_4otis_geonotify-service_internal_dto_resp.WebhookTestResponse": {
             "type": "object",
             "properties": {
-                "latitude": {
-                    "type": "number"
+                "attempts": {
+                    "type": "integer"
                 },
-                "longitude": {
-                    "type": "number"
+                "delivered": {
+                    "type": "boolean"
                 },
-                "user_id": {
+                "error": {
                     "type": "string"
+                },
+                "latency_ms": {
+                    "type": "number"
+                },
+                "status_code": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_4otis_geonotify-service_internal_dto_resp.HealthResponse": {
+        "github_com_4otis_geonotify-service_internal_dto_resp.WorkerLockResponse": {
             "type": "object",
             "properties": {
-                "active_incidents": {
+                "acquired": {
                     "type": "integer"
                 },
-                "pending_webhooks": {
+                "errors": {
                     "type": "integer"
                 },
-                "status": {
+                "held": {
+                    "type": "boolean"
+                },
+                "held_since": {
                     "type": "string"
                 },
-                "timestamp": {
+                "last_error": {
+                    "type": "string"
+                },
+                "lost": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentCreateResponse": {
+        "github_com_4otis_geonotify-service_internal_dto_resp.WorkerLocksResponse": {
             "type": "object",
             "properties": {
-                "incident_id": {
-                    "type": "integer"
+                "enabled": {
+                    "type": "boolean"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "locks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.WorkerLockResponse"
+                    }
                 }
             }
         },
-        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse": {
+        "github_com_4otis_geonotify-service_internal_dto_v2_resp.LocationCheckBatchResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "results": {
+                    "description": "Results — итоги в порядке checks из запроса",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_v2_resp.LocationCheckBatchResult"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_v2_resp.LocationCheckBatchResult": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string"
                 },
-                "descr": {
+                "result": {
+                    "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_v2_resp.LocationCheckResponse"
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_v2_resp.LocationCheckResponse": {
+            "type": "object",
+            "properties": {
+                "ahead": {
+                    "description": "Ahead — зоны на пути, если в запросе переданы speed_mps и heading_deg",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_v2_resp.ZoneAhead"
+                    }
+                },
+                "has_alert": {
+                    "type": "boolean"
+                },
+                "match": {
+                    "description": "Match — inside, possibly_inside или outside с учетом accuracy_m",
+                    "type": "string"
+                },
+                "nearest": {
+                    "description": "Nearest — ближайшая зона, в которую точка не попала",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_v2_resp.NearestZone"
+                        }
+                    ]
+                },
+                "place": {
                     "type": "string"
                 },
+                "zones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_v2_resp.ZoneMatch"
+                    }
+                }
+            }
+        },
+        "github_com_4otis_geonotify-service_internal_dto_v2_resp.NearestZone": {
+            "type": "object",
+            "properties": {
+                "bearing_deg": {
+                    "type": "number"
+                },
+                "distance_m": {
+                    "type": "number"
+                },
+                "distance_to_edge_m": {
+                    "description": "DistanceToEdgeM — сколько осталось до границы зоны",
+                    "type": "number"
+                },
                 "incident_id": {
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
                 "latitude": {
                     "type": "number"
                 },
@@ -512,65 +7701,132 @@ const docTemplate = `{
                 "radius_m": {
                     "type": "number"
                 },
-                "updated_at": {
-                    "type": "string"
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "restricted",
+                        "internal"
+                    ]
                 }
             }
         },
-        "github_com_4otis_geonotify-service_internal_dto_resp.IncidentsListResponse": {
+        "github_com_4otis_geonotify-service_internal_dto_v2_resp.ZoneAhead": {
             "type": "object",
             "properties": {
-                "incidents": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
-                    }
+                "bearing_deg": {
+                    "type": "number"
                 },
-                "limit": {
-                    "type": "integer"
+                "distance_m": {
+                    "type": "number"
                 },
-                "page": {
-                    "type": "integer"
+                "distance_to_edge_m": {
+                    "description": "DistanceToEdgeM — сколько осталось до границы зоны",
+                    "type": "number"
                 },
-                "total_pages": {
+                "eta_seconds": {
+                    "type": "number"
+                },
+                "incident_id": {
                     "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "radius_m": {
+                    "type": "number"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "restricted",
+                        "internal"
+                    ]
                 }
             }
         },
-        "github_com_4otis_geonotify-service_internal_dto_resp.LocationCheckResponse": {
+        "github_com_4otis_geonotify-service_internal_dto_v2_resp.ZoneMatch": {
             "type": "object",
             "properties": {
-                "has_alert": {
-                    "type": "boolean"
+                "bearing_deg": {
+                    "description": "BearingDeg — азимут на центр зоны в градусах от севера по часовой стрелке",
+                    "type": "number"
                 },
-                "incidents": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_dto_resp.IncidentResponse"
-                    }
+                "descr": {
+                    "type": "string"
+                },
+                "distance_m": {
+                    "description": "DistanceM — расстояние от точки до центра зоны",
+                    "type": "number"
+                },
+                "distance_to_edge_m": {
+                    "description": "DistanceToEdgeM — расстояние от точки до границы зоны изнутри",
+                    "type": "number"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "match": {
+                    "description": "Match — inside или possibly_inside, если круг погрешности пересекает границу зоны",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "radius_m": {
+                    "type": "number"
+                },
+                "visibility": {
+                    "description": "Visibility restricted и internal — зона предупреждает, но название и описание не раскрываются (пустые)",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "restricted",
+                        "internal"
+                    ]
                 }
             }
         },
-        "github_com_4otis_geonotify-service_internal_dto_resp.StatsResponse": {
+        "github_com_4otis_geonotify-service_internal_handler_http_bind.FieldError": {
             "type": "object",
             "properties": {
-                "period_start": {
+                "field": {
                     "type": "string"
                 },
-                "total_checks": {
-                    "type": "integer"
-                },
-                "user_count": {
-                    "type": "integer"
+                "message": {
+                    "type": "string"
                 },
-                "window_minutes": {
-                    "type": "integer"
+                "rule": {
+                    "type": "string"
                 }
             }
         },
-        "internal_handler_http.ErrorResponse": {
+        "github_com_4otis_geonotify-service_internal_handler_http_respond.ErrorResponse": {
             "type": "object",
             "properties": {
+                "details": {
+                    "description": "Details — нарушения по полям для ошибок проверки запроса",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_4otis_geonotify-service_internal_handler_http_bind.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
@@ -578,10 +7834,19 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "github_com_4otis_geonotify-service_internal_handler_http_respond.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
         "ApiKeyAuth": {
+            "description": "Bearer {API_KEY} или Bearer {JWT} оператора",
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
@@ -592,7 +7857,7 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:8081",
+	Host:             "",
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "geonotify-service API",
@@ -0,0 +1,156 @@
+package alertfeed
+
+import (
+	"strings"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// geoFields — форма записи ленты: GeoRSS Simple (point, polygon, box и radius в метрах, как в ленте
+// самого сервиса) и элементы CAP, встроенные в запись (polygon, circle)
+type geoFields struct {
+	Points   []string `xml:"point"`
+	Polygons []string `xml:"polygon"`
+	Boxes    []string `xml:"box"`
+	Circles  []string `xml:"circle"`
+	Radius   string   `xml:"radius"`
+}
+
+// capFields — элементы CAP, которые ленты служб (например, NWS) встраивают в записи Atom и RSS
+type capFields struct {
+	Status   string `xml:"status"`
+	MsgType  string `xml:"msgType"`
+	Urgency  string `xml:"urgency"`
+	Severity string `xml:"severity"`
+	Expires  string `xml:"expires"`
+}
+
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Summary    string         `xml:"summary"`
+	Content    string         `xml:"content"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Categories []atomCategory `xml:"category"`
+	geoFields
+	capFields
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+type rssFeed struct {
+	Items []rssItem `xml:"channel>item"`
+}
+
+type rssItem struct {
+	GUID        string   `xml:"guid"`
+	Link        string   `xml:"link"`
+	Title       string   `xml:"title"`
+	Description string   `xml:"description"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+	geoFields
+	capFields
+}
+
+func (f atomFeed) toAlerts(pointRadius float64) []entity.ExternalAlert {
+	alerts := make([]entity.ExternalAlert, 0, len(f.Entries))
+	for _, e := range f.Entries {
+		sent := e.Updated
+		if sent == "" {
+			sent = e.Published
+		}
+		descr := e.Summary
+		if strings.TrimSpace(descr) == "" {
+			descr = e.Content
+		}
+
+		categories := make([]string, 0, 2*len(e.Categories))
+		for _, c := range e.Categories {
+			categories = append(categories, c.Label, c.Term)
+		}
+
+		if alert, ok := entryAlert(e.ID, e.Title, descr, sent, categories, e.geoFields, e.capFields, pointRadius); ok {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+func (f rssFeed) toAlerts(pointRadius float64) []entity.ExternalAlert {
+	alerts := make([]entity.ExternalAlert, 0, len(f.Items))
+	for _, item := range f.Items {
+		id := item.GUID
+		if strings.TrimSpace(id) == "" {
+			id = item.Link
+		}
+
+		if alert, ok := entryAlert(id, item.Title, item.Description, item.PubDate, item.Categories, item.geoFields, item.capFields, pointRadius); ok {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+// entryAlert приводит запись Atom или RSS к оповещению. Запись меняется на месте, поэтому
+// оповещение не ссылается на прежние, а Sent — время последнего изменения записи.
+// Уровень опасности берется из cap:severity, иначе из категорий: уровень CAP (Severe) или уровень зоны (high)
+func entryAlert(id, title, descr, sent string, categories []string, geo geoFields, capEl capFields, pointRadius float64) (entity.ExternalAlert, bool) {
+	id = strings.TrimSpace(id)
+	if id == "" || !capActual(capEl.Status) {
+		return entity.ExternalAlert{}, false
+	}
+
+	alert := entity.ExternalAlert{
+		ID:        id,
+		Name:      strings.TrimSpace(title),
+		Descr:     strings.TrimSpace(descr),
+		Severity:  capSeverity(capEl.Severity),
+		ExpiresAt: parseExpiry(capEl.Expires),
+		Ended:     strings.TrimSpace(capEl.MsgType) == "Cancel" || capEnded(capEl.Urgency),
+	}
+	alert.Sent, _ = parseTime(sent)
+
+	for _, c := range categories {
+		if alert.Severity != "" {
+			break
+		}
+		if alert.Severity = capSeverity(c); alert.Severity == "" && entity.ValidSeverity(c) {
+			alert.Severity = c
+		}
+	}
+
+	shape := areaShape{pointRadius: pointRadius}
+	for _, p := range geo.Polygons {
+		shape.addPolygon(p)
+	}
+	for _, b := range geo.Boxes {
+		shape.addBox(b)
+	}
+	for _, c := range geo.Circles {
+		shape.addCircle(c)
+	}
+	// точка рядом с полигоном — лишь его центр (так пишет и лента самого сервиса),
+	// georss:radius относится к единственной точке записи
+	if len(shape.polygons) == 0 && len(shape.circles) == 0 {
+		var radius float64
+		if r, ok := parseNumbers(geo.Radius); ok && len(r) == 1 && len(geo.Points) == 1 {
+			radius = r[0]
+		}
+		for _, p := range geo.Points {
+			if c, ok := parseNumbers(p); ok && len(c) == 2 {
+				shape.addPoint(c[0], c[1], radius)
+			}
+		}
+	}
+
+	return alert, shape.apply(&alert) || alert.Ended
+}
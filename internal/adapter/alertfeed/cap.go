@@ -0,0 +1,136 @@
+package alertfeed
+
+import (
+	"strings"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// capAlert — документ CAP 1.1 или 1.2; элементы сопоставляются без учета пространства имен
+type capAlert struct {
+	Identifier string    `xml:"identifier"`
+	Sent       string    `xml:"sent"`
+	Status     string    `xml:"status"`
+	MsgType    string    `xml:"msgType"`
+	References string    `xml:"references"`
+	Infos      []capInfo `xml:"info"`
+}
+
+type capInfo struct {
+	Event        string    `xml:"event"`
+	ResponseType []string  `xml:"responseType"`
+	Urgency      string    `xml:"urgency"`
+	Severity     string    `xml:"severity"`
+	Expires      string    `xml:"expires"`
+	Headline     string    `xml:"headline"`
+	Description  string    `xml:"description"`
+	Instruction  string    `xml:"instruction"`
+	Areas        []capArea `xml:"area"`
+}
+
+type capArea struct {
+	Polygons []string `xml:"polygon"`
+	Circles  []string `xml:"circle"`
+}
+
+// toAlert берет текст и форму из первого блока info: остальные обычно повторяют его на других языках.
+// false — оповещение не о реальной обстановке, служебное или без формы
+func (a capAlert) toAlert(pointRadius float64) (entity.ExternalAlert, bool) {
+	id := strings.TrimSpace(a.Identifier)
+	if id == "" || !capActual(a.Status) {
+		return entity.ExternalAlert{}, false
+	}
+
+	alert := entity.ExternalAlert{
+		ID:         id,
+		References: capReferences(a.References),
+	}
+	alert.Sent, _ = parseTime(a.Sent)
+
+	switch strings.TrimSpace(a.MsgType) {
+	case "Alert", "Update":
+	case "Cancel":
+		alert.Ended = true
+	default:
+		// Ack и Error — ответы на чужие сообщения, а не оповещения
+		return entity.ExternalAlert{}, false
+	}
+
+	if len(a.Infos) == 0 {
+		return alert, alert.Ended
+	}
+	info := a.Infos[0]
+
+	alert.Name = strings.TrimSpace(info.Headline)
+	if alert.Name == "" {
+		alert.Name = strings.TrimSpace(info.Event)
+	}
+	alert.Descr = joinText(info.Description, info.Instruction)
+	alert.Severity = capSeverity(info.Severity)
+	alert.ExpiresAt = parseExpiry(info.Expires)
+	if capEnded(info.Urgency, info.ResponseType...) {
+		alert.Ended = true
+	}
+
+	shape := areaShape{pointRadius: pointRadius}
+	for _, area := range info.Areas {
+		for _, p := range area.Polygons {
+			shape.addPolygon(p)
+		}
+		for _, c := range area.Circles {
+			shape.addCircle(c)
+		}
+	}
+
+	// отмене форма не нужна: она только завершает уже импортированную зону
+	return alert, shape.apply(&alert) || alert.Ended
+}
+
+// capActual — оповещение о реальной обстановке; учения, тесты и черновики не импортируются.
+// Пустой статус допускается для записей лент, не указывающих его
+func capActual(status string) bool {
+	status = strings.TrimSpace(status)
+	return status == "" || status == "Actual"
+}
+
+// capEnded — опасность миновала: срочность Past или рекомендация AllClear
+func capEnded(urgency string, responseTypes ...string) bool {
+	if strings.TrimSpace(urgency) == "Past" {
+		return true
+	}
+	for _, rt := range responseTypes {
+		if strings.TrimSpace(rt) == "AllClear" {
+			return true
+		}
+	}
+	return false
+}
+
+// capReferences извлекает идентификаторы из списка "sender,identifier,sent" через пробел
+func capReferences(references string) []string {
+	fields := strings.Fields(references)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(fields))
+	for _, ref := range fields {
+		parts := strings.Split(ref, ",")
+		if len(parts) == 3 {
+			ids = append(ids, parts[1])
+		} else {
+			ids = append(ids, ref)
+		}
+	}
+	return ids
+}
+
+func joinText(parts ...string) string {
+	texts := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			texts = append(texts, p)
+		}
+	}
+	return strings.Join(texts, "\n\n")
+}
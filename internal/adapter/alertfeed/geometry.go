@@ -0,0 +1,160 @@
+package alertfeed
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/pkg/geojson"
+)
+
+// circleSegments — число вершин многоугольника, которым круг входит в зону вместе с полигонами
+const circleSegments = 64
+
+type circle struct {
+	lat, lng, radius float64
+}
+
+// areaShape собирает форму зоны из всех полигонов и кругов оповещения. Некорректные фигуры
+// пропускаются: оповещение с одной испорченной фигурой лучше импортировать по остальным
+type areaShape struct {
+	pointRadius float64
+	polygons    geojson.MultiPolygon
+	circles     []circle
+}
+
+// addPolygon разбирает полигон CAP ("lat,lon lat,lon ...") или GeoRSS ("lat lon lat lon ...")
+func (s *areaShape) addPolygon(text string) {
+	coords, ok := parseNumbers(text)
+	if !ok || len(coords)%2 != 0 {
+		return
+	}
+
+	ring := make([]geojson.Position, 0, len(coords)/2)
+	for i := 0; i < len(coords); i += 2 {
+		ring = append(ring, geojson.Position{coords[i+1], coords[i]})
+	}
+	ring, err := geojson.ExteriorRing(ring)
+	if err != nil {
+		return
+	}
+	s.polygons = append(s.polygons, [][]geojson.Position{ring})
+}
+
+// addBox разбирает прямоугольник GeoRSS "south west north east"
+func (s *areaShape) addBox(text string) {
+	c, ok := parseNumbers(text)
+	if !ok || len(c) != 4 {
+		return
+	}
+	south, west, north, east := c[0], c[1], c[2], c[3]
+	s.addPolygon(strings.Join([]string{
+		formatPair(south, west), formatPair(south, east), formatPair(north, east), formatPair(north, west),
+	}, " "))
+}
+
+// addCircle разбирает круг CAP "lat,lon радиус_км"; нулевой радиус — точка
+func (s *areaShape) addCircle(text string) {
+	c, ok := parseNumbers(text)
+	if !ok || len(c) != 3 || c[2] < 0 {
+		return
+	}
+	s.addPoint(c[0], c[1], c[2]*1000)
+}
+
+// addPoint добавляет круг с центром в точке; radius 0 — радиус по умолчанию
+func (s *areaShape) addPoint(lat, lng, radius float64) {
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return
+	}
+	if radius <= 0 {
+		radius = s.pointRadius
+	}
+	s.circles = append(s.circles, circle{lat: lat, lng: lng, radius: radius})
+}
+
+// apply переносит форму в оповещение: единственный круг остается кругом, иначе круги
+// приближаются многоугольниками и объединяются с полигонами. false — фигур нет
+func (s *areaShape) apply(alert *entity.ExternalAlert) bool {
+	if len(s.polygons) == 0 && len(s.circles) == 0 {
+		return false
+	}
+	if len(s.polygons) == 0 && len(s.circles) == 1 {
+		c := s.circles[0]
+		alert.Latitude, alert.Longitude, alert.Radius = c.lat, c.lng, c.radius
+		return true
+	}
+
+	polygons := s.polygons
+	for _, c := range s.circles {
+		polygons = append(polygons, geojson.Circle(c.lat, c.lng, c.radius, circleSegments)...)
+	}
+	alert.Latitude, alert.Longitude, alert.Radius = polygons.BoundingCircle()
+	alert.Polygons = polygons
+	return true
+}
+
+// parseNumbers разбирает числа, разделенные пробелами или запятыми
+func parseNumbers(text string) ([]float64, bool) {
+	fields := strings.Fields(strings.ReplaceAll(text, ",", " "))
+	if len(fields) == 0 {
+		return nil, false
+	}
+
+	numbers := make([]float64, len(fields))
+	for i, f := range fields {
+		n, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, false
+		}
+		numbers[i] = n
+	}
+	return numbers, true
+}
+
+func formatPair(lat, lng float64) string {
+	return strconv.FormatFloat(lat, 'f', -1, 64) + "," + strconv.FormatFloat(lng, 'f', -1, 64)
+}
+
+// capSeverity переводит уровень опасности CAP; пустая строка — уровень не указан или неизвестен
+func capSeverity(severity string) string {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "extreme":
+		return entity.SeverityCritical
+	case "severe":
+		return entity.SeverityHigh
+	case "moderate":
+		return entity.SeverityMedium
+	case "minor":
+		return entity.SeverityLow
+	}
+	return ""
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+}
+
+// parseTime разбирает время CAP и Atom (RFC 3339) и RSS (RFC 1123)
+func parseTime(text string) (time.Time, bool) {
+	text = strings.TrimSpace(text)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseExpiry(text string) *time.Time {
+	t, ok := parseTime(text)
+	if !ok {
+		return nil
+	}
+	return &t
+}
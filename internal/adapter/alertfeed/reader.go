@@ -0,0 +1,140 @@
+package alertfeed
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/alertfeed"
+	"golang.org/x/text/encoding/ianaindex"
+)
+
+var _ alertfeed.Reader = (*HTTPReader)(nil)
+
+// maxFeedBytes ограничивает размер ленты: крупные ленты государственных служб занимают единицы мегабайт
+const maxFeedBytes = 16 << 20
+
+type Options struct {
+	UserAgent string
+	Timeout   time.Duration
+	// PointRadius — радиус зоны в метрах для оповещений, заданных только точкой
+	PointRadius float64
+}
+
+// HTTPReader загружает ленту по HTTP и разбирает ее по корневому элементу: alert — CAP, feed — Atom, rss — RSS
+type HTTPReader struct {
+	client      *http.Client
+	userAgent   string
+	pointRadius float64
+}
+
+func NewHTTPReader(opts Options) *HTTPReader {
+	return &HTTPReader{
+		client:      &http.Client{Timeout: opts.Timeout},
+		userAgent:   opts.UserAgent,
+		pointRadius: opts.PointRadius,
+	}
+}
+
+func (r *HTTPReader) Read(ctx context.Context, url string) ([]entity.ExternalAlert, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build alert feed request: %w", err)
+	}
+	req.Header.Set("Accept", "application/cap+xml, application/atom+xml, application/rss+xml, application/xml;q=0.9, text/xml;q=0.8")
+	if r.userAgent != "" {
+		req.Header.Set("User-Agent", r.userAgent)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch alert feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("alert feed responded with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert feed: %w", err)
+	}
+	if len(body) > maxFeedBytes {
+		return nil, fmt.Errorf("%w: larger than %d bytes", entity.ErrInvalidAlertFeed, maxFeedBytes)
+	}
+
+	return r.parse(body)
+}
+
+func (r *HTTPReader) parse(body []byte) ([]entity.ExternalAlert, error) {
+	root, err := rootElement(body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root {
+	case "alert":
+		var doc capAlert
+		if err := newDecoder(body).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", entity.ErrInvalidAlertFeed, err)
+		}
+		alert, ok := doc.toAlert(r.pointRadius)
+		if !ok {
+			return nil, nil
+		}
+		return []entity.ExternalAlert{alert}, nil
+
+	case "feed":
+		var doc atomFeed
+		if err := newDecoder(body).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", entity.ErrInvalidAlertFeed, err)
+		}
+		return doc.toAlerts(r.pointRadius), nil
+
+	case "rss":
+		var doc rssFeed
+		if err := newDecoder(body).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", entity.ErrInvalidAlertFeed, err)
+		}
+		return doc.toAlerts(r.pointRadius), nil
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported root element <%s>, expected CAP alert, Atom feed or RSS", entity.ErrInvalidAlertFeed, root)
+	}
+}
+
+func rootElement(body []byte) (string, error) {
+	dec := newDecoder(body)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("%w: empty document", entity.ErrInvalidAlertFeed)
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", entity.ErrInvalidAlertFeed, err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// newDecoder понимает и ленты не в UTF-8: многие национальные службы отдают windows-1251 или ISO-8859-1
+func newDecoder(body []byte) *xml.Decoder {
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		enc, err := ianaindex.IANA.Encoding(label)
+		if err != nil || enc == nil {
+			return nil, fmt.Errorf("unsupported charset %q", label)
+		}
+		return enc.NewDecoder().Reader(input), nil
+	}
+	return dec
+}
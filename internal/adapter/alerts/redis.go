@@ -0,0 +1,129 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/alerts"
+	"github.com/4otis/geonotify-service/pkg/redis"
+	"go.uber.org/zap"
+)
+
+var (
+	_ alerts.Bus           = (*RedisBus)(nil)
+	_ alerts.LocationIndex = (*RedisLocationIndex)(nil)
+	_ alerts.AlertCounter  = (*RedisAlertCounter)(nil)
+)
+
+const lastLocationsKey = "users:last_location"
+
+// RedisBus рассылает алерты через Redis Pub/Sub, канал на каждого пользователя.
+// Доставка best effort: алерты, пришедшие без подключенного клиента, теряются
+type RedisBus struct {
+	redis  *redis.Client
+	logger *zap.Logger
+}
+
+func NewRedisBus(redis *redis.Client, logger *zap.Logger) *RedisBus {
+	return &RedisBus{
+		redis:  redis,
+		logger: logger,
+	}
+}
+
+func channel(userID string) string {
+	return "alerts:user:" + userID
+}
+
+func (b *RedisBus) Publish(ctx context.Context, alert entity.Alert) error {
+	if !b.redis.Available() {
+		return entity.ErrDependencyUnavailable
+	}
+	return b.redis.Publish(ctx, channel(alert.UserID), alert)
+}
+
+func (b *RedisBus) Subscribe(ctx context.Context, userID string) (<-chan entity.Alert, func(), error) {
+	if !b.redis.Available() {
+		return nil, nil, entity.ErrDependencyUnavailable
+	}
+	messages, unsubscribe, err := b.redis.Subscribe(ctx, channel(userID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := make(chan entity.Alert)
+	done := make(chan struct{})
+	go func() {
+		defer close(out)
+		for data := range messages {
+			var alert entity.Alert
+			if err := json.Unmarshal(data, &alert); err != nil {
+				b.logger.Warn("failed to decode alert", zap.Error(err))
+				continue
+			}
+
+			select {
+			case out <- alert:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return out, func() {
+		close(done)
+		unsubscribe()
+	}, nil
+}
+
+type RedisLocationIndex struct {
+	redis *redis.Client
+}
+
+func NewRedisLocationIndex(redis *redis.Client) *RedisLocationIndex {
+	return &RedisLocationIndex{redis: redis}
+}
+
+func (i *RedisLocationIndex) Track(ctx context.Context, userID string, lat, lng float64) error {
+	if !i.redis.Available() {
+		return entity.ErrDependencyUnavailable
+	}
+	return i.redis.GeoAdd(ctx, lastLocationsKey, userID, lat, lng)
+}
+
+func (i *RedisLocationIndex) UsersWithin(ctx context.Context, lat, lng, radiusM float64) ([]string, error) {
+	if !i.redis.Available() {
+		return nil, entity.ErrDependencyUnavailable
+	}
+	return i.redis.GeoRadius(ctx, lastLocationsKey, lat, lng, radiusM)
+}
+
+func (i *RedisLocationIndex) Forget(ctx context.Context, userID string) error {
+	if !i.redis.Available() {
+		return entity.ErrDependencyUnavailable
+	}
+	return i.redis.GeoRemove(ctx, lastLocationsKey, userID)
+}
+
+// RedisAlertCounter хранит счетчик на каждое окно: ключ содержит номер окна, поэтому продление срока жизни
+// при каждом алерте не растягивает окно, а только откладывает удаление ключа
+type RedisAlertCounter struct {
+	redis *redis.Client
+}
+
+func NewRedisAlertCounter(redis *redis.Client) *RedisAlertCounter {
+	return &RedisAlertCounter{redis: redis}
+}
+
+func (c *RedisAlertCounter) Increment(ctx context.Context, userID string, window time.Duration) (int64, error) {
+	if !c.redis.Available() {
+		return 0, entity.ErrDependencyUnavailable
+	}
+	slot := time.Now().UnixNano() / int64(window)
+	key := "alerts:count:" + userID + ":" + strconv.FormatInt(slot, 10)
+
+	return c.redis.Incr(ctx, key, window)
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	_ auth.TokenIssuer   = (*HMAC)(nil)
+	_ auth.TokenVerifier = (*HMAC)(nil)
+)
+
+const issuer = "geonotify-service"
+
+// HMAC выпускает и проверяет короткоживущие токены операторов, подписанные HS256
+type HMAC struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewHMAC(secret string, ttl time.Duration) *HMAC {
+	return &HMAC{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+type claims struct {
+	Username string `json:"username"`
+	Role     string `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (h *HMAC) Issue(actor entity.Actor) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(h.ttl)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Username: actor.Name,
+		Role:     actor.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   strconv.Itoa(actor.OperatorID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(h.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signed, expiresAt, nil
+}
+
+func (h *HMAC) Verify(_ context.Context, token string) (entity.Actor, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (any, error) {
+		return h.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return entity.Actor{}, fmt.Errorf("%w: %v", entity.ErrInvalidToken, err)
+	}
+
+	operatorID, err := strconv.Atoi(c.Subject)
+	if err != nil || c.Username == "" {
+		return entity.Actor{}, fmt.Errorf("%w: malformed claims", entity.ErrInvalidToken)
+	}
+
+	// токены, выпущенные до появления ролей, действуют как редакторские до повторного входа
+	role := c.Role
+	if role == "" {
+		role = entity.RoleEditor
+	}
+
+	return entity.Actor{Name: c.Username, OperatorID: operatorID, Role: role}, nil
+}
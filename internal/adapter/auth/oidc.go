@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+)
+
+var _ auth.TokenVerifier = (*OIDC)(nil)
+
+// minRefreshInterval ограничивает повторную загрузку JWKS при токене с неизвестным kid
+const minRefreshInterval = time.Minute
+
+type OIDCOptions struct {
+	Issuer   string
+	Audience string
+	// JWKSURL пустой — адрес берется из {Issuer}/.well-known/openid-configuration
+	JWKSURL       string
+	UsernameClaim string
+	RefreshEvery  time.Duration
+}
+
+// OIDC проверяет токены внешнего провайдера (Keycloak и т.п.) по ключам из JWKS.
+// Ключи кэшируются и перезагружаются раз в RefreshEvery или при появлении нового kid.
+// Загрузка идет вне mu и одна на все запросы: медленный провайдер не задерживает токены с известным kid
+type OIDC struct {
+	client *http.Client
+	opts   OIDCOptions
+	group  singleflight.Group
+
+	mu        sync.Mutex
+	jwksURL   string
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func NewOIDC(client *http.Client, opts OIDCOptions) *OIDC {
+	return &OIDC{
+		client:  client,
+		opts:    opts,
+		jwksURL: opts.JWKSURL,
+	}
+}
+
+// Refresh загружает ключи заранее, чтобы первый запрос не ждал провайдера
+func (o *OIDC) Refresh(ctx context.Context) error {
+	return o.refresh(ctx)
+}
+
+func (o *OIDC) Verify(ctx context.Context, token string) (entity.Actor, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return o.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256"}),
+		jwt.WithIssuer(o.opts.Issuer),
+		jwt.WithAudience(o.opts.Audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return entity.Actor{}, fmt.Errorf("%w: %v", entity.ErrInvalidToken, err)
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return entity.Actor{}, fmt.Errorf("%w: missing sub claim", entity.ErrInvalidToken)
+	}
+
+	name, _ := claims[o.opts.UsernameClaim].(string)
+	if name == "" {
+		name = subject
+	}
+
+	return entity.Actor{Name: name, Subject: subject}, nil
+}
+
+// key возвращает ключ по kid; для неизвестного kid JWKS перезагружается не чаще minRefreshInterval
+func (o *OIDC) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	o.mu.Lock()
+	key, ok := o.keys[kid]
+	sinceFetch := time.Since(o.fetchedAt)
+	o.mu.Unlock()
+
+	if (!ok && sinceFetch > minRefreshInterval) || sinceFetch > o.opts.RefreshEvery {
+		// загрузку разделяют все ждущие запросы, поэтому отмена одного из них ее не прерывает
+		err := o.refresh(context.WithoutCancel(ctx))
+
+		o.mu.Lock()
+		key, ok = o.keys[kid]
+		loaded := o.keys != nil
+		o.mu.Unlock()
+
+		if err != nil && !loaded {
+			return nil, err
+		}
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// refresh объединяет одновременные загрузки ключей в одну
+func (o *OIDC) refresh(ctx context.Context) error {
+	_, err, _ := o.group.Do("jwks", func() (any, error) {
+		return nil, o.fetch(ctx)
+	})
+	return err
+}
+
+// fetch загружает JWKS без o.mu и подменяет набор ключей под ним. При ошибке остаются ранее загруженные ключи
+func (o *OIDC) fetch(ctx context.Context) error {
+	// время фиксируется и при ошибке, чтобы недоступный провайдер не опрашивался на каждый запрос
+	o.mu.Lock()
+	o.fetchedAt = time.Now()
+	jwksURL := o.jwksURL
+	o.mu.Unlock()
+
+	if jwksURL == "" {
+		var discovery struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		discoveryURL := strings.TrimSuffix(o.opts.Issuer, "/") + "/.well-known/openid-configuration"
+		if err := o.getJSON(ctx, discoveryURL, &discovery); err != nil {
+			return fmt.Errorf("failed to discover jwks uri: %w", err)
+		}
+		if discovery.JWKSURI == "" {
+			return errors.New("failed to discover jwks uri: jwks_uri is empty")
+		}
+		jwksURL = discovery.JWKSURI
+
+		o.mu.Lock()
+		o.jwksURL = jwksURL
+		o.mu.Unlock()
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := o.getJSON(ctx, jwksURL, &set); err != nil {
+		return fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			// ключи неподдерживаемых типов пропускаются, остальные остаются рабочими
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("failed to fetch jwks: no usable signing keys")
+	}
+
+	o.mu.Lock()
+	o.keys = keys
+	o.mu.Unlock()
+
+	return nil
+}
+
+func (o *OIDC) getJSON(ctx context.Context, url string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid base64url value %q", s)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
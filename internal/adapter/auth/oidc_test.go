@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOIDCKeyDoesNotWaitForRefresh(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := map[string]any{"keys": []map[string]string{{
+		"kid": "k1",
+		"kty": "RSA",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(private.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(private.E)).Bytes()),
+	}}}
+
+	var requests atomic.Int32
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// первая загрузка отвечает сразу, повторная висит, пока тест ее не отпустит
+		if requests.Add(1) > 1 {
+			close(fetching)
+			<-release
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+	defer srv.Close()
+
+	o := NewOIDC(srv.Client(), OIDCOptions{JWKSURL: srv.URL, RefreshEvery: time.Hour})
+	ctx := context.Background()
+	if err := o.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	o.mu.Lock()
+	o.fetchedAt = time.Now().Add(-2 * minRefreshInterval)
+	o.mu.Unlock()
+
+	// токены с неизвестным kid запускают одну общую загрузку
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := o.key(ctx, "k2"); err == nil {
+				t.Error("key(k2) error = nil, want unknown signing key")
+			}
+		}()
+	}
+	<-fetching
+
+	known := make(chan error, 1)
+	go func() {
+		_, err := o.key(ctx, "k1")
+		known <- err
+	}()
+	select {
+	case err := <-known:
+		if err != nil {
+			t.Errorf("key(k1) error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("key(k1) waits for the JWKS fetch")
+	}
+
+	close(release)
+	wg.Wait()
+	if n := requests.Load(); n != 2 {
+		t.Errorf("JWKS requests = %d, want 2", n)
+	}
+}
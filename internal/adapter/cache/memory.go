@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/cache"
+)
+
+var (
+	_ cache.Cache   = (*Memory)(nil)
+	_ cache.Counter = (*Memory)(nil)
+)
+
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// Memory — кэш в памяти процесса для dev/test без Redis. Значения хранятся
+// в JSON, как и в Redis, поэтому вызывающий код не может изменить закэшированное.
+// Кэш не разделяется между репликами
+type Memory struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	counters  map[string]memoryCounter
+	lastSweep time.Time
+}
+
+type memoryCounter struct {
+	n         int64
+	expiresAt time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		entries:   make(map[string]memoryEntry),
+		counters:  make(map[string]memoryCounter),
+		lastSweep: time.Now(),
+	}
+}
+
+func (m *Memory) Get(ctx context.Context, key string, dest interface{}) error {
+	m.mu.Lock()
+	entry, ok := m.entries[key]
+	if ok && entry.expired(time.Now()) {
+		delete(m.entries, key)
+		ok = false
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return entity.ErrCacheMiss
+	}
+
+	if err := json.Unmarshal(entry.data, dest); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Memory) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	now := time.Now()
+	entry := memoryEntry{data: data}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = entry
+	m.sweep(now)
+
+	return nil
+}
+
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	delete(m.entries, key)
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *Memory) TTL(ctx context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := m.entries[key]
+	if !ok || entry.expired(now) {
+		return 0, entity.ErrCacheMiss
+	}
+
+	if entry.expiresAt.IsZero() {
+		return 0, nil
+	}
+
+	return entry.expiresAt.Sub(now), nil
+}
+
+// Increment считает в окнах, выровненных по времени, как и счетчики в Redis
+func (m *Memory) Increment(ctx context.Context, key string, window time.Duration) (int64, error) {
+	now := time.Now()
+	slot := now.UnixNano() / int64(window)
+	key += ":" + strconv.FormatInt(slot, 10)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counter := m.counters[key]
+	counter.n++
+	counter.expiresAt = time.Unix(0, (slot+1)*int64(window))
+	m.counters[key] = counter
+	m.sweep(now)
+
+	return counter.n, nil
+}
+
+// sweep удаляет просроченные ключи не чаще раза в sweepInterval; вызывается под mu
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for key, entry := range m.entries {
+		if entry.expired(now) {
+			delete(m.entries, key)
+		}
+	}
+	for key, counter := range m.counters {
+		if !now.Before(counter.expiresAt) {
+			delete(m.counters, key)
+		}
+	}
+}
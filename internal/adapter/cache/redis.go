@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/cache"
+	"github.com/4otis/geonotify-service/pkg/redis"
+)
+
+var (
+	_ cache.Cache   = (*Redis)(nil)
+	_ cache.Counter = (*Redis)(nil)
+)
+
+type Redis struct {
+	client *redis.Client
+}
+
+func NewRedis(client *redis.Client) *Redis {
+	return &Redis{client: client}
+}
+
+// Пока Redis недоступен, Get отвечает промахом, а запись пропускается —
+// чтение идет напрямую в БД без ожидания таймаутов
+func (r *Redis) Get(ctx context.Context, key string, dest interface{}) error {
+	if !r.client.Available() {
+		return entity.ErrCacheMiss
+	}
+
+	err := r.client.Get(ctx, key, dest)
+	if errors.Is(err, redis.ErrNotFound) {
+		return entity.ErrCacheMiss
+	}
+	return err
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if !r.client.Available() {
+		return entity.ErrDependencyUnavailable
+	}
+	return r.client.Set(ctx, key, value, ttl)
+}
+
+func (r *Redis) Delete(ctx context.Context, key string) error {
+	if !r.client.Available() {
+		return entity.ErrDependencyUnavailable
+	}
+	return r.client.Delete(ctx, key)
+}
+
+func (r *Redis) TTL(ctx context.Context, key string) (time.Duration, error) {
+	if !r.client.Available() {
+		return 0, entity.ErrDependencyUnavailable
+	}
+	ttl, err := r.client.TTL(ctx, key)
+	if errors.Is(err, redis.ErrNotFound) {
+		return 0, entity.ErrCacheMiss
+	}
+	return ttl, err
+}
+
+// Increment хранит счетчик на каждое окно: ключ содержит номер окна, поэтому окно не растягивается
+func (r *Redis) Increment(ctx context.Context, key string, window time.Duration) (int64, error) {
+	if !r.client.Available() {
+		return 0, entity.ErrDependencyUnavailable
+	}
+	slot := time.Now().UnixNano() / int64(window)
+
+	return r.client.Incr(ctx, key+":"+strconv.FormatInt(slot, 10), window)
+}
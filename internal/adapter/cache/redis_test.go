@@ -0,0 +1,83 @@
+//go:build integration
+
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/adapter/cache"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/testenv"
+)
+
+var rdb *testenv.Redis
+
+func TestMain(m *testing.M) {
+	testenv.Main(m, func(ctx context.Context) (func(), error) {
+		var err error
+		rdb, err = testenv.StartRedis(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return rdb.Close, nil
+	})
+}
+
+func TestRedisGetSet(t *testing.T) {
+	rdb.Reset(t)
+	c := cache.NewRedis(rdb.Client)
+	ctx := context.Background()
+
+	var got []*entity.Incident
+	if err := c.Get(ctx, "incidents", &got); !errors.Is(err, entity.ErrCacheMiss) {
+		t.Fatalf("Get() missing key error = %v, want %v", err, entity.ErrCacheMiss)
+	}
+
+	want := []*entity.Incident{{ID: 1, Name: "Пожар", Latitude: 55.75, Longitude: 37.61, Radius: 500}}
+	if err := c.Set(ctx, "incidents", want, time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := c.Get(ctx, "incidents", &got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 1 || got[0].Name != "Пожар" {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+
+	ttl, err := c.TTL(ctx, "incidents")
+	if err != nil || ttl <= 0 || ttl > time.Minute {
+		t.Errorf("TTL() = %v, %v; want (0, 1m]", ttl, err)
+	}
+
+	if err := c.Delete(ctx, "incidents"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := c.Get(ctx, "incidents", &got); !errors.Is(err, entity.ErrCacheMiss) {
+		t.Errorf("Get() after Delete error = %v, want %v", err, entity.ErrCacheMiss)
+	}
+}
+
+func TestRedisIncrement(t *testing.T) {
+	rdb.Reset(t)
+	c := cache.NewRedis(rdb.Client)
+	ctx := context.Background()
+
+	// окно в час: тест не переходит границу окна
+	for want := int64(1); want <= 3; want++ {
+		got, err := c.Increment(ctx, "quota:key-1", time.Hour)
+		if err != nil {
+			t.Fatalf("Increment() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("Increment() = %d, want %d", got, want)
+		}
+	}
+
+	got, err := c.Increment(ctx, "quota:key-2", time.Hour)
+	if err != nil || got != 1 {
+		t.Errorf("Increment() other key = %d, %v; want 1", got, err)
+	}
+}
@@ -0,0 +1,167 @@
+// Package chaos оборачивает отправку вебхуков и их очередь искусственными сбоями и задержками,
+// чтобы в автотестах проверять повторы, DLQ и опрос outbox. Включается только настройками CHAOS_*
+// и не запускается при ENV=production.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/delivery"
+)
+
+var (
+	_ delivery.WebhookSender = (*Sender)(nil)
+	_ delivery.Queue         = (*Queue)(nil)
+)
+
+var ErrInjected = errors.New("chaos: injected failure")
+
+// Faults — доля вызовов (0–1), которые завершаются ErrInjected, и задержка перед каждым вызовом
+type Faults struct {
+	FailureRate float64
+	Latency     time.Duration
+}
+
+func (f Faults) Enabled() bool {
+	return f.FailureRate > 0 || f.Latency > 0
+}
+
+// Injector — общий источник случайности для всех оберток: с одинаковым seed и одинаковым порядком
+// вызовов сбои повторяются
+type Injector struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewInjector с seed 0 берет seed от текущего времени
+func NewInjector(seed int64) *Injector {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Injector{rnd: rand.New(rand.NewSource(seed))}
+}
+
+func (i *Injector) fail(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.rnd.Float64() < rate
+}
+
+// Sender: сорванная попытка доставки выглядит как ответ 503 получателя, поэтому проходит тот же путь
+// повторов и DLQ, что и настоящая
+type Sender struct {
+	next     delivery.WebhookSender
+	faults   Faults
+	injector *Injector
+}
+
+func NewSender(next delivery.WebhookSender, faults Faults, injector *Injector) *Sender {
+	return &Sender{next: next, faults: faults, injector: injector}
+}
+
+func (s *Sender) Send(ctx context.Context, endpoint entity.WebhookEndpoint, eventType string, payload []byte) (entity.DeliveryResult, error) {
+	return s.send(ctx, func() (entity.DeliveryResult, error) {
+		return s.next.Send(ctx, endpoint, eventType, payload)
+	})
+}
+
+func (s *Sender) SendBatch(ctx context.Context, endpoint entity.WebhookEndpoint, payloads [][]byte) (entity.DeliveryResult, error) {
+	return s.send(ctx, func() (entity.DeliveryResult, error) {
+		return s.next.SendBatch(ctx, endpoint, payloads)
+	})
+}
+
+func (s *Sender) send(ctx context.Context, next func() (entity.DeliveryResult, error)) (entity.DeliveryResult, error) {
+	start := time.Now()
+	if err := sleep(ctx, s.faults.Latency); err != nil {
+		return entity.DeliveryResult{Latency: time.Since(start)}, err
+	}
+
+	if s.injector.fail(s.faults.FailureRate) {
+		result := entity.DeliveryResult{StatusCode: http.StatusServiceUnavailable, Latency: time.Since(start)}
+		return result, fmt.Errorf("%w: HTTP status: %d", ErrInjected, result.StatusCode)
+	}
+
+	result, err := next()
+	result.Latency = time.Since(start)
+	return result, err
+}
+
+// Queue: сорванные Push и Schedule оставляют вебхук опросу outbox, сорванный PopBlocking
+// ждет весь timeout, как вызов к зависшему брокеру
+type Queue struct {
+	next     delivery.Queue
+	faults   Faults
+	injector *Injector
+}
+
+func NewQueue(next delivery.Queue, faults Faults, injector *Injector) *Queue {
+	return &Queue{next: next, faults: faults, injector: injector}
+}
+
+func (q *Queue) Push(ctx context.Context, task entity.WebhookTask) error {
+	if err := q.inject(ctx); err != nil {
+		return err
+	}
+	return q.next.Push(ctx, task)
+}
+
+func (q *Queue) Schedule(ctx context.Context, task entity.WebhookTask, at time.Time) error {
+	if err := q.inject(ctx); err != nil {
+		return err
+	}
+	return q.next.Schedule(ctx, task, at)
+}
+
+func (q *Queue) PopBlocking(ctx context.Context, timeout time.Duration) (*entity.WebhookTask, error) {
+	if err := q.inject(ctx); err != nil {
+		if errors.Is(err, ErrInjected) {
+			if err := sleep(ctx, timeout); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+	return q.next.PopBlocking(ctx, timeout)
+}
+
+// Depth не сбоит: мониторинг должен видеть очередь и во время испытаний
+func (q *Queue) Depth(ctx context.Context) (entity.QueueDepth, error) {
+	return q.next.Depth(ctx)
+}
+
+func (q *Queue) inject(ctx context.Context) error {
+	if err := sleep(ctx, q.faults.Latency); err != nil {
+		return err
+	}
+	if q.injector.fail(q.faults.FailureRate) {
+		return ErrInjected
+	}
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
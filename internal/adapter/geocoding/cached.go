@@ -0,0 +1,47 @@
+package geocoding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/port/cache"
+	"github.com/4otis/geonotify-service/internal/port/geo"
+)
+
+var _ geo.ReverseGeocoder = (*CachedReverse)(nil)
+
+// CachedReverse кэширует обратное геокодирование. Координаты округляются
+// до 4 знаков (~11 м), чтобы соседние проверки попадали в один ключ
+type CachedReverse struct {
+	next  geo.ReverseGeocoder
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func NewCachedReverse(next geo.ReverseGeocoder, cache cache.Cache, ttl time.Duration) *CachedReverse {
+	return &CachedReverse{
+		next:  next,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+func (c *CachedReverse) Reverse(ctx context.Context, lat, lng float64) (string, error) {
+	key := fmt.Sprintf("geo:reverse:%.4f:%.4f", lat, lng)
+
+	var place string
+	if err := c.cache.Get(ctx, key, &place); err == nil {
+		return place, nil
+	}
+
+	place, err := c.next.Reverse(ctx, lat, lng)
+	if err != nil {
+		return "", err
+	}
+
+	// ошибка записи в кэш не должна ломать проверку
+	_ = c.cache.Set(ctx, key, place, c.ttl)
+
+	return place, nil
+}
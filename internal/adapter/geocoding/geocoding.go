@@ -0,0 +1,70 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/geo"
+)
+
+const (
+	ProviderNominatim = "nominatim"
+	ProviderGoogle    = "google"
+)
+
+type Options struct {
+	Provider string
+	// BaseURL пустой — используется публичный адрес провайдера
+	BaseURL   string
+	APIKey    string
+	UserAgent string
+	Timeout   time.Duration
+}
+
+// New создает геокодер выбранного провайдера
+func New(opts Options) (geo.Provider, error) {
+	client := &http.Client{Timeout: opts.Timeout}
+
+	switch opts.Provider {
+	case ProviderNominatim:
+		return newNominatim(client, opts), nil
+	case ProviderGoogle:
+		if opts.APIKey == "" {
+			return nil, fmt.Errorf("google geocoder requires an API key")
+		}
+		return newGoogle(client, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown geocoding provider %q", opts.Provider)
+	}
+}
+
+func getJSON(ctx context.Context, client *http.Client, url, userAgent string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build geocoding request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrGeocoderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: provider returned status %d", entity.ErrGeocoderUnavailable, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("%w: invalid response: %v", entity.ErrGeocoderUnavailable, err)
+	}
+
+	return nil
+}
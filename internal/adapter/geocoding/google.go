@@ -0,0 +1,97 @@
+package geocoding
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/geo"
+)
+
+var _ geo.Provider = (*Google)(nil)
+
+const googleBaseURL = "https://maps.googleapis.com/maps/api/geocode/json"
+
+type Google struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+func newGoogle(client *http.Client, opts Options) *Google {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = googleBaseURL
+	}
+
+	return &Google{
+		client:  client,
+		baseURL: baseURL,
+		apiKey:  opts.APIKey,
+	}
+}
+
+type googleResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+	Results      []struct {
+		FormattedAddress string `json:"formatted_address"`
+		Geometry         struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+		} `json:"geometry"`
+	} `json:"results"`
+}
+
+func (g *Google) Geocode(ctx context.Context, address string) (lat, lng float64, err error) {
+	query := url.Values{}
+	query.Set("address", address)
+
+	resp, err := g.query(ctx, query)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	location := resp.Results[0].Geometry.Location
+	return location.Lat, location.Lng, nil
+}
+
+func (g *Google) Reverse(ctx context.Context, lat, lng float64) (string, error) {
+	query := url.Values{}
+	query.Set("latlng", fmt.Sprintf("%f,%f", lat, lng))
+
+	resp, err := g.query(ctx, query)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Results[0].FormattedAddress, nil
+}
+
+// query выполняет запрос и гарантирует непустой список результатов
+func (g *Google) query(ctx context.Context, query url.Values) (*googleResponse, error) {
+	query.Set("key", g.apiKey)
+
+	var resp googleResponse
+	if err := getJSON(ctx, g.client, g.baseURL+"?"+query.Encode(), "", &resp); err != nil {
+		return nil, err
+	}
+
+	switch resp.Status {
+	case "OK":
+	case "ZERO_RESULTS":
+		return nil, entity.ErrAddressNotFound
+	default:
+		return nil, fmt.Errorf("%w: %s %s", entity.ErrGeocoderUnavailable, resp.Status, resp.ErrorMessage)
+	}
+
+	if len(resp.Results) == 0 {
+		return nil, entity.ErrAddressNotFound
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,116 @@
+package geocoding
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/geo"
+)
+
+var _ geo.Provider = (*Nominatim)(nil)
+
+const nominatimBaseURL = "https://nominatim.openstreetmap.org"
+
+// Nominatim — геокодер OpenStreetMap. Публичный сервер требует User-Agent
+// и ограничивает частоту запросов (1 в секунду)
+type Nominatim struct {
+	client    *http.Client
+	baseURL   string
+	userAgent string
+}
+
+func newNominatim(client *http.Client, opts Options) *Nominatim {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = nominatimBaseURL
+	}
+
+	return &Nominatim{
+		client:    client,
+		baseURL:   baseURL,
+		userAgent: opts.UserAgent,
+	}
+}
+
+type nominatimPlace struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+func (n *Nominatim) Geocode(ctx context.Context, address string) (lat, lng float64, err error) {
+	query := url.Values{}
+	query.Set("q", address)
+	query.Set("format", "jsonv2")
+	query.Set("limit", "1")
+
+	var places []nominatimPlace
+	if err := getJSON(ctx, n.client, n.baseURL+"/search?"+query.Encode(), n.userAgent, &places); err != nil {
+		return 0, 0, err
+	}
+
+	if len(places) == 0 {
+		return 0, 0, entity.ErrAddressNotFound
+	}
+
+	lat, err = strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: invalid latitude %q", entity.ErrGeocoderUnavailable, places[0].Lat)
+	}
+
+	lng, err = strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: invalid longitude %q", entity.ErrGeocoderUnavailable, places[0].Lon)
+	}
+
+	return lat, lng, nil
+}
+
+type nominatimReverse struct {
+	DisplayName string `json:"display_name"`
+	Address     struct {
+		Road        string `json:"road"`
+		HouseNumber string `json:"house_number"`
+		City        string `json:"city"`
+		Town        string `json:"town"`
+		Village     string `json:"village"`
+	} `json:"address"`
+}
+
+func (n *Nominatim) Reverse(ctx context.Context, lat, lng float64) (string, error) {
+	query := url.Values{}
+	query.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+	query.Set("lon", strconv.FormatFloat(lng, 'f', -1, 64))
+	query.Set("format", "jsonv2")
+	query.Set("zoom", "18")
+
+	var place nominatimReverse
+	if err := getJSON(ctx, n.client, n.baseURL+"/reverse?"+query.Encode(), n.userAgent, &place); err != nil {
+		return "", err
+	}
+
+	// короткая форма "город, улица дом" читается лучше полного display_name
+	var parts []string
+	for _, locality := range []string{place.Address.City, place.Address.Town, place.Address.Village} {
+		if locality != "" {
+			parts = append(parts, locality)
+			break
+		}
+	}
+	if place.Address.Road != "" {
+		parts = append(parts, strings.TrimSpace(place.Address.Road+" "+place.Address.HouseNumber))
+	}
+
+	if len(parts) > 0 {
+		return strings.Join(parts, ", "), nil
+	}
+	if place.DisplayName != "" {
+		return place.DisplayName, nil
+	}
+
+	return "", entity.ErrAddressNotFound
+}
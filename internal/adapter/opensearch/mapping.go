@@ -0,0 +1,134 @@
+package opensearch
+
+import (
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+var incidentMappings = map[string]any{
+	"dynamic": "strict",
+	"properties": map[string]any{
+		"id":               map[string]any{"type": "integer"},
+		"name":             textWithKeyword,
+		"descr":            map[string]any{"type": "text"},
+		"location":         map[string]any{"type": "geo_point"},
+		"radius_m":         map[string]any{"type": "double"},
+		"geometry":         map[string]any{"type": "geo_shape"},
+		"is_active":        map[string]any{"type": "boolean"},
+		"status":           map[string]any{"type": "keyword"},
+		"state":            map[string]any{"type": "keyword"},
+		"severity":         map[string]any{"type": "keyword"},
+		"source":           map[string]any{"type": "keyword"},
+		"schedule":         map[string]any{"type": "keyword"},
+		"address":          textWithKeyword,
+		"created_by":       map[string]any{"type": "keyword"},
+		"updated_by":       map[string]any{"type": "keyword"},
+		"published_by":     map[string]any{"type": "keyword"},
+		"created_at":       map[string]any{"type": "date"},
+		"updated_at":       map[string]any{"type": "date"},
+		"published_at":     map[string]any{"type": "date"},
+		"expires_at":       map[string]any{"type": "date"},
+		"state_changed_at": map[string]any{"type": "date"},
+	},
+}
+
+var checkMappings = map[string]any{
+	"dynamic": "strict",
+	"properties": map[string]any{
+		"id":         map[string]any{"type": "long"},
+		"user_id":    map[string]any{"type": "keyword"},
+		"location":   map[string]any{"type": "geo_point"},
+		"has_alert":  map[string]any{"type": "boolean"},
+		"created_at": map[string]any{"type": "date"},
+	},
+}
+
+var textWithKeyword = map[string]any{
+	"type": "text",
+	"fields": map[string]any{
+		"keyword": map[string]any{"type": "keyword", "ignore_above": 256},
+	},
+}
+
+func indexTemplate(pattern string, mappings map[string]any) map[string]any {
+	return map[string]any{
+		"index_patterns": []string{pattern},
+		"template": map[string]any{
+			"mappings": mappings,
+		},
+	}
+}
+
+type geoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// geoShape — MultiPolygon в GeoJSON: точки [долгота, широта]
+type geoShape struct {
+	Type        string           `json:"type"`
+	Coordinates [][][][2]float64 `json:"coordinates"`
+}
+
+type incidentDoc struct {
+	ID             int        `json:"id"`
+	Name           string     `json:"name"`
+	Descr          string     `json:"descr"`
+	Location       geoPoint   `json:"location"`
+	Radius         float64    `json:"radius_m"`
+	Geometry       *geoShape  `json:"geometry,omitempty"`
+	IsActive       bool       `json:"is_active"`
+	Status         string     `json:"status"`
+	State          string     `json:"state"`
+	Severity       string     `json:"severity"`
+	Source         string     `json:"source"`
+	Schedule       string     `json:"schedule,omitempty"`
+	Address        string     `json:"address,omitempty"`
+	CreatedBy      string     `json:"created_by,omitempty"`
+	UpdatedBy      string     `json:"updated_by,omitempty"`
+	PublishedBy    string     `json:"published_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	PublishedAt    *time.Time `json:"published_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	StateChangedAt time.Time  `json:"state_changed_at"`
+}
+
+type checkDoc struct {
+	ID        int       `json:"id"`
+	UserID    string    `json:"user_id"`
+	Location  geoPoint  `json:"location"`
+	HasAlert  bool      `json:"has_alert"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// toIncidentDoc: у полигональной зоны в geometry ее форма, у круглой — только центр и радиус
+func toIncidentDoc(inc *entity.Incident) incidentDoc {
+	doc := incidentDoc{
+		ID:             inc.ID,
+		Name:           inc.Name,
+		Descr:          inc.Descr,
+		Location:       geoPoint{Lat: inc.Latitude, Lon: inc.Longitude},
+		Radius:         inc.Radius,
+		IsActive:       inc.IsActive,
+		Status:         inc.Status,
+		State:          inc.State,
+		Severity:       inc.Severity,
+		Source:         inc.Source,
+		Schedule:       inc.Schedule,
+		Address:        inc.Address,
+		CreatedBy:      inc.CreatedBy,
+		UpdatedBy:      inc.UpdatedBy,
+		PublishedBy:    inc.PublishedBy,
+		CreatedAt:      inc.CreatedAt,
+		UpdatedAt:      inc.UpdatedAt,
+		PublishedAt:    inc.PublishedAt,
+		ExpiresAt:      inc.ExpiresAt,
+		StateChangedAt: inc.StateChangedAt,
+	}
+	if len(inc.Polygons) > 0 {
+		doc.Geometry = &geoShape{Type: "MultiPolygon", Coordinates: inc.Polygons}
+	}
+	return doc
+}
@@ -0,0 +1,247 @@
+// Package opensearch зеркалирует зоны и проверки координат в OpenSearch (или Elasticsearch) через Bulk API
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/search"
+)
+
+var _ search.Indexer = (*Indexer)(nil)
+
+const (
+	defaultTimeout = 30 * time.Second
+	// maxResponseBytes — ответ Bulk API перечисляет все документы пачки
+	maxResponseBytes = 16 << 20
+)
+
+type Options struct {
+	URL      string
+	Username string
+	Password string
+	// IndexPrefix — начало имен индексов и шаблонов: <prefix>-incidents и <prefix>-checks-YYYY.MM.DD
+	IndexPrefix string
+}
+
+// Indexer пишет зоны в индекс <prefix>-incidents (ID документа — ID зоны), а проверки — в суточные
+// индексы <prefix>-checks-YYYY.MM.DD по дате проверки, чтобы старые дни удалялись целиком
+type Indexer struct {
+	client   *http.Client
+	url      string
+	username string
+	password string
+	prefix   string
+}
+
+func NewIndexer(opts Options) *Indexer {
+	return &Indexer{
+		client:   &http.Client{Timeout: defaultTimeout},
+		url:      strings.TrimRight(opts.URL, "/"),
+		username: opts.Username,
+		password: opts.Password,
+		prefix:   opts.IndexPrefix,
+	}
+}
+
+func (ix *Indexer) EnsureTemplates(ctx context.Context) error {
+	templates := map[string]any{
+		ix.prefix + "-incidents": indexTemplate(ix.prefix+"-incidents*", incidentMappings),
+		ix.prefix + "-checks":    indexTemplate(ix.prefix+"-checks-*", checkMappings),
+	}
+	for name, template := range templates {
+		if err := ix.do(ctx, http.MethodPut, "/_index_template/"+name, "application/json", template, nil); err != nil {
+			return fmt.Errorf("failed to put index template %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (ix *Indexer) IndexIncidents(ctx context.Context, changes []entity.IncidentChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	index := ix.prefix + "-incidents"
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, change := range changes {
+		id := strconv.Itoa(change.Incident.ID)
+		if change.Deleted {
+			enc.Encode(map[string]any{"delete": bulkTarget{Index: index, ID: id}})
+			continue
+		}
+		enc.Encode(map[string]any{"index": bulkTarget{Index: index, ID: id}})
+		enc.Encode(toIncidentDoc(change.Incident))
+	}
+
+	if err := ix.bulk(ctx, &body); err != nil {
+		return fmt.Errorf("failed to index incidents: %w", err)
+	}
+	return nil
+}
+
+func (ix *Indexer) IndexChecks(ctx context.Context, checks []*entity.Check) error {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, check := range checks {
+		index := ix.prefix + "-checks-" + check.CreatedAt.Format("2006.01.02")
+		enc.Encode(map[string]any{"index": bulkTarget{Index: index, ID: strconv.Itoa(check.ID)}})
+		enc.Encode(checkDoc{
+			ID:        check.ID,
+			UserID:    check.UserID,
+			Location:  geoPoint{Lat: check.Latitude, Lon: check.Longitude},
+			HasAlert:  check.HasAlert,
+			CreatedAt: check.CreatedAt,
+		})
+	}
+
+	if err := ix.bulk(ctx, &body); err != nil {
+		return fmt.Errorf("failed to index checks: %w", err)
+	}
+	return nil
+}
+
+func (ix *Indexer) DeleteUserChecks(ctx context.Context, userID string) error {
+	body := map[string]any{"query": userChecksQuery(userID)}
+	if err := ix.do(ctx, http.MethodPost, "/"+ix.prefix+"-checks-*/_delete_by_query?conflicts=proceed&refresh=true",
+		"application/json", body, nil); err != nil {
+		return fmt.Errorf("failed to delete user checks: %w", err)
+	}
+	return nil
+}
+
+// AnonymizeUserChecks огрубляет координаты так же, как БД: округлением до precision знаков
+func (ix *Indexer) AnonymizeUserChecks(ctx context.Context, userID, anonymizedID string, precision int) error {
+	body := map[string]any{
+		"query": userChecksQuery(userID),
+		"script": map[string]any{
+			"lang": "painless",
+			"source": "double f = Math.pow(10, params.precision);" +
+				"ctx._source.user_id = params.user_id;" +
+				"ctx._source.location.lat = Math.round(ctx._source.location.lat * f) / f;" +
+				"ctx._source.location.lon = Math.round(ctx._source.location.lon * f) / f;",
+			"params": map[string]any{"user_id": anonymizedID, "precision": precision},
+		},
+	}
+	if err := ix.do(ctx, http.MethodPost, "/"+ix.prefix+"-checks-*/_update_by_query?conflicts=proceed&refresh=true",
+		"application/json", body, nil); err != nil {
+		return fmt.Errorf("failed to anonymize user checks: %w", err)
+	}
+	return nil
+}
+
+func userChecksQuery(userID string) map[string]any {
+	return map[string]any{"term": map[string]any{"user_id": userID}}
+}
+
+type bulkTarget struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
+type bulkResponse struct {
+	Errors bool                  `json:"errors"`
+	Items  []map[string]bulkItem `json:"items"`
+}
+
+type bulkItem struct {
+	ID     string `json:"_id"`
+	Status int    `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
+// bulk отправляет пачку и возвращает ошибку, если хотя бы один документ не записан.
+// Удаление документа, которого нет в индексе, ошибкой не считается
+func (ix *Indexer) bulk(ctx context.Context, body *bytes.Buffer) error {
+	var resp bulkResponse
+	if err := ix.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", body, &resp); err != nil {
+		return err
+	}
+	if !resp.Errors {
+		return nil
+	}
+
+	failed := 0
+	var first string
+	for _, item := range resp.Items {
+		for action, result := range item {
+			if result.Status < 300 || (action == "delete" && result.Status == http.StatusNotFound) {
+				continue
+			}
+			failed++
+			if first == "" && result.Error != nil {
+				first = fmt.Sprintf("%s %s: %s: %s", action, result.ID, result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d documents failed, first: %s", failed, len(resp.Items), first)
+}
+
+// do отправляет запрос; body — готовое тело (*bytes.Buffer) или значение для JSON, out nil — ответ не разбирается
+func (ix *Indexer) do(ctx context.Context, method, path, contentType string, body any, out any) error {
+	reader, ok := body.(*bytes.Buffer)
+	if !ok {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewBuffer(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, ix.url+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if ix.username != "" {
+		req.SetBasicAuth(ix.username, ix.password)
+	}
+
+	resp, err := ix.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP status: %d: %s", resp.StatusCode, truncate(string(data), 512))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,97 @@
+// Package opsmetrics считает попытки доставки вебхуков и обращения к кэшу вместе с их ошибками,
+// чтобы модуль оповещений дежурных мог сравнивать долю ошибок с порогами
+package opsmetrics
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/cache"
+	"github.com/4otis/geonotify-service/internal/port/delivery"
+)
+
+var (
+	_ delivery.WebhookSender = (*Sender)(nil)
+	_ cache.Cache            = (*Cache)(nil)
+)
+
+// Counter — число операций и ошибок с момента предыдущего Take
+type Counter struct {
+	total  atomic.Int64
+	failed atomic.Int64
+}
+
+func (c *Counter) observe(err error) {
+	c.total.Add(1)
+	if err != nil {
+		c.failed.Add(1)
+	}
+}
+
+// Take возвращает накопленные счетчики и обнуляет их
+func (c *Counter) Take() (total, failed int64) {
+	return c.total.Swap(0), c.failed.Swap(0)
+}
+
+// Sender считает каждую попытку доставки; неуспешная — любая, на которую получатель не ответил 2xx
+type Sender struct {
+	next    delivery.WebhookSender
+	counter *Counter
+}
+
+func NewSender(next delivery.WebhookSender, counter *Counter) *Sender {
+	return &Sender{next: next, counter: counter}
+}
+
+func (s *Sender) Send(ctx context.Context, endpoint entity.WebhookEndpoint, eventType string, payload []byte) (entity.DeliveryResult, error) {
+	result, err := s.next.Send(ctx, endpoint, eventType, payload)
+	s.counter.observe(err)
+	return result, err
+}
+
+// SendBatch считает пачку одной попыткой: доля ошибок — по запросам к получателям, а не по событиям
+func (s *Sender) SendBatch(ctx context.Context, endpoint entity.WebhookEndpoint, payloads [][]byte) (entity.DeliveryResult, error) {
+	result, err := s.next.SendBatch(ctx, endpoint, payloads)
+	s.counter.observe(err)
+	return result, err
+}
+
+// Cache считает обращения к кэшу. Промах — не ошибка, а недоступный в деградированном режиме Redis — ошибка:
+// кэш при этом не работает
+type Cache struct {
+	next    cache.Cache
+	counter *Counter
+}
+
+func NewCache(next cache.Cache, counter *Counter) *Cache {
+	return &Cache{next: next, counter: counter}
+}
+
+func (c *Cache) Get(ctx context.Context, key string, dest interface{}) error {
+	return c.observe(c.next.Get(ctx, key, dest))
+}
+
+func (c *Cache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return c.observe(c.next.Set(ctx, key, value, ttl))
+}
+
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	return c.observe(c.next.Delete(ctx, key))
+}
+
+func (c *Cache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := c.next.TTL(ctx, key)
+	return ttl, c.observe(err)
+}
+
+func (c *Cache) observe(err error) error {
+	if errors.Is(err, entity.ErrCacheMiss) {
+		c.counter.observe(nil)
+	} else {
+		c.counter.observe(err)
+	}
+	return err
+}
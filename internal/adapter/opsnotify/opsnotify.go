@@ -0,0 +1,116 @@
+// Package opsnotify отправляет сообщения дежурным в Slack (incoming webhook) и Telegram (Bot API)
+package opsnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/port/ops"
+)
+
+var (
+	_ ops.Notifier = (*Slack)(nil)
+	_ ops.Notifier = (*Telegram)(nil)
+	_ ops.Notifier = Multi(nil)
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	defaultTelegramURL = "https://api.telegram.org"
+)
+
+type Slack struct {
+	client *http.Client
+	url    string
+}
+
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{client: &http.Client{Timeout: defaultTimeout}, url: webhookURL}
+}
+
+func (s *Slack) Notify(ctx context.Context, text string) error {
+	if err := postJSON(ctx, s.client, s.url, map[string]string{"text": text}); err != nil {
+		return fmt.Errorf("slack: %w", err)
+	}
+	return nil
+}
+
+type Telegram struct {
+	client *http.Client
+	url    string
+	chatID string
+}
+
+// NewTelegram: baseURL пустой — api.telegram.org
+func NewTelegram(baseURL, botToken, chatID string) *Telegram {
+	if baseURL == "" {
+		baseURL = defaultTelegramURL
+	}
+	return &Telegram{
+		client: &http.Client{Timeout: defaultTimeout},
+		url:    baseURL + "/bot" + botToken + "/sendMessage",
+		chatID: chatID,
+	}
+}
+
+func (t *Telegram) Notify(ctx context.Context, text string) error {
+	body := map[string]any{
+		"chat_id":                  t.chatID,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	}
+	// ошибка клиента содержит URL запроса, а в нем токен бота
+	if err := postJSON(ctx, t.client, t.url, body); err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("telegram: %w", err)
+	}
+	return nil
+}
+
+// Multi отправляет сообщение во все каналы; сбой одного канала не мешает остальным
+type Multi []ops.Notifier
+
+func (m Multi) Notify(ctx context.Context, text string) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, text); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func postJSON(ctx context.Context, client *http.Client, target string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP status: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package publisher
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/events"
+	"github.com/4otis/geonotify-service/pkg/redis"
+)
+
+var _ events.Publisher = (*RedisStream)(nil)
+
+// RedisStream публикует события в Redis Stream, имя стрима берется из topic события.
+// Доставка at-least-once: потребители должны дедуплицировать по полю event_id
+type RedisStream struct {
+	redis  *redis.Client
+	maxLen int64
+}
+
+func NewRedisStream(redis *redis.Client, maxLen int64) *RedisStream {
+	return &RedisStream{
+		redis:  redis,
+		maxLen: maxLen,
+	}
+}
+
+func (p *RedisStream) Publish(ctx context.Context, event *entity.Event) error {
+	_, err := p.redis.XAdd(ctx, event.Topic, p.maxLen, map[string]interface{}{
+		"event_id":   strconv.FormatInt(event.ID, 10),
+		"type":       event.Type,
+		"key":        event.Key,
+		"payload":    string(event.Payload),
+		"created_at": event.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+
+	return err
+}
@@ -0,0 +1,275 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/delivery"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var _ delivery.Queue = (*AMQP)(nil)
+
+const (
+	amqpPrefetch = 10
+
+	// delayQueueIdle — через сколько простоя брокер удаляет очередь задержки
+	delayQueueIdle = time.Hour
+)
+
+// delayBuckets — длительности очередей задержки по возрастанию. Задержка округляется вверх
+// до ближайшей, поэтому очередей не больше len(delayBuckets) при любых политиках повторов.
+// Лишнее ожидание безвредно: вебхук в срок подберет опрос outbox, а поздняя задача не найдет
+// его для аренды
+var delayBuckets = []time.Duration{
+	time.Second,
+	5 * time.Second,
+	15 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+	3 * time.Hour,
+	6 * time.Hour,
+	12 * time.Hour,
+	24 * time.Hour,
+}
+
+// delayBucket округляет задержку вверх до длительности очереди задержки;
+// false — задержка длиннее самой долгой очереди
+func delayBucket(delay time.Duration) (time.Duration, bool) {
+	i, _ := slices.BinarySearch(delayBuckets, delay)
+	if i == len(delayBuckets) {
+		return 0, false
+	}
+	return delayBuckets[i], true
+}
+
+// AMQP — очередь в RabbitMQ. Отложенные задачи публикуются в очередь задержки
+// с x-message-ttl, откуда брокер по истечении TTL перекладывает их (dead-letter)
+// в основную очередь — повторные попытки планирует сам брокер.
+// Очередь задержки своя для каждой длительности из delayBuckets, чтобы короткие задержки
+// не ждали за длинными: брокер снимает по TTL только сообщения в голове очереди
+type AMQP struct {
+	url   string
+	queue string
+
+	mu          sync.Mutex
+	conn        *amqp.Connection
+	ch          *amqp.Channel
+	deliveries  <-chan amqp.Delivery
+	delayQueues map[int64]struct{}
+}
+
+func NewAMQP(url, queue string) (*AMQP, error) {
+	q := &AMQP{
+		url:         url,
+		queue:       queue,
+		delayQueues: make(map[int64]struct{}),
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.connect(); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// connect (пере)открывает соединение и объявляет основную очередь; вызывается под mu
+func (q *AMQP) connect() error {
+	if q.ch != nil && !q.ch.IsClosed() {
+		return nil
+	}
+	if q.conn != nil {
+		q.conn.Close()
+	}
+
+	conn, err := amqp.Dial(q.url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to amqp broker: %w", err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to open amqp channel: %w", err)
+	}
+
+	if _, err := ch.QueueDeclare(q.queue, true, false, false, false, nil); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to declare queue %s: %w", q.queue, err)
+	}
+
+	if err := ch.Qos(amqpPrefetch, 0, false); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to set amqp prefetch: %w", err)
+	}
+
+	deliveries, err := ch.Consume(q.queue, "", false, false, false, false, nil)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to consume queue %s: %w", q.queue, err)
+	}
+
+	q.conn = conn
+	q.ch = ch
+	q.deliveries = deliveries
+	clear(q.delayQueues)
+
+	return nil
+}
+
+func (q *AMQP) Push(ctx context.Context, task entity.WebhookTask) error {
+	return q.publish(ctx, q.queue, task)
+}
+
+func (q *AMQP) Schedule(ctx context.Context, task entity.WebhookTask, at time.Time) error {
+	delay := time.Until(at)
+	if delay <= 0 {
+		return q.Push(ctx, task)
+	}
+
+	// дольше суток брокер задачу не держит: вебхук подберет опрос outbox
+	bucket, ok := delayBucket(delay)
+	if !ok {
+		return nil
+	}
+
+	q.mu.Lock()
+	delayQueue, err := q.declareDelayQueue(bucket.Milliseconds())
+	q.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return q.publish(ctx, delayQueue, task)
+}
+
+// declareDelayQueue объявляет очередь задержки на delayMs из delayBuckets; вызывается под mu
+func (q *AMQP) declareDelayQueue(delayMs int64) (string, error) {
+	if err := q.connect(); err != nil {
+		return "", err
+	}
+
+	name := q.queue + ".delay." + strconv.FormatInt(delayMs, 10)
+	if _, ok := q.delayQueues[delayMs]; ok {
+		return name, nil
+	}
+
+	_, err := q.ch.QueueDeclare(name, true, false, false, false, amqp.Table{
+		"x-message-ttl":             delayMs,
+		"x-expires":                 delayMs + delayQueueIdle.Milliseconds(),
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": q.queue,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to declare delay queue %s: %w", name, err)
+	}
+
+	q.delayQueues[delayMs] = struct{}{}
+	return name, nil
+}
+
+func (q *AMQP) publish(ctx context.Context, queue string, task entity.WebhookTask) error {
+	body, err := json.Marshal(wireTask(task))
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook task: %w", err)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.connect(); err != nil {
+		return err
+	}
+
+	err = q.ch.PublishWithContext(ctx, "", queue, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Body:         body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish webhook task to %s: %w", queue, err)
+	}
+
+	return nil
+}
+
+// PopBlocking подтверждает сообщение сразу после получения: outbox в Postgres
+// остается источником истины, и потерянная задача будет подобрана опросом БД
+func (q *AMQP) PopBlocking(ctx context.Context, timeout time.Duration) (*entity.WebhookTask, error) {
+	q.mu.Lock()
+	err := q.connect()
+	deliveries := q.deliveries
+	q.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		return nil, nil
+	case d, ok := <-deliveries:
+		if !ok {
+			return nil, fmt.Errorf("amqp consumer channel closed")
+		}
+
+		if err := d.Ack(false); err != nil {
+			return nil, fmt.Errorf("failed to ack webhook task: %w", err)
+		}
+
+		var task wireTask
+		if err := json.Unmarshal(d.Body, &task); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal webhook task: %w", err)
+		}
+
+		result := entity.WebhookTask(task)
+		return &result, nil
+	}
+}
+
+// Depth возвращает только готовые сообщения основной очереди: отложенные разбросаны
+// по очередям задержки, которые брокер создает и удаляет сам
+func (q *AMQP) Depth(ctx context.Context) (entity.QueueDepth, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.connect(); err != nil {
+		return entity.QueueDepth{}, err
+	}
+
+	queue, err := q.ch.QueueDeclarePassive(q.queue, true, false, false, false, nil)
+	if err != nil {
+		return entity.QueueDepth{}, fmt.Errorf("failed to inspect queue %s: %w", q.queue, err)
+	}
+
+	return entity.QueueDepth{Ready: int64(queue.Messages)}, nil
+}
+
+func (q *AMQP) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.conn == nil {
+		return nil
+	}
+
+	return q.conn.Close()
+}
@@ -0,0 +1,29 @@
+package queue
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDelayBucket(t *testing.T) {
+	tests := []struct {
+		delay time.Duration
+		want  time.Duration
+		ok    bool
+	}{
+		{delay: time.Millisecond, want: time.Second, ok: true},
+		{delay: time.Second, want: time.Second, ok: true},
+		{delay: 1500 * time.Millisecond, want: 5 * time.Second, ok: true},
+		{delay: 61 * time.Second, want: 2 * time.Minute, ok: true},
+		{delay: 90 * time.Minute, want: 3 * time.Hour, ok: true},
+		{delay: 24 * time.Hour, want: 24 * time.Hour, ok: true},
+		{delay: 24*time.Hour + time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		got, ok := delayBucket(tt.delay)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("delayBucket(%v) = %v, %v; want %v, %v", tt.delay, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/delivery"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ delivery.Queue = (*Postgres)(nil)
+
+// pollInterval — как часто PopBlocking перепроверяет пустую очередь
+const pollInterval = 500 * time.Millisecond
+
+// Postgres — очередь на таблице webhook_queue для развертываний без Redis.
+// Конкурентные воркеры разбирают задачи через FOR UPDATE SKIP LOCKED
+type Postgres struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgres(pool *pgxpool.Pool) *Postgres {
+	return &Postgres{pool: pool}
+}
+
+func (q *Postgres) Push(ctx context.Context, task entity.WebhookTask) error {
+	return q.insert(ctx, task, time.Now())
+}
+
+func (q *Postgres) Schedule(ctx context.Context, task entity.WebhookTask, at time.Time) error {
+	return q.insert(ctx, task, at)
+}
+
+func (q *Postgres) insert(ctx context.Context, task entity.WebhookTask, at time.Time) error {
+	query := `
+	INSERT INTO webhook_queue (webhook_id, check_id, available_at)
+	VALUES ($1, $2, $3);
+	`
+
+	_, err := postgres.Conn(ctx, q.pool).Exec(ctx, query, task.WebhookID, task.CheckID, at)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue webhook task (webhook_id=%v): %w", task.WebhookID, err)
+	}
+
+	return nil
+}
+
+func (q *Postgres) PopBlocking(ctx context.Context, timeout time.Duration) (*entity.WebhookTask, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		task, err := q.pop(ctx)
+		if err != nil || task != nil {
+			return task, err
+		}
+
+		wait := min(pollInterval, time.Until(deadline))
+		if wait <= 0 {
+			return nil, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (q *Postgres) Depth(ctx context.Context) (entity.QueueDepth, error) {
+	query := `
+	SELECT
+		COUNT(*) FILTER (WHERE available_at <= NOW()),
+		COUNT(*) FILTER (WHERE available_at > NOW())
+	FROM webhook_queue;
+	`
+
+	var (
+		depth     entity.QueueDepth
+		scheduled int64
+	)
+	if err := postgres.Conn(ctx, q.pool).QueryRow(ctx, query).Scan(&depth.Ready, &scheduled); err != nil {
+		return entity.QueueDepth{}, fmt.Errorf("failed to count webhook tasks: %w", err)
+	}
+	depth.Scheduled = &scheduled
+
+	return depth, nil
+}
+
+func (q *Postgres) pop(ctx context.Context) (*entity.WebhookTask, error) {
+	query := `
+	DELETE FROM webhook_queue
+	WHERE id = (
+		SELECT id FROM webhook_queue
+		WHERE available_at <= NOW()
+		ORDER BY available_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING webhook_id, check_id;
+	`
+
+	var task entity.WebhookTask
+	err := postgres.Conn(ctx, q.pool).QueryRow(ctx, query).Scan(&task.WebhookID, &task.CheckID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to pop webhook task: %w", err)
+	}
+
+	return &task, nil
+}
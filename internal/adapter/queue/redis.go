@@ -0,0 +1,135 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/delivery"
+	"github.com/4otis/geonotify-service/pkg/redis"
+)
+
+var _ delivery.Queue = (*Redis)(nil)
+
+const (
+	readyKey     = "webhooks:queue"
+	scheduledKey = "webhooks:scheduled"
+
+	// promoteBatch ограничивает число отложенных задач, переносимых за один Pop
+	promoteBatch = 100
+)
+
+// wireTask — формат задачи в брокерах, совместимый с прежним форматом webhooks:queue
+type wireTask struct {
+	WebhookID int `json:"webhook_id"`
+	CheckID   int `json:"check_id"`
+}
+
+// Redis хранит готовые задачи в списке, отложенные — в sorted set
+// со временем готовности в миллисекундах Unix
+type Redis struct {
+	redis *redis.Client
+}
+
+func NewRedis(redis *redis.Client) *Redis {
+	return &Redis{redis: redis}
+}
+
+// Пока Redis недоступен, задачи не ставятся: вебхуки подберет опрос outbox
+func (q *Redis) Push(ctx context.Context, task entity.WebhookTask) error {
+	if !q.redis.Available() {
+		return entity.ErrDependencyUnavailable
+	}
+	return q.redis.LPush(ctx, readyKey, wireTask(task))
+}
+
+func (q *Redis) Schedule(ctx context.Context, task entity.WebhookTask, at time.Time) error {
+	if !q.redis.Available() {
+		return entity.ErrDependencyUnavailable
+	}
+	return q.redis.ZAdd(ctx, scheduledKey, float64(at.UnixMilli()), wireTask(task))
+}
+
+func (q *Redis) PopBlocking(ctx context.Context, timeout time.Duration) (*entity.WebhookTask, error) {
+	if !q.redis.Available() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(timeout):
+			return nil, nil
+		}
+	}
+	if err := q.promoteDue(ctx); err != nil {
+		return nil, err
+	}
+
+	_, data, err := q.redis.BRPop(ctx, readyKey, timeout)
+	if errors.Is(err, redis.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var task wireTask
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook task: %w", err)
+	}
+
+	result := entity.WebhookTask(task)
+	return &result, nil
+}
+
+// Depth: наступившие отложенные задачи еще лежат в sorted set до следующего Pop, но считаются готовыми
+func (q *Redis) Depth(ctx context.Context) (entity.QueueDepth, error) {
+	if !q.redis.Available() {
+		return entity.QueueDepth{}, entity.ErrDependencyUnavailable
+	}
+
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	ready, err := q.redis.LLen(ctx, readyKey)
+	if err != nil {
+		return entity.QueueDepth{}, err
+	}
+	due, err := q.redis.ZCount(ctx, scheduledKey, "-inf", now)
+	if err != nil {
+		return entity.QueueDepth{}, err
+	}
+	scheduled, err := q.redis.ZCount(ctx, scheduledKey, "("+now, "+inf")
+	if err != nil {
+		return entity.QueueDepth{}, err
+	}
+
+	return entity.QueueDepth{Ready: ready + due, Scheduled: &scheduled}, nil
+}
+
+// promoteDue переносит наступившие отложенные задачи в список готовых.
+// Задачу переносит только та реплика, чей ZREM ее действительно удалил
+func (q *Redis) promoteDue(ctx context.Context) error {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	members, err := q.redis.ZRangeByScore(ctx, scheduledKey, "-inf", now, 0, promoteBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		removed, err := q.redis.ZRem(ctx, scheduledKey, json.RawMessage(member))
+		if err != nil {
+			return err
+		}
+		if !removed {
+			continue
+		}
+
+		if err := q.redis.LPush(ctx, readyKey, json.RawMessage(member)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
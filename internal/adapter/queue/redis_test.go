@@ -0,0 +1,66 @@
+//go:build integration
+
+package queue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/adapter/queue"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/testenv"
+)
+
+var rdb *testenv.Redis
+
+func TestMain(m *testing.M) {
+	testenv.Main(m, func(ctx context.Context) (func(), error) {
+		var err error
+		rdb, err = testenv.StartRedis(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return rdb.Close, nil
+	})
+}
+
+func TestRedisQueue(t *testing.T) {
+	rdb.Reset(t)
+	q := queue.NewRedis(rdb.Client)
+	ctx := context.Background()
+
+	if err := q.Push(ctx, entity.WebhookTask{WebhookID: 1, CheckID: 10}); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if err := q.Schedule(ctx, entity.WebhookTask{WebhookID: 2}, time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("Schedule() due error = %v", err)
+	}
+	if err := q.Schedule(ctx, entity.WebhookTask{WebhookID: 3}, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Schedule() later error = %v", err)
+	}
+
+	depth, err := q.Depth(ctx)
+	if err != nil {
+		t.Fatalf("Depth() error = %v", err)
+	}
+	if depth.Ready != 2 || depth.Scheduled == nil || *depth.Scheduled != 1 {
+		t.Errorf("Depth() = %d ready, %v scheduled; want 2 and 1", depth.Ready, depth.Scheduled)
+	}
+
+	// задачи отдаются в порядке постановки, наступившая отложенная — после готовых
+	for _, want := range []entity.WebhookTask{{WebhookID: 1, CheckID: 10}, {WebhookID: 2}} {
+		task, err := q.PopBlocking(ctx, time.Second)
+		if err != nil {
+			t.Fatalf("PopBlocking() error = %v", err)
+		}
+		if task == nil || *task != want {
+			t.Fatalf("PopBlocking() = %+v, want %+v", task, want)
+		}
+	}
+
+	task, err := q.PopBlocking(ctx, time.Second)
+	if err != nil || task != nil {
+		t.Errorf("PopBlocking() on empty queue = %+v, %v; want nil", task, err)
+	}
+}
@@ -0,0 +1,53 @@
+package region
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/4otis/geonotify-service/internal/port/geo"
+	"github.com/4otis/geonotify-service/pkg/geojson"
+)
+
+var _ geo.OperatingArea = (*Area)(nil)
+
+// Area — область работы из GeoJSON-границ: точка должна попасть в allowlist (если он задан)
+// и не попасть в denylist
+type Area struct {
+	allow geojson.MultiPolygon
+	deny  geojson.MultiPolygon
+}
+
+// NewArea загружает границы из файлов; пустой путь означает, что список не задан
+func NewArea(allowPath, denyPath string) (*Area, error) {
+	allow, err := load(allowPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load region allowlist: %w", err)
+	}
+
+	deny, err := load(denyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load region denylist: %w", err)
+	}
+
+	return &Area{allow: allow, deny: deny}, nil
+}
+
+func (a *Area) Contains(lat, lng float64) bool {
+	if a.allow != nil && !a.allow.Contains(lat, lng) {
+		return false
+	}
+	return !a.deny.Contains(lat, lng)
+}
+
+func load(path string) (geojson.MultiPolygon, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return geojson.ParseBoundaries(data)
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.ApprovalRepo = (*ApprovalRepo)(nil)
+
+const approvalColumns = `
+	id, incident_id, status, COALESCE(severity, ''), requested_by, requested_at,
+	COALESCE(decided_by, ''), decided_at, COALESCE(reason, '')
+`
+
+type ApprovalRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewApprovalRepo(pool *pgxpool.Pool) *ApprovalRepo {
+	return &ApprovalRepo{pool: pool}
+}
+
+func scanApproval(row pgx.Row) (*entity.Approval, error) {
+	a := &entity.Approval{}
+
+	err := row.Scan(
+		&a.ID,
+		&a.IncidentID,
+		&a.Status,
+		&a.Severity,
+		&a.RequestedBy,
+		&a.RequestedAt,
+		&a.DecidedBy,
+		&a.DecidedAt,
+		&a.Reason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (r *ApprovalRepo) Create(ctx context.Context, approval entity.Approval) (approvalID int, err error) {
+	query := `
+	INSERT INTO pending_approvals (incident_id, requested_by, severity)
+	VALUES ($1, $2, NULLIF($3, ''))
+	RETURNING id;
+	`
+
+	err = postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		approval.IncidentID,
+		approval.RequestedBy,
+		approval.Severity,
+	).Scan(&approvalID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return 0, entity.ErrApprovalPending
+		}
+		return 0, fmt.Errorf("failed to create approval: %w", err)
+	}
+
+	return approvalID, nil
+}
+
+func (r *ApprovalRepo) ReadForUpdate(ctx context.Context, approvalID int) (*entity.Approval, error) {
+	query := `
+	SELECT ` + approvalColumns + `
+	FROM pending_approvals
+	WHERE id = $1
+	FOR UPDATE;
+	`
+
+	a, err := scanApproval(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, approvalID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrApprovalNotFound
+		}
+		return nil, fmt.Errorf("failed to select approval (by id=%v): %w", approvalID, err)
+	}
+
+	return a, nil
+}
+
+// HasPending сообщает, ждет ли зона решения по заявке
+func (r *ApprovalRepo) HasPending(ctx context.Context, incID int) (bool, error) {
+	query := `
+	SELECT EXISTS (
+		SELECT 1 FROM pending_approvals
+		WHERE incident_id = $1 AND status = 'pending'
+	);
+	`
+
+	var pending bool
+	if err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query, incID).Scan(&pending); err != nil {
+		return false, fmt.Errorf("failed to check pending approval (incident_id=%v): %w", incID, err)
+	}
+
+	return pending, nil
+}
+
+// ReadByStatus возвращает заявки от новых к старым; пустой status — заявки во всех статусах
+func (r *ApprovalRepo) ReadByStatus(ctx context.Context, status string, limit int) ([]*entity.Approval, error) {
+	query := `
+	SELECT ` + approvalColumns + `
+	FROM pending_approvals
+	WHERE $1 = '' OR status = $1
+	ORDER BY requested_at DESC, id DESC
+	LIMIT $2;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query approvals: %w", err)
+	}
+	defer rows.Close()
+
+	approvals := make([]*entity.Approval, 0, limit)
+	for rows.Next() {
+		a, err := scanApproval(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval from rows: %w", err)
+		}
+		approvals = append(approvals, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating approval rows: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// Decide закрывает заявку, ожидающую решения; иначе возвращает entity.ErrApprovalDecided
+func (r *ApprovalRepo) Decide(ctx context.Context, approvalID int, status, decidedBy, reason string) error {
+	query := `
+	UPDATE pending_approvals
+	SET
+		status = $1,
+		decided_by = $2,
+		decided_at = NOW(),
+		reason = NULLIF($3, '')
+	WHERE id = $4 AND status = 'pending';
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, status, decidedBy, reason, approvalID)
+	if err != nil {
+		return fmt.Errorf("failed to decide approval (id=%v): %w", approvalID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrApprovalDecided
+	}
+
+	return nil
+}
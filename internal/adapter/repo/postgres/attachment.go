@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.AttachmentRepo = (*AttachmentRepo)(nil)
+
+type AttachmentRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAttachmentRepo(pool *pgxpool.Pool) *AttachmentRepo {
+	return &AttachmentRepo{pool: pool}
+}
+
+func scanAttachment(row pgx.Row) (*entity.Attachment, error) {
+	a := &entity.Attachment{}
+
+	err := row.Scan(
+		&a.ID,
+		&a.IncidentID,
+		&a.ObjectKey,
+		&a.FileName,
+		&a.ContentType,
+		&a.Size,
+		&a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (r *AttachmentRepo) Create(ctx context.Context, attachment entity.Attachment) (attachmentID int, err error) {
+	query := `
+	INSERT INTO incident_attachments (incident_id, object_key, file_name, content_type, size_bytes)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id;
+	`
+
+	err = postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		attachment.IncidentID,
+		attachment.ObjectKey,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+	).Scan(&attachmentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return attachmentID, nil
+}
+
+func (r *AttachmentRepo) Read(ctx context.Context, incidentID, attachmentID int) (*entity.Attachment, error) {
+	query := `
+	SELECT id, incident_id, object_key, file_name, content_type, size_bytes, created_at
+	FROM incident_attachments
+	WHERE id = $1 AND incident_id = $2;
+	`
+
+	a, err := scanAttachment(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, attachmentID, incidentID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("failed to select attachment (id=%v): %w", attachmentID, err)
+	}
+
+	return a, nil
+}
+
+func (r *AttachmentRepo) ReadByIncident(ctx context.Context, incidentID int) ([]*entity.Attachment, error) {
+	query := `
+	SELECT id, incident_id, object_key, file_name, content_type, size_bytes, created_at
+	FROM incident_attachments
+	WHERE incident_id = $1
+	ORDER BY created_at DESC;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments (incident_id=%v): %w", incidentID, err)
+	}
+	defer rows.Close()
+
+	attachments := make([]*entity.Attachment, 0)
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment from rows: %w", err)
+		}
+		attachments = append(attachments, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating attachment rows: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentRepo) Delete(ctx context.Context, incidentID, attachmentID int) error {
+	query := `
+	DELETE FROM incident_attachments
+	WHERE id = $1 AND incident_id = $2;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, attachmentID, incidentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment (id=%v): %w", attachmentID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrAttachmentNotFound
+	}
+
+	return nil
+}
@@ -2,37 +2,186 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/4otis/geonotify-service/internal/entity"
 	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/fieldcrypt"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var _ repo.CheckRepo = (*CheckRepo)(nil)
 
+// sealedPrecision — до скольких знаков огрубляются координаты зашифрованной проверки в открытых колонках:
+// по ним ReadInArea отбирает кандидатов, точные координаты сверяются после расшифровки
+const sealedPrecision = 2
+
 type CheckRepo struct {
 	pool *pgxpool.Pool
+	// replica nil — статистика читается из основной БД
+	replica *postgres.Replica
+	// keys nil — проверки пишутся открыто
+	keys *fieldcrypt.Keyring
+}
+
+func NewCheckRepo(pool *pgxpool.Pool, replica *postgres.Replica, keys *fieldcrypt.Keyring) *CheckRepo {
+	return &CheckRepo{pool: pool, replica: replica, keys: keys}
+}
+
+// sealedCheck — то, что шифруется в sealed
+type sealedCheck struct {
+	UserID    string  `json:"user_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// storedCheck — колонки проверки в том виде, в котором они лежат в таблице
+type storedCheck struct {
+	userID    string
+	latitude  float64
+	longitude float64
+	// keyID nil — открытая проверка
+	keyID  *int
+	sealed []byte
+}
+
+// seal шифрует user_id и координаты активным ключом: в user_id остается HMAC-индекс, к которому
+// привязан шифротекст, координаты огрубляются до sealedPrecision
+func (r *CheckRepo) seal(check entity.Check) (storedCheck, error) {
+	if r.keys == nil {
+		return storedCheck{userID: check.UserID, latitude: check.Latitude, longitude: check.Longitude}, nil
+	}
+
+	plaintext, err := json.Marshal(sealedCheck{
+		UserID:    check.UserID,
+		Latitude:  check.Latitude,
+		Longitude: check.Longitude,
+	})
+	if err != nil {
+		return storedCheck{}, fmt.Errorf("failed to marshal check: %w", err)
+	}
+
+	index := r.keys.Index(check.UserID)
+	keyID, sealed, err := r.keys.Seal(plaintext, []byte(index))
+	if err != nil {
+		return storedCheck{}, fmt.Errorf("failed to encrypt check: %w", err)
+	}
+
+	scale := math.Pow10(sealedPrecision)
+	return storedCheck{
+		userID:    index,
+		latitude:  math.Round(check.Latitude*scale) / scale,
+		longitude: math.Round(check.Longitude*scale) / scale,
+		keyID:     &keyID,
+		sealed:    sealed,
+	}, nil
+}
+
+// open заменяет HMAC-индекс и огрубленные координаты прочитанной проверки расшифрованными
+func (r *CheckRepo) open(check *entity.Check, keyID *int, sealed []byte) error {
+	if keyID == nil || *keyID == 0 {
+		return nil
+	}
+	if r.keys == nil {
+		return fmt.Errorf("check %d is encrypted with key %d, but no encryption keys are configured", check.ID, *keyID)
+	}
+
+	plaintext, err := r.keys.Open(*keyID, sealed, []byte(check.UserID))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt check %d: %w", check.ID, err)
+	}
+
+	var opened sealedCheck
+	if err := json.Unmarshal(plaintext, &opened); err != nil {
+		return fmt.Errorf("failed to unmarshal check %d: %w", check.ID, err)
+	}
+	check.UserID = opened.UserID
+	check.Latitude = opened.Latitude
+	check.Longitude = opened.Longitude
+
+	return nil
 }
 
-func NewCheckRepo(pool *pgxpool.Pool) *CheckRepo {
-	return &CheckRepo{pool: pool}
+// userIDKeys возвращает значения user_id, под которыми лежат проверки пользователя: открытое
+// и HMAC-индексы под всеми ключами, пока проверки под прежними ключами не перешифрованы
+func userIDKeys(keys *fieldcrypt.Keyring, userID string) []string {
+	if keys == nil {
+		return []string{userID}
+	}
+	return append([]string{userID}, keys.Indexes(userID)...)
+}
+
+// query читает проверки, выбранные первыми колонками
+// id, user_id, latitude, longitude, has_alert, created_at, stored_at, key_id, sealed, и расшифровывает их
+func (r *CheckRepo) query(ctx context.Context, conn postgres.Querier, query string, args ...any) ([]*entity.Check, error) {
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := []*entity.Check{}
+	for rows.Next() {
+		var (
+			check  = &entity.Check{}
+			keyID  *int
+			sealed []byte
+		)
+		err := rows.Scan(
+			&check.ID,
+			&check.UserID,
+			&check.Latitude,
+			&check.Longitude,
+			&check.HasAlert,
+			&check.CreatedAt,
+			&check.StoredAt,
+			&keyID,
+			&sealed,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan check: %w", err)
+		}
+		if err := r.open(check, keyID, sealed); err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, check)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating check rows: %w", err)
+	}
+
+	return checks, nil
 }
 
 func (r *CheckRepo) Create(ctx context.Context, check entity.Check) (checkID int, err error) {
+	stored, err := r.seal(check)
+	if err != nil {
+		return 0, err
+	}
+
 	query := `
-	INSERT INTO checks (user_id, latitude, longitude, has_alert, created_at)
-	VALUES ($1, $2, $3, $4, $5)
+	INSERT INTO checks (user_id, latitude, longitude, has_alert, created_at, key_id, sealed, incident_ids)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	RETURNING id;
 	`
 
-	err = r.pool.QueryRow(ctx, query,
-		check.UserID,
-		check.Latitude,
-		check.Longitude,
+	err = postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		stored.userID,
+		stored.latitude,
+		stored.longitude,
 		check.HasAlert,
 		time.Now(),
+		stored.keyID,
+		stored.sealed,
+		incidentIDsColumn(check.IncidentIDs),
 	).Scan(&checkID)
 
 	if err != nil {
@@ -42,7 +191,114 @@ func (r *CheckRepo) Create(ctx context.Context, check entity.Check) (checkID int
 	return checkID, nil
 }
 
-func (r *CheckRepo) GetStats(ctx context.Context, windowMinutes int) (userCount, totalChecks int, periodStart time.Time, err error) {
+// incidentIDsColumn — значение incident_ids: проверка без алерта хранит NULL
+func incidentIDsColumn(ids []int) any {
+	if len(ids) == 0 {
+		return nil
+	}
+	return ids
+}
+
+func (r *CheckRepo) ReserveIDs(ctx context.Context, n int) ([]int, error) {
+	query := `SELECT nextval(pg_get_serial_sequence('checks', 'id')) FROM generate_series(1, $1);`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve check ids: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve check ids: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *CheckRepo) CreateBatch(ctx context.Context, checks []entity.Check) ([]int, error) {
+	if len(checks) == 0 {
+		return nil, nil
+	}
+
+	missing := 0
+	for _, c := range checks {
+		if c.ID == 0 {
+			missing++
+		}
+	}
+
+	var reserved []int
+	if missing > 0 {
+		var err error
+		reserved, err = r.ReserveIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	checkIDs := make([]int, len(checks))
+	_, err := postgres.Conn(ctx, r.pool).CopyFrom(ctx,
+		pgx.Identifier{"checks"},
+		[]string{"id", "user_id", "latitude", "longitude", "has_alert", "created_at", "key_id", "sealed", "incident_ids"},
+		pgx.CopyFromSlice(len(checks), func(i int) ([]any, error) {
+			c := checks[i]
+			if c.ID == 0 {
+				c.ID, reserved = reserved[0], reserved[1:]
+			}
+			if c.CreatedAt.IsZero() {
+				c.CreatedAt = now
+			}
+			checkIDs[i] = c.ID
+			stored, err := r.seal(c)
+			if err != nil {
+				return nil, err
+			}
+			return []any{c.ID, stored.userID, stored.latitude, stored.longitude, c.HasAlert, c.CreatedAt, stored.keyID, stored.sealed,
+				incidentIDsColumn(c.IncidentIDs)}, nil
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy checks batch: %w", err)
+	}
+
+	return checkIDs, nil
+}
+
+func (r *CheckRepo) CopyBatch(ctx context.Context, checks []entity.Check) error {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	_, err := postgres.Conn(ctx, r.pool).CopyFrom(ctx,
+		pgx.Identifier{"checks"},
+		[]string{"user_id", "latitude", "longitude", "has_alert", "created_at", "key_id", "sealed", "incident_ids"},
+		pgx.CopyFromSlice(len(checks), func(i int) ([]any, error) {
+			c := checks[i]
+			if c.CreatedAt.IsZero() {
+				c.CreatedAt = now
+			}
+			stored, err := r.seal(c)
+			if err != nil {
+				return nil, err
+			}
+			return []any{stored.userID, stored.latitude, stored.longitude, c.HasAlert, c.CreatedAt, stored.keyID, stored.sealed,
+				incidentIDsColumn(c.IncidentIDs)}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to copy checks batch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CheckRepo) GetStats(ctx context.Context, windowMinutes int, estimate bool) (userCount, totalChecks int, periodStart time.Time, err error) {
+	if estimate {
+		return r.estimateStats(ctx, windowMinutes)
+	}
+
 	query := `
 	SELECT 
 		COUNT(DISTINCT user_id) as user_count,
@@ -54,10 +310,377 @@ func (r *CheckRepo) GetStats(ctx context.Context, windowMinutes int) (userCount,
 
 	query = fmt.Sprintf(query, windowMinutes, windowMinutes)
 
-	err = r.pool.QueryRow(ctx, query).Scan(&userCount, &totalChecks, &periodStart)
+	err = postgres.ReadConn(ctx, r.pool, r.replica).QueryRow(ctx, query).Scan(&userCount, &totalChecks, &periodStart)
 	if err != nil {
 		return 0, 0, time.Time{}, fmt.Errorf("failed to get stats: %w", err)
 	}
 
 	return userCount, totalChecks, periodStart, nil
 }
+
+// estimateStats оценивает число проверок и пользователей за окно по плану запроса, не читая сами проверки
+func (r *CheckRepo) estimateStats(ctx context.Context, windowMinutes int) (userCount, totalChecks int, periodStart time.Time, err error) {
+	conn := postgres.ReadConn(ctx, r.pool, r.replica)
+
+	err = conn.QueryRow(ctx, `SELECT NOW() - make_interval(mins => $1);`, windowMinutes).Scan(&periodStart)
+	if err != nil {
+		return 0, 0, time.Time{}, fmt.Errorf("failed to get stats period: %w", err)
+	}
+
+	window := `FROM checks WHERE created_at >= NOW() - make_interval(mins => $1)`
+
+	totalChecks, err = postgres.EstimateRows(ctx, conn, `SELECT 1 `+window, windowMinutes)
+	if err != nil {
+		return 0, 0, time.Time{}, fmt.Errorf("failed to estimate checks count: %w", err)
+	}
+
+	userCount, err = postgres.EstimateRows(ctx, conn, `SELECT DISTINCT user_id `+window, windowMinutes)
+	if err != nil {
+		return 0, 0, time.Time{}, fmt.Errorf("failed to estimate users count: %w", err)
+	}
+
+	return min(userCount, totalChecks), totalChecks, periodStart, nil
+}
+
+const (
+	checkPartitionPrefix = "checks_p"
+	checkPartitionLayout = "20060102"
+)
+
+func (r *CheckRepo) CreateDailyPartition(ctx context.Context, day time.Time) (created bool, err error) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	name := checkPartitionPrefix + from.Format(checkPartitionLayout)
+
+	var exists bool
+	err = postgres.Conn(ctx, r.pool).QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL;`, name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check partition %s: %w", name, err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	query := fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s PARTITION OF checks
+	FOR VALUES FROM ('%s') TO ('%s');
+	`,
+		pgx.Identifier{name}.Sanitize(),
+		from.Format(time.DateOnly),
+		to.Format(time.DateOnly),
+	)
+
+	if _, err = postgres.Conn(ctx, r.pool).Exec(ctx, query); err != nil {
+		return false, fmt.Errorf("failed to create partition %s: %w", name, err)
+	}
+
+	return true, nil
+}
+
+func (r *CheckRepo) DropPartitionsBefore(ctx context.Context, before time.Time) (dropped []string, err error) {
+	query := `
+	SELECT child.relname
+	FROM pg_inherits
+	JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+	JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+	WHERE parent.relname = 'checks' AND child.relname LIKE 'checks\_p%';
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check partitions: %w", err)
+	}
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan partition name: %w", err)
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating partition rows: %w", err)
+	}
+
+	for _, name := range names {
+		day, err := time.Parse(checkPartitionLayout, strings.TrimPrefix(name, checkPartitionPrefix))
+		if err != nil {
+			continue
+		}
+
+		// партиция удаляется только целиком вышедшей за окно хранения
+		if !day.AddDate(0, 0, 1).After(before) {
+			query := fmt.Sprintf(`DROP TABLE IF EXISTS %s;`, pgx.Identifier{name}.Sanitize())
+			if _, err := postgres.Conn(ctx, r.pool).Exec(ctx, query); err != nil {
+				return dropped, fmt.Errorf("failed to drop partition %s: %w", name, err)
+			}
+			dropped = append(dropped, name)
+		}
+	}
+
+	return dropped, nil
+}
+
+func (r *CheckRepo) ReadRecent(ctx context.Context, limit int) ([]*entity.Check, error) {
+	query := `
+	SELECT id, user_id, latitude, longitude, has_alert, created_at, COALESCE(stored_at, created_at), key_id, sealed
+	FROM checks
+	ORDER BY created_at DESC
+	LIMIT $1;
+	`
+
+	checks, err := r.query(ctx, postgres.ReadConn(ctx, r.pool, r.replica), query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent checks: %w", err)
+	}
+
+	return checks, nil
+}
+
+// ReadInArea читает с реплики: фильтр по created_at отсекает лишние партиции, индекса по координатам нет.
+// У зашифрованных проверок в таблице огрубленные координаты, поэтому кандидаты отбираются с запасом
+// на огрубление, а точные координаты сверяются после расшифровки — страницами, пока не наберется limit
+func (r *CheckRepo) ReadInArea(ctx context.Context, from time.Time, minLat, maxLat, minLng, maxLng float64, limit int) ([]*entity.Check, error) {
+	query := `
+	SELECT id, user_id, latitude, longitude, has_alert, created_at, COALESCE(stored_at, created_at), key_id, sealed
+	FROM checks
+	WHERE (created_at, id) > ($1, $2)
+		AND latitude BETWEEN $3 AND $4
+		AND longitude BETWEEN $5 AND $6
+	ORDER BY created_at, id
+	LIMIT $7;
+	`
+
+	margin := 0.0
+	if r.keys != nil {
+		margin = math.Pow10(-sealedPrecision)
+	}
+
+	var checks []*entity.Check
+	// id проверок положительные, поэтому курсор (from, 0) включает проверки, созданные ровно в from
+	after := entity.SyncCursor{UpdatedAt: from}
+	for {
+		page, err := r.query(ctx, postgres.ReadConn(ctx, r.pool, r.replica), query,
+			after.UpdatedAt, after.ID, minLat-margin, maxLat+margin, minLng-margin, maxLng+margin, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query checks in area: %w", err)
+		}
+
+		for _, check := range page {
+			if check.Latitude < minLat || check.Latitude > maxLat || check.Longitude < minLng || check.Longitude > maxLng {
+				continue
+			}
+			checks = append(checks, check)
+			if len(checks) == limit {
+				return checks, nil
+			}
+		}
+		if len(page) < limit {
+			return checks, nil
+		}
+
+		last := page[len(page)-1]
+		after = entity.SyncCursor{UpdatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// ReadSince идет по stored_at, а не по created_at: отложенная запись сохраняет проверку с прошедшим
+// created_at, и курсор по created_at ее бы пропустил
+func (r *CheckRepo) ReadSince(ctx context.Context, after entity.SyncCursor, settleSeconds, limit int) ([]*entity.Check, error) {
+	query := `
+	SELECT id, user_id, latitude, longitude, has_alert, created_at, COALESCE(stored_at, created_at), key_id, sealed
+	FROM checks
+	WHERE (COALESCE(stored_at, created_at), id) > ($1, $2)
+		AND COALESCE(stored_at, created_at) <= NOW() - make_interval(secs => $3)
+	ORDER BY COALESCE(stored_at, created_at), id
+	LIMIT $4;
+	`
+
+	checks, err := r.query(ctx, postgres.ReadConn(ctx, r.pool, r.replica), query, after.UpdatedAt, after.ID, settleSeconds, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query checks since cursor: %w", err)
+	}
+
+	return checks, nil
+}
+
+// DeleteByUser удаляет и присутствие пользователя в поминутной статистике: оно сведено из его проверок
+func (r *CheckRepo) DeleteByUser(ctx context.Context, userID string) (int64, error) {
+	query := `
+	WITH stats AS (
+		DELETE FROM check_stats_users
+		WHERE user_id = ANY($1)
+	)
+	DELETE FROM checks
+	WHERE user_id = ANY($1);
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, userIDKeys(r.keys, userID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user checks: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// AnonymizeByUser оставляет обезличенные проверки открытыми: key_id = 0 исключает их из перешифровки.
+// Координаты зашифрованных проверок в таблице уже огрублены до sealedPrecision. Присутствие пользователя
+// в поминутной статистике удаляется, как в DeleteByUser: счетчики проверок остаются
+func (r *CheckRepo) AnonymizeByUser(ctx context.Context, userID, anonymizedID string, precision int) (int64, error) {
+	query := `
+	WITH stats AS (
+		DELETE FROM check_stats_users
+		WHERE user_id = ANY($1)
+	)
+	UPDATE checks
+	SET
+		user_id = $2,
+		latitude = ROUND(latitude::NUMERIC, $3)::DOUBLE PRECISION,
+		longitude = ROUND(longitude::NUMERIC, $3)::DOUBLE PRECISION,
+		key_id = 0,
+		sealed = NULL
+	WHERE user_id = ANY($1);
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, userIDKeys(r.keys, userID), anonymizedID, precision)
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize user checks: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *CheckRepo) ReadByUser(ctx context.Context, userID string) ([]*entity.Check, error) {
+	query := `
+	SELECT id, user_id, latitude, longitude, has_alert, created_at, COALESCE(stored_at, created_at), key_id, sealed
+	FROM checks
+	WHERE user_id = ANY($1)
+	ORDER BY created_at, id;
+	`
+
+	checks, err := r.query(ctx, postgres.Conn(ctx, r.pool), query, userIDKeys(r.keys, userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user checks: %w", err)
+	}
+
+	return checks, nil
+}
+
+// Reencrypt вызывается в транзакции: выбранные проверки заблокированы до коммита, поэтому реплики
+// перешифровывают разные проверки.
+// HMAC-индекс user_id у каждого ключа свой, поэтому до перешифровки у пользователя есть проверки под
+// несколькими user_id, и COUNT(DISTINCT user_id) в статистике считает его по разу на каждый. Вместе
+// с проверками на индекс активного ключа переводится и поминутное присутствие пользователя в
+// check_stats_users, так что после перешифровки пользователь снова считается один раз
+func (r *CheckRepo) Reencrypt(ctx context.Context, limit int) (int, error) {
+	if r.keys == nil {
+		return 0, nil
+	}
+
+	query := `
+	SELECT id, user_id, latitude, longitude, has_alert, created_at, COALESCE(stored_at, created_at), key_id, sealed
+	FROM checks
+	WHERE key_id IS NULL OR (key_id > 0 AND key_id <> $1)
+	LIMIT $2
+	FOR UPDATE SKIP LOCKED;
+	`
+
+	checks, err := r.query(ctx, postgres.Conn(ctx, r.pool), query, r.keys.Active(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock checks for re-encryption: %w", err)
+	}
+	if len(checks) == 0 {
+		return 0, nil
+	}
+
+	var (
+		ids        = make([]int, len(checks))
+		createdAts = make([]time.Time, len(checks))
+		userIDs    = make([]string, len(checks))
+		latitudes  = make([]float64, len(checks))
+		longitudes = make([]float64, len(checks))
+		sealed     = make([][]byte, len(checks))
+	)
+	for i, check := range checks {
+		stored, err := r.seal(*check)
+		if err != nil {
+			return 0, err
+		}
+		ids[i] = check.ID
+		createdAts[i] = check.CreatedAt
+		userIDs[i] = stored.userID
+		latitudes[i] = stored.latitude
+		longitudes[i] = stored.longitude
+		sealed[i] = stored.sealed
+	}
+
+	update := `
+	UPDATE checks c
+	SET
+		user_id = u.user_id,
+		latitude = u.latitude,
+		longitude = u.longitude,
+		key_id = $7,
+		sealed = u.sealed
+	FROM unnest($1::INTEGER[], $2::TIMESTAMP[], $3::TEXT[], $4::DOUBLE PRECISION[], $5::DOUBLE PRECISION[], $6::BYTEA[])
+		AS u(id, created_at, user_id, latitude, longitude, sealed)
+	WHERE c.id = u.id AND c.created_at = u.created_at;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, update, ids, createdAts, userIDs, latitudes, longitudes, sealed, r.keys.Active())
+	if err != nil {
+		return 0, fmt.Errorf("failed to re-encrypt checks: %w", err)
+	}
+
+	if err := r.remapStatsUsers(ctx, checks); err != nil {
+		return 0, err
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// remapStatsUsers переводит присутствие пользователей перешифрованных проверок в check_stats_users
+// с открытого user_id и индексов прежних ключей на индекс активного ключа
+func (r *CheckRepo) remapStatsUsers(ctx context.Context, checks []*entity.Check) error {
+	var (
+		seen = make(map[string]bool, len(checks))
+		from []string
+		to   []string
+	)
+	for _, check := range checks {
+		if seen[check.UserID] {
+			continue
+		}
+		seen[check.UserID] = true
+
+		active := r.keys.Index(check.UserID)
+		for _, old := range userIDKeys(r.keys, check.UserID) {
+			if old != active {
+				from = append(from, old)
+				to = append(to, active)
+			}
+		}
+	}
+
+	query := `
+	WITH moved AS (
+		DELETE FROM check_stats_users s
+		USING unnest($1::TEXT[], $2::TEXT[]) AS m(old_id, new_id)
+		WHERE s.user_id = m.old_id
+		RETURNING s.minute, m.new_id
+	)
+	INSERT INTO check_stats_users (minute, user_id)
+	SELECT DISTINCT minute, new_id FROM moved
+	ON CONFLICT DO NOTHING;
+	`
+
+	if _, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, from, to); err != nil {
+		return fmt.Errorf("failed to remap stats users of re-encrypted checks: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	pgrepo "github.com/4otis/geonotify-service/internal/adapter/repo/postgres"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/testenv"
+	"github.com/4otis/geonotify-service/pkg/fieldcrypt"
+)
+
+func TestCheckRepoCreate(t *testing.T) {
+	keys, err := fieldcrypt.Parse([]string{"1:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", fieldcrypt.KeySize)))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		keys *fieldcrypt.Keyring
+		// stored — user_id в таблице: открытый или HMAC-индекс
+		stored func(userID string) string
+	}{
+		{name: "plain", stored: func(userID string) string { return userID }},
+		{name: "encrypted", keys: keys, stored: keys.Index},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg.Reset(t, "checks")
+			repo := pgrepo.NewCheckRepo(pg.Pool, nil, tt.keys)
+			ctx := context.Background()
+
+			check := testenv.Check("user-1", 55.75581, 37.61732)
+			check.HasAlert = true
+			check.IncidentIDs = []int{3, 5}
+			id, err := repo.Create(ctx, check)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			recent, err := repo.ReadRecent(ctx, 10)
+			if err != nil {
+				t.Fatalf("ReadRecent() error = %v", err)
+			}
+			if len(recent) != 1 {
+				t.Fatalf("ReadRecent() = %d checks, want 1", len(recent))
+			}
+			got := recent[0]
+			if got.ID != id || got.UserID != check.UserID || got.Latitude != check.Latitude || !got.HasAlert {
+				t.Errorf("ReadRecent() = %+v, want %+v", got, check)
+			}
+
+			n := testenv.Count(t, pg.Pool, `SELECT COUNT(*) FROM checks WHERE user_id = $1 AND incident_ids = $2`,
+				tt.stored(check.UserID), check.IncidentIDs)
+			if n != 1 {
+				t.Errorf("stored checks = %d, want 1", n)
+			}
+
+			// новая проверка попадает в дневную партицию, а не в checks_default
+			if n := testenv.Count(t, pg.Pool, `SELECT COUNT(*) FROM checks_default`); n != 0 {
+				t.Errorf("checks_default rows = %d, want 0", n)
+			}
+		})
+	}
+}
+
+func TestCheckRepoBatches(t *testing.T) {
+	pg.Reset(t, "checks")
+	repo := pgrepo.NewCheckRepo(pg.Pool, nil, nil)
+	ctx := context.Background()
+
+	reserved, err := repo.ReserveIDs(ctx, 1)
+	if err != nil {
+		t.Fatalf("ReserveIDs() error = %v", err)
+	}
+
+	keyed := []entity.Check{
+		testenv.Check("user-1", 55.1, 37.1),
+		testenv.Check("user-2", 55.2, 37.2),
+	}
+	keyed[1].ID = reserved[0]
+
+	ids, err := repo.CreateBatch(ctx, keyed)
+	if err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+	if len(ids) != 2 || ids[1] != reserved[0] || ids[0] == 0 || ids[0] == ids[1] {
+		t.Errorf("CreateBatch() ids = %v, reserved %v", ids, reserved)
+	}
+
+	if err := repo.CopyBatch(ctx, []entity.Check{testenv.Check("user-3", 55.3, 37.3)}); err != nil {
+		t.Fatalf("CopyBatch() error = %v", err)
+	}
+
+	if n := testenv.Count(t, pg.Pool, `SELECT COUNT(*) FROM checks`); n != 3 {
+		t.Errorf("checks = %d, want 3", n)
+	}
+	for _, id := range ids {
+		if n := testenv.Count(t, pg.Pool, `SELECT COUNT(*) FROM checks WHERE id = $1`, id); n != 1 {
+			t.Errorf("checks with id %d = %d, want 1", id, n)
+		}
+	}
+}
+
+// проверка, записанная позже с прошедшим created_at (CheckBatcher), не пропадает за курсором
+func TestCheckRepoReadSinceLateCheck(t *testing.T) {
+	pg.Reset(t, "checks")
+	repo := pgrepo.NewCheckRepo(pg.Pool, nil, nil)
+	ctx := context.Background()
+
+	if err := repo.CopyBatch(ctx, []entity.Check{testenv.Check("user-1", 55.1, 37.1)}); err != nil {
+		t.Fatalf("CopyBatch() error = %v", err)
+	}
+	first, err := repo.ReadSince(ctx, entity.SyncCursor{}, 0, 10)
+	if err != nil || len(first) != 1 {
+		t.Fatalf("ReadSince() = %d checks, %v; want 1", len(first), err)
+	}
+
+	late := testenv.Check("user-2", 55.2, 37.2)
+	late.CreatedAt = first[0].CreatedAt.Add(-time.Hour)
+	if err := repo.CopyBatch(ctx, []entity.Check{late}); err != nil {
+		t.Fatalf("CopyBatch() error = %v", err)
+	}
+
+	got, err := repo.ReadSince(ctx, entity.SyncCursor{UpdatedAt: first[0].StoredAt, ID: first[0].ID}, 0, 10)
+	if err != nil {
+		t.Fatalf("ReadSince() error = %v", err)
+	}
+	if len(got) != 1 || got[0].UserID != late.UserID || !got[0].CreatedAt.Equal(late.CreatedAt) {
+		t.Errorf("ReadSince() = %+v, want late check of user-2", got)
+	}
+}
+
+func TestCheckRepoPartitions(t *testing.T) {
+	repo := pgrepo.NewCheckRepo(pg.Pool, nil, nil)
+	ctx := context.Background()
+
+	day := time.Date(2001, 2, 3, 15, 4, 5, 0, time.UTC)
+	created, err := repo.CreateDailyPartition(ctx, day)
+	if err != nil || !created {
+		t.Fatalf("CreateDailyPartition() = %v, %v; want created", created, err)
+	}
+	created, err = repo.CreateDailyPartition(ctx, day)
+	if err != nil || created {
+		t.Fatalf("CreateDailyPartition() again = %v, %v; want existing", created, err)
+	}
+
+	// партиция дня удаляется, только когда весь день вышел за границу
+	dropped, err := repo.DropPartitionsBefore(ctx, day)
+	if err != nil || len(dropped) != 0 {
+		t.Fatalf("DropPartitionsBefore(same day) = %v, %v; want nothing", dropped, err)
+	}
+	dropped, err = repo.DropPartitionsBefore(ctx, day.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("DropPartitionsBefore() error = %v", err)
+	}
+	if len(dropped) != 1 || dropped[0] != "checks_p20010203" {
+		t.Errorf("DropPartitionsBefore() = %v, want [checks_p20010203]", dropped)
+	}
+}
+
+func TestCheckRepoDeleteByUser(t *testing.T) {
+	pg.Reset(t, "checks")
+	repo := pgrepo.NewCheckRepo(pg.Pool, nil, nil)
+	ctx := context.Background()
+
+	for _, userID := range []string{"user-1", "user-1", "user-2"} {
+		if _, err := repo.Create(ctx, testenv.Check(userID, 55.75, 37.61)); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	deleted, err := repo.DeleteByUser(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("DeleteByUser() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("DeleteByUser() = %d, want 2", deleted)
+	}
+	if n := testenv.Count(t, pg.Pool, `SELECT COUNT(*) FROM checks`); n != 1 {
+		t.Errorf("checks left = %d, want 1", n)
+	}
+}
+
+// после ротации ключа у пользователя есть проверки под двумя HMAC-индексами: до перешифровки статистика
+// считает его дважды, после — один раз
+func TestCheckRepoReencryptMergesStatsUsers(t *testing.T) {
+	pg.Reset(t, "checks", "check_stats_minutes", "check_stats_users", "check_stats_incidents")
+	ctx := context.Background()
+
+	secret := func(c string) string { return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(c, fieldcrypt.KeySize))) }
+	oldKeys, err := fieldcrypt.Parse([]string{"1:" + secret("a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := fieldcrypt.Parse([]string{"2:" + secret("b"), "1:" + secret("a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := pgrepo.NewStatsRepo(pg.Pool, nil)
+
+	if _, err := pgrepo.NewCheckRepo(pg.Pool, nil, oldKeys).Create(ctx, testenv.Check("user-1", 55.1, 37.1)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	repo := pgrepo.NewCheckRepo(pg.Pool, nil, rotated)
+	if _, err := repo.Create(ctx, testenv.Check("user-1", 55.2, 37.2)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := stats.Rollup(ctx, time.Now().UTC().Add(-time.Hour), time.Now().UTC().Add(time.Minute)); err != nil {
+		t.Fatalf("Rollup() error = %v", err)
+	}
+
+	window := func() entity.CheckStats {
+		t.Helper()
+		got, err := stats.Read(ctx, time.Now().UTC().Add(-2*time.Hour), time.Now().UTC().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		return got
+	}
+	if got := window(); got.Users != 2 {
+		t.Errorf("users before re-encryption = %d, want 2: one per blind index", got.Users)
+	}
+
+	if n, err := repo.Reencrypt(ctx, 10); err != nil || n != 1 {
+		t.Fatalf("Reencrypt() = %d, %v; want 1", n, err)
+	}
+	if got := window(); got.Users != 1 {
+		t.Errorf("users after re-encryption = %d, want 1", got.Users)
+	}
+	if n := testenv.Count(t, pg.Pool, `SELECT COUNT(*) FROM check_stats_users WHERE user_id = $1`, rotated.Index("user-1")); n == 0 {
+		t.Error("stats users are not moved to the active key index")
+	}
+}
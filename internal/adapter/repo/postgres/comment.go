@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.CommentRepo = (*CommentRepo)(nil)
+
+const commentColumns = `
+	c.id, c.incident_id, c.body, COALESCE(c.author, ''), c.created_at
+`
+
+type CommentRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewCommentRepo(pool *pgxpool.Pool) *CommentRepo {
+	return &CommentRepo{pool: pool}
+}
+
+func (r *CommentRepo) Create(ctx context.Context, comment entity.IncidentComment) (*entity.IncidentComment, error) {
+	query := `
+	INSERT INTO incident_comments (incident_id, body, author)
+	VALUES ($1, $2, NULLIF($3, ''))
+	RETURNING id, created_at;
+	`
+
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		comment.IncidentID,
+		comment.Body,
+		comment.Author,
+	).Scan(&comment.ID, &comment.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create incident comment (id=%v): %w", comment.IncidentID, err)
+	}
+
+	return &comment, nil
+}
+
+func (r *CommentRepo) ReadByIncident(ctx context.Context, incID, limit int) ([]entity.IncidentComment, error) {
+	query := `
+	SELECT ` + commentColumns + `
+	FROM incident_comments c
+	WHERE c.incident_id = $1
+	ORDER BY c.id DESC
+	LIMIT $2;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, incID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident comments (id=%v): %w", incID, err)
+	}
+
+	return scanComments(rows)
+}
+
+func (r *CommentRepo) ReadLatest(ctx context.Context, incIDs []int) ([]entity.IncidentComment, error) {
+	if len(incIDs) == 0 {
+		return []entity.IncidentComment{}, nil
+	}
+
+	query := `
+	SELECT DISTINCT ON (c.incident_id) ` + commentColumns + `
+	FROM incident_comments c
+	WHERE c.incident_id = ANY($1)
+	ORDER BY c.incident_id, c.id DESC;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, incIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest incident comments: %w", err)
+	}
+
+	return scanComments(rows)
+}
+
+func scanComments(rows pgx.Rows) ([]entity.IncidentComment, error) {
+	defer rows.Close()
+
+	comments := make([]entity.IncidentComment, 0)
+	for rows.Next() {
+		var c entity.IncidentComment
+		if err := rows.Scan(&c.ID, &c.IncidentID, &c.Body, &c.Author, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan incident comment from rows: %w", err)
+		}
+		comments = append(comments, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating incident comment rows: %w", err)
+	}
+
+	return comments, nil
+}
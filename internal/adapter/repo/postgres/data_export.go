@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.DataExportRepo = (*DataExportRepo)(nil)
+
+const dataExportColumns = `
+	id, user_id, status, requested_by, requested_at, completed_at, expires_at,
+	COALESCE(object_key, ''), size_bytes, attempts, COALESCE(last_error, '')
+`
+
+type DataExportRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewDataExportRepo(pool *pgxpool.Pool) *DataExportRepo {
+	return &DataExportRepo{pool: pool}
+}
+
+func scanDataExport(row pgx.Row) (*entity.DataExport, error) {
+	e := &entity.DataExport{}
+
+	err := row.Scan(
+		&e.ID,
+		&e.UserID,
+		&e.Status,
+		&e.RequestedBy,
+		&e.RequestedAt,
+		&e.CompletedAt,
+		&e.ExpiresAt,
+		&e.ObjectKey,
+		&e.SizeBytes,
+		&e.Attempts,
+		&e.LastError,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (r *DataExportRepo) Create(ctx context.Context, export entity.DataExport) (exportID int, err error) {
+	query := `
+	INSERT INTO user_data_exports (user_id, requested_by)
+	VALUES ($1, $2)
+	RETURNING id;
+	`
+
+	err = postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		export.UserID,
+		export.RequestedBy,
+	).Scan(&exportID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return 0, entity.ErrDataExportPending
+		}
+		return 0, fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	return exportID, nil
+}
+
+func (r *DataExportRepo) Read(ctx context.Context, exportID int) (*entity.DataExport, error) {
+	query := `
+	SELECT ` + dataExportColumns + `
+	FROM user_data_exports
+	WHERE id = $1;
+	`
+
+	e, err := scanDataExport(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, exportID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("failed to select data export (by id=%v): %w", exportID, err)
+	}
+
+	return e, nil
+}
+
+func (r *DataExportRepo) ReadLatest(ctx context.Context, userID string) (*entity.DataExport, error) {
+	query := `
+	SELECT ` + dataExportColumns + `
+	FROM user_data_exports
+	WHERE user_id = $1
+	ORDER BY requested_at DESC, id DESC
+	LIMIT 1;
+	`
+
+	e, err := scanDataExport(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("failed to select latest data export: %w", err)
+	}
+
+	return e, nil
+}
+
+func (r *DataExportRepo) LockPending(ctx context.Context, limit int) ([]*entity.DataExport, error) {
+	query := `
+	SELECT ` + dataExportColumns + `
+	FROM user_data_exports
+	WHERE status = 'pending'
+	ORDER BY requested_at, id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED;
+	`
+
+	return r.query(ctx, query, limit)
+}
+
+func (r *DataExportRepo) Complete(ctx context.Context, exportID int, objectKey string, sizeBytes int64, retentionHours int) error {
+	query := `
+	UPDATE user_data_exports
+	SET
+		status = 'ready',
+		completed_at = NOW(),
+		expires_at = NOW() + make_interval(hours => $1),
+		object_key = $2,
+		size_bytes = $3,
+		last_error = NULL
+	WHERE id = $4;
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, retentionHours, objectKey, sizeBytes, exportID)
+	if err != nil {
+		return fmt.Errorf("failed to complete data export (id=%v): %w", exportID, err)
+	}
+
+	return nil
+}
+
+func (r *DataExportRepo) Fail(ctx context.Context, exportID int, reason string) error {
+	query := `
+	UPDATE user_data_exports
+	SET
+		attempts = attempts + 1,
+		last_error = $1
+	WHERE id = $2;
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, reason, exportID)
+	if err != nil {
+		return fmt.Errorf("failed to record data export failure (id=%v): %w", exportID, err)
+	}
+
+	return nil
+}
+
+func (r *DataExportRepo) ExpireDue(ctx context.Context, limit int) ([]*entity.DataExport, error) {
+	query := `
+	UPDATE user_data_exports
+	SET status = 'expired'
+	WHERE id IN (
+		SELECT id
+		FROM user_data_exports
+		WHERE status = 'ready' AND expires_at <= NOW()
+		ORDER BY expires_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + dataExportColumns + `;
+	`
+
+	return r.query(ctx, query, limit)
+}
+
+func (r *DataExportRepo) ExpireByUser(ctx context.Context, userID string) ([]*entity.DataExport, error) {
+	query := `
+	UPDATE user_data_exports
+	SET status = 'expired'
+	WHERE user_id = $1 AND status <> 'expired'
+	RETURNING ` + dataExportColumns + `;
+	`
+
+	return r.query(ctx, query, userID)
+}
+
+func (r *DataExportRepo) query(ctx context.Context, query string, args ...any) ([]*entity.DataExport, error) {
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query data exports: %w", err)
+	}
+	defer rows.Close()
+
+	var exports []*entity.DataExport
+	for rows.Next() {
+		e, err := scanDataExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan data export from rows: %w", err)
+		}
+		exports = append(exports, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating data export rows: %w", err)
+	}
+
+	return exports, nil
+}
@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.ErasureRepo = (*ErasureRepo)(nil)
+
+const erasureColumns = `
+	id, user_id, mode, status, requested_by, requested_at,
+	COALESCE(confirmed_by, ''), confirmed_at, completed_at, attempts, COALESCE(last_error, ''),
+	erased_checks, erased_webhooks, erased_zones, erased_preferences, erased_group_memberships
+`
+
+type ErasureRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewErasureRepo(pool *pgxpool.Pool) *ErasureRepo {
+	return &ErasureRepo{pool: pool}
+}
+
+func scanErasure(row pgx.Row) (*entity.Erasure, error) {
+	e := &entity.Erasure{}
+
+	err := row.Scan(
+		&e.ID,
+		&e.UserID,
+		&e.Mode,
+		&e.Status,
+		&e.RequestedBy,
+		&e.RequestedAt,
+		&e.ConfirmedBy,
+		&e.ConfirmedAt,
+		&e.CompletedAt,
+		&e.Attempts,
+		&e.LastError,
+		&e.Result.Checks,
+		&e.Result.Webhooks,
+		&e.Result.Zones,
+		&e.Result.Preferences,
+		&e.Result.GroupMemberships,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (r *ErasureRepo) Create(ctx context.Context, erasure entity.Erasure) (erasureID int, err error) {
+	query := `
+	INSERT INTO user_erasures (user_id, mode, requested_by)
+	VALUES ($1, $2, $3)
+	RETURNING id;
+	`
+
+	err = postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		erasure.UserID,
+		erasure.Mode,
+		erasure.RequestedBy,
+	).Scan(&erasureID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return 0, entity.ErrErasurePending
+		}
+		return 0, fmt.Errorf("failed to create erasure: %w", err)
+	}
+
+	return erasureID, nil
+}
+
+func (r *ErasureRepo) Read(ctx context.Context, erasureID int) (*entity.Erasure, error) {
+	query := `
+	SELECT ` + erasureColumns + `
+	FROM user_erasures
+	WHERE id = $1;
+	`
+
+	e, err := scanErasure(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, erasureID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrErasureNotFound
+		}
+		return nil, fmt.Errorf("failed to select erasure (by id=%v): %w", erasureID, err)
+	}
+
+	return e, nil
+}
+
+func (r *ErasureRepo) ReadByStatus(ctx context.Context, status string, limit int) ([]*entity.Erasure, error) {
+	query := `
+	SELECT ` + erasureColumns + `
+	FROM user_erasures
+	WHERE $1 = '' OR status = $1
+	ORDER BY requested_at DESC, id DESC
+	LIMIT $2;
+	`
+
+	return r.query(ctx, query, status, limit)
+}
+
+func (r *ErasureRepo) Decide(ctx context.Context, erasureID int, status, decidedBy string) error {
+	query := `
+	UPDATE user_erasures
+	SET
+		status = $1,
+		confirmed_by = $2,
+		confirmed_at = NOW()
+	WHERE id = $3 AND status = 'pending';
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, status, decidedBy, erasureID)
+	if err != nil {
+		return fmt.Errorf("failed to decide erasure (id=%v): %w", erasureID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrErasureClosed
+	}
+
+	return nil
+}
+
+func (r *ErasureRepo) LockConfirmed(ctx context.Context, limit int) ([]*entity.Erasure, error) {
+	query := `
+	SELECT ` + erasureColumns + `
+	FROM user_erasures
+	WHERE status = 'confirmed'
+	ORDER BY confirmed_at, id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED;
+	`
+
+	return r.query(ctx, query, limit)
+}
+
+func (r *ErasureRepo) Complete(ctx context.Context, erasureID int, result entity.ErasureResult) error {
+	query := `
+	UPDATE user_erasures
+	SET
+		status = 'completed',
+		completed_at = NOW(),
+		last_error = NULL,
+		erased_checks = $1,
+		erased_webhooks = $2,
+		erased_zones = $3,
+		erased_preferences = $4,
+		erased_group_memberships = $5
+	WHERE id = $6;
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query,
+		result.Checks,
+		result.Webhooks,
+		result.Zones,
+		result.Preferences,
+		result.GroupMemberships,
+		erasureID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete erasure (id=%v): %w", erasureID, err)
+	}
+
+	return nil
+}
+
+func (r *ErasureRepo) Fail(ctx context.Context, erasureID int, reason string) error {
+	query := `
+	UPDATE user_erasures
+	SET
+		attempts = attempts + 1,
+		last_error = $1
+	WHERE id = $2;
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, reason, erasureID)
+	if err != nil {
+		return fmt.Errorf("failed to record erasure failure (id=%v): %w", erasureID, err)
+	}
+
+	return nil
+}
+
+func (r *ErasureRepo) query(ctx context.Context, query string, args ...any) ([]*entity.Erasure, error) {
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query erasures: %w", err)
+	}
+	defer rows.Close()
+
+	var erasures []*entity.Erasure
+	for rows.Next() {
+		e, err := scanErasure(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan erasure from rows: %w", err)
+		}
+		erasures = append(erasures, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating erasure rows: %w", err)
+	}
+
+	return erasures, nil
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.EventRepo = (*EventRepo)(nil)
+
+type EventRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewEventRepo(pool *pgxpool.Pool) *EventRepo {
+	return &EventRepo{pool: pool}
+}
+
+func (r *EventRepo) Create(ctx context.Context, event entity.Event) (eventID int64, err error) {
+	query := `
+	INSERT INTO event_outbox (topic, event_type, event_key, payload)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id;
+	`
+
+	err = postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		event.Topic,
+		event.Type,
+		event.Key,
+		event.Payload,
+	).Scan(&eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	return eventID, nil
+}
+
+// LockPending блокирует самые старые события; SKIP LOCKED позволяет нескольким репликам
+// публиковать параллельно, не получая одни и те же строки
+func (r *EventRepo) LockPending(ctx context.Context, limit int) ([]*entity.Event, error) {
+	query := `
+	SELECT id, topic, event_type, event_key, payload, created_at
+	FROM event_outbox
+	ORDER BY id ASC
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock pending events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*entity.Event, 0, limit)
+	for rows.Next() {
+		e := &entity.Event{}
+
+		err := rows.Scan(
+			&e.ID,
+			&e.Topic,
+			&e.Type,
+			&e.Key,
+			&e.Payload,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event from rows: %w", err)
+		}
+
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating event rows: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *EventRepo) Delete(ctx context.Context, eventIDs []int64) error {
+	query := `
+	DELETE FROM event_outbox
+	WHERE id = ANY($1);
+	`
+
+	if _, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, eventIDs); err != nil {
+		return fmt.Errorf("failed to delete published events: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/geojson"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.GeometryVersionRepo = (*GeometryVersionRepo)(nil)
+
+// geometryVersions нумерует версии каждой зоны по порядку записи
+const geometryVersions = `
+	SELECT
+		incident_id,
+		ROW_NUMBER() OVER (PARTITION BY incident_id ORDER BY id) AS version,
+		latitude, longitude, radius_m, geometry,
+		COALESCE(changed_by, '') AS changed_by, valid_from, id
+	FROM incident_geometry_versions
+`
+
+type GeometryVersionRepo struct {
+	pool *pgxpool.Pool
+	// replica nil — история читается из основной БД
+	replica *postgres.Replica
+}
+
+func NewGeometryVersionRepo(pool *pgxpool.Pool, replica *postgres.Replica) *GeometryVersionRepo {
+	return &GeometryVersionRepo{
+		pool:    pool,
+		replica: replica,
+	}
+}
+
+func (r *GeometryVersionRepo) ReadByIncident(ctx context.Context, incID int) ([]entity.GeometryVersion, error) {
+	query := `
+	SELECT incident_id, version, latitude, longitude, radius_m, geometry, changed_by, valid_from
+	FROM (` + geometryVersions + ` WHERE incident_id = $1) v
+	ORDER BY version;
+	`
+
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query, incID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query geometry versions (id=%v): %w", incID, err)
+	}
+
+	return scanGeometryVersions(rows)
+}
+
+func (r *GeometryVersionRepo) ReadAt(ctx context.Context, incIDs []int, at time.Time) ([]entity.GeometryVersion, error) {
+	if len(incIDs) == 0 {
+		return []entity.GeometryVersion{}, nil
+	}
+
+	query := `
+	SELECT DISTINCT ON (incident_id)
+		incident_id, version, latitude, longitude, radius_m, geometry, changed_by, valid_from
+	FROM (` + geometryVersions + ` WHERE incident_id = ANY($1)) v
+	WHERE valid_from <= $2
+	ORDER BY incident_id, valid_from DESC, id DESC;
+	`
+
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query, incIDs, at.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query geometry versions at %v: %w", at, err)
+	}
+
+	return scanGeometryVersions(rows)
+}
+
+func scanGeometryVersions(rows pgx.Rows) ([]entity.GeometryVersion, error) {
+	defer rows.Close()
+
+	versions := make([]entity.GeometryVersion, 0)
+	for rows.Next() {
+		var (
+			v        entity.GeometryVersion
+			geometry []byte
+		)
+		err := rows.Scan(&v.IncidentID, &v.Version, &v.Latitude, &v.Longitude, &v.Radius, &geometry, &v.ChangedBy, &v.ValidFrom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan geometry version from rows: %w", err)
+		}
+		if geometry != nil {
+			polygons, err := geojson.Unmarshal(geometry)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode geometry version of incident (id=%v): %w", v.IncidentID, err)
+			}
+			v.Polygons = polygons
+		}
+		versions = append(versions, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating geometry version rows: %w", err)
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,320 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.GroupRepo = (*GroupRepo)(nil)
+
+const groupColumns = `
+	g.id, g.name, COALESCE(g.descr, ''), g.managers, COALESCE(g.created_by, ''), g.created_at, g.updated_at,
+	(SELECT COUNT(*) FROM group_members m WHERE m.group_id = g.id),
+	e.id, e.url, e.secret, e.enabled, e.created_at, e.updated_at
+`
+
+const groupFrom = `
+	FROM user_groups g
+	LEFT JOIN webhook_endpoints e ON e.group_id = g.id
+`
+
+type GroupRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewGroupRepo(pool *pgxpool.Pool) *GroupRepo {
+	return &GroupRepo{pool: pool}
+}
+
+func scanGroup(row pgx.Row) (*entity.Group, error) {
+	g := &entity.Group{}
+
+	var (
+		endpointID                       *int
+		url, secret                      *string
+		enabled                          *bool
+		endpointCreated, endpointUpdated *time.Time
+	)
+	err := row.Scan(
+		&g.ID,
+		&g.Name,
+		&g.Descr,
+		&g.Managers,
+		&g.CreatedBy,
+		&g.CreatedAt,
+		&g.UpdatedAt,
+		&g.MemberCount,
+		&endpointID,
+		&url,
+		&secret,
+		&enabled,
+		&endpointCreated,
+		&endpointUpdated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpointID != nil {
+		g.Webhook = &entity.WebhookEndpoint{
+			ID:        *endpointID,
+			URL:       *url,
+			Secret:    *secret,
+			Events:    []string{entity.WebhookGroupAlert},
+			Enabled:   *enabled,
+			GroupID:   g.ID,
+			CreatedAt: *endpointCreated,
+			UpdatedAt: *endpointUpdated,
+		}
+	}
+
+	return g, nil
+}
+
+func (r *GroupRepo) Create(ctx context.Context, group entity.Group) (groupID int, err error) {
+	query := `
+	INSERT INTO user_groups (name, descr, managers, created_by)
+	VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''))
+	RETURNING id;
+	`
+
+	err = postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		group.Name,
+		group.Descr,
+		group.Managers,
+		group.CreatedBy,
+	).Scan(&groupID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	return groupID, nil
+}
+
+func (r *GroupRepo) Read(ctx context.Context, groupID int) (*entity.Group, error) {
+	query := `
+	SELECT ` + groupColumns + groupFrom + `
+	WHERE g.id = $1;
+	`
+
+	g, err := scanGroup(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, groupID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to select group (by id=%v): %w", groupID, err)
+	}
+
+	return g, nil
+}
+
+func (r *GroupRepo) ReadAll(ctx context.Context) ([]*entity.Group, error) {
+	query := `
+	SELECT ` + groupColumns + groupFrom + `
+	ORDER BY g.id;
+	`
+
+	return r.query(ctx, query)
+}
+
+func (r *GroupRepo) ReadByMember(ctx context.Context, userID string) ([]*entity.Group, error) {
+	query := `
+	SELECT ` + groupColumns + groupFrom + `
+	JOIN group_members gm ON gm.group_id = g.id AND gm.user_id = $1
+	ORDER BY g.id;
+	`
+
+	return r.query(ctx, query, userID)
+}
+
+func (r *GroupRepo) query(ctx context.Context, query string, args ...any) ([]*entity.Group, error) {
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*entity.Group
+	for rows.Next() {
+		g, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group from rows: %w", err)
+		}
+		groups = append(groups, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating group rows: %w", err)
+	}
+
+	return groups, nil
+}
+
+func (r *GroupRepo) Update(ctx context.Context, group entity.Group) error {
+	query := `
+	UPDATE user_groups
+	SET
+		name = $1,
+		descr = NULLIF($2, ''),
+		managers = $3,
+		updated_at = NOW()
+	WHERE id = $4;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query,
+		group.Name,
+		group.Descr,
+		group.Managers,
+		group.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update group (id=%v): %w", group.ID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrGroupNotFound
+	}
+
+	return nil
+}
+
+func (r *GroupRepo) Delete(ctx context.Context, groupID int) error {
+	query := `
+	DELETE FROM user_groups
+	WHERE id = $1;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to delete group (id=%v): %w", groupID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrGroupNotFound
+	}
+
+	return nil
+}
+
+func (r *GroupRepo) ReadMembers(ctx context.Context, groupID int) ([]string, error) {
+	query := `
+	SELECT user_id
+	FROM group_members
+	WHERE group_id = $1
+	ORDER BY user_id;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read group members: %w", err)
+	}
+
+	userIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan group members: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+func (r *GroupRepo) AddMembers(ctx context.Context, groupID int, userIDs []string) (int, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	query := `
+	INSERT INTO group_members (group_id, user_id)
+	SELECT $1, unnest($2::varchar[])
+	ON CONFLICT (group_id, user_id) DO NOTHING;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, groupID, userIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add group members (group_id=%v): %w", groupID, err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+func (r *GroupRepo) RemoveMember(ctx context.Context, groupID int, userID string) error {
+	query := `
+	DELETE FROM group_members
+	WHERE group_id = $1 AND user_id = $2;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, groupID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove group member (group_id=%v): %w", groupID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrGroupMemberNotFound
+	}
+
+	return nil
+}
+
+// ReadInDanger: user_zones обновляется только при проверках, поэтому выключенные с тех пор зоны отбрасываются
+func (r *GroupRepo) ReadInDanger(ctx context.Context, groupID int) ([]entity.GroupMember, error) {
+	query := `
+	SELECT m.user_id, i.id, i.name, i.severity, z.entered_at
+	FROM group_members m
+	JOIN user_zones z ON z.user_id = m.user_id
+	JOIN incidents i ON i.id = z.incident_id
+	WHERE m.group_id = $1
+		AND i.is_active = true AND i.status = 'published' AND i.deleted_at IS NULL
+		AND (i.expires_at IS NULL OR i.expires_at > NOW())
+	ORDER BY m.user_id, z.entered_at;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query group members in danger: %w", err)
+	}
+	defer rows.Close()
+
+	var members []entity.GroupMember
+	for rows.Next() {
+		var (
+			userID string
+			zone   entity.GroupMemberZone
+		)
+		if err := rows.Scan(&userID, &zone.IncidentID, &zone.Name, &zone.Severity, &zone.EnteredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan group member zone from rows: %w", err)
+		}
+
+		if n := len(members); n > 0 && members[n-1].UserID == userID {
+			members[n-1].Zones = append(members[n-1].Zones, zone)
+			continue
+		}
+		members = append(members, entity.GroupMember{UserID: userID, Zones: []entity.GroupMemberZone{zone}})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating group member zone rows: %w", err)
+	}
+
+	return members, nil
+}
+
+func (r *GroupRepo) RemoveUser(ctx context.Context, userID string) (int64, error) {
+	query := `
+	DELETE FROM group_members
+	WHERE user_id = $1;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove user from groups: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
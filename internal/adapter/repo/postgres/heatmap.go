@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.HeatmapRepo = (*HeatmapRepo)(nil)
+
+// h3MaxResolution — самое мелкое разрешение H3
+const h3MaxResolution = 15
+
+type HeatmapRepo struct {
+	pool *pgxpool.Pool
+	// replica nil — счетчики читаются из основной БД
+	replica *postgres.Replica
+}
+
+func NewHeatmapRepo(pool *pgxpool.Pool, replica *postgres.Replica) *HeatmapRepo {
+	return &HeatmapRepo{
+		pool:    pool,
+		replica: replica,
+	}
+}
+
+func (r *HeatmapRepo) Add(ctx context.Context, resolution int, buckets []entity.HeatmapBucket) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	cells := make([]int64, len(buckets))
+	hours := make([]time.Time, len(buckets))
+	checks := make([]int64, len(buckets))
+	alerts := make([]int64, len(buckets))
+	for i, b := range buckets {
+		cells[i] = b.Cell
+		hours[i] = b.Hour.UTC()
+		checks[i] = int64(b.Checks)
+		alerts[i] = int64(b.Alerts)
+	}
+
+	// повторы ячейки и часа в одной пачке складываются: ON CONFLICT не обновляет строку дважды
+	query := `
+	INSERT INTO check_heatmap (resolution, cell, hour, checks, alerts)
+	SELECT $1, cell, hour, SUM(checks), SUM(alerts)
+	FROM unnest($2::bigint[], $3::timestamp[], $4::bigint[], $5::bigint[]) AS u(cell, hour, checks, alerts)
+	GROUP BY cell, hour
+	ON CONFLICT (hour, resolution, cell) DO UPDATE
+	SET checks = check_heatmap.checks + EXCLUDED.checks,
+		alerts = check_heatmap.alerts + EXCLUDED.alerts;
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, resolution, cells, hours, checks, alerts)
+	if err != nil {
+		return fmt.Errorf("failed to add check heatmap counts: %w", err)
+	}
+
+	return nil
+}
+
+// Read сводит ячейки к родительским разрешения resolution прямо в запросе: в индексе H3 разрешение занимает
+// биты 52–55, а у родителя цифры более мелких разрешений (по 3 младших бита на разрешение) равны 7
+func (r *HeatmapRepo) Read(ctx context.Context, resolution int, from, to time.Time) ([]entity.HeatmapCell, error) {
+	digits := int64(1)<<((h3MaxResolution-resolution)*3) - 1
+	clearBits := int64(h3MaxResolution)<<52 | digits
+	setBits := int64(resolution)<<52 | digits
+
+	query := `
+	SELECT (cell & ~$2::bigint) | $3::bigint AS parent, SUM(checks), SUM(alerts)
+	FROM check_heatmap
+	WHERE resolution >= $1 AND hour >= $4 AND hour < $5
+	GROUP BY parent
+	ORDER BY SUM(checks) DESC, parent;
+	`
+
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query, resolution, clearBits, setBits, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query check heatmap: %w", err)
+	}
+	defer rows.Close()
+
+	cells := make([]entity.HeatmapCell, 0)
+	for rows.Next() {
+		var c entity.HeatmapCell
+		if err := rows.Scan(&c.Cell, &c.Checks, &c.Alerts); err != nil {
+			return nil, fmt.Errorf("failed to scan check heatmap cell from rows: %w", err)
+		}
+		cells = append(cells, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating check heatmap rows: %w", err)
+	}
+
+	return cells, nil
+}
+
+func (r *HeatmapRepo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+	DELETE FROM check_heatmap
+	WHERE hour < $1;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete check heatmap counts: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
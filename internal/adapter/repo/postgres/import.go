@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.ImportRepo = (*ImportRepo)(nil)
+
+type ImportRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewImportRepo(pool *pgxpool.Pool) *ImportRepo {
+	return &ImportRepo{pool: pool}
+}
+
+func (r *ImportRepo) Lock(ctx context.Context, feed string) error {
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('incident_imports:' || $1));`, feed)
+	if err != nil {
+		return fmt.Errorf("failed to lock incident imports (feed=%v): %w", feed, err)
+	}
+
+	return nil
+}
+
+func (r *ImportRepo) Read(ctx context.Context, feed string, externalIDs []string) ([]entity.IncidentImport, error) {
+	query := `
+	SELECT feed, external_id, COALESCE(incident_id, 0), sent_at
+	FROM incident_imports
+	WHERE feed = $1 AND external_id = ANY($2);
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, feed, externalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident imports (feed=%v): %w", feed, err)
+	}
+
+	return scanImports(rows)
+}
+
+func (r *ImportRepo) Save(ctx context.Context, imp entity.IncidentImport) error {
+	query := `
+	INSERT INTO incident_imports (feed, external_id, incident_id, sent_at)
+	VALUES ($1, $2, NULLIF($3, 0), $4)
+	ON CONFLICT (feed, external_id) DO UPDATE
+	SET incident_id = EXCLUDED.incident_id, sent_at = EXCLUDED.sent_at, imported_at = NOW();
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, imp.Feed, imp.ExternalID, imp.IncidentID, imp.SentAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save incident import (feed=%v, id=%v): %w", imp.Feed, imp.ExternalID, err)
+	}
+
+	return nil
+}
+
+func (r *ImportRepo) ReadOpen(ctx context.Context, feed string) ([]entity.IncidentImport, error) {
+	query := `
+	SELECT ii.feed, ii.external_id, ii.incident_id, ii.sent_at
+	FROM incident_imports ii
+	JOIN incidents i ON i.id = ii.incident_id
+	WHERE ii.feed = $1
+		AND i.deleted_at IS NULL
+		AND i.state IN ('planned', 'active', 'contained');
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, feed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open incident imports (feed=%v): %w", feed, err)
+	}
+
+	return scanImports(rows)
+}
+
+func scanImports(rows pgx.Rows) ([]entity.IncidentImport, error) {
+	defer rows.Close()
+
+	imports := make([]entity.IncidentImport, 0)
+	for rows.Next() {
+		var imp entity.IncidentImport
+		if err := rows.Scan(&imp.Feed, &imp.ExternalID, &imp.IncidentID, &imp.SentAt); err != nil {
+			return nil, fmt.Errorf("failed to scan incident import from rows: %w", err)
+		}
+		imports = append(imports, imp)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating incident import rows: %w", err)
+	}
+
+	return imports, nil
+}
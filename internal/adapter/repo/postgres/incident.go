@@ -4,44 +4,213 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/4otis/geonotify-service/internal/entity"
 	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/geojson"
 	"github.com/4otis/geonotify-service/pkg/postgres"
-	"github.com/jackc/pgx"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var _ repo.IncidentRepo = (*IncidentRepo)(nil)
 
+const incidentColumns = `
+	id, name, descr, latitude, longitude,
+	radius_m, is_active, created_at, updated_at,
+	COALESCE(schedule, ''), COALESCE(schedule_duration_m, 0), expires_at,
+	COALESCE(address, ''), geometry,
+	COALESCE(created_by, ''), COALESCE(updated_by, ''),
+	status, COALESCE(published_by, ''), published_at,
+	severity,
+	state, state_changed_at,
+	state_planned_at, state_active_at, state_contained_at, state_resolved_at, state_archived_at,
+	source, visibility
+`
+
+// versionReturning и recordVersions пишут историю зоны в том же запросе, что ее меняет:
+// изменение оформляется как CTE changed с versionReturning. Версия формы пишется, только если форма
+// отличается от последней записанной, а запись активности — если зона начала или перестала действовать
+// либо сменился срок действия
+const versionReturning = `RETURNING id, latitude, longitude, radius_m, geometry, updated_by, updated_at,
+	status = 'published' AND is_active AND deleted_at IS NULL AS in_effect, expires_at`
+
+const recordVersions = `
+	versioned AS (
+		INSERT INTO incident_geometry_versions (incident_id, latitude, longitude, radius_m, geometry, changed_by, valid_from)
+		SELECT c.id, c.latitude, c.longitude, c.radius_m, c.geometry, c.updated_by, c.updated_at
+		FROM changed c
+		LEFT JOIN LATERAL (
+			SELECT latitude, longitude, radius_m, geometry
+			FROM incident_geometry_versions
+			WHERE incident_id = c.id
+			ORDER BY id DESC
+			LIMIT 1
+		) v ON TRUE
+		WHERE v.latitude IS NULL
+			OR (v.latitude, v.longitude, v.radius_m) IS DISTINCT FROM (c.latitude, c.longitude, c.radius_m)
+			OR v.geometry IS DISTINCT FROM c.geometry
+	),
+	activity AS (
+		INSERT INTO incident_activity (incident_id, in_effect, expires_at, valid_from)
+		SELECT c.id, c.in_effect, c.expires_at, c.updated_at
+		FROM changed c
+		LEFT JOIN LATERAL (
+			SELECT in_effect, expires_at
+			FROM incident_activity
+			WHERE incident_id = c.id
+			ORDER BY id DESC
+			LIMIT 1
+		) a ON TRUE
+		WHERE a.in_effect IS NULL
+			OR a.in_effect <> c.in_effect
+			OR a.expires_at IS DISTINCT FROM c.expires_at
+	)
+`
+
 type IncidentRepo struct {
 	pool *pgxpool.Pool
+	// replica nil — списки читаются из основной БД
+	replica *postgres.Replica
 }
 
-func NewIncidentRepo(pool *pgxpool.Pool) *IncidentRepo {
+func NewIncidentRepo(pool *pgxpool.Pool, replica *postgres.Replica) *IncidentRepo {
 	return &IncidentRepo{
-		pool: pool,
+		pool:    pool,
+		replica: replica,
+	}
+}
+
+func scanIncident(row pgx.Row) (*entity.Incident, error) {
+	i := &entity.Incident{}
+	var geometry []byte
+	// порядок совпадает с entity.States
+	stateTimes := make([]*time.Time, len(entity.States))
+
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Descr,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Radius,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Schedule,
+		&i.ScheduleDurationMin,
+		&i.ExpiresAt,
+		&i.Address,
+		&geometry,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.Status,
+		&i.PublishedBy,
+		&i.PublishedAt,
+		&i.Severity,
+		&i.State,
+		&i.StateChangedAt,
+		&stateTimes[0],
+		&stateTimes[1],
+		&stateTimes[2],
+		&stateTimes[3],
+		&stateTimes[4],
+		&i.Source,
+		&i.Visibility,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	i.StateTimes = make(map[string]time.Time, len(stateTimes))
+	for n, t := range stateTimes {
+		if t != nil {
+			i.StateTimes[entity.States[n]] = *t
+		}
+	}
+
+	if geometry != nil {
+		polygons, err := geojson.Unmarshal(geometry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode geometry of incident (id=%v): %w", i.ID, err)
+		}
+		i.Polygons = polygons
+	}
+
+	return i, nil
+}
+
+func scanIncidents(rows pgx.Rows, capacity int) ([]*entity.Incident, error) {
+	defer rows.Close()
+
+	incidents := make([]*entity.Incident, 0, capacity)
+	for rows.Next() {
+		i, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident from rows: %w", err)
+		}
+		incidents = append(incidents, i)
 	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating incident rows: %w", err)
+	}
+
+	return incidents, nil
 }
 
 func (r *IncidentRepo) Create(ctx context.Context, incident entity.Incident) (incidentID int, err error) {
 	query := `
+	WITH changed AS (
 	INSERT INTO incidents (
-		name, descr, latitude, longitude, radius_m, is_active
+		name, descr, latitude, longitude, radius_m, is_active,
+		schedule, schedule_duration_m, expires_at, address,
+		created_by, updated_by,
+		status, published_by, published_at,
+		severity,
+		state, state_planned_at, state_active_at, state_contained_at,
+		source, visibility
 	) VALUES (
-		@name, @descr, @latitude, @longitude, @radius_m, @is_active
-	) RETURNING id;
+		@name, @descr, @latitude, @longitude, @radius_m, @is_active,
+		NULLIF(@schedule, ''), NULLIF(@schedule_duration_m, 0), @expires_at,
+		NULLIF(@address, ''),
+		NULLIF(@created_by, ''), NULLIF(@created_by, ''),
+		@status,
+		CASE WHEN @status = 'published' THEN NULLIF(@created_by, '') END,
+		CASE WHEN @status = 'published' THEN NOW() END,
+		@severity,
+		@state,
+		CASE WHEN @state = 'planned' THEN NOW() END,
+		CASE WHEN @state = 'active' THEN NOW() END,
+		CASE WHEN @state = 'contained' THEN NOW() END,
+		COALESCE(NULLIF(@source, ''), 'manual'),
+		COALESCE(NULLIF(@visibility, ''), 'public')
+	) ` + versionReturning + `
+	),` + recordVersions + `
+	SELECT id FROM changed;
 	`
 	args := map[string]interface{}{
-		"name":      incident.Name,
-		"descr":     incident.Descr,
-		"latitude":  incident.Latitude,
-		"longitude": incident.Longitude,
-		"radius_m":  incident.Radius,
-		"is_active": true,
+		"name":                incident.Name,
+		"descr":               incident.Descr,
+		"latitude":            incident.Latitude,
+		"longitude":           incident.Longitude,
+		"radius_m":            incident.Radius,
+		"is_active":           incident.IsActive,
+		"schedule":            incident.Schedule,
+		"schedule_duration_m": incident.ScheduleDurationMin,
+		"expires_at":          incident.ExpiresAt,
+		"address":             incident.Address,
+		"created_by":          incident.CreatedBy,
+		"status":              incident.Status,
+		"severity":            incident.Severity,
+		"state":               incident.State,
+		"source":              incident.Source,
+		"visibility":          incident.Visibility,
 	}
 
-	err = postgres.QueryRowNamed(ctx, r.pool, query, args).Scan(&incidentID)
+	err = postgres.QueryRowNamed(ctx, postgres.Conn(ctx, r.pool), query, args).Scan(&incidentID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create incident: %w", err)
 	}
@@ -51,26 +220,12 @@ func (r *IncidentRepo) Create(ctx context.Context, incident entity.Incident) (in
 
 func (r *IncidentRepo) Read(ctx context.Context, incID int) (*entity.Incident, error) {
 	query := `
-	SELECT 
-		id, name, descr, latitude, longitude,
-		radius_m, is_active, created_at, updated_at
+	SELECT ` + incidentColumns + `
 	FROM incidents
-	WHERE id=$1 AND deleted_at IS NULL;	
+	WHERE id=$1 AND deleted_at IS NULL;
 	`
 
-	i := &entity.Incident{}
-
-	err := r.pool.QueryRow(ctx, query, incID).Scan(
-		&i.ID,
-		&i.Name,
-		&i.Descr,
-		&i.Latitude,
-		&i.Longitude,
-		&i.Radius,
-		&i.IsActive,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
+	i, err := scanIncident(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, incID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, entity.ErrIncidentNotFound
@@ -81,96 +236,281 @@ func (r *IncidentRepo) Read(ctx context.Context, incID int) (*entity.Incident, e
 	return i, nil
 }
 
-func (r *IncidentRepo) ReadWithPagination(ctx context.Context, page, limit int) ([]*entity.Incident, int, error) {
-	query := `
-	SELECT COUNT(*)
-	FROM incidents
-	WHERE deleted_at IS NULL;
-	`
-	totalIncidents := 0
-
-	err := r.pool.QueryRow(ctx, query).Scan(&totalIncidents)
+// ReadWithPagination: пустой status — инциденты во всех статусах, visibilities nil — с любой видимостью.
+// estimate — вместо COUNT(*) вернуть оценку планировщика числа инцидентов
+func (r *IncidentRepo) ReadWithPagination(ctx context.Context, page, limit int, status string, visibilities []string, estimate bool) ([]*entity.Incident, int, error) {
+	totalIncidents, err := r.count(ctx, status, visibilities, estimate)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count incidents: %w", err)
+		return nil, 0, err
 	}
 
-	incidents := make([]*entity.Incident, 0, limit)
-
-	if totalIncidents == 0 && page == 1 {
-		return incidents, totalIncidents, nil
+	// оценка бывает нулевой и для непустой таблицы, поэтому без чтения страницы отвечает только COUNT
+	if totalIncidents == 0 && page == 1 && !estimate {
+		return make([]*entity.Incident, 0), totalIncidents, nil
 	}
 
-	query = `
-	SELECT 
-		id, name, descr, latitude, longitude,
-		radius_m, is_active, created_at, updated_at
+	query := `
+	SELECT ` + incidentColumns + `
 	FROM incidents
 	WHERE deleted_at IS NULL
-	ORDER BY updated_at DESC
+		AND ($3 = '' OR status = $3)
+		AND ($4::text[] IS NULL OR visibility = ANY($4))
+	ORDER BY updated_at DESC, id DESC
 	LIMIT $1 OFFSET $2;
 	`
 
 	offset := (page - 1) * limit
-	rows, err := r.pool.Query(ctx, query, limit, offset)
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query, limit, offset, status, visibilities)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to query incident: %w", err)
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		i := &entity.Incident{}
-		err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Descr,
-			&i.Latitude,
-			&i.Longitude,
-			&i.Radius,
-			&i.IsActive,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		)
+	incidents, err := scanIncidents(rows, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return incidents, totalIncidents, nil
+}
+
+func (r *IncidentRepo) count(ctx context.Context, status string, visibilities []string, estimate bool) (int, error) {
+	from := `
+	FROM incidents
+	WHERE deleted_at IS NULL
+		AND ($1 = '' OR status = $1)
+		AND ($2::text[] IS NULL OR visibility = ANY($2))
+	`
+	conn := postgres.ReadConn(ctx, r.pool, r.replica)
+
+	if estimate {
+		total, err := postgres.EstimateRows(ctx, conn, "SELECT 1"+from, status, visibilities)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan incident from rows: %w", err)
+			return 0, fmt.Errorf("failed to estimate incidents count: %w", err)
 		}
-		incidents = append(incidents, i)
+		return total, nil
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error while iterating incident rows: %w", err)
+	total := 0
+	if err := conn.QueryRow(ctx, "SELECT COUNT(*)"+from, status, visibilities).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count incidents: %w", err)
 	}
+	return total, nil
+}
 
-	return incidents, totalIncidents, nil
+// ReadAfter читает до limit инцидентов, следующих за after, без подсчета общего числа.
+// after nil — с начала списка
+func (r *IncidentRepo) ReadAfter(ctx context.Context, after *entity.IncidentCursor, limit int, status string, visibilities []string) ([]*entity.Incident, error) {
+	query := `
+	SELECT ` + incidentColumns + `
+	FROM incidents
+	WHERE deleted_at IS NULL
+		AND ($2 = '' OR status = $2)
+		AND ($3::timestamp IS NULL OR (updated_at, id) < ($3, $4))
+		AND ($5::text[] IS NULL OR visibility = ANY($5))
+	ORDER BY updated_at DESC, id DESC
+	LIMIT $1;
+	`
+
+	var updatedAt *time.Time
+	afterID := 0
+	if after != nil {
+		updatedAt = &after.UpdatedAt
+		afterID = after.ID
+	}
+
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query, limit, status, updatedAt, afterID, visibilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incidents after cursor: %w", err)
+	}
+
+	return scanIncidents(rows, limit)
 }
 
 func (r *IncidentRepo) Update(ctx context.Context, incident entity.Incident) error {
 	query := `
-	UPDATE incidents 
-	SET 
+	WITH changed AS (
+	UPDATE incidents
+	SET
 		name = $1,
 		descr = $2,
 		latitude = $3,
 		longitude = $4,
 		radius_m = $5,
 		is_active = $6,
+		schedule = NULLIF($7, ''),
+		schedule_duration_m = NULLIF($8, 0),
+		expires_at = $9,
+		address = NULLIF($10, ''),
+		-- полигон сохраняется, только если охватывающий круг не изменился
+		geometry = CASE WHEN latitude = $3 AND longitude = $4 AND radius_m = $5 THEN geometry END,
+		updated_by = NULLIF($11, ''),
+		-- пустые уровень опасности и видимость оставляют текущие
+		severity = COALESCE(NULLIF($13, ''), severity),
+		visibility = COALESCE(NULLIF($14, ''), visibility),
 		updated_at = NOW()
-	WHERE id = $7 AND deleted_at IS NULL;
+	WHERE id = $12 AND deleted_at IS NULL
+	` + versionReturning + `
+	),` + recordVersions + `
+	SELECT COUNT(*) FROM changed;
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	var updated int
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
 		incident.Name,
 		incident.Descr,
 		incident.Latitude,
 		incident.Longitude,
 		incident.Radius,
 		incident.IsActive,
+		incident.Schedule,
+		incident.ScheduleDurationMin,
+		incident.ExpiresAt,
+		incident.Address,
+		incident.UpdatedBy,
 		incident.ID,
-	)
+		incident.Severity,
+		incident.Visibility,
+	).Scan(&updated)
 	if err != nil {
 		return fmt.Errorf("failed to update incident (id=%v): %w", incident.ID, err)
 	}
 
-	if result.RowsAffected() == 0 {
+	if updated == 0 {
+		return entity.ErrIncidentNotFound
+	}
+
+	return nil
+}
+
+// UpdatePartial обновляет только заданные в патче колонки
+func (r *IncidentRepo) UpdatePartial(ctx context.Context, incID int, patch entity.IncidentPatch) error {
+	var (
+		sets []string
+		args []any
+	)
+	set := func(expr string, arg any) {
+		args = append(args, arg)
+		sets = append(sets, fmt.Sprintf(expr, len(args)))
+	}
+
+	if patch.Name != nil {
+		set("name = $%d", *patch.Name)
+	}
+	if patch.Descr != nil {
+		set("descr = $%d", *patch.Descr)
+	}
+	// полигон сохраняется, только если охватывающий круг не изменился
+	var circle []string
+	if patch.Latitude != nil {
+		set("latitude = $%d", *patch.Latitude)
+		circle = append(circle, sets[len(sets)-1])
+	}
+	if patch.Longitude != nil {
+		set("longitude = $%d", *patch.Longitude)
+		circle = append(circle, sets[len(sets)-1])
+	}
+	if patch.Radius != nil {
+		set("radius_m = $%d", *patch.Radius)
+		circle = append(circle, sets[len(sets)-1])
+	}
+	if len(circle) > 0 {
+		sets = append(sets, fmt.Sprintf("geometry = CASE WHEN %s THEN geometry END", strings.Join(circle, " AND ")))
+	}
+	if patch.IsActive != nil {
+		set("is_active = $%d", *patch.IsActive)
+	}
+	if patch.Schedule != nil {
+		set("schedule = NULLIF($%d, '')", *patch.Schedule)
+	}
+	if patch.ScheduleDurationMin != nil {
+		set("schedule_duration_m = NULLIF($%d, 0)", *patch.ScheduleDurationMin)
+	}
+	if patch.ExpiresAt != nil {
+		// нулевое время в патче снимает срок действия
+		var expiresAt *time.Time
+		if !patch.ExpiresAt.IsZero() {
+			expiresAt = patch.ExpiresAt
+		}
+		set("expires_at = $%d", expiresAt)
+	}
+	if patch.Address != nil {
+		set("address = NULLIF($%d, '')", *patch.Address)
+	}
+	if patch.Severity != nil {
+		set("severity = $%d", *patch.Severity)
+	}
+	if patch.Visibility != nil {
+		set("visibility = $%d", *patch.Visibility)
+	}
+
+	set("updated_by = NULLIF($%d, '')", patch.UpdatedBy)
+	sets = append(sets, "updated_at = NOW()")
+	args = append(args, incID)
+
+	query := fmt.Sprintf(`
+	WITH changed AS (
+	UPDATE incidents
+	SET %s
+	WHERE id = $%d AND deleted_at IS NULL
+	`+versionReturning+`
+	),`+recordVersions+`
+	SELECT COUNT(*) FROM changed;
+	`, strings.Join(sets, ", "), len(args))
+
+	var updated int
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query, args...).Scan(&updated)
+	if err != nil {
+		return fmt.Errorf("failed to partially update incident (id=%v): %w", incID, err)
+	}
+
+	if updated == 0 {
+		return entity.ErrIncidentNotFound
+	}
+
+	return nil
+}
+
+// UpdateGeometry заменяет форму зоны; для круга полигоны сбрасываются
+func (r *IncidentRepo) UpdateGeometry(ctx context.Context, incID int, geometry entity.IncidentGeometry) error {
+	var polygons []byte
+	if len(geometry.Polygons) > 0 {
+		var err error
+		polygons, err = geojson.MultiPolygon(geometry.Polygons).Marshal()
+		if err != nil {
+			return fmt.Errorf("failed to encode geometry of incident (id=%v): %w", incID, err)
+		}
+	}
+
+	query := `
+	WITH changed AS (
+	UPDATE incidents
+	SET
+		latitude = $1,
+		longitude = $2,
+		radius_m = $3,
+		geometry = $4,
+		updated_by = NULLIF($5, ''),
+		updated_at = NOW()
+	WHERE id = $6 AND deleted_at IS NULL
+	` + versionReturning + `
+	),` + recordVersions + `
+	SELECT COUNT(*) FROM changed;
+	`
+
+	var updated int
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		geometry.Latitude,
+		geometry.Longitude,
+		geometry.Radius,
+		polygons,
+		geometry.UpdatedBy,
+		incID,
+	).Scan(&updated)
+	if err != nil {
+		return fmt.Errorf("failed to update geometry of incident (id=%v): %w", incID, err)
+	}
+
+	if updated == 0 {
 		return entity.ErrIncidentNotFound
 	}
 
@@ -179,19 +519,24 @@ func (r *IncidentRepo) Update(ctx context.Context, incident entity.Incident) err
 
 func (r *IncidentRepo) Delete(ctx context.Context, incID int) error {
 	query := `
-	UPDATE incidents 
-	SET 
+	WITH changed AS (
+	UPDATE incidents
+	SET
 		deleted_at = NOW(),
 		updated_at = NOW()
-	WHERE id = $1 AND deleted_at IS NULL;
+	WHERE id = $1 AND deleted_at IS NULL
+	` + versionReturning + `
+	),` + recordVersions + `
+	SELECT COUNT(*) FROM changed;
 	`
 
-	result, err := r.pool.Exec(ctx, query, incID)
+	var deleted int
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query, incID).Scan(&deleted)
 	if err != nil {
 		return fmt.Errorf("failed to soft delete incident (id=%v): %w", incID, err)
 	}
 
-	if result.RowsAffected() == 0 {
+	if deleted == 0 {
 		return entity.ErrIncidentNotFound
 	}
 
@@ -200,44 +545,286 @@ func (r *IncidentRepo) Delete(ctx context.Context, incID int) error {
 
 func (r *IncidentRepo) ReadAllActive(ctx context.Context) ([]*entity.Incident, error) {
 	query := `
-	SELECT
-		id, name, descr, latitude, longitude,
-		radius_m, is_active, created_at, updated_at
+	SELECT ` + incidentColumns + `
 	FROM incidents
-	WHERE is_active=true AND deleted_at IS NULL
+	WHERE is_active=true AND status='published' AND deleted_at IS NULL
+		AND (expires_at IS NULL OR expires_at > NOW())
 	ORDER BY updated_at DESC;
 	`
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query all active incidents: %w", err)
 	}
+
+	return scanIncidents(rows, 0)
+}
+
+// ReadInEffectAt возвращает зоны, действовавшие в момент at, по истории активности. Поля зон — текущие,
+// форму на момент at возвращает GeometryVersionRepo.ReadAt
+func (r *IncidentRepo) ReadInEffectAt(ctx context.Context, at time.Time) ([]*entity.Incident, error) {
+	query := `
+	SELECT ` + incidentColumns + `
+	FROM incidents
+	WHERE id IN (
+		SELECT incident_id
+		FROM (
+			SELECT DISTINCT ON (incident_id) incident_id, in_effect, expires_at
+			FROM incident_activity
+			WHERE valid_from <= $1
+			ORDER BY incident_id, valid_from DESC, id DESC
+		) a
+		WHERE a.in_effect AND (a.expires_at IS NULL OR a.expires_at > $1)
+	)
+	ORDER BY id;
+	`
+
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query, at.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incidents in effect at %v: %w", at, err)
+	}
+
+	return scanIncidents(rows, 0)
+}
+
+func (r *IncidentRepo) ReadChanged(ctx context.Context, after entity.SyncCursor, settleSeconds, limit int) ([]entity.IncidentChange, error) {
+	query := `
+	SELECT ` + incidentColumns + `, deleted_at IS NOT NULL
+	FROM incidents
+	WHERE (updated_at, id) > ($1, $2)
+		AND updated_at <= NOW() - make_interval(secs => $3)
+	ORDER BY updated_at, id
+	LIMIT $4;
+	`
+
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query, after.UpdatedAt, after.ID, settleSeconds, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changed incidents: %w", err)
+	}
 	defer rows.Close()
 
-	incidents := make([]*entity.Incident, 0)
+	changes := make([]entity.IncidentChange, 0, limit)
 	for rows.Next() {
-		i := &entity.Incident{}
-		err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Descr,
-			&i.Latitude,
-			&i.Longitude,
-			&i.Radius,
-			&i.IsActive,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		)
-
+		var change entity.IncidentChange
+		change.Incident, err = scanIncident(extraColumns{row: rows, dest: []any{&change.Deleted}})
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan incident from rows: %w", err)
+			return nil, fmt.Errorf("failed to scan changed incident from rows: %w", err)
 		}
-		incidents = append(incidents, i)
+		changes = append(changes, change)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error while iterating incident rows: %w", err)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating changed incident rows: %w", err)
 	}
 
-	return incidents, nil
+	return changes, nil
+}
+
+// extraColumns дописывает к scanIncident столбцы, выбранные после incidentColumns
+type extraColumns struct {
+	row  pgx.Row
+	dest []any
+}
+
+func (e extraColumns) Scan(dest ...any) error {
+	return e.row.Scan(append(dest, e.dest...)...)
+}
+
+// Version: удаление мягкое и тоже сдвигает updated_at, поэтому максимум берется по всем строкам
+func (r *IncidentRepo) Version(ctx context.Context) (entity.IncidentsVersion, error) {
+	query := `
+	SELECT
+		COUNT(*) FILTER (WHERE deleted_at IS NULL),
+		COALESCE(MAX(updated_at), 'epoch'::timestamp)
+	FROM incidents;
+	`
+
+	var v entity.IncidentsVersion
+	err := postgres.ReadConn(ctx, r.pool, r.replica).QueryRow(ctx, query).Scan(&v.Count, &v.UpdatedAt)
+	if err != nil {
+		return entity.IncidentsVersion{}, fmt.Errorf("failed to read incidents version: %w", err)
+	}
+
+	return v, nil
+}
+
+func (r *IncidentRepo) ReadScheduled(ctx context.Context) ([]*entity.Incident, error) {
+	query := `
+	SELECT ` + incidentColumns + `
+	FROM incidents
+	WHERE schedule IS NOT NULL AND deleted_at IS NULL
+		AND state IN ('active', 'contained')
+		AND (expires_at IS NULL OR expires_at > NOW());
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled incidents: %w", err)
+	}
+
+	return scanIncidents(rows, 0)
+}
+
+func (r *IncidentRepo) SetActive(ctx context.Context, incID int, isActive bool) error {
+	query := `
+	WITH changed AS (
+	UPDATE incidents
+	SET
+		is_active = $1,
+		updated_at = NOW()
+	WHERE id = $2 AND deleted_at IS NULL
+	` + versionReturning + `
+	),` + recordVersions + `
+	SELECT COUNT(*) FROM changed;
+	`
+
+	var updated int
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query, isActive, incID).Scan(&updated)
+	if err != nil {
+		return fmt.Errorf("failed to set incident active=%v (id=%v): %w", isActive, incID, err)
+	}
+
+	if updated == 0 {
+		return entity.ErrIncidentNotFound
+	}
+
+	return nil
+}
+
+// ResolveExpired переводит незавершенные инциденты с истекшим expires_at в resolved и возвращает переходы
+func (r *IncidentRepo) ResolveExpired(ctx context.Context) ([]entity.StateChange, error) {
+	query := `
+	WITH expired AS (
+		SELECT id AS expired_id, state AS from_state
+		FROM incidents
+		WHERE state IN ('planned', 'active', 'contained')
+			AND expires_at IS NOT NULL
+			AND expires_at <= NOW()
+			AND deleted_at IS NULL
+		FOR UPDATE
+	),
+	changed AS (
+	UPDATE incidents i
+	SET
+		state = 'resolved',
+		state_changed_at = NOW(),
+		state_resolved_at = NOW(),
+		is_active = false,
+		updated_at = NOW()
+	FROM expired e
+	WHERE i.id = e.expired_id
+	` + versionReturning + `, e.from_state
+	),` + recordVersions + `
+	SELECT id, from_state FROM changed;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve expired incidents: %w", err)
+	}
+
+	changes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.StateChange, error) {
+		c := entity.StateChange{To: entity.StateResolved}
+		err := row.Scan(&c.IncidentID, &c.From)
+		return c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect expired incidents: %w", err)
+	}
+
+	return changes, nil
+}
+
+// DeleteBatch мягко удаляет инциденты из списка и возвращает id удаленных
+func (r *IncidentRepo) DeleteBatch(ctx context.Context, incIDs []int) ([]int, error) {
+	query := `
+	WITH changed AS (
+	UPDATE incidents
+	SET
+		deleted_at = NOW(),
+		updated_at = NOW()
+	WHERE id = ANY($1) AND deleted_at IS NULL
+	` + versionReturning + `
+	),` + recordVersions + `
+	SELECT id FROM changed;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, incIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to soft delete incidents: %w", err)
+	}
+
+	deletedIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect deleted incident ids: %w", err)
+	}
+
+	return deletedIDs, nil
+}
+
+// SetStatus переводит инцидент в статус to, только если текущий статус — один из from.
+// Иначе возвращает entity.ErrInvalidStatusTransition, существование инцидента проверяет вызывающий
+func (r *IncidentRepo) SetStatus(ctx context.Context, incID int, from []string, to, updatedBy string) error {
+	query := `
+	WITH changed AS (
+	UPDATE incidents
+	SET
+		status = $1,
+		updated_by = NULLIF($2, ''),
+		updated_at = NOW(),
+		published_by = CASE WHEN $1 = 'published' THEN NULLIF($2, '') ELSE published_by END,
+		published_at = CASE WHEN $1 = 'published' THEN NOW() ELSE published_at END
+	WHERE id = $3 AND deleted_at IS NULL AND status = ANY($4)
+	` + versionReturning + `
+	),` + recordVersions + `
+	SELECT COUNT(*) FROM changed;
+	`
+
+	var updated int
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query, to, updatedBy, incID, from).Scan(&updated)
+	if err != nil {
+		return fmt.Errorf("failed to set status of incident (id=%v): %w", incID, err)
+	}
+
+	if updated == 0 {
+		return entity.ErrInvalidStatusTransition
+	}
+
+	return nil
+}
+
+// SetState переводит инцидент из стадии from в to, отмечая время входа в стадию, и выставляет is_active.
+// Если стадия уже изменилась, возвращает entity.ErrInvalidStateTransition
+func (r *IncidentRepo) SetState(ctx context.Context, incID int, from, to string, isActive bool, updatedBy string) error {
+	query := `
+	WITH changed AS (
+	UPDATE incidents
+	SET
+		state = $1,
+		state_changed_at = NOW(),
+		state_planned_at = CASE WHEN $1 = 'planned' THEN NOW() ELSE state_planned_at END,
+		state_active_at = CASE WHEN $1 = 'active' THEN NOW() ELSE state_active_at END,
+		state_contained_at = CASE WHEN $1 = 'contained' THEN NOW() ELSE state_contained_at END,
+		state_resolved_at = CASE WHEN $1 = 'resolved' THEN NOW() ELSE state_resolved_at END,
+		state_archived_at = CASE WHEN $1 = 'archived' THEN NOW() ELSE state_archived_at END,
+		is_active = $2,
+		updated_by = NULLIF($3, ''),
+		updated_at = NOW()
+	WHERE id = $4 AND deleted_at IS NULL AND state = $5
+	` + versionReturning + `
+	),` + recordVersions + `
+	SELECT COUNT(*) FROM changed;
+	`
+
+	var updated int
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query, to, isActive, updatedBy, incID, from).Scan(&updated)
+	if err != nil {
+		return fmt.Errorf("failed to set state of incident (id=%v): %w", incID, err)
+	}
+
+	if updated == 0 {
+		return entity.ErrInvalidStateTransition
+	}
+
+	return nil
 }
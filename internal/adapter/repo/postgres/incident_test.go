@@ -0,0 +1,231 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pgrepo "github.com/4otis/geonotify-service/internal/adapter/repo/postgres"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/testenv"
+)
+
+func TestIncidentRepoCreateRead(t *testing.T) {
+	pg.Reset(t, "incidents")
+	repo := pgrepo.NewIncidentRepo(pg.Pool, nil)
+	ctx := context.Background()
+
+	want := testenv.Incident("Пожар")
+	id, err := repo.Create(ctx, want)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.Read(ctx, id)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got.Name != want.Name || got.Latitude != want.Latitude || got.Radius != want.Radius {
+		t.Errorf("Read() = %+v, want %+v", got, want)
+	}
+	if got.Status != entity.IncidentPublished || got.PublishedBy != "fixture" || got.PublishedAt == nil {
+		t.Errorf("Read() publication = %q by %q at %v", got.Status, got.PublishedBy, got.PublishedAt)
+	}
+	if got.Source != "manual" {
+		t.Errorf("Read() source = %q, want manual", got.Source)
+	}
+
+	if n := testenv.Count(t, pg.Pool, `SELECT COUNT(*) FROM incident_geometry_versions WHERE incident_id = $1`, id); n != 1 {
+		t.Errorf("geometry versions = %d, want 1", n)
+	}
+}
+
+func TestIncidentRepoReadAllActive(t *testing.T) {
+	pg.Reset(t, "incidents")
+	repo := pgrepo.NewIncidentRepo(pg.Pool, nil)
+	ctx := context.Background()
+
+	active := testenv.Incident("active")
+
+	draft := testenv.Incident("draft")
+	draft.Status = entity.IncidentDraft
+
+	inactive := testenv.Incident("inactive")
+	inactive.IsActive = false
+
+	expired := testenv.Incident("expired")
+	expired.ExpiresAt = ptr(time.Now().Add(-time.Minute))
+
+	deleted := testenv.Incident("deleted")
+
+	ids := make(map[string]int)
+	for _, inc := range []entity.Incident{active, draft, inactive, expired, deleted} {
+		id, err := repo.Create(ctx, inc)
+		if err != nil {
+			t.Fatalf("Create(%s) error = %v", inc.Name, err)
+		}
+		ids[inc.Name] = id
+	}
+	if err := repo.Delete(ctx, ids["deleted"]); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	got, err := repo.ReadAllActive(ctx)
+	if err != nil {
+		t.Fatalf("ReadAllActive() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != ids["active"] {
+		t.Errorf("ReadAllActive() = %v, want only %d", got, ids["active"])
+	}
+}
+
+func TestIncidentRepoUpdatePartial(t *testing.T) {
+	pg.Reset(t, "incidents")
+	repo := pgrepo.NewIncidentRepo(pg.Pool, nil)
+	ctx := context.Background()
+
+	expiring := testenv.Incident("Пожар")
+	expiring.ExpiresAt = ptr(time.Now().Add(time.Hour))
+	id, err := repo.Create(ctx, expiring)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		id       int
+		patch    entity.IncidentPatch
+		wantErr  error
+		check    func(t *testing.T, inc *entity.Incident)
+		versions int
+	}{
+		{
+			name:  "rename keeps geometry",
+			id:    id,
+			patch: entity.IncidentPatch{Name: ptr("Лесной пожар"), UpdatedBy: "editor"},
+			check: func(t *testing.T, inc *entity.Incident) {
+				if inc.Name != "Лесной пожар" || inc.UpdatedBy != "editor" {
+					t.Errorf("incident = %q by %q", inc.Name, inc.UpdatedBy)
+				}
+				if inc.ExpiresAt == nil {
+					t.Error("expires_at cleared by unrelated patch")
+				}
+			},
+			versions: 1,
+		},
+		{
+			name:  "zero expiry clears it",
+			id:    id,
+			patch: entity.IncidentPatch{ExpiresAt: &time.Time{}},
+			check: func(t *testing.T, inc *entity.Incident) {
+				if inc.ExpiresAt != nil {
+					t.Errorf("expires_at = %v, want nil", inc.ExpiresAt)
+				}
+			},
+			versions: 1,
+		},
+		{
+			name:  "move records new version",
+			id:    id,
+			patch: entity.IncidentPatch{Latitude: ptr(55.76), Radius: ptr(800.0)},
+			check: func(t *testing.T, inc *entity.Incident) {
+				if inc.Latitude != 55.76 || inc.Radius != 800 {
+					t.Errorf("incident at %v r=%v", inc.Latitude, inc.Radius)
+				}
+			},
+			versions: 2,
+		},
+		{
+			name:    "missing incident",
+			id:      id + 100,
+			patch:   entity.IncidentPatch{Name: ptr("x")},
+			wantErr: entity.ErrIncidentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.UpdatePartial(ctx, tt.id, tt.patch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdatePartial() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			inc, err := repo.Read(ctx, tt.id)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			tt.check(t, inc)
+
+			n := testenv.Count(t, pg.Pool, `SELECT COUNT(*) FROM incident_geometry_versions WHERE incident_id = $1`, tt.id)
+			if n != tt.versions {
+				t.Errorf("geometry versions = %d, want %d", n, tt.versions)
+			}
+		})
+	}
+}
+
+func TestIncidentRepoSetStatus(t *testing.T) {
+	pg.Reset(t, "incidents")
+	repo := pgrepo.NewIncidentRepo(pg.Pool, nil)
+	ctx := context.Background()
+
+	draft := testenv.Incident("draft")
+	draft.Status = entity.IncidentDraft
+	id, err := repo.Create(ctx, draft)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	from := []string{entity.IncidentDraft}
+	if err := repo.SetStatus(ctx, id, from, entity.IncidentPublished, "publisher"); err != nil {
+		t.Fatalf("SetStatus() error = %v", err)
+	}
+	inc, err := repo.Read(ctx, id)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if inc.Status != entity.IncidentPublished || inc.PublishedBy != "publisher" || inc.PublishedAt == nil {
+		t.Errorf("Read() = %q by %q at %v", inc.Status, inc.PublishedBy, inc.PublishedAt)
+	}
+
+	// повторная публикация уже не из черновика
+	if err := repo.SetStatus(ctx, id, from, entity.IncidentPublished, "publisher"); !errors.Is(err, entity.ErrInvalidStatusTransition) {
+		t.Errorf("SetStatus() error = %v, want %v", err, entity.ErrInvalidStatusTransition)
+	}
+}
+
+func TestIncidentRepoDelete(t *testing.T) {
+	pg.Reset(t, "incidents")
+	repo := pgrepo.NewIncidentRepo(pg.Pool, nil)
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, testenv.Incident("Пожар"))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := repo.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.Read(ctx, id); !errors.Is(err, entity.ErrIncidentNotFound) {
+		t.Errorf("Read() error = %v, want %v", err, entity.ErrIncidentNotFound)
+	}
+	if err := repo.Delete(ctx, id); !errors.Is(err, entity.ErrIncidentNotFound) {
+		t.Errorf("Delete() again error = %v, want %v", err, entity.ErrIncidentNotFound)
+	}
+
+	// удаление мягкое: строка остается для истории
+	if n := testenv.Count(t, pg.Pool, `SELECT COUNT(*) FROM incidents WHERE id = $1 AND deleted_at IS NOT NULL`, id); n != 1 {
+		t.Errorf("soft deleted rows = %d, want 1", n)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
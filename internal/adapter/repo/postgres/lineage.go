@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.LineageRepo = (*LineageRepo)(nil)
+
+type LineageRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewLineageRepo(pool *pgxpool.Pool) *LineageRepo {
+	return &LineageRepo{pool: pool}
+}
+
+func (r *LineageRepo) Create(ctx context.Context, links []entity.IncidentLink) error {
+	if len(links) == 0 {
+		return nil
+	}
+
+	parents := make([]int, len(links))
+	children := make([]int, len(links))
+	operations := make([]string, len(links))
+	actors := make([]string, len(links))
+	for i, l := range links {
+		parents[i] = l.ParentID
+		children[i] = l.ChildID
+		operations[i] = l.Operation
+		actors[i] = l.Actor
+	}
+
+	query := `
+	INSERT INTO incident_lineage (parent_id, child_id, operation, actor)
+	SELECT * FROM unnest($1::int[], $2::int[], $3::varchar[], $4::varchar[]);
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, parents, children, operations, actors)
+	if err != nil {
+		return fmt.Errorf("failed to create incident lineage: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LineageRepo) ReadByIncident(ctx context.Context, incID int) ([]entity.IncidentLink, error) {
+	query := `
+	SELECT parent_id, child_id, operation, COALESCE(actor, ''), created_at
+	FROM incident_lineage
+	WHERE parent_id = $1 OR child_id = $1
+	ORDER BY created_at DESC, child_id DESC, parent_id;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, incID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident lineage (id=%v): %w", incID, err)
+	}
+	defer rows.Close()
+
+	links := make([]entity.IncidentLink, 0)
+	for rows.Next() {
+		var l entity.IncidentLink
+		if err := rows.Scan(&l.ParentID, &l.ChildID, &l.Operation, &l.Actor, &l.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan incident lineage from rows: %w", err)
+		}
+		links = append(links, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating incident lineage rows: %w", err)
+	}
+
+	return links, nil
+}
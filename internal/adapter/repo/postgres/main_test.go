@@ -0,0 +1,23 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4otis/geonotify-service/internal/testenv"
+)
+
+var pg *testenv.Postgres
+
+func TestMain(m *testing.M) {
+	testenv.Main(m, func(ctx context.Context) (func(), error) {
+		var err error
+		pg, err = testenv.StartPostgres(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return pg.Close, nil
+	})
+}
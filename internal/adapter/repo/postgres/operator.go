@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.OperatorRepo = (*OperatorRepo)(nil)
+
+const operatorColumns = `
+	id, username, COALESCE(password_hash, ''), COALESCE(oidc_subject, ''), role, created_at
+`
+
+// uniqueViolation — код ошибки Postgres при нарушении уникальности
+const uniqueViolation = "23505"
+
+type OperatorRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewOperatorRepo(pool *pgxpool.Pool) *OperatorRepo {
+	return &OperatorRepo{pool: pool}
+}
+
+func scanOperator(row pgx.Row) (*entity.Operator, error) {
+	o := &entity.Operator{}
+
+	err := row.Scan(
+		&o.ID,
+		&o.Username,
+		&o.PasswordHash,
+		&o.OIDCSubject,
+		&o.Role,
+		&o.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (r *OperatorRepo) Create(ctx context.Context, operator entity.Operator) (operatorID int, err error) {
+	query := `
+	INSERT INTO operators (username, password_hash, oidc_subject, role)
+	VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4)
+	RETURNING id;
+	`
+
+	err = postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		operator.Username,
+		operator.PasswordHash,
+		operator.OIDCSubject,
+		operator.Role,
+	).Scan(&operatorID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return 0, entity.ErrOperatorExists
+		}
+		return 0, fmt.Errorf("failed to create operator: %w", err)
+	}
+
+	return operatorID, nil
+}
+
+func (r *OperatorRepo) ReadByUsername(ctx context.Context, username string) (*entity.Operator, error) {
+	query := `
+	SELECT ` + operatorColumns + `
+	FROM operators
+	WHERE username = $1;
+	`
+
+	o, err := scanOperator(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, username))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrOperatorNotFound
+		}
+		return nil, fmt.Errorf("failed to select operator (username=%v): %w", username, err)
+	}
+
+	return o, nil
+}
+
+func (r *OperatorRepo) ReadByOIDCSubject(ctx context.Context, subject string) (*entity.Operator, error) {
+	query := `
+	SELECT ` + operatorColumns + `
+	FROM operators
+	WHERE oidc_subject = $1;
+	`
+
+	o, err := scanOperator(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, subject))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrOperatorNotFound
+		}
+		return nil, fmt.Errorf("failed to select operator (oidc_subject=%v): %w", subject, err)
+	}
+
+	return o, nil
+}
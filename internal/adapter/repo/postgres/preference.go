@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.PreferenceRepo = (*PreferenceRepo)(nil)
+
+const preferenceColumns = `
+	user_id, min_severity, quiet_hours, timezone, channels, max_alerts_per_hour, updated_at
+`
+
+// quietHoursJSON — окно тишины в колонке quiet_hours
+type quietHoursJSON struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Days  []string `json:"days,omitempty"`
+}
+
+type PreferenceRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPreferenceRepo(pool *pgxpool.Pool) *PreferenceRepo {
+	return &PreferenceRepo{pool: pool}
+}
+
+func scanPreferences(row pgx.Row) (*entity.UserPreferences, error) {
+	p := &entity.UserPreferences{}
+
+	var quietHours []byte
+	err := row.Scan(
+		&p.UserID,
+		&p.MinSeverity,
+		&quietHours,
+		&p.Timezone,
+		&p.Channels,
+		&p.MaxAlertsPerHour,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []quietHoursJSON
+	if err := json.Unmarshal(quietHours, &windows); err != nil {
+		return nil, fmt.Errorf("failed to decode quiet hours: %w", err)
+	}
+	p.QuietHours = make([]entity.QuietHours, len(windows))
+	for i, w := range windows {
+		p.QuietHours[i] = entity.QuietHours{Start: w.Start, End: w.End, Days: w.Days}
+	}
+
+	return p, nil
+}
+
+func (r *PreferenceRepo) Read(ctx context.Context, userID string) (*entity.UserPreferences, error) {
+	query := `
+	SELECT ` + preferenceColumns + `
+	FROM user_preferences
+	WHERE user_id = $1;
+	`
+
+	p, err := scanPreferences(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrPreferencesNotFound
+		}
+		return nil, fmt.Errorf("failed to select user preferences (user_id=%v): %w", userID, err)
+	}
+
+	return p, nil
+}
+
+func (r *PreferenceRepo) Upsert(ctx context.Context, prefs entity.UserPreferences) (*entity.UserPreferences, error) {
+	windows := make([]quietHoursJSON, len(prefs.QuietHours))
+	for i, w := range prefs.QuietHours {
+		windows[i] = quietHoursJSON{Start: w.Start, End: w.End, Days: w.Days}
+	}
+	quietHours, err := json.Marshal(windows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode quiet hours: %w", err)
+	}
+
+	query := `
+	INSERT INTO user_preferences (user_id, min_severity, quiet_hours, timezone, channels, max_alerts_per_hour, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	ON CONFLICT (user_id) DO UPDATE
+	SET
+		min_severity = EXCLUDED.min_severity,
+		quiet_hours = EXCLUDED.quiet_hours,
+		timezone = EXCLUDED.timezone,
+		channels = EXCLUDED.channels,
+		max_alerts_per_hour = EXCLUDED.max_alerts_per_hour,
+		updated_at = EXCLUDED.updated_at
+	RETURNING ` + preferenceColumns + `;
+	`
+
+	p, err := scanPreferences(postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		prefs.UserID,
+		prefs.MinSeverity,
+		quietHours,
+		prefs.Timezone,
+		prefs.Channels,
+		prefs.MaxAlertsPerHour,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save user preferences (user_id=%v): %w", prefs.UserID, err)
+	}
+
+	return p, nil
+}
+
+func (r *PreferenceRepo) Delete(ctx context.Context, userID string) (int64, error) {
+	query := `
+	DELETE FROM user_preferences
+	WHERE user_id = $1;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user preferences: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
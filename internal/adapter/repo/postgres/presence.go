@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.PresenceRepo = (*PresenceRepo)(nil)
+
+type PresenceRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPresenceRepo(pool *pgxpool.Pool) *PresenceRepo {
+	return &PresenceRepo{pool: pool}
+}
+
+func (r *PresenceRepo) ReadZones(ctx context.Context, userID string) ([]int, error) {
+	query := `
+	SELECT incident_id
+	FROM user_zones
+	WHERE user_id = $1;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user zones: %w", err)
+	}
+
+	incidentIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan user zones: %w", err)
+	}
+
+	return incidentIDs, nil
+}
+
+func (r *PresenceRepo) Enter(ctx context.Context, userID string, incidentIDs []int) ([]int, error) {
+	if len(incidentIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+	INSERT INTO user_zones (user_id, incident_id)
+	SELECT $1, unnest($2::int[])
+	ON CONFLICT (user_id, incident_id) DO NOTHING
+	RETURNING incident_id;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, userID, incidentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enter user zones: %w", err)
+	}
+
+	entered, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan entered zones: %w", err)
+	}
+
+	return entered, nil
+}
+
+func (r *PresenceRepo) Exit(ctx context.Context, userID string, incidentIDs []int) ([]int, error) {
+	if len(incidentIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+	DELETE FROM user_zones
+	WHERE user_id = $1 AND incident_id = ANY($2)
+	RETURNING incident_id;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, userID, incidentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exit user zones: %w", err)
+	}
+
+	exited, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan exited zones: %w", err)
+	}
+
+	return exited, nil
+}
+
+func (r *PresenceRepo) Forget(ctx context.Context, userID string) (int64, error) {
+	query := `
+	DELETE FROM user_zones
+	WHERE user_id = $1;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to forget user zones: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
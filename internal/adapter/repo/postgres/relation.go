@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.RelationRepo = (*RelationRepo)(nil)
+
+type RelationRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRelationRepo(pool *pgxpool.Pool) *RelationRepo {
+	return &RelationRepo{pool: pool}
+}
+
+// Create и Delete сдвигают updated_at обеих зон: связи входят в ответ зоны, и ETag списка и синхронизация
+// должны увидеть изменение
+func (r *RelationRepo) Create(ctx context.Context, relation entity.IncidentRelation) (*entity.IncidentRelation, error) {
+	query := `
+	WITH touched AS (
+		UPDATE incidents SET updated_at = NOW()
+		WHERE id IN ($1, $2)
+	)
+	INSERT INTO incident_relations (incident_id, related_id, type, created_by)
+	VALUES ($1, $2, $3, NULLIF($4, ''))
+	RETURNING created_at;
+	`
+
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		relation.IncidentID,
+		relation.RelatedID,
+		relation.Type,
+		relation.CreatedBy,
+	).Scan(&relation.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, entity.ErrRelationExists
+		}
+		return nil, fmt.Errorf("failed to create incident relation (id=%v, related_id=%v): %w",
+			relation.IncidentID, relation.RelatedID, err)
+	}
+
+	return &relation, nil
+}
+
+func (r *RelationRepo) Delete(ctx context.Context, relation entity.IncidentRelation) error {
+	query := `
+	WITH deleted AS (
+		DELETE FROM incident_relations
+		WHERE incident_id = $1 AND related_id = $2 AND type = $3
+		RETURNING incident_id, related_id
+	)
+	UPDATE incidents SET updated_at = NOW()
+	WHERE id IN (SELECT incident_id FROM deleted UNION SELECT related_id FROM deleted);
+	`
+
+	tag, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, relation.IncidentID, relation.RelatedID, relation.Type)
+	if err != nil {
+		return fmt.Errorf("failed to delete incident relation (id=%v, related_id=%v): %w",
+			relation.IncidentID, relation.RelatedID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrRelationNotFound
+	}
+
+	return nil
+}
+
+func (r *RelationRepo) ReadByIncidents(ctx context.Context, incIDs []int) ([]entity.IncidentRelation, error) {
+	if len(incIDs) == 0 {
+		return []entity.IncidentRelation{}, nil
+	}
+
+	query := `
+	SELECT r.incident_id, r.related_id, r.type, COALESCE(r.created_by, ''), r.created_at
+	FROM incident_relations r
+	JOIN incidents i ON i.id = r.incident_id AND i.deleted_at IS NULL
+	JOIN incidents ri ON ri.id = r.related_id AND ri.deleted_at IS NULL
+	WHERE r.incident_id = ANY($1) OR r.related_id = ANY($1)
+	ORDER BY r.created_at, r.incident_id, r.related_id;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, incIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident relations: %w", err)
+	}
+	defer rows.Close()
+
+	relations := make([]entity.IncidentRelation, 0)
+	for rows.Next() {
+		var rel entity.IncidentRelation
+		if err := rows.Scan(&rel.IncidentID, &rel.RelatedID, &rel.Type, &rel.CreatedBy, &rel.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan incident relation from rows: %w", err)
+		}
+		relations = append(relations, rel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating incident relation rows: %w", err)
+	}
+
+	return relations, nil
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.SchemaRepo = (*SchemaRepo)(nil)
+
+type SchemaRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewSchemaRepo(pool *pgxpool.Pool) *SchemaRepo {
+	return &SchemaRepo{pool: pool}
+}
+
+func (r *SchemaRepo) AppliedVersion(ctx context.Context) (version int64, err error) {
+	query := `
+	SELECT COALESCE(MAX(version_id), 0)
+	FROM goose_db_version
+	WHERE is_applied;
+	`
+
+	err = r.pool.QueryRow(ctx, query).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read applied migration version: %w", err)
+	}
+
+	return version, nil
+}
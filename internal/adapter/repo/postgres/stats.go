@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.StatsRepo = (*StatsRepo)(nil)
+
+type StatsRepo struct {
+	pool *pgxpool.Pool
+	// replica nil — счетчики читаются из основной БД
+	replica *postgres.Replica
+}
+
+func NewStatsRepo(pool *pgxpool.Pool, replica *postgres.Replica) *StatsRepo {
+	return &StatsRepo{
+		pool:    pool,
+		replica: replica,
+	}
+}
+
+// Rollup пишет все три таблицы счетчиков одним запросом: изменяющие CTE выполняются всегда.
+// Окно выбирается по моменту записи, а минута счетчика — по created_at: проверка, записанная с опозданием
+// (CheckBatcher), прибавляется к своей уже сведенной минуте
+func (r *StatsRepo) Rollup(ctx context.Context, from, to time.Time) error {
+	query := `
+	WITH window_checks AS (
+		SELECT date_trunc('minute', created_at) AS minute, user_id, has_alert, incident_ids
+		FROM checks
+		WHERE COALESCE(stored_at, created_at) >= $1 AND COALESCE(stored_at, created_at) < $2
+	),
+	minutes AS (
+		INSERT INTO check_stats_minutes (minute, checks, alerts)
+		SELECT minute, COUNT(*), COUNT(*) FILTER (WHERE has_alert)
+		FROM window_checks
+		GROUP BY minute
+		ON CONFLICT (minute) DO UPDATE
+		SET checks = check_stats_minutes.checks + EXCLUDED.checks,
+			alerts = check_stats_minutes.alerts + EXCLUDED.alerts
+	),
+	users AS (
+		INSERT INTO check_stats_users (minute, user_id)
+		SELECT DISTINCT minute, user_id
+		FROM window_checks
+		ON CONFLICT DO NOTHING
+	)
+	INSERT INTO check_stats_incidents (minute, incident_id, checks)
+	SELECT minute, incident_id, COUNT(*)
+	FROM window_checks, unnest(incident_ids) AS incident_id
+	GROUP BY minute, incident_id
+	ON CONFLICT (minute, incident_id) DO UPDATE
+	SET checks = check_stats_incidents.checks + EXCLUDED.checks;
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, from.UTC(), to.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to roll up checks from %v to %v: %w", from, to, err)
+	}
+
+	return nil
+}
+
+func (r *StatsRepo) FirstCheckAt(ctx context.Context) (time.Time, error) {
+	query := `SELECT MIN(COALESCE(stored_at, created_at)) FROM checks;`
+
+	var first *time.Time
+	if err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query).Scan(&first); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read first check time: %w", err)
+	}
+	if first == nil {
+		return time.Time{}, nil
+	}
+
+	return *first, nil
+}
+
+func (r *StatsRepo) Read(ctx context.Context, from, to time.Time) (entity.CheckStats, error) {
+	query := `
+	SELECT
+		(SELECT COUNT(DISTINCT user_id) FROM check_stats_users WHERE minute >= $1 AND minute < $2),
+		COALESCE(SUM(checks), 0),
+		COALESCE(SUM(alerts), 0)
+	FROM check_stats_minutes
+	WHERE minute >= $1 AND minute < $2;
+	`
+
+	var stats entity.CheckStats
+	err := postgres.ReadConn(ctx, r.pool, r.replica).QueryRow(ctx, query, from.UTC(), to.UTC()).
+		Scan(&stats.Users, &stats.Checks, &stats.Alerts)
+	if err != nil {
+		return entity.CheckStats{}, fmt.Errorf("failed to read check stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *StatsRepo) ReadByIncident(ctx context.Context, from, to time.Time, visibilities []string) ([]entity.IncidentCheckStats, error) {
+	query := `
+	SELECT s.incident_id, i.name, SUM(s.checks)
+	FROM check_stats_incidents s
+	JOIN incidents i ON i.id = s.incident_id
+	WHERE s.minute >= $1 AND s.minute < $2
+		AND i.deleted_at IS NULL
+		AND ($3::text[] IS NULL OR i.visibility = ANY($3))
+	GROUP BY s.incident_id, i.name
+	ORDER BY SUM(s.checks) DESC, s.incident_id;
+	`
+
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query, from.UTC(), to.UTC(), visibilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query check stats by incident: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]entity.IncidentCheckStats, 0)
+	for rows.Next() {
+		var s entity.IncidentCheckStats
+		if err := rows.Scan(&s.IncidentID, &s.Name, &s.Checks); err != nil {
+			return nil, fmt.Errorf("failed to scan incident check stats from rows: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating incident check stats rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *StatsRepo) DeleteBefore(ctx context.Context, before time.Time) error {
+	query := `
+	WITH minutes AS (
+		DELETE FROM check_stats_minutes WHERE minute < $1
+	),
+	users AS (
+		DELETE FROM check_stats_users WHERE minute < $1
+	)
+	DELETE FROM check_stats_incidents
+	WHERE minute < $1;
+	`
+
+	if _, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, before.UTC()); err != nil {
+		return fmt.Errorf("failed to delete check stats: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.SyncCursorRepo = (*SyncCursorRepo)(nil)
+
+type SyncCursorRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewSyncCursorRepo(pool *pgxpool.Pool) *SyncCursorRepo {
+	return &SyncCursorRepo{pool: pool}
+}
+
+func (r *SyncCursorRepo) Read(ctx context.Context, name string) (entity.SyncCursor, error) {
+	query := `
+	SELECT synced_until, last_id
+	FROM search_sync_cursors
+	WHERE name = $1;
+	`
+
+	var cursor entity.SyncCursor
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query, name).Scan(&cursor.UpdatedAt, &cursor.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.SyncCursor{}, nil
+	}
+	if err != nil {
+		return entity.SyncCursor{}, fmt.Errorf("failed to read sync cursor (name=%v): %w", name, err)
+	}
+
+	return cursor, nil
+}
+
+// ReadForUpdate сначала создает недостающую позицию: заблокировать можно только существующую строку
+func (r *SyncCursorRepo) ReadForUpdate(ctx context.Context, name string) (entity.SyncCursor, error) {
+	conn := postgres.Conn(ctx, r.pool)
+
+	_, err := conn.Exec(ctx, `
+	INSERT INTO search_sync_cursors (name, synced_until, last_id)
+	VALUES ($1, 'epoch'::timestamp, 0)
+	ON CONFLICT (name) DO NOTHING;
+	`, name)
+	if err != nil {
+		return entity.SyncCursor{}, fmt.Errorf("failed to create sync cursor (name=%v): %w", name, err)
+	}
+
+	var cursor entity.SyncCursor
+	err = conn.QueryRow(ctx, `
+	SELECT synced_until, last_id
+	FROM search_sync_cursors
+	WHERE name = $1
+	FOR UPDATE;
+	`, name).Scan(&cursor.UpdatedAt, &cursor.ID)
+	if err != nil {
+		return entity.SyncCursor{}, fmt.Errorf("failed to lock sync cursor (name=%v): %w", name, err)
+	}
+	if cursor.UpdatedAt.Equal(time.Unix(0, 0)) {
+		return entity.SyncCursor{}, nil
+	}
+
+	return cursor, nil
+}
+
+func (r *SyncCursorRepo) Save(ctx context.Context, name string, cursor entity.SyncCursor) error {
+	query := `
+	INSERT INTO search_sync_cursors (name, synced_until, last_id)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (name) DO UPDATE
+	SET synced_until = EXCLUDED.synced_until,
+		last_id = EXCLUDED.last_id,
+		updated_at = NOW();
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, name, cursor.UpdatedAt, cursor.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save sync cursor (name=%v): %w", name, err)
+	}
+
+	return nil
+}
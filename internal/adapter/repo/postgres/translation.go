@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.TranslationRepo = (*TranslationRepo)(nil)
+
+const translationColumns = `
+	t.incident_id, t.locale, t.name, COALESCE(t.descr, ''), COALESCE(t.updated_by, ''), t.updated_at
+`
+
+type TranslationRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewTranslationRepo(pool *pgxpool.Pool) *TranslationRepo {
+	return &TranslationRepo{pool: pool}
+}
+
+// Upsert вместе с переводом сдвигает updated_at зоны: от него зависят ETag и версия набора инцидентов
+func (r *TranslationRepo) Upsert(ctx context.Context, translation entity.IncidentTranslation) error {
+	query := `
+	WITH saved AS (
+		INSERT INTO incident_translations (incident_id, locale, name, descr, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (incident_id, locale) DO UPDATE
+		SET
+			name = EXCLUDED.name,
+			descr = EXCLUDED.descr,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING incident_id
+	)
+	UPDATE incidents
+	SET
+		updated_at = NOW(),
+		updated_by = $5
+	WHERE id IN (SELECT incident_id FROM saved);
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query,
+		translation.IncidentID,
+		translation.Locale,
+		translation.Name,
+		translation.Descr,
+		translation.UpdatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save incident translation (id=%v, locale=%v): %w",
+			translation.IncidentID, translation.Locale, err)
+	}
+
+	return nil
+}
+
+func (r *TranslationRepo) Delete(ctx context.Context, incID int, locale, updatedBy string) error {
+	query := `
+	WITH deleted AS (
+		DELETE FROM incident_translations
+		WHERE incident_id = $1 AND locale = $2
+		RETURNING incident_id
+	)
+	UPDATE incidents
+	SET
+		updated_at = NOW(),
+		updated_by = $3
+	WHERE id IN (SELECT incident_id FROM deleted);
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, incID, locale, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to delete incident translation (id=%v, locale=%v): %w", incID, locale, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrTranslationNotFound
+	}
+
+	return nil
+}
+
+func (r *TranslationRepo) ReadByIncident(ctx context.Context, incID int) ([]entity.IncidentTranslation, error) {
+	query := `
+	SELECT ` + translationColumns + `
+	FROM incident_translations t
+	WHERE t.incident_id = $1
+	ORDER BY t.locale;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, incID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident translations (id=%v): %w", incID, err)
+	}
+
+	return scanTranslations(rows)
+}
+
+// ReadByIncidents читает переводы сразу для многих зон, например для списка или набора активных зон
+func (r *TranslationRepo) ReadByIncidents(ctx context.Context, incIDs []int) ([]entity.IncidentTranslation, error) {
+	if len(incIDs) == 0 {
+		return []entity.IncidentTranslation{}, nil
+	}
+
+	query := `
+	SELECT ` + translationColumns + `
+	FROM incident_translations t
+	WHERE t.incident_id = ANY($1)
+	ORDER BY t.incident_id, t.locale;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, incIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident translations: %w", err)
+	}
+
+	return scanTranslations(rows)
+}
+
+func scanTranslations(rows pgx.Rows) ([]entity.IncidentTranslation, error) {
+	defer rows.Close()
+
+	translations := make([]entity.IncidentTranslation, 0)
+	for rows.Next() {
+		var t entity.IncidentTranslation
+		err := rows.Scan(&t.IncidentID, &t.Locale, &t.Name, &t.Descr, &t.UpdatedBy, &t.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident translation from rows: %w", err)
+		}
+		translations = append(translations, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating incident translation rows: %w", err)
+	}
+
+	return translations, nil
+}
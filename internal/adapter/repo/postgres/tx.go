@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.Transactor = (*Transactor)(nil)
+
+type Transactor struct {
+	pool *pgxpool.Pool
+}
+
+func NewTransactor(pool *pgxpool.Pool) *Transactor {
+	return &Transactor{pool: pool}
+}
+
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return postgres.WithinTx(ctx, t.pool, fn)
+}
+
+func (t *Transactor) InTx(ctx context.Context) bool {
+	return postgres.InTx(ctx)
+}
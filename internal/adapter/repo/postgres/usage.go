@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.UsageRepo = (*UsageRepo)(nil)
+
+type UsageRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewUsageRepo(pool *pgxpool.Pool) *UsageRepo {
+	return &UsageRepo{pool: pool}
+}
+
+func (r *UsageRepo) Add(ctx context.Context, usage []entity.APIKeyUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(usage))
+	days := make([]time.Time, len(usage))
+	requests := make([]int64, len(usage))
+	checks := make([]int64, len(usage))
+	alerts := make([]int64, len(usage))
+	webhooks := make([]int64, len(usage))
+	for i, u := range usage {
+		keys[i] = u.Key
+		days[i] = u.Day
+		requests[i] = u.Requests
+		checks[i] = u.Checks
+		alerts[i] = u.Alerts
+		webhooks[i] = u.Webhooks
+	}
+
+	// повторы ключа и дня в одной пачке складываются: ON CONFLICT не обновляет строку дважды
+	query := `
+	INSERT INTO api_key_usage (key_name, day, requests, checks, alerts, webhooks)
+	SELECT key_name, day, SUM(requests), SUM(checks), SUM(alerts), SUM(webhooks)
+	FROM unnest($1::varchar[], $2::date[], $3::bigint[], $4::bigint[], $5::bigint[], $6::bigint[])
+		AS u(key_name, day, requests, checks, alerts, webhooks)
+	GROUP BY key_name, day
+	ON CONFLICT (key_name, day) DO UPDATE
+	SET requests = api_key_usage.requests + EXCLUDED.requests,
+		checks = api_key_usage.checks + EXCLUDED.checks,
+		alerts = api_key_usage.alerts + EXCLUDED.alerts,
+		webhooks = api_key_usage.webhooks + EXCLUDED.webhooks,
+		updated_at = NOW();
+	`
+
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, keys, days, requests, checks, alerts, webhooks)
+	if err != nil {
+		return fmt.Errorf("failed to add api key usage: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UsageRepo) List(ctx context.Context, from, to time.Time) ([]entity.APIKeyUsage, error) {
+	query := `
+	SELECT key_name, day, requests, checks, alerts, webhooks
+	FROM api_key_usage
+	WHERE day BETWEEN $1::date AND $2::date
+	ORDER BY key_name, day;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api key usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := make([]entity.APIKeyUsage, 0)
+	for rows.Next() {
+		var u entity.APIKeyUsage
+		if err := rows.Scan(&u.Key, &u.Day, &u.Requests, &u.Checks, &u.Alerts, &u.Webhooks); err != nil {
+			return nil, fmt.Errorf("failed to scan api key usage from rows: %w", err)
+		}
+		usage = append(usage, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating api key usage rows: %w", err)
+	}
+
+	return usage, nil
+}
@@ -2,11 +2,15 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/4otis/geonotify-service/internal/entity"
 	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/fieldcrypt"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -14,23 +18,29 @@ var _ repo.WebhookRepo = (*WebhookRepo)(nil)
 
 type WebhookRepo struct {
 	pool *pgxpool.Pool
+	// replica nil — сводки по вебхукам читаются из основной БД
+	replica *postgres.Replica
+	// checkKeys — ключи шифрования проверок: вебхуки пользователя находятся по его проверкам
+	checkKeys *fieldcrypt.Keyring
 }
 
-func NewWebhookRepo(pool *pgxpool.Pool) *WebhookRepo {
-	return &WebhookRepo{pool: pool}
+func NewWebhookRepo(pool *pgxpool.Pool, replica *postgres.Replica, checkKeys *fieldcrypt.Keyring) *WebhookRepo {
+	return &WebhookRepo{pool: pool, replica: replica, checkKeys: checkKeys}
 }
 
 func (r *WebhookRepo) Create(ctx context.Context, webhook entity.Webhook) (int, error) {
 	query := `
 	INSERT INTO webhooks (
-		check_id, state, retry_cnt, payload, created_at, updated_at, scheduled_at
-	) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		endpoint_id, check_id, event_type, state, retry_cnt, payload, created_at, updated_at, scheduled_at
+	) VALUES (NULLIF($1, 0), NULLIF($2, 0), $3, $4, $5, $6, $7, $8, $9)
 	RETURNING id;
 	`
 
 	var webhookID int
-	err := r.pool.QueryRow(ctx, query,
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		webhook.EndpointID,
 		webhook.CheckID,
+		webhook.EventType,
 		webhook.State,
 		webhook.RetryCnt,
 		webhook.Payload,
@@ -60,7 +70,7 @@ func (r *WebhookRepo) UpdateState(ctx context.Context, id int, state string, ret
 	WHERE id = $3;
 	`
 
-	result, err := r.pool.Exec(ctx, query, state, retryCnt, id)
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, state, retryCnt, id)
 	if err != nil {
 		return fmt.Errorf("failed to update webhook status: %w", err)
 	}
@@ -72,22 +82,75 @@ func (r *WebhookRepo) UpdateState(ctx context.Context, id int, state string, ret
 	return nil
 }
 
-func (r *WebhookRepo) MarkAsDelivered(ctx context.Context, id int) error {
+func (r *WebhookRepo) ScheduleRetry(ctx context.Context, id int, workerID string, retryCnt int, delay time.Duration) error {
+	query := `
+	UPDATE webhooks
+	SET
+		state = 'in progress',
+		retry_cnt = $1,
+		updated_at = NOW(),
+		scheduled_at = NOW() + $2 * INTERVAL '1 second',
+		claimed_by = NULL,
+		claimed_until = NULL
+	WHERE id = $3 AND claimed_by = $4;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, retryCnt, delay.Seconds(), id, workerID)
+	if err != nil {
+		return fmt.Errorf("failed to schedule webhook retry: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrWebhookLeaseLost
+	}
+
+	return nil
+}
+
+func (r *WebhookRepo) MarkAsFailed(ctx context.Context, id int, workerID string) error {
+	query := `
+	UPDATE webhooks
+	SET
+		state = 'failed',
+		updated_at = NOW(),
+		claimed_by = NULL,
+		claimed_until = NULL
+	WHERE id = $1 AND claimed_by = $2;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, id, workerID)
+	if err != nil {
+		return fmt.Errorf("failed to mark webhook as failed: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrWebhookLeaseLost
+	}
+
+	return nil
+}
+
+func (r *WebhookRepo) MarkAsDelivered(ctx context.Context, id int, workerID string) error {
 	query := `
 	UPDATE webhooks 
 	SET 
 		state = 'delivered', 
-		updated_at = NOW()
-	WHERE id = $1;
+		updated_at = NOW(),
+		delivered_at = $3,
+		claimed_by = NULL,
+		claimed_until = NULL
+	WHERE id = $1 AND claimed_by = $2;
 	`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	// delivered_at пишется временем сервиса, как created_at вебхуков и проверок, чтобы задержка
+	// не зависела от часового пояса сессии БД
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, id, workerID, time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to mark webhook as delivered: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("webhook not found")
+		return entity.ErrWebhookLeaseLost
 	}
 
 	return nil
@@ -96,25 +159,26 @@ func (r *WebhookRepo) MarkAsDelivered(ctx context.Context, id int) error {
 func (r *WebhookRepo) Read(ctx context.Context, id int) (*entity.Webhook, error) {
 	query := `
     SELECT 
-        id, check_id, state, retry_cnt, payload, 
+        id, COALESCE(endpoint_id, 0), COALESCE(check_id, 0), event_type,
+        state, retry_cnt, payload,
         created_at, updated_at, scheduled_at
     FROM webhooks
     WHERE id = $1;
     `
 
 	wh := &entity.Webhook{}
-	var deliveredAt *time.Time
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&wh.ID,
+		&wh.EndpointID,
 		&wh.CheckID,
+		&wh.EventType,
 		&wh.State,
 		&wh.RetryCnt,
 		&wh.Payload,
 		&wh.CreatedAt,
 		&wh.UpdatedAt,
 		&wh.ScheduledAt,
-		&deliveredAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get webhook by id: %w", err)
@@ -126,7 +190,8 @@ func (r *WebhookRepo) Read(ctx context.Context, id int) (*entity.Webhook, error)
 func (r *WebhookRepo) ReadInProgress(ctx context.Context, limit int) ([]*entity.Webhook, error) {
 	query := `
 	SELECT 
-		id, check_id, state, retry_cnt, payload, 
+		id, COALESCE(endpoint_id, 0), COALESCE(check_id, 0), event_type,
+		state, retry_cnt, payload,
 		created_at, updated_at, scheduled_at
 	FROM webhooks
 	WHERE state='in progress'
@@ -135,7 +200,7 @@ func (r *WebhookRepo) ReadInProgress(ctx context.Context, limit int) ([]*entity.
 	LIMIT $1;
 	`
 
-	rows, err := r.pool.Query(ctx, query, limit)
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query 'in progress' webhooks: %w", err)
 	}
@@ -147,7 +212,455 @@ func (r *WebhookRepo) ReadInProgress(ctx context.Context, limit int) ([]*entity.
 
 		err := rows.Scan(
 			&wh.ID,
+			&wh.EndpointID,
+			&wh.CheckID,
+			&wh.EventType,
+			&wh.State,
+			&wh.RetryCnt,
+			&wh.Payload,
+			&wh.CreatedAt,
+			&wh.UpdatedAt,
+			&wh.ScheduledAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+
+		webhooks = append(webhooks, wh)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating webhook rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepo) Claim(ctx context.Context, id int, workerID string, lease time.Duration) (*entity.Webhook, error) {
+	query := `
+	UPDATE webhooks
+	SET
+		state = 'processing',
+		updated_at = NOW(),
+		claimed_by = $2,
+		claimed_until = NOW() + $3 * INTERVAL '1 second'
+	WHERE id = $1 AND (
+		(state = 'in progress' AND scheduled_at <= NOW())
+		OR (state = 'processing' AND claimed_until < NOW())
+	)
+	RETURNING
+		id, COALESCE(endpoint_id, 0), COALESCE(check_id, 0), event_type,
+		state, retry_cnt, payload,
+		created_at, updated_at, scheduled_at;
+	`
+
+	wh := &entity.Webhook{}
+
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query, id, workerID, lease.Seconds()).Scan(
+		&wh.ID,
+		&wh.EndpointID,
+		&wh.CheckID,
+		&wh.EventType,
+		&wh.State,
+		&wh.RetryCnt,
+		&wh.Payload,
+		&wh.CreatedAt,
+		&wh.UpdatedAt,
+		&wh.ScheduledAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrWebhookNotClaimable
+		}
+		return nil, fmt.Errorf("failed to claim webhook (id=%v): %w", id, err)
+	}
+
+	return wh, nil
+}
+
+// ClaimDue захватывает готовые к отправке вебхуки, а также вебхуки с истекшей арендой
+// (воркер упал посреди доставки). SKIP LOCKED позволяет нескольким репликам
+// разбирать outbox, не блокируя друг друга и не получая одни и те же строки
+func (r *WebhookRepo) ClaimDue(ctx context.Context, limit int, workerID string, lease time.Duration) ([]*entity.Webhook, error) {
+	query := `
+	WITH due AS (
+		SELECT id
+		FROM webhooks
+		WHERE (state = 'in progress' AND scheduled_at <= NOW())
+			OR (state = 'processing' AND claimed_until < NOW())
+		ORDER BY scheduled_at ASC
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	UPDATE webhooks
+	SET
+		state = 'processing',
+		updated_at = NOW(),
+		claimed_by = $2,
+		claimed_until = NOW() + $3 * INTERVAL '1 second'
+	FROM due
+	WHERE webhooks.id = due.id
+	RETURNING
+		webhooks.id, COALESCE(webhooks.endpoint_id, 0), COALESCE(webhooks.check_id, 0),
+		webhooks.event_type, webhooks.state, webhooks.retry_cnt, webhooks.payload,
+		webhooks.created_at, webhooks.updated_at, webhooks.scheduled_at;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, limit, workerID, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := make([]*entity.Webhook, 0, limit)
+	for rows.Next() {
+		wh := &entity.Webhook{}
+
+		err := rows.Scan(
+			&wh.ID,
+			&wh.EndpointID,
+			&wh.CheckID,
+			&wh.EventType,
+			&wh.State,
+			&wh.RetryCnt,
+			&wh.Payload,
+			&wh.CreatedAt,
+			&wh.UpdatedAt,
+			&wh.ScheduledAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+
+		webhooks = append(webhooks, wh)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating webhook rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// RequeueFailed сбрасывает счетчик попыток и планирует доставку на сейчас:
+// вебхуки подберет обычный опрос outbox воркером
+func (r *WebhookRepo) RequeueFailed(ctx context.Context, ids []int) (int, error) {
+	query := `
+	UPDATE webhooks
+	SET
+		state = 'in progress',
+		retry_cnt = 0,
+		updated_at = NOW(),
+		scheduled_at = NOW()
+	WHERE state = 'failed'
+		AND ($1::int[] IS NULL OR id = ANY($1));
+	`
+
+	// пустой срез передается как NULL — без фильтра по id
+	var filter []int
+	if len(ids) > 0 {
+		filter = ids
+	}
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue failed webhooks: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// Redrive ищет зону в конверте события (data.incident_id, data.incidents[*].ID) и в payload
+// тревог, созданных до конвертов. Окно Spread делится поровну между вебхуками в порядке создания
+func (r *WebhookRepo) Redrive(ctx context.Context, filter entity.WebhookRedriveFilter) ([]*entity.Webhook, error) {
+	query := `
+	WITH matched AS (
+		SELECT
+			id,
+			ROW_NUMBER() OVER (ORDER BY created_at, id) - 1 AS rn,
+			COUNT(*) OVER () AS total
+		FROM webhooks
+		WHERE state = $1
+			AND ($2::timestamptz IS NULL OR created_at >= $2::timestamptz)
+			AND ($3::timestamptz IS NULL OR created_at < $3::timestamptz)
+			AND ($4::int = 0 OR endpoint_id = $4)
+			AND ($5::int = 0 OR jsonb_path_exists(
+				convert_from(payload, 'UTF8')::jsonb,
+				'$ ? (@.data.incident_id == $id || @.data.incidents[*].ID == $id || @.incidents[*].ID == $id)',
+				jsonb_build_object('id', $5::int)))
+	)
+	UPDATE webhooks
+	SET
+		state = 'in progress',
+		retry_cnt = 0,
+		updated_at = NOW(),
+		scheduled_at = NOW() + (matched.rn::float8 / matched.total) * $6 * INTERVAL '1 second'
+	FROM matched
+	WHERE webhooks.id = matched.id
+		AND webhooks.state = $1
+	RETURNING webhooks.id, COALESCE(webhooks.check_id, 0), webhooks.scheduled_at;
+	`
+
+	// нулевые границы передаются как NULL — без фильтра по времени создания
+	var createdFrom, createdTo *time.Time
+	if !filter.CreatedFrom.IsZero() {
+		createdFrom = &filter.CreatedFrom
+	}
+	if !filter.CreatedTo.IsZero() {
+		createdTo = &filter.CreatedTo
+	}
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query,
+		filter.State,
+		createdFrom,
+		createdTo,
+		filter.EndpointID,
+		filter.IncidentID,
+		filter.Spread.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redrive webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*entity.Webhook
+	for rows.Next() {
+		wh := &entity.Webhook{}
+		if err := rows.Scan(&wh.ID, &wh.CheckID, &wh.ScheduledAt); err != nil {
+			return nil, fmt.Errorf("failed to scan redriven webhook: %w", err)
+		}
+		webhooks = append(webhooks, wh)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating redriven webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// Backlog читает основную БД, а не реплику: отставание реплики исказило бы возраст очереди
+func (r *WebhookRepo) Backlog(ctx context.Context) (entity.WebhookBacklog, error) {
+	query := `
+	SELECT
+		COUNT(*) FILTER (WHERE state = 'in progress'),
+		COUNT(*) FILTER (WHERE state = 'processing'),
+		COUNT(*) FILTER (WHERE state = 'failed'),
+		COUNT(*) FILTER (WHERE (state = 'in progress' AND scheduled_at <= NOW())
+			OR (state = 'processing' AND claimed_until < NOW())),
+		MIN(created_at) FILTER (WHERE state IN ('in progress', 'processing')),
+		MIN(CASE WHEN state = 'in progress' THEN scheduled_at ELSE claimed_until END)
+			FILTER (WHERE (state = 'in progress' AND scheduled_at <= NOW())
+				OR (state = 'processing' AND claimed_until < NOW())),
+		NOW()
+	FROM webhooks
+	WHERE state <> 'delivered';
+	`
+
+	var backlog entity.WebhookBacklog
+	err := postgres.Conn(ctx, r.pool).QueryRow(ctx, query).Scan(
+		&backlog.Pending,
+		&backlog.Processing,
+		&backlog.Failed,
+		&backlog.Due,
+		&backlog.OldestPendingAt,
+		&backlog.OldestDueAt,
+		&backlog.CheckedAt,
+	)
+	if err != nil {
+		return entity.WebhookBacklog{}, fmt.Errorf("failed to read webhook backlog: %w", err)
+	}
+
+	return backlog, nil
+}
+
+// DeliveryLatency отсчитывает задержку от создания проверки вебхука: туда входит и время в буфере
+// проверок. Проверка ищется по id во всех партициях; если ее уже удалили, отсчет идет от создания вебхука
+func (r *WebhookRepo) DeliveryLatency(ctx context.Context, window time.Duration, endpointID int) ([]entity.WebhookLatency, error) {
+	query := `
+	SELECT
+		w.endpoint_id,
+		COUNT(*),
+		percentile_cont(ARRAY[0.5, 0.95, 0.99]) WITHIN GROUP (
+			ORDER BY GREATEST(EXTRACT(EPOCH FROM w.delivered_at - COALESCE(c.created_at, w.created_at)), 0)
+		)
+	FROM webhooks w
+	LEFT JOIN checks c ON c.id = w.check_id
+	WHERE w.delivered_at >= $1
+		AND w.endpoint_id IS NOT NULL
+		AND ($2 = 0 OR w.endpoint_id = $2)
+	GROUP BY w.endpoint_id
+	ORDER BY w.endpoint_id;
+	`
+
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query, time.Now().Add(-window), endpointID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook delivery latency: %w", err)
+	}
+	defer rows.Close()
+
+	var latencies []entity.WebhookLatency
+	for rows.Next() {
+		var (
+			l       entity.WebhookLatency
+			seconds []float64
+		)
+		if err := rows.Scan(&l.EndpointID, &l.Delivered, &seconds); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery latency: %w", err)
+		}
+		if len(seconds) == 3 {
+			l.P50 = time.Duration(seconds[0] * float64(time.Second))
+			l.P95 = time.Duration(seconds[1] * float64(time.Second))
+			l.P99 = time.Duration(seconds[2] * float64(time.Second))
+		}
+		latencies = append(latencies, l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating webhook delivery latency: %w", err)
+	}
+
+	return latencies, nil
+}
+
+// CountByState не считает доставленные вебхуки: их большинство, а для глубины очереди они не нужны
+func (r *WebhookRepo) CountByState(ctx context.Context) (map[string]int, error) {
+	query := `
+	SELECT state, COUNT(*)
+	FROM webhooks
+	WHERE state <> 'delivered'
+	GROUP BY state;
+	`
+
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhooks by state: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var (
+			state string
+			count int
+		)
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook count: %w", err)
+		}
+		counts[state] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating webhook counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *WebhookRepo) ReadFailed(ctx context.Context, limit int) ([]*entity.Webhook, error) {
+	query := `
+	SELECT
+		id, COALESCE(endpoint_id, 0), COALESCE(check_id, 0), event_type,
+		state, retry_cnt, payload,
+		created_at, updated_at, scheduled_at
+	FROM webhooks
+	WHERE state = 'failed'
+	ORDER BY updated_at DESC
+	LIMIT $1;
+	`
+
+	rows, err := postgres.ReadConn(ctx, r.pool, r.replica).Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query failed webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := make([]*entity.Webhook, 0, limit)
+	for rows.Next() {
+		wh := &entity.Webhook{}
+
+		err := rows.Scan(
+			&wh.ID,
+			&wh.EndpointID,
+			&wh.CheckID,
+			&wh.EventType,
+			&wh.State,
+			&wh.RetryCnt,
+			&wh.Payload,
+			&wh.CreatedAt,
+			&wh.UpdatedAt,
+			&wh.ScheduledAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+
+		webhooks = append(webhooks, wh)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating webhook rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepo) AttachOrphans(ctx context.Context, endpointID int) (int, error) {
+	query := `
+	UPDATE webhooks
+	SET endpoint_id = $1, updated_at = NOW()
+	WHERE endpoint_id IS NULL AND state <> 'delivered';
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, endpointID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to attach webhooks to endpoint: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// DeleteByUser: вебхуки проверок хранят user_id в payload, поэтому удаляются и доставленные
+func (r *WebhookRepo) DeleteByUser(ctx context.Context, userID string) (int64, error) {
+	query := `
+	DELETE FROM webhooks
+	WHERE check_id IN (SELECT id FROM checks WHERE user_id = ANY($1));
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, userIDKeys(r.checkKeys, userID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user webhooks: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *WebhookRepo) ReadByUser(ctx context.Context, userID string, eventTypes []string) ([]*entity.Webhook, error) {
+	query := `
+	SELECT
+		id, COALESCE(endpoint_id, 0), COALESCE(check_id, 0), event_type,
+		state, retry_cnt, payload,
+		created_at, updated_at, scheduled_at
+	FROM webhooks
+	WHERE check_id IN (SELECT id FROM checks WHERE user_id = ANY($1)) AND event_type = ANY($2)
+	ORDER BY created_at, id;
+	`
+
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, userIDKeys(r.checkKeys, userID), eventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*entity.Webhook
+	for rows.Next() {
+		wh := &entity.Webhook{}
+
+		err := rows.Scan(
+			&wh.ID,
+			&wh.EndpointID,
 			&wh.CheckID,
+			&wh.EventType,
 			&wh.State,
 			&wh.RetryCnt,
 			&wh.Payload,
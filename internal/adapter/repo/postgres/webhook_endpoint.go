@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repo.WebhookEndpointRepo = (*WebhookEndpointRepo)(nil)
+
+const webhookEndpointColumns = `
+	id, url, secret, events, enabled,
+	max_retries, retry_delay_seconds, COALESCE(backoff, ''), timeout_seconds,
+	batch_size, batch_wait_ms, COALESCE(compression, ''),
+	COALESCE(group_id, 0), COALESCE(created_by, ''), created_at, updated_at
+`
+
+type WebhookEndpointRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookEndpointRepo(pool *pgxpool.Pool) *WebhookEndpointRepo {
+	return &WebhookEndpointRepo{pool: pool}
+}
+
+func scanWebhookEndpoint(row pgx.Row) (*entity.WebhookEndpoint, error) {
+	e := &entity.WebhookEndpoint{}
+
+	err := row.Scan(
+		&e.ID,
+		&e.URL,
+		&e.Secret,
+		&e.Events,
+		&e.Enabled,
+		&e.Retry.MaxRetries,
+		&e.Retry.RetryDelaySeconds,
+		&e.Retry.Backoff,
+		&e.Retry.TimeoutSeconds,
+		&e.Delivery.BatchSize,
+		&e.Delivery.BatchWaitMs,
+		&e.Delivery.Compression,
+		&e.GroupID,
+		&e.CreatedBy,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (r *WebhookEndpointRepo) Create(ctx context.Context, endpoint entity.WebhookEndpoint) (endpointID int, err error) {
+	query := `
+	INSERT INTO webhook_endpoints (url, secret, events, enabled,
+		max_retries, retry_delay_seconds, backoff, timeout_seconds,
+		batch_size, batch_wait_ms, compression, group_id, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10, NULLIF($11, ''), NULLIF($12, 0), NULLIF($13, ''))
+	RETURNING id;
+	`
+
+	err = postgres.Conn(ctx, r.pool).QueryRow(ctx, query,
+		endpoint.URL,
+		endpoint.Secret,
+		endpoint.Events,
+		endpoint.Enabled,
+		endpoint.Retry.MaxRetries,
+		endpoint.Retry.RetryDelaySeconds,
+		endpoint.Retry.Backoff,
+		endpoint.Retry.TimeoutSeconds,
+		endpoint.Delivery.BatchSize,
+		endpoint.Delivery.BatchWaitMs,
+		endpoint.Delivery.Compression,
+		endpoint.GroupID,
+		endpoint.CreatedBy,
+	).Scan(&endpointID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return endpointID, nil
+}
+
+func (r *WebhookEndpointRepo) Read(ctx context.Context, endpointID int) (*entity.WebhookEndpoint, error) {
+	query := `
+	SELECT ` + webhookEndpointColumns + `
+	FROM webhook_endpoints
+	WHERE id = $1;
+	`
+
+	e, err := scanWebhookEndpoint(postgres.Conn(ctx, r.pool).QueryRow(ctx, query, endpointID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrWebhookEndpointNotFound
+		}
+		return nil, fmt.Errorf("failed to select webhook endpoint (by id=%v): %w", endpointID, err)
+	}
+
+	return e, nil
+}
+
+func (r *WebhookEndpointRepo) ReadAll(ctx context.Context) ([]*entity.WebhookEndpoint, error) {
+	query := `
+	SELECT ` + webhookEndpointColumns + `
+	FROM webhook_endpoints
+	WHERE group_id IS NULL
+	ORDER BY id;
+	`
+
+	return r.query(ctx, query)
+}
+
+func (r *WebhookEndpointRepo) ReadSubscribed(ctx context.Context, eventType string) ([]*entity.WebhookEndpoint, error) {
+	query := `
+	SELECT ` + webhookEndpointColumns + `
+	FROM webhook_endpoints
+	WHERE enabled AND group_id IS NULL AND ($1 = ANY(events) OR '*' = ANY(events))
+	ORDER BY id;
+	`
+
+	return r.query(ctx, query, eventType)
+}
+
+func (r *WebhookEndpointRepo) query(ctx context.Context, query string, args ...any) ([]*entity.WebhookEndpoint, error) {
+	rows, err := postgres.Conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	var endpoints []*entity.WebhookEndpoint
+	for rows.Next() {
+		e, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook endpoint from rows: %w", err)
+		}
+		endpoints = append(endpoints, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while iterating webhook endpoint rows: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+func (r *WebhookEndpointRepo) Update(ctx context.Context, endpoint entity.WebhookEndpoint) error {
+	query := `
+	UPDATE webhook_endpoints
+	SET
+		url = $1,
+		secret = $2,
+		events = $3,
+		enabled = $4,
+		max_retries = $5,
+		retry_delay_seconds = $6,
+		backoff = NULLIF($7, ''),
+		timeout_seconds = $8,
+		batch_size = $9,
+		batch_wait_ms = $10,
+		compression = NULLIF($11, ''),
+		updated_at = NOW()
+	WHERE id = $12;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query,
+		endpoint.URL,
+		endpoint.Secret,
+		endpoint.Events,
+		endpoint.Enabled,
+		endpoint.Retry.MaxRetries,
+		endpoint.Retry.RetryDelaySeconds,
+		endpoint.Retry.Backoff,
+		endpoint.Retry.TimeoutSeconds,
+		endpoint.Delivery.BatchSize,
+		endpoint.Delivery.BatchWaitMs,
+		endpoint.Delivery.Compression,
+		endpoint.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook endpoint (id=%v): %w", endpoint.ID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrWebhookEndpointNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookEndpointRepo) Delete(ctx context.Context, endpointID int) error {
+	query := `
+	DELETE FROM webhook_endpoints
+	WHERE id = $1;
+	`
+
+	result, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, endpointID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint (id=%v): %w", endpointID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrWebhookEndpointNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pgrepo "github.com/4otis/geonotify-service/internal/adapter/repo/postgres"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/testenv"
+)
+
+const lease = time.Minute
+
+func TestWebhookRepoClaim(t *testing.T) {
+	pg.Reset(t, "webhooks", "checks")
+	repo := pgrepo.NewWebhookRepo(pg.Pool, nil, nil)
+	ctx := context.Background()
+
+	checkID, err := pgrepo.NewCheckRepo(pg.Pool, nil, nil).Create(ctx, testenv.Check("user-1", 55.75, 37.61))
+	if err != nil {
+		t.Fatalf("Create check error = %v", err)
+	}
+	id, err := repo.Create(ctx, testenv.Webhook(checkID))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	wh, err := repo.Claim(ctx, id, "worker-1", lease)
+	if err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if wh.State != "processing" || wh.CheckID != checkID || wh.EndpointID != 0 {
+		t.Errorf("Claim() = %+v", wh)
+	}
+
+	// аренда еще действует: второй воркер вебхук не получает
+	if _, err := repo.Claim(ctx, id, "worker-2", lease); !errors.Is(err, entity.ErrWebhookNotClaimable) {
+		t.Errorf("Claim() by second worker error = %v, want %v", err, entity.ErrWebhookNotClaimable)
+	}
+	if err := repo.MarkAsDelivered(ctx, id, "worker-2"); !errors.Is(err, entity.ErrWebhookLeaseLost) {
+		t.Errorf("MarkAsDelivered() by second worker error = %v, want %v", err, entity.ErrWebhookLeaseLost)
+	}
+
+	if err := repo.MarkAsDelivered(ctx, id, "worker-1"); err != nil {
+		t.Fatalf("MarkAsDelivered() error = %v", err)
+	}
+	if n := testenv.Count(t, pg.Pool, `SELECT COUNT(*) FROM webhooks WHERE id = $1 AND state = 'delivered' AND delivered_at IS NOT NULL`, id); n != 1 {
+		t.Errorf("delivered webhooks = %d, want 1", n)
+	}
+}
+
+func TestWebhookRepoClaimExpiredLease(t *testing.T) {
+	pg.Reset(t, "webhooks")
+	repo := pgrepo.NewWebhookRepo(pg.Pool, nil, nil)
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, testenv.Webhook(0))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := repo.Claim(ctx, id, "worker-1", -time.Second); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+
+	// воркер упал: аренда истекла, вебхук забирает другой
+	if _, err := repo.Claim(ctx, id, "worker-2", lease); err != nil {
+		t.Fatalf("Claim() after expired lease error = %v", err)
+	}
+	if err := repo.MarkAsFailed(ctx, id, "worker-1"); !errors.Is(err, entity.ErrWebhookLeaseLost) {
+		t.Errorf("MarkAsFailed() by previous worker error = %v, want %v", err, entity.ErrWebhookLeaseLost)
+	}
+}
+
+func TestWebhookRepoRetries(t *testing.T) {
+	pg.Reset(t, "webhooks")
+	repo := pgrepo.NewWebhookRepo(pg.Pool, nil, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		finish func(id int) error
+		state  string
+		due    bool
+	}{
+		{
+			name:   "retry later",
+			finish: func(id int) error { return repo.ScheduleRetry(ctx, id, "worker-1", 1, time.Hour) },
+			state:  "in progress",
+		},
+		{
+			name:   "retry now",
+			finish: func(id int) error { return repo.ScheduleRetry(ctx, id, "worker-1", 1, 0) },
+			state:  "in progress",
+			due:    true,
+		},
+		{
+			name:   "failed",
+			finish: func(id int) error { return repo.MarkAsFailed(ctx, id, "worker-1") },
+			state:  "failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := repo.Create(ctx, testenv.Webhook(0))
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if _, err := repo.Claim(ctx, id, "worker-1", lease); err != nil {
+				t.Fatalf("Claim() error = %v", err)
+			}
+			if err := tt.finish(id); err != nil {
+				t.Fatalf("finish error = %v", err)
+			}
+
+			wh, err := repo.Read(ctx, id)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if wh.State != tt.state {
+				t.Errorf("state = %q, want %q", wh.State, tt.state)
+			}
+
+			_, err = repo.Claim(ctx, id, "worker-2", lease)
+			if due := err == nil; due != tt.due {
+				t.Errorf("Claim() after finish error = %v, want due %v", err, tt.due)
+			}
+		})
+	}
+}
+
+func TestWebhookRepoRequeueFailed(t *testing.T) {
+	pg.Reset(t, "webhooks")
+	repo := pgrepo.NewWebhookRepo(pg.Pool, nil, nil)
+	ctx := context.Background()
+
+	var failed []int
+	for i := 0; i < 3; i++ {
+		id, err := repo.Create(ctx, testenv.Webhook(0))
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if _, err := repo.Claim(ctx, id, "worker-1", lease); err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		if err := repo.MarkAsFailed(ctx, id, "worker-1"); err != nil {
+			t.Fatalf("MarkAsFailed() error = %v", err)
+		}
+		failed = append(failed, id)
+	}
+	if _, err := repo.Create(ctx, testenv.Webhook(0)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	counts, err := repo.CountByState(ctx)
+	if err != nil {
+		t.Fatalf("CountByState() error = %v", err)
+	}
+	if counts["failed"] != 3 || counts["in progress"] != 1 {
+		t.Errorf("CountByState() = %v, want 3 failed and 1 in progress", counts)
+	}
+
+	requeued, err := repo.RequeueFailed(ctx, failed[:1])
+	if err != nil || requeued != 1 {
+		t.Fatalf("RequeueFailed(one) = %d, %v; want 1", requeued, err)
+	}
+	requeued, err = repo.RequeueFailed(ctx, nil)
+	if err != nil || requeued != 2 {
+		t.Fatalf("RequeueFailed(all) = %d, %v; want 2", requeued, err)
+	}
+
+	wh, err := repo.Claim(ctx, failed[0], "worker-2", lease)
+	if err != nil {
+		t.Fatalf("Claim() requeued error = %v", err)
+	}
+	if wh.RetryCnt != 0 {
+		t.Errorf("requeued retry_cnt = %d, want 0", wh.RetryCnt)
+	}
+}
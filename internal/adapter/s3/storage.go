@@ -0,0 +1,96 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/port/storage"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+var _ storage.ObjectStorage = (*Storage)(nil)
+
+// Storage хранит объекты в S3-совместимом хранилище (MinIO, AWS S3)
+type Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+type Options struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	Region    string
+	UseSSL    bool
+}
+
+func NewStorage(ctx context.Context, opts Options) (*Storage, error) {
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, ""),
+		Secure: opts.UseSSL,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	s := &Storage{
+		client: client,
+		bucket: opts.Bucket,
+	}
+
+	if err := s.ensureBucket(ctx, opts.Region); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Storage) ensureBucket(ctx context.Context, region string) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return fmt.Errorf("failed to check bucket %s: %w", s.bucket, err)
+	}
+
+	if exists {
+		return nil
+	}
+
+	if err := s.client.MakeBucket(ctx, s.bucket, minio.MakeBucketOptions{Region: region}); err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", s.bucket, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, key, expiry, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object %s: %w", key, err)
+	}
+
+	return u.String(), nil
+}
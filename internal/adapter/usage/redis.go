@@ -0,0 +1,197 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/usage"
+	"github.com/4otis/geonotify-service/pkg/redis"
+)
+
+var _ usage.Meter = (*RedisMeter)(nil)
+
+const (
+	dayLayout   = time.DateOnly
+	monthLayout = "2006-01"
+
+	// pendingKey — использование, еще не перенесенное в БД: поле "ключ|день|метрика" -> значение
+	pendingKey = "usage:pending"
+)
+
+// Метрики в полях pendingKey
+const (
+	metricRequests = "requests"
+	metricChecks   = "checks"
+	metricAlerts   = "alerts"
+	metricWebhooks = "webhooks"
+)
+
+// recordScript учитывает запрос, только если оба счетчика ниже квот (0 — без ограничения),
+// и возвращает {учтен, запросы за сутки, запросы за месяц}
+var recordScript = redis.NewScript(`
+local day = tonumber(redis.call('GET', KEYS[1]) or '0')
+local month = tonumber(redis.call('GET', KEYS[2]) or '0')
+local daily, monthly = tonumber(ARGV[1]), tonumber(ARGV[2])
+if (daily > 0 and day >= daily) or (monthly > 0 and month >= monthly) then
+	return {0, day, month}
+end
+day = redis.call('INCR', KEYS[1])
+redis.call('EXPIREAT', KEYS[1], ARGV[4])
+month = redis.call('INCR', KEYS[2])
+redis.call('EXPIREAT', KEYS[2], ARGV[5])
+redis.call('HINCRBY', KEYS[3], ARGV[3], 1)
+return {1, day, month}
+`)
+
+// drainScript забирает накопленные запросы и очищает их одной операцией, чтобы не потерять учтенные между чтением и удалением
+var drainScript = redis.NewScript(`
+local pending = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+return pending
+`)
+
+// RedisMeter хранит счетчики суток и месяца (UTC) на каждый ключ: по ним проверяются квоты.
+// Счетчики живут сутки после конца своего периода, поэтому потеря данных Redis обнуляет квоты текущего периода
+type RedisMeter struct {
+	redis *redis.Client
+}
+
+func NewRedisMeter(redis *redis.Client) *RedisMeter {
+	return &RedisMeter{redis: redis}
+}
+
+func (m *RedisMeter) Record(ctx context.Context, key string, quota entity.APIKeyQuota, now time.Time) (entity.UsageCounters, error) {
+	if !m.redis.Available() {
+		return entity.UsageCounters{}, entity.ErrDependencyUnavailable
+	}
+
+	now = now.UTC()
+	dayEnd := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	monthEnd := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+
+	values, err := m.redis.RunInts(ctx, recordScript,
+		[]string{dayCounter(key, now), monthCounter(key, now), pendingKey},
+		quota.Daily,
+		quota.Monthly,
+		pendingField(key, now, metricRequests),
+		dayEnd.Add(24*time.Hour).Unix(),
+		monthEnd.Add(24*time.Hour).Unix(),
+	)
+	if err != nil {
+		return entity.UsageCounters{}, err
+	}
+	if len(values) != 3 {
+		return entity.UsageCounters{}, fmt.Errorf("unexpected usage script result: %v", values)
+	}
+
+	return entity.UsageCounters{Allowed: values[0] == 1, Day: values[1], Month: values[2]}, nil
+}
+
+func (m *RedisMeter) Current(ctx context.Context, key string, now time.Time) (entity.UsageCounters, error) {
+	if !m.redis.Available() {
+		return entity.UsageCounters{}, entity.ErrDependencyUnavailable
+	}
+
+	now = now.UTC()
+	values, err := m.redis.GetInts(ctx, dayCounter(key, now), monthCounter(key, now))
+	if err != nil {
+		return entity.UsageCounters{}, err
+	}
+
+	return entity.UsageCounters{Allowed: true, Day: values[0], Month: values[1]}, nil
+}
+
+func (m *RedisMeter) Drain(ctx context.Context) ([]entity.APIKeyUsage, error) {
+	if !m.redis.Available() {
+		return nil, entity.ErrDependencyUnavailable
+	}
+
+	pending, err := m.redis.RunStrings(ctx, drainScript, []string{pendingKey})
+	if err != nil {
+		return nil, err
+	}
+
+	type keyDay struct {
+		key string
+		day string
+	}
+	byKeyDay := make(map[keyDay]*entity.APIKeyUsage)
+	order := make([]keyDay, 0)
+	for i := 0; i+1 < len(pending); i += 2 {
+		parts := strings.Split(pending[i], "|")
+		// поля "ключ|день" остались от версий, которые считали только запросы
+		if len(parts) == 2 {
+			parts = append(parts, metricRequests)
+		}
+		value, err := strconv.ParseInt(pending[i+1], 10, 64)
+		if len(parts) != 3 || err != nil {
+			continue
+		}
+		day, err := time.Parse(dayLayout, parts[1])
+		if err != nil {
+			continue
+		}
+
+		kd := keyDay{key: parts[0], day: parts[1]}
+		u, ok := byKeyDay[kd]
+		if !ok {
+			u = &entity.APIKeyUsage{Key: kd.key, Day: day}
+			byKeyDay[kd] = u
+			order = append(order, kd)
+		}
+		switch parts[2] {
+		case metricRequests:
+			u.Requests += value
+		case metricChecks:
+			u.Checks += value
+		case metricAlerts:
+			u.Alerts += value
+		case metricWebhooks:
+			u.Webhooks += value
+		}
+	}
+
+	usage := make([]entity.APIKeyUsage, 0, len(order))
+	for _, kd := range order {
+		usage = append(usage, *byKeyDay[kd])
+	}
+	return usage, nil
+}
+
+func (m *RedisMeter) Add(ctx context.Context, usage []entity.APIKeyUsage) error {
+	if !m.redis.Available() {
+		return entity.ErrDependencyUnavailable
+	}
+
+	fields := make(map[string]int64)
+	for _, u := range usage {
+		for metric, value := range map[string]int64{
+			metricRequests: u.Requests,
+			metricChecks:   u.Checks,
+			metricAlerts:   u.Alerts,
+			metricWebhooks: u.Webhooks,
+		} {
+			if value != 0 {
+				fields[pendingField(u.Key, u.Day, metric)] += value
+			}
+		}
+	}
+
+	return m.redis.HIncrBy(ctx, pendingKey, fields)
+}
+
+func pendingField(key string, day time.Time, metric string) string {
+	return key + "|" + day.UTC().Format(dayLayout) + "|" + metric
+}
+
+func dayCounter(key string, now time.Time) string {
+	return "usage:day:" + key + ":" + now.Format(dayLayout)
+}
+
+func monthCounter(key string, now time.Time) string {
+	return "usage:month:" + key + ":" + now.Format(monthLayout)
+}
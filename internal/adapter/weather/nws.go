@@ -0,0 +1,132 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/4otis/geonotify-service/pkg/geojson"
+)
+
+const nwsBaseURL = "https://api.weather.gov"
+
+// NWS — предупреждения Национальной метеослужбы США. API требует User-Agent с контактами
+type NWS struct {
+	client    *http.Client
+	baseURL   string
+	userAgent string
+}
+
+func newNWS(client *http.Client, opts Options) *NWS {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = nwsBaseURL
+	}
+
+	return &NWS{
+		client:    client,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		userAgent: opts.UserAgent,
+	}
+}
+
+type nwsCollection struct {
+	Features []struct {
+		Geometry   json.RawMessage `json:"geometry"`
+		Properties struct {
+			ID          string     `json:"id"`
+			Sent        time.Time  `json:"sent"`
+			Expires     *time.Time `json:"expires"`
+			Ends        *time.Time `json:"ends"`
+			Status      string     `json:"status"`
+			MessageType string     `json:"messageType"`
+			Severity    string     `json:"severity"`
+			Event       string     `json:"event"`
+			Headline    string     `json:"headline"`
+			Description string     `json:"description"`
+			Instruction string     `json:"instruction"`
+			References  []struct {
+				Identifier string `json:"identifier"`
+			} `json:"references"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+func (n *NWS) alerts(ctx context.Context, loc Location) ([]rawAlert, error) {
+	query := url.Values{}
+	query.Set("point", fmt.Sprintf("%.4f,%.4f", loc.Latitude, loc.Longitude))
+
+	var collection nwsCollection
+	if err := getJSON(ctx, n.client, n.baseURL+"/alerts/active?"+query.Encode(), n.userAgent, "application/geo+json", &collection); err != nil {
+		return nil, err
+	}
+
+	alerts := make([]rawAlert, 0, len(collection.Features))
+	for _, f := range collection.Features {
+		p := f.Properties
+		// учения, тесты и служебные сообщения не импортируются
+		if p.ID == "" || p.Status != "Actual" {
+			continue
+		}
+
+		a := rawAlert{Keys: []string{p.Event, p.Severity}}
+		a.ID = p.ID
+		a.Sent = p.Sent.UTC()
+		a.Ended = p.MessageType == "Cancel"
+		a.Name = p.Headline
+		if a.Name == "" {
+			a.Name = p.Event
+		}
+		a.Descr = strings.TrimSpace(strings.Join([]string{p.Description, p.Instruction}, "\n\n"))
+		for _, ref := range p.References {
+			a.References = append(a.References, ref.Identifier)
+		}
+
+		// ends — окончание явления, expires — лишь срок действия сообщения
+		expires := p.Expires
+		if p.Ends != nil {
+			expires = p.Ends
+		}
+		if expires != nil {
+			utc := expires.UTC()
+			a.ExpiresAt = &utc
+		}
+
+		// предупреждения по зонам прогноза приходят без геометрии и получают круг вокруг места
+		if polygons, ok := nwsPolygons(f.Geometry); ok {
+			a.Polygons = polygons
+			a.Latitude, a.Longitude, a.Radius = polygons.BoundingCircle()
+		}
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+// nwsPolygons берет внешние кольца полигонов: ориентация колец в ответах NWS не гарантирована
+func nwsPolygons(raw json.RawMessage) (geojson.MultiPolygon, bool) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, false
+	}
+
+	boundaries, err := geojson.ParseBoundaries(raw)
+	if err != nil {
+		return nil, false
+	}
+
+	polygons := make(geojson.MultiPolygon, 0, len(boundaries))
+	for _, polygon := range boundaries {
+		ring, err := geojson.ExteriorRing(polygon[0])
+		if err != nil {
+			continue
+		}
+		polygons = append(polygons, [][]geojson.Position{ring})
+	}
+
+	return polygons, len(polygons) > 0
+}
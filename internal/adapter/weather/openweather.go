@@ -0,0 +1,83 @@
+package weather
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const openWeatherBaseURL = "https://api.openweathermap.org"
+
+// OpenWeather — предупреждения из One Call API 3.0. У предупреждений нет ни ID, ни геометрии,
+// ни уровня опасности: ID строится из отправителя, явления, начала и места, зона — круг вокруг места,
+// уровень сопоставляется по названию явления и тегам
+type OpenWeather struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+func newOpenWeather(client *http.Client, opts Options) *OpenWeather {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = openWeatherBaseURL
+	}
+
+	return &OpenWeather{
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  opts.APIKey,
+	}
+}
+
+type openWeatherResponse struct {
+	Alerts []struct {
+		SenderName  string   `json:"sender_name"`
+		Event       string   `json:"event"`
+		Start       int64    `json:"start"`
+		End         int64    `json:"end"`
+		Description string   `json:"description"`
+		Tags        []string `json:"tags"`
+	} `json:"alerts"`
+}
+
+func (o *OpenWeather) alerts(ctx context.Context, loc Location) ([]rawAlert, error) {
+	query := url.Values{}
+	query.Set("lat", strconv.FormatFloat(loc.Latitude, 'f', -1, 64))
+	query.Set("lon", strconv.FormatFloat(loc.Longitude, 'f', -1, 64))
+	query.Set("exclude", "current,minutely,hourly,daily")
+	query.Set("appid", o.apiKey)
+
+	var resp openWeatherResponse
+	if err := getJSON(ctx, o.client, o.baseURL+"/data/3.0/onecall?"+query.Encode(), "", "application/json", &resp); err != nil {
+		return nil, err
+	}
+
+	alerts := make([]rawAlert, 0, len(resp.Alerts))
+	for _, w := range resp.Alerts {
+		a := rawAlert{Keys: append([]string{w.Event}, w.Tags...)}
+		a.ID = openWeatherID(w.SenderName, w.Event, w.Start, loc)
+		a.Sent = time.Unix(w.Start, 0).UTC()
+		a.Name = w.Event
+		a.Descr = strings.TrimSpace(w.Description)
+		if w.End > 0 {
+			end := time.Unix(w.End, 0).UTC()
+			a.ExpiresAt = &end
+		}
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+func openWeatherID(sender, event string, start int64, loc Location) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d|%.4f,%.4f", sender, event, start, loc.Latitude, loc.Longitude)))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,190 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/weather"
+)
+
+const (
+	ProviderNWS         = "nws"
+	ProviderOpenWeather = "openweather"
+)
+
+// Location — место, для которого запрашиваются предупреждения
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type Options struct {
+	Provider string
+	// BaseURL пустой — используется публичный адрес провайдера
+	BaseURL   string
+	APIKey    string
+	UserAgent string
+	Timeout   time.Duration
+	Locations []Location
+	// Radius — радиус зоны в метрах вокруг места для предупреждений без геометрии
+	Radius float64
+	// SeverityMap сопоставляет названию явления или уровню опасности провайдера уровень опасности зоны
+	// (без учета регистра); сначала ищется явление. Несопоставленные предупреждения пропускаются
+	SeverityMap map[string]string
+	// MinSeverity — предупреждения с меньшим уровнем опасности пропускаются
+	MinSeverity string
+}
+
+// rawAlert — предупреждение провайдера: Keys — название явления и уровни опасности провайдера
+// в порядке поиска в SeverityMap
+type rawAlert struct {
+	entity.ExternalAlert
+	Keys []string
+}
+
+// client — запрос предупреждений у конкретного провайдера для одного места
+type client interface {
+	alerts(ctx context.Context, loc Location) ([]rawAlert, error)
+}
+
+var _ weather.Provider = (*provider)(nil)
+
+// provider опрашивает места по очереди и сопоставляет уровни опасности
+type provider struct {
+	client      client
+	locations   []Location
+	radius      float64
+	severityMap map[string]string
+	minSeverity int
+}
+
+var severityOrder = []string{
+	entity.SeverityLow,
+	entity.SeverityMedium,
+	entity.SeverityHigh,
+	entity.SeverityCritical,
+}
+
+func severityRank(severity string) int {
+	for i, s := range severityOrder {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// New создает погодный провайдер
+func New(opts Options) (weather.Provider, error) {
+	httpClient := &http.Client{Timeout: opts.Timeout}
+
+	var c client
+	switch opts.Provider {
+	case ProviderNWS:
+		c = newNWS(httpClient, opts)
+	case ProviderOpenWeather:
+		if opts.APIKey == "" {
+			return nil, fmt.Errorf("openweather provider requires an API key")
+		}
+		c = newOpenWeather(httpClient, opts)
+	default:
+		return nil, fmt.Errorf("unknown weather provider %q", opts.Provider)
+	}
+
+	severityMap := make(map[string]string, len(opts.SeverityMap))
+	for key, severity := range opts.SeverityMap {
+		if !entity.ValidSeverity(severity) {
+			return nil, fmt.Errorf("weather severity map: %q maps to unknown severity %q", key, severity)
+		}
+		severityMap[strings.ToLower(strings.TrimSpace(key))] = severity
+	}
+
+	minSeverity := severityRank(opts.MinSeverity)
+	if minSeverity < 0 {
+		return nil, fmt.Errorf("unknown weather min severity %q", opts.MinSeverity)
+	}
+
+	return &provider{
+		client:      c,
+		locations:   opts.Locations,
+		radius:      opts.Radius,
+		severityMap: severityMap,
+		minSeverity: minSeverity,
+	}, nil
+}
+
+// Alerts при ошибке по любому месту возвращает ошибку: неполный список завершил бы зоны пропущенных мест.
+// Предупреждение, действующее в нескольких местах, возвращается один раз
+func (p *provider) Alerts(ctx context.Context) ([]entity.ExternalAlert, error) {
+	var alerts []entity.ExternalAlert
+	seen := make(map[string]bool)
+
+	for _, loc := range p.locations {
+		raw, err := p.client.alerts(ctx, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range raw {
+			if seen[a.ID] {
+				continue
+			}
+			seen[a.ID] = true
+
+			severity, ok := p.severity(a.Keys)
+			// отмене уровень не нужен: она только завершает уже созданную зону
+			if !a.Ended && (!ok || severityRank(severity) < p.minSeverity) {
+				continue
+			}
+			a.Severity = severity
+
+			if a.Radius == 0 && len(a.Polygons) == 0 {
+				a.Latitude, a.Longitude, a.Radius = loc.Latitude, loc.Longitude, p.radius
+			}
+			alerts = append(alerts, a.ExternalAlert)
+		}
+	}
+
+	return alerts, nil
+}
+
+func (p *provider) severity(keys []string) (string, bool) {
+	for _, key := range keys {
+		if severity, ok := p.severityMap[strings.ToLower(strings.TrimSpace(key))]; ok {
+			return severity, true
+		}
+	}
+	return "", false
+}
+
+func getJSON(ctx context.Context, client *http.Client, url, userAgent, accept string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build weather request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch weather alerts: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("weather provider returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("invalid weather provider response: %w", err)
+	}
+
+	return nil
+}
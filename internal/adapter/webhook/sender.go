@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/delivery"
+)
+
+var _ delivery.WebhookSender = (*HTTPSender)(nil)
+
+const (
+	SignatureHeader = "X-Geonotify-Signature"
+	TimestampHeader = "X-Geonotify-Timestamp"
+	EventHeader     = "X-Geonotify-Event"
+	BatchSizeHeader = "X-Geonotify-Batch-Size"
+
+	// BatchEvent — тип события в заголовке NDJSON-пачки; типы событий пачки — в их конвертах
+	BatchEvent = "batch"
+)
+
+type HTTPSender struct {
+	client  *http.Client
+	timeout time.Duration
+	headers map[string]map[string]string
+}
+
+type Options struct {
+	// Timeout — таймаут запроса для получателей без собственного timeout_seconds
+	Timeout time.Duration
+	TLS     TLSOptions
+	// ProxyURL пустой — прокси берется из HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+	ProxyURL string
+	// Headers: хост получателя (host или host:port) -> заголовки; "*" применяется ко всем
+	Headers map[string]map[string]string
+}
+
+func NewHTTPSender(opts Options) (*HTTPSender, error) {
+	tlsConfig, err := opts.TLS.build()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &HTTPSender{
+		client: &http.Client{
+			Transport: transport,
+		},
+		timeout: opts.Timeout,
+		headers: opts.Headers,
+	}, nil
+}
+
+// Send выполняет одну попытку доставки; успешной считается только попытка с ответом 2xx
+func (s *HTTPSender) Send(ctx context.Context, endpoint entity.WebhookEndpoint, eventType string, payload []byte) (entity.DeliveryResult, error) {
+	return s.post(ctx, endpoint, payload, map[string]string{
+		"Content-Type": "application/json",
+		EventHeader:    eventType,
+	})
+}
+
+// SendBatch отправляет payloads одним NDJSON-запросом: по событию в строке, в том же конверте, что и у Send.
+// Тип события в заголовке — BatchEvent, число событий — в BatchSizeHeader
+func (s *HTTPSender) SendBatch(ctx context.Context, endpoint entity.WebhookEndpoint, payloads [][]byte) (entity.DeliveryResult, error) {
+	var body bytes.Buffer
+	for _, payload := range payloads {
+		body.Write(payload)
+		body.WriteByte('\n')
+	}
+
+	return s.post(ctx, endpoint, body.Bytes(), map[string]string{
+		"Content-Type":  "application/x-ndjson",
+		EventHeader:     BatchEvent,
+		BatchSizeHeader: strconv.Itoa(len(payloads)),
+	})
+}
+
+// post подписывает несжатое тело: получатель проверяет подпись после распаковки
+func (s *HTTPSender) post(ctx context.Context, endpoint entity.WebhookEndpoint, body []byte, headers map[string]string) (entity.DeliveryResult, error) {
+	timeout := s.timeout
+	if endpoint.Retry.TimeoutSeconds != nil {
+		timeout = time.Duration(*endpoint.Retry.TimeoutSeconds) * time.Second
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	sent := body
+	if endpoint.Delivery.Compression == entity.CompressionGzip {
+		var err error
+		if sent, err = gzipBody(body); err != nil {
+			return entity.DeliveryResult{}, fmt.Errorf("failed to compress webhook body: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(sent))
+	if err != nil {
+		return entity.DeliveryResult{}, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if endpoint.Delivery.Compression == entity.CompressionGzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	s.injectHeaders(req)
+	sign(req, endpoint.Secret, body)
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	result := entity.DeliveryResult{Latency: time.Since(start)}
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return result, fmt.Errorf("HTTP status: %d", resp.StatusCode)
+	}
+
+	return result, nil
+}
+
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *HTTPSender) injectHeaders(req *http.Request) {
+	for _, key := range []string{"*", req.URL.Hostname(), req.URL.Host} {
+		for name, value := range s.headers[key] {
+			req.Header.Set(name, value)
+		}
+	}
+}
+
+// sign добавляет HMAC-SHA256 от "timestamp.payload", если у получателя задан секрет;
+// получатель проверяет подпись и отбрасывает запросы со старым timestamp
+func sign(req *http.Request, secret string, payload []byte) {
+	if secret == "" {
+		return
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+type TLSOptions struct {
+	CertFile   string
+	KeyFile    string
+	CAFile     string
+	MinVersion string
+}
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+func (o TLSOptions) build() (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if o.MinVersion != "" {
+		version, ok := tlsVersions[o.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS min version %q", o.MinVersion)
+		}
+		cfg.MinVersion = version
+	}
+
+	// клиентский сертификат для mTLS
+	if o.CertFile != "" || o.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load webhook client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	// собственный CA добавляется к системным, а не заменяет их
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhook CA bundle: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in webhook CA bundle %s", o.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
@@ -2,17 +2,52 @@ package app
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/4otis/geonotify-service/config"
 	_ "github.com/4otis/geonotify-service/docs"
+	"github.com/4otis/geonotify-service/internal/adapter/alertfeed"
+	"github.com/4otis/geonotify-service/internal/adapter/alerts"
+	authadapter "github.com/4otis/geonotify-service/internal/adapter/auth"
+	cacheadapter "github.com/4otis/geonotify-service/internal/adapter/cache"
+	"github.com/4otis/geonotify-service/internal/adapter/chaos"
+	"github.com/4otis/geonotify-service/internal/adapter/geocoding"
+	"github.com/4otis/geonotify-service/internal/adapter/opensearch"
+	"github.com/4otis/geonotify-service/internal/adapter/opsmetrics"
+	"github.com/4otis/geonotify-service/internal/adapter/opsnotify"
+	"github.com/4otis/geonotify-service/internal/adapter/publisher"
+	"github.com/4otis/geonotify-service/internal/adapter/queue"
+	"github.com/4otis/geonotify-service/internal/adapter/region"
 	"github.com/4otis/geonotify-service/internal/adapter/repo/postgres"
+	"github.com/4otis/geonotify-service/internal/adapter/s3"
+	usageadapter "github.com/4otis/geonotify-service/internal/adapter/usage"
+	weatheradapter "github.com/4otis/geonotify-service/internal/adapter/weather"
+	"github.com/4otis/geonotify-service/internal/adapter/webhook"
 	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/entity"
 	httphandler "github.com/4otis/geonotify-service/internal/handler/http"
+	mqtthandler "github.com/4otis/geonotify-service/internal/handler/mqtt"
+	alertsport "github.com/4otis/geonotify-service/internal/port/alerts"
+	"github.com/4otis/geonotify-service/internal/port/auth"
+	"github.com/4otis/geonotify-service/internal/port/cache"
+	"github.com/4otis/geonotify-service/internal/port/delivery"
+	"github.com/4otis/geonotify-service/internal/port/geo"
+	"github.com/4otis/geonotify-service/internal/port/ops"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/internal/port/search"
+	"github.com/4otis/geonotify-service/internal/port/storage"
+	"github.com/4otis/geonotify-service/internal/port/usage"
 	"github.com/4otis/geonotify-service/internal/worker"
+	"github.com/4otis/geonotify-service/migrations"
+	"github.com/4otis/geonotify-service/pkg/fieldcrypt"
+	"github.com/4otis/geonotify-service/pkg/h3cell"
+	"github.com/4otis/geonotify-service/pkg/locale"
 	"github.com/4otis/geonotify-service/pkg/logger"
+	pgpkg "github.com/4otis/geonotify-service/pkg/postgres"
 	"github.com/4otis/geonotify-service/pkg/redis"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
@@ -21,35 +56,124 @@ import (
 	"go.uber.org/zap"
 )
 
+// redisHealthInterval — период проверки Redis для выхода из деградированного режима
+const redisHealthInterval = 5 * time.Second
+
+// Сроки v1 проверки координат: устарела с выходом v2, отключается через полгода после этого
+var (
+	locationV1Deprecated = time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	locationV1Sunset     = time.Date(2027, 4, 16, 0, 0, 0, 0, time.UTC)
+)
+
 type App struct {
+	mode          Mode
 	config        *config.Config
 	logger        *zap.Logger
 	httpServer    *http.Server
+	acmeServer    *http.Server
 	dbPool        *pgxpool.Pool
+	dbReplica     *pgpkg.Replica
+	queryTracer   *pgpkg.QueryTracer
+	workerLocks   *pgpkg.Locks
 	redisClient   *redis.Client
 	webhookWorker *worker.WebhookWorker
+
+	partitionWorker *worker.PartitionWorker
+	checkBatcher    *worker.CheckBatcher
+	scheduleWorker  *worker.ScheduleWorker
+	alertImport     *worker.ImportWorker
+	eventRelay      *worker.EventRelayWorker
+	locationStream  *worker.LocationConsumer
+	mqttSubscriber  *mqtthandler.LocationSubscriber
+	webhookSender   delivery.WebhookSender
+	webhookQueue    delivery.Queue
+	amqpQueue       *queue.AMQP
+	objectStorage   *s3.Storage
+	opsAlerts       *worker.OpsAlertWorker
+	usageFlush      *worker.UsageFlushWorker
+	searchIndex     *worker.SearchIndexWorker
+	heatmapWorker   *worker.HeatmapWorker
+	statsRollup     *worker.StatsRollupWorker
+	erasureWorker   *worker.ErasureWorker
+	// checkKeys и reencryptWorker — nil, если проверки не шифруются
+	checkKeys       *fieldcrypt.Keyring
+	reencryptWorker *worker.ReencryptWorker
+	// dataExportWorker — nil без объектного хранилища
+	dataExportWorker *worker.DataExportWorker
+
+	// webhookAttempts и cacheCalls считают операции и ошибки для оповещений дежурных;
+	// nil — оповещения выключены
+	webhookAttempts *opsmetrics.Counter
+	cacheCalls      *opsmetrics.Counter
+
+	// invalidateIncidentsCache сбрасывает кэш после восстановления Redis:
+	// пока он был недоступен, инвалидации пропускались
+	invalidateIncidentsCache func(ctx context.Context) error
+	stopRedisMonitor         context.CancelFunc
+
+	settings        *config.Holder
+	logLevel        zap.AtomicLevel
+	stopConfigWatch func()
 }
 
-func New(cfg *config.Config) (*App, error) {
-	zapLogger, err := logger.NewDevelopment(cfg.LogLevel)
+func New(settings *config.Holder, mode Mode) (*App, error) {
+	cfg := settings.Get()
+
+	logLevel, err := logger.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	// без явного LOG_FORMAT в production пишется JSON, иначе читаемый формат разработки
+	logFormat := cfg.LogFormat
+	if logFormat == "" {
+		logFormat = "console"
+		if cfg.IsProduction() {
+			logFormat = "json"
+		}
+	}
+
+	zapLogger, err := logger.Build(logLevel, logger.Options{
+		Format:         logFormat,
+		Outputs:        cfg.LogOutput,
+		Development:    !cfg.IsProduction(),
+		FileMaxSizeMB:  cfg.LogFileMaxSizeMB,
+		FileMaxBackups: cfg.LogFileMaxBackups,
+		FileMaxAgeDays: cfg.LogFileMaxAgeDays,
+		FileCompress:   cfg.LogFileCompress,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	app := &App{
-		config: cfg,
-		logger: zapLogger,
+		mode:     mode,
+		config:   cfg,
+		logger:   zapLogger,
+		settings: settings,
+		logLevel: logLevel,
+	}
+
+	settings.OnReload(app.applyReloadedConfig)
+
+	if cfg.OpsAlertsEnabled() {
+		app.webhookAttempts = &opsmetrics.Counter{}
+		app.cacheCalls = &opsmetrics.Counter{}
 	}
 
 	if err := app.initDB(); err != nil {
 		return nil, err
 	}
 
+	if err := app.initCheckEncryption(); err != nil {
+		return nil, err
+	}
+
 	if err := app.initRedis(); err != nil {
 		return nil, err
 	}
 
-	if err := app.initUseCasesAndHandlers(); err != nil {
+	if err := app.initStorage(); err != nil {
 		return nil, err
 	}
 
@@ -57,13 +181,73 @@ func New(cfg *config.Config) (*App, error) {
 		return nil, err
 	}
 
+	if err := app.initUseCasesAndHandlers(); err != nil {
+		return nil, err
+	}
+
+	if err := app.initPartitionWorker(); err != nil {
+		return nil, err
+	}
+
+	app.initSearchIndex()
+	app.initHeatmapWorker()
+
 	return app, nil
 }
 
+// newDBPool создает пул с трассировкой запросов и настройками PG_POOL_*; соединения открываются при первом запросе
+func (a *App) newDBPool(ctx context.Context, name, dbURL string) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.ConnConfig.Tracer = a.queryTracer
+
+	if a.config.DBPoolMaxConns > 0 {
+		poolConfig.MaxConns = int32(a.config.DBPoolMaxConns)
+	}
+	if a.config.DBPoolMinConns > 0 {
+		poolConfig.MinConns = int32(a.config.DBPoolMinConns)
+	}
+	if a.config.DBPoolMaxConnLifetimeSeconds > 0 {
+		poolConfig.MaxConnLifetime = time.Duration(a.config.DBPoolMaxConnLifetimeSeconds) * time.Second
+	}
+	if a.config.DBPoolMaxConnIdleSeconds > 0 {
+		poolConfig.MaxConnIdleTime = time.Duration(a.config.DBPoolMaxConnIdleSeconds) * time.Second
+	}
+	if a.config.DBPoolHealthCheckSeconds > 0 {
+		poolConfig.HealthCheckPeriod = time.Duration(a.config.DBPoolHealthCheckSeconds) * time.Second
+	}
+	// MinConns больше MaxConns из URL pgxpool не примет, поэтому сообщение об ошибке называет настройку
+	if poolConfig.MinConns > poolConfig.MaxConns {
+		return nil, fmt.Errorf("%s database pool: min conns %d exceed max conns %d (PG_POOL_MIN_CONNS)",
+			name, poolConfig.MinConns, poolConfig.MaxConns)
+	}
+
+	a.logger.Info("Database pool configured",
+		zap.String("pool", name),
+		zap.Int32("max_conns", poolConfig.MaxConns),
+		zap.Int32("min_conns", poolConfig.MinConns),
+		zap.Duration("max_conn_lifetime", poolConfig.MaxConnLifetime),
+		zap.Duration("max_conn_idle_time", poolConfig.MaxConnIdleTime),
+		zap.Duration("health_check_period", poolConfig.HealthCheckPeriod))
+
+	return pgxpool.NewWithConfig(ctx, poolConfig)
+}
+
 func (a *App) initDB() error {
 	ctx := context.Background()
 
-	pool, err := pgxpool.New(ctx, a.config.DBURL)
+	a.queryTracer = pgpkg.NewQueryTracer(
+		time.Duration(a.config.DBSlowQueryMs)*time.Millisecond,
+		func(sql string, duration time.Duration, err error) {
+			a.logger.Warn("Slow query",
+				zap.String("sql", sql),
+				zap.Duration("duration", duration),
+				zap.Error(err))
+		})
+
+	pool, err := a.newDBPool(ctx, "primary", a.config.DBURL)
 	if err != nil {
 		return err
 	}
@@ -74,61 +258,729 @@ func (a *App) initDB() error {
 	}
 
 	a.logger.Info("Database connected successfully")
+
+	if a.config.WorkerLocksEnabled {
+		a.workerLocks = pgpkg.NewLocks(pool, func(name string, held bool, err error) {
+			if held {
+				a.logger.Info("Worker lock acquired", zap.String("lock", name))
+				return
+			}
+			a.logger.Warn("Worker lock lost", zap.String("lock", name), zap.Error(err))
+		})
+	}
+
+	if a.config.DBReplicaURL == "" {
+		return nil
+	}
+
+	// реплика подключается лениво: ее недоступность при старте не мешает работе на основной БД
+	replicaPool, err := a.newDBPool(ctx, "replica", a.config.DBReplicaURL)
+	if err != nil {
+		return err
+	}
+	a.dbReplica = pgpkg.NewReplica(replicaPool,
+		time.Duration(a.config.DBReplicaRetrySeconds)*time.Second,
+		func(available bool, err error) {
+			if available {
+				a.logger.Info("Database replica is available again, reads are routed to the replica")
+				return
+			}
+			a.logger.Warn("Database replica is unavailable, reads fall back to the primary", zap.Error(err))
+		})
+	a.logger.Info("Database replica configured")
+
+	return nil
+}
+
+// initCheckEncryption загружает ключи шифрования проверок; без ключей проверки пишутся открыто
+func (a *App) initCheckEncryption() error {
+	specs, err := a.config.CheckEncryptionKeyList()
+	if err != nil {
+		return err
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+
+	keys, err := fieldcrypt.Parse(specs)
+	if err != nil {
+		return err
+	}
+	a.checkKeys = keys
+
+	a.reencryptWorker = worker.NewReencryptWorker(
+		a.logger,
+		postgres.NewCheckRepo(a.dbPool, a.dbReplica, keys),
+		postgres.NewTransactor(a.dbPool),
+		a.config.CheckReencryptIntervalSeconds,
+		a.leader("check-reencryption"),
+	)
+
+	a.logger.Info("Check encryption enabled",
+		zap.Int("active_key", keys.Active()),
+		zap.Int("keys", len(specs)))
 	return nil
 }
 
 func (a *App) initRedis() error {
+	if a.config.RedisURL == "" {
+		a.logger.Warn("REDIS_URL is not set, running without Redis: alert stream is disabled")
+		return nil
+	}
+
 	ctx := context.Background()
 
-	redisClient, err := redis.NewClient(ctx, a.config.RedisURL)
+	redisClient, err := redis.NewClient(ctx, a.config.RedisURL, redis.PoolOptions{
+		PoolSize:        a.config.RedisPoolSize,
+		MinIdleConns:    a.config.RedisMinIdleConns,
+		PoolTimeout:     time.Duration(a.config.RedisPoolTimeoutSeconds) * time.Second,
+		ConnMaxLifetime: time.Duration(a.config.RedisConnMaxLifetimeSeconds) * time.Second,
+	})
 	if err != nil {
 		return err
 	}
 	a.redisClient = redisClient
 
-	a.logger.Info("Redis connected successfully")
+	pool := redisClient.Pool()
+	a.logger.Info("Redis connected successfully",
+		zap.Int("pool_size", pool.PoolSize),
+		zap.Int("min_idle_conns", pool.MinIdleConns),
+		zap.Duration("pool_timeout", pool.PoolTimeout),
+		zap.Duration("conn_max_lifetime", pool.ConnMaxLifetime))
+	return nil
+}
+
+func (a *App) initStorage() error {
+	if a.config.S3Endpoint == "" {
+		a.logger.Info("S3_ENDPOINT is not set, incident attachments are disabled")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objectStorage, err := s3.NewStorage(ctx, s3.Options{
+		Endpoint:  a.config.S3Endpoint,
+		AccessKey: a.config.S3AccessKey,
+		SecretKey: a.config.S3SecretKey,
+		Bucket:    a.config.S3Bucket,
+		Region:    a.config.S3Region,
+		UseSSL:    a.config.S3UseSSL,
+	})
+	if err != nil {
+		return err
+	}
+	a.objectStorage = objectStorage
+
+	a.logger.Info("Object storage connected successfully",
+		zap.String("bucket", a.config.S3Bucket))
 	return nil
 }
 
+func (a *App) newGeocoder() (geo.Provider, error) {
+	if a.config.GeocoderProvider == "" {
+		a.logger.Info("GEOCODER_PROVIDER is not set, geocoding is disabled")
+		return nil, nil
+	}
+
+	return geocoding.New(geocoding.Options{
+		Provider:  a.config.GeocoderProvider,
+		BaseURL:   a.config.GeocoderURL,
+		APIKey:    a.config.GeocoderAPIKey,
+		UserAgent: a.config.GeocoderUserAgent,
+		Timeout:   5 * time.Second,
+	})
+}
+
+// newCache выбирает бэкенд кэша по CACHE_BACKEND
+// newOIDCVerifier создает проверку токенов внешнего провайдера. Недоступность провайдера
+// при старте не мешает запуску: ключи будут загружены при первом запросе с токеном
+func (a *App) newOIDCVerifier() *authadapter.OIDC {
+	verifier := authadapter.NewOIDC(&http.Client{Timeout: 5 * time.Second}, authadapter.OIDCOptions{
+		Issuer:        a.config.OIDCIssuer,
+		Audience:      a.config.OIDCAudience,
+		JWKSURL:       a.config.OIDCJWKSURL,
+		UsernameClaim: a.config.OIDCUsernameClaim,
+		RefreshEvery:  time.Duration(a.config.OIDCJWKSRefreshMinutes) * time.Minute,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := verifier.Refresh(ctx); err != nil {
+		a.logger.Warn("failed to load OIDC signing keys, will retry on demand",
+			zap.Error(err),
+			zap.String("issuer", a.config.OIDCIssuer))
+	} else {
+		a.logger.Info("OIDC authentication enabled",
+			zap.String("issuer", a.config.OIDCIssuer),
+			zap.String("audience", a.config.OIDCAudience))
+	}
+
+	return verifier
+}
+
+func (a *App) newCache() cache.Cache {
+	var c cache.Cache
+	if a.config.CacheBackend == "memory" {
+		a.logger.Info("Using in-memory cache")
+		c = cacheadapter.NewMemory()
+	} else {
+		c = cacheadapter.NewRedis(a.redisClient)
+	}
+
+	if a.cacheCalls != nil {
+		return opsmetrics.NewCache(c, a.cacheCalls)
+	}
+	return c
+}
+
+// newCounter возвращает счетчики лимитов запросов в бэкенде кэша: с Redis лимит общий для всех реплик
+func (a *App) newCounter() cache.Counter {
+	if a.config.CacheBackend == "memory" {
+		return cacheadapter.NewMemory()
+	}
+	return cacheadapter.NewRedis(a.redisClient)
+}
+
+// newQueue выбирает бэкенд очереди вебхуков по QUEUE_BACKEND
+func (a *App) newQueue() (delivery.Queue, error) {
+	switch a.config.QueueBackend {
+	case "postgres":
+		a.logger.Info("Using Postgres webhook queue")
+		return queue.NewPostgres(a.dbPool), nil
+	case "amqp":
+		amqpQueue, err := queue.NewAMQP(a.config.AMQPURL, a.config.AMQPQueue)
+		if err != nil {
+			return nil, err
+		}
+		a.amqpQueue = amqpQueue
+		a.logger.Info("Using AMQP webhook queue", zap.String("queue", a.config.AMQPQueue))
+		return amqpQueue, nil
+	}
+
+	return queue.NewRedis(a.redisClient), nil
+}
+
+// injectChaos оборачивает отправку вебхуков и их очередь искусственными сбоями из CHAOS_*;
+// при ENV=production эти настройки не проходят валидацию
+func (a *App) injectChaos() {
+	if !a.config.ChaosEnabled() {
+		return
+	}
+
+	senderFaults := chaos.Faults{
+		FailureRate: float64(a.config.ChaosWebhookFailurePercent) / 100,
+		Latency:     time.Duration(a.config.ChaosWebhookLatencyMs) * time.Millisecond,
+	}
+	queueFaults := chaos.Faults{
+		FailureRate: float64(a.config.ChaosQueueFailurePercent) / 100,
+		Latency:     time.Duration(a.config.ChaosQueueLatencyMs) * time.Millisecond,
+	}
+
+	injector := chaos.NewInjector(int64(a.config.ChaosSeed))
+	if senderFaults.Enabled() {
+		a.webhookSender = chaos.NewSender(a.webhookSender, senderFaults, injector)
+	}
+	if queueFaults.Enabled() {
+		a.webhookQueue = chaos.NewQueue(a.webhookQueue, queueFaults, injector)
+	}
+
+	a.logger.Warn("Chaos mode is on: webhook deliveries and queue calls fail and slow down on purpose",
+		zap.Int("webhook_failure_percent", a.config.ChaosWebhookFailurePercent),
+		zap.Int("webhook_latency_ms", a.config.ChaosWebhookLatencyMs),
+		zap.Int("queue_failure_percent", a.config.ChaosQueueFailurePercent),
+		zap.Int("queue_latency_ms", a.config.ChaosQueueLatencyMs),
+		zap.Int("seed", a.config.ChaosSeed))
+}
+
+// importSources собирает источники импорта оповещений: внешние ленты (по имени, чтобы они опрашивались
+// в одном порядке) и погодный провайдер
+func (a *App) importSources() ([]worker.ImportSource, error) {
+	names := make([]string, 0, len(a.config.FeedImportSources))
+	for name := range a.config.FeedImportSources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	reader := alertfeed.NewHTTPReader(alertfeed.Options{
+		UserAgent:   "geonotify-service",
+		Timeout:     30 * time.Second,
+		PointRadius: float64(a.config.FeedImportPointRadiusM),
+	})
+	sources := make([]worker.ImportSource, 0, len(names)+1)
+	for _, name := range names {
+		url := a.config.FeedImportSources[name]
+		sources = append(sources, worker.ImportSource{
+			Feed: cases.ImportFeed{Name: name, Source: entity.SourceFeed, Publish: a.config.FeedImportPublish},
+			Read: func(ctx context.Context) ([]entity.ExternalAlert, error) {
+				return reader.Read(ctx, url)
+			},
+			Interval: time.Duration(a.config.FeedImportIntervalSeconds) * time.Second,
+		})
+	}
+
+	if a.config.WeatherProvider == "" {
+		return sources, nil
+	}
+
+	locations := make([]weatheradapter.Location, 0, len(a.config.WeatherLocations))
+	for _, location := range a.config.WeatherLocations {
+		lat, lon, err := config.ParseLocation(location)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, weatheradapter.Location{Latitude: lat, Longitude: lon})
+	}
+
+	provider, err := weatheradapter.New(weatheradapter.Options{
+		Provider:    a.config.WeatherProvider,
+		BaseURL:     a.config.WeatherURL,
+		APIKey:      a.config.WeatherAPIKey,
+		UserAgent:   a.config.WeatherUserAgent,
+		Timeout:     30 * time.Second,
+		Locations:   locations,
+		Radius:      float64(a.config.WeatherRadiusM),
+		SeverityMap: a.config.WeatherSeverityMap,
+		MinSeverity: a.config.WeatherMinSeverity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.logger.Info("Weather alerts enabled",
+		zap.String("provider", a.config.WeatherProvider),
+		zap.Int("locations", len(locations)))
+
+	return append(sources, worker.ImportSource{
+		Feed:     cases.ImportFeed{Name: a.config.WeatherProvider, Source: entity.SourceWeather, Publish: a.config.WeatherPublish},
+		Read:     provider.Alerts,
+		Interval: time.Duration(a.config.WeatherIntervalSeconds) * time.Second,
+	}), nil
+}
+
 func (a *App) initWebhookWorker() error {
-	webhookRepo := postgres.NewWebhookRepo(a.dbPool)
+	webhookRepo := postgres.NewWebhookRepo(a.dbPool, a.dbReplica, a.checkKeys)
+	sender, err := webhook.NewHTTPSender(webhook.Options{
+		Timeout: entity.DefaultWebhookTimeout,
+		TLS: webhook.TLSOptions{
+			CertFile:   a.config.WebhookTLSCertFile,
+			KeyFile:    a.config.WebhookTLSKeyFile,
+			CAFile:     a.config.WebhookTLSCAFile,
+			MinVersion: a.config.WebhookTLSMinVersion,
+		},
+		ProxyURL: a.config.WebhookProxyURL,
+		Headers:  a.config.WebhookHeaders,
+	})
+	if err != nil {
+		return err
+	}
+	a.webhookSender = sender
+
+	a.webhookQueue, err = a.newQueue()
+	if err != nil {
+		return err
+	}
+	a.injectChaos()
+	// поверх сбоев CHAOS_*, чтобы они тоже учитывались в доле неуспешных доставок
+	if a.webhookAttempts != nil {
+		a.webhookSender = opsmetrics.NewSender(a.webhookSender, a.webhookAttempts)
+	}
 
 	a.webhookWorker = worker.NewWebhookWorker(
 		a.logger,
 		webhookRepo,
-		a.redisClient,
-		a.config.WebhookURL,
-		a.config.MaxRetries,
-		a.config.RetryDelaySeconds,
+		postgres.NewWebhookEndpointRepo(a.dbPool),
+		a.webhookQueue,
+		a.webhookSender,
+		a.settings,
+		a.config.WebhookPollIntervalSeconds,
+		a.config.WebhookLeaseSeconds,
+		a.leader("webhook-outbox"),
 	)
 
 	return nil
 }
 
+func (a *App) initPartitionWorker() error {
+	checkRepo := postgres.NewCheckRepo(a.dbPool, a.dbReplica, a.checkKeys)
+
+	a.partitionWorker = worker.NewPartitionWorker(
+		a.logger,
+		checkRepo,
+		a.config.CheckPartitionPremakeDays,
+		a.config.CheckRetentionDays,
+		a.config.PartitionMaintenanceMinutes,
+		a.leader("check-partitions"),
+	)
+
+	return nil
+}
+
+// initSearchIndex создает выгрузку зон и проверок в OpenSearch; без OPENSEARCH_URL она выключена
+func (a *App) initSearchIndex() {
+	if a.config.OpenSearchURL == "" {
+		return
+	}
+
+	a.searchIndex = worker.NewSearchIndexWorker(
+		a.logger,
+		a.newSearchIndexer(),
+		postgres.NewIncidentRepo(a.dbPool, a.dbReplica),
+		postgres.NewCheckRepo(a.dbPool, a.dbReplica, a.checkKeys),
+		postgres.NewSyncCursorRepo(a.dbPool),
+		a.config.OpenSearchBatchSize,
+		a.config.OpenSearchSettleSeconds,
+		a.config.OpenSearchIntervalSeconds,
+		a.leader("search-index"),
+	)
+}
+
+// initHeatmapWorker создает сводку проверок в тепловую карту по H3-ячейкам; без cgo H3 недоступна,
+// и тепловая карта отключается
+func (a *App) initHeatmapWorker() {
+	if !h3cell.Supported {
+		a.logger.Warn("Heatmap disabled: the binary is built without cgo")
+		return
+	}
+	a.heatmapWorker = worker.NewHeatmapWorker(
+		a.logger,
+		postgres.NewCheckRepo(a.dbPool, a.dbReplica, a.checkKeys),
+		postgres.NewHeatmapRepo(a.dbPool, a.dbReplica),
+		postgres.NewSyncCursorRepo(a.dbPool),
+		postgres.NewTransactor(a.dbPool),
+		a.config.HeatmapResolution,
+		a.config.HeatmapBatchSize,
+		a.config.HeatmapSettleSeconds,
+		a.config.CheckRetentionDays,
+		a.config.HeatmapIntervalSeconds,
+		a.leader("check-heatmap"),
+	)
+}
+
+func (a *App) newSearchIndexer() *opensearch.Indexer {
+	return opensearch.NewIndexer(opensearch.Options{
+		URL:         a.config.OpenSearchURL,
+		Username:    a.config.OpenSearchUsername,
+		Password:    a.config.OpenSearchPassword,
+		IndexPrefix: a.config.OpenSearchIndexPrefix,
+	})
+}
+
+// initStatsRollup создает сводку проверок в поминутные счетчики статистики
+func (a *App) initStatsRollup(stats cases.StatsUseCase) {
+	a.statsRollup = worker.NewStatsRollupWorker(
+		a.logger,
+		stats,
+		a.config.StatsRollupSettleSeconds,
+		a.config.CheckRetentionDays,
+		a.config.StatsRollupIntervalSeconds,
+		a.leader("check-stats"),
+	)
+}
+
+// initOpsAlerts создает оповещения дежурных во всех режимах: ошибки кэша видны в API,
+// сбои доставки — в воркерах. Глубину общей очереди и задержку доставки проверяет одна реплика
+func (a *App) initOpsAlerts(stats cases.StatsUseCase, endpoints cases.WebhookEndpointUseCase) {
+	if !a.config.OpsAlertsEnabled() {
+		return
+	}
+
+	var notifiers opsnotify.Multi
+	if a.config.OpsAlertSlackWebhookURL != "" {
+		notifiers = append(notifiers, opsnotify.NewSlack(a.config.OpsAlertSlackWebhookURL))
+	}
+	if a.config.OpsAlertTelegramBotToken != "" {
+		notifiers = append(notifiers, opsnotify.NewTelegram("", a.config.OpsAlertTelegramBotToken, a.config.OpsAlertTelegramChatID))
+	}
+
+	var notifier ops.Notifier = notifiers
+	if len(notifiers) == 1 {
+		notifier = notifiers[0]
+	}
+
+	a.opsAlerts = worker.NewOpsAlertWorker(
+		a.logger,
+		notifier,
+		stats,
+		endpoints,
+		a.webhookAttempts,
+		a.cacheCalls,
+		worker.OpsAlertThresholds{
+			MinSamples:            a.config.OpsAlertMinSamples,
+			WebhookFailurePercent: a.config.OpsAlertWebhookFailurePercent,
+			CacheErrorPercent:     a.config.OpsAlertCacheErrorPercent,
+			QueueDepth:            a.config.OpsAlertQueueDepth,
+			WebhookSLO:            a.config.WebhookSLOLatencyMs > 0,
+		},
+		a.config.OpsAlertIntervalSeconds,
+		a.config.OpsAlertCooldownMinutes,
+		a.leader("ops-alert-queue-depth"),
+	)
+}
+
+// newUsageUseCase создает учет использования API-ключей и воркер переноса счетчиков в БД;
+// meter nil — учет выключен (USAGE_METERING_ENABLED)
+func (a *App) newUsageUseCase(meter usage.Meter) cases.UsageUseCase {
+	if meter == nil {
+		return nil
+	}
+
+	quotas := make(map[string]entity.APIKeyQuota, len(a.config.APIKeys)+1)
+	if a.config.APIKey != "" {
+		quotas[entity.DefaultAPIKey] = entity.APIKeyQuota{}
+	}
+	for name, key := range a.config.APIKeys {
+		quotas[name] = entity.APIKeyQuota{Daily: int64(key.DailyQuota), Monthly: int64(key.MonthlyQuota)}
+	}
+
+	usageUseCase := cases.NewUsageUseCase(
+		meter,
+		postgres.NewUsageRepo(a.dbPool),
+		quotas,
+		a.logger,
+	)
+	a.usageFlush = worker.NewUsageFlushWorker(a.logger, usageUseCase, a.config.UsageFlushSeconds)
+
+	a.logger.Info("API key usage metering enabled", zap.Int("api_keys", len(quotas)))
+	return usageUseCase
+}
+
+// leader возвращает блокировку периодической задачи; nil — блокировки выключены и задача идет в каждой реплике
+func (a *App) leader(name string) worker.Leader {
+	if a.workerLocks == nil {
+		return nil
+	}
+	return a.workerLocks.Lock(name)
+}
+
 func (a *App) initUseCasesAndHandlers() error {
-	incidentRepo := postgres.NewIncidentRepo(a.dbPool)
-	checkRepo := postgres.NewCheckRepo(a.dbPool)
-	webhookRepo := postgres.NewWebhookRepo(a.dbPool)
+	incidentRepo := postgres.NewIncidentRepo(a.dbPool, a.dbReplica)
+	var checkRepo repo.CheckRepo = postgres.NewCheckRepo(a.dbPool, a.dbReplica, a.checkKeys)
+	if a.config.CheckBatchEnabled {
+		a.checkBatcher = worker.NewCheckBatcher(
+			a.logger,
+			checkRepo,
+			postgres.NewTransactor(a.dbPool),
+			a.config.CheckBatchSize,
+			a.config.CheckBatchFlushMs,
+			a.config.CheckBatchBuffer,
+		)
+		checkRepo = a.checkBatcher
+	}
+	switch a.config.CheckLocationPrivacy {
+	case cases.LocationPrivacyRound:
+		checkRepo = cases.NewCoarseCheckRepo(checkRepo, cases.LocationPrivacyRound, a.config.CheckLocationDecimals)
+	case cases.LocationPrivacyGeohash:
+		checkRepo = cases.NewCoarseCheckRepo(checkRepo, cases.LocationPrivacyGeohash, a.config.CheckLocationGeohashLength)
+	}
+	webhookRepo := postgres.NewWebhookRepo(a.dbPool, a.dbReplica, a.checkKeys)
+	// вебхуки считаются при постановке в outbox, повторы и переотправки из админки в учет не попадают
+	var outboxWebhookRepo repo.WebhookRepo = webhookRepo
+	var usageMeter usage.Meter
+	if a.config.UsageMeteringEnabled {
+		usageMeter = usageadapter.NewRedisMeter(a.redisClient)
+		checkRepo = cases.NewMeteredCheckRepo(checkRepo, usageMeter, a.logger)
+		outboxWebhookRepo = cases.NewMeteredWebhookRepo(webhookRepo, usageMeter, a.logger)
+	}
+	webhookEndpointRepo := postgres.NewWebhookEndpointRepo(a.dbPool)
+
+	geocoder, err := a.newGeocoder()
+	if err != nil {
+		return err
+	}
+
+	incidentsCache := a.newCache()
+
+	var area geo.OperatingArea
+	if a.config.RegionAllowlistFile != "" || a.config.RegionDenylistFile != "" {
+		regionArea, err := region.NewArea(a.config.RegionAllowlistFile, a.config.RegionDenylistFile)
+		if err != nil {
+			return err
+		}
+		area = regionArea
+		a.logger.Info("Operating area restricted",
+			zap.String("allowlist", a.config.RegionAllowlistFile),
+			zap.String("denylist", a.config.RegionDenylistFile))
+	}
+
+	var reverseGeocoder geo.ReverseGeocoder
+	if geocoder != nil {
+		reverseGeocoder = geocoding.NewCachedReverse(
+			geocoder,
+			incidentsCache,
+			time.Duration(a.config.ReverseGeocodeCacheTTLMinutes)*time.Minute,
+		)
+	}
+
+	// один outbox на все потоки событий: тема хранится в каждом событии
+	var checkEvents, approvalEvents repo.EventRepo
+	if a.config.CheckEventsEnabled || a.config.ApprovalEventsEnabled {
+		eventRepo := postgres.NewEventRepo(a.dbPool)
+		if a.config.CheckEventsEnabled {
+			checkEvents = eventRepo
+		}
+		if a.config.ApprovalEventsEnabled {
+			approvalEvents = eventRepo
+		}
+		a.eventRelay = worker.NewEventRelayWorker(
+			a.logger,
+			eventRepo,
+			postgres.NewTransactor(a.dbPool),
+			publisher.NewRedisStream(a.redisClient, int64(a.config.CheckEventsStreamMaxLen)),
+			a.config.EventRelayBatchSize,
+			a.config.EventRelayIntervalMs,
+			a.leader("event-relay"),
+		)
+	}
+
+	var (
+		alertBus      alertsport.Bus
+		userLocations alertsport.LocationIndex
+	)
+	if a.redisClient != nil {
+		alertBus = alerts.NewRedisBus(a.redisClient, a.logger)
+		userLocations = alerts.NewRedisLocationIndex(a.redisClient)
+	}
+
+	webhookOutbox := cases.NewWebhookOutbox(outboxWebhookRepo, webhookEndpointRepo, a.webhookQueue,
+		a.config.WebhookMaxPayloadKB<<10, a.logger)
+
+	preferenceRepo := postgres.NewPreferenceRepo(a.dbPool)
+	var alertDispatcher *cases.AlertDispatcher
+	if alertBus != nil {
+		alertDispatcher = cases.NewAlertDispatcher(
+			alertBus,
+			alerts.NewRedisAlertCounter(a.redisClient),
+			preferenceRepo,
+			incidentsCache,
+			webhookOutbox,
+			postgres.NewTransactor(a.dbPool),
+			a.logger,
+		)
+	}
+
+	// формат проверен при валидации конфигурации
+	defaultLocale, _ := locale.Canonical(a.config.IncidentDefaultLocale)
+	translationRepo := postgres.NewTranslationRepo(a.dbPool)
+	commentRepo := postgres.NewCommentRepo(a.dbPool)
+	var webhookComments repo.CommentRepo
+	if a.config.WebhookIncludeComments {
+		webhookComments = commentRepo
+	}
 
+	groupRepo := postgres.NewGroupRepo(a.dbPool)
+	geometryVersionRepo := postgres.NewGeometryVersionRepo(a.dbPool, a.dbReplica)
 	locationUseCase := cases.NewLocationUseCase(
 		incidentRepo,
 		checkRepo,
-		webhookRepo,
-		a.redisClient,
+		webhookOutbox,
+		checkEvents,
+		postgres.NewTransactor(a.dbPool),
+		incidentsCache,
 		a.logger,
-		a.config.CacheTTLMinutes,
+		a.settings,
+		reverseGeocoder,
+		alertDispatcher,
+		userLocations,
+		area,
+		postgres.NewPresenceRepo(a.dbPool),
+		groupRepo,
+		translationRepo,
+		defaultLocale,
+		webhookComments,
+		geometryVersionRepo,
 	)
+
+	a.invalidateIncidentsCache = locationUseCase.InvalidateIncidentsCache
+
+	if a.config.LocationStreamEnabled {
+		a.locationStream = worker.NewLocationConsumer(
+			a.logger,
+			locationUseCase,
+			a.redisClient,
+			a.config.LocationStream,
+			a.config.LocationStreamGroup,
+			a.config.LocationConsumerConcurrency,
+			a.config.LocationStreamBatchSize,
+		)
+	}
+
+	if a.config.MQTTBrokerURL != "" {
+		a.mqttSubscriber, err = mqtthandler.NewLocationSubscriber(a.logger, locationUseCase, mqtthandler.Options{
+			BrokerURL: a.config.MQTTBrokerURL,
+			ClientID:  a.config.MQTTClientID,
+			Username:  a.config.MQTTUsername,
+			Password:  a.config.MQTTPassword,
+			Topic:     a.config.MQTTTopic,
+			QoS:       byte(a.config.MQTTQoS),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	incidentUseCase := cases.NewIncidentUseCase(
 		incidentRepo,
+		postgres.NewApprovalRepo(a.dbPool),
+		postgres.NewLineageRepo(a.dbPool),
+		postgres.NewRelationRepo(a.dbPool),
+		geometryVersionRepo,
+		translationRepo,
+		commentRepo,
+		a.config.WebhookIncludeComments,
+		defaultLocale,
+		postgres.NewImportRepo(a.dbPool),
+		checkRepo,
+		postgres.NewTransactor(a.dbPool),
 		locationUseCase,
+		geocoder,
+		alertDispatcher,
+		userLocations,
+		area,
+		a.config.IncidentReviewRequired,
+		cases.OverlapPolicy{
+			Enabled:   a.config.IncidentOverlapCheck,
+			Threshold: float64(a.config.IncidentOverlapThresholdPercent) / 100,
+		},
+		approvalEvents,
+		a.config.ApprovalEventsStream,
+		webhookOutbox,
 		a.logger,
 	)
+	a.scheduleWorker = worker.NewScheduleWorker(
+		a.logger,
+		incidentUseCase,
+		a.config.ScheduleIntervalSeconds,
+		a.leader("incident-schedules"),
+	)
+	importSources, err := a.importSources()
+	if err != nil {
+		return err
+	}
+	for i := range importSources {
+		importSources[i].Leader = a.leader("import:" + importSources[i].Feed.Key())
+	}
+	if len(importSources) > 0 {
+		a.alertImport = worker.NewImportWorker(
+			a.logger,
+			incidentUseCase,
+			importSources,
+		)
+	}
 	statsUseCase := cases.NewStatsUseCase(
 		incidentRepo,
 		checkRepo,
 		webhookRepo,
+		postgres.NewHeatmapRepo(a.dbPool, a.dbReplica),
+		postgres.NewStatsRepo(a.dbPool, a.dbReplica),
+		postgres.NewSyncCursorRepo(a.dbPool),
+		postgres.NewTransactor(a.dbPool),
+		a.webhookQueue,
+		a.config.HeatmapResolution,
+		time.Duration(a.config.StatsFreshnessSeconds)*time.Second,
 		a.logger,
 	)
+	a.initStatsRollup(statsUseCase)
 
 	httpIncidentHandler := httphandler.NewIncidentHandler(
 		a.logger,
@@ -138,103 +990,642 @@ func (a *App) initUseCasesAndHandlers() error {
 		a.logger,
 		locationUseCase,
 	)
+	var httpAlertHandler *httphandler.AlertHandler
+	if alertBus != nil {
+		httpAlertHandler = httphandler.NewAlertHandler(
+			a.logger,
+			cases.NewAlertUseCase(alertBus),
+		)
+	}
+	httpPreferenceHandler := httphandler.NewPreferenceHandler(
+		a.logger,
+		cases.NewPreferenceUseCase(preferenceRepo, incidentsCache, a.logger),
+	)
 	httpStatsHandler := httphandler.NewStatsHandler(
 		a.logger,
 		statsUseCase,
 		a.config.StatsTimeWindowMinutes,
 	)
+	migrationsVersion, err := migrations.LatestVersion()
+	if err != nil {
+		return err
+	}
+
+	webhookUseCase := cases.NewWebhookUseCase(
+		webhookRepo,
+		webhookEndpointRepo,
+		webhookOutbox,
+		postgres.NewTransactor(a.dbPool),
+		a.webhookSender,
+		a.settings,
+		a.logger,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err = webhookUseCase.BootstrapEndpoint(ctx, a.config.WebhookURL, a.config.WebhookSecret, a.config.WebhookEvents)
+	if err != nil {
+		return err
+	}
+	a.initOpsAlerts(statsUseCase, webhookUseCase)
+
+	httpWebhookHandler := httphandler.NewWebhookHandler(
+		a.logger,
+		webhookUseCase,
+	)
+	httpWebhookEndpointHandler := httphandler.NewWebhookEndpointHandler(
+		a.logger,
+		webhookUseCase,
+	)
+
+	httpGroupHandler := httphandler.NewGroupHandler(
+		a.logger,
+		cases.NewGroupUseCase(groupRepo, webhookEndpointRepo, postgres.NewTransactor(a.dbPool), a.logger),
+	)
+
+	httpApprovalHandler := httphandler.NewApprovalHandler(
+		a.logger,
+		incidentUseCase,
+	)
+
+	// выгрузка и удаление данных пользователя идут мимо пакетной записи и учета запросов: это не новые проверки
+	userCheckRepo := postgres.NewCheckRepo(a.dbPool, a.dbReplica, a.checkKeys)
+	var (
+		exportRepo    repo.DataExportRepo
+		exportStorage storage.ObjectStorage
+	)
+	var httpDataExportHandler *httphandler.DataExportHandler
+	if a.objectStorage != nil {
+		exportRepo = postgres.NewDataExportRepo(a.dbPool)
+		exportStorage = a.objectStorage
+		dataExportUseCase := cases.NewDataExportUseCase(
+			exportRepo,
+			userCheckRepo,
+			webhookRepo,
+			postgres.NewPresenceRepo(a.dbPool),
+			preferenceRepo,
+			groupRepo,
+			exportStorage,
+			postgres.NewTransactor(a.dbPool),
+			a.config.DataExportRetentionHours,
+			time.Duration(a.config.DataExportURLExpiryMinutes)*time.Minute,
+			a.logger,
+		)
+		a.dataExportWorker = worker.NewDataExportWorker(
+			a.logger,
+			dataExportUseCase,
+			a.config.DataExportIntervalSeconds,
+			a.leader("user-data-exports"),
+		)
+		httpDataExportHandler = httphandler.NewDataExportHandler(
+			a.logger,
+			dataExportUseCase,
+		)
+	}
+
+	var searchIndexer search.Indexer
+	if a.config.OpenSearchURL != "" {
+		searchIndexer = a.newSearchIndexer()
+	}
+	erasureUseCase := cases.NewErasureUseCase(
+		postgres.NewErasureRepo(a.dbPool),
+		userCheckRepo,
+		webhookRepo,
+		postgres.NewPresenceRepo(a.dbPool),
+		preferenceRepo,
+		groupRepo,
+		exportRepo,
+		exportStorage,
+		postgres.NewTransactor(a.dbPool),
+		incidentsCache,
+		userLocations,
+		searchIndexer,
+		a.logger,
+	)
+	a.erasureWorker = worker.NewErasureWorker(
+		a.logger,
+		erasureUseCase,
+		a.config.ErasureIntervalSeconds,
+		a.leader("user-erasures"),
+	)
+	httpErasureHandler := httphandler.NewErasureHandler(
+		a.logger,
+		erasureUseCase,
+	)
+	var (
+		tokenIssuer    auth.TokenIssuer
+		tokenVerifiers []auth.TokenVerifier
+	)
+	if a.config.JWTSecret != "" {
+		tokens := authadapter.NewHMAC(a.config.JWTSecret, time.Duration(a.config.JWTTTLMinutes)*time.Minute)
+		tokenIssuer = tokens
+		tokenVerifiers = append(tokenVerifiers, tokens)
+	}
+	if a.config.OIDCIssuer != "" {
+		tokenVerifiers = append(tokenVerifiers, a.newOIDCVerifier())
+	}
+	authUseCase := cases.NewAuthUseCase(
+		postgres.NewOperatorRepo(a.dbPool),
+		tokenIssuer,
+		tokenVerifiers,
+		a.logger,
+	)
+	httpAuthHandler := httphandler.NewAuthHandler(
+		a.logger,
+		authUseCase,
+	)
+	apiKeys := map[string]string{entity.DefaultAPIKey: a.config.APIKey}
+	for name, key := range a.config.APIKeys {
+		apiKeys[name] = key.Key
+	}
+	usageUseCase := a.newUsageUseCase(usageMeter)
+	authMiddleware, err := httphandler.NewAuthMiddleware(
+		a.logger,
+		authUseCase,
+		apiKeys,
+		usageUseCase,
+		a.config.AuthPolicies,
+		a.config.OperatorIPAllowlist,
+		a.config.TrustedProxies,
+	)
+	if err != nil {
+		return err
+	}
+	httpSystemHandler := httphandler.NewSystemHandler(
+		a.logger,
+		statsUseCase,
+		usageUseCase,
+		webhookUseCase,
+		a.config.QueueBackend,
+	)
+	var workerLocks httphandler.WorkerLocks
+	if a.workerLocks != nil {
+		workerLocks = a.workerLocks
+	}
+	httpAdminHandler := httphandler.NewAdminHandler(
+		a.logger,
+		a.settings,
+		statsUseCase,
+		a.queryTracer,
+		workerLocks,
+	)
+	metricsHandler := httphandler.NewMetricsHandler(a.logger, a.queryTracer, workerLocks, webhookUseCase)
+	// процесс без воркеров не должен считаться неготовым из-за остановленного воркера вебхуков
+	var webhookWorkerStatus httphandler.WorkerStatus
+	if a.mode.runsWorkers() {
+		webhookWorkerStatus = a.webhookWorker
+	}
 	httpHealthHandler := httphandler.NewHealthHandler(
 		a.logger,
 		a.dbPool,
 		a.redisClient,
-		statsUseCase,
+		postgres.NewSchemaRepo(a.dbPool),
+		webhookWorkerStatus,
+		migrationsVersion,
 	)
+	httpDocsHandler, err := httphandler.NewDocsHandler(a.logger)
+	if err != nil {
+		return err
+	}
+	httpDashboardHandler, err := httphandler.NewDashboardHandler()
+	if err != nil {
+		return err
+	}
 
-	r := chi.NewRouter()
-
-	r.Use(logger.Log(a.logger))
-	r.Use(middleware.Timeout(30 * time.Second))
+	var httpAttachmentHandler *httphandler.AttachmentHandler
+	if a.objectStorage != nil {
+		maxSize := int64(a.config.AttachmentMaxSizeMB) << 20
+		attachmentUseCase := cases.NewAttachmentUseCase(
+			incidentRepo,
+			postgres.NewAttachmentRepo(a.dbPool),
+			a.objectStorage,
+			maxSize,
+			time.Duration(a.config.AttachmentURLExpiryMinutes)*time.Minute,
+			a.logger,
+		)
+		httpAttachmentHandler = httphandler.NewAttachmentHandler(
+			a.logger,
+			attachmentUseCase,
+			maxSize,
+		)
+	}
 
-	r.Post("/api/v1/location/check", httpLocationHandler.LocationCheck)
-	r.Get("/api/v1/incidents/stats", httpStatsHandler.GetStats)
-	r.Get("/api/v1/system/health", httpHealthHandler.HealthCheck)
+	var httpFeedHandler *httphandler.FeedHandler
+	if a.config.FeedEnabled {
+		httpFeedHandler = httphandler.NewFeedHandler(
+			a.logger,
+			cases.NewFeedUseCase(incidentRepo, translationRepo, locationUseCase, defaultLocale, a.logger),
+			httphandler.FeedOptions{
+				BaseURL: a.config.FeedBaseURL,
+				Title:   a.config.FeedTitle,
+				Sender:  a.config.FeedSender,
+			},
+		)
+	}
 
-	r.Route("/api/v1/incidents", func(r chi.Router) {
-		r.Use(a.apiKeyMiddleware)
+	var httpPublicIncidentHandler *httphandler.PublicIncidentHandler
+	if a.config.PublicAPIEnabled {
+		httpPublicIncidentHandler = httphandler.NewPublicIncidentHandler(
+			a.logger,
+			cases.NewPublicIncidentUseCase(
+				cases.NewFeedUseCase(incidentRepo, translationRepo, locationUseCase, defaultLocale, a.logger),
+				a.newCache(),
+				time.Duration(a.config.PublicAPICacheSeconds)*time.Second,
+				a.logger,
+			),
+		)
+	}
 
-		r.Post("/", httpIncidentHandler.IncidentCreate)
-		r.Get("/", httpIncidentHandler.IncidentList)
-		r.Get("/{incident_id}", httpIncidentHandler.IncidentGet)
-		r.Put("/{incident_id}", httpIncidentHandler.IncidentUpdate)
-		r.Delete("/{incident_id}", httpIncidentHandler.IncidentDelete)
-	})
+	r := chi.NewRouter()
 
-	r.Get("/swagger/*", httpSwagger.WrapHandler)
+	r.Use(logger.Log(a.logger, logger.Sampling{
+		Initial:    a.config.AccessLogSampleInitial,
+		Thereafter: a.config.AccessLogSampleThereafter,
+	}))
+	r.Use(httphandler.Recoverer(a.logger))
+	r.Use(httphandler.MaxBodySize(int64(a.config.HTTPMaxBodyKB) << 10))
+	if len(a.config.CORSAllowedOrigins) > 0 {
+		r.Use(httphandler.CORS(httphandler.CORSOptions{
+			AllowedOrigins:   a.config.CORSAllowedOrigins,
+			AllowedMethods:   a.config.CORSAllowedMethods,
+			AllowedHeaders:   a.config.CORSAllowedHeaders,
+			ExposedHeaders:   a.config.CORSExposedHeaders,
+			AllowCredentials: a.config.CORSAllowCredentials,
+			MaxAgeSeconds:    a.config.CORSMaxAgeSeconds,
+		}))
+	}
+	// доступ ко всем маршрутам определяется политиками, а не группами роутера
+	r.Use(authMiddleware.Handler)
 
-	a.httpServer = &http.Server{
-		Addr:    ":" + a.config.HTTPPort,
-		Handler: r,
+	// SSE-поток долгоживущий, поэтому он вне группы с таймаутом
+	if httpAlertHandler != nil {
+		r.Get("/api/v1/users/{user_id}/alerts/stream", httpAlertHandler.Stream)
 	}
 
-	return nil
-}
+	// сжимаются только ответы, которые бывают большими: списки инцидентов с геометрией зон, лента и статистика
+	compress := func(next http.Handler) http.Handler { return next }
+	if a.config.HTTPCompressionLevel > 0 {
+		compress = middleware.Compress(a.config.HTTPCompressionLevel, "application/json", "application/atom+xml")
+	}
 
-func (a *App) apiKeyMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(30 * time.Second))
 
-		if authHeader == "" {
-			a.respondWithError(w, http.StatusUnauthorized, "authorization header is required")
-			return
+		r.With(httphandler.Deprecated("/api/v2/location/check", locationV1Deprecated, locationV1Sunset)).
+			Post("/api/v1/location/check", httpLocationHandler.LocationCheck)
+		r.Post("/api/v2/location/check", httpLocationHandler.LocationCheckV2)
+		r.Post("/api/v1/location/simulate", httpLocationHandler.LocationSimulate)
+		r.Post("/api/v2/location/check/batch", httpLocationHandler.LocationCheckBatch)
+		r.With(compress).Get("/api/v1/incidents/stats", httpStatsHandler.GetStats)
+		r.With(compress).Get("/api/v1/stats/heatmap", httpStatsHandler.GetHeatmap)
+		r.With(compress).Get("/api/v1/stats/incidents", httpStatsHandler.GetIncidentStats)
+		if httpFeedHandler != nil {
+			r.With(compress).Get("/feeds/incidents", httpFeedHandler.Incidents)
+			r.Get("/feeds/incidents/{incident_id}", httpFeedHandler.Alert)
 		}
-
-		const bearerPrefix = "Bearer "
-		if len(authHeader) <= len(bearerPrefix) || authHeader[:len(bearerPrefix)] != bearerPrefix {
-			a.respondWithError(w, http.StatusUnauthorized, "authorization header must be in 'Bearer {token}' format")
-			return
+		if httpPublicIncidentHandler != nil {
+			limit := httphandler.RateLimit(a.logger, a.newCounter(), "public",
+				a.config.PublicAPIRateLimitPerMinute, authMiddleware.ClientIP)
+			r.With(limit, compress).Get("/api/v1/public/incidents", httpPublicIncidentHandler.PublicIncidentList)
 		}
-
-		apiKey := authHeader[len(bearerPrefix):]
-		if apiKey != a.config.APIKey {
-			a.respondWithError(w, http.StatusUnauthorized, "invalid API key")
-			return
+		r.Get("/api/v1/users/{user_id}/preferences", httpPreferenceHandler.PreferencesGet)
+		r.Put("/api/v1/users/{user_id}/preferences", httpPreferenceHandler.PreferencesPut)
+		r.Delete("/api/v1/users/{user_id}/data", httpErasureHandler.ErasureRequest)
+		if httpDataExportHandler != nil {
+			r.Get("/api/v1/users/{user_id}/data-export", httpDataExportHandler.DataExport)
 		}
+		r.Get("/healthz", httpHealthHandler.Liveness)
+		r.Get("/readyz", httpHealthHandler.Readiness)
+		r.Method(http.MethodGet, "/metrics", metricsHandler)
+		if tokenIssuer != nil {
+			r.Post("/api/v1/auth/login", httpAuthHandler.Login)
+		}
+
+		r.Route("/api/v1/incidents", func(r chi.Router) {
+			r.Post("/", httpIncidentHandler.IncidentCreate)
+			r.With(compress).Get("/", httpIncidentHandler.IncidentList)
+			r.Patch("/batch", httpIncidentHandler.IncidentBatch)
+			r.Post("/merge", httpIncidentHandler.IncidentMerge)
+			r.With(compress).Get("/{incident_id}", httpIncidentHandler.IncidentGet)
+			r.Put("/{incident_id}", httpIncidentHandler.IncidentUpdate)
+			r.Patch("/{incident_id}", httpIncidentHandler.IncidentPatch)
+			r.Put("/{incident_id}/geometry", httpIncidentHandler.IncidentGeometry)
+			r.Post("/{incident_id}/publish", httpIncidentHandler.IncidentPublish)
+			r.Post("/{incident_id}/archive", httpIncidentHandler.IncidentArchive)
+			r.Post("/{incident_id}/state", httpIncidentHandler.IncidentTransition)
+			r.Post("/{incident_id}/split", httpIncidentHandler.IncidentSplit)
+			r.Get("/{incident_id}/lineage", httpIncidentHandler.IncidentLineage)
+			r.Get("/{incident_id}/versions", httpIncidentHandler.IncidentVersions)
+			r.Post("/{incident_id}/backtest", httpIncidentHandler.IncidentBacktest)
+			r.Get("/{incident_id}/translations", httpIncidentHandler.IncidentTranslations)
+			r.Put("/{incident_id}/translations/{locale}", httpIncidentHandler.IncidentTranslationPut)
+			r.Delete("/{incident_id}/translations/{locale}", httpIncidentHandler.IncidentTranslationDelete)
+			r.Get("/{incident_id}/comments", httpIncidentHandler.IncidentComments)
+			r.Post("/{incident_id}/comments", httpIncidentHandler.IncidentCommentCreate)
+			r.Get("/{incident_id}/relations", httpIncidentHandler.IncidentRelations)
+			r.Post("/{incident_id}/relations", httpIncidentHandler.IncidentRelationCreate)
+			r.Delete("/{incident_id}/relations/{type}/{related_id}", httpIncidentHandler.IncidentRelationDelete)
+			r.Delete("/{incident_id}", httpIncidentHandler.IncidentDelete)
+
+			if httpAttachmentHandler != nil {
+				r.Post("/{incident_id}/attachments", httpAttachmentHandler.AttachmentUpload)
+				r.Get("/{incident_id}/attachments", httpAttachmentHandler.AttachmentList)
+				r.Delete("/{incident_id}/attachments/{attachment_id}", httpAttachmentHandler.AttachmentDelete)
+			}
+		})
 
-		next.ServeHTTP(w, r)
+		r.Route("/api/v1/webhooks", func(r chi.Router) {
+			r.Post("/test", httpWebhookHandler.TestWebhook)
+			r.Post("/replay", httpWebhookHandler.ReplayWebhooks)
+			r.Post("/redrive", httpWebhookHandler.RedriveWebhooks)
+		})
+
+		r.Route("/api/v1/webhook-endpoints", func(r chi.Router) {
+			r.Get("/", httpWebhookEndpointHandler.EndpointList)
+			r.Post("/", httpWebhookEndpointHandler.EndpointCreate)
+			r.Get("/{endpoint_id}", httpWebhookEndpointHandler.EndpointGet)
+			r.Get("/{endpoint_id}/slo", httpWebhookEndpointHandler.EndpointSLO)
+			r.Patch("/{endpoint_id}", httpWebhookEndpointHandler.EndpointUpdate)
+			r.Delete("/{endpoint_id}", httpWebhookEndpointHandler.EndpointDelete)
+		})
+
+		r.Route("/api/v1/groups", func(r chi.Router) {
+			r.Get("/", httpGroupHandler.GroupList)
+			r.Post("/", httpGroupHandler.GroupCreate)
+			r.Get("/{group_id}", httpGroupHandler.GroupGet)
+			r.Patch("/{group_id}", httpGroupHandler.GroupUpdate)
+			r.Delete("/{group_id}", httpGroupHandler.GroupDelete)
+			r.Get("/{group_id}/members", httpGroupHandler.GroupMembers)
+			r.Post("/{group_id}/members", httpGroupHandler.GroupMembersAdd)
+			r.Delete("/{group_id}/members/{user_id}", httpGroupHandler.GroupMemberRemove)
+			r.Put("/{group_id}/webhook", httpGroupHandler.GroupWebhookPut)
+			r.Delete("/{group_id}/webhook", httpGroupHandler.GroupWebhookDelete)
+			r.Get("/{group_id}/status", httpGroupHandler.GroupStatus)
+		})
+
+		r.Route("/api/v1/approvals", func(r chi.Router) {
+			r.Get("/", httpApprovalHandler.ApprovalList)
+			r.Post("/{approval_id}/approve", httpApprovalHandler.ApprovalApprove)
+			r.Post("/{approval_id}/reject", httpApprovalHandler.ApprovalReject)
+		})
+
+		r.Route("/api/v1/erasures", func(r chi.Router) {
+			r.Get("/", httpErasureHandler.ErasureList)
+			r.Get("/{erasure_id}", httpErasureHandler.ErasureGet)
+			r.Post("/{erasure_id}/confirm", httpErasureHandler.ErasureConfirm)
+			r.Post("/{erasure_id}/cancel", httpErasureHandler.ErasureCancel)
+		})
+
+		r.Get("/api/v1/system/queues", httpSystemHandler.GetQueues)
+		r.Get("/api/v1/system/webhook-slo", httpSystemHandler.GetWebhookSLO)
+		r.Get("/api/v1/system/usage", httpSystemHandler.GetUsage)
+		r.Get("/api/v1/system/usage/export", httpSystemHandler.ExportUsage)
+
+		r.Route("/api/v1/admin", func(r chi.Router) {
+			r.Get("/dashboard", httpAdminHandler.GetDashboard)
+			r.Get("/config", httpAdminHandler.GetConfig)
+			r.Post("/config/reload", httpAdminHandler.ReloadConfig)
+			r.Get("/query-stats", httpAdminHandler.GetQueryStats)
+			r.Delete("/query-stats", httpAdminHandler.ResetQueryStats)
+			r.Get("/locks", httpAdminHandler.GetWorkerLocks)
+			r.Post("/operators", httpAuthHandler.OperatorCreate)
+		})
+
+		r.Get("/swagger/*", httpSwagger.WrapHandler)
+		r.Get("/openapi.json", httpDocsHandler.OpenAPI)
+		r.Get("/admin", httpDashboardHandler.Index)
+		r.Get("/admin/*", httpDashboardHandler.Static)
 	})
-}
 
-func (a *App) respondWithError(w http.ResponseWriter, code int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
+	var handler http.Handler = r
+	if !a.mode.servesAPI() {
+		handler = probeRouter(a.logger, httpHealthHandler, authMiddleware.Handler(metricsHandler))
+	}
 
-	errorResponse := map[string]interface{}{
-		"error":   http.StatusText(code),
-		"message": message,
+	a.httpServer = &http.Server{
+		Addr:              ":" + a.config.HTTPPort,
+		Handler:           handler,
+		ReadHeaderTimeout: time.Duration(a.config.HTTPReadTimeoutSeconds) * time.Second,
+		ReadTimeout:       time.Duration(a.config.HTTPReadTimeoutSeconds) * time.Second,
+		WriteTimeout:      time.Duration(a.config.HTTPWriteTimeoutSeconds) * time.Second,
+		IdleTimeout:       time.Duration(a.config.HTTPIdleTimeoutSeconds) * time.Second,
+	}
+	if httpAlertHandler != nil {
+		a.httpServer.RegisterOnShutdown(httpAlertHandler.Close)
 	}
 
-	if err := json.NewEncoder(w).Encode(errorResponse); err != nil {
-		a.logger.Error("failed to encode error response", zap.Error(err))
+	if a.tlsEnabled() {
+		tlsConfig, manager, err := a.buildServerTLS()
+		if err != nil {
+			return err
+		}
+		a.httpServer.TLSConfig = tlsConfig
+
+		if manager != nil && a.config.TLSAutocertHTTPPort != "" {
+			a.acmeServer = &http.Server{
+				Addr:              ":" + a.config.TLSAutocertHTTPPort,
+				Handler:           manager.HTTPHandler(nil),
+				ReadHeaderTimeout: time.Duration(a.config.HTTPReadTimeoutSeconds) * time.Second,
+			}
+		}
 	}
+
+	return nil
 }
 
-func (a *App) Run() error {
-	ctx := context.Background()
-	a.webhookWorker.Start(ctx)
+func (a *App) applyReloadedConfig(cfg *config.Config) {
+	if err := a.logLevel.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		a.logger.Error("failed to apply reloaded log level", zap.Error(err))
+	}
+
+	a.logger.Info("Runtime config applied",
+		zap.String("log_level", cfg.LogLevel),
+		zap.Int("cache_ttl_minutes", cfg.CacheTTLMinutes),
+		zap.Int("webhook_max_retries", cfg.MaxRetries),
+		zap.Int("webhook_retry_delay_seconds", cfg.RetryDelaySeconds),
+		zap.Int("prediction_horizon_seconds", cfg.PredictionHorizonSeconds))
+}
+
+// Run запускает воркеры и HTTP-сервер и блокируется, пока не отменен ctx (тогда возвращает nil)
+// или сервер не упал. Воркеры не привязаны к отмене ctx: их останавливает Stop, который нужно
+// вызвать в любом случае, в том числе после ошибки Run
+func (a *App) Run(ctx context.Context) error {
+	runCtx := ctx
+	ctx = context.WithoutCancel(ctx)
+
+	a.stopConfigWatch = a.settings.WatchSignals(func(cfg *config.Config, err error) {
+		if err != nil {
+			a.logger.Error("Config reload on SIGHUP failed", zap.Error(err))
+		}
+	})
+	if a.redisClient != nil {
+		monitorCtx, cancel := context.WithCancel(ctx)
+		a.stopRedisMonitor = cancel
+		go a.redisClient.MonitorHealth(monitorCtx, redisHealthInterval, a.onRedisAvailability)
+	}
+	if a.checkBatcher != nil {
+		a.checkBatcher.Start(ctx)
+	}
+	if a.opsAlerts != nil {
+		a.opsAlerts.Start(ctx)
+	}
+	if a.mode.runsWorkers() {
+		if err := a.startWorkers(ctx); err != nil {
+			return err
+		}
+	}
 
+	serverErr := make(chan error, 2)
 	go func() {
 		a.logger.Info("Starting HTTP server",
+			zap.String("mode", string(a.mode)),
 			zap.String("port", a.config.HTTPPort),
+			zap.Bool("tls", a.tlsEnabled()),
 			zap.String("env", a.config.LogLevel))
 
-		if err := a.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			a.logger.Fatal("HTTP server error", zap.Error(err))
+		var err error
+		if a.tlsEnabled() {
+			// при autocert файлы пустые: сертификат берется из TLSConfig.GetCertificate
+			err = a.httpServer.ListenAndServeTLS(a.config.TLSCertFile, a.config.TLSKeyFile)
+		} else {
+			err = a.httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
 		}
 	}()
 
+	if a.acmeServer != nil {
+		go func() {
+			a.logger.Info("Starting ACME challenge server", zap.String("port", a.config.TLSAutocertHTTPPort))
+
+			if err := a.acmeServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErr <- fmt.Errorf("acme challenge server: %w", err)
+			}
+		}()
+	}
+
+	select {
+	case <-runCtx.Done():
+		return nil
+	case err := <-serverErr:
+		return fmt.Errorf("http server: %w", err)
+	}
+}
+
+func (a *App) startWorkers(ctx context.Context) error {
+	a.webhookWorker.Start(ctx)
+	a.partitionWorker.Start(ctx)
+	a.scheduleWorker.Start(ctx)
+	if a.alertImport != nil {
+		a.alertImport.Start(ctx)
+	}
+	if a.eventRelay != nil {
+		a.eventRelay.Start(ctx)
+	}
+	if a.usageFlush != nil {
+		a.usageFlush.Start(ctx)
+	}
+	if a.searchIndex != nil {
+		a.searchIndex.Start(ctx)
+	}
+	if a.heatmapWorker != nil {
+		a.heatmapWorker.Start(ctx)
+	}
+	a.statsRollup.Start(ctx)
+	a.erasureWorker.Start(ctx)
+	if a.reencryptWorker != nil {
+		a.reencryptWorker.Start(ctx)
+	}
+	if a.dataExportWorker != nil {
+		a.dataExportWorker.Start(ctx)
+	}
+	if a.locationStream != nil {
+		if err := a.locationStream.Start(ctx); err != nil {
+			return err
+		}
+	}
+	if a.mqttSubscriber != nil {
+		if err := a.mqttSubscriber.Start(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (a *App) onRedisAvailability(available bool) {
+	if !available {
+		a.logger.Warn("Redis is unavailable, switching to degraded mode: incidents are read from DB, webhooks are delivered by outbox polling")
+		return
+	}
+
+	a.logger.Info("Redis is available again, leaving degraded mode")
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisHealthInterval)
+	defer cancel()
+	if err := a.invalidateIncidentsCache(ctx); err != nil {
+		a.logger.Error("Failed to reset incidents cache after Redis recovery", zap.Error(err))
+	}
+}
+
+func (a *App) stopWorkers() {
+	if a.webhookWorker != nil {
+		a.webhookWorker.Stop()
+	}
+
+	if a.partitionWorker != nil {
+		a.partitionWorker.Stop()
+	}
+
+	if a.scheduleWorker != nil {
+		a.scheduleWorker.Stop()
+	}
+
+	if a.alertImport != nil {
+		a.alertImport.Stop()
+	}
+
+	if a.locationStream != nil {
+		a.locationStream.Stop()
+	}
+
+	if a.mqttSubscriber != nil {
+		a.mqttSubscriber.Stop()
+	}
+
+	if a.eventRelay != nil {
+		a.eventRelay.Stop()
+	}
+
+	if a.usageFlush != nil {
+		a.usageFlush.Stop()
+	}
+
+	if a.searchIndex != nil {
+		a.searchIndex.Stop()
+	}
+
+	if a.heatmapWorker != nil {
+		a.heatmapWorker.Stop()
+	}
+
+	if a.statsRollup != nil {
+		a.statsRollup.Stop()
+	}
+
+	if a.erasureWorker != nil {
+		a.erasureWorker.Stop()
+	}
+
+	if a.reencryptWorker != nil {
+		a.reencryptWorker.Stop()
+	}
+
+	if a.dataExportWorker != nil {
+		a.dataExportWorker.Stop()
+	}
+}
+
 func (a *App) Stop() {
 	a.logger.Info("Shutting down servers...")
 
@@ -245,8 +1636,46 @@ func (a *App) Stop() {
 		a.logger.Error("HTTP server shutdown error", zap.Error(err))
 	}
 
-	if a.webhookWorker != nil {
-		a.webhookWorker.Stop()
+	if a.acmeServer != nil {
+		if err := a.acmeServer.Shutdown(ctx); err != nil {
+			a.logger.Error("ACME challenge server shutdown error", zap.Error(err))
+		}
+	}
+
+	if a.stopConfigWatch != nil {
+		a.stopConfigWatch()
+	}
+
+	if a.stopRedisMonitor != nil {
+		a.stopRedisMonitor()
+	}
+
+	if a.opsAlerts != nil {
+		a.opsAlerts.Stop()
+	}
+
+	if a.mode.runsWorkers() {
+		a.stopWorkers()
+	}
+
+	// после остановки всех источников координат, чтобы записать последние проверки
+	if a.checkBatcher != nil {
+		a.checkBatcher.Stop()
+	}
+
+	// после остановки воркеров, чтобы блокировки сразу подхватили другие реплики
+	if a.workerLocks != nil {
+		a.workerLocks.ReleaseAll(ctx)
+	}
+
+	if a.amqpQueue != nil {
+		if err := a.amqpQueue.Close(); err != nil {
+			a.logger.Error("AMQP connection close error", zap.Error(err))
+		}
+	}
+
+	if a.dbReplica != nil {
+		a.dbReplica.Close()
 	}
 
 	if a.dbPool != nil {
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+
+	httphandler "github.com/4otis/geonotify-service/internal/handler/http"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// Mode — какие части сервиса запускает процесс. API и воркеры используют одну конфигурацию и БД,
+// поэтому их можно развернуть и масштабировать раздельно
+type Mode string
+
+const (
+	// ModeAll — HTTP API и фоновые воркеры в одном процессе
+	ModeAll Mode = "all"
+	// ModeAPI — только HTTP API: вебхуки ставятся в outbox и очередь, но доставляют их процессы с ModeWorker
+	ModeAPI Mode = "api"
+	// ModeWorker — доставка вебхуков, периодические задачи и прием координат из потока и MQTT;
+	// HTTP-сервер отдает только /healthz, /readyz и /metrics
+	ModeWorker Mode = "worker"
+)
+
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case ModeAll, ModeAPI, ModeWorker:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown mode %q (expected api, worker or all)", s)
+	}
+}
+
+func (m Mode) servesAPI() bool {
+	return m != ModeWorker
+}
+
+func (m Mode) runsWorkers() bool {
+	return m != ModeAPI
+}
+
+// probeRouter — HTTP-сервер процесса без API: пробы для оркестратора и метрики; metrics уже закрыт
+// политикой доступа
+func probeRouter(logger *zap.Logger, health *httphandler.HealthHandler, metrics http.Handler) http.Handler {
+	r := chi.NewRouter()
+	r.Use(httphandler.Recoverer(logger))
+	r.Get("/healthz", health.Liveness)
+	r.Get("/readyz", health.Readiness)
+	r.Method(http.MethodGet, "/metrics", metrics)
+	return r
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+var serverTLSVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// serverCipherSuites — наборы для TLS 1.2: только ECDHE с AEAD. Для TLS 1.3 наборы не настраиваются.
+// TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 обязателен для HTTP/2 (RFC 7540, 9.2.2)
+var serverCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+}
+
+func (a *App) tlsEnabled() bool {
+	return a.config.TLSCertFile != "" || len(a.config.TLSAutocertDomains) > 0
+}
+
+// buildServerTLS собирает TLS-конфиг HTTP-сервера. Сертификат из файлов подгружает сам
+// ListenAndServeTLS; при autocert сертификаты выдает менеджер, который возвращается вторым значением
+func (a *App) buildServerTLS() (*tls.Config, *autocert.Manager, error) {
+	version, ok := serverTLSVersions[a.config.TLSMinVersion]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported TLS min version %q", a.config.TLSMinVersion)
+	}
+
+	cfg := &tls.Config{
+		MinVersion:   version,
+		CipherSuites: serverCipherSuites,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	if len(a.config.TLSAutocertDomains) == 0 {
+		return cfg, nil, nil
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(a.config.TLSAutocertDomains...),
+		Cache:      autocert.DirCache(a.config.TLSAutocertCacheDir),
+		Email:      a.config.TLSAutocertEmail,
+	}
+	cfg.GetCertificate = manager.GetCertificate
+	// проверка tls-alpn-01 проходит на том же порту, что и API
+	cfg.NextProtos = append(cfg.NextProtos, acme.ALPNProto)
+
+	return cfg, manager, nil
+}
@@ -0,0 +1,67 @@
+package cases
+
+import (
+	"context"
+	"slices"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type actorKey struct{}
+
+// WithActor сохраняет в контексте исполнителя запроса, прошедшего аутентификацию
+func WithActor(ctx context.Context, actor entity.Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext возвращает исполнителя запроса; ok=false для фоновых задач и публичных ручек
+func ActorFromContext(ctx context.Context) (entity.Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(entity.Actor)
+	return actor, ok
+}
+
+// actorName — имя исполнителя для created_by/updated_by, пустое без аутентификации
+func actorName(ctx context.Context) string {
+	actor, _ := ActorFromContext(ctx)
+	return actor.Name
+}
+
+// canPublish сообщает, может ли исполнитель публиковать и менять действующие зоны.
+// Без аутентификации (маршрут открыт политикой public) роли не проверяются
+func canPublish(ctx context.Context) bool {
+	actor, ok := ActorFromContext(ctx)
+	return !ok || actor.CanPublish()
+}
+
+func requirePublisher(ctx context.Context) error {
+	if !canPublish(ctx) {
+		return entity.ErrForbidden
+	}
+	return nil
+}
+
+// VisibleIncidents возвращает видимости зон, которые исполнитель видит в списках и карточках зон, nil — все зоны:
+// публикатор видит все, редактор — кроме internal, а без аутентификации (маршрут открыт политикой public) — только публичные
+func VisibleIncidents(ctx context.Context) []string {
+	actor, ok := ActorFromContext(ctx)
+	switch {
+	case !ok:
+		return []string{entity.VisibilityPublic}
+	case actor.CanPublish():
+		return nil
+	default:
+		return []string{entity.VisibilityPublic, entity.VisibilityRestricted}
+	}
+}
+
+// canSee сообщает, видит ли исполнитель зону с видимостью visibility
+func canSee(ctx context.Context, visibility string) bool {
+	visible := VisibleIncidents(ctx)
+	if visible == nil {
+		return true
+	}
+	if visibility == "" {
+		visibility = entity.VisibilityPublic
+	}
+	return slices.Contains(visible, visibility)
+}
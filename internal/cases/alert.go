@@ -0,0 +1,210 @@
+package cases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/alerts"
+	"github.com/4otis/geonotify-service/internal/port/cache"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"go.uber.org/zap"
+)
+
+var _ AlertUseCase = (*AlertUseCaseImpl)(nil)
+
+type AlertUseCase interface {
+	Subscribe(ctx context.Context, userID string) (<-chan entity.Alert, func(), error)
+}
+
+type AlertUseCaseImpl struct {
+	bus alerts.Bus
+}
+
+func NewAlertUseCase(bus alerts.Bus) *AlertUseCaseImpl {
+	return &AlertUseCaseImpl{bus: bus}
+}
+
+func (uc *AlertUseCaseImpl) Subscribe(ctx context.Context, userID string) (<-chan entity.Alert, func(), error) {
+	return uc.bus.Subscribe(ctx, userID)
+}
+
+// AlertDispatcher доставляет алерты пользователям с учетом их настроек (entity.UserPreferences):
+// отбрасывает зоны ниже порога опасности, в окна тишины пропускает только критические зоны,
+// ограничивает число алертов в час и отправляет алерт в выбранные каналы
+type AlertDispatcher struct {
+	bus alerts.Bus
+	// counter nil — число алертов не ограничивается
+	counter     alerts.AlertCounter
+	preferences repo.PreferenceRepo
+	cache       cache.Cache
+	webhooks    *WebhookOutbox
+	tx          repo.Transactor
+	logger      *zap.Logger
+}
+
+func NewAlertDispatcher(
+	bus alerts.Bus,
+	counter alerts.AlertCounter,
+	preferences repo.PreferenceRepo,
+	cache cache.Cache,
+	webhooks *WebhookOutbox,
+	tx repo.Transactor,
+	logger *zap.Logger,
+) *AlertDispatcher {
+	return &AlertDispatcher{
+		bus:         bus,
+		counter:     counter,
+		preferences: preferences,
+		cache:       cache,
+		webhooks:    webhooks,
+		tx:          tx,
+		logger:      logger,
+	}
+}
+
+// Dispatch — best effort: ошибки доставки только логируются. Если настройки не прочитались,
+// алерт уходит по настройкам по умолчанию: лишний алерт лучше потерянного
+func (d *AlertDispatcher) Dispatch(ctx context.Context, alert entity.Alert) {
+	prefs, err := d.userPreferences(ctx, alert.UserID)
+	if err != nil {
+		d.logger.Warn("failed to read user preferences, using defaults",
+			zap.Error(err),
+			zap.String("user_id", alert.UserID))
+		prefs = entity.DefaultUserPreferences(alert.UserID)
+	}
+
+	incidents := make([]*entity.Incident, 0, len(alert.Incidents))
+	for _, inc := range alert.Incidents {
+		if severityRank[inc.Severity] >= severityRank[prefs.MinSeverity] {
+			// пользователь узнает о непубличной зоне, но не ее название и описание
+			incidents = append(incidents, inc.Redacted())
+		}
+	}
+	// критическая опасность важнее тишины: в окно тишины алерт уходит только с критическими зонами
+	if len(incidents) > 0 && quietAt(prefs, alert.CreatedAt) {
+		incidents = criticalIncidents(incidents)
+		if len(incidents) == 0 {
+			d.logger.Debug("alert suppressed by quiet hours",
+				zap.String("user_id", alert.UserID),
+				zap.String("type", alert.Type))
+		}
+	}
+	if len(incidents) == 0 {
+		return
+	}
+	alert.Incidents = incidents
+
+	if d.throttled(ctx, prefs, alert) {
+		d.logger.Debug("alert throttled",
+			zap.String("user_id", alert.UserID),
+			zap.String("type", alert.Type),
+			zap.Int("max_alerts_per_hour", prefs.MaxAlertsPerHour))
+		return
+	}
+
+	for _, channel := range prefs.Channels {
+		switch channel {
+		case entity.AlertChannelStream:
+			if err := d.bus.Publish(ctx, alert); err != nil {
+				d.logger.Warn("failed to publish user alert",
+					zap.Error(err),
+					zap.String("user_id", alert.UserID),
+					zap.String("type", alert.Type))
+			}
+		case entity.AlertChannelWebhook:
+			if err := d.enqueueWebhook(ctx, alert); err != nil {
+				d.logger.Warn("failed to enqueue user alert webhook",
+					zap.Error(err),
+					zap.String("user_id", alert.UserID),
+					zap.String("type", alert.Type))
+			}
+		}
+	}
+}
+
+// throttled учитывает алерт в счетчике пользователя и сообщает, превышен ли его лимит в час.
+// Алерт с критической зоной учитывается, но не отбрасывается; при недоступном счетчике алерт отправляется
+func (d *AlertDispatcher) throttled(ctx context.Context, prefs entity.UserPreferences, alert entity.Alert) bool {
+	if prefs.MaxAlertsPerHour <= 0 || d.counter == nil {
+		return false
+	}
+
+	count, err := d.counter.Increment(ctx, alert.UserID, time.Hour)
+	if errors.Is(err, entity.ErrDependencyUnavailable) {
+		return false
+	}
+	if err != nil {
+		d.logger.Warn("failed to count user alerts",
+			zap.Error(err),
+			zap.String("user_id", alert.UserID))
+		return false
+	}
+
+	return count > int64(prefs.MaxAlertsPerHour) && len(criticalIncidents(alert.Incidents)) == 0
+}
+
+func criticalIncidents(incidents []*entity.Incident) []*entity.Incident {
+	critical := make([]*entity.Incident, 0, len(incidents))
+	for _, inc := range incidents {
+		if inc.Severity == entity.SeverityCritical {
+			critical = append(critical, inc)
+		}
+	}
+	return critical
+}
+
+func (d *AlertDispatcher) enqueueWebhook(ctx context.Context, alert entity.Alert) error {
+	subscribed, err := d.webhooks.Subscribed(ctx, entity.WebhookUserAlert)
+	if err != nil || !subscribed {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"type":      alert.Type,
+		"user_id":   alert.UserID,
+		"incidents": alert.Incidents,
+		"timestamp": alert.CreatedAt.Format(time.RFC3339),
+	}
+	if alert.CheckID != 0 {
+		data["check_id"] = alert.CheckID
+	}
+
+	var webhookIDs []int
+	err = d.tx.WithinTx(ctx, func(ctx context.Context) error {
+		webhookIDs, err = d.webhooks.Enqueue(ctx, entity.WebhookUserAlert, alert.CheckID, data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	d.webhooks.Notify(ctx, alert.CheckID, webhookIDs)
+
+	return nil
+}
+
+// userPreferences читает настройки через кэш: алерт рассылается на каждую проверку, попавшую в зону
+func (d *AlertDispatcher) userPreferences(ctx context.Context, userID string) (entity.UserPreferences, error) {
+	key := preferencesCacheKey(userID)
+
+	var prefs entity.UserPreferences
+	if err := d.cache.Get(ctx, key, &prefs); err == nil {
+		return prefs, nil
+	}
+
+	stored, err := d.preferences.Read(ctx, userID)
+	if errors.Is(err, entity.ErrPreferencesNotFound) {
+		prefs = entity.DefaultUserPreferences(userID)
+	} else if err != nil {
+		return entity.UserPreferences{}, err
+	} else {
+		prefs = *stored
+	}
+
+	if err := d.cache.Set(ctx, key, prefs, preferencesCacheTTL); err != nil {
+		d.logger.Debug("failed to cache user preferences", zap.Error(err))
+	}
+
+	return prefs, nil
+}
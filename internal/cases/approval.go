@@ -0,0 +1,241 @@
+package cases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/zap"
+)
+
+var _ ApprovalUseCase = (*IncidentUseCaseImpl)(nil)
+
+// ApprovalUseCase — правило двух операторов для критических зон: заявку подает публикатор
+// через PublishIncident или повышая опубликованную зону до критической, а решает другой публикатор
+type ApprovalUseCase interface {
+	ListApprovals(ctx context.Context, status string, limit int) ([]*entity.Approval, error)
+	Approve(ctx context.Context, approvalID int) (*entity.Approval, error)
+	Reject(ctx context.Context, approvalID int, reason string) (*entity.Approval, error)
+}
+
+// Типы событий заявок в потоке approvalStream
+const (
+	approvalRequestedEvent = "approval.requested"
+	approvalApprovedEvent  = "approval.approved"
+	approvalRejectedEvent  = "approval.rejected"
+)
+
+// ListApprovals возвращает заявки от новых к старым; пустой status — заявки во всех статусах
+func (uc *IncidentUseCaseImpl) ListApprovals(ctx context.Context, status string, limit int) ([]*entity.Approval, error) {
+	return uc.approvals.ReadByStatus(ctx, status, limit)
+}
+
+// Approve публикует критическую зону или повышает опубликованную зону до критической по заявке. Одобрить может только публикатор,
+// который не подавал заявку: у API-ключа одно имя, поэтому он не одобряет собственные заявки
+func (uc *IncidentUseCaseImpl) Approve(ctx context.Context, approvalID int) (*entity.Approval, error) {
+	if err := requirePublisher(ctx); err != nil {
+		return nil, err
+	}
+
+	var (
+		approval   *entity.Approval
+		incident   *entity.Incident
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		approval, err = uc.decide(ctx, approvalID, entity.ApprovalApproved, "")
+		if err != nil {
+			return err
+		}
+
+		current, err := uc.repo.Read(ctx, approval.IncidentID)
+		if err != nil {
+			return err
+		}
+		if approval.Severity != "" {
+			incident, err = uc.setSeverity(ctx, approval.IncidentID, approval.Severity, approval.DecidedBy)
+		} else {
+			incident, err = uc.setPublished(ctx, approval.IncidentID, approval.DecidedBy)
+		}
+		if err != nil {
+			return err
+		}
+
+		webhookIDs, err = uc.incidentWebhook(ctx, approval.IncidentID, current)
+		if err != nil {
+			return err
+		}
+
+		return uc.createApprovalEvent(ctx, approvalApprovedEvent, approval, incident)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	uc.logger.Info("critical incident approved",
+		zap.Int("approval_id", approval.ID),
+		zap.Int("incident_id", approval.IncidentID),
+		zap.String("requested_by", approval.RequestedBy),
+		zap.String("approved_by", approval.DecidedBy),
+		zap.String("severity", approval.Severity))
+
+	if approval.Severity != "" {
+		if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+			uc.logger.Warn("failed to invalidate cache after escalating incident",
+				zap.Error(err))
+		}
+		return approval, nil
+	}
+
+	uc.afterPublish(ctx, incident)
+	return approval, nil
+}
+
+// Reject закрывает заявку, зона остается в прежнем статусе. Автор заявки может отозвать ее сам
+func (uc *IncidentUseCaseImpl) Reject(ctx context.Context, approvalID int, reason string) (*entity.Approval, error) {
+	if err := requirePublisher(ctx); err != nil {
+		return nil, err
+	}
+
+	var approval *entity.Approval
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		approval, err = uc.decide(ctx, approvalID, entity.ApprovalRejected, reason)
+		if err != nil {
+			return err
+		}
+
+		incident, err := uc.repo.Read(ctx, approval.IncidentID)
+		if err != nil && !errors.Is(err, entity.ErrIncidentNotFound) {
+			return err
+		}
+
+		return uc.createApprovalEvent(ctx, approvalRejectedEvent, approval, incident)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("critical incident rejected",
+		zap.Int("approval_id", approval.ID),
+		zap.Int("incident_id", approval.IncidentID),
+		zap.String("rejected_by", approval.DecidedBy))
+
+	return approval, nil
+}
+
+// requestApproval заводит заявку и оповещает одобряющих; вызывается внутри транзакции.
+// Пустая severity — заявка на публикацию, иначе — на повышение критичности опубликованной зоны
+func (uc *IncidentUseCaseImpl) requestApproval(ctx context.Context, incident *entity.Incident, severity string) (*entity.Approval, error) {
+	approvalID, err := uc.approvals.Create(ctx, entity.Approval{
+		IncidentID:  incident.ID,
+		Severity:    severity,
+		RequestedBy: actorName(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	approval, err := uc.approvals.ReadForUpdate(ctx, approvalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.createApprovalEvent(ctx, approvalRequestedEvent, approval, incident); err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// requestEscalation заводит заявку на повышение опубликованной зоны до критической
+// после того, как остальная правка уже записана; вызывается внутри транзакции
+func (uc *IncidentUseCaseImpl) requestEscalation(ctx context.Context, incID int) (*entity.Approval, error) {
+	incident, err := uc.repo.Read(ctx, incID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.requestApproval(ctx, incident, entity.SeverityCritical)
+}
+
+// setSeverity меняет критичность зоны по одобренной заявке и возвращает ее новое состояние
+func (uc *IncidentUseCaseImpl) setSeverity(ctx context.Context, incID int, severity, updatedBy string) (*entity.Incident, error) {
+	patch := entity.IncidentPatch{Severity: &severity, UpdatedBy: updatedBy}
+	if err := uc.repo.UpdatePartial(ctx, incID, patch); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.Read(ctx, incID)
+}
+
+// decide закрывает заявку решением текущего исполнителя; вызывается внутри транзакции
+func (uc *IncidentUseCaseImpl) decide(ctx context.Context, approvalID int, status, reason string) (*entity.Approval, error) {
+	approval, err := uc.approvals.ReadForUpdate(ctx, approvalID)
+	if err != nil {
+		return nil, err
+	}
+	if approval.Status != entity.ApprovalPending {
+		return nil, entity.ErrApprovalDecided
+	}
+
+	name := actorName(ctx)
+	if name == "" || (status == entity.ApprovalApproved && name == approval.RequestedBy) {
+		return nil, entity.ErrSelfApproval
+	}
+
+	if err := uc.approvals.Decide(ctx, approvalID, status, name, reason); err != nil {
+		return nil, err
+	}
+
+	return uc.approvals.ReadForUpdate(ctx, approvalID)
+}
+
+// createApprovalEvent кладет событие заявки в outbox, его публикует EventRelayWorker.
+// incident может быть nil, если зона уже удалена
+func (uc *IncidentUseCaseImpl) createApprovalEvent(ctx context.Context, eventType string, approval *entity.Approval, incident *entity.Incident) error {
+	if uc.events == nil {
+		return nil
+	}
+
+	payload := map[string]interface{}{
+		"approval_id":  approval.ID,
+		"incident_id":  approval.IncidentID,
+		"status":       approval.Status,
+		"requested_by": approval.RequestedBy,
+		"requested_at": approval.RequestedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if approval.DecidedBy != "" {
+		payload["decided_by"] = approval.DecidedBy
+	}
+	if approval.Reason != "" {
+		payload["reason"] = approval.Reason
+	}
+	if approval.Severity != "" {
+		payload["requested_severity"] = approval.Severity
+	}
+	if incident != nil {
+		payload["incident_name"] = incident.Name
+		payload["severity"] = incident.Severity
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal approval event payload: %w", err)
+	}
+
+	_, err = uc.events.Create(ctx, entity.Event{
+		Topic:   uc.approvalStream,
+		Type:    eventType,
+		Key:     strconv.Itoa(approval.IncidentID),
+		Payload: payloadBytes,
+	})
+
+	return err
+}
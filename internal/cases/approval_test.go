@@ -0,0 +1,83 @@
+package cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApprove(t *testing.T) {
+	draft := &entity.Incident{ID: 9, Status: entity.IncidentDraft, Severity: entity.SeverityCritical}
+	published := &entity.Incident{ID: 9, Status: entity.IncidentPublished, Severity: entity.SeverityMedium}
+
+	tests := []struct {
+		name     string
+		actor    entity.Actor
+		severity string
+		setup    func(d incidentDeps)
+		wantErr  error
+	}{
+		{
+			name:  "publication",
+			actor: publisher,
+			setup: func(d incidentDeps) {
+				gomock.InOrder(
+					d.incidents.EXPECT().Read(gomock.Any(), 9).Return(draft, nil),
+					d.incidents.EXPECT().SetStatus(gomock.Any(), 9, gomock.Any(), entity.IncidentPublished, publisher.Name).Return(nil),
+					d.incidents.EXPECT().Read(gomock.Any(), 9).Return(&entity.Incident{ID: 9, Status: entity.IncidentPublished}, nil),
+				)
+				d.location.EXPECT().InvalidateIncidentsCache(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "escalation",
+			actor:    publisher,
+			severity: entity.SeverityCritical,
+			setup: func(d incidentDeps) {
+				gomock.InOrder(
+					d.incidents.EXPECT().Read(gomock.Any(), 9).Return(published, nil),
+					d.incidents.EXPECT().UpdatePartial(gomock.Any(), 9, entity.IncidentPatch{
+						Severity:  ptr(entity.SeverityCritical),
+						UpdatedBy: publisher.Name,
+					}).Return(nil),
+					d.incidents.EXPECT().Read(gomock.Any(), 9).Return(&entity.Incident{ID: 9, Status: entity.IncidentPublished, Severity: entity.SeverityCritical}, nil),
+				)
+				d.location.EXPECT().InvalidateIncidentsCache(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "requester cannot approve",
+			actor:   entity.Actor{Name: "carol", Role: entity.RolePublisher},
+			setup:   func(d incidentDeps) {},
+			wantErr: entity.ErrSelfApproval,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, d := newIncidentUseCase(t)
+			pending := &entity.Approval{ID: 3, IncidentID: 9, Status: entity.ApprovalPending, Severity: tt.severity, RequestedBy: "carol"}
+			d.approvals.EXPECT().ReadForUpdate(gomock.Any(), 3).Return(pending, nil)
+			if tt.wantErr == nil {
+				decided := *pending
+				decided.Status = entity.ApprovalApproved
+				decided.DecidedBy = tt.actor.Name
+				d.approvals.EXPECT().Decide(gomock.Any(), 3, entity.ApprovalApproved, tt.actor.Name, "").Return(nil)
+				d.approvals.EXPECT().ReadForUpdate(gomock.Any(), 3).Return(&decided, nil)
+			}
+			tt.setup(d)
+
+			approval, err := uc.Approve(cases.WithActor(context.Background(), tt.actor), 3)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Approve() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && approval.Status != entity.ApprovalApproved {
+				t.Errorf("Approve() status = %q, want %q", approval.Status, entity.ApprovalApproved)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package cases
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/internal/port/storage"
+	"go.uber.org/zap"
+)
+
+var _ AttachmentUseCase = (*AttachmentUseCaseImpl)(nil)
+
+type AttachmentUseCase interface {
+	UploadAttachment(ctx context.Context, incidentID int, fileName string, body io.Reader, size int64) (*AttachmentWithURL, error)
+	ListAttachments(ctx context.Context, incidentID int) ([]AttachmentWithURL, error)
+	DeleteAttachment(ctx context.Context, incidentID, attachmentID int) error
+}
+
+type AttachmentUseCaseImpl struct {
+	incidentRepo   repo.IncidentRepo
+	attachmentRepo repo.AttachmentRepo
+	storage        storage.ObjectStorage
+	maxSize        int64
+	urlExpiry      time.Duration
+	logger         *zap.Logger
+}
+
+func NewAttachmentUseCase(
+	incidentRepo repo.IncidentRepo,
+	attachmentRepo repo.AttachmentRepo,
+	storage storage.ObjectStorage,
+	maxSizeBytes int64,
+	urlExpiry time.Duration,
+	logger *zap.Logger,
+) *AttachmentUseCaseImpl {
+	return &AttachmentUseCaseImpl{
+		incidentRepo:   incidentRepo,
+		attachmentRepo: attachmentRepo,
+		storage:        storage,
+		maxSize:        maxSizeBytes,
+		urlExpiry:      urlExpiry,
+		logger:         logger,
+	}
+}
+
+type AttachmentWithURL struct {
+	Attachment *entity.Attachment
+	URL        string
+}
+
+// расширение объекта выбирается по реальному содержимому, а не по имени файла
+var attachmentExtensions = map[string]string{
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+func (uc *AttachmentUseCaseImpl) UploadAttachment(ctx context.Context, incidentID int, fileName string, body io.Reader, size int64) (*AttachmentWithURL, error) {
+	if size > uc.maxSize {
+		return nil, entity.ErrAttachmentTooLarge
+	}
+
+	if _, err := uc.incidentRepo.Read(ctx, incidentID); err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(body, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	ext, ok := attachmentExtensions[contentType]
+	if !ok {
+		return nil, entity.ErrUnsupportedAttachmentType
+	}
+
+	attachment := entity.Attachment{
+		IncidentID:  incidentID,
+		ObjectKey:   fmt.Sprintf("incidents/%d/%s%s", incidentID, newObjectID(), ext),
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+	}
+
+	err = uc.storage.Put(ctx, attachment.ObjectKey, io.MultiReader(bytes.NewReader(head), body), size, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment.ID, err = uc.attachmentRepo.Create(ctx, attachment)
+	if err != nil {
+		// метаданные не сохранились — объект в хранилище никто не найдет, удаляем его
+		if delErr := uc.storage.Delete(ctx, attachment.ObjectKey); delErr != nil {
+			uc.logger.Warn("failed to remove orphaned attachment object",
+				zap.String("object_key", attachment.ObjectKey),
+				zap.Error(delErr))
+		}
+		return nil, err
+	}
+	attachment.CreatedAt = time.Now()
+
+	uc.logger.Info("attachment uploaded",
+		zap.Int("incident_id", incidentID),
+		zap.Int("attachment_id", attachment.ID),
+		zap.String("content_type", contentType),
+		zap.Int64("size", size))
+
+	return uc.withURL(ctx, &attachment)
+}
+
+func (uc *AttachmentUseCaseImpl) ListAttachments(ctx context.Context, incidentID int) ([]AttachmentWithURL, error) {
+	if _, err := uc.incidentRepo.Read(ctx, incidentID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := uc.attachmentRepo.ReadByIncident(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]AttachmentWithURL, 0, len(attachments))
+	for _, a := range attachments {
+		item, err := uc.withURL(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *item)
+	}
+
+	return result, nil
+}
+
+func (uc *AttachmentUseCaseImpl) DeleteAttachment(ctx context.Context, incidentID, attachmentID int) error {
+	attachment, err := uc.attachmentRepo.Read(ctx, incidentID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.attachmentRepo.Delete(ctx, incidentID, attachmentID); err != nil {
+		return err
+	}
+
+	if err := uc.storage.Delete(ctx, attachment.ObjectKey); err != nil {
+		uc.logger.Warn("failed to delete attachment object",
+			zap.String("object_key", attachment.ObjectKey),
+			zap.Error(err))
+	}
+
+	return nil
+}
+
+func (uc *AttachmentUseCaseImpl) withURL(ctx context.Context, attachment *entity.Attachment) (*AttachmentWithURL, error) {
+	url, err := uc.storage.PresignedURL(ctx, attachment.ObjectKey, uc.urlExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AttachmentWithURL{
+		Attachment: attachment,
+		URL:        url,
+	}, nil
+}
+
+func newObjectID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,171 @@
+package cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/auth"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ AuthUseCase = (*AuthUseCaseImpl)(nil)
+
+type AuthUseCase interface {
+	Login(ctx context.Context, username, password string) (AccessToken, error)
+	Authenticate(ctx context.Context, token string) (entity.Actor, error)
+	CreateOperator(ctx context.Context, username, password, oidcSubject, role string) (operatorID int, err error)
+}
+
+type AuthUseCaseImpl struct {
+	operators repo.OperatorRepo
+	issuer    auth.TokenIssuer
+	verifiers []auth.TokenVerifier
+	logger    *zap.Logger
+}
+
+// NewAuthUseCase: issuer nil — вход по паролю отключен; verifiers проверяются по порядку
+func NewAuthUseCase(operators repo.OperatorRepo, issuer auth.TokenIssuer,
+	verifiers []auth.TokenVerifier, logger *zap.Logger) *AuthUseCaseImpl {
+	return &AuthUseCaseImpl{
+		operators: operators,
+		issuer:    issuer,
+		verifiers: verifiers,
+		logger:    logger,
+	}
+}
+
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// bcrypt учитывает не больше 72 байт пароля
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
+// dummyHash сравнивается с паролем несуществующего оператора, чтобы время ответа
+// не выдавало, есть ли такой username
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("geonotify-dummy-password"), bcrypt.DefaultCost)
+
+func (uc *AuthUseCaseImpl) Login(ctx context.Context, username, password string) (AccessToken, error) {
+	operator, err := uc.operators.ReadByUsername(ctx, username)
+	if errors.Is(err, entity.ErrOperatorNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return AccessToken{}, entity.ErrInvalidCredentials
+	}
+	if err != nil {
+		return AccessToken{}, err
+	}
+
+	// оператор только с OIDC входит через провайдера, пароля у него нет
+	if operator.PasswordHash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return AccessToken{}, entity.ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(operator.PasswordHash), []byte(password)); err != nil {
+		return AccessToken{}, entity.ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := uc.issuer.Issue(entity.Actor{
+		Name:       operator.Username,
+		OperatorID: operator.ID,
+		Role:       operator.Role,
+	})
+	if err != nil {
+		return AccessToken{}, err
+	}
+
+	uc.logger.Info("operator logged in",
+		zap.String("username", operator.Username))
+
+	return AccessToken{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// Authenticate проверяет токен оператора: собственный JWT или токен OIDC-провайдера.
+// Пользователь OIDC, привязанный к оператору через oidc_subject, действует от имени оператора и с его ролью,
+// остальные пользователи OIDC — редакторы
+func (uc *AuthUseCaseImpl) Authenticate(ctx context.Context, token string) (entity.Actor, error) {
+	err := entity.ErrInvalidToken
+	for _, verifier := range uc.verifiers {
+		var actor entity.Actor
+		actor, err = verifier.Verify(ctx, token)
+		if err != nil {
+			continue
+		}
+		if actor.Subject == "" {
+			return actor, nil
+		}
+
+		operator, err := uc.operators.ReadByOIDCSubject(ctx, actor.Subject)
+		if errors.Is(err, entity.ErrOperatorNotFound) {
+			actor.Role = entity.RoleEditor
+			return actor, nil
+		}
+		if err != nil {
+			return entity.Actor{}, err
+		}
+		actor.Name = operator.Username
+		actor.OperatorID = operator.ID
+		actor.Role = operator.Role
+		return actor, nil
+	}
+
+	return entity.Actor{}, err
+}
+
+// CreateOperator заводит оператора с паролем, OIDC-субъектом или обоими; пустая роль — редактор.
+// Заводить операторов может только публикатор, иначе редактор выдал бы себе право публикации
+func (uc *AuthUseCaseImpl) CreateOperator(ctx context.Context, username, password, oidcSubject, role string) (int, error) {
+	if err := requirePublisher(ctx); err != nil {
+		return 0, err
+	}
+	if username == "" || username == entity.ActorAPIKey {
+		return 0, fmt.Errorf("%w: username is required and must not be %q", entity.ErrInvalidOperator, entity.ActorAPIKey)
+	}
+	if password == "" && oidcSubject == "" {
+		return 0, fmt.Errorf("%w: password or oidc_subject is required", entity.ErrInvalidOperator)
+	}
+	if password != "" && (len(password) < minPasswordLength || len(password) > maxPasswordLength) {
+		return 0, fmt.Errorf("%w: password must be %d to %d bytes long", entity.ErrInvalidOperator, minPasswordLength, maxPasswordLength)
+	}
+	switch role {
+	case "":
+		role = entity.RoleEditor
+	case entity.RoleEditor, entity.RolePublisher:
+	default:
+		return 0, fmt.Errorf("%w: role must be %s or %s", entity.ErrInvalidOperator, entity.RoleEditor, entity.RolePublisher)
+	}
+
+	operator := entity.Operator{
+		Username:    username,
+		OIDCSubject: oidcSubject,
+		Role:        role,
+	}
+	if password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return 0, fmt.Errorf("failed to hash password: %w", err)
+		}
+		operator.PasswordHash = string(hash)
+	}
+
+	operatorID, err := uc.operators.Create(ctx, operator)
+	if err != nil {
+		return 0, err
+	}
+
+	uc.logger.Info("operator created",
+		zap.Int("operator_id", operatorID),
+		zap.String("username", username),
+		zap.String("role", role),
+		zap.String("created_by", actorName(ctx)))
+
+	return operatorID, nil
+}
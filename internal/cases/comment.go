@@ -0,0 +1,84 @@
+package cases
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// maxCommentLength — самый длинный комментарий в символах
+const maxCommentLength = 2000
+
+func (uc *IncidentUseCaseImpl) IncidentComments(ctx context.Context, incID, limit int) ([]entity.IncidentComment, error) {
+	if _, err := uc.repo.Read(ctx, incID); err != nil {
+		return nil, err
+	}
+
+	return uc.comments.ReadByIncident(ctx, incID, limit)
+}
+
+// AddIncidentComment записывает заметку к зоне. Комментировать может любой оператор, в том числе
+// опубликованную зону; если комментарии попадают в вебхуки, получатели опубликованной зоны
+// получают incident.updated с новым комментарием
+func (uc *IncidentUseCaseImpl) AddIncidentComment(ctx context.Context, incID int, body string) (*entity.IncidentComment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxCommentLength {
+		return nil, entity.ErrInvalidComment
+	}
+
+	var (
+		saved      *entity.IncidentComment
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := uc.repo.Read(ctx, incID); err != nil {
+			return err
+		}
+
+		var err error
+		saved, err = uc.comments.Create(ctx, entity.IncidentComment{
+			IncidentID: incID,
+			Body:       body,
+			Author:     actorName(ctx),
+		})
+		if err != nil || !uc.commentsInWebhooks {
+			return err
+		}
+
+		// сама зона не меняется, поэтому она же служит снимком «до»
+		before, err := uc.incidentSnapshot(ctx, incID)
+		if err != nil {
+			return err
+		}
+		webhookIDs, err = uc.incidentWebhook(ctx, incID, before)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	uc.logger.Info("incident comment added",
+		zap.Int("id", incID),
+		zap.Int("comment_id", saved.ID),
+		zap.String("author", saved.Author))
+
+	return saved, nil
+}
+
+// latestComment возвращает последний комментарий зоны для вебхука; nil — комментариев нет
+// или они не попадают в вебхуки
+func (uc *IncidentUseCaseImpl) latestComment(ctx context.Context, incID int) (*entity.IncidentComment, error) {
+	if !uc.commentsInWebhooks {
+		return nil, nil
+	}
+
+	comments, err := uc.comments.ReadLatest(ctx, []int{incID})
+	if err != nil || len(comments) == 0 {
+		return nil, err
+	}
+	return &comments[0], nil
+}
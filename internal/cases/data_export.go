@@ -0,0 +1,330 @@
+package cases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/internal/port/storage"
+	"go.uber.org/zap"
+)
+
+var _ DataExportUseCase = (*DataExportUseCaseImpl)(nil)
+
+// dataExportAlertEvents — события вебхуков, которые попадают в выгрузку как алерты пользователя
+var dataExportAlertEvents = []string{
+	entity.WebhookLocationAlert,
+	entity.WebhookUserAlert,
+	entity.WebhookUserEntered,
+	entity.WebhookUserExited,
+	entity.WebhookGroupAlert,
+}
+
+// DataExportUseCase — выгрузка данных пользователя по запросу субъекта данных: архив собирает
+// DataExportWorker, скачивается он по временной ссылке на объектное хранилище
+type DataExportUseCase interface {
+	// UserDataExport возвращает выгрузку пользователя в работе или готовую, а если их нет — заводит новую
+	UserDataExport(ctx context.Context, userID string) (*DataExportWithURL, error)
+	// ProcessExports собирает не больше limit выгрузок и возвращает число собранных.
+	// Неудачная выгрузка останавливает пачку и повторяется при следующем запуске
+	ProcessExports(ctx context.Context, limit int) (completed int, err error)
+	// ExpireExports удаляет архивы с истекшим сроком хранения
+	ExpireExports(ctx context.Context, limit int) (expired int, err error)
+}
+
+type DataExportUseCaseImpl struct {
+	exports     repo.DataExportRepo
+	checks      repo.CheckRepo
+	webhooks    repo.WebhookRepo
+	presence    repo.PresenceRepo
+	preferences repo.PreferenceRepo
+	groups      repo.GroupRepo
+	storage     storage.ObjectStorage
+	tx          repo.Transactor
+	// retentionHours — сколько хранится готовый архив
+	retentionHours int
+	urlExpiry      time.Duration
+	logger         *zap.Logger
+}
+
+func NewDataExportUseCase(
+	exports repo.DataExportRepo,
+	checks repo.CheckRepo,
+	webhooks repo.WebhookRepo,
+	presence repo.PresenceRepo,
+	preferences repo.PreferenceRepo,
+	groups repo.GroupRepo,
+	storage storage.ObjectStorage,
+	tx repo.Transactor,
+	retentionHours int,
+	urlExpiry time.Duration,
+	logger *zap.Logger,
+) *DataExportUseCaseImpl {
+	return &DataExportUseCaseImpl{
+		exports:        exports,
+		checks:         checks,
+		webhooks:       webhooks,
+		presence:       presence,
+		preferences:    preferences,
+		groups:         groups,
+		storage:        storage,
+		tx:             tx,
+		retentionHours: retentionHours,
+		urlExpiry:      urlExpiry,
+		logger:         logger,
+	}
+}
+
+// DataExportWithURL — выгрузка со ссылкой на скачивание; URL пустой, пока архив не готов
+type DataExportWithURL struct {
+	Export *entity.DataExport
+	URL    string
+}
+
+func (uc *DataExportUseCaseImpl) UserDataExport(ctx context.Context, userID string) (*DataExportWithURL, error) {
+	export, err := uc.exports.ReadLatest(ctx, userID)
+	if err != nil && !errors.Is(err, entity.ErrDataExportNotFound) {
+		return nil, err
+	}
+	if err == nil && export.Status != entity.DataExportExpired {
+		return uc.withURL(ctx, export)
+	}
+
+	exportID, err := uc.exports.Create(ctx, entity.DataExport{
+		UserID:      userID,
+		RequestedBy: actorName(ctx),
+	})
+	if errors.Is(err, entity.ErrDataExportPending) {
+		// параллельный запрос уже завел выгрузку
+		export, err = uc.exports.ReadLatest(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		return uc.withURL(ctx, export)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	export, err = uc.exports.Read(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("user data export requested",
+		zap.Int("export_id", export.ID),
+		zap.String("user_id", export.UserID),
+		zap.String("requested_by", export.RequestedBy))
+
+	return &DataExportWithURL{Export: export}, nil
+}
+
+func (uc *DataExportUseCaseImpl) withURL(ctx context.Context, export *entity.DataExport) (*DataExportWithURL, error) {
+	if export.Status != entity.DataExportReady {
+		return &DataExportWithURL{Export: export}, nil
+	}
+
+	// ссылка не переживает архив
+	expiry := uc.urlExpiry
+	if left := time.Until(*export.ExpiresAt); left < expiry {
+		expiry = max(left, time.Second)
+	}
+	url, err := uc.storage.PresignedURL(ctx, export.ObjectKey, expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DataExportWithURL{Export: export, URL: url}, nil
+}
+
+func (uc *DataExportUseCaseImpl) ProcessExports(ctx context.Context, limit int) (int, error) {
+	completed := 0
+	for completed < limit {
+		export, err := uc.processNext(ctx)
+		if err != nil {
+			return completed, err
+		}
+		if export == nil {
+			return completed, nil
+		}
+		completed++
+	}
+
+	return completed, nil
+}
+
+// processNext собирает одну выгрузку, держа ее заблокированной до записи архива
+func (uc *DataExportUseCaseImpl) processNext(ctx context.Context) (*entity.DataExport, error) {
+	var (
+		export *entity.DataExport
+		size   int64
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		exports, err := uc.exports.LockPending(ctx, 1)
+		if err != nil || len(exports) == 0 {
+			return err
+		}
+		export = exports[0]
+
+		archive, err := uc.archive(ctx, export)
+		if err != nil {
+			return err
+		}
+		size = int64(len(archive))
+
+		objectKey := "user-exports/" + newObjectID() + ".json"
+		if err := uc.storage.Put(ctx, objectKey, bytes.NewReader(archive), size, "application/json"); err != nil {
+			return err
+		}
+
+		return uc.exports.Complete(ctx, export.ID, objectKey, size, uc.retentionHours)
+	})
+	if err != nil {
+		if export != nil {
+			if failErr := uc.exports.Fail(ctx, export.ID, err.Error()); failErr != nil {
+				uc.logger.Error("failed to record data export failure",
+					zap.Error(failErr),
+					zap.Int("export_id", export.ID))
+			}
+		}
+		return nil, err
+	}
+	if export == nil {
+		return nil, nil
+	}
+
+	uc.logger.Info("user data exported",
+		zap.Int("export_id", export.ID),
+		zap.String("user_id", export.UserID),
+		zap.Int64("size_bytes", size))
+
+	return export, nil
+}
+
+// archive собирает JSON со всеми данными пользователя: проверками, алертами из вебхуков,
+// настройками, текущими зонами и группами
+func (uc *DataExportUseCaseImpl) archive(ctx context.Context, export *entity.DataExport) ([]byte, error) {
+	userID := export.UserID
+
+	checks, err := uc.checks.ReadByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	checkList := make([]map[string]interface{}, len(checks))
+	for i, c := range checks {
+		checkList[i] = map[string]interface{}{
+			"check_id":   c.ID,
+			"latitude":   c.Latitude,
+			"longitude":  c.Longitude,
+			"has_alert":  c.HasAlert,
+			"created_at": c.CreatedAt.UTC().Format(time.RFC3339Nano),
+		}
+	}
+
+	webhooks, err := uc.webhooks.ReadByUser(ctx, userID, dataExportAlertEvents)
+	if err != nil {
+		return nil, err
+	}
+	alertList := make([]map[string]interface{}, 0, len(webhooks))
+	// вебхук создается на каждого получателя, пользователь получил алерт один раз
+	seen := make(map[string]bool, len(webhooks))
+	for _, wh := range webhooks {
+		key := fmt.Sprintf("%d:%s", wh.CheckID, wh.EventType)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		var envelope struct {
+			CreatedAt string          `json:"created_at"`
+			Data      json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(wh.Payload, &envelope); err != nil {
+			uc.logger.Warn("skipping webhook with malformed payload in data export",
+				zap.Int("webhook_id", wh.ID),
+				zap.Error(err))
+			continue
+		}
+		alertList = append(alertList, map[string]interface{}{
+			"check_id":   wh.CheckID,
+			"event":      wh.EventType,
+			"created_at": envelope.CreatedAt,
+			"data":       envelope.Data,
+		})
+	}
+
+	var prefs map[string]interface{}
+	saved, err := uc.preferences.Read(ctx, userID)
+	if err != nil && !errors.Is(err, entity.ErrPreferencesNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		quietHours := make([]map[string]interface{}, len(saved.QuietHours))
+		for i, w := range saved.QuietHours {
+			quietHours[i] = map[string]interface{}{"start": w.Start, "end": w.End, "days": w.Days}
+		}
+		prefs = map[string]interface{}{
+			"min_severity":        saved.MinSeverity,
+			"quiet_hours":         quietHours,
+			"timezone":            saved.Timezone,
+			"channels":            saved.Channels,
+			"max_alerts_per_hour": saved.MaxAlertsPerHour,
+			"updated_at":          saved.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		}
+	}
+
+	zones, err := uc.presence.ReadZones(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if zones == nil {
+		zones = []int{}
+	}
+
+	groups, err := uc.groups.ReadByMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	groupList := make([]map[string]interface{}, len(groups))
+	for i, g := range groups {
+		groupList[i] = map[string]interface{}{"group_id": g.ID, "name": g.Name}
+	}
+
+	archive, err := json.MarshalIndent(map[string]interface{}{
+		"export_id":    export.ID,
+		"user_id":      userID,
+		"generated_at": time.Now().UTC().Format(time.RFC3339Nano),
+		"checks":       checkList,
+		"alerts":       alertList,
+		"preferences":  prefs,
+		"zones":        zones,
+		"groups":       groupList,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data export: %w", err)
+	}
+
+	return archive, nil
+}
+
+func (uc *DataExportUseCaseImpl) ExpireExports(ctx context.Context, limit int) (int, error) {
+	expired, err := uc.exports.ExpireDue(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, export := range expired {
+		if err := uc.storage.Delete(ctx, export.ObjectKey); err != nil {
+			uc.logger.Warn("failed to delete expired data export object",
+				zap.String("object_key", export.ObjectKey),
+				zap.Error(err))
+		}
+	}
+
+	return len(expired), nil
+}
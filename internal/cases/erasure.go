@@ -0,0 +1,309 @@
+package cases
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/alerts"
+	"github.com/4otis/geonotify-service/internal/port/cache"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/internal/port/search"
+	"github.com/4otis/geonotify-service/internal/port/storage"
+	"go.uber.org/zap"
+)
+
+var _ ErasureUseCase = (*ErasureUseCaseImpl)(nil)
+
+// ErasureUseCase — удаление данных пользователя по запросу на забвение: заявку подает оператор,
+// подтверждает другой публикатор, данные удаляет ErasureWorker
+type ErasureUseCase interface {
+	RequestErasure(ctx context.Context, userID, mode string) (*entity.Erasure, error)
+	Erasure(ctx context.Context, erasureID int) (*entity.Erasure, error)
+	// ListErasures возвращает заявки от новых к старым; пустой status — заявки во всех статусах
+	ListErasures(ctx context.Context, status string, limit int) ([]*entity.Erasure, error)
+	ConfirmErasure(ctx context.Context, erasureID int) (*entity.Erasure, error)
+	CancelErasure(ctx context.Context, erasureID int) (*entity.Erasure, error)
+	// ProcessErasures выполняет не больше limit подтвержденных заявок и возвращает число выполненных.
+	// Неудачная заявка останавливает пачку и повторяется при следующем запуске
+	ProcessErasures(ctx context.Context, limit int) (completed int, err error)
+}
+
+type ErasureUseCaseImpl struct {
+	erasures    repo.ErasureRepo
+	checks      repo.CheckRepo
+	webhooks    repo.WebhookRepo
+	presence    repo.PresenceRepo
+	preferences repo.PreferenceRepo
+	groups      repo.GroupRepo
+	// exports и storage — nil без объектного хранилища: выгрузок данных тогда нет
+	exports repo.DataExportRepo
+	storage storage.ObjectStorage
+	tx      repo.Transactor
+	cache   cache.Cache
+	// locations и indexer — nil, если последние точки пользователей и поисковый индекс не ведутся
+	locations alerts.LocationIndex
+	indexer   search.Indexer
+	logger    *zap.Logger
+}
+
+func NewErasureUseCase(
+	erasures repo.ErasureRepo,
+	checks repo.CheckRepo,
+	webhooks repo.WebhookRepo,
+	presence repo.PresenceRepo,
+	preferences repo.PreferenceRepo,
+	groups repo.GroupRepo,
+	exports repo.DataExportRepo,
+	storage storage.ObjectStorage,
+	tx repo.Transactor,
+	cache cache.Cache,
+	locations alerts.LocationIndex,
+	indexer search.Indexer,
+	logger *zap.Logger,
+) *ErasureUseCaseImpl {
+	return &ErasureUseCaseImpl{
+		erasures:    erasures,
+		checks:      checks,
+		webhooks:    webhooks,
+		presence:    presence,
+		preferences: preferences,
+		groups:      groups,
+		exports:     exports,
+		storage:     storage,
+		tx:          tx,
+		cache:       cache,
+		locations:   locations,
+		indexer:     indexer,
+		logger:      logger,
+	}
+}
+
+func (uc *ErasureUseCaseImpl) RequestErasure(ctx context.Context, userID, mode string) (*entity.Erasure, error) {
+	if mode == "" {
+		mode = entity.ErasureDelete
+	}
+	if mode != entity.ErasureDelete && mode != entity.ErasureAnonymize {
+		return nil, entity.ErrInvalidErasureMode
+	}
+
+	erasureID, err := uc.erasures.Create(ctx, entity.Erasure{
+		UserID:      userID,
+		Mode:        mode,
+		RequestedBy: actorName(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	erasure, err := uc.erasures.Read(ctx, erasureID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("user data erasure requested",
+		zap.Int("erasure_id", erasure.ID),
+		zap.String("user_id", erasure.UserID),
+		zap.String("mode", erasure.Mode),
+		zap.String("requested_by", erasure.RequestedBy))
+
+	return erasure, nil
+}
+
+func (uc *ErasureUseCaseImpl) Erasure(ctx context.Context, erasureID int) (*entity.Erasure, error) {
+	return uc.erasures.Read(ctx, erasureID)
+}
+
+func (uc *ErasureUseCaseImpl) ListErasures(ctx context.Context, status string, limit int) ([]*entity.Erasure, error) {
+	return uc.erasures.ReadByStatus(ctx, status, limit)
+}
+
+// ConfirmErasure ставит заявку в очередь на удаление. Подтвердить может только публикатор,
+// который не подавал заявку: удаление необратимо
+func (uc *ErasureUseCaseImpl) ConfirmErasure(ctx context.Context, erasureID int) (*entity.Erasure, error) {
+	erasure, err := uc.decide(ctx, erasureID, entity.ErasureConfirmed)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("user data erasure confirmed",
+		zap.Int("erasure_id", erasure.ID),
+		zap.String("user_id", erasure.UserID),
+		zap.String("requested_by", erasure.RequestedBy),
+		zap.String("confirmed_by", erasure.ConfirmedBy))
+
+	return erasure, nil
+}
+
+// CancelErasure закрывает заявку без удаления. Автор заявки может отозвать ее сам
+func (uc *ErasureUseCaseImpl) CancelErasure(ctx context.Context, erasureID int) (*entity.Erasure, error) {
+	erasure, err := uc.decide(ctx, erasureID, entity.ErasureCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("user data erasure cancelled",
+		zap.Int("erasure_id", erasure.ID),
+		zap.String("user_id", erasure.UserID),
+		zap.String("cancelled_by", erasure.ConfirmedBy))
+
+	return erasure, nil
+}
+
+func (uc *ErasureUseCaseImpl) decide(ctx context.Context, erasureID int, status string) (*entity.Erasure, error) {
+	if err := requirePublisher(ctx); err != nil {
+		return nil, err
+	}
+
+	erasure, err := uc.erasures.Read(ctx, erasureID)
+	if err != nil {
+		return nil, err
+	}
+	if erasure.Status != entity.ErasurePending {
+		return nil, entity.ErrErasureClosed
+	}
+
+	name := actorName(ctx)
+	if name == "" || (status == entity.ErasureConfirmed && name == erasure.RequestedBy) {
+		return nil, entity.ErrSelfConfirmation
+	}
+
+	if err := uc.erasures.Decide(ctx, erasureID, status, name); err != nil {
+		return nil, err
+	}
+
+	return uc.erasures.Read(ctx, erasureID)
+}
+
+func (uc *ErasureUseCaseImpl) ProcessErasures(ctx context.Context, limit int) (int, error) {
+	completed := 0
+	for completed < limit {
+		erasure, err := uc.processNext(ctx)
+		if err != nil {
+			return completed, err
+		}
+		if erasure == nil {
+			return completed, nil
+		}
+		completed++
+	}
+
+	return completed, nil
+}
+
+// processNext выполняет одну подтвержденную заявку в транзакции: Redis и поисковый индекс чистятся
+// до коммита, поэтому при их сбое изменения в БД откатываются и заявка повторяется целиком
+func (uc *ErasureUseCaseImpl) processNext(ctx context.Context) (*entity.Erasure, error) {
+	var erasure *entity.Erasure
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		erasures, err := uc.erasures.LockConfirmed(ctx, 1)
+		if err != nil || len(erasures) == 0 {
+			return err
+		}
+		erasure = erasures[0]
+
+		result, err := uc.erase(ctx, erasure)
+		if err != nil {
+			return err
+		}
+		erasure.Result = result
+
+		return uc.erasures.Complete(ctx, erasure.ID, result)
+	})
+	if err != nil {
+		if erasure != nil {
+			if failErr := uc.erasures.Fail(ctx, erasure.ID, err.Error()); failErr != nil {
+				uc.logger.Error("failed to record erasure failure",
+					zap.Error(failErr),
+					zap.Int("erasure_id", erasure.ID))
+			}
+		}
+		return nil, err
+	}
+	if erasure == nil {
+		return nil, nil
+	}
+
+	uc.logger.Info("user data erased",
+		zap.Int("erasure_id", erasure.ID),
+		zap.String("user_id", erasure.UserID),
+		zap.String("mode", erasure.Mode),
+		zap.String("requested_by", erasure.RequestedBy),
+		zap.String("confirmed_by", erasure.ConfirmedBy),
+		zap.Int64("checks", erasure.Result.Checks),
+		zap.Int64("webhooks", erasure.Result.Webhooks),
+		zap.Int64("zones", erasure.Result.Zones),
+		zap.Int64("preferences", erasure.Result.Preferences),
+		zap.Int64("group_memberships", erasure.Result.GroupMemberships))
+
+	return erasure, nil
+}
+
+// erase вызывается внутри транзакции. Вебхуки удаляются первыми: они находятся по проверкам пользователя
+func (uc *ErasureUseCaseImpl) erase(ctx context.Context, erasure *entity.Erasure) (entity.ErasureResult, error) {
+	var (
+		result entity.ErasureResult
+		err    error
+	)
+	userID := erasure.UserID
+
+	if result.Webhooks, err = uc.webhooks.DeleteByUser(ctx, userID); err != nil {
+		return result, err
+	}
+	if erasure.Mode == entity.ErasureAnonymize {
+		result.Checks, err = uc.checks.AnonymizeByUser(ctx, userID, entity.AnonymizedUserID, entity.AnonymizedPrecision)
+	} else {
+		result.Checks, err = uc.checks.DeleteByUser(ctx, userID)
+	}
+	if err != nil {
+		return result, err
+	}
+	if result.Zones, err = uc.presence.Forget(ctx, userID); err != nil {
+		return result, err
+	}
+	if result.Preferences, err = uc.preferences.Delete(ctx, userID); err != nil {
+		return result, err
+	}
+	if result.GroupMemberships, err = uc.groups.RemoveUser(ctx, userID); err != nil {
+		return result, err
+	}
+
+	if uc.exports != nil {
+		exports, err := uc.exports.ExpireByUser(ctx, userID)
+		if err != nil {
+			return result, err
+		}
+		for _, export := range exports {
+			if export.ObjectKey == "" {
+				continue
+			}
+			if err := uc.storage.Delete(ctx, export.ObjectKey); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if uc.locations != nil {
+		if err := uc.locations.Forget(ctx, userID); err != nil {
+			return result, err
+		}
+	}
+	if uc.indexer != nil {
+		if erasure.Mode == entity.ErasureAnonymize {
+			err = uc.indexer.AnonymizeUserChecks(ctx, userID, entity.AnonymizedUserID, entity.AnonymizedPrecision)
+		} else {
+			err = uc.indexer.DeleteUserChecks(ctx, userID)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// кэш настроек истекает сам, поэтому ошибка сброса только логируется
+	if err := uc.cache.Delete(ctx, preferencesCacheKey(userID)); err != nil {
+		uc.logger.Warn("failed to invalidate cached user preferences",
+			zap.Error(err),
+			zap.String("user_id", userID))
+	}
+
+	return result, nil
+}
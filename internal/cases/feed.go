@@ -0,0 +1,107 @@
+package cases
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"go.uber.org/zap"
+)
+
+var _ FeedUseCase = (*FeedUseCaseImpl)(nil)
+
+// FeedUseCase — публичная лента действующих зон для агрегаторов оповещений (Atom, CAP)
+type FeedUseCase interface {
+	// ActiveIncidents возвращает действующие опубликованные публичные зоны с переводом на язык из locales
+	ActiveIncidents(ctx context.Context, locales []string) ([]*entity.Incident, error)
+	// Alert возвращает публичную зону, которая была опубликована (в том числе завершенную или снятую с публикации),
+	// со всеми переводами. Черновики, непубличные и удаленные зоны — entity.ErrIncidentNotFound
+	Alert(ctx context.Context, incID int) (*FeedAlert, error)
+	// Version меняется при любом изменении зон; по ней строится ETag ленты
+	Version(ctx context.Context) (string, error)
+}
+
+// FeedAlert — зона для CAP-сообщения: исходный текст на языке DefaultLocale и переводы
+type FeedAlert struct {
+	Incident      *entity.Incident
+	Translations  []entity.IncidentTranslation
+	DefaultLocale string
+}
+
+type FeedUseCaseImpl struct {
+	incidentRepo repo.IncidentRepo
+	translations repo.TranslationRepo
+	locationCase LocationUseCase
+	// defaultLocale — язык исходных названий и описаний зон, канонический тег BCP 47
+	defaultLocale string
+	logger        *zap.Logger
+}
+
+func NewFeedUseCase(incidentRepo repo.IncidentRepo, translations repo.TranslationRepo,
+	locationCase LocationUseCase, defaultLocale string, logger *zap.Logger) *FeedUseCaseImpl {
+	return &FeedUseCaseImpl{
+		incidentRepo:  incidentRepo,
+		translations:  translations,
+		locationCase:  locationCase,
+		defaultLocale: defaultLocale,
+		logger:        logger,
+	}
+}
+
+func (uc *FeedUseCaseImpl) ActiveIncidents(ctx context.Context, locales []string) ([]*entity.Incident, error) {
+	active, err := uc.incidentRepo.ReadAllActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	incidents := make([]*entity.Incident, 0, len(active))
+	for _, inc := range active {
+		if inc.Public() {
+			incidents = append(incidents, inc)
+		}
+	}
+	if len(locales) == 0 || len(incidents) == 0 {
+		return incidents, nil
+	}
+
+	incIDs := make([]int, len(incidents))
+	for i, inc := range incidents {
+		incIDs[i] = inc.ID
+	}
+	translations, err := uc.translations.ReadByIncidents(ctx, incIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byIncident := groupTranslations(translations)
+	for i, inc := range incidents {
+		incidents[i] = localizeIncident(inc, byIncident[inc.ID], locales, uc.defaultLocale)
+	}
+
+	return incidents, nil
+}
+
+func (uc *FeedUseCaseImpl) Alert(ctx context.Context, incID int) (*FeedAlert, error) {
+	incident, err := uc.incidentRepo.Read(ctx, incID)
+	if err != nil {
+		return nil, err
+	}
+	// неопубликованный черновик и непубличную зону лента не раскрывает
+	if incident.Status == entity.IncidentDraft || incident.PublishedAt == nil || !incident.Public() {
+		return nil, entity.ErrIncidentNotFound
+	}
+
+	translations, err := uc.translations.ReadByIncident(ctx, incID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FeedAlert{
+		Incident:      incident,
+		Translations:  translations,
+		DefaultLocale: uc.defaultLocale,
+	}, nil
+}
+
+func (uc *FeedUseCaseImpl) Version(ctx context.Context) (string, error) {
+	return uc.locationCase.IncidentsVersion(ctx)
+}
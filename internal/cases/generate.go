@@ -0,0 +1,3 @@
+package cases
+
+//go:generate mockgen -destination=mocks/location.go -package=mocks github.com/4otis/geonotify-service/internal/cases LocationUseCase
@@ -0,0 +1,313 @@
+package cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"go.uber.org/zap"
+)
+
+var _ GroupUseCase = (*GroupUseCaseImpl)(nil)
+
+// GroupUseCase — группы пользователей. Создать группу может любой оператор, менять ее состав
+// и получателя вебхуков — публикатор, создатель или менеджер группы (см. entity.Group.Manager)
+type GroupUseCase interface {
+	ListGroups(ctx context.Context) ([]*entity.Group, error)
+	ReadGroup(ctx context.Context, groupID int) (*entity.Group, error)
+	CreateGroup(ctx context.Context, group entity.Group, userIDs []string) (*entity.Group, error)
+	UpdateGroup(ctx context.Context, groupID int, patch entity.GroupPatch) (*entity.Group, error)
+	DeleteGroup(ctx context.Context, groupID int) error
+
+	GroupMembers(ctx context.Context, groupID int) ([]string, error)
+	AddGroupMembers(ctx context.Context, groupID int, userIDs []string) (added int, err error)
+	RemoveGroupMember(ctx context.Context, groupID int, userID string) error
+
+	// SetGroupWebhook регистрирует или заменяет получателя сводных алертов group.alert
+	SetGroupWebhook(ctx context.Context, groupID int, targetURL, secret string) (*entity.Group, error)
+	DeleteGroupWebhook(ctx context.Context, groupID int) error
+
+	// GroupStatus возвращает участников, которые по последней проверке находятся в действующих зонах
+	GroupStatus(ctx context.Context, groupID int) (*entity.GroupStatus, error)
+}
+
+type GroupUseCaseImpl struct {
+	repo      repo.GroupRepo
+	endpoints repo.WebhookEndpointRepo
+	tx        repo.Transactor
+	logger    *zap.Logger
+}
+
+func NewGroupUseCase(repo repo.GroupRepo, endpoints repo.WebhookEndpointRepo, tx repo.Transactor, logger *zap.Logger) *GroupUseCaseImpl {
+	return &GroupUseCaseImpl{
+		repo:      repo,
+		endpoints: endpoints,
+		tx:        tx,
+		logger:    logger,
+	}
+}
+
+func (uc *GroupUseCaseImpl) ListGroups(ctx context.Context) ([]*entity.Group, error) {
+	return uc.repo.ReadAll(ctx)
+}
+
+func (uc *GroupUseCaseImpl) ReadGroup(ctx context.Context, groupID int) (*entity.Group, error) {
+	return uc.repo.Read(ctx, groupID)
+}
+
+func (uc *GroupUseCaseImpl) CreateGroup(ctx context.Context, group entity.Group, userIDs []string) (*entity.Group, error) {
+	group.CreatedBy = actorName(ctx)
+	if group.Managers == nil {
+		group.Managers = []string{}
+	}
+
+	var groupID, added int
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		groupID, err = uc.repo.Create(ctx, group)
+		if err != nil {
+			return err
+		}
+
+		added, err = uc.repo.AddMembers(ctx, groupID, userIDs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("group created",
+		zap.Int("group_id", groupID),
+		zap.String("name", group.Name),
+		zap.Int("members", added),
+		zap.String("actor", group.CreatedBy))
+
+	return uc.repo.Read(ctx, groupID)
+}
+
+func (uc *GroupUseCaseImpl) UpdateGroup(ctx context.Context, groupID int, patch entity.GroupPatch) (*entity.Group, error) {
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := uc.managedGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		patch.Apply(group)
+		return uc.repo.Update(ctx, *group)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("group updated",
+		zap.Int("group_id", groupID),
+		zap.String("actor", actorName(ctx)))
+
+	return uc.repo.Read(ctx, groupID)
+}
+
+// DeleteGroup удаляет группу вместе с получателем вебхуков и его недоставленными вебхуками
+func (uc *GroupUseCaseImpl) DeleteGroup(ctx context.Context, groupID int) error {
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := uc.managedGroup(ctx, groupID); err != nil {
+			return err
+		}
+		return uc.repo.Delete(ctx, groupID)
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Info("group deleted",
+		zap.Int("group_id", groupID),
+		zap.String("actor", actorName(ctx)))
+
+	return nil
+}
+
+func (uc *GroupUseCaseImpl) GroupMembers(ctx context.Context, groupID int) ([]string, error) {
+	if _, err := uc.repo.Read(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ReadMembers(ctx, groupID)
+}
+
+func (uc *GroupUseCaseImpl) AddGroupMembers(ctx context.Context, groupID int, userIDs []string) (int, error) {
+	var added int
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := uc.managedGroup(ctx, groupID); err != nil {
+			return err
+		}
+
+		var err error
+		added, err = uc.repo.AddMembers(ctx, groupID, userIDs)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	uc.logger.Info("group members added",
+		zap.Int("group_id", groupID),
+		zap.Int("added", added),
+		zap.String("actor", actorName(ctx)))
+
+	return added, nil
+}
+
+func (uc *GroupUseCaseImpl) RemoveGroupMember(ctx context.Context, groupID int, userID string) error {
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := uc.managedGroup(ctx, groupID); err != nil {
+			return err
+		}
+		return uc.repo.RemoveMember(ctx, groupID, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Info("group member removed",
+		zap.Int("group_id", groupID),
+		zap.String("user_id", userID),
+		zap.String("actor", actorName(ctx)))
+
+	return nil
+}
+
+// SetGroupWebhook: получатель группы подписан только на group.alert и использует политику попыток по умолчанию
+func (uc *GroupUseCaseImpl) SetGroupWebhook(ctx context.Context, groupID int, targetURL, secret string) (*entity.Group, error) {
+	if !validWebhookURL(targetURL) {
+		return nil, entity.ErrInvalidWebhookURL
+	}
+
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := uc.managedGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		if group.Webhook == nil {
+			_, err := uc.endpoints.Create(ctx, entity.WebhookEndpoint{
+				URL:       targetURL,
+				Secret:    secret,
+				Events:    []string{entity.WebhookGroupAlert},
+				Enabled:   true,
+				GroupID:   groupID,
+				CreatedBy: actorName(ctx),
+			})
+			return err
+		}
+
+		endpoint, err := uc.endpoints.Read(ctx, group.Webhook.ID)
+		if err != nil {
+			return err
+		}
+		endpoint.URL = targetURL
+		endpoint.Secret = secret
+		endpoint.Enabled = true
+		return uc.endpoints.Update(ctx, *endpoint)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("group webhook set",
+		zap.Int("group_id", groupID),
+		zap.String("url", targetURL),
+		zap.String("actor", actorName(ctx)))
+
+	return uc.repo.Read(ctx, groupID)
+}
+
+func (uc *GroupUseCaseImpl) DeleteGroupWebhook(ctx context.Context, groupID int) error {
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := uc.managedGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if group.Webhook == nil {
+			return entity.ErrGroupWebhookNotFound
+		}
+		return uc.endpoints.Delete(ctx, group.Webhook.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Info("group webhook deleted",
+		zap.Int("group_id", groupID),
+		zap.String("actor", actorName(ctx)))
+
+	return nil
+}
+
+func (uc *GroupUseCaseImpl) GroupStatus(ctx context.Context, groupID int) (*entity.GroupStatus, error) {
+	group, err := uc.repo.Read(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := uc.repo.ReadInDanger(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.GroupStatus{Group: group, MembersInDanger: members}, nil
+}
+
+// managedGroup читает группу и проверяет, что исполнитель может ее менять
+func (uc *GroupUseCaseImpl) managedGroup(ctx context.Context, groupID int) (*entity.Group, error) {
+	group, err := uc.repo.Read(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor, ok := ActorFromContext(ctx); ok && !group.Manager(actor) {
+		return nil, entity.ErrForbidden
+	}
+	return group, nil
+}
+
+// groupAlerts вызывается в транзакции проверки, когда пользователь вошел в зоны entered: каждой его группе
+// с включенным получателем кладет один сводный group.alert с зонами входа и числом участников в зонах
+func (uc *LocationUseCaseImpl) groupAlerts(ctx context.Context, checkID int, query LocationCheckQuery,
+	groups []*entity.Group, entered []*entity.Incident) ([]int, error) {
+	if len(entered) == 0 {
+		return nil, nil
+	}
+
+	var webhookIDs []int
+	for _, group := range groups {
+		if group.Webhook == nil || !group.Webhook.Enabled {
+			continue
+		}
+
+		inDanger, err := uc.groups.ReadInDanger(ctx, group.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		data := map[string]interface{}{
+			"group_id":          group.ID,
+			"group_name":        group.Name,
+			"user_id":           query.UserID,
+			"check_id":          checkID,
+			"latitude":          query.Latitude,
+			"longitude":         query.Longitude,
+			"incidents":         entered,
+			"members_in_danger": len(inDanger),
+			"members_total":     group.MemberCount,
+			"timestamp":         time.Now().UTC().Format(time.RFC3339),
+		}
+
+		ids, err := uc.webhooks.EnqueueTo(ctx, []*entity.WebhookEndpoint{group.Webhook}, entity.WebhookGroupAlert, checkID, data)
+		if err != nil {
+			return nil, err
+		}
+		webhookIDs = append(webhookIDs, ids...)
+	}
+
+	return webhookIDs, nil
+}
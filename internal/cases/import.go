@@ -0,0 +1,393 @@
+package cases
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// maxIncidentName — длина названия зоны в символах, как в API и в БД
+const maxIncidentName = 127
+
+// Исходы применения одного оповещения
+const (
+	importCreated   = "created"
+	importUpdated   = "updated"
+	importResolved  = "resolved"
+	importSkipped   = "skipped"
+	importUnchanged = "unchanged"
+)
+
+// ImportFeed — внешний источник оповещений для ImportAlerts
+type ImportFeed struct {
+	// Name отличает одинаковые ID оповещений разных источников
+	Name string
+	// Source — источник зон: entity.SourceFeed или entity.SourceWeather
+	Source string
+	// Publish — публиковать новые зоны сразу, иначе создаются черновики
+	Publish bool
+}
+
+// Key — имя источника в обработанных оповещениях и в created_by: "<Source>:<Name>"
+func (f ImportFeed) Key() string {
+	return f.Source + ":" + f.Name
+}
+
+// ImportResult — итог импорта ленты. Skipped — оповещения, не ставшие зонами или не примененные к ним
+// (вне зоны обслуживания, истекшие, о зонах, которые оператор удалил или снял с публикации)
+type ImportResult struct {
+	Created  int
+	Updated  int
+	Resolved int
+	Skipped  int
+	Failed   int
+}
+
+// Changed сообщает, изменил ли импорт хотя бы одну зону
+func (r ImportResult) Changed() bool {
+	return r.Created+r.Updated+r.Resolved > 0
+}
+
+// ImportAlerts применяет оповещения источника от имени "<Source>:<Name>". Новое оповещение создает зону
+// с source источника: опубликованную при Publish, иначе черновик; критическая зона всегда создается черновиком.
+// Обновление меняет текст, форму, уровень опасности и срок действия зоны, отмена завершает ее (resolved).
+// Оповещение, уже примененное с тем же или более поздним Sent, пропускается. Зоны ленты, ни одного
+// оповещения которых в ней больше нет, завершаются: ленты служб содержат только действующие оповещения.
+// Если хотя бы одно оповещение не применилось, зоны не завершаются, чтобы не закрыть зону из-за сбоя
+func (uc *IncidentUseCaseImpl) ImportAlerts(ctx context.Context, feed ImportFeed, alerts []entity.ExternalAlert) (ImportResult, error) {
+	ctx = WithActor(ctx, entity.Actor{Name: feed.Key(), Role: entity.RolePublisher})
+
+	// обновление применяется после оповещения, на которое ссылается
+	alerts = append([]entity.ExternalAlert(nil), alerts...)
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].Sent.Before(alerts[j].Sent)
+	})
+
+	var (
+		result     ImportResult
+		webhookIDs []int
+		created    []*entity.Incident
+	)
+	seen := make(map[string]bool, len(alerts))
+	for _, alert := range alerts {
+		seen[alert.ID] = true
+		for _, ref := range alert.References {
+			seen[ref] = true
+		}
+
+		outcome, inc, ids, err := uc.importAlert(ctx, feed, alert)
+		if err != nil {
+			result.Failed++
+			uc.logger.Warn("failed to import alert",
+				zap.String("feed", feed.Key()),
+				zap.String("external_id", alert.ID),
+				zap.Error(err))
+			continue
+		}
+		webhookIDs = append(webhookIDs, ids...)
+
+		switch outcome {
+		case importCreated:
+			result.Created++
+			created = append(created, inc)
+		case importUpdated:
+			result.Updated++
+		case importResolved:
+			result.Resolved++
+		case importSkipped:
+			result.Skipped++
+		}
+	}
+
+	var err error
+	if result.Failed == 0 {
+		var (
+			resolved int
+			ids      []int
+		)
+		resolved, ids, err = uc.resolveMissing(ctx, feed.Key(), seen)
+		result.Resolved += resolved
+		webhookIDs = append(webhookIDs, ids...)
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	if result.Changed() {
+		if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+			uc.logger.Warn("failed to invalidate cache after importing alerts",
+				zap.Error(err))
+		}
+	}
+
+	for _, inc := range created {
+		if inc.Status == entity.IncidentPublished && inc.IsActive && uc.dispatcher != nil {
+			uc.notifyUsersNearby(ctx, inc.ID)
+		}
+	}
+
+	return result, err
+}
+
+// importAlert применяет одно оповещение в своей транзакции и запоминает его вместе с зоной.
+// Зона ищется по ID оповещения, затем по оповещениям, на которые оно ссылается
+func (uc *IncidentUseCaseImpl) importAlert(ctx context.Context, feed ImportFeed, alert entity.ExternalAlert) (string, *entity.Incident, []int, error) {
+	key := feed.Key()
+	var (
+		outcome    string
+		created    *entity.Incident
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.imports.Lock(ctx, key); err != nil {
+			return err
+		}
+		imports, err := uc.imports.Read(ctx, key, append([]string{alert.ID}, alert.References...))
+		if err != nil {
+			return err
+		}
+
+		incID := 0
+		for _, imp := range imports {
+			if imp.ExternalID != alert.ID {
+				continue
+			}
+			if !alert.Sent.After(imp.SentAt) {
+				outcome = importUnchanged
+				return nil
+			}
+			incID = imp.IncidentID
+		}
+		for _, imp := range imports {
+			if incID == 0 {
+				incID = imp.IncidentID
+			}
+		}
+
+		if incID == 0 {
+			created, webhookIDs, err = uc.createImported(ctx, feed, alert)
+			outcome = importSkipped
+			if created != nil {
+				outcome = importCreated
+				incID = created.ID
+			}
+		} else {
+			outcome, webhookIDs, err = uc.updateImported(ctx, incID, alert)
+		}
+		if err != nil {
+			return err
+		}
+
+		return uc.imports.Save(ctx, entity.IncidentImport{
+			Feed:       key,
+			ExternalID: alert.ID,
+			IncidentID: incID,
+			SentAt:     alert.Sent,
+		})
+	})
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return outcome, created, webhookIDs, nil
+}
+
+// createImported вызывается внутри транзакции; nil — оповещение не становится зоной: оно уже
+// завершено или истекло либо зона вне области обслуживания. Перекрытие с действующими зонами
+// не проверяется: оповещения служб часто накрывают одну территорию
+func (uc *IncidentUseCaseImpl) createImported(ctx context.Context, feed ImportFeed, alert entity.ExternalAlert) (*entity.Incident, []int, error) {
+	now := time.Now()
+	if alert.Ended || alertExpired(alert, now) {
+		return nil, nil, nil
+	}
+	if err := uc.checkArea(alert.Latitude, alert.Longitude); err != nil {
+		if errors.Is(err, entity.ErrOutsideArea) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	incident := entity.Incident{
+		CreatedBy: actorName(ctx),
+		Status:    entity.IncidentDraft,
+		State:     entity.StateActive,
+		Source:    feed.Source,
+	}
+	applyAlert(&incident, alert)
+	if feed.Publish && incident.Severity != entity.SeverityCritical {
+		incident.Status = entity.IncidentPublished
+	}
+	if err := validateExpiry(&incident, now); err != nil {
+		return nil, nil, err
+	}
+	if err := applySchedule(&incident, now); err != nil {
+		return nil, nil, err
+	}
+
+	created, err := uc.createWithGeometry(ctx, incident)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	webhookIDs, err := uc.incidentWebhook(ctx, created.ID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return created, webhookIDs, nil
+}
+
+// updateImported вызывается внутри транзакции. Зоны, которые оператор удалил, снял с публикации
+// или завершил, оповещения больше не меняют
+func (uc *IncidentUseCaseImpl) updateImported(ctx context.Context, incID int, alert entity.ExternalAlert) (string, []int, error) {
+	current, err := uc.repo.Read(ctx, incID)
+	if errors.Is(err, entity.ErrIncidentNotFound) {
+		return importSkipped, nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if current.Status == entity.IncidentArchived || !replaceable(current.State) {
+		return importSkipped, nil, nil
+	}
+
+	if alert.Ended || alertExpired(alert, time.Now()) {
+		webhookIDs, err := uc.resolveImported(ctx, current)
+		return importResolved, webhookIDs, err
+	}
+	if err := uc.checkArea(alert.Latitude, alert.Longitude); err != nil {
+		if errors.Is(err, entity.ErrOutsideArea) {
+			return importSkipped, nil, nil
+		}
+		return "", nil, err
+	}
+
+	incident := *current
+	applyAlert(&incident, alert)
+	incident.UpdatedBy = actorName(ctx)
+	if err := validateExpiry(&incident, time.Now()); err != nil {
+		return "", nil, err
+	}
+	if err := uc.repo.Update(ctx, incident); err != nil {
+		return "", nil, err
+	}
+	// Update сохраняет полигоны, только если не изменился охватывающий круг
+	err = uc.repo.UpdateGeometry(ctx, incID, entity.IncidentGeometry{
+		Latitude:  incident.Latitude,
+		Longitude: incident.Longitude,
+		Radius:    incident.Radius,
+		Polygons:  incident.Polygons,
+		UpdatedBy: incident.UpdatedBy,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	webhookIDs, err := uc.incidentWebhook(ctx, incID, current)
+	return importUpdated, webhookIDs, err
+}
+
+// resolveImported вызывается внутри транзакции: завершает зону, опасность которой по данным ленты миновала
+func (uc *IncidentUseCaseImpl) resolveImported(ctx context.Context, current *entity.Incident) ([]int, error) {
+	webhookIDs, err := uc.setState(ctx, current, entity.StateResolved)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := uc.incidentWebhook(ctx, current.ID, current)
+	return append(webhookIDs, ids...), err
+}
+
+// resolveMissing завершает незавершенные зоны ленты, ни одного оповещения которых нет среди seen
+func (uc *IncidentUseCaseImpl) resolveMissing(ctx context.Context, feed string, seen map[string]bool) (int, []int, error) {
+	open, err := uc.imports.ReadOpen(ctx, feed)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	present := make(map[int]bool, len(open))
+	for _, imp := range open {
+		if seen[imp.ExternalID] {
+			present[imp.IncidentID] = true
+		}
+	}
+
+	var (
+		resolved   int
+		webhookIDs []int
+	)
+	for _, imp := range open {
+		if present[imp.IncidentID] {
+			continue
+		}
+		present[imp.IncidentID] = true
+
+		var (
+			ids  []int
+			done bool
+		)
+		err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			current, err := uc.repo.Read(ctx, imp.IncidentID)
+			if err != nil {
+				return err
+			}
+			// зону могли завершить между чтением списка и транзакцией
+			if !replaceable(current.State) {
+				return nil
+			}
+
+			ids, err = uc.resolveImported(ctx, current)
+			done = err == nil
+			return err
+		})
+		if err != nil {
+			uc.logger.Warn("failed to resolve incident missing from alert feed",
+				zap.String("feed", feed),
+				zap.Int("incident_id", imp.IncidentID),
+				zap.Error(err))
+			continue
+		}
+		if done {
+			resolved++
+		}
+		webhookIDs = append(webhookIDs, ids...)
+	}
+
+	return resolved, webhookIDs, nil
+}
+
+// applyAlert переносит в зону текст, форму, уровень опасности и срок действия оповещения.
+// Оповещение без уровня опасности не меняет уровень зоны (у новой — medium)
+func applyAlert(incident *entity.Incident, alert entity.ExternalAlert) {
+	incident.Name = truncateRunes(alert.Name, maxIncidentName)
+	if incident.Name == "" {
+		incident.Name = truncateRunes(alert.ID, maxIncidentName)
+	}
+	incident.Descr = alert.Descr
+	incident.Latitude = alert.Latitude
+	incident.Longitude = alert.Longitude
+	incident.Radius = alert.Radius
+	incident.Polygons = alert.Polygons
+	incident.ExpiresAt = alert.ExpiresAt
+
+	switch {
+	case entity.ValidSeverity(alert.Severity):
+		incident.Severity = alert.Severity
+	case incident.Severity == "":
+		incident.Severity = entity.SeverityMedium
+	}
+}
+
+func alertExpired(alert entity.ExternalAlert, now time.Time) bool {
+	return alert.ExpiresAt != nil && !alert.ExpiresAt.After(now)
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
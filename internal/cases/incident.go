@@ -2,95 +2,621 @@ package cases
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"math"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/alerts"
+	"github.com/4otis/geonotify-service/internal/port/geo"
 	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/schedule"
 	"go.uber.org/zap"
 )
 
 var _ IncidentUseCase = (*IncidentUseCaseImpl)(nil)
 
 type IncidentUseCase interface {
-	CreateIncident(ctx context.Context, incident entity.Incident) (incID int, err error)
+	// CreateIncident с checkOverlap (nil — по настройке) отклоняет зону, перекрывающую активные,
+	// ошибкой *entity.OverlapError
+	CreateIncident(ctx context.Context, incident entity.Incident, checkOverlap *bool) (incID int, err error)
 	ReadIncident(ctx context.Context, incId int) (*entity.Incident, error)
-	ReadIncidentsWithPagination(ctx context.Context, page, limit int) (IncidentsWithPagination, error)
-	UpdateIncident(ctx context.Context, incident entity.Incident) error
+	// ReadIncidentsWithPagination с estimate считает страницы по оценке числа инцидентов, а не по COUNT(*)
+	ReadIncidentsWithPagination(ctx context.Context, page, limit int, status string, estimate bool) (IncidentsWithPagination, error)
+	// ReadIncidentsAfter — постраничный вывод по курсору; пустой cursor — первая страница
+	ReadIncidentsAfter(ctx context.Context, cursor string, limit int, status string) (IncidentsPage, error)
+	// IncidentsVersion меняется при любом изменении инцидентов; по ней строится ETag списка
+	IncidentsVersion(ctx context.Context) (string, error)
+	// UpdateIncident и UpdateIncidentPartial не повышают опубликованную зону до критической сразу:
+	// остальная правка применяется, а на критичность возвращается заявка на одобрение
+	UpdateIncident(ctx context.Context, incident entity.Incident) (*entity.Approval, error)
+	UpdateIncidentPartial(ctx context.Context, incID int, patch entity.IncidentPatch) (*entity.Approval, error)
+	UpdateIncidentGeometry(ctx context.Context, incID int, geometry entity.IncidentGeometry) error
 	DeleteIncident(ctx context.Context, incID int) error
+	ApplySchedules(ctx context.Context, now time.Time) (changed int, err error)
+	ExpireIncidents(ctx context.Context) (expired int, err error)
+	BatchIncidents(ctx context.Context, incIDs []int, action string) (affected int, err error)
+	// PublishIncident для критической зоны не публикует ее, а возвращает заявку на одобрение
+	PublishIncident(ctx context.Context, incID int) (*entity.Incident, *entity.Approval, error)
+	ArchiveIncident(ctx context.Context, incID int) (*entity.Incident, error)
+	// TransitionIncident переводит зону в другую стадию жизни опасности по разрешенному переходу
+	TransitionIncident(ctx context.Context, incID int, state string) (*entity.Incident, error)
+	// MergeIncidents объединяет опубликованные зоны в одну, снимая исходные с публикации
+	MergeIncidents(ctx context.Context, incIDs []int, name, descr string) (*entity.Incident, error)
+	// SplitIncident разделяет опубликованную зону на части, снимая исходную с публикации
+	SplitIncident(ctx context.Context, incID int, parts []entity.IncidentPart) ([]*entity.Incident, error)
+	IncidentLineage(ctx context.Context, incID int) ([]entity.IncidentLink, error)
+	IncidentRelations(ctx context.Context, incID int) ([]entity.IncidentRelation, error)
+	AddIncidentRelation(ctx context.Context, relation entity.IncidentRelation) (*entity.IncidentRelation, error)
+	DeleteIncidentRelation(ctx context.Context, relation entity.IncidentRelation) error
+	// IncidentVersions возвращает версии формы зоны от первой к последней
+	IncidentVersions(ctx context.Context, incID int) ([]entity.GeometryVersion, error)
+	IncidentTranslations(ctx context.Context, incID int) ([]entity.IncidentTranslation, error)
+	SetIncidentTranslation(ctx context.Context, translation entity.IncidentTranslation) (*entity.IncidentTranslation, error)
+	DeleteIncidentTranslation(ctx context.Context, incID int, locale string) error
+	// IncidentComments возвращает limit последних комментариев зоны, новые первыми
+	IncidentComments(ctx context.Context, incID, limit int) ([]entity.IncidentComment, error)
+	AddIncidentComment(ctx context.Context, incID int, body string) (*entity.IncidentComment, error)
+	// LocalizeIncidents подставляет переводы названий и описаний на язык из locales (по убыванию предпочтения)
+	LocalizeIncidents(ctx context.Context, incidents []*entity.Incident, locales []string) ([]*entity.Incident, error)
+	// ImportAlerts применяет оповещения внешнего источника: создает, обновляет и завершает его зоны
+	ImportAlerts(ctx context.Context, feed ImportFeed, alerts []entity.ExternalAlert) (ImportResult, error)
+	// BacktestIncident прогоняет недавние проверки координат через зону и считает, кого бы она оповестила
+	BacktestIncident(ctx context.Context, query BacktestQuery) (BacktestResult, error)
 }
 
 type IncidentUseCaseImpl struct {
 	repo         repo.IncidentRepo
-	locationCase LocationUseCase
-	logger       *zap.Logger
+	approvals    repo.ApprovalRepo
+	lineage      repo.LineageRepo
+	relations    repo.RelationRepo
+	versions     repo.GeometryVersionRepo
+	translations repo.TranslationRepo
+	comments     repo.CommentRepo
+	// commentsInWebhooks — события зон несут ее последний комментарий, а новый комментарий
+	// к опубликованной зоне отправляет incident.updated
+	commentsInWebhooks bool
+	// defaultLocale — язык исходных названий и описаний зон, канонический тег BCP 47
+	defaultLocale string
+	imports       repo.ImportRepo
+	checks        repo.CheckRepo
+	tx            repo.Transactor
+	locationCase  LocationUseCase
+	geocoder      geo.Geocoder
+	// dispatcher nil — пользователи рядом с новыми зонами не оповещаются
+	dispatcher *AlertDispatcher
+	locations  alerts.LocationIndex
+	// area nil — зоны можно создавать где угодно
+	area geo.OperatingArea
+	// reviewRequired — черновик публикует не его автор, а публикация при создании запрещена
+	reviewRequired bool
+	overlap        OverlapPolicy
+	// events nil — одобряющие не оповещаются, заявки видны только в API
+	events         repo.EventRepo
+	approvalStream string
+	webhooks       *WebhookOutbox
+	logger         *zap.Logger
 }
 
-func NewIncidentUseCase(repo repo.IncidentRepo,
-	locationCase LocationUseCase, logger *zap.Logger) *IncidentUseCaseImpl {
+// OverlapPolicy — проверка перекрытия новой зоны с активными: Enabled — проверять без явного
+// check_overlap, Threshold — доля площади меньшей зоны (0–1), начиная с которой зона не создается
+type OverlapPolicy struct {
+	Enabled   bool
+	Threshold float64
+}
+
+// geocoder может быть nil — тогда инциденты создаются только по координатам
+func NewIncidentUseCase(repo repo.IncidentRepo, approvals repo.ApprovalRepo, lineage repo.LineageRepo,
+	relations repo.RelationRepo, versions repo.GeometryVersionRepo, translations repo.TranslationRepo, comments repo.CommentRepo, commentsInWebhooks bool,
+	defaultLocale string, imports repo.ImportRepo, checks repo.CheckRepo, tx repo.Transactor,
+	locationCase LocationUseCase, geocoder geo.Geocoder,
+	dispatcher *AlertDispatcher, locations alerts.LocationIndex, area geo.OperatingArea,
+	reviewRequired bool, overlap OverlapPolicy, events repo.EventRepo, approvalStream string,
+	webhooks *WebhookOutbox, logger *zap.Logger) *IncidentUseCaseImpl {
 	return &IncidentUseCaseImpl{
-		repo:         repo,
-		locationCase: locationCase,
-		logger:       logger,
+		repo:               repo,
+		approvals:          approvals,
+		lineage:            lineage,
+		relations:          relations,
+		versions:           versions,
+		translations:       translations,
+		comments:           comments,
+		commentsInWebhooks: commentsInWebhooks,
+		defaultLocale:      defaultLocale,
+		imports:            imports,
+		checks:             checks,
+		tx:                 tx,
+		locationCase:       locationCase,
+		geocoder:           geocoder,
+		dispatcher:         dispatcher,
+		locations:          locations,
+		area:               area,
+		reviewRequired:     reviewRequired,
+		overlap:            overlap,
+		events:             events,
+		approvalStream:     approvalStream,
+		webhooks:           webhooks,
+		logger:             logger,
 	}
 }
 
-func (uc *IncidentUseCaseImpl) CreateIncident(ctx context.Context, incident entity.Incident) (incID int, err error) {
-	incID, err = uc.repo.Create(ctx, incident)
+// CreateIncident создает зону в статусе incident.Status. Без статуса зона публикуется сразу,
+// если исполнитель может публиковать, ревью не требуется и зона не критическая, иначе создается черновик
+func (uc *IncidentUseCaseImpl) CreateIncident(ctx context.Context, incident entity.Incident, checkOverlap *bool) (incID int, err error) {
+	incident.CreatedBy = actorName(ctx)
+	if incident.Severity == "" {
+		incident.Severity = entity.SeverityMedium
+	}
+	if !entity.ValidSeverity(incident.Severity) {
+		return 0, entity.ErrInvalidSeverity
+	}
+	if incident.Visibility == "" {
+		incident.Visibility = entity.VisibilityPublic
+	}
+	if err := checkVisibility(ctx, incident.Visibility); err != nil {
+		return 0, err
+	}
+	switch incident.State {
+	case "":
+		incident.State = entity.StateActive
+	case entity.StatePlanned, entity.StateActive, entity.StateContained:
+	default:
+		return 0, entity.ErrInvalidState
+	}
+	critical := incident.Severity == entity.SeverityCritical
+
+	switch incident.Status {
+	case "":
+		incident.Status = entity.IncidentDraft
+		if canPublish(ctx) && !uc.reviewRequired && !critical {
+			incident.Status = entity.IncidentPublished
+		}
+	case entity.IncidentDraft:
+	case entity.IncidentPublished:
+		if err := requirePublisher(ctx); err != nil {
+			return 0, err
+		}
+		if critical {
+			return 0, entity.ErrApprovalRequired
+		}
+		if uc.reviewRequired {
+			return 0, entity.ErrSelfReview
+		}
+	default:
+		return 0, entity.ErrInvalidStatus
+	}
+	if err := uc.resolveAddress(ctx, &incident); err != nil {
+		return 0, err
+	}
+	if err := uc.checkArea(incident.Latitude, incident.Longitude); err != nil {
+		return 0, err
+	}
+	if err := validateExpiry(&incident, time.Now()); err != nil {
+		return 0, err
+	}
+	if err := applySchedule(&incident, time.Now()); err != nil {
+		return 0, err
+	}
+	if (checkOverlap == nil && uc.overlap.Enabled) || (checkOverlap != nil && *checkOverlap) {
+		if err := uc.checkOverlap(ctx, &incident); err != nil {
+			return 0, err
+		}
+	}
+
+	var webhookIDs []int
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		incID, err = uc.repo.Create(ctx, incident)
+		if err != nil {
+			return err
+		}
+
+		webhookIDs, err = uc.incidentWebhook(ctx, incID, nil)
+		return err
+	})
 	if err != nil {
 		return 0, err
 	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
 
 	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
 		uc.logger.Warn("failed to invalidate cache after creating incident",
 			zap.Error(err))
 	}
 
+	if incident.Status == entity.IncidentPublished && incident.IsActive && uc.dispatcher != nil {
+		uc.notifyUsersNearby(ctx, incID)
+	}
+
 	return incID, nil
 }
 
+// notifyUsersNearby отправляет алерт пользователям, чья последняя точка попала в новую или опубликованную зону
+func (uc *IncidentUseCaseImpl) notifyUsersNearby(ctx context.Context, incID int) {
+	inc, err := uc.repo.Read(ctx, incID)
+	if err != nil {
+		uc.logger.Warn("failed to read incident for alerts",
+			zap.Error(err),
+			zap.Int("incident_id", incID))
+		return
+	}
+
+	userIDs, err := uc.locations.UsersWithin(ctx, inc.Latitude, inc.Longitude, inc.Radius)
+	if errors.Is(err, entity.ErrDependencyUnavailable) {
+		return
+	}
+	if err != nil {
+		uc.logger.Warn("failed to find users near new incident",
+			zap.Error(err),
+			zap.Int("incident_id", incID))
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, userID := range userIDs {
+		uc.dispatcher.Dispatch(ctx, entity.Alert{
+			Type:      entity.AlertIncidentCreated,
+			UserID:    userID,
+			Incidents: []*entity.Incident{inc},
+			CreatedAt: now,
+		})
+	}
+}
+
+// ReadIncident не раскрывает зону, которую исполнитель не видит в списках: она не найдена
 func (uc *IncidentUseCaseImpl) ReadIncident(ctx context.Context, incId int) (*entity.Incident, error) {
-	return uc.repo.Read(ctx, incId)
+	incident, err := uc.repo.Read(ctx, incId)
+	if err != nil {
+		return nil, err
+	}
+	if !canSee(ctx, incident.Visibility) {
+		return nil, entity.ErrIncidentNotFound
+	}
+	if err := uc.withRelations(ctx, []*entity.Incident{incident}); err != nil {
+		return nil, err
+	}
+	return incident, nil
 }
 
-func (uc *IncidentUseCaseImpl) ReadIncidentsWithPagination(ctx context.Context, page, limit int) (IncidentsWithPagination, error) {
+func (uc *IncidentUseCaseImpl) ReadIncidentsWithPagination(ctx context.Context, page, limit int, status string, estimate bool) (IncidentsWithPagination, error) {
 
 	if page < 1 {
 		page = 1
 	}
 
-	incidents, totalCount, err := uc.repo.ReadWithPagination(ctx, page, limit)
+	incidents, totalCount, err := uc.repo.ReadWithPagination(ctx, page, limit, status, VisibleIncidents(ctx), estimate)
 	if err != nil {
 		return IncidentsWithPagination{}, err
 	}
+	if err := uc.withRelations(ctx, incidents); err != nil {
+		return IncidentsWithPagination{}, err
+	}
 
 	totalPages := int(math.Ceil(float64(totalCount) / float64(limit)))
 
 	return IncidentsWithPagination{
 		Incidents:  incidents,
 		TotalPages: totalPages,
+		Estimated:  estimate,
 	}, nil
 }
 
-func (uc *IncidentUseCaseImpl) UpdateIncident(ctx context.Context, incident entity.Incident) error {
-	err := uc.repo.Update(ctx, incident)
+// ReadIncidentsAfter не считает общее число инцидентов и не пропускает строки через OFFSET,
+// поэтому не замедляется на дальних страницах. NextCursor пуст на последней странице
+func (uc *IncidentUseCaseImpl) ReadIncidentsAfter(ctx context.Context, cursor string, limit int, status string) (IncidentsPage, error) {
+	var after *entity.IncidentCursor
+	if cursor != "" {
+		c, err := decodeIncidentCursor(cursor)
+		if err != nil {
+			return IncidentsPage{}, err
+		}
+		after = &c
+	}
+
+	// лишняя строка показывает, есть ли следующая страница
+	incidents, err := uc.repo.ReadAfter(ctx, after, limit+1, status, VisibleIncidents(ctx))
+	if err != nil {
+		return IncidentsPage{}, err
+	}
+
+	page := IncidentsPage{Incidents: incidents}
+	if len(incidents) > limit {
+		page.Incidents = incidents[:limit]
+		last := page.Incidents[limit-1]
+		page.NextCursor = encodeIncidentCursor(entity.IncidentCursor{UpdatedAt: last.UpdatedAt, ID: last.ID})
+	}
+	if err := uc.withRelations(ctx, page.Incidents); err != nil {
+		return IncidentsPage{}, err
+	}
+
+	return page, nil
+}
+
+// курсор непрозрачен для клиента: base64 от "<updated_at в микросекундах>:<id>"
+func (uc *IncidentUseCaseImpl) IncidentsVersion(ctx context.Context) (string, error) {
+	return uc.locationCase.IncidentsVersion(ctx)
+}
+
+func encodeIncidentCursor(c entity.IncidentCursor) string {
+	raw := strconv.FormatInt(c.UpdatedAt.UnixMicro(), 10) + ":" + strconv.Itoa(c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeIncidentCursor(cursor string) (entity.IncidentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return entity.IncidentCursor{}, entity.ErrInvalidCursor
+	}
+
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return entity.IncidentCursor{}, entity.ErrInvalidCursor
+	}
+	us, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return entity.IncidentCursor{}, entity.ErrInvalidCursor
+	}
+	incID, err := strconv.Atoi(id)
 	if err != nil {
+		return entity.IncidentCursor{}, entity.ErrInvalidCursor
+	}
+
+	return entity.IncidentCursor{UpdatedAt: time.UnixMicro(us).UTC(), ID: incID}, nil
+}
+
+func (uc *IncidentUseCaseImpl) UpdateIncident(ctx context.Context, incident entity.Incident) (*entity.Approval, error) {
+	incident.UpdatedBy = actorName(ctx)
+	if incident.Severity != "" && !entity.ValidSeverity(incident.Severity) {
+		return nil, entity.ErrInvalidSeverity
+	}
+	if incident.Visibility != "" {
+		if err := checkVisibility(ctx, incident.Visibility); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.resolveAddress(ctx, &incident); err != nil {
+		return nil, err
+	}
+	if err := uc.checkArea(incident.Latitude, incident.Longitude); err != nil {
+		return nil, err
+	}
+	if err := validateExpiry(&incident, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var (
+		approval   *entity.Approval
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkEditable(ctx, incident.ID); err != nil {
+			return err
+		}
+		current, err := uc.repo.Read(ctx, incident.ID)
+		if err != nil {
+			return err
+		}
+		escalate := escalates(current, incident.Severity)
+		if escalate {
+			incident.Severity = current.Severity
+		}
+
+		incident.State, webhookIDs, err = uc.applyActive(ctx, current, incident.IsActive)
+		if err != nil {
+			return err
+		}
+		if err := applySchedule(&incident, time.Now()); err != nil {
+			return err
+		}
+		if err := uc.repo.Update(ctx, incident); err != nil {
+			return err
+		}
+
+		ids, err := uc.incidentWebhook(ctx, incident.ID, current)
+		webhookIDs = append(webhookIDs, ids...)
+		if err != nil || !escalate {
+			return err
+		}
+
+		approval, err = uc.requestEscalation(ctx, incident.ID)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
 
 	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
 		uc.logger.Warn("failed to invalidate cache after updating incident",
 			zap.Error(err))
 	}
 
-	return nil
+	uc.logEscalation(approval)
+	return approval, nil
+}
+
+// UpdateIncidentPartial меняет только переданные поля. Расписание и срок действия
+// проверяются на инциденте с уже примененным патчем
+func (uc *IncidentUseCaseImpl) UpdateIncidentPartial(ctx context.Context, incID int, patch entity.IncidentPatch) (*entity.Approval, error) {
+	patch.UpdatedBy = actorName(ctx)
+	if patch.Severity != nil && !entity.ValidSeverity(*patch.Severity) {
+		return nil, entity.ErrInvalidSeverity
+	}
+	if patch.Visibility != nil {
+		if err := checkVisibility(ctx, *patch.Visibility); err != nil {
+			return nil, err
+		}
+	}
+	if patch.Address != nil && patch.Latitude == nil && patch.Longitude == nil {
+		located := entity.Incident{Address: *patch.Address}
+		if err := uc.resolveAddress(ctx, &located); err != nil {
+			return nil, err
+		}
+		if located.Latitude != 0 || located.Longitude != 0 {
+			patch.Latitude = &located.Latitude
+			patch.Longitude = &located.Longitude
+		}
+	}
 
+	var (
+		approval   *entity.Approval
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := uc.repo.Read(ctx, incID)
+		if err != nil {
+			return err
+		}
+		if !canSee(ctx, current.Visibility) {
+			return entity.ErrIncidentNotFound
+		}
+		if current.Status != entity.IncidentDraft {
+			if err := requirePublisher(ctx); err != nil {
+				return err
+			}
+		}
+		if err := uc.checkNotPending(ctx, incID); err != nil {
+			return err
+		}
+		escalate := patch.Severity != nil && escalates(current, *patch.Severity)
+		if escalate {
+			patch.Severity = nil
+		}
+
+		now := time.Now()
+		merged := *current
+		patch.Apply(&merged)
+		if patch.IsActive != nil {
+			merged.State, webhookIDs, err = uc.applyActive(ctx, current, *patch.IsActive)
+			if err != nil {
+				return err
+			}
+		}
+
+		// зоны, созданные до сужения области, можно править, пока их не двигают
+		if patch.Latitude != nil || patch.Longitude != nil {
+			if err := uc.checkArea(merged.Latitude, merged.Longitude); err != nil {
+				return err
+			}
+		}
+
+		if patch.ExpiresAt != nil && !patch.ExpiresAt.IsZero() {
+			if err := validateExpiry(&merged, now); err != nil {
+				return err
+			}
+			patch.ExpiresAt = merged.ExpiresAt
+		}
+		if err := applySchedule(&merged, now); err != nil {
+			return err
+		}
+		patch.IsActive = &merged.IsActive
+
+		if err := uc.repo.UpdatePartial(ctx, incID, patch); err != nil {
+			return err
+		}
+
+		ids, err := uc.incidentWebhook(ctx, incID, current)
+		webhookIDs = append(webhookIDs, ids...)
+		if err != nil || !escalate {
+			return err
+		}
+
+		approval, err = uc.requestEscalation(ctx, incID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after patching incident",
+			zap.Error(err))
+	}
+
+	uc.logEscalation(approval)
+	return approval, nil
+}
+
+// escalates сообщает, что правка поднимает опубликованную зону до критической: это решает второй оператор
+func escalates(current *entity.Incident, severity string) bool {
+	return current.Status == entity.IncidentPublished &&
+		severity == entity.SeverityCritical &&
+		current.Severity != entity.SeverityCritical
+}
+
+// logEscalation пишет в журнал заявку на повышение критичности; nil — правка обошлась без заявки
+func (uc *IncidentUseCaseImpl) logEscalation(approval *entity.Approval) {
+	if approval == nil {
+		return
+	}
+
+	uc.logger.Info("critical escalation awaits approval",
+		zap.Int("id", approval.IncidentID),
+		zap.Int("approval_id", approval.ID),
+		zap.String("requested_by", approval.RequestedBy))
+}
+
+func (uc *IncidentUseCaseImpl) UpdateIncidentGeometry(ctx context.Context, incID int, geometry entity.IncidentGeometry) error {
+	geometry.UpdatedBy = actorName(ctx)
+	if err := uc.checkArea(geometry.Latitude, geometry.Longitude); err != nil {
+		return err
+	}
+
+	var webhookIDs []int
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkEditable(ctx, incID); err != nil {
+			return err
+		}
+		before, err := uc.incidentSnapshot(ctx, incID)
+		if err != nil {
+			return err
+		}
+		if err := uc.repo.UpdateGeometry(ctx, incID, geometry); err != nil {
+			return err
+		}
+
+		webhookIDs, err = uc.incidentWebhook(ctx, incID, before)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after updating incident geometry",
+			zap.Error(err))
+	}
+
+	return nil
 }
 
 func (uc *IncidentUseCaseImpl) DeleteIncident(ctx context.Context, incID int) error {
-	err := uc.repo.Delete(ctx, incID)
+	var webhookIDs []int
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkEditable(ctx, incID); err != nil {
+			return err
+		}
+		before, err := uc.incidentSnapshot(ctx, incID)
+		if err != nil {
+			return err
+		}
+		if err := uc.repo.Delete(ctx, incID); err != nil {
+			return err
+		}
+
+		webhookIDs, err = uc.incidentWebhook(ctx, incID, before)
+		return err
+	})
 	if err != nil {
 		return err
 	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
 
 	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
 		uc.logger.Warn("failed to invalidate cache after deleting incident",
@@ -100,7 +626,595 @@ func (uc *IncidentUseCaseImpl) DeleteIncident(ctx context.Context, incID int) er
 	return nil
 }
 
+// BatchIncidents применяет действие ко всем инцидентам из списка в одной транзакции.
+// Если хотя бы один инцидент не найден или ждет решения по заявке, изменения откатываются. Доступно только публикатору
+func (uc *IncidentUseCaseImpl) BatchIncidents(ctx context.Context, incIDs []int, action string) (affected int, err error) {
+	if err := requirePublisher(ctx); err != nil {
+		return 0, err
+	}
+	incIDs = uniqueIDs(incIDs)
+
+	var webhookIDs []int
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before := make(map[int]*entity.Incident, len(incIDs))
+		var pending []int
+		for _, incID := range incIDs {
+			err := uc.checkNotPending(ctx, incID)
+			if errors.Is(err, entity.ErrApprovalPending) {
+				pending = append(pending, incID)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			snapshot, err := uc.incidentSnapshot(ctx, incID)
+			if err != nil {
+				return err
+			}
+			before[incID] = snapshot
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%w: %v", entity.ErrApprovalPending, pending)
+		}
+
+		var changedIDs []int
+		switch action {
+		case entity.BatchActivate, entity.BatchDeactivate:
+			var ids []int
+			changedIDs, ids, err = uc.applyActiveBatch(ctx, incIDs, action == entity.BatchActivate)
+			webhookIDs = append(webhookIDs, ids...)
+		case entity.BatchDelete:
+			changedIDs, err = uc.repo.DeleteBatch(ctx, incIDs)
+		default:
+			return entity.ErrInvalidBatchAction
+		}
+		if err != nil {
+			return err
+		}
+
+		if missing := missingIDs(incIDs, changedIDs); len(missing) > 0 {
+			return fmt.Errorf("%w: %v", entity.ErrIncidentNotFound, missing)
+		}
+
+		affected = len(changedIDs)
+		for _, incID := range changedIDs {
+			ids, err := uc.incidentWebhook(ctx, incID, before[incID])
+			if err != nil {
+				return err
+			}
+			webhookIDs = append(webhookIDs, ids...)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after batch incident update",
+			zap.Error(err),
+			zap.String("action", action))
+	}
+
+	return affected, nil
+}
+
+// PublishIncident атомарно выпускает черновик или архивную зону: с этого момента она участвует в проверках.
+// При включенном ревью публикатор не должен быть автором или последним редактором зоны.
+// Критическая зона остается в прежнем статусе до одобрения вторым оператором (см. ApproveIncident)
+func (uc *IncidentUseCaseImpl) PublishIncident(ctx context.Context, incID int) (*entity.Incident, *entity.Approval, error) {
+	if err := requirePublisher(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		published  *entity.Incident
+		approval   *entity.Approval
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := uc.repo.Read(ctx, incID)
+		if err != nil {
+			return err
+		}
+		if current.Status == entity.IncidentPublished {
+			return entity.ErrInvalidStatusTransition
+		}
+
+		name := actorName(ctx)
+		if uc.reviewRequired && name != "" && (name == current.CreatedBy || name == current.UpdatedBy) {
+			return entity.ErrSelfReview
+		}
+
+		if current.Severity == entity.SeverityCritical {
+			approval, err = uc.requestApproval(ctx, current, "")
+			return err
+		}
+
+		published, err = uc.setPublished(ctx, incID, name)
+		if err != nil {
+			return err
+		}
+
+		webhookIDs, err = uc.incidentWebhook(ctx, incID, current)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	if approval != nil {
+		uc.logger.Info("critical incident awaits approval",
+			zap.Int("id", incID),
+			zap.Int("approval_id", approval.ID),
+			zap.String("requested_by", approval.RequestedBy))
+		return nil, approval, nil
+	}
+
+	uc.afterPublish(ctx, published)
+	return published, nil, nil
+}
+
+// setPublished переводит черновик или архивную зону в published и возвращает ее новое состояние
+func (uc *IncidentUseCaseImpl) setPublished(ctx context.Context, incID int, publishedBy string) (*entity.Incident, error) {
+	from := []string{entity.IncidentDraft, entity.IncidentArchived}
+	if err := uc.repo.SetStatus(ctx, incID, from, entity.IncidentPublished, publishedBy); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.Read(ctx, incID)
+}
+
+// afterPublish вызывается после коммита публикации: зона попадает в проверки и оповещает пользователей рядом
+func (uc *IncidentUseCaseImpl) afterPublish(ctx context.Context, published *entity.Incident) {
+	uc.logger.Info("incident published",
+		zap.Int("id", published.ID),
+		zap.String("published_by", published.PublishedBy))
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after publishing incident",
+			zap.Error(err))
+	}
+
+	if published.IsActive && uc.dispatcher != nil {
+		uc.notifyUsersNearby(ctx, published.ID)
+	}
+}
+
+// ArchiveIncident снимает зону с публикации или откладывает черновик, не удаляя его
+func (uc *IncidentUseCaseImpl) ArchiveIncident(ctx context.Context, incID int) (*entity.Incident, error) {
+	if err := requirePublisher(ctx); err != nil {
+		return nil, err
+	}
+
+	var (
+		archived   *entity.Incident
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := uc.repo.Read(ctx, incID)
+		if err != nil {
+			return err
+		}
+		if err := uc.checkNotPending(ctx, incID); err != nil {
+			return err
+		}
+
+		from := []string{entity.IncidentDraft, entity.IncidentPublished}
+		if err := uc.repo.SetStatus(ctx, incID, from, entity.IncidentArchived, actorName(ctx)); err != nil {
+			return err
+		}
+
+		archived, err = uc.repo.Read(ctx, incID)
+		if err != nil {
+			return err
+		}
+
+		webhookIDs, err = uc.incidentWebhook(ctx, incID, current)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	uc.logger.Info("incident archived",
+		zap.Int("id", incID),
+		zap.String("archived_by", archived.UpdatedBy))
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after archiving incident",
+			zap.Error(err))
+	}
+
+	return archived, nil
+}
+
+// checkEditable пускает редактора только к черновикам; действующие и архивные зоны меняет публикатор.
+// Скрытую от редактора зону он не находит. Зону, ожидающую решения по заявке, не меняет никто
+func (uc *IncidentUseCaseImpl) checkEditable(ctx context.Context, incID int) error {
+	if !canPublish(ctx) {
+		current, err := uc.repo.Read(ctx, incID)
+		if err != nil {
+			return err
+		}
+		if !canSee(ctx, current.Visibility) {
+			return entity.ErrIncidentNotFound
+		}
+		if current.Status != entity.IncidentDraft {
+			return entity.ErrForbidden
+		}
+	}
+
+	return uc.checkNotPending(ctx, incID)
+}
+
+// checkNotPending возвращает entity.ErrApprovalPending, если по зоне ждет решения заявка:
+// одобряющий должен решать по той зоне, которую видел. Автор может отозвать заявку и править дальше
+func (uc *IncidentUseCaseImpl) checkNotPending(ctx context.Context, incID int) error {
+	pending, err := uc.approvals.HasPending(ctx, incID)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return entity.ErrApprovalPending
+	}
+
+	return nil
+}
+
+// incidentEventsSubscribed сообщает, нужны ли получателям события зон: без подписки состояние зон не перечитывается
+func (uc *IncidentUseCaseImpl) incidentEventsSubscribed(ctx context.Context) (bool, error) {
+	return uc.webhooks.Subscribed(ctx,
+		entity.WebhookIncidentCreated,
+		entity.WebhookIncidentUpdated,
+		entity.WebhookIncidentDeactivated)
+}
+
+// incidentSnapshot читает зону до изменения для incidentWebhook; nil — зоны нет или события зон не нужны
+func (uc *IncidentUseCaseImpl) incidentSnapshot(ctx context.Context, incID int) (*entity.Incident, error) {
+	subscribed, err := uc.incidentEventsSubscribed(ctx)
+	if err != nil || !subscribed {
+		return nil, err
+	}
+
+	incident, err := uc.repo.Read(ctx, incID)
+	if errors.Is(err, entity.ErrIncidentNotFound) {
+		return nil, nil
+	}
+
+	return incident, err
+}
+
+// incidentWebhook сравнивает зону до изменения с текущей и кладет событие в outbox; вызывается внутри транзакции.
+// Получатель видит только опубликованные зоны: публикация — incident.created, выключение, снятие с публикации
+// и удаление — incident.deactivated, остальные изменения опубликованной зоны — incident.updated
+func (uc *IncidentUseCaseImpl) incidentWebhook(ctx context.Context, incID int, before *entity.Incident) ([]int, error) {
+	subscribed, err := uc.incidentEventsSubscribed(ctx)
+	if err != nil || !subscribed {
+		return nil, err
+	}
+
+	after, err := uc.incidentSnapshot(ctx, incID)
+	if err != nil {
+		return nil, err
+	}
+
+	wasPublished := before != nil && before.Status == entity.IncidentPublished
+	isPublished := after != nil && after.Status == entity.IncidentPublished
+
+	var eventType string
+	switch {
+	case !wasPublished && isPublished:
+		eventType = entity.WebhookIncidentCreated
+	case wasPublished && (!isPublished || (before.IsActive && !after.IsActive)):
+		eventType = entity.WebhookIncidentDeactivated
+	case wasPublished:
+		eventType = entity.WebhookIncidentUpdated
+	default:
+		return nil, nil
+	}
+
+	data := map[string]interface{}{
+		"incident_id": incID,
+		"deleted":     after == nil,
+	}
+	if after != nil {
+		data["incident"] = after
+	} else {
+		data["incident"] = before
+	}
+	if actor := actorName(ctx); actor != "" {
+		data["actor"] = actor
+	}
+	comment, err := uc.latestComment(ctx, incID)
+	if err != nil {
+		return nil, err
+	}
+	if comment != nil {
+		data["latest_comment"] = comment
+	}
+	supersedes, supersededBy, err := uc.supersessions(ctx, incID)
+	if err != nil {
+		return nil, err
+	}
+	if len(supersedes) > 0 {
+		data["supersedes"] = supersedes
+	}
+	if len(supersededBy) > 0 {
+		data["superseded_by"] = supersededBy
+	}
+
+	return uc.webhooks.Enqueue(ctx, eventType, 0, data)
+}
+
+// expiredWebhook пишет incident.deactivated для зоны, завершенной по expires_at
+func (uc *IncidentUseCaseImpl) expiredWebhook(ctx context.Context, change entity.StateChange) ([]int, error) {
+	after, err := uc.incidentSnapshot(ctx, change.IncidentID)
+	if err != nil || after == nil {
+		return nil, err
+	}
+
+	before := *after
+	before.State = change.From
+	before.IsActive = entity.StateInEffect(change.From)
+
+	return uc.incidentWebhook(ctx, change.IncidentID, &before)
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
+
+func missingIDs(requested, found []int) []int {
+	foundSet := make(map[int]struct{}, len(found))
+	for _, id := range found {
+		foundSet[id] = struct{}{}
+	}
+
+	var missing []int
+	for _, id := range requested {
+		if _, ok := foundSet[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// ApplySchedules включает и выключает инциденты с расписанием в соответствии с текущим временем
+func (uc *IncidentUseCaseImpl) ApplySchedules(ctx context.Context, now time.Time) (changed int, err error) {
+	incidents, err := uc.repo.ReadScheduled(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, incident := range incidents {
+		window, err := scheduleWindow(incident)
+		if err != nil {
+			uc.logger.Warn("skipping incident with invalid schedule",
+				zap.Int("id", incident.ID),
+				zap.String("schedule", incident.Schedule),
+				zap.Error(err))
+			continue
+		}
+
+		active := window.ActiveAt(now)
+		if active == incident.IsActive {
+			continue
+		}
+
+		var webhookIDs []int
+		err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.repo.SetActive(ctx, incident.ID, active); err != nil {
+				return err
+			}
+
+			var err error
+			webhookIDs, err = uc.incidentWebhook(ctx, incident.ID, incident)
+			return err
+		})
+		if err != nil {
+			return changed, err
+		}
+		uc.webhooks.Notify(ctx, 0, webhookIDs)
+		changed++
+
+		uc.logger.Info("incident toggled by schedule",
+			zap.Int("id", incident.ID),
+			zap.Bool("is_active", active))
+	}
+
+	if changed > 0 {
+		if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+			uc.logger.Warn("failed to invalidate cache after applying schedules",
+				zap.Error(err))
+		}
+	}
+
+	return changed, nil
+}
+
+// ExpireIncidents завершает (переводит в resolved) инциденты, у которых истек expires_at
+func (uc *IncidentUseCaseImpl) ExpireIncidents(ctx context.Context) (expired int, err error) {
+	var (
+		incIDs     []int
+		webhookIDs []int
+	)
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		changes, err := uc.repo.ResolveExpired(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range changes {
+			incIDs = append(incIDs, change.IncidentID)
+
+			ids, err := uc.expiredWebhook(ctx, change)
+			if err != nil {
+				return err
+			}
+			webhookIDs = append(webhookIDs, ids...)
+
+			ids, err = uc.stateWebhook(ctx, change)
+			if err != nil {
+				return err
+			}
+			webhookIDs = append(webhookIDs, ids...)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	if len(incIDs) == 0 {
+		return 0, nil
+	}
+
+	uc.logger.Info("incidents expired",
+		zap.Ints("ids", incIDs))
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after expiring incidents",
+			zap.Error(err))
+	}
+
+	return len(incIDs), nil
+}
+
+// NextActivation возвращает начало следующего окна активности или nil для инцидентов без расписания
+// и зон, которые по стадии не действуют
+func NextActivation(incident *entity.Incident, now time.Time) *time.Time {
+	if !entity.StateInEffect(incident.State) {
+		return nil
+	}
+
+	window, err := scheduleWindow(incident)
+	if err != nil || window == nil {
+		return nil
+	}
+
+	next := window.NextActivation(now)
+	return &next
+}
+
+func scheduleWindow(incident *entity.Incident) (*schedule.Window, error) {
+	if incident.Schedule == "" {
+		return nil, nil
+	}
+
+	return schedule.Parse(incident.Schedule, time.Duration(incident.ScheduleDurationMin)*time.Minute)
+}
+
+// resolveAddress заполняет координаты по адресу, если они не переданы явно.
+// При явных координатах адрес сохраняется как есть, без геокодирования
+// checkArea проверяет, что центр зоны лежит в области работы сервиса
+// checkOverlap сравнивает зону с активными опубликованными зонами. Проверка не блокирует
+// одновременное создание двух похожих зон — она защищает от случайных дублей, а не от гонок
+func (uc *IncidentUseCaseImpl) checkOverlap(ctx context.Context, incident *entity.Incident) error {
+	active, err := uc.repo.ReadAllActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read active incidents: %w", err)
+	}
+
+	if ids := overlapping(incident, active, uc.overlap.Threshold); len(ids) > 0 {
+		return &entity.OverlapError{IncidentIDs: ids}
+	}
+	return nil
+}
+
+// checkVisibility проверяет видимость, которую выставляет исполнитель: скрыть зону от редакторов может только публикатор
+func checkVisibility(ctx context.Context, visibility string) error {
+	if !entity.ValidVisibility(visibility) {
+		return entity.ErrInvalidVisibility
+	}
+	if visibility == entity.VisibilityInternal {
+		return requirePublisher(ctx)
+	}
+	return nil
+}
+
+func (uc *IncidentUseCaseImpl) checkArea(lat, lng float64) error {
+	if uc.area != nil && !uc.area.Contains(lat, lng) {
+		return entity.ErrOutsideArea
+	}
+	return nil
+}
+
+func (uc *IncidentUseCaseImpl) resolveAddress(ctx context.Context, incident *entity.Incident) error {
+	if incident.Address == "" || incident.Latitude != 0 || incident.Longitude != 0 {
+		return nil
+	}
+
+	if uc.geocoder == nil {
+		return entity.ErrGeocodingDisabled
+	}
+
+	lat, lng, err := uc.geocoder.Geocode(ctx, incident.Address)
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Debug("incident address geocoded",
+		zap.String("address", incident.Address),
+		zap.Float64("lat", lat),
+		zap.Float64("lng", lng))
+
+	incident.Latitude = lat
+	incident.Longitude = lng
+
+	return nil
+}
+
+func validateExpiry(incident *entity.Incident, now time.Time) error {
+	if incident.ExpiresAt == nil {
+		return nil
+	}
+
+	if !incident.ExpiresAt.After(now) {
+		return entity.ErrInvalidExpiry
+	}
+
+	expiresAt := incident.ExpiresAt.UTC()
+	incident.ExpiresAt = &expiresAt
+
+	return nil
+}
+
+// applySchedule проверяет расписание и выставляет is_active: зона действует в стадиях active и contained
+// и, если у нее есть расписание, только внутри окна
+func applySchedule(incident *entity.Incident, now time.Time) error {
+	window, err := scheduleWindow(incident)
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrInvalidSchedule, err)
+	}
+
+	incident.IsActive = entity.StateInEffect(incident.State) && (window == nil || window.ActiveAt(now))
+
+	return nil
+}
+
 type IncidentsWithPagination struct {
 	Incidents  []*entity.Incident
 	TotalPages int
+	// Estimated — TotalPages посчитан по оценке числа инцидентов
+	Estimated bool
+}
+
+type IncidentsPage struct {
+	Incidents  []*entity.Incident
+	NextCursor string
 }
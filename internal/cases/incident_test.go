@@ -0,0 +1,390 @@
+package cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	casemocks "github.com/4otis/geonotify-service/internal/cases/mocks"
+	"github.com/4otis/geonotify-service/internal/entity"
+	deliverymocks "github.com/4otis/geonotify-service/internal/port/delivery/mocks"
+	repomocks "github.com/4otis/geonotify-service/internal/port/repo/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+// incidentDeps — моки зависимостей IncidentUseCaseImpl. Получателей вебхуков нет,
+// поэтому события зон не формируются
+type incidentDeps struct {
+	incidents *repomocks.MockIncidentRepo
+	approvals *repomocks.MockApprovalRepo
+	location  *casemocks.MockLocationUseCase
+}
+
+func newIncidentUseCase(t *testing.T) (*cases.IncidentUseCaseImpl, incidentDeps) {
+	ctrl := gomock.NewController(t)
+	d := incidentDeps{
+		incidents: repomocks.NewMockIncidentRepo(ctrl),
+		approvals: repomocks.NewMockApprovalRepo(ctrl),
+		location:  casemocks.NewMockLocationUseCase(ctrl),
+	}
+
+	tx := repomocks.NewMockTransactor(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	endpoints := repomocks.NewMockWebhookEndpointRepo(ctrl)
+	endpoints.EXPECT().ReadAll(gomock.Any()).Return(nil, nil).AnyTimes()
+
+	logger := zap.NewNop()
+	outbox := cases.NewWebhookOutbox(repomocks.NewMockWebhookRepo(ctrl), endpoints, deliverymocks.NewMockQueue(ctrl), 0, logger)
+
+	uc := cases.NewIncidentUseCase(d.incidents, d.approvals, nil, nil, nil, nil, nil, false,
+		"ru", nil, nil, tx, d.location, nil, nil, nil, nil,
+		false, cases.OverlapPolicy{}, nil, "", outbox, logger)
+	return uc, d
+}
+
+var (
+	publisher = entity.Actor{Name: "alice", Role: entity.RolePublisher}
+	editor    = entity.Actor{Name: "bob", Role: entity.RoleEditor}
+)
+
+func TestCreateIncident(t *testing.T) {
+	tests := []struct {
+		name       string
+		actor      entity.Actor
+		incident   entity.Incident
+		wantStatus string
+		wantErr    error
+	}{
+		{
+			name:       "publisher publishes right away",
+			actor:      publisher,
+			incident:   entity.Incident{Name: "Пожар", Latitude: 55.75, Longitude: 37.61, Radius: 300},
+			wantStatus: entity.IncidentPublished,
+		},
+		{
+			name:       "editor creates draft",
+			actor:      editor,
+			incident:   entity.Incident{Name: "Пожар", Latitude: 55.75, Longitude: 37.61, Radius: 300},
+			wantStatus: entity.IncidentDraft,
+		},
+		{
+			name:       "critical goes to draft",
+			actor:      publisher,
+			incident:   entity.Incident{Name: "Пожар", Severity: entity.SeverityCritical, Radius: 300},
+			wantStatus: entity.IncidentDraft,
+		},
+		{
+			name:     "critical cannot be published on create",
+			actor:    publisher,
+			incident: entity.Incident{Severity: entity.SeverityCritical, Status: entity.IncidentPublished},
+			wantErr:  entity.ErrApprovalRequired,
+		},
+		{
+			name:     "editor cannot publish",
+			actor:    editor,
+			incident: entity.Incident{Status: entity.IncidentPublished},
+			wantErr:  entity.ErrForbidden,
+		},
+		{
+			name:     "editor cannot create internal",
+			actor:    editor,
+			incident: entity.Incident{Visibility: entity.VisibilityInternal},
+			wantErr:  entity.ErrForbidden,
+		},
+		{
+			name:     "unknown severity",
+			actor:    publisher,
+			incident: entity.Incident{Severity: "apocalyptic"},
+			wantErr:  entity.ErrInvalidSeverity,
+		},
+		{
+			name:     "unknown state",
+			actor:    publisher,
+			incident: entity.Incident{State: "forgotten"},
+			wantErr:  entity.ErrInvalidState,
+		},
+		{
+			name:     "expired",
+			actor:    publisher,
+			incident: entity.Incident{ExpiresAt: ptr(time.Now().Add(-time.Hour))},
+			wantErr:  entity.ErrInvalidExpiry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, d := newIncidentUseCase(t)
+			if tt.wantErr == nil {
+				d.incidents.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, inc entity.Incident) (int, error) {
+						if inc.Status != tt.wantStatus || inc.CreatedBy != tt.actor.Name {
+							t.Errorf("created status %q by %q, want %q by %q", inc.Status, inc.CreatedBy, tt.wantStatus, tt.actor.Name)
+						}
+						return 42, nil
+					})
+				d.location.EXPECT().InvalidateIncidentsCache(gomock.Any()).Return(nil)
+			}
+
+			incID, err := uc.CreateIncident(cases.WithActor(context.Background(), tt.actor), tt.incident, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateIncident() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && incID != 42 {
+				t.Errorf("CreateIncident() id = %d, want 42", incID)
+			}
+		})
+	}
+}
+
+func TestUpdateIncidentPartial(t *testing.T) {
+	draft := &entity.Incident{ID: 5, Status: entity.IncidentDraft, State: entity.StateActive, IsActive: true,
+		Severity: entity.SeverityMedium, Visibility: entity.VisibilityPublic}
+	published := &entity.Incident{ID: 5, Status: entity.IncidentPublished, State: entity.StateActive, IsActive: true,
+		Severity: entity.SeverityMedium, Visibility: entity.VisibilityPublic}
+	internal := &entity.Incident{ID: 5, Status: entity.IncidentDraft, State: entity.StateActive, IsActive: true,
+		Visibility: entity.VisibilityInternal}
+
+	tests := []struct {
+		name    string
+		actor   entity.Actor
+		current *entity.Incident
+		readErr error
+		pending bool
+		patch   entity.IncidentPatch
+		// wantSeverity — критичность, которая пишется сразу; nil — не меняется
+		wantSeverity *string
+		wantApproval bool
+		wantErr      error
+	}{
+		{name: "editor renames draft", actor: editor, current: draft, patch: entity.IncidentPatch{Name: ptr("Пожар")}},
+		{name: "publisher renames published", actor: publisher, current: published, patch: entity.IncidentPatch{Name: ptr("Пожар")}},
+		{name: "draft becomes critical right away", actor: editor, current: draft, patch: entity.IncidentPatch{Severity: ptr(entity.SeverityCritical)}, wantSeverity: ptr(entity.SeverityCritical)},
+		{name: "published raised to high right away", actor: publisher, current: published, patch: entity.IncidentPatch{Severity: ptr(entity.SeverityHigh)}, wantSeverity: ptr(entity.SeverityHigh)},
+		{name: "published escalation waits for approval", actor: publisher, current: published, patch: entity.IncidentPatch{Name: ptr("Пожар"), Severity: ptr(entity.SeverityCritical)}, wantApproval: true},
+		{name: "pending approval locks edits", actor: publisher, current: published, pending: true, patch: entity.IncidentPatch{Name: ptr("Пожар")}, wantErr: entity.ErrApprovalPending},
+		{name: "editor cannot edit published", actor: editor, current: published, patch: entity.IncidentPatch{Name: ptr("Пожар")}, wantErr: entity.ErrForbidden},
+		{name: "editor does not see internal", actor: editor, current: internal, patch: entity.IncidentPatch{Name: ptr("Пожар")}, wantErr: entity.ErrIncidentNotFound},
+		{name: "not found", actor: publisher, readErr: entity.ErrIncidentNotFound, patch: entity.IncidentPatch{Name: ptr("Пожар")}, wantErr: entity.ErrIncidentNotFound},
+		{name: "unknown severity", actor: publisher, patch: entity.IncidentPatch{Severity: ptr("apocalyptic")}, wantErr: entity.ErrInvalidSeverity},
+		{name: "expiry in the past", actor: publisher, current: draft, patch: entity.IncidentPatch{ExpiresAt: ptr(time.Now().Add(-time.Minute))}, wantErr: entity.ErrInvalidExpiry},
+		{name: "expiry cleared", actor: editor, current: draft, patch: entity.IncidentPatch{ExpiresAt: &time.Time{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, d := newIncidentUseCase(t)
+			if tt.current != nil || tt.readErr != nil {
+				d.incidents.EXPECT().Read(gomock.Any(), 5).Return(tt.current, tt.readErr).AnyTimes()
+			}
+			d.approvals.EXPECT().HasPending(gomock.Any(), 5).Return(tt.pending, nil).AnyTimes()
+			if tt.wantErr == nil {
+				d.incidents.EXPECT().UpdatePartial(gomock.Any(), 5, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int, patch entity.IncidentPatch) error {
+						if patch.UpdatedBy != tt.actor.Name || patch.IsActive == nil || !*patch.IsActive {
+							t.Errorf("patch = %+v, want updated by %q and still active", patch, tt.actor.Name)
+						}
+						if (patch.Severity == nil) != (tt.wantSeverity == nil) ||
+							(patch.Severity != nil && *patch.Severity != *tt.wantSeverity) {
+							t.Errorf("patch severity = %v, want %v", patch.Severity, tt.wantSeverity)
+						}
+						if tt.patch.ExpiresAt != nil && tt.patch.ExpiresAt.IsZero() &&
+							(patch.ExpiresAt == nil || !patch.ExpiresAt.IsZero()) {
+							t.Errorf("patch expires_at = %v, want cleared", patch.ExpiresAt)
+						}
+						return nil
+					})
+				d.location.EXPECT().InvalidateIncidentsCache(gomock.Any()).Return(nil)
+			}
+			if tt.wantApproval {
+				d.approvals.EXPECT().Create(gomock.Any(), entity.Approval{IncidentID: 5, Severity: entity.SeverityCritical, RequestedBy: tt.actor.Name}).Return(7, nil)
+				d.approvals.EXPECT().ReadForUpdate(gomock.Any(), 7).
+					Return(&entity.Approval{ID: 7, IncidentID: 5, Status: entity.ApprovalPending, Severity: entity.SeverityCritical}, nil)
+			}
+
+			approval, err := uc.UpdateIncidentPartial(cases.WithActor(context.Background(), tt.actor), 5, tt.patch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateIncidentPartial() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantApproval != (approval != nil) {
+				t.Errorf("UpdateIncidentPartial() approval = %v, want approval %v", approval, tt.wantApproval)
+			}
+		})
+	}
+}
+
+func TestPublishIncident(t *testing.T) {
+	draft := &entity.Incident{ID: 9, Status: entity.IncidentDraft, State: entity.StateActive, Severity: entity.SeverityMedium}
+	critical := &entity.Incident{ID: 9, Status: entity.IncidentDraft, State: entity.StateActive, Severity: entity.SeverityCritical}
+	published := &entity.Incident{ID: 9, Status: entity.IncidentPublished, State: entity.StateActive, Severity: entity.SeverityMedium}
+
+	tests := []struct {
+		name         string
+		actor        entity.Actor
+		setup        func(d incidentDeps)
+		wantApproval bool
+		wantErr      error
+	}{
+		{
+			name:  "draft is published",
+			actor: publisher,
+			setup: func(d incidentDeps) {
+				gomock.InOrder(
+					d.incidents.EXPECT().Read(gomock.Any(), 9).Return(draft, nil),
+					d.incidents.EXPECT().SetStatus(gomock.Any(), 9, gomock.Any(), entity.IncidentPublished, publisher.Name).Return(nil),
+					d.incidents.EXPECT().Read(gomock.Any(), 9).Return(published, nil),
+				)
+				d.location.EXPECT().InvalidateIncidentsCache(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "critical waits for approval",
+			actor: publisher,
+			setup: func(d incidentDeps) {
+				d.incidents.EXPECT().Read(gomock.Any(), 9).Return(critical, nil)
+				d.approvals.EXPECT().Create(gomock.Any(), entity.Approval{IncidentID: 9, RequestedBy: publisher.Name}).Return(3, nil)
+				d.approvals.EXPECT().ReadForUpdate(gomock.Any(), 3).
+					Return(&entity.Approval{ID: 3, IncidentID: 9, Status: entity.ApprovalPending, RequestedBy: publisher.Name}, nil)
+			},
+			wantApproval: true,
+		},
+		{
+			name:  "already published",
+			actor: publisher,
+			setup: func(d incidentDeps) {
+				d.incidents.EXPECT().Read(gomock.Any(), 9).Return(published, nil)
+			},
+			wantErr: entity.ErrInvalidStatusTransition,
+		},
+		{
+			name:    "editor cannot publish",
+			actor:   editor,
+			setup:   func(d incidentDeps) {},
+			wantErr: entity.ErrForbidden,
+		},
+		{
+			name:  "approval write fails",
+			actor: publisher,
+			setup: func(d incidentDeps) {
+				d.incidents.EXPECT().Read(gomock.Any(), 9).Return(critical, nil)
+				d.approvals.EXPECT().Create(gomock.Any(), gomock.Any()).Return(0, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, d := newIncidentUseCase(t)
+			tt.setup(d)
+
+			inc, approval, err := uc.PublishIncident(cases.WithActor(context.Background(), tt.actor), 9)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PublishIncident() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.wantApproval != (approval != nil) || tt.wantApproval == (inc != nil) {
+				t.Errorf("PublishIncident() = %v, %v; want approval %v", inc, approval, tt.wantApproval)
+			}
+		})
+	}
+}
+
+func TestDeleteIncident(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   entity.Actor
+		status  string
+		pending bool
+		wantErr error
+	}{
+		{name: "publisher deletes published", actor: publisher, status: entity.IncidentPublished},
+		{name: "pending approval locks deletion", actor: publisher, status: entity.IncidentDraft, pending: true, wantErr: entity.ErrApprovalPending},
+		{name: "editor deletes draft", actor: editor, status: entity.IncidentDraft},
+		{name: "editor cannot delete published", actor: editor, status: entity.IncidentPublished, wantErr: entity.ErrForbidden},
+		{name: "editor cannot delete archived", actor: editor, status: entity.IncidentArchived, wantErr: entity.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, d := newIncidentUseCase(t)
+			d.incidents.EXPECT().Read(gomock.Any(), 4).
+				Return(&entity.Incident{ID: 4, Status: tt.status, Visibility: entity.VisibilityPublic}, nil).AnyTimes()
+			d.approvals.EXPECT().HasPending(gomock.Any(), 4).Return(tt.pending, nil).AnyTimes()
+			if tt.wantErr == nil {
+				d.incidents.EXPECT().Delete(gomock.Any(), 4).Return(nil)
+				d.location.EXPECT().InvalidateIncidentsCache(gomock.Any()).Return(nil)
+			}
+
+			err := uc.DeleteIncident(cases.WithActor(context.Background(), tt.actor), 4)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteIncident() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBatchIncidents(t *testing.T) {
+	tests := []struct {
+		name    string
+		pending map[int]bool
+		wantErr error
+	}{
+		{name: "deletes all", pending: map[int]bool{}},
+		{name: "pending approval locks the whole batch", pending: map[int]bool{2: true}, wantErr: entity.ErrApprovalPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, d := newIncidentUseCase(t)
+			for _, id := range []int{1, 2} {
+				d.approvals.EXPECT().HasPending(gomock.Any(), id).Return(tt.pending[id], nil)
+			}
+			if tt.wantErr == nil {
+				d.incidents.EXPECT().DeleteBatch(gomock.Any(), []int{1, 2}).Return([]int{1, 2}, nil)
+				d.location.EXPECT().InvalidateIncidentsCache(gomock.Any()).Return(nil)
+			}
+
+			_, err := uc.BatchIncidents(cases.WithActor(context.Background(), publisher), []int{1, 2}, entity.BatchDelete)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BatchIncidents() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArchiveIncident(t *testing.T) {
+	tests := []struct {
+		name    string
+		pending bool
+		wantErr error
+	}{
+		{name: "archives published"},
+		{name: "pending approval locks archiving", pending: true, wantErr: entity.ErrApprovalPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, d := newIncidentUseCase(t)
+			d.incidents.EXPECT().Read(gomock.Any(), 4).
+				Return(&entity.Incident{ID: 4, Status: entity.IncidentPublished}, nil).AnyTimes()
+			d.approvals.EXPECT().HasPending(gomock.Any(), 4).Return(tt.pending, nil)
+			if tt.wantErr == nil {
+				d.incidents.EXPECT().SetStatus(gomock.Any(), 4, gomock.Any(), entity.IncidentArchived, publisher.Name).Return(nil)
+				d.location.EXPECT().InvalidateIncidentsCache(gomock.Any()).Return(nil)
+			}
+
+			_, err := uc.ArchiveIncident(cases.WithActor(context.Background(), publisher), 4)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ArchiveIncident() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
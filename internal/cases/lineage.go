@@ -0,0 +1,326 @@
+package cases
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/pkg/geojson"
+	"go.uber.org/zap"
+)
+
+// circleSegments — число вершин многоугольника, которым круглая зона входит в объединение
+const circleSegments = 64
+
+var severityRank = map[string]int{
+	entity.SeverityLow:      1,
+	entity.SeverityMedium:   2,
+	entity.SeverityHigh:     3,
+	entity.SeverityCritical: 4,
+}
+
+// stateRank упорядочивает незавершенные стадии: объединенная зона получает самую острую из стадий исходных
+var stateRank = map[string]int{
+	entity.StatePlanned:   1,
+	entity.StateContained: 2,
+	entity.StateActive:    3,
+}
+
+// visibilityRank упорядочивает видимость: объединенная зона скрыта так же, как самая закрытая из исходных
+var visibilityRank = map[string]int{
+	entity.VisibilityPublic:     0,
+	entity.VisibilityRestricted: 1,
+	entity.VisibilityInternal:   2,
+}
+
+// replaceable — объединять и разделять можно только незавершенные зоны
+func replaceable(state string) bool {
+	_, ok := stateRank[state]
+	return ok
+}
+
+// MergeIncidents создает опубликованную зону из полигонов исходных (круги приближаются многоугольниками)
+// с наибольшим уровнем опасности и самой острой стадией и снимает исходные с публикации. Исходные зоны уже прошли публикацию,
+// поэтому итоговая публикуется без повторного одобрения. Расписания не переносятся: зона активна сразу
+func (uc *IncidentUseCaseImpl) MergeIncidents(ctx context.Context, incIDs []int, name, descr string) (*entity.Incident, error) {
+	if err := requirePublisher(ctx); err != nil {
+		return nil, err
+	}
+
+	incIDs = uniqueIDs(incIDs)
+	if len(incIDs) < 2 {
+		return nil, entity.ErrInvalidMerge
+	}
+
+	var (
+		merged     *entity.Incident
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		originals := make([]*entity.Incident, 0, len(incIDs))
+		for _, id := range incIDs {
+			inc, err := uc.repo.Read(ctx, id)
+			if err != nil {
+				return err
+			}
+			if inc.Status != entity.IncidentPublished {
+				return entity.ErrInvalidStatusTransition
+			}
+			if !replaceable(inc.State) {
+				return entity.ErrInvalidStateTransition
+			}
+			if err := uc.checkNotPending(ctx, id); err != nil {
+				return err
+			}
+			originals = append(originals, inc)
+		}
+
+		incident := mergedIncident(originals)
+		incident.Name = name
+		incident.Descr = descr
+		incident.CreatedBy = actorName(ctx)
+
+		created, ids, err := uc.replaceIncidents(ctx, originals, []entity.Incident{incident}, entity.LineageMerge)
+		if err != nil {
+			return err
+		}
+		merged, webhookIDs = created[0], ids
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	uc.logger.Info("incidents merged",
+		zap.Int("id", merged.ID),
+		zap.Ints("merged_ids", incIDs),
+		zap.String("merged_by", merged.CreatedBy))
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after merging incidents",
+			zap.Error(err))
+	}
+
+	return merged, nil
+}
+
+// SplitIncident создает по опубликованной зоне на каждую часть и снимает исходную с публикации.
+// Части наследуют описание, уровень опасности, стадию, расписание и срок действия исходной зоны
+func (uc *IncidentUseCaseImpl) SplitIncident(ctx context.Context, incID int, parts []entity.IncidentPart) ([]*entity.Incident, error) {
+	if err := requirePublisher(ctx); err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		if err := uc.checkArea(part.Geometry.Latitude, part.Geometry.Longitude); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		created    []*entity.Incident
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := uc.repo.Read(ctx, incID)
+		if err != nil {
+			return err
+		}
+		if current.Status != entity.IncidentPublished {
+			return entity.ErrInvalidStatusTransition
+		}
+		if !replaceable(current.State) {
+			return entity.ErrInvalidStateTransition
+		}
+		if err := uc.checkNotPending(ctx, incID); err != nil {
+			return err
+		}
+
+		incidents := make([]entity.Incident, len(parts))
+		for i, part := range parts {
+			incidents[i] = entity.Incident{
+				Name:      part.Name,
+				Descr:     current.Descr,
+				Latitude:  part.Geometry.Latitude,
+				Longitude: part.Geometry.Longitude,
+				Radius:    part.Geometry.Radius,
+				Polygons:  part.Geometry.Polygons,
+				IsActive:  current.IsActive,
+
+				Schedule:            current.Schedule,
+				ScheduleDurationMin: current.ScheduleDurationMin,
+				ExpiresAt:           current.ExpiresAt,
+				CreatedBy:           actorName(ctx),
+				Status:              entity.IncidentPublished,
+				Severity:            current.Severity,
+				State:               current.State,
+				Visibility:          current.Visibility,
+			}
+		}
+
+		created, webhookIDs, err = uc.replaceIncidents(ctx, []*entity.Incident{current}, incidents, entity.LineageSplit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	createdIDs := make([]int, len(created))
+	for i, inc := range created {
+		createdIDs[i] = inc.ID
+	}
+	uc.logger.Info("incident split",
+		zap.Int("id", incID),
+		zap.Ints("part_ids", createdIDs),
+		zap.String("split_by", actorName(ctx)))
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after splitting incident",
+			zap.Error(err))
+	}
+
+	return created, nil
+}
+
+// replaceIncidents вызывается внутри транзакции: создает зоны incidents, снимает originals с публикации
+// и связывает каждую исходную зону с каждой новой. Пользователей рядом не оповещает: новые зоны
+// описывают уже объявленную опасность
+func (uc *IncidentUseCaseImpl) replaceIncidents(ctx context.Context, originals []*entity.Incident,
+	incidents []entity.Incident, operation string) ([]*entity.Incident, []int, error) {
+	actor := actorName(ctx)
+
+	var webhookIDs []int
+	created := make([]*entity.Incident, 0, len(incidents))
+	for _, incident := range incidents {
+		inc, err := uc.createWithGeometry(ctx, incident)
+		if err != nil {
+			return nil, nil, err
+		}
+		created = append(created, inc)
+
+		ids, err := uc.incidentWebhook(ctx, inc.ID, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		webhookIDs = append(webhookIDs, ids...)
+	}
+
+	links := make([]entity.IncidentLink, 0, len(originals)*len(created))
+	for _, original := range originals {
+		from := []string{entity.IncidentPublished}
+		if err := uc.repo.SetStatus(ctx, original.ID, from, entity.IncidentArchived, actor); err != nil {
+			return nil, nil, err
+		}
+
+		ids, err := uc.incidentWebhook(ctx, original.ID, original)
+		if err != nil {
+			return nil, nil, err
+		}
+		webhookIDs = append(webhookIDs, ids...)
+
+		for _, inc := range created {
+			links = append(links, entity.IncidentLink{
+				ParentID:  original.ID,
+				ChildID:   inc.ID,
+				Operation: operation,
+				Actor:     actor,
+			})
+		}
+	}
+
+	if err := uc.lineage.Create(ctx, links); err != nil {
+		return nil, nil, err
+	}
+
+	return created, webhookIDs, nil
+}
+
+// createWithGeometry вызывается внутри транзакции: создает зону вместе с полигонами и возвращает ее
+func (uc *IncidentUseCaseImpl) createWithGeometry(ctx context.Context, incident entity.Incident) (*entity.Incident, error) {
+	incID, err := uc.repo.Create(ctx, incident)
+	if err != nil {
+		return nil, err
+	}
+	// Create сохраняет только охватывающий круг
+	if len(incident.Polygons) > 0 {
+		err := uc.repo.UpdateGeometry(ctx, incID, entity.IncidentGeometry{
+			Latitude:  incident.Latitude,
+			Longitude: incident.Longitude,
+			Radius:    incident.Radius,
+			Polygons:  incident.Polygons,
+			UpdatedBy: incident.CreatedBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.repo.Read(ctx, incID)
+}
+
+// mergedIncident собирает форму, уровень опасности и срок действия объединенной зоны. Полигоны исходных
+// зон могут перекрываться: попадание точки это не меняет, но расстояние до края считается и до
+// внутренних границ, поэтому точка с большой погрешностью у такой границы получит possibly_inside
+func mergedIncident(originals []*entity.Incident) entity.Incident {
+	incident := entity.Incident{
+		Status:     entity.IncidentPublished,
+		Severity:   entity.SeverityLow,
+		State:      entity.StatePlanned,
+		Visibility: entity.VisibilityPublic,
+	}
+
+	var polygons geojson.MultiPolygon
+	for i, inc := range originals {
+		if len(inc.Polygons) > 0 {
+			polygons = append(polygons, inc.Polygons...)
+		} else {
+			polygons = append(polygons, geojson.Circle(inc.Latitude, inc.Longitude, inc.Radius, circleSegments)...)
+		}
+
+		if severityRank[inc.Severity] > severityRank[incident.Severity] {
+			incident.Severity = inc.Severity
+		}
+		if stateRank[inc.State] > stateRank[incident.State] {
+			incident.State = inc.State
+		}
+		if visibilityRank[inc.Visibility] > visibilityRank[incident.Visibility] {
+			incident.Visibility = inc.Visibility
+		}
+
+		// объединенная зона действует, пока действует хотя бы одна из исходных
+		switch {
+		case i == 0:
+			incident.ExpiresAt = inc.ExpiresAt
+		case incident.ExpiresAt == nil:
+		case inc.ExpiresAt == nil || inc.ExpiresAt.After(*incident.ExpiresAt):
+			incident.ExpiresAt = inc.ExpiresAt
+		}
+	}
+
+	incident.IsActive = entity.StateInEffect(incident.State)
+	incident.Latitude, incident.Longitude, incident.Radius = polygons.BoundingCircle()
+	incident.Polygons = polygons
+
+	return incident
+}
+
+func (uc *IncidentUseCaseImpl) IncidentLineage(ctx context.Context, incID int) ([]entity.IncidentLink, error) {
+	if _, err := uc.repo.Read(ctx, incID); err != nil {
+		return nil, err
+	}
+
+	return uc.lineage.ReadByIncident(ctx, incID)
+}
+
+// IncidentVersions не раскрывает зону, которую исполнитель не видит: она не найдена
+func (uc *IncidentUseCaseImpl) IncidentVersions(ctx context.Context, incID int) ([]entity.GeometryVersion, error) {
+	incident, err := uc.repo.Read(ctx, incID)
+	if err != nil {
+		return nil, err
+	}
+	if !canSee(ctx, incident.Visibility) {
+		return nil, entity.ErrIncidentNotFound
+	}
+
+	return uc.versions.ReadByIncident(ctx, incID)
+}
@@ -3,99 +3,545 @@ package cases
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
 	"time"
 
+	"github.com/4otis/geonotify-service/config"
 	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/alerts"
+	"github.com/4otis/geonotify-service/internal/port/cache"
+	"github.com/4otis/geonotify-service/internal/port/geo"
 	"github.com/4otis/geonotify-service/internal/port/repo"
-	"github.com/4otis/geonotify-service/pkg/redis"
+	"github.com/4otis/geonotify-service/pkg/geojson"
 	"go.uber.org/zap"
 )
 
 var _ LocationUseCase = (*LocationUseCaseImpl)(nil)
 
+const (
+	activeIncidentsCacheKey    = "active_incidents:v1"
+	activeTranslationsCacheKey = "active_translations:v1"
+	incidentsVersionCacheKey   = "incidents_version:v1"
+)
+
 type LocationUseCase interface {
-	CheckLocation(ctx context.Context, userID string, lat, lng float64) (bool, []*entity.Incident, error)
+	CheckLocation(ctx context.Context, query LocationCheckQuery) (LocationCheckResult, error)
+	CheckLocations(ctx context.Context, queries []LocationCheckQuery) ([]LocationBatchItem, error)
+	// SimulateLocation сопоставляет точку с зонами так же, как CheckLocation, но ничего не записывает:
+	// проверка не сохраняется, вебхуки, события, присутствие и алерты пользователю не формируются
+	SimulateLocation(ctx context.Context, query LocationCheckQuery) (LocationCheckResult, error)
 	InvalidateIncidentsCache(ctx context.Context) error
+	// IncidentsVersion — непрозрачная версия набора инцидентов, хранится в кэше до его инвалидации
+	IncidentsVersion(ctx context.Context) (string, error)
 }
 
 type LocationUseCaseImpl struct {
 	incidentRepo repo.IncidentRepo
 	checkRepo    repo.CheckRepo
-	webhookRepo  repo.WebhookRepo
-	redis        *redis.Client
+	webhooks     *WebhookOutbox
+	eventRepo    repo.EventRepo
+	tx           repo.Transactor
+	cache        cache.Cache
 	logger       *zap.Logger
-	cacheTTL     time.Duration
+	settings     *config.Holder
+	reverseGeo   geo.ReverseGeocoder
+	// dispatcher nil — алерты пользователям не отправляются
+	dispatcher *AlertDispatcher
+	locations  alerts.LocationIndex
+	// area nil — проверки ведутся без ограничения области
+	area geo.OperatingArea
+	// presence nil — события user.entered и user.exited не формируются
+	presence repo.PresenceRepo
+	// groups nil — сводные алерты групп не формируются
+	groups       repo.GroupRepo
+	translations repo.TranslationRepo
+	// defaultLocale — язык исходных названий и описаний зон, канонический тег BCP 47
+	defaultLocale string
+	// comments nil — последние комментарии зон не попадают в location.alert
+	comments repo.CommentRepo
+	versions repo.GeometryVersionRepo
 }
 
 func NewLocationUseCase(
 	incidentRepo repo.IncidentRepo,
 	checkRepo repo.CheckRepo,
-	webhookRepo repo.WebhookRepo,
-	redis *redis.Client,
+	webhooks *WebhookOutbox,
+	eventRepo repo.EventRepo,
+	tx repo.Transactor,
+	cache cache.Cache,
 	logger *zap.Logger,
-	cacheTTLMinutes int,
+	settings *config.Holder,
+	reverseGeo geo.ReverseGeocoder,
+	dispatcher *AlertDispatcher,
+	locations alerts.LocationIndex,
+	area geo.OperatingArea,
+	presence repo.PresenceRepo,
+	groups repo.GroupRepo,
+	translations repo.TranslationRepo,
+	defaultLocale string,
+	comments repo.CommentRepo,
+	versions repo.GeometryVersionRepo,
 ) *LocationUseCaseImpl {
 	return &LocationUseCaseImpl{
-		incidentRepo: incidentRepo,
-		checkRepo:    checkRepo,
-		webhookRepo:  webhookRepo,
-		redis:        redis,
-		logger:       logger,
-		cacheTTL:     time.Duration(cacheTTLMinutes) * time.Minute,
+		incidentRepo:  incidentRepo,
+		checkRepo:     checkRepo,
+		webhooks:      webhooks,
+		eventRepo:     eventRepo,
+		tx:            tx,
+		cache:         cache,
+		logger:        logger,
+		settings:      settings,
+		reverseGeo:    reverseGeo,
+		dispatcher:    dispatcher,
+		locations:     locations,
+		area:          area,
+		presence:      presence,
+		groups:        groups,
+		translations:  translations,
+		defaultLocale: defaultLocale,
+		comments:      comments,
+		versions:      versions,
+	}
+}
+
+type LocationCheckQuery struct {
+	UserID    string
+	Latitude  float64
+	Longitude float64
+	// AccuracyM — радиус погрешности координат в метрах, 0 если неизвестен
+	AccuracyM float64
+	// Altitude — высота над уровнем моря в метрах, nil если не передана
+	Altitude *float64
+	// SpeedMps и HeadingDeg включают прогноз: оба nil — прогноза нет
+	SpeedMps       *float64
+	HeadingDeg     *float64
+	ResolveAddress bool
+	// Locales — языки клиента по убыванию предпочтения: названия и описания зон в ответе переводятся
+	Locales []string
+	// At — проверить по зонам и их формам на этот момент в прошлом; такая проверка ничего не записывает
+	At *time.Time
+}
+
+type LocationCheckResult struct {
+	HasAlert bool
+	// Match — лучшее попадание среди всех зон: inside, possibly_inside или outside
+	Match     string
+	Incidents []*entity.Incident
+	// Matches — попадание в каждую найденную зону по ID инцидента
+	Matches map[int]string
+	// Distances — положение точки относительно центра каждой найденной зоны по ID инцидента
+	Distances map[int]IncidentDistance
+	// Nearest — ближайшая активная зона, в которую точка не попала, nil если таких нет
+	Nearest *NearestIncident
+	// Ahead — зоны, в которые спроецированный по скорости и курсу путь войдет в пределах горизонта прогноза
+	Ahead []PredictedIncident
+	// Place — название места для точки проверки, пустое если не запрашивалось или не определено
+	Place string
+}
+
+// IncidentDistance — расстояние от точки проверки до центра зоны и направление на него
+type IncidentDistance struct {
+	DistanceM float64
+	// BearingDeg — азимут на центр зоны в градусах от севера по часовой стрелке
+	BearingDeg float64
+	// Inside — точка внутри зоны, EdgeM — расстояние от точки до границы зоны
+	Inside bool
+	EdgeM  float64
+}
+
+type NearestIncident struct {
+	Incident *entity.Incident
+	IncidentDistance
+}
+
+type PredictedIncident struct {
+	Incident *entity.Incident
+	IncidentDistance
+	// ETASeconds — через сколько секунд точка войдет в зону при текущих скорости и курсе
+	ETASeconds float64
+}
+
+// motion — скорость и курс для прогноза пути
+type motion struct {
+	speed   float64
+	heading float64
+	horizon float64
+}
+
+// zoneMatches — результат сопоставления точки с активными зонами
+type zoneMatches struct {
+	incidents []*entity.Incident
+	classes   map[int]string
+	distances map[int]IncidentDistance
+	nearest   *NearestIncident
+	ahead     []PredictedIncident
+}
+
+// match возвращает лучшее попадание среди найденных зон
+func (m zoneMatches) match() string {
+	best := entity.MatchOutside
+	for _, class := range m.classes {
+		if class == entity.MatchInside {
+			return entity.MatchInside
+		}
+		best = entity.MatchPossiblyInside
 	}
+	return best
 }
 
-func (uc *LocationUseCaseImpl) CheckLocation(ctx context.Context, userID string, lat, lng float64) (bool, []*entity.Incident, error) {
-	if strings.TrimSpace(userID) == "" {
-		return false, nil, entity.ErrUserIDRequired
+func (uc *LocationUseCaseImpl) CheckLocation(ctx context.Context, query LocationCheckQuery) (LocationCheckResult, error) {
+	if query.At != nil {
+		return uc.SimulateLocation(ctx, query)
 	}
 
-	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
-		return false, nil, entity.ErrInvalidCoordinates
+	mv, err := uc.validateQuery(query)
+	if err != nil {
+		return LocationCheckResult{}, err
 	}
 
-	uc.logger.Debug("checking location",
-		zap.String("user_id", userID),
-		zap.Float64("lat", lat),
-		zap.Float64("lng", lng))
+	activeIncidents, err := uc.getActiveIncidents(ctx)
+	if err != nil {
+		return LocationCheckResult{}, fmt.Errorf("failed to get active incidents: %w", err)
+	}
+
+	p := uc.evaluate(ctx, query, mv, activeIncidents)
+	p.presence, err = uc.presenceScope(ctx, query.UserID)
+	if err != nil {
+		return LocationCheckResult{}, fmt.Errorf("failed to track user zones: %w", err)
+	}
+
+	// на проверку без вебхуков, событий и алертов ничего не ссылается: ID ей не нужен,
+	// и запись можно отложить (CheckBatcher)
+	if !uc.referenced(p) {
+		if err := uc.checkRepo.CopyBatch(ctx, []entity.Check{p.check}); err != nil {
+			return LocationCheckResult{}, fmt.Errorf("failed to save check: %w", err)
+		}
+		uc.notifyUser(ctx, query.UserID, query.Latitude, query.Longitude, 0, nil, nil)
+
+		return redactResult(uc.translateResult(ctx, p.result(), activeIncidents, query.Locales)), nil
+	}
+
+	// проверка и вебхук пишутся в одной транзакции (outbox):
+	// если запись вебхука не удалась, проверка тоже не сохраняется
+	var (
+		checkID    int
+		webhookIDs []int
+	)
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		checkID, err = uc.saveCheck(ctx, p.check)
+		if err != nil {
+			return fmt.Errorf("failed to save check: %w", err)
+		}
+
+		webhookIDs, err = uc.record(ctx, checkID, p, activeIncidents)
+		return err
+	})
+	if err != nil {
+		return LocationCheckResult{}, err
+	}
+
+	uc.webhooks.Notify(ctx, checkID, webhookIDs)
+	uc.notifyUser(ctx, query.UserID, query.Latitude, query.Longitude, checkID, p.matches.incidents, p.matches.ahead)
+
+	return redactResult(uc.translateResult(ctx, p.result(), activeIncidents, query.Locales)), nil
+}
+
+func (uc *LocationUseCaseImpl) SimulateLocation(ctx context.Context, query LocationCheckQuery) (LocationCheckResult, error) {
+	mv, err := uc.validateQuery(query)
+	if err != nil {
+		return LocationCheckResult{}, err
+	}
+
+	if query.At != nil {
+		return uc.checkAt(ctx, query, mv)
+	}
+
+	activeIncidents, err := uc.getActiveIncidents(ctx)
+	if err != nil {
+		return LocationCheckResult{}, fmt.Errorf("failed to get active incidents: %w", err)
+	}
+
+	p := uc.evaluate(ctx, query, mv, activeIncidents)
+
+	uc.logger.Debug("location check simulated",
+		zap.String("user_id", query.UserID),
+		zap.Bool("has_alert", p.check.HasAlert))
+
+	return redactResult(uc.translateResult(ctx, p.result(), activeIncidents, query.Locales)), nil
+}
+
+// translateResult переводит зоны ответа на языки locales. Без переводов ответ все равно отдается:
+// проверка к этому моменту уже записана
+func (uc *LocationUseCaseImpl) translateResult(ctx context.Context, result LocationCheckResult,
+	activeIncidents []*entity.Incident, locales []string) LocationCheckResult {
+	if len(locales) == 0 {
+		return result
+	}
+
+	translations, err := uc.getActiveTranslations(ctx, activeIncidents)
+	if err != nil {
+		uc.logger.Warn("failed to get incident translations", zap.Error(err))
+		return result
+	}
+	return uc.localizeResult(result, translations, locales)
+}
+
+// LocationBatchItem — итог одной проверки из пачки; Err — причина, по которой проверка отклонена
+type LocationBatchItem struct {
+	LocationCheckResult
+	Err error
+}
+
+// CheckLocations выполняет пачку проверок: отклоненные проверки возвращаются с Err, остальные
+// записываются одной командой COPY в общей с их вебхуками транзакции. Ошибка записи отменяет всю пачку
+func (uc *LocationUseCaseImpl) CheckLocations(ctx context.Context, queries []LocationCheckQuery) ([]LocationBatchItem, error) {
+	items := make([]LocationBatchItem, len(queries))
 
 	activeIncidents, err := uc.getActiveIncidents(ctx)
 	if err != nil {
-		return false, nil, fmt.Errorf("failed to get active incidents: %w", err)
+		return nil, fmt.Errorf("failed to get active incidents: %w", err)
+	}
+
+	// ID нужен только проверкам, на которые ссылаются вебхуки, события или присутствие,
+	// остальные пишутся без возврата ID
+	var keyed, unkeyed []int
+	pending := make([]pendingCheck, len(queries))
+	for i, query := range queries {
+		mv, err := uc.validateQuery(query)
+		if err != nil {
+			items[i].Err = err
+			continue
+		}
+
+		pending[i] = uc.evaluate(ctx, query, mv, activeIncidents)
+		pending[i].presence, err = uc.presenceScope(ctx, query.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to track user zones: %w", err)
+		}
+		if uc.referenced(pending[i]) {
+			keyed = append(keyed, i)
+		} else {
+			unkeyed = append(unkeyed, i)
+		}
+	}
+
+	checkIDs := make([]int, len(queries))
+	webhookIDs := make([][]int, len(queries))
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		ids, err := uc.checkRepo.CreateBatch(ctx, checksAt(pending, keyed))
+		if err != nil {
+			return fmt.Errorf("failed to save checks: %w", err)
+		}
+		if err := uc.checkRepo.CopyBatch(ctx, checksAt(pending, unkeyed)); err != nil {
+			return fmt.Errorf("failed to save checks: %w", err)
+		}
+
+		for j, i := range keyed {
+			checkIDs[i] = ids[j]
+			webhookIDs[i], err = uc.record(ctx, ids[j], pending[i], activeIncidents)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Debug("checks batch saved",
+		zap.Int("checks", len(keyed)+len(unkeyed)),
+		zap.Int("rejected", len(queries)-len(keyed)-len(unkeyed)))
+
+	var translations map[int][]entity.IncidentTranslation
+	for _, query := range queries {
+		if len(query.Locales) == 0 {
+			continue
+		}
+		translations, err = uc.getActiveTranslations(ctx, activeIncidents)
+		if err != nil {
+			uc.logger.Warn("failed to get incident translations", zap.Error(err))
+		}
+		break
+	}
+
+	for i, query := range queries {
+		if items[i].Err != nil {
+			continue
+		}
+		p := pending[i]
+		uc.webhooks.Notify(ctx, checkIDs[i], webhookIDs[i])
+		uc.notifyUser(ctx, query.UserID, query.Latitude, query.Longitude, checkIDs[i], p.matches.incidents, p.matches.ahead)
+		items[i].LocationCheckResult = redactResult(uc.localizeResult(p.result(), translations, query.Locales))
+	}
+
+	return items, nil
+}
+
+// pendingCheck — проверка, сопоставленная с зонами, но еще не записанная
+type pendingCheck struct {
+	query    LocationCheckQuery
+	matches  zoneMatches
+	place    string
+	check    entity.Check
+	presence presenceScope
+}
+
+// presenceScope — ради чего отслеживается присутствие пользователя в зонах: подписка на события
+// входа и выхода и группы, в которых он состоит
+type presenceScope struct {
+	subscribed bool
+	groups     []*entity.Group
+}
+
+func (s presenceScope) tracked() bool {
+	return s.subscribed || len(s.groups) > 0
+}
+
+// referenced сообщает, сошлются ли на проверку вебхуки, события или алерты пользователю:
+// такой проверке нужен ID, и она пишется в одной транзакции с ними
+func (uc *LocationUseCaseImpl) referenced(p pendingCheck) bool {
+	return p.needWebhook() || p.presence.tracked() || uc.eventRepo != nil
+}
+
+// needWebhook — вебхук отправляется и для зоны впереди, но has_alert проверки отражает только фактическое попадание
+func (p pendingCheck) needWebhook() bool {
+	return p.check.HasAlert || len(p.matches.ahead) > 0
+}
+
+func (p pendingCheck) result() LocationCheckResult {
+	return LocationCheckResult{
+		HasAlert:  p.check.HasAlert,
+		Match:     p.matches.match(),
+		Incidents: p.matches.incidents,
+		Matches:   p.matches.classes,
+		Distances: p.matches.distances,
+		Nearest:   p.matches.nearest,
+		Ahead:     p.matches.ahead,
+		Place:     p.place,
+	}
+}
+
+func checksAt(pending []pendingCheck, idx []int) []entity.Check {
+	checks := make([]entity.Check, len(idx))
+	for j, i := range idx {
+		checks[j] = pending[i].check
+	}
+	return checks
+}
+
+// validateQuery проверяет запрос и возвращает параметры прогноза пути
+func (uc *LocationUseCaseImpl) validateQuery(query LocationCheckQuery) (*motion, error) {
+	lat, lng := query.Latitude, query.Longitude
+
+	if strings.TrimSpace(query.UserID) == "" {
+		return nil, entity.ErrUserIDRequired
+	}
+
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil, entity.ErrInvalidCoordinates
+	}
+
+	if uc.area != nil && !uc.area.Contains(lat, lng) {
+		return nil, entity.ErrOutsideArea
+	}
+
+	if query.AccuracyM < 0 || math.IsNaN(query.AccuracyM) {
+		return nil, entity.ErrInvalidAccuracy
 	}
 
-	matchingIncidents := uc.findMatchingIncidents(lat, lng, activeIncidents)
-	hasAlert := len(matchingIncidents) > 0
+	return uc.motionFor(query)
+}
+
+// evaluate сопоставляет точку с активными зонами
+func (uc *LocationUseCaseImpl) evaluate(ctx context.Context, query LocationCheckQuery, mv *motion, activeIncidents []*entity.Incident) pendingCheck {
+	uc.logger.Debug("checking location",
+		zap.String("user_id", query.UserID),
+		zap.Float64("lat", query.Latitude),
+		zap.Float64("lng", query.Longitude))
+
+	// зоны, которые точка лишь возможно задевает, тоже поднимают тревогу:
+	// пропустить опасность хуже, чем предупредить лишний раз
+	matches := uc.findMatchingIncidents(query.Latitude, query.Longitude, query.AccuracyM, mv, activeIncidents)
 
 	uc.logger.Debug("mathcingIncidents",
-		zap.Int("amount", len(matchingIncidents)),
-		zap.String("user_id", userID),
+		zap.Int("amount", len(matches.incidents)),
+		zap.String("user_id", query.UserID),
 	)
 
-	checkID, err := uc.saveCheck(ctx, userID, lat, lng, hasAlert)
+	var place string
+	if query.ResolveAddress {
+		place = uc.resolvePlace(ctx, query.Latitude, query.Longitude)
+	}
+
+	return pendingCheck{
+		query:   query,
+		matches: matches,
+		place:   place,
+		check: entity.Check{
+			UserID:      query.UserID,
+			Latitude:    query.Latitude,
+			Longitude:   query.Longitude,
+			HasAlert:    len(matches.incidents) > 0,
+			IncidentIDs: incidentIDs(matches.incidents),
+		},
+	}
+}
+
+// record пишет вебхуки, присутствие и событие сохраненной проверки; вызывается в ее транзакции
+func (uc *LocationUseCaseImpl) record(ctx context.Context, checkID int, p pendingCheck, activeIncidents []*entity.Incident) ([]int, error) {
+	var webhookIDs []int
+	if p.needWebhook() {
+		ids, err := uc.createWebhook(ctx, checkID, p.query, p.matches, p.place)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webhook: %w", err)
+		}
+		webhookIDs = ids
+	}
+
+	presenceIDs, err := uc.trackPresence(ctx, checkID, p.query, p.presence, p.matches.incidents, activeIncidents)
 	if err != nil {
-		return false, nil, fmt.Errorf("failed to save check: %w", err)
+		return nil, fmt.Errorf("failed to track user zones: %w", err)
 	}
+	webhookIDs = append(webhookIDs, presenceIDs...)
 
-	if hasAlert {
-		if err := uc.createWebhook(ctx, checkID, matchingIncidents); err != nil {
-			uc.logger.Error("failed to create webhook",
-				zap.Error(err),
-				zap.Int("check_id", checkID))
+	if uc.eventRepo != nil {
+		q := p.query
+		if err := uc.createCheckEvent(ctx, checkID, q.UserID, q.Latitude, q.Longitude, p.matches.incidents, p.place); err != nil {
+			return nil, fmt.Errorf("failed to create check event: %w", err)
 		}
 	}
 
-	return hasAlert, matchingIncidents, nil
+	return webhookIDs, nil
+}
+
+// resolvePlace — best effort: при недоступном геокодере проверка выполняется без адреса
+func (uc *LocationUseCaseImpl) resolvePlace(ctx context.Context, lat, lng float64) string {
+	if uc.reverseGeo == nil {
+		uc.logger.Debug("reverse geocoding requested but not configured")
+		return ""
+	}
+
+	place, err := uc.reverseGeo.Reverse(ctx, lat, lng)
+	if err != nil {
+		uc.logger.Warn("reverse geocoding failed",
+			zap.Error(err),
+			zap.Float64("lat", lat),
+			zap.Float64("lng", lng))
+		return ""
+	}
+
+	return place
 }
 
 func (uc *LocationUseCaseImpl) getActiveIncidents(ctx context.Context) ([]*entity.Incident, error) {
-	cacheKey := "active_incidents:v1"
+	cacheKey := activeIncidentsCacheKey
 
 	var cachedIncidents []*entity.Incident
-	if err := uc.redis.Get(cacheKey, &cachedIncidents); err == nil {
+	if err := uc.cache.Get(ctx, cacheKey, &cachedIncidents); err == nil {
 		uc.logger.Debug("retrieved active incidents from cache",
 			zap.Int("count", len(cachedIncidents)))
 		return cachedIncidents, nil
@@ -111,9 +557,11 @@ func (uc *LocationUseCaseImpl) getActiveIncidents(ctx context.Context) ([]*entit
 	uc.logger.Debug("retrieved active incidents from DB",
 		zap.Int("count", len(incidents)))
 
-	if err := uc.redis.Set(cacheKey, incidents, uc.cacheTTL); err != nil {
+	cacheTTL := time.Duration(uc.settings.Get().CacheTTLMinutes) * time.Minute
+	if err := uc.cache.Set(ctx, cacheKey, incidents, cacheTTL); err != nil {
 		uc.logger.Debug("failed to cache incidents",
 			zap.Error(err))
+		return incidents, nil
 	}
 
 	uc.logger.Debug("successfully cached incidents",
@@ -122,26 +570,203 @@ func (uc *LocationUseCaseImpl) getActiveIncidents(ctx context.Context) ([]*entit
 	return incidents, nil
 }
 
-func (uc *LocationUseCaseImpl) findMatchingIncidents(lat, lng float64, incidents []*entity.Incident) []*entity.Incident {
-	var matching []*entity.Incident
+// getActiveTranslations возвращает переводы активных зон по ID инцидента; кэшируется и сбрасывается вместе с зонами
+func (uc *LocationUseCaseImpl) getActiveTranslations(ctx context.Context, activeIncidents []*entity.Incident) (map[int][]entity.IncidentTranslation, error) {
+	var cached map[int][]entity.IncidentTranslation
+	if err := uc.cache.Get(ctx, activeTranslationsCacheKey, &cached); err == nil {
+		return cached, nil
+	}
+
+	incIDs := make([]int, len(activeIncidents))
+	for i, inc := range activeIncidents {
+		incIDs[i] = inc.ID
+	}
+	translations, err := uc.translations.ReadByIncidents(ctx, incIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident translations from DB: %w", err)
+	}
+	byIncident := groupTranslations(translations)
 
+	cacheTTL := time.Duration(uc.settings.Get().CacheTTLMinutes) * time.Minute
+	if err := uc.cache.Set(ctx, activeTranslationsCacheKey, byIncident, cacheTTL); err != nil {
+		uc.logger.Debug("failed to cache incident translations",
+			zap.Error(err))
+	}
+
+	return byIncident, nil
+}
+
+// localizeResult подставляет в зоны результата переводы на язык клиента; вебхуки и уведомления
+// к этому моменту уже сформированы с исходным текстом
+func (uc *LocationUseCaseImpl) localizeResult(result LocationCheckResult, translations map[int][]entity.IncidentTranslation, locales []string) LocationCheckResult {
+	if len(locales) == 0 || len(translations) == 0 {
+		return result
+	}
+
+	incidents := make([]*entity.Incident, len(result.Incidents))
+	for i, inc := range result.Incidents {
+		if inc != nil {
+			inc = localizeIncident(inc, translations[inc.ID], locales, uc.defaultLocale)
+		}
+		incidents[i] = inc
+	}
+	result.Incidents = incidents
+
+	if result.Nearest != nil {
+		nearest := *result.Nearest
+		nearest.Incident = localizeIncident(nearest.Incident, translations[nearest.Incident.ID], locales, uc.defaultLocale)
+		result.Nearest = &nearest
+	}
+
+	ahead := make([]PredictedIncident, len(result.Ahead))
+	for i, p := range result.Ahead {
+		p.Incident = localizeIncident(p.Incident, translations[p.Incident.ID], locales, uc.defaultLocale)
+		ahead[i] = p
+	}
+	result.Ahead = ahead
+
+	return result
+}
+
+// redactResult убирает из ответа названия и описания непубличных зон: точка в такой зоне получает предупреждение,
+// но не сведения о зоне. Вызывается после перевода, иначе перевод вернет название
+func redactResult(result LocationCheckResult) LocationCheckResult {
+	incidents := make([]*entity.Incident, len(result.Incidents))
+	for i, inc := range result.Incidents {
+		if inc != nil {
+			inc = inc.Redacted()
+		}
+		incidents[i] = inc
+	}
+	result.Incidents = incidents
+
+	if result.Nearest != nil {
+		nearest := *result.Nearest
+		nearest.Incident = nearest.Incident.Redacted()
+		result.Nearest = &nearest
+	}
+
+	ahead := make([]PredictedIncident, len(result.Ahead))
+	for i, p := range result.Ahead {
+		p.Incident = p.Incident.Redacted()
+		ahead[i] = p
+	}
+	result.Ahead = ahead
+
+	return result
+}
+
+// findMatchingIncidents возвращает зоны, в которые точка попала или возможно попала
+// с учетом погрешности, расстояния до них и ближайшую зону из тех, в которые точка не попала
+func (uc *LocationUseCaseImpl) findMatchingIncidents(lat, lng, accuracy float64, mv *motion, incidents []*entity.Incident) zoneMatches {
+	m := zoneMatches{
+		classes:   make(map[int]string),
+		distances: make(map[int]IncidentDistance),
+	}
+
+	now := time.Now()
 	for _, incident := range incidents {
-		if isPointInRadius(lat, lng, incident.Latitude, incident.Longitude, incident.Radius) {
-			matching = append(matching, incident)
+		// кэш может содержать инциденты, истекшие после его заполнения
+		if incident.ExpiresAt != nil && !incident.ExpiresAt.After(now) {
+			continue
+		}
+
+		d := IncidentDistance{
+			DistanceM:  distanceMeters(lat, lng, incident.Latitude, incident.Longitude),
+			BearingDeg: bearingDegrees(lat, lng, incident.Latitude, incident.Longitude),
+		}
+		d.Inside, d.EdgeM = zoneEdge(incident, d.DistanceM, lat, lng)
+
+		if class := classifyMatch(d.Inside, d.EdgeM, accuracy); class != entity.MatchOutside {
+			m.incidents = append(m.incidents, incident)
+			m.classes[incident.ID] = class
+			m.distances[incident.ID] = d
+			continue
+		}
+
+		// для полигональной зоны путь проверяется по охватывающему кругу
+		if mv != nil {
+			if eta, ok := timeToEntry(d, incident.Radius, mv.speed, mv.heading); ok && eta <= mv.horizon {
+				m.ahead = append(m.ahead, PredictedIncident{Incident: incident, IncidentDistance: d, ETASeconds: eta})
+			}
+		}
+
+		// ближайшая по границе зоны, а не по центру: большая зона может быть ближе маленькой
+		if m.nearest == nil || d.EdgeM < m.nearest.EdgeM {
+			m.nearest = &NearestIncident{Incident: incident, IncidentDistance: d}
 		}
 	}
 
-	return matching
+	return m
 }
 
-func (uc *LocationUseCaseImpl) saveCheck(ctx context.Context, userID string, lat, lng float64, hasAlert bool) (int, error) {
-	check := entity.Check{
-		UserID:    userID,
-		Latitude:  lat,
-		Longitude: lng,
-		HasAlert:  hasAlert,
+// motionFor проверяет скорость и курс из запроса; nil — прогноз не запрошен или отключен
+func (uc *LocationUseCaseImpl) motionFor(query LocationCheckQuery) (*motion, error) {
+	if query.SpeedMps == nil && query.HeadingDeg == nil {
+		return nil, nil
+	}
+	if query.SpeedMps == nil || query.HeadingDeg == nil {
+		return nil, entity.ErrInvalidMotion
+	}
+
+	speed, heading := *query.SpeedMps, *query.HeadingDeg
+	if !(speed >= 0) || !(heading >= 0 && heading < 360) {
+		return nil, entity.ErrInvalidMotion
+	}
+
+	horizon := uc.settings.Get().PredictionHorizonSeconds
+	if horizon == 0 || speed == 0 {
+		return nil, nil
+	}
+
+	return &motion{speed: speed, heading: heading, horizon: float64(horizon)}, nil
+}
+
+// timeToEntry проецирует путь из точки по прямой с постоянной скоростью и возвращает,
+// через сколько секунд он войдет в круг зоны. На горизонте прогноза (сотни метров — километры)
+// кривизной Земли можно пренебречь: центр зоны задается расстоянием и азимутом на плоскости
+func timeToEntry(d IncidentDistance, radius, speed, heading float64) (float64, bool) {
+	angle := (heading - d.BearingDeg) * math.Pi / 180
+
+	// проекция центра зоны на направление движения и расстояние от центра до прямой пути
+	along := d.DistanceM * math.Cos(angle)
+	across := d.DistanceM * math.Sin(angle)
+	if along <= 0 || math.Abs(across) > radius {
+		return 0, false
+	}
+
+	entry := along - math.Sqrt(radius*radius-across*across)
+	if entry < 0 {
+		return 0, false
+	}
+
+	return entry / speed, true
+}
+
+// zoneEdge определяет, внутри ли зоны точка, и расстояние от нее до границы зоны
+func zoneEdge(incident *entity.Incident, distance, lat, lng float64) (inside bool, edge float64) {
+	if len(incident.Polygons) > 0 {
+		polygons := geojson.MultiPolygon(incident.Polygons)
+		return polygons.Contains(lat, lng), polygons.BoundaryDistance(lat, lng)
 	}
 
+	return distance <= incident.Radius, math.Abs(distance - incident.Radius)
+}
+
+// classifyMatch сравнивает круг погрешности вокруг точки с зоной: круг целиком внутри зоны — inside,
+// пересекает ее границу — possibly_inside. При нулевой погрешности ответ бинарный
+func classifyMatch(inside bool, edge, accuracy float64) string {
+	switch {
+	case inside && edge >= accuracy:
+		return entity.MatchInside
+	case inside || edge <= accuracy:
+		return entity.MatchPossiblyInside
+	default:
+		return entity.MatchOutside
+	}
+}
+
+func (uc *LocationUseCaseImpl) saveCheck(ctx context.Context, check entity.Check) (int, error) {
 	checkID, err := uc.checkRepo.Create(ctx, check)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create check record: %w", err)
@@ -149,68 +774,317 @@ func (uc *LocationUseCaseImpl) saveCheck(ctx context.Context, userID string, lat
 
 	uc.logger.Debug("check saved",
 		zap.Int("check_id", checkID),
-		zap.Bool("has_alert", hasAlert))
+		zap.Bool("has_alert", check.HasAlert))
 
 	return checkID, nil
 }
 
-func (uc *LocationUseCaseImpl) createWebhook(ctx context.Context, checkID int, incidents []*entity.Incident) error {
+func (uc *LocationUseCaseImpl) createWebhook(ctx context.Context, checkID int, query LocationCheckQuery, matches zoneMatches, place string) ([]int, error) {
+	incidents := matches.incidents
+
 	payload := map[string]interface{}{
 		"check_id":  checkID,
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 		"incidents": incidents,
+		"match":     matches.match(),
+		"matches":   matches.classes,
+	}
+	// в match и matches по-прежнему учитываются все найденные зоны
+	if limit := uc.settings.Get().WebhookMaxIncidents; limit > 0 && len(incidents) > limit {
+		payload["incidents"] = incidents[:limit]
+		payload["incidents_total"] = len(incidents)
+		payload["truncated"] = true
+	}
+	if place != "" {
+		payload["place"] = place
+	}
+	if uc.comments != nil {
+		shown, _ := payload["incidents"].([]*entity.Incident)
+		incIDs := make([]int, len(shown))
+		for i, inc := range shown {
+			incIDs[i] = inc.ID
+		}
+		comments, err := uc.comments.ReadLatest(ctx, incIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(comments) > 0 {
+			payload["latest_comments"] = comments
+		}
+	}
+	if query.AccuracyM > 0 {
+		payload["accuracy_m"] = query.AccuracyM
+	}
+	if query.Altitude != nil {
+		payload["altitude"] = *query.Altitude
+	}
+	if len(matches.ahead) > 0 {
+		ahead := make([]map[string]interface{}, len(matches.ahead))
+		for i, p := range matches.ahead {
+			ahead[i] = map[string]interface{}{
+				"incident_id": p.Incident.ID,
+				"eta_seconds": math.Round(p.ETASeconds),
+				"distance_m":  math.Round(p.DistanceM),
+			}
+		}
+		payload["ahead"] = ahead
 	}
 	// json.Marshal разыменовывает указатели,
 	// []*entity.Incident обработается корректно
-	payloadBytes, err := json.Marshal(payload)
+	return uc.webhooks.Enqueue(ctx, entity.WebhookLocationAlert, checkID, payload)
+}
+
+// presenceScope читает подписку на события присутствия и группы пользователя до записи проверки:
+// от них зависит, нужен ли проверке ID
+func (uc *LocationUseCaseImpl) presenceScope(ctx context.Context, userID string) (presenceScope, error) {
+	if uc.presence == nil {
+		return presenceScope{}, nil
+	}
+
+	subscribed, err := uc.webhooks.Subscribed(ctx, entity.WebhookUserEntered, entity.WebhookUserExited)
 	if err != nil {
-		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+		return presenceScope{}, err
 	}
+	var groups []*entity.Group
+	if uc.groups != nil {
+		groups, err = uc.groups.ReadByMember(ctx, userID)
+		if err != nil {
+			return presenceScope{}, err
+		}
+	}
+
+	return presenceScope{subscribed: subscribed, groups: groups}, nil
+}
 
-	webhook := entity.Webhook{
-		CheckID:     checkID,
-		State:       "in progress",
-		RetryCnt:    0,
-		Payload:     payloadBytes,
-		ScheduledAt: time.Now(),
+// trackPresence сравнивает зоны проверки с зонами прошлой проверки пользователя и пишет
+// события входа и выхода и сводные алерты его групп. Присутствие отслеживается, пока есть подписка
+// на события входа и выхода, а у участников групп — всегда: по нему строится статус группы
+func (uc *LocationUseCaseImpl) trackPresence(ctx context.Context, checkID int, query LocationCheckQuery, scope presenceScope,
+	incidents, active []*entity.Incident) ([]int, error) {
+	if !scope.tracked() {
+		return nil, nil
 	}
+	subscribed, groups := scope.subscribed, scope.groups
 
-	webhookID, err := uc.webhookRepo.Create(ctx, webhook)
+	previous, err := uc.presence.ReadZones(ctx, query.UserID)
 	if err != nil {
-		return fmt.Errorf("failed to create webhook record: %w", err)
+		return nil, err
 	}
 
-	queueTask := map[string]interface{}{
-		"webhook_id": webhookID,
-		"check_id":   checkID,
-		"payload":    string(payloadBytes),
+	inside := make(map[int]struct{}, len(incidents))
+	for _, inc := range incidents {
+		inside[inc.ID] = struct{}{}
+	}
+	was := make(map[int]struct{}, len(previous))
+	var left []int
+	for _, id := range previous {
+		was[id] = struct{}{}
+		if _, ok := inside[id]; !ok {
+			left = append(left, id)
+		}
+	}
+	var came []int
+	for _, inc := range incidents {
+		if _, ok := was[inc.ID]; !ok {
+			came = append(came, inc.ID)
+		}
+	}
+
+	entered, err := uc.presence.Enter(ctx, query.UserID, came)
+	if err != nil {
+		return nil, err
+	}
+	exited, err := uc.presence.Exit(ctx, query.UserID, left)
+	if err != nil {
+		return nil, err
+	}
+
+	// зона, из которой вышел пользователь, может быть уже выключена — тогда в событии только ее id
+	known := make(map[int]*entity.Incident, len(active))
+	for _, inc := range active {
+		known[inc.ID] = inc
+	}
+
+	enteredIncidents := make([]*entity.Incident, 0, len(entered))
+	for _, incID := range entered {
+		if inc, ok := known[incID]; ok {
+			enteredIncidents = append(enteredIncidents, inc)
+		}
+	}
+	webhookIDs, err := uc.groupAlerts(ctx, checkID, query, groups, enteredIncidents)
+	if err != nil {
+		return nil, err
+	}
+	if !subscribed {
+		return webhookIDs, nil
+	}
+
+	for _, transition := range []struct {
+		eventType   string
+		incidentIDs []int
+	}{
+		{entity.WebhookUserEntered, entered},
+		{entity.WebhookUserExited, exited},
+	} {
+		for _, incID := range transition.incidentIDs {
+			data := map[string]interface{}{
+				"user_id":     query.UserID,
+				"check_id":    checkID,
+				"latitude":    query.Latitude,
+				"longitude":   query.Longitude,
+				"incident_id": incID,
+				"timestamp":   time.Now().UTC().Format(time.RFC3339),
+			}
+			if inc, ok := known[incID]; ok {
+				data["incident"] = inc
+			}
+
+			ids, err := uc.webhooks.Enqueue(ctx, transition.eventType, checkID, data)
+			if err != nil {
+				return nil, err
+			}
+			webhookIDs = append(webhookIDs, ids...)
+		}
+	}
+
+	return webhookIDs, nil
+}
+
+// createCheckEvent кладет событие проверки в outbox, его публикует EventRelayWorker
+func (uc *LocationUseCaseImpl) createCheckEvent(ctx context.Context, checkID int, userID string, lat, lng float64, incidents []*entity.Incident, place string) error {
+	incidentIDs := make([]int, len(incidents))
+	for i, inc := range incidents {
+		incidentIDs[i] = inc.ID
+	}
+
+	payload := map[string]interface{}{
+		"check_id":     checkID,
+		"user_id":      userID,
+		"latitude":     lat,
+		"longitude":    lng,
+		"has_alert":    len(incidents) > 0,
+		"incident_ids": incidentIDs,
+		"checked_at":   time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	if place != "" {
+		payload["place"] = place
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal check event payload: %w", err)
 	}
 
-	if err := uc.redis.LPush("webhooks:queue", queueTask); err != nil {
-		uc.logger.Error("failed to push webhook to queue",
+	_, err = uc.eventRepo.Create(ctx, entity.Event{
+		Topic:   uc.settings.Get().CheckEventsStream,
+		Type:    "check.saved",
+		Key:     userID,
+		Payload: payloadBytes,
+	})
+
+	return err
+}
+
+// notifyUser запоминает последнюю точку пользователя и отправляет ему алерт по его настройкам.
+// Ошибки не влияют на результат проверки: поток алертов — best effort
+func (uc *LocationUseCaseImpl) notifyUser(ctx context.Context, userID string, lat, lng float64, checkID int, incidents []*entity.Incident, ahead []PredictedIncident) {
+	if uc.dispatcher == nil {
+		return
+	}
+
+	err := uc.locations.Track(ctx, userID, lat, lng)
+	if errors.Is(err, entity.ErrDependencyUnavailable) {
+		return
+	}
+	if err != nil {
+		uc.logger.Warn("failed to track user location",
 			zap.Error(err),
-			zap.Int("webhook_id", webhookID))
+			zap.String("user_id", userID))
 	}
 
-	uc.logger.Info("webhook created",
-		zap.Int("webhook_id", webhookID),
-		zap.Int("check_id", checkID),
-		zap.Int("incidents_count", len(incidents)))
+	if len(incidents) > 0 {
+		uc.publishAlert(ctx, entity.AlertCheckMatched, userID, checkID, incidents)
+	}
 
-	return nil
+	if len(ahead) > 0 {
+		aheadIncidents := make([]*entity.Incident, len(ahead))
+		for i, p := range ahead {
+			aheadIncidents[i] = p.Incident
+		}
+		uc.publishAlert(ctx, entity.AlertZoneAhead, userID, checkID, aheadIncidents)
+	}
+}
+
+func (uc *LocationUseCaseImpl) publishAlert(ctx context.Context, alertType, userID string, checkID int, incidents []*entity.Incident) {
+	uc.dispatcher.Dispatch(ctx, entity.Alert{
+		Type:      alertType,
+		UserID:    userID,
+		CheckID:   checkID,
+		Incidents: incidents,
+		CreatedAt: time.Now().UTC(),
+	})
 }
 
 func (uc *LocationUseCaseImpl) InvalidateIncidentsCache(ctx context.Context) error {
-	cacheKey := "active_incidents:v1"
-	if err := uc.redis.Delete(cacheKey); err != nil && err != redis.ErrNotFound {
+	err := uc.deleteIncidentsCache(ctx)
+	if errors.Is(err, entity.ErrDependencyUnavailable) {
+		// кэш сбрасывается целиком при восстановлении Redis
+		uc.logger.Debug("cache unavailable, invalidation deferred")
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("failed to invalidate cache: %w", err)
 	}
 
 	uc.logger.Debug("incidents cache invalidated")
+
+	// кэш мог успеть заполниться с отстающей реплики, поэтому сбрасывается еще раз
+	if s := uc.settings.Get(); s.DBReplicaURL != "" && s.DBReplicaMaxLagSeconds > 0 {
+		time.AfterFunc(time.Duration(s.DBReplicaMaxLagSeconds)*time.Second, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := uc.deleteIncidentsCache(ctx); err != nil && !errors.Is(err, entity.ErrDependencyUnavailable) {
+				uc.logger.Warn("failed to invalidate cache after replica lag", zap.Error(err))
+			}
+		})
+	}
+
 	return nil
 }
 
-func isPointInRadius(lat1, lon1, lat2, lon2, radius float64) bool {
+func (uc *LocationUseCaseImpl) deleteIncidentsCache(ctx context.Context) error {
+	if err := uc.cache.Delete(ctx, activeIncidentsCacheKey); err != nil {
+		return err
+	}
+	if err := uc.cache.Delete(ctx, activeTranslationsCacheKey); err != nil {
+		return err
+	}
+	return uc.cache.Delete(ctx, incidentsVersionCacheKey)
+}
+
+// IncidentsVersion читает версию из кэша, а при промахе или недоступном кэше — из БД
+func (uc *LocationUseCaseImpl) IncidentsVersion(ctx context.Context) (string, error) {
+	var version string
+	if err := uc.cache.Get(ctx, incidentsVersionCacheKey, &version); err == nil {
+		return version, nil
+	}
+
+	v, err := uc.incidentRepo.Version(ctx)
+	if err != nil {
+		return "", err
+	}
+	version = fmt.Sprintf("%d-%d", v.Count, v.UpdatedAt.UnixMicro())
+
+	cacheTTL := time.Duration(uc.settings.Get().CacheTTLMinutes) * time.Minute
+	if err := uc.cache.Set(ctx, incidentsVersionCacheKey, version, cacheTTL); err != nil {
+		uc.logger.Debug("failed to cache incidents version", zap.Error(err))
+	}
+
+	return version, nil
+}
+
+// distanceMeters возвращает расстояние между точками по большому кругу
+func distanceMeters(lat1, lon1, lat2, lon2 float64) float64 {
 	const earthRadius_m = 6371000
 
 	lat1Rad := lat1 * math.Pi / 180
@@ -227,6 +1101,17 @@ func isPointInRadius(lat1, lon1, lat2, lon2, radius float64) bool {
 			math.Sin(dLon/2)*math.Sin(dLon/2)
 	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
 
-	distance := earthRadius_m * c
-	return distance <= radius
+	return earthRadius_m * c
+}
+
+// bearingDegrees возвращает начальный азимут с первой точки на вторую в диапазоне [0, 360)
+func bearingDegrees(lat1, lon1, lat2, lon2 float64) float64 {
+	lat1Rad := lat1 * math.Pi / 180
+	lat2Rad := lat2 * math.Pi / 180
+	dLon := (lon2 - lon1) * math.Pi / 180
+
+	y := math.Sin(dLon) * math.Cos(lat2Rad)
+	x := math.Cos(lat1Rad)*math.Sin(lat2Rad) - math.Sin(lat1Rad)*math.Cos(lat2Rad)*math.Cos(dLon)
+
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
 }
@@ -0,0 +1,77 @@
+package cases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// checkAt сопоставляет точку с зонами, действовавшими в момент query.At, в их тогдашней форме — для разбора
+// претензий и расследований. Проверка не сохраняется, вебхуки и алерты не отправляются
+func (uc *LocationUseCaseImpl) checkAt(ctx context.Context, query LocationCheckQuery, mv *motion) (LocationCheckResult, error) {
+	incidents, err := uc.incidentsAt(ctx, *query.At)
+	if err != nil {
+		return LocationCheckResult{}, err
+	}
+
+	p := uc.evaluate(ctx, query, mv, incidents)
+
+	uc.logger.Debug("historical location check",
+		zap.String("user_id", query.UserID),
+		zap.Time("at", *query.At),
+		zap.Bool("has_alert", p.check.HasAlert))
+
+	result := p.result()
+	// переводы действующих зон кэшируются, а прошлый набор зон другой, поэтому они читаются напрямую
+	if len(query.Locales) > 0 {
+		translations, err := uc.translations.ReadByIncidents(ctx, incidentIDs(incidents))
+		if err != nil {
+			uc.logger.Warn("failed to get incident translations", zap.Error(err))
+		} else {
+			result = uc.localizeResult(result, groupTranslations(translations), query.Locales)
+		}
+	}
+
+	return redactResult(result), nil
+}
+
+// incidentsAt возвращает зоны, действовавшие в момент at, с формой из версии, действовавшей тогда же
+func (uc *LocationUseCaseImpl) incidentsAt(ctx context.Context, at time.Time) ([]*entity.Incident, error) {
+	incidents, err := uc.incidentRepo.ReadInEffectAt(ctx, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incidents in effect at %v: %w", at, err)
+	}
+
+	versions, err := uc.versions.ReadAt(ctx, incidentIDs(incidents), at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get geometry versions: %w", err)
+	}
+	byIncident := make(map[int]entity.GeometryVersion, len(versions))
+	for _, v := range versions {
+		byIncident[v.IncidentID] = v
+	}
+
+	for _, inc := range incidents {
+		if v, ok := byIncident[inc.ID]; ok {
+			inc.Latitude = v.Latitude
+			inc.Longitude = v.Longitude
+			inc.Radius = v.Radius
+			inc.Polygons = v.Polygons
+		}
+		// ReadInEffectAt уже отобрал зоны, действовавшие в момент at, текущий флаг к нему не относится
+		inc.IsActive = true
+	}
+
+	return incidents, nil
+}
+
+func incidentIDs(incidents []*entity.Incident) []int {
+	ids := make([]int, len(incidents))
+	for i, inc := range incidents {
+		ids[i] = inc.ID
+	}
+	return ids
+}
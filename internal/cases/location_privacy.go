@@ -0,0 +1,62 @@
+package cases
+
+import (
+	"context"
+	"math"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/geohash"
+)
+
+// Режимы огрубления координат записываемых проверок
+const (
+	LocationPrivacyRound   = "round"
+	LocationPrivacyGeohash = "geohash"
+)
+
+// CoarseCheckRepo огрубляет координаты проверок перед записью: round — до precision знаков после запятой,
+// geohash — до центра ячейки геохеша длиной precision. Зоны сверяются с точными координатами запроса
+// до записи, огрубляется только то, что хранится
+type CoarseCheckRepo struct {
+	repo.CheckRepo
+	mode      string
+	precision int
+}
+
+func NewCoarseCheckRepo(next repo.CheckRepo, mode string, precision int) *CoarseCheckRepo {
+	return &CoarseCheckRepo{CheckRepo: next, mode: mode, precision: precision}
+}
+
+func (r *CoarseCheckRepo) Create(ctx context.Context, check entity.Check) (int, error) {
+	return r.CheckRepo.Create(ctx, r.coarsen(check))
+}
+
+func (r *CoarseCheckRepo) CreateBatch(ctx context.Context, checks []entity.Check) ([]int, error) {
+	return r.CheckRepo.CreateBatch(ctx, r.coarsenAll(checks))
+}
+
+func (r *CoarseCheckRepo) CopyBatch(ctx context.Context, checks []entity.Check) error {
+	return r.CheckRepo.CopyBatch(ctx, r.coarsenAll(checks))
+}
+
+// coarsenAll не меняет checks вызывающего: по ним еще строятся вебхуки и события
+func (r *CoarseCheckRepo) coarsenAll(checks []entity.Check) []entity.Check {
+	coarse := make([]entity.Check, len(checks))
+	for i, check := range checks {
+		coarse[i] = r.coarsen(check)
+	}
+	return coarse
+}
+
+func (r *CoarseCheckRepo) coarsen(check entity.Check) entity.Check {
+	switch r.mode {
+	case LocationPrivacyRound:
+		scale := math.Pow10(r.precision)
+		check.Latitude = math.Round(check.Latitude*scale) / scale
+		check.Longitude = math.Round(check.Longitude*scale) / scale
+	case LocationPrivacyGeohash:
+		check.Latitude, check.Longitude = geohash.Center(check.Latitude, check.Longitude, r.precision)
+	}
+	return check
+}
@@ -0,0 +1,95 @@
+package cases_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/entity"
+	repomocks "github.com/4otis/geonotify-service/internal/port/repo/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCoarseCheckRepoCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		precision int
+		lat, lng  float64
+		wantLat   float64
+		wantLng   float64
+	}{
+		{name: "round", mode: cases.LocationPrivacyRound, precision: 2, lat: 55.75581, lng: 37.61732, wantLat: 55.76, wantLng: 37.62},
+		{name: "round negative", mode: cases.LocationPrivacyRound, precision: 1, lat: -33.86785, lng: -151.20732, wantLat: -33.9, wantLng: -151.2},
+		{name: "round to degrees", mode: cases.LocationPrivacyRound, precision: 0, lat: 89.5, lng: -179.6, wantLat: 90, wantLng: -180},
+		{name: "geohash", mode: cases.LocationPrivacyGeohash, precision: 5, lat: 42.6, lng: -5.6, wantLat: 42.60498046875, wantLng: -5.60302734375},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			next := repomocks.NewMockCheckRepo(ctrl)
+			r := cases.NewCoarseCheckRepo(next, tt.mode, tt.precision)
+
+			next.EXPECT().Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, check entity.Check) (int, error) {
+					if check.Latitude != tt.wantLat || check.Longitude != tt.wantLng {
+						t.Errorf("stored (%v, %v), want (%v, %v)", check.Latitude, check.Longitude, tt.wantLat, tt.wantLng)
+					}
+					return 1, nil
+				})
+
+			if _, err := r.Create(context.Background(), entity.Check{UserID: "u1", Latitude: tt.lat, Longitude: tt.lng}); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		})
+	}
+}
+
+// по проверкам вызывающего еще строятся вебхуки и события, поэтому огрубляется только копия
+func TestCoarseCheckRepoKeepsCallerChecks(t *testing.T) {
+	for _, mode := range []string{cases.LocationPrivacyRound, cases.LocationPrivacyGeohash} {
+		t.Run(mode, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			next := repomocks.NewMockCheckRepo(ctrl)
+			r := cases.NewCoarseCheckRepo(next, mode, 3)
+
+			checks := []entity.Check{
+				{UserID: "u1", Latitude: 55.75581, Longitude: 37.61732},
+				{UserID: "u2", Latitude: -33.86785, Longitude: 151.20732},
+			}
+			original := append([]entity.Check(nil), checks...)
+
+			assertCoarse := func(stored []entity.Check) {
+				t.Helper()
+				for i := range stored {
+					if stored[i].Latitude == original[i].Latitude && stored[i].Longitude == original[i].Longitude {
+						t.Errorf("stored check %d keeps exact coordinates", i)
+					}
+				}
+			}
+			next.EXPECT().CopyBatch(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, stored []entity.Check) error {
+					assertCoarse(stored)
+					return nil
+				})
+			next.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, stored []entity.Check) ([]int, error) {
+					assertCoarse(stored)
+					return []int{1, 2}, nil
+				})
+
+			if err := r.CopyBatch(context.Background(), checks); err != nil {
+				t.Fatalf("CopyBatch() error = %v", err)
+			}
+			if _, err := r.CreateBatch(context.Background(), checks); err != nil {
+				t.Fatalf("CreateBatch() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(checks, original) {
+				t.Errorf("caller checks = %+v, want unchanged %+v", checks, original)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4otis/geonotify-service/config"
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/entity"
+	cachemocks "github.com/4otis/geonotify-service/internal/port/cache/mocks"
+	deliverymocks "github.com/4otis/geonotify-service/internal/port/delivery/mocks"
+	repomocks "github.com/4otis/geonotify-service/internal/port/repo/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+var errDB = errors.New("db is down")
+
+// locationDeps — моки зависимостей LocationUseCaseImpl, которые участвуют в CheckLocation
+type locationDeps struct {
+	incidents *repomocks.MockIncidentRepo
+	checks    *repomocks.MockCheckRepo
+	webhooks  *repomocks.MockWebhookRepo
+	endpoints *repomocks.MockWebhookEndpointRepo
+	tx        *repomocks.MockTransactor
+	cache     *cachemocks.MockCache
+	queue     *deliverymocks.MockQueue
+}
+
+func newLocationUseCase(t *testing.T) (*cases.LocationUseCaseImpl, locationDeps) {
+	ctrl := gomock.NewController(t)
+	d := locationDeps{
+		incidents: repomocks.NewMockIncidentRepo(ctrl),
+		checks:    repomocks.NewMockCheckRepo(ctrl),
+		webhooks:  repomocks.NewMockWebhookRepo(ctrl),
+		endpoints: repomocks.NewMockWebhookEndpointRepo(ctrl),
+		tx:        repomocks.NewMockTransactor(ctrl),
+		cache:     cachemocks.NewMockCache(ctrl),
+		queue:     deliverymocks.NewMockQueue(ctrl),
+	}
+	// транзакция в тестах — просто вызов fn, ошибка fn возвращается как есть
+	d.tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	logger := zap.NewNop()
+	settings := config.NewHolder("", &config.Config{CacheTTLMinutes: 5})
+	outbox := cases.NewWebhookOutbox(d.webhooks, d.endpoints, d.queue, 0, logger)
+
+	uc := cases.NewLocationUseCase(d.incidents, d.checks, outbox, nil, d.tx, d.cache, logger, settings,
+		nil, nil, nil, nil, nil, nil, nil, "ru", nil, nil)
+	return uc, d
+}
+
+// cacheHit отдает из кэша список зон
+func cacheHit(incidents []*entity.Incident) func(context.Context, string, interface{}) error {
+	return func(_ context.Context, _ string, dest interface{}) error {
+		*dest.(*[]*entity.Incident) = incidents
+		return nil
+	}
+}
+
+func testIncident() *entity.Incident {
+	return &entity.Incident{
+		ID:         7,
+		Name:       "Пожар",
+		Latitude:   55.7558,
+		Longitude:  37.6173,
+		Radius:     500,
+		IsActive:   true,
+		State:      entity.StateActive,
+		Status:     entity.IncidentPublished,
+		Severity:   entity.SeverityMedium,
+		Visibility: entity.VisibilityPublic,
+	}
+}
+
+func TestCheckLocationCoordinates(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   cases.LocationCheckQuery
+		wantErr error
+	}{
+		{name: "north east corner", query: cases.LocationCheckQuery{UserID: "u1", Latitude: 90, Longitude: 180}},
+		{name: "south west corner", query: cases.LocationCheckQuery{UserID: "u1", Latitude: -90, Longitude: -180}},
+		{name: "zero point", query: cases.LocationCheckQuery{UserID: "u1"}},
+		{name: "latitude above 90", query: cases.LocationCheckQuery{UserID: "u1", Latitude: 90.000001}, wantErr: entity.ErrInvalidCoordinates},
+		{name: "latitude below -90", query: cases.LocationCheckQuery{UserID: "u1", Latitude: -90.000001}, wantErr: entity.ErrInvalidCoordinates},
+		{name: "longitude above 180", query: cases.LocationCheckQuery{UserID: "u1", Longitude: 180.000001}, wantErr: entity.ErrInvalidCoordinates},
+		{name: "longitude below -180", query: cases.LocationCheckQuery{UserID: "u1", Longitude: -180.000001}, wantErr: entity.ErrInvalidCoordinates},
+		{name: "blank user", query: cases.LocationCheckQuery{UserID: "  ", Latitude: 10, Longitude: 10}, wantErr: entity.ErrUserIDRequired},
+		{name: "negative accuracy", query: cases.LocationCheckQuery{UserID: "u1", AccuracyM: -1}, wantErr: entity.ErrInvalidAccuracy},
+		{name: "speed without heading", query: cases.LocationCheckQuery{UserID: "u1", SpeedMps: ptr(3.0)}, wantErr: entity.ErrInvalidMotion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, d := newLocationUseCase(t)
+			if tt.wantErr == nil {
+				d.cache.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheHit(nil))
+				// проверка без совпадений ни на что не ссылается и пишется без транзакции
+				d.checks.EXPECT().CopyBatch(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			result, err := uc.CheckLocation(context.Background(), tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckLocation() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && result.HasAlert {
+				t.Errorf("CheckLocation() HasAlert = true without active incidents")
+			}
+		})
+	}
+}
+
+func TestCheckLocationCache(t *testing.T) {
+	inc := testIncident()
+
+	tests := []struct {
+		name    string
+		setup   func(d locationDeps)
+		wantErr error
+	}{
+		{
+			name: "hit",
+			setup: func(d locationDeps) {
+				d.cache.EXPECT().Get(gomock.Any(), "active_incidents:v1", gomock.Any()).
+					DoAndReturn(cacheHit([]*entity.Incident{inc}))
+			},
+		},
+		{
+			name: "miss",
+			setup: func(d locationDeps) {
+				d.cache.EXPECT().Get(gomock.Any(), "active_incidents:v1", gomock.Any()).Return(entity.ErrCacheMiss)
+				d.incidents.EXPECT().ReadAllActive(gomock.Any()).Return([]*entity.Incident{inc}, nil)
+				d.cache.EXPECT().Set(gomock.Any(), "active_incidents:v1", []*entity.Incident{inc}, 5*time.Minute).Return(nil)
+			},
+		},
+		{
+			name: "miss and cache write fails",
+			setup: func(d locationDeps) {
+				d.cache.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.ErrCacheMiss)
+				d.incidents.EXPECT().ReadAllActive(gomock.Any()).Return([]*entity.Incident{inc}, nil)
+				d.cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("redis is down"))
+			},
+		},
+		{
+			name: "miss and db fails",
+			setup: func(d locationDeps) {
+				d.cache.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.ErrCacheMiss)
+				d.incidents.EXPECT().ReadAllActive(gomock.Any()).Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, d := newLocationUseCase(t)
+			tt.setup(d)
+			if tt.wantErr == nil {
+				d.checks.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+				d.endpoints.EXPECT().ReadSubscribed(gomock.Any(), entity.WebhookLocationAlert).Return(nil, nil)
+			}
+
+			result, err := uc.CheckLocation(context.Background(), cases.LocationCheckQuery{
+				UserID:    "u1",
+				Latitude:  inc.Latitude,
+				Longitude: inc.Longitude,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckLocation() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !result.HasAlert || result.Match != entity.MatchInside {
+				t.Errorf("CheckLocation() = HasAlert %v, Match %q; want alert inside", result.HasAlert, result.Match)
+			}
+			if len(result.Incidents) != 1 || result.Incidents[0].ID != inc.ID {
+				t.Errorf("CheckLocation() incidents = %v, want [%d]", result.Incidents, inc.ID)
+			}
+		})
+	}
+}
+
+func TestCheckLocationWebhook(t *testing.T) {
+	inc := testIncident()
+	endpoint := &entity.WebhookEndpoint{ID: 3, URL: "https://example.com/hook", Enabled: true}
+
+	tests := []struct {
+		name    string
+		setup   func(d locationDeps)
+		wantErr error
+	}{
+		{
+			name: "enqueued and pushed",
+			setup: func(d locationDeps) {
+				d.endpoints.EXPECT().ReadSubscribed(gomock.Any(), entity.WebhookLocationAlert).
+					Return([]*entity.WebhookEndpoint{endpoint}, nil)
+				d.webhooks.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, wh entity.Webhook) (int, error) {
+						if wh.EndpointID != endpoint.ID || wh.CheckID != 11 || wh.EventType != entity.WebhookLocationAlert {
+							t.Errorf("webhook = %+v, want endpoint %d, check 11", wh, endpoint.ID)
+						}
+						return 21, nil
+					})
+				d.queue.EXPECT().Push(gomock.Any(), entity.WebhookTask{WebhookID: 21, CheckID: 11}).Return(nil)
+			},
+		},
+		{
+			name: "push fails after commit",
+			setup: func(d locationDeps) {
+				d.endpoints.EXPECT().ReadSubscribed(gomock.Any(), gomock.Any()).
+					Return([]*entity.WebhookEndpoint{endpoint}, nil)
+				d.webhooks.EXPECT().Create(gomock.Any(), gomock.Any()).Return(21, nil)
+				d.queue.EXPECT().Push(gomock.Any(), gomock.Any()).Return(errors.New("queue is down"))
+			},
+		},
+		{
+			name: "queue unavailable",
+			setup: func(d locationDeps) {
+				d.endpoints.EXPECT().ReadSubscribed(gomock.Any(), gomock.Any()).
+					Return([]*entity.WebhookEndpoint{endpoint}, nil)
+				d.webhooks.EXPECT().Create(gomock.Any(), gomock.Any()).Return(21, nil)
+				d.queue.EXPECT().Push(gomock.Any(), gomock.Any()).Return(entity.ErrDependencyUnavailable)
+			},
+		},
+		{
+			name: "no subscribers",
+			setup: func(d locationDeps) {
+				d.endpoints.EXPECT().ReadSubscribed(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name: "endpoints read fails",
+			setup: func(d locationDeps) {
+				d.endpoints.EXPECT().ReadSubscribed(gomock.Any(), gomock.Any()).Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+		{
+			name: "webhook write fails",
+			setup: func(d locationDeps) {
+				d.endpoints.EXPECT().ReadSubscribed(gomock.Any(), gomock.Any()).
+					Return([]*entity.WebhookEndpoint{endpoint}, nil)
+				d.webhooks.EXPECT().Create(gomock.Any(), gomock.Any()).Return(0, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, d := newLocationUseCase(t)
+			d.cache.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheHit([]*entity.Incident{inc}))
+			d.checks.EXPECT().Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, check entity.Check) (int, error) {
+					if !check.HasAlert || len(check.IncidentIDs) != 1 || check.IncidentIDs[0] != inc.ID {
+						t.Errorf("check = %+v, want alert for incident %d", check, inc.ID)
+					}
+					return 11, nil
+				})
+			tt.setup(d)
+
+			_, err := uc.CheckLocation(context.Background(), cases.LocationCheckQuery{
+				UserID:    "u1",
+				Latitude:  inc.Latitude,
+				Longitude: inc.Longitude,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckLocation() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckLocationSaveFails(t *testing.T) {
+	uc, d := newLocationUseCase(t)
+	d.cache.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheHit([]*entity.Incident{testIncident()}))
+	d.checks.EXPECT().Create(gomock.Any(), gomock.Any()).Return(0, errDB)
+
+	_, err := uc.CheckLocation(context.Background(), cases.LocationCheckQuery{UserID: "u1", Latitude: 55.7558, Longitude: 37.6173})
+	if !errors.Is(err, errDB) {
+		t.Fatalf("CheckLocation() error = %v, want %v", err, errDB)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
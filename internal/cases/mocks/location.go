@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/4otis/geonotify-service/internal/cases (interfaces: LocationUseCase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/location.go -package=mocks github.com/4otis/geonotify-service/internal/cases LocationUseCase
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cases "github.com/4otis/geonotify-service/internal/cases"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLocationUseCase is a mock of LocationUseCase interface.
+type MockLocationUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockLocationUseCaseMockRecorder is the mock recorder for MockLocationUseCase.
+type MockLocationUseCaseMockRecorder struct {
+	mock *MockLocationUseCase
+}
+
+// NewMockLocationUseCase creates a new mock instance.
+func NewMockLocationUseCase(ctrl *gomock.Controller) *MockLocationUseCase {
+	mock := &MockLocationUseCase{ctrl: ctrl}
+	mock.recorder = &MockLocationUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationUseCase) EXPECT() *MockLocationUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CheckLocation mocks base method.
+func (m *MockLocationUseCase) CheckLocation(ctx context.Context, query cases.LocationCheckQuery) (cases.LocationCheckResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLocation", ctx, query)
+	ret0, _ := ret[0].(cases.LocationCheckResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLocation indicates an expected call of CheckLocation.
+func (mr *MockLocationUseCaseMockRecorder) CheckLocation(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLocation", reflect.TypeOf((*MockLocationUseCase)(nil).CheckLocation), ctx, query)
+}
+
+// CheckLocations mocks base method.
+func (m *MockLocationUseCase) CheckLocations(ctx context.Context, queries []cases.LocationCheckQuery) ([]cases.LocationBatchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLocations", ctx, queries)
+	ret0, _ := ret[0].([]cases.LocationBatchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLocations indicates an expected call of CheckLocations.
+func (mr *MockLocationUseCaseMockRecorder) CheckLocations(ctx, queries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLocations", reflect.TypeOf((*MockLocationUseCase)(nil).CheckLocations), ctx, queries)
+}
+
+// IncidentsVersion mocks base method.
+func (m *MockLocationUseCase) IncidentsVersion(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncidentsVersion", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncidentsVersion indicates an expected call of IncidentsVersion.
+func (mr *MockLocationUseCaseMockRecorder) IncidentsVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncidentsVersion", reflect.TypeOf((*MockLocationUseCase)(nil).IncidentsVersion), ctx)
+}
+
+// InvalidateIncidentsCache mocks base method.
+func (m *MockLocationUseCase) InvalidateIncidentsCache(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateIncidentsCache", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateIncidentsCache indicates an expected call of InvalidateIncidentsCache.
+func (mr *MockLocationUseCaseMockRecorder) InvalidateIncidentsCache(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateIncidentsCache", reflect.TypeOf((*MockLocationUseCase)(nil).InvalidateIncidentsCache), ctx)
+}
+
+// SimulateLocation mocks base method.
+func (m *MockLocationUseCase) SimulateLocation(ctx context.Context, query cases.LocationCheckQuery) (cases.LocationCheckResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateLocation", ctx, query)
+	ret0, _ := ret[0].(cases.LocationCheckResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateLocation indicates an expected call of SimulateLocation.
+func (mr *MockLocationUseCaseMockRecorder) SimulateLocation(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateLocation", reflect.TypeOf((*MockLocationUseCase)(nil).SimulateLocation), ctx, query)
+}
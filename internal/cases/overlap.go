@@ -0,0 +1,79 @@
+package cases
+
+import (
+	"math"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/pkg/geojson"
+)
+
+// overlapGridSteps — число точек сетки по стороне квадрата вокруг меньшей зоны
+const overlapGridSteps = 40
+
+// metersPerDegree — длина градуса широты (и долготы на экваторе)
+const metersPerDegree = 111320.0
+
+// overlapping возвращает ID активных зон, перекрытых с зоной incident не меньше чем на threshold
+// (доля площади меньшей из двух зон)
+func overlapping(incident *entity.Incident, active []*entity.Incident, threshold float64) []int {
+	var ids []int
+	for _, other := range active {
+		if other.ID == incident.ID {
+			continue
+		}
+		if zoneOverlap(incident, other) >= threshold {
+			ids = append(ids, other.ID)
+		}
+	}
+	return ids
+}
+
+// zoneOverlap оценивает, какая доля площади меньшей зоны покрыта другой: точки равномерной сетки
+// в квадрате вокруг охватывающего круга меньшей зоны проверяются на попадание в обе зоны. Меньшей
+// считается зона с меньшим охватывающим кругом. На размерах зон (до десятков километров) плоская
+// проекция вокруг центра дает погрешность намного меньше шага сетки
+func zoneOverlap(a, b *entity.Incident) float64 {
+	if distanceMeters(a.Latitude, a.Longitude, b.Latitude, b.Longitude) >= a.Radius+b.Radius {
+		return 0
+	}
+
+	small, large := a, b
+	if b.Radius < a.Radius {
+		small, large = b, a
+	}
+	if small.Radius <= 0 {
+		return 0
+	}
+
+	step := 2 * small.Radius / overlapGridSteps
+	latStep := step / metersPerDegree
+	lngStep := step / (metersPerDegree * math.Cos(small.Latitude*math.Pi/180))
+
+	inSmall, inBoth := 0, 0
+	for i := 0; i < overlapGridSteps; i++ {
+		// точки в центрах ячеек сетки
+		lat := small.Latitude + (float64(i)-overlapGridSteps/2+0.5)*latStep
+		for j := 0; j < overlapGridSteps; j++ {
+			lng := small.Longitude + (float64(j)-overlapGridSteps/2+0.5)*lngStep
+			if !zoneContains(small, lat, lng) {
+				continue
+			}
+			inSmall++
+			if zoneContains(large, lat, lng) {
+				inBoth++
+			}
+		}
+	}
+	if inSmall == 0 {
+		return 0
+	}
+
+	return float64(inBoth) / float64(inSmall)
+}
+
+func zoneContains(incident *entity.Incident, lat, lng float64) bool {
+	if len(incident.Polygons) > 0 {
+		return geojson.MultiPolygon(incident.Polygons).Contains(lat, lng)
+	}
+	return distanceMeters(lat, lng, incident.Latitude, incident.Longitude) <= incident.Radius
+}
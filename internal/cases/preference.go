@@ -0,0 +1,220 @@
+package cases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/cache"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"go.uber.org/zap"
+)
+
+var _ PreferenceUseCase = (*PreferenceUseCaseImpl)(nil)
+
+// preferencesCacheTTL — сколько реплика может рассылать алерты по устаревшим настройкам
+// при кэше в памяти; Redis-кэш сбрасывается сразу при изменении
+const preferencesCacheTTL = time.Minute
+
+func preferencesCacheKey(userID string) string {
+	return "user_preferences:v1:" + userID
+}
+
+type PreferenceUseCase interface {
+	// UserPreferences возвращает настройки пользователя или настройки по умолчанию, если он их не сохранял
+	UserPreferences(ctx context.Context, userID string) (*entity.UserPreferences, error)
+	SetUserPreferences(ctx context.Context, prefs entity.UserPreferences) (*entity.UserPreferences, error)
+}
+
+type PreferenceUseCaseImpl struct {
+	repo   repo.PreferenceRepo
+	cache  cache.Cache
+	logger *zap.Logger
+}
+
+func NewPreferenceUseCase(repo repo.PreferenceRepo, cache cache.Cache, logger *zap.Logger) *PreferenceUseCaseImpl {
+	return &PreferenceUseCaseImpl{
+		repo:   repo,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+func (uc *PreferenceUseCaseImpl) UserPreferences(ctx context.Context, userID string) (*entity.UserPreferences, error) {
+	prefs, err := uc.repo.Read(ctx, userID)
+	if errors.Is(err, entity.ErrPreferencesNotFound) {
+		defaults := entity.DefaultUserPreferences(userID)
+		return &defaults, nil
+	}
+
+	return prefs, err
+}
+
+func (uc *PreferenceUseCaseImpl) SetUserPreferences(ctx context.Context, prefs entity.UserPreferences) (*entity.UserPreferences, error) {
+	prefs, err := normalizePreferences(prefs)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := uc.repo.Upsert(ctx, prefs)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("user preferences updated",
+		zap.String("user_id", saved.UserID),
+		zap.String("min_severity", saved.MinSeverity),
+		zap.Int("quiet_hours", len(saved.QuietHours)),
+		zap.Strings("channels", saved.Channels),
+		zap.Int("max_alerts_per_hour", saved.MaxAlertsPerHour))
+
+	if err := uc.cache.Delete(ctx, preferencesCacheKey(saved.UserID)); err != nil {
+		uc.logger.Warn("failed to invalidate cached user preferences",
+			zap.Error(err),
+			zap.String("user_id", saved.UserID))
+	}
+
+	return saved, nil
+}
+
+// normalizePreferences подставляет значения по умолчанию вместо незаданных полей, проверяет
+// остальные и убирает повторы каналов и дней
+func normalizePreferences(prefs entity.UserPreferences) (entity.UserPreferences, error) {
+	defaults := entity.DefaultUserPreferences(prefs.UserID)
+	if prefs.MinSeverity == "" {
+		prefs.MinSeverity = defaults.MinSeverity
+	}
+	if prefs.Timezone == "" {
+		prefs.Timezone = defaults.Timezone
+	}
+	if prefs.QuietHours == nil {
+		prefs.QuietHours = defaults.QuietHours
+	}
+	if prefs.Channels == nil {
+		prefs.Channels = defaults.Channels
+	}
+
+	if !entity.ValidSeverity(prefs.MinSeverity) {
+		return prefs, entity.ErrInvalidSeverity
+	}
+	if _, err := time.LoadLocation(prefs.Timezone); err != nil {
+		return prefs, entity.ErrInvalidTimezone
+	}
+	windows := make([]entity.QuietHours, len(prefs.QuietHours))
+	for i, w := range prefs.QuietHours {
+		start, err := clockMinutes(w.Start)
+		if err != nil {
+			return prefs, entity.ErrInvalidQuietHours
+		}
+		end, err := clockMinutes(w.End)
+		if err != nil || start == end {
+			return prefs, entity.ErrInvalidQuietHours
+		}
+
+		days := make([]string, 0, len(w.Days))
+		seen := make(map[string]bool, len(w.Days))
+		for _, day := range w.Days {
+			day = strings.ToLower(day)
+			if _, ok := entity.Weekdays[day]; !ok {
+				return prefs, entity.ErrInvalidQuietHours
+			}
+			if !seen[day] {
+				seen[day] = true
+				days = append(days, day)
+			}
+		}
+		windows[i] = entity.QuietHours{Start: w.Start, End: w.End, Days: days}
+	}
+	prefs.QuietHours = windows
+
+	if prefs.MaxAlertsPerHour < 0 {
+		return prefs, entity.ErrInvalidAlertLimit
+	}
+
+	channels := make([]string, 0, len(prefs.Channels))
+	seen := make(map[string]bool, len(prefs.Channels))
+	for _, channel := range prefs.Channels {
+		if !entity.ValidAlertChannel(channel) {
+			return prefs, entity.ErrInvalidAlertChannel
+		}
+		if !seen[channel] {
+			seen[channel] = true
+			channels = append(channels, channel)
+		}
+	}
+	if len(channels) == 0 {
+		return prefs, entity.ErrInvalidAlertChannel
+	}
+	prefs.Channels = channels
+
+	return prefs, nil
+}
+
+// quietAt сообщает, попадает ли момент t в одно из окон тишины по часовому поясу пользователя
+func quietAt(prefs entity.UserPreferences, t time.Time) bool {
+	if len(prefs.QuietHours) == 0 {
+		return false
+	}
+
+	loc, err := time.LoadLocation(prefs.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	today := local.Weekday()
+	yesterday := (today + 6) % 7
+
+	for _, w := range prefs.QuietHours {
+		start, err := clockMinutes(w.Start)
+		if err != nil {
+			continue
+		}
+		end, err := clockMinutes(w.End)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case start < end:
+			if minute >= start && minute < end && onDay(w, today) {
+				return true
+			}
+		// окно через полночь, например 22:00-07:00: утренняя часть относится к окну, начатому накануне
+		case minute >= start:
+			if onDay(w, today) {
+				return true
+			}
+		case minute < end:
+			if onDay(w, yesterday) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// onDay сообщает, начинается ли окно тишины в день day
+func onDay(w entity.QuietHours, day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if entity.Weekdays[d] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// clockMinutes переводит время "15:04" в минуты от полуночи
+func clockMinutes(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
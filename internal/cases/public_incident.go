@@ -0,0 +1,99 @@
+package cases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/cache"
+	"go.uber.org/zap"
+)
+
+var _ PublicIncidentUseCase = (*PublicIncidentUseCaseImpl)(nil)
+
+const publicIncidentsCacheKeyPrefix = "public:incidents:"
+
+// PublicIncidentUseCase — действующие опубликованные зоны для публичных приложений без ключа оператора
+type PublicIncidentUseCase interface {
+	// ActiveIncidents возвращает снимок действующих зон с переводом на язык из locales. Снимок живет в кэше
+	// свой срок и не сбрасывается при изменении зон
+	ActiveIncidents(ctx context.Context, locales []string) (*PublicIncidents, error)
+}
+
+// PublicIncidents — снимок зон без внутренних полей: описаний, адресов, авторов и расписаний.
+// Version — версия набора зон на момент снимка, по ней строится ETag
+type PublicIncidents struct {
+	Version   string
+	Incidents []*entity.Incident
+}
+
+type PublicIncidentUseCaseImpl struct {
+	feed   FeedUseCase
+	cache  cache.Cache
+	ttl    time.Duration
+	logger *zap.Logger
+}
+
+func NewPublicIncidentUseCase(feed FeedUseCase, cache cache.Cache, ttl time.Duration, logger *zap.Logger) *PublicIncidentUseCaseImpl {
+	return &PublicIncidentUseCaseImpl{
+		feed:   feed,
+		cache:  cache,
+		ttl:    ttl,
+		logger: logger,
+	}
+}
+
+func (uc *PublicIncidentUseCaseImpl) ActiveIncidents(ctx context.Context, locales []string) (*PublicIncidents, error) {
+	cacheKey := publicIncidentsCacheKeyPrefix + strings.Join(locales, ",")
+
+	var cached PublicIncidents
+	if err := uc.cache.Get(ctx, cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	// версия читается до зон: если зоны изменятся между запросами, снимок получит старую версию
+	// и следующий запрос после истечения кэша отдаст новый ETag
+	version, err := uc.feed.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents, err := uc.feed.ActiveIncidents(ctx, locales)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &PublicIncidents{
+		Version:   version,
+		Incidents: make([]*entity.Incident, len(incidents)),
+	}
+	for i, inc := range incidents {
+		snapshot.Incidents[i] = publicIncident(inc)
+	}
+
+	if err := uc.cache.Set(ctx, cacheKey, snapshot, uc.ttl); err != nil {
+		uc.logger.Warn("failed to cache public incidents", zap.Error(err))
+	}
+
+	return snapshot, nil
+}
+
+// publicIncident копирует только поля, которые можно показывать без авторизации
+func publicIncident(inc *entity.Incident) *entity.Incident {
+	return &entity.Incident{
+		ID:          inc.ID,
+		Name:        inc.Name,
+		Latitude:    inc.Latitude,
+		Longitude:   inc.Longitude,
+		Radius:      inc.Radius,
+		IsActive:    inc.IsActive,
+		UpdatedAt:   inc.UpdatedAt,
+		ExpiresAt:   inc.ExpiresAt,
+		Status:      inc.Status,
+		PublishedAt: inc.PublishedAt,
+		Severity:    inc.Severity,
+		Polygons:    inc.Polygons,
+		State:       inc.State,
+	}
+}
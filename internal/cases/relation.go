@@ -0,0 +1,205 @@
+package cases
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// IncidentRelations возвращает связи зоны с ее стороны: parent, child, supersedes, superseded_by
+func (uc *IncidentUseCaseImpl) IncidentRelations(ctx context.Context, incID int) ([]entity.IncidentRelation, error) {
+	// ReadIncident уже заполняет связи
+	incident, err := uc.ReadIncident(ctx, incID)
+	if err != nil {
+		return nil, err
+	}
+
+	return incident.Relations, nil
+}
+
+// AddIncidentRelation связывает зону relation.IncidentID с relation.RelatedID. Связь меняет обе зоны,
+// поэтому редактор может связывать только черновики; получатели опубликованных зон получают incident.updated
+// с обновленными supersedes и superseded_by
+func (uc *IncidentUseCaseImpl) AddIncidentRelation(ctx context.Context, relation entity.IncidentRelation) (*entity.IncidentRelation, error) {
+	if relation.Type != entity.RelationParent && relation.Type != entity.RelationSupersedes ||
+		relation.IncidentID == relation.RelatedID {
+		return nil, entity.ErrInvalidRelation
+	}
+	relation.CreatedBy = actorName(ctx)
+
+	var saved *entity.IncidentRelation
+	err := uc.changeRelation(ctx, relation, func(ctx context.Context) error {
+		if err := uc.checkRelationCycle(ctx, relation); err != nil {
+			return err
+		}
+
+		var err error
+		saved, err = uc.relations.Create(ctx, relation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("incident relation added",
+		zap.Int("id", saved.IncidentID),
+		zap.Int("related_id", saved.RelatedID),
+		zap.String("type", saved.Type),
+		zap.String("actor", saved.CreatedBy))
+
+	return saved, nil
+}
+
+func (uc *IncidentUseCaseImpl) DeleteIncidentRelation(ctx context.Context, relation entity.IncidentRelation) error {
+	err := uc.changeRelation(ctx, relation, func(ctx context.Context) error {
+		return uc.relations.Delete(ctx, relation)
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Info("incident relation deleted",
+		zap.Int("id", relation.IncidentID),
+		zap.Int("related_id", relation.RelatedID),
+		zap.String("type", relation.Type),
+		zap.String("actor", actorName(ctx)))
+
+	return nil
+}
+
+// changeRelation проверяет доступ к обеим зонам и выполняет change в транзакции вместе с вебхуками обеих зон
+func (uc *IncidentUseCaseImpl) changeRelation(ctx context.Context, relation entity.IncidentRelation, change func(ctx context.Context) error) error {
+	ids := []int{relation.IncidentID, relation.RelatedID}
+	for _, id := range ids {
+		incident, err := uc.repo.Read(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !canSee(ctx, incident.Visibility) {
+			return entity.ErrIncidentNotFound
+		}
+		if err := uc.checkEditable(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	var webhookIDs []int
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		befores := make([]*entity.Incident, len(ids))
+		for i, id := range ids {
+			before, err := uc.incidentSnapshot(ctx, id)
+			if err != nil {
+				return err
+			}
+			befores[i] = before
+		}
+
+		if err := change(ctx); err != nil {
+			return err
+		}
+
+		for i, id := range ids {
+			created, err := uc.incidentWebhook(ctx, id, befores[i])
+			if err != nil {
+				return err
+			}
+			webhookIDs = append(webhookIDs, created...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	return nil
+}
+
+// checkRelationCycle не дает связать зоны по кругу: заменить зону, которая заменяет эту,
+// или сделать зону родительской для собственного предка
+func (uc *IncidentUseCaseImpl) checkRelationCycle(ctx context.Context, relation entity.IncidentRelation) error {
+	visited := map[int]bool{relation.IncidentID: true}
+	current := relation.RelatedID
+	for current != 0 {
+		if visited[current] {
+			return entity.ErrRelationExists
+		}
+		visited[current] = true
+
+		related, err := uc.relations.ReadByIncidents(ctx, []int{current})
+		if err != nil {
+			return err
+		}
+
+		next := 0
+		for _, r := range related {
+			if r.IncidentID != current || r.Type != relation.Type {
+				continue
+			}
+			if r.RelatedID == relation.IncidentID {
+				return entity.ErrRelationExists
+			}
+			// у зоны одна родительская, а цепочки замен проверяются только на прямой возврат
+			if relation.Type == entity.RelationParent {
+				next = r.RelatedID
+			}
+		}
+		current = next
+	}
+
+	return nil
+}
+
+// withRelations заполняет Relations зон одним запросом
+func (uc *IncidentUseCaseImpl) withRelations(ctx context.Context, incidents []*entity.Incident) error {
+	if len(incidents) == 0 {
+		return nil
+	}
+
+	byID := make(map[int]*entity.Incident, len(incidents))
+	ids := make([]int, len(incidents))
+	for i, incident := range incidents {
+		byID[incident.ID] = incident
+		ids[i] = incident.ID
+	}
+
+	relations, err := uc.relations.ReadByIncidents(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, incident := range incidents {
+		incident.Relations = []entity.IncidentRelation{}
+	}
+	for _, r := range relations {
+		if incident, ok := byID[r.IncidentID]; ok {
+			incident.Relations = append(incident.Relations, r)
+		}
+		if incident, ok := byID[r.RelatedID]; ok {
+			incident.Relations = append(incident.Relations, r.From(r.RelatedID))
+		}
+	}
+
+	return nil
+}
+
+// supersessions возвращает зоны, которые заменяет зона incID, и зоны, которые заменяют ее, для вебхуков
+func (uc *IncidentUseCaseImpl) supersessions(ctx context.Context, incID int) (supersedes, supersededBy []int, err error) {
+	relations, err := uc.relations.ReadByIncidents(ctx, []int{incID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, r := range relations {
+		r = r.From(incID)
+		switch r.Type {
+		case entity.RelationSupersedes:
+			supersedes = append(supersedes, r.RelatedID)
+		case entity.RelationSupersededBy:
+			supersededBy = append(supersededBy, r.RelatedID)
+		}
+	}
+
+	return supersedes, supersededBy, nil
+}
@@ -0,0 +1,164 @@
+package cases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// TransitionIncident переводит зону в стадию state по разрешенному переходу (см. entity.CanTransition).
+// Черновики переводит и редактор, остальные зоны — публикатор. Зона, которая снова начала действовать,
+// оповещает пользователей рядом
+func (uc *IncidentUseCaseImpl) TransitionIncident(ctx context.Context, incID int, state string) (*entity.Incident, error) {
+	if !entity.ValidState(state) {
+		return nil, entity.ErrInvalidState
+	}
+
+	var (
+		current    *entity.Incident
+		updated    *entity.Incident
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkEditable(ctx, incID); err != nil {
+			return err
+		}
+		var err error
+		current, err = uc.repo.Read(ctx, incID)
+		if err != nil {
+			return err
+		}
+
+		webhookIDs, err = uc.setState(ctx, current, state)
+		if err != nil {
+			return err
+		}
+
+		updated, err = uc.repo.Read(ctx, incID)
+		if err != nil {
+			return err
+		}
+
+		ids, err := uc.incidentWebhook(ctx, incID, current)
+		webhookIDs = append(webhookIDs, ids...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	uc.logger.Info("incident state changed",
+		zap.Int("id", incID),
+		zap.String("from", current.State),
+		zap.String("to", updated.State),
+		zap.String("changed_by", updated.UpdatedBy))
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after changing incident state",
+			zap.Error(err))
+	}
+
+	if updated.Status == entity.IncidentPublished && !current.IsActive && updated.IsActive && uc.dispatcher != nil {
+		uc.notifyUsersNearby(ctx, incID)
+	}
+
+	return updated, nil
+}
+
+// setState вызывается внутри транзакции: переводит зону current в стадию to, пересчитывает is_active
+// и кладет в outbox incident.state_changed
+func (uc *IncidentUseCaseImpl) setState(ctx context.Context, current *entity.Incident, to string) ([]int, error) {
+	if !entity.CanTransition(current.State, to) {
+		return nil, entity.ErrInvalidStateTransition
+	}
+
+	next := *current
+	next.State = to
+	if err := applySchedule(&next, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.SetState(ctx, current.ID, current.State, to, next.IsActive, actorName(ctx)); err != nil {
+		return nil, err
+	}
+
+	return uc.stateWebhook(ctx, entity.StateChange{IncidentID: current.ID, From: current.State, To: to})
+}
+
+// applyActive переводит флаг is_active из запроса в стадию и при необходимости меняет ее внутри транзакции:
+// включение запланированной или завершенной зоны — active, выключение действующей — resolved.
+// Флаг, совпадающий с текущим is_active, стадию не меняет: так зона с расписанием вне окна не завершается
+func (uc *IncidentUseCaseImpl) applyActive(ctx context.Context, current *entity.Incident, active bool) (string, []int, error) {
+	var to string
+	switch {
+	case active == current.IsActive, active == entity.StateInEffect(current.State):
+		return current.State, nil, nil
+	case active && current.State == entity.StateArchived:
+		return "", nil, entity.ErrInvalidStateTransition
+	case active:
+		to = entity.StateActive
+	default:
+		to = entity.StateResolved
+	}
+
+	webhookIDs, err := uc.setState(ctx, current, to)
+	if err != nil {
+		return "", nil, err
+	}
+	return to, webhookIDs, nil
+}
+
+// applyActiveBatch включает или выключает зоны из списка через applyActive и возвращает id найденных
+func (uc *IncidentUseCaseImpl) applyActiveBatch(ctx context.Context, incIDs []int, active bool) ([]int, []int, error) {
+	var foundIDs, webhookIDs []int
+	for _, incID := range incIDs {
+		current, err := uc.repo.Read(ctx, incID)
+		if errors.Is(err, entity.ErrIncidentNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		foundIDs = append(foundIDs, incID)
+
+		_, ids, err := uc.applyActive(ctx, current, active)
+		if err != nil {
+			return nil, nil, err
+		}
+		webhookIDs = append(webhookIDs, ids...)
+	}
+
+	return foundIDs, webhookIDs, nil
+}
+
+// stateWebhook кладет в outbox incident.state_changed для опубликованной зоны; вызывается внутри транзакции
+func (uc *IncidentUseCaseImpl) stateWebhook(ctx context.Context, change entity.StateChange) ([]int, error) {
+	subscribed, err := uc.webhooks.Subscribed(ctx, entity.WebhookIncidentState)
+	if err != nil || !subscribed {
+		return nil, err
+	}
+
+	after, err := uc.repo.Read(ctx, change.IncidentID)
+	if err != nil {
+		return nil, err
+	}
+	if after.Status != entity.IncidentPublished {
+		return nil, nil
+	}
+
+	data := map[string]interface{}{
+		"incident_id": change.IncidentID,
+		"from":        change.From,
+		"to":          change.To,
+		"incident":    after,
+	}
+	if actor := actorName(ctx); actor != "" {
+		data["actor"] = actor
+	}
+
+	return uc.webhooks.Enqueue(ctx, entity.WebhookIncidentState, 0, data)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/delivery"
 	"github.com/4otis/geonotify-service/internal/port/repo"
 	"go.uber.org/zap"
 )
@@ -12,38 +14,128 @@ import (
 var _ StatsUseCase = (*StatsUseCaseImpl)(nil)
 
 type StatsUseCase interface {
-	GetStats(ctx context.Context, windowMinutes int) (userCount, totalChecks int, periodStart time.Time, err error)
+	// GetStats с estimate возвращает оценки вместо точных чисел: на больших таблицах это на порядки быстрее
+	GetStats(ctx context.Context, windowMinutes int, estimate bool) (userCount, totalChecks int, periodStart time.Time, err error)
 	GetActiveIncidentsCount(ctx context.Context) (int, error)
 	GetPendingWebhooksCount(ctx context.Context) (int, error)
+	GetDashboard(ctx context.Context, limit int) (*Dashboard, error)
+	GetQueueStatus(ctx context.Context) (*QueueStatus, error)
+	// GetHeatmap возвращает число проверок и алертов по H3-ячейкам разрешения resolution за часы с from до to
+	GetHeatmap(ctx context.Context, resolution int, from, to time.Time) ([]entity.HeatmapCell, error)
+	// GetIncidentStats возвращает сведенные счетчики за последние windowMinutes минут сводки
+	// и число проверок по видимым исполнителю зонам
+	GetIncidentStats(ctx context.Context, windowMinutes int) (*IncidentStats, error)
+	// RollupStats сводит в поминутные счетчики проверки старше settle и возвращает число сведенных минут
+	RollupStats(ctx context.Context, settle time.Duration) (int, error)
+	// DeleteStatsBefore удаляет поминутные счетчики раньше before
+	DeleteStatsBefore(ctx context.Context, before time.Time) error
 }
 
+// IncidentStats — сведенные счетчики проверок за минуты с PeriodStart до PeriodEnd
+type IncidentStats struct {
+	PeriodStart time.Time
+	// PeriodEnd — до какой минуты проверки сведены; отстает от текущего времени на задержку сводки
+	PeriodEnd time.Time
+	Totals    entity.CheckStats
+	Incidents []entity.IncidentCheckStats
+}
+
+// Dashboard — сводка для админки: зоны на карте, очередь вебхуков и последние события
+type Dashboard struct {
+	ActiveIncidents []*entity.Incident
+	// WebhookStates — число недоставленных вебхуков по состояниям (in progress, processing, failed)
+	WebhookStates  map[string]int
+	RecentChecks   []*entity.Check
+	FailedWebhooks []*entity.Webhook
+}
+
+// QueueStatus — состояние доставки вебхуков для мониторинга: очередь задач и outbox в БД
+type QueueStatus struct {
+	Queue entity.QueueDepth
+	// QueueErr — очередь недоступна (например, Redis в деградированном режиме); outbox при этом считается
+	QueueErr error
+	Outbox   entity.WebhookBacklog
+	// OldestPendingAge — возраст самого старого недоставленного вебхука,
+	// PollerLag — сколько самый старый готовый к отправке вебхук ждет, пока его захватят
+	OldestPendingAge time.Duration
+	PollerLag        time.Duration
+}
+
+const (
+	// maxHeatmapDays — наибольший период тепловой карты
+	maxHeatmapDays = 92
+	// statsRollupCursor — имя позиции сводки в search_sync_cursors: до какой минуты проверки уже сведены
+	statsRollupCursor = "stats:checks"
+	// statsRollupChunk — сколько минут сводится одной транзакцией
+	statsRollupChunk = time.Hour
+)
+
 type StatsUseCaseImpl struct {
 	incidentRepo repo.IncidentRepo
 	checkRepo    repo.CheckRepo
 	webhookRepo  repo.WebhookRepo
-	logger       *zap.Logger
+	heatmapRepo  repo.HeatmapRepo
+	statsRepo    repo.StatsRepo
+	cursors      repo.SyncCursorRepo
+	tx           repo.Transactor
+	queue        delivery.Queue
+	// heatmapResolution — разрешение, с которым записываются ячейки тепловой карты; мельче запросить нельзя
+	heatmapResolution int
+	// freshness — насколько сводка может отставать, чтобы GetStats отвечал по ней; 0 — GetStats всегда считает по checks
+	freshness time.Duration
+	logger    *zap.Logger
 }
 
 func NewStatsUseCase(
 	incidentRepo repo.IncidentRepo,
 	checkRepo repo.CheckRepo,
 	webhookRepo repo.WebhookRepo,
+	heatmapRepo repo.HeatmapRepo,
+	statsRepo repo.StatsRepo,
+	cursors repo.SyncCursorRepo,
+	tx repo.Transactor,
+	queue delivery.Queue,
+	heatmapResolution int,
+	freshness time.Duration,
 	logger *zap.Logger,
 ) *StatsUseCaseImpl {
 	return &StatsUseCaseImpl{
-		incidentRepo: incidentRepo,
-		checkRepo:    checkRepo,
-		webhookRepo:  webhookRepo,
-		logger:       logger,
+		incidentRepo:      incidentRepo,
+		checkRepo:         checkRepo,
+		webhookRepo:       webhookRepo,
+		heatmapRepo:       heatmapRepo,
+		statsRepo:         statsRepo,
+		cursors:           cursors,
+		tx:                tx,
+		queue:             queue,
+		heatmapResolution: heatmapResolution,
+		freshness:         freshness,
+		logger:            logger,
 	}
 }
 
-func (uc *StatsUseCaseImpl) GetStats(ctx context.Context, windowMinutes int) (userCount, totalChecks int, periodStart time.Time, err error) {
+func (uc *StatsUseCaseImpl) GetStats(ctx context.Context, windowMinutes int, estimate bool) (userCount, totalChecks int, periodStart time.Time, err error) {
 	if windowMinutes <= 0 {
 		return 0, 0, time.Time{}, fmt.Errorf("window minutes must be positive")
 	}
 
-	userCount, totalChecks, periodStart, err = uc.checkRepo.GetStats(ctx, windowMinutes)
+	if !estimate {
+		stats, periodStart, ok, err := uc.rolledUpStats(ctx, windowMinutes)
+		if err != nil {
+			return 0, 0, time.Time{}, err
+		}
+		if ok {
+			uc.logger.Debug("stats retrieved from rollups",
+				zap.Int("window_minutes", windowMinutes),
+				zap.Int("user_count", stats.Users),
+				zap.Int("total_checks", stats.Checks),
+				zap.Time("period_start", periodStart))
+
+			return stats.Users, stats.Checks, periodStart, nil
+		}
+	}
+
+	userCount, totalChecks, periodStart, err = uc.checkRepo.GetStats(ctx, windowMinutes, estimate)
 	if err != nil {
 		return 0, 0, time.Time{}, fmt.Errorf("failed to get stats: %w", err)
 	}
@@ -51,11 +143,46 @@ func (uc *StatsUseCaseImpl) GetStats(ctx context.Context, windowMinutes int) (us
 	uc.logger.Debug("stats retrieved",
 		zap.Int("window_minutes", windowMinutes),
 		zap.Int("user_count", userCount),
-		zap.Int("total_checks", totalChecks))
+		zap.Int("total_checks", totalChecks),
+		zap.Bool("estimated", estimate))
 
 	return userCount, totalChecks, periodStart, nil
 }
 
+// rolledUpStats считает окно по сводке, если она отстает не больше чем на freshness. Окно отсчитывается
+// от минуты, до которой проверки сведены, а не от текущего времени; ok false — сводка отстала или выключена
+func (uc *StatsUseCaseImpl) rolledUpStats(ctx context.Context, windowMinutes int) (stats entity.CheckStats, periodStart time.Time, ok bool, err error) {
+	if uc.freshness <= 0 {
+		return entity.CheckStats{}, time.Time{}, false, nil
+	}
+
+	until, err := uc.rolledUpUntil(ctx)
+	if err != nil {
+		return entity.CheckStats{}, time.Time{}, false, err
+	}
+	if time.Since(until) > uc.freshness {
+		return entity.CheckStats{}, time.Time{}, false, nil
+	}
+
+	periodStart = until.Add(-time.Duration(windowMinutes) * time.Minute)
+	stats, err = uc.statsRepo.Read(ctx, periodStart, until)
+	if err != nil {
+		return entity.CheckStats{}, time.Time{}, false, fmt.Errorf("failed to get rolled up stats: %w", err)
+	}
+
+	return stats, periodStart, true, nil
+}
+
+// rolledUpUntil возвращает минуту, до которой проверки сведены; нулевое время — сводки еще не было
+func (uc *StatsUseCaseImpl) rolledUpUntil(ctx context.Context) (time.Time, error) {
+	cursor, err := uc.cursors.Read(ctx, statsRollupCursor)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get stats rollup position: %w", err)
+	}
+
+	return cursor.UpdatedAt, nil
+}
+
 func (uc *StatsUseCaseImpl) GetActiveIncidentsCount(ctx context.Context) (int, error) {
 	incidents, err := uc.incidentRepo.ReadAllActive(ctx)
 	if err != nil {
@@ -74,3 +201,167 @@ func (uc *StatsUseCaseImpl) GetPendingWebhooksCount(ctx context.Context) (int, e
 
 	return len(webhooks), nil
 }
+
+// GetDashboard собирает сводку; limit ограничивает списки последних проверок и неудачных доставок
+func (uc *StatsUseCaseImpl) GetDashboard(ctx context.Context, limit int) (*Dashboard, error) {
+	incidents, err := uc.incidentRepo.ReadAllActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active incidents: %w", err)
+	}
+
+	states, err := uc.webhookRepo.CountByState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook queue depth: %w", err)
+	}
+
+	checks, err := uc.checkRepo.ReadRecent(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent checks: %w", err)
+	}
+
+	failed, err := uc.webhookRepo.ReadFailed(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failed webhooks: %w", err)
+	}
+
+	return &Dashboard{
+		ActiveIncidents: incidents,
+		WebhookStates:   states,
+		RecentChecks:    checks,
+		FailedWebhooks:  failed,
+	}, nil
+}
+
+func (uc *StatsUseCaseImpl) GetQueueStatus(ctx context.Context) (*QueueStatus, error) {
+	backlog, err := uc.webhookRepo.Backlog(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook backlog: %w", err)
+	}
+
+	status := &QueueStatus{Outbox: backlog}
+	if backlog.OldestPendingAt != nil {
+		status.OldestPendingAge = max(backlog.CheckedAt.Sub(*backlog.OldestPendingAt), 0)
+	}
+	if backlog.OldestDueAt != nil {
+		status.PollerLag = max(backlog.CheckedAt.Sub(*backlog.OldestDueAt), 0)
+	}
+
+	status.Queue, status.QueueErr = uc.queue.Depth(ctx)
+	if status.QueueErr != nil {
+		uc.logger.Warn("webhook queue depth unavailable", zap.Error(status.QueueErr))
+	}
+
+	return status, nil
+}
+
+// GetHeatmap суммирует почасовые счетчики, поэтому from и to учитываются с точностью до часа.
+// Проверки последних минут попадают в карту с задержкой сводки
+func (uc *StatsUseCaseImpl) GetHeatmap(ctx context.Context, resolution int, from, to time.Time) ([]entity.HeatmapCell, error) {
+	if resolution < 0 || resolution > uc.heatmapResolution ||
+		!from.Before(to) || to.Sub(from) > maxHeatmapDays*24*time.Hour {
+		return nil, entity.ErrInvalidHeatmap
+	}
+
+	cells, err := uc.heatmapRepo.Read(ctx, resolution, from.Truncate(time.Hour), to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check heatmap: %w", err)
+	}
+
+	uc.logger.Debug("heatmap retrieved",
+		zap.Int("resolution", resolution),
+		zap.Time("from", from),
+		zap.Time("to", to),
+		zap.Int("cells", len(cells)))
+
+	return cells, nil
+}
+
+// GetIncidentStats считает только по сводке: по checks без нее число проверок по зонам не получить.
+// Без сводки возвращает entity.ErrStatsNotReady
+func (uc *StatsUseCaseImpl) GetIncidentStats(ctx context.Context, windowMinutes int) (*IncidentStats, error) {
+	if windowMinutes <= 0 {
+		return nil, fmt.Errorf("window minutes must be positive")
+	}
+
+	until, err := uc.rolledUpUntil(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if until.IsZero() {
+		return nil, entity.ErrStatsNotReady
+	}
+
+	stats := &IncidentStats{
+		PeriodStart: until.Add(-time.Duration(windowMinutes) * time.Minute),
+		PeriodEnd:   until,
+	}
+
+	stats.Totals, err = uc.statsRepo.Read(ctx, stats.PeriodStart, stats.PeriodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rolled up stats: %w", err)
+	}
+
+	stats.Incidents, err = uc.statsRepo.ReadByIncident(ctx, stats.PeriodStart, stats.PeriodEnd, VisibleIncidents(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rolled up stats by incident: %w", err)
+	}
+
+	return stats, nil
+}
+
+// RollupStats сводит проверки по часу за транзакцию, пока не дойдет до минуты, в которой еще могут появиться
+// проверки моложе settle. Позиция читается с блокировкой в транзакции сводки, поэтому реплики без блокировок
+// воркеров не сводят одни минуты дважды. Первая сводка начинается с самой старой хранящейся проверки
+func (uc *StatsUseCaseImpl) RollupStats(ctx context.Context, settle time.Duration) (int, error) {
+	total := 0
+	for {
+		minutes := 0
+		err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			cursor, err := uc.cursors.ReadForUpdate(ctx, statsRollupCursor)
+			if err != nil {
+				return err
+			}
+
+			from := cursor.UpdatedAt
+			if from.IsZero() {
+				first, err := uc.statsRepo.FirstCheckAt(ctx)
+				if err != nil {
+					return err
+				}
+				if first.IsZero() {
+					return nil
+				}
+				from = first.UTC().Truncate(time.Minute)
+			}
+
+			to := time.Now().UTC().Add(-settle).Truncate(time.Minute)
+			if !from.Before(to) {
+				return nil
+			}
+			if chunk := from.Add(statsRollupChunk); chunk.Before(to) {
+				to = chunk
+			}
+
+			if err := uc.statsRepo.Rollup(ctx, from, to); err != nil {
+				return err
+			}
+			minutes = int(to.Sub(from) / time.Minute)
+			return uc.cursors.Save(ctx, statsRollupCursor, entity.SyncCursor{UpdatedAt: to})
+		})
+		if err != nil {
+			return total, fmt.Errorf("failed to roll up check stats: %w", err)
+		}
+		if minutes == 0 {
+			return total, nil
+		}
+		total += minutes
+	}
+}
+
+func (uc *StatsUseCaseImpl) DeleteStatsBefore(ctx context.Context, before time.Time) error {
+	if err := uc.statsRepo.DeleteBefore(ctx, before); err != nil {
+		return fmt.Errorf("failed to delete check stats: %w", err)
+	}
+
+	return nil
+}
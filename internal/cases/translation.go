@@ -0,0 +1,189 @@
+package cases
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/pkg/locale"
+	"go.uber.org/zap"
+)
+
+func (uc *IncidentUseCaseImpl) IncidentTranslations(ctx context.Context, incID int) ([]entity.IncidentTranslation, error) {
+	if _, err := uc.repo.Read(ctx, incID); err != nil {
+		return nil, err
+	}
+
+	return uc.translations.ReadByIncident(ctx, incID)
+}
+
+// SetIncidentTranslation создает или заменяет перевод зоны. Права те же, что на изменение самой зоны
+func (uc *IncidentUseCaseImpl) SetIncidentTranslation(ctx context.Context, translation entity.IncidentTranslation) (*entity.IncidentTranslation, error) {
+	tag, ok := locale.Canonical(translation.Locale)
+	if !ok {
+		return nil, entity.ErrInvalidLocale
+	}
+	translation.Locale = tag
+	translation.UpdatedBy = actorName(ctx)
+
+	var (
+		saved      *entity.IncidentTranslation
+		webhookIDs []int
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkEditable(ctx, translation.IncidentID); err != nil {
+			return err
+		}
+		before, err := uc.incidentSnapshot(ctx, translation.IncidentID)
+		if err != nil {
+			return err
+		}
+		if _, err := uc.repo.Read(ctx, translation.IncidentID); err != nil {
+			return err
+		}
+
+		if err := uc.translations.Upsert(ctx, translation); err != nil {
+			return err
+		}
+
+		translations, err := uc.translations.ReadByIncident(ctx, translation.IncidentID)
+		if err != nil {
+			return err
+		}
+		for i := range translations {
+			if translations[i].Locale == tag {
+				saved = &translations[i]
+			}
+		}
+		if saved == nil {
+			return entity.ErrTranslationNotFound
+		}
+
+		webhookIDs, err = uc.incidentWebhook(ctx, translation.IncidentID, before)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	uc.logger.Info("incident translation saved",
+		zap.Int("id", translation.IncidentID),
+		zap.String("locale", tag),
+		zap.String("updated_by", translation.UpdatedBy))
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after saving incident translation",
+			zap.Error(err))
+	}
+
+	return saved, nil
+}
+
+func (uc *IncidentUseCaseImpl) DeleteIncidentTranslation(ctx context.Context, incID int, loc string) error {
+	tag, ok := locale.Canonical(loc)
+	if !ok {
+		return entity.ErrInvalidLocale
+	}
+
+	var webhookIDs []int
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkEditable(ctx, incID); err != nil {
+			return err
+		}
+		before, err := uc.incidentSnapshot(ctx, incID)
+		if err != nil {
+			return err
+		}
+		if _, err := uc.repo.Read(ctx, incID); err != nil {
+			return err
+		}
+
+		if err := uc.translations.Delete(ctx, incID, tag, actorName(ctx)); err != nil {
+			return err
+		}
+
+		webhookIDs, err = uc.incidentWebhook(ctx, incID, before)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	uc.webhooks.Notify(ctx, 0, webhookIDs)
+
+	uc.logger.Info("incident translation deleted",
+		zap.Int("id", incID),
+		zap.String("locale", tag),
+		zap.String("deleted_by", actorName(ctx)))
+
+	if err := uc.locationCase.InvalidateIncidentsCache(ctx); err != nil {
+		uc.logger.Warn("failed to invalidate cache after deleting incident translation",
+			zap.Error(err))
+	}
+
+	return nil
+}
+
+// LocalizeIncidents возвращает зоны с названием и описанием на языке, лучше всего подходящем к locales
+// (по убыванию предпочтения). Зоны без подходящего перевода и зоны, для которых лучше подходит язык
+// исходного текста, возвращаются как есть; исходные зоны не меняются
+func (uc *IncidentUseCaseImpl) LocalizeIncidents(ctx context.Context, incidents []*entity.Incident, locales []string) ([]*entity.Incident, error) {
+	if len(locales) == 0 || len(incidents) == 0 {
+		return incidents, nil
+	}
+
+	incIDs := make([]int, len(incidents))
+	for i, inc := range incidents {
+		incIDs[i] = inc.ID
+	}
+	translations, err := uc.translations.ReadByIncidents(ctx, incIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byIncident := groupTranslations(translations)
+	localized := make([]*entity.Incident, len(incidents))
+	for i, inc := range incidents {
+		localized[i] = localizeIncident(inc, byIncident[inc.ID], locales, uc.defaultLocale)
+	}
+
+	return localized, nil
+}
+
+func groupTranslations(translations []entity.IncidentTranslation) map[int][]entity.IncidentTranslation {
+	byIncident := make(map[int][]entity.IncidentTranslation)
+	for _, t := range translations {
+		byIncident[t.IncidentID] = append(byIncident[t.IncidentID], t)
+	}
+	return byIncident
+}
+
+// localizeIncident возвращает копию зоны с переводом, подходящим к locales, или саму зону, если перевода нет
+// или клиенту больше подходит defaultLocale — язык исходного текста
+func localizeIncident(inc *entity.Incident, translations []entity.IncidentTranslation, locales []string, defaultLocale string) *entity.Incident {
+	if inc == nil || len(translations) == 0 {
+		return inc
+	}
+
+	available := make([]string, 0, len(translations)+1)
+	available = append(available, defaultLocale)
+	for _, t := range translations {
+		available = append(available, t.Locale)
+	}
+	tag, ok := locale.Match(locales, available)
+	if !ok || tag == defaultLocale {
+		return inc
+	}
+
+	for _, t := range translations {
+		if t.Locale != tag {
+			continue
+		}
+		localized := *inc
+		localized.Name = t.Name
+		if t.Descr != "" {
+			localized.Descr = t.Descr
+		}
+		return &localized
+	}
+	return inc
+}
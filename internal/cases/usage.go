@@ -0,0 +1,305 @@
+package cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/internal/port/usage"
+	"go.uber.org/zap"
+)
+
+var (
+	_ UsageUseCase     = (*UsageUseCaseImpl)(nil)
+	_ repo.CheckRepo   = (*MeteredCheckRepo)(nil)
+	_ repo.WebhookRepo = (*MeteredWebhookRepo)(nil)
+)
+
+// maxUsageDays — самый длинный период отчета об использовании
+const maxUsageDays = 366
+
+type UsageUseCase interface {
+	// Record учитывает запрос API-ключа. Квота исчерпана — entity.ErrQuotaExceeded и момент, когда она обновится.
+	// Недоступный счетчик не мешает запросу: он проходит без учета
+	Record(ctx context.Context, key string) (retryAt time.Time, err error)
+	// Flush переносит накопленные счетчики в БД и возвращает число перенесенных запросов
+	Flush(ctx context.Context) (int64, error)
+	// GetUsage возвращает запросы ключей за сутки с from по to (UTC). API-ключ, кроме SECRET_API_KEY,
+	// видит только себя
+	GetUsage(ctx context.Context, from, to time.Time) (*UsageReport, error)
+	// ExportUsage возвращает перенесенные в БД счетчики ключей по суткам с from по to (UTC) для биллинга,
+	// по ключу и дню; сутки без использования пропускаются. Ограничения те же, что у GetUsage
+	ExportUsage(ctx context.Context, from, to time.Time) ([]entity.APIKeyUsage, error)
+}
+
+type UsageReport struct {
+	From time.Time
+	To   time.Time
+	Keys []KeyUsage
+}
+
+// KeyUsage — использование одного ключа. Current — счетчики квот за текущие сутки и месяц,
+// CurrentErr — они недоступны; Days — перенесенные в БД запросы по суткам, текущие сутки в них отстают на интервал переноса
+type KeyUsage struct {
+	Key        string
+	Quota      entity.APIKeyQuota
+	Current    entity.UsageCounters
+	CurrentErr error
+	Days       []entity.APIKeyUsage
+}
+
+type UsageUseCaseImpl struct {
+	meter     usage.Meter
+	usageRepo repo.UsageRepo
+	// quotas — все известные ключи по имени, в том числе без ограничений
+	quotas map[string]entity.APIKeyQuota
+	logger *zap.Logger
+}
+
+func NewUsageUseCase(
+	meter usage.Meter,
+	usageRepo repo.UsageRepo,
+	quotas map[string]entity.APIKeyQuota,
+	logger *zap.Logger,
+) *UsageUseCaseImpl {
+	return &UsageUseCaseImpl{
+		meter:     meter,
+		usageRepo: usageRepo,
+		quotas:    quotas,
+		logger:    logger,
+	}
+}
+
+func (uc *UsageUseCaseImpl) Record(ctx context.Context, key string) (time.Time, error) {
+	now := time.Now().UTC()
+	quota := uc.quotas[key]
+
+	counters, err := uc.meter.Record(ctx, key, quota, now)
+	if errors.Is(err, entity.ErrDependencyUnavailable) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		uc.logger.Warn("failed to record api key usage, request is not metered",
+			zap.Error(err),
+			zap.String("api_key", key))
+		return time.Time{}, nil
+	}
+	if counters.Allowed {
+		return time.Time{}, nil
+	}
+
+	retryAt := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	if quota.Monthly > 0 && counters.Month >= quota.Monthly {
+		retryAt = time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	uc.logger.Debug("api key quota exceeded",
+		zap.String("api_key", key),
+		zap.Int64("day", counters.Day),
+		zap.Int64("month", counters.Month),
+		zap.Time("retry_at", retryAt))
+
+	return retryAt, entity.ErrQuotaExceeded
+}
+
+func (uc *UsageUseCaseImpl) Flush(ctx context.Context) (int64, error) {
+	drained, err := uc.meter.Drain(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to drain usage counters: %w", err)
+	}
+
+	var requests int64
+	for _, u := range drained {
+		requests += u.Requests
+	}
+
+	if err := uc.usageRepo.Add(ctx, drained); err != nil {
+		// счетчики возвращаются, чтобы перенести их в следующий раз
+		if restoreErr := uc.meter.Add(ctx, drained); restoreErr != nil {
+			uc.logger.Error("failed to restore usage counters, requests are lost",
+				zap.Error(restoreErr),
+				zap.Int64("requests", requests))
+		}
+		return 0, fmt.Errorf("failed to save usage: %w", err)
+	}
+
+	return requests, nil
+}
+
+func (uc *UsageUseCaseImpl) GetUsage(ctx context.Context, from, to time.Time) (*UsageReport, error) {
+	history, only, err := uc.history(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*KeyUsage)
+	add := func(key string) *KeyUsage {
+		if ku, ok := byKey[key]; ok {
+			return ku
+		}
+		ku := &KeyUsage{Key: key, Quota: uc.quotas[key], Days: make([]entity.APIKeyUsage, 0)}
+		byKey[key] = ku
+		return ku
+	}
+	for key := range uc.quotas {
+		if only == "" || key == only {
+			add(key)
+		}
+	}
+	// в истории остаются и ключи, удаленные из настроек
+	for _, u := range history {
+		if only == "" || u.Key == only {
+			ku := add(u.Key)
+			ku.Days = append(ku.Days, u)
+		}
+	}
+
+	now := time.Now()
+	report := &UsageReport{From: from, To: to, Keys: make([]KeyUsage, 0, len(byKey))}
+	for _, ku := range byKey {
+		ku.Current, ku.CurrentErr = uc.meter.Current(ctx, ku.Key, now)
+		report.Keys = append(report.Keys, *ku)
+	}
+	sort.Slice(report.Keys, func(i, j int) bool { return report.Keys[i].Key < report.Keys[j].Key })
+
+	uc.logger.Debug("api key usage retrieved",
+		zap.Time("from", from),
+		zap.Time("to", to),
+		zap.Int("keys", len(report.Keys)))
+
+	return report, nil
+}
+
+func (uc *UsageUseCaseImpl) ExportUsage(ctx context.Context, from, to time.Time) ([]entity.APIKeyUsage, error) {
+	history, only, err := uc.history(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]entity.APIKeyUsage, 0, len(history))
+	for _, u := range history {
+		if only == "" || u.Key == only {
+			rows = append(rows, u)
+		}
+	}
+
+	uc.logger.Info("api key usage exported",
+		zap.Time("from", from),
+		zap.Time("to", to),
+		zap.Int("rows", len(rows)))
+
+	return rows, nil
+}
+
+// history возвращает использование за период и ключ, которым ограничен запрос; пустой — все ключи
+func (uc *UsageUseCaseImpl) history(ctx context.Context, from, to time.Time) ([]entity.APIKeyUsage, string, error) {
+	if to.Before(from) || to.Sub(from) > maxUsageDays*24*time.Hour {
+		return nil, "", entity.ErrInvalidUsageDay
+	}
+
+	history, err := uc.usageRepo.List(ctx, from, to)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get usage: %w", err)
+	}
+
+	// ключ клиента не должен видеть, сколько запросов делают другие
+	only := ""
+	if actor, ok := ActorFromContext(ctx); ok && actor.APIKey != "" && actor.APIKey != entity.DefaultAPIKey {
+		only = actor.APIKey
+	}
+
+	return history, only, nil
+}
+
+// MeteredCheckRepo учитывает записанные проверки и проверки, попавшие в зоны, за API-ключом запроса.
+// Проверки без API-ключа (операторы, потоки координат) не учитываются
+type MeteredCheckRepo struct {
+	repo.CheckRepo
+	meter  usage.Meter
+	logger *zap.Logger
+}
+
+func NewMeteredCheckRepo(next repo.CheckRepo, meter usage.Meter, logger *zap.Logger) *MeteredCheckRepo {
+	return &MeteredCheckRepo{CheckRepo: next, meter: meter, logger: logger}
+}
+
+func (r *MeteredCheckRepo) Create(ctx context.Context, check entity.Check) (int, error) {
+	checkID, err := r.CheckRepo.Create(ctx, check)
+	if err == nil {
+		r.count(ctx, []entity.Check{check})
+	}
+	return checkID, err
+}
+
+func (r *MeteredCheckRepo) CreateBatch(ctx context.Context, checks []entity.Check) ([]int, error) {
+	checkIDs, err := r.CheckRepo.CreateBatch(ctx, checks)
+	if err == nil {
+		r.count(ctx, checks)
+	}
+	return checkIDs, err
+}
+
+func (r *MeteredCheckRepo) CopyBatch(ctx context.Context, checks []entity.Check) error {
+	err := r.CheckRepo.CopyBatch(ctx, checks)
+	if err == nil {
+		r.count(ctx, checks)
+	}
+	return err
+}
+
+func (r *MeteredCheckRepo) count(ctx context.Context, checks []entity.Check) {
+	key := requestAPIKey(ctx)
+	if key == "" || len(checks) == 0 {
+		return
+	}
+
+	u := entity.APIKeyUsage{Key: key, Day: time.Now(), Checks: int64(len(checks))}
+	for _, check := range checks {
+		if check.HasAlert {
+			u.Alerts++
+		}
+	}
+	meterUsage(ctx, r.meter, r.logger, u)
+}
+
+// MeteredWebhookRepo учитывает вебхуки, созданные по запросам API-ключа: по одному на получателя, без повторов доставки
+type MeteredWebhookRepo struct {
+	repo.WebhookRepo
+	meter  usage.Meter
+	logger *zap.Logger
+}
+
+func NewMeteredWebhookRepo(next repo.WebhookRepo, meter usage.Meter, logger *zap.Logger) *MeteredWebhookRepo {
+	return &MeteredWebhookRepo{WebhookRepo: next, meter: meter, logger: logger}
+}
+
+func (r *MeteredWebhookRepo) Create(ctx context.Context, w entity.Webhook) (int, error) {
+	webhookID, err := r.WebhookRepo.Create(ctx, w)
+	if err != nil {
+		return 0, err
+	}
+
+	if key := requestAPIKey(ctx); key != "" {
+		meterUsage(ctx, r.meter, r.logger, entity.APIKeyUsage{Key: key, Day: time.Now(), Webhooks: 1})
+	}
+	return webhookID, nil
+}
+
+// requestAPIKey — имя API-ключа запроса, пустое для операторов и фоновых задач
+func requestAPIKey(ctx context.Context) string {
+	actor, _ := ActorFromContext(ctx)
+	return actor.APIKey
+}
+
+// meterUsage не возвращает ошибку: недоступный счетчик не должен срывать проверку, использование при этом теряется
+func meterUsage(ctx context.Context, meter usage.Meter, logger *zap.Logger, u entity.APIKeyUsage) {
+	if err := meter.Add(ctx, []entity.APIKeyUsage{u}); err != nil {
+		logger.Debug("failed to meter api key usage",
+			zap.Error(err),
+			zap.String("api_key", u.Key))
+	}
+}
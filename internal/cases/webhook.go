@@ -0,0 +1,416 @@
+package cases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/4otis/geonotify-service/config"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/delivery"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"go.uber.org/zap"
+)
+
+var _ WebhookUseCase = (*WebhookUseCaseImpl)(nil)
+
+type WebhookUseCase interface {
+	SendTestWebhook(ctx context.Context, targetURL string, attempts int) (WebhookTestResult, error)
+	ReplayFailed(ctx context.Context, ids []int) (int, error)
+	RedriveFailed(ctx context.Context, filter entity.WebhookRedriveFilter) (int, error)
+}
+
+type WebhookUseCaseImpl struct {
+	webhookRepo  repo.WebhookRepo
+	endpointRepo repo.WebhookEndpointRepo
+	outbox       *WebhookOutbox
+	tx           repo.Transactor
+	sender       delivery.WebhookSender
+	settings     *config.Holder
+	logger       *zap.Logger
+}
+
+func NewWebhookUseCase(
+	webhookRepo repo.WebhookRepo,
+	endpointRepo repo.WebhookEndpointRepo,
+	outbox *WebhookOutbox,
+	tx repo.Transactor,
+	sender delivery.WebhookSender,
+	settings *config.Holder,
+	logger *zap.Logger,
+) *WebhookUseCaseImpl {
+	return &WebhookUseCaseImpl{
+		webhookRepo:  webhookRepo,
+		endpointRepo: endpointRepo,
+		outbox:       outbox,
+		tx:           tx,
+		sender:       sender,
+		settings:     settings,
+		logger:       logger,
+	}
+}
+
+type WebhookTestResult struct {
+	Delivered  bool
+	StatusCode int
+	Latency    time.Duration
+	Attempts   int
+	Err        error
+}
+
+const testWebhookRetryDelay = time.Second
+
+// SendTestWebhook отправляет тестовый payload тем же отправителем (с подписью), что и воркер.
+// Ретраи выполняются синхронно с короткой паузой, их число ограничено WEBHOOK_MAX_RETRIES
+func (uc *WebhookUseCaseImpl) SendTestWebhook(ctx context.Context, targetURL string, attempts int) (WebhookTestResult, error) {
+	u, err := url.Parse(targetURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return WebhookTestResult{}, entity.ErrInvalidWebhookURL
+	}
+
+	maxAttempts := uc.settings.Get().MaxRetries + 1
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > maxAttempts {
+		attempts = maxAttempts
+	}
+
+	// тестовый вебхук повторяет конверт тревоги проверки, чтобы получатель проверил и разбор события
+	payload, err := json.Marshal(map[string]interface{}{
+		"event":      entity.WebhookLocationAlert,
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+		"data": map[string]interface{}{
+			"check_id":  0,
+			"test":      true,
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+			"incidents": []*entity.Incident{{
+				ID:        0,
+				Name:      "Test incident",
+				Descr:     "Sample payload sent from geonotify-service",
+				Latitude:  55.7558,
+				Longitude: 37.6173,
+				Radius:    500,
+				IsActive:  true,
+				State:     entity.StateActive,
+				CreatedAt: time.Now().UTC(),
+				UpdatedAt: time.Now().UTC(),
+			}},
+		},
+	})
+	if err != nil {
+		return WebhookTestResult{}, fmt.Errorf("failed to marshal test payload: %w", err)
+	}
+
+	var result WebhookTestResult
+	for attempt := 1; attempt <= attempts; attempt++ {
+		// тестовый вебхук подписывается секретом WEBHOOK_SECRET, как и получатель из конфигурации
+		endpoint := entity.WebhookEndpoint{URL: targetURL, Secret: uc.settings.Get().WebhookSecret}
+		res, sendErr := uc.sender.Send(ctx, endpoint, entity.WebhookLocationAlert, payload)
+		result = WebhookTestResult{
+			Delivered:  sendErr == nil,
+			StatusCode: res.StatusCode,
+			Latency:    res.Latency,
+			Attempts:   attempt,
+			Err:        sendErr,
+		}
+
+		if sendErr == nil || attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, nil
+		case <-time.After(testWebhookRetryDelay * time.Duration(attempt)):
+		}
+	}
+
+	uc.logger.Info("test webhook sent",
+		zap.String("url", targetURL),
+		zap.Bool("delivered", result.Delivered),
+		zap.Int("status_code", result.StatusCode),
+		zap.Int("attempts", result.Attempts))
+
+	return result, nil
+}
+
+// ReplayFailed повторно ставит в доставку вебхуки, исчерпавшие попытки; пустой ids — все такие вебхуки
+func (uc *WebhookUseCaseImpl) ReplayFailed(ctx context.Context, ids []int) (int, error) {
+	requeued, err := uc.webhookRepo.RequeueFailed(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	uc.logger.Info("failed webhooks requeued",
+		zap.Int("requeued", requeued),
+		zap.Ints("ids", ids),
+		zap.String("actor", actorName(ctx)))
+
+	return requeued, nil
+}
+
+// RedriveFailed возвращает в доставку недоставленные вебхуки по фильтру, например после простоя получателя.
+// В отличие от ReplayFailed вебхуки сразу ставятся в очередь, а не ждут опроса outbox
+func (uc *WebhookUseCaseImpl) RedriveFailed(ctx context.Context, filter entity.WebhookRedriveFilter) (int, error) {
+	if filter.State == "" {
+		filter.State = "failed"
+	}
+
+	webhooks, err := uc.webhookRepo.Redrive(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	uc.outbox.NotifyAt(ctx, webhooks)
+
+	uc.logger.Info("failed webhooks redriven",
+		zap.Int("redriven", len(webhooks)),
+		zap.Time("created_from", filter.CreatedFrom),
+		zap.Time("created_to", filter.CreatedTo),
+		zap.Int("incident_id", filter.IncidentID),
+		zap.Int("endpoint_id", filter.EndpointID),
+		zap.Duration("spread", filter.Spread),
+		zap.String("actor", actorName(ctx)))
+
+	return len(webhooks), nil
+}
+
+// WebhookOutbox записывает события для получателей вебхуков в outbox: каждому подписанному
+// получателю — отдельный вебхук со своими попытками. Enqueue вызывается в транзакции изменения,
+// Notify — после ее коммита, чтобы разбудить воркер
+type WebhookOutbox struct {
+	repo      repo.WebhookRepo
+	endpoints repo.WebhookEndpointRepo
+	queue     delivery.Queue
+	// maxPayload — предел размера payload в байтах, см. shrinkWebhookData
+	maxPayload int
+	logger     *zap.Logger
+
+	mu       sync.Mutex
+	cached   []*entity.WebhookEndpoint
+	cachedAt time.Time
+}
+
+// subscriptionsTTL — насколько устаревшим может быть список получателей в Subscribed
+const subscriptionsTTL = 5 * time.Second
+
+func NewWebhookOutbox(webhookRepo repo.WebhookRepo, endpoints repo.WebhookEndpointRepo, queue delivery.Queue,
+	maxPayloadBytes int, logger *zap.Logger) *WebhookOutbox {
+	return &WebhookOutbox{
+		repo:       webhookRepo,
+		endpoints:  endpoints,
+		queue:      queue,
+		maxPayload: maxPayloadBytes,
+		logger:     logger,
+	}
+}
+
+// Subscribed сообщает, ждет ли хоть один включенный получатель одно из событий. Ответ строится
+// по списку получателей, кэшированному на subscriptionsTTL, и нужен лишь чтобы не готовить
+// данные событий впустую; сами вебхуки Enqueue создает по актуальному списку
+func (o *WebhookOutbox) Subscribed(ctx context.Context, eventTypes ...string) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.cached == nil || time.Since(o.cachedAt) > subscriptionsTTL {
+		endpoints, err := o.endpoints.ReadAll(ctx)
+		if err != nil {
+			return false, err
+		}
+		o.cached = endpoints
+		if o.cached == nil {
+			o.cached = []*entity.WebhookEndpoint{}
+		}
+		o.cachedAt = time.Now()
+	}
+
+	for _, endpoint := range o.cached {
+		if !endpoint.Enabled {
+			continue
+		}
+		for _, eventType := range eventTypes {
+			if endpoint.Subscribed(eventType) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Enqueue оборачивает data в конверт {event, created_at, data} и кладет по вебхуку на каждого
+// подписанного получателя. checkID 0 — событие без проверки
+func (o *WebhookOutbox) Enqueue(ctx context.Context, eventType string, checkID int, data interface{}) ([]int, error) {
+	endpoints, err := o.endpoints.ReadSubscribed(ctx, eventType)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.EnqueueTo(ctx, endpoints, eventType, checkID, data)
+}
+
+// EnqueueTo кладет событие получателям endpoints независимо от их подписок, например получателю группы
+func (o *WebhookOutbox) EnqueueTo(ctx context.Context, endpoints []*entity.WebhookEndpoint, eventType string,
+	checkID int, data interface{}) ([]int, error) {
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+
+	var err error
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	var payload []byte
+	for step := 0; ; step++ {
+		payload, err = json.Marshal(map[string]interface{}{
+			"event":      eventType,
+			"created_at": createdAt,
+			"data":       data,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s webhook payload: %w", eventType, err)
+		}
+		if o.maxPayload <= 0 || len(payload) <= o.maxPayload {
+			break
+		}
+
+		fields, ok := data.(map[string]interface{})
+		if !ok || !shrinkWebhookData(fields, step) {
+			// проверка или изменение не должны падать из-за вебхука, поэтому событие только теряется
+			o.logger.Error("webhook payload exceeds size limit, event dropped",
+				zap.String("event", eventType),
+				zap.Int("check_id", checkID),
+				zap.Int("payload_bytes", len(payload)),
+				zap.Int("limit_bytes", o.maxPayload))
+			return nil, nil
+		}
+		o.logger.Warn("webhook payload exceeds size limit, truncating",
+			zap.String("event", eventType),
+			zap.Int("check_id", checkID),
+			zap.Int("payload_bytes", len(payload)),
+			zap.Int("step", step))
+	}
+
+	webhookIDs := make([]int, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		webhookID, err := o.repo.Create(ctx, entity.Webhook{
+			EndpointID:  endpoint.ID,
+			CheckID:     checkID,
+			EventType:   eventType,
+			State:       "in progress",
+			Payload:     payload,
+			ScheduledAt: time.Now(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webhook record: %w", err)
+		}
+
+		o.logger.Info("webhook created",
+			zap.Int("webhook_id", webhookID),
+			zap.Int("endpoint_id", endpoint.ID),
+			zap.String("event", eventType),
+			zap.Int("check_id", checkID))
+
+		webhookIDs = append(webhookIDs, webhookID)
+	}
+
+	return webhookIDs, nil
+}
+
+// shrinkWebhookData урезает данные события на шаге step: на шаге 0 у зон в "incident" и "incidents"
+// убирается геометрия полигонов ("geometry_omitted": true), на шаге 1 зоны заменяются их ID
+// ("incident_ids" вместо "incidents", "incident" убирается — его ID уже в "incident_id"), а комментарии зон убираются.
+// Возвращает false, когда урезать больше нечего
+func shrinkWebhookData(data map[string]interface{}, step int) bool {
+	incident, _ := data["incident"].(*entity.Incident)
+	incidents, _ := data["incidents"].([]*entity.Incident)
+	if incident == nil && incidents == nil {
+		return false
+	}
+
+	switch step {
+	case 0:
+		// зоны общие с кэшем активных инцидентов, поэтому меняются копии
+		withoutPolygons := func(inc *entity.Incident) *entity.Incident {
+			c := *inc
+			c.Polygons = nil
+			return &c
+		}
+		if incident != nil {
+			data["incident"] = withoutPolygons(incident)
+		}
+		if incidents != nil {
+			stripped := make([]*entity.Incident, len(incidents))
+			for i, inc := range incidents {
+				stripped[i] = withoutPolygons(inc)
+			}
+			data["incidents"] = stripped
+		}
+		data["geometry_omitted"] = true
+	case 1:
+		if incident != nil {
+			delete(data, "incident")
+		}
+		if incidents != nil {
+			ids := make([]int, len(incidents))
+			for i, inc := range incidents {
+				ids[i] = inc.ID
+			}
+			delete(data, "incidents")
+			data["incident_ids"] = ids
+		}
+		delete(data, "latest_comment")
+		delete(data, "latest_comments")
+		data["truncated"] = true
+	default:
+		return false
+	}
+	return true
+}
+
+// Notify будит воркер после коммита транзакции; если push не удался,
+// вебхук все равно будет доставлен при ближайшем опросе outbox
+func (o *WebhookOutbox) Notify(ctx context.Context, checkID int, webhookIDs []int) {
+	for _, webhookID := range webhookIDs {
+		err := o.queue.Push(ctx, entity.WebhookTask{WebhookID: webhookID, CheckID: checkID})
+		if errors.Is(err, entity.ErrDependencyUnavailable) {
+			return
+		}
+		if err != nil {
+			o.logger.Warn("failed to push webhook to queue",
+				zap.Error(err),
+				zap.Int("webhook_id", webhookID))
+		}
+	}
+}
+
+// NotifyAt будит воркер к ScheduledAt каждого вебхука; как и в Notify, потерянные
+// задачи подберет опрос outbox
+func (o *WebhookOutbox) NotifyAt(ctx context.Context, webhooks []*entity.Webhook) {
+	for _, wh := range webhooks {
+		task := entity.WebhookTask{WebhookID: wh.ID, CheckID: wh.CheckID}
+
+		var err error
+		if wh.ScheduledAt.After(time.Now()) {
+			err = o.queue.Schedule(ctx, task, wh.ScheduledAt)
+		} else {
+			err = o.queue.Push(ctx, task)
+		}
+		if errors.Is(err, entity.ErrDependencyUnavailable) {
+			return
+		}
+		if err != nil {
+			o.logger.Warn("failed to push webhook to queue",
+				zap.Error(err),
+				zap.Int("webhook_id", wh.ID))
+		}
+	}
+}
+
+// invalidate сбрасывает кэш Subscribed после изменения получателей в этом экземпляре сервиса
+func (o *WebhookOutbox) invalidate() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.cached = nil
+}
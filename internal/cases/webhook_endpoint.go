@@ -0,0 +1,256 @@
+package cases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/zap"
+)
+
+var _ WebhookEndpointUseCase = (*WebhookUseCaseImpl)(nil)
+
+// WebhookEndpointUseCase — получатели вебхуков: каждое событие доставляется всем включенным
+// получателям, подписанным на его тип. Изменять получателей может только публикатор
+type WebhookEndpointUseCase interface {
+	ListEndpoints(ctx context.Context) ([]*entity.WebhookEndpoint, error)
+	ReadEndpoint(ctx context.Context, endpointID int) (*entity.WebhookEndpoint, error)
+	CreateEndpoint(ctx context.Context, endpoint entity.WebhookEndpoint) (*entity.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, endpointID int, patch entity.WebhookEndpointPatch) (*entity.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, endpointID int) error
+	// EndpointSLO возвращает задержку доставки получателю и ее сравнение с целью WEBHOOK_SLO_*
+	EndpointSLO(ctx context.Context, endpointID int) (*WebhookSLO, error)
+	// EndpointSLOs — то же по всем общим получателям
+	EndpointSLOs(ctx context.Context) ([]WebhookSLO, error)
+}
+
+func (uc *WebhookUseCaseImpl) ListEndpoints(ctx context.Context) ([]*entity.WebhookEndpoint, error) {
+	return uc.endpointRepo.ReadAll(ctx)
+}
+
+func (uc *WebhookUseCaseImpl) ReadEndpoint(ctx context.Context, endpointID int) (*entity.WebhookEndpoint, error) {
+	return uc.readEndpoint(ctx, endpointID)
+}
+
+// readEndpoint читает общего получателя: получатели групп управляются через группы
+func (uc *WebhookUseCaseImpl) readEndpoint(ctx context.Context, endpointID int) (*entity.WebhookEndpoint, error) {
+	endpoint, err := uc.endpointRepo.Read(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.GroupID != 0 {
+		return nil, entity.ErrWebhookEndpointNotFound
+	}
+	return endpoint, nil
+}
+
+func (uc *WebhookUseCaseImpl) CreateEndpoint(ctx context.Context, endpoint entity.WebhookEndpoint) (*entity.WebhookEndpoint, error) {
+	if err := requirePublisher(ctx); err != nil {
+		return nil, err
+	}
+	if err := uc.validateEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+
+	endpoint.CreatedBy = actorName(ctx)
+	endpointID, err := uc.endpointRepo.Create(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	uc.outbox.invalidate()
+
+	uc.logger.Info("webhook endpoint created",
+		zap.Int("endpoint_id", endpointID),
+		zap.String("url", endpoint.URL),
+		zap.Strings("events", endpoint.Events),
+		zap.String("actor", endpoint.CreatedBy))
+
+	return uc.endpointRepo.Read(ctx, endpointID)
+}
+
+func (uc *WebhookUseCaseImpl) UpdateEndpoint(ctx context.Context, endpointID int, patch entity.WebhookEndpointPatch) (*entity.WebhookEndpoint, error) {
+	if err := requirePublisher(ctx); err != nil {
+		return nil, err
+	}
+
+	var endpoint *entity.WebhookEndpoint
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		endpoint, err = uc.readEndpoint(ctx, endpointID)
+		if err != nil {
+			return err
+		}
+
+		patch.Apply(endpoint)
+		if err := uc.validateEndpoint(*endpoint); err != nil {
+			return err
+		}
+
+		return uc.endpointRepo.Update(ctx, *endpoint)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.outbox.invalidate()
+
+	uc.logger.Info("webhook endpoint updated",
+		zap.Int("endpoint_id", endpointID),
+		zap.Bool("enabled", endpoint.Enabled),
+		zap.String("actor", actorName(ctx)))
+
+	return uc.endpointRepo.Read(ctx, endpointID)
+}
+
+// DeleteEndpoint удаляет получателя; его недоставленные вебхуки удаляются вместе с ним
+func (uc *WebhookUseCaseImpl) DeleteEndpoint(ctx context.Context, endpointID int) error {
+	if err := requirePublisher(ctx); err != nil {
+		return err
+	}
+
+	if _, err := uc.readEndpoint(ctx, endpointID); err != nil {
+		return err
+	}
+	if err := uc.endpointRepo.Delete(ctx, endpointID); err != nil {
+		return err
+	}
+	uc.outbox.invalidate()
+
+	uc.logger.Info("webhook endpoint deleted",
+		zap.Int("endpoint_id", endpointID),
+		zap.String("actor", actorName(ctx)))
+
+	return nil
+}
+
+// BootstrapEndpoint заводит получателя из WEBHOOK_URL, пока получателей в базе нет, и передает ему
+// вебхуки, созданные до появления получателей. Дальше получатели управляются только через API
+func (uc *WebhookUseCaseImpl) BootstrapEndpoint(ctx context.Context, targetURL, secret string, events []string) error {
+	if targetURL == "" {
+		return nil
+	}
+
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		endpoints, err := uc.endpointRepo.ReadAll(ctx)
+		if err != nil {
+			return err
+		}
+		if len(endpoints) > 0 {
+			return nil
+		}
+
+		endpointID, err := uc.endpointRepo.Create(ctx, entity.WebhookEndpoint{
+			URL:       targetURL,
+			Secret:    secret,
+			Events:    events,
+			Enabled:   true,
+			CreatedBy: "config",
+		})
+		if err != nil {
+			return err
+		}
+
+		attached, err := uc.webhookRepo.AttachOrphans(ctx, endpointID)
+		if err != nil {
+			return err
+		}
+
+		uc.logger.Info("webhook endpoint bootstrapped from WEBHOOK_URL",
+			zap.Int("endpoint_id", endpointID),
+			zap.Strings("events", events),
+			zap.Int("attached_webhooks", attached))
+
+		return nil
+	})
+}
+
+func validWebhookURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func (uc *WebhookUseCaseImpl) validateEndpoint(endpoint entity.WebhookEndpoint) error {
+	if !validWebhookURL(endpoint.URL) {
+		return entity.ErrInvalidWebhookURL
+	}
+
+	if len(endpoint.Events) == 0 {
+		return entity.ErrInvalidWebhookEvents
+	}
+	for _, event := range endpoint.Events {
+		if event != "*" && !entity.ValidWebhookEvent(event) {
+			return entity.ErrInvalidWebhookEvents
+		}
+	}
+
+	if err := uc.validateRetryPolicy(endpoint.Retry); err != nil {
+		return err
+	}
+	return uc.validateDelivery(endpoint)
+}
+
+func (uc *WebhookUseCaseImpl) validateRetryPolicy(policy entity.WebhookRetryPolicy) error {
+	if policy.MaxRetries != nil && *policy.MaxRetries < 0 {
+		return fmt.Errorf("%w: max_retries must be >= 0", entity.ErrInvalidRetryPolicy)
+	}
+	if policy.RetryDelaySeconds != nil && *policy.RetryDelaySeconds < 1 {
+		return fmt.Errorf("%w: retry_delay_seconds must be >= 1", entity.ErrInvalidRetryPolicy)
+	}
+
+	switch policy.Backoff {
+	case "", entity.BackoffLinear, entity.BackoffExponential:
+	default:
+		return fmt.Errorf("%w: backoff must be %s or %s", entity.ErrInvalidRetryPolicy, entity.BackoffLinear, entity.BackoffExponential)
+	}
+
+	// аренда вебхука должна переживать запрос доставки, иначе его заберет другой воркер
+	if policy.TimeoutSeconds != nil {
+		lease := uc.settings.Get().WebhookLeaseSeconds
+		if *policy.TimeoutSeconds < 1 || *policy.TimeoutSeconds >= lease {
+			return fmt.Errorf("%w: timeout_seconds must be between 1 and %d (below WEBHOOK_LEASE_SECONDS)",
+				entity.ErrInvalidRetryPolicy, lease-1)
+		}
+	}
+
+	return nil
+}
+
+// maxWebhookBatchSize ограничивает пачку: тело запроса не больше maxWebhookBatchSize payload
+const maxWebhookBatchSize = 1000
+
+// validateDelivery проверяет батчинг: вебхуки пачки ждут отправки под арендой, поэтому ожидание
+// вместе с таймаутом запроса должно укладываться в аренду, иначе вебхук заберет другой воркер
+func (uc *WebhookUseCaseImpl) validateDelivery(endpoint entity.WebhookEndpoint) error {
+	d := endpoint.Delivery
+
+	switch d.Compression {
+	case "", entity.CompressionGzip:
+	default:
+		return fmt.Errorf("%w: compression must be %s or none", entity.ErrInvalidWebhookDelivery, entity.CompressionGzip)
+	}
+
+	if !d.Batched() {
+		if d.BatchSize < 0 || d.BatchWaitMs != 0 {
+			return fmt.Errorf("%w: batch_wait_ms requires batch_size", entity.ErrInvalidWebhookDelivery)
+		}
+		return nil
+	}
+
+	if d.BatchSize < 2 || d.BatchSize > maxWebhookBatchSize {
+		return fmt.Errorf("%w: batch_size must be 0 or between 2 and %d", entity.ErrInvalidWebhookDelivery, maxWebhookBatchSize)
+	}
+
+	cfg := uc.settings.Get()
+	timeout := int(entity.DefaultWebhookTimeout / time.Second)
+	if endpoint.Retry.TimeoutSeconds != nil {
+		timeout = *endpoint.Retry.TimeoutSeconds
+	}
+	maxWaitMs := (cfg.WebhookLeaseSeconds - timeout) * 1000
+	if d.BatchWaitMs < 1 || d.BatchWaitMs >= maxWaitMs {
+		return fmt.Errorf("%w: batch_wait_ms must be between 1 and %d (WEBHOOK_LEASE_SECONDS minus the request timeout)",
+			entity.ErrInvalidWebhookDelivery, maxWaitMs-1)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/config"
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// WebhookSLO — задержка доставки вебхуков получателю за Window и цель для перцентиля Percentile;
+// Target 0 — цель не задана. Breached — перцентиль выше цели, а доставок за окно не меньше MinDeliveries
+type WebhookSLO struct {
+	EndpointID    int
+	URL           string
+	Enabled       bool
+	Window        time.Duration
+	Latency       entity.WebhookLatency
+	Percentile    int
+	Target        time.Duration
+	MinDeliveries int
+	Breached      bool
+}
+
+func (uc *WebhookUseCaseImpl) EndpointSLO(ctx context.Context, endpointID int) (*WebhookSLO, error) {
+	endpoint, err := uc.readEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := uc.settings.Get()
+	latencies, err := uc.webhookRepo.DeliveryLatency(ctx, sloWindow(cfg), endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	latency := entity.WebhookLatency{EndpointID: endpointID}
+	if len(latencies) > 0 {
+		latency = latencies[0]
+	}
+
+	slo := newWebhookSLO(cfg, endpoint, latency)
+	return &slo, nil
+}
+
+// EndpointSLOs считает задержку всех получателей одним запросом; получатели без доставок за окно
+// возвращаются с нулевой задержкой
+func (uc *WebhookUseCaseImpl) EndpointSLOs(ctx context.Context) ([]WebhookSLO, error) {
+	endpoints, err := uc.endpointRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := uc.settings.Get()
+	latencies, err := uc.webhookRepo.DeliveryLatency(ctx, sloWindow(cfg), 0)
+	if err != nil {
+		return nil, err
+	}
+	byEndpoint := make(map[int]entity.WebhookLatency, len(latencies))
+	for _, l := range latencies {
+		byEndpoint[l.EndpointID] = l
+	}
+
+	slos := make([]WebhookSLO, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		latency, ok := byEndpoint[endpoint.ID]
+		if !ok {
+			latency.EndpointID = endpoint.ID
+		}
+		slos = append(slos, newWebhookSLO(cfg, endpoint, latency))
+	}
+
+	return slos, nil
+}
+
+func sloWindow(cfg *config.Config) time.Duration {
+	return time.Duration(cfg.WebhookSLOWindowMinutes) * time.Minute
+}
+
+func newWebhookSLO(cfg *config.Config, endpoint *entity.WebhookEndpoint, latency entity.WebhookLatency) WebhookSLO {
+	slo := WebhookSLO{
+		EndpointID:    endpoint.ID,
+		URL:           endpoint.URL,
+		Enabled:       endpoint.Enabled,
+		Window:        sloWindow(cfg),
+		Latency:       latency,
+		Percentile:    cfg.WebhookSLOPercentile,
+		Target:        time.Duration(cfg.WebhookSLOLatencyMs) * time.Millisecond,
+		MinDeliveries: cfg.WebhookSLOMinDeliveries,
+	}
+	slo.Breached = slo.Target > 0 &&
+		latency.Delivered > 0 && latency.Delivered >= slo.MinDeliveries &&
+		latency.Percentile(slo.Percentile) > slo.Target
+	return slo
+}
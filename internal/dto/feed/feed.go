@@ -0,0 +1,92 @@
+// Package feed — XML публичной ленты зон: Atom 1.0 (RFC 4287) с GeoRSS и сообщения CAP 1.2 (OASIS)
+package feed
+
+import "encoding/xml"
+
+const (
+	AtomNamespace   = "http://www.w3.org/2005/Atom"
+	GeoRSSNamespace = "http://www.georss.org/georss"
+	CAPNamespace    = "urn:oasis:names:tc:emergency:cap:1.2"
+
+	AtomContentType = "application/atom+xml"
+	CAPContentType  = "application/cap+xml"
+)
+
+type AtomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	GeoRSS  string      `xml:"xmlns:georss,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  AtomPerson  `xml:"author"`
+	Links   []AtomLink  `xml:"link"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+type AtomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type AtomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr,omitempty"`
+}
+
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Summary    string         `xml:"summary,omitempty"`
+	Links      []AtomLink     `xml:"link"`
+	Categories []AtomCategory `xml:"category"`
+	// Point — центр зоны "широта долгота", Polygons — внешние контуры полигонов "ш д ш д ..."
+	Point    string   `xml:"georss:point"`
+	Radius   float64  `xml:"georss:radius,omitempty"`
+	Polygons []string `xml:"georss:polygon"`
+}
+
+// CAPAlert — сообщение CAP 1.2; по блоку Info на каждый язык
+type CAPAlert struct {
+	XMLName    xml.Name  `xml:"alert"`
+	XMLNS      string    `xml:"xmlns,attr"`
+	Identifier string    `xml:"identifier"`
+	Sender     string    `xml:"sender"`
+	Sent       string    `xml:"sent"`
+	Status     string    `xml:"status"`
+	MsgType    string    `xml:"msgType"`
+	Scope      string    `xml:"scope"`
+	Info       []CAPInfo `xml:"info"`
+}
+
+type CAPInfo struct {
+	Language string `xml:"language"`
+	Category string `xml:"category"`
+	Event    string `xml:"event"`
+	// ResponseType AllClear — опасность миновала
+	ResponseType string    `xml:"responseType,omitempty"`
+	Urgency      string    `xml:"urgency"`
+	Severity     string    `xml:"severity"`
+	Certainty    string    `xml:"certainty"`
+	Effective    string    `xml:"effective,omitempty"`
+	Expires      string    `xml:"expires,omitempty"`
+	SenderName   string    `xml:"senderName,omitempty"`
+	Headline     string    `xml:"headline"`
+	Description  string    `xml:"description,omitempty"`
+	Web          string    `xml:"web,omitempty"`
+	Area         []CAPArea `xml:"area"`
+}
+
+// CAPArea — круг "широта,долгота радиус_км" или полигоны из пар "широта,долгота", замкнутые первой точкой
+type CAPArea struct {
+	AreaDesc string   `xml:"areaDesc"`
+	Polygons []string `xml:"polygon,omitempty"`
+	Circles  []string `xml:"circle,omitempty"`
+}
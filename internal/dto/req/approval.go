@@ -0,0 +1,5 @@
+package req
+
+type ApprovalRejectRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=1000"`
+}
@@ -0,0 +1,14 @@
+package req
+
+type LoginRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type OperatorCreateRequest struct {
+	Username    string `json:"username" validate:"required,max=127"`
+	Password    string `json:"password,omitempty" validate:"required_without=OIDCSubject,omitempty,min=8,max=72"`
+	OIDCSubject string `json:"oidc_subject,omitempty" validate:"max=255"`
+	// Role — editor (по умолчанию) или publisher
+	Role string `json:"role,omitempty" enums:"editor,publisher" validate:"omitempty,oneof=editor publisher"`
+}
@@ -0,0 +1,26 @@
+package req
+
+// GroupCreateRequest — managers: операторы, которые управляют группой наравне с ее создателем и публикаторами
+type GroupCreateRequest struct {
+	Name     string   `json:"name" validate:"required,max=127" example:"Delivery fleet"`
+	Descr    string   `json:"descr,omitempty" validate:"max=1000"`
+	Managers []string `json:"managers,omitempty" validate:"max=100,dive,required,max=255"`
+	UserIDs  []string `json:"user_ids,omitempty" validate:"max=10000,dive,required,max=127"`
+}
+
+// GroupPatchRequest — частичное обновление: отсутствующие поля не меняются
+type GroupPatchRequest struct {
+	Name     *string   `json:"name,omitempty" validate:"omitnil,min=1,max=127"`
+	Descr    *string   `json:"descr,omitempty" validate:"omitnil,max=1000"`
+	Managers *[]string `json:"managers,omitempty" validate:"omitnil,max=100,dive,required,max=255"`
+}
+
+type GroupMembersRequest struct {
+	UserIDs []string `json:"user_ids" validate:"required,min=1,max=10000,dive,required,max=127"`
+}
+
+// GroupWebhookRequest — пустой secret отключает подпись вебхуков группы
+type GroupWebhookRequest struct {
+	URL    string `json:"url" validate:"required,max=2048"`
+	Secret string `json:"secret,omitempty" validate:"max=255"`
+}
@@ -1,18 +1,163 @@
 package req
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type IncidentCreateRequest struct {
-	Name      string  `json:"name"`
+	Name      string  `json:"name" validate:"required,max=127"`
 	Descr     string  `json:"descr"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Radius    float64 `json:"radius_m"`
+	Latitude  float64 `json:"latitude" validate:"gte=-90,lte=90"`
+	Longitude float64 `json:"longitude" validate:"gte=-180,lte=180"`
+	Radius    float64 `json:"radius_m" validate:"gt=0"`
+	// CRS — система координат центра, по умолчанию EPSG:4326. Радиус всегда в метрах
+	CRS string `json:"crs,omitempty"`
+
+	Schedule            string `json:"schedule,omitempty"`
+	ScheduleDurationMin int    `json:"schedule_duration_minutes,omitempty" validate:"gte=0"`
+
+	// ExpiresAt и TTLMinutes взаимоисключающие: TTL отсчитывается от момента запроса
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	TTLMinutes int        `json:"ttl_minutes,omitempty" validate:"gte=0,excluded_with=ExpiresAt"`
+
+	// Address геокодируется, если latitude и longitude не переданы
+	Address string `json:"address,omitempty" validate:"max=511"`
+
+	// Status по умолчанию published для публикатора без обязательного ревью, иначе draft
+	Status string `json:"status,omitempty" enums:"draft,published" validate:"omitempty,oneof=draft published"`
+	// Severity по умолчанию medium; critical публикуется только с одобрения второго оператора
+	Severity string `json:"severity,omitempty" enums:"low,medium,high,critical" validate:"omitempty,oneof=low medium high critical"`
+	// State по умолчанию active; planned — опасность ожидается, но еще не действует
+	State string `json:"state,omitempty" enums:"planned,active,contained" validate:"omitempty,oneof=planned active contained"`
+	// Visibility по умолчанию public; restricted — зона предупреждает без названия и описания и не попадает
+	// в публичные ленты, internal — еще и скрыта от редакторов, выставляет ее только публикатор
+	Visibility string `json:"visibility,omitempty" enums:"public,restricted,internal" validate:"omitempty,oneof=public restricted internal"`
 }
 
 type IncidentUpdateRequest struct {
-	Name      string  `json:"name"`
+	Name      string  `json:"name" validate:"required,max=127"`
 	Descr     string  `json:"descr"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Radius    float64 `json:"radius_m"`
-	IsActive  bool    `json:"is_active"`
+	Latitude  float64 `json:"latitude" validate:"gte=-90,lte=90"`
+	Longitude float64 `json:"longitude" validate:"gte=-180,lte=180"`
+	Radius    float64 `json:"radius_m" validate:"gt=0"`
+	// IsActive меняет стадию: true для запланированной или завершенной зоны — active,
+	// false для действующей — resolved (см. POST /incidents/{id}/state)
+	IsActive bool `json:"is_active"`
+
+	Schedule            string `json:"schedule,omitempty"`
+	ScheduleDurationMin int    `json:"schedule_duration_minutes,omitempty" validate:"gte=0"`
+
+	// ExpiresAt и TTLMinutes взаимоисключающие: TTL отсчитывается от момента запроса
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	TTLMinutes int        `json:"ttl_minutes,omitempty" validate:"gte=0,excluded_with=ExpiresAt"`
+
+	// Address геокодируется, если latitude и longitude не переданы
+	Address string `json:"address,omitempty" validate:"max=511"`
+
+	// Severity и Visibility не меняются, если не переданы
+	Severity   string `json:"severity,omitempty" enums:"low,medium,high,critical" validate:"omitempty,oneof=low medium high critical"`
+	Visibility string `json:"visibility,omitempty" enums:"public,restricted,internal" validate:"omitempty,oneof=public restricted internal"`
+}
+
+// IncidentPatchRequest — частичное обновление: отсутствующие поля не меняются
+type IncidentPatchRequest struct {
+	Name      *string  `json:"name,omitempty" validate:"omitnil,min=1,max=127"`
+	Descr     *string  `json:"descr,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty" validate:"omitnil,gte=-90,lte=90"`
+	Longitude *float64 `json:"longitude,omitempty" validate:"omitnil,gte=-180,lte=180"`
+	Radius    *float64 `json:"radius_m,omitempty" validate:"omitnil,gt=0"`
+	// IsActive меняет стадию так же, как в IncidentUpdateRequest
+	IsActive *bool `json:"is_active,omitempty"`
+
+	Schedule            *string `json:"schedule,omitempty"`
+	ScheduleDurationMin *int    `json:"schedule_duration_minutes,omitempty" validate:"omitnil,gte=0"`
+
+	// ExpiresAt со значением null снимает срок действия
+	ExpiresAt  NullableTime `json:"expires_at" swaggertype:"string" format:"date-time" extensions:"x-nullable"`
+	TTLMinutes int          `json:"ttl_minutes,omitempty" validate:"gte=0,excluded_with=ExpiresAt"`
+
+	Address    *string `json:"address,omitempty" validate:"omitnil,max=511"`
+	Severity   *string `json:"severity,omitempty" enums:"low,medium,high,critical" validate:"omitnil,oneof=low medium high critical"`
+	Visibility *string `json:"visibility,omitempty" enums:"public,restricted,internal" validate:"omitnil,oneof=public restricted internal"`
+}
+
+// NullableTime отличает отсутствующее поле от явного null: Set — поле есть в запросе,
+// Value == nil при Set — передан null
+type NullableTime struct {
+	Set   bool
+	Value *time.Time
+}
+
+func (t *NullableTime) UnmarshalJSON(data []byte) error {
+	t.Set = true
+	if string(data) == "null" {
+		t.Value = nil
+		return nil
+	}
+
+	var v time.Time
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	t.Value = &v
+	return nil
+}
+
+// Null — передан ли явный null
+func (t NullableTime) Null() bool {
+	return t.Set && t.Value == nil
+}
+
+type IncidentBatchRequest struct {
+	IDs    []int  `json:"ids" validate:"required,min=1,max=500,dive,gt=0"`
+	Action string `json:"action" enums:"activate,deactivate,delete" validate:"required,oneof=activate deactivate delete"`
+}
+
+// IncidentStateRequest — переход зоны в другую стадию жизни опасности
+type IncidentStateRequest struct {
+	State string `json:"state" enums:"planned,active,contained,resolved,archived" validate:"required,oneof=planned active contained resolved archived"`
+}
+
+// IncidentMergeRequest — объединение опубликованных зон в одну
+type IncidentMergeRequest struct {
+	IDs   []int  `json:"ids" validate:"required,min=2,max=50,dive,gt=0"`
+	Name  string `json:"name" validate:"required,max=127"`
+	Descr string `json:"descr"`
+}
+
+// IncidentBacktestRequest — прогон недавних проверок координат через зону. Без hours берутся последние 24 часа,
+// radius_m подменяет радиус круглой зоны
+type IncidentBacktestRequest struct {
+	Hours  int      `json:"hours,omitempty" example:"24" validate:"omitempty,min=1,max=720"`
+	Radius *float64 `json:"radius_m,omitempty" validate:"omitnil,gt=0"`
+}
+
+// IncidentSplitRequest — разделение опубликованной зоны на части
+type IncidentSplitRequest struct {
+	Parts []IncidentSplitPart `json:"parts" validate:"required,min=2,max=20,dive"`
+}
+
+type IncidentSplitPart struct {
+	Name string `json:"name" validate:"required,max=127"`
+	// Geometry — GeoJSON в том же формате, что и для PUT /incidents/{id}/geometry
+	Geometry json.RawMessage `json:"geometry" validate:"required" swaggertype:"object"`
+}
+
+// IncidentTranslationRequest — название и описание зоны на языке из пути запроса
+type IncidentTranslationRequest struct {
+	Name  string `json:"name" validate:"required,max=127"`
+	Descr string `json:"descr"`
+}
+
+// IncidentCommentRequest — заметка оператора о ходе ситуации в зоне
+type IncidentCommentRequest struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
+// IncidentRelationRequest — связь зоны с другой: parent — зона входит в related_id,
+// supersedes — зона заменяет related_id
+type IncidentRelationRequest struct {
+	RelatedID int    `json:"related_id" validate:"required,gt=0"`
+	Type      string `json:"type" validate:"required,oneof=parent supersedes" enums:"parent,supersedes"`
 }
@@ -1,7 +1,21 @@
 package req
 
 type LocationCheckRequest struct {
-	UserID    string  `json:"user_id"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
+	UserID    string  `json:"user_id" validate:"required,max=127"`
+	Latitude  float64 `json:"latitude" validate:"gte=-90,lte=90"`
+	Longitude float64 `json:"longitude" validate:"gte=-180,lte=180"`
+	// CRS — система координат точки, по умолчанию EPSG:4326.
+	// Для проецированных систем latitude — northing (y), longitude — easting (x)
+	CRS string `json:"crs,omitempty"`
+	// AccuracyM — радиус погрешности координат в метрах
+	AccuracyM float64 `json:"accuracy_m,omitempty" validate:"gte=0"`
+	// Altitude — высота над уровнем моря в метрах
+	Altitude *float64 `json:"altitude,omitempty"`
+	// SpeedMps и HeadingDeg (градусы от севера по часовой стрелке) включают прогноз попадания в зону
+	SpeedMps   *float64 `json:"speed_mps,omitempty" validate:"omitnil,gte=0"`
+	HeadingDeg *float64 `json:"heading_deg,omitempty" validate:"omitnil,gte=0,lt=360"`
+}
+
+type LocationCheckBatchRequest struct {
+	Checks []LocationCheckRequest `json:"checks" validate:"required,min=1,max=1000,dive"`
 }
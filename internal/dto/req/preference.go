@@ -0,0 +1,20 @@
+package req
+
+// QuietHoursRequest — окно тишины по местному времени пользователя; start позже end — окно через полночь.
+// days — дни начала окна, без них окно действует каждый день
+type QuietHoursRequest struct {
+	Start string   `json:"start" validate:"required" example:"22:00"`
+	End   string   `json:"end" validate:"required" example:"07:00"`
+	Days  []string `json:"days,omitempty" validate:"max=7,dive,oneof=mon tue wed thu fri sat sun" example:"mon,tue,wed,thu,fri"`
+}
+
+// UserPreferencesRequest заменяет настройки целиком: отсутствующие поля получают значения по умолчанию
+// (min_severity low, без окон тишины, timezone UTC, channels ["stream"], max_alerts_per_hour 0 — без ограничения)
+type UserPreferencesRequest struct {
+	MinSeverity string              `json:"min_severity,omitempty" validate:"omitempty,oneof=low medium high critical"`
+	QuietHours  []QuietHoursRequest `json:"quiet_hours,omitempty" validate:"max=10,dive"`
+	Timezone    string              `json:"timezone,omitempty" validate:"max=64" example:"Europe/Moscow"`
+	Channels    []string            `json:"channels,omitempty" validate:"omitempty,min=1,dive,oneof=stream webhook"`
+
+	MaxAlertsPerHour int `json:"max_alerts_per_hour,omitempty" validate:"gte=0,lte=3600"`
+}
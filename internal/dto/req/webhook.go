@@ -0,0 +1,62 @@
+package req
+
+import "time"
+
+type WebhookTestRequest struct {
+	URL      string `json:"url" validate:"required"`
+	Attempts int    `json:"attempts" validate:"gte=0"`
+}
+
+// WebhookReplayRequest — пустой ids повторяет все вебхуки в состоянии failed
+type WebhookReplayRequest struct {
+	IDs []int `json:"ids,omitempty" validate:"max=1000,dive,gt=0"`
+}
+
+// WebhookEndpointCreateRequest — events: типы событий или "*" для всех; enabled по умолчанию true.
+// Не заданные поля политики попыток берутся из настроек сервиса
+type WebhookEndpointCreateRequest struct {
+	URL     string   `json:"url" validate:"required,max=2048"`
+	Secret  string   `json:"secret,omitempty" validate:"max=255"`
+	Events  []string `json:"events" validate:"required,min=1,dive,required"`
+	Enabled *bool    `json:"enabled,omitempty"`
+
+	MaxRetries        *int   `json:"max_retries,omitempty" validate:"omitnil,gte=0,lte=100"`
+	RetryDelaySeconds *int   `json:"retry_delay_seconds,omitempty" validate:"omitnil,gte=1,lte=86400"`
+	Backoff           string `json:"backoff,omitempty" validate:"omitempty,oneof=linear exponential"`
+	TimeoutSeconds    *int   `json:"timeout_seconds,omitempty" validate:"omitnil,gte=1"`
+
+	// BatchSize 0 — каждое событие отдельным запросом; иначе события уходят NDJSON-пачками
+	// до batch_size штук, ожидая не дольше batch_wait_ms
+	BatchSize   int    `json:"batch_size,omitempty" validate:"gte=0,lte=1000"`
+	BatchWaitMs int    `json:"batch_wait_ms,omitempty" validate:"gte=0"`
+	Compression string `json:"compression,omitempty" validate:"omitempty,oneof=gzip none"`
+}
+
+// WebhookEndpointPatchRequest — частичное обновление: отсутствующие поля не меняются, пустой secret отключает подпись
+type WebhookEndpointPatchRequest struct {
+	URL     *string   `json:"url,omitempty" validate:"omitnil,min=1,max=2048"`
+	Secret  *string   `json:"secret,omitempty" validate:"omitnil,max=255"`
+	Events  *[]string `json:"events,omitempty" validate:"omitnil,min=1,dive,required"`
+	Enabled *bool     `json:"enabled,omitempty"`
+
+	MaxRetries        *int    `json:"max_retries,omitempty" validate:"omitnil,gte=0,lte=100"`
+	RetryDelaySeconds *int    `json:"retry_delay_seconds,omitempty" validate:"omitnil,gte=1,lte=86400"`
+	Backoff           *string `json:"backoff,omitempty" validate:"omitnil,oneof=linear exponential"`
+	TimeoutSeconds    *int    `json:"timeout_seconds,omitempty" validate:"omitnil,gte=1"`
+
+	// batch_size 0 выключает батчинг, compression none — сжатие
+	BatchSize   *int    `json:"batch_size,omitempty" validate:"omitnil,gte=0,lte=1000"`
+	BatchWaitMs *int    `json:"batch_wait_ms,omitempty" validate:"omitnil,gte=0"`
+	Compression *string `json:"compression,omitempty" validate:"omitnil,oneof=gzip none"`
+}
+
+// WebhookRedriveRequest — фильтры повторной доставки; отсутствующие поля не фильтруют.
+// created_from включительно, created_to — нет
+type WebhookRedriveRequest struct {
+	State         string     `json:"state,omitempty" validate:"omitempty,oneof=failed"`
+	CreatedFrom   *time.Time `json:"created_from,omitempty"`
+	CreatedTo     *time.Time `json:"created_to,omitempty"`
+	IncidentID    int        `json:"incident_id,omitempty" validate:"gte=0"`
+	EndpointID    int        `json:"endpoint_id,omitempty" validate:"gte=0"`
+	SpreadSeconds int        `json:"spread_seconds,omitempty" validate:"gte=0,lte=86400"`
+}
@@ -0,0 +1,84 @@
+package resp
+
+import "time"
+
+type RuntimeConfigResponse struct {
+	LogLevel          string `json:"log_level"`
+	CacheTTLMinutes   int    `json:"cache_ttl_minutes"`
+	MaxRetries        int    `json:"webhook_max_retries"`
+	RetryDelaySeconds int    `json:"webhook_retry_delay_seconds"`
+
+	PredictionHorizonSeconds int `json:"prediction_horizon_seconds"`
+}
+
+type DashboardResponse struct {
+	ActiveIncidents []IncidentResponse      `json:"active_incidents"`
+	WebhookQueue    WebhookQueueResponse    `json:"webhook_queue"`
+	RecentChecks    []CheckResponse         `json:"recent_checks"`
+	FailedWebhooks  []FailedWebhookResponse `json:"failed_webhooks"`
+	GeneratedAt     time.Time               `json:"generated_at"`
+}
+
+// WebhookQueueResponse — недоставленные вебхуки: ждут отправки, отправляются и исчерпали попытки
+type WebhookQueueResponse struct {
+	Pending    int `json:"pending"`
+	Processing int `json:"processing"`
+	Failed     int `json:"failed"`
+}
+
+type CheckResponse struct {
+	CheckID   int       `json:"check_id"`
+	UserID    string    `json:"user_id"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	HasAlert  bool      `json:"has_alert"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FailedWebhookResponse struct {
+	WebhookID  int       `json:"webhook_id"`
+	EndpointID int       `json:"endpoint_id"`
+	Event      string    `json:"event"`
+	CheckID    int       `json:"check_id"`
+	RetryCnt   int       `json:"retry_cnt"`
+	CreatedAt  time.Time `json:"created_at"`
+	FailedAt   time.Time `json:"failed_at"`
+}
+
+type QueryStatsResponse struct {
+	// BucketsMs — верхние границы корзин гистограммы; последняя корзина в buckets — дольше всех границ
+	BucketsMs []float64           `json:"buckets_ms"`
+	Queries   []QueryStatResponse `json:"queries"`
+}
+
+// QueryStatResponse — статистика запроса к БД с нормализованным SQL (литералы заменены на ?)
+type QueryStatResponse struct {
+	SQL     string  `json:"sql"`
+	Calls   int64   `json:"calls"`
+	Errors  int64   `json:"errors"`
+	TotalMs float64 `json:"total_ms"`
+	MeanMs  float64 `json:"mean_ms"`
+	MaxMs   float64 `json:"max_ms"`
+	Buckets []int64 `json:"buckets"`
+}
+
+// WorkerLocksResponse — блокировки периодических задач глазами одной реплики (Instance — ее hostname)
+type WorkerLocksResponse struct {
+	Instance string               `json:"instance"`
+	Enabled  bool                 `json:"enabled"`
+	Locks    []WorkerLockResponse `json:"locks"`
+}
+
+// WorkerLockResponse — блокировка задачи: held — реплика сейчас владеет ею; runs и skipped — запуски задачи,
+// выполненные и пропущенные, пока блокировкой владела другая реплика
+type WorkerLockResponse struct {
+	Name      string     `json:"name"`
+	Held      bool       `json:"held"`
+	HeldSince *time.Time `json:"held_since,omitempty"`
+	Acquired  int64      `json:"acquired"`
+	Lost      int64      `json:"lost"`
+	Runs      int64      `json:"runs"`
+	Skipped   int64      `json:"skipped"`
+	Errors    int64      `json:"errors"`
+	LastError string     `json:"last_error,omitempty"`
+}
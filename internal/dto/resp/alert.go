@@ -0,0 +1,11 @@
+package resp
+
+import "time"
+
+type AlertEvent struct {
+	Type      string             `json:"type"`
+	UserID    string             `json:"user_id"`
+	CheckID   int                `json:"check_id,omitempty"`
+	Incidents []IncidentResponse `json:"incidents"`
+	CreatedAt time.Time          `json:"created_at"`
+}
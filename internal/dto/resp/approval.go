@@ -0,0 +1,19 @@
+package resp
+
+import "time"
+
+type ApprovalResponse struct {
+	ApprovalID  int        `json:"approval_id"`
+	IncidentID  int        `json:"incident_id"`
+	Status      string     `json:"status" enums:"pending,approved,rejected"`
+	Severity    string     `json:"severity,omitempty" enums:"critical"`
+	RequestedBy string     `json:"requested_by"`
+	RequestedAt time.Time  `json:"requested_at"`
+	DecidedBy   string     `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+}
+
+type ApprovalsListResponse struct {
+	Approvals []ApprovalResponse `json:"approvals"`
+}
@@ -0,0 +1,17 @@
+package resp
+
+import "time"
+
+type AttachmentResponse struct {
+	AttachmentID int       `json:"attachment_id"`
+	IncidentID   int       `json:"incident_id"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	SizeBytes    int64     `json:"size_bytes"`
+	URL          string    `json:"url"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type AttachmentsListResponse struct {
+	Attachments []AttachmentResponse `json:"attachments"`
+}
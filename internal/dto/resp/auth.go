@@ -0,0 +1,13 @@
+package resp
+
+import "time"
+
+type LoginResponse struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type OperatorCreateResponse struct {
+	ID int `json:"id"`
+}
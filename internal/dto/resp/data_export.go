@@ -0,0 +1,19 @@
+package resp
+
+import "time"
+
+type DataExportResponse struct {
+	ExportID    int        `json:"export_id"`
+	UserID      string     `json:"user_id"`
+	Status      string     `json:"status" enums:"pending,ready"`
+	RequestedBy string     `json:"requested_by"`
+	RequestedAt time.Time  `json:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// ExpiresAt — когда архив будет удален из хранилища
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	SizeBytes int64      `json:"size_bytes,omitempty"`
+	// DownloadURL — временная подписанная ссылка на JSON-архив, есть только у готовой выгрузки
+	DownloadURL string `json:"download_url,omitempty"`
+	Attempts    int    `json:"attempts,omitempty"`
+	LastError   string `json:"last_error,omitempty"`
+}
@@ -0,0 +1,31 @@
+package resp
+
+import "time"
+
+type ErasureResponse struct {
+	ErasureID   int        `json:"erasure_id"`
+	UserID      string     `json:"user_id"`
+	Mode        string     `json:"mode" enums:"delete,anonymize"`
+	Status      string     `json:"status" enums:"pending,confirmed,completed,cancelled"`
+	RequestedBy string     `json:"requested_by"`
+	RequestedAt time.Time  `json:"requested_at"`
+	ConfirmedBy string     `json:"confirmed_by,omitempty"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Attempts    int        `json:"attempts,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	// Erased заполняется после выполнения заявки
+	Erased *ErasureResultResponse `json:"erased,omitempty"`
+}
+
+type ErasureResultResponse struct {
+	Checks           int64 `json:"checks"`
+	Webhooks         int64 `json:"webhooks"`
+	Zones            int64 `json:"zones"`
+	Preferences      int64 `json:"preferences"`
+	GroupMemberships int64 `json:"group_memberships"`
+}
+
+type ErasuresListResponse struct {
+	Erasures []ErasureResponse `json:"erasures"`
+}
@@ -0,0 +1,56 @@
+package resp
+
+import "time"
+
+// GroupWebhookResponse — секрет не возвращается, has_secret показывает, подписываются ли вебхуки
+type GroupWebhookResponse struct {
+	EndpointID int    `json:"endpoint_id"`
+	URL        string `json:"url"`
+	HasSecret  bool   `json:"has_secret"`
+	Enabled    bool   `json:"enabled"`
+}
+
+type GroupResponse struct {
+	GroupID     int      `json:"group_id"`
+	Name        string   `json:"name"`
+	Descr       string   `json:"descr,omitempty"`
+	Managers    []string `json:"managers"`
+	MemberCount int      `json:"member_count"`
+	// Webhook отсутствует, пока получатель группы не зарегистрирован
+	Webhook   *GroupWebhookResponse `json:"webhook,omitempty"`
+	CreatedBy string                `json:"created_by,omitempty"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+type GroupsListResponse struct {
+	Groups []GroupResponse `json:"groups"`
+}
+
+type GroupMembersResponse struct {
+	GroupID int      `json:"group_id"`
+	UserIDs []string `json:"user_ids"`
+}
+
+type GroupMembersAddedResponse struct {
+	Added int `json:"added"`
+}
+
+type GroupMemberZoneResponse struct {
+	IncidentID int       `json:"incident_id"`
+	Name       string    `json:"name"`
+	Severity   string    `json:"severity" enums:"low,medium,high,critical"`
+	EnteredAt  time.Time `json:"entered_at"`
+}
+
+type GroupMemberInDangerResponse struct {
+	UserID string                    `json:"user_id"`
+	Zones  []GroupMemberZoneResponse `json:"zones"`
+}
+
+type GroupStatusResponse struct {
+	GroupID         int                           `json:"group_id"`
+	Name            string                        `json:"name"`
+	MembersTotal    int                           `json:"members_total"`
+	MembersInDanger []GroupMemberInDangerResponse `json:"members_in_danger"`
+}
@@ -2,9 +2,21 @@ package resp
 
 import "time"
 
-type HealthResponse struct {
-	Status          string    `json:"status"`
-	Timestamp       time.Time `json:"timestamp"`
-	ActiveIncidents int       `json:"active_incidents"`
-	PendingWebhooks int       `json:"pending_webhooks"`
+type LivenessResponse struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type ReadinessResponse struct {
+	Status string `json:"status"`
+	// Degraded — необязательная зависимость (Redis) недоступна, но запросы обслуживаются
+	Degraded  bool                        `json:"degraded"`
+	Timestamp time.Time                   `json:"timestamp"`
+	Checks    map[string]DependencyStatus `json:"checks"`
+}
+
+type DependencyStatus struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
 }
@@ -1,11 +1,26 @@
 package resp
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type IncidentCreateResponse struct {
 	IncidentID int `json:"incident_id"`
 }
 
+// IncidentOverlapResponse — ответ 409 на создание зоны, перекрывающей активные
+type IncidentOverlapResponse struct {
+	Error       string `json:"error"`
+	Message     string `json:"message"`
+	IncidentIDs []int  `json:"conflicting_incident_ids"`
+}
+
+type IncidentBatchResponse struct {
+	Action   string `json:"action"`
+	Affected int    `json:"affected"`
+}
+
 type IncidentResponse struct {
 	IncidentID int       `json:"incident_id"`
 	Name       string    `json:"name"`
@@ -14,8 +29,47 @@ type IncidentResponse struct {
 	Longitude  float64   `json:"longitude"`
 	Radius     float64   `json:"radius_m"`
 	IsActive   bool      `json:"is_active"`
+	State      string    `json:"state" enums:"planned,active,contained,resolved,archived"`
+	Status     string    `json:"status" enums:"draft,published,archived"`
+	Severity   string    `json:"severity" enums:"low,medium,high,critical"`
+	Source     string    `json:"source" enums:"manual,feed,weather"`
+	Visibility string    `json:"visibility" enums:"public,restricted,internal"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+
+	Schedule            string     `json:"schedule,omitempty"`
+	ScheduleDurationMin int        `json:"schedule_duration_minutes,omitempty"`
+	NextActivation      *time.Time `json:"next_activation,omitempty"`
+	ExpiresAt           *time.Time `json:"expires_at,omitempty"`
+	Address             string     `json:"address,omitempty"`
+	CreatedBy           string     `json:"created_by,omitempty"`
+	UpdatedBy           string     `json:"updated_by,omitempty"`
+	PublishedBy         string     `json:"published_by,omitempty"`
+	PublishedAt         *time.Time `json:"published_at,omitempty"`
+
+	// StateTimes — когда зона последний раз входила в каждую из пройденных стадий
+	StateChangedAt time.Time            `json:"state_changed_at"`
+	StateTimes     map[string]time.Time `json:"state_times"`
+
+	// Geometry — GeoJSON MultiPolygon полигональной зоны, для круглых зон не возвращается
+	Geometry json.RawMessage `json:"geometry,omitempty" swaggertype:"object"`
+
+	// Relations — связи с другими зонами; возвращаются при чтении зоны и списка
+	Relations []IncidentRelationResponse `json:"relations,omitempty"`
+}
+
+// IncidentRelationResponse — связь со стороны зоны: parent — она входит в related_id, child — related_id входит в нее,
+// supersedes — она заменяет related_id, superseded_by — related_id заменяет ее
+type IncidentRelationResponse struct {
+	RelatedID int       `json:"related_id"`
+	Type      string    `json:"type" enums:"parent,child,supersedes,superseded_by"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type IncidentRelationsResponse struct {
+	IncidentID int                        `json:"incident_id"`
+	Relations  []IncidentRelationResponse `json:"relations"`
 }
 
 type IncidentsListResponse struct {
@@ -23,4 +77,103 @@ type IncidentsListResponse struct {
 	Page       int                `json:"page"`
 	Limit      int                `json:"limit"`
 	TotalPages int                `json:"total_pages"`
+	// TotalEstimated — total_pages посчитан по оценке числа инцидентов (запрос с count=estimated)
+	TotalEstimated bool `json:"total_estimated,omitempty"`
+	// NextCursor — курсор следующей страницы при запросе с cursor, на последней странице пуст.
+	// В этом режиме page и total_pages равны 0
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type IncidentSplitResponse struct {
+	Incidents []IncidentResponse `json:"incidents"`
+}
+
+// IncidentLinkResponse — запись истории: зона child_id получена из parent_id слиянием или разделением
+type IncidentLinkResponse struct {
+	ParentID  int       `json:"parent_id"`
+	ChildID   int       `json:"child_id"`
+	Operation string    `json:"operation" enums:"merge,split"`
+	Actor     string    `json:"actor,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type IncidentLineageResponse struct {
+	IncidentID int                    `json:"incident_id"`
+	Links      []IncidentLinkResponse `json:"links"`
+}
+
+// IncidentBacktestResponse — сколько пользователей оповестила бы зона за период from–to
+type IncidentBacktestResponse struct {
+	IncidentID        int       `json:"incident_id"`
+	Radius            float64   `json:"radius_m"`
+	From              time.Time `json:"from"`
+	To                time.Time `json:"to"`
+	ChecksScanned     int       `json:"checks_scanned"`
+	ChecksMatched     int       `json:"checks_matched"`
+	UsersAlerted      int       `json:"users_alerted"`
+	UsersNewlyAlerted int       `json:"users_newly_alerted"`
+	Truncated         bool      `json:"truncated"`
+}
+
+type IncidentTranslationResponse struct {
+	Locale    string    `json:"locale" example:"en"`
+	Name      string    `json:"name"`
+	Descr     string    `json:"descr"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IncidentVersionResponse — форма зоны, действовавшая с valid_from до следующей версии
+type IncidentVersionResponse struct {
+	Version   int     `json:"version"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Radius    float64 `json:"radius_m"`
+	// Geometry — GeoJSON MultiPolygon полигональной зоны, для круглых зон не возвращается
+	Geometry  json.RawMessage `json:"geometry,omitempty" swaggertype:"object"`
+	ChangedBy string          `json:"changed_by,omitempty"`
+	ValidFrom time.Time       `json:"valid_from"`
+}
+
+type IncidentVersionsResponse struct {
+	IncidentID int                       `json:"incident_id"`
+	Versions   []IncidentVersionResponse `json:"versions"`
+}
+
+type IncidentTranslationsResponse struct {
+	IncidentID   int                           `json:"incident_id"`
+	Translations []IncidentTranslationResponse `json:"translations"`
+}
+
+type IncidentCommentResponse struct {
+	ID        int       `json:"id"`
+	Body      string    `json:"body"`
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type IncidentCommentsResponse struct {
+	IncidentID int                       `json:"incident_id"`
+	Comments   []IncidentCommentResponse `json:"comments"`
+}
+
+// PublicIncidentResponse — зона в публичном API: без описания, адреса, авторов и расписания
+type PublicIncidentResponse struct {
+	IncidentID  int        `json:"incident_id"`
+	Name        string     `json:"name"`
+	Latitude    float64    `json:"latitude"`
+	Longitude   float64    `json:"longitude"`
+	Radius      float64    `json:"radius_m"`
+	State       string     `json:"state" enums:"planned,active,contained,resolved,archived"`
+	Severity    string     `json:"severity" enums:"low,medium,high,critical"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+
+	// Geometry — GeoJSON MultiPolygon полигональной зоны, для круглых зон не возвращается
+	Geometry json.RawMessage `json:"geometry,omitempty" swaggertype:"object"`
+}
+
+type PublicIncidentsListResponse struct {
+	Incidents []PublicIncidentResponse `json:"incidents"`
 }
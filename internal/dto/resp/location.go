@@ -3,4 +3,5 @@ package resp
 type LocationCheckResponse struct {
 	HasAlert  bool               `json:"has_alert"`
 	Incidents []IncidentResponse `json:"incidents,omitempty"`
+	Place     string             `json:"place,omitempty"`
 }
@@ -0,0 +1,21 @@
+package resp
+
+import "time"
+
+type QuietHoursResponse struct {
+	Start string   `json:"start" example:"22:00"`
+	End   string   `json:"end" example:"07:00"`
+	Days  []string `json:"days,omitempty" enums:"mon,tue,wed,thu,fri,sat,sun"`
+}
+
+type UserPreferencesResponse struct {
+	UserID      string               `json:"user_id"`
+	MinSeverity string               `json:"min_severity" enums:"low,medium,high,critical"`
+	QuietHours  []QuietHoursResponse `json:"quiet_hours"`
+	Timezone    string               `json:"timezone" example:"Europe/Moscow"`
+	Channels    []string             `json:"channels" enums:"stream,webhook"`
+	// MaxAlertsPerHour 0 — без ограничения
+	MaxAlertsPerHour int `json:"max_alerts_per_hour"`
+	// UpdatedAt отсутствует, пока пользователь не сохранял настройки
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
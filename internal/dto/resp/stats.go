@@ -7,4 +7,44 @@ type StatsResponse struct {
 	TotalChecks   int       `json:"total_checks"`
 	WindowMinutes int       `json:"window_minutes"`
 	PeriodStart   time.Time `json:"period_start"`
+	// Estimated — user_count и total_checks приблизительные (запрос с count=estimated)
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// HeatmapResponse — проверки координат по H3-ячейкам за период, начиная с самых частых
+type HeatmapResponse struct {
+	Resolution int                   `json:"resolution"`
+	From       time.Time             `json:"from"`
+	To         time.Time             `json:"to"`
+	Cells      []HeatmapCellResponse `json:"cells"`
+}
+
+type HeatmapCellResponse struct {
+	// Cell — индекс H3-ячейки в шестнадцатеричной записи
+	Cell string `json:"cell"`
+	// Latitude и Longitude — центр ячейки, для карт без библиотеки H3
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Checks    int     `json:"checks"`
+	// Alerts — проверки, попавшие в зону
+	Alerts int `json:"alerts"`
+}
+
+// IncidentStatsResponse — сведенные счетчики проверок за window_minutes минут до period_end
+type IncidentStatsResponse struct {
+	WindowMinutes int       `json:"window_minutes"`
+	PeriodStart   time.Time `json:"period_start"`
+	// PeriodEnd — до какой минуты проверки сведены; отстает от текущего времени на задержку сводки
+	PeriodEnd   time.Time `json:"period_end"`
+	UserCount   int       `json:"user_count"`
+	TotalChecks int       `json:"total_checks"`
+	AlertChecks int       `json:"alert_checks"`
+	// Incidents — зоны, в которые попадали проверки, начиная с самых частых; удаленные зоны не возвращаются
+	Incidents []IncidentCheckStatsResponse `json:"incidents"`
+}
+
+type IncidentCheckStatsResponse struct {
+	IncidentID int    `json:"incident_id"`
+	Name       string `json:"name"`
+	Checks     int    `json:"checks"`
 }
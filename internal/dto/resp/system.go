@@ -0,0 +1,83 @@
+package resp
+
+import "time"
+
+// QueuesResponse — состояние доставки вебхуков для алертинга
+type QueuesResponse struct {
+	Queue       WebhookQueueDepthResponse `json:"queue"`
+	Outbox      WebhookOutboxResponse     `json:"outbox"`
+	GeneratedAt time.Time                 `json:"generated_at"`
+}
+
+// WebhookQueueDepthResponse — очередь задач доставки (QUEUE_BACKEND). scheduled нет, если брокер не сообщает
+// число отложенных задач (amqp); error — очередь недоступна, ready и scheduled тогда не заполняются
+type WebhookQueueDepthResponse struct {
+	Backend   string `json:"backend"`
+	Ready     int64  `json:"ready"`
+	Scheduled *int64 `json:"scheduled,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// WebhookOutboxResponse — недоставленные вебхуки в БД. due — готовые к отправке, но не захваченные воркером;
+// poller_lag_seconds — сколько ждет самый старый из них; oldest_pending_age_seconds — возраст самого старого
+// недоставленного вебхука (in progress или processing)
+type WebhookOutboxResponse struct {
+	Pending                 int     `json:"pending"`
+	Processing              int     `json:"processing"`
+	Failed                  int     `json:"failed"`
+	Due                     int     `json:"due"`
+	OldestPendingAgeSeconds float64 `json:"oldest_pending_age_seconds"`
+	PollerLagSeconds        float64 `json:"poller_lag_seconds"`
+}
+
+// UsageResponse — запросы API-ключей за сутки с from по to (UTC); enabled false — учет выключен (USAGE_METERING_ENABLED)
+type UsageResponse struct {
+	Enabled bool                  `json:"enabled"`
+	From    string                `json:"from,omitempty"`
+	To      string                `json:"to,omitempty"`
+	Keys    []APIKeyUsageResponse `json:"keys"`
+}
+
+// APIKeyUsageResponse — использование ключа: квоты (0 — без ограничения), счетчики квот за текущие сутки и месяц
+// и использование по суткам, перенесенное в БД; total — сумма запросов за период
+type APIKeyUsageResponse struct {
+	Key          string             `json:"key"`
+	DailyQuota   int64              `json:"daily_quota"`
+	MonthlyQuota int64              `json:"monthly_quota"`
+	Current      APIKeyUsageCurrent `json:"current"`
+	Total        int64              `json:"total"`
+	Days         []UsageDayResponse `json:"days"`
+}
+
+// APIKeyUsageCurrent — счетчики, по которым проверяются квоты; error — счетчики недоступны (Redis в деградированном режиме)
+type APIKeyUsageCurrent struct {
+	Today     int64  `json:"today"`
+	ThisMonth int64  `json:"this_month"`
+	Error     string `json:"error,omitempty"`
+}
+
+// UsageDayResponse — использование ключа за сутки: запросы, записанные проверки координат, проверки с алертом
+// и вебхуки, поставленные в очередь доставки
+type UsageDayResponse struct {
+	Day      string `json:"day"`
+	Requests int64  `json:"requests"`
+	Checks   int64  `json:"checks"`
+	Alerts   int64  `json:"alerts"`
+	Webhooks int64  `json:"webhooks"`
+}
+
+// UsageExportResponse — выгрузка использования для биллинга: строка на ключ и сутки с использованием
+type UsageExportResponse struct {
+	From string           `json:"from"`
+	To   string           `json:"to"`
+	Rows []UsageExportRow `json:"rows"`
+}
+
+type UsageExportRow struct {
+	APIKey   string `json:"api_key"`
+	Day      string `json:"day"`
+	Requests int64  `json:"requests"`
+	Checks   int64  `json:"checks"`
+	Alerts   int64  `json:"alerts"`
+	Webhooks int64  `json:"webhooks"`
+}
@@ -0,0 +1,71 @@
+package resp
+
+import "time"
+
+type WebhookTestResponse struct {
+	Delivered  bool    `json:"delivered"`
+	StatusCode int     `json:"status_code,omitempty"`
+	LatencyMs  float64 `json:"latency_ms"`
+	Attempts   int     `json:"attempts"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type WebhookReplayResponse struct {
+	Requeued int `json:"requeued"`
+}
+
+type WebhookRedriveResponse struct {
+	Redriven int `json:"redriven"`
+}
+
+// WebhookEndpointResponse — секрет получателя не возвращается, has_secret показывает, подписываются ли вебхуки
+type WebhookEndpointResponse struct {
+	EndpointID int      `json:"endpoint_id"`
+	URL        string   `json:"url"`
+	HasSecret  bool     `json:"has_secret"`
+	Events     []string `json:"events"`
+	Enabled    bool     `json:"enabled"`
+
+	// отсутствующие поля политики попыток берутся из настроек сервиса
+	MaxRetries        *int   `json:"max_retries,omitempty"`
+	RetryDelaySeconds *int   `json:"retry_delay_seconds,omitempty"`
+	Backoff           string `json:"backoff,omitempty"`
+	TimeoutSeconds    *int   `json:"timeout_seconds,omitempty"`
+
+	// отсутствующий batch_size — каждое событие отдельным запросом, compression — без сжатия
+	BatchSize   int    `json:"batch_size,omitempty"`
+	BatchWaitMs int    `json:"batch_wait_ms,omitempty"`
+	Compression string `json:"compression,omitempty"`
+
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookEndpointsListResponse struct {
+	Endpoints []WebhookEndpointResponse `json:"endpoints"`
+}
+
+// WebhookSLOResponse — задержка доставки вебхуков получателю от создания проверки до успешной доставки
+// за последние window_minutes. target_ms 0 — цель не задана; breached — перцентиль percentile выше цели
+// при числе доставок не меньше min_deliveries
+type WebhookSLOResponse struct {
+	EndpointID    int    `json:"endpoint_id"`
+	URL           string `json:"url"`
+	Enabled       bool   `json:"enabled"`
+	WindowMinutes int    `json:"window_minutes"`
+	Delivered     int    `json:"delivered"`
+	P50Ms         int64  `json:"p50_ms"`
+	P95Ms         int64  `json:"p95_ms"`
+	P99Ms         int64  `json:"p99_ms"`
+	Percentile    int    `json:"percentile"`
+	TargetMs      int64  `json:"target_ms"`
+	MinDeliveries int    `json:"min_deliveries"`
+	Breached      bool   `json:"breached"`
+}
+
+type WebhookSLOListResponse struct {
+	Endpoints   []WebhookSLOResponse `json:"endpoints"`
+	Breached    int                  `json:"breached"`
+	GeneratedAt time.Time            `json:"generated_at"`
+}
@@ -0,0 +1,68 @@
+// Package resp содержит ответы API версии v2
+package resp
+
+import "time"
+
+type LocationCheckResponse struct {
+	HasAlert bool `json:"has_alert"`
+	// Match — inside, possibly_inside или outside с учетом accuracy_m
+	Match string      `json:"match"`
+	Zones []ZoneMatch `json:"zones"`
+	// Ahead — зоны на пути, если в запросе переданы speed_mps и heading_deg
+	Ahead []ZoneAhead `json:"ahead,omitempty"`
+	// Nearest — ближайшая зона, в которую точка не попала
+	Nearest *NearestZone `json:"nearest,omitempty"`
+	Place   string       `json:"place,omitempty"`
+}
+
+type LocationCheckBatchResponse struct {
+	// Results — итоги в порядке checks из запроса
+	Results []LocationCheckBatchResult `json:"results"`
+}
+
+// LocationCheckBatchResult — заполнено либо result, либо error, если проверка отклонена
+type LocationCheckBatchResult struct {
+	Result *LocationCheckResponse `json:"result,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+// ZoneMatch — опасная зона, в которую попала точка
+type ZoneMatch struct {
+	IncidentID int        `json:"incident_id"`
+	Name       string     `json:"name"`
+	Descr      string     `json:"descr"`
+	Latitude   float64    `json:"latitude"`
+	Longitude  float64    `json:"longitude"`
+	Radius     float64    `json:"radius_m"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	// Visibility restricted и internal — зона предупреждает, но название и описание не раскрываются (пустые)
+	Visibility string `json:"visibility" enums:"public,restricted,internal"`
+	// Match — inside или possibly_inside, если круг погрешности пересекает границу зоны
+	Match string `json:"match"`
+	// DistanceM — расстояние от точки до центра зоны
+	DistanceM float64 `json:"distance_m"`
+	// DistanceToEdgeM — расстояние от точки до границы зоны изнутри
+	DistanceToEdgeM float64 `json:"distance_to_edge_m"`
+	// BearingDeg — азимут на центр зоны в градусах от севера по часовой стрелке
+	BearingDeg float64 `json:"bearing_deg"`
+}
+
+// NearestZone — ближайшая опасная зона впереди
+type NearestZone struct {
+	IncidentID int     `json:"incident_id"`
+	Name       string  `json:"name"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	Radius     float64 `json:"radius_m"`
+	Visibility string  `json:"visibility" enums:"public,restricted,internal"`
+	DistanceM  float64 `json:"distance_m"`
+	// DistanceToEdgeM — сколько осталось до границы зоны
+	DistanceToEdgeM float64 `json:"distance_to_edge_m"`
+	BearingDeg      float64 `json:"bearing_deg"`
+}
+
+// ZoneAhead — зона, в которую точка войдет при сохранении скорости и курса
+type ZoneAhead struct {
+	NearestZone
+	ETASeconds float64 `json:"eta_seconds"`
+}
@@ -2,13 +2,286 @@ package entity
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
 var (
 	ErrIncidentNotFound   = errors.New("incident not found")
 	ErrInvalidCoordinates = errors.New("invalid coordinates")
+	ErrInvalidAccuracy    = errors.New("accuracy_m must be non-negative")
+	ErrInvalidMotion      = errors.New("speed_mps must be non-negative and heading_deg within [0, 360)")
+	ErrOutsideArea        = errors.New("coordinates are outside the service operating area")
 	ErrUserIDRequired     = errors.New("user_id is required")
+	ErrInvalidSchedule    = errors.New("invalid schedule")
+	ErrInvalidExpiry      = errors.New("expires_at must be in the future")
+	ErrInvalidBatchAction = errors.New("action must be one of: activate, deactivate, delete")
+	ErrCacheMiss          = errors.New("cache miss")
+
+	// ErrDependencyUnavailable — необязательная зависимость (Redis) недоступна, сервис работает в деградированном режиме
+	ErrDependencyUnavailable = errors.New("dependency temporarily unavailable")
+
+	ErrAddressNotFound     = errors.New("address not found")
+	ErrGeocoderUnavailable = errors.New("geocoding provider unavailable")
+	ErrGeocodingDisabled   = errors.New("geocoding is not configured")
+
+	ErrAttachmentNotFound        = errors.New("attachment not found")
+	ErrUnsupportedAttachmentType = errors.New("unsupported attachment type: only images and PDF are allowed")
+	ErrAttachmentTooLarge        = errors.New("attachment is too large")
+
+	ErrInvalidWebhookURL   = errors.New("webhook url must be a valid http(s) URL")
+	ErrWebhookNotClaimable = errors.New("webhook is not due or already claimed")
+	ErrWebhookLeaseLost    = errors.New("webhook lease expired and was taken by another worker")
+
+	ErrWebhookEndpointNotFound = errors.New("webhook endpoint not found")
+	ErrInvalidWebhookEvents    = errors.New("events must list at least one known event type or *")
+	ErrInvalidRetryPolicy      = errors.New("invalid webhook retry policy")
+	ErrInvalidWebhookDelivery  = errors.New("invalid webhook delivery settings")
+
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrOperatorNotFound   = errors.New("operator not found")
+	ErrOperatorExists     = errors.New("operator with this username or oidc subject already exists")
+	ErrInvalidOperator    = errors.New("invalid operator")
+
+	ErrForbidden               = errors.New("operator role does not allow this action")
+	ErrInvalidStatus           = errors.New("status must be one of: draft, published")
+	ErrInvalidStatusTransition = errors.New("incident status does not allow this action")
+	ErrSelfReview              = errors.New("incident must be published by an operator other than its authors")
+
+	ErrInvalidSeverity   = errors.New("severity must be one of: low, medium, high, critical")
+	ErrInvalidVisibility = errors.New("visibility must be one of: public, restricted, internal")
+	ErrApprovalRequired  = errors.New("critical incident must be approved by a second operator before publishing")
+	ErrApprovalNotFound  = errors.New("approval not found")
+	ErrApprovalPending   = errors.New("incident already awaits approval")
+	ErrApprovalDecided   = errors.New("approval is already decided")
+	ErrSelfApproval      = errors.New("approval must be decided by an operator other than the requester")
+
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	ErrIncidentOverlap = errors.New("incident overlaps existing active incidents")
+
+	ErrInvalidMerge = errors.New("merge requires at least two distinct incidents")
+
+	ErrInvalidState           = errors.New("state must be one of: planned, active, contained, resolved, archived")
+	ErrInvalidStateTransition = errors.New("incident state does not allow this transition")
+
+	ErrInvalidLocale       = errors.New("locale must be a valid BCP 47 language tag, e.g. en or pt-BR")
+	ErrTranslationNotFound = errors.New("translation not found")
+
+	ErrInvalidComment = errors.New("comment must be 1-2000 characters")
+
+	ErrInvalidRelation  = errors.New("relation type must be one of: parent, supersedes, and link two different incidents")
+	ErrRelationNotFound = errors.New("incident relation not found")
+	ErrRelationExists   = errors.New("incident relation already exists or conflicts with an existing one")
+
+	ErrInvalidAlertFeed = errors.New("invalid alert feed")
+
+	ErrInvalidQuietHours   = errors.New("quiet hours must be HH:MM windows with different start and end and days from mon to sun")
+	ErrInvalidTimezone     = errors.New("timezone must be an IANA time zone, e.g. Europe/Moscow")
+	ErrInvalidAlertChannel = errors.New("channels must be a non-empty list of: stream, webhook")
+	ErrPreferencesNotFound = errors.New("user preferences not found")
+	ErrInvalidAlertLimit   = errors.New("max_alerts_per_hour must not be negative")
+
+	ErrGroupNotFound        = errors.New("group not found")
+	ErrGroupWebhookNotFound = errors.New("group webhook not found")
+	ErrGroupMemberNotFound  = errors.New("user is not a member of the group")
+
+	ErrInvalidBacktest = errors.New("radius_m can only be overridden for a circular zone")
+
+	ErrQuotaExceeded   = errors.New("API key quota exceeded")
+	ErrInvalidUsageDay = errors.New("from and to must be YYYY-MM-DD dates, from not after to, at most 366 days apart")
+
+	ErrErasureNotFound    = errors.New("erasure request not found")
+	ErrErasurePending     = errors.New("user already has an open erasure request")
+	ErrErasureClosed      = errors.New("erasure request is not awaiting confirmation")
+	ErrSelfConfirmation   = errors.New("erasure must be confirmed by an operator other than the requester")
+	ErrInvalidErasureMode = errors.New("mode must be one of: delete, anonymize")
+
+	ErrDataExportNotFound = errors.New("data export not found")
+	ErrDataExportPending  = errors.New("data export is already being generated")
+
+	ErrInvalidHeatmap = errors.New("resolution must be between 0 and the stored heatmap resolution, from before to, at most 92 days apart")
+	ErrStatsNotReady  = errors.New("check stats have not been rolled up yet")
+)
+
+// Попадание точки в зону с учетом погрешности координат
+const (
+	MatchInside         = "inside"
+	MatchPossiblyInside = "possibly_inside"
+	MatchOutside        = "outside"
+)
+
+// Действия пакетной операции над инцидентами
+const (
+	BatchActivate   = "activate"
+	BatchDeactivate = "deactivate"
+	BatchDelete     = "delete"
+)
+
+// Статусы инцидента: в проверках участвуют только опубликованные зоны, is_active действует внутри статуса
+const (
+	IncidentDraft     = "draft"
+	IncidentPublished = "published"
+	IncidentArchived  = "archived"
+)
+
+// Стадии жизни опасности (state), независимые от стадии публикации (status): в проверках участвуют
+// опубликованные зоны в active и contained, archived — конечная стадия
+const (
+	StatePlanned   = "planned"
+	StateActive    = "active"
+	StateContained = "contained"
+	StateResolved  = "resolved"
+	StateArchived  = "archived"
+)
+
+// States — стадии жизни опасности в порядке жизненного цикла
+var States = []string{StatePlanned, StateActive, StateContained, StateResolved, StateArchived}
+
+var stateTransitions = map[string][]string{
+	StatePlanned:   {StateActive, StateResolved},
+	StateActive:    {StateContained, StateResolved},
+	StateContained: {StateActive, StateResolved},
+	// resolved -> active — повторное возникновение опасности
+	StateResolved: {StateActive, StateArchived},
+}
+
+// ValidState сообщает, известна ли стадия жизни опасности
+func ValidState(state string) bool {
+	_, ok := stateTransitions[state]
+	return ok || state == StateArchived
+}
+
+// CanTransition сообщает, разрешен ли переход между стадиями
+func CanTransition(from, to string) bool {
+	for _, s := range stateTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StateInEffect сообщает, действует ли опасность в этой стадии
+func StateInEffect(state string) bool {
+	return state == StateActive || state == StateContained
+}
+
+// Источники зон: созданные оператором, импортированные из внешних лент оповещений и из погодного API
+const (
+	SourceManual  = "manual"
+	SourceFeed    = "feed"
+	SourceWeather = "weather"
+)
+
+// Роли операторов: редактор готовит черновики, публикатор выпускает и меняет действующие зоны
+const (
+	RoleEditor    = "editor"
+	RolePublisher = "publisher"
+)
+
+// Уровни опасности зоны: критические публикуются только с одобрения второго оператора
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// ValidSeverity сообщает, известен ли уровень опасности
+func ValidSeverity(severity string) bool {
+	switch severity {
+	case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return true
+	}
+	return false
+}
+
+// Видимость зоны: public — зона видна везде; restricted — предупреждает пользователей, но без названия
+// и описания и не попадает в публичные ленты; internal — как restricted, а в списках операторов видна только публикаторам
+const (
+	VisibilityPublic     = "public"
+	VisibilityRestricted = "restricted"
+	VisibilityInternal   = "internal"
+)
+
+// ValidVisibility сообщает, известна ли видимость зоны
+func ValidVisibility(visibility string) bool {
+	switch visibility {
+	case VisibilityPublic, VisibilityRestricted, VisibilityInternal:
+		return true
+	}
+	return false
+}
+
+// Типы событий вебхуков: тип передается в поле event конверта и в заголовке X-Geonotify-Event
+const (
+	WebhookLocationAlert       = "location.alert"
+	WebhookIncidentCreated     = "incident.created"
+	WebhookIncidentUpdated     = "incident.updated"
+	WebhookIncidentDeactivated = "incident.deactivated"
+	WebhookIncidentState       = "incident.state_changed"
+	WebhookUserEntered         = "user.entered"
+	WebhookUserExited          = "user.exited"
+	WebhookUserAlert           = "user.alert"
+	// WebhookGroupAlert получает только получатель группы, поэтому в WebhookEvents его нет
+	WebhookGroupAlert = "group.alert"
+)
+
+// WebhookEvents — все типы событий, на которые может подписаться получатель
+var WebhookEvents = []string{
+	WebhookLocationAlert,
+	WebhookIncidentCreated,
+	WebhookIncidentUpdated,
+	WebhookIncidentDeactivated,
+	WebhookIncidentState,
+	WebhookUserEntered,
+	WebhookUserExited,
+	WebhookUserAlert,
+}
+
+// ValidWebhookEvent сообщает, известен ли тип события вебхука
+func ValidWebhookEvent(eventType string) bool {
+	for _, e := range WebhookEvents {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Статусы заявки на публикацию критической зоны
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+)
+
+// Статусы заявки на удаление данных пользователя
+const (
+	ErasurePending   = "pending"
+	ErasureConfirmed = "confirmed"
+	ErasureCompleted = "completed"
+	ErasureCancelled = "cancelled"
+)
+
+// Способы удаления данных пользователя: delete удаляет проверки, anonymize оставляет их для статистики
+// под AnonymizedUserID с координатами, огрубленными до AnonymizedPrecision знаков
+const (
+	ErasureDelete    = "delete"
+	ErasureAnonymize = "anonymize"
+
+	AnonymizedUserID    = "anonymized"
+	AnonymizedPrecision = 2
+)
+
+// Статусы выгрузки данных пользователя
+const (
+	DataExportPending = "pending"
+	DataExportReady   = "ready"
+	// DataExportExpired — архив удален из хранилища по истечении срока хранения
+	DataExportExpired = "expired"
 )
 
 type Incident struct {
@@ -21,11 +294,455 @@ type Incident struct {
 	IsActive  bool
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// Schedule — cron-выражение начала окна активности, пустое для разовых инцидентов
+	Schedule            string
+	ScheduleDurationMin int
+
+	// ExpiresAt — момент автоматической деактивации, nil для бессрочных инцидентов
+	ExpiresAt *time.Time
+
+	// Address — исходный адрес, по которому геокодированы координаты
+	Address string
+
+	// CreatedBy и UpdatedBy — кто создал и последним изменил зону (см. Actor.Name)
+	CreatedBy string
+	UpdatedBy string
+
+	Status      string
+	PublishedBy string
+	PublishedAt *time.Time
+
+	Severity string
+
+	// Polygons — форма зоны (MultiPolygon, точки [долгота, широта]), nil для круглых зон.
+	// Для полигональной зоны Latitude, Longitude и Radius описывают охватывающий круг
+	Polygons [][][][2]float64
+
+	// State — стадия жизни опасности; IsActive производный: зона в active или contained и внутри окна расписания.
+	// StateTimes — когда зона последний раз входила в каждую из пройденных стадий
+	State          string
+	StateChangedAt time.Time
+	StateTimes     map[string]time.Time
+
+	// Source — откуда появилась зона: SourceManual, SourceFeed или SourceWeather
+	Source string
+
+	// Visibility — кому видны сведения о зоне; пустая (зоны из кэша до появления поля) — VisibilityPublic
+	Visibility string
+
+	// Relations — связи с другими зонами с точки зрения этой зоны; заполняются только при чтении зоны и списков
+	Relations []IncidentRelation
 }
 
-type Webhook struct {
+// Public сообщает, можно ли показывать название и описание зоны пользователям и в публичных лентах
+func (i *Incident) Public() bool {
+	return i.Visibility == "" || i.Visibility == VisibilityPublic
+}
+
+// Redacted возвращает зону для пользователей: у непубличной — копию без названия, описания, адреса и авторов,
+// у публичной — ее саму
+func (i *Incident) Redacted() *Incident {
+	if i.Public() {
+		return i
+	}
+	redacted := *i
+	redacted.Name = ""
+	redacted.Descr = ""
+	redacted.Address = ""
+	redacted.CreatedBy = ""
+	redacted.UpdatedBy = ""
+	redacted.PublishedBy = ""
+	return &redacted
+}
+
+// StateChange — переход зоны между стадиями жизни опасности
+type StateChange struct {
+	IncidentID int
+	From       string
+	To         string
+}
+
+// IncidentGeometry — новая форма зоны: круг или полигоны вместе с охватывающим кругом
+type IncidentGeometry struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+	Polygons  [][][][2]float64
+	UpdatedBy string
+}
+
+// IncidentPart — зона, выделяемая из исходной при разделении
+type IncidentPart struct {
+	Name     string
+	Geometry IncidentGeometry
+}
+
+// IncidentPatch — частичное обновление инцидента: nil-поля не меняются.
+// ExpiresAt с нулевым временем снимает срок действия
+type IncidentPatch struct {
+	Name      *string
+	Descr     *string
+	Latitude  *float64
+	Longitude *float64
+	Radius    *float64
+	IsActive  *bool
+
+	Schedule            *string
+	ScheduleDurationMin *int
+	ExpiresAt           *time.Time
+	Address             *string
+	Severity            *string
+	Visibility          *string
+
+	// UpdatedBy выставляется всегда, а не только при наличии в патче
+	UpdatedBy string
+}
+
+// Apply переносит заданные поля патча в инцидент
+func (p IncidentPatch) Apply(incident *Incident) {
+	if p.Name != nil {
+		incident.Name = *p.Name
+	}
+	if p.Descr != nil {
+		incident.Descr = *p.Descr
+	}
+	if p.Latitude != nil {
+		incident.Latitude = *p.Latitude
+	}
+	if p.Longitude != nil {
+		incident.Longitude = *p.Longitude
+	}
+	if p.Radius != nil {
+		incident.Radius = *p.Radius
+	}
+	if p.IsActive != nil {
+		incident.IsActive = *p.IsActive
+	}
+	if p.Schedule != nil {
+		incident.Schedule = *p.Schedule
+	}
+	if p.ScheduleDurationMin != nil {
+		incident.ScheduleDurationMin = *p.ScheduleDurationMin
+	}
+	if p.ExpiresAt != nil {
+		incident.ExpiresAt = p.ExpiresAt
+		if p.ExpiresAt.IsZero() {
+			incident.ExpiresAt = nil
+		}
+	}
+	if p.Address != nil {
+		incident.Address = *p.Address
+	}
+	if p.Severity != nil {
+		incident.Severity = *p.Severity
+	}
+	if p.Visibility != nil {
+		incident.Visibility = *p.Visibility
+	}
+}
+
+// Erasure — заявка на удаление данных пользователя (право на забвение). Заявку подтверждает
+// оператор, не подававший ее, после чего данные удаляет фоновая задача. Сама заявка остается как запись аудита
+type Erasure struct {
+	ID          int
+	UserID      string
+	Mode        string
+	Status      string
+	RequestedBy string
+	RequestedAt time.Time
+	// ConfirmedBy пустой, пока заявка ждет подтверждения; для отмененной заявки — кто ее отменил
+	ConfirmedBy string
+	ConfirmedAt *time.Time
+	CompletedAt *time.Time
+	// Attempts и LastError — неудачные попытки удаления, задача повторяет их до успеха
+	Attempts  int
+	LastError string
+	Result    ErasureResult
+}
+
+// ErasureResult — сколько записей удалено или обезличено
+type ErasureResult struct {
+	Checks           int64
+	Webhooks         int64
+	Zones            int64
+	Preferences      int64
+	GroupMemberships int64
+}
+
+// DataExport — выгрузка всех данных пользователя по запросу субъекта данных: JSON-архив в объектном хранилище,
+// который собирает фоновая задача и хранит до ExpiresAt
+type DataExport struct {
+	ID          int
+	UserID      string
+	Status      string
+	RequestedBy string
+	RequestedAt time.Time
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time
+	// ObjectKey и SizeBytes заполняются, когда архив готов
+	ObjectKey string
+	SizeBytes int64
+	Attempts  int
+	LastError string
+}
+
+// Approval — заявка на публикацию критической зоны или на повышение опубликованной зоны до критической;
+// решает ее оператор, не подававший заявку
+type Approval struct {
+	ID         int
+	IncidentID int
+	Status     string
+	// Severity — критичность, которую получит опубликованная зона; пустая у заявки на публикацию
+	Severity    string
+	RequestedBy string
+	RequestedAt time.Time
+	// DecidedBy и DecidedAt пустые, пока заявка ждет решения
+	DecidedBy string
+	DecidedAt *time.Time
+	// Reason — причина отказа
+	Reason string
+}
+
+// Операции, после которых зоны связаны в истории: слияние нескольких зон в одну и разделение зоны на части
+const (
+	LineageMerge = "merge"
+	LineageSplit = "split"
+)
+
+// IncidentLink — запись истории зон: ChildID создана из ParentID слиянием или разделением,
+// ParentID при этом снята с публикации
+type IncidentLink struct {
+	ParentID  int
+	ChildID   int
+	Operation string
+	Actor     string
+	CreatedAt time.Time
+}
+
+// Типы связей между зонами, которые задает оператор
+const (
+	// RelationParent — зона входит в более крупную зону RelatedID, например сектор в районе эвакуации
+	RelationParent = "parent"
+	// RelationSupersedes — зона заменяет зону RelatedID, например зона эвакуации — прежнюю зону предупреждения
+	RelationSupersedes = "supersedes"
+)
+
+// Те же связи со стороны зоны RelatedID
+const (
+	RelationChild        = "child"
+	RelationSupersededBy = "superseded_by"
+)
+
+// IncidentRelation — связь зоны IncidentID с зоной RelatedID; CreatedBy — см. Actor.Name
+type IncidentRelation struct {
+	IncidentID int
+	RelatedID  int
+	Type       string
+	CreatedBy  string
+	CreatedAt  time.Time
+}
+
+// From возвращает связь со стороны зоны incID: для RelatedID зоны меняются местами, а тип — на обратный
+func (r IncidentRelation) From(incID int) IncidentRelation {
+	if r.IncidentID == incID {
+		return r
+	}
+	r.IncidentID, r.RelatedID = r.RelatedID, r.IncidentID
+	switch r.Type {
+	case RelationParent:
+		r.Type = RelationChild
+	case RelationSupersedes:
+		r.Type = RelationSupersededBy
+	}
+	return r
+}
+
+// GeometryVersion — форма зоны, действовавшая с ValidFrom до следующей версии; Version — порядковый номер с 1.
+// ChangedBy — см. Actor.Name
+type GeometryVersion struct {
+	IncidentID int
+	Version    int
+	Latitude   float64
+	Longitude  float64
+	Radius     float64
+	// Polygons — как у Incident, nil для круглой зоны
+	Polygons  [][][][2]float64
+	ChangedBy string
+	ValidFrom time.Time
+}
+
+// IncidentTranslation — название и описание зоны на другом языке; Locale — тег BCP 47 в канонической форме
+type IncidentTranslation struct {
+	IncidentID int
+	Locale     string
+	Name       string
+	Descr      string
+	UpdatedBy  string
+	UpdatedAt  time.Time
+}
+
+// IncidentComment — заметка оператора о ходе ситуации в зоне; Author — см. Actor.Name
+type IncidentComment struct {
+	ID         int
+	IncidentID int
+	Body       string
+	Author     string
+	CreatedAt  time.Time
+}
+
+// ExternalAlert — оповещение внешней ленты (CAP, Atom или RSS с GeoRSS), приведенное к форме зоны.
+// ID уникален в пределах ленты, References — ID прежних оповещений, которые это обновляет или отменяет
+type ExternalAlert struct {
+	ID         string
+	References []string
+	// Sent — время выпуска или последнего изменения оповещения: более старое повторно не применяется
+	Sent time.Time
+	// Ended — оповещение отменено или опасность миновала
+	Ended bool
+
+	Name      string
+	Descr     string
+	Severity  string
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+	Polygons  [][][][2]float64
+	ExpiresAt *time.Time
+}
+
+// IncidentImport — уже обработанное оповещение внешней ленты; IncidentID 0 — оповещение пропущено
+type IncidentImport struct {
+	Feed       string
+	ExternalID string
+	IncidentID int
+	SentAt     time.Time
+}
+
+type Attachment struct {
 	ID          int
+	IncidentID  int
+	ObjectKey   string
+	FileName    string
+	ContentType string
+	Size        int64
+	CreatedAt   time.Time
+}
+
+// Operator — учетная запись оператора: вход по паролю (PasswordHash, bcrypt) и/или через OIDC
+type Operator struct {
+	ID           int
+	Username     string
+	PasswordHash string
+	OIDCSubject  string
+	Role         string
+	CreatedAt    time.Time
+}
+
+// ActorAPIKey — имя исполнителя для запросов со статическим API-ключом
+const ActorAPIKey = "api-key"
+
+// DefaultAPIKey — имя, под которым учитываются запросы с SECRET_API_KEY
+const DefaultAPIKey = "default"
+
+// Actor — кто выполняет запрос оператора
+type Actor struct {
+	// Name — имя оператора, ActorAPIKey или "ActorAPIKey:имя" для ключей из API_KEYS, попадает в created_by/updated_by
+	Name string
+	// OperatorID — 0 для API-ключа и пользователей OIDC без учетной записи оператора
+	OperatorID int
+	// Subject — sub токена внешнего OIDC-провайдера
+	Subject string
+	// Role — RoleEditor или RolePublisher; API-ключ действует как публикатор
+	Role string
+	// APIKey — имя API-ключа запроса, пустое для операторов
+	APIKey string
+}
+
+func (a Actor) CanPublish() bool {
+	return a.Role == RolePublisher
+}
+
+// WebhookTask — задача очереди доставки: будит воркер для конкретного вебхука
+type WebhookTask struct {
+	WebhookID int
+	CheckID   int
+}
+
+// QueueDepth — задачи в очереди доставки: готовые к выдаче воркеру и отложенные повторы.
+// Scheduled nil — брокер не сообщает число отложенных задач
+type QueueDepth struct {
+	Ready     int64
+	Scheduled *int64
+}
+
+// WebhookBacklog — недоставленные вебхуки в outbox на момент CheckedAt (время БД).
+// Due — готовые к отправке, но никем не захваченные (включая вебхуки с истекшей арендой);
+// OldestDueAt — с какого момента ждет самый старый из них, то есть насколько отстает опрос outbox
+type WebhookBacklog struct {
+	Pending         int
+	Processing      int
+	Failed          int
+	Due             int
+	OldestPendingAt *time.Time
+	OldestDueAt     *time.Time
+	CheckedAt       time.Time
+}
+
+// WebhookLatency — задержка доставки вебхуков получателю от создания проверки до успешной доставки
+// (для событий без проверки — от постановки в очередь), перцентили по доставкам за окно
+type WebhookLatency struct {
+	EndpointID int
+	Delivered  int
+	P50        time.Duration
+	P95        time.Duration
+	P99        time.Duration
+}
+
+// Percentile возвращает перцентиль задержки p: 50, 95 или 99
+func (l WebhookLatency) Percentile(p int) time.Duration {
+	switch p {
+	case 50:
+		return l.P50
+	case 99:
+		return l.P99
+	default:
+		return l.P95
+	}
+}
+
+// APIKeyQuota — лимиты запросов API-ключа за сутки и календарный месяц (UTC); 0 — без ограничения
+type APIKeyQuota struct {
+	Daily   int64
+	Monthly int64
+}
+
+// APIKeyUsage — использование API-ключа за сутки Day (UTC): запросы, проверки координат, проверки,
+// попавшие в зоны, и вебхуки, созданные по запросам ключа
+type APIKeyUsage struct {
+	Key      string
+	Day      time.Time
+	Requests int64
+	Checks   int64
+	Alerts   int64
+	Webhooks int64
+}
+
+// UsageCounters — запросы API-ключа за текущие сутки и месяц вместе с учтенным.
+// Allowed false — запрос не учтен, потому что квота уже исчерпана
+type UsageCounters struct {
+	Allowed bool
+	Day     int64
+	Month   int64
+}
+
+type Webhook struct {
+	ID int
+	// EndpointID 0 — вебхук создан до появления получателей и еще не привязан к получателю
+	EndpointID int
+	// CheckID 0 — событие не связано с проверкой (события зон)
 	CheckID     int
+	EventType   string
 	State       string
 	RetryCnt    int
 	Payload     []byte
@@ -34,6 +751,349 @@ type Webhook struct {
 	ScheduledAt time.Time
 }
 
+// OverlapError — новая зона перекрывает активные зоны IncidentIDs; errors.Is(err, ErrIncidentOverlap)
+type OverlapError struct {
+	IncidentIDs []int
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("%s: %v", ErrIncidentOverlap, e.IncidentIDs)
+}
+
+func (e *OverlapError) Unwrap() error {
+	return ErrIncidentOverlap
+}
+
+// IncidentsVersion — версия набора инцидентов: любое изменение сдвигает updated_at, а удаление
+// еще и уменьшает Count, так что набор не меняется, пока версия та же
+type IncidentsVersion struct {
+	Count     int
+	UpdatedAt time.Time
+}
+
+// IncidentCursor — позиция в списке инцидентов, упорядоченном по (updated_at, id) от новых к старым
+type IncidentCursor struct {
+	UpdatedAt time.Time
+	ID        int
+}
+
+// IncidentChange — изменение зоны для выгрузки во внешние системы; у удаленной зоны Incident — ее последнее состояние
+type IncidentChange struct {
+	Incident *Incident
+	Deleted  bool
+}
+
+// SyncCursor — позиция выгрузки во внешнюю систему: время изменения и ID последней выгруженной строки
+type SyncCursor struct {
+	UpdatedAt time.Time
+	ID        int
+}
+
+// WebhookRedriveFilter — отбор недоставленных вебхуков для повторной доставки; нулевые поля не фильтруют
+type WebhookRedriveFilter struct {
+	State       string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	IncidentID  int
+	EndpointID  int
+	// Spread растягивает доставку на окно, чтобы не перегрузить восстановившегося получателя
+	Spread time.Duration
+}
+
+// WebhookEndpoint — получатель вебхуков: каждое событие, на которое он подписан, доставляется ему отдельно
+type WebhookEndpoint struct {
+	ID  int
+	URL string
+	// Secret пустой — вебхуки получателю не подписываются
+	Secret string
+	// Events — типы событий из WebhookEvents или "*" для всех событий
+	Events  []string
+	Enabled bool
+	Retry   WebhookRetryPolicy
+	// Delivery — батчинг и сжатие запросов к получателю
+	Delivery WebhookDelivery
+	// GroupID 0 — общий получатель; получатель группы получает только ее group.alert
+	GroupID   int
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookDelivery — формат запросов к получателю. BatchSize 0 — каждое событие отдельным JSON-запросом;
+// иначе события копятся до BatchSize штук или BatchWaitMs и уходят одним NDJSON-запросом
+type WebhookDelivery struct {
+	BatchSize   int
+	BatchWaitMs int
+	// Compression — сжатие тела запроса: CompressionGzip или пустая строка без сжатия
+	Compression string
+}
+
+const CompressionGzip = "gzip"
+
+// Batched сообщает, копятся ли события получателя в пачки
+func (d WebhookDelivery) Batched() bool {
+	return d.BatchSize > 0
+}
+
+// Стратегии паузы между попытками доставки
+const (
+	BackoffLinear      = "linear"
+	BackoffExponential = "exponential"
+)
+
+// maxRetryDelay ограничивает экспоненциальную паузу между попытками
+const maxRetryDelay = 24 * time.Hour
+
+// DefaultWebhookTimeout — таймаут запроса доставки для получателей без собственного TimeoutSeconds
+const DefaultWebhookTimeout = 10 * time.Second
+
+// WebhookRetryPolicy — попытки доставки получателю. nil-поля и пустой Backoff берутся
+// из настроек сервиса (WEBHOOK_MAX_RETRIES, WEBHOOK_RETRY_DELAY_SECONDS, линейная пауза, таймаут отправителя)
+type WebhookRetryPolicy struct {
+	MaxRetries        *int
+	RetryDelaySeconds *int
+	Backoff           string
+	TimeoutSeconds    *int
+}
+
+// Delay возвращает паузу перед повторной попыткой retry (с 1): линейную base*retry
+// или экспоненциальную base*2^(retry-1), не больше суток
+func (p WebhookRetryPolicy) Delay(base time.Duration, retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+	if p.Backoff != BackoffExponential {
+		return base * time.Duration(retry)
+	}
+
+	delay := base
+	for i := 1; i < retry && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// Subscribed сообщает, подписан ли получатель на событие этого типа
+func (e WebhookEndpoint) Subscribed(eventType string) bool {
+	for _, event := range e.Events {
+		if event == "*" || event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEndpointPatch — частичное обновление получателя: nil-поля не меняются
+type WebhookEndpointPatch struct {
+	URL     *string
+	Secret  *string
+	Events  *[]string
+	Enabled *bool
+
+	MaxRetries        *int
+	RetryDelaySeconds *int
+	Backoff           *string
+	TimeoutSeconds    *int
+
+	BatchSize   *int
+	BatchWaitMs *int
+	Compression *string
+}
+
+// Apply переносит заданные поля патча в получателя
+func (p WebhookEndpointPatch) Apply(endpoint *WebhookEndpoint) {
+	if p.URL != nil {
+		endpoint.URL = *p.URL
+	}
+	if p.Secret != nil {
+		endpoint.Secret = *p.Secret
+	}
+	if p.Events != nil {
+		endpoint.Events = *p.Events
+	}
+	if p.Enabled != nil {
+		endpoint.Enabled = *p.Enabled
+	}
+	if p.MaxRetries != nil {
+		endpoint.Retry.MaxRetries = p.MaxRetries
+	}
+	if p.RetryDelaySeconds != nil {
+		endpoint.Retry.RetryDelaySeconds = p.RetryDelaySeconds
+	}
+	if p.Backoff != nil {
+		endpoint.Retry.Backoff = *p.Backoff
+	}
+	if p.TimeoutSeconds != nil {
+		endpoint.Retry.TimeoutSeconds = p.TimeoutSeconds
+	}
+	if p.BatchSize != nil {
+		endpoint.Delivery.BatchSize = *p.BatchSize
+	}
+	if p.BatchWaitMs != nil {
+		endpoint.Delivery.BatchWaitMs = *p.BatchWaitMs
+	}
+	if p.Compression != nil {
+		endpoint.Delivery.Compression = *p.Compression
+	}
+}
+
+// Event — запись outbox для публикации во внешний поток (Redis Stream)
+type Event struct {
+	ID        int64
+	Topic     string
+	Type      string
+	Key       string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+const (
+	AlertCheckMatched    = "check_matched"
+	AlertIncidentCreated = "incident_created"
+	AlertZoneAhead       = "zone_ahead"
+)
+
+// Alert — уведомление пользователя: его проверка попала в зону, путь ведет в зону
+// или новая зона накрыла его последнюю известную точку
+type Alert struct {
+	Type      string
+	UserID    string
+	CheckID   int
+	Incidents []*Incident
+	CreatedAt time.Time
+}
+
+// Каналы доставки алертов пользователю
+const (
+	// AlertChannelStream — SSE-поток /api/v1/users/{user_id}/alerts/stream
+	AlertChannelStream = "stream"
+	// AlertChannelWebhook — событие вебхука user.alert, например для push-уведомлений через бэкенд приложения
+	AlertChannelWebhook = "webhook"
+)
+
+// ValidAlertChannel сообщает, известен ли канал доставки алертов
+func ValidAlertChannel(channel string) bool {
+	return channel == AlertChannelStream || channel == AlertChannelWebhook
+}
+
+// Weekdays — дни недели окон тишины
+var Weekdays = map[string]time.Weekday{
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+	"sun": time.Sunday,
+}
+
+// QuietHours — окно тишины по местному времени пользователя, время в формате "15:04".
+// Start позже End — окно переходит через полночь. Days — дни начала окна (mon...sun), пустой — каждый день
+type QuietHours struct {
+	Start string
+	End   string
+	Days  []string
+}
+
+// UserPreferences — настройки алертов пользователя: алерты о зонах ниже MinSeverity не отправляются,
+// в окна тишины отправляются только алерты о критических зонах, остальные уходят в каналы Channels,
+// пока пользователь не получил MaxAlertsPerHour алертов за час
+type UserPreferences struct {
+	UserID      string
+	MinSeverity string
+	QuietHours  []QuietHours
+	// Timezone — часовой пояс IANA, в котором заданы окна тишины
+	Timezone string
+	Channels []string
+	// MaxAlertsPerHour 0 — без ограничения; алерты о критических зонах не ограничиваются, но учитываются
+	MaxAlertsPerHour int
+	UpdatedAt        time.Time
+}
+
+// DefaultUserPreferences — настройки пользователя, который их не сохранял: все алерты в SSE-поток
+func DefaultUserPreferences(userID string) UserPreferences {
+	return UserPreferences{
+		UserID:      userID,
+		MinSeverity: SeverityLow,
+		QuietHours:  []QuietHours{},
+		Timezone:    "UTC",
+		Channels:    []string{AlertChannelStream},
+	}
+}
+
+// Group — группа пользователей, например автопарк или школьный класс. Когда участник входит в зону,
+// получатель группы получает сводный алерт group.alert
+type Group struct {
+	ID    int
+	Name  string
+	Descr string
+	// Managers — операторы, которые управляют группой наравне с публикаторами
+	Managers    []string
+	MemberCount int
+	// Webhook nil — получатель группы не зарегистрирован
+	Webhook   *WebhookEndpoint
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Manager сообщает, может ли исполнитель менять группу: публикатор, создатель или менеджер группы
+func (g Group) Manager(actor Actor) bool {
+	if actor.CanPublish() || (g.CreatedBy != "" && actor.Name == g.CreatedBy) {
+		return true
+	}
+	for _, name := range g.Managers {
+		if name == actor.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupPatch — частичное обновление группы: nil-поля не меняются
+type GroupPatch struct {
+	Name     *string
+	Descr    *string
+	Managers *[]string
+}
+
+// Apply переносит заданные поля патча в группу
+func (p GroupPatch) Apply(group *Group) {
+	if p.Name != nil {
+		group.Name = *p.Name
+	}
+	if p.Descr != nil {
+		group.Descr = *p.Descr
+	}
+	if p.Managers != nil {
+		group.Managers = *p.Managers
+	}
+}
+
+// GroupMember — участник группы в действующих зонах по его последней проверке
+type GroupMember struct {
+	UserID string
+	Zones  []GroupMemberZone
+}
+
+type GroupMemberZone struct {
+	IncidentID int
+	Name       string
+	Severity   string
+	EnteredAt  time.Time
+}
+
+// GroupStatus — участники группы, находящиеся в зонах
+type GroupStatus struct {
+	Group           *Group
+	MembersInDanger []GroupMember
+}
+
+type DeliveryResult struct {
+	StatusCode int
+	Latency    time.Duration
+}
+
 type Check struct {
 	ID        int
 	UserID    string
@@ -41,4 +1101,38 @@ type Check struct {
 	Longitude float64
 	HasAlert  bool
 	CreatedAt time.Time
+	// StoredAt — момент записи проверки; отложенная запись сохраняет проверку позже CreatedAt,
+	// поэтому выгрузки идут по StoredAt. При записи не используется
+	StoredAt time.Time
+	// IncidentIDs — зоны, в которые попала проверка; при чтении не заполняется
+	IncidentIDs []int
+}
+
+// CheckStats — уникальные пользователи, проверки и проверки с алертом за период
+type CheckStats struct {
+	Users  int
+	Checks int
+	Alerts int
+}
+
+// IncidentCheckStats — число проверок, попавших в зону, за период
+type IncidentCheckStats struct {
+	IncidentID int
+	Name       string
+	Checks     int
+}
+
+// HeatmapBucket — число проверок и проверок с алертом в H3-ячейке Cell за час, начинающийся в Hour (UTC)
+type HeatmapBucket struct {
+	Cell   int64
+	Hour   time.Time
+	Checks int
+	Alerts int
+}
+
+// HeatmapCell — счетчики H3-ячейки за период
+type HeatmapCell struct {
+	Cell   int64
+	Checks int
+	Alerts int
 }
@@ -0,0 +1,270 @@
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/4otis/geonotify-service/pkg/client"
+)
+
+// Loader применяет сценарии к запущенному сервису. Токену клиента нужны права на создание зон и групп,
+// а для зон со status: published — права публикатора
+type Loader struct {
+	client *client.Client
+}
+
+func NewLoader(c *client.Client) *Loader {
+	return &Loader{client: c}
+}
+
+// Seeded — объекты, созданные сценарием: ID зон и групп по их ключам
+type Seeded struct {
+	Incidents map[string]int
+	Groups    map[string]int
+}
+
+// StepResult — ответ сервиса на одну проверку маршрута. Point — индекс точки в сценарии,
+// промежуточные шаги отрезка получают индекс его конечной точки, Expected — только у нее самой
+type StepResult struct {
+	User      string
+	Track     int
+	Point     int
+	Latitude  float64
+	Longitude float64
+	HasAlert  bool
+	// Incidents — ключи зон сценария, в которые попала точка; зоны вне сценария не учитываются
+	Incidents []string
+	Expected  *[]string
+	Err       error
+}
+
+// Failed сообщает, что проверка не прошла или ее ответ не совпал с ожиданием
+func (r StepResult) Failed() bool {
+	if r.Err != nil {
+		return true
+	}
+	if r.Expected == nil {
+		return false
+	}
+
+	expected := slices.Clone(*r.Expected)
+	slices.Sort(expected)
+	return !slices.Equal(slices.Compact(expected), r.Incidents)
+}
+
+type Report struct {
+	Steps []StepResult
+}
+
+func (r Report) Failures() []StepResult {
+	var failed []StepResult
+	for _, step := range r.Steps {
+		if step.Failed() {
+			failed = append(failed, step)
+		}
+	}
+	return failed
+}
+
+// Seed создает зоны и группы сценария и задает настройки алертов пользователей. При ошибке возвращает
+// и то, что успело создаться, чтобы его можно было удалить через Cleanup
+func (l *Loader) Seed(ctx context.Context, s *Scenario) (*Seeded, error) {
+	seeded := &Seeded{
+		Incidents: make(map[string]int, len(s.Incidents)),
+		Groups:    make(map[string]int, len(s.Groups)),
+	}
+
+	for _, inc := range s.Incidents {
+		id, err := l.client.CreateIncident(ctx, client.IncidentCreateRequest{
+			Name:       inc.Name,
+			Descr:      inc.Descr,
+			Latitude:   inc.Latitude,
+			Longitude:  inc.Longitude,
+			Radius:     inc.Radius,
+			TTLMinutes: inc.TTLMinutes,
+			Status:     client.IncidentStatus(inc.Status),
+			Severity:   client.Severity(inc.Severity),
+			State:      client.IncidentState(inc.State),
+		})
+		if err != nil {
+			return seeded, fmt.Errorf("failed to create incident %q: %w", inc.Key, err)
+		}
+		seeded.Incidents[inc.Key] = id
+
+		if inc.Geometry == "" {
+			continue
+		}
+		if err := l.client.SetIncidentGeometry(ctx, id, json.RawMessage(inc.Geometry)); err != nil {
+			return seeded, fmt.Errorf("failed to set geometry of incident %q: %w", inc.Key, err)
+		}
+	}
+
+	for _, u := range s.Users {
+		if u.Preferences == nil {
+			continue
+		}
+		if _, err := l.client.SetUserPreferences(ctx, u.ID, userPreferences(u.Preferences)); err != nil {
+			return seeded, fmt.Errorf("failed to set preferences of user %q: %w", u.ID, err)
+		}
+	}
+
+	for _, g := range s.Groups {
+		group, err := l.client.CreateGroup(ctx, client.GroupCreateRequest{
+			Name:    g.Name,
+			Descr:   g.Descr,
+			UserIDs: g.Members,
+		})
+		if err != nil {
+			return seeded, fmt.Errorf("failed to create group %q: %w", g.Key, err)
+		}
+		seeded.Groups[g.Key] = group.ID
+
+		if g.WebhookURL == "" {
+			continue
+		}
+		if _, err := l.client.SetGroupWebhook(ctx, group.ID, g.WebhookURL, ""); err != nil {
+			return seeded, fmt.Errorf("failed to set webhook of group %q: %w", g.Key, err)
+		}
+	}
+
+	return seeded, nil
+}
+
+// Replay проигрывает маршруты одновременно, точки каждого — по порядку. Ошибки отдельных проверок
+// не прерывают прогон, а попадают в отчет; ошибка возвращается, только если прервался ctx
+func (l *Loader) Replay(ctx context.Context, s *Scenario, seeded *Seeded) (Report, error) {
+	keys := make(map[int]string, len(seeded.Incidents))
+	for key, id := range seeded.Incidents {
+		keys[id] = key
+	}
+
+	results := make([][]StepResult, len(s.Tracks))
+	var wg sync.WaitGroup
+	for i := range s.Tracks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = l.replayTrack(ctx, i, s.Tracks[i], keys)
+		}()
+	}
+	wg.Wait()
+
+	var report Report
+	for _, steps := range results {
+		report.Steps = append(report.Steps, steps...)
+	}
+
+	return report, ctx.Err()
+}
+
+func (l *Loader) replayTrack(ctx context.Context, trackIdx int, track Track, keys map[int]string) []StepResult {
+	var (
+		steps []StepResult
+		prev  Point
+	)
+	for j, p := range track.Points {
+		n := max(p.Steps, 1)
+		for k := 1; k <= n; k++ {
+			if len(steps) > 0 && !sleep(ctx, time.Duration(track.IntervalMS)*time.Millisecond) {
+				return steps
+			}
+
+			// отрезок от предыдущей точки делится на n равных шагов, последний совпадает с p
+			frac := float64(k) / float64(n)
+			step := StepResult{
+				User:      track.User,
+				Track:     trackIdx,
+				Point:     j,
+				Latitude:  p.Latitude,
+				Longitude: p.Longitude,
+			}
+			if k < n {
+				step.Latitude = prev.Latitude + (p.Latitude-prev.Latitude)*frac
+				step.Longitude = prev.Longitude + (p.Longitude-prev.Longitude)*frac
+			} else {
+				step.Expected = p.Expect
+			}
+
+			l.check(ctx, &step, p.AccuracyM, keys)
+			steps = append(steps, step)
+		}
+		prev = p
+	}
+
+	return steps
+}
+
+func (l *Loader) check(ctx context.Context, step *StepResult, accuracy float64, keys map[int]string) {
+	result, err := l.client.CheckLocation(ctx, client.LocationCheckRequest{
+		UserID:    step.User,
+		Latitude:  step.Latitude,
+		Longitude: step.Longitude,
+		AccuracyM: accuracy,
+	})
+	if err != nil {
+		step.Err = err
+		return
+	}
+
+	step.HasAlert = result.HasAlert
+	for _, inc := range result.Incidents {
+		if key, ok := keys[inc.ID]; ok {
+			step.Incidents = append(step.Incidents, key)
+		}
+	}
+	slices.Sort(step.Incidents)
+	step.Incidents = slices.Compact(step.Incidents)
+}
+
+// Cleanup удаляет созданные сценарием группы и зоны. Настройки алертов пользователей остаются
+func (l *Loader) Cleanup(ctx context.Context, seeded *Seeded) error {
+	var errs []error
+	for key, id := range seeded.Groups {
+		if err := l.client.DeleteGroup(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete group %q (id=%d): %w", key, id, err))
+		}
+	}
+	for key, id := range seeded.Incidents {
+		if err := l.client.DeleteIncident(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete incident %q (id=%d): %w", key, id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func userPreferences(p *Preferences) client.UserPreferences {
+	prefs := client.UserPreferences{
+		MinSeverity:      client.Severity(p.MinSeverity),
+		Timezone:         p.Timezone,
+		MaxAlertsPerHour: p.MaxAlertsPerHour,
+	}
+	for _, ch := range p.Channels {
+		prefs.Channels = append(prefs.Channels, client.AlertChannel(ch))
+	}
+	for _, q := range p.QuietHours {
+		prefs.QuietHours = append(prefs.QuietHours, client.QuietHours{Start: q.Start, End: q.End, Days: q.Days})
+	}
+	return prefs
+}
+
+// sleep ждет d или отмены ctx; false — ctx отменен
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,218 @@
+// Package fixtures загружает декларативные YAML-сценарии — зоны, пользователей, группы и маршруты
+// движения — в запущенный geonotify-service через pkg/client. Маршруты проигрываются проверками
+// координат, а ожидания по точкам сравниваются с ответами сервиса: так собираются повторяемые
+// демо-стенды и сквозные тесты цепочки алертов.
+package fixtures
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Scenario — содержимое YAML-файла сценария
+type Scenario struct {
+	Name      string     `yaml:"name"`
+	Incidents []Incident `yaml:"incidents"`
+	Users     []User     `yaml:"users"`
+	Groups    []Group    `yaml:"groups"`
+	Tracks    []Track    `yaml:"tracks"`
+}
+
+// Incident — зона сценария. Key — имя зоны внутри сценария, на него ссылаются ожидания маршрутов.
+// Geometry — GeoJSON полигона или мультиполигона, заменяет круг после создания зоны, поэтому radius_m нужен и ей
+type Incident struct {
+	Key        string  `yaml:"key"`
+	Name       string  `yaml:"name"`
+	Descr      string  `yaml:"descr"`
+	Latitude   float64 `yaml:"latitude"`
+	Longitude  float64 `yaml:"longitude"`
+	Radius     float64 `yaml:"radius_m"`
+	Geometry   string  `yaml:"geometry"`
+	Severity   string  `yaml:"severity"`
+	State      string  `yaml:"state"`
+	Status     string  `yaml:"status"`
+	TTLMinutes int     `yaml:"ttl_minutes"`
+}
+
+// User — пользователь сценария; Preferences nil — настройки алертов не задаются
+type User struct {
+	ID          string       `yaml:"id"`
+	Preferences *Preferences `yaml:"preferences"`
+}
+
+type Preferences struct {
+	MinSeverity      string       `yaml:"min_severity"`
+	Timezone         string       `yaml:"timezone"`
+	Channels         []string     `yaml:"channels"`
+	MaxAlertsPerHour int          `yaml:"max_alerts_per_hour"`
+	QuietHours       []QuietHours `yaml:"quiet_hours"`
+}
+
+type QuietHours struct {
+	Start string   `yaml:"start"`
+	End   string   `yaml:"end"`
+	Days  []string `yaml:"days"`
+}
+
+// Group — группа пользователей; WebhookURL пустой — получатель group.alert не регистрируется
+type Group struct {
+	Key        string   `yaml:"key"`
+	Name       string   `yaml:"name"`
+	Descr      string   `yaml:"descr"`
+	Members    []string `yaml:"members"`
+	WebhookURL string   `yaml:"webhook_url"`
+}
+
+// Track — маршрут пользователя: точки проверяются по порядку с паузой IntervalMS
+type Track struct {
+	User       string  `yaml:"user"`
+	IntervalMS int     `yaml:"interval_ms"`
+	Points     []Point `yaml:"points"`
+}
+
+// Point — точка маршрута. Steps > 1 проигрывает отрезок от предыдущей точки равными шагами,
+// последний из которых — сама точка. Expect — ключи зон, в которых точка должна оказаться:
+// nil — ответ не проверяется, пустой список — алерта быть не должно
+type Point struct {
+	Latitude  float64   `yaml:"lat"`
+	Longitude float64   `yaml:"lng"`
+	AccuracyM float64   `yaml:"accuracy_m"`
+	Steps     int       `yaml:"steps"`
+	Expect    *[]string `yaml:"expect"`
+}
+
+// LoadFile читает сценарий из файла; "-" — из stdin
+func LoadFile(path string) (*Scenario, error) {
+	if path == "-" {
+		return Load(os.Stdin)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Load разбирает сценарий и проверяет его; неизвестные поля — ошибка, чтобы опечатки не терялись молча
+func Load(r io.Reader) (*Scenario, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	var s Scenario
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Validate проверяет сценарий целиком и возвращает список всех найденных проблем
+func (s *Scenario) Validate() error {
+	var problems []string
+	addProblem := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	incidents := make(map[string]bool, len(s.Incidents))
+	for i, inc := range s.Incidents {
+		switch {
+		case inc.Key == "":
+			addProblem("incidents[%d]: key is required", i)
+		case incidents[inc.Key]:
+			addProblem("incidents[%d]: duplicate key %q", i, inc.Key)
+		}
+		incidents[inc.Key] = true
+
+		if inc.Name == "" {
+			addProblem("incidents[%d]: name is required", i)
+		}
+		if !validPoint(inc.Latitude, inc.Longitude) {
+			addProblem("incidents[%d]: invalid coordinates", i)
+		}
+		if inc.Radius <= 0 {
+			addProblem("incidents[%d]: radius_m must be positive", i)
+		}
+	}
+
+	users := make(map[string]bool, len(s.Users))
+	for i, u := range s.Users {
+		switch {
+		case u.ID == "":
+			addProblem("users[%d]: id is required", i)
+		case users[u.ID]:
+			addProblem("users[%d]: duplicate id %q", i, u.ID)
+		}
+		users[u.ID] = true
+	}
+
+	groups := make(map[string]bool, len(s.Groups))
+	for i, g := range s.Groups {
+		switch {
+		case g.Key == "":
+			addProblem("groups[%d]: key is required", i)
+		case groups[g.Key]:
+			addProblem("groups[%d]: duplicate key %q", i, g.Key)
+		}
+		groups[g.Key] = true
+
+		if g.Name == "" {
+			addProblem("groups[%d]: name is required", i)
+		}
+		for _, member := range g.Members {
+			if !users[member] {
+				addProblem("groups[%d]: unknown member %q", i, member)
+			}
+		}
+	}
+
+	for i, t := range s.Tracks {
+		if !users[t.User] {
+			addProblem("tracks[%d]: unknown user %q", i, t.User)
+		}
+		if t.IntervalMS < 0 {
+			addProblem("tracks[%d]: interval_ms must not be negative", i)
+		}
+		if len(t.Points) == 0 {
+			addProblem("tracks[%d]: at least one point is required", i)
+		}
+
+		for j, p := range t.Points {
+			if !validPoint(p.Latitude, p.Longitude) {
+				addProblem("tracks[%d].points[%d]: invalid coordinates", i, j)
+			}
+			if p.Steps < 0 || (j == 0 && p.Steps > 1) {
+				addProblem("tracks[%d].points[%d]: steps must not be negative and are not allowed on the first point", i, j)
+			}
+			if p.Expect == nil {
+				continue
+			}
+			for _, key := range *p.Expect {
+				if !incidents[key] {
+					addProblem("tracks[%d].points[%d]: unknown incident %q in expect", i, j, key)
+				}
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid scenario:\n  - %s", strings.Join(problems, "\n  - "))
+}
+
+func validPoint(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
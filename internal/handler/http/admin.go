@@ -0,0 +1,282 @@
+package http
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/config"
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultDashboardLimit = 50
+	maxDashboardLimit     = 500
+)
+
+// QueryStats — накопленная статистика запросов к БД
+type QueryStats interface {
+	Snapshot() []postgres.QueryStat
+	Reset()
+}
+
+// WorkerLocks — распределенные блокировки периодических задач
+type WorkerLocks interface {
+	Snapshot() []postgres.LockStat
+}
+
+type AdminHandler struct {
+	logger   *zap.Logger
+	settings *config.Holder
+	stats    cases.StatsUseCase
+	queries  QueryStats
+	// locks nil — блокировки выключены
+	locks WorkerLocks
+}
+
+func NewAdminHandler(
+	logger *zap.Logger,
+	settings *config.Holder,
+	stats cases.StatsUseCase,
+	queries QueryStats,
+	locks WorkerLocks,
+) *AdminHandler {
+	return &AdminHandler{
+		logger:   logger,
+		settings: settings,
+		stats:    stats,
+		queries:  queries,
+		locks:    locks,
+	}
+}
+
+// ReloadConfig обрабатывает POST /api/v1/admin/config/reload
+// @Summary      Перечитать конфигурацию (оператор)
+// @ID           reloadConfig
+// @Description  Перечитывает конфигурацию без рестарта: уровень логирования, TTL кэша, политику ретраев вебхуков
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {object} dtoResp.RuntimeConfigResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      422 {object} respond.ErrorResponse
+// @Router       /api/v1/admin/config/reload [post]
+func (h *AdminHandler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	cfg, err := h.settings.Reload()
+	if err != nil {
+		h.logger.Error("config reload failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	h.logger.Info("config reloaded via admin endpoint")
+
+	respond.JSON(w, h.logger, http.StatusOK, runtimeConfigResponse(cfg))
+}
+
+// GetConfig обрабатывает GET /api/v1/admin/config
+// @Summary      Текущие значения перезагружаемых настроек (оператор)
+// @ID           getConfig
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {object} dtoResp.RuntimeConfigResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Router       /api/v1/admin/config [get]
+func (h *AdminHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	respond.JSON(w, h.logger, http.StatusOK, runtimeConfigResponse(h.settings.Get()))
+}
+
+// GetDashboard обрабатывает GET /api/v1/admin/dashboard
+// @Summary      Сводка для админки (оператор)
+// @ID           getDashboard
+// @Description  Активные зоны, глубина очереди вебхуков, последние проверки и неудачные доставки
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        limit  query     int  false  "Размер списков проверок и неудачных доставок (по умолчанию 50, максимум 500)"
+// @Success      200    {object}  dtoResp.DashboardResponse
+// @Failure      400    {object}  respond.ErrorResponse
+// @Failure      401    {object}  respond.ErrorResponse
+// @Failure      500    {object}  respond.ErrorResponse
+// @Router       /api/v1/admin/dashboard [get]
+func (h *AdminHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	limit := defaultDashboardLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > maxDashboardLimit {
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid limit parameter (must be between 1 and 500)")
+			return
+		}
+		limit = l
+	}
+
+	dashboard, err := h.stats.GetDashboard(r.Context(), limit)
+	if err != nil {
+		h.logger.Error("failed to build dashboard", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "failed to build dashboard")
+		return
+	}
+
+	now := time.Now()
+	response := dtoResp.DashboardResponse{
+		ActiveIncidents: make([]dtoResp.IncidentResponse, len(dashboard.ActiveIncidents)),
+		WebhookQueue: dtoResp.WebhookQueueResponse{
+			Pending:    dashboard.WebhookStates["in progress"],
+			Processing: dashboard.WebhookStates["processing"],
+			Failed:     dashboard.WebhookStates["failed"],
+		},
+		RecentChecks:   make([]dtoResp.CheckResponse, len(dashboard.RecentChecks)),
+		FailedWebhooks: make([]dtoResp.FailedWebhookResponse, len(dashboard.FailedWebhooks)),
+		GeneratedAt:    now,
+	}
+	for i, inc := range dashboard.ActiveIncidents {
+		response.ActiveIncidents[i] = toIncidentResponse(inc, now)
+	}
+	for i, check := range dashboard.RecentChecks {
+		response.RecentChecks[i] = dtoResp.CheckResponse{
+			CheckID:   check.ID,
+			UserID:    check.UserID,
+			Latitude:  check.Latitude,
+			Longitude: check.Longitude,
+			HasAlert:  check.HasAlert,
+			CreatedAt: check.CreatedAt,
+		}
+	}
+	for i, wh := range dashboard.FailedWebhooks {
+		response.FailedWebhooks[i] = dtoResp.FailedWebhookResponse{
+			WebhookID:  wh.ID,
+			EndpointID: wh.EndpointID,
+			Event:      wh.EventType,
+			CheckID:    wh.CheckID,
+			RetryCnt:   wh.RetryCnt,
+			CreatedAt:  wh.CreatedAt,
+			FailedAt:   wh.UpdatedAt,
+		}
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// GetQueryStats обрабатывает GET /api/v1/admin/query-stats
+// @Summary      Статистика запросов к БД (оператор)
+// @ID           getQueryStats
+// @Description  Число вызовов, ошибки и гистограмма длительности каждого запроса с момента запуска
+// @Description  или последнего сброса, начиная с запросов с наибольшим суммарным временем
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        limit  query     int  false  "Сколько запросов вернуть (по умолчанию 50, максимум 500)"
+// @Success      200    {object}  dtoResp.QueryStatsResponse
+// @Failure      400    {object}  respond.ErrorResponse
+// @Failure      401    {object}  respond.ErrorResponse
+// @Router       /api/v1/admin/query-stats [get]
+func (h *AdminHandler) GetQueryStats(w http.ResponseWriter, r *http.Request) {
+	limit := defaultDashboardLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > maxDashboardLimit {
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid limit parameter (must be between 1 and 500)")
+			return
+		}
+		limit = l
+	}
+
+	stats := h.queries.Snapshot()
+	if len(stats) > limit {
+		stats = stats[:limit]
+	}
+
+	response := dtoResp.QueryStatsResponse{
+		BucketsMs: postgres.QueryBucketsMs,
+		Queries:   make([]dtoResp.QueryStatResponse, len(stats)),
+	}
+	for i, s := range stats {
+		response.Queries[i] = dtoResp.QueryStatResponse{
+			SQL:     s.SQL,
+			Calls:   s.Calls,
+			Errors:  s.Errors,
+			TotalMs: durationMs(s.Total),
+			MeanMs:  durationMs(s.Total / time.Duration(s.Calls)),
+			MaxMs:   durationMs(s.Max),
+			Buckets: s.Buckets,
+		}
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// ResetQueryStats обрабатывает DELETE /api/v1/admin/query-stats
+// @Summary      Сбросить статистику запросов к БД (оператор)
+// @ID           resetQueryStats
+// @Tags         admin
+// @Security     ApiKeyAuth
+// @Success      204
+// @Failure      401  {object}  respond.ErrorResponse
+// @Router       /api/v1/admin/query-stats [delete]
+func (h *AdminHandler) ResetQueryStats(w http.ResponseWriter, r *http.Request) {
+	h.queries.Reset()
+
+	actor, _ := cases.ActorFromContext(r.Context())
+	h.logger.Info("query stats reset", zap.String("actor", actor.Name))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetWorkerLocks обрабатывает GET /api/v1/admin/locks
+// @Summary      Блокировки периодических задач (оператор)
+// @ID           getWorkerLocks
+// @Description  Какими блокировками периодических задач владеет реплика, обработавшая запрос, сколько раз она их
+// @Description  захватывала и теряла и сколько запусков задач выполнила или пропустила. Счетчики — с момента запуска реплики
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {object}  dtoResp.WorkerLocksResponse
+// @Failure      401  {object}  respond.ErrorResponse
+// @Router       /api/v1/admin/locks [get]
+func (h *AdminHandler) GetWorkerLocks(w http.ResponseWriter, r *http.Request) {
+	response := dtoResp.WorkerLocksResponse{
+		Enabled: h.locks != nil,
+		Locks:   []dtoResp.WorkerLockResponse{},
+	}
+	if h.locks != nil {
+		for _, s := range h.locks.Snapshot() {
+			lock := dtoResp.WorkerLockResponse{
+				Name:      s.Name,
+				Held:      s.Held,
+				Acquired:  s.Acquired,
+				Lost:      s.Lost,
+				Runs:      s.Runs,
+				Skipped:   s.Skipped,
+				Errors:    s.Errors,
+				LastError: s.LastError,
+			}
+			if s.Held {
+				lock.HeldSince = &s.HeldSince
+			}
+			response.Locks = append(response.Locks, lock)
+		}
+	}
+	response.Instance, _ = os.Hostname()
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func runtimeConfigResponse(cfg *config.Config) dtoResp.RuntimeConfigResponse {
+	return dtoResp.RuntimeConfigResponse{
+		LogLevel:          cfg.LogLevel,
+		CacheTTLMinutes:   cfg.CacheTTLMinutes,
+		MaxRetries:        cfg.MaxRetries,
+		RetryDelaySeconds: cfg.RetryDelaySeconds,
+
+		PredictionHorizonSeconds: cfg.PredictionHorizonSeconds,
+	}
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+const alertHeartbeatInterval = 15 * time.Second
+
+type AlertHandler struct {
+	logger    *zap.Logger
+	uc        cases.AlertUseCase
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+func NewAlertHandler(logger *zap.Logger, uc cases.AlertUseCase) *AlertHandler {
+	return &AlertHandler{
+		logger:  logger,
+		uc:      uc,
+		closing: make(chan struct{}),
+	}
+}
+
+// Close завершает открытые потоки, иначе http.Server.Shutdown ждал бы их до таймаута
+func (h *AlertHandler) Close() {
+	h.closeOnce.Do(func() { close(h.closing) })
+}
+
+// Stream обрабатывает GET /api/v1/users/{user_id}/alerts/stream
+// @Summary      Поток алертов пользователя
+// @ID           streamAlerts
+// @Description  Server-Sent Events: событие alert приходит, когда проверка пользователя попала в зону или рядом с его последней точкой создана новая зона
+// @Tags         alerts
+// @Produce      text/event-stream
+// @Param        user_id path string true "ID пользователя"
+// @Success      200 {object} dtoResp.AlertEvent
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Failure      503 {object} respond.ErrorResponse
+// @Router       /api/v1/users/{user_id}/alerts/stream [get]
+func (h *AlertHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "user_id")
+	if userID == "" {
+		respond.Error(w, h.logger, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	ctx := r.Context()
+	alerts, unsubscribe, err := h.uc.Subscribe(ctx, userID)
+	if errors.Is(err, entity.ErrDependencyUnavailable) {
+		respond.Error(w, h.logger, http.StatusServiceUnavailable, "alert stream is temporarily unavailable")
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to subscribe to alerts",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "failed to subscribe to alerts")
+		return
+	}
+	defer unsubscribe()
+
+	rc := http.NewResponseController(w)
+	// поток живет дольше HTTP_WRITE_TIMEOUT_SECONDS, поэтому дедлайн записи сервера для него снимается
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Warn("failed to clear write deadline for alert stream", zap.Error(err))
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprint(w, ": connected\n\n"); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		h.logger.Error("streaming is not supported by response writer", zap.Error(err))
+		return
+	}
+
+	heartbeat := time.NewTicker(alertHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.closing:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case alert, ok := <-alerts:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(toAlertEvent(alert))
+			if err != nil {
+				h.logger.Error("failed to encode alert", zap.Error(err))
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: alert\ndata: %s\n\n", data); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func toAlertEvent(alert entity.Alert) dtoResp.AlertEvent {
+	incidents := make([]dtoResp.IncidentResponse, 0, len(alert.Incidents))
+	for _, inc := range alert.Incidents {
+		incidents = append(incidents, toIncidentResponse(inc, alert.CreatedAt))
+	}
+
+	return dtoResp.AlertEvent{
+		Type:      alert.Type,
+		UserID:    alert.UserID,
+		CheckID:   alert.CheckID,
+		Incidents: incidents,
+		CreatedAt: alert.CreatedAt,
+	}
+}
@@ -0,0 +1,197 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultApprovalsLimit = 50
+	maxApprovalsLimit     = 500
+)
+
+type ApprovalHandler struct {
+	logger *zap.Logger
+	uc     cases.ApprovalUseCase
+}
+
+func NewApprovalHandler(logger *zap.Logger, uc cases.ApprovalUseCase) *ApprovalHandler {
+	return &ApprovalHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// ApprovalList обрабатывает GET /api/v1/approvals
+// @Summary      Заявки на публикацию критических зон (оператор)
+// @ID           listApprovals
+// @Description  Заявки от новых к старым. Без status возвращаются заявки во всех статусах
+// @Tags         approvals
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        status  query     string  false  "Фильтр по статусу" Enums(pending, approved, rejected)
+// @Param        limit   query     int     false  "Размер списка (по умолчанию 50, максимум 500)"
+// @Success      200     {object}  dtoResp.ApprovalsListResponse
+// @Failure      400     {object}  respond.ErrorResponse
+// @Failure      401     {object}  respond.ErrorResponse
+// @Failure      500     {object}  respond.ErrorResponse
+// @Router       /api/v1/approvals [get]
+func (h *ApprovalHandler) ApprovalList(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", entity.ApprovalPending, entity.ApprovalApproved, entity.ApprovalRejected:
+	default:
+		respond.Error(w, h.logger, http.StatusBadRequest, "invalid status parameter (must be pending, approved or rejected)")
+		return
+	}
+
+	limit := defaultApprovalsLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > maxApprovalsLimit {
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid limit parameter (must be between 1 and 500)")
+			return
+		}
+		limit = l
+	}
+
+	approvals, err := h.uc.ListApprovals(r.Context(), status, limit)
+	if err != nil {
+		h.logger.Error("approval list failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	response := dtoResp.ApprovalsListResponse{
+		Approvals: make([]dtoResp.ApprovalResponse, len(approvals)),
+	}
+	for i, a := range approvals {
+		response.Approvals[i] = toApprovalResponse(a)
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// ApprovalApprove обрабатывает POST /api/v1/approvals/{approval_id}/approve
+// @Summary      Одобрить публикацию критической зоны (публикатор)
+// @ID           approveIncident
+// @Description  Публикует зону по заявке. Одобрить может только публикатор, который не подавал заявку
+// @Tags         approvals
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        approval_id  path      int  true  "ID заявки"
+// @Success      200          {object}  dtoResp.ApprovalResponse
+// @Failure      400          {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401          {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403          {object}  respond.ErrorResponse  "Недостаточно прав или одобрение собственной заявки"
+// @Failure      404          {object}  respond.ErrorResponse  "Заявка или зона не найдена"
+// @Failure      409          {object}  respond.ErrorResponse  "Заявка уже рассмотрена"
+// @Failure      500          {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/approvals/{approval_id}/approve [post]
+func (h *ApprovalHandler) ApprovalApprove(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "approval_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	approval, err := h.uc.Approve(r.Context(), id)
+	if err != nil {
+		h.logger.Error("approval approve failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithDecisionError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toApprovalResponse(approval))
+}
+
+// ApprovalReject обрабатывает POST /api/v1/approvals/{approval_id}/reject
+// @Summary      Отклонить публикацию критической зоны (публикатор)
+// @ID           rejectIncident
+// @Description  Закрывает заявку, зона остается неопубликованной. Автор заявки может отозвать ее сам
+// @Tags         approvals
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        approval_id  path      int                           true   "ID заявки"
+// @Param        request      body      dtoReq.ApprovalRejectRequest  false  "Причина отказа"
+// @Success      200          {object}  dtoResp.ApprovalResponse
+// @Failure      400          {object}  respond.ErrorResponse  "Неверный ID или причина"
+// @Failure      401          {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403          {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404          {object}  respond.ErrorResponse  "Заявка не найдена"
+// @Failure      409          {object}  respond.ErrorResponse  "Заявка уже рассмотрена"
+// @Failure      500          {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/approvals/{approval_id}/reject [post]
+func (h *ApprovalHandler) ApprovalReject(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "approval_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	var req dtoReq.ApprovalRejectRequest
+	// тело необязательно: причину отказа можно не указывать
+	if r.ContentLength != 0 {
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Invalid(w, h.logger, err)
+			return
+		}
+	}
+
+	approval, err := h.uc.Reject(r.Context(), id, req.Reason)
+	if err != nil {
+		h.logger.Error("approval reject failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithDecisionError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toApprovalResponse(approval))
+}
+
+// respondWithDecisionError отвечает на ошибки решения по заявке
+func (h *ApprovalHandler) respondWithDecisionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, entity.ErrApprovalNotFound),
+		errors.Is(err, entity.ErrIncidentNotFound):
+		respond.Error(w, h.logger, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrForbidden),
+		errors.Is(err, entity.ErrSelfApproval):
+		respond.Error(w, h.logger, http.StatusForbidden, err.Error())
+	case errors.Is(err, entity.ErrApprovalDecided),
+		errors.Is(err, entity.ErrInvalidStatusTransition):
+		respond.Error(w, h.logger, http.StatusConflict, err.Error())
+	default:
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+	}
+}
+
+func toApprovalResponse(a *entity.Approval) dtoResp.ApprovalResponse {
+	return dtoResp.ApprovalResponse{
+		ApprovalID:  a.ID,
+		IncidentID:  a.IncidentID,
+		Status:      a.Status,
+		Severity:    a.Severity,
+		RequestedBy: a.RequestedBy,
+		RequestedAt: a.RequestedAt,
+		DecidedBy:   a.DecidedBy,
+		DecidedAt:   a.DecidedAt,
+		Reason:      a.Reason,
+	}
+}
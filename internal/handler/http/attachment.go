@@ -0,0 +1,179 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// запас на заголовки multipart сверх размера самого файла
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	logger  *zap.Logger
+	uc      cases.AttachmentUseCase
+	maxSize int64
+}
+
+func NewAttachmentHandler(logger *zap.Logger, uc cases.AttachmentUseCase, maxSizeBytes int64) *AttachmentHandler {
+	return &AttachmentHandler{
+		logger:  logger,
+		uc:      uc,
+		maxSize: maxSizeBytes,
+	}
+}
+
+// AttachmentUpload обрабатывает POST /api/v1/incidents/{incident_id}/attachments
+// @Summary      Прикрепить файл к инциденту (оператор)
+// @ID           uploadAttachment
+// @Description  Загружает изображение или PDF (карта обстановки, официальное уведомление) в объектное хранилище
+// @Tags         attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id  path      int   true  "ID инцидента"
+// @Param        file         formData  file  true  "Изображение (png, jpeg, gif, webp) или PDF"
+// @Success      201 {object} dtoResp.AttachmentResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      404 {object} respond.ErrorResponse
+// @Failure      413 {object} respond.ErrorResponse
+// @Failure      415 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v1/incidents/{incident_id}/attachments [post]
+func (h *AttachmentHandler) AttachmentUpload(w http.ResponseWriter, r *http.Request) {
+	incidentID, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	SetBodyLimit(w, r, h.maxSize+multipartOverhead)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respond.Error(w, h.logger, http.StatusRequestEntityTooLarge, entity.ErrAttachmentTooLarge.Error())
+			return
+		}
+		respond.Error(w, h.logger, http.StatusBadRequest, "multipart field \"file\" is required")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.uc.UploadAttachment(r.Context(), incidentID, header.Filename, file, header.Size)
+	if err != nil {
+		h.handleError(w, err, incidentID)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusCreated, toAttachmentResponse(result))
+}
+
+// AttachmentList обрабатывает GET /api/v1/incidents/{incident_id}/attachments
+// @Summary      Список вложений инцидента (оператор)
+// @ID           listAttachments
+// @Description  Метаданные вложений и временные ссылки на скачивание
+// @Tags         attachments
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id  path  int  true  "ID инцидента"
+// @Success      200 {object} dtoResp.AttachmentsListResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      404 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v1/incidents/{incident_id}/attachments [get]
+func (h *AttachmentHandler) AttachmentList(w http.ResponseWriter, r *http.Request) {
+	incidentID, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	result, err := h.uc.ListAttachments(r.Context(), incidentID)
+	if err != nil {
+		h.handleError(w, err, incidentID)
+		return
+	}
+
+	attachments := make([]dtoResp.AttachmentResponse, len(result))
+	for i := range result {
+		attachments[i] = toAttachmentResponse(&result[i])
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, dtoResp.AttachmentsListResponse{
+		Attachments: attachments,
+	})
+}
+
+// AttachmentDelete обрабатывает DELETE /api/v1/incidents/{incident_id}/attachments/{attachment_id}
+// @Summary      Удалить вложение (оператор)
+// @ID           deleteAttachment
+// @Description  Удаляет метаданные вложения и сам файл из хранилища
+// @Tags         attachments
+// @Security     ApiKeyAuth
+// @Param        incident_id    path  int  true  "ID инцидента"
+// @Param        attachment_id  path  int  true  "ID вложения"
+// @Success      204
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      404 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v1/incidents/{incident_id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandler) AttachmentDelete(w http.ResponseWriter, r *http.Request) {
+	incidentID, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	attachmentID, err := strconv.Atoi(chi.URLParam(r, "attachment_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "attachment id required/not valid")
+		return
+	}
+
+	if err := h.uc.DeleteAttachment(r.Context(), incidentID, attachmentID); err != nil {
+		h.handleError(w, err, incidentID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AttachmentHandler) handleError(w http.ResponseWriter, err error, incidentID int) {
+	switch {
+	case errors.Is(err, entity.ErrIncidentNotFound), errors.Is(err, entity.ErrAttachmentNotFound):
+		respond.Error(w, h.logger, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrAttachmentTooLarge):
+		respond.Error(w, h.logger, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, entity.ErrUnsupportedAttachmentType):
+		respond.Error(w, h.logger, http.StatusUnsupportedMediaType, err.Error())
+	default:
+		h.logger.Error("attachment request failed",
+			zap.Error(err),
+			zap.Int("incident_id", incidentID))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func toAttachmentResponse(item *cases.AttachmentWithURL) dtoResp.AttachmentResponse {
+	return dtoResp.AttachmentResponse{
+		AttachmentID: item.Attachment.ID,
+		IncidentID:   item.Attachment.IncidentID,
+		FileName:     item.Attachment.FileName,
+		ContentType:  item.Attachment.ContentType,
+		SizeBytes:    item.Attachment.Size,
+		URL:          item.URL,
+		CreatedAt:    item.Attachment.CreatedAt,
+	}
+}
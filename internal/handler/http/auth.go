@@ -0,0 +1,107 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"go.uber.org/zap"
+)
+
+type AuthHandler struct {
+	logger *zap.Logger
+	uc     cases.AuthUseCase
+}
+
+func NewAuthHandler(logger *zap.Logger, uc cases.AuthUseCase) *AuthHandler {
+	return &AuthHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// Login обрабатывает POST /api/v1/auth/login
+// @Summary      Вход оператора
+// @ID           login
+// @Description  Проверяет логин и пароль оператора и выдает короткоживущий JWT. Токен передается в заголовке Authorization: Bearer вместо API-ключа
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body dtoReq.LoginRequest true "Учетные данные оператора"
+// @Success      200 {object} dtoResp.LoginResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v1/auth/login [post]
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req dtoReq.LoginRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	token, err := h.uc.Login(r.Context(), req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCredentials) {
+			h.logger.Warn("operator login failed", zap.String("username", req.Username))
+			respond.Error(w, h.logger, http.StatusUnauthorized, err.Error())
+			return
+		}
+		h.logger.Error("operator login failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, dtoResp.LoginResponse{
+		AccessToken: token.Token,
+		TokenType:   "Bearer",
+		ExpiresAt:   token.ExpiresAt.UTC(),
+	})
+}
+
+// OperatorCreate обрабатывает POST /api/v1/admin/operators
+// @Summary      Создать учетную запись оператора (оператор)
+// @ID           createOperator
+// @Description  Заводит оператора с паролем для входа через /api/v1/auth/login и/или с субъектом OIDC. Роль по умолчанию editor; заводить операторов может только публикатор
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body dtoReq.OperatorCreateRequest true "Данные оператора"
+// @Success      201 {object} dtoResp.OperatorCreateResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      403 {object} respond.ErrorResponse
+// @Failure      409 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v1/admin/operators [post]
+func (h *AuthHandler) OperatorCreate(w http.ResponseWriter, r *http.Request) {
+	var req dtoReq.OperatorCreateRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	operatorID, err := h.uc.CreateOperator(r.Context(), req.Username, req.Password, req.OIDCSubject, req.Role)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidOperator):
+			respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrOperatorExists):
+			respond.Error(w, h.logger, http.StatusConflict, err.Error())
+		case errors.Is(err, entity.ErrForbidden):
+			respond.Error(w, h.logger, http.StatusForbidden, err.Error())
+		default:
+			h.logger.Error("failed to create operator", zap.Error(err))
+			respond.Error(w, h.logger, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusCreated, dtoResp.OperatorCreateResponse{ID: operatorID})
+}
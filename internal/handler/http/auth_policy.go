@@ -0,0 +1,369 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Политики доступа к маршрутам
+const (
+	PolicyPublic = "public"
+	PolicyAPIKey = "api-key"
+	PolicyJWT    = "jwt"
+	PolicyEither = "either"
+)
+
+// unmatchedAuthPolicy — политика маршрута без правила: новый маршрут закрыт, пока его явно не откроют
+const unmatchedAuthPolicy = PolicyEither
+
+// DefaultAuthPolicies — политики по умолчанию; ключ — "[МЕТОД ]префикс пути", сегмент * совпадает с любым сегментом.
+// Публичные маршруты перечислены явно, остальные получают unmatchedAuthPolicy
+var DefaultAuthPolicies = map[string]string{
+	"/api/v1/location":             PolicyPublic,
+	"/api/v2/location":             PolicyPublic,
+	"/api/v1/users/*/alerts":       PolicyPublic,
+	"/api/v1/users/*/preferences":  PolicyPublic,
+	"/api/v1/auth/login":           PolicyPublic,
+	"/feeds":                       PolicyPublic,
+	"/healthz":                     PolicyPublic,
+	"/readyz":                      PolicyPublic,
+	"/metrics":                     PolicyEither,
+	"/swagger":                     PolicyPublic,
+	"/openapi.json":                PolicyPublic,
+	"/admin":                       PolicyPublic,
+	"/api/v1/incidents":            PolicyEither,
+	"/api/v1/incidents/stats":      PolicyPublic,
+	"/api/v1/stats":                PolicyEither,
+	"/api/v1/public":               PolicyPublic,
+	"/api/v2/location/check/batch": PolicyEither,
+	"/api/v1/webhooks":             PolicyEither,
+	"/api/v1/webhook-endpoints":    PolicyEither,
+	"/api/v1/approvals":            PolicyEither,
+	"/api/v1/erasures":             PolicyEither,
+	"DELETE /api/v1/users":         PolicyEither,
+	"/api/v1/users/*/data-export":  PolicyEither,
+	"/api/v1/groups":               PolicyEither,
+	"/api/v1/admin":                PolicyEither,
+	"/api/v1/system":               PolicyEither,
+}
+
+// OperatorQueryParams — параметры запроса, с которыми публичный маршрут требует аутентификации оператора (политика either):
+// at на проверке координат раскрывает прошлое состояние зон, в том числе снятых и удаленных
+var OperatorQueryParams = []string{"at"}
+
+type authRule struct {
+	method string
+	prefix string
+	policy string
+}
+
+// AuthMiddleware применяет политику доступа маршрута: маршрут без правила требует unmatchedAuthPolicy.
+// Для непубличных маршрутов дополнительно проверяется IP клиента по allowlist
+type AuthMiddleware struct {
+	logger *zap.Logger
+	auth   cases.AuthUseCase
+	// apiKeys — API-ключи по значению
+	apiKeys map[string]apiKey
+	usage   cases.UsageUseCase
+	rules   []authRule
+	allow   []netip.Prefix
+	trusted []netip.Prefix
+}
+
+type apiKey struct {
+	name string
+	// id — отпечаток ключа для логов, сам ключ в лог не попадает
+	id string
+}
+
+// NewAuthMiddleware: apiKeys — значения API-ключей по имени, SECRET_API_KEY — под entity.DefaultAPIKey;
+// usage nil — запросы ключей не учитываются и квоты не проверяются. policies дополняют и переопределяют
+// DefaultAuthPolicies; пустой ipAllowlist — без ограничения по IP; trustedProxies — адреса прокси, которым
+// доверяется X-Forwarded-For
+func NewAuthMiddleware(logger *zap.Logger, auth cases.AuthUseCase, apiKeys map[string]string, usage cases.UsageUseCase,
+	policies map[string]string, ipAllowlist, trustedProxies []string) (*AuthMiddleware, error) {
+	merged := make(map[string]string, len(DefaultAuthPolicies)+len(policies))
+	for route, policy := range DefaultAuthPolicies {
+		merged[route] = policy
+	}
+	for route, policy := range policies {
+		merged[route] = policy
+	}
+
+	rules := make([]authRule, 0, len(merged))
+	for route, policy := range merged {
+		rule, err := parseAuthRule(route, policy)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	// самый длинный префикс выигрывает, правило с методом — раньше правила без него
+	sort.Slice(rules, func(i, j int) bool {
+		if len(rules[i].prefix) != len(rules[j].prefix) {
+			return len(rules[i].prefix) > len(rules[j].prefix)
+		}
+		return rules[i].method > rules[j].method
+	})
+
+	allow, err := parsePrefixes(ipAllowlist)
+	if err != nil {
+		return nil, fmt.Errorf("invalid operator ip allowlist: %w", err)
+	}
+	trusted, err := parsePrefixes(trustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	keys := make(map[string]apiKey, len(apiKeys))
+	for name, key := range apiKeys {
+		if key == "" {
+			continue
+		}
+		sum := sha256.Sum256([]byte(key))
+		keys[key] = apiKey{name: name, id: hex.EncodeToString(sum[:4])}
+	}
+
+	return &AuthMiddleware{
+		logger:  logger,
+		auth:    auth,
+		apiKeys: keys,
+		usage:   usage,
+		rules:   rules,
+		allow:   allow,
+		trusted: trusted,
+	}, nil
+}
+
+// parseAuthRule разбирает правило "[МЕТОД ]префикс" = политика
+func parseAuthRule(route, policy string) (authRule, error) {
+	switch policy {
+	case PolicyPublic, PolicyAPIKey, PolicyJWT, PolicyEither:
+	default:
+		return authRule{}, fmt.Errorf("route %q: unknown auth policy %q, expected public, api-key, jwt or either", route, policy)
+	}
+
+	method, prefix, ok := strings.Cut(strings.TrimSpace(route), " ")
+	if !ok {
+		method, prefix = "", method
+	}
+	prefix = strings.TrimSpace(prefix)
+	if !strings.HasPrefix(prefix, "/") {
+		return authRule{}, fmt.Errorf("route %q: path prefix must start with /", route)
+	}
+
+	return authRule{
+		method: strings.ToUpper(method),
+		prefix: strings.TrimSuffix(prefix, "/"),
+		policy: policy,
+	}, nil
+}
+
+// parsePrefixes разбирает список IP-адресов и подсетей в нотации CIDR
+func parsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if strings.Contains(v, "/") {
+			p, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, p.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+func (m *AuthMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy := m.policy(r)
+		if policy == PolicyPublic {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(m.allow) > 0 {
+			ip, ok := m.ClientIP(r)
+			if !ok || !containsAddr(m.allow, ip) {
+				m.logger.Warn("operator request from address outside allowlist",
+					zap.String("remote_addr", r.RemoteAddr),
+					zap.String("path", r.URL.Path))
+				respond.Error(w, m.logger, http.StatusForbidden, "client address is not allowed")
+				return
+			}
+		}
+
+		authHeader := r.Header.Get("Authorization")
+
+		if authHeader == "" {
+			respond.Error(w, m.logger, http.StatusUnauthorized, "authorization header is required")
+			return
+		}
+
+		const bearerPrefix = "Bearer "
+		if len(authHeader) <= len(bearerPrefix) || authHeader[:len(bearerPrefix)] != bearerPrefix {
+			respond.Error(w, m.logger, http.StatusUnauthorized, "authorization header must be in 'Bearer {token}' format")
+			return
+		}
+
+		token := authHeader[len(bearerPrefix):]
+		if key, ok := m.apiKeys[token]; ok && policy != PolicyJWT {
+			logger.AddAccessFields(r.Context(), zap.String("api_key_id", key.id), zap.String("api_key", key.name))
+			if m.usage != nil {
+				retryAt, err := m.usage.Record(r.Context(), key.name)
+				if errors.Is(err, entity.ErrQuotaExceeded) {
+					w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(retryAt).Seconds()))))
+					respond.Error(w, m.logger, http.StatusTooManyRequests, "API key quota exceeded")
+					return
+				}
+			}
+
+			actor := entity.Actor{Name: entity.ActorAPIKey, Role: entity.RolePublisher, APIKey: key.name}
+			if key.name != entity.DefaultAPIKey {
+				actor.Name += ":" + key.name
+			}
+			next.ServeHTTP(w, r.WithContext(cases.WithActor(r.Context(), actor)))
+			return
+		}
+		if policy == PolicyAPIKey {
+			respond.Error(w, m.logger, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+
+		actor, err := m.auth.Authenticate(r.Context(), token)
+		if errors.Is(err, entity.ErrInvalidToken) {
+			m.logger.Debug("operator token rejected", zap.Error(err))
+			if policy == PolicyJWT {
+				respond.Error(w, m.logger, http.StatusUnauthorized, "invalid token")
+			} else {
+				respond.Error(w, m.logger, http.StatusUnauthorized, "invalid API key or token")
+			}
+			return
+		}
+		if err != nil {
+			m.logger.Error("failed to authenticate operator", zap.Error(err))
+			respond.Error(w, m.logger, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		logger.AddAccessFields(r.Context(), zap.String("actor", actor.Name))
+		next.ServeHTTP(w, r.WithContext(cases.WithActor(r.Context(), actor)))
+	})
+}
+
+func (m *AuthMiddleware) policy(r *http.Request) string {
+	policy := unmatchedAuthPolicy
+	path := r.URL.Path
+	for _, rule := range m.rules {
+		if rule.method != "" && rule.method != r.Method {
+			continue
+		}
+		if matchPrefix(path, rule.prefix) {
+			policy = rule.policy
+			break
+		}
+	}
+
+	if policy == PolicyPublic {
+		query := r.URL.Query()
+		for _, param := range OperatorQueryParams {
+			if query.Has(param) {
+				return PolicyEither
+			}
+		}
+	}
+	return policy
+}
+
+// matchPrefix сообщает, начинается ли путь с сегментов префикса; сегмент * совпадает с любым непустым сегментом
+func matchPrefix(path, prefix string) bool {
+	if !strings.Contains(prefix, "*") {
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+
+	pathSegments := strings.Split(path, "/")
+	prefixSegments := strings.Split(prefix, "/")
+	if len(pathSegments) < len(prefixSegments) {
+		return false
+	}
+	for i, segment := range prefixSegments {
+		if segment == "*" && pathSegments[i] != "" {
+			continue
+		}
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ClientIP берет адрес из X-Forwarded-For, только если соединение пришло от доверенного прокси:
+// справа налево пропускаются доверенные прокси, первый недоверенный адрес — клиент
+func (m *AuthMiddleware) ClientIP(r *http.Request) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	ip = ip.Unmap()
+
+	if !containsAddr(m.trusted, ip) {
+		return ip, true
+	}
+
+	forwarded := r.Header.Values("X-Forwarded-For")
+	if len(forwarded) == 0 {
+		return ip, true
+	}
+
+	hops := strings.Split(strings.Join(forwarded, ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		ip = hop.Unmap()
+		if !containsAddr(m.trusted, ip) {
+			break
+		}
+	}
+	return ip, true
+}
+
+func containsAddr(prefixes []netip.Prefix, ip netip.Addr) bool {
+	for _, p := range prefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
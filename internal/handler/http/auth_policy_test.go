@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestAuthPolicy(t *testing.T) {
+	m, err := NewAuthMiddleware(zap.NewNop(), nil, nil, nil,
+		map[string]string{"GET /api/v1/experimental": PolicyPublic}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method string
+		target string
+		want   string
+	}{
+		{method: http.MethodPost, target: "/api/v2/location/check", want: PolicyPublic},
+		{method: http.MethodPost, target: "/api/v2/location/check/batch", want: PolicyEither},
+		{method: http.MethodPost, target: "/api/v1/location/check?at=2026-01-01T00:00:00Z", want: PolicyEither},
+		{method: http.MethodGet, target: "/api/v1/users/u1/preferences", want: PolicyPublic},
+		{method: http.MethodDelete, target: "/api/v1/users/u1/data", want: PolicyEither},
+		{method: http.MethodGet, target: "/api/v1/incidents/stats", want: PolicyPublic},
+		{method: http.MethodGet, target: "/api/v1/incidents/7", want: PolicyEither},
+		{method: http.MethodGet, target: "/healthz", want: PolicyPublic},
+		{method: http.MethodGet, target: "/admin/app.js", want: PolicyPublic},
+		{method: http.MethodGet, target: "/api/v1/admin/locks", want: PolicyEither},
+		// маршрут без правила закрыт
+		{method: http.MethodGet, target: "/api/v1/reports", want: unmatchedAuthPolicy},
+		{method: http.MethodGet, target: "/healthz-debug", want: unmatchedAuthPolicy},
+		{method: http.MethodGet, target: "/api/v1/experimental", want: PolicyPublic},
+		{method: http.MethodPost, target: "/api/v1/experimental", want: unmatchedAuthPolicy},
+	}
+
+	for _, tt := range tests {
+		if got := m.policy(httptest.NewRequest(tt.method, tt.target, nil)); got != tt.want {
+			t.Errorf("policy(%s %s) = %q, want %q", tt.method, tt.target, got, tt.want)
+		}
+	}
+}
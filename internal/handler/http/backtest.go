@@ -0,0 +1,80 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// defaultBacktestHours — период прогона, если hours не передан
+const defaultBacktestHours = 24
+
+// @Summary      Прогнать недавние проверки через зону (оператор)
+// @ID           backtestIncident
+// @Description  Считает, сколько пользователей получили бы алерт, если бы зона действовала последние hours часов:
+// @Description  проверки координат за период прогоняются через ее форму. Подходит для черновиков и измененных зон,
+// @Description  radius_m подменяет радиус круглой зоны, чтобы подобрать размер до сохранения. Стадия, расписание
+// @Description  и срок действия зоны не учитываются. users_newly_alerted — пользователи, которые в зоне не получили
+// @Description  алертов от действовавших тогда зон. За один запрос учитывается не больше 100 000 проверок (truncated)
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int                             true  "ID инцидента"
+// @Param        request        body      dtoReq.IncidentBacktestRequest  true  "Период и радиус"
+// @Success      200            {object}  dtoResp.IncidentBacktestResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный формат данных или радиус для полигональной зоны"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/backtest [post]
+func (h *IncidentHandler) IncidentBacktest(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	var req dtoReq.IncidentBacktestRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	query := cases.BacktestQuery{IncidentID: id, Hours: req.Hours}
+	if query.Hours == 0 {
+		query.Hours = defaultBacktestHours
+	}
+	if req.Radius != nil {
+		query.Radius = *req.Radius
+	}
+
+	result, err := h.uc.BacktestIncident(r.Context(), query)
+	if err != nil {
+		h.logger.Error("incident backtest failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, dtoResp.IncidentBacktestResponse{
+		IncidentID:        result.IncidentID,
+		Radius:            result.Radius,
+		From:              result.From,
+		To:                result.To,
+		ChecksScanned:     result.ChecksScanned,
+		ChecksMatched:     result.ChecksMatched,
+		UsersAlerted:      result.UsersAlerted,
+		UsersNewlyAlerted: result.UsersNewlyAlerted,
+		Truncated:         result.Truncated,
+	})
+}
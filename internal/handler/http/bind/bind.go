@@ -0,0 +1,174 @@
+// Package bind разбирает JSON-тела запросов и проверяет их по тегам validate у DTO.
+// Ошибки проверки возвращаются по полям, с именами из json-тегов
+package bind
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrInvalidJSON  = errors.New("invalid JSON format")
+	ErrBodyTooLarge = errors.New("request body is too large")
+)
+
+// FieldError — нарушенное правило одного поля запроса
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Error — все нарушения в запросе
+type Error struct {
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Field + " " + f.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(jsonName)
+	return v
+}
+
+// Decode читает JSON-тело запроса в dst без проверки: нужен, когда значения
+// приводятся к общему виду (например, координаты к WGS84) до Struct
+func Decode(r *http.Request, dst any) error {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return ErrBodyTooLarge
+		}
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	return nil
+}
+
+// JSON читает тело запроса в dst и проверяет его
+func JSON(r *http.Request, dst any) error {
+	if err := Decode(r, dst); err != nil {
+		return err
+	}
+	return Struct(dst)
+}
+
+// Struct проверяет структуру по тегам validate и возвращает *Error с нарушениями
+func Struct(v any) error {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	fields := make([]FieldError, len(verrs))
+	for i, fe := range verrs {
+		fields[i] = FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: message(t, fe),
+		}
+	}
+	return &Error{Fields: fields}
+}
+
+// fieldPath — путь поля без имени корневой структуры: "ids[2]", "name"
+func fieldPath(fe validator.FieldError) string {
+	_, path, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return path
+}
+
+func message(root reflect.Type, fe validator.FieldError) string {
+	param := fe.Param()
+	kind := fe.Kind()
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return fmt.Sprintf("is required when %s is not set", siblingName(root, param))
+	case "excluded_with":
+		return fmt.Sprintf("must not be set together with %s", siblingName(root, param))
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(param, " ", ", "))
+	case "gt":
+		return "must be > " + param
+	case "gte":
+		return "must be >= " + param
+	case "lt":
+		return "must be < " + param
+	case "lte":
+		return "must be <= " + param
+	case "min", "max":
+		bound := "at least"
+		if fe.Tag() == "max" {
+			bound = "at most"
+		}
+		switch kind {
+		case reflect.String:
+			if fe.Tag() == "min" && param == "1" {
+				return "must not be empty"
+			}
+			return fmt.Sprintf("must be %s %s characters long", bound, param)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("must contain %s %s items", bound, param)
+		default:
+			if fe.Tag() == "max" {
+				return "must be <= " + param
+			}
+			return "must be >= " + param
+		}
+	case "url", "http_url":
+		return "must be a valid URL"
+	default:
+		return fmt.Sprintf("failed %q validation", fe.Tag())
+	}
+}
+
+// siblingName переводит имя поля Go из параметра правила в имя из json-тега
+func siblingName(root reflect.Type, name string) string {
+	if root.Kind() != reflect.Struct {
+		return name
+	}
+	if f, ok := root.FieldByName(name); ok {
+		return jsonName(f)
+	}
+	return name
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	}
+	return name
+}
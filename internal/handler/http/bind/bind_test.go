@@ -0,0 +1,242 @@
+package bind_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/pkg/crs"
+	"go.uber.org/zap"
+)
+
+type item struct {
+	ID int `json:"id" validate:"gt=0"`
+}
+
+type request struct {
+	Name     string   `json:"name" validate:"max=5"`
+	Code     string   `json:"code" validate:"omitempty,min=3"`
+	Title    string   `json:"title" validate:"min=1"`
+	Tags     []string `json:"tags" validate:"min=1,max=2"`
+	Count    int      `json:"count" validate:"min=1,max=10"`
+	Address  string   `json:"address" validate:"required_without=Latitude"`
+	Latitude float64  `json:"lat"`
+	Radius   float64  `json:"radius_m" validate:"excluded_with=Polygons"`
+	Polygons []string `json:"polygons"`
+	Severity string   `json:"severity" validate:"omitempty,oneof=low medium high"`
+	Items    []item   `json:"items" validate:"dive"`
+	IDs      []int    `json:"ids" validate:"dive,gt=0"`
+}
+
+// valid — тело без нарушений, тесты портят в нем одно поле
+const valid = `{"name":"fire","title":"t","tags":["a"],"count":1,"lat":55.7}`
+
+func newRequest(body string) *http.Request {
+	return httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+}
+
+// patch подменяет поля valid
+func patch(t *testing.T, fields map[string]any) string {
+	t.Helper()
+	var body map[string]any
+	if err := json.Unmarshal([]byte(valid), &body); err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range fields {
+		body[k] = v
+	}
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+func TestJSONFieldErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  map[string]any
+		field   string
+		rule    string
+		message string
+	}{
+		{"required_without uses json names", map[string]any{"lat": 0}, "address", "required_without", "is required when lat is not set"},
+		{"excluded_with uses json names", map[string]any{"radius_m": 100, "polygons": []string{"p"}}, "radius_m", "excluded_with", "must not be set together with polygons"},
+		{"oneof lists values", map[string]any{"severity": "extreme"}, "severity", "oneof", "must be one of: low, medium, high"},
+		{"max string", map[string]any{"name": "wildfire"}, "name", "max", "must be at most 5 characters long"},
+		{"min string", map[string]any{"code": "ab"}, "code", "min", "must be at least 3 characters long"},
+		{"min 1 string", map[string]any{"title": ""}, "title", "min", "must not be empty"},
+		{"min slice", map[string]any{"tags": []string{}}, "tags", "min", "must contain at least 1 items"},
+		{"max slice", map[string]any{"tags": []string{"a", "b", "c"}}, "tags", "max", "must contain at most 2 items"},
+		{"min number", map[string]any{"count": 0}, "count", "min", "must be >= 1"},
+		{"max number", map[string]any{"count": 11}, "count", "max", "must be <= 10"},
+		{"nested struct path", map[string]any{"items": []map[string]int{{"id": 1}, {"id": 0}}}, "items[1].id", "gt", "must be > 0"},
+		{"slice element path", map[string]any{"ids": []int{1, 2, -3}}, "ids[2]", "gt", "must be > 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst request
+			err := bind.JSON(newRequest(patch(t, tt.fields)), &dst)
+
+			var bindErr *bind.Error
+			if !errors.As(err, &bindErr) {
+				t.Fatalf("JSON() error = %v, want *bind.Error", err)
+			}
+			if len(bindErr.Fields) != 1 {
+				t.Fatalf("JSON() fields = %+v, want one", bindErr.Fields)
+			}
+			want := bind.FieldError{Field: tt.field, Rule: tt.rule, Message: tt.message}
+			if got := bindErr.Fields[0]; got != want {
+				t.Errorf("JSON() field = %+v, want %+v", got, want)
+			}
+			if got, want := err.Error(), tt.field+" "+tt.message; got != want {
+				t.Errorf("Error() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestJSONValid(t *testing.T) {
+	var dst request
+	if err := bind.JSON(newRequest(valid), &dst); err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	if dst.Name != "fire" || dst.Latitude != 55.7 {
+		t.Errorf("JSON() decoded %+v", dst)
+	}
+}
+
+func TestJSONMultipleErrors(t *testing.T) {
+	var dst request
+	err := bind.JSON(newRequest(patch(t, map[string]any{"name": "wildfire", "count": 0})), &dst)
+
+	var bindErr *bind.Error
+	if !errors.As(err, &bindErr) || len(bindErr.Fields) != 2 {
+		t.Fatalf("JSON() error = %v, want two field errors", err)
+	}
+	if got, want := err.Error(), "name must be at most 5 characters long; count must be >= 1"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var dst request
+	if err := bind.Decode(newRequest(`{"name":`), &dst); !errors.Is(err, bind.ErrInvalidJSON) {
+		t.Errorf("Decode() error = %v, want %v", err, bind.ErrInvalidJSON)
+	}
+
+	r := newRequest(valid)
+	r.Body = http.MaxBytesReader(httptest.NewRecorder(), r.Body, 4)
+	if err := bind.Decode(r, &dst); !errors.Is(err, bind.ErrBodyTooLarge) {
+		t.Errorf("Decode() error = %v, want %v", err, bind.ErrBodyTooLarge)
+	}
+}
+
+type point struct {
+	CRS       string  `json:"crs"`
+	Latitude  float64 `json:"lat" validate:"gte=-90,lte=90"`
+	Longitude float64 `json:"lng" validate:"gte=-180,lte=180"`
+}
+
+// TestDecodeConvertStruct повторяет путь ручек с параметром crs: координаты приводятся к WGS84
+// между Decode и Struct, поэтому пределы проверяются уже в градусах
+func TestDecodeConvertStruct(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+		lat     float64
+	}{
+		{name: "wgs84", body: `{"lat":55.75,"lng":37.62}`, lat: 55.75},
+		{name: "web mercator meters", body: `{"crs":"EPSG:3857","lat":7509137.5,"lng":4187591.9}`, lat: 55.75},
+		{name: "degrees out of range", body: `{"lat":91,"lng":0}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst point
+			if err := bind.Decode(newRequest(tt.body), &dst); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			lat, lng, err := crs.ToWGS84(dst.CRS, dst.Longitude, dst.Latitude)
+			if err != nil {
+				t.Fatalf("ToWGS84() error = %v", err)
+			}
+			dst.Latitude, dst.Longitude = lat, lng
+
+			err = bind.Struct(&dst)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Struct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (dst.Latitude < tt.lat-0.01 || dst.Latitude > tt.lat+0.01) {
+				t.Errorf("latitude = %v, want ~%v", dst.Latitude, tt.lat)
+			}
+		})
+	}
+}
+
+func TestInvalidResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    int
+		message string
+		details []bind.FieldError
+	}{
+		{
+			name:    "field errors",
+			err:     &bind.Error{Fields: []bind.FieldError{{Field: "ids[0]", Rule: "gt", Message: "must be > 0"}}},
+			code:    http.StatusBadRequest,
+			message: "ids[0] must be > 0",
+			details: []bind.FieldError{{Field: "ids[0]", Rule: "gt", Message: "must be > 0"}},
+		},
+		{
+			name:    "decoder details are hidden",
+			err:     errors.Join(bind.ErrInvalidJSON, errors.New("unexpected EOF")),
+			code:    http.StatusBadRequest,
+			message: bind.ErrInvalidJSON.Error(),
+		},
+		{
+			name:    "body too large",
+			err:     bind.ErrBodyTooLarge,
+			code:    http.StatusRequestEntityTooLarge,
+			message: bind.ErrBodyTooLarge.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			respond.Invalid(w, zap.NewNop(), tt.err)
+
+			if w.Code != tt.code {
+				t.Errorf("status = %d, want %d", w.Code, tt.code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			var body respond.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("response is not JSON: %v", err)
+			}
+			if body.Error != http.StatusText(tt.code) || body.Message != tt.message {
+				t.Errorf("response = %+v, want error %q, message %q", body, http.StatusText(tt.code), tt.message)
+			}
+			if len(body.Details) != len(tt.details) {
+				t.Fatalf("details = %+v, want %+v", body.Details, tt.details)
+			}
+			for i := range tt.details {
+				if body.Details[i] != tt.details[i] {
+					t.Errorf("details[%d] = %+v, want %+v", i, body.Details[i], tt.details[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"io"
+	"net/http"
+)
+
+// MaxBodySize ограничивает тело любого запроса limit байтами независимо от Content-Type:
+// чтение сверх лимита завершается ошибкой *http.MaxBytesError, на которую bind отвечает 413.
+// Маршрут с большими телами (загрузка вложений) поднимает лимит через SetBodyLimit
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = &limitedBody{w: w, body: r.Body, limit: limit}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetBodyLimit меняет лимит тела запроса для одного маршрута; вызывается до чтения тела.
+// Без MaxBodySize тело просто ограничивается limit байтами
+func SetBodyLimit(w http.ResponseWriter, r *http.Request, limit int64) {
+	if b, ok := r.Body.(*limitedBody); ok && b.reader == nil {
+		b.limit = limit
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+}
+
+// limitedBody откладывает http.MaxBytesReader до первого чтения, чтобы обработчик успел поменять лимит
+type limitedBody struct {
+	w      http.ResponseWriter
+	body   io.ReadCloser
+	limit  int64
+	reader io.ReadCloser
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.reader == nil {
+		b.reader = http.MaxBytesReader(b.w, b.body, b.limit)
+	}
+	return b.reader.Read(p)
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		// raise — лимит, который выставляет обработчик маршрута; 0 — лимит по умолчанию
+		raise   int64
+		size    int
+		wantErr bool
+	}{
+		{name: "within limit", contentType: "application/json", size: 10},
+		{name: "over limit", contentType: "application/json", size: 11, wantErr: true},
+		{name: "multipart header does not lift limit", contentType: "multipart/form-data; boundary=x", size: 11, wantErr: true},
+		{name: "route raises limit", contentType: "multipart/form-data; boundary=x", raise: 20, size: 20},
+		{name: "over raised limit", contentType: "multipart/form-data; boundary=x", raise: 20, size: 21, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var readErr error
+			handler := MaxBodySize(10)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.raise > 0 {
+					SetBodyLimit(w, r, tt.raise)
+				}
+				_, readErr = io.ReadAll(r.Body)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("x", tt.size)))
+			req.Header.Set("Content-Type", tt.contentType)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			var maxBytesErr *http.MaxBytesError
+			if got := errors.As(readErr, &maxBytesErr); got != tt.wantErr {
+				t.Errorf("read error = %v, want limit error %v", readErr, tt.wantErr)
+			}
+		})
+	}
+}
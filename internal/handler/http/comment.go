@@ -0,0 +1,122 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCommentsLimit = 50
+	maxCommentsLimit     = 500
+)
+
+// @Summary      Комментарии зоны (оператор)
+// @ID           listIncidentComments
+// @Description  Заметки операторов о ходе ситуации в зоне, новые первыми
+// @Tags         incidents
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int     true   "ID инцидента"
+// @Param        limit          query     int     false  "Размер списка (по умолчанию 50, максимум 500)"
+// @Success      200            {object}  dtoResp.IncidentCommentsResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID или limit"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/comments [get]
+func (h *IncidentHandler) IncidentComments(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	limit := defaultCommentsLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > maxCommentsLimit {
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid limit parameter (must be between 1 and 500)")
+			return
+		}
+		limit = l
+	}
+
+	comments, err := h.uc.IncidentComments(r.Context(), id, limit)
+	if err != nil {
+		h.logger.Error("incident comments read failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	response := dtoResp.IncidentCommentsResponse{
+		IncidentID: id,
+		Comments:   make([]dtoResp.IncidentCommentResponse, len(comments)),
+	}
+	for i := range comments {
+		response.Comments[i] = toCommentResponse(&comments[i])
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// @Summary      Добавить комментарий к зоне (оператор)
+// @ID           createIncidentComment
+// @Description  Заметка о ходе ситуации, до 2000 символов; автор — оператор запроса. Комментировать можно и опубликованную зону.
+// @Description  С WEBHOOK_INCLUDE_COMMENTS=true комментарий к опубликованной зоне отправляет incident.updated
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int                              true  "ID инцидента"
+// @Param        request        body      dtoReq.IncidentCommentRequest    true  "Комментарий"
+// @Success      201            {object}  dtoResp.IncidentCommentResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный формат данных"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/comments [post]
+func (h *IncidentHandler) IncidentCommentCreate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	var req dtoReq.IncidentCommentRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	comment, err := h.uc.AddIncidentComment(r.Context(), id, req.Body)
+	if err != nil {
+		h.logger.Error("incident comment save failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusCreated, toCommentResponse(comment))
+}
+
+func toCommentResponse(c *entity.IncidentComment) dtoResp.IncidentCommentResponse {
+	return dtoResp.IncidentCommentResponse{
+		ID:        c.ID,
+		Body:      c.Body,
+		Author:    c.Author,
+		CreatedAt: c.CreatedAt,
+	}
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CORSOptions — настройки CORS для браузерных клиентов с других доменов.
+// Origin "*" разрешает любой источник, "https://*.example.com" — поддомены example.com
+type CORSOptions struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAgeSeconds    int
+}
+
+// CORS отвечает на preflight-запросы (OPTIONS с Access-Control-Request-Method) сам, до авторизации:
+// браузер не передает в них Authorization. К остальным запросам с разрешенным Origin добавляет
+// заголовки Access-Control-*, запросы с чужим Origin проходят без них, и браузер не отдаст ответ скрипту
+func CORS(opts CORSOptions) func(http.Handler) http.Handler {
+	methods := strings.Join(opts.AllowedMethods, ", ")
+	headers := strings.Join(opts.AllowedHeaders, ", ")
+	exposed := strings.Join(opts.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(opts.MaxAgeSeconds)
+
+	allowedMethods := make(map[string]bool, len(opts.AllowedMethods))
+	for _, m := range opts.AllowedMethods {
+		allowedMethods[strings.ToUpper(m)] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Add("Vary", "Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if preflight {
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+			}
+
+			if !originAllowed(opts.AllowedOrigins, origin) {
+				if preflight {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// с credentials браузер не принимает "*", поэтому всегда возвращается сам Origin
+			h.Set("Access-Control-Allow-Origin", origin)
+			if opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if !preflight {
+				if exposed != "" {
+					h.Set("Access-Control-Expose-Headers", exposed)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if allowedMethods[strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))] {
+				h.Set("Access-Control-Allow-Methods", methods)
+				if headers != "" {
+					h.Set("Access-Control-Allow-Headers", headers)
+				}
+				if opts.MaxAgeSeconds > 0 {
+					h.Set("Access-Control-Max-Age", maxAge)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+func originAllowed(allowed []string, origin string) bool {
+	for _, pattern := range allowed {
+		if pattern == "*" || strings.EqualFold(pattern, origin) {
+			return true
+		}
+		scheme, host, ok := strings.Cut(pattern, "*.")
+		if !ok {
+			continue
+		}
+		// "https://*.example.com" не совпадает с самим https://example.com
+		originScheme, originHost, ok := strings.Cut(origin, "://")
+		if ok && strings.EqualFold(scheme, originScheme+"://") &&
+			len(originHost) > len(host)+1 &&
+			strings.HasSuffix(strings.ToLower(originHost), "."+strings.ToLower(host)) {
+			return true
+		}
+	}
+	return false
+}
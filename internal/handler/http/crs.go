@@ -0,0 +1,24 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/4otis/geonotify-service/pkg/crs"
+)
+
+// toWGS84 переводит координаты запроса из системы code в WGS84 на месте.
+// Для проецированных систем latitude содержит northing (y), а longitude — easting (x).
+// Возвращает сообщение об ошибке для ответа 400 или пустую строку
+func toWGS84(code string, lat, lng *float64) string {
+	wgsLat, wgsLng, err := crs.ToWGS84(code, *lng, *lat)
+	switch {
+	case errors.Is(err, crs.ErrUnsupported):
+		return fmt.Sprintf("unsupported crs %q: use EPSG:4326, EPSG:3857 or WGS84 UTM (EPSG:326NN/327NN)", code)
+	case err != nil:
+		return "invalid coordinates"
+	}
+
+	*lat, *lng = wgsLat, wgsLng
+	return ""
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// dashboardFiles — статика админки: одна страница без сборки, данные берет из /api/v1/admin/dashboard
+//
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+// dashboardCSP разрешает Leaflet с unpkg и тайлы OpenStreetMap, все остальное — только с самого сервиса
+const dashboardCSP = "default-src 'self'; " +
+	"script-src 'self' https://unpkg.com; " +
+	"style-src 'self' https://unpkg.com; " +
+	"img-src 'self' data: https://unpkg.com https://*.tile.openstreetmap.org; " +
+	"connect-src 'self'; frame-ancestors 'none'"
+
+// DashboardHandler отдает админку; сама страница публичная, а запросы к API она делает с токеном оператора
+type DashboardHandler struct {
+	files http.Handler
+}
+
+func NewDashboardHandler() (*DashboardHandler, error) {
+	root, err := fs.Sub(dashboardFiles, "dashboard")
+	if err != nil {
+		return nil, err
+	}
+
+	return &DashboardHandler{
+		files: http.StripPrefix("/admin", http.FileServer(http.FS(root))),
+	}, nil
+}
+
+// Index обрабатывает GET /admin
+func (h *DashboardHandler) Index(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/admin/", http.StatusMovedPermanently)
+}
+
+// Static обрабатывает GET /admin/*
+func (h *DashboardHandler) Static(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Security-Policy", dashboardCSP)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// файлы встроены в бинарник, после обновления сервиса браузер должен их перезапросить
+	w.Header().Set("Cache-Control", "no-cache")
+	h.files.ServeHTTP(w, r)
+}
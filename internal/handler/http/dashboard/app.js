@@ -0,0 +1,321 @@
+'use strict';
+
+// Админка geonotify: страница без сборки, работает поверх публичного API с токеном оператора
+// (API-ключ или JWT), токен хранится только в sessionStorage вкладки.
+
+const TOKEN_KEY = 'geonotify.token';
+const REFRESH_MS = 10000;
+
+const $ = (id) => document.getElementById(id);
+
+let map;
+let zonesLayer;
+let checksLayer;
+let fitted = false;
+let timer;
+// исходная версия редактируемой зоны: PATCH отправляет только измененные поля
+let editing = null;
+
+async function api(method, path, body) {
+  const headers = { Accept: 'application/json' };
+  const token = sessionStorage.getItem(TOKEN_KEY);
+  if (token) headers.Authorization = 'Bearer ' + token;
+  if (body !== undefined) headers['Content-Type'] = 'application/json';
+
+  const resp = await fetch(path, {
+    method,
+    headers,
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+  // 401 без токена — неверный пароль при входе, а не истекшая сессия
+  if (resp.status === 401 && token) {
+    logout();
+    throw new Error('Требуется вход');
+  }
+
+  const data = resp.status === 204 ? null : await resp.json().catch(() => null);
+  if (!resp.ok) {
+    let message = (data && (data.message || data.error)) || resp.statusText;
+    if (data && data.details) {
+      message += ': ' + data.details.map((d) => d.field + ' ' + d.message).join(', ');
+    }
+    throw new Error(message);
+  }
+  return data;
+}
+
+function showLogin() {
+  clearInterval(timer);
+  $('dashboard').hidden = true;
+  $('logout').hidden = true;
+  $('login').hidden = false;
+}
+
+function showDashboard() {
+  $('login').hidden = true;
+  $('dashboard').hidden = false;
+  $('logout').hidden = false;
+  initMap();
+  refresh();
+  clearInterval(timer);
+  timer = setInterval(refresh, REFRESH_MS);
+}
+
+function logout() {
+  sessionStorage.removeItem(TOKEN_KEY);
+  showLogin();
+}
+
+function initMap() {
+  if (map) return;
+  map = L.map('map').setView([55.751, 37.618], 10);
+  L.tileLayer('https://{s}.tile.openstreetmap.org/{z}/{x}/{y}.png', {
+    maxZoom: 19,
+    attribution: '&copy; OpenStreetMap contributors',
+  }).addTo(map);
+  zonesLayer = L.featureGroup().addTo(map);
+  checksLayer = L.featureGroup().addTo(map);
+
+  map.on('click', (e) => {
+    const form = $('zone-form');
+    form.latitude.value = e.latlng.lat.toFixed(6);
+    form.longitude.value = e.latlng.lng.toFixed(6);
+  });
+}
+
+async function refresh() {
+  try {
+    const data = await api('GET', '/api/v1/admin/dashboard');
+    render(data);
+    $('updated').textContent = 'обновлено ' + formatTime(data.generated_at);
+  } catch (err) {
+    $('updated').textContent = 'ошибка обновления: ' + err.message;
+  }
+}
+
+function render(data) {
+  $('active-count').textContent = data.active_incidents.length;
+  $('queue-pending').textContent = data.webhook_queue.pending;
+  $('queue-processing').textContent = data.webhook_queue.processing;
+  $('queue-failed').textContent = data.webhook_queue.failed;
+
+  zonesLayer.clearLayers();
+  for (const inc of data.active_incidents) {
+    const style = { color: '#cf222e', weight: 2, fillOpacity: 0.15 };
+    const shape = inc.geometry
+      ? L.geoJSON(inc.geometry, { style })
+      : L.circle([inc.latitude, inc.longitude], Object.assign({ radius: inc.radius_m }, style));
+    shape.bindTooltip('#' + inc.incident_id + ' ' + inc.name);
+    shape.on('click', (e) => {
+      L.DomEvent.stopPropagation(e);
+      editZone(inc);
+    });
+    zonesLayer.addLayer(shape);
+  }
+
+  checksLayer.clearLayers();
+  for (const check of data.recent_checks) {
+    L.circleMarker([check.latitude, check.longitude], {
+      radius: 4,
+      color: check.has_alert ? '#cf222e' : '#0969da',
+      fillOpacity: 0.8,
+    })
+      .bindTooltip(check.user_id + ' · ' + formatTime(check.created_at))
+      .addTo(checksLayer);
+  }
+
+  if (!fitted) {
+    const bounds = zonesLayer.getBounds().extend(checksLayer.getBounds());
+    if (bounds.isValid()) {
+      map.fitBounds(bounds, { padding: [20, 20], maxZoom: 15 });
+      fitted = true;
+    }
+  }
+
+  fillTable($('checks'), data.recent_checks, (check) => {
+    const row = cells([
+      check.check_id,
+      check.user_id,
+      check.latitude.toFixed(5) + ', ' + check.longitude.toFixed(5),
+      check.has_alert ? 'да' : 'нет',
+      formatTime(check.created_at),
+    ]);
+    if (check.has_alert) row.className = 'alert';
+    return row;
+  });
+
+  fillTable($('failed'), data.failed_webhooks, (wh) => {
+    const row = cells([
+      wh.webhook_id,
+      wh.endpoint_id || '',
+      wh.event,
+      wh.check_id || '',
+      wh.retry_cnt,
+      formatTime(wh.failed_at),
+    ]);
+    const button = document.createElement('button');
+    button.type = 'button';
+    button.textContent = 'Повторить';
+    button.addEventListener('click', () => replay([wh.webhook_id]));
+    row.insertCell().appendChild(button);
+    return row;
+  });
+}
+
+function fillTable(tbody, items, toRow) {
+  tbody.replaceChildren(...items.map(toRow));
+}
+
+function cells(values) {
+  const row = document.createElement('tr');
+  for (const value of values) {
+    row.insertCell().textContent = value;
+  }
+  return row;
+}
+
+async function replay(ids) {
+  try {
+    const res = await api('POST', '/api/v1/webhooks/replay', ids ? { ids } : undefined);
+    $('updated').textContent = 'в очередь возвращено вебхуков: ' + res.requeued;
+    refresh();
+  } catch (err) {
+    $('updated').textContent = 'ошибка повтора: ' + err.message;
+  }
+}
+
+function editZone(inc) {
+  const form = $('zone-form');
+  editing = inc;
+  form.elements.name.value = inc.name;
+  form.descr.value = inc.descr || '';
+  form.latitude.value = inc.latitude;
+  form.longitude.value = inc.longitude;
+  form.radius_m.value = inc.radius_m;
+  form.ttl_minutes.value = '';
+  form.is_active.checked = inc.is_active;
+  form.is_active.parentElement.hidden = false;
+  $('zone-title').textContent = 'Зона #' + inc.incident_id;
+  setZoneMessage('');
+}
+
+function resetZone() {
+  const form = $('zone-form');
+  editing = null;
+  form.reset();
+  // новая зона создается активной
+  form.is_active.parentElement.hidden = true;
+  $('zone-title').textContent = 'Новая зона';
+}
+
+function zoneFields(form) {
+  const fields = {
+    name: form.elements.name.value.trim(),
+    descr: form.descr.value,
+    latitude: Number(form.latitude.value),
+    longitude: Number(form.longitude.value),
+    radius_m: Number(form.radius_m.value),
+  };
+  if (form.ttl_minutes.value) fields.ttl_minutes = Number(form.ttl_minutes.value);
+  return fields;
+}
+
+async function saveZone(e) {
+  e.preventDefault();
+  const form = e.target;
+  const fields = zoneFields(form);
+
+  try {
+    if (!editing) {
+      const res = await api('POST', '/api/v1/incidents', fields);
+      setZoneMessage('Зона #' + res.incident_id + ' создана');
+      resetZone();
+    } else {
+      fields.is_active = form.is_active.checked;
+      const patch = {};
+      for (const [key, value] of Object.entries(fields)) {
+        if (key === 'ttl_minutes' || editing[key] !== value) patch[key] = value;
+      }
+      if (Object.keys(patch).length === 0) {
+        setZoneMessage('Изменений нет');
+        return;
+      }
+      await api('PATCH', '/api/v1/incidents/' + editing.incident_id, patch);
+      editZone(await api('GET', '/api/v1/incidents/' + editing.incident_id));
+      setZoneMessage('Сохранено');
+    }
+    refresh();
+  } catch (err) {
+    setZoneMessage(err.message, true);
+  }
+}
+
+async function lookupZone() {
+  const id = $('zone-lookup-id').value;
+  if (!id) return;
+  try {
+    const inc = await api('GET', '/api/v1/incidents/' + encodeURIComponent(id));
+    editZone(inc);
+    map.setView([inc.latitude, inc.longitude], Math.max(map.getZoom(), 13));
+  } catch (err) {
+    setZoneMessage(err.message, true);
+  }
+}
+
+function setZoneMessage(text, isError) {
+  const el = $('zone-message');
+  el.textContent = text;
+  el.className = isError ? 'error' : 'muted';
+}
+
+function formatTime(value) {
+  return value ? new Date(value).toLocaleString() : '';
+}
+
+$('login-password').addEventListener('submit', async (e) => {
+  e.preventDefault();
+  const form = e.target;
+  try {
+    const res = await api('POST', '/api/v1/auth/login', {
+      username: form.username.value,
+      password: form.password.value,
+    });
+    sessionStorage.setItem(TOKEN_KEY, res.access_token);
+    form.reset();
+    $('login-error').textContent = '';
+    showDashboard();
+  } catch (err) {
+    $('login-error').textContent = err.message;
+  }
+});
+
+$('login-token').addEventListener('submit', (e) => {
+  e.preventDefault();
+  sessionStorage.setItem(TOKEN_KEY, e.target.token.value.trim());
+  e.target.reset();
+  $('login-error').textContent = '';
+  showDashboard();
+});
+
+$('logout').addEventListener('click', logout);
+$('zone-form').addEventListener('submit', saveZone);
+$('zone-reset').addEventListener('click', () => {
+  resetZone();
+  setZoneMessage('');
+});
+$('zone-lookup').addEventListener('click', lookupZone);
+$('zone-lookup-id').addEventListener('keydown', (e) => {
+  // поле поиска внутри формы зоны: Enter не должен сохранять зону
+  if (e.key === 'Enter') {
+    e.preventDefault();
+    lookupZone();
+  }
+});
+$('replay-all').addEventListener('click', () => replay(null));
+
+resetZone();
+if (sessionStorage.getItem(TOKEN_KEY)) {
+  showDashboard();
+} else {
+  showLogin();
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+type DataExportHandler struct {
+	logger *zap.Logger
+	uc     cases.DataExportUseCase
+}
+
+func NewDataExportHandler(logger *zap.Logger, uc cases.DataExportUseCase) *DataExportHandler {
+	return &DataExportHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// DataExport обрабатывает GET /api/v1/users/{user_id}/data-export
+// @Summary      Выгрузка данных пользователя (оператор)
+// @ID           getUserDataExport
+// @Description  Все данные о пользователе одним JSON-архивом по запросу субъекта данных: проверки координат с временем,
+// @Description  полученные алерты, настройки алертов, текущие зоны и группы. Первый запрос заводит выгрузку и отвечает 202,
+// @Description  архив собирается в фоне; повторные запросы возвращают ее статус, а готовая выгрузка (200) — временную ссылку
+// @Description  download_url. Архив хранится DATA_EXPORT_RETENTION_HOURS часов, после этого запрос заводит новую выгрузку
+// @Tags         privacy
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        user_id  path      string  true  "ID пользователя"
+// @Success      200      {object}  dtoResp.DataExportResponse  "Архив готов"
+// @Success      202      {object}  dtoResp.DataExportResponse  "Архив собирается"
+// @Failure      400      {object}  respond.ErrorResponse  "Неверный user_id"
+// @Failure      401      {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      500      {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/users/{user_id}/data-export [get]
+func (h *DataExportHandler) DataExport(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "user_id")
+	if userID == "" || len(userID) > maxUserIDLength {
+		respond.Error(w, h.logger, http.StatusBadRequest, "user_id is required and must be at most 127 characters")
+		return
+	}
+
+	export, err := h.uc.UserDataExport(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("user data export failed",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	status := http.StatusAccepted
+	if export.Export.Status == entity.DataExportReady {
+		status = http.StatusOK
+	}
+
+	respond.JSON(w, h.logger, status, toDataExportResponse(export))
+}
+
+func toDataExportResponse(e *cases.DataExportWithURL) dtoResp.DataExportResponse {
+	return dtoResp.DataExportResponse{
+		ExportID:    e.Export.ID,
+		UserID:      e.Export.UserID,
+		Status:      e.Export.Status,
+		RequestedBy: e.Export.RequestedBy,
+		RequestedAt: e.Export.RequestedAt,
+		CompletedAt: e.Export.CompletedAt,
+		ExpiresAt:   e.Export.ExpiresAt,
+		SizeBytes:   e.Export.SizeBytes,
+		DownloadURL: e.URL,
+		Attempts:    e.Export.Attempts,
+		LastError:   e.Export.LastError,
+	}
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/4otis/geonotify-service/docs"
+	"github.com/4otis/geonotify-service/pkg/openapi"
+	"go.uber.org/zap"
+)
+
+// openAPISchemaPrefix убирается из имен моделей: swag называет их полным путем пакета,
+// когда короткие имена совпадают (resp.LocationCheckResponse в v1 и v2)
+const openAPISchemaPrefix = "github_com_4otis_geonotify-service_internal_"
+
+// OpenAPISpec строит спецификацию OpenAPI 3 из swagger-документации, сгенерированной по аннотациям ручек
+func OpenAPISpec() ([]byte, error) {
+	return openapi.FromSwagger2([]byte(docs.SwaggerInfo.ReadDoc()), openAPISchemaPrefix)
+}
+
+type DocsHandler struct {
+	logger *zap.Logger
+	spec   []byte
+}
+
+// NewDocsHandler строит спецификацию один раз при старте
+func NewDocsHandler(logger *zap.Logger) (*DocsHandler, error) {
+	spec, err := OpenAPISpec()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DocsHandler{
+		logger: logger,
+		spec:   spec,
+	}, nil
+}
+
+// OpenAPI обрабатывает GET /openapi.json
+func (h *DocsHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(h.spec); err != nil {
+		h.logger.Error("failed to write openapi spec", zap.Error(err))
+	}
+}
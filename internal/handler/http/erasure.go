@@ -0,0 +1,269 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultErasuresLimit = 50
+	maxErasuresLimit     = 500
+)
+
+type ErasureHandler struct {
+	logger *zap.Logger
+	uc     cases.ErasureUseCase
+}
+
+func NewErasureHandler(logger *zap.Logger, uc cases.ErasureUseCase) *ErasureHandler {
+	return &ErasureHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// ErasureRequest обрабатывает DELETE /api/v1/users/{user_id}/data
+// @Summary      Удалить данные пользователя (оператор)
+// @ID           requestUserErasure
+// @Description  Заводит заявку на удаление данных пользователя по запросу на забвение. Данные удаляются фоновой задачей
+// @Description  после подтверждения другим публикатором: проверки координат (mode=anonymize оставляет их для статистики
+// @Description  без user_id и с координатами, округленными до 0.01°), вебхуки по ним, зоны присутствия, настройки алертов,
+// @Description  участие в группах, последняя точка в Redis и документы в OpenSearch. Заявка остается как запись аудита
+// @Tags         privacy
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        user_id  path      string  true   "ID пользователя"
+// @Param        mode     query     string  false  "Способ удаления (по умолчанию delete)" Enums(delete, anonymize)
+// @Success      202      {object}  dtoResp.ErasureResponse
+// @Failure      400      {object}  respond.ErrorResponse  "Неверный user_id или mode"
+// @Failure      401      {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      409      {object}  respond.ErrorResponse  "У пользователя уже есть незавершенная заявка"
+// @Failure      500      {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/users/{user_id}/data [delete]
+func (h *ErasureHandler) ErasureRequest(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "user_id")
+	if userID == "" || len(userID) > maxUserIDLength {
+		respond.Error(w, h.logger, http.StatusBadRequest, "user_id is required and must be at most 127 characters")
+		return
+	}
+
+	erasure, err := h.uc.RequestErasure(r.Context(), userID, r.URL.Query().Get("mode"))
+	if err != nil {
+		h.logger.Error("erasure request failed",
+			zap.Error(err),
+			zap.String("user_id", userID))
+
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusAccepted, toErasureResponse(erasure))
+}
+
+// ErasureList обрабатывает GET /api/v1/erasures
+// @Summary      Заявки на удаление данных пользователей (оператор)
+// @ID           listErasures
+// @Description  Заявки от новых к старым. Без status возвращаются заявки во всех статусах
+// @Tags         privacy
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        status  query     string  false  "Фильтр по статусу" Enums(pending, confirmed, completed, cancelled)
+// @Param        limit   query     int     false  "Размер списка (по умолчанию 50, максимум 500)"
+// @Success      200     {object}  dtoResp.ErasuresListResponse
+// @Failure      400     {object}  respond.ErrorResponse
+// @Failure      401     {object}  respond.ErrorResponse
+// @Failure      500     {object}  respond.ErrorResponse
+// @Router       /api/v1/erasures [get]
+func (h *ErasureHandler) ErasureList(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", entity.ErasurePending, entity.ErasureConfirmed, entity.ErasureCompleted, entity.ErasureCancelled:
+	default:
+		respond.Error(w, h.logger, http.StatusBadRequest, "invalid status parameter (must be pending, confirmed, completed or cancelled)")
+		return
+	}
+
+	limit := defaultErasuresLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > maxErasuresLimit {
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid limit parameter (must be between 1 and 500)")
+			return
+		}
+		limit = l
+	}
+
+	erasures, err := h.uc.ListErasures(r.Context(), status, limit)
+	if err != nil {
+		h.logger.Error("erasure list failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	response := dtoResp.ErasuresListResponse{
+		Erasures: make([]dtoResp.ErasureResponse, len(erasures)),
+	}
+	for i, e := range erasures {
+		response.Erasures[i] = toErasureResponse(e)
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// ErasureGet обрабатывает GET /api/v1/erasures/{erasure_id}
+// @Summary      Заявка на удаление данных пользователя (оператор)
+// @ID           getErasure
+// @Description  Статус заявки; у выполненной — сколько записей удалено или обезличено
+// @Tags         privacy
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        erasure_id  path      int  true  "ID заявки"
+// @Success      200         {object}  dtoResp.ErasureResponse
+// @Failure      400         {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401         {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404         {object}  respond.ErrorResponse  "Заявка не найдена"
+// @Failure      500         {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/erasures/{erasure_id} [get]
+func (h *ErasureHandler) ErasureGet(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "erasure_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	erasure, err := h.uc.Erasure(r.Context(), id)
+	if err != nil {
+		h.logger.Error("erasure read failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toErasureResponse(erasure))
+}
+
+// ErasureConfirm обрабатывает POST /api/v1/erasures/{erasure_id}/confirm
+// @Summary      Подтвердить удаление данных пользователя (публикатор)
+// @ID           confirmErasure
+// @Description  Ставит заявку в очередь на удаление, задача выполняет ее раз в ERASURE_INTERVAL_SECONDS.
+// @Description  Подтвердить может только публикатор, который не подавал заявку
+// @Tags         privacy
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        erasure_id  path      int  true  "ID заявки"
+// @Success      200         {object}  dtoResp.ErasureResponse
+// @Failure      400         {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401         {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403         {object}  respond.ErrorResponse  "Недостаточно прав или подтверждение собственной заявки"
+// @Failure      404         {object}  respond.ErrorResponse  "Заявка не найдена"
+// @Failure      409         {object}  respond.ErrorResponse  "Заявка уже подтверждена или отменена"
+// @Failure      500         {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/erasures/{erasure_id}/confirm [post]
+func (h *ErasureHandler) ErasureConfirm(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "erasure_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	erasure, err := h.uc.ConfirmErasure(r.Context(), id)
+	if err != nil {
+		h.logger.Error("erasure confirm failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toErasureResponse(erasure))
+}
+
+// ErasureCancel обрабатывает POST /api/v1/erasures/{erasure_id}/cancel
+// @Summary      Отменить удаление данных пользователя (публикатор)
+// @ID           cancelErasure
+// @Description  Закрывает заявку, ожидающую подтверждения, данные остаются. Автор заявки может отозвать ее сам
+// @Tags         privacy
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        erasure_id  path      int  true  "ID заявки"
+// @Success      200         {object}  dtoResp.ErasureResponse
+// @Failure      400         {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401         {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403         {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404         {object}  respond.ErrorResponse  "Заявка не найдена"
+// @Failure      409         {object}  respond.ErrorResponse  "Заявка уже подтверждена или отменена"
+// @Failure      500         {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/erasures/{erasure_id}/cancel [post]
+func (h *ErasureHandler) ErasureCancel(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "erasure_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	erasure, err := h.uc.CancelErasure(r.Context(), id)
+	if err != nil {
+		h.logger.Error("erasure cancel failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toErasureResponse(erasure))
+}
+
+func (h *ErasureHandler) respondWithError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidErasureMode):
+		respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entity.ErrErasureNotFound):
+		respond.Error(w, h.logger, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrForbidden),
+		errors.Is(err, entity.ErrSelfConfirmation):
+		respond.Error(w, h.logger, http.StatusForbidden, err.Error())
+	case errors.Is(err, entity.ErrErasurePending),
+		errors.Is(err, entity.ErrErasureClosed):
+		respond.Error(w, h.logger, http.StatusConflict, err.Error())
+	default:
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+	}
+}
+
+func toErasureResponse(e *entity.Erasure) dtoResp.ErasureResponse {
+	response := dtoResp.ErasureResponse{
+		ErasureID:   e.ID,
+		UserID:      e.UserID,
+		Mode:        e.Mode,
+		Status:      e.Status,
+		RequestedBy: e.RequestedBy,
+		RequestedAt: e.RequestedAt,
+		ConfirmedBy: e.ConfirmedBy,
+		ConfirmedAt: e.ConfirmedAt,
+		CompletedAt: e.CompletedAt,
+		Attempts:    e.Attempts,
+		LastError:   e.LastError,
+	}
+	if e.Status == entity.ErasureCompleted {
+		response.Erased = &dtoResp.ErasureResultResponse{
+			Checks:           e.Result.Checks,
+			Webhooks:         e.Result.Webhooks,
+			Zones:            e.Result.Zones,
+			Preferences:      e.Result.Preferences,
+			GroupMemberships: e.Result.GroupMemberships,
+		}
+	}
+	return response
+}
@@ -0,0 +1,261 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/dto/feed"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/pkg/geojson"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// capTimeLayout — CAP требует смещение в виде -07:00, "Z" не допускается
+const capTimeLayout = "2006-01-02T15:04:05-07:00"
+
+var capSeverity = map[string]string{
+	entity.SeverityLow:      "Minor",
+	entity.SeverityMedium:   "Moderate",
+	entity.SeverityHigh:     "Severe",
+	entity.SeverityCritical: "Extreme",
+}
+
+var capUrgency = map[string]string{
+	entity.StatePlanned:   "Future",
+	entity.StateActive:    "Immediate",
+	entity.StateContained: "Expected",
+	entity.StateResolved:  "Past",
+	entity.StateArchived:  "Past",
+}
+
+// FeedOptions — оформление публичной ленты. BaseURL пустой — ссылки строятся от адреса запроса
+type FeedOptions struct {
+	BaseURL string
+	Title   string
+	// Sender — идентификатор отправителя CAP-сообщений, без пробелов и запятых
+	Sender string
+}
+
+// FeedHandler отдает действующие зоны агрегаторам оповещений: ленту Atom со ссылками
+// на сообщения CAP 1.2 по каждой зоне. Обе ручки публичные и только читают
+type FeedHandler struct {
+	logger *zap.Logger
+	uc     cases.FeedUseCase
+	opts   FeedOptions
+}
+
+func NewFeedHandler(logger *zap.Logger, uc cases.FeedUseCase, opts FeedOptions) *FeedHandler {
+	opts.BaseURL = strings.TrimSuffix(opts.BaseURL, "/")
+	return &FeedHandler{
+		logger: logger,
+		uc:     uc,
+		opts:   opts,
+	}
+}
+
+// Incidents обрабатывает GET /feeds/incidents: Atom с действующими опубликованными зонами,
+// названия и описания — на языке из Accept-Language
+func (h *FeedHandler) Incidents(w http.ResponseWriter, r *http.Request) {
+	locales := acceptLocales(r)
+	base := h.baseURL(r)
+
+	w.Header().Set("Vary", "Accept-Language")
+	version, err := h.uc.Version(r.Context())
+	if err != nil {
+		h.logger.Warn("failed to read incidents version", zap.Error(err))
+	} else if respond.NotModified(w, r, localeETag(version, locales)) {
+		return
+	}
+
+	incidents, err := h.uc.ActiveIncidents(r.Context(), locales)
+	if err != nil {
+		h.logger.Error("incident feed failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	self := base + "/feeds/incidents"
+	atom := feed.AtomFeed{
+		XMLNS:   feed.AtomNamespace,
+		GeoRSS:  feed.GeoRSSNamespace,
+		ID:      self,
+		Title:   h.opts.Title,
+		Author:  feed.AtomPerson{Name: h.opts.Sender},
+		Links:   []feed.AtomLink{{Rel: "self", Type: feed.AtomContentType, Href: self}},
+		Entries: make([]feed.AtomEntry, len(incidents)),
+	}
+
+	var updated time.Time
+	for i, inc := range incidents {
+		if inc.UpdatedAt.After(updated) {
+			updated = inc.UpdatedAt
+		}
+		atom.Entries[i] = toAtomEntry(inc, self)
+	}
+	// пустая лента тоже обязана иметь updated
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	atom.Updated = updated.UTC().Format(time.RFC3339)
+
+	respond.XML(w, h.logger, http.StatusOK, feed.AtomContentType, atom)
+}
+
+// Alert обрабатывает GET /feeds/incidents/{incident_id}: сообщение CAP 1.2 с блоком info на каждый язык.
+// Для завершенной или снятой с публикации зоны отдается Update с responseType AllClear
+func (h *FeedHandler) Alert(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	alert, err := h.uc.Alert(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, entity.ErrIncidentNotFound) {
+			respond.Error(w, h.logger, http.StatusNotFound, "incident not found")
+			return
+		}
+		h.logger.Error("incident alert feed failed",
+			zap.Error(err),
+			zap.Int("id", id))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	inc := alert.Incident
+	if respond.NotModified(w, r, fmt.Sprintf("%d-%d", inc.ID, inc.UpdatedAt.UnixMicro())) {
+		return
+	}
+
+	respond.XML(w, h.logger, http.StatusOK, feed.CAPContentType, h.toCAPAlert(alert))
+}
+
+func (h *FeedHandler) baseURL(r *http.Request) string {
+	if h.opts.BaseURL != "" {
+		return h.opts.BaseURL
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+func toAtomEntry(inc *entity.Incident, self string) feed.AtomEntry {
+	link := self + "/" + strconv.Itoa(inc.ID)
+	entry := feed.AtomEntry{
+		ID:      link,
+		Title:   inc.Name,
+		Updated: inc.UpdatedAt.UTC().Format(time.RFC3339),
+		Summary: inc.Descr,
+		Links:   []feed.AtomLink{{Rel: "alternate", Type: feed.CAPContentType, Href: link}},
+		Categories: []feed.AtomCategory{
+			{Term: inc.Severity, Label: capSeverity[inc.Severity]},
+			{Term: inc.State},
+		},
+		Point: fmt.Sprintf("%.6f %.6f", inc.Latitude, inc.Longitude),
+	}
+	if inc.PublishedAt != nil {
+		entry.Published = inc.PublishedAt.UTC().Format(time.RFC3339)
+	}
+
+	if len(inc.Polygons) == 0 {
+		entry.Radius = inc.Radius
+		return entry
+	}
+	for _, polygon := range inc.Polygons {
+		entry.Polygons = append(entry.Polygons, ringString(polygon[0], " ", " "))
+	}
+	return entry
+}
+
+func (h *FeedHandler) toCAPAlert(alert *cases.FeedAlert) feed.CAPAlert {
+	inc := alert.Incident
+
+	msgType, responseType, urgency := "Alert", "", capUrgency[inc.State]
+	ended := inc.State == entity.StateResolved || inc.State == entity.StateArchived
+	if inc.Status != entity.IncidentPublished || ended {
+		msgType, responseType, urgency = "Update", "AllClear", "Past"
+	}
+
+	certainty := "Observed"
+	if inc.State == entity.StatePlanned {
+		certainty = "Likely"
+	}
+
+	area := feed.CAPArea{AreaDesc: inc.Address}
+	if area.AreaDesc == "" {
+		area.AreaDesc = inc.Name
+	}
+	if len(inc.Polygons) == 0 {
+		// радиус круга в CAP — в километрах
+		area.Circles = []string{fmt.Sprintf("%.6f,%.6f %.3f", inc.Latitude, inc.Longitude, inc.Radius/1000)}
+	}
+	for _, polygon := range inc.Polygons {
+		area.Polygons = append(area.Polygons, ringString(polygon[0], ",", " "))
+	}
+
+	info := feed.CAPInfo{
+		Language:     alert.DefaultLocale,
+		Category:     "Safety",
+		Event:        inc.Name,
+		ResponseType: responseType,
+		Urgency:      urgency,
+		Severity:     capSeverity[inc.Severity],
+		Certainty:    certainty,
+		SenderName:   h.opts.Title,
+		Headline:     inc.Name,
+		Description:  inc.Descr,
+		Area:         []feed.CAPArea{area},
+	}
+	if inc.PublishedAt != nil {
+		info.Effective = inc.PublishedAt.Format(capTimeLayout)
+	}
+	if inc.ExpiresAt != nil {
+		info.Expires = inc.ExpiresAt.Format(capTimeLayout)
+	}
+
+	infos := []feed.CAPInfo{info}
+	for _, t := range alert.Translations {
+		if t.Locale == alert.DefaultLocale {
+			continue
+		}
+		translated := info
+		translated.Language = t.Locale
+		translated.Event = t.Name
+		translated.Headline = t.Name
+		if t.Descr != "" {
+			translated.Description = t.Descr
+		}
+		infos = append(infos, translated)
+	}
+
+	return feed.CAPAlert{
+		XMLNS: feed.CAPNamespace,
+		// новая версия зоны — новое сообщение CAP
+		Identifier: fmt.Sprintf("%s.incident.%d.%d", h.opts.Sender, inc.ID, inc.UpdatedAt.Unix()),
+		Sender:     h.opts.Sender,
+		Sent:       inc.UpdatedAt.Format(capTimeLayout),
+		Status:     "Actual",
+		MsgType:    msgType,
+		Scope:      "Public",
+		Info:       infos,
+	}
+}
+
+// ringString записывает контур как пары "широта<sep>долгота" через pairSep; GeoJSON хранит долготу первой
+func ringString(ring []geojson.Position, sep, pairSep string) string {
+	points := make([]string, len(ring))
+	for i, p := range ring {
+		points[i] = fmt.Sprintf("%.6f%s%.6f", p[1], sep, p[0])
+	}
+	return strings.Join(points, pairSep)
+}
@@ -0,0 +1,479 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+type GroupHandler struct {
+	logger *zap.Logger
+	uc     cases.GroupUseCase
+}
+
+func NewGroupHandler(logger *zap.Logger, uc cases.GroupUseCase) *GroupHandler {
+	return &GroupHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// GroupList обрабатывает GET /api/v1/groups
+// @Summary      Группы пользователей (оператор)
+// @ID           listGroups
+// @Tags         groups
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {object}  dtoResp.GroupsListResponse
+// @Failure      401  {object}  respond.ErrorResponse
+// @Failure      500  {object}  respond.ErrorResponse
+// @Router       /api/v1/groups [get]
+func (h *GroupHandler) GroupList(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.uc.ListGroups(r.Context())
+	if err != nil {
+		h.logger.Error("group list failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	response := dtoResp.GroupsListResponse{
+		Groups: make([]dtoResp.GroupResponse, len(groups)),
+	}
+	for i, g := range groups {
+		response.Groups[i] = toGroupResponse(g)
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// GroupCreate обрабатывает POST /api/v1/groups
+// @Summary      Создать группу пользователей (оператор)
+// @ID           createGroup
+// @Description  Группа объединяет пользователей, например автопарк или школьный класс. Менять группу могут ее создатель,
+// @Description  операторы из managers и публикаторы
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request  body      dtoReq.GroupCreateRequest  true  "Название, менеджеры и участники"
+// @Success      201      {object}  dtoResp.GroupResponse
+// @Failure      400      {object}  respond.ErrorResponse  "Неверный запрос"
+// @Failure      401      {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      500      {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/groups [post]
+func (h *GroupHandler) GroupCreate(w http.ResponseWriter, r *http.Request) {
+	var req dtoReq.GroupCreateRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	created, err := h.uc.CreateGroup(r.Context(), entity.Group{
+		Name:     req.Name,
+		Descr:    req.Descr,
+		Managers: req.Managers,
+	}, req.UserIDs)
+	if err != nil {
+		h.logger.Error("group create failed", zap.Error(err))
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusCreated, toGroupResponse(created))
+}
+
+// GroupGet обрабатывает GET /api/v1/groups/{group_id}
+// @Summary      Группа пользователей (оператор)
+// @ID           getGroup
+// @Tags         groups
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        group_id  path      int  true  "ID группы"
+// @Success      200       {object}  dtoResp.GroupResponse
+// @Failure      400       {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401       {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404       {object}  respond.ErrorResponse  "Группа не найдена"
+// @Failure      500       {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/groups/{group_id} [get]
+func (h *GroupHandler) GroupGet(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.groupID(w, r)
+	if !ok {
+		return
+	}
+
+	group, err := h.uc.ReadGroup(r.Context(), id)
+	if err != nil {
+		h.logFailure("group read failed", err, id)
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toGroupResponse(group))
+}
+
+// GroupUpdate обрабатывает PATCH /api/v1/groups/{group_id}
+// @Summary      Изменить группу пользователей (менеджер группы)
+// @ID           updateGroup
+// @Description  Меняет только переданные поля; managers заменяет список менеджеров целиком
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        group_id  path      int                       true  "ID группы"
+// @Param        request   body      dtoReq.GroupPatchRequest  true  "Изменяемые поля"
+// @Success      200       {object}  dtoResp.GroupResponse
+// @Failure      400       {object}  respond.ErrorResponse  "Неверный ID или запрос"
+// @Failure      401       {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403       {object}  respond.ErrorResponse  "Оператор не управляет группой"
+// @Failure      404       {object}  respond.ErrorResponse  "Группа не найдена"
+// @Failure      500       {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/groups/{group_id} [patch]
+func (h *GroupHandler) GroupUpdate(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.groupID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dtoReq.GroupPatchRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	updated, err := h.uc.UpdateGroup(r.Context(), id, entity.GroupPatch{
+		Name:     req.Name,
+		Descr:    req.Descr,
+		Managers: req.Managers,
+	})
+	if err != nil {
+		h.logFailure("group update failed", err, id)
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toGroupResponse(updated))
+}
+
+// GroupDelete обрабатывает DELETE /api/v1/groups/{group_id}
+// @Summary      Удалить группу пользователей (менеджер группы)
+// @ID           deleteGroup
+// @Description  Удаляет группу вместе с участниками и получателем вебхуков группы
+// @Tags         groups
+// @Security     ApiKeyAuth
+// @Param        group_id  path  int  true  "ID группы"
+// @Success      204
+// @Failure      400  {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401  {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403  {object}  respond.ErrorResponse  "Оператор не управляет группой"
+// @Failure      404  {object}  respond.ErrorResponse  "Группа не найдена"
+// @Failure      500  {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/groups/{group_id} [delete]
+func (h *GroupHandler) GroupDelete(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.groupID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.DeleteGroup(r.Context(), id); err != nil {
+		h.logFailure("group delete failed", err, id)
+		h.respondWithError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GroupMembers обрабатывает GET /api/v1/groups/{group_id}/members
+// @Summary      Участники группы (оператор)
+// @ID           listGroupMembers
+// @Tags         groups
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        group_id  path      int  true  "ID группы"
+// @Success      200       {object}  dtoResp.GroupMembersResponse
+// @Failure      400       {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401       {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404       {object}  respond.ErrorResponse  "Группа не найдена"
+// @Failure      500       {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/groups/{group_id}/members [get]
+func (h *GroupHandler) GroupMembers(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.groupID(w, r)
+	if !ok {
+		return
+	}
+
+	userIDs, err := h.uc.GroupMembers(r.Context(), id)
+	if err != nil {
+		h.logFailure("group members read failed", err, id)
+		h.respondWithError(w, err)
+		return
+	}
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, dtoResp.GroupMembersResponse{GroupID: id, UserIDs: userIDs})
+}
+
+// GroupMembersAdd обрабатывает POST /api/v1/groups/{group_id}/members
+// @Summary      Добавить участников группы (менеджер группы)
+// @ID           addGroupMembers
+// @Description  Пользователи, которые уже состоят в группе, пропускаются; added — число добавленных
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        group_id  path      int                         true  "ID группы"
+// @Param        request   body      dtoReq.GroupMembersRequest  true  "ID пользователей"
+// @Success      200       {object}  dtoResp.GroupMembersAddedResponse
+// @Failure      400       {object}  respond.ErrorResponse  "Неверный ID или запрос"
+// @Failure      401       {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403       {object}  respond.ErrorResponse  "Оператор не управляет группой"
+// @Failure      404       {object}  respond.ErrorResponse  "Группа не найдена"
+// @Failure      500       {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/groups/{group_id}/members [post]
+func (h *GroupHandler) GroupMembersAdd(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.groupID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dtoReq.GroupMembersRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	added, err := h.uc.AddGroupMembers(r.Context(), id, req.UserIDs)
+	if err != nil {
+		h.logFailure("group members add failed", err, id)
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, dtoResp.GroupMembersAddedResponse{Added: added})
+}
+
+// GroupMemberRemove обрабатывает DELETE /api/v1/groups/{group_id}/members/{user_id}
+// @Summary      Исключить участника группы (менеджер группы)
+// @ID           removeGroupMember
+// @Tags         groups
+// @Security     ApiKeyAuth
+// @Param        group_id  path  int     true  "ID группы"
+// @Param        user_id   path  string  true  "ID пользователя"
+// @Success      204
+// @Failure      400  {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401  {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403  {object}  respond.ErrorResponse  "Оператор не управляет группой"
+// @Failure      404  {object}  respond.ErrorResponse  "Группа не найдена или пользователь в ней не состоит"
+// @Failure      500  {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/groups/{group_id}/members/{user_id} [delete]
+func (h *GroupHandler) GroupMemberRemove(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.groupID(w, r)
+	if !ok {
+		return
+	}
+	userID := chi.URLParam(r, "user_id")
+	if userID == "" || len(userID) > maxUserIDLength {
+		respond.Error(w, h.logger, http.StatusBadRequest, "user_id is required and must be at most 127 characters")
+		return
+	}
+
+	if err := h.uc.RemoveGroupMember(r.Context(), id, userID); err != nil {
+		h.logFailure("group member remove failed", err, id)
+		h.respondWithError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GroupWebhookPut обрабатывает PUT /api/v1/groups/{group_id}/webhook
+// @Summary      Задать получателя вебхуков группы (менеджер группы)
+// @ID           putGroupWebhook
+// @Description  Получатель группы получает только событие group.alert: один сводный вебхук на каждую проверку, в которой
+// @Description  участник группы вошел в зоны. Повторный вызов заменяет URL и секрет и включает получателя
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        group_id  path      int                         true  "ID группы"
+// @Param        request   body      dtoReq.GroupWebhookRequest  true  "URL и секрет получателя"
+// @Success      200       {object}  dtoResp.GroupResponse
+// @Failure      400       {object}  respond.ErrorResponse  "Неверный ID или URL"
+// @Failure      401       {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403       {object}  respond.ErrorResponse  "Оператор не управляет группой"
+// @Failure      404       {object}  respond.ErrorResponse  "Группа не найдена"
+// @Failure      500       {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/groups/{group_id}/webhook [put]
+func (h *GroupHandler) GroupWebhookPut(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.groupID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dtoReq.GroupWebhookRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	group, err := h.uc.SetGroupWebhook(r.Context(), id, req.URL, req.Secret)
+	if err != nil {
+		h.logFailure("group webhook set failed", err, id)
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toGroupResponse(group))
+}
+
+// GroupWebhookDelete обрабатывает DELETE /api/v1/groups/{group_id}/webhook
+// @Summary      Удалить получателя вебхуков группы (менеджер группы)
+// @ID           deleteGroupWebhook
+// @Description  Удаляет получателя вместе с его вебхуками, включая недоставленные
+// @Tags         groups
+// @Security     ApiKeyAuth
+// @Param        group_id  path  int  true  "ID группы"
+// @Success      204
+// @Failure      400  {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401  {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403  {object}  respond.ErrorResponse  "Оператор не управляет группой"
+// @Failure      404  {object}  respond.ErrorResponse  "Группа или ее получатель не найдены"
+// @Failure      500  {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/groups/{group_id}/webhook [delete]
+func (h *GroupHandler) GroupWebhookDelete(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.groupID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.DeleteGroupWebhook(r.Context(), id); err != nil {
+		h.logFailure("group webhook delete failed", err, id)
+		h.respondWithError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GroupStatus обрабатывает GET /api/v1/groups/{group_id}/status
+// @Summary      Участники группы в зонах (оператор)
+// @ID           getGroupStatus
+// @Description  Участники, которые по последней проверке координат находятся в действующих зонах, и зоны каждого из них
+// @Tags         groups
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        group_id  path      int  true  "ID группы"
+// @Success      200       {object}  dtoResp.GroupStatusResponse
+// @Failure      400       {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401       {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404       {object}  respond.ErrorResponse  "Группа не найдена"
+// @Failure      500       {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/groups/{group_id}/status [get]
+func (h *GroupHandler) GroupStatus(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.groupID(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.uc.GroupStatus(r.Context(), id)
+	if err != nil {
+		h.logFailure("group status read failed", err, id)
+		h.respondWithError(w, err)
+		return
+	}
+
+	response := dtoResp.GroupStatusResponse{
+		GroupID:         status.Group.ID,
+		Name:            status.Group.Name,
+		MembersTotal:    status.Group.MemberCount,
+		MembersInDanger: make([]dtoResp.GroupMemberInDangerResponse, len(status.MembersInDanger)),
+	}
+	for i, member := range status.MembersInDanger {
+		zones := make([]dtoResp.GroupMemberZoneResponse, len(member.Zones))
+		for j, zone := range member.Zones {
+			zones[j] = dtoResp.GroupMemberZoneResponse{
+				IncidentID: zone.IncidentID,
+				Name:       zone.Name,
+				Severity:   zone.Severity,
+				EnteredAt:  zone.EnteredAt,
+			}
+		}
+		response.MembersInDanger[i] = dtoResp.GroupMemberInDangerResponse{UserID: member.UserID, Zones: zones}
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+func (h *GroupHandler) groupID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "group_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return 0, false
+	}
+	return id, true
+}
+
+// logFailure не логирует ожидаемые ответы 4xx
+func (h *GroupHandler) logFailure(msg string, err error, groupID int) {
+	if errors.Is(err, entity.ErrGroupNotFound) ||
+		errors.Is(err, entity.ErrGroupMemberNotFound) ||
+		errors.Is(err, entity.ErrGroupWebhookNotFound) ||
+		errors.Is(err, entity.ErrInvalidWebhookURL) ||
+		errors.Is(err, entity.ErrForbidden) {
+		return
+	}
+	h.logger.Error(msg,
+		zap.Error(err),
+		zap.Int("id", groupID))
+}
+
+func (h *GroupHandler) respondWithError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidWebhookURL):
+		respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entity.ErrGroupNotFound),
+		errors.Is(err, entity.ErrGroupMemberNotFound),
+		errors.Is(err, entity.ErrGroupWebhookNotFound):
+		respond.Error(w, h.logger, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrForbidden):
+		respond.Error(w, h.logger, http.StatusForbidden, err.Error())
+	default:
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+	}
+}
+
+func toGroupResponse(g *entity.Group) dtoResp.GroupResponse {
+	response := dtoResp.GroupResponse{
+		GroupID:     g.ID,
+		Name:        g.Name,
+		Descr:       g.Descr,
+		Managers:    g.Managers,
+		MemberCount: g.MemberCount,
+		CreatedBy:   g.CreatedBy,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+	if response.Managers == nil {
+		response.Managers = []string{}
+	}
+	if g.Webhook != nil {
+		response.Webhook = &dtoResp.GroupWebhookResponse{
+			EndpointID: g.Webhook.ID,
+			URL:        g.Webhook.URL,
+			HasSecret:  g.Webhook.Secret != "",
+			Enabled:    g.Webhook.Enabled,
+		}
+	}
+	return response
+}
@@ -1,112 +1,162 @@
 package http
 
 import (
-	"encoding/json"
+	"context"
+	"fmt"
 	"net/http"
 	"time"
 
-	"github.com/4otis/geonotify-service/internal/cases"
 	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/internal/port/repo"
 	"github.com/4otis/geonotify-service/pkg/redis"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
+type WorkerStatus interface {
+	Running() bool
+}
+
 type HealthHandler struct {
-	logger *zap.Logger
-	dbPool *pgxpool.Pool
-	redis  *redis.Client
-	uc     cases.StatsUseCase
+	logger     *zap.Logger
+	dbPool     *pgxpool.Pool
+	redis      *redis.Client
+	schemaRepo repo.SchemaRepo
+	// worker nil — процесс не запускает воркер вебхуков (-mode api)
+	worker            WorkerStatus
+	migrationsVersion int64
 }
 
-func NewHealthHandler(logger *zap.Logger, dbPool *pgxpool.Pool, redis *redis.Client, uc cases.StatsUseCase) *HealthHandler {
+func NewHealthHandler(
+	logger *zap.Logger,
+	dbPool *pgxpool.Pool,
+	redis *redis.Client,
+	schemaRepo repo.SchemaRepo,
+	worker WorkerStatus,
+	migrationsVersion int64,
+) *HealthHandler {
 	return &HealthHandler{
-		logger: logger,
-		dbPool: dbPool,
-		redis:  redis,
-		uc:     uc,
+		logger:            logger,
+		dbPool:            dbPool,
+		redis:             redis,
+		schemaRepo:        schemaRepo,
+		worker:            worker,
+		migrationsVersion: migrationsVersion,
 	}
 }
 
-// HealthCheck обрабатывает GET /api/v1/system/health
-// @Summary      Health check
-// @Description  Проверка состояния системы
+// Liveness обрабатывает GET /healthz
+// @Summary      Liveness probe
+// @ID           liveness
+// @Description  Проверка, что процесс запущен и отвечает на запросы
 // @Tags         system
 // @Produce      json
-// @Success      200 {object} dtoResp.HealthResponse
-// @Failure      500 {object} dtoResp.HealthResponse
-// @Router       /api/v1/system/health [get]
-func (h *HealthHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// @Success      200 {object} dtoResp.LivenessResponse
+// @Router       /healthz [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	response := dtoResp.LivenessResponse{
+		Status:    "ok",
+		Timestamp: time.Now().UTC(),
+	}
 
-	status := "healthy"
-	httpStatus := http.StatusOK
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
 
-	dbHealthy := true
-	if err := h.dbPool.Ping(ctx); err != nil {
-		h.logger.Error("database health check failed", zap.Error(err))
-		dbHealthy = false
-		status = "unhealthy"
-		httpStatus = http.StatusServiceUnavailable
+// Readiness обрабатывает GET /readyz
+// @Summary      Readiness probe
+// @ID           readiness
+// @Description  Проверка готовности принимать трафик: БД, Redis, миграции, воркер вебхуков (кроме -mode api). Недоступный Redis не делает сервис неготовым: status=degraded, degraded=true
+// @Tags         system
+// @Produce      json
+// @Success      200 {object} dtoResp.ReadinessResponse
+// @Failure      503 {object} dtoResp.ReadinessResponse
+// @Router       /readyz [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	checks := map[string]dtoResp.DependencyStatus{
+		"database": h.probe(func() error {
+			return h.dbPool.Ping(ctx)
+		}),
+		"migrations": h.probe(func() error {
+			applied, err := h.schemaRepo.AppliedVersion(ctx)
+			if err != nil {
+				return err
+			}
+			if applied < h.migrationsVersion {
+				return fmt.Errorf("applied version %d is behind expected %d", applied, h.migrationsVersion)
+			}
+			return nil
+		}),
+	}
+	if h.worker != nil {
+		checks["webhook_worker"] = h.probe(func() error {
+			if !h.worker.Running() {
+				return fmt.Errorf("worker is not running")
+			}
+			return nil
+		})
 	}
 
-	redisHealthy := true
-	if err := h.redis.HealthCheck(); err != nil {
-		h.logger.Error("redis health check failed", zap.Error(err))
-		redisHealthy = false
-		if status == "healthy" {
+	status := "ready"
+	httpStatus := http.StatusOK
+
+	// Redis необязателен: без него сервис работает в деградированном режиме
+	// (инциденты из БД, вебхуки через опрос outbox) и остается готовым
+	degraded := false
+	if h.redis != nil {
+		redisCheck := h.probe(func() error {
+			return h.redis.HealthCheck(ctx)
+		})
+		checks["redis"] = redisCheck
+		if redisCheck.Status != "up" {
+			h.logger.Warn("redis is unavailable, serving in degraded mode",
+				zap.String("error", redisCheck.Error))
+			degraded = true
 			status = "degraded"
-			httpStatus = http.StatusPartialContent
 		}
 	}
 
-	var activeIncidents, inProgressWebhooks int
-	var metricsErr error
-
-	if dbHealthy {
-		activeIncidents, metricsErr = h.uc.GetActiveIncidentsCount(ctx)
-		if metricsErr != nil {
-			h.logger.Warn("failed to get active incidents count", zap.Error(metricsErr))
+	for name, check := range checks {
+		if name == "redis" {
+			continue
 		}
-
-		inProgressWebhooks, metricsErr = h.uc.GetPendingWebhooksCount(ctx)
-		if metricsErr != nil {
-			h.logger.Warn("failed to get in-progress webhooks count", zap.Error(metricsErr))
+		if check.Status != "up" {
+			h.logger.Warn("readiness check failed",
+				zap.String("dependency", name),
+				zap.String("error", check.Error))
+			status = "not ready"
+			httpStatus = http.StatusServiceUnavailable
 		}
 	}
 
-	response := dtoResp.HealthResponse{
-		Status:          status,
-		Timestamp:       time.Now().UTC(),
-		ActiveIncidents: activeIncidents,
-		PendingWebhooks: inProgressWebhooks,
+	response := dtoResp.ReadinessResponse{
+		Status:    status,
+		Degraded:  degraded,
+		Timestamp: time.Now().UTC(),
+		Checks:    checks,
 	}
 
-	responseWithDetails := struct {
-		dtoResp.HealthResponse
-		Components struct {
-			Database string `json:"database"`
-			Redis    string `json:"redis"`
-		} `json:"components,omitempty"`
-	}{
-		HealthResponse: response,
-	}
+	respond.JSON(w, h.logger, httpStatus, response)
+}
 
-	responseWithDetails.Components.Database = "healthy"
-	if !dbHealthy {
-		responseWithDetails.Components.Database = "unhealthy"
-	}
+func (h *HealthHandler) probe(check func() error) dtoResp.DependencyStatus {
+	start := time.Now()
+	err := check()
+	latency := float64(time.Since(start).Microseconds()) / 1000
 
-	responseWithDetails.Components.Redis = "healthy"
-	if !redisHealthy {
-		responseWithDetails.Components.Redis = "unhealthy"
+	if err != nil {
+		return dtoResp.DependencyStatus{
+			Status:    "down",
+			LatencyMs: latency,
+			Error:     err.Error(),
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(httpStatus)
-
-	if err := json.NewEncoder(w).Encode(responseWithDetails); err != nil {
-		h.logger.Error("failed to encode health response", zap.Error(err))
-		w.Write([]byte(`{"status":"error","message":"failed to encode response"}`))
+	return dtoResp.DependencyStatus{
+		Status:    "up",
+		LatencyMs: latency,
 	}
 }
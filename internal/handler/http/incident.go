@@ -1,18 +1,30 @@
 package http
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/4otis/geonotify-service/internal/cases"
 	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
 	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
 	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/pkg/geojson"
 	"github.com/go-chi/chi"
 	"go.uber.org/zap"
 )
 
+const maxGeometryBytes = 1 << 20
+
 type IncidentHandler struct {
 	logger *zap.Logger
 	uc     cases.IncidentUseCase
@@ -26,27 +38,51 @@ func NewIncidentHandler(logger *zap.Logger, uc cases.IncidentUseCase) *IncidentH
 }
 
 // @Summary      Создать инцидент (оператор)
-// @Description  Создать новую опасную зону (требуется API key)
+// @ID           createIncident
+// @Description  Создать новую опасную зону (требуется API key). Вместо координат можно передать address — он будет геокодирован.
+// @Description  Редактор создает только черновики; без status зона публикуется сразу, если это разрешено роли и ревью не обязательно
 // @Tags         incidents
 // @Accept       json
 // @Produce      json
 // @Security     ApiKeyAuth
 // @Param        request        body      dtoReq.IncidentCreateRequest  true  "Данные инцидента"
+// @Param        check_overlap  query     bool                          false "Отклонить зону, перекрывающую активные (по умолчанию — INCIDENT_OVERLAP_CHECK)"
 // @Success      201            {object}  dtoResp.IncidentCreateResponse
-// @Failure      400            {string}  string  "Неверный формат данных"
-// @Failure      401            {string}  string  "Не авторизован"
-// @Failure      500            {string}  string  "Внутренняя ошибка сервера"
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный формат данных"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Публикация недоступна"
+// @Failure      409            {object}  dtoResp.IncidentOverlapResponse  "Зона перекрывает активные зоны или критическую зону нельзя опубликовать без одобрения"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Failure      502            {object}  respond.ErrorResponse  "Сервис геокодирования недоступен"
 // @Router       /api/v1/incidents [post]
 func (h *IncidentHandler) IncidentCreate(w http.ResponseWriter, r *http.Request) {
+	var checkOverlap *bool
+	if v := r.URL.Query().Get("check_overlap"); v != "" {
+		check, err := strconv.ParseBool(v)
+		if err != nil {
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid check_overlap parameter (must be true or false)")
+			return
+		}
+		checkOverlap = &check
+	}
+
 	var req dtoReq.IncidentCreateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if err := bind.Decode(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
 		return
 	}
 
-	isValid, msg := h.validateIncidentRequest(req.Name, req.Latitude, req.Longitude, req.Radius)
-	if !isValid {
-		http.Error(w, msg, http.StatusBadRequest)
+	// нулевые координаты означают создание по адресу, переводить нечего
+	if req.Latitude != 0 || req.Longitude != 0 {
+		if msg := toWGS84(req.CRS, &req.Latitude, &req.Longitude); msg != "" {
+			respond.Error(w, h.logger, http.StatusBadRequest, msg)
+			return
+		}
+	}
+
+	// диапазоны координат проверяются уже в WGS84
+	if err := bind.Struct(&req); err != nil {
+		respond.Invalid(w, h.logger, err)
 		return
 	}
 
@@ -56,12 +92,30 @@ func (h *IncidentHandler) IncidentCreate(w http.ResponseWriter, r *http.Request)
 		Latitude:  req.Latitude,
 		Longitude: req.Longitude,
 		Radius:    req.Radius,
+
+		Schedule:            req.Schedule,
+		ScheduleDurationMin: req.ScheduleDurationMin,
+		ExpiresAt:           resolveExpiresAt(req.ExpiresAt, req.TTLMinutes),
+		Address:             req.Address,
+		Status:              req.Status,
+		Severity:            req.Severity,
+		State:               req.State,
+		Visibility:          req.Visibility,
 	}
 
-	incidentID, err := h.uc.CreateIncident(r.Context(), incident)
+	incidentID, err := h.uc.CreateIncident(r.Context(), incident, checkOverlap)
+	var overlapErr *entity.OverlapError
+	if errors.As(err, &overlapErr) {
+		respond.JSON(w, h.logger, http.StatusConflict, dtoResp.IncidentOverlapResponse{
+			Error:       http.StatusText(http.StatusConflict),
+			Message:     overlapErr.Error(),
+			IncidentIDs: overlapErr.IncidentIDs,
+		})
+		return
+	}
 	if err != nil {
 		h.logger.Error("incident create failed", zap.Error(err))
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		h.respondWithWriteError(w, err)
 		return
 	}
 
@@ -69,29 +123,30 @@ func (h *IncidentHandler) IncidentCreate(w http.ResponseWriter, r *http.Request)
 		IncidentID: incidentID,
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.logger.Error("failed to encode response", zap.Error(err))
-	}
+	respond.JSON(w, h.logger, http.StatusCreated, response)
 }
 
 // @Summary      Получить инцидент по ID (оператор)
+// @ID           getIncident
 // @Description  Детали конкретной зоны опасности
 // @Tags         incidents
 // @Produce      json
 // @Security     ApiKeyAuth
-// @Param        incident_id  path    string  true  "ID инцидента"
+// @Param        incident_id      path    int     true   "ID инцидента"
+// @Param        If-None-Match    header  string  false  "ETag из предыдущего ответа"
+// @Param        Accept-Language  header  string  false  "Языки клиента: название и описание отдаются в переводе"
 // @Success      200 {object} dtoResp.IncidentResponse
-// @Failure      401 {string} string "Не авторизован"
-// @Failure      404 {string} string "Инцидент не найден"
-// @Failure      500 {string} string "Внутренняя ошибка сервера"
+// @Header       200 {string} ETag "Версия инцидента"
+// @Success      304 "Инцидент не изменился"
+// @Failure      400 {object} respond.ErrorResponse "Неверный ID"
+// @Failure      401 {object} respond.ErrorResponse "Не авторизован"
+// @Failure      404 {object} respond.ErrorResponse "Инцидент не найден"
+// @Failure      500 {object} respond.ErrorResponse "Внутренняя ошибка сервера"
 // @Router       /api/v1/incidents/{incident_id} [get]
 func (h *IncidentHandler) IncidentGet(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
 	if err != nil {
-		http.Error(w, "id required/not valid", http.StatusBadRequest)
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
 		return
 	}
 
@@ -101,47 +156,71 @@ func (h *IncidentHandler) IncidentGet(w http.ResponseWriter, r *http.Request) {
 			zap.Error(err),
 			zap.Int("id", id))
 		if err == entity.ErrIncidentNotFound {
-			http.Error(w, "incident not found", http.StatusNotFound)
+			respond.Error(w, h.logger, http.StatusNotFound, "incident not found")
 		} else {
-			http.Error(w, "internal error", http.StatusInternalServerError)
+			respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
 		}
 		return
 	}
 
-	response := dtoResp.IncidentResponse{
-		IncidentID: incident.ID,
-		Name:       incident.Name,
-		Descr:      incident.Descr,
-		Latitude:   incident.Latitude,
-		Longitude:  incident.Longitude,
-		Radius:     incident.Radius,
-		IsActive:   incident.IsActive,
-		CreatedAt:  incident.CreatedAt,
-		UpdatedAt:  incident.UpdatedAt,
+	locales := acceptLocales(r)
+	w.Header().Set("Vary", "Accept-Language")
+	if respond.NotModified(w, r, localeETag(fmt.Sprintf("%d-%d", incident.ID, incident.UpdatedAt.UnixMicro()), locales)) {
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.logger.Error("failed to encode response", zap.Error(err))
+	localized, err := h.uc.LocalizeIncidents(r.Context(), []*entity.Incident{incident}, locales)
+	if err != nil {
+		h.logger.Error("incident localization failed",
+			zap.Error(err),
+			zap.Int("id", id))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
 	}
+
+	response := toIncidentResponse(localized[0], time.Now())
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
 }
 
 // @Summary      Получить список инцидентов с пагинацией (оператор)
-// @Description  Получить все инциденты с поддержкой пагинации
+// @ID           listIncidents
+// @Description  Получить все инциденты с поддержкой пагинации. С параметром cursor (пустой — первая страница)
+// @Description  список листается по курсору из next_cursor без подсчета общего числа страниц — так дальние
+// @Description  страницы не замедляются на больших таблицах. page вместе с cursor не передается
 // @Tags         incidents
 // @Produce      json
 // @Security     ApiKeyAuth
 // @Param        page           query     int     false  "Номер страницы (по умолчанию 1)"
+// @Param        cursor         query     string  false  "Курсор страницы из next_cursor предыдущего ответа"
+// @Param        count          query     string  false  "Точное число страниц или оценка (total_estimated: true)" Enums(exact, estimated)
 // @Param        limit          query     int     false  "Лимит на страницу (по умолчанию 10, максимум 100)"
+// @Param        status         query     string  false  "Фильтр по статусу" Enums(draft, published, archived)
+// @Param        If-None-Match  header    string  false  "ETag из предыдущего ответа"
+// @Param        Accept-Language  header  string  false  "Языки клиента: названия и описания отдаются в переводе"
 // @Success      200            {object}  dtoResp.IncidentsListResponse
-// @Failure      400            {string}  string  "Неверные параметры пагинации"
-// @Failure      401            {string}  string  "Не авторизован"
-// @Failure      500            {string}  string  "Внутренняя ошибка сервера"
+// @Header       200            {string}  ETag  "Версия набора инцидентов для этих параметров"
+// @Success      304            "Список не изменился"
+// @Failure      400            {object}  respond.ErrorResponse  "Неверные параметры пагинации или курсор"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
 // @Router       /api/v1/incidents [get]
 func (h *IncidentHandler) IncidentList(w http.ResponseWriter, r *http.Request) {
 	pageStr := r.URL.Query().Get("page")
 	limitStr := r.URL.Query().Get("limit")
+	status := r.URL.Query().Get("status")
+	// наличие cursor, даже пустого, включает постраничный вывод по курсору
+	byCursor := r.URL.Query().Has("cursor")
+	estimate, ok := countMode(r)
+	if !ok {
+		respond.Error(w, h.logger, http.StatusBadRequest, "invalid count parameter (must be exact or estimated)")
+		return
+	}
+
+	if byCursor && pageStr != "" {
+		respond.Error(w, h.logger, http.StatusBadRequest, "page and cursor parameters are mutually exclusive")
+		return
+	}
 
 	page := 1
 	limit := 10
@@ -149,7 +228,7 @@ func (h *IncidentHandler) IncidentList(w http.ResponseWriter, r *http.Request) {
 	if pageStr != "" {
 		p, err := strconv.Atoi(pageStr)
 		if err != nil || p < 1 {
-			http.Error(w, "invalid page parameter (must be >= 1)", http.StatusBadRequest)
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid page parameter (must be >= 1)")
 			return
 		}
 		page = p
@@ -158,82 +237,134 @@ func (h *IncidentHandler) IncidentList(w http.ResponseWriter, r *http.Request) {
 	if limitStr != "" {
 		l, err := strconv.Atoi(limitStr)
 		if err != nil || l < 1 {
-			http.Error(w, "invalid limit parameter (must be >= 1)", http.StatusBadRequest)
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid limit parameter (must be >= 1)")
 			return
 		}
 		limit = l
 	}
 
-	result, err := h.uc.ReadIncidentsWithPagination(r.Context(), page, limit)
+	switch status {
+	case "", entity.IncidentDraft, entity.IncidentPublished, entity.IncidentArchived:
+	default:
+		respond.Error(w, h.logger, http.StatusBadRequest, "invalid status parameter (must be draft, published or archived)")
+		return
+	}
+
+	// ETag зависит от версии набора инцидентов и параметров запроса; без версии ответ отдается целиком
+	w.Header().Set("Vary", "Accept-Language")
+	version, err := h.uc.IncidentsVersion(r.Context())
+	if err != nil {
+		h.logger.Warn("failed to read incidents version", zap.Error(err))
+	} else if respond.NotModified(w, r, listETag(version+"|"+strings.Join(cases.VisibleIncidents(r.Context()), ","), r)) {
+		return
+	}
+
+	if byCursor {
+		h.incidentListAfter(w, r, r.URL.Query().Get("cursor"), limit, status)
+		return
+	}
+
+	result, err := h.uc.ReadIncidentsWithPagination(r.Context(), page, limit, status, estimate)
 	if err != nil {
 		h.logger.Error("incident list failed",
 			zap.Error(err),
 			zap.Int("page", page),
 			zap.Int("limit", limit))
 
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
 		return
 	}
 
-	incidents := make([]dtoResp.IncidentResponse, len(result.Incidents))
-	for i, inc := range result.Incidents {
-		incidents[i] = dtoResp.IncidentResponse{
-			IncidentID: inc.ID,
-			Name:       inc.Name,
-			Descr:      inc.Descr,
-			Latitude:   inc.Latitude,
-			Longitude:  inc.Longitude,
-			Radius:     inc.Radius,
-			IsActive:   inc.IsActive,
-			CreatedAt:  inc.CreatedAt,
-			UpdatedAt:  inc.UpdatedAt,
-		}
+	localized, err := h.uc.LocalizeIncidents(r.Context(), result.Incidents, acceptLocales(r))
+	if err != nil {
+		h.logger.Error("incident list localization failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	now := time.Now()
+	incidents := make([]dtoResp.IncidentResponse, len(localized))
+	for i, inc := range localized {
+		incidents[i] = toIncidentResponse(inc, now)
 	}
 
 	response := dtoResp.IncidentsListResponse{
-		Incidents:  incidents,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: result.TotalPages,
+		Incidents:      incidents,
+		Page:           page,
+		Limit:          limit,
+		TotalPages:     result.TotalPages,
+		TotalEstimated: result.Estimated,
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+func (h *IncidentHandler) incidentListAfter(w http.ResponseWriter, r *http.Request, cursor string, limit int, status string) {
+	result, err := h.uc.ReadIncidentsAfter(r.Context(), cursor, limit, status)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCursor) {
+			respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("incident list failed",
+			zap.Error(err),
+			zap.String("cursor", cursor),
+			zap.Int("limit", limit))
+
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	localized, err := h.uc.LocalizeIncidents(r.Context(), result.Incidents, acceptLocales(r))
+	if err != nil {
+		h.logger.Error("incident list localization failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.logger.Error("failed to encode response", zap.Error(err))
+	now := time.Now()
+	incidents := make([]dtoResp.IncidentResponse, len(localized))
+	for i, inc := range localized {
+		incidents[i] = toIncidentResponse(inc, now)
 	}
+
+	respond.JSON(w, h.logger, http.StatusOK, dtoResp.IncidentsListResponse{
+		Incidents:  incidents,
+		Limit:      limit,
+		NextCursor: result.NextCursor,
+	})
 }
 
 // @Summary      Обновить инцидент (оператор)
-// @Description  Полное обновление данных существующей опасной зоны (PUT)
+// @ID           updateIncident
+// @Description  Полное обновление данных существующей опасной зоны (PUT).
+// @Description  Повышение опубликованной зоны до critical не применяется сразу: остальные поля меняются, а на критичность создается заявка (202)
 // @Tags         incidents
 // @Accept       json
 // @Produce      json
 // @Security     ApiKeyAuth
 // @Param        incident_id    path      int                            true  "ID инцидента"
 // @Param        request        body      dtoReq.IncidentUpdateRequest   true  "Полные данные инцидента"
-// @Success      200            {string}  string                         "Инцидент обновлен"
-// @Failure      400            {string}  string                         "Неверный формат данных"
-// @Failure      401            {string}  string                         "Не авторизован"
-// @Failure      404            {string}  string                         "Инцидент не найден"
-// @Failure      500            {string}  string                         "Внутренняя ошибка сервера"
+// @Success      200            {object}  respond.MessageResponse                         "Инцидент обновлен"
+// @Success      202            {object}  dtoResp.ApprovalResponse                        "Повышение до critical ждет одобрения"
+// @Failure      400            {object}  respond.ErrorResponse                         "Неверный формат данных"
+// @Failure      401            {object}  respond.ErrorResponse                         "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse                         "Зона опубликована, изменить ее может только публикатор"
+// @Failure      404            {object}  respond.ErrorResponse                         "Инцидент не найден"
+// @Failure      409            {object}  respond.ErrorResponse                         "is_active: зона в архивной стадии не включается, или зона ждет решения по заявке"
+// @Failure      500            {object}  respond.ErrorResponse                         "Внутренняя ошибка сервера"
+// @Failure      502            {object}  respond.ErrorResponse                         "Сервис геокодирования недоступен"
 // @Router       /api/v1/incidents/{incident_id} [put]
 func (h *IncidentHandler) IncidentUpdate(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
 	if err != nil {
-		http.Error(w, "id required/not valid", http.StatusBadRequest)
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
 		return
 	}
 
 	var req dtoReq.IncidentUpdateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
-		return
-	}
-
-	isValid, msg := h.validateIncidentRequest(req.Name, req.Latitude, req.Longitude, req.Radius)
-	if !isValid {
-		http.Error(w, msg, http.StatusBadRequest)
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
 		return
 	}
 
@@ -245,43 +376,169 @@ func (h *IncidentHandler) IncidentUpdate(w http.ResponseWriter, r *http.Request)
 		Longitude: req.Longitude,
 		Radius:    req.Radius,
 		IsActive:  req.IsActive,
+
+		Schedule:            req.Schedule,
+		ScheduleDurationMin: req.ScheduleDurationMin,
+		ExpiresAt:           resolveExpiresAt(req.ExpiresAt, req.TTLMinutes),
+		Address:             req.Address,
+		Severity:            req.Severity,
+		Visibility:          req.Visibility,
 	}
 
-	err = h.uc.UpdateIncident(r.Context(), incident)
+	approval, err := h.uc.UpdateIncident(r.Context(), incident)
 	if err != nil {
 		h.logger.Error("incident update failed",
 			zap.Error(err),
 			zap.Int("id", id))
 
-		if err == entity.ErrIncidentNotFound {
-			http.Error(w, "incident not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "internal error", http.StatusInternalServerError)
-		}
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	if approval != nil {
+		respond.JSON(w, h.logger, http.StatusAccepted, toApprovalResponse(approval))
+		return
+	}
+
+	respond.Message(w, h.logger, http.StatusOK, "incident updated")
+}
+
+// @Summary      Частично обновить инцидент (оператор)
+// @ID           patchIncident
+// @Description  Изменить только переданные поля опасной зоны (PATCH).
+// @Description  Повышение опубликованной зоны до critical не применяется сразу: остальные поля меняются, а на критичность создается заявка (202).
+// @Description  "expires_at": null снимает срок действия; без поля expires_at срок не меняется
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int                            true  "ID инцидента"
+// @Param        request        body      dtoReq.IncidentPatchRequest    true  "Изменяемые поля инцидента"
+// @Success      200            {object}  respond.MessageResponse                         "Инцидент обновлен"
+// @Success      202            {object}  dtoResp.ApprovalResponse                        "Повышение до critical ждет одобрения"
+// @Failure      400            {object}  respond.ErrorResponse                         "Неверный формат данных"
+// @Failure      401            {object}  respond.ErrorResponse                         "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse                         "Зона опубликована, изменить ее может только публикатор"
+// @Failure      404            {object}  respond.ErrorResponse                         "Инцидент не найден"
+// @Failure      409            {object}  respond.ErrorResponse                         "is_active: зона в архивной стадии не включается, или зона ждет решения по заявке"
+// @Failure      500            {object}  respond.ErrorResponse                         "Внутренняя ошибка сервера"
+// @Failure      502            {object}  respond.ErrorResponse                         "Сервис геокодирования недоступен"
+// @Router       /api/v1/incidents/{incident_id} [patch]
+func (h *IncidentHandler) IncidentPatch(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(`{"message": "incident updated"}`))
+	var req dtoReq.IncidentPatchRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	patch := entity.IncidentPatch{
+		Name:      req.Name,
+		Descr:     req.Descr,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Radius:    req.Radius,
+		IsActive:  req.IsActive,
+
+		Schedule:            req.Schedule,
+		ScheduleDurationMin: req.ScheduleDurationMin,
+		ExpiresAt:           resolvePatchExpiresAt(req.ExpiresAt, req.TTLMinutes),
+		Address:             req.Address,
+		Severity:            req.Severity,
+		Visibility:          req.Visibility,
+	}
+
+	approval, err := h.uc.UpdateIncidentPartial(r.Context(), id, patch)
+	if err != nil {
+		h.logger.Error("incident patch failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	if approval != nil {
+		respond.JSON(w, h.logger, http.StatusAccepted, toApprovalResponse(approval))
+		return
+	}
+
+	respond.Message(w, h.logger, http.StatusOK, "incident updated")
+}
+
+// @Summary      Задать форму зоны в GeoJSON (оператор)
+// @ID           setIncidentGeometry
+// @Description  Заменить форму опасной зоны: Point с radius_m (в properties для Feature), Polygon или MultiPolygon.
+// @Description  Внешние кольца — против часовой стрелки, дыры — по часовой, самопересечения не допускаются
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int       true  "ID инцидента"
+// @Param        request        body      object    true  "GeoJSON-геометрия или Feature"
+// @Success      200            {object}  respond.MessageResponse    "Форма зоны обновлена"
+// @Failure      400            {object}  respond.ErrorResponse    "Неверный GeoJSON"
+// @Failure      401            {object}  respond.ErrorResponse    "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse    "Зона опубликована, изменить ее может только публикатор"
+// @Failure      404            {object}  respond.ErrorResponse    "Инцидент не найден"
+// @Failure      413            {object}  respond.ErrorResponse    "Слишком большой GeoJSON"
+// @Failure      500            {object}  respond.ErrorResponse    "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/geometry [put]
+func (h *IncidentHandler) IncidentGeometry(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxGeometryBytes))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusRequestEntityTooLarge, "geometry is too large")
+		return
+	}
+
+	shape, err := geojson.Parse(body)
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.uc.UpdateIncidentGeometry(r.Context(), id, shapeGeometry(shape))
+	if err != nil {
+		h.logger.Error("incident geometry update failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	respond.Message(w, h.logger, http.StatusOK, "incident geometry updated")
 }
 
 // @Summary      Удалить инцидент (оператор)
+// @ID           deleteIncident
 // @Description  Мягкое удаление опасной зоны
 // @Tags         incidents
 // @Produce      json
 // @Security     ApiKeyAuth
 // @Param        incident_id    path      int     true  "ID инцидента"
-// @Success      200            {string}  string  "Инцидент удален"
-// @Failure      400            {string}  string  "Неверный ID"
-// @Failure      401            {string}  string  "Не авторизован"
-// @Failure      404            {string}  string  "Инцидент не найден"
-// @Failure      500            {string}  string  "Внутренняя ошибка сервера"
+// @Success      200            {object}  respond.MessageResponse  "Инцидент удален"
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Зона опубликована, удалить ее может только публикатор"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
 // @Router       /api/v1/incidents/{incident_id} [delete]
 func (h *IncidentHandler) IncidentDelete(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
 	if err != nil {
-		http.Error(w, "id required/not valid", http.StatusBadRequest)
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
 		return
 	}
 
@@ -291,32 +548,322 @@ func (h *IncidentHandler) IncidentDelete(w http.ResponseWriter, r *http.Request)
 			zap.Error(err),
 			zap.Int("id", id))
 
-		if err == entity.ErrIncidentNotFound {
-			http.Error(w, "incident not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "internal error", http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, entity.ErrIncidentNotFound):
+			respond.Error(w, h.logger, http.StatusNotFound, "incident not found")
+		case errors.Is(err, entity.ErrForbidden):
+			respond.Error(w, h.logger, http.StatusForbidden, err.Error())
+		default:
+			respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
 		}
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(`{"message": "incident deleted"}`))
+	respond.Message(w, h.logger, http.StatusOK, "incident deleted")
 }
 
-func (h *IncidentHandler) validateCoordinates(lat, lng float64) bool {
-	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+// @Summary      Пакетное изменение инцидентов (оператор)
+// @ID           batchIncidents
+// @Description  Включить, выключить или удалить несколько зон одной транзакцией. Если хотя бы одна зона не найдена или ждет решения по заявке, ничего не меняется. Доступно только публикатору.
+// @Description  Включение переводит запланированные и завершенные зоны в active, выключение — действующие в resolved
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request        body      dtoReq.IncidentBatchRequest  true  "ID инцидентов и действие"
+// @Success      200            {object}  dtoResp.IncidentBatchResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный формат данных"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      409            {object}  respond.ErrorResponse  "Зона в архивной стадии не включается или ждет решения по заявке"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/batch [patch]
+func (h *IncidentHandler) IncidentBatch(w http.ResponseWriter, r *http.Request) {
+	var req dtoReq.IncidentBatchRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	affected, err := h.uc.BatchIncidents(r.Context(), req.IDs, req.Action)
+	if err != nil {
+		h.logger.Error("incident batch failed",
+			zap.Error(err),
+			zap.String("action", req.Action),
+			zap.Ints("ids", req.IDs))
+
+		switch {
+		case errors.Is(err, entity.ErrInvalidBatchAction):
+			respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrIncidentNotFound):
+			respond.Error(w, h.logger, http.StatusNotFound, err.Error())
+		case errors.Is(err, entity.ErrForbidden):
+			respond.Error(w, h.logger, http.StatusForbidden, err.Error())
+		case errors.Is(err, entity.ErrInvalidStateTransition),
+			errors.Is(err, entity.ErrApprovalPending):
+			respond.Error(w, h.logger, http.StatusConflict, err.Error())
+		default:
+			respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		}
+		return
+	}
+
+	response := dtoResp.IncidentBatchResponse{
+		Action:   req.Action,
+		Affected: affected,
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
 }
 
-func (h *IncidentHandler) validateIncidentRequest(name string, lat, lng, radius float64) (bool, string) {
-	if name == "" {
-		return false, "name is required"
+// @Summary      Опубликовать инцидент (публикатор)
+// @ID           publishIncident
+// @Description  Перевести черновик или архивную зону в статус published: с этого момента она участвует в проверках.
+// @Description  При обязательном ревью публикатор не может быть автором или последним редактором зоны.
+// @Description  Критическая зона не публикуется сразу: создается заявка (202), зону выпускает одобривший ее второй оператор
+// @Tags         incidents
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int     true  "ID инцидента"
+// @Success      200            {object}  dtoResp.IncidentResponse
+// @Success      202            {object}  dtoResp.ApprovalResponse  "Зона критическая, заявка ждет одобрения"
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Недостаточно прав или публикация собственной правки"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      409            {object}  respond.ErrorResponse  "Зона уже опубликована или ждет одобрения"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/publish [post]
+func (h *IncidentHandler) IncidentPublish(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
 	}
-	if !h.validateCoordinates(lat, lng) {
-		return false, "invalid coordinates"
+
+	incident, approval, err := h.uc.PublishIncident(r.Context(), id)
+	if err != nil {
+		h.logger.Error("incident publish failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
 	}
-	if radius <= 0 {
-		return false, "radius_m must be > 0"
+
+	if approval != nil {
+		respond.JSON(w, h.logger, http.StatusAccepted, toApprovalResponse(approval))
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toIncidentResponse(incident, time.Now()))
+}
+
+// @Summary      Архивировать инцидент (публикатор)
+// @ID           archiveIncident
+// @Description  Снять зону с публикации или отложить черновик. Архивная зона не участвует в проверках, но ее можно опубликовать снова
+// @Tags         incidents
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int     true  "ID инцидента"
+// @Success      200            {object}  dtoResp.IncidentResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      409            {object}  respond.ErrorResponse  "Зона уже в архиве или ждет решения по заявке"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/archive [post]
+func (h *IncidentHandler) IncidentArchive(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	incident, err := h.uc.ArchiveIncident(r.Context(), id)
+	if err != nil {
+		h.logger.Error("incident archive failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toIncidentResponse(incident, time.Now()))
+}
+
+// @Summary      Перевести зону в другую стадию (оператор)
+// @ID           transitionIncident
+// @Description  Сменить стадию жизни опасности: planned → active, resolved; active → contained, resolved;
+// @Description  contained → active, resolved; resolved → active, archived. В проверках участвуют зоны в active и contained.
+// @Description  Черновики переводит и редактор, остальные зоны — публикатор. Получатели вебхуков видят incident.state_changed
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int                          true  "ID инцидента"
+// @Param        request        body      dtoReq.IncidentStateRequest  true  "Новая стадия"
+// @Success      200            {object}  dtoResp.IncidentResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID или стадия"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      409            {object}  respond.ErrorResponse  "Переход из текущей стадии не разрешен"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/state [post]
+func (h *IncidentHandler) IncidentTransition(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	var req dtoReq.IncidentStateRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	incident, err := h.uc.TransitionIncident(r.Context(), id, req.State)
+	if err != nil {
+		h.logger.Error("incident state transition failed",
+			zap.Error(err),
+			zap.Int("id", id),
+			zap.String("state", req.State))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toIncidentResponse(incident, time.Now()))
+}
+
+// respondWithWriteError отвечает на ошибки создания, обновления и смены статуса инцидента
+func (h *IncidentHandler) respondWithWriteError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, entity.ErrIncidentNotFound):
+		respond.Error(w, h.logger, http.StatusNotFound, "incident not found")
+	case errors.Is(err, entity.ErrTranslationNotFound),
+		errors.Is(err, entity.ErrRelationNotFound):
+		respond.Error(w, h.logger, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrForbidden),
+		errors.Is(err, entity.ErrSelfReview):
+		respond.Error(w, h.logger, http.StatusForbidden, err.Error())
+	case errors.Is(err, entity.ErrInvalidStatusTransition),
+		errors.Is(err, entity.ErrInvalidStateTransition),
+		errors.Is(err, entity.ErrApprovalRequired),
+		errors.Is(err, entity.ErrApprovalPending),
+		errors.Is(err, entity.ErrRelationExists):
+		respond.Error(w, h.logger, http.StatusConflict, err.Error())
+	case errors.Is(err, entity.ErrInvalidStatus),
+		errors.Is(err, entity.ErrInvalidSeverity),
+		errors.Is(err, entity.ErrInvalidVisibility),
+		errors.Is(err, entity.ErrInvalidSchedule),
+		errors.Is(err, entity.ErrInvalidExpiry),
+		errors.Is(err, entity.ErrAddressNotFound),
+		errors.Is(err, entity.ErrOutsideArea),
+		errors.Is(err, entity.ErrInvalidMerge),
+		errors.Is(err, entity.ErrInvalidState),
+		errors.Is(err, entity.ErrInvalidLocale),
+		errors.Is(err, entity.ErrInvalidComment),
+		errors.Is(err, entity.ErrInvalidRelation),
+		errors.Is(err, entity.ErrInvalidBacktest),
+		errors.Is(err, entity.ErrGeocodingDisabled):
+		respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entity.ErrGeocoderUnavailable):
+		respond.Error(w, h.logger, http.StatusBadGateway, "geocoding provider unavailable")
+	default:
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+	}
+}
+
+// shapeGeometry переводит разобранный GeoJSON в форму зоны; для полигонов считается охватывающий круг
+func shapeGeometry(shape *geojson.Shape) entity.IncidentGeometry {
+	geometry := entity.IncidentGeometry{
+		Latitude:  shape.Center[1],
+		Longitude: shape.Center[0],
+		Radius:    shape.Radius,
+	}
+	if len(shape.Polygons) > 0 {
+		geometry.Latitude, geometry.Longitude, geometry.Radius = shape.Polygons.BoundingCircle()
+		geometry.Polygons = shape.Polygons
+	}
+
+	return geometry
+}
+
+// resolveExpiresAt приводит expires_at/ttl_minutes из запроса к абсолютному времени истечения.
+// Взаимоисключение полей проверено тегами запроса
+func resolveExpiresAt(expiresAt *time.Time, ttlMinutes int) *time.Time {
+	if ttlMinutes == 0 {
+		return expiresAt
+	}
+
+	t := time.Now().Add(time.Duration(ttlMinutes) * time.Minute)
+	return &t
+}
+
+// resolvePatchExpiresAt — resolveExpiresAt для PATCH: явный null в expires_at
+// передается в патч нулевым временем и снимает срок действия
+func resolvePatchExpiresAt(expiresAt dtoReq.NullableTime, ttlMinutes int) *time.Time {
+	if expiresAt.Null() {
+		return &time.Time{}
+	}
+	return resolveExpiresAt(expiresAt.Value, ttlMinutes)
+}
+
+func listETag(version string, r *http.Request) string {
+	key := version + "|" + r.URL.Path + "?" + r.URL.Query().Encode() + "|" + strings.Join(acceptLocales(r), ",")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// visibility — видимость зоны для ответа: у зон из кэша, записанного до появления поля, она пустая
+func visibility(incident *entity.Incident) string {
+	if incident.Visibility == "" {
+		return entity.VisibilityPublic
+	}
+	return incident.Visibility
+}
+
+func toIncidentResponse(incident *entity.Incident, now time.Time) dtoResp.IncidentResponse {
+	var geometry json.RawMessage
+	if len(incident.Polygons) > 0 {
+		// полигоны уже проверены при сохранении, ошибка кодирования невозможна
+		geometry, _ = geojson.MultiPolygon(incident.Polygons).Marshal()
+	}
+
+	return dtoResp.IncidentResponse{
+		IncidentID: incident.ID,
+		Name:       incident.Name,
+		Descr:      incident.Descr,
+		Latitude:   incident.Latitude,
+		Longitude:  incident.Longitude,
+		Radius:     incident.Radius,
+		IsActive:   incident.IsActive,
+		State:      incident.State,
+		Status:     incident.Status,
+		Severity:   incident.Severity,
+		Source:     incident.Source,
+		Visibility: visibility(incident),
+		CreatedAt:  incident.CreatedAt,
+		UpdatedAt:  incident.UpdatedAt,
+
+		Schedule:            incident.Schedule,
+		ScheduleDurationMin: incident.ScheduleDurationMin,
+		NextActivation:      cases.NextActivation(incident, now),
+		ExpiresAt:           incident.ExpiresAt,
+		Address:             incident.Address,
+		CreatedBy:           incident.CreatedBy,
+		UpdatedBy:           incident.UpdatedBy,
+		PublishedBy:         incident.PublishedBy,
+		PublishedAt:         incident.PublishedAt,
+		StateChangedAt:      incident.StateChangedAt,
+		StateTimes:          incident.StateTimes,
+		Geometry:            geometry,
+		Relations:           toRelationResponses(incident.Relations),
 	}
-	return true, ""
 }
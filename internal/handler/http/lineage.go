@@ -0,0 +1,223 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/pkg/geojson"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// @Summary      Объединить зоны (публикатор)
+// @ID           mergeIncidents
+// @Description  Создать опубликованную зону из нескольких опубликованных: форма — MultiPolygon из их полигонов
+// @Description  (круглые зоны приближаются многоугольниками), уровень опасности — наибольший, срок действия — наибольший.
+// @Description  Исходные зоны переводятся в архив, связь с новой зоной видна в истории /lineage
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request        body      dtoReq.IncidentMergeRequest  true  "ID объединяемых зон и данные новой зоны"
+// @Success      201            {object}  dtoResp.IncidentResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный формат данных"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      409            {object}  respond.ErrorResponse  "Одна из зон не опубликована или ждет решения по заявке"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/merge [post]
+func (h *IncidentHandler) IncidentMerge(w http.ResponseWriter, r *http.Request) {
+	var req dtoReq.IncidentMergeRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	incident, err := h.uc.MergeIncidents(r.Context(), req.IDs, req.Name, req.Descr)
+	if err != nil {
+		h.logger.Error("incident merge failed",
+			zap.Error(err),
+			zap.Ints("ids", req.IDs))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusCreated, toIncidentResponse(incident, time.Now()))
+}
+
+// @Summary      Разделить зону (публикатор)
+// @ID           splitIncident
+// @Description  Заменить опубликованную зону несколькими: форма каждой части задается GeoJSON, как в PUT /geometry,
+// @Description  описание, уровень опасности, расписание и срок действия наследуются. Исходная зона переводится в архив
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int                          true  "ID инцидента"
+// @Param        request        body      dtoReq.IncidentSplitRequest  true  "Части зоны"
+// @Success      201            {object}  dtoResp.IncidentSplitResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный формат данных или GeoJSON"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      409            {object}  respond.ErrorResponse  "Зона не опубликована или ждет решения по заявке"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/split [post]
+func (h *IncidentHandler) IncidentSplit(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	var req dtoReq.IncidentSplitRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	parts := make([]entity.IncidentPart, len(req.Parts))
+	for i, part := range req.Parts {
+		shape, err := geojson.Parse(part.Geometry)
+		if err != nil {
+			respond.Error(w, h.logger, http.StatusBadRequest, fmt.Sprintf("parts[%d].geometry: %v", i, err))
+			return
+		}
+		parts[i] = entity.IncidentPart{
+			Name:     part.Name,
+			Geometry: shapeGeometry(shape),
+		}
+	}
+
+	incidents, err := h.uc.SplitIncident(r.Context(), id, parts)
+	if err != nil {
+		h.logger.Error("incident split failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	now := time.Now()
+	response := dtoResp.IncidentSplitResponse{
+		Incidents: make([]dtoResp.IncidentResponse, len(incidents)),
+	}
+	for i, incident := range incidents {
+		response.Incidents[i] = toIncidentResponse(incident, now)
+	}
+
+	respond.JSON(w, h.logger, http.StatusCreated, response)
+}
+
+// @Summary      История слияний и разделений зоны (оператор)
+// @ID           getIncidentLineage
+// @Description  Связи зоны с исходными и полученными из нее зонами: кто и когда объединил или разделил зоны
+// @Tags         incidents
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int     true  "ID инцидента"
+// @Success      200            {object}  dtoResp.IncidentLineageResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/lineage [get]
+func (h *IncidentHandler) IncidentLineage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	links, err := h.uc.IncidentLineage(r.Context(), id)
+	if err != nil {
+		h.logger.Error("incident lineage read failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	response := dtoResp.IncidentLineageResponse{
+		IncidentID: id,
+		Links:      make([]dtoResp.IncidentLinkResponse, len(links)),
+	}
+	for i, l := range links {
+		response.Links[i] = dtoResp.IncidentLinkResponse{
+			ParentID:  l.ParentID,
+			ChildID:   l.ChildID,
+			Operation: l.Operation,
+			Actor:     l.Actor,
+			CreatedAt: l.CreatedAt,
+		}
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// @Summary      История формы зоны (оператор)
+// @ID           listIncidentVersions
+// @Description  Версии центра, радиуса и полигонов зоны от первой к последней: новая версия записывается при каждом изменении формы.
+// @Description  Зоны, созданные до появления истории, имеют одну версию с формой на момент обновления и датой создания зоны
+// @Tags         incidents
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int     true  "ID инцидента"
+// @Success      200            {object}  dtoResp.IncidentVersionsResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/versions [get]
+func (h *IncidentHandler) IncidentVersions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	versions, err := h.uc.IncidentVersions(r.Context(), id)
+	if err != nil {
+		h.logger.Error("incident versions read failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	response := dtoResp.IncidentVersionsResponse{
+		IncidentID: id,
+		Versions:   make([]dtoResp.IncidentVersionResponse, len(versions)),
+	}
+	for i, v := range versions {
+		var geometry json.RawMessage
+		if len(v.Polygons) > 0 {
+			// полигоны уже проверены при сохранении, ошибка кодирования невозможна
+			geometry, _ = geojson.MultiPolygon(v.Polygons).Marshal()
+		}
+		response.Versions[i] = dtoResp.IncidentVersionResponse{
+			Version:   v.Version,
+			Latitude:  v.Latitude,
+			Longitude: v.Longitude,
+			Radius:    v.Radius,
+			Geometry:  geometry,
+			ChangedBy: v.ChangedBy,
+			ValidFrom: v.ValidFrom,
+		}
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
@@ -1,13 +1,19 @@
 package http
 
 import (
-	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/4otis/geonotify-service/internal/cases"
 	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
 	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	dtoRespV2 "github.com/4otis/geonotify-service/internal/dto/v2/resp"
 	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/pkg/logger"
 	"go.uber.org/zap"
 )
 
@@ -25,96 +31,304 @@ func NewLocationHandler(logger *zap.Logger, uc cases.LocationUseCase) *LocationH
 
 // LocationCheck обрабатывает POST /api/v1/location/check
 // @Summary      Проверить координаты
-// @Description  Проверить, попадает ли точка в опасную зону (публичный эндпоинт)
+// @ID           checkLocation
+// @Description  Проверить, попадает ли точка в опасную зону (публичный эндпоинт). Устарел, используйте /api/v2/location/check
 // @Tags         location
 // @Accept       json
 // @Produce      json
 // @Param        request body dtoReq.LocationCheckRequest true "Координаты для проверки"
+// @Param        resolve_address query bool false "Добавить в ответ и вебхук название места (обратное геокодирование)"
+// @Param        dry_run query bool false "Только сопоставить точку с зонами: проверка не сохраняется, вебхуки и алерты не отправляются"
+// @Param        at query string false "Только для операторов: проверить по зонам, действовавшим в этот момент в прошлом (RFC 3339), в их тогдашней форме; проверка не сохраняется, как с dry_run"
+// @Param        Accept-Language header string false "Языки клиента: названия и описания зон отдаются в переводе"
 // @Success      200 {object} dtoResp.LocationCheckResponse
-// @Failure      400 {object} ErrorResponse
-// @Failure      500 {object} ErrorResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse "at без аутентификации оператора"
+// @Failure      500 {object} respond.ErrorResponse
+// @Deprecated
 // @Router       /api/v1/location/check [post]
 func (h *LocationHandler) LocationCheck(w http.ResponseWriter, r *http.Request) {
-	var req dtoReq.LocationCheckRequest
+	h.checkLocation(w, r, false, func(result cases.LocationCheckResult) any {
+		now := time.Now()
+		incidentResponses := make([]dtoResp.IncidentResponse, len(result.Incidents))
+		for i, inc := range result.Incidents {
+			if inc != nil {
+				incidentResponses[i] = toIncidentResponse(inc, now)
+			}
+		}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Error("failed to decode request body", zap.Error(err))
-		h.respondWithError(w, http.StatusBadRequest, "invalid JSON format")
+		return dtoResp.LocationCheckResponse{
+			HasAlert:  result.HasAlert,
+			Incidents: incidentResponses,
+			Place:     result.Place,
+		}
+	})
+}
+
+// LocationCheckV2 обрабатывает POST /api/v2/location/check
+// @Summary      Проверить координаты (v2)
+// @ID           checkLocationV2
+// @Description  Проверить, попадает ли точка в опасную зону, и получить расстояния и азимуты до найденных зон и до ближайшей зоны впереди (публичный эндпоинт)
+// @Tags         location
+// @Accept       json
+// @Produce      json
+// @Param        request body dtoReq.LocationCheckRequest true "Координаты для проверки"
+// @Param        resolve_address query bool false "Добавить в ответ и вебхук название места (обратное геокодирование)"
+// @Param        dry_run query bool false "Только сопоставить точку с зонами: проверка не сохраняется, вебхуки и алерты не отправляются"
+// @Param        at query string false "Только для операторов: проверить по зонам, действовавшим в этот момент в прошлом (RFC 3339), в их тогдашней форме; проверка не сохраняется, как с dry_run"
+// @Param        Accept-Language header string false "Языки клиента: названия и описания зон отдаются в переводе"
+// @Success      200 {object} dtoRespV2.LocationCheckResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse "at без аутентификации оператора"
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v2/location/check [post]
+func (h *LocationHandler) LocationCheckV2(w http.ResponseWriter, r *http.Request) {
+	h.checkLocation(w, r, false, func(result cases.LocationCheckResult) any {
+		return toLocationCheckResponseV2(result)
+	})
+}
+
+// LocationSimulate обрабатывает POST /api/v1/location/simulate
+// @Summary      Симулировать проверку координат
+// @ID           simulateLocation
+// @Description  То же, что /api/v2/location/check?dry_run=true: точка сопоставляется с действующими зонами, но проверка
+// @Description  не сохраняется и не попадает в статистику, вебхуки, события и алерты пользователю не отправляются.
+// @Description  Для тестирования интеграций (публичный эндпоинт)
+// @Tags         location
+// @Accept       json
+// @Produce      json
+// @Param        request body dtoReq.LocationCheckRequest true "Координаты для проверки"
+// @Param        resolve_address query bool false "Добавить в ответ название места (обратное геокодирование)"
+// @Param        at query string false "Только для операторов: проверить по зонам, действовавшим в этот момент в прошлом (RFC 3339), в их тогдашней форме"
+// @Param        Accept-Language header string false "Языки клиента: названия и описания зон отдаются в переводе"
+// @Success      200 {object} dtoRespV2.LocationCheckResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse "at без аутентификации оператора"
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v1/location/simulate [post]
+func (h *LocationHandler) LocationSimulate(w http.ResponseWriter, r *http.Request) {
+	h.checkLocation(w, r, true, func(result cases.LocationCheckResult) any {
+		return toLocationCheckResponseV2(result)
+	})
+}
+
+// LocationCheckBatch обрабатывает POST /api/v2/location/check/batch
+// @Summary      Проверить пачку координат
+// @ID           checkLocationBatch
+// @Description  Проверяет до 1000 точек за запрос; проверки сохраняются одной записью в БД, поэтому
+// @Description  эндпоинт предназначен для трекеров и шлюзов с большим потоком координат. Отклоненная точка
+// @Description  (например, вне области работы) возвращается с error и не мешает остальным. Адрес не определяется
+// @Tags         location
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body dtoReq.LocationCheckBatchRequest true "Координаты для проверки"
+// @Param        Accept-Language header string false "Языки клиента: названия и описания зон отдаются в переводе"
+// @Success      200 {object} dtoRespV2.LocationCheckBatchResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v2/location/check/batch [post]
+func (h *LocationHandler) LocationCheckBatch(w http.ResponseWriter, r *http.Request) {
+	var req dtoReq.LocationCheckBatchRequest
+
+	if err := bind.Decode(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
 		return
 	}
 
-	if req.UserID == "" {
-		h.respondWithError(w, http.StatusBadRequest, "user_id is required")
-		return
+	for i := range req.Checks {
+		check := &req.Checks[i]
+		if msg := toWGS84(check.CRS, &check.Latitude, &check.Longitude); msg != "" {
+			respond.Error(w, h.logger, http.StatusBadRequest, fmt.Sprintf("checks[%d]: %s", i, msg))
+			return
+		}
 	}
 
-	if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 {
-		h.respondWithError(w, http.StatusBadRequest, "invalid coordinates")
+	if err := bind.Struct(&req); err != nil {
+		respond.Invalid(w, h.logger, err)
 		return
 	}
 
-	hasAlert, incidents, err := h.uc.CheckLocation(r.Context(), req.UserID, req.Latitude, req.Longitude)
+	locales := acceptLocales(r)
+	queries := make([]cases.LocationCheckQuery, len(req.Checks))
+	for i, check := range req.Checks {
+		queries[i] = cases.LocationCheckQuery{
+			UserID:     check.UserID,
+			Latitude:   check.Latitude,
+			Longitude:  check.Longitude,
+			AccuracyM:  check.AccuracyM,
+			Altitude:   check.Altitude,
+			SpeedMps:   check.SpeedMps,
+			HeadingDeg: check.HeadingDeg,
+			Locales:    locales,
+		}
+	}
+
+	items, err := h.uc.CheckLocations(r.Context(), queries)
 	if err != nil {
-		h.logger.Error("location check failed",
+		h.logger.Error("location batch check failed",
 			zap.Error(err),
-			zap.String("user_id", req.UserID))
+			zap.Int("checks", len(queries)))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal server error")
+		return
+	}
 
-		switch err {
-		case entity.ErrUserIDRequired, entity.ErrInvalidCoordinates:
-			h.respondWithError(w, http.StatusBadRequest, err.Error())
-		default:
-			h.respondWithError(w, http.StatusInternalServerError, "internal server error")
+	response := dtoRespV2.LocationCheckBatchResponse{
+		Results: make([]dtoRespV2.LocationCheckBatchResult, len(items)),
+	}
+	for i, item := range items {
+		if item.Err != nil {
+			response.Results[i].Error = item.Err.Error()
+			continue
 		}
-		return
+		result := toLocationCheckResponseV2(item.LocationCheckResult)
+		response.Results[i].Result = &result
 	}
 
-	incidentResponses := make([]dtoResp.IncidentResponse, len(incidents))
-	for i, inc := range incidents {
-		if inc != nil {
-			incidentResponses[i] = dtoResp.IncidentResponse{
-				IncidentID: inc.ID,
-				Name:       inc.Name,
-				Descr:      inc.Descr,
-				Latitude:   inc.Latitude,
-				Longitude:  inc.Longitude,
-				Radius:     inc.Radius,
-				IsActive:   inc.IsActive,
-				CreatedAt:  inc.CreatedAt,
-				UpdatedAt:  inc.UpdatedAt,
-			}
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+func toLocationCheckResponseV2(result cases.LocationCheckResult) dtoRespV2.LocationCheckResponse {
+	zones := make([]dtoRespV2.ZoneMatch, 0, len(result.Incidents))
+	for _, inc := range result.Incidents {
+		if inc == nil {
+			continue
+		}
+		distance := result.Distances[inc.ID]
+		var depth float64
+		if distance.Inside {
+			depth = distance.EdgeM
 		}
+		zones = append(zones, dtoRespV2.ZoneMatch{
+			IncidentID:      inc.ID,
+			Name:            inc.Name,
+			Descr:           inc.Descr,
+			Latitude:        inc.Latitude,
+			Longitude:       inc.Longitude,
+			Radius:          inc.Radius,
+			ExpiresAt:       inc.ExpiresAt,
+			Visibility:      visibility(inc),
+			Match:           result.Matches[inc.ID],
+			DistanceM:       distance.DistanceM,
+			DistanceToEdgeM: depth,
+			BearingDeg:      distance.BearingDeg,
+		})
 	}
 
-	response := dtoResp.LocationCheckResponse{
-		HasAlert:  hasAlert,
-		Incidents: incidentResponses,
+	var nearest *dtoRespV2.NearestZone
+	if n := result.Nearest; n != nil {
+		nearest = &dtoRespV2.NearestZone{
+			IncidentID:      n.Incident.ID,
+			Name:            n.Incident.Name,
+			Latitude:        n.Incident.Latitude,
+			Longitude:       n.Incident.Longitude,
+			Radius:          n.Incident.Radius,
+			Visibility:      visibility(n.Incident),
+			DistanceM:       n.DistanceM,
+			DistanceToEdgeM: n.EdgeM,
+			BearingDeg:      n.BearingDeg,
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	ahead := make([]dtoRespV2.ZoneAhead, len(result.Ahead))
+	for i, p := range result.Ahead {
+		ahead[i] = dtoRespV2.ZoneAhead{
+			NearestZone: dtoRespV2.NearestZone{
+				IncidentID:      p.Incident.ID,
+				Name:            p.Incident.Name,
+				Latitude:        p.Incident.Latitude,
+				Longitude:       p.Incident.Longitude,
+				Radius:          p.Incident.Radius,
+				Visibility:      visibility(p.Incident),
+				DistanceM:       p.DistanceM,
+				DistanceToEdgeM: p.EdgeM,
+				BearingDeg:      p.BearingDeg,
+			},
+			ETASeconds: p.ETASeconds,
+		}
+	}
 
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.logger.Error("failed to encode response", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"error": "failed to encode response"}`))
+	return dtoRespV2.LocationCheckResponse{
+		HasAlert: result.HasAlert,
+		Match:    result.Match,
+		Zones:    zones,
+		Ahead:    ahead,
+		Nearest:  nearest,
+		Place:    result.Place,
 	}
 }
 
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-}
+// checkLocation — общая для всех версий API часть проверки координат:
+// версии отличаются только формой ответа, которую строит render. simulate или dry_run=true — проверка без записи
+func (h *LocationHandler) checkLocation(w http.ResponseWriter, r *http.Request, simulate bool, render func(cases.LocationCheckResult) any) {
+	var req dtoReq.LocationCheckRequest
 
-func (h *LocationHandler) respondWithError(w http.ResponseWriter, code int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
+	if err := bind.Decode(r, &req); err != nil {
+		h.logger.Error("failed to decode request body", zap.Error(err))
+		respond.Invalid(w, h.logger, err)
+		return
+	}
 
-	errorResponse := ErrorResponse{
-		Error:   http.StatusText(code),
-		Message: message,
+	if msg := toWGS84(req.CRS, &req.Latitude, &req.Longitude); msg != "" {
+		respond.Error(w, h.logger, http.StatusBadRequest, msg)
+		return
 	}
 
-	if err := json.NewEncoder(w).Encode(errorResponse); err != nil {
-		h.logger.Error("failed to encode error response", zap.Error(err))
+	if err := bind.Struct(&req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
 	}
+
+	logger.AddAccessFields(r.Context(), zap.String("user_id", req.UserID))
+
+	resolveAddress, _ := strconv.ParseBool(r.URL.Query().Get("resolve_address"))
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	var at *time.Time
+	if v := r.URL.Query().Get("at"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil || t.After(time.Now()) {
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid at parameter (must be an RFC 3339 timestamp not in the future)")
+			return
+		}
+		at = &t
+		logger.AddAccessFields(r.Context(), zap.Time("at", t))
+	}
+
+	check := h.uc.CheckLocation
+	if simulate || dryRun || at != nil {
+		check = h.uc.SimulateLocation
+		logger.AddAccessFields(r.Context(), zap.Bool("dry_run", true))
+	}
+
+	result, err := check(r.Context(), cases.LocationCheckQuery{
+		UserID:         req.UserID,
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		AccuracyM:      req.AccuracyM,
+		Altitude:       req.Altitude,
+		SpeedMps:       req.SpeedMps,
+		HeadingDeg:     req.HeadingDeg,
+		ResolveAddress: resolveAddress,
+		Locales:        acceptLocales(r),
+		At:             at,
+	})
+	if err != nil {
+		h.logger.Error("location check failed",
+			zap.Error(err),
+			zap.String("user_id", req.UserID))
+
+		switch err {
+		case entity.ErrUserIDRequired, entity.ErrInvalidCoordinates, entity.ErrInvalidAccuracy, entity.ErrInvalidMotion, entity.ErrOutsideArea:
+			respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+		default:
+			respond.Error(w, h.logger, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	response := render(result)
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
 }
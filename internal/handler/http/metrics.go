@@ -0,0 +1,214 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const (
+	// metricsNamespace — префикс имен метрик сервиса
+	metricsNamespace = "geonotify"
+	// metricsQueryTimeout ограничивает запросы к БД при сборе метрик: Prometheus не передает контекст опроса
+	metricsQueryTimeout = 10 * time.Second
+)
+
+// WebhookSLOs — задержка доставки вебхуков по получателям
+type WebhookSLOs interface {
+	EndpointSLOs(ctx context.Context) ([]cases.WebhookSLO, error)
+}
+
+// NewMetricsHandler отдает метрики в текстовом формате Prometheus (GET /metrics). Метрики собираются
+// при каждом опросе из тех же источников, что и JSON-эндпоинты администрирования, поэтому счетчики
+// процесса сбрасываются вместе с ними. locks nil — блокировки воркеров выключены
+func NewMetricsHandler(logger *zap.Logger, queries QueryStats, locks WorkerLocks, slos WebhookSLOs) http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		newQueryCollector(queries),
+		newWebhookSLOCollector(slos),
+	)
+	if locks != nil {
+		registry.MustRegister(newLockCollector(locks))
+	}
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorLog:      zap.NewStdLog(logger),
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// queryCollector — гистограммы длительности запросов к БД по нормализованному SQL
+type queryCollector struct {
+	queries  QueryStats
+	duration *prometheus.Desc
+	errors   *prometheus.Desc
+}
+
+func newQueryCollector(queries QueryStats) *queryCollector {
+	return &queryCollector{
+		queries: queries,
+		duration: prometheus.NewDesc(metricsNamespace+"_db_query_duration_seconds",
+			"Duration of database queries by normalized SQL since process start or the last reset.",
+			[]string{"query"}, nil),
+		errors: prometheus.NewDesc(metricsNamespace+"_db_query_errors_total",
+			"Failed database queries by normalized SQL since process start or the last reset.",
+			[]string{"query"}, nil),
+	}
+}
+
+func (c *queryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.duration
+	ch <- c.errors
+}
+
+func (c *queryCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stat := range c.queries.Snapshot() {
+		// корзины QueryStat не накопительные, а в Prometheus каждая корзина включает предыдущие
+		buckets := make(map[float64]uint64, len(postgres.QueryBucketsMs))
+		var cumulative int64
+		for i, bound := range postgres.QueryBucketsMs {
+			cumulative += stat.Buckets[i]
+			buckets[bound/1000] = uint64(cumulative)
+		}
+
+		ch <- prometheus.MustNewConstHistogram(c.duration, uint64(stat.Calls), stat.Total.Seconds(), buckets, stat.SQL)
+		ch <- prometheus.MustNewConstMetric(c.errors, prometheus.CounterValue, float64(stat.Errors), stat.SQL)
+	}
+}
+
+// lockCollector — владение блокировками периодических задач в этом процессе и счетчики их захвата и запусков
+type lockCollector struct {
+	locks    WorkerLocks
+	held     *prometheus.Desc
+	acquired *prometheus.Desc
+	lost     *prometheus.Desc
+	runs     *prometheus.Desc
+	skipped  *prometheus.Desc
+	errors   *prometheus.Desc
+}
+
+func newLockCollector(locks WorkerLocks) *lockCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(metricsNamespace+"_worker_lock_"+name, help, []string{"lock"}, nil)
+	}
+
+	return &lockCollector{
+		locks:    locks,
+		held:     desc("held", "Whether this process holds the worker lock (1) or not (0)."),
+		acquired: desc("acquired_total", "Times this process acquired the worker lock."),
+		lost:     desc("lost_total", "Times this process lost the worker lock because its session broke."),
+		runs:     desc("runs_total", "Task runs this process performed under the worker lock."),
+		skipped:  desc("skipped_total", "Task runs this process skipped while another process held the worker lock."),
+		errors:   desc("errors_total", "Errors acquiring or checking the worker lock."),
+	}
+}
+
+func (c *lockCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.held
+	ch <- c.acquired
+	ch <- c.lost
+	ch <- c.runs
+	ch <- c.skipped
+	ch <- c.errors
+}
+
+func (c *lockCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stat := range c.locks.Snapshot() {
+		held := 0.0
+		if stat.Held {
+			held = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.held, prometheus.GaugeValue, held, stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.acquired, prometheus.CounterValue, float64(stat.Acquired), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.lost, prometheus.CounterValue, float64(stat.Lost), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.runs, prometheus.CounterValue, float64(stat.Runs), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.skipped, prometheus.CounterValue, float64(stat.Skipped), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.errors, prometheus.CounterValue, float64(stat.Errors), stat.Name)
+	}
+}
+
+// webhookSLOCollector — задержка доставки вебхуков по получателям за окно WEBHOOK_SLO_WINDOW_MINUTES.
+// Перцентили считает Postgres по доставленным вебхукам, поэтому все реплики отдают одни и те же значения
+type webhookSLOCollector struct {
+	slos       WebhookSLOs
+	latency    *prometheus.Desc
+	deliveries *prometheus.Desc
+	target     *prometheus.Desc
+	breached   *prometheus.Desc
+}
+
+func newWebhookSLOCollector(slos WebhookSLOs) *webhookSLOCollector {
+	return &webhookSLOCollector{
+		slos: slos,
+		latency: prometheus.NewDesc(metricsNamespace+"_webhook_delivery_latency_seconds",
+			"Webhook delivery latency percentiles from check creation to successful delivery over the SLO window.",
+			[]string{"endpoint_id", "quantile"}, nil),
+		deliveries: prometheus.NewDesc(metricsNamespace+"_webhook_slo_deliveries",
+			"Webhooks delivered to the endpoint over the SLO window.",
+			[]string{"endpoint_id"}, nil),
+		target: prometheus.NewDesc(metricsNamespace+"_webhook_slo_target_seconds",
+			"Webhook delivery latency target for the SLO percentile.",
+			[]string{"quantile"}, nil),
+		breached: prometheus.NewDesc(metricsNamespace+"_webhook_slo_breached",
+			"Whether the endpoint breaches the webhook delivery latency target (1) or not (0).",
+			[]string{"endpoint_id"}, nil),
+	}
+}
+
+func (c *webhookSLOCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.latency
+	ch <- c.deliveries
+	ch <- c.target
+	ch <- c.breached
+}
+
+func (c *webhookSLOCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsQueryTimeout)
+	defer cancel()
+
+	slos, err := c.slos.EndpointSLOs(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.latency, err)
+		return
+	}
+
+	for i, slo := range slos {
+		// цель общая для всех получателей
+		if i == 0 && slo.Target > 0 {
+			ch <- prometheus.MustNewConstMetric(c.target, prometheus.GaugeValue, slo.Target.Seconds(), quantile(slo.Percentile))
+		}
+
+		endpointID := strconv.Itoa(slo.EndpointID)
+		ch <- prometheus.MustNewConstMetric(c.deliveries, prometheus.GaugeValue, float64(slo.Latency.Delivered), endpointID)
+		breached := 0.0
+		if slo.Breached {
+			breached = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.breached, prometheus.GaugeValue, breached, endpointID)
+
+		// без доставок за окно задержки нет, а не нулевая
+		if slo.Latency.Delivered == 0 {
+			continue
+		}
+		for _, p := range []int{50, 95, 99} {
+			ch <- prometheus.MustNewConstMetric(c.latency, prometheus.GaugeValue,
+				slo.Latency.Percentile(p).Seconds(), endpointID, quantile(p))
+		}
+	}
+}
+
+// quantile переводит перцентиль в значение метки quantile, как у summary Prometheus: 95 — "0.95"
+func quantile(percentile int) string {
+	return strconv.FormatFloat(float64(percentile)/100, 'f', -1, 64)
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/pkg/postgres"
+	"go.uber.org/zap"
+)
+
+type stubQueryStats []postgres.QueryStat
+
+func (s stubQueryStats) Snapshot() []postgres.QueryStat { return s }
+func (s stubQueryStats) Reset()                         {}
+
+type stubWorkerLocks []postgres.LockStat
+
+func (s stubWorkerLocks) Snapshot() []postgres.LockStat { return s }
+
+type stubWebhookSLOs []cases.WebhookSLO
+
+func (s stubWebhookSLOs) EndpointSLOs(context.Context) ([]cases.WebhookSLO, error) { return s, nil }
+
+func TestMetricsHandler(t *testing.T) {
+	buckets := make([]int64, len(postgres.QueryBucketsMs)+1)
+	buckets[0], buckets[2], buckets[len(buckets)-1] = 3, 2, 1
+	queries := stubQueryStats{{SQL: "SELECT ?", Calls: 6, Errors: 1, Total: 12 * time.Second, Buckets: buckets}}
+	locks := stubWorkerLocks{
+		{Name: "heatmap", Held: true, Acquired: 2, Lost: 1, Runs: 10},
+		{Name: "relay", Skipped: 4, Errors: 1},
+	}
+	slos := stubWebhookSLOs{
+		{
+			EndpointID: 1, Percentile: 95, Target: 2 * time.Second, Breached: true,
+			Latency: entity.WebhookLatency{Delivered: 40, P50: time.Second, P95: 3 * time.Second, P99: 4500 * time.Millisecond},
+		},
+		{EndpointID: 2, Percentile: 95, Target: 2 * time.Second},
+	}
+
+	rec := httptest.NewRecorder()
+	NewMetricsHandler(zap.NewNop(), queries, locks, slos).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	body, _ := io.ReadAll(rec.Body)
+
+	// корзины накопительные, а запрос дольше всех границ попадает только в +Inf
+	for _, want := range []string{
+		`geonotify_db_query_duration_seconds_bucket{query="SELECT ?",le="0.001"} 3`,
+		`geonotify_db_query_duration_seconds_bucket{query="SELECT ?",le="0.005"} 3`,
+		`geonotify_db_query_duration_seconds_bucket{query="SELECT ?",le="0.01"} 5`,
+		`geonotify_db_query_duration_seconds_bucket{query="SELECT ?",le="5"} 5`,
+		`geonotify_db_query_duration_seconds_bucket{query="SELECT ?",le="+Inf"} 6`,
+		`geonotify_db_query_duration_seconds_sum{query="SELECT ?"} 12`,
+		`geonotify_db_query_errors_total{query="SELECT ?"} 1`,
+		`geonotify_worker_lock_held{lock="heatmap"} 1`,
+		`geonotify_worker_lock_held{lock="relay"} 0`,
+		`geonotify_worker_lock_acquired_total{lock="heatmap"} 2`,
+		`geonotify_worker_lock_lost_total{lock="heatmap"} 1`,
+		`geonotify_worker_lock_runs_total{lock="heatmap"} 10`,
+		`geonotify_worker_lock_skipped_total{lock="relay"} 4`,
+		`geonotify_worker_lock_errors_total{lock="relay"} 1`,
+		`geonotify_webhook_delivery_latency_seconds{endpoint_id="1",quantile="0.5"} 1`,
+		`geonotify_webhook_delivery_latency_seconds{endpoint_id="1",quantile="0.95"} 3`,
+		`geonotify_webhook_delivery_latency_seconds{endpoint_id="1",quantile="0.99"} 4.5`,
+		`geonotify_webhook_slo_deliveries{endpoint_id="1"} 40`,
+		`geonotify_webhook_slo_deliveries{endpoint_id="2"} 0`,
+		`geonotify_webhook_slo_breached{endpoint_id="1"} 1`,
+		`geonotify_webhook_slo_breached{endpoint_id="2"} 0`,
+		`geonotify_webhook_slo_target_seconds{quantile="0.95"} 2`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %q", want)
+		}
+	}
+
+	// у получателя без доставок за окно нет перцентилей
+	if strings.Contains(string(body), `geonotify_webhook_delivery_latency_seconds{endpoint_id="2"`) {
+		t.Error("metrics contain latency of an endpoint without deliveries")
+	}
+}
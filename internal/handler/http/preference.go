@@ -0,0 +1,154 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// maxUserIDLength совпадает с ограничением user_id в проверках координат
+const maxUserIDLength = 127
+
+type PreferenceHandler struct {
+	logger *zap.Logger
+	uc     cases.PreferenceUseCase
+}
+
+func NewPreferenceHandler(logger *zap.Logger, uc cases.PreferenceUseCase) *PreferenceHandler {
+	return &PreferenceHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// PreferencesGet обрабатывает GET /api/v1/users/{user_id}/preferences
+// @Summary      Настройки алертов пользователя
+// @ID           getUserPreferences
+// @Description  Порог опасности, окна тишины и каналы доставки алертов. Пользователь без сохраненных настроек
+// @Description  получает значения по умолчанию: все алерты в SSE-поток
+// @Tags         alerts
+// @Produce      json
+// @Param        user_id  path      string  true  "ID пользователя"
+// @Success      200      {object}  dtoResp.UserPreferencesResponse
+// @Failure      400      {object}  respond.ErrorResponse
+// @Failure      500      {object}  respond.ErrorResponse
+// @Router       /api/v1/users/{user_id}/preferences [get]
+func (h *PreferenceHandler) PreferencesGet(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.uc.UserPreferences(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("user preferences read failed",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toPreferencesResponse(prefs))
+}
+
+// PreferencesPut обрабатывает PUT /api/v1/users/{user_id}/preferences
+// @Summary      Задать настройки алертов пользователя
+// @ID           putUserPreferences
+// @Description  Заменяет настройки целиком. Алерты о зонах с уровнем опасности ниже min_severity не отправляются, в окна тишины
+// @Description  quiet_hours (по времени timezone) отправляются только алерты о критических зонах. Сверх max_alerts_per_hour
+// @Description  алертов за час отправляются тоже только критические. Остальные уходят в каналы channels: stream — SSE-поток
+// @Description  /api/v1/users/{user_id}/alerts/stream, webhook — событие вебхука user.alert
+// @Tags         alerts
+// @Accept       json
+// @Produce      json
+// @Param        user_id  path      string                         true  "ID пользователя"
+// @Param        request  body      dtoReq.UserPreferencesRequest  true  "Настройки"
+// @Success      200      {object}  dtoResp.UserPreferencesResponse
+// @Failure      400      {object}  respond.ErrorResponse  "Неверный уровень опасности, окно тишины, часовой пояс, канал или лимит"
+// @Failure      500      {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/users/{user_id}/preferences [put]
+func (h *PreferenceHandler) PreferencesPut(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dtoReq.UserPreferencesRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	prefs := entity.UserPreferences{
+		UserID:      userID,
+		MinSeverity: req.MinSeverity,
+		Timezone:    req.Timezone,
+		Channels:    req.Channels,
+
+		MaxAlertsPerHour: req.MaxAlertsPerHour,
+	}
+	if req.QuietHours != nil {
+		prefs.QuietHours = make([]entity.QuietHours, len(req.QuietHours))
+		for i, window := range req.QuietHours {
+			prefs.QuietHours[i] = entity.QuietHours{Start: window.Start, End: window.End, Days: window.Days}
+		}
+	}
+
+	saved, err := h.uc.SetUserPreferences(r.Context(), prefs)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidSeverity),
+			errors.Is(err, entity.ErrInvalidQuietHours),
+			errors.Is(err, entity.ErrInvalidTimezone),
+			errors.Is(err, entity.ErrInvalidAlertChannel),
+			errors.Is(err, entity.ErrInvalidAlertLimit):
+			respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.Error("user preferences save failed",
+				zap.Error(err),
+				zap.String("user_id", userID))
+			respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		}
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toPreferencesResponse(saved))
+}
+
+func (h *PreferenceHandler) userID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := chi.URLParam(r, "user_id")
+	if userID == "" || len(userID) > maxUserIDLength {
+		respond.Error(w, h.logger, http.StatusBadRequest, "user_id is required and must be at most 127 characters")
+		return "", false
+	}
+	return userID, true
+}
+
+func toPreferencesResponse(p *entity.UserPreferences) dtoResp.UserPreferencesResponse {
+	response := dtoResp.UserPreferencesResponse{
+		UserID:      p.UserID,
+		MinSeverity: p.MinSeverity,
+		QuietHours:  make([]dtoResp.QuietHoursResponse, len(p.QuietHours)),
+		Timezone:    p.Timezone,
+		Channels:    p.Channels,
+
+		MaxAlertsPerHour: p.MaxAlertsPerHour,
+	}
+	for i, window := range p.QuietHours {
+		response.QuietHours[i] = dtoResp.QuietHoursResponse{Start: window.Start, End: window.End, Days: window.Days}
+	}
+	if !p.UpdatedAt.IsZero() {
+		updatedAt := p.UpdatedAt
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
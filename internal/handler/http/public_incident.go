@@ -0,0 +1,89 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/pkg/geojson"
+	"go.uber.org/zap"
+)
+
+// PublicIncidentHandler отдает действующие зоны публичным приложениям без ключа оператора.
+// Маршрут идет со своим лимитом запросов на адрес клиента
+type PublicIncidentHandler struct {
+	logger *zap.Logger
+	uc     cases.PublicIncidentUseCase
+}
+
+func NewPublicIncidentHandler(logger *zap.Logger, uc cases.PublicIncidentUseCase) *PublicIncidentHandler {
+	return &PublicIncidentHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// PublicIncidentList обрабатывает GET /api/v1/public/incidents
+// @Summary      Действующие зоны (публичный доступ)
+// @ID           listPublicIncidents
+// @Description  Действующие опубликованные зоны без авторизации: только название, геометрия, стадия и уровень опасности,
+// @Description  без описаний, адресов и авторов. Ответ кэшируется на PUBLIC_API_CACHE_SECONDS, запросы с одного адреса
+// @Description  ограничены PUBLIC_API_RATE_LIMIT_PER_MINUTE в минуту
+// @Tags         public
+// @Produce      json
+// @Param        If-None-Match    header  string  false  "ETag из предыдущего ответа"
+// @Param        Accept-Language  header  string  false  "Языки клиента: названия отдаются в переводе"
+// @Success      200  {object}  dtoResp.PublicIncidentsListResponse
+// @Header       200  {string}  ETag  "Версия набора зон для этих языков"
+// @Success      304  "Список не изменился"
+// @Failure      429  {object}  respond.ErrorResponse  "Превышен лимит запросов"
+// @Failure      500  {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/public/incidents [get]
+func (h *PublicIncidentHandler) PublicIncidentList(w http.ResponseWriter, r *http.Request) {
+	locales := acceptLocales(r)
+
+	incidents, err := h.uc.ActiveIncidents(r.Context(), locales)
+	if err != nil {
+		h.logger.Error("public incident list failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	if respond.NotModified(w, r, localeETag("public-"+incidents.Version, locales)) {
+		return
+	}
+
+	response := dtoResp.PublicIncidentsListResponse{
+		Incidents: make([]dtoResp.PublicIncidentResponse, len(incidents.Incidents)),
+	}
+	for i, inc := range incidents.Incidents {
+		response.Incidents[i] = toPublicIncidentResponse(inc)
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+func toPublicIncidentResponse(incident *entity.Incident) dtoResp.PublicIncidentResponse {
+	var geometry json.RawMessage
+	if len(incident.Polygons) > 0 {
+		geometry, _ = geojson.MultiPolygon(incident.Polygons).Marshal()
+	}
+
+	return dtoResp.PublicIncidentResponse{
+		IncidentID:  incident.ID,
+		Name:        incident.Name,
+		Latitude:    incident.Latitude,
+		Longitude:   incident.Longitude,
+		Radius:      incident.Radius,
+		State:       incident.State,
+		Severity:    incident.Severity,
+		PublishedAt: incident.PublishedAt,
+		UpdatedAt:   incident.UpdatedAt,
+		ExpiresAt:   incident.ExpiresAt,
+		Geometry:    geometry,
+	}
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/internal/port/cache"
+	"go.uber.org/zap"
+)
+
+// RateLimit ограничивает число запросов с одного адреса клиента до perMinute в минуту, сверх лимита — 429
+// с Retry-After до начала следующей минуты. name разделяет счетчики разных лимитов. Пока счетчик
+// недоступен, запросы пропускаются: лимит защищает от перегрузки, а не от доступа
+func RateLimit(logger *zap.Logger, counter cache.Counter, name string, perMinute int,
+	clientIP func(r *http.Request) (netip.Addr, bool)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, ok := clientIP(r)
+			if !ok {
+				respond.Error(w, logger, http.StatusBadRequest, "client address is not valid")
+				return
+			}
+
+			n, err := counter.Increment(r.Context(), "ratelimit:"+name+":"+ip.String(), time.Minute)
+			if err != nil {
+				if !errors.Is(err, entity.ErrDependencyUnavailable) {
+					logger.Warn("rate limit counter failed", zap.Error(err), zap.String("limit", name))
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(perMinute))
+			w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(max(int64(perMinute)-n, 0), 10))
+			if n > int64(perMinute) {
+				retryAfter := time.Minute - time.Duration(time.Now().UnixNano()%int64(time.Minute))
+				w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+				respond.Error(w, logger, http.StatusTooManyRequests, "rate limit exceeded")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
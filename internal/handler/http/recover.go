@@ -0,0 +1,39 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"go.uber.org/zap"
+)
+
+// Recoverer перехватывает панику обработчика: она пишется в лог со стеком, а клиент получает 500
+// в обычном формате ошибок без подробностей. http.ErrAbortHandler пробрасывается дальше — им
+// обработчик намеренно обрывает соединение
+func Recoverer(logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if err, ok := rec.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(rec)
+				}
+
+				logger.Error("panic while handling request",
+					zap.Any("panic", rec),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.Stack("stack"))
+
+				// если обработчик уже начал ответ, статус не изменится, а ошибка допишется к телу
+				respond.Error(w, logger, http.StatusInternalServerError, "internal server error")
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
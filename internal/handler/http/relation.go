@@ -0,0 +1,168 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// @Summary      Связи зоны (оператор)
+// @ID           listIncidentRelations
+// @Description  Связи с другими зонами со стороны этой зоны: parent — она входит в related_id, child — related_id входит в нее,
+// @Description  supersedes — она заменяет related_id, superseded_by — related_id заменяет ее. Связи с удаленными зонами не возвращаются
+// @Tags         incidents
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int     true   "ID инцидента"
+// @Success      200            {object}  dtoResp.IncidentRelationsResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/relations [get]
+func (h *IncidentHandler) IncidentRelations(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	relations, err := h.uc.IncidentRelations(r.Context(), id)
+	if err != nil {
+		h.logger.Error("incident relations read failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, dtoResp.IncidentRelationsResponse{
+		IncidentID: id,
+		Relations:  toRelationResponses(relations),
+	})
+}
+
+// @Summary      Связать зону с другой (оператор)
+// @ID           createIncidentRelation
+// @Description  parent — зона входит в related_id (у зоны одна родительская), supersedes — зона заменяет related_id,
+// @Description  например зона эвакуации заменяет прежнюю зону предупреждения. Редактор связывает только черновики.
+// @Description  Опубликованные зоны получают incident.updated, где supersedes и superseded_by перечисляют связанные зоны
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int                               true  "ID инцидента"
+// @Param        request        body      dtoReq.IncidentRelationRequest    true  "Связь"
+// @Success      201            {object}  dtoResp.IncidentRelationResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный формат данных или связь зоны с самой собой"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      409            {object}  respond.ErrorResponse  "Связь уже есть, у зоны уже есть родительская или связь замкнет цикл"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/relations [post]
+func (h *IncidentHandler) IncidentRelationCreate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	var req dtoReq.IncidentRelationRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	relation, err := h.uc.AddIncidentRelation(r.Context(), entity.IncidentRelation{
+		IncidentID: id,
+		RelatedID:  req.RelatedID,
+		Type:       req.Type,
+	})
+	if err != nil {
+		h.logger.Error("incident relation save failed",
+			zap.Error(err),
+			zap.Int("id", id),
+			zap.Int("related_id", req.RelatedID))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusCreated, toRelationResponse(*relation))
+}
+
+// @Summary      Удалить связь зоны (оператор)
+// @ID           deleteIncidentRelation
+// @Description  Удаляет связь, заданную со стороны зоны: type — parent или supersedes
+// @Tags         incidents
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int     true   "ID инцидента"
+// @Param        type           path      string  true   "Тип связи" Enums(parent, supersedes)
+// @Param        related_id     path      int     true   "ID связанного инцидента"
+// @Success      204
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент или связь не найдены"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/relations/{type}/{related_id} [delete]
+func (h *IncidentHandler) IncidentRelationDelete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+	relatedID, err := strconv.Atoi(chi.URLParam(r, "related_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "related_id required/not valid")
+		return
+	}
+
+	err = h.uc.DeleteIncidentRelation(r.Context(), entity.IncidentRelation{
+		IncidentID: id,
+		RelatedID:  relatedID,
+		Type:       chi.URLParam(r, "type"),
+	})
+	if err != nil {
+		h.logger.Error("incident relation delete failed",
+			zap.Error(err),
+			zap.Int("id", id),
+			zap.Int("related_id", relatedID))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toRelationResponses(relations []entity.IncidentRelation) []dtoResp.IncidentRelationResponse {
+	if relations == nil {
+		return nil
+	}
+
+	response := make([]dtoResp.IncidentRelationResponse, len(relations))
+	for i, rel := range relations {
+		response[i] = toRelationResponse(rel)
+	}
+	return response
+}
+
+func toRelationResponse(rel entity.IncidentRelation) dtoResp.IncidentRelationResponse {
+	return dtoResp.IncidentRelationResponse{
+		RelatedID: rel.RelatedID,
+		Type:      rel.Type,
+		CreatedBy: rel.CreatedBy,
+		CreatedAt: rel.CreatedAt,
+	}
+}
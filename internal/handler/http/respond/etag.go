@@ -0,0 +1,38 @@
+package respond
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NotModified выставляет ETag и Cache-Control: no-cache (клиент хранит ответ, но каждый раз
+// перепроверяет его) и отвечает 304, если etag совпал с If-None-Match. ETag слабый: тело
+// может отличаться сжатием, а содержимое — нет
+func NotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	etag = `W/"` + etag + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches — слабое сравнение из RFC 9110: W/ не учитывается
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// Package respond — общая запись HTTP-ответов: JSON (или XML лент) с кодом статуса и ошибки в едином формате
+package respond
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"go.uber.org/zap"
+)
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+	// Details — нарушения по полям для ошибок проверки запроса
+	Details []bind.FieldError `json:"details,omitempty"`
+}
+
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+// JSON пишет payload с кодом code. Заголовок уже отправлен, поэтому ошибка кодирования только логируется
+func JSON(w http.ResponseWriter, logger *zap.Logger, code int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		logger.Error("failed to encode response", zap.Error(err))
+	}
+}
+
+// XML пишет payload с XML-декларацией и типом contentType (например, application/atom+xml)
+func XML(w http.ResponseWriter, logger *zap.Logger, code int, contentType string, payload any) {
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.WriteHeader(code)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		logger.Error("failed to write response", zap.Error(err))
+		return
+	}
+	if err := xml.NewEncoder(w).Encode(payload); err != nil {
+		logger.Error("failed to encode response", zap.Error(err))
+	}
+}
+
+// Error пишет ErrorResponse: error — текст статуса, message — подробности для клиента
+func Error(w http.ResponseWriter, logger *zap.Logger, code int, message string) {
+	JSON(w, logger, code, ErrorResponse{
+		Error:   http.StatusText(code),
+		Message: message,
+	})
+}
+
+// Message пишет MessageResponse для операций без тела результата
+func Message(w http.ResponseWriter, logger *zap.Logger, code int, message string) {
+	JSON(w, logger, code, MessageResponse{Message: message})
+}
+
+// Invalid отвечает 400 на ошибку разбора или проверки тела запроса из пакета bind
+// и 413 на тело больше лимита
+func Invalid(w http.ResponseWriter, logger *zap.Logger, err error) {
+	if errors.Is(err, bind.ErrBodyTooLarge) {
+		Error(w, logger, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+
+	response := ErrorResponse{
+		Error:   http.StatusText(http.StatusBadRequest),
+		Message: err.Error(),
+	}
+
+	var bindErr *bind.Error
+	switch {
+	case errors.As(err, &bindErr):
+		response.Details = bindErr.Fields
+	case errors.Is(err, bind.ErrInvalidJSON):
+		// подробности декодера не нужны клиенту
+		response.Message = bind.ErrInvalidJSON.Error()
+	}
+
+	JSON(w, logger, http.StatusBadRequest, response)
+}
@@ -1,14 +1,22 @@
 package http
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/4otis/geonotify-service/internal/cases"
 	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/pkg/h3cell"
 	"go.uber.org/zap"
 )
 
+// defaultHeatmapResolution — разрешение тепловой карты без параметра resolution: ячейка около 5 км²
+const defaultHeatmapResolution = 7
+
 type StatsHandler struct {
 	logger    *zap.Logger
 	uc        cases.StatsUseCase
@@ -25,17 +33,29 @@ func NewStatsHandler(logger *zap.Logger, uc cases.StatsUseCase, windowMin int) *
 
 // GetStats обрабатывает GET /api/v1/incidents/stats
 // @Summary      Статистика по зонам
-// @Description  Получить статистику уникальных пользователей за последние N минут
+// @ID           getStats
+// @Description  Получить статистику уникальных пользователей за последние N минут.
+// @Description  Точный подсчет берется из поминутной сводки, если она отстает не больше чем на STATS_FRESHNESS_SECONDS:
+// @Description  тогда окно заканчивается на последней сведенной минуте и period_start сдвинут на ее задержку.
+// @Description  С count=estimated числа — оценки планировщика Postgres (estimated: true), а не точный подсчет
 // @Tags         stats
 // @Produce      json
+// @Param        count  query     string  false  "Точный подсчет или оценка (по умолчанию exact)" Enums(exact, estimated)
 // @Success      200 {object} dtoResp.StatsResponse
-// @Failure      500 {object} ErrorResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
 // @Router       /api/v1/incidents/stats [get]
 func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
-	userCount, totalChecks, periodStart, err := h.uc.GetStats(r.Context(), h.windowMin)
+	estimate, ok := countMode(r)
+	if !ok {
+		respond.Error(w, h.logger, http.StatusBadRequest, "invalid count parameter (must be exact or estimated)")
+		return
+	}
+
+	userCount, totalChecks, periodStart, err := h.uc.GetStats(r.Context(), h.windowMin, estimate)
 	if err != nil {
 		h.logger.Error("failed to get stats", zap.Error(err))
-		h.respondWithError(w, http.StatusInternalServerError, "failed to retrieve statistics")
+		respond.Error(w, h.logger, http.StatusInternalServerError, "failed to retrieve statistics")
 		return
 	}
 
@@ -44,32 +64,159 @@ func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 		TotalChecks:   totalChecks,
 		WindowMinutes: h.windowMin,
 		PeriodStart:   periodStart,
+		Estimated:     estimate,
 	}
 
-	h.respondWithJSON(w, http.StatusOK, response)
+	respond.JSON(w, h.logger, http.StatusOK, response)
 }
 
-func (h *StatsHandler) respondWithError(w http.ResponseWriter, code int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
+// GetHeatmap обрабатывает GET /api/v1/stats/heatmap
+// @Summary      Тепловая карта проверок (оператор)
+// @ID           getCheckHeatmap
+// @Description  Число проверок координат и проверок с алертом по ячейкам H3 за период, начиная с самых частых.
+// @Description  Счетчики почасовые и сводятся фоновым воркером, поэтому период учитывается с точностью до часа,
+// @Description  а последние проверки появляются с задержкой около минуты. Разрешение — не мельче HEATMAP_RESOLUTION сервера
+// @Tags         stats
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        resolution  query     int     false  "Разрешение H3 (по умолчанию 7)"
+// @Param        from        query     string  false  "Начало периода, RFC 3339 (по умолчанию сутки назад)"
+// @Param        to          query     string  false  "Конец периода, RFC 3339 (по умолчанию сейчас); не больше 92 дней от from"
+// @Success      200 {object} dtoResp.HeatmapResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Failure      501 {object} respond.ErrorResponse  "Сервер собран без cgo, тепловая карта недоступна"
+// @Router       /api/v1/stats/heatmap [get]
+func (h *StatsHandler) GetHeatmap(w http.ResponseWriter, r *http.Request) {
+	if !h3cell.Supported {
+		respond.Error(w, h.logger, http.StatusNotImplemented, "heatmap is not available: the server is built without cgo")
+		return
+	}
+
+	query := r.URL.Query()
 
-	errorResponse := ErrorResponse{
-		Error:   http.StatusText(code),
-		Message: message,
+	resolution := defaultHeatmapResolution
+	if v := query.Get("resolution"); v != "" {
+		var err error
+		if resolution, err = strconv.Atoi(v); err != nil {
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid resolution parameter")
+			return
+		}
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-24 * time.Hour)
+	for _, p := range []struct {
+		name string
+		dest *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		if v := query.Get(p.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				respond.Error(w, h.logger, http.StatusBadRequest, "invalid "+p.name+" parameter (must be an RFC 3339 timestamp)")
+				return
+			}
+			*p.dest = t.UTC()
+		}
+	}
+
+	cells, err := h.uc.GetHeatmap(r.Context(), resolution, from, to)
+	if errors.Is(err, entity.ErrInvalidHeatmap) {
+		respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to get heatmap", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "failed to retrieve heatmap")
+		return
 	}
 
-	if err := json.NewEncoder(w).Encode(errorResponse); err != nil {
-		h.logger.Error("failed to encode error response", zap.Error(err))
+	response := dtoResp.HeatmapResponse{
+		Resolution: resolution,
+		From:       from,
+		To:         to,
+		Cells:      make([]dtoResp.HeatmapCellResponse, len(cells)),
 	}
+	for i, c := range cells {
+		lat, lng := h3cell.Center(c.Cell)
+		response.Cells[i] = dtoResp.HeatmapCellResponse{
+			Cell:      h3cell.String(c.Cell),
+			Latitude:  lat,
+			Longitude: lng,
+			Checks:    c.Checks,
+			Alerts:    c.Alerts,
+		}
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
 }
 
-func (h *StatsHandler) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
+// GetIncidentStats обрабатывает GET /api/v1/stats/incidents
+// @Summary      Проверки по зонам (оператор)
+// @ID           getIncidentStats
+// @Description  Уникальные пользователи, проверки, проверки с алертом и число проверок в каждой зоне за window_minutes минут
+// @Description  до period_end — последней минуты, до которой фоновый воркер свел проверки. Редактор не видит внутренние зоны.
+// @Description  Проверки, сохраненные до появления сводки по зонам, в счетчики зон не попадают
+// @Tags         stats
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        window_minutes  query     int     false  "Длина окна в минутах (по умолчанию STATS_TIME_WINDOWS_MINUTES)"
+// @Success      200 {object} dtoResp.IncidentStatsResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Failure      503 {object} respond.ErrorResponse  "Проверки еще не сведены"
+// @Router       /api/v1/stats/incidents [get]
+func (h *StatsHandler) GetIncidentStats(w http.ResponseWriter, r *http.Request) {
+	window := h.windowMin
+	if v := r.URL.Query().Get("window_minutes"); v != "" {
+		var err error
+		if window, err = strconv.Atoi(v); err != nil || window <= 0 {
+			respond.Error(w, h.logger, http.StatusBadRequest, "invalid window_minutes parameter (must be a positive integer)")
+			return
+		}
+	}
+
+	stats, err := h.uc.GetIncidentStats(r.Context(), window)
+	if errors.Is(err, entity.ErrStatsNotReady) {
+		respond.Error(w, h.logger, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to get incident stats", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "failed to retrieve statistics")
+		return
+	}
+
+	response := dtoResp.IncidentStatsResponse{
+		WindowMinutes: window,
+		PeriodStart:   stats.PeriodStart,
+		PeriodEnd:     stats.PeriodEnd,
+		UserCount:     stats.Totals.Users,
+		TotalChecks:   stats.Totals.Checks,
+		AlertChecks:   stats.Totals.Alerts,
+		Incidents:     make([]dtoResp.IncidentCheckStatsResponse, len(stats.Incidents)),
+	}
+	for i, s := range stats.Incidents {
+		response.Incidents[i] = dtoResp.IncidentCheckStatsResponse{
+			IncidentID: s.IncidentID,
+			Name:       s.Name,
+			Checks:     s.Checks,
+		}
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
 
-	if err := json.NewEncoder(w).Encode(payload); err != nil {
-		h.logger.Error("failed to encode response", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"error": "failed to encode response"}`))
+// countMode разбирает параметр count: estimated — вернуть оценку числа строк вместо COUNT(*)
+func countMode(r *http.Request) (estimate bool, ok bool) {
+	switch r.URL.Query().Get("count") {
+	case "", "exact":
+		return false, true
+	case "estimated":
+		return true, true
+	default:
+		return false, false
 	}
 }
@@ -0,0 +1,316 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"go.uber.org/zap"
+)
+
+type SystemHandler struct {
+	logger       *zap.Logger
+	stats        cases.StatsUseCase
+	usage        cases.UsageUseCase
+	endpoints    cases.WebhookEndpointUseCase
+	queueBackend string
+}
+
+// NewSystemHandler: usage nil — учет запросов API-ключей выключен
+func NewSystemHandler(
+	logger *zap.Logger,
+	stats cases.StatsUseCase,
+	usage cases.UsageUseCase,
+	endpoints cases.WebhookEndpointUseCase,
+	queueBackend string,
+) *SystemHandler {
+	return &SystemHandler{
+		logger:       logger,
+		stats:        stats,
+		usage:        usage,
+		endpoints:    endpoints,
+		queueBackend: queueBackend,
+	}
+}
+
+// GetQueues обрабатывает GET /api/v1/system/queues
+// @Summary      Глубина и отставание очереди вебхуков (оператор)
+// @ID           getQueues
+// @Description  Число задач в очереди доставки, недоставленные вебхуки в outbox по состояниям, возраст самого старого
+// @Description  из них и отставание опроса outbox. Если очередь недоступна, ее ошибка возвращается в queue.error, а outbox считается
+// @Tags         system
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {object}  dtoResp.QueuesResponse
+// @Failure      401  {object}  respond.ErrorResponse
+// @Failure      500  {object}  respond.ErrorResponse
+// @Router       /api/v1/system/queues [get]
+func (h *SystemHandler) GetQueues(w http.ResponseWriter, r *http.Request) {
+	status, err := h.stats.GetQueueStatus(r.Context())
+	if err != nil {
+		h.logger.Error("failed to get queue status", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "failed to get queue status")
+		return
+	}
+
+	response := dtoResp.QueuesResponse{
+		Queue: dtoResp.WebhookQueueDepthResponse{
+			Backend: h.queueBackend,
+		},
+		Outbox: dtoResp.WebhookOutboxResponse{
+			Pending:                 status.Outbox.Pending,
+			Processing:              status.Outbox.Processing,
+			Failed:                  status.Outbox.Failed,
+			Due:                     status.Outbox.Due,
+			OldestPendingAgeSeconds: status.OldestPendingAge.Seconds(),
+			PollerLagSeconds:        status.PollerLag.Seconds(),
+		},
+		GeneratedAt: time.Now(),
+	}
+	if status.QueueErr != nil {
+		response.Queue.Error = status.QueueErr.Error()
+	} else {
+		response.Queue.Ready = status.Queue.Ready
+		response.Queue.Scheduled = status.Queue.Scheduled
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// GetWebhookSLO обрабатывает GET /api/v1/system/webhook-slo
+// @Summary      Задержка доставки вебхуков по получателям (оператор)
+// @ID           getWebhookSLO
+// @Description  Для каждого общего получателя — перцентили p50/p95/p99 задержки от создания проверки до успешной доставки
+// @Description  за последние WEBHOOK_SLO_WINDOW_MINUTES и нарушение цели WEBHOOK_SLO_LATENCY_MS; breached — число нарушителей.
+// @Description  Получатели без доставок за окно возвращаются с delivered 0
+// @Tags         system
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {object}  dtoResp.WebhookSLOListResponse
+// @Failure      401  {object}  respond.ErrorResponse
+// @Failure      500  {object}  respond.ErrorResponse
+// @Router       /api/v1/system/webhook-slo [get]
+func (h *SystemHandler) GetWebhookSLO(w http.ResponseWriter, r *http.Request) {
+	slos, err := h.endpoints.EndpointSLOs(r.Context())
+	if err != nil {
+		h.logger.Error("failed to get webhook delivery latency", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "failed to get webhook delivery latency")
+		return
+	}
+
+	response := dtoResp.WebhookSLOListResponse{
+		Endpoints:   make([]dtoResp.WebhookSLOResponse, 0, len(slos)),
+		GeneratedAt: time.Now(),
+	}
+	for _, slo := range slos {
+		response.Endpoints = append(response.Endpoints, toWebhookSLOResponse(slo))
+		if slo.Breached {
+			response.Breached++
+		}
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// GetUsage обрабатывает GET /api/v1/system/usage
+// @Summary      Запросы API-ключей и квоты (оператор, клиент)
+// @ID           getUsage
+// @Description  Число запросов, проверок координат, алертов и вебхуков каждого API-ключа по суткам (UTC) с from по to, квоты ключей и их счетчики за текущие сутки
+// @Description  и месяц. По суткам данные переносятся из Redis периодически, поэтому текущие сутки в days отстают; current точен.
+// @Description  Ключ из API_KEYS видит только себя
+// @Tags         system
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        from  query     string  false  "Первые сутки, YYYY-MM-DD (по умолчанию — начало текущего месяца)"
+// @Param        to    query     string  false  "Последние сутки, YYYY-MM-DD (по умолчанию — сегодня)"
+// @Success      200  {object}  dtoResp.UsageResponse
+// @Failure      400  {object}  respond.ErrorResponse
+// @Failure      401  {object}  respond.ErrorResponse
+// @Failure      500  {object}  respond.ErrorResponse
+// @Router       /api/v1/system/usage [get]
+func (h *SystemHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	if h.usage == nil {
+		respond.JSON(w, h.logger, http.StatusOK, dtoResp.UsageResponse{Keys: []dtoResp.APIKeyUsageResponse{}})
+		return
+	}
+
+	now := time.Now().UTC()
+	from, to, err := usagePeriod(r,
+		time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.usage.GetUsage(r.Context(), from, to)
+	if errors.Is(err, entity.ErrInvalidUsageDay) {
+		respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to get api key usage", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "failed to get api key usage")
+		return
+	}
+
+	response := dtoResp.UsageResponse{
+		Enabled: true,
+		From:    report.From.Format(time.DateOnly),
+		To:      report.To.Format(time.DateOnly),
+		Keys:    make([]dtoResp.APIKeyUsageResponse, 0, len(report.Keys)),
+	}
+	for _, ku := range report.Keys {
+		key := dtoResp.APIKeyUsageResponse{
+			Key:          ku.Key,
+			DailyQuota:   ku.Quota.Daily,
+			MonthlyQuota: ku.Quota.Monthly,
+			Days:         make([]dtoResp.UsageDayResponse, 0, len(ku.Days)),
+		}
+		if ku.CurrentErr != nil {
+			key.Current.Error = ku.CurrentErr.Error()
+		} else {
+			key.Current.Today = ku.Current.Day
+			key.Current.ThisMonth = ku.Current.Month
+		}
+		for _, d := range ku.Days {
+			key.Days = append(key.Days, dtoResp.UsageDayResponse{
+				Day:      d.Day.Format(time.DateOnly),
+				Requests: d.Requests,
+				Checks:   d.Checks,
+				Alerts:   d.Alerts,
+				Webhooks: d.Webhooks,
+			})
+			key.Total += d.Requests
+		}
+		response.Keys = append(response.Keys, key)
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// ExportUsage обрабатывает GET /api/v1/system/usage/export
+// @Summary      Выгрузка использования API-ключей для биллинга (оператор, клиент)
+// @ID           exportUsage
+// @Description  Строка на ключ и сутки (UTC) с from по to: запросы, записанные проверки координат, проверки с алертом
+// @Description  и вебхуки, поставленные в очередь доставки. Сутки без использования не выгружаются. По умолчанию —
+// @Description  вчерашние сутки, уже полностью перенесенные из Redis. Ключ из API_KEYS выгружает только себя
+// @Tags         system
+// @Produce      json
+// @Produce      text/csv
+// @Security     ApiKeyAuth
+// @Param        from    query     string  false  "Первые сутки, YYYY-MM-DD (по умолчанию — вчера)"
+// @Param        to      query     string  false  "Последние сутки, YYYY-MM-DD (по умолчанию — вчера)"
+// @Param        format  query     string  false  "Формат выгрузки"  Enums(csv, json)  default(csv)
+// @Success      200  {object}  dtoResp.UsageExportResponse
+// @Failure      400  {object}  respond.ErrorResponse
+// @Failure      401  {object}  respond.ErrorResponse
+// @Failure      404  {object}  respond.ErrorResponse
+// @Failure      500  {object}  respond.ErrorResponse
+// @Router       /api/v1/system/usage/export [get]
+func (h *SystemHandler) ExportUsage(w http.ResponseWriter, r *http.Request) {
+	if h.usage == nil {
+		respond.Error(w, h.logger, http.StatusNotFound, "api key usage metering is disabled")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		respond.Error(w, h.logger, http.StatusBadRequest, "format must be csv or json")
+		return
+	}
+
+	now := time.Now().UTC()
+	yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+	from, to, err := usagePeriod(r, yesterday, yesterday)
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	usage, err := h.usage.ExportUsage(r.Context(), from, to)
+	if errors.Is(err, entity.ErrInvalidUsageDay) {
+		respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to export api key usage", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "failed to export api key usage")
+		return
+	}
+
+	response := dtoResp.UsageExportResponse{
+		From: from.Format(time.DateOnly),
+		To:   to.Format(time.DateOnly),
+		Rows: make([]dtoResp.UsageExportRow, 0, len(usage)),
+	}
+	for _, u := range usage {
+		response.Rows = append(response.Rows, dtoResp.UsageExportRow{
+			APIKey:   u.Key,
+			Day:      u.Day.Format(time.DateOnly),
+			Requests: u.Requests,
+			Checks:   u.Checks,
+			Alerts:   u.Alerts,
+			Webhooks: u.Webhooks,
+		})
+	}
+
+	if format == "json" {
+		respond.JSON(w, h.logger, http.StatusOK, response)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="usage_%s_%s.csv"`, response.From, response.To))
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	records := [][]string{{"api_key", "day", "requests", "checks", "alerts", "webhooks"}}
+	for _, row := range response.Rows {
+		records = append(records, []string{
+			row.APIKey,
+			row.Day,
+			strconv.FormatInt(row.Requests, 10),
+			strconv.FormatInt(row.Checks, 10),
+			strconv.FormatInt(row.Alerts, 10),
+			strconv.FormatInt(row.Webhooks, 10),
+		})
+	}
+	// заголовок уже отправлен, поэтому ошибка записи только логируется
+	if err := cw.WriteAll(records); err != nil {
+		h.logger.Error("failed to write response", zap.Error(err))
+	}
+}
+
+// usagePeriod читает сутки from и to из запроса; пустые параметры заменяются значениями по умолчанию
+func usagePeriod(r *http.Request, from, to time.Time) (time.Time, time.Time, error) {
+	for _, param := range []struct {
+		name string
+		day  *time.Time
+	}{
+		{"from", &from},
+		{"to", &to},
+	} {
+		v := r.URL.Query().Get(param.name)
+		if v == "" {
+			continue
+		}
+		day, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, entity.ErrInvalidUsageDay
+		}
+		*param.day = day
+	}
+	return from, to, nil
+}
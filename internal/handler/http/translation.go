@@ -0,0 +1,163 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/4otis/geonotify-service/pkg/locale"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// @Summary      Переводы зоны (оператор)
+// @ID           listIncidentTranslations
+// @Description  Названия и описания зоны на других языках. Клиенты получают их в проверках координат и списках
+// @Description  инцидентов по заголовку Accept-Language
+// @Tags         incidents
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int     true  "ID инцидента"
+// @Success      200            {object}  dtoResp.IncidentTranslationsResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/translations [get]
+func (h *IncidentHandler) IncidentTranslations(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	translations, err := h.uc.IncidentTranslations(r.Context(), id)
+	if err != nil {
+		h.logger.Error("incident translations read failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	response := dtoResp.IncidentTranslationsResponse{
+		IncidentID:   id,
+		Translations: make([]dtoResp.IncidentTranslationResponse, len(translations)),
+	}
+	for i := range translations {
+		response.Translations[i] = toTranslationResponse(&translations[i])
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// @Summary      Задать перевод зоны (оператор)
+// @ID           putIncidentTranslation
+// @Description  Создать или заменить название и описание зоны на языке locale (тег BCP 47: en, pt-BR).
+// @Description  Пустое описание перевода — клиент получит исходное. Права те же, что на изменение зоны
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int                                  true  "ID инцидента"
+// @Param        locale         path      string                               true  "Язык перевода"
+// @Param        request        body      dtoReq.IncidentTranslationRequest    true  "Перевод"
+// @Success      200            {object}  dtoResp.IncidentTranslationResponse
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный формат данных или язык"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Зона опубликована, изменить ее может только публикатор"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент не найден"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/translations/{locale} [put]
+func (h *IncidentHandler) IncidentTranslationPut(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	var req dtoReq.IncidentTranslationRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	translation, err := h.uc.SetIncidentTranslation(r.Context(), entity.IncidentTranslation{
+		IncidentID: id,
+		Locale:     chi.URLParam(r, "locale"),
+		Name:       req.Name,
+		Descr:      req.Descr,
+	})
+	if err != nil {
+		h.logger.Error("incident translation save failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toTranslationResponse(translation))
+}
+
+// @Summary      Удалить перевод зоны (оператор)
+// @ID           deleteIncidentTranslation
+// @Description  Клиенты, предпочитающие этот язык, снова получат исходное название и описание
+// @Tags         incidents
+// @Security     ApiKeyAuth
+// @Param        incident_id    path      int     true  "ID инцидента"
+// @Param        locale         path      string  true  "Язык перевода"
+// @Success      204
+// @Failure      400            {object}  respond.ErrorResponse  "Неверный ID или язык"
+// @Failure      401            {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403            {object}  respond.ErrorResponse  "Зона опубликована, изменить ее может только публикатор"
+// @Failure      404            {object}  respond.ErrorResponse  "Инцидент или перевод не найден"
+// @Failure      500            {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/incidents/{incident_id}/translations/{locale} [delete]
+func (h *IncidentHandler) IncidentTranslationDelete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "incident_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	if err := h.uc.DeleteIncidentTranslation(r.Context(), id, chi.URLParam(r, "locale")); err != nil {
+		h.logger.Error("incident translation delete failed",
+			zap.Error(err),
+			zap.Int("id", id))
+
+		h.respondWithWriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// acceptLocales возвращает языки из Accept-Language по убыванию предпочтения
+func acceptLocales(r *http.Request) []string {
+	return locale.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+// localeETag добавляет к ETag языки клиента: ответы на разных языках — разные представления
+func localeETag(etag string, locales []string) string {
+	if len(locales) == 0 {
+		return etag
+	}
+	return etag + "-" + strings.Join(locales, ",")
+}
+
+func toTranslationResponse(t *entity.IncidentTranslation) dtoResp.IncidentTranslationResponse {
+	return dtoResp.IncidentTranslationResponse{
+		Locale:    t.Locale,
+		Name:      t.Name,
+		Descr:     t.Descr,
+		UpdatedBy: t.UpdatedBy,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Deprecated помечает ответы устаревшей версии API заголовками Deprecation с датой устаревания (RFC 9745),
+// Sunset с датой отключения (RFC 8594) и Link со ссылкой на эндпоинт, который ее заменяет
+func Deprecated(successor string, deprecatedAt, sunset time.Time) func(http.Handler) http.Handler {
+	deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	sunsetAt := sunset.UTC().Format(http.TimeFormat)
+	link := fmt.Sprintf(`<%s>; rel="successor-version"`, successor)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", deprecation)
+			w.Header().Set("Sunset", sunsetAt)
+			w.Header().Add("Link", link)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
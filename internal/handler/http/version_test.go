@@ -0,0 +1,29 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeprecated(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 16, 0, 0, 0, 0, time.UTC)
+	handler := Deprecated("/api/v2/location/check", deprecatedAt, sunset)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/location/check", nil))
+
+	want := map[string]string{
+		"Deprecation": "@1792108800",
+		"Sunset":      "Fri, 16 Apr 2027 00:00:00 GMT",
+		"Link":        `</api/v2/location/check>; rel="successor-version"`,
+	}
+	for name, value := range want {
+		if got := rec.Header().Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+}
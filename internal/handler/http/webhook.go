@@ -0,0 +1,160 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	logger *zap.Logger
+	uc     cases.WebhookUseCase
+}
+
+func NewWebhookHandler(logger *zap.Logger, uc cases.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// TestWebhook обрабатывает POST /api/v1/webhooks/test
+// @Summary      Отправить тестовый вебхук (оператор)
+// @ID           testWebhook
+// @Description  Отправляет пример payload на указанный URL тем же механизмом подписи и ретраев, что и реальные вебхуки, и возвращает статус и задержку получателя
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body dtoReq.WebhookTestRequest true "URL получателя и число попыток"
+// @Success      200 {object} dtoResp.WebhookTestResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v1/webhooks/test [post]
+func (h *WebhookHandler) TestWebhook(w http.ResponseWriter, r *http.Request) {
+	var req dtoReq.WebhookTestRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	result, err := h.uc.SendTestWebhook(r.Context(), req.URL, req.Attempts)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidWebhookURL) {
+			respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("test webhook failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	response := dtoResp.WebhookTestResponse{
+		Delivered:  result.Delivered,
+		StatusCode: result.StatusCode,
+		LatencyMs:  float64(result.Latency.Microseconds()) / 1000,
+		Attempts:   result.Attempts,
+	}
+	if result.Err != nil {
+		response.Error = result.Err.Error()
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// ReplayWebhooks обрабатывает POST /api/v1/webhooks/replay
+// @Summary      Повторить неотправленные вебхуки (оператор)
+// @ID           replayWebhooks
+// @Description  Возвращает в очередь доставки вебхуки, исчерпавшие попытки (state=failed), со сброшенным счетчиком.
+// @Description  Без ids повторяются все такие вебхуки
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body dtoReq.WebhookReplayRequest false "ID вебхуков"
+// @Success      200 {object} dtoResp.WebhookReplayResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v1/webhooks/replay [post]
+func (h *WebhookHandler) ReplayWebhooks(w http.ResponseWriter, r *http.Request) {
+	var req dtoReq.WebhookReplayRequest
+	// тело необязательно: пустой запрос повторяет все неотправленные вебхуки
+	if r.ContentLength != 0 {
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Invalid(w, h.logger, err)
+			return
+		}
+	}
+
+	requeued, err := h.uc.ReplayFailed(r.Context(), req.IDs)
+	if err != nil {
+		h.logger.Error("webhook replay failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, dtoResp.WebhookReplayResponse{Requeued: requeued})
+}
+
+// RedriveWebhooks обрабатывает POST /api/v1/webhooks/redrive
+// @Summary      Повторить недоставленные вебхуки по фильтру (оператор)
+// @ID           redriveWebhooks
+// @Description  Возвращает в доставку недоставленные вебхуки, созданные в [created_from, created_to), по зоне и получателю,
+// @Description  со сброшенным счетчиком и сразу ставит их в очередь. spread_seconds растягивает доставку на окно,
+// @Description  чтобы не перегрузить получателя после простоя. Без фильтров повторяются все недоставленные вебхуки
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body dtoReq.WebhookRedriveRequest false "Фильтры"
+// @Success      200 {object} dtoResp.WebhookRedriveResponse
+// @Failure      400 {object} respond.ErrorResponse
+// @Failure      401 {object} respond.ErrorResponse
+// @Failure      500 {object} respond.ErrorResponse
+// @Router       /api/v1/webhooks/redrive [post]
+func (h *WebhookHandler) RedriveWebhooks(w http.ResponseWriter, r *http.Request) {
+	var req dtoReq.WebhookRedriveRequest
+	// тело необязательно: пустой запрос повторяет все недоставленные вебхуки
+	if r.ContentLength != 0 {
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Invalid(w, h.logger, err)
+			return
+		}
+	}
+
+	filter := entity.WebhookRedriveFilter{
+		State:      req.State,
+		IncidentID: req.IncidentID,
+		EndpointID: req.EndpointID,
+		Spread:     time.Duration(req.SpreadSeconds) * time.Second,
+	}
+	if req.CreatedFrom != nil {
+		filter.CreatedFrom = *req.CreatedFrom
+	}
+	if req.CreatedTo != nil {
+		filter.CreatedTo = *req.CreatedTo
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedTo.After(filter.CreatedFrom) {
+		respond.Error(w, h.logger, http.StatusBadRequest, "created_to must be after created_from")
+		return
+	}
+
+	redriven, err := h.uc.RedriveFailed(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("webhook redrive failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, dtoResp.WebhookRedriveResponse{Redriven: redriven})
+}
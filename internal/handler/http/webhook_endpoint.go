@@ -0,0 +1,343 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	dtoReq "github.com/4otis/geonotify-service/internal/dto/req"
+	dtoResp "github.com/4otis/geonotify-service/internal/dto/resp"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/handler/http/bind"
+	"github.com/4otis/geonotify-service/internal/handler/http/respond"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+type WebhookEndpointHandler struct {
+	logger *zap.Logger
+	uc     cases.WebhookEndpointUseCase
+}
+
+func NewWebhookEndpointHandler(logger *zap.Logger, uc cases.WebhookEndpointUseCase) *WebhookEndpointHandler {
+	return &WebhookEndpointHandler{
+		logger: logger,
+		uc:     uc,
+	}
+}
+
+// EndpointList обрабатывает GET /api/v1/webhook-endpoints
+// @Summary      Получатели вебхуков (оператор)
+// @ID           listWebhookEndpoints
+// @Description  Все зарегистрированные получатели. Секреты не возвращаются
+// @Tags         webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {object}  dtoResp.WebhookEndpointsListResponse
+// @Failure      401  {object}  respond.ErrorResponse
+// @Failure      500  {object}  respond.ErrorResponse
+// @Router       /api/v1/webhook-endpoints [get]
+func (h *WebhookEndpointHandler) EndpointList(w http.ResponseWriter, r *http.Request) {
+	endpoints, err := h.uc.ListEndpoints(r.Context())
+	if err != nil {
+		h.logger.Error("webhook endpoint list failed", zap.Error(err))
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	response := dtoResp.WebhookEndpointsListResponse{
+		Endpoints: make([]dtoResp.WebhookEndpointResponse, len(endpoints)),
+	}
+	for i, e := range endpoints {
+		response.Endpoints[i] = toWebhookEndpointResponse(e)
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, response)
+}
+
+// EndpointCreate обрабатывает POST /api/v1/webhook-endpoints
+// @Summary      Зарегистрировать получателя вебхуков (публикатор)
+// @ID           createWebhookEndpoint
+// @Description  Получатель начинает получать события из events ("*" — все события) сразу после регистрации.
+// @Description  Вебхуки подписываются секретом, если он задан. Не заданные max_retries, retry_delay_seconds,
+// @Description  backoff и timeout_seconds берутся из настроек сервиса. С batch_size события копятся до batch_size штук
+// @Description  или batch_wait_ms и уходят одним NDJSON-запросом (X-Geonotify-Event: batch), с compression=gzip тело
+// @Description  сжимается (Content-Encoding: gzip); подпись считается по несжатому телу
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request  body      dtoReq.WebhookEndpointCreateRequest  true  "URL, секрет и события получателя"
+// @Success      201      {object}  dtoResp.WebhookEndpointResponse
+// @Failure      400      {object}  respond.ErrorResponse  "Неверный URL, события, политика попыток или батчинг"
+// @Failure      401      {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403      {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      500      {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/webhook-endpoints [post]
+func (h *WebhookEndpointHandler) EndpointCreate(w http.ResponseWriter, r *http.Request) {
+	var req dtoReq.WebhookEndpointCreateRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	endpoint := entity.WebhookEndpoint{
+		URL:     req.URL,
+		Secret:  req.Secret,
+		Events:  req.Events,
+		Enabled: true,
+		Retry: entity.WebhookRetryPolicy{
+			MaxRetries:        req.MaxRetries,
+			RetryDelaySeconds: req.RetryDelaySeconds,
+			Backoff:           req.Backoff,
+			TimeoutSeconds:    req.TimeoutSeconds,
+		},
+		Delivery: entity.WebhookDelivery{
+			BatchSize:   req.BatchSize,
+			BatchWaitMs: req.BatchWaitMs,
+			Compression: compression(req.Compression),
+		},
+	}
+	if req.Enabled != nil {
+		endpoint.Enabled = *req.Enabled
+	}
+
+	created, err := h.uc.CreateEndpoint(r.Context(), endpoint)
+	if err != nil {
+		h.logger.Error("webhook endpoint create failed", zap.Error(err))
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusCreated, toWebhookEndpointResponse(created))
+}
+
+// EndpointGet обрабатывает GET /api/v1/webhook-endpoints/{endpoint_id}
+// @Summary      Получатель вебхуков (оператор)
+// @ID           getWebhookEndpoint
+// @Tags         webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        endpoint_id  path      int  true  "ID получателя"
+// @Success      200          {object}  dtoResp.WebhookEndpointResponse
+// @Failure      400          {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401          {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404          {object}  respond.ErrorResponse  "Получатель не найден"
+// @Failure      500          {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/webhook-endpoints/{endpoint_id} [get]
+func (h *WebhookEndpointHandler) EndpointGet(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "endpoint_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	endpoint, err := h.uc.ReadEndpoint(r.Context(), id)
+	if err != nil {
+		if !errors.Is(err, entity.ErrWebhookEndpointNotFound) {
+			h.logger.Error("webhook endpoint read failed",
+				zap.Error(err),
+				zap.Int("id", id))
+		}
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toWebhookEndpointResponse(endpoint))
+}
+
+// EndpointUpdate обрабатывает PATCH /api/v1/webhook-endpoints/{endpoint_id}
+// @Summary      Изменить получателя вебхуков (публикатор)
+// @ID           updateWebhookEndpoint
+// @Description  Меняет только переданные поля. Выключенному получателю новые события не создаются,
+// @Description  а уже созданные вебхуки помечаются недоставленными. Новая политика попыток применяется со следующей попытки
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        endpoint_id  path      int                                  true  "ID получателя"
+// @Param        request      body      dtoReq.WebhookEndpointPatchRequest  true  "Изменяемые поля"
+// @Success      200          {object}  dtoResp.WebhookEndpointResponse
+// @Failure      400          {object}  respond.ErrorResponse  "Неверный ID, URL, события, политика попыток или батчинг"
+// @Failure      401          {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403          {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404          {object}  respond.ErrorResponse  "Получатель не найден"
+// @Failure      500          {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/webhook-endpoints/{endpoint_id} [patch]
+func (h *WebhookEndpointHandler) EndpointUpdate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "endpoint_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	var req dtoReq.WebhookEndpointPatchRequest
+	if err := bind.JSON(r, &req); err != nil {
+		respond.Invalid(w, h.logger, err)
+		return
+	}
+
+	patch := entity.WebhookEndpointPatch{
+		URL:     req.URL,
+		Secret:  req.Secret,
+		Events:  req.Events,
+		Enabled: req.Enabled,
+
+		MaxRetries:        req.MaxRetries,
+		RetryDelaySeconds: req.RetryDelaySeconds,
+		Backoff:           req.Backoff,
+		TimeoutSeconds:    req.TimeoutSeconds,
+
+		BatchSize:   req.BatchSize,
+		BatchWaitMs: req.BatchWaitMs,
+	}
+	if req.Compression != nil {
+		c := compression(*req.Compression)
+		patch.Compression = &c
+	}
+
+	updated, err := h.uc.UpdateEndpoint(r.Context(), id, patch)
+	if err != nil {
+		h.logger.Error("webhook endpoint update failed",
+			zap.Error(err),
+			zap.Int("id", id))
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toWebhookEndpointResponse(updated))
+}
+
+// EndpointDelete обрабатывает DELETE /api/v1/webhook-endpoints/{endpoint_id}
+// @Summary      Удалить получателя вебхуков (публикатор)
+// @ID           deleteWebhookEndpoint
+// @Description  Удаляет получателя вместе с его вебхуками, включая недоставленные
+// @Tags         webhooks
+// @Security     ApiKeyAuth
+// @Param        endpoint_id  path  int  true  "ID получателя"
+// @Success      204
+// @Failure      400  {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401  {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      403  {object}  respond.ErrorResponse  "Недостаточно прав"
+// @Failure      404  {object}  respond.ErrorResponse  "Получатель не найден"
+// @Failure      500  {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/webhook-endpoints/{endpoint_id} [delete]
+func (h *WebhookEndpointHandler) EndpointDelete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "endpoint_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	if err := h.uc.DeleteEndpoint(r.Context(), id); err != nil {
+		h.logger.Error("webhook endpoint delete failed",
+			zap.Error(err),
+			zap.Int("id", id))
+		h.respondWithError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EndpointSLO обрабатывает GET /api/v1/webhook-endpoints/{endpoint_id}/slo
+// @Summary      Задержка доставки получателю и цель SLO (оператор)
+// @ID           getWebhookEndpointSLO
+// @Description  Перцентили p50/p95/p99 задержки от создания проверки до успешной доставки вебхука за последние WEBHOOK_SLO_WINDOW_MINUTES
+// @Description  и сравнение перцентиля WEBHOOK_SLO_PERCENTILE с целью WEBHOOK_SLO_LATENCY_MS. Недоставленные вебхуки в задержку не входят
+// @Tags         webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        endpoint_id  path      int  true  "ID получателя"
+// @Success      200          {object}  dtoResp.WebhookSLOResponse
+// @Failure      400          {object}  respond.ErrorResponse  "Неверный ID"
+// @Failure      401          {object}  respond.ErrorResponse  "Не авторизован"
+// @Failure      404          {object}  respond.ErrorResponse  "Получатель не найден"
+// @Failure      500          {object}  respond.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /api/v1/webhook-endpoints/{endpoint_id}/slo [get]
+func (h *WebhookEndpointHandler) EndpointSLO(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "endpoint_id"))
+	if err != nil {
+		respond.Error(w, h.logger, http.StatusBadRequest, "id required/not valid")
+		return
+	}
+
+	slo, err := h.uc.EndpointSLO(r.Context(), id)
+	if err != nil {
+		if !errors.Is(err, entity.ErrWebhookEndpointNotFound) {
+			h.logger.Error("webhook endpoint slo read failed",
+				zap.Error(err),
+				zap.Int("id", id))
+		}
+		h.respondWithError(w, err)
+		return
+	}
+
+	respond.JSON(w, h.logger, http.StatusOK, toWebhookSLOResponse(*slo))
+}
+
+func (h *WebhookEndpointHandler) respondWithError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidWebhookURL),
+		errors.Is(err, entity.ErrInvalidWebhookEvents),
+		errors.Is(err, entity.ErrInvalidRetryPolicy),
+		errors.Is(err, entity.ErrInvalidWebhookDelivery):
+		respond.Error(w, h.logger, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entity.ErrWebhookEndpointNotFound):
+		respond.Error(w, h.logger, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrForbidden):
+		respond.Error(w, h.logger, http.StatusForbidden, err.Error())
+	default:
+		respond.Error(w, h.logger, http.StatusInternalServerError, "internal error")
+	}
+}
+
+func toWebhookEndpointResponse(e *entity.WebhookEndpoint) dtoResp.WebhookEndpointResponse {
+	return dtoResp.WebhookEndpointResponse{
+		EndpointID: e.ID,
+		URL:        e.URL,
+		HasSecret:  e.Secret != "",
+		Events:     e.Events,
+		Enabled:    e.Enabled,
+
+		MaxRetries:        e.Retry.MaxRetries,
+		RetryDelaySeconds: e.Retry.RetryDelaySeconds,
+		Backoff:           e.Retry.Backoff,
+		TimeoutSeconds:    e.Retry.TimeoutSeconds,
+
+		BatchSize:   e.Delivery.BatchSize,
+		BatchWaitMs: e.Delivery.BatchWaitMs,
+		Compression: e.Delivery.Compression,
+
+		CreatedBy: e.CreatedBy,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+func toWebhookSLOResponse(slo cases.WebhookSLO) dtoResp.WebhookSLOResponse {
+	return dtoResp.WebhookSLOResponse{
+		EndpointID:    slo.EndpointID,
+		URL:           slo.URL,
+		Enabled:       slo.Enabled,
+		WindowMinutes: int(slo.Window / time.Minute),
+		Delivered:     slo.Latency.Delivered,
+		P50Ms:         slo.Latency.P50.Milliseconds(),
+		P95Ms:         slo.Latency.P95.Milliseconds(),
+		P99Ms:         slo.Latency.P99.Milliseconds(),
+		Percentile:    slo.Percentile,
+		TargetMs:      slo.Target.Milliseconds(),
+		MinDeliveries: slo.MinDeliveries,
+		Breached:      slo.Breached,
+	}
+}
+
+// compression переводит значение запроса в настройку получателя: none — без сжатия
+func compression(value string) string {
+	if value == "none" {
+		return ""
+	}
+	return value
+}
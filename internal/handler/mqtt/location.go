@@ -0,0 +1,185 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/entity"
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"go.uber.org/zap"
+)
+
+type Options struct {
+	BrokerURL string
+	ClientID  string
+	Username  string
+	Password  string
+	// Topic — шаблон подписки; сегмент "+" содержит идентификатор устройства (user_id).
+	// Поддерживаются общие подписки вида $share/<group>/geonotify/+/location
+	Topic string
+	QoS   byte
+}
+
+// LocationMessage — payload от трекера; user_id можно не передавать, он берется из топика
+type LocationMessage struct {
+	UserID    string   `json:"user_id"`
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	AccuracyM float64  `json:"accuracy_m,omitempty"`
+	Altitude  *float64 `json:"altitude,omitempty"`
+	// SpeedMps и HeadingDeg включают прогноз попадания в зону
+	SpeedMps   *float64 `json:"speed_mps,omitempty"`
+	HeadingDeg *float64 `json:"heading_deg,omitempty"`
+}
+
+// LocationSubscriber принимает координаты устройств по MQTT и прогоняет их через CheckLocation
+type LocationSubscriber struct {
+	logger      *zap.Logger
+	uc          cases.LocationUseCase
+	client      paho.Client
+	topic       string
+	qos         byte
+	deviceIndex int
+}
+
+func NewLocationSubscriber(logger *zap.Logger, uc cases.LocationUseCase, opts Options) (*LocationSubscriber, error) {
+	deviceIndex := deviceSegment(opts.Topic)
+	if deviceIndex < 0 {
+		return nil, fmt.Errorf("mqtt topic %q must contain a single-level wildcard (+) for the device id", opts.Topic)
+	}
+
+	s := &LocationSubscriber{
+		logger:      logger,
+		uc:          uc,
+		topic:       opts.Topic,
+		qos:         opts.QoS,
+		deviceIndex: deviceIndex,
+	}
+
+	clientOpts := paho.NewClientOptions().
+		AddBroker(opts.BrokerURL).
+		SetClientID(opts.ClientID).
+		SetUsername(opts.Username).
+		SetPassword(opts.Password).
+		SetCleanSession(false).
+		SetAutoReconnect(true).
+		SetOrderMatters(false).
+		SetConnectTimeout(10 * time.Second).
+		SetOnConnectHandler(s.onConnect).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			logger.Warn("MQTT connection lost", zap.Error(err))
+		})
+
+	s.client = paho.NewClient(clientOpts)
+
+	return s, nil
+}
+
+func (s *LocationSubscriber) Start() error {
+	token := s.client.Connect()
+	if !token.WaitTimeout(10 * time.Second) {
+		return fmt.Errorf("mqtt connect timeout")
+	}
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("failed to connect to mqtt broker: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocationSubscriber) Stop() {
+	s.logger.Info("Stopping MQTT location subscriber")
+	s.client.Disconnect(1000)
+}
+
+// onConnect (пере)подписывается при каждом подключении, в т.ч. после реконнекта
+func (s *LocationSubscriber) onConnect(client paho.Client) {
+	token := client.Subscribe(s.topic, s.qos, s.handleMessage)
+	token.Wait()
+	if err := token.Error(); err != nil {
+		s.logger.Error("MQTT subscribe failed", zap.Error(err), zap.String("topic", s.topic))
+		return
+	}
+
+	s.logger.Info("MQTT location subscriber connected", zap.String("topic", s.topic))
+}
+
+func (s *LocationSubscriber) handleMessage(_ paho.Client, msg paho.Message) {
+	// паника в колбэке paho остановила бы весь процесс
+	defer func() {
+		if rec := recover(); rec != nil {
+			s.logger.Error("panic while handling MQTT location message",
+				zap.Any("panic", rec),
+				zap.String("topic", msg.Topic()),
+				zap.Stack("stack"))
+		}
+	}()
+
+	var location LocationMessage
+	if err := json.Unmarshal(msg.Payload(), &location); err != nil {
+		s.logger.Warn("invalid MQTT location payload",
+			zap.Error(err),
+			zap.String("topic", msg.Topic()))
+		return
+	}
+
+	if location.UserID == "" {
+		location.UserID = s.deviceID(msg.Topic())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := s.uc.CheckLocation(ctx, cases.LocationCheckQuery{
+		UserID:     location.UserID,
+		Latitude:   location.Latitude,
+		Longitude:  location.Longitude,
+		AccuracyM:  location.AccuracyM,
+		Altitude:   location.Altitude,
+		SpeedMps:   location.SpeedMps,
+		HeadingDeg: location.HeadingDeg,
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrUserIDRequired) || errors.Is(err, entity.ErrInvalidCoordinates) || errors.Is(err, entity.ErrInvalidAccuracy) || errors.Is(err, entity.ErrInvalidMotion) || errors.Is(err, entity.ErrOutsideArea) {
+			s.logger.Warn("invalid MQTT location update",
+				zap.Error(err),
+				zap.String("topic", msg.Topic()))
+			return
+		}
+		s.logger.Error("MQTT location check failed",
+			zap.Error(err),
+			zap.String("user_id", location.UserID))
+	}
+}
+
+func (s *LocationSubscriber) deviceID(topic string) string {
+	segments := strings.Split(topic, "/")
+	if s.deviceIndex >= len(segments) {
+		return ""
+	}
+	return segments[s.deviceIndex]
+}
+
+// deviceSegment возвращает номер сегмента "+" в шаблоне без префикса общей подписки
+func deviceSegment(pattern string) int {
+	if strings.HasPrefix(pattern, "$share/") {
+		parts := strings.SplitN(pattern, "/", 3)
+		if len(parts) < 3 {
+			return -1
+		}
+		pattern = parts[2]
+	}
+
+	for i, segment := range strings.Split(pattern, "/") {
+		if segment == "+" {
+			return i
+		}
+	}
+
+	return -1
+}
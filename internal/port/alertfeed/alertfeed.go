@@ -0,0 +1,14 @@
+package alertfeed
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// Reader читает внешнюю ленту оповещений: документ CAP, Atom или RSS с GeoRSS
+type Reader interface {
+	// Read возвращает оповещения ленты, которые можно привести к зоне. Оповещения без геометрии
+	// и не относящиеся к реальной обстановке (учения, тесты) пропускаются
+	Read(ctx context.Context, url string) ([]entity.ExternalAlert, error)
+}
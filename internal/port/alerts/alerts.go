@@ -0,0 +1,28 @@
+package alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// Bus доставляет алерты подключенным клиентам на любой реплике
+type Bus interface {
+	Publish(ctx context.Context, alert entity.Alert) error
+	Subscribe(ctx context.Context, userID string) (alerts <-chan entity.Alert, unsubscribe func(), err error)
+}
+
+// LocationIndex хранит последнюю известную точку пользователя
+type LocationIndex interface {
+	Track(ctx context.Context, userID string, lat, lng float64) error
+	UsersWithin(ctx context.Context, lat, lng, radiusM float64) ([]string, error)
+	// Forget удаляет последнюю точку пользователя
+	Forget(ctx context.Context, userID string) error
+}
+
+// AlertCounter считает алерты пользователя в окнах фиксированной длины
+type AlertCounter interface {
+	// Increment учитывает алерт и возвращает число алертов пользователя в текущем окне вместе с ним
+	Increment(ctx context.Context, userID string, window time.Duration) (int64, error)
+}
@@ -0,0 +1,3 @@
+package alerts
+
+//go:generate mockgen -destination=mocks/alerts.go -package=mocks github.com/4otis/geonotify-service/internal/port/alerts Bus,LocationIndex,AlertCounter
@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/4otis/geonotify-service/internal/port/alerts (interfaces: Bus,LocationIndex,AlertCounter)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/alerts.go -package=mocks github.com/4otis/geonotify-service/internal/port/alerts Bus,LocationIndex,AlertCounter
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/4otis/geonotify-service/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBus is a mock of Bus interface.
+type MockBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockBusMockRecorder
+	isgomock struct{}
+}
+
+// MockBusMockRecorder is the mock recorder for MockBus.
+type MockBusMockRecorder struct {
+	mock *MockBus
+}
+
+// NewMockBus creates a new mock instance.
+func NewMockBus(ctrl *gomock.Controller) *MockBus {
+	mock := &MockBus{ctrl: ctrl}
+	mock.recorder = &MockBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBus) EXPECT() *MockBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockBus) Publish(ctx context.Context, alert entity.Alert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockBusMockRecorder) Publish(ctx, alert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockBus)(nil).Publish), ctx, alert)
+}
+
+// Subscribe mocks base method.
+func (m *MockBus) Subscribe(ctx context.Context, userID string) (<-chan entity.Alert, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, userID)
+	ret0, _ := ret[0].(<-chan entity.Alert)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockBusMockRecorder) Subscribe(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockBus)(nil).Subscribe), ctx, userID)
+}
+
+// MockLocationIndex is a mock of LocationIndex interface.
+type MockLocationIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationIndexMockRecorder
+	isgomock struct{}
+}
+
+// MockLocationIndexMockRecorder is the mock recorder for MockLocationIndex.
+type MockLocationIndexMockRecorder struct {
+	mock *MockLocationIndex
+}
+
+// NewMockLocationIndex creates a new mock instance.
+func NewMockLocationIndex(ctrl *gomock.Controller) *MockLocationIndex {
+	mock := &MockLocationIndex{ctrl: ctrl}
+	mock.recorder = &MockLocationIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationIndex) EXPECT() *MockLocationIndexMockRecorder {
+	return m.recorder
+}
+
+// Forget mocks base method.
+func (m *MockLocationIndex) Forget(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forget", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Forget indicates an expected call of Forget.
+func (mr *MockLocationIndexMockRecorder) Forget(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forget", reflect.TypeOf((*MockLocationIndex)(nil).Forget), ctx, userID)
+}
+
+// Track mocks base method.
+func (m *MockLocationIndex) Track(ctx context.Context, userID string, lat, lng float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Track", ctx, userID, lat, lng)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Track indicates an expected call of Track.
+func (mr *MockLocationIndexMockRecorder) Track(ctx, userID, lat, lng any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockLocationIndex)(nil).Track), ctx, userID, lat, lng)
+}
+
+// UsersWithin mocks base method.
+func (m *MockLocationIndex) UsersWithin(ctx context.Context, lat, lng, radiusM float64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsersWithin", ctx, lat, lng, radiusM)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsersWithin indicates an expected call of UsersWithin.
+func (mr *MockLocationIndexMockRecorder) UsersWithin(ctx, lat, lng, radiusM any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsersWithin", reflect.TypeOf((*MockLocationIndex)(nil).UsersWithin), ctx, lat, lng, radiusM)
+}
+
+// MockAlertCounter is a mock of AlertCounter interface.
+type MockAlertCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertCounterMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertCounterMockRecorder is the mock recorder for MockAlertCounter.
+type MockAlertCounterMockRecorder struct {
+	mock *MockAlertCounter
+}
+
+// NewMockAlertCounter creates a new mock instance.
+func NewMockAlertCounter(ctrl *gomock.Controller) *MockAlertCounter {
+	mock := &MockAlertCounter{ctrl: ctrl}
+	mock.recorder = &MockAlertCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertCounter) EXPECT() *MockAlertCounterMockRecorder {
+	return m.recorder
+}
+
+// Increment mocks base method.
+func (m *MockAlertCounter) Increment(ctx context.Context, userID string, window time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, userID, window)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockAlertCounterMockRecorder) Increment(ctx, userID, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockAlertCounter)(nil).Increment), ctx, userID, window)
+}
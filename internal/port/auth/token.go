@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type TokenIssuer interface {
+	Issue(actor entity.Actor) (token string, expiresAt time.Time, err error)
+}
+
+type TokenVerifier interface {
+	// Verify возвращает entity.ErrInvalidToken для неверного или просроченного токена
+	Verify(ctx context.Context, token string) (entity.Actor, error)
+}
@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache — key-value кэш с TTL. Промах возвращается как entity.ErrCacheMiss
+type Cache interface {
+	Get(ctx context.Context, key string, dest interface{}) error
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	// TTL возвращает оставшееся время жизни ключа, 0 — ключ без срока
+	TTL(ctx context.Context, key string) (time.Duration, error)
+}
+
+// Counter считает события по ключу в окнах фиксированной длины
+type Counter interface {
+	// Increment учитывает событие и возвращает число событий ключа в текущем окне вместе с ним
+	Increment(ctx context.Context, key string, window time.Duration) (int64, error)
+}
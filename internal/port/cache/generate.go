@@ -0,0 +1,3 @@
+package cache
+
+//go:generate mockgen -destination=mocks/cache.go -package=mocks github.com/4otis/geonotify-service/internal/port/cache Cache,Counter
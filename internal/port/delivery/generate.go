@@ -0,0 +1,3 @@
+package delivery
+
+//go:generate mockgen -destination=mocks/delivery.go -package=mocks github.com/4otis/geonotify-service/internal/port/delivery WebhookSender,Queue
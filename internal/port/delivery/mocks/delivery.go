@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/4otis/geonotify-service/internal/port/delivery (interfaces: WebhookSender,Queue)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/delivery.go -package=mocks github.com/4otis/geonotify-service/internal/port/delivery WebhookSender,Queue
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/4otis/geonotify-service/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhookSender) Send(ctx context.Context, endpoint entity.WebhookEndpoint, eventType string, payload []byte) (entity.DeliveryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, endpoint, eventType, payload)
+	ret0, _ := ret[0].(entity.DeliveryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookSenderMockRecorder) Send(ctx, endpoint, eventType, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookSender)(nil).Send), ctx, endpoint, eventType, payload)
+}
+
+// SendBatch mocks base method.
+func (m *MockWebhookSender) SendBatch(ctx context.Context, endpoint entity.WebhookEndpoint, payloads [][]byte) (entity.DeliveryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBatch", ctx, endpoint, payloads)
+	ret0, _ := ret[0].(entity.DeliveryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendBatch indicates an expected call of SendBatch.
+func (mr *MockWebhookSenderMockRecorder) SendBatch(ctx, endpoint, payloads any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBatch", reflect.TypeOf((*MockWebhookSender)(nil).SendBatch), ctx, endpoint, payloads)
+}
+
+// MockQueue is a mock of Queue interface.
+type MockQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueueMockRecorder
+	isgomock struct{}
+}
+
+// MockQueueMockRecorder is the mock recorder for MockQueue.
+type MockQueueMockRecorder struct {
+	mock *MockQueue
+}
+
+// NewMockQueue creates a new mock instance.
+func NewMockQueue(ctrl *gomock.Controller) *MockQueue {
+	mock := &MockQueue{ctrl: ctrl}
+	mock.recorder = &MockQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueue) EXPECT() *MockQueueMockRecorder {
+	return m.recorder
+}
+
+// Depth mocks base method.
+func (m *MockQueue) Depth(ctx context.Context) (entity.QueueDepth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Depth", ctx)
+	ret0, _ := ret[0].(entity.QueueDepth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Depth indicates an expected call of Depth.
+func (mr *MockQueueMockRecorder) Depth(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Depth", reflect.TypeOf((*MockQueue)(nil).Depth), ctx)
+}
+
+// PopBlocking mocks base method.
+func (m *MockQueue) PopBlocking(ctx context.Context, timeout time.Duration) (*entity.WebhookTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopBlocking", ctx, timeout)
+	ret0, _ := ret[0].(*entity.WebhookTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopBlocking indicates an expected call of PopBlocking.
+func (mr *MockQueueMockRecorder) PopBlocking(ctx, timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopBlocking", reflect.TypeOf((*MockQueue)(nil).PopBlocking), ctx, timeout)
+}
+
+// Push mocks base method.
+func (m *MockQueue) Push(ctx context.Context, task entity.WebhookTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockQueueMockRecorder) Push(ctx, task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockQueue)(nil).Push), ctx, task)
+}
+
+// Schedule mocks base method.
+func (m *MockQueue) Schedule(ctx context.Context, task entity.WebhookTask, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, task, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockQueueMockRecorder) Schedule(ctx, task, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockQueue)(nil).Schedule), ctx, task, at)
+}
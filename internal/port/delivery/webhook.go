@@ -0,0 +1,27 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type WebhookSender interface {
+	// Send передает тип события в заголовке, получатель может маршрутизировать запрос без разбора тела
+	Send(ctx context.Context, endpoint entity.WebhookEndpoint, eventType string, payload []byte) (entity.DeliveryResult, error)
+	// SendBatch доставляет несколько событий одним запросом: пачка доставлена или не доставлена целиком
+	SendBatch(ctx context.Context, endpoint entity.WebhookEndpoint, payloads [][]byte) (entity.DeliveryResult, error)
+}
+
+// Queue передает воркеру задачи доставки вебхуков. Outbox в Postgres остается
+// источником истины: потерянная задача будет подобрана опросом БД
+type Queue interface {
+	Push(ctx context.Context, task entity.WebhookTask) error
+	// PopBlocking ждет задачу не дольше timeout; nil без ошибки — очередь пуста
+	PopBlocking(ctx context.Context, timeout time.Duration) (*entity.WebhookTask, error)
+	// Schedule кладет задачу, которая станет доступна не раньше at
+	Schedule(ctx context.Context, task entity.WebhookTask, at time.Time) error
+	// Depth считает задачи в очереди для мониторинга
+	Depth(ctx context.Context) (entity.QueueDepth, error)
+}
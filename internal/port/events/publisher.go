@@ -0,0 +1,11 @@
+package events
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, event *entity.Event) error
+}
@@ -0,0 +1,6 @@
+package geo
+
+// OperatingArea — область, в которой работает сервис
+type OperatingArea interface {
+	Contains(lat, lng float64) bool
+}
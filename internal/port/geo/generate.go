@@ -0,0 +1,3 @@
+package geo
+
+//go:generate mockgen -destination=mocks/geo.go -package=mocks github.com/4otis/geonotify-service/internal/port/geo OperatingArea,Geocoder,ReverseGeocoder,Provider
@@ -0,0 +1,17 @@
+package geo
+
+import "context"
+
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (lat, lng float64, err error)
+}
+
+type ReverseGeocoder interface {
+	Reverse(ctx context.Context, lat, lng float64) (place string, err error)
+}
+
+// Provider — провайдер, поддерживающий прямое и обратное геокодирование
+type Provider interface {
+	Geocoder
+	ReverseGeocoder
+}
@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/4otis/geonotify-service/internal/port/geo (interfaces: OperatingArea,Geocoder,ReverseGeocoder,Provider)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/geo.go -package=mocks github.com/4otis/geonotify-service/internal/port/geo OperatingArea,Geocoder,ReverseGeocoder,Provider
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOperatingArea is a mock of OperatingArea interface.
+type MockOperatingArea struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperatingAreaMockRecorder
+	isgomock struct{}
+}
+
+// MockOperatingAreaMockRecorder is the mock recorder for MockOperatingArea.
+type MockOperatingAreaMockRecorder struct {
+	mock *MockOperatingArea
+}
+
+// NewMockOperatingArea creates a new mock instance.
+func NewMockOperatingArea(ctrl *gomock.Controller) *MockOperatingArea {
+	mock := &MockOperatingArea{ctrl: ctrl}
+	mock.recorder = &MockOperatingAreaMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperatingArea) EXPECT() *MockOperatingAreaMockRecorder {
+	return m.recorder
+}
+
+// Contains mocks base method.
+func (m *MockOperatingArea) Contains(lat, lng float64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contains", lat, lng)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Contains indicates an expected call of Contains.
+func (mr *MockOperatingAreaMockRecorder) Contains(lat, lng any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contains", reflect.TypeOf((*MockOperatingArea)(nil).Contains), lat, lng)
+}
+
+// MockGeocoder is a mock of Geocoder interface.
+type MockGeocoder struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeocoderMockRecorder
+	isgomock struct{}
+}
+
+// MockGeocoderMockRecorder is the mock recorder for MockGeocoder.
+type MockGeocoderMockRecorder struct {
+	mock *MockGeocoder
+}
+
+// NewMockGeocoder creates a new mock instance.
+func NewMockGeocoder(ctrl *gomock.Controller) *MockGeocoder {
+	mock := &MockGeocoder{ctrl: ctrl}
+	mock.recorder = &MockGeocoderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeocoder) EXPECT() *MockGeocoderMockRecorder {
+	return m.recorder
+}
+
+// Geocode mocks base method.
+func (m *MockGeocoder) Geocode(ctx context.Context, address string) (float64, float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Geocode", ctx, address)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Geocode indicates an expected call of Geocode.
+func (mr *MockGeocoderMockRecorder) Geocode(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Geocode", reflect.TypeOf((*MockGeocoder)(nil).Geocode), ctx, address)
+}
+
+// MockReverseGeocoder is a mock of ReverseGeocoder interface.
+type MockReverseGeocoder struct {
+	ctrl     *gomock.Controller
+	recorder *MockReverseGeocoderMockRecorder
+	isgomock struct{}
+}
+
+// MockReverseGeocoderMockRecorder is the mock recorder for MockReverseGeocoder.
+type MockReverseGeocoderMockRecorder struct {
+	mock *MockReverseGeocoder
+}
+
+// NewMockReverseGeocoder creates a new mock instance.
+func NewMockReverseGeocoder(ctrl *gomock.Controller) *MockReverseGeocoder {
+	mock := &MockReverseGeocoder{ctrl: ctrl}
+	mock.recorder = &MockReverseGeocoderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReverseGeocoder) EXPECT() *MockReverseGeocoderMockRecorder {
+	return m.recorder
+}
+
+// Reverse mocks base method.
+func (m *MockReverseGeocoder) Reverse(ctx context.Context, lat, lng float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", ctx, lat, lng)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockReverseGeocoderMockRecorder) Reverse(ctx, lat, lng any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockReverseGeocoder)(nil).Reverse), ctx, lat, lng)
+}
+
+// MockProvider is a mock of Provider interface.
+type MockProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockProviderMockRecorder is the mock recorder for MockProvider.
+type MockProviderMockRecorder struct {
+	mock *MockProvider
+}
+
+// NewMockProvider creates a new mock instance.
+func NewMockProvider(ctrl *gomock.Controller) *MockProvider {
+	mock := &MockProvider{ctrl: ctrl}
+	mock.recorder = &MockProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProvider) EXPECT() *MockProviderMockRecorder {
+	return m.recorder
+}
+
+// Geocode mocks base method.
+func (m *MockProvider) Geocode(ctx context.Context, address string) (float64, float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Geocode", ctx, address)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Geocode indicates an expected call of Geocode.
+func (mr *MockProviderMockRecorder) Geocode(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Geocode", reflect.TypeOf((*MockProvider)(nil).Geocode), ctx, address)
+}
+
+// Reverse mocks base method.
+func (m *MockProvider) Reverse(ctx context.Context, lat, lng float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", ctx, lat, lng)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockProviderMockRecorder) Reverse(ctx, lat, lng any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockProvider)(nil).Reverse), ctx, lat, lng)
+}
@@ -0,0 +1,9 @@
+package ops
+
+import "context"
+
+// Notifier отправляет сообщение дежурным в канал эксплуатации. Реализации идут к каналу напрямую,
+// минуя очередь и outbox вебхуков, чтобы сервис мог сообщить и о сбоях собственной доставки
+type Notifier interface {
+	Notify(ctx context.Context, text string) error
+}
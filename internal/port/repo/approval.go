@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type ApprovalRepo interface {
+	// Create возвращает entity.ErrApprovalPending, если у зоны уже есть заявка, ожидающая решения
+	Create(ctx context.Context, approval entity.Approval) (approvalID int, err error)
+	// ReadForUpdate должен вызываться внутри транзакции: заявка остается заблокированной до ее завершения
+	ReadForUpdate(ctx context.Context, approvalID int) (*entity.Approval, error)
+	// HasPending сообщает, ждет ли зона решения по заявке: такую зону не правят, пока заявку не решат или не отзовут
+	HasPending(ctx context.Context, incID int) (bool, error)
+	ReadByStatus(ctx context.Context, status string, limit int) ([]*entity.Approval, error)
+	Decide(ctx context.Context, approvalID int, status, decidedBy, reason string) error
+}
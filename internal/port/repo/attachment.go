@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type AttachmentRepo interface {
+	Create(ctx context.Context, attachment entity.Attachment) (attachmentID int, err error)
+	Read(ctx context.Context, incidentID, attachmentID int) (*entity.Attachment, error)
+	ReadByIncident(ctx context.Context, incidentID int) ([]*entity.Attachment, error)
+	Delete(ctx context.Context, incidentID, attachmentID int) error
+}
@@ -9,5 +9,31 @@ import (
 
 type CheckRepo interface {
 	Create(ctx context.Context, check entity.Check) (checkID int, err error)
-	GetStats(ctx context.Context, minutes int) (userCnt, totalChecks int, periodStart time.Time, err error)
+	// ReserveIDs выделяет n идентификаторов проверок, не записывая сами проверки
+	ReserveIDs(ctx context.Context, n int) ([]int, error)
+	// CreateBatch записывает проверки одной командой COPY и возвращает их ID в порядке checks.
+	// Проверкам без ID идентификаторы выделяются заранее, без CreatedAt — ставится текущее время
+	CreateBatch(ctx context.Context, checks []entity.Check) (checkIDs []int, err error)
+	// CopyBatch — CreateBatch без возврата ID: их назначает БД, лишний запрос за ID не делается
+	CopyBatch(ctx context.Context, checks []entity.Check) error
+	// GetStats с estimate возвращает оценки планировщика вместо точных чисел
+	GetStats(ctx context.Context, minutes int, estimate bool) (userCnt, totalChecks int, periodStart time.Time, err error)
+	CreateDailyPartition(ctx context.Context, day time.Time) (created bool, err error)
+	DropPartitionsBefore(ctx context.Context, before time.Time) (dropped []string, err error)
+	ReadRecent(ctx context.Context, limit int) ([]*entity.Check, error)
+	// ReadInArea возвращает не больше limit проверок с момента from внутри прямоугольника координат
+	ReadInArea(ctx context.Context, from time.Time, minLat, maxLat, minLng, maxLng float64, limit int) ([]*entity.Check, error)
+	// ReadSince возвращает не больше limit проверок после курсора (stored_at, id), записанных не позже
+	// settleSeconds секунд назад, в порядке записи
+	ReadSince(ctx context.Context, after entity.SyncCursor, settleSeconds, limit int) ([]*entity.Check, error)
+	// ReadByUser возвращает все проверки пользователя от старых к новым
+	ReadByUser(ctx context.Context, userID string) ([]*entity.Check, error)
+	DeleteByUser(ctx context.Context, userID string) (deleted int64, err error)
+	// AnonymizeByUser переписывает проверки пользователя на anonymizedID и округляет координаты
+	// до precision знаков после запятой
+	AnonymizeByUser(ctx context.Context, userID, anonymizedID string, precision int) (anonymized int64, err error)
+	// Reencrypt шифрует активным ключом не больше limit проверок — открытых и зашифрованных прежними ключами —
+	// и возвращает их число. Присутствие их пользователей в поминутной статистике переводится на индекс
+	// активного ключа. Без ключей шифрования ничего не делает
+	Reencrypt(ctx context.Context, limit int) (reencrypted int, err error)
 }
@@ -0,0 +1,15 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type CommentRepo interface {
+	Create(ctx context.Context, comment entity.IncidentComment) (*entity.IncidentComment, error)
+	// ReadByIncident возвращает limit последних комментариев зоны, новые первыми
+	ReadByIncident(ctx context.Context, incID, limit int) ([]entity.IncidentComment, error)
+	// ReadLatest возвращает последний комментарий каждой из зон; зоны без комментариев пропускаются
+	ReadLatest(ctx context.Context, incIDs []int) ([]entity.IncidentComment, error)
+}
@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type DataExportRepo interface {
+	// Create возвращает entity.ErrDataExportPending, если выгрузка пользователя уже в работе
+	Create(ctx context.Context, export entity.DataExport) (exportID int, err error)
+	Read(ctx context.Context, exportID int) (*entity.DataExport, error)
+	// ReadLatest возвращает последнюю выгрузку пользователя или entity.ErrDataExportNotFound
+	ReadLatest(ctx context.Context, userID string) (*entity.DataExport, error)
+	// LockPending должен вызываться внутри транзакции: возвращает выгрузки в работе, начиная со старых,
+	// и пропускает заблокированные другой репликой
+	LockPending(ctx context.Context, limit int) ([]*entity.DataExport, error)
+	// Complete помечает выгрузку готовой; архив хранится retentionHours часов
+	Complete(ctx context.Context, exportID int, objectKey string, sizeBytes int64, retentionHours int) error
+	Fail(ctx context.Context, exportID int, reason string) error
+	// ExpireDue помечает истекшими не больше limit готовых выгрузок с прошедшим expires_at и возвращает их
+	ExpireDue(ctx context.Context, limit int) ([]*entity.DataExport, error)
+	// ExpireByUser помечает истекшими все выгрузки пользователя и возвращает их; архив есть только у готовых
+	ExpireByUser(ctx context.Context, userID string) ([]*entity.DataExport, error)
+}
@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type ErasureRepo interface {
+	// Create возвращает entity.ErrErasurePending, если у пользователя уже есть незавершенная заявка
+	Create(ctx context.Context, erasure entity.Erasure) (erasureID int, err error)
+	Read(ctx context.Context, erasureID int) (*entity.Erasure, error)
+	// ReadByStatus возвращает заявки от новых к старым; пустой status — заявки во всех статусах
+	ReadByStatus(ctx context.Context, status string, limit int) ([]*entity.Erasure, error)
+	// Decide подтверждает или отменяет заявку, ожидающую подтверждения; иначе возвращает entity.ErrErasureClosed
+	Decide(ctx context.Context, erasureID int, status, decidedBy string) error
+	// LockConfirmed должен вызываться внутри транзакции: возвращает подтвержденные заявки, начиная
+	// со старых, и пропускает заблокированные другой репликой
+	LockConfirmed(ctx context.Context, limit int) ([]*entity.Erasure, error)
+	Complete(ctx context.Context, erasureID int, result entity.ErasureResult) error
+	Fail(ctx context.Context, erasureID int, reason string) error
+}
@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type EventRepo interface {
+	Create(ctx context.Context, event entity.Event) (eventID int64, err error)
+	// LockPending должен вызываться внутри транзакции: строки остаются заблокированными до ее завершения
+	LockPending(ctx context.Context, limit int) ([]*entity.Event, error)
+	Delete(ctx context.Context, eventIDs []int64) error
+}
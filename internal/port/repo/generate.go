@@ -0,0 +1,3 @@
+package repo
+
+//go:generate mockgen -destination=mocks/repo.go -package=mocks github.com/4otis/geonotify-service/internal/port/repo ApprovalRepo,AttachmentRepo,CheckRepo,CommentRepo,DataExportRepo,ErasureRepo,EventRepo,GeometryVersionRepo,GroupRepo,HeatmapRepo,ImportRepo,IncidentRepo,LineageRepo,OperatorRepo,PreferenceRepo,PresenceRepo,RelationRepo,SchemaRepo,StatsRepo,SyncCursorRepo,TranslationRepo,Transactor,UsageRepo,WebhookRepo,WebhookEndpointRepo
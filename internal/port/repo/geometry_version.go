@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// GeometryVersionRepo читает историю форм зон; версии пишет IncidentRepo в том же запросе, что меняет форму
+type GeometryVersionRepo interface {
+	// ReadByIncident возвращает версии формы зоны от первой к последней
+	ReadByIncident(ctx context.Context, incID int) ([]entity.GeometryVersion, error)
+	// ReadAt возвращает для каждой из зон версию, действовавшую в момент at; зоны, созданные позже, пропускаются
+	ReadAt(ctx context.Context, incIDs []int, at time.Time) ([]entity.GeometryVersion, error)
+}
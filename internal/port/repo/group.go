@@ -0,0 +1,28 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// GroupRepo — группы пользователей; группа читается вместе с числом участников и получателем вебхуков
+type GroupRepo interface {
+	Create(ctx context.Context, group entity.Group) (groupID int, err error)
+	Read(ctx context.Context, groupID int) (*entity.Group, error)
+	ReadAll(ctx context.Context) ([]*entity.Group, error)
+	// ReadByMember возвращает группы, в которых состоит пользователь
+	ReadByMember(ctx context.Context, userID string) ([]*entity.Group, error)
+	Update(ctx context.Context, group entity.Group) error
+	// Delete удаляет группу вместе с участниками и получателем вебхуков
+	Delete(ctx context.Context, groupID int) error
+
+	ReadMembers(ctx context.Context, groupID int) (userIDs []string, err error)
+	// AddMembers пропускает пользователей, которые уже состоят в группе, и возвращает число добавленных
+	AddMembers(ctx context.Context, groupID int, userIDs []string) (added int, err error)
+	RemoveMember(ctx context.Context, groupID int, userID string) error
+	// RemoveUser исключает пользователя из всех групп
+	RemoveUser(ctx context.Context, userID string) (removed int64, err error)
+	// ReadInDanger возвращает участников, которые по последней проверке находятся в действующих зонах
+	ReadInDanger(ctx context.Context, groupID int) ([]entity.GroupMember, error)
+}
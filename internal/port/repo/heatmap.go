@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type HeatmapRepo interface {
+	// Add прибавляет счетчики к сохраненным за те же ячейку и час; ячейки записаны с разрешением resolution
+	Add(ctx context.Context, resolution int, buckets []entity.HeatmapBucket) error
+	// Read суммирует счетчики за часы с from до to по ячейкам разрешения resolution, начиная с самых частых.
+	// Ячейки, записанные с более грубым разрешением, не учитываются
+	Read(ctx context.Context, resolution int, from, to time.Time) ([]entity.HeatmapCell, error)
+	// DeleteBefore удаляет счетчики за часы раньше before
+	DeleteBefore(ctx context.Context, before time.Time) (deleted int64, err error)
+}
@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type ImportRepo interface {
+	// Lock вызывается внутри транзакции: до ее конца импорт ленты feed другими репликами ждет
+	Lock(ctx context.Context, feed string) error
+	// Read возвращает обработанные оповещения ленты feed с ID из externalIDs
+	Read(ctx context.Context, feed string, externalIDs []string) ([]entity.IncidentImport, error)
+	// Save запоминает обработанное оповещение или обновляет его время выпуска
+	Save(ctx context.Context, imp entity.IncidentImport) error
+	// ReadOpen возвращает оповещения ленты, по которым есть неудаленные зоны в незавершенных стадиях
+	ReadOpen(ctx context.Context, feed string) ([]entity.IncidentImport, error)
+}
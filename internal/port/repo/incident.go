@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/4otis/geonotify-service/internal/entity"
 )
@@ -9,8 +10,28 @@ import (
 type IncidentRepo interface {
 	Create(ctx context.Context, incident entity.Incident) (incidentID int, err error)
 	Read(ctx context.Context, incID int) (i *entity.Incident, err error)
-	ReadWithPagination(ctx context.Context, page, limit int) ([]*entity.Incident, int, error)
+	// ReadWithPagination с estimate возвращает оценку общего числа инцидентов вместо точного.
+	// visibilities ограничивает видимость зон списка, nil — зоны с любой видимостью
+	ReadWithPagination(ctx context.Context, page, limit int, status string, visibilities []string, estimate bool) ([]*entity.Incident, int, error)
+	ReadAfter(ctx context.Context, after *entity.IncidentCursor, limit int, status string, visibilities []string) ([]*entity.Incident, error)
 	ReadAllActive(ctx context.Context) ([]*entity.Incident, error)
+	// ReadInEffectAt возвращает зоны, которые в момент at действовали по истории активности: были опубликованы,
+	// включены, не удалены и не истекли. Форма зон — текущая
+	ReadInEffectAt(ctx context.Context, at time.Time) ([]*entity.Incident, error)
+	// ReadChanged возвращает не больше limit зон, измененных после курсора (updated_at, id) и не позже
+	// settleSeconds секунд назад, от старых изменений к новым; удаленные зоны тоже возвращаются
+	ReadChanged(ctx context.Context, after entity.SyncCursor, settleSeconds, limit int) ([]entity.IncidentChange, error)
+	// Version меняется при любом создании, изменении или удалении инцидента
+	Version(ctx context.Context) (entity.IncidentsVersion, error)
 	Update(ctx context.Context, incident entity.Incident) error
+	UpdatePartial(ctx context.Context, incID int, patch entity.IncidentPatch) error
+	UpdateGeometry(ctx context.Context, incID int, geometry entity.IncidentGeometry) error
 	Delete(ctx context.Context, incID int) error
+	ReadScheduled(ctx context.Context) ([]*entity.Incident, error)
+	// SetActive включает и выключает зону по окну расписания, не меняя ее стадию
+	SetActive(ctx context.Context, incID int, isActive bool) error
+	ResolveExpired(ctx context.Context) ([]entity.StateChange, error)
+	DeleteBatch(ctx context.Context, incIDs []int) (deletedIDs []int, err error)
+	SetStatus(ctx context.Context, incID int, from []string, to, updatedBy string) error
+	SetState(ctx context.Context, incID int, from, to string, isActive bool, updatedBy string) error
 }
@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type LineageRepo interface {
+	Create(ctx context.Context, links []entity.IncidentLink) error
+	// ReadByIncident возвращает связи, где зона — исходная или полученная, от новых к старым
+	ReadByIncident(ctx context.Context, incID int) ([]entity.IncidentLink, error)
+}
@@ -0,0 +1,2654 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/4otis/geonotify-service/internal/port/repo (interfaces: ApprovalRepo,AttachmentRepo,CheckRepo,CommentRepo,DataExportRepo,ErasureRepo,EventRepo,GeometryVersionRepo,GroupRepo,HeatmapRepo,ImportRepo,IncidentRepo,LineageRepo,OperatorRepo,PreferenceRepo,PresenceRepo,RelationRepo,SchemaRepo,StatsRepo,SyncCursorRepo,TranslationRepo,Transactor,UsageRepo,WebhookRepo,WebhookEndpointRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/repo.go -package=mocks github.com/4otis/geonotify-service/internal/port/repo ApprovalRepo,AttachmentRepo,CheckRepo,CommentRepo,DataExportRepo,ErasureRepo,EventRepo,GeometryVersionRepo,GroupRepo,HeatmapRepo,ImportRepo,IncidentRepo,LineageRepo,OperatorRepo,PreferenceRepo,PresenceRepo,RelationRepo,SchemaRepo,StatsRepo,SyncCursorRepo,TranslationRepo,Transactor,UsageRepo,WebhookRepo,WebhookEndpointRepo
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/4otis/geonotify-service/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApprovalRepo is a mock of ApprovalRepo interface.
+type MockApprovalRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockApprovalRepoMockRecorder is the mock recorder for MockApprovalRepo.
+type MockApprovalRepoMockRecorder struct {
+	mock *MockApprovalRepo
+}
+
+// NewMockApprovalRepo creates a new mock instance.
+func NewMockApprovalRepo(ctrl *gomock.Controller) *MockApprovalRepo {
+	mock := &MockApprovalRepo{ctrl: ctrl}
+	mock.recorder = &MockApprovalRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalRepo) EXPECT() *MockApprovalRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockApprovalRepo) Create(ctx context.Context, approval entity.Approval) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, approval)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApprovalRepoMockRecorder) Create(ctx, approval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApprovalRepo)(nil).Create), ctx, approval)
+}
+
+// Decide mocks base method.
+func (m *MockApprovalRepo) Decide(ctx context.Context, approvalID int, status, decidedBy, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, approvalID, status, decidedBy, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockApprovalRepoMockRecorder) Decide(ctx, approvalID, status, decidedBy, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockApprovalRepo)(nil).Decide), ctx, approvalID, status, decidedBy, reason)
+}
+
+// HasPending mocks base method.
+func (m *MockApprovalRepo) HasPending(ctx context.Context, incID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPending", ctx, incID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPending indicates an expected call of HasPending.
+func (mr *MockApprovalRepoMockRecorder) HasPending(ctx, incID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPending", reflect.TypeOf((*MockApprovalRepo)(nil).HasPending), ctx, incID)
+}
+
+// ReadByStatus mocks base method.
+func (m *MockApprovalRepo) ReadByStatus(ctx context.Context, status string, limit int) ([]*entity.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByStatus", ctx, status, limit)
+	ret0, _ := ret[0].([]*entity.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByStatus indicates an expected call of ReadByStatus.
+func (mr *MockApprovalRepoMockRecorder) ReadByStatus(ctx, status, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByStatus", reflect.TypeOf((*MockApprovalRepo)(nil).ReadByStatus), ctx, status, limit)
+}
+
+// ReadForUpdate mocks base method.
+func (m *MockApprovalRepo) ReadForUpdate(ctx context.Context, approvalID int) (*entity.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadForUpdate", ctx, approvalID)
+	ret0, _ := ret[0].(*entity.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadForUpdate indicates an expected call of ReadForUpdate.
+func (mr *MockApprovalRepoMockRecorder) ReadForUpdate(ctx, approvalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadForUpdate", reflect.TypeOf((*MockApprovalRepo)(nil).ReadForUpdate), ctx, approvalID)
+}
+
+// MockAttachmentRepo is a mock of AttachmentRepo interface.
+type MockAttachmentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentRepoMockRecorder is the mock recorder for MockAttachmentRepo.
+type MockAttachmentRepoMockRecorder struct {
+	mock *MockAttachmentRepo
+}
+
+// NewMockAttachmentRepo creates a new mock instance.
+func NewMockAttachmentRepo(ctrl *gomock.Controller) *MockAttachmentRepo {
+	mock := &MockAttachmentRepo{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepo) EXPECT() *MockAttachmentRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachmentRepo) Create(ctx context.Context, attachment entity.Attachment) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, attachment)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentRepoMockRecorder) Create(ctx, attachment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachmentRepo)(nil).Create), ctx, attachment)
+}
+
+// Delete mocks base method.
+func (m *MockAttachmentRepo) Delete(ctx context.Context, incidentID, attachmentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, incidentID, attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentRepoMockRecorder) Delete(ctx, incidentID, attachmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachmentRepo)(nil).Delete), ctx, incidentID, attachmentID)
+}
+
+// Read mocks base method.
+func (m *MockAttachmentRepo) Read(ctx context.Context, incidentID, attachmentID int) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, incidentID, attachmentID)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockAttachmentRepoMockRecorder) Read(ctx, incidentID, attachmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockAttachmentRepo)(nil).Read), ctx, incidentID, attachmentID)
+}
+
+// ReadByIncident mocks base method.
+func (m *MockAttachmentRepo) ReadByIncident(ctx context.Context, incidentID int) ([]*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByIncident", ctx, incidentID)
+	ret0, _ := ret[0].([]*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByIncident indicates an expected call of ReadByIncident.
+func (mr *MockAttachmentRepoMockRecorder) ReadByIncident(ctx, incidentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByIncident", reflect.TypeOf((*MockAttachmentRepo)(nil).ReadByIncident), ctx, incidentID)
+}
+
+// MockCheckRepo is a mock of CheckRepo interface.
+type MockCheckRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockCheckRepoMockRecorder is the mock recorder for MockCheckRepo.
+type MockCheckRepoMockRecorder struct {
+	mock *MockCheckRepo
+}
+
+// NewMockCheckRepo creates a new mock instance.
+func NewMockCheckRepo(ctrl *gomock.Controller) *MockCheckRepo {
+	mock := &MockCheckRepo{ctrl: ctrl}
+	mock.recorder = &MockCheckRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCheckRepo) EXPECT() *MockCheckRepoMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeByUser mocks base method.
+func (m *MockCheckRepo) AnonymizeByUser(ctx context.Context, userID, anonymizedID string, precision int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeByUser", ctx, userID, anonymizedID, precision)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeByUser indicates an expected call of AnonymizeByUser.
+func (mr *MockCheckRepoMockRecorder) AnonymizeByUser(ctx, userID, anonymizedID, precision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeByUser", reflect.TypeOf((*MockCheckRepo)(nil).AnonymizeByUser), ctx, userID, anonymizedID, precision)
+}
+
+// CopyBatch mocks base method.
+func (m *MockCheckRepo) CopyBatch(ctx context.Context, checks []entity.Check) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyBatch", ctx, checks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyBatch indicates an expected call of CopyBatch.
+func (mr *MockCheckRepoMockRecorder) CopyBatch(ctx, checks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyBatch", reflect.TypeOf((*MockCheckRepo)(nil).CopyBatch), ctx, checks)
+}
+
+// Create mocks base method.
+func (m *MockCheckRepo) Create(ctx context.Context, check entity.Check) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, check)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCheckRepoMockRecorder) Create(ctx, check any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCheckRepo)(nil).Create), ctx, check)
+}
+
+// CreateBatch mocks base method.
+func (m *MockCheckRepo) CreateBatch(ctx context.Context, checks []entity.Check) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, checks)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockCheckRepoMockRecorder) CreateBatch(ctx, checks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockCheckRepo)(nil).CreateBatch), ctx, checks)
+}
+
+// CreateDailyPartition mocks base method.
+func (m *MockCheckRepo) CreateDailyPartition(ctx context.Context, day time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDailyPartition", ctx, day)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDailyPartition indicates an expected call of CreateDailyPartition.
+func (mr *MockCheckRepoMockRecorder) CreateDailyPartition(ctx, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDailyPartition", reflect.TypeOf((*MockCheckRepo)(nil).CreateDailyPartition), ctx, day)
+}
+
+// DeleteByUser mocks base method.
+func (m *MockCheckRepo) DeleteByUser(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUser", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUser indicates an expected call of DeleteByUser.
+func (mr *MockCheckRepoMockRecorder) DeleteByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUser", reflect.TypeOf((*MockCheckRepo)(nil).DeleteByUser), ctx, userID)
+}
+
+// DropPartitionsBefore mocks base method.
+func (m *MockCheckRepo) DropPartitionsBefore(ctx context.Context, before time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropPartitionsBefore", ctx, before)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DropPartitionsBefore indicates an expected call of DropPartitionsBefore.
+func (mr *MockCheckRepoMockRecorder) DropPartitionsBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropPartitionsBefore", reflect.TypeOf((*MockCheckRepo)(nil).DropPartitionsBefore), ctx, before)
+}
+
+// GetStats mocks base method.
+func (m *MockCheckRepo) GetStats(ctx context.Context, minutes int, estimate bool) (int, int, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, minutes, estimate)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(time.Time)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockCheckRepoMockRecorder) GetStats(ctx, minutes, estimate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockCheckRepo)(nil).GetStats), ctx, minutes, estimate)
+}
+
+// ReadByUser mocks base method.
+func (m *MockCheckRepo) ReadByUser(ctx context.Context, userID string) ([]*entity.Check, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByUser", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Check)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByUser indicates an expected call of ReadByUser.
+func (mr *MockCheckRepoMockRecorder) ReadByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByUser", reflect.TypeOf((*MockCheckRepo)(nil).ReadByUser), ctx, userID)
+}
+
+// ReadInArea mocks base method.
+func (m *MockCheckRepo) ReadInArea(ctx context.Context, from time.Time, minLat, maxLat, minLng, maxLng float64, limit int) ([]*entity.Check, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadInArea", ctx, from, minLat, maxLat, minLng, maxLng, limit)
+	ret0, _ := ret[0].([]*entity.Check)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadInArea indicates an expected call of ReadInArea.
+func (mr *MockCheckRepoMockRecorder) ReadInArea(ctx, from, minLat, maxLat, minLng, maxLng, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadInArea", reflect.TypeOf((*MockCheckRepo)(nil).ReadInArea), ctx, from, minLat, maxLat, minLng, maxLng, limit)
+}
+
+// ReadRecent mocks base method.
+func (m *MockCheckRepo) ReadRecent(ctx context.Context, limit int) ([]*entity.Check, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadRecent", ctx, limit)
+	ret0, _ := ret[0].([]*entity.Check)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadRecent indicates an expected call of ReadRecent.
+func (mr *MockCheckRepoMockRecorder) ReadRecent(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRecent", reflect.TypeOf((*MockCheckRepo)(nil).ReadRecent), ctx, limit)
+}
+
+// ReadSince mocks base method.
+func (m *MockCheckRepo) ReadSince(ctx context.Context, after entity.SyncCursor, settleSeconds, limit int) ([]*entity.Check, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSince", ctx, after, settleSeconds, limit)
+	ret0, _ := ret[0].([]*entity.Check)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSince indicates an expected call of ReadSince.
+func (mr *MockCheckRepoMockRecorder) ReadSince(ctx, after, settleSeconds, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSince", reflect.TypeOf((*MockCheckRepo)(nil).ReadSince), ctx, after, settleSeconds, limit)
+}
+
+// Reencrypt mocks base method.
+func (m *MockCheckRepo) Reencrypt(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reencrypt", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reencrypt indicates an expected call of Reencrypt.
+func (mr *MockCheckRepoMockRecorder) Reencrypt(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reencrypt", reflect.TypeOf((*MockCheckRepo)(nil).Reencrypt), ctx, limit)
+}
+
+// ReserveIDs mocks base method.
+func (m *MockCheckRepo) ReserveIDs(ctx context.Context, n int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveIDs", ctx, n)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveIDs indicates an expected call of ReserveIDs.
+func (mr *MockCheckRepoMockRecorder) ReserveIDs(ctx, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveIDs", reflect.TypeOf((*MockCheckRepo)(nil).ReserveIDs), ctx, n)
+}
+
+// MockCommentRepo is a mock of CommentRepo interface.
+type MockCommentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockCommentRepoMockRecorder is the mock recorder for MockCommentRepo.
+type MockCommentRepoMockRecorder struct {
+	mock *MockCommentRepo
+}
+
+// NewMockCommentRepo creates a new mock instance.
+func NewMockCommentRepo(ctrl *gomock.Controller) *MockCommentRepo {
+	mock := &MockCommentRepo{ctrl: ctrl}
+	mock.recorder = &MockCommentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentRepo) EXPECT() *MockCommentRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentRepo) Create(ctx context.Context, comment entity.IncidentComment) (*entity.IncidentComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment)
+	ret0, _ := ret[0].(*entity.IncidentComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentRepoMockRecorder) Create(ctx, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentRepo)(nil).Create), ctx, comment)
+}
+
+// ReadByIncident mocks base method.
+func (m *MockCommentRepo) ReadByIncident(ctx context.Context, incID, limit int) ([]entity.IncidentComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByIncident", ctx, incID, limit)
+	ret0, _ := ret[0].([]entity.IncidentComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByIncident indicates an expected call of ReadByIncident.
+func (mr *MockCommentRepoMockRecorder) ReadByIncident(ctx, incID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByIncident", reflect.TypeOf((*MockCommentRepo)(nil).ReadByIncident), ctx, incID, limit)
+}
+
+// ReadLatest mocks base method.
+func (m *MockCommentRepo) ReadLatest(ctx context.Context, incIDs []int) ([]entity.IncidentComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadLatest", ctx, incIDs)
+	ret0, _ := ret[0].([]entity.IncidentComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadLatest indicates an expected call of ReadLatest.
+func (mr *MockCommentRepoMockRecorder) ReadLatest(ctx, incIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLatest", reflect.TypeOf((*MockCommentRepo)(nil).ReadLatest), ctx, incIDs)
+}
+
+// MockDataExportRepo is a mock of DataExportRepo interface.
+type MockDataExportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataExportRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockDataExportRepoMockRecorder is the mock recorder for MockDataExportRepo.
+type MockDataExportRepoMockRecorder struct {
+	mock *MockDataExportRepo
+}
+
+// NewMockDataExportRepo creates a new mock instance.
+func NewMockDataExportRepo(ctrl *gomock.Controller) *MockDataExportRepo {
+	mock := &MockDataExportRepo{ctrl: ctrl}
+	mock.recorder = &MockDataExportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataExportRepo) EXPECT() *MockDataExportRepoMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockDataExportRepo) Complete(ctx context.Context, exportID int, objectKey string, sizeBytes int64, retentionHours int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, exportID, objectKey, sizeBytes, retentionHours)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockDataExportRepoMockRecorder) Complete(ctx, exportID, objectKey, sizeBytes, retentionHours any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockDataExportRepo)(nil).Complete), ctx, exportID, objectKey, sizeBytes, retentionHours)
+}
+
+// Create mocks base method.
+func (m *MockDataExportRepo) Create(ctx context.Context, export entity.DataExport) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, export)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDataExportRepoMockRecorder) Create(ctx, export any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDataExportRepo)(nil).Create), ctx, export)
+}
+
+// ExpireByUser mocks base method.
+func (m *MockDataExportRepo) ExpireByUser(ctx context.Context, userID string) ([]*entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireByUser", ctx, userID)
+	ret0, _ := ret[0].([]*entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireByUser indicates an expected call of ExpireByUser.
+func (mr *MockDataExportRepoMockRecorder) ExpireByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireByUser", reflect.TypeOf((*MockDataExportRepo)(nil).ExpireByUser), ctx, userID)
+}
+
+// ExpireDue mocks base method.
+func (m *MockDataExportRepo) ExpireDue(ctx context.Context, limit int) ([]*entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireDue", ctx, limit)
+	ret0, _ := ret[0].([]*entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireDue indicates an expected call of ExpireDue.
+func (mr *MockDataExportRepoMockRecorder) ExpireDue(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireDue", reflect.TypeOf((*MockDataExportRepo)(nil).ExpireDue), ctx, limit)
+}
+
+// Fail mocks base method.
+func (m *MockDataExportRepo) Fail(ctx context.Context, exportID int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, exportID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockDataExportRepoMockRecorder) Fail(ctx, exportID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockDataExportRepo)(nil).Fail), ctx, exportID, reason)
+}
+
+// LockPending mocks base method.
+func (m *MockDataExportRepo) LockPending(ctx context.Context, limit int) ([]*entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPending", ctx, limit)
+	ret0, _ := ret[0].([]*entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPending indicates an expected call of LockPending.
+func (mr *MockDataExportRepoMockRecorder) LockPending(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPending", reflect.TypeOf((*MockDataExportRepo)(nil).LockPending), ctx, limit)
+}
+
+// Read mocks base method.
+func (m *MockDataExportRepo) Read(ctx context.Context, exportID int) (*entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, exportID)
+	ret0, _ := ret[0].(*entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockDataExportRepoMockRecorder) Read(ctx, exportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockDataExportRepo)(nil).Read), ctx, exportID)
+}
+
+// ReadLatest mocks base method.
+func (m *MockDataExportRepo) ReadLatest(ctx context.Context, userID string) (*entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadLatest", ctx, userID)
+	ret0, _ := ret[0].(*entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadLatest indicates an expected call of ReadLatest.
+func (mr *MockDataExportRepoMockRecorder) ReadLatest(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLatest", reflect.TypeOf((*MockDataExportRepo)(nil).ReadLatest), ctx, userID)
+}
+
+// MockErasureRepo is a mock of ErasureRepo interface.
+type MockErasureRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockErasureRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockErasureRepoMockRecorder is the mock recorder for MockErasureRepo.
+type MockErasureRepoMockRecorder struct {
+	mock *MockErasureRepo
+}
+
+// NewMockErasureRepo creates a new mock instance.
+func NewMockErasureRepo(ctrl *gomock.Controller) *MockErasureRepo {
+	mock := &MockErasureRepo{ctrl: ctrl}
+	mock.recorder = &MockErasureRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockErasureRepo) EXPECT() *MockErasureRepoMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockErasureRepo) Complete(ctx context.Context, erasureID int, result entity.ErasureResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, erasureID, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockErasureRepoMockRecorder) Complete(ctx, erasureID, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockErasureRepo)(nil).Complete), ctx, erasureID, result)
+}
+
+// Create mocks base method.
+func (m *MockErasureRepo) Create(ctx context.Context, erasure entity.Erasure) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, erasure)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockErasureRepoMockRecorder) Create(ctx, erasure any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockErasureRepo)(nil).Create), ctx, erasure)
+}
+
+// Decide mocks base method.
+func (m *MockErasureRepo) Decide(ctx context.Context, erasureID int, status, decidedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, erasureID, status, decidedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockErasureRepoMockRecorder) Decide(ctx, erasureID, status, decidedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockErasureRepo)(nil).Decide), ctx, erasureID, status, decidedBy)
+}
+
+// Fail mocks base method.
+func (m *MockErasureRepo) Fail(ctx context.Context, erasureID int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, erasureID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockErasureRepoMockRecorder) Fail(ctx, erasureID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockErasureRepo)(nil).Fail), ctx, erasureID, reason)
+}
+
+// LockConfirmed mocks base method.
+func (m *MockErasureRepo) LockConfirmed(ctx context.Context, limit int) ([]*entity.Erasure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockConfirmed", ctx, limit)
+	ret0, _ := ret[0].([]*entity.Erasure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockConfirmed indicates an expected call of LockConfirmed.
+func (mr *MockErasureRepoMockRecorder) LockConfirmed(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockConfirmed", reflect.TypeOf((*MockErasureRepo)(nil).LockConfirmed), ctx, limit)
+}
+
+// Read mocks base method.
+func (m *MockErasureRepo) Read(ctx context.Context, erasureID int) (*entity.Erasure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, erasureID)
+	ret0, _ := ret[0].(*entity.Erasure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockErasureRepoMockRecorder) Read(ctx, erasureID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockErasureRepo)(nil).Read), ctx, erasureID)
+}
+
+// ReadByStatus mocks base method.
+func (m *MockErasureRepo) ReadByStatus(ctx context.Context, status string, limit int) ([]*entity.Erasure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByStatus", ctx, status, limit)
+	ret0, _ := ret[0].([]*entity.Erasure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByStatus indicates an expected call of ReadByStatus.
+func (mr *MockErasureRepoMockRecorder) ReadByStatus(ctx, status, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByStatus", reflect.TypeOf((*MockErasureRepo)(nil).ReadByStatus), ctx, status, limit)
+}
+
+// MockEventRepo is a mock of EventRepo interface.
+type MockEventRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockEventRepoMockRecorder is the mock recorder for MockEventRepo.
+type MockEventRepoMockRecorder struct {
+	mock *MockEventRepo
+}
+
+// NewMockEventRepo creates a new mock instance.
+func NewMockEventRepo(ctrl *gomock.Controller) *MockEventRepo {
+	mock := &MockEventRepo{ctrl: ctrl}
+	mock.recorder = &MockEventRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRepo) EXPECT() *MockEventRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEventRepo) Create(ctx context.Context, event entity.Event) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEventRepoMockRecorder) Create(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEventRepo)(nil).Create), ctx, event)
+}
+
+// Delete mocks base method.
+func (m *MockEventRepo) Delete(ctx context.Context, eventIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, eventIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockEventRepoMockRecorder) Delete(ctx, eventIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEventRepo)(nil).Delete), ctx, eventIDs)
+}
+
+// LockPending mocks base method.
+func (m *MockEventRepo) LockPending(ctx context.Context, limit int) ([]*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPending", ctx, limit)
+	ret0, _ := ret[0].([]*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPending indicates an expected call of LockPending.
+func (mr *MockEventRepoMockRecorder) LockPending(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPending", reflect.TypeOf((*MockEventRepo)(nil).LockPending), ctx, limit)
+}
+
+// MockGeometryVersionRepo is a mock of GeometryVersionRepo interface.
+type MockGeometryVersionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeometryVersionRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockGeometryVersionRepoMockRecorder is the mock recorder for MockGeometryVersionRepo.
+type MockGeometryVersionRepoMockRecorder struct {
+	mock *MockGeometryVersionRepo
+}
+
+// NewMockGeometryVersionRepo creates a new mock instance.
+func NewMockGeometryVersionRepo(ctrl *gomock.Controller) *MockGeometryVersionRepo {
+	mock := &MockGeometryVersionRepo{ctrl: ctrl}
+	mock.recorder = &MockGeometryVersionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeometryVersionRepo) EXPECT() *MockGeometryVersionRepoMockRecorder {
+	return m.recorder
+}
+
+// ReadAt mocks base method.
+func (m *MockGeometryVersionRepo) ReadAt(ctx context.Context, incIDs []int, at time.Time) ([]entity.GeometryVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAt", ctx, incIDs, at)
+	ret0, _ := ret[0].([]entity.GeometryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAt indicates an expected call of ReadAt.
+func (mr *MockGeometryVersionRepoMockRecorder) ReadAt(ctx, incIDs, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAt", reflect.TypeOf((*MockGeometryVersionRepo)(nil).ReadAt), ctx, incIDs, at)
+}
+
+// ReadByIncident mocks base method.
+func (m *MockGeometryVersionRepo) ReadByIncident(ctx context.Context, incID int) ([]entity.GeometryVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByIncident", ctx, incID)
+	ret0, _ := ret[0].([]entity.GeometryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByIncident indicates an expected call of ReadByIncident.
+func (mr *MockGeometryVersionRepoMockRecorder) ReadByIncident(ctx, incID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByIncident", reflect.TypeOf((*MockGeometryVersionRepo)(nil).ReadByIncident), ctx, incID)
+}
+
+// MockGroupRepo is a mock of GroupRepo interface.
+type MockGroupRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockGroupRepoMockRecorder is the mock recorder for MockGroupRepo.
+type MockGroupRepoMockRecorder struct {
+	mock *MockGroupRepo
+}
+
+// NewMockGroupRepo creates a new mock instance.
+func NewMockGroupRepo(ctrl *gomock.Controller) *MockGroupRepo {
+	mock := &MockGroupRepo{ctrl: ctrl}
+	mock.recorder = &MockGroupRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupRepo) EXPECT() *MockGroupRepoMockRecorder {
+	return m.recorder
+}
+
+// AddMembers mocks base method.
+func (m *MockGroupRepo) AddMembers(ctx context.Context, groupID int, userIDs []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMembers", ctx, groupID, userIDs)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMembers indicates an expected call of AddMembers.
+func (mr *MockGroupRepoMockRecorder) AddMembers(ctx, groupID, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMembers", reflect.TypeOf((*MockGroupRepo)(nil).AddMembers), ctx, groupID, userIDs)
+}
+
+// Create mocks base method.
+func (m *MockGroupRepo) Create(ctx context.Context, group entity.Group) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, group)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGroupRepoMockRecorder) Create(ctx, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGroupRepo)(nil).Create), ctx, group)
+}
+
+// Delete mocks base method.
+func (m *MockGroupRepo) Delete(ctx context.Context, groupID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGroupRepoMockRecorder) Delete(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGroupRepo)(nil).Delete), ctx, groupID)
+}
+
+// Read mocks base method.
+func (m *MockGroupRepo) Read(ctx context.Context, groupID int) (*entity.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, groupID)
+	ret0, _ := ret[0].(*entity.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockGroupRepoMockRecorder) Read(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockGroupRepo)(nil).Read), ctx, groupID)
+}
+
+// ReadAll mocks base method.
+func (m *MockGroupRepo) ReadAll(ctx context.Context) ([]*entity.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", ctx)
+	ret0, _ := ret[0].([]*entity.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockGroupRepoMockRecorder) ReadAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockGroupRepo)(nil).ReadAll), ctx)
+}
+
+// ReadByMember mocks base method.
+func (m *MockGroupRepo) ReadByMember(ctx context.Context, userID string) ([]*entity.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByMember", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByMember indicates an expected call of ReadByMember.
+func (mr *MockGroupRepoMockRecorder) ReadByMember(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByMember", reflect.TypeOf((*MockGroupRepo)(nil).ReadByMember), ctx, userID)
+}
+
+// ReadInDanger mocks base method.
+func (m *MockGroupRepo) ReadInDanger(ctx context.Context, groupID int) ([]entity.GroupMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadInDanger", ctx, groupID)
+	ret0, _ := ret[0].([]entity.GroupMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadInDanger indicates an expected call of ReadInDanger.
+func (mr *MockGroupRepoMockRecorder) ReadInDanger(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadInDanger", reflect.TypeOf((*MockGroupRepo)(nil).ReadInDanger), ctx, groupID)
+}
+
+// ReadMembers mocks base method.
+func (m *MockGroupRepo) ReadMembers(ctx context.Context, groupID int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMembers", ctx, groupID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMembers indicates an expected call of ReadMembers.
+func (mr *MockGroupRepoMockRecorder) ReadMembers(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMembers", reflect.TypeOf((*MockGroupRepo)(nil).ReadMembers), ctx, groupID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockGroupRepo) RemoveMember(ctx context.Context, groupID int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockGroupRepoMockRecorder) RemoveMember(ctx, groupID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockGroupRepo)(nil).RemoveMember), ctx, groupID, userID)
+}
+
+// RemoveUser mocks base method.
+func (m *MockGroupRepo) RemoveUser(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUser", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveUser indicates an expected call of RemoveUser.
+func (mr *MockGroupRepoMockRecorder) RemoveUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUser", reflect.TypeOf((*MockGroupRepo)(nil).RemoveUser), ctx, userID)
+}
+
+// Update mocks base method.
+func (m *MockGroupRepo) Update(ctx context.Context, group entity.Group) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGroupRepoMockRecorder) Update(ctx, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGroupRepo)(nil).Update), ctx, group)
+}
+
+// MockHeatmapRepo is a mock of HeatmapRepo interface.
+type MockHeatmapRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHeatmapRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockHeatmapRepoMockRecorder is the mock recorder for MockHeatmapRepo.
+type MockHeatmapRepoMockRecorder struct {
+	mock *MockHeatmapRepo
+}
+
+// NewMockHeatmapRepo creates a new mock instance.
+func NewMockHeatmapRepo(ctrl *gomock.Controller) *MockHeatmapRepo {
+	mock := &MockHeatmapRepo{ctrl: ctrl}
+	mock.recorder = &MockHeatmapRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHeatmapRepo) EXPECT() *MockHeatmapRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockHeatmapRepo) Add(ctx context.Context, resolution int, buckets []entity.HeatmapBucket) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, resolution, buckets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockHeatmapRepoMockRecorder) Add(ctx, resolution, buckets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockHeatmapRepo)(nil).Add), ctx, resolution, buckets)
+}
+
+// DeleteBefore mocks base method.
+func (m *MockHeatmapRepo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockHeatmapRepoMockRecorder) DeleteBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockHeatmapRepo)(nil).DeleteBefore), ctx, before)
+}
+
+// Read mocks base method.
+func (m *MockHeatmapRepo) Read(ctx context.Context, resolution int, from, to time.Time) ([]entity.HeatmapCell, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, resolution, from, to)
+	ret0, _ := ret[0].([]entity.HeatmapCell)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockHeatmapRepoMockRecorder) Read(ctx, resolution, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockHeatmapRepo)(nil).Read), ctx, resolution, from, to)
+}
+
+// MockImportRepo is a mock of ImportRepo interface.
+type MockImportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockImportRepoMockRecorder is the mock recorder for MockImportRepo.
+type MockImportRepoMockRecorder struct {
+	mock *MockImportRepo
+}
+
+// NewMockImportRepo creates a new mock instance.
+func NewMockImportRepo(ctrl *gomock.Controller) *MockImportRepo {
+	mock := &MockImportRepo{ctrl: ctrl}
+	mock.recorder = &MockImportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepo) EXPECT() *MockImportRepoMockRecorder {
+	return m.recorder
+}
+
+// Lock mocks base method.
+func (m *MockImportRepo) Lock(ctx context.Context, feed string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, feed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockImportRepoMockRecorder) Lock(ctx, feed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockImportRepo)(nil).Lock), ctx, feed)
+}
+
+// Read mocks base method.
+func (m *MockImportRepo) Read(ctx context.Context, feed string, externalIDs []string) ([]entity.IncidentImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, feed, externalIDs)
+	ret0, _ := ret[0].([]entity.IncidentImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockImportRepoMockRecorder) Read(ctx, feed, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockImportRepo)(nil).Read), ctx, feed, externalIDs)
+}
+
+// ReadOpen mocks base method.
+func (m *MockImportRepo) ReadOpen(ctx context.Context, feed string) ([]entity.IncidentImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadOpen", ctx, feed)
+	ret0, _ := ret[0].([]entity.IncidentImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadOpen indicates an expected call of ReadOpen.
+func (mr *MockImportRepoMockRecorder) ReadOpen(ctx, feed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOpen", reflect.TypeOf((*MockImportRepo)(nil).ReadOpen), ctx, feed)
+}
+
+// Save mocks base method.
+func (m *MockImportRepo) Save(ctx context.Context, imp entity.IncidentImport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, imp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockImportRepoMockRecorder) Save(ctx, imp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockImportRepo)(nil).Save), ctx, imp)
+}
+
+// MockIncidentRepo is a mock of IncidentRepo interface.
+type MockIncidentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncidentRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockIncidentRepoMockRecorder is the mock recorder for MockIncidentRepo.
+type MockIncidentRepoMockRecorder struct {
+	mock *MockIncidentRepo
+}
+
+// NewMockIncidentRepo creates a new mock instance.
+func NewMockIncidentRepo(ctrl *gomock.Controller) *MockIncidentRepo {
+	mock := &MockIncidentRepo{ctrl: ctrl}
+	mock.recorder = &MockIncidentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncidentRepo) EXPECT() *MockIncidentRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIncidentRepo) Create(ctx context.Context, incident entity.Incident) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, incident)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIncidentRepoMockRecorder) Create(ctx, incident any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIncidentRepo)(nil).Create), ctx, incident)
+}
+
+// Delete mocks base method.
+func (m *MockIncidentRepo) Delete(ctx context.Context, incID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, incID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIncidentRepoMockRecorder) Delete(ctx, incID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIncidentRepo)(nil).Delete), ctx, incID)
+}
+
+// DeleteBatch mocks base method.
+func (m *MockIncidentRepo) DeleteBatch(ctx context.Context, incIDs []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBatch", ctx, incIDs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBatch indicates an expected call of DeleteBatch.
+func (mr *MockIncidentRepoMockRecorder) DeleteBatch(ctx, incIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockIncidentRepo)(nil).DeleteBatch), ctx, incIDs)
+}
+
+// Read mocks base method.
+func (m *MockIncidentRepo) Read(ctx context.Context, incID int) (*entity.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, incID)
+	ret0, _ := ret[0].(*entity.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockIncidentRepoMockRecorder) Read(ctx, incID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockIncidentRepo)(nil).Read), ctx, incID)
+}
+
+// ReadAfter mocks base method.
+func (m *MockIncidentRepo) ReadAfter(ctx context.Context, after *entity.IncidentCursor, limit int, status string, visibilities []string) ([]*entity.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAfter", ctx, after, limit, status, visibilities)
+	ret0, _ := ret[0].([]*entity.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAfter indicates an expected call of ReadAfter.
+func (mr *MockIncidentRepoMockRecorder) ReadAfter(ctx, after, limit, status, visibilities any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAfter", reflect.TypeOf((*MockIncidentRepo)(nil).ReadAfter), ctx, after, limit, status, visibilities)
+}
+
+// ReadAllActive mocks base method.
+func (m *MockIncidentRepo) ReadAllActive(ctx context.Context) ([]*entity.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAllActive", ctx)
+	ret0, _ := ret[0].([]*entity.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAllActive indicates an expected call of ReadAllActive.
+func (mr *MockIncidentRepoMockRecorder) ReadAllActive(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAllActive", reflect.TypeOf((*MockIncidentRepo)(nil).ReadAllActive), ctx)
+}
+
+// ReadChanged mocks base method.
+func (m *MockIncidentRepo) ReadChanged(ctx context.Context, after entity.SyncCursor, settleSeconds, limit int) ([]entity.IncidentChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadChanged", ctx, after, settleSeconds, limit)
+	ret0, _ := ret[0].([]entity.IncidentChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadChanged indicates an expected call of ReadChanged.
+func (mr *MockIncidentRepoMockRecorder) ReadChanged(ctx, after, settleSeconds, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadChanged", reflect.TypeOf((*MockIncidentRepo)(nil).ReadChanged), ctx, after, settleSeconds, limit)
+}
+
+// ReadInEffectAt mocks base method.
+func (m *MockIncidentRepo) ReadInEffectAt(ctx context.Context, at time.Time) ([]*entity.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadInEffectAt", ctx, at)
+	ret0, _ := ret[0].([]*entity.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadInEffectAt indicates an expected call of ReadInEffectAt.
+func (mr *MockIncidentRepoMockRecorder) ReadInEffectAt(ctx, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadInEffectAt", reflect.TypeOf((*MockIncidentRepo)(nil).ReadInEffectAt), ctx, at)
+}
+
+// ReadScheduled mocks base method.
+func (m *MockIncidentRepo) ReadScheduled(ctx context.Context) ([]*entity.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadScheduled", ctx)
+	ret0, _ := ret[0].([]*entity.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadScheduled indicates an expected call of ReadScheduled.
+func (mr *MockIncidentRepoMockRecorder) ReadScheduled(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadScheduled", reflect.TypeOf((*MockIncidentRepo)(nil).ReadScheduled), ctx)
+}
+
+// ReadWithPagination mocks base method.
+func (m *MockIncidentRepo) ReadWithPagination(ctx context.Context, page, limit int, status string, visibilities []string, estimate bool) ([]*entity.Incident, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadWithPagination", ctx, page, limit, status, visibilities, estimate)
+	ret0, _ := ret[0].([]*entity.Incident)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadWithPagination indicates an expected call of ReadWithPagination.
+func (mr *MockIncidentRepoMockRecorder) ReadWithPagination(ctx, page, limit, status, visibilities, estimate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadWithPagination", reflect.TypeOf((*MockIncidentRepo)(nil).ReadWithPagination), ctx, page, limit, status, visibilities, estimate)
+}
+
+// ResolveExpired mocks base method.
+func (m *MockIncidentRepo) ResolveExpired(ctx context.Context) ([]entity.StateChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveExpired", ctx)
+	ret0, _ := ret[0].([]entity.StateChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveExpired indicates an expected call of ResolveExpired.
+func (mr *MockIncidentRepoMockRecorder) ResolveExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveExpired", reflect.TypeOf((*MockIncidentRepo)(nil).ResolveExpired), ctx)
+}
+
+// SetActive mocks base method.
+func (m *MockIncidentRepo) SetActive(ctx context.Context, incID int, isActive bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActive", ctx, incID, isActive)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetActive indicates an expected call of SetActive.
+func (mr *MockIncidentRepoMockRecorder) SetActive(ctx, incID, isActive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActive", reflect.TypeOf((*MockIncidentRepo)(nil).SetActive), ctx, incID, isActive)
+}
+
+// SetState mocks base method.
+func (m *MockIncidentRepo) SetState(ctx context.Context, incID int, from, to string, isActive bool, updatedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetState", ctx, incID, from, to, isActive, updatedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetState indicates an expected call of SetState.
+func (mr *MockIncidentRepoMockRecorder) SetState(ctx, incID, from, to, isActive, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetState", reflect.TypeOf((*MockIncidentRepo)(nil).SetState), ctx, incID, from, to, isActive, updatedBy)
+}
+
+// SetStatus mocks base method.
+func (m *MockIncidentRepo) SetStatus(ctx context.Context, incID int, from []string, to, updatedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, incID, from, to, updatedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockIncidentRepoMockRecorder) SetStatus(ctx, incID, from, to, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockIncidentRepo)(nil).SetStatus), ctx, incID, from, to, updatedBy)
+}
+
+// Update mocks base method.
+func (m *MockIncidentRepo) Update(ctx context.Context, incident entity.Incident) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, incident)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIncidentRepoMockRecorder) Update(ctx, incident any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIncidentRepo)(nil).Update), ctx, incident)
+}
+
+// UpdateGeometry mocks base method.
+func (m *MockIncidentRepo) UpdateGeometry(ctx context.Context, incID int, geometry entity.IncidentGeometry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGeometry", ctx, incID, geometry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGeometry indicates an expected call of UpdateGeometry.
+func (mr *MockIncidentRepoMockRecorder) UpdateGeometry(ctx, incID, geometry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGeometry", reflect.TypeOf((*MockIncidentRepo)(nil).UpdateGeometry), ctx, incID, geometry)
+}
+
+// UpdatePartial mocks base method.
+func (m *MockIncidentRepo) UpdatePartial(ctx context.Context, incID int, patch entity.IncidentPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePartial", ctx, incID, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePartial indicates an expected call of UpdatePartial.
+func (mr *MockIncidentRepoMockRecorder) UpdatePartial(ctx, incID, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePartial", reflect.TypeOf((*MockIncidentRepo)(nil).UpdatePartial), ctx, incID, patch)
+}
+
+// Version mocks base method.
+func (m *MockIncidentRepo) Version(ctx context.Context) (entity.IncidentsVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version", ctx)
+	ret0, _ := ret[0].(entity.IncidentsVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockIncidentRepoMockRecorder) Version(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockIncidentRepo)(nil).Version), ctx)
+}
+
+// MockLineageRepo is a mock of LineageRepo interface.
+type MockLineageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockLineageRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockLineageRepoMockRecorder is the mock recorder for MockLineageRepo.
+type MockLineageRepoMockRecorder struct {
+	mock *MockLineageRepo
+}
+
+// NewMockLineageRepo creates a new mock instance.
+func NewMockLineageRepo(ctrl *gomock.Controller) *MockLineageRepo {
+	mock := &MockLineageRepo{ctrl: ctrl}
+	mock.recorder = &MockLineageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLineageRepo) EXPECT() *MockLineageRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLineageRepo) Create(ctx context.Context, links []entity.IncidentLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, links)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLineageRepoMockRecorder) Create(ctx, links any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLineageRepo)(nil).Create), ctx, links)
+}
+
+// ReadByIncident mocks base method.
+func (m *MockLineageRepo) ReadByIncident(ctx context.Context, incID int) ([]entity.IncidentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByIncident", ctx, incID)
+	ret0, _ := ret[0].([]entity.IncidentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByIncident indicates an expected call of ReadByIncident.
+func (mr *MockLineageRepoMockRecorder) ReadByIncident(ctx, incID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByIncident", reflect.TypeOf((*MockLineageRepo)(nil).ReadByIncident), ctx, incID)
+}
+
+// MockOperatorRepo is a mock of OperatorRepo interface.
+type MockOperatorRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperatorRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockOperatorRepoMockRecorder is the mock recorder for MockOperatorRepo.
+type MockOperatorRepoMockRecorder struct {
+	mock *MockOperatorRepo
+}
+
+// NewMockOperatorRepo creates a new mock instance.
+func NewMockOperatorRepo(ctrl *gomock.Controller) *MockOperatorRepo {
+	mock := &MockOperatorRepo{ctrl: ctrl}
+	mock.recorder = &MockOperatorRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperatorRepo) EXPECT() *MockOperatorRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOperatorRepo) Create(ctx context.Context, operator entity.Operator) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, operator)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOperatorRepoMockRecorder) Create(ctx, operator any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOperatorRepo)(nil).Create), ctx, operator)
+}
+
+// ReadByOIDCSubject mocks base method.
+func (m *MockOperatorRepo) ReadByOIDCSubject(ctx context.Context, subject string) (*entity.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByOIDCSubject", ctx, subject)
+	ret0, _ := ret[0].(*entity.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByOIDCSubject indicates an expected call of ReadByOIDCSubject.
+func (mr *MockOperatorRepoMockRecorder) ReadByOIDCSubject(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByOIDCSubject", reflect.TypeOf((*MockOperatorRepo)(nil).ReadByOIDCSubject), ctx, subject)
+}
+
+// ReadByUsername mocks base method.
+func (m *MockOperatorRepo) ReadByUsername(ctx context.Context, username string) (*entity.Operator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByUsername", ctx, username)
+	ret0, _ := ret[0].(*entity.Operator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByUsername indicates an expected call of ReadByUsername.
+func (mr *MockOperatorRepoMockRecorder) ReadByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByUsername", reflect.TypeOf((*MockOperatorRepo)(nil).ReadByUsername), ctx, username)
+}
+
+// MockPreferenceRepo is a mock of PreferenceRepo interface.
+type MockPreferenceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferenceRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockPreferenceRepoMockRecorder is the mock recorder for MockPreferenceRepo.
+type MockPreferenceRepoMockRecorder struct {
+	mock *MockPreferenceRepo
+}
+
+// NewMockPreferenceRepo creates a new mock instance.
+func NewMockPreferenceRepo(ctrl *gomock.Controller) *MockPreferenceRepo {
+	mock := &MockPreferenceRepo{ctrl: ctrl}
+	mock.recorder = &MockPreferenceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferenceRepo) EXPECT() *MockPreferenceRepoMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPreferenceRepo) Delete(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPreferenceRepoMockRecorder) Delete(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPreferenceRepo)(nil).Delete), ctx, userID)
+}
+
+// Read mocks base method.
+func (m *MockPreferenceRepo) Read(ctx context.Context, userID string) (*entity.UserPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, userID)
+	ret0, _ := ret[0].(*entity.UserPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockPreferenceRepoMockRecorder) Read(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockPreferenceRepo)(nil).Read), ctx, userID)
+}
+
+// Upsert mocks base method.
+func (m *MockPreferenceRepo) Upsert(ctx context.Context, prefs entity.UserPreferences) (*entity.UserPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, prefs)
+	ret0, _ := ret[0].(*entity.UserPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockPreferenceRepoMockRecorder) Upsert(ctx, prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockPreferenceRepo)(nil).Upsert), ctx, prefs)
+}
+
+// MockPresenceRepo is a mock of PresenceRepo interface.
+type MockPresenceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockPresenceRepoMockRecorder is the mock recorder for MockPresenceRepo.
+type MockPresenceRepoMockRecorder struct {
+	mock *MockPresenceRepo
+}
+
+// NewMockPresenceRepo creates a new mock instance.
+func NewMockPresenceRepo(ctrl *gomock.Controller) *MockPresenceRepo {
+	mock := &MockPresenceRepo{ctrl: ctrl}
+	mock.recorder = &MockPresenceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenceRepo) EXPECT() *MockPresenceRepoMockRecorder {
+	return m.recorder
+}
+
+// Enter mocks base method.
+func (m *MockPresenceRepo) Enter(ctx context.Context, userID string, incidentIDs []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enter", ctx, userID, incidentIDs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enter indicates an expected call of Enter.
+func (mr *MockPresenceRepoMockRecorder) Enter(ctx, userID, incidentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enter", reflect.TypeOf((*MockPresenceRepo)(nil).Enter), ctx, userID, incidentIDs)
+}
+
+// Exit mocks base method.
+func (m *MockPresenceRepo) Exit(ctx context.Context, userID string, incidentIDs []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exit", ctx, userID, incidentIDs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exit indicates an expected call of Exit.
+func (mr *MockPresenceRepoMockRecorder) Exit(ctx, userID, incidentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exit", reflect.TypeOf((*MockPresenceRepo)(nil).Exit), ctx, userID, incidentIDs)
+}
+
+// Forget mocks base method.
+func (m *MockPresenceRepo) Forget(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forget", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Forget indicates an expected call of Forget.
+func (mr *MockPresenceRepoMockRecorder) Forget(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forget", reflect.TypeOf((*MockPresenceRepo)(nil).Forget), ctx, userID)
+}
+
+// ReadZones mocks base method.
+func (m *MockPresenceRepo) ReadZones(ctx context.Context, userID string) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadZones", ctx, userID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadZones indicates an expected call of ReadZones.
+func (mr *MockPresenceRepoMockRecorder) ReadZones(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadZones", reflect.TypeOf((*MockPresenceRepo)(nil).ReadZones), ctx, userID)
+}
+
+// MockRelationRepo is a mock of RelationRepo interface.
+type MockRelationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelationRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockRelationRepoMockRecorder is the mock recorder for MockRelationRepo.
+type MockRelationRepoMockRecorder struct {
+	mock *MockRelationRepo
+}
+
+// NewMockRelationRepo creates a new mock instance.
+func NewMockRelationRepo(ctrl *gomock.Controller) *MockRelationRepo {
+	mock := &MockRelationRepo{ctrl: ctrl}
+	mock.recorder = &MockRelationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelationRepo) EXPECT() *MockRelationRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRelationRepo) Create(ctx context.Context, relation entity.IncidentRelation) (*entity.IncidentRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, relation)
+	ret0, _ := ret[0].(*entity.IncidentRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRelationRepoMockRecorder) Create(ctx, relation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRelationRepo)(nil).Create), ctx, relation)
+}
+
+// Delete mocks base method.
+func (m *MockRelationRepo) Delete(ctx context.Context, relation entity.IncidentRelation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, relation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRelationRepoMockRecorder) Delete(ctx, relation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRelationRepo)(nil).Delete), ctx, relation)
+}
+
+// ReadByIncidents mocks base method.
+func (m *MockRelationRepo) ReadByIncidents(ctx context.Context, incIDs []int) ([]entity.IncidentRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByIncidents", ctx, incIDs)
+	ret0, _ := ret[0].([]entity.IncidentRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByIncidents indicates an expected call of ReadByIncidents.
+func (mr *MockRelationRepoMockRecorder) ReadByIncidents(ctx, incIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByIncidents", reflect.TypeOf((*MockRelationRepo)(nil).ReadByIncidents), ctx, incIDs)
+}
+
+// MockSchemaRepo is a mock of SchemaRepo interface.
+type MockSchemaRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemaRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSchemaRepoMockRecorder is the mock recorder for MockSchemaRepo.
+type MockSchemaRepoMockRecorder struct {
+	mock *MockSchemaRepo
+}
+
+// NewMockSchemaRepo creates a new mock instance.
+func NewMockSchemaRepo(ctrl *gomock.Controller) *MockSchemaRepo {
+	mock := &MockSchemaRepo{ctrl: ctrl}
+	mock.recorder = &MockSchemaRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemaRepo) EXPECT() *MockSchemaRepoMockRecorder {
+	return m.recorder
+}
+
+// AppliedVersion mocks base method.
+func (m *MockSchemaRepo) AppliedVersion(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppliedVersion", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppliedVersion indicates an expected call of AppliedVersion.
+func (mr *MockSchemaRepoMockRecorder) AppliedVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppliedVersion", reflect.TypeOf((*MockSchemaRepo)(nil).AppliedVersion), ctx)
+}
+
+// MockStatsRepo is a mock of StatsRepo interface.
+type MockStatsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockStatsRepoMockRecorder is the mock recorder for MockStatsRepo.
+type MockStatsRepoMockRecorder struct {
+	mock *MockStatsRepo
+}
+
+// NewMockStatsRepo creates a new mock instance.
+func NewMockStatsRepo(ctrl *gomock.Controller) *MockStatsRepo {
+	mock := &MockStatsRepo{ctrl: ctrl}
+	mock.recorder = &MockStatsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsRepo) EXPECT() *MockStatsRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteBefore mocks base method.
+func (m *MockStatsRepo) DeleteBefore(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockStatsRepoMockRecorder) DeleteBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockStatsRepo)(nil).DeleteBefore), ctx, before)
+}
+
+// FirstCheckAt mocks base method.
+func (m *MockStatsRepo) FirstCheckAt(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FirstCheckAt", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FirstCheckAt indicates an expected call of FirstCheckAt.
+func (mr *MockStatsRepoMockRecorder) FirstCheckAt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FirstCheckAt", reflect.TypeOf((*MockStatsRepo)(nil).FirstCheckAt), ctx)
+}
+
+// Read mocks base method.
+func (m *MockStatsRepo) Read(ctx context.Context, from, to time.Time) (entity.CheckStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, from, to)
+	ret0, _ := ret[0].(entity.CheckStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockStatsRepoMockRecorder) Read(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockStatsRepo)(nil).Read), ctx, from, to)
+}
+
+// ReadByIncident mocks base method.
+func (m *MockStatsRepo) ReadByIncident(ctx context.Context, from, to time.Time, visibilities []string) ([]entity.IncidentCheckStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByIncident", ctx, from, to, visibilities)
+	ret0, _ := ret[0].([]entity.IncidentCheckStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByIncident indicates an expected call of ReadByIncident.
+func (mr *MockStatsRepoMockRecorder) ReadByIncident(ctx, from, to, visibilities any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByIncident", reflect.TypeOf((*MockStatsRepo)(nil).ReadByIncident), ctx, from, to, visibilities)
+}
+
+// Rollup mocks base method.
+func (m *MockStatsRepo) Rollup(ctx context.Context, from, to time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollup", ctx, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollup indicates an expected call of Rollup.
+func (mr *MockStatsRepoMockRecorder) Rollup(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollup", reflect.TypeOf((*MockStatsRepo)(nil).Rollup), ctx, from, to)
+}
+
+// MockSyncCursorRepo is a mock of SyncCursorRepo interface.
+type MockSyncCursorRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncCursorRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSyncCursorRepoMockRecorder is the mock recorder for MockSyncCursorRepo.
+type MockSyncCursorRepoMockRecorder struct {
+	mock *MockSyncCursorRepo
+}
+
+// NewMockSyncCursorRepo creates a new mock instance.
+func NewMockSyncCursorRepo(ctrl *gomock.Controller) *MockSyncCursorRepo {
+	mock := &MockSyncCursorRepo{ctrl: ctrl}
+	mock.recorder = &MockSyncCursorRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncCursorRepo) EXPECT() *MockSyncCursorRepoMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockSyncCursorRepo) Read(ctx context.Context, name string) (entity.SyncCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, name)
+	ret0, _ := ret[0].(entity.SyncCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockSyncCursorRepoMockRecorder) Read(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockSyncCursorRepo)(nil).Read), ctx, name)
+}
+
+// ReadForUpdate mocks base method.
+func (m *MockSyncCursorRepo) ReadForUpdate(ctx context.Context, name string) (entity.SyncCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadForUpdate", ctx, name)
+	ret0, _ := ret[0].(entity.SyncCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadForUpdate indicates an expected call of ReadForUpdate.
+func (mr *MockSyncCursorRepoMockRecorder) ReadForUpdate(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadForUpdate", reflect.TypeOf((*MockSyncCursorRepo)(nil).ReadForUpdate), ctx, name)
+}
+
+// Save mocks base method.
+func (m *MockSyncCursorRepo) Save(ctx context.Context, name string, cursor entity.SyncCursor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, name, cursor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSyncCursorRepoMockRecorder) Save(ctx, name, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSyncCursorRepo)(nil).Save), ctx, name, cursor)
+}
+
+// MockTranslationRepo is a mock of TranslationRepo interface.
+type MockTranslationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockTranslationRepoMockRecorder is the mock recorder for MockTranslationRepo.
+type MockTranslationRepoMockRecorder struct {
+	mock *MockTranslationRepo
+}
+
+// NewMockTranslationRepo creates a new mock instance.
+func NewMockTranslationRepo(ctrl *gomock.Controller) *MockTranslationRepo {
+	mock := &MockTranslationRepo{ctrl: ctrl}
+	mock.recorder = &MockTranslationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationRepo) EXPECT() *MockTranslationRepoMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTranslationRepo) Delete(ctx context.Context, incID int, locale, updatedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, incID, locale, updatedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTranslationRepoMockRecorder) Delete(ctx, incID, locale, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTranslationRepo)(nil).Delete), ctx, incID, locale, updatedBy)
+}
+
+// ReadByIncident mocks base method.
+func (m *MockTranslationRepo) ReadByIncident(ctx context.Context, incID int) ([]entity.IncidentTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByIncident", ctx, incID)
+	ret0, _ := ret[0].([]entity.IncidentTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByIncident indicates an expected call of ReadByIncident.
+func (mr *MockTranslationRepoMockRecorder) ReadByIncident(ctx, incID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByIncident", reflect.TypeOf((*MockTranslationRepo)(nil).ReadByIncident), ctx, incID)
+}
+
+// ReadByIncidents mocks base method.
+func (m *MockTranslationRepo) ReadByIncidents(ctx context.Context, incIDs []int) ([]entity.IncidentTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByIncidents", ctx, incIDs)
+	ret0, _ := ret[0].([]entity.IncidentTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByIncidents indicates an expected call of ReadByIncidents.
+func (mr *MockTranslationRepoMockRecorder) ReadByIncidents(ctx, incIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByIncidents", reflect.TypeOf((*MockTranslationRepo)(nil).ReadByIncidents), ctx, incIDs)
+}
+
+// Upsert mocks base method.
+func (m *MockTranslationRepo) Upsert(ctx context.Context, translation entity.IncidentTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, translation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockTranslationRepoMockRecorder) Upsert(ctx, translation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockTranslationRepo)(nil).Upsert), ctx, translation)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// InTx mocks base method.
+func (m *MockTransactor) InTx(ctx context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockTransactorMockRecorder) InTx(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockTransactor)(nil).InTx), ctx)
+}
+
+// WithinTx mocks base method.
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockTransactorMockRecorder) WithinTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockTransactor)(nil).WithinTx), ctx, fn)
+}
+
+// MockUsageRepo is a mock of UsageRepo interface.
+type MockUsageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsageRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockUsageRepoMockRecorder is the mock recorder for MockUsageRepo.
+type MockUsageRepoMockRecorder struct {
+	mock *MockUsageRepo
+}
+
+// NewMockUsageRepo creates a new mock instance.
+func NewMockUsageRepo(ctrl *gomock.Controller) *MockUsageRepo {
+	mock := &MockUsageRepo{ctrl: ctrl}
+	mock.recorder = &MockUsageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsageRepo) EXPECT() *MockUsageRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockUsageRepo) Add(ctx context.Context, usage []entity.APIKeyUsage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, usage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockUsageRepoMockRecorder) Add(ctx, usage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockUsageRepo)(nil).Add), ctx, usage)
+}
+
+// List mocks base method.
+func (m *MockUsageRepo) List(ctx context.Context, from, to time.Time) ([]entity.APIKeyUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, from, to)
+	ret0, _ := ret[0].([]entity.APIKeyUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUsageRepoMockRecorder) List(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUsageRepo)(nil).List), ctx, from, to)
+}
+
+// MockWebhookRepo is a mock of WebhookRepo interface.
+type MockWebhookRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepoMockRecorder is the mock recorder for MockWebhookRepo.
+type MockWebhookRepoMockRecorder struct {
+	mock *MockWebhookRepo
+}
+
+// NewMockWebhookRepo creates a new mock instance.
+func NewMockWebhookRepo(ctrl *gomock.Controller) *MockWebhookRepo {
+	mock := &MockWebhookRepo{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepo) EXPECT() *MockWebhookRepoMockRecorder {
+	return m.recorder
+}
+
+// AttachOrphans mocks base method.
+func (m *MockWebhookRepo) AttachOrphans(ctx context.Context, endpointID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachOrphans", ctx, endpointID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachOrphans indicates an expected call of AttachOrphans.
+func (mr *MockWebhookRepoMockRecorder) AttachOrphans(ctx, endpointID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachOrphans", reflect.TypeOf((*MockWebhookRepo)(nil).AttachOrphans), ctx, endpointID)
+}
+
+// Backlog mocks base method.
+func (m *MockWebhookRepo) Backlog(ctx context.Context) (entity.WebhookBacklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backlog", ctx)
+	ret0, _ := ret[0].(entity.WebhookBacklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backlog indicates an expected call of Backlog.
+func (mr *MockWebhookRepoMockRecorder) Backlog(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backlog", reflect.TypeOf((*MockWebhookRepo)(nil).Backlog), ctx)
+}
+
+// Claim mocks base method.
+func (m *MockWebhookRepo) Claim(ctx context.Context, id int, workerID string, lease time.Duration) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, id, workerID, lease)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockWebhookRepoMockRecorder) Claim(ctx, id, workerID, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockWebhookRepo)(nil).Claim), ctx, id, workerID, lease)
+}
+
+// ClaimDue mocks base method.
+func (m *MockWebhookRepo) ClaimDue(ctx context.Context, limit int, workerID string, lease time.Duration) ([]*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, limit, workerID, lease)
+	ret0, _ := ret[0].([]*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockWebhookRepoMockRecorder) ClaimDue(ctx, limit, workerID, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockWebhookRepo)(nil).ClaimDue), ctx, limit, workerID, lease)
+}
+
+// CountByState mocks base method.
+func (m *MockWebhookRepo) CountByState(ctx context.Context) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByState", ctx)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByState indicates an expected call of CountByState.
+func (mr *MockWebhookRepoMockRecorder) CountByState(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByState", reflect.TypeOf((*MockWebhookRepo)(nil).CountByState), ctx)
+}
+
+// Create mocks base method.
+func (m *MockWebhookRepo) Create(ctx context.Context, w entity.Webhook) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, w)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookRepoMockRecorder) Create(ctx, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookRepo)(nil).Create), ctx, w)
+}
+
+// DeleteByUser mocks base method.
+func (m *MockWebhookRepo) DeleteByUser(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUser", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUser indicates an expected call of DeleteByUser.
+func (mr *MockWebhookRepoMockRecorder) DeleteByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUser", reflect.TypeOf((*MockWebhookRepo)(nil).DeleteByUser), ctx, userID)
+}
+
+// DeliveryLatency mocks base method.
+func (m *MockWebhookRepo) DeliveryLatency(ctx context.Context, window time.Duration, endpointID int) ([]entity.WebhookLatency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryLatency", ctx, window, endpointID)
+	ret0, _ := ret[0].([]entity.WebhookLatency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliveryLatency indicates an expected call of DeliveryLatency.
+func (mr *MockWebhookRepoMockRecorder) DeliveryLatency(ctx, window, endpointID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryLatency", reflect.TypeOf((*MockWebhookRepo)(nil).DeliveryLatency), ctx, window, endpointID)
+}
+
+// MarkAsDelivered mocks base method.
+func (m *MockWebhookRepo) MarkAsDelivered(ctx context.Context, id int, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsDelivered", ctx, id, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsDelivered indicates an expected call of MarkAsDelivered.
+func (mr *MockWebhookRepoMockRecorder) MarkAsDelivered(ctx, id, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsDelivered", reflect.TypeOf((*MockWebhookRepo)(nil).MarkAsDelivered), ctx, id, workerID)
+}
+
+// MarkAsFailed mocks base method.
+func (m *MockWebhookRepo) MarkAsFailed(ctx context.Context, id int, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsFailed", ctx, id, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsFailed indicates an expected call of MarkAsFailed.
+func (mr *MockWebhookRepoMockRecorder) MarkAsFailed(ctx, id, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsFailed", reflect.TypeOf((*MockWebhookRepo)(nil).MarkAsFailed), ctx, id, workerID)
+}
+
+// Read mocks base method.
+func (m *MockWebhookRepo) Read(ctx context.Context, id int) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, id)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockWebhookRepoMockRecorder) Read(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockWebhookRepo)(nil).Read), ctx, id)
+}
+
+// ReadByUser mocks base method.
+func (m *MockWebhookRepo) ReadByUser(ctx context.Context, userID string, eventTypes []string) ([]*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByUser", ctx, userID, eventTypes)
+	ret0, _ := ret[0].([]*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByUser indicates an expected call of ReadByUser.
+func (mr *MockWebhookRepoMockRecorder) ReadByUser(ctx, userID, eventTypes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByUser", reflect.TypeOf((*MockWebhookRepo)(nil).ReadByUser), ctx, userID, eventTypes)
+}
+
+// ReadFailed mocks base method.
+func (m *MockWebhookRepo) ReadFailed(ctx context.Context, limit int) ([]*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFailed", ctx, limit)
+	ret0, _ := ret[0].([]*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadFailed indicates an expected call of ReadFailed.
+func (mr *MockWebhookRepoMockRecorder) ReadFailed(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFailed", reflect.TypeOf((*MockWebhookRepo)(nil).ReadFailed), ctx, limit)
+}
+
+// ReadInProgress mocks base method.
+func (m *MockWebhookRepo) ReadInProgress(ctx context.Context, limit int) ([]*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadInProgress", ctx, limit)
+	ret0, _ := ret[0].([]*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadInProgress indicates an expected call of ReadInProgress.
+func (mr *MockWebhookRepoMockRecorder) ReadInProgress(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadInProgress", reflect.TypeOf((*MockWebhookRepo)(nil).ReadInProgress), ctx, limit)
+}
+
+// Redrive mocks base method.
+func (m *MockWebhookRepo) Redrive(ctx context.Context, filter entity.WebhookRedriveFilter) ([]*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redrive", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redrive indicates an expected call of Redrive.
+func (mr *MockWebhookRepoMockRecorder) Redrive(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redrive", reflect.TypeOf((*MockWebhookRepo)(nil).Redrive), ctx, filter)
+}
+
+// RequeueFailed mocks base method.
+func (m *MockWebhookRepo) RequeueFailed(ctx context.Context, ids []int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueFailed", ctx, ids)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueFailed indicates an expected call of RequeueFailed.
+func (mr *MockWebhookRepoMockRecorder) RequeueFailed(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueFailed", reflect.TypeOf((*MockWebhookRepo)(nil).RequeueFailed), ctx, ids)
+}
+
+// ScheduleRetry mocks base method.
+func (m *MockWebhookRepo) ScheduleRetry(ctx context.Context, id int, workerID string, retryCnt int, delay time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleRetry", ctx, id, workerID, retryCnt, delay)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleRetry indicates an expected call of ScheduleRetry.
+func (mr *MockWebhookRepoMockRecorder) ScheduleRetry(ctx, id, workerID, retryCnt, delay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleRetry", reflect.TypeOf((*MockWebhookRepo)(nil).ScheduleRetry), ctx, id, workerID, retryCnt, delay)
+}
+
+// UpdateState mocks base method.
+func (m *MockWebhookRepo) UpdateState(ctx context.Context, id int, newState string, retryCnt int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateState", ctx, id, newState, retryCnt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateState indicates an expected call of UpdateState.
+func (mr *MockWebhookRepoMockRecorder) UpdateState(ctx, id, newState, retryCnt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateState", reflect.TypeOf((*MockWebhookRepo)(nil).UpdateState), ctx, id, newState, retryCnt)
+}
+
+// MockWebhookEndpointRepo is a mock of WebhookEndpointRepo interface.
+type MockWebhookEndpointRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookEndpointRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookEndpointRepoMockRecorder is the mock recorder for MockWebhookEndpointRepo.
+type MockWebhookEndpointRepoMockRecorder struct {
+	mock *MockWebhookEndpointRepo
+}
+
+// NewMockWebhookEndpointRepo creates a new mock instance.
+func NewMockWebhookEndpointRepo(ctrl *gomock.Controller) *MockWebhookEndpointRepo {
+	mock := &MockWebhookEndpointRepo{ctrl: ctrl}
+	mock.recorder = &MockWebhookEndpointRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookEndpointRepo) EXPECT() *MockWebhookEndpointRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookEndpointRepo) Create(ctx context.Context, endpoint entity.WebhookEndpoint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, endpoint)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookEndpointRepoMockRecorder) Create(ctx, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).Create), ctx, endpoint)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookEndpointRepo) Delete(ctx context.Context, endpointID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, endpointID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookEndpointRepoMockRecorder) Delete(ctx, endpointID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).Delete), ctx, endpointID)
+}
+
+// Read mocks base method.
+func (m *MockWebhookEndpointRepo) Read(ctx context.Context, endpointID int) (*entity.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, endpointID)
+	ret0, _ := ret[0].(*entity.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockWebhookEndpointRepoMockRecorder) Read(ctx, endpointID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).Read), ctx, endpointID)
+}
+
+// ReadAll mocks base method.
+func (m *MockWebhookEndpointRepo) ReadAll(ctx context.Context) ([]*entity.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", ctx)
+	ret0, _ := ret[0].([]*entity.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockWebhookEndpointRepoMockRecorder) ReadAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).ReadAll), ctx)
+}
+
+// ReadSubscribed mocks base method.
+func (m *MockWebhookEndpointRepo) ReadSubscribed(ctx context.Context, eventType string) ([]*entity.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSubscribed", ctx, eventType)
+	ret0, _ := ret[0].([]*entity.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSubscribed indicates an expected call of ReadSubscribed.
+func (mr *MockWebhookEndpointRepoMockRecorder) ReadSubscribed(ctx, eventType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSubscribed", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).ReadSubscribed), ctx, eventType)
+}
+
+// Update mocks base method.
+func (m *MockWebhookEndpointRepo) Update(ctx context.Context, endpoint entity.WebhookEndpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, endpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookEndpointRepoMockRecorder) Update(ctx, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).Update), ctx, endpoint)
+}
@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type OperatorRepo interface {
+	Create(ctx context.Context, operator entity.Operator) (operatorID int, err error)
+	ReadByUsername(ctx context.Context, username string) (*entity.Operator, error)
+	ReadByOIDCSubject(ctx context.Context, subject string) (*entity.Operator, error)
+}
@@ -0,0 +1,15 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type PreferenceRepo interface {
+	// Read возвращает сохраненные настройки; пользователь их не сохранял — entity.ErrPreferencesNotFound
+	Read(ctx context.Context, userID string) (*entity.UserPreferences, error)
+	// Upsert создает или заменяет настройки пользователя и возвращает сохраненные
+	Upsert(ctx context.Context, prefs entity.UserPreferences) (*entity.UserPreferences, error)
+	Delete(ctx context.Context, userID string) (deleted int64, err error)
+}
@@ -0,0 +1,14 @@
+package repo
+
+import "context"
+
+// PresenceRepo хранит зоны, в которых пользователь находился по последней проверке
+type PresenceRepo interface {
+	ReadZones(ctx context.Context, userID string) (incidentIDs []int, err error)
+	// Enter и Exit возвращают только зоны, состояние которых действительно изменилось:
+	// при параллельных проверках одного пользователя событие достается одной из них
+	Enter(ctx context.Context, userID string, incidentIDs []int) (entered []int, err error)
+	Exit(ctx context.Context, userID string, incidentIDs []int) (exited []int, err error)
+	// Forget удаляет все зоны пользователя без событий выхода
+	Forget(ctx context.Context, userID string) (forgotten int64, err error)
+}
@@ -0,0 +1,15 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type RelationRepo interface {
+	// Create возвращает entity.ErrRelationExists, если такая связь есть или у зоны уже есть родительская
+	Create(ctx context.Context, relation entity.IncidentRelation) (*entity.IncidentRelation, error)
+	Delete(ctx context.Context, relation entity.IncidentRelation) error
+	// ReadByIncidents возвращает связи, где любая из зон — IncidentID или RelatedID; связи с удаленными зонами пропускаются
+	ReadByIncidents(ctx context.Context, incIDs []int) ([]entity.IncidentRelation, error)
+}
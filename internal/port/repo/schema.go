@@ -0,0 +1,7 @@
+package repo
+
+import "context"
+
+type SchemaRepo interface {
+	AppliedVersion(ctx context.Context) (version int64, err error)
+}
@@ -0,0 +1,24 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// StatsRepo — поминутные счетчики проверок, сведенные из checks
+type StatsRepo interface {
+	// Rollup сводит проверки, записанные с from до to, в поминутные счетчики, прибавляя их к уже сведенным.
+	// Повторная сводка тех же минут учтет проверки дважды, поэтому позицию сводки ведет вызывающий
+	Rollup(ctx context.Context, from, to time.Time) error
+	// FirstCheckAt возвращает время записи самой старой проверки, нулевое без проверок
+	FirstCheckAt(ctx context.Context) (time.Time, error)
+	// Read суммирует счетчики за минуты с from до to
+	Read(ctx context.Context, from, to time.Time) (entity.CheckStats, error)
+	// ReadByIncident возвращает число проверок в каждой неудаленной зоне за минуты с from до to, начиная с самых частых.
+	// visibilities ограничивает видимость зон, nil — зоны с любой видимостью
+	ReadByIncident(ctx context.Context, from, to time.Time, visibilities []string) ([]entity.IncidentCheckStats, error)
+	// DeleteBefore удаляет счетчики за минуты раньше before
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type SyncCursorRepo interface {
+	// Read возвращает сохраненную позицию выгрузки name; без сохраненной — нулевую
+	Read(ctx context.Context, name string) (entity.SyncCursor, error)
+	Save(ctx context.Context, name string, cursor entity.SyncCursor) error
+	// ReadForUpdate — Read с блокировкой позиции до конца транзакции: вызывается в транзакции, которая ее и сдвигает,
+	// чтобы реплики без блокировок воркеров не обработали одну пачку дважды
+	ReadForUpdate(ctx context.Context, name string) (entity.SyncCursor, error)
+}
@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type TranslationRepo interface {
+	// Upsert создает или заменяет перевод зоны на язык translation.Locale и сдвигает updated_at зоны
+	Upsert(ctx context.Context, translation entity.IncidentTranslation) error
+	// Delete удаляет перевод и сдвигает updated_at зоны; перевода нет — entity.ErrTranslationNotFound
+	Delete(ctx context.Context, incID int, locale, updatedBy string) error
+	ReadByIncident(ctx context.Context, incID int) ([]entity.IncidentTranslation, error)
+	ReadByIncidents(ctx context.Context, incIDs []int) ([]entity.IncidentTranslation, error)
+}
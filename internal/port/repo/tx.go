@@ -0,0 +1,9 @@
+package repo
+
+import "context"
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// InTx сообщает, выполняется ли ctx внутри WithinTx
+	InTx(ctx context.Context) bool
+}
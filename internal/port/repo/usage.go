@@ -0,0 +1,15 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type UsageRepo interface {
+	// Add прибавляет использование к сохраненному за те же ключ и сутки
+	Add(ctx context.Context, usage []entity.APIKeyUsage) error
+	// List возвращает использование за сутки с from по to включительно, упорядоченные по ключу и дню
+	List(ctx context.Context, from, to time.Time) ([]entity.APIKeyUsage, error)
+}
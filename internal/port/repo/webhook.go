@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/4otis/geonotify-service/internal/entity"
 )
@@ -11,5 +12,29 @@ type WebhookRepo interface {
 	UpdateState(ctx context.Context, id int, newState string, retryCnt int) error
 	Read(ctx context.Context, id int) (*entity.Webhook, error)
 	ReadInProgress(ctx context.Context, limit int) ([]*entity.Webhook, error)
-	MarkAsDelivered(ctx context.Context, id int) error
+	MarkAsDelivered(ctx context.Context, id int, workerID string) error
+	MarkAsFailed(ctx context.Context, id int, workerID string) error
+	ScheduleRetry(ctx context.Context, id int, workerID string, retryCnt int, delay time.Duration) error
+	Claim(ctx context.Context, id int, workerID string, lease time.Duration) (*entity.Webhook, error)
+	ClaimDue(ctx context.Context, limit int, workerID string, lease time.Duration) ([]*entity.Webhook, error)
+	// RequeueFailed возвращает вебхуки в состоянии failed в outbox; пустой ids — все такие вебхуки
+	RequeueFailed(ctx context.Context, ids []int) (requeued int, err error)
+	// Redrive возвращает в outbox вебхуки по фильтру со сброшенным счетчиком и распределяет
+	// их scheduled_at по filter.Spread; возвращаются ID, CheckID и ScheduledAt вебхуков
+	Redrive(ctx context.Context, filter entity.WebhookRedriveFilter) ([]*entity.Webhook, error)
+	// CountByState считает недоставленные вебхуки по состояниям
+	CountByState(ctx context.Context) (map[string]int, error)
+	// Backlog считает недоставленные вебхуки и их возраст по основной БД
+	Backlog(ctx context.Context) (entity.WebhookBacklog, error)
+	// DeliveryLatency считает перцентили задержки доставки по получателям за window до текущего момента;
+	// endpointID 0 — по всем получателям. Получатели без доставок за окно не возвращаются
+	DeliveryLatency(ctx context.Context, window time.Duration, endpointID int) ([]entity.WebhookLatency, error)
+	// ReadFailed возвращает вебхуки, исчерпавшие попытки, начиная с последних
+	ReadFailed(ctx context.Context, limit int) ([]*entity.Webhook, error)
+	// AttachOrphans привязывает недоставленные вебхуки без получателя к endpointID
+	AttachOrphans(ctx context.Context, endpointID int) (attached int, err error)
+	// ReadByUser возвращает вебхуки проверок пользователя с типами событий eventTypes от старых к новым
+	ReadByUser(ctx context.Context, userID string, eventTypes []string) ([]*entity.Webhook, error)
+	// DeleteByUser удаляет вебхуки проверок пользователя в любом состоянии; вызывается до удаления самих проверок
+	DeleteByUser(ctx context.Context, userID string) (deleted int64, err error)
 }
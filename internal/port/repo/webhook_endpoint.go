@@ -0,0 +1,19 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+type WebhookEndpointRepo interface {
+	Create(ctx context.Context, endpoint entity.WebhookEndpoint) (endpointID int, err error)
+	Read(ctx context.Context, endpointID int) (*entity.WebhookEndpoint, error)
+	// ReadAll возвращает общих получателей, без получателей групп
+	ReadAll(ctx context.Context) ([]*entity.WebhookEndpoint, error)
+	// ReadSubscribed возвращает включенных общих получателей, подписанных на событие eventType
+	ReadSubscribed(ctx context.Context, eventType string) ([]*entity.WebhookEndpoint, error)
+	Update(ctx context.Context, endpoint entity.WebhookEndpoint) error
+	// Delete удаляет получателя вместе с его недоставленными вебхуками
+	Delete(ctx context.Context, endpointID int) error
+}
@@ -0,0 +1,20 @@
+package search
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// Indexer зеркалирует зоны и проверки координат во внешний поисковый индекс для аналитики.
+// Повторная запись того же документа его заменяет, поэтому пачки можно отправлять повторно
+type Indexer interface {
+	// EnsureTemplates создает или обновляет шаблоны индексов с маппингами полей
+	EnsureTemplates(ctx context.Context) error
+	// IndexIncidents записывает измененные зоны и удаляет из индекса удаленные
+	IndexIncidents(ctx context.Context, changes []entity.IncidentChange) error
+	IndexChecks(ctx context.Context, checks []*entity.Check) error
+	// DeleteUserChecks и AnonymizeUserChecks повторяют в индексе удаление данных пользователя
+	DeleteUserChecks(ctx context.Context, userID string) error
+	AnonymizeUserChecks(ctx context.Context, userID, anonymizedID string, precision int) error
+}
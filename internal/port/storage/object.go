@@ -0,0 +1,13 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type ObjectStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
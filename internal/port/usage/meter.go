@@ -0,0 +1,22 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// Meter считает запросы API-ключей в хранилище, общем для всех реплик: по его счетчикам
+// проверяются квоты, а накопленное с прошлого Drain переносится в БД
+type Meter interface {
+	// Record учитывает запрос ключа key в сутках и месяце now, если он укладывается в quota
+	Record(ctx context.Context, key string, quota entity.APIKeyQuota, now time.Time) (entity.UsageCounters, error)
+	// Current возвращает счетчики ключа за сутки и месяц now, не учитывая запрос
+	Current(ctx context.Context, key string, now time.Time) (entity.UsageCounters, error)
+	// Add прибавляет использование к накопленному, не проверяя квоты: так учитываются проверки, алерты
+	// и вебхуки, а также возвращается забранное Drain, которое не удалось сохранить
+	Add(ctx context.Context, usage []entity.APIKeyUsage) error
+	// Drain забирает использование, накопленное с прошлого вызова, по ключам и суткам
+	Drain(ctx context.Context) ([]entity.APIKeyUsage, error)
+}
@@ -0,0 +1,14 @@
+package weather
+
+import (
+	"context"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+)
+
+// Provider — погодный API с предупреждениями об опасных явлениях
+type Provider interface {
+	// Alerts возвращает действующие предупреждения для отслеживаемых мест с уже сопоставленным
+	// уровнем опасности; предупреждения ниже порога из настроек не возвращаются
+	Alerts(ctx context.Context) ([]entity.ExternalAlert, error)
+}
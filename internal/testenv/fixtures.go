@@ -0,0 +1,58 @@
+//go:build integration
+
+package testenv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Incident — опубликованная действующая круглая зона в центре Москвы
+func Incident(name string) entity.Incident {
+	return entity.Incident{
+		Name:       name,
+		Descr:      name + " description",
+		Latitude:   55.7558,
+		Longitude:  37.6173,
+		Radius:     500,
+		IsActive:   true,
+		Status:     entity.IncidentPublished,
+		Severity:   entity.SeverityMedium,
+		State:      entity.StateActive,
+		Visibility: entity.VisibilityPublic,
+		CreatedBy:  "fixture",
+	}
+}
+
+// Check — проверка пользователя в точке без попадания в зоны
+func Check(userID string, lat, lng float64) entity.Check {
+	return entity.Check{
+		UserID:    userID,
+		Latitude:  lat,
+		Longitude: lng,
+	}
+}
+
+// Webhook — вебхук события проверки, готовый к доставке
+func Webhook(checkID int) entity.Webhook {
+	return entity.Webhook{
+		CheckID:   checkID,
+		EventType: entity.WebhookLocationAlert,
+		State:     "in progress",
+		Payload:   []byte(`{"event":"location.alert","data":{}}`),
+	}
+}
+
+// Count считает строки запроса вида SELECT COUNT(*)
+func Count(t testing.TB, pool *pgxpool.Pool, query string, args ...any) int {
+	t.Helper()
+
+	var n int
+	if err := pool.QueryRow(context.Background(), query, args...).Scan(&n); err != nil {
+		t.Fatalf("failed to count %q: %v", query, err)
+	}
+	return n
+}
@@ -0,0 +1,214 @@
+//go:build integration
+
+// Package testenv поднимает Postgres и Redis в контейнерах testcontainers для интеграционных тестов.
+// Тесты с тегом integration запускаются командой make test-integration, нужен доступный Docker
+package testenv
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+
+	"github.com/4otis/geonotify-service/pkg/redis"
+)
+
+// образы те же, что в docker-compose.yml
+const (
+	postgresImage = "postgres:15"
+	redisImage    = "redis:7-alpine"
+
+	startTimeout = 2 * time.Minute
+)
+
+// Postgres — контейнер с примененными миграциями. Запускается один раз на пакет из TestMain,
+// тесты очищают таблицы через Reset
+type Postgres struct {
+	Pool      *pgxpool.Pool
+	URL       string
+	container *tcpostgres.PostgresContainer
+}
+
+func StartPostgres(ctx context.Context) (*Postgres, error) {
+	ctx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+
+	container, err := tcpostgres.Run(ctx, postgresImage,
+		tcpostgres.WithDatabase("geonotify"),
+		tcpostgres.WithUsername("geonotify"),
+		tcpostgres.WithPassword("geonotify"),
+		tcpostgres.BasicWaitStrategies(),
+	)
+	if err != nil {
+		if container != nil {
+			_ = testcontainers.TerminateContainer(container)
+		}
+		return nil, fmt.Errorf("failed to start postgres container: %w", err)
+	}
+
+	pg := &Postgres{container: container}
+	pg.URL, err = container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		pg.Close()
+		return nil, fmt.Errorf("failed to get postgres connection string: %w", err)
+	}
+
+	pg.Pool, err = pgxpool.New(ctx, pg.URL)
+	if err != nil {
+		pg.Close()
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+
+	if err := Migrate(ctx, pg.Pool); err != nil {
+		pg.Close()
+		return nil, err
+	}
+
+	return pg, nil
+}
+
+func (p *Postgres) Close() {
+	if p.Pool != nil {
+		p.Pool.Close()
+	}
+	_ = testcontainers.TerminateContainer(p.container)
+}
+
+// Reset очищает таблицы и сбрасывает их последовательности; зависимые таблицы очищаются каскадом
+func (p *Postgres) Reset(t testing.TB, tables ...string) {
+	t.Helper()
+
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = pgx.Identifier{table}.Sanitize()
+	}
+	query := fmt.Sprintf(`TRUNCATE %s RESTART IDENTITY CASCADE;`, strings.Join(names, ", "))
+	if _, err := p.Pool.Exec(context.Background(), query); err != nil {
+		t.Fatalf("failed to truncate %v: %v", tables, err)
+	}
+}
+
+// Migrate применяет секции Up миграций goose по порядку имен файлов. Секция выполняется
+// одним запросом простого протокола, поэтому блоки StatementBegin/End не требуют разбора
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	files, err := filepath.Glob(filepath.Join(MigrationsDir(), "*.sql"))
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", filepath.Base(file), err)
+		}
+
+		up := upSection(string(raw))
+		if strings.TrimSpace(up) == "" {
+			continue
+		}
+		if _, err := pool.Exec(ctx, up); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", filepath.Base(file), err)
+		}
+	}
+
+	return nil
+}
+
+// MigrationsDir — каталог migrations в корне модуля
+func MigrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "migrations")
+}
+
+func upSection(migration string) string {
+	_, up, ok := strings.Cut(migration, "-- +goose Up")
+	if !ok {
+		return ""
+	}
+	up, _, _ = strings.Cut(up, "-- +goose Down")
+	return up
+}
+
+// Redis — контейнер Redis; Client — клиент сервиса, через который работают адаптеры
+type Redis struct {
+	Client    *redis.Client
+	URL       string
+	container *tcredis.RedisContainer
+}
+
+func StartRedis(ctx context.Context) (*Redis, error) {
+	ctx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+
+	container, err := tcredis.Run(ctx, redisImage)
+	if err != nil {
+		if container != nil {
+			_ = testcontainers.TerminateContainer(container)
+		}
+		return nil, fmt.Errorf("failed to start redis container: %w", err)
+	}
+
+	r := &Redis{container: container}
+	r.URL, err = container.ConnectionString(ctx)
+	if err != nil {
+		r.Close()
+		return nil, fmt.Errorf("failed to get redis connection string: %w", err)
+	}
+
+	r.Client, err = redis.NewClient(ctx, r.URL, redis.PoolOptions{})
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Redis) Close() {
+	if r.Client != nil {
+		_ = r.Client.Close()
+	}
+	_ = testcontainers.TerminateContainer(r.container)
+}
+
+// Reset удаляет все ключи
+func (r *Redis) Reset(t testing.TB) {
+	t.Helper()
+
+	opts, err := goredis.ParseURL(r.URL)
+	if err != nil {
+		t.Fatalf("failed to parse redis URL: %v", err)
+	}
+	client := goredis.NewClient(opts)
+	defer client.Close()
+
+	if err := client.FlushAll(context.Background()).Err(); err != nil {
+		t.Fatalf("failed to flush redis: %v", err)
+	}
+}
+
+// Main запускает тесты пакета в TestMain, подняв контейнеры через start; без Docker тесты падают сразу
+func Main(m *testing.M, start func(ctx context.Context) (stop func(), err error)) {
+	stop, err := start(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "testenv:", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
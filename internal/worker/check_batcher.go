@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"go.uber.org/zap"
+)
+
+var _ repo.CheckRepo = (*CheckBatcher)(nil)
+
+// checkBatcherStopTimeout — сколько ждать записи остатка буфера при остановке
+const checkBatcherStopTimeout = 10 * time.Second
+
+// CheckBatcher — отложенная запись проверок: CopyBatch вне транзакции кладет проверки в буфер,
+// а буфер сбрасывается в БД через COPY раз в flushEvery или по size строк. Так пишутся только проверки,
+// на которые ничего не ссылается. Create, CreateBatch и CopyBatch внутри транзакции пишут сразу,
+// поэтому проверка фиксируется или откатывается вместе со своими вебхуками и событиями.
+// Проверки в буфере теряются при падении процесса, поэтому батчер включается явно (CHECK_BATCH_ENABLED).
+// created_at проверки — момент проверки, а не записи: сводки и выгрузки читают проверки по stored_at
+type CheckBatcher struct {
+	repo.CheckRepo
+
+	tx         repo.Transactor
+	logger     *zap.Logger
+	size       int
+	capacity   int
+	flushEvery time.Duration
+
+	mu      sync.Mutex
+	pending []entity.Check
+
+	flushChan chan struct{}
+	stopChan  chan struct{}
+	done      chan struct{}
+}
+
+func NewCheckBatcher(
+	logger *zap.Logger,
+	checkRepo repo.CheckRepo,
+	tx repo.Transactor,
+	size int,
+	flushMs int,
+	capacity int,
+) *CheckBatcher {
+	return &CheckBatcher{
+		CheckRepo:  checkRepo,
+		tx:         tx,
+		logger:     logger,
+		size:       size,
+		capacity:   capacity,
+		flushEvery: time.Duration(flushMs) * time.Millisecond,
+		flushChan:  make(chan struct{}, 1),
+		stopChan:   make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// CopyBatch откладывает запись проверок до ближайшего сброса буфера. В транзакции и при
+// заполненном буфере проверки пишутся сразу, как без батчинга
+func (b *CheckBatcher) CopyBatch(ctx context.Context, checks []entity.Check) error {
+	if len(checks) == 0 {
+		return nil
+	}
+	if b.tx.InTx(ctx) {
+		return b.CheckRepo.CopyBatch(ctx, checks)
+	}
+
+	now := time.Now()
+	b.mu.Lock()
+	if len(b.pending)+len(checks) > b.capacity {
+		b.mu.Unlock()
+		return b.CheckRepo.CopyBatch(ctx, checks)
+	}
+	for _, check := range checks {
+		if check.CreatedAt.IsZero() {
+			check.CreatedAt = now
+		}
+		b.pending = append(b.pending, check)
+	}
+	full := len(b.pending) >= b.size
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.flushChan <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (b *CheckBatcher) Start(ctx context.Context) {
+	b.logger.Info("Starting check write batcher",
+		zap.Int("batch_size", b.size),
+		zap.Duration("flush_interval", b.flushEvery),
+		zap.Int("buffer", b.capacity))
+
+	go b.run(ctx)
+}
+
+// Stop дожидается записи проверок, оставшихся в буфере
+func (b *CheckBatcher) Stop() {
+	b.logger.Info("Stopping check write batcher")
+	close(b.stopChan)
+	<-b.done
+}
+
+func (b *CheckBatcher) run(ctx context.Context) {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.flushEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stopChan:
+			b.flushOnStop()
+			return
+		case <-ctx.Done():
+			b.flushOnStop()
+			return
+		case <-ticker.C:
+			b.flush(ctx)
+		case <-b.flushChan:
+			b.flush(ctx)
+		}
+	}
+}
+
+func (b *CheckBatcher) flushOnStop() {
+	ctx, cancel := context.WithTimeout(context.Background(), checkBatcherStopTimeout)
+	defer cancel()
+
+	if err := b.flush(ctx); err != nil {
+		b.mu.Lock()
+		lost := len(b.pending)
+		b.pending = nil
+		b.mu.Unlock()
+
+		b.logger.Error("Checks lost on shutdown", zap.Int("checks", lost))
+	}
+}
+
+func (b *CheckBatcher) flush(ctx context.Context) error {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	err := b.CheckRepo.CopyBatch(ctx, batch)
+	if err == nil {
+		b.logger.Debug("Checks batch flushed", zap.Int("checks", len(batch)))
+		return nil
+	}
+
+	// несохраненная пачка возвращается в начало буфера, но не сверх его размера
+	b.mu.Lock()
+	room := b.capacity - len(b.pending)
+	lost := 0
+	if room < len(batch) {
+		lost = len(batch) - max(room, 0)
+		batch = batch[:len(batch)-lost]
+	}
+	b.pending = append(batch, b.pending...)
+	b.mu.Unlock()
+
+	b.logger.Error("Failed to flush checks batch",
+		zap.Error(err),
+		zap.Int("checks", len(batch)+lost),
+		zap.Int("lost", lost))
+
+	return err
+}
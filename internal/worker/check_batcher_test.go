@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	repomocks "github.com/4otis/geonotify-service/internal/port/repo/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCheckBatcherKeepsCheckTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	checks := repomocks.NewMockCheckRepo(ctrl)
+	tx := repomocks.NewMockTransactor(ctrl)
+	b := NewCheckBatcher(zap.NewNop(), checks, tx, 10, 1000, 100)
+	ctx := context.Background()
+
+	checkedAt := time.Now().Add(-time.Minute)
+	tx.EXPECT().InTx(gomock.Any()).Return(false)
+	if err := b.CopyBatch(ctx, []entity.Check{{UserID: "user-1", CreatedAt: checkedAt}}); err != nil {
+		t.Fatalf("CopyBatch() error = %v", err)
+	}
+
+	// первая запись не удалась: пачка вернулась в буфер
+	checks.EXPECT().CopyBatch(gomock.Any(), gomock.Any()).Return(errors.New("db is down"))
+	if err := b.flush(ctx); err == nil {
+		t.Fatal("flush() error = nil, want db error")
+	}
+
+	checks.EXPECT().CopyBatch(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, batch []entity.Check) error {
+			if len(batch) != 1 || !batch[0].CreatedAt.Equal(checkedAt) {
+				t.Errorf("batch = %+v, want one check created at %v", batch, checkedAt)
+			}
+			return nil
+		})
+	if err := b.flush(ctx); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+}
+
+func TestCheckBatcherWritesThrough(t *testing.T) {
+	tests := []struct {
+		name     string
+		inTx     bool
+		capacity int
+	}{
+		{name: "inside transaction", inTx: true, capacity: 100},
+		{name: "buffer full", inTx: false, capacity: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			checks := repomocks.NewMockCheckRepo(ctrl)
+			tx := repomocks.NewMockTransactor(ctrl)
+			b := NewCheckBatcher(zap.NewNop(), checks, tx, 10, 1000, tt.capacity)
+			ctx := context.Background()
+
+			batch := []entity.Check{{UserID: "user-1"}, {UserID: "user-2"}}
+			tx.EXPECT().InTx(gomock.Any()).Return(tt.inTx)
+			checks.EXPECT().CopyBatch(gomock.Any(), batch).Return(nil)
+			if err := b.CopyBatch(ctx, batch); err != nil {
+				t.Fatalf("CopyBatch() error = %v", err)
+			}
+
+			// в буфере ничего не осталось
+			if err := b.flush(ctx); err != nil {
+				t.Fatalf("flush() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"go.uber.org/zap"
+)
+
+const (
+	// exportsPerRun ограничивает число архивов за запуск: архив активного пользователя собирается
+	// из проверок во всех партициях
+	exportsPerRun = 10
+	// expiredExportsPerRun — сколько истекших архивов удаляется за запуск
+	expiredExportsPerRun = 100
+)
+
+// DataExportWorker собирает выгрузки данных пользователей и удаляет архивы с истекшим сроком хранения
+type DataExportWorker struct {
+	logger   *zap.Logger
+	exportUC cases.DataExportUseCase
+	interval time.Duration
+	leader   Leader
+	stopChan chan struct{}
+}
+
+func NewDataExportWorker(
+	logger *zap.Logger,
+	exportUC cases.DataExportUseCase,
+	intervalSeconds int,
+	leader Leader,
+) *DataExportWorker {
+	return &DataExportWorker{
+		logger:   logger,
+		exportUC: exportUC,
+		interval: time.Duration(intervalSeconds) * time.Second,
+		leader:   leader,
+		stopChan: make(chan struct{}),
+	}
+}
+
+func (w *DataExportWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting user data export worker", zap.Duration("interval", w.interval))
+
+	go w.run(ctx)
+}
+
+func (w *DataExportWorker) Stop() {
+	w.logger.Info("Stopping user data export worker")
+	close(w.stopChan)
+}
+
+func (w *DataExportWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+func (w *DataExportWorker) process(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic while exporting user data")
+
+	if !leads(ctx, w.leader) {
+		return
+	}
+
+	completed, err := w.exportUC.ProcessExports(ctx, exportsPerRun)
+	if err != nil {
+		w.logger.Error("Failed to export user data", zap.Error(err))
+	}
+
+	if completed > 0 {
+		w.logger.Info("User data exports generated", zap.Int("completed", completed))
+	}
+
+	expired, err := w.exportUC.ExpireExports(ctx, expiredExportsPerRun)
+	if err != nil {
+		w.logger.Error("Failed to expire user data exports", zap.Error(err))
+	}
+
+	if expired > 0 {
+		w.logger.Info("Expired user data exports deleted", zap.Int("expired", expired))
+	}
+}
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"go.uber.org/zap"
+)
+
+// erasuresPerRun ограничивает число заявок за запуск: удаление проверок активного пользователя
+// может затронуть все партиции
+const erasuresPerRun = 10
+
+// ErasureWorker выполняет подтвержденные заявки на удаление данных пользователей
+type ErasureWorker struct {
+	logger    *zap.Logger
+	erasureUC cases.ErasureUseCase
+	interval  time.Duration
+	leader    Leader
+	stopChan  chan struct{}
+}
+
+func NewErasureWorker(
+	logger *zap.Logger,
+	erasureUC cases.ErasureUseCase,
+	intervalSeconds int,
+	leader Leader,
+) *ErasureWorker {
+	return &ErasureWorker{
+		logger:    logger,
+		erasureUC: erasureUC,
+		interval:  time.Duration(intervalSeconds) * time.Second,
+		leader:    leader,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+func (w *ErasureWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting user erasure worker", zap.Duration("interval", w.interval))
+
+	go w.run(ctx)
+}
+
+func (w *ErasureWorker) Stop() {
+	w.logger.Info("Stopping user erasure worker")
+	close(w.stopChan)
+}
+
+func (w *ErasureWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+func (w *ErasureWorker) process(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic while erasing user data")
+
+	if !leads(ctx, w.leader) {
+		return
+	}
+
+	completed, err := w.erasureUC.ProcessErasures(ctx, erasuresPerRun)
+	if err != nil {
+		w.logger.Error("Failed to erase user data", zap.Error(err))
+	}
+
+	if completed > 0 {
+		w.logger.Info("User data erasures completed", zap.Int("completed", completed))
+	}
+}
@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/port/events"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"go.uber.org/zap"
+)
+
+// EventRelayWorker переносит события из outbox в публикатор. Публикация и удаление
+// строк идут в одной транзакции: при сбое до коммита события будут опубликованы повторно
+type EventRelayWorker struct {
+	logger    *zap.Logger
+	eventRepo repo.EventRepo
+	tx        repo.Transactor
+	publisher events.Publisher
+	batchSize int
+	interval  time.Duration
+	leader    Leader
+	stopChan  chan struct{}
+}
+
+func NewEventRelayWorker(
+	logger *zap.Logger,
+	eventRepo repo.EventRepo,
+	tx repo.Transactor,
+	publisher events.Publisher,
+	batchSize int,
+	intervalMs int,
+	leader Leader,
+) *EventRelayWorker {
+	return &EventRelayWorker{
+		logger:    logger,
+		eventRepo: eventRepo,
+		tx:        tx,
+		publisher: publisher,
+		batchSize: batchSize,
+		interval:  time.Duration(intervalMs) * time.Millisecond,
+		leader:    leader,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+func (w *EventRelayWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting event relay worker",
+		zap.Int("batch_size", w.batchSize),
+		zap.Duration("interval", w.interval))
+
+	go w.run(ctx)
+}
+
+func (w *EventRelayWorker) Stop() {
+	w.logger.Info("Stopping event relay worker")
+	close(w.stopChan)
+}
+
+func (w *EventRelayWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.drain(ctx)
+		}
+	}
+}
+
+// drain публикует пачки, пока outbox не опустеет, чтобы всплеск не ждал следующих тиков
+func (w *EventRelayWorker) drain(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic while relaying events")
+
+	if !leads(ctx, w.leader) {
+		return
+	}
+
+	for {
+		published, err := w.relayBatch(ctx)
+		if err != nil {
+			w.logger.Error("Failed to relay events", zap.Error(err))
+			return
+		}
+
+		if published < w.batchSize {
+			return
+		}
+	}
+}
+
+func (w *EventRelayWorker) relayBatch(ctx context.Context) (int, error) {
+	published := 0
+
+	err := w.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pending, err := w.eventRepo.LockPending(ctx, w.batchSize)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int64, 0, len(pending))
+		for _, event := range pending {
+			if err := w.publisher.Publish(ctx, event); err != nil {
+				// уже опубликованные события пачки будут отправлены повторно
+				return fmt.Errorf("failed to publish event %d: %w", event.ID, err)
+			}
+			ids = append(ids, event.ID)
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := w.eventRepo.Delete(ctx, ids); err != nil {
+			return err
+		}
+		published = len(ids)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if published > 0 {
+		w.logger.Debug("Events relayed", zap.Int("count", published))
+	}
+
+	return published, nil
+}
@@ -0,0 +1,197 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/pkg/h3cell"
+	"go.uber.org/zap"
+)
+
+const heatmapSyncCursor = "heatmap:checks"
+
+// HeatmapWorker сводит новые проверки координат в почасовые счетчики по H3-ячейкам разрешения resolution.
+// Счетчики пачки и позиция курсора пишутся в одной транзакции: при сбое или на нескольких репликах
+// пачка не учитывается дважды.
+// Строки моложе settleSeconds ждут следующего запуска, как в SearchIndexWorker. Счетчики старше
+// retentionDays удаляются вместе с партициями проверок, 0 — хранятся бессрочно
+type HeatmapWorker struct {
+	logger        *zap.Logger
+	checks        repo.CheckRepo
+	heatmap       repo.HeatmapRepo
+	cursors       repo.SyncCursorRepo
+	tx            repo.Transactor
+	resolution    int
+	batchSize     int
+	settleSeconds int
+	retentionDays int
+	interval      time.Duration
+	leader        Leader
+	stopChan      chan struct{}
+}
+
+func NewHeatmapWorker(
+	logger *zap.Logger,
+	checks repo.CheckRepo,
+	heatmap repo.HeatmapRepo,
+	cursors repo.SyncCursorRepo,
+	tx repo.Transactor,
+	resolution int,
+	batchSize int,
+	settleSeconds int,
+	retentionDays int,
+	intervalSeconds int,
+	leader Leader,
+) *HeatmapWorker {
+	return &HeatmapWorker{
+		logger:        logger,
+		checks:        checks,
+		heatmap:       heatmap,
+		cursors:       cursors,
+		tx:            tx,
+		resolution:    resolution,
+		batchSize:     batchSize,
+		settleSeconds: settleSeconds,
+		retentionDays: retentionDays,
+		interval:      time.Duration(intervalSeconds) * time.Second,
+		leader:        leader,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+func (w *HeatmapWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting heatmap worker",
+		zap.Int("resolution", w.resolution),
+		zap.Int("batch_size", w.batchSize),
+		zap.Duration("interval", w.interval))
+
+	go w.run(ctx)
+}
+
+func (w *HeatmapWorker) Stop() {
+	w.logger.Info("Stopping heatmap worker")
+	close(w.stopChan)
+}
+
+func (w *HeatmapWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.rollup(ctx)
+		}
+	}
+}
+
+func (w *HeatmapWorker) rollup(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic while rolling up check heatmap")
+
+	if !leads(ctx, w.leader) {
+		return
+	}
+
+	if err := w.rollupChecks(ctx); err != nil {
+		w.logger.Error("Failed to roll up checks into heatmap", zap.Error(err))
+	}
+
+	if w.retentionDays > 0 {
+		before := time.Now().UTC().AddDate(0, 0, -w.retentionDays)
+		deleted, err := w.heatmap.DeleteBefore(ctx, before)
+		if err != nil {
+			w.logger.Error("Failed to delete expired heatmap counts", zap.Error(err))
+		} else if deleted > 0 {
+			w.logger.Info("Expired heatmap counts deleted", zap.Int64("rows", deleted))
+		}
+	}
+}
+
+// rollupChecks сводит пачки, пока не дойдет до проверок моложе settleSeconds. Курсор читается с блокировкой
+// в транзакции пачки: без блокировок воркеров реплики сводят пачки по очереди, а не одну и ту же дважды
+func (w *HeatmapWorker) rollupChecks(ctx context.Context) error {
+	total := 0
+	for {
+		n, err := w.rollupBatch(ctx)
+		if err != nil {
+			return err
+		}
+		total += n
+
+		if n < w.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.logger.Debug("Checks rolled up into heatmap", zap.Int("count", total))
+	}
+	return nil
+}
+
+// rollupBatch сводит одну пачку и возвращает число учтенных проверок
+func (w *HeatmapWorker) rollupBatch(ctx context.Context) (int, error) {
+	n := 0
+	err := w.tx.WithinTx(ctx, func(ctx context.Context) error {
+		cursor, err := w.cursors.ReadForUpdate(ctx, heatmapSyncCursor)
+		if err != nil {
+			return err
+		}
+
+		checks, err := w.checks.ReadSince(ctx, cursor, w.settleSeconds, w.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(checks) == 0 {
+			return nil
+		}
+
+		if err := w.heatmap.Add(ctx, w.resolution, w.buckets(checks)); err != nil {
+			return err
+		}
+		last := checks[len(checks)-1]
+		n = len(checks)
+		return w.cursors.Save(ctx, heatmapSyncCursor, entity.SyncCursor{UpdatedAt: last.StoredAt, ID: last.ID})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// buckets считает проверки пачки по ячейкам и часам
+func (w *HeatmapWorker) buckets(checks []*entity.Check) []entity.HeatmapBucket {
+	type key struct {
+		cell int64
+		hour time.Time
+	}
+
+	index := make(map[key]int, len(checks))
+	buckets := make([]entity.HeatmapBucket, 0, len(checks))
+	for _, c := range checks {
+		k := key{
+			cell: h3cell.FromLatLng(c.Latitude, c.Longitude, w.resolution),
+			hour: c.CreatedAt.UTC().Truncate(time.Hour),
+		}
+
+		i, ok := index[k]
+		if !ok {
+			i = len(buckets)
+			index[k] = i
+			buckets = append(buckets, entity.HeatmapBucket{Cell: k.cell, Hour: k.hour})
+		}
+		buckets[i].Checks++
+		if c.HasAlert {
+			buckets[i].Alerts++
+		}
+	}
+
+	return buckets
+}
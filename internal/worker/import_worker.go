@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// ImportSource — внешний источник оповещений: лента CAP, Atom или RSS либо погодный провайдер
+type ImportSource struct {
+	Feed cases.ImportFeed
+	Read func(ctx context.Context) ([]entity.ExternalAlert, error)
+	// Interval — период опроса источника
+	Interval time.Duration
+	// Leader — блокировка источника, чтобы при нескольких репликах его опрашивала одна
+	Leader Leader
+}
+
+// ImportWorker опрашивает внешние источники оповещений и переносит оповещения в зоны.
+// Каждый источник опрашивается со своим периодом в отдельной горутине
+type ImportWorker struct {
+	logger     *zap.Logger
+	incidentUC cases.IncidentUseCase
+	sources    []ImportSource
+	stopChan   chan struct{}
+}
+
+func NewImportWorker(
+	logger *zap.Logger,
+	incidentUC cases.IncidentUseCase,
+	sources []ImportSource,
+) *ImportWorker {
+	return &ImportWorker{
+		logger:     logger,
+		incidentUC: incidentUC,
+		sources:    sources,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+func (w *ImportWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting alert import worker", zap.Int("sources", len(w.sources)))
+
+	for _, source := range w.sources {
+		go w.run(ctx, source)
+	}
+}
+
+func (w *ImportWorker) Stop() {
+	w.logger.Info("Stopping alert import worker")
+	close(w.stopChan)
+}
+
+func (w *ImportWorker) run(ctx context.Context, source ImportSource) {
+	w.importSource(ctx, source)
+
+	ticker := time.NewTicker(source.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.importSource(ctx, source)
+		}
+	}
+}
+
+// importSource при ошибке чтения источника ничего не меняет: зоны источника не завершаются из-за его недоступности
+func (w *ImportWorker) importSource(ctx context.Context, source ImportSource) {
+	feed := zap.String("feed", source.Feed.Key())
+	defer recoverPanic(w.logger, "Panic while importing alerts", feed)
+
+	if !leads(ctx, source.Leader) {
+		return
+	}
+
+	alerts, err := source.Read(ctx)
+	if err != nil {
+		w.logger.Error("Failed to read alerts", feed, zap.Error(err))
+		return
+	}
+
+	result, err := w.incidentUC.ImportAlerts(ctx, source.Feed, alerts)
+	if err != nil {
+		w.logger.Error("Failed to import alerts", feed, zap.Error(err))
+	}
+
+	if result.Changed() || result.Failed > 0 {
+		w.logger.Info("Alerts imported",
+			feed,
+			zap.Int("alerts", len(alerts)),
+			zap.Int("created", result.Created),
+			zap.Int("updated", result.Updated),
+			zap.Int("resolved", result.Resolved),
+			zap.Int("skipped", result.Skipped),
+			zap.Int("failed", result.Failed))
+	}
+}
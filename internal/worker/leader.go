@@ -0,0 +1,14 @@
+package worker
+
+import "context"
+
+// Leader решает, выполнять ли очередной запуск периодической задачи в этой реплике,
+// чтобы при нескольких репликах задача выполнялась одной из них
+type Leader interface {
+	Hold(ctx context.Context) bool
+}
+
+// leads: leader nil — распределенные блокировки выключены, задача выполняется в каждой реплике
+func leads(ctx context.Context, leader Leader) bool {
+	return leader == nil || leader.Hold(ctx)
+}
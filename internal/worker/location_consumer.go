@@ -0,0 +1,216 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/pkg/redis"
+	"go.uber.org/zap"
+)
+
+// сообщения, не подтвержденные дольше этого времени (упавший потребитель), забираются повторно
+const locationClaimIdle = time.Minute
+
+// LocationConsumer читает обновления координат из Redis Stream через группу потребителей
+// и прогоняет их через CheckLocation. Сообщение подтверждается только после обработки,
+// поэтому при падении реплики оно будет обработано повторно
+type LocationConsumer struct {
+	logger      *zap.Logger
+	locationUC  cases.LocationUseCase
+	redis       *redis.Client
+	stream      string
+	group       string
+	consumerID  string
+	concurrency int
+	batchSize   int64
+	stopChan    chan struct{}
+	wg          sync.WaitGroup
+}
+
+func NewLocationConsumer(
+	logger *zap.Logger,
+	locationUC cases.LocationUseCase,
+	redis *redis.Client,
+	stream string,
+	group string,
+	concurrency int,
+	batchSize int,
+) *LocationConsumer {
+	return &LocationConsumer{
+		logger:      logger,
+		locationUC:  locationUC,
+		redis:       redis,
+		stream:      stream,
+		group:       group,
+		consumerID:  newWorkerID(),
+		concurrency: concurrency,
+		batchSize:   int64(batchSize),
+		stopChan:    make(chan struct{}),
+	}
+}
+
+func (c *LocationConsumer) Start(ctx context.Context) error {
+	if err := c.redis.EnsureGroup(ctx, c.stream, c.group); err != nil {
+		return err
+	}
+
+	c.logger.Info("Starting location stream consumer",
+		zap.String("stream", c.stream),
+		zap.String("group", c.group),
+		zap.Int("concurrency", c.concurrency))
+
+	for i := 0; i < c.concurrency; i++ {
+		c.wg.Add(1)
+		go c.consume(ctx, fmt.Sprintf("%s-%d", c.consumerID, i))
+	}
+
+	return nil
+}
+
+// Stop дожидается, пока потребители закончат текущие пачки
+func (c *LocationConsumer) Stop() {
+	c.logger.Info("Stopping location stream consumer")
+	close(c.stopChan)
+	c.wg.Wait()
+}
+
+func (c *LocationConsumer) consume(ctx context.Context, consumer string) {
+	defer c.wg.Done()
+
+	lastClaim := time.Time{}
+	for {
+		select {
+		case <-c.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if time.Since(lastClaim) >= locationClaimIdle {
+			lastClaim = time.Now()
+			stale, err := c.redis.ClaimStale(ctx, c.stream, c.group, consumer, locationClaimIdle, c.batchSize)
+			if err != nil {
+				c.logger.Error("Failed to claim stale location messages", zap.Error(err))
+			}
+			c.handle(ctx, stale)
+		}
+
+		messages, err := c.redis.ReadGroup(ctx, c.stream, c.group, consumer, c.batchSize, 5*time.Second)
+		if err != nil {
+			c.logger.Error("Failed to read location stream", zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		c.handle(ctx, messages)
+	}
+}
+
+// handle проверяет всю пачку сообщений одним вызовом CheckLocations: проверки пишутся одной командой COPY.
+// Если пачку записать не удалось, ни одно сообщение не подтверждается и пачка будет забрана повторно
+func (c *LocationConsumer) handle(ctx context.Context, messages []redis.StreamMessage) {
+	// после паники пачка остается неподтвержденной и будет забрана повторно
+	defer recoverPanic(c.logger, "Panic while handling location messages", zap.Int("messages", len(messages)))
+
+	if len(messages) == 0 {
+		return
+	}
+
+	queries := make([]cases.LocationCheckQuery, 0, len(messages))
+	valid := make([]redis.StreamMessage, 0, len(messages))
+	for _, msg := range messages {
+		query, err := parseLocationMessage(msg.Values)
+		if err != nil {
+			// некорректное сообщение повторная обработка не исправит — подтверждаем и пропускаем
+			c.skip(msg, err)
+			c.ack(ctx, msg)
+			continue
+		}
+		queries = append(queries, query)
+		valid = append(valid, msg)
+	}
+	if len(queries) == 0 {
+		return
+	}
+
+	items, err := c.locationUC.CheckLocations(ctx, queries)
+	if err != nil {
+		// без ack сообщения останутся в pending и будут забраны повторно
+		c.logger.Error("Failed to process location updates",
+			zap.Error(err),
+			zap.Int("messages", len(valid)))
+		return
+	}
+
+	for i, msg := range valid {
+		if items[i].Err != nil {
+			c.skip(msg, items[i].Err)
+		}
+		c.ack(ctx, msg)
+	}
+}
+
+func (c *LocationConsumer) skip(msg redis.StreamMessage, err error) {
+	c.logger.Warn("Skipping invalid location update",
+		zap.Error(err),
+		zap.String("message_id", msg.ID))
+}
+
+func (c *LocationConsumer) ack(ctx context.Context, msg redis.StreamMessage) {
+	if err := c.redis.Ack(ctx, c.stream, c.group, msg.ID); err != nil {
+		c.logger.Error("Failed to ack location update",
+			zap.Error(err),
+			zap.String("message_id", msg.ID))
+	}
+}
+
+// parseLocationMessage ожидает поля user_id, latitude, longitude
+func parseLocationMessage(values map[string]interface{}) (cases.LocationCheckQuery, error) {
+	userID, _ := values["user_id"].(string)
+	if userID == "" {
+		return cases.LocationCheckQuery{}, entity.ErrUserIDRequired
+	}
+
+	latStr, _ := values["latitude"].(string)
+	lngStr, _ := values["longitude"].(string)
+
+	lat, latErr := strconv.ParseFloat(latStr, 64)
+	lng, lngErr := strconv.ParseFloat(lngStr, 64)
+	if latErr != nil || lngErr != nil {
+		return cases.LocationCheckQuery{}, entity.ErrInvalidCoordinates
+	}
+
+	query := cases.LocationCheckQuery{UserID: userID, Latitude: lat, Longitude: lng}
+
+	// accuracy_m, altitude, speed_mps и heading_deg необязательны
+	if accStr, ok := values["accuracy_m"].(string); ok {
+		acc, err := strconv.ParseFloat(accStr, 64)
+		if err != nil {
+			return cases.LocationCheckQuery{}, entity.ErrInvalidAccuracy
+		}
+		query.AccuracyM = acc
+	}
+	query.Altitude = parseOptionalFloat(values, "altitude")
+	query.SpeedMps = parseOptionalFloat(values, "speed_mps")
+	query.HeadingDeg = parseOptionalFloat(values, "heading_deg")
+
+	return query, nil
+}
+
+func parseOptionalFloat(values map[string]interface{}, key string) *float64 {
+	str, ok := values[key].(string)
+	if !ok {
+		return nil
+	}
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
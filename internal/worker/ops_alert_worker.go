@@ -0,0 +1,203 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/port/ops"
+	"go.uber.org/zap"
+)
+
+// RateCounter — число операций и ошибок с предыдущего вызова Take
+type RateCounter interface {
+	Take() (total, failed int64)
+}
+
+// OpsAlertThresholds — пороги оповещений дежурных; 0 — порог не проверяется
+type OpsAlertThresholds struct {
+	// MinSamples — меньше операций за интервал недостаточно, чтобы судить о доле ошибок
+	MinSamples            int
+	WebhookFailurePercent int
+	CacheErrorPercent     int
+	QueueDepth            int
+	// WebhookSLO — сообщать о получателях, нарушающих цель задержки доставки (WEBHOOK_SLO_*)
+	WebhookSLO bool
+}
+
+// OpsAlertWorker раз в интервал сравнивает состояние сервиса с порогами и сообщает дежурным о проблеме
+// и о ее исчезновении. Доли ошибок считаются по этой реплике, поэтому проверяются в каждой; очередь
+// и задержка доставки общие, их проверяет реплика, владеющая depthLeader
+type OpsAlertWorker struct {
+	logger      *zap.Logger
+	notifier    ops.Notifier
+	stats       cases.StatsUseCase
+	endpoints   cases.WebhookEndpointUseCase
+	webhooks    RateCounter
+	cache       RateCounter
+	thresholds  OpsAlertThresholds
+	interval    time.Duration
+	cooldown    time.Duration
+	depthLeader Leader
+	instance    string
+	// firing — проблемы, о которых сообщено, и время последнего сообщения
+	firing   map[string]time.Time
+	stopChan chan struct{}
+}
+
+func NewOpsAlertWorker(
+	logger *zap.Logger,
+	notifier ops.Notifier,
+	stats cases.StatsUseCase,
+	endpoints cases.WebhookEndpointUseCase,
+	webhooks RateCounter,
+	cache RateCounter,
+	thresholds OpsAlertThresholds,
+	intervalSeconds int,
+	cooldownMinutes int,
+	depthLeader Leader,
+) *OpsAlertWorker {
+	instance, _ := os.Hostname()
+	return &OpsAlertWorker{
+		logger:      logger,
+		notifier:    notifier,
+		stats:       stats,
+		endpoints:   endpoints,
+		webhooks:    webhooks,
+		cache:       cache,
+		thresholds:  thresholds,
+		interval:    time.Duration(intervalSeconds) * time.Second,
+		cooldown:    time.Duration(cooldownMinutes) * time.Minute,
+		depthLeader: depthLeader,
+		instance:    instance,
+		firing:      make(map[string]time.Time),
+		stopChan:    make(chan struct{}),
+	}
+}
+
+func (w *OpsAlertWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting ops alert worker",
+		zap.Duration("interval", w.interval),
+		zap.Duration("cooldown", w.cooldown))
+
+	go w.run(ctx)
+}
+
+func (w *OpsAlertWorker) Stop() {
+	w.logger.Info("Stopping ops alert worker")
+	close(w.stopChan)
+}
+
+func (w *OpsAlertWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.evaluate(ctx)
+		}
+	}
+}
+
+func (w *OpsAlertWorker) evaluate(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic while evaluating ops alerts")
+
+	total, failed := w.webhooks.Take()
+	w.checkRate(ctx, "webhook_failure_rate", "webhook delivery failure rate", total, failed, w.thresholds.WebhookFailurePercent)
+
+	total, failed = w.cache.Take()
+	w.checkRate(ctx, "cache_error_rate", "cache error rate", total, failed, w.thresholds.CacheErrorPercent)
+
+	if (w.thresholds.QueueDepth > 0 || w.thresholds.WebhookSLO) && leads(ctx, w.depthLeader) {
+		if w.thresholds.QueueDepth > 0 {
+			w.checkQueue(ctx)
+		}
+		if w.thresholds.WebhookSLO {
+			w.checkSLO(ctx)
+		}
+	}
+}
+
+// checkRate: при слишком малом числе операций состояние проблемы не меняется
+func (w *OpsAlertWorker) checkRate(ctx context.Context, key, name string, total, failed int64, thresholdPercent int) {
+	if thresholdPercent <= 0 || total == 0 || total < int64(w.thresholds.MinSamples) {
+		return
+	}
+
+	percent := float64(failed) * 100 / float64(total)
+	text := fmt.Sprintf("%s is %.1f%% (%d of %d in the last %s, threshold %d%%)",
+		name, percent, failed, total, w.interval, thresholdPercent)
+	w.check(ctx, key, percent >= float64(thresholdPercent), text)
+}
+
+func (w *OpsAlertWorker) checkQueue(ctx context.Context) {
+	status, err := w.stats.GetQueueStatus(ctx)
+	if err != nil {
+		w.logger.Error("Failed to read queue status for ops alerts", zap.Error(err))
+		return
+	}
+
+	pending := status.Outbox.Pending + status.Outbox.Processing
+	text := fmt.Sprintf("%d undelivered webhooks (threshold %d), oldest is %s old, poller lag %s",
+		pending, w.thresholds.QueueDepth,
+		status.OldestPendingAge.Round(time.Second), status.PollerLag.Round(time.Second))
+	w.check(ctx, "queue_depth", pending >= w.thresholds.QueueDepth, text)
+}
+
+// checkSLO сообщает о каждом получателе отдельно; о получателе, удаленном во время нарушения,
+// сообщение об исчезновении не приходит
+func (w *OpsAlertWorker) checkSLO(ctx context.Context) {
+	slos, err := w.endpoints.EndpointSLOs(ctx)
+	if err != nil {
+		w.logger.Error("Failed to read webhook delivery latency for ops alerts", zap.Error(err))
+		return
+	}
+
+	for _, slo := range slos {
+		text := fmt.Sprintf("webhook endpoint %d (%s) p%d delivery latency is %s (target %s, %d deliveries in the last %s)",
+			slo.EndpointID, slo.URL, slo.Percentile,
+			slo.Latency.Percentile(slo.Percentile).Round(time.Millisecond), slo.Target,
+			slo.Latency.Delivered, slo.Window)
+		w.check(ctx, fmt.Sprintf("webhook_slo:%d", slo.EndpointID), slo.Breached, text)
+	}
+}
+
+// check сообщает о проблеме не чаще раза в cooldown и один раз — о ее исчезновении.
+// Если сообщение не ушло, оно повторяется на следующем интервале
+func (w *OpsAlertWorker) check(ctx context.Context, key string, firing bool, text string) {
+	last, fired := w.firing[key]
+
+	var msg string
+	switch {
+	case firing && fired && time.Since(last) < w.cooldown:
+		return
+	case firing:
+		msg = fmt.Sprintf("[geonotify-service %s] ALERT: %s", w.instance, text)
+	case fired:
+		msg = fmt.Sprintf("[geonotify-service %s] RESOLVED: %s", w.instance, text)
+	default:
+		return
+	}
+
+	if err := w.notifier.Notify(ctx, msg); err != nil {
+		w.logger.Error("Failed to send ops alert",
+			zap.Error(err),
+			zap.String("alert", key),
+			zap.String("text", text))
+		return
+	}
+	w.logger.Warn("Ops alert sent", zap.String("alert", key), zap.Bool("firing", firing), zap.String("text", text))
+
+	if firing {
+		w.firing[key] = time.Now()
+	} else {
+		delete(w.firing, key)
+	}
+}
@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"go.uber.org/zap"
+)
+
+type PartitionWorker struct {
+	logger        *zap.Logger
+	checkRepo     repo.CheckRepo
+	premakeDays   int
+	retentionDays int
+	interval      time.Duration
+	leader        Leader
+	stopChan      chan struct{}
+}
+
+func NewPartitionWorker(
+	logger *zap.Logger,
+	checkRepo repo.CheckRepo,
+	premakeDays int,
+	retentionDays int,
+	intervalMinutes int,
+	leader Leader,
+) *PartitionWorker {
+	return &PartitionWorker{
+		logger:        logger,
+		checkRepo:     checkRepo,
+		premakeDays:   premakeDays,
+		retentionDays: retentionDays,
+		interval:      time.Duration(intervalMinutes) * time.Minute,
+		leader:        leader,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+func (w *PartitionWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting partition maintenance worker",
+		zap.Int("premake_days", w.premakeDays),
+		zap.Int("retention_days", w.retentionDays))
+
+	go w.run(ctx)
+}
+
+func (w *PartitionWorker) Stop() {
+	w.logger.Info("Stopping partition maintenance worker")
+	close(w.stopChan)
+}
+
+func (w *PartitionWorker) run(ctx context.Context) {
+	w.maintain(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.maintain(ctx)
+		}
+	}
+}
+
+func (w *PartitionWorker) maintain(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic during partition maintenance")
+
+	if !leads(ctx, w.leader) {
+		return
+	}
+
+	today := time.Now().UTC()
+
+	for i := 0; i <= w.premakeDays; i++ {
+		day := today.AddDate(0, 0, i)
+
+		created, err := w.checkRepo.CreateDailyPartition(ctx, day)
+		if err != nil {
+			w.logger.Error("Failed to create checks partition",
+				zap.Error(err),
+				zap.String("day", day.Format(time.DateOnly)))
+			continue
+		}
+
+		if created {
+			w.logger.Info("Checks partition created",
+				zap.String("day", day.Format(time.DateOnly)))
+		}
+	}
+
+	// retention_days = 0 означает бессрочное хранение
+	if w.retentionDays <= 0 {
+		return
+	}
+
+	before := today.AddDate(0, 0, -w.retentionDays)
+	dropped, err := w.checkRepo.DropPartitionsBefore(ctx, before)
+	if err != nil {
+		w.logger.Error("Failed to drop old checks partitions", zap.Error(err))
+	}
+
+	for _, name := range dropped {
+		w.logger.Info("Checks partition dropped", zap.String("partition", name))
+	}
+}
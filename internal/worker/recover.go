@@ -0,0 +1,12 @@
+package worker
+
+import "go.uber.org/zap"
+
+// recoverPanic перехватывает панику в горутине воркера, чтобы одна задача или сообщение
+// не остановили весь процесс: паника пишется в лог со стеком, воркер продолжает работу.
+// Вызывается только через defer
+func recoverPanic(logger *zap.Logger, msg string, fields ...zap.Field) {
+	if rec := recover(); rec != nil {
+		logger.Error(msg, append(fields, zap.Any("panic", rec), zap.Stack("stack"))...)
+	}
+}
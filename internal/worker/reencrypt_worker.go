@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"go.uber.org/zap"
+)
+
+// checksPerReencryption — проверок в одной транзакции: блокировки держатся недолго, а запуск
+// продолжается пачками, пока перешифровывать нечего
+const checksPerReencryption = 500
+
+// ReencryptWorker шифрует активным ключом открытые проверки и проверки под прежними ключами:
+// после смены ключа старый можно убрать из конфигурации, когда задача перестанет находить проверки
+type ReencryptWorker struct {
+	logger    *zap.Logger
+	checkRepo repo.CheckRepo
+	tx        repo.Transactor
+	interval  time.Duration
+	leader    Leader
+	stopChan  chan struct{}
+}
+
+func NewReencryptWorker(
+	logger *zap.Logger,
+	checkRepo repo.CheckRepo,
+	tx repo.Transactor,
+	intervalSeconds int,
+	leader Leader,
+) *ReencryptWorker {
+	return &ReencryptWorker{
+		logger:    logger,
+		checkRepo: checkRepo,
+		tx:        tx,
+		interval:  time.Duration(intervalSeconds) * time.Second,
+		leader:    leader,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+func (w *ReencryptWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting check re-encryption worker", zap.Duration("interval", w.interval))
+
+	go w.run(ctx)
+}
+
+func (w *ReencryptWorker) Stop() {
+	w.logger.Info("Stopping check re-encryption worker")
+	close(w.stopChan)
+}
+
+func (w *ReencryptWorker) run(ctx context.Context) {
+	w.reencrypt(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.reencrypt(ctx)
+		}
+	}
+}
+
+func (w *ReencryptWorker) reencrypt(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic during check re-encryption")
+
+	if !leads(ctx, w.leader) {
+		return
+	}
+
+	total := 0
+	defer func() {
+		if total > 0 {
+			w.logger.Info("Checks re-encrypted", zap.Int("checks", total))
+		}
+	}()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		var reencrypted int
+		err := w.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			reencrypted, err = w.checkRepo.Reencrypt(ctx, checksPerReencryption)
+			return err
+		})
+		if err != nil {
+			w.logger.Error("Failed to re-encrypt checks", zap.Error(err))
+			return
+		}
+
+		total += reencrypted
+		if reencrypted < checksPerReencryption {
+			return
+		}
+	}
+}
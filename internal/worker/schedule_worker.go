@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"go.uber.org/zap"
+)
+
+// ScheduleWorker включает и выключает инциденты по их cron-расписанию
+// и деактивирует инциденты с истекшим expires_at
+type ScheduleWorker struct {
+	logger     *zap.Logger
+	incidentUC cases.IncidentUseCase
+	interval   time.Duration
+	leader     Leader
+	stopChan   chan struct{}
+}
+
+func NewScheduleWorker(
+	logger *zap.Logger,
+	incidentUC cases.IncidentUseCase,
+	intervalSeconds int,
+	leader Leader,
+) *ScheduleWorker {
+	return &ScheduleWorker{
+		logger:     logger,
+		incidentUC: incidentUC,
+		interval:   time.Duration(intervalSeconds) * time.Second,
+		leader:     leader,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+func (w *ScheduleWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting incident schedule worker", zap.Duration("interval", w.interval))
+
+	go w.run(ctx)
+}
+
+func (w *ScheduleWorker) Stop() {
+	w.logger.Info("Stopping incident schedule worker")
+	close(w.stopChan)
+}
+
+func (w *ScheduleWorker) run(ctx context.Context) {
+	w.apply(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.apply(ctx)
+		}
+	}
+}
+
+func (w *ScheduleWorker) apply(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic while applying incident schedules")
+
+	if !leads(ctx, w.leader) {
+		return
+	}
+
+	changed, err := w.incidentUC.ApplySchedules(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to apply incident schedules", zap.Error(err))
+	}
+
+	if changed > 0 {
+		w.logger.Info("Incident schedules applied", zap.Int("changed", changed))
+	}
+
+	expired, err := w.incidentUC.ExpireIncidents(ctx)
+	if err != nil {
+		w.logger.Error("Failed to expire incidents", zap.Error(err))
+	}
+
+	if expired > 0 {
+		w.logger.Info("Expired incidents deactivated", zap.Int("expired", expired))
+	}
+}
@@ -0,0 +1,191 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/repo"
+	"github.com/4otis/geonotify-service/internal/port/search"
+	"go.uber.org/zap"
+)
+
+const (
+	incidentsSyncCursor = "search:incidents"
+	checksSyncCursor    = "search:checks"
+)
+
+// SearchIndexWorker выгружает изменения зон и новые проверки координат в поисковый индекс. Позиции
+// выгрузки хранятся в БД и сдвигаются только после записи пачки: при сбое пачка отправляется повторно.
+// Строки моложе settleSeconds не выгружаются — к этому времени параллельные транзакции с более ранним
+// временем изменения должны завершиться, иначе курсор прошел бы мимо них
+type SearchIndexWorker struct {
+	logger        *zap.Logger
+	indexer       search.Indexer
+	incidents     repo.IncidentRepo
+	checks        repo.CheckRepo
+	cursors       repo.SyncCursorRepo
+	batchSize     int
+	settleSeconds int
+	interval      time.Duration
+	leader        Leader
+	// templatesReady — шаблоны индексов созданы; до этого документы не пишутся, иначе индексы
+	// создались бы с маппингом по умолчанию
+	templatesReady bool
+	stopChan       chan struct{}
+}
+
+func NewSearchIndexWorker(
+	logger *zap.Logger,
+	indexer search.Indexer,
+	incidents repo.IncidentRepo,
+	checks repo.CheckRepo,
+	cursors repo.SyncCursorRepo,
+	batchSize int,
+	settleSeconds int,
+	intervalSeconds int,
+	leader Leader,
+) *SearchIndexWorker {
+	return &SearchIndexWorker{
+		logger:        logger,
+		indexer:       indexer,
+		incidents:     incidents,
+		checks:        checks,
+		cursors:       cursors,
+		batchSize:     batchSize,
+		settleSeconds: settleSeconds,
+		interval:      time.Duration(intervalSeconds) * time.Second,
+		leader:        leader,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+func (w *SearchIndexWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting search index worker",
+		zap.Int("batch_size", w.batchSize),
+		zap.Int("settle_seconds", w.settleSeconds),
+		zap.Duration("interval", w.interval))
+
+	go w.run(ctx)
+}
+
+func (w *SearchIndexWorker) Stop() {
+	w.logger.Info("Stopping search index worker")
+	close(w.stopChan)
+}
+
+func (w *SearchIndexWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.sync(ctx)
+		}
+	}
+}
+
+func (w *SearchIndexWorker) sync(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic while syncing search index")
+
+	if !leads(ctx, w.leader) {
+		return
+	}
+
+	if !w.templatesReady {
+		if err := w.indexer.EnsureTemplates(ctx); err != nil {
+			w.logger.Error("Failed to put search index templates", zap.Error(err))
+			return
+		}
+		w.templatesReady = true
+	}
+
+	if err := w.syncIncidents(ctx); err != nil {
+		w.logger.Error("Failed to sync incidents to search index", zap.Error(err))
+	}
+	if err := w.syncChecks(ctx); err != nil {
+		w.logger.Error("Failed to sync checks to search index", zap.Error(err))
+	}
+}
+
+// syncIncidents выгружает пачки, пока не дойдет до изменений моложе settleSeconds
+func (w *SearchIndexWorker) syncIncidents(ctx context.Context) error {
+	cursor, err := w.cursors.Read(ctx, incidentsSyncCursor)
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for {
+		changes, err := w.incidents.ReadChanged(ctx, cursor, w.settleSeconds, w.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			break
+		}
+
+		if err := w.indexer.IndexIncidents(ctx, changes); err != nil {
+			return err
+		}
+
+		last := changes[len(changes)-1].Incident
+		cursor = entity.SyncCursor{UpdatedAt: last.UpdatedAt, ID: last.ID}
+		if err := w.cursors.Save(ctx, incidentsSyncCursor, cursor); err != nil {
+			return err
+		}
+		total += len(changes)
+
+		if len(changes) < w.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.logger.Debug("Incidents synced to search index", zap.Int("count", total))
+	}
+	return nil
+}
+
+func (w *SearchIndexWorker) syncChecks(ctx context.Context) error {
+	cursor, err := w.cursors.Read(ctx, checksSyncCursor)
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for {
+		checks, err := w.checks.ReadSince(ctx, cursor, w.settleSeconds, w.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(checks) == 0 {
+			break
+		}
+
+		if err := w.indexer.IndexChecks(ctx, checks); err != nil {
+			return err
+		}
+
+		last := checks[len(checks)-1]
+		cursor = entity.SyncCursor{UpdatedAt: last.StoredAt, ID: last.ID}
+		if err := w.cursors.Save(ctx, checksSyncCursor, cursor); err != nil {
+			return err
+		}
+		total += len(checks)
+
+		if len(checks) < w.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.logger.Debug("Checks synced to search index", zap.Int("count", total))
+	}
+	return nil
+}
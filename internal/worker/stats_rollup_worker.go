@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"go.uber.org/zap"
+)
+
+// StatsRollupWorker сводит проверки в поминутные счетчики, по которым отвечают эндпоинты статистики.
+// Минуты, в которых еще могут появиться проверки моложе settle, ждут следующего запуска. Счетчики
+// старше retentionDays удаляются вместе с партициями проверок, 0 — хранятся бессрочно
+type StatsRollupWorker struct {
+	logger        *zap.Logger
+	stats         cases.StatsUseCase
+	settle        time.Duration
+	retentionDays int
+	interval      time.Duration
+	leader        Leader
+	stopChan      chan struct{}
+}
+
+func NewStatsRollupWorker(
+	logger *zap.Logger,
+	stats cases.StatsUseCase,
+	settleSeconds int,
+	retentionDays int,
+	intervalSeconds int,
+	leader Leader,
+) *StatsRollupWorker {
+	return &StatsRollupWorker{
+		logger:        logger,
+		stats:         stats,
+		settle:        time.Duration(settleSeconds) * time.Second,
+		retentionDays: retentionDays,
+		interval:      time.Duration(intervalSeconds) * time.Second,
+		leader:        leader,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+func (w *StatsRollupWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting stats rollup worker",
+		zap.Duration("settle", w.settle),
+		zap.Duration("interval", w.interval))
+
+	go w.run(ctx)
+}
+
+func (w *StatsRollupWorker) Stop() {
+	w.logger.Info("Stopping stats rollup worker")
+	close(w.stopChan)
+}
+
+func (w *StatsRollupWorker) run(ctx context.Context) {
+	w.rollup(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.rollup(ctx)
+		}
+	}
+}
+
+func (w *StatsRollupWorker) rollup(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic while rolling up check stats")
+
+	if !leads(ctx, w.leader) {
+		return
+	}
+
+	minutes, err := w.stats.RollupStats(ctx, w.settle)
+	if err != nil {
+		w.logger.Error("Failed to roll up check stats", zap.Error(err))
+	}
+	if minutes > 0 {
+		w.logger.Debug("Check stats rolled up", zap.Int("minutes", minutes))
+	}
+
+	if w.retentionDays > 0 {
+		before := time.Now().UTC().AddDate(0, 0, -w.retentionDays)
+		if err := w.stats.DeleteStatsBefore(ctx, before); err != nil {
+			w.logger.Error("Failed to delete expired check stats", zap.Error(err))
+		}
+	}
+}
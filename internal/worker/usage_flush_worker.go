@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/cases"
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// UsageFlushWorker переносит счетчики запросов API-ключей из Redis в БД. Счетчики забираются атомарно,
+// поэтому воркер может работать в нескольких репликах одновременно
+type UsageFlushWorker struct {
+	logger   *zap.Logger
+	usage    cases.UsageUseCase
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+func NewUsageFlushWorker(logger *zap.Logger, usage cases.UsageUseCase, intervalSeconds int) *UsageFlushWorker {
+	return &UsageFlushWorker{
+		logger:   logger,
+		usage:    usage,
+		interval: time.Duration(intervalSeconds) * time.Second,
+		stopChan: make(chan struct{}),
+	}
+}
+
+func (w *UsageFlushWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting usage flush worker", zap.Duration("interval", w.interval))
+
+	go w.run(ctx)
+}
+
+func (w *UsageFlushWorker) Stop() {
+	w.logger.Info("Stopping usage flush worker")
+	close(w.stopChan)
+}
+
+func (w *UsageFlushWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.flush(ctx)
+		}
+	}
+}
+
+func (w *UsageFlushWorker) flush(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic while flushing usage counters")
+
+	requests, err := w.usage.Flush(ctx)
+	if errors.Is(err, entity.ErrDependencyUnavailable) {
+		w.logger.Debug("Redis is unavailable, usage counters are not flushed")
+		return
+	}
+	if err != nil {
+		w.logger.Error("Failed to flush usage counters", zap.Error(err))
+		return
+	}
+
+	if requests > 0 {
+		w.logger.Debug("Usage counters flushed", zap.Int64("requests", requests))
+	}
+}
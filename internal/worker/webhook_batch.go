@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/4otis/geonotify-service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// webhookBatches копит вебхуки получателей с батчингом: пачка уходит, когда набрала BatchSize вебхуков
+// или первый из них прождал BatchWaitMs. Вебхуки пачки остаются под арендой воркера; если процесс
+// остановится, не отправив пачку, их после истечения аренды подберет опрос outbox
+type webhookBatches struct {
+	mu      sync.Mutex
+	pending map[int]*webhookBatch
+}
+
+type webhookBatch struct {
+	endpoint entity.WebhookEndpoint
+	webhooks []*entity.Webhook
+	timer    *time.Timer
+}
+
+// take забирает пачку получателя на отправку, если ее еще не забрали
+func (b *webhookBatches) take(endpointID int, batch *webhookBatch) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pending[endpointID] != batch {
+		return false
+	}
+	delete(b.pending, endpointID)
+	return true
+}
+
+// enqueueBatch добавляет вебхук в пачку получателя и отправляет пачку, если та набралась.
+// Пачка берет настройки получателя на момент первого вебхука
+func (w *WebhookWorker) enqueueBatch(ctx context.Context, endpoint *entity.WebhookEndpoint, wh *entity.Webhook) {
+	w.batches.mu.Lock()
+	batch, ok := w.batches.pending[endpoint.ID]
+	if !ok {
+		batch = &webhookBatch{endpoint: *endpoint}
+		w.batches.pending[endpoint.ID] = batch
+		batch.timer = time.AfterFunc(time.Duration(endpoint.Delivery.BatchWaitMs)*time.Millisecond, func() {
+			if w.batches.take(endpoint.ID, batch) {
+				w.deliverBatch(ctx, batch)
+			}
+		})
+	}
+	batch.webhooks = append(batch.webhooks, wh)
+
+	full := len(batch.webhooks) >= batch.endpoint.Delivery.BatchSize
+	if full {
+		delete(w.batches.pending, endpoint.ID)
+		batch.timer.Stop()
+	}
+	w.batches.mu.Unlock()
+
+	if full {
+		w.deliverBatch(ctx, batch)
+	}
+}
+
+// deliverBatch отправляет пачку одним запросом. Пачка доставляется целиком, а при ошибке
+// у каждого вебхука свой счетчик попыток: повтор попадет в следующую пачку
+func (w *WebhookWorker) deliverBatch(ctx context.Context, batch *webhookBatch) {
+	defer recoverPanic(w.logger, "Panic while delivering webhook batch", zap.Int("endpoint_id", batch.endpoint.ID))
+
+	payloads := make([][]byte, len(batch.webhooks))
+	for i, wh := range batch.webhooks {
+		payloads[i] = wh.Payload
+	}
+
+	result, sendErr := w.sender.SendBatch(ctx, batch.endpoint, payloads)
+	if sendErr != nil {
+		for _, wh := range batch.webhooks {
+			if err := w.handleRetry(ctx, wh, batch.endpoint.Retry, sendErr); err != nil {
+				w.logger.Error("Failed to send webhook",
+					zap.Error(err),
+					zap.Int("webhook_id", wh.ID))
+			}
+		}
+		return
+	}
+
+	for _, wh := range batch.webhooks {
+		if err := w.webhookRepo.MarkAsDelivered(ctx, wh.ID, w.workerID); err != nil {
+			w.logger.Error("Failed to mark batched webhook as delivered",
+				zap.Error(err),
+				zap.Int("webhook_id", wh.ID))
+		}
+	}
+	w.logger.Info("Webhook batch delivered successfully",
+		zap.Int("endpoint_id", batch.endpoint.ID),
+		zap.Int("webhooks", len(batch.webhooks)),
+		zap.Int("status_code", result.StatusCode),
+		zap.Duration("latency", result.Latency))
+}
@@ -1,60 +1,83 @@
 package worker
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"net/http"
+	"os"
+	"sync/atomic"
 	"time"
 
+	"github.com/4otis/geonotify-service/config"
 	"github.com/4otis/geonotify-service/internal/entity"
+	"github.com/4otis/geonotify-service/internal/port/delivery"
 	"github.com/4otis/geonotify-service/internal/port/repo"
-	"github.com/4otis/geonotify-service/pkg/redis"
 	"go.uber.org/zap"
 )
 
 type WebhookWorker struct {
 	logger      *zap.Logger
 	webhookRepo repo.WebhookRepo
-	redis       *redis.Client
-	webhookURL  string
-	maxRetries  int
-	retryDelay  time.Duration
+	endpoints   repo.WebhookEndpointRepo
+	queue       delivery.Queue
+	sender      delivery.WebhookSender
+	settings    *config.Holder
+	pollEvery   time.Duration
+	workerID    string
+	lease       time.Duration
+	dbLeader    Leader
+	batches     webhookBatches
 	stopChan    chan struct{}
+	running     atomic.Bool
 }
 
 func NewWebhookWorker(
 	logger *zap.Logger,
 	webhookRepo repo.WebhookRepo,
-	redis *redis.Client,
-	webhookURL string,
-	maxRetries int,
-	retryDelaySeconds int,
+	endpoints repo.WebhookEndpointRepo,
+	queue delivery.Queue,
+	sender delivery.WebhookSender,
+	settings *config.Holder,
+	pollIntervalSeconds int,
+	leaseSeconds int,
+	dbLeader Leader,
 ) *WebhookWorker {
 	return &WebhookWorker{
 		logger:      logger,
 		webhookRepo: webhookRepo,
-		redis:       redis,
-		webhookURL:  webhookURL,
-		maxRetries:  maxRetries,
-		retryDelay:  time.Duration(retryDelaySeconds) * time.Second,
+		endpoints:   endpoints,
+		queue:       queue,
+		sender:      sender,
+		settings:    settings,
+		pollEvery:   time.Duration(pollIntervalSeconds) * time.Second,
+		workerID:    newWorkerID(),
+		lease:       time.Duration(leaseSeconds) * time.Second,
+		dbLeader:    dbLeader,
+		batches:     webhookBatches{pending: make(map[int]*webhookBatch)},
 		stopChan:    make(chan struct{}),
 	}
 }
 
 func (w *WebhookWorker) Start(ctx context.Context) {
-	w.logger.Info("Starting webhook worker")
+	w.logger.Info("Starting webhook worker", zap.String("worker_id", w.workerID))
 
+	w.running.Store(true)
 	go w.processQueue(ctx)
 	go w.processDB(ctx)
 }
 
 func (w *WebhookWorker) Stop() {
 	w.logger.Info("Stopping webhook worker")
+	w.running.Store(false)
 	close(w.stopChan)
 }
 
+func (w *WebhookWorker) Running() bool {
+	return w.running.Load()
+}
+
 func (w *WebhookWorker) processQueue(ctx context.Context) {
 	w.logger.Info("Starting queue processor")
 
@@ -65,23 +88,33 @@ func (w *WebhookWorker) processQueue(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		default:
-			_, data, err := w.redis.BRPop("webhooks:queue", 5*time.Second)
-			if err != nil {
-				if err != redis.ErrNotFound {
-					w.logger.Error("Failed to pop from queue", zap.Error(err))
-				}
-				continue
-			}
+			w.popTask(ctx)
+		}
+	}
+}
+
+// popTask забирает из очереди одну задачу и доставляет ее в отдельной горутине
+func (w *WebhookWorker) popTask(ctx context.Context) {
+	defer recoverPanic(w.logger, "Panic while reading webhook queue")
 
-			go w.processTask(ctx, data)
+	task, err := w.queue.PopBlocking(ctx, 5*time.Second)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("Failed to pop from queue", zap.Error(err))
 		}
+		return
+	}
+	if task == nil {
+		return
 	}
+
+	go w.processTask(ctx, *task)
 }
 
 func (w *WebhookWorker) processDB(ctx context.Context) {
-	w.logger.Info("Starting DB processor")
+	w.logger.Info("Starting DB processor", zap.Duration("poll_interval", w.pollEvery))
 
-	ticker := time.NewTicker(30 * time.Second)
+	ticker := time.NewTicker(w.pollEvery)
 	defer ticker.Stop()
 
 	for {
@@ -91,54 +124,46 @@ func (w *WebhookWorker) processDB(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			webhooks, err := w.webhookRepo.ReadInProgress(ctx, 10)
+			// захват через ClaimDue и так безопасен, блокировка лишь избавляет БД от одинакового опроса всеми репликами
+			if !leads(ctx, w.dbLeader) {
+				continue
+			}
+
+			webhooks, err := w.webhookRepo.ClaimDue(ctx, 10, w.workerID, w.lease)
 			if err != nil {
-				w.logger.Error("Failed to read in-progress webhooks", zap.Error(err))
+				w.logger.Error("Failed to claim due webhooks", zap.Error(err))
 				continue
 			}
 
 			for _, wh := range webhooks {
-				if wh.ScheduledAt.After(time.Now()) {
-					continue
-				}
-
-				task := map[string]interface{}{
-					"webhook_id": wh.ID,
-					"check_id":   wh.CheckID,
-					"payload":    string(wh.Payload),
-				}
-
-				if err := w.redis.LPush("webhooks:queue", task); err != nil {
-					w.logger.Error("Failed to push webhook to queue",
-						zap.Error(err),
-						zap.Int("webhook_id", wh.ID))
-				}
+				go w.deliver(ctx, wh)
 			}
 		}
 	}
 }
 
-func (w *WebhookWorker) processTask(ctx context.Context, data []byte) {
-	var task map[string]interface{}
-	if err := json.Unmarshal(data, &task); err != nil {
-		w.logger.Error("Failed to unmarshal task", zap.Error(err))
-		return
-	}
+func (w *WebhookWorker) processTask(ctx context.Context, task entity.WebhookTask) {
+	defer recoverPanic(w.logger, "Panic while processing webhook task", zap.Int("webhook_id", task.WebhookID))
 
-	webhookID, ok := task["webhook_id"].(float64)
-	if !ok {
-		w.logger.Error("Invalid webhook_id in task")
-		return
-	}
-
-	wh, err := w.webhookRepo.Read(ctx, int(webhookID))
+	wh, err := w.webhookRepo.Claim(ctx, task.WebhookID, w.workerID, w.lease)
 	if err != nil {
-		w.logger.Error("Failed to get webhook by ID",
+		if errors.Is(err, entity.ErrWebhookNotClaimable) {
+			w.logger.Debug("Webhook already claimed or not due yet",
+				zap.Int("webhook_id", task.WebhookID))
+			return
+		}
+		w.logger.Error("Failed to claim webhook",
 			zap.Error(err),
-			zap.Int("webhook_id", int(webhookID)))
+			zap.Int("webhook_id", task.WebhookID))
 		return
 	}
 
+	w.deliver(ctx, wh)
+}
+
+func (w *WebhookWorker) deliver(ctx context.Context, wh *entity.Webhook) {
+	defer recoverPanic(w.logger, "Panic while delivering webhook", zap.Int("webhook_id", wh.ID))
+
 	if err := w.sendWebhook(ctx, wh); err != nil {
 		w.logger.Error("Failed to send webhook",
 			zap.Error(err),
@@ -147,39 +172,73 @@ func (w *WebhookWorker) processTask(ctx context.Context, data []byte) {
 }
 
 func (w *WebhookWorker) sendWebhook(ctx context.Context, wh *entity.Webhook) error {
-	if err := w.webhookRepo.UpdateState(ctx, wh.ID, "processing", wh.RetryCnt); err != nil {
-		return fmt.Errorf("failed to update state: %w", err)
+	endpoint, err := w.endpointFor(ctx, wh)
+	if err != nil {
+		return err
+	}
+	if endpoint.Delivery.Batched() {
+		w.enqueueBatch(ctx, endpoint, wh)
+		return nil
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", w.webhookURL, bytes.NewReader(wh.Payload))
+	result, err := w.sender.Send(ctx, *endpoint, wh.EventType, wh.Payload)
 	if err != nil {
-		return w.handleRetry(ctx, wh, err)
+		return w.handleRetry(ctx, wh, endpoint.Retry, err)
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return w.handleRetry(ctx, wh, err)
+	if err := w.webhookRepo.MarkAsDelivered(ctx, wh.ID, w.workerID); err != nil {
+		return fmt.Errorf("failed to mark as delivered: %w", err)
 	}
-	defer resp.Body.Close()
+	w.logger.Info("Webhook delivered successfully",
+		zap.Int("webhook_id", wh.ID),
+		zap.Int("endpoint_id", wh.EndpointID),
+		zap.String("event", wh.EventType),
+		zap.Int("status_code", result.StatusCode),
+		zap.Duration("latency", result.Latency))
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		if err := w.webhookRepo.MarkAsDelivered(ctx, wh.ID); err != nil {
-			return fmt.Errorf("failed to mark as delivered: %w", err)
+	return nil
+}
+
+// endpointFor возвращает получателя вебхука. Вебхук без получателя или для выключенного получателя
+// сразу помечается failed: после включения получателя его можно вернуть через replay
+func (w *WebhookWorker) endpointFor(ctx context.Context, wh *entity.Webhook) (*entity.WebhookEndpoint, error) {
+	var reason error
+	if wh.EndpointID == 0 {
+		reason = errors.New("webhook has no endpoint")
+	} else {
+		endpoint, err := w.endpoints.Read(ctx, wh.EndpointID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhook endpoint: %w", err)
 		}
-		w.logger.Info("Webhook delivered successfully",
-			zap.Int("webhook_id", wh.ID),
-			zap.Int("status_code", resp.StatusCode))
-		return nil
+		if endpoint.Enabled {
+			return endpoint, nil
+		}
+		reason = fmt.Errorf("webhook endpoint %d is disabled", endpoint.ID)
+	}
+
+	if err := w.webhookRepo.MarkAsFailed(ctx, wh.ID, w.workerID); err != nil {
+		return nil, fmt.Errorf("failed to mark as failed: %v (original: %w)", err, reason)
 	}
 
-	return w.handleRetry(ctx, wh, fmt.Errorf("HTTP status: %d", resp.StatusCode))
+	return nil, reason
 }
 
-func (w *WebhookWorker) handleRetry(ctx context.Context, wh *entity.Webhook, err error) error {
-	if wh.RetryCnt >= w.maxRetries {
-		if updateErr := w.webhookRepo.UpdateState(ctx, wh.ID, "failed", wh.RetryCnt); updateErr != nil {
+// handleRetry планирует следующую попытку по политике получателя; не заданные в ней
+// значения берутся из настроек сервиса
+func (w *WebhookWorker) handleRetry(ctx context.Context, wh *entity.Webhook, policy entity.WebhookRetryPolicy, err error) error {
+	cfg := w.settings.Get()
+
+	maxRetries := cfg.MaxRetries
+	if policy.MaxRetries != nil {
+		maxRetries = *policy.MaxRetries
+	}
+	retryDelaySeconds := cfg.RetryDelaySeconds
+	if policy.RetryDelaySeconds != nil {
+		retryDelaySeconds = *policy.RetryDelaySeconds
+	}
+
+	if wh.RetryCnt >= maxRetries {
+		if updateErr := w.webhookRepo.MarkAsFailed(ctx, wh.ID, w.workerID); updateErr != nil {
 			return fmt.Errorf("failed to mark as failed: %v (original: %w)", updateErr, err)
 		}
 		w.logger.Error("Webhook failed after max retries",
@@ -189,28 +248,39 @@ func (w *WebhookWorker) handleRetry(ctx context.Context, wh *entity.Webhook, err
 		return fmt.Errorf("max retries exceeded: %w", err)
 	}
 
+	// следующая попытка планируется в outbox, ее подберет опрос БД;
+	// отложенная задача в очереди лишь позволяет не ждать следующего опроса
 	newRetryCount := wh.RetryCnt + 1
-	if updateErr := w.webhookRepo.UpdateState(ctx, wh.ID, "in progress", newRetryCount); updateErr != nil {
-		return fmt.Errorf("failed to update retry count: %v (original: %w)", updateErr, err)
-	}
-
-	retryTask := map[string]interface{}{
-		"webhook_id": wh.ID,
-		"check_id":   wh.CheckID,
-		"payload":    string(wh.Payload),
+	delay := policy.Delay(time.Duration(retryDelaySeconds)*time.Second, newRetryCount)
+	if updateErr := w.webhookRepo.ScheduleRetry(ctx, wh.ID, w.workerID, newRetryCount, delay); updateErr != nil {
+		return fmt.Errorf("failed to schedule retry: %v (original: %w)", updateErr, err)
 	}
 
-	time.Sleep(w.retryDelay)
-	if pushErr := w.redis.LPush("webhooks:queue", retryTask); pushErr != nil {
-		w.logger.Error("Failed to schedule retry",
-			zap.Error(pushErr),
+	task := entity.WebhookTask{WebhookID: wh.ID, CheckID: wh.CheckID}
+	if queueErr := w.queue.Schedule(ctx, task, time.Now().Add(delay)); queueErr != nil {
+		w.logger.Warn("Failed to schedule retry in queue",
+			zap.Error(queueErr),
 			zap.Int("webhook_id", wh.ID))
 	}
 
 	w.logger.Info("Webhook scheduled for retry",
 		zap.Int("webhook_id", wh.ID),
 		zap.Int("retry_count", newRetryCount),
+		zap.Duration("delay", delay),
 		zap.Error(err))
 
 	return err
 }
+
+// newWorkerID идентифицирует экземпляр воркера в аренде вебхука (claimed_by)
+func newWorkerID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix))
+}
@@ -0,0 +1,70 @@
+-- +goose Up
+-- +goose StatementBegin
+-- у партиционированной таблицы первичный ключ обязан включать ключ партиционирования,
+-- поэтому внешний ключ webhooks -> checks(id) больше не поддерживается
+ALTER TABLE webhooks DROP CONSTRAINT IF EXISTS webhooks_check_id_fkey;
+
+ALTER TABLE checks RENAME TO checks_legacy;
+ALTER INDEX idx_checks_user_id RENAME TO idx_checks_legacy_user_id;
+ALTER INDEX idx_checks_has_alert RENAME TO idx_checks_legacy_has_alert;
+ALTER INDEX idx_checks_created_at RENAME TO idx_checks_legacy_created_at;
+
+CREATE TABLE checks (
+    id SERIAL,
+    user_id VARCHAR(127) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    has_alert BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+-- сюда попадают строки, для которых партиция еще не создана воркером обслуживания
+CREATE TABLE checks_default PARTITION OF checks DEFAULT;
+
+CREATE INDEX idx_checks_user_id ON checks(user_id);
+CREATE INDEX idx_checks_has_alert ON checks(has_alert);
+CREATE INDEX idx_checks_created_at ON checks(created_at);
+
+INSERT INTO checks (id, user_id, latitude, longitude, has_alert, created_at)
+SELECT id, user_id, latitude, longitude, has_alert, COALESCE(created_at, NOW())
+FROM checks_legacy;
+
+SELECT setval(pg_get_serial_sequence('checks', 'id'), COALESCE((SELECT MAX(id) FROM checks), 0) + 1, false);
+
+DROP TABLE checks_legacy;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE checks RENAME TO checks_partitioned;
+ALTER INDEX idx_checks_user_id RENAME TO idx_checks_partitioned_user_id;
+ALTER INDEX idx_checks_has_alert RENAME TO idx_checks_partitioned_has_alert;
+ALTER INDEX idx_checks_created_at RENAME TO idx_checks_partitioned_created_at;
+
+CREATE TABLE checks (
+    id SERIAL PRIMARY KEY,
+    user_id VARCHAR(127) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    has_alert BOOLEAN NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_checks_user_id ON checks(user_id);
+CREATE INDEX idx_checks_has_alert ON checks(has_alert);
+CREATE INDEX idx_checks_created_at ON checks(created_at);
+
+INSERT INTO checks (id, user_id, latitude, longitude, has_alert, created_at)
+SELECT id, user_id, latitude, longitude, has_alert, created_at
+FROM checks_partitioned;
+
+SELECT setval(pg_get_serial_sequence('checks', 'id'), COALESCE((SELECT MAX(id) FROM checks), 0) + 1, false);
+
+DROP TABLE checks_partitioned;
+
+DELETE FROM webhooks WHERE check_id NOT IN (SELECT id FROM checks);
+ALTER TABLE webhooks
+    ADD CONSTRAINT webhooks_check_id_fkey
+    FOREIGN KEY (check_id) REFERENCES checks(id) ON DELETE CASCADE;
+-- +goose StatementEnd
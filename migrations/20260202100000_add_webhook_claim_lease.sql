@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhooks
+    ADD COLUMN claimed_by VARCHAR(127) DEFAULT NULL,
+    ADD COLUMN claimed_until TIMESTAMP DEFAULT NULL;
+
+CREATE INDEX idx_webhooks_claimed_until ON webhooks(claimed_until) WHERE state = 'processing';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_webhooks_claimed_until;
+
+ALTER TABLE webhooks
+    DROP COLUMN claimed_by,
+    DROP COLUMN claimed_until;
+-- +goose StatementEnd
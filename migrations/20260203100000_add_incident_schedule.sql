@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE incidents
+    ADD COLUMN schedule VARCHAR(127) DEFAULT NULL,
+    ADD COLUMN schedule_duration_m INTEGER DEFAULT NULL;
+
+CREATE INDEX idx_incidents_schedule ON incidents(id) WHERE schedule IS NOT NULL AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_incidents_schedule;
+
+ALTER TABLE incidents
+    DROP COLUMN schedule,
+    DROP COLUMN schedule_duration_m;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE incidents
+    ADD COLUMN expires_at TIMESTAMP DEFAULT NULL;
+
+CREATE INDEX idx_incidents_expires_at ON incidents(expires_at) WHERE is_active = true AND expires_at IS NOT NULL AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_incidents_expires_at;
+
+ALTER TABLE incidents
+    DROP COLUMN expires_at;
+-- +goose StatementEnd
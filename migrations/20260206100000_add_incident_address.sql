@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE incidents
+    ADD COLUMN address VARCHAR(511) DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE incidents
+    DROP COLUMN address;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS webhook_queue (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id INT NOT NULL,
+    check_id INT NOT NULL,
+    available_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_queue_available_at ON webhook_queue (available_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS webhook_queue;
+-- +goose StatementEnd
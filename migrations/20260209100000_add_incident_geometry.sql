@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE incidents
+    ADD COLUMN geometry JSONB DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE incidents
+    DROP COLUMN geometry;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS operators (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(127) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) DEFAULT NULL,
+    oidc_subject VARCHAR(255) DEFAULT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+ALTER TABLE incidents
+    ADD COLUMN created_by VARCHAR(255) DEFAULT NULL,
+    ADD COLUMN updated_by VARCHAR(255) DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE incidents
+    DROP COLUMN created_by,
+    DROP COLUMN updated_by;
+
+DROP TABLE IF EXISTS operators;
+-- +goose StatementEnd
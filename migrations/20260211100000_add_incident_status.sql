@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- существующие зоны уже действуют, поэтому считаются опубликованными
+ALTER TABLE incidents
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'published', 'archived')),
+    ADD COLUMN published_by VARCHAR(255) DEFAULT NULL,
+    ADD COLUMN published_at TIMESTAMP DEFAULT NULL;
+
+ALTER TABLE incidents ALTER COLUMN status DROP DEFAULT;
+
+CREATE INDEX idx_incidents_status ON incidents(status) WHERE deleted_at IS NULL;
+
+-- существующие операторы меняли зоны без ограничений и сохраняют право публикации,
+-- новые по умолчанию только готовят черновики
+ALTER TABLE operators
+    ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'publisher'
+        CHECK (role IN ('editor', 'publisher'));
+
+ALTER TABLE operators ALTER COLUMN role SET DEFAULT 'editor';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE operators
+    DROP COLUMN role;
+
+DROP INDEX IF EXISTS idx_incidents_status;
+
+ALTER TABLE incidents
+    DROP COLUMN status,
+    DROP COLUMN published_by,
+    DROP COLUMN published_at;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE incidents
+    ADD COLUMN severity VARCHAR(16) NOT NULL DEFAULT 'medium'
+        CHECK (severity IN ('low', 'medium', 'high', 'critical'));
+
+CREATE TABLE IF NOT EXISTS pending_approvals (
+    id SERIAL PRIMARY KEY,
+    incident_id INTEGER NOT NULL REFERENCES incidents(id),
+    status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected')),
+    requested_by VARCHAR(255) NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    decided_by VARCHAR(255) DEFAULT NULL,
+    decided_at TIMESTAMP DEFAULT NULL,
+    reason TEXT DEFAULT NULL
+);
+
+-- у зоны не больше одной заявки, ожидающей решения
+CREATE UNIQUE INDEX idx_pending_approvals_incident_pending
+    ON pending_approvals(incident_id) WHERE status = 'pending';
+CREATE INDEX idx_pending_approvals_status ON pending_approvals(status, requested_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pending_approvals;
+
+ALTER TABLE incidents
+    DROP COLUMN severity;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- до появления типов событий вебхуки отправлялись только по тревогам проверок
+ALTER TABLE webhooks
+    ADD COLUMN event_type VARCHAR(63) NOT NULL DEFAULT 'location.alert';
+
+ALTER TABLE webhooks ALTER COLUMN event_type DROP DEFAULT;
+
+-- зона, в которой сейчас находится пользователь: по разнице с новой проверкой
+-- определяются события user.entered и user.exited
+CREATE TABLE user_zones (
+    user_id VARCHAR(127) NOT NULL,
+    incident_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    entered_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, incident_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_zones;
+
+ALTER TABLE webhooks
+    DROP COLUMN event_type;
+-- +goose StatementEnd
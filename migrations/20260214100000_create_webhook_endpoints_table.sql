@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE webhook_endpoints (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL DEFAULT '',
+    -- типы событий, на которые подписан получатель; '*' — все события
+    events TEXT[] NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_by VARCHAR(255) DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- вебхук — доставка события одному получателю, у каждой доставки свои попытки.
+-- Вебхуки, созданные до появления получателей, привязываются к получателю из WEBHOOK_URL при старте
+ALTER TABLE webhooks
+    ADD COLUMN endpoint_id INTEGER REFERENCES webhook_endpoints(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_webhooks_endpoint_id ON webhooks(endpoint_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_webhooks_endpoint_id;
+
+ALTER TABLE webhooks
+    DROP COLUMN endpoint_id;
+
+DROP TABLE IF EXISTS webhook_endpoints;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- NULL — значение из настроек сервиса (WEBHOOK_MAX_RETRIES, WEBHOOK_RETRY_DELAY_SECONDS, линейная пауза, таймаут отправителя)
+ALTER TABLE webhook_endpoints
+    ADD COLUMN max_retries INTEGER DEFAULT NULL,
+    ADD COLUMN retry_delay_seconds INTEGER DEFAULT NULL,
+    ADD COLUMN backoff VARCHAR(15) DEFAULT NULL,
+    ADD COLUMN timeout_seconds INTEGER DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhook_endpoints
+    DROP COLUMN max_retries,
+    DROP COLUMN retry_delay_seconds,
+    DROP COLUMN backoff,
+    DROP COLUMN timeout_seconds;
+-- +goose StatementEnd
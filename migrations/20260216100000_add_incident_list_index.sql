@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- порядок списка инцидентов: постраничный курсор идет по этому индексу без OFFSET
+CREATE INDEX idx_incidents_updated_at_id ON incidents(updated_at DESC, id DESC) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_incidents_updated_at_id;
+-- +goose StatementEnd
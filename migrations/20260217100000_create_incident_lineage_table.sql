@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS incident_lineage (
+    parent_id INTEGER NOT NULL REFERENCES incidents(id),
+    child_id INTEGER NOT NULL REFERENCES incidents(id),
+    operation VARCHAR(16) NOT NULL CHECK (operation IN ('merge', 'split')),
+    actor VARCHAR(255) DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (parent_id, child_id)
+);
+
+CREATE INDEX idx_incident_lineage_child ON incident_lineage(child_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS incident_lineage;
+-- +goose StatementEnd
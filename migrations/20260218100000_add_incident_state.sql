@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE incidents
+    ADD COLUMN state VARCHAR(16) NOT NULL DEFAULT 'active'
+        CHECK (state IN ('planned', 'active', 'contained', 'resolved', 'archived')),
+    ADD COLUMN state_changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ADD COLUMN state_planned_at TIMESTAMP DEFAULT NULL,
+    ADD COLUMN state_active_at TIMESTAMP DEFAULT NULL,
+    ADD COLUMN state_contained_at TIMESTAMP DEFAULT NULL,
+    ADD COLUMN state_resolved_at TIMESTAMP DEFAULT NULL,
+    ADD COLUMN state_archived_at TIMESTAMP DEFAULT NULL;
+
+-- выключенные зоны без расписания и зоны с истекшим сроком завершены,
+-- зоны с расписанием остаются действующими и включаются по окнам
+UPDATE incidents
+SET
+    state = CASE
+        WHEN NOT is_active AND (schedule IS NULL OR expires_at <= NOW()) THEN 'resolved'
+        ELSE 'active'
+    END,
+    state_active_at = created_at,
+    state_resolved_at = CASE
+        WHEN NOT is_active AND (schedule IS NULL OR expires_at <= NOW()) THEN updated_at
+    END,
+    state_changed_at = updated_at;
+
+ALTER TABLE incidents ALTER COLUMN state DROP DEFAULT;
+
+DROP INDEX IF EXISTS idx_incidents_expires_at;
+CREATE INDEX idx_incidents_expires_at ON incidents(expires_at)
+    WHERE state IN ('planned', 'active', 'contained') AND expires_at IS NOT NULL AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_incidents_expires_at;
+CREATE INDEX idx_incidents_expires_at ON incidents(expires_at) WHERE is_active = true AND expires_at IS NOT NULL AND deleted_at IS NULL;
+
+ALTER TABLE incidents
+    DROP COLUMN state,
+    DROP COLUMN state_changed_at,
+    DROP COLUMN state_planned_at,
+    DROP COLUMN state_active_at,
+    DROP COLUMN state_contained_at,
+    DROP COLUMN state_resolved_at,
+    DROP COLUMN state_archived_at;
+-- +goose StatementEnd
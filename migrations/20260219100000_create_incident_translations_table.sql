@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS incident_translations (
+    incident_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(127) NOT NULL,
+    descr TEXT,
+    updated_by VARCHAR(255) DEFAULT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (incident_id, locale)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS incident_translations;
+-- +goose StatementEnd
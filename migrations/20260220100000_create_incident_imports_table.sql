@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE incidents
+    ADD COLUMN source VARCHAR(16) NOT NULL DEFAULT 'manual';
+
+-- обработанные оповещения внешних лент: повторный опрос не создает зону заново,
+-- incident_id NULL — оповещение пропущено (вне зоны обслуживания, уже завершено)
+CREATE TABLE IF NOT EXISTS incident_imports (
+    feed VARCHAR(64) NOT NULL,
+    external_id TEXT NOT NULL,
+    incident_id INTEGER REFERENCES incidents(id) ON DELETE CASCADE,
+    sent_at TIMESTAMP NOT NULL,
+    imported_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (feed, external_id)
+);
+
+CREATE INDEX idx_incident_imports_incident_id ON incident_imports(incident_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS incident_imports;
+
+ALTER TABLE incidents DROP COLUMN source;
+-- +goose StatementEnd
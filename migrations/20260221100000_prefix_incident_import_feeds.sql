@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- оповещения хранятся по источнику вместе с его типом ("feed:<имя>", "weather:<провайдер>"),
+-- чтобы лента и погодный провайдер с одинаковым именем не смешивались
+ALTER TABLE incident_imports ALTER COLUMN feed TYPE VARCHAR(80);
+
+UPDATE incident_imports SET feed = 'feed:' || feed;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM incident_imports WHERE feed NOT LIKE 'feed:%';
+
+UPDATE incident_imports SET feed = substr(feed, length('feed:') + 1);
+
+ALTER TABLE incident_imports ALTER COLUMN feed TYPE VARCHAR(64);
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id VARCHAR(127) PRIMARY KEY,
+    min_severity VARCHAR(16) NOT NULL DEFAULT 'low',
+    -- окна тишины [{"start": "22:00", "end": "07:00"}] по времени timezone
+    quiet_hours JSONB NOT NULL DEFAULT '[]',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    channels TEXT[] NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_preferences;
+-- +goose StatementEnd
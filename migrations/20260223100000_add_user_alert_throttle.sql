@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 0 — без ограничения числа алертов
+ALTER TABLE user_preferences
+    ADD COLUMN max_alerts_per_hour INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_preferences
+    DROP COLUMN max_alerts_per_hour;
+-- +goose StatementEnd
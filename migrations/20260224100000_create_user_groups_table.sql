@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- groups — зарезервированное слово PostgreSQL, поэтому таблица называется user_groups
+CREATE TABLE IF NOT EXISTS user_groups (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(127) NOT NULL,
+    descr TEXT,
+    managers TEXT[] NOT NULL DEFAULT '{}',
+    created_by VARCHAR(255) DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS group_members (
+    group_id INTEGER NOT NULL REFERENCES user_groups(id) ON DELETE CASCADE,
+    user_id VARCHAR(127) NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX idx_group_members_user_id ON group_members(user_id);
+
+-- получатель с group_id получает только сводные алерты своей группы
+ALTER TABLE webhook_endpoints
+    ADD COLUMN group_id INTEGER REFERENCES user_groups(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_webhook_endpoints_group_id ON webhook_endpoints(group_id) WHERE group_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_webhook_endpoints_group_id;
+
+ALTER TABLE webhook_endpoints
+    DROP COLUMN group_id;
+
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS user_groups;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- запросы API-ключей по суткам (UTC); счетчики текущих суток живут в Redis и переносятся сюда периодически
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    key_name VARCHAR(64) NOT NULL,
+    day DATE NOT NULL,
+    requests BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (key_name, day)
+);
+
+CREATE INDEX idx_api_key_usage_day ON api_key_usage(day);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS api_key_usage;
+-- +goose StatementEnd
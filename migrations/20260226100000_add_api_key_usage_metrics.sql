@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- проверки координат, найденные в них алерты и созданные ими вебхуки по API-ключам — для выгрузки в биллинг
+ALTER TABLE api_key_usage
+    ADD COLUMN checks BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN alerts BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN webhooks BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE api_key_usage
+    DROP COLUMN webhooks,
+    DROP COLUMN alerts,
+    DROP COLUMN checks;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- позиции выгрузки зон и проверок в поисковый индекс: время изменения и ID последней выгруженной строки
+CREATE TABLE IF NOT EXISTS search_sync_cursors (
+    name VARCHAR(64) PRIMARY KEY,
+    synced_until TIMESTAMP NOT NULL,
+    last_id BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- выгрузка изменений идет от старых к новым и видит удаленные зоны
+CREATE INDEX idx_incidents_updated_at_id_all ON incidents(updated_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_incidents_updated_at_id_all;
+DROP TABLE IF EXISTS search_sync_cursors;
+-- +goose StatementEnd
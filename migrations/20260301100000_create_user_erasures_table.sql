@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- заявки на удаление данных пользователя; не удаляются и служат записью аудита
+CREATE TABLE IF NOT EXISTS user_erasures (
+    id SERIAL PRIMARY KEY,
+    user_id VARCHAR(127) NOT NULL,
+    mode VARCHAR(16) NOT NULL
+        CHECK (mode IN ('delete', 'anonymize')),
+    status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'completed', 'cancelled')),
+    requested_by VARCHAR(255) NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    confirmed_by VARCHAR(255) DEFAULT NULL,
+    confirmed_at TIMESTAMP DEFAULT NULL,
+    completed_at TIMESTAMP DEFAULT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT DEFAULT NULL,
+    erased_checks BIGINT NOT NULL DEFAULT 0,
+    erased_webhooks BIGINT NOT NULL DEFAULT 0,
+    erased_zones BIGINT NOT NULL DEFAULT 0,
+    erased_preferences BIGINT NOT NULL DEFAULT 0,
+    erased_group_memberships BIGINT NOT NULL DEFAULT 0
+);
+
+-- у пользователя не больше одной незавершенной заявки
+CREATE UNIQUE INDEX idx_user_erasures_user_open
+    ON user_erasures(user_id) WHERE status IN ('pending', 'confirmed');
+CREATE INDEX idx_user_erasures_status ON user_erasures(status, requested_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_erasures;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- выгрузки данных пользователя по запросу субъекта; сам архив лежит в объектном хранилище
+CREATE TABLE IF NOT EXISTS user_data_exports (
+    id SERIAL PRIMARY KEY,
+    user_id VARCHAR(127) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'ready', 'expired')),
+    requested_by VARCHAR(255) NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP DEFAULT NULL,
+    expires_at TIMESTAMP DEFAULT NULL,
+    object_key VARCHAR(255) DEFAULT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT DEFAULT NULL
+);
+
+-- у пользователя не больше одной выгрузки в работе
+CREATE UNIQUE INDEX idx_user_data_exports_user_pending
+    ON user_data_exports(user_id) WHERE status = 'pending';
+CREATE INDEX idx_user_data_exports_user ON user_data_exports(user_id, requested_at DESC);
+CREATE INDEX idx_user_data_exports_status ON user_data_exports(status, expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_data_exports;
+-- +goose StatementEnd
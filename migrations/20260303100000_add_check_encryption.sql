@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- key_id: NULL — открытая проверка, ожидающая шифрования; 0 — открытая намеренно (обезличенная);
+-- больше 0 — user_id и точные координаты зашифрованы в sealed этим ключом, в user_id хранится
+-- HMAC-индекс, а в latitude/longitude — координаты, огрубленные до 0.01°
+ALTER TABLE checks
+    ADD COLUMN key_id SMALLINT DEFAULT NULL,
+    ADD COLUMN sealed BYTEA DEFAULT NULL;
+
+-- перешифровка ищет проверки под неактивными ключами и открытые
+CREATE INDEX idx_checks_key_id ON checks(key_id);
+
+UPDATE checks SET key_id = 0 WHERE user_id = 'anonymized';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- у зашифрованных проверок остаются только HMAC-индекс и огрубленные координаты
+DROP INDEX IF EXISTS idx_checks_key_id;
+ALTER TABLE checks
+    DROP COLUMN sealed,
+    DROP COLUMN key_id;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- public — зона видна везде, restricted — предупреждает без названия и описания,
+-- internal — как restricted, а в списках операторов видна только публикаторам
+ALTER TABLE incidents
+    ADD COLUMN visibility VARCHAR(16) NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('public', 'restricted', 'internal'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE incidents DROP COLUMN visibility;
+-- +goose StatementEnd
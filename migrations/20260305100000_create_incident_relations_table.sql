@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS incident_relations (
+    incident_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    related_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('parent', 'supersedes')),
+    created_by VARCHAR(255) DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (incident_id, related_id, type),
+    CHECK (incident_id <> related_id)
+);
+
+CREATE INDEX idx_incident_relations_related_id ON incident_relations(related_id);
+-- у зоны не больше одной родительской
+CREATE UNIQUE INDEX idx_incident_relations_parent ON incident_relations(incident_id) WHERE type = 'parent';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS incident_relations;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS incident_geometry_versions (
+    id BIGSERIAL PRIMARY KEY,
+    incident_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    radius_m DOUBLE PRECISION NOT NULL,
+    geometry JSONB DEFAULT NULL,
+    changed_by VARCHAR(255) DEFAULT NULL,
+    valid_from TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_incident_geometry_versions_incident_id ON incident_geometry_versions(incident_id, valid_from DESC, id DESC);
+
+-- прежняя история форм не хранилась: существующие зоны получают одну версию с текущей формой от момента создания
+INSERT INTO incident_geometry_versions (incident_id, latitude, longitude, radius_m, geometry, changed_by, valid_from)
+SELECT id, latitude, longitude, radius_m, geometry, created_by, COALESCE(created_at, NOW())
+FROM incidents
+ORDER BY id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS incident_geometry_versions;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS incident_activity (
+    id BIGSERIAL PRIMARY KEY,
+    incident_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    -- in_effect — зона опубликована, включена и не удалена; истечение срока проверяется по expires_at
+    in_effect BOOLEAN NOT NULL,
+    expires_at TIMESTAMP DEFAULT NULL,
+    valid_from TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_incident_activity_incident_id ON incident_activity(incident_id, valid_from DESC, id DESC);
+
+-- прежняя история включений не хранилась: опубликованные зоны считаются действующими с момента публикации,
+-- а зоны, которые сейчас не действуют, — выключенными с последнего изменения
+INSERT INTO incident_activity (incident_id, in_effect, expires_at, valid_from)
+SELECT id, TRUE, expires_at, published_at
+FROM incidents
+WHERE published_at IS NOT NULL
+ORDER BY id;
+
+INSERT INTO incident_activity (incident_id, in_effect, expires_at, valid_from)
+SELECT id, FALSE, expires_at, GREATEST(COALESCE(deleted_at, updated_at, created_at, NOW()), COALESCE(published_at, created_at, NOW()))
+FROM incidents
+WHERE NOT (status = 'published' AND is_active AND deleted_at IS NULL) OR published_at IS NULL
+ORDER BY id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS incident_activity;
+-- +goose StatementEnd
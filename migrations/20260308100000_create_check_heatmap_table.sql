@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- почасовые счетчики проверок по H3-ячейкам; resolution — разрешение, с которым ячейки записаны
+CREATE TABLE IF NOT EXISTS check_heatmap (
+    resolution SMALLINT NOT NULL,
+    cell BIGINT NOT NULL,
+    hour TIMESTAMP NOT NULL,
+    checks INTEGER NOT NULL DEFAULT 0,
+    alerts INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (hour, resolution, cell)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS check_heatmap;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- зоны, в которые попала проверка, — для счетчиков по зонам; NULL — проверка без алерта
+ALTER TABLE checks ADD COLUMN incident_ids INTEGER[] DEFAULT NULL;
+
+-- поминутные счетчики проверок; сводятся воркером из checks
+CREATE TABLE IF NOT EXISTS check_stats_minutes (
+    minute TIMESTAMP PRIMARY KEY,
+    checks BIGINT NOT NULL DEFAULT 0,
+    alerts BIGINT NOT NULL DEFAULT 0
+);
+
+-- пользователи, проверявшие координаты в эту минуту: уникальные пользователи за окно не складываются из минут.
+-- user_id — в том виде, в котором он лежит в checks (HMAC-индекс для зашифрованных проверок)
+CREATE TABLE IF NOT EXISTS check_stats_users (
+    minute TIMESTAMP NOT NULL,
+    user_id VARCHAR(127) NOT NULL,
+    PRIMARY KEY (minute, user_id)
+);
+
+CREATE INDEX idx_check_stats_users_user_id ON check_stats_users(user_id);
+
+CREATE TABLE IF NOT EXISTS check_stats_incidents (
+    minute TIMESTAMP NOT NULL,
+    incident_id INTEGER NOT NULL,
+    checks BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (minute, incident_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS check_stats_incidents;
+DROP TABLE IF EXISTS check_stats_users;
+DROP TABLE IF EXISTS check_stats_minutes;
+ALTER TABLE checks DROP COLUMN IF EXISTS incident_ids;
+-- +goose StatementEnd
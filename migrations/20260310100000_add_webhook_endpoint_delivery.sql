@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- batch_size 0 — каждое событие отдельным запросом; иначе события копятся до batch_size штук или batch_wait_ms
+-- и уходят одним NDJSON-запросом. compression NULL — тело без сжатия
+ALTER TABLE webhook_endpoints
+    ADD COLUMN batch_size INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN batch_wait_ms INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN compression VARCHAR(15) DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhook_endpoints
+    DROP COLUMN batch_size,
+    DROP COLUMN batch_wait_ms,
+    DROP COLUMN compression;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- delivered_at — момент успешной доставки; по нему считается задержка доставки получателям.
+-- Вебхуки, доставленные до миграции, в задержку не попадают
+ALTER TABLE webhooks
+    ADD COLUMN delivered_at TIMESTAMP DEFAULT NULL;
+
+CREATE INDEX idx_webhooks_delivered_at ON webhooks(delivered_at, endpoint_id) WHERE delivered_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_webhooks_delivered_at;
+
+ALTER TABLE webhooks
+    DROP COLUMN delivered_at;
+-- +goose StatementEnd
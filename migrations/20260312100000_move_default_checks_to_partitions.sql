@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- миграция партиционирования переносила все старые проверки в checks_default: воркер обслуживания не может
+-- создать партицию дня, строки которого лежат в checks_default, а хранение по сроку их не удаляет.
+-- Строки переносятся в суточные партиции; заодно создаются партиции на окно, которое воркер создает заранее
+-- (CHECKS_PARTITION_PREMAKE_DAYS по умолчанию), чтобы новые проверки не попадали в checks_default до его запуска
+DO $$
+DECLARE
+    partition_day DATE;
+BEGIN
+    CREATE TEMP TABLE checks_default_rows ON COMMIT DROP AS SELECT * FROM checks_default;
+    DELETE FROM checks_default;
+
+    FOR partition_day IN
+        SELECT DISTINCT created_at::date FROM checks_default_rows
+        UNION
+        SELECT generate_series(CURRENT_DATE, CURRENT_DATE + 3, INTERVAL '1 day')::date
+    LOOP
+        EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF checks FOR VALUES FROM (%L) TO (%L)',
+            'checks_p' || to_char(partition_day, 'YYYYMMDD'), partition_day, partition_day + 1);
+    END LOOP;
+
+    INSERT INTO checks SELECT * FROM checks_default_rows;
+END $$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- партиции остаются: вернуть строки в checks_default нельзя, пока существуют партиции их дней
+SELECT 1;
+-- +goose StatementEnd
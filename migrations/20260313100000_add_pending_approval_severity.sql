@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- заявка на повышение опубликованной зоны до critical хранит новую критичность; NULL — заявка на публикацию
+ALTER TABLE pending_approvals
+    ADD COLUMN severity VARCHAR(16) DEFAULT NULL
+        CHECK (severity = 'critical');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pending_approvals
+    DROP COLUMN severity;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- stored_at — момент записи проверки: отложенная запись (CheckBatcher) сохраняет проверку позже created_at.
+-- У проверок, записанных до миграции, stored_at пуст и совпадает с created_at
+ALTER TABLE checks
+    ADD COLUMN stored_at TIMESTAMP;
+ALTER TABLE checks
+    ALTER COLUMN stored_at SET DEFAULT NOW();
+CREATE INDEX idx_checks_stored_at ON checks ((COALESCE(stored_at, created_at)), id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_checks_stored_at;
+ALTER TABLE checks
+    DROP COLUMN stored_at;
+-- +goose StatementEnd
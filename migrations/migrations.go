@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// LatestVersion возвращает версию последней миграции в формате goose
+func LatestVersion() (int64, error) {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var latest int64
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok {
+			continue
+		}
+
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if version > latest {
+			latest = version
+		}
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrStreamClosed — сервер закрыл поток алертов (например, при остановке)
+var ErrStreamClosed = errors.New("alert stream closed by server")
+
+// StreamAlerts подписывается на алерты пользователя (Server-Sent Events) и вызывает fn на каждое событие.
+// Блокирует до отмены ctx, ошибки fn или закрытия потока сервером. Таймаут HTTPClient
+// обрывает поток, поэтому для долгих подписок нужен клиент без таймаута
+func (c *Client) StreamAlerts(ctx context.Context, userID string, fn func(AlertEvent) error) error {
+	req := request{
+		method:       http.MethodGet,
+		path:         "/api/v1/users/" + url.PathEscape(userID) + "/alerts/stream",
+		responseType: "text/event-stream",
+	}
+	resp, err := c.open(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var (
+		event string
+		data  strings.Builder
+	)
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// пустая строка завершает событие
+			if event == "alert" && data.Len() > 0 {
+				var alert AlertEvent
+				if err := json.Unmarshal([]byte(data.String()), &alert); err != nil {
+					return fmt.Errorf("failed to decode alert: %w", err)
+				}
+				if err := fn(alert); err != nil {
+					return err
+				}
+			}
+			event = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// комментарий: приветствие и heartbeat
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return ErrStreamClosed
+}
+
+// UserPreferences возвращает настройки алертов пользователя; не сохранявший их пользователь получает значения по умолчанию
+func (c *Client) UserPreferences(ctx context.Context, userID string) (*UserPreferences, error) {
+	var out UserPreferences
+	if err := c.call(ctx, http.MethodGet, preferencesPath(userID), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetUserPreferences заменяет настройки алертов пользователя целиком; UserID и UpdatedAt в in не учитываются
+func (c *Client) SetUserPreferences(ctx context.Context, userID string, in UserPreferences) (*UserPreferences, error) {
+	in.UserID, in.UpdatedAt = "", nil
+
+	var out UserPreferences
+	if err := c.call(ctx, http.MethodPut, preferencesPath(userID), in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func preferencesPath(userID string) string {
+	return "/api/v1/users/" + url.PathEscape(userID) + "/preferences"
+}
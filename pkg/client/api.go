@@ -0,0 +1,930 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CheckLocation проверяет точку пользователя (POST /api/v1/location/check)
+func (c *Client) CheckLocation(ctx context.Context, in LocationCheckRequest) (*LocationCheckResult, error) {
+	var out LocationCheckResult
+	if err := c.checkLocation(ctx, "/api/v1/location/check", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CheckLocationV2 проверяет точку с расстояниями до зон и прогнозом (POST /api/v2/location/check)
+func (c *Client) CheckLocationV2(ctx context.Context, in LocationCheckRequest) (*LocationCheckResultV2, error) {
+	var out LocationCheckResultV2
+	if err := c.checkLocation(ctx, "/api/v2/location/check", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SimulateLocation сопоставляет точку с зонами без записи проверки (POST /api/v1/location/simulate):
+// то же, что CheckLocationV2 с DryRun
+func (c *Client) SimulateLocation(ctx context.Context, in LocationCheckRequest) (*LocationCheckResultV2, error) {
+	var out LocationCheckResultV2
+	if err := c.checkLocation(ctx, "/api/v1/location/simulate", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CheckLocationBatch проверяет до 1000 точек за запрос (POST /api/v2/location/check/batch);
+// итоги возвращаются в порядке in. ResolveAddress в пачке не поддерживается
+func (c *Client) CheckLocationBatch(ctx context.Context, in []LocationCheckRequest) ([]LocationCheckBatchResult, error) {
+	var out struct {
+		Results []LocationCheckBatchResult `json:"results"`
+	}
+	body := map[string]any{"checks": in}
+	if err := c.call(ctx, http.MethodPost, "/api/v2/location/check/batch", body, &out); err != nil {
+		return nil, err
+	}
+	return out.Results, nil
+}
+
+func (c *Client) checkLocation(ctx context.Context, path string, in LocationCheckRequest, out any) error {
+	req, err := jsonRequest(http.MethodPost, path, in)
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	if in.ResolveAddress {
+		query.Set("resolve_address", "true")
+	}
+	if in.DryRun {
+		query.Set("dry_run", "true")
+	}
+	if in.At != nil {
+		query.Set("at", in.At.UTC().Format(time.RFC3339))
+	}
+	if len(query) > 0 {
+		req.query = query
+	}
+	return c.send(ctx, req, out)
+}
+
+// CreateIncident создает опасную зону и возвращает ее ID
+func (c *Client) CreateIncident(ctx context.Context, in IncidentCreateRequest) (int, error) {
+	req, err := jsonRequest(http.MethodPost, "/api/v1/incidents", in)
+	if err != nil {
+		return 0, err
+	}
+	if in.CheckOverlap != nil {
+		req.query = url.Values{"check_overlap": {strconv.FormatBool(*in.CheckOverlap)}}
+	}
+
+	var out struct {
+		IncidentID int `json:"incident_id"`
+	}
+	if err := c.send(ctx, req, &out); err != nil {
+		return 0, err
+	}
+	return out.IncidentID, nil
+}
+
+func (c *Client) GetIncident(ctx context.Context, id int) (*Incident, error) {
+	var out Incident
+	if err := c.call(ctx, http.MethodGet, incidentPath(id), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListIncidents возвращает страницу инцидентов; нулевые page и limit — значения сервера по умолчанию
+func (c *Client) ListIncidents(ctx context.Context, page, limit int) (*IncidentList, error) {
+	return c.ListIncidentsByStatus(ctx, page, limit, "")
+}
+
+// ListIncidentsByStatus как ListIncidents, но только инциденты в статусе status; пустой — все
+func (c *Client) ListIncidentsByStatus(ctx context.Context, page, limit int, status IncidentStatus) (*IncidentList, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", string(status))
+	}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var out IncidentList
+	req := request{method: http.MethodGet, path: "/api/v1/incidents", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListIncidentsAfter возвращает страницу инцидентов после курсора из NextCursor предыдущей страницы;
+// пустой cursor — первая страница. В отличие от ListIncidents сервер не считает число страниц
+func (c *Client) ListIncidentsAfter(ctx context.Context, cursor string, limit int, status IncidentStatus) (*IncidentList, error) {
+	query := url.Values{}
+	query.Set("cursor", cursor)
+	if status != "" {
+		query.Set("status", string(status))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var out IncidentList
+	req := request{method: http.MethodGet, path: "/api/v1/incidents", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateIncident заменяет данные зоны. Повышение опубликованной зоны до критической сервер не применяет,
+// а заводит заявку: тогда возвращается Approval, а остальные поля уже изменены
+func (c *Client) UpdateIncident(ctx context.Context, id int, in IncidentUpdateRequest) (*Approval, error) {
+	return c.edit(ctx, http.MethodPut, incidentPath(id), in)
+}
+
+// PatchIncident меняет переданные поля зоны; заявка возвращается так же, как в UpdateIncident
+func (c *Client) PatchIncident(ctx context.Context, id int, in IncidentPatchRequest) (*Approval, error) {
+	return c.edit(ctx, http.MethodPatch, incidentPath(id), in)
+}
+
+// edit выполняет правку зоны; ответ 202 несет заявку на одобрение
+func (c *Client) edit(ctx context.Context, method, path string, in any) (*Approval, error) {
+	req, err := jsonRequest(method, path, in)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.open(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, nil
+	}
+
+	var approval Approval
+	if err := json.NewDecoder(resp.Body).Decode(&approval); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &approval, nil
+}
+
+// SetIncidentGeometry заменяет форму зоны GeoJSON-геометрией или Feature
+func (c *Client) SetIncidentGeometry(ctx context.Context, id int, geometry json.RawMessage) error {
+	return c.call(ctx, http.MethodPut, incidentPath(id)+"/geometry", geometry, nil)
+}
+
+func (c *Client) DeleteIncident(ctx context.Context, id int) error {
+	return c.call(ctx, http.MethodDelete, incidentPath(id), nil, nil)
+}
+
+// PublishIncident выпускает черновик или архивную зону и возвращает ее новое состояние.
+// Критическую зону сервер не публикует, а заводит заявку: тогда возвращается только Approval
+func (c *Client) PublishIncident(ctx context.Context, id int) (*Incident, *Approval, error) {
+	resp, err := c.open(ctx, request{method: http.MethodPost, path: incidentPath(id) + "/publish"})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	if resp.StatusCode == http.StatusAccepted {
+		var approval Approval
+		if err := dec.Decode(&approval); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		return nil, &approval, nil
+	}
+
+	var out Incident
+	if err := dec.Decode(&out); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &out, nil, nil
+}
+
+// ArchiveIncident снимает зону с публикации
+func (c *Client) ArchiveIncident(ctx context.Context, id int) (*Incident, error) {
+	var out Incident
+	if err := c.call(ctx, http.MethodPost, incidentPath(id)+"/archive", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TransitionIncident переводит зону в другую стадию жизни опасности и возвращает ее новое состояние
+func (c *Client) TransitionIncident(ctx context.Context, id int, state IncidentState) (*Incident, error) {
+	in := struct {
+		State IncidentState `json:"state"`
+	}{State: state}
+
+	var out Incident
+	if err := c.call(ctx, http.MethodPost, incidentPath(id)+"/state", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// MergeIncidents объединяет опубликованные зоны в новую, исходные переводятся в архив
+func (c *Client) MergeIncidents(ctx context.Context, ids []int, name, descr string) (*Incident, error) {
+	in := struct {
+		IDs   []int  `json:"ids"`
+		Name  string `json:"name"`
+		Descr string `json:"descr,omitempty"`
+	}{IDs: ids, Name: name, Descr: descr}
+
+	var out Incident
+	if err := c.call(ctx, http.MethodPost, "/api/v1/incidents/merge", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SplitIncident заменяет опубликованную зону частями, исходная переводится в архив
+func (c *Client) SplitIncident(ctx context.Context, id int, parts []IncidentPart) ([]Incident, error) {
+	in := struct {
+		Parts []IncidentPart `json:"parts"`
+	}{Parts: parts}
+
+	var out struct {
+		Incidents []Incident `json:"incidents"`
+	}
+	if err := c.call(ctx, http.MethodPost, incidentPath(id)+"/split", in, &out); err != nil {
+		return nil, err
+	}
+	return out.Incidents, nil
+}
+
+// IncidentLineage возвращает историю слияний и разделений, в которых участвовала зона
+func (c *Client) IncidentLineage(ctx context.Context, id int) ([]IncidentLink, error) {
+	var out struct {
+		Links []IncidentLink `json:"links"`
+	}
+	if err := c.call(ctx, http.MethodGet, incidentPath(id)+"/lineage", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Links, nil
+}
+
+// IncidentVersions возвращает версии формы зоны от первой к последней
+func (c *Client) IncidentVersions(ctx context.Context, id int) ([]GeometryVersion, error) {
+	var out struct {
+		Versions []GeometryVersion `json:"versions"`
+	}
+	if err := c.call(ctx, http.MethodGet, incidentPath(id)+"/versions", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Versions, nil
+}
+
+// ListIncidentRelations возвращает связи зоны с ее стороны
+func (c *Client) ListIncidentRelations(ctx context.Context, id int) ([]IncidentRelation, error) {
+	var out struct {
+		Relations []IncidentRelation `json:"relations"`
+	}
+	if err := c.call(ctx, http.MethodGet, incidentPath(id)+"/relations", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Relations, nil
+}
+
+// AddIncidentRelation связывает зону id с relatedID: RelationParent — зона входит в relatedID,
+// RelationSupersedes — зона заменяет relatedID
+func (c *Client) AddIncidentRelation(ctx context.Context, id, relatedID int, typ RelationType) (*IncidentRelation, error) {
+	in := struct {
+		RelatedID int          `json:"related_id"`
+		Type      RelationType `json:"type"`
+	}{RelatedID: relatedID, Type: typ}
+
+	var out IncidentRelation
+	if err := c.call(ctx, http.MethodPost, incidentPath(id)+"/relations", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteIncidentRelation удаляет связь, заданную со стороны зоны id
+func (c *Client) DeleteIncidentRelation(ctx context.Context, id, relatedID int, typ RelationType) error {
+	path := incidentPath(id) + "/relations/" + url.PathEscape(string(typ)) + "/" + strconv.Itoa(relatedID)
+	return c.call(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// BacktestIncident прогоняет проверки координат за последние hours часов через зону (0 — за сутки).
+// radius > 0 подменяет радиус круглой зоны
+func (c *Client) BacktestIncident(ctx context.Context, id, hours int, radius float64) (*IncidentBacktest, error) {
+	in := struct {
+		Hours  int     `json:"hours,omitempty"`
+		Radius float64 `json:"radius_m,omitempty"`
+	}{Hours: hours, Radius: radius}
+
+	var out IncidentBacktest
+	if err := c.call(ctx, http.MethodPost, incidentPath(id)+"/backtest", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListIncidentTranslations возвращает переводы названия и описания зоны
+func (c *Client) ListIncidentTranslations(ctx context.Context, id int) ([]IncidentTranslation, error) {
+	var out struct {
+		Translations []IncidentTranslation `json:"translations"`
+	}
+	if err := c.call(ctx, http.MethodGet, incidentPath(id)+"/translations", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Translations, nil
+}
+
+// SetIncidentTranslation создает или заменяет перевод зоны на язык locale (тег BCP 47: en, pt-BR)
+func (c *Client) SetIncidentTranslation(ctx context.Context, id int, locale, name, descr string) (*IncidentTranslation, error) {
+	in := struct {
+		Name  string `json:"name"`
+		Descr string `json:"descr,omitempty"`
+	}{Name: name, Descr: descr}
+
+	var out IncidentTranslation
+	if err := c.call(ctx, http.MethodPut, translationPath(id, locale), in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) DeleteIncidentTranslation(ctx context.Context, id int, locale string) error {
+	return c.call(ctx, http.MethodDelete, translationPath(id, locale), nil, nil)
+}
+
+func translationPath(id int, locale string) string {
+	return incidentPath(id) + "/translations/" + url.PathEscape(locale)
+}
+
+// ListIncidentComments возвращает комментарии зоны, новые первыми; нулевой limit — значение сервера
+func (c *Client) ListIncidentComments(ctx context.Context, id, limit int) ([]IncidentComment, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var out struct {
+		Comments []IncidentComment `json:"comments"`
+	}
+	req := request{method: http.MethodGet, path: incidentPath(id) + "/comments", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return out.Comments, nil
+}
+
+// AddIncidentComment записывает заметку о ходе ситуации в зоне; автор — владелец ключа или токена
+func (c *Client) AddIncidentComment(ctx context.Context, id int, body string) (*IncidentComment, error) {
+	in := struct {
+		Body string `json:"body"`
+	}{Body: body}
+
+	var out IncidentComment
+	if err := c.call(ctx, http.MethodPost, incidentPath(id)+"/comments", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListApprovals возвращает заявки на публикацию от новых к старым; пустой status — все, нулевой limit — значение сервера
+func (c *Client) ListApprovals(ctx context.Context, status ApprovalStatus, limit int) ([]Approval, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", string(status))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var out struct {
+		Approvals []Approval `json:"approvals"`
+	}
+	req := request{method: http.MethodGet, path: "/api/v1/approvals", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return out.Approvals, nil
+}
+
+// ApproveIncident одобряет заявку и тем самым публикует критическую зону
+func (c *Client) ApproveIncident(ctx context.Context, approvalID int) (*Approval, error) {
+	var out Approval
+	if err := c.call(ctx, http.MethodPost, approvalPath(approvalID)+"/approve", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectIncident отклоняет заявку; reason может быть пустым
+func (c *Client) RejectIncident(ctx context.Context, approvalID int, reason string) (*Approval, error) {
+	var in any
+	if reason != "" {
+		in = map[string]string{"reason": reason}
+	}
+
+	var out Approval
+	if err := c.call(ctx, http.MethodPost, approvalPath(approvalID)+"/reject", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RequestErasure заводит заявку на удаление данных пользователя; пустой mode — delete
+func (c *Client) RequestErasure(ctx context.Context, userID string, mode ErasureMode) (*Erasure, error) {
+	query := url.Values{}
+	if mode != "" {
+		query.Set("mode", string(mode))
+	}
+
+	var out Erasure
+	req := request{method: http.MethodDelete, path: "/api/v1/users/" + url.PathEscape(userID) + "/data", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UserDataExport возвращает выгрузку данных пользователя, заводя ее при первом запросе;
+// DownloadURL заполнен, когда архив готов (статус ready)
+func (c *Client) UserDataExport(ctx context.Context, userID string) (*DataExport, error) {
+	var out DataExport
+	if err := c.call(ctx, http.MethodGet, "/api/v1/users/"+url.PathEscape(userID)+"/data-export", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListErasures возвращает заявки на удаление от новых к старым; пустой status — все, нулевой limit — значение сервера
+func (c *Client) ListErasures(ctx context.Context, status ErasureStatus, limit int) ([]Erasure, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", string(status))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var out struct {
+		Erasures []Erasure `json:"erasures"`
+	}
+	req := request{method: http.MethodGet, path: "/api/v1/erasures", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return out.Erasures, nil
+}
+
+func (c *Client) GetErasure(ctx context.Context, erasureID int) (*Erasure, error) {
+	var out Erasure
+	if err := c.call(ctx, http.MethodGet, erasurePath(erasureID), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConfirmErasure ставит заявку в очередь на удаление; подтверждает оператор, не подававший заявку
+func (c *Client) ConfirmErasure(ctx context.Context, erasureID int) (*Erasure, error) {
+	var out Erasure
+	if err := c.call(ctx, http.MethodPost, erasurePath(erasureID)+"/confirm", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) CancelErasure(ctx context.Context, erasureID int) (*Erasure, error) {
+	var out Erasure
+	if err := c.call(ctx, http.MethodPost, erasurePath(erasureID)+"/cancel", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BatchIncidents применяет действие к группе инцидентов и возвращает число затронутых
+func (c *Client) BatchIncidents(ctx context.Context, ids []int, action BatchAction) (int, error) {
+	in := struct {
+		IDs    []int       `json:"ids"`
+		Action BatchAction `json:"action"`
+	}{IDs: ids, Action: action}
+
+	var out struct {
+		Affected int `json:"affected"`
+	}
+	if err := c.call(ctx, http.MethodPatch, "/api/v1/incidents/batch", in, &out); err != nil {
+		return 0, err
+	}
+	return out.Affected, nil
+}
+
+// UploadAttachment загружает файл к инциденту; тип содержимого определяет сервер
+func (c *Client) UploadAttachment(ctx context.Context, incidentID int, fileName string, file io.Reader) (*Attachment, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	req := request{
+		method:      http.MethodPost,
+		path:        incidentPath(incidentID) + "/attachments",
+		body:        body.Bytes(),
+		contentType: mw.FormDataContentType(),
+	}
+
+	var out Attachment
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) ListAttachments(ctx context.Context, incidentID int) ([]Attachment, error) {
+	var out struct {
+		Attachments []Attachment `json:"attachments"`
+	}
+	if err := c.call(ctx, http.MethodGet, incidentPath(incidentID)+"/attachments", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Attachments, nil
+}
+
+func (c *Client) DeleteAttachment(ctx context.Context, incidentID, attachmentID int) error {
+	path := incidentPath(incidentID) + "/attachments/" + strconv.Itoa(attachmentID)
+	return c.call(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// Stats возвращает число пользователей и проверок за окно статистики
+func (c *Client) Stats(ctx context.Context) (*Stats, error) {
+	var out Stats
+	if err := c.call(ctx, http.MethodGet, "/api/v1/incidents/stats", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// EstimatedStats как Stats, но числа — быстрые оценки сервера (Estimated), а не точный подсчет
+func (c *Client) EstimatedStats(ctx context.Context) (*Stats, error) {
+	query := url.Values{}
+	query.Set("count", "estimated")
+
+	var out Stats
+	req := request{method: http.MethodGet, path: "/api/v1/incidents/stats", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Heatmap возвращает число проверок и алертов по H3-ячейкам за период, начиная с самых частых
+func (c *Client) Heatmap(ctx context.Context, in HeatmapRequest) (*Heatmap, error) {
+	query := url.Values{}
+	if in.Resolution != nil {
+		query.Set("resolution", strconv.Itoa(*in.Resolution))
+	}
+	if in.From != nil {
+		query.Set("from", in.From.UTC().Format(time.RFC3339))
+	}
+	if in.To != nil {
+		query.Set("to", in.To.UTC().Format(time.RFC3339))
+	}
+
+	var out Heatmap
+	req := request{method: http.MethodGet, path: "/api/v1/stats/heatmap", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// IncidentStats возвращает сведенные счетчики проверок и число проверок по зонам за windowMinutes минут
+// до последней сведенной минуты; windowMinutes 0 — окно сервера по умолчанию
+func (c *Client) IncidentStats(ctx context.Context, windowMinutes int) (*IncidentStats, error) {
+	query := url.Values{}
+	if windowMinutes > 0 {
+		query.Set("window_minutes", strconv.Itoa(windowMinutes))
+	}
+
+	var out IncidentStats
+	req := request{method: http.MethodGet, path: "/api/v1/stats/incidents", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PublicIncidents возвращает действующие зоны из публичного API без служебных полей; запрос не требует
+// авторизации и ограничен лимитом с одного IP
+func (c *Client) PublicIncidents(ctx context.Context) ([]PublicIncident, error) {
+	var out struct {
+		Incidents []PublicIncident `json:"incidents"`
+	}
+	if err := c.call(ctx, http.MethodGet, "/api/v1/public/incidents", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Incidents, nil
+}
+
+// TestWebhook отправляет тестовый вебхук на url; attempts 0 — настройка сервиса
+func (c *Client) TestWebhook(ctx context.Context, webhookURL string, attempts int) (*WebhookTestResult, error) {
+	in := struct {
+		URL      string `json:"url"`
+		Attempts int    `json:"attempts"`
+	}{URL: webhookURL, Attempts: attempts}
+
+	var out WebhookTestResult
+	if err := c.call(ctx, http.MethodPost, "/api/v1/webhooks/test", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReplayWebhooks возвращает в доставку вебхуки, исчерпавшие попытки; без ids — все такие вебхуки.
+// Возвращает число поставленных в очередь
+func (c *Client) ReplayWebhooks(ctx context.Context, ids ...int) (int, error) {
+	in := struct {
+		IDs []int `json:"ids,omitempty"`
+	}{IDs: ids}
+
+	var out struct {
+		Requeued int `json:"requeued"`
+	}
+	if err := c.call(ctx, http.MethodPost, "/api/v1/webhooks/replay", in, &out); err != nil {
+		return 0, err
+	}
+	return out.Requeued, nil
+}
+
+// RedriveWebhooks сразу ставит в доставку недоставленные вебхуки по фильтру и возвращает их число
+func (c *Client) RedriveWebhooks(ctx context.Context, in WebhookRedriveRequest) (int, error) {
+	var out struct {
+		Redriven int `json:"redriven"`
+	}
+	if err := c.call(ctx, http.MethodPost, "/api/v1/webhooks/redrive", in, &out); err != nil {
+		return 0, err
+	}
+	return out.Redriven, nil
+}
+
+func (c *Client) ListWebhookEndpoints(ctx context.Context) ([]WebhookEndpoint, error) {
+	var out struct {
+		Endpoints []WebhookEndpoint `json:"endpoints"`
+	}
+	if err := c.call(ctx, http.MethodGet, "/api/v1/webhook-endpoints", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Endpoints, nil
+}
+
+func (c *Client) GetWebhookEndpoint(ctx context.Context, id int) (*WebhookEndpoint, error) {
+	var out WebhookEndpoint
+	if err := c.call(ctx, http.MethodGet, webhookEndpointPath(id), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) CreateWebhookEndpoint(ctx context.Context, in WebhookEndpointCreateRequest) (*WebhookEndpoint, error) {
+	var out WebhookEndpoint
+	if err := c.call(ctx, http.MethodPost, "/api/v1/webhook-endpoints", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) PatchWebhookEndpoint(ctx context.Context, id int, in WebhookEndpointPatchRequest) (*WebhookEndpoint, error) {
+	var out WebhookEndpoint
+	if err := c.call(ctx, http.MethodPatch, webhookEndpointPath(id), in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteWebhookEndpoint удаляет получателя вместе с его недоставленными вебхуками
+func (c *Client) DeleteWebhookEndpoint(ctx context.Context, id int) error {
+	return c.call(ctx, http.MethodDelete, webhookEndpointPath(id), nil, nil)
+}
+
+// WebhookEndpointSLO возвращает перцентили задержки доставки получателю и нарушение цели
+func (c *Client) WebhookEndpointSLO(ctx context.Context, id int) (*WebhookSLO, error) {
+	var out WebhookSLO
+	if err := c.call(ctx, http.MethodGet, webhookEndpointPath(id)+"/slo", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Dashboard возвращает сводку админки; limit 0 — размер списков по умолчанию
+func (c *Client) Dashboard(ctx context.Context, limit int) (*Dashboard, error) {
+	var query url.Values
+	if limit > 0 {
+		query = url.Values{"limit": {strconv.Itoa(limit)}}
+	}
+
+	var out Dashboard
+	req := request{method: http.MethodGet, path: "/api/v1/admin/dashboard", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) RuntimeConfig(ctx context.Context) (*RuntimeConfig, error) {
+	var out RuntimeConfig
+	if err := c.call(ctx, http.MethodGet, "/api/v1/admin/config", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReloadConfig перечитывает конфигурацию сервиса и возвращает примененные настройки
+func (c *Client) ReloadConfig(ctx context.Context) (*RuntimeConfig, error) {
+	var out RuntimeConfig
+	if err := c.call(ctx, http.MethodPost, "/api/v1/admin/config/reload", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// QueryStats возвращает статистику запросов к БД, начиная с самых затратных; limit 0 — 50 запросов
+func (c *Client) QueryStats(ctx context.Context, limit int) (*QueryStats, error) {
+	var query url.Values
+	if limit > 0 {
+		query = url.Values{"limit": {strconv.Itoa(limit)}}
+	}
+
+	var out QueryStats
+	req := request{method: http.MethodGet, path: "/api/v1/admin/query-stats", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) ResetQueryStats(ctx context.Context) error {
+	return c.call(ctx, http.MethodDelete, "/api/v1/admin/query-stats", nil, nil)
+}
+
+// Queues возвращает глубину очереди вебхуков и отставание их доставки
+func (c *Client) Queues(ctx context.Context) (*Queues, error) {
+	var out Queues
+	if err := c.call(ctx, http.MethodGet, "/api/v1/system/queues", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// WebhookSLOs возвращает задержку доставки всех общих получателей
+func (c *Client) WebhookSLOs(ctx context.Context) (*WebhookSLOList, error) {
+	var out WebhookSLOList
+	if err := c.call(ctx, http.MethodGet, "/api/v1/system/webhook-slo", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Usage возвращает запросы API-ключей по суткам с from по to (YYYY-MM-DD, пустые — с начала месяца по сегодня)
+// вместе с квотами. API-ключ клиента получает только себя
+func (c *Client) Usage(ctx context.Context, from, to string) (*Usage, error) {
+	var out Usage
+	req := request{method: http.MethodGet, path: "/api/v1/system/usage", query: usagePeriod(from, to)}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportUsage возвращает использование API-ключей по суткам с from по to для биллинга
+// (пустые — вчерашние сутки)
+func (c *Client) ExportUsage(ctx context.Context, from, to string) (*UsageExport, error) {
+	query := usagePeriod(from, to)
+	query.Set("format", "json")
+
+	var out UsageExport
+	req := request{method: http.MethodGet, path: "/api/v1/system/usage/export", query: query}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportUsageCSV пишет в w ту же выгрузку в CSV с заголовком api_key,day,requests,checks,alerts,webhooks
+func (c *Client) ExportUsageCSV(ctx context.Context, from, to string, w io.Writer) error {
+	query := usagePeriod(from, to)
+	query.Set("format", "csv")
+
+	req := request{method: http.MethodGet, path: "/api/v1/system/usage/export", query: query, responseType: "text/csv"}
+	resp, err := c.open(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	return nil
+}
+
+func usagePeriod(from, to string) url.Values {
+	query := url.Values{}
+	if from != "" {
+		query.Set("from", from)
+	}
+	if to != "" {
+		query.Set("to", to)
+	}
+	return query
+}
+
+// WorkerLocks возвращает блокировки периодических задач реплики, обработавшей запрос
+func (c *Client) WorkerLocks(ctx context.Context) (*WorkerLocks, error) {
+	var out WorkerLocks
+	if err := c.call(ctx, http.MethodGet, "/api/v1/admin/locks", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateOperator заводит учетную запись оператора и возвращает ее ID
+func (c *Client) CreateOperator(ctx context.Context, in OperatorCreateRequest) (int, error) {
+	var out struct {
+		ID int `json:"id"`
+	}
+	if err := c.call(ctx, http.MethodPost, "/api/v1/admin/operators", in, &out); err != nil {
+		return 0, err
+	}
+	return out.ID, nil
+}
+
+// Login выдает JWT оператора; дальше его можно передать через WithToken
+func (c *Client) Login(ctx context.Context, username, password string) (*Token, error) {
+	in := struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}{Username: username, Password: password}
+
+	var out Token
+	if err := c.call(ctx, http.MethodPost, "/api/v1/auth/login", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) Liveness(ctx context.Context) (*Liveness, error) {
+	var out Liveness
+	if err := c.call(ctx, http.MethodGet, "/healthz", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Readiness возвращает состояние зависимостей; неготовый сервис (503) не считается ошибкой
+func (c *Client) Readiness(ctx context.Context) (*Readiness, error) {
+	var out Readiness
+	req := request{
+		method: http.MethodGet,
+		path:   "/readyz",
+		accept: []int{http.StatusServiceUnavailable},
+	}
+	if err := c.send(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func incidentPath(id int) string {
+	return "/api/v1/incidents/" + strconv.Itoa(id)
+}
+
+func approvalPath(id int) string {
+	return "/api/v1/approvals/" + strconv.Itoa(id)
+}
+
+func erasurePath(id int) string {
+	return "/api/v1/erasures/" + strconv.Itoa(id)
+}
+
+func webhookEndpointPath(id int) string {
+	return "/api/v1/webhook-endpoints/" + strconv.Itoa(id)
+}
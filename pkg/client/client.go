@@ -0,0 +1,296 @@
+// Package client — Go SDK для API geonotify-service: типизированные методы всех ручек,
+// авторизация по API-ключу или JWT оператора и повтор идемпотентных запросов.
+//
+//	c, err := client.New("http://localhost:8080", client.Options{Token: apiKey})
+//	res, err := c.CheckLocation(ctx, client.LocationCheckRequest{UserID: "user-1", Latitude: 55.75, Longitude: 37.61})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultTimeout    = 10 * time.Second
+	DefaultMaxRetries = 2
+	DefaultRetryDelay = 200 * time.Millisecond
+
+	// maxRetryAfter — самая долгая пауза из заголовка Retry-After, которую стоит ждать; с более долгой,
+	// например при исчерпанной квоте API-ключа, запрос не повторяется
+	maxRetryAfter = 30 * time.Second
+)
+
+type Options struct {
+	// HTTPClient по умолчанию — http.Client с таймаутом DefaultTimeout
+	HTTPClient *http.Client
+	// Token — API-ключ или JWT оператора, передается как Bearer. Для публичных ручек не нужен
+	Token string
+	// MaxRetries — число повторов при сетевой ошибке, 429 и 5xx; 0 — DefaultMaxRetries, отрицательное — без повторов.
+	// Повторяются только идемпотентные запросы (GET, PUT, DELETE)
+	MaxRetries int
+	// RetryDelay — пауза перед первым повтором, дальше удваивается
+	RetryDelay time.Duration
+	UserAgent  string
+	// AcceptLanguage — языки клиента в формате заголовка Accept-Language ("en, de;q=0.8"):
+	// названия и описания зон в проверках и списках приходят в переводе, если он есть
+	AcceptLanguage string
+}
+
+type Client struct {
+	baseURL *url.URL
+	opts    Options
+}
+
+func New(baseURL string, opts Options) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base url %q: scheme must be http or https", baseURL)
+	}
+
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultMaxRetries
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = DefaultRetryDelay
+	}
+
+	return &Client{baseURL: u, opts: opts}, nil
+}
+
+// WithToken возвращает копию клиента с другим токеном, например после Login
+func (c *Client) WithToken(token string) *Client {
+	cp := *c
+	cp.opts.Token = token
+	return &cp
+}
+
+// APIError — ответ сервиса с кодом не 2xx
+type APIError struct {
+	StatusCode int
+	ErrorResponse
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("geonotify: %d %s: %s", e.StatusCode, e.ErrorResponse.Error, e.Message)
+	}
+	return fmt.Sprintf("geonotify: %d %s", e.StatusCode, e.ErrorResponse.Error)
+}
+
+// StatusCode возвращает HTTP-код из *APIError или 0 для остальных ошибок
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+	// responseType — заголовок Accept, по умолчанию application/json
+	responseType string
+	// accept — коды ответа, кроме 2xx, тело которых разбирается в out (503 у /readyz)
+	accept []int
+}
+
+func jsonRequest(method, path string, in any) (request, error) {
+	req := request{method: method, path: path}
+	if in == nil {
+		return req, nil
+	}
+
+	body, err := json.Marshal(in)
+	if err != nil {
+		return request{}, fmt.Errorf("failed to encode request: %w", err)
+	}
+	req.body = body
+	req.contentType = "application/json"
+	return req, nil
+}
+
+func (r request) idempotent() bool {
+	switch r.method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// call отправляет JSON-запрос и разбирает JSON-ответ в out, если out не nil
+func (c *Client) call(ctx context.Context, method, path string, in, out any) error {
+	req, err := jsonRequest(method, path, in)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, req, out)
+}
+
+func (c *Client) send(ctx context.Context, req request, out any) error {
+	resp, err := c.open(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// open выполняет запрос с повторами и возвращает ответ с кодом 2xx или из req.accept.
+// Тело ответа закрывает вызывающий
+func (c *Client) open(ctx context.Context, req request) (*http.Response, error) {
+	attempts := 1
+	if req.idempotent() {
+		attempts += c.opts.MaxRetries
+	}
+
+	delay := c.opts.RetryDelay
+	for attempt := 1; ; attempt++ {
+		resp, err := c.do(ctx, req)
+		if err == nil && (resp.StatusCode < 300 || accepts(req.accept, resp.StatusCode)) {
+			return resp, nil
+		}
+
+		wait := delay
+		if err == nil {
+			err = readAPIError(resp)
+			if !retryableStatus(resp.StatusCode) {
+				return nil, err
+			}
+			if after, ok := retryAfter(resp); ok {
+				if after > maxRetryAfter {
+					return nil, err
+				}
+				wait = after
+			}
+		} else if ctx.Err() != nil {
+			return nil, err
+		}
+
+		if attempt >= attempts {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+func (c *Client) do(ctx context.Context, req request) (*http.Response, error) {
+	// сегменты req.path уже экранированы
+	target := c.baseURL.String() + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if req.responseType != "" {
+		httpReq.Header.Set("Accept", req.responseType)
+	} else {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+	if c.opts.Token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.opts.Token)
+	}
+	if c.opts.UserAgent != "" {
+		httpReq.Header.Set("User-Agent", c.opts.UserAgent)
+	}
+	if c.opts.AcceptLanguage != "" {
+		httpReq.Header.Set("Accept-Language", c.opts.AcceptLanguage)
+	}
+
+	return c.opts.HTTPClient.Do(httpReq)
+}
+
+// readAPIError читает тело ошибки и закрывает его
+func readAPIError(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err := json.Unmarshal(data, &apiErr.ErrorResponse); err != nil || apiErr.ErrorResponse.Error == "" {
+		apiErr.ErrorResponse = ErrorResponse{
+			Error:   http.StatusText(resp.StatusCode),
+			Message: strings.TrimSpace(string(data)),
+		}
+	}
+	return apiErr
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	var d time.Duration
+	if seconds, err := strconv.Atoi(v); err == nil {
+		d = time.Duration(seconds) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil {
+		d = time.Until(t)
+	} else {
+		return 0, false
+	}
+
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
+
+func accepts(codes []int, code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func (c *Client) ListGroups(ctx context.Context) ([]Group, error) {
+	var out struct {
+		Groups []Group `json:"groups"`
+	}
+	if err := c.call(ctx, http.MethodGet, "/api/v1/groups", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Groups, nil
+}
+
+func (c *Client) GetGroup(ctx context.Context, id int) (*Group, error) {
+	var out Group
+	if err := c.call(ctx, http.MethodGet, groupPath(id), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateGroup создает группу; создатель управляет ею наравне с менеджерами из in.Managers
+func (c *Client) CreateGroup(ctx context.Context, in GroupCreateRequest) (*Group, error) {
+	var out Group
+	if err := c.call(ctx, http.MethodPost, "/api/v1/groups", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) PatchGroup(ctx context.Context, id int, in GroupPatchRequest) (*Group, error) {
+	var out Group
+	if err := c.call(ctx, http.MethodPatch, groupPath(id), in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteGroup удаляет группу вместе с участниками и получателем вебхуков группы
+func (c *Client) DeleteGroup(ctx context.Context, id int) error {
+	return c.call(ctx, http.MethodDelete, groupPath(id), nil, nil)
+}
+
+func (c *Client) GroupMembers(ctx context.Context, id int) ([]string, error) {
+	var out struct {
+		UserIDs []string `json:"user_ids"`
+	}
+	if err := c.call(ctx, http.MethodGet, groupPath(id)+"/members", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.UserIDs, nil
+}
+
+// AddGroupMembers возвращает число добавленных: уже состоящие в группе пользователи пропускаются
+func (c *Client) AddGroupMembers(ctx context.Context, id int, userIDs ...string) (int, error) {
+	in := struct {
+		UserIDs []string `json:"user_ids"`
+	}{UserIDs: userIDs}
+
+	var out struct {
+		Added int `json:"added"`
+	}
+	if err := c.call(ctx, http.MethodPost, groupPath(id)+"/members", in, &out); err != nil {
+		return 0, err
+	}
+	return out.Added, nil
+}
+
+func (c *Client) RemoveGroupMember(ctx context.Context, id int, userID string) error {
+	return c.call(ctx, http.MethodDelete, groupPath(id)+"/members/"+url.PathEscape(userID), nil, nil)
+}
+
+// SetGroupWebhook регистрирует или заменяет получателя сводных алертов группы (WebhookGroupAlert);
+// пустой secret отключает подпись
+func (c *Client) SetGroupWebhook(ctx context.Context, id int, webhookURL, secret string) (*Group, error) {
+	in := struct {
+		URL    string `json:"url"`
+		Secret string `json:"secret,omitempty"`
+	}{URL: webhookURL, Secret: secret}
+
+	var out Group
+	if err := c.call(ctx, http.MethodPut, groupPath(id)+"/webhook", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) DeleteGroupWebhook(ctx context.Context, id int) error {
+	return c.call(ctx, http.MethodDelete, groupPath(id)+"/webhook", nil, nil)
+}
+
+// GroupStatus возвращает участников группы, которые по последней проверке находятся в действующих зонах
+func (c *Client) GroupStatus(ctx context.Context, id int) (*GroupStatus, error) {
+	var out GroupStatus
+	if err := c.call(ctx, http.MethodGet, groupPath(id)+"/status", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func groupPath(id int) string {
+	return "/api/v1/groups/" + strconv.Itoa(id)
+}
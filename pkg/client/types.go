@@ -0,0 +1,938 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ErrorResponse — единый формат ошибок API
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+	// Details — нарушения по полям для ошибок проверки запроса
+	Details []FieldError `json:"details,omitempty"`
+	// ConflictingIncidentIDs — активные зоны, перекрытые новой (409 на CreateIncident)
+	ConflictingIncidentIDs []int `json:"conflicting_incident_ids,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type LocationCheckRequest struct {
+	UserID    string  `json:"user_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	// CRS — система координат точки, по умолчанию EPSG:4326
+	CRS        string   `json:"crs,omitempty"`
+	AccuracyM  float64  `json:"accuracy_m,omitempty"`
+	Altitude   *float64 `json:"altitude,omitempty"`
+	SpeedMps   *float64 `json:"speed_mps,omitempty"`
+	HeadingDeg *float64 `json:"heading_deg,omitempty"`
+
+	// ResolveAddress — вернуть адрес точки в Place
+	ResolveAddress bool `json:"-"`
+	// DryRun — только сопоставить точку с зонами: проверка не сохраняется, вебхуки и алерты не отправляются.
+	// В пачке не поддерживается
+	DryRun bool `json:"-"`
+	// At — проверить по зонам, действовавшим в этот момент в прошлом, в их тогдашней форме; проверка
+	// не сохраняется, как с DryRun. Нужен ключ оператора, в пачке не поддерживается
+	At *time.Time `json:"-"`
+}
+
+type LocationCheckResult struct {
+	HasAlert  bool       `json:"has_alert"`
+	Incidents []Incident `json:"incidents,omitempty"`
+	Place     string     `json:"place,omitempty"`
+}
+
+// LocationCheckResultV2 — ответ /api/v2/location/check
+type LocationCheckResultV2 struct {
+	HasAlert bool `json:"has_alert"`
+	// Match — inside, possibly_inside или outside
+	Match   string       `json:"match"`
+	Zones   []ZoneMatch  `json:"zones"`
+	Ahead   []ZoneAhead  `json:"ahead,omitempty"`
+	Nearest *NearestZone `json:"nearest,omitempty"`
+	Place   string       `json:"place,omitempty"`
+}
+
+// LocationCheckBatchResult — итог одной точки из CheckLocationBatch: Result либо Error
+type LocationCheckBatchResult struct {
+	Result *LocationCheckResultV2 `json:"result,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+type ZoneMatch struct {
+	IncidentID int        `json:"incident_id"`
+	Name       string     `json:"name"`
+	Descr      string     `json:"descr"`
+	Latitude   float64    `json:"latitude"`
+	Longitude  float64    `json:"longitude"`
+	Radius     float64    `json:"radius_m"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	// Visibility не public — название и описание зоны не раскрываются
+	Visibility      Visibility `json:"visibility"`
+	Match           string     `json:"match"`
+	DistanceM       float64    `json:"distance_m"`
+	DistanceToEdgeM float64    `json:"distance_to_edge_m"`
+	BearingDeg      float64    `json:"bearing_deg"`
+}
+
+type NearestZone struct {
+	IncidentID      int        `json:"incident_id"`
+	Name            string     `json:"name"`
+	Latitude        float64    `json:"latitude"`
+	Longitude       float64    `json:"longitude"`
+	Radius          float64    `json:"radius_m"`
+	Visibility      Visibility `json:"visibility"`
+	DistanceM       float64    `json:"distance_m"`
+	DistanceToEdgeM float64    `json:"distance_to_edge_m"`
+	BearingDeg      float64    `json:"bearing_deg"`
+}
+
+type ZoneAhead struct {
+	NearestZone
+	ETASeconds float64 `json:"eta_seconds"`
+}
+
+type Incident struct {
+	ID                  int            `json:"incident_id"`
+	Name                string         `json:"name"`
+	Descr               string         `json:"descr"`
+	Latitude            float64        `json:"latitude"`
+	Longitude           float64        `json:"longitude"`
+	Radius              float64        `json:"radius_m"`
+	IsActive            bool           `json:"is_active"`
+	State               IncidentState  `json:"state"`
+	Status              IncidentStatus `json:"status"`
+	Severity            Severity       `json:"severity"`
+	Source              IncidentSource `json:"source"`
+	Visibility          Visibility     `json:"visibility"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	Schedule            string         `json:"schedule,omitempty"`
+	ScheduleDurationMin int            `json:"schedule_duration_minutes,omitempty"`
+	NextActivation      *time.Time     `json:"next_activation,omitempty"`
+	ExpiresAt           *time.Time     `json:"expires_at,omitempty"`
+	Address             string         `json:"address,omitempty"`
+	CreatedBy           string         `json:"created_by,omitempty"`
+	UpdatedBy           string         `json:"updated_by,omitempty"`
+	PublishedBy         string         `json:"published_by,omitempty"`
+	PublishedAt         *time.Time     `json:"published_at,omitempty"`
+	// StateTimes — когда зона последний раз входила в каждую из пройденных стадий
+	StateChangedAt time.Time                   `json:"state_changed_at"`
+	StateTimes     map[IncidentState]time.Time `json:"state_times"`
+	// Geometry — GeoJSON MultiPolygon полигональной зоны
+	Geometry json.RawMessage `json:"geometry,omitempty"`
+	// Relations — связи с другими зонами; приходят при чтении зоны и списка
+	Relations []IncidentRelation `json:"relations,omitempty"`
+}
+
+// PublicIncident — зона в публичном API: без описания, адреса, авторов и расписания
+type PublicIncident struct {
+	ID          int           `json:"incident_id"`
+	Name        string        `json:"name"`
+	Latitude    float64       `json:"latitude"`
+	Longitude   float64       `json:"longitude"`
+	Radius      float64       `json:"radius_m"`
+	State       IncidentState `json:"state"`
+	Severity    Severity      `json:"severity"`
+	PublishedAt *time.Time    `json:"published_at,omitempty"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	ExpiresAt   *time.Time    `json:"expires_at,omitempty"`
+	// Geometry — GeoJSON MultiPolygon полигональной зоны
+	Geometry json.RawMessage `json:"geometry,omitempty"`
+}
+
+type IncidentList struct {
+	Incidents  []Incident `json:"incidents"`
+	Page       int        `json:"page"`
+	Limit      int        `json:"limit"`
+	TotalPages int        `json:"total_pages"`
+	// TotalEstimated — TotalPages посчитан по оценке числа инцидентов (count=estimated)
+	TotalEstimated bool `json:"total_estimated,omitempty"`
+	// NextCursor заполняется в ответе ListIncidentsAfter; пустой — страница последняя
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type IncidentCreateRequest struct {
+	Name      string  `json:"name"`
+	Descr     string  `json:"descr"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Radius    float64 `json:"radius_m"`
+	CRS       string  `json:"crs,omitempty"`
+
+	Schedule            string     `json:"schedule,omitempty"`
+	ScheduleDurationMin int        `json:"schedule_duration_minutes,omitempty"`
+	ExpiresAt           *time.Time `json:"expires_at,omitempty"`
+	TTLMinutes          int        `json:"ttl_minutes,omitempty"`
+	// Address геокодируется, если координаты не заданы
+	Address string `json:"address,omitempty"`
+	// Status — draft или published; пустой выбирает сервер по роли оператора
+	Status IncidentStatus `json:"status,omitempty"`
+	// Severity по умолчанию medium
+	Severity Severity `json:"severity,omitempty"`
+	// State — planned, active или contained; пустой — active
+	State IncidentState `json:"state,omitempty"`
+	// Visibility по умолчанию public; internal выставляет только публикатор
+	Visibility Visibility `json:"visibility,omitempty"`
+	// CheckOverlap — отклонить зону, перекрывающую активные; nil — по настройке сервера
+	CheckOverlap *bool `json:"-"`
+}
+
+type IncidentUpdateRequest struct {
+	Name      string  `json:"name"`
+	Descr     string  `json:"descr"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Radius    float64 `json:"radius_m"`
+	IsActive  bool    `json:"is_active"`
+
+	Schedule            string     `json:"schedule,omitempty"`
+	ScheduleDurationMin int        `json:"schedule_duration_minutes,omitempty"`
+	ExpiresAt           *time.Time `json:"expires_at,omitempty"`
+	TTLMinutes          int        `json:"ttl_minutes,omitempty"`
+	Address             string     `json:"address,omitempty"`
+	// Severity и Visibility не меняются, если пустые
+	Severity   Severity   `json:"severity,omitempty"`
+	Visibility Visibility `json:"visibility,omitempty"`
+}
+
+// IncidentPatchRequest — частичное обновление: nil-поля не меняются
+type IncidentPatchRequest struct {
+	Name      *string  `json:"name,omitempty"`
+	Descr     *string  `json:"descr,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Radius    *float64 `json:"radius_m,omitempty"`
+	IsActive  *bool    `json:"is_active,omitempty"`
+
+	Schedule            *string     `json:"schedule,omitempty"`
+	ScheduleDurationMin *int        `json:"schedule_duration_minutes,omitempty"`
+	ExpiresAt           *time.Time  `json:"expires_at,omitempty"`
+	TTLMinutes          int         `json:"ttl_minutes,omitempty"`
+	Address             *string     `json:"address,omitempty"`
+	Severity            *Severity   `json:"severity,omitempty"`
+	Visibility          *Visibility `json:"visibility,omitempty"`
+
+	// ClearExpiresAt снимает срок действия: отправляется "expires_at": null
+	ClearExpiresAt bool `json:"-"`
+}
+
+func (r IncidentPatchRequest) MarshalJSON() ([]byte, error) {
+	type plain IncidentPatchRequest
+	if !r.ClearExpiresAt {
+		return json.Marshal(plain(r))
+	}
+
+	return json.Marshal(struct {
+		plain
+		ExpiresAt *time.Time `json:"expires_at"`
+	}{plain: plain(r)})
+}
+
+// IncidentPart — часть зоны для SplitIncident; Geometry — GeoJSON, как в SetIncidentGeometry
+type IncidentPart struct {
+	Name     string          `json:"name"`
+	Geometry json.RawMessage `json:"geometry"`
+}
+
+// LineageOperation — операция, связавшая зоны в истории
+type LineageOperation string
+
+const (
+	LineageMerge LineageOperation = "merge"
+	LineageSplit LineageOperation = "split"
+)
+
+// IncidentLink — запись истории: зона ChildID получена из ParentID
+type IncidentLink struct {
+	ParentID  int              `json:"parent_id"`
+	ChildID   int              `json:"child_id"`
+	Operation LineageOperation `json:"operation"`
+	Actor     string           `json:"actor,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// RelationType — связь со стороны зоны: Parent и Supersedes задает оператор, Child и SupersededBy — их обратные стороны
+type RelationType string
+
+const (
+	RelationParent       RelationType = "parent"
+	RelationChild        RelationType = "child"
+	RelationSupersedes   RelationType = "supersedes"
+	RelationSupersededBy RelationType = "superseded_by"
+)
+
+// IncidentRelation — связь зоны с зоной RelatedID
+type IncidentRelation struct {
+	RelatedID int          `json:"related_id"`
+	Type      RelationType `json:"type"`
+	CreatedBy string       `json:"created_by,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// GeometryVersion — форма зоны, действовавшая с ValidFrom до следующей версии
+type GeometryVersion struct {
+	Version   int     `json:"version"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Radius    float64 `json:"radius_m"`
+	// Geometry — GeoJSON MultiPolygon полигональной зоны
+	Geometry  json.RawMessage `json:"geometry,omitempty"`
+	ChangedBy string          `json:"changed_by,omitempty"`
+	ValidFrom time.Time       `json:"valid_from"`
+}
+
+// IncidentBacktest — сколько пользователей оповестила бы зона за период From–To.
+// UsersNewlyAlerted — те из них, кто в зоне не получил алертов от действовавших тогда зон
+type IncidentBacktest struct {
+	IncidentID        int       `json:"incident_id"`
+	Radius            float64   `json:"radius_m"`
+	From              time.Time `json:"from"`
+	To                time.Time `json:"to"`
+	ChecksScanned     int       `json:"checks_scanned"`
+	ChecksMatched     int       `json:"checks_matched"`
+	UsersAlerted      int       `json:"users_alerted"`
+	UsersNewlyAlerted int       `json:"users_newly_alerted"`
+	Truncated         bool      `json:"truncated"`
+}
+
+// IncidentTranslation — название и описание зоны на языке Locale
+type IncidentTranslation struct {
+	Locale    string    `json:"locale"`
+	Name      string    `json:"name"`
+	Descr     string    `json:"descr"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IncidentComment — заметка оператора о ходе ситуации в зоне
+type IncidentComment struct {
+	ID        int       `json:"id"`
+	Body      string    `json:"body"`
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IncidentStatus — стадия публикации зоны; в проверках участвуют только опубликованные
+type IncidentStatus string
+
+const (
+	IncidentDraft     IncidentStatus = "draft"
+	IncidentPublished IncidentStatus = "published"
+	IncidentArchived  IncidentStatus = "archived"
+)
+
+// IncidentState — стадия жизни опасности; в проверках участвуют зоны в active и contained
+type IncidentState string
+
+const (
+	StatePlanned   IncidentState = "planned"
+	StateActive    IncidentState = "active"
+	StateContained IncidentState = "contained"
+	StateResolved  IncidentState = "resolved"
+	StateArchived  IncidentState = "archived"
+)
+
+// Severity — уровень опасности зоны; критическая публикуется только с одобрения второго оператора
+type Severity string
+
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// Visibility — кому видны сведения о зоне: restricted предупреждает пользователей без названия и описания
+// и не попадает в публичные ленты, internal — еще и скрыта от редакторов
+type Visibility string
+
+const (
+	VisibilityPublic     Visibility = "public"
+	VisibilityRestricted Visibility = "restricted"
+	VisibilityInternal   Visibility = "internal"
+)
+
+// IncidentSource — откуда появилась зона: создана оператором, импортирована из внешней ленты оповещений
+// или из погодных предупреждений
+type IncidentSource string
+
+const (
+	SourceManual  IncidentSource = "manual"
+	SourceFeed    IncidentSource = "feed"
+	SourceWeather IncidentSource = "weather"
+)
+
+// ApprovalStatus — статус заявки на публикацию критической зоны
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalRejected ApprovalStatus = "rejected"
+)
+
+// Approval — заявка на публикацию критической зоны; у заявки на повышение опубликованной зоны
+// до критической задана Severity
+type Approval struct {
+	ID          int            `json:"approval_id"`
+	IncidentID  int            `json:"incident_id"`
+	Status      ApprovalStatus `json:"status"`
+	Severity    Severity       `json:"severity,omitempty"`
+	RequestedBy string         `json:"requested_by"`
+	RequestedAt time.Time      `json:"requested_at"`
+	DecidedBy   string         `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time     `json:"decided_at,omitempty"`
+	Reason      string         `json:"reason,omitempty"`
+}
+
+// ErasureStatus — статус заявки на удаление данных пользователя
+type ErasureStatus string
+
+const (
+	ErasurePending   ErasureStatus = "pending"
+	ErasureConfirmed ErasureStatus = "confirmed"
+	ErasureCompleted ErasureStatus = "completed"
+	ErasureCancelled ErasureStatus = "cancelled"
+)
+
+// ErasureMode — способ удаления: anonymize оставляет проверки для статистики без user_id
+type ErasureMode string
+
+const (
+	ErasureDelete    ErasureMode = "delete"
+	ErasureAnonymize ErasureMode = "anonymize"
+)
+
+type Erasure struct {
+	ID          int            `json:"erasure_id"`
+	UserID      string         `json:"user_id"`
+	Mode        ErasureMode    `json:"mode"`
+	Status      ErasureStatus  `json:"status"`
+	RequestedBy string         `json:"requested_by"`
+	RequestedAt time.Time      `json:"requested_at"`
+	ConfirmedBy string         `json:"confirmed_by,omitempty"`
+	ConfirmedAt *time.Time     `json:"confirmed_at,omitempty"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+	Attempts    int            `json:"attempts,omitempty"`
+	LastError   string         `json:"last_error,omitempty"`
+	Erased      *ErasureResult `json:"erased,omitempty"`
+}
+
+// ErasureResult — сколько записей удалено или обезличено
+type ErasureResult struct {
+	Checks           int64 `json:"checks"`
+	Webhooks         int64 `json:"webhooks"`
+	Zones            int64 `json:"zones"`
+	Preferences      int64 `json:"preferences"`
+	GroupMemberships int64 `json:"group_memberships"`
+}
+
+// DataExportStatus — статус выгрузки данных пользователя
+type DataExportStatus string
+
+const (
+	DataExportPending DataExportStatus = "pending"
+	DataExportReady   DataExportStatus = "ready"
+)
+
+type DataExport struct {
+	ID          int              `json:"export_id"`
+	UserID      string           `json:"user_id"`
+	Status      DataExportStatus `json:"status"`
+	RequestedBy string           `json:"requested_by"`
+	RequestedAt time.Time        `json:"requested_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty"`
+	SizeBytes   int64            `json:"size_bytes,omitempty"`
+	DownloadURL string           `json:"download_url,omitempty"`
+	Attempts    int              `json:"attempts,omitempty"`
+	LastError   string           `json:"last_error,omitempty"`
+}
+
+// BatchAction — действие над группой инцидентов
+type BatchAction string
+
+const (
+	BatchActivate   BatchAction = "activate"
+	BatchDeactivate BatchAction = "deactivate"
+	BatchDelete     BatchAction = "delete"
+)
+
+type Attachment struct {
+	ID          int       `json:"attachment_id"`
+	IncidentID  int       `json:"incident_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Stats struct {
+	UserCount     int       `json:"user_count"`
+	TotalChecks   int       `json:"total_checks"`
+	WindowMinutes int       `json:"window_minutes"`
+	PeriodStart   time.Time `json:"period_start"`
+	Estimated     bool      `json:"estimated,omitempty"`
+}
+
+// HeatmapRequest — параметры тепловой карты; nil — значение сервера по умолчанию: разрешение 7, последние сутки
+type HeatmapRequest struct {
+	Resolution *int
+	From       *time.Time
+	To         *time.Time
+}
+
+type Heatmap struct {
+	Resolution int           `json:"resolution"`
+	From       time.Time     `json:"from"`
+	To         time.Time     `json:"to"`
+	Cells      []HeatmapCell `json:"cells"`
+}
+
+// HeatmapCell — ячейка H3 (индекс в шестнадцатеричной записи) с центром в Latitude, Longitude
+type HeatmapCell struct {
+	Cell      string  `json:"cell"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Checks    int     `json:"checks"`
+	Alerts    int     `json:"alerts"`
+}
+
+// IncidentStats — счетчики за минуты с PeriodStart до PeriodEnd, до которой сервер свел проверки
+type IncidentStats struct {
+	WindowMinutes int                  `json:"window_minutes"`
+	PeriodStart   time.Time            `json:"period_start"`
+	PeriodEnd     time.Time            `json:"period_end"`
+	UserCount     int                  `json:"user_count"`
+	TotalChecks   int                  `json:"total_checks"`
+	AlertChecks   int                  `json:"alert_checks"`
+	Incidents     []IncidentCheckStats `json:"incidents"`
+}
+
+type IncidentCheckStats struct {
+	IncidentID int    `json:"incident_id"`
+	Name       string `json:"name"`
+	Checks     int    `json:"checks"`
+}
+
+type WebhookTestResult struct {
+	Delivered  bool    `json:"delivered"`
+	StatusCode int     `json:"status_code,omitempty"`
+	LatencyMs  float64 `json:"latency_ms"`
+	Attempts   int     `json:"attempts"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// WebhookEndpoint — получатель вебхуков; секрет сервер не возвращает, только HasSecret
+type WebhookEndpoint struct {
+	ID        int                `json:"endpoint_id"`
+	URL       string             `json:"url"`
+	HasSecret bool               `json:"has_secret"`
+	Events    []WebhookEventType `json:"events"`
+	Enabled   bool               `json:"enabled"`
+	RetryPolicy
+	DeliveryOptions
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookBackoff string
+
+const (
+	BackoffLinear      WebhookBackoff = "linear"
+	BackoffExponential WebhookBackoff = "exponential"
+)
+
+// RetryPolicy — попытки доставки получателю; nil-поля и пустой Backoff — настройки сервиса
+type RetryPolicy struct {
+	MaxRetries        *int           `json:"max_retries,omitempty"`
+	RetryDelaySeconds *int           `json:"retry_delay_seconds,omitempty"`
+	Backoff           WebhookBackoff `json:"backoff,omitempty"`
+	TimeoutSeconds    *int           `json:"timeout_seconds,omitempty"`
+}
+
+type WebhookCompression string
+
+const (
+	CompressionGzip WebhookCompression = "gzip"
+	// CompressionNone выключает сжатие в WebhookEndpointPatchRequest; в ответах сервера без сжатия поле пустое
+	CompressionNone WebhookCompression = "none"
+)
+
+// DeliveryOptions — формат запросов к получателю. BatchSize 0 — каждое событие отдельным JSON-запросом;
+// иначе события копятся до BatchSize штук или BatchWaitMs и уходят одним NDJSON-запросом
+type DeliveryOptions struct {
+	BatchSize   int                `json:"batch_size,omitempty"`
+	BatchWaitMs int                `json:"batch_wait_ms,omitempty"`
+	Compression WebhookCompression `json:"compression,omitempty"`
+}
+
+// WebhookEndpointCreateRequest — Events: типы событий или "*" для всех; Enabled nil — получатель включен
+type WebhookEndpointCreateRequest struct {
+	URL     string             `json:"url"`
+	Secret  string             `json:"secret,omitempty"`
+	Events  []WebhookEventType `json:"events"`
+	Enabled *bool              `json:"enabled,omitempty"`
+	RetryPolicy
+	DeliveryOptions
+}
+
+// WebhookEndpointPatchRequest — частичное обновление: nil-поля не меняются, пустой Secret отключает подпись
+type WebhookEndpointPatchRequest struct {
+	URL     *string             `json:"url,omitempty"`
+	Secret  *string             `json:"secret,omitempty"`
+	Events  *[]WebhookEventType `json:"events,omitempty"`
+	Enabled *bool               `json:"enabled,omitempty"`
+
+	MaxRetries        *int            `json:"max_retries,omitempty"`
+	RetryDelaySeconds *int            `json:"retry_delay_seconds,omitempty"`
+	Backoff           *WebhookBackoff `json:"backoff,omitempty"`
+	TimeoutSeconds    *int            `json:"timeout_seconds,omitempty"`
+
+	BatchSize   *int                `json:"batch_size,omitempty"`
+	BatchWaitMs *int                `json:"batch_wait_ms,omitempty"`
+	Compression *WebhookCompression `json:"compression,omitempty"`
+}
+
+// WebhookSLO — задержка доставки получателю от создания проверки до успешной доставки за WindowMinutes.
+// TargetMs 0 — цель на сервере не задана; Breached — перцентиль Percentile выше цели
+type WebhookSLO struct {
+	EndpointID    int    `json:"endpoint_id"`
+	URL           string `json:"url"`
+	Enabled       bool   `json:"enabled"`
+	WindowMinutes int    `json:"window_minutes"`
+	Delivered     int    `json:"delivered"`
+	P50Ms         int64  `json:"p50_ms"`
+	P95Ms         int64  `json:"p95_ms"`
+	P99Ms         int64  `json:"p99_ms"`
+	Percentile    int    `json:"percentile"`
+	TargetMs      int64  `json:"target_ms"`
+	MinDeliveries int    `json:"min_deliveries"`
+	Breached      bool   `json:"breached"`
+}
+
+type WebhookSLOList struct {
+	Endpoints   []WebhookSLO `json:"endpoints"`
+	Breached    int          `json:"breached"`
+	GeneratedAt time.Time    `json:"generated_at"`
+}
+
+// WebhookRedriveRequest — фильтры повторной доставки; нулевые поля не фильтруют.
+// CreatedFrom включительно, CreatedTo — нет
+type WebhookRedriveRequest struct {
+	CreatedFrom   *time.Time `json:"created_from,omitempty"`
+	CreatedTo     *time.Time `json:"created_to,omitempty"`
+	IncidentID    int        `json:"incident_id,omitempty"`
+	EndpointID    int        `json:"endpoint_id,omitempty"`
+	SpreadSeconds int        `json:"spread_seconds,omitempty"`
+}
+
+type Dashboard struct {
+	ActiveIncidents []Incident      `json:"active_incidents"`
+	WebhookQueue    WebhookQueue    `json:"webhook_queue"`
+	RecentChecks    []Check         `json:"recent_checks"`
+	FailedWebhooks  []FailedWebhook `json:"failed_webhooks"`
+	GeneratedAt     time.Time       `json:"generated_at"`
+}
+
+// WebhookQueue — недоставленные вебхуки по состояниям
+type WebhookQueue struct {
+	Pending    int `json:"pending"`
+	Processing int `json:"processing"`
+	Failed     int `json:"failed"`
+}
+
+type Check struct {
+	ID        int       `json:"check_id"`
+	UserID    string    `json:"user_id"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	HasAlert  bool      `json:"has_alert"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookEventType — тип события вебхука, приходит в поле event и в заголовке X-Geonotify-Event
+type WebhookEventType string
+
+const (
+	WebhookLocationAlert       WebhookEventType = "location.alert"
+	WebhookIncidentCreated     WebhookEventType = "incident.created"
+	WebhookIncidentUpdated     WebhookEventType = "incident.updated"
+	WebhookIncidentDeactivated WebhookEventType = "incident.deactivated"
+	WebhookIncidentState       WebhookEventType = "incident.state_changed"
+	WebhookUserEntered         WebhookEventType = "user.entered"
+	WebhookUserExited          WebhookEventType = "user.exited"
+	WebhookUserAlert           WebhookEventType = "user.alert"
+	// WebhookGroupAlert приходит только получателю группы, см. Client.SetGroupWebhook
+	WebhookGroupAlert WebhookEventType = "group.alert"
+)
+
+// WebhookEnvelope — тело вебхука; Data разбирается получателем по Event
+type WebhookEnvelope struct {
+	Event     WebhookEventType `json:"event"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      json.RawMessage  `json:"data"`
+}
+
+type FailedWebhook struct {
+	ID         int              `json:"webhook_id"`
+	EndpointID int              `json:"endpoint_id"`
+	Event      WebhookEventType `json:"event"`
+	CheckID    int              `json:"check_id"`
+	RetryCnt   int              `json:"retry_cnt"`
+	CreatedAt  time.Time        `json:"created_at"`
+	FailedAt   time.Time        `json:"failed_at"`
+}
+
+type RuntimeConfig struct {
+	LogLevel                 string `json:"log_level"`
+	CacheTTLMinutes          int    `json:"cache_ttl_minutes"`
+	MaxRetries               int    `json:"webhook_max_retries"`
+	RetryDelaySeconds        int    `json:"webhook_retry_delay_seconds"`
+	PredictionHorizonSeconds int    `json:"prediction_horizon_seconds"`
+}
+
+// QueryStats — статистика запросов сервиса к БД
+type QueryStats struct {
+	// BucketsMs — границы корзин Buckets каждого запроса; последняя корзина — дольше всех границ
+	BucketsMs []float64   `json:"buckets_ms"`
+	Queries   []QueryStat `json:"queries"`
+}
+
+type QueryStat struct {
+	SQL     string  `json:"sql"`
+	Calls   int64   `json:"calls"`
+	Errors  int64   `json:"errors"`
+	TotalMs float64 `json:"total_ms"`
+	MeanMs  float64 `json:"mean_ms"`
+	MaxMs   float64 `json:"max_ms"`
+	Buckets []int64 `json:"buckets"`
+}
+
+// Queues — состояние доставки вебхуков: очередь задач и outbox в БД
+type Queues struct {
+	Queue       QueueDepth  `json:"queue"`
+	Outbox      OutboxDepth `json:"outbox"`
+	GeneratedAt time.Time   `json:"generated_at"`
+}
+
+// QueueDepth: Scheduled nil — брокер не сообщает число отложенных задач; Error — очередь недоступна
+type QueueDepth struct {
+	Backend   string `json:"backend"`
+	Ready     int64  `json:"ready"`
+	Scheduled *int64 `json:"scheduled,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type OutboxDepth struct {
+	Pending                 int     `json:"pending"`
+	Processing              int     `json:"processing"`
+	Failed                  int     `json:"failed"`
+	Due                     int     `json:"due"`
+	OldestPendingAgeSeconds float64 `json:"oldest_pending_age_seconds"`
+	PollerLagSeconds        float64 `json:"poller_lag_seconds"`
+}
+
+// Usage — запросы API-ключей за сутки с From по To (UTC); Enabled false — учет на сервере выключен
+type Usage struct {
+	Enabled bool          `json:"enabled"`
+	From    string        `json:"from,omitempty"`
+	To      string        `json:"to,omitempty"`
+	Keys    []APIKeyUsage `json:"keys"`
+}
+
+// APIKeyUsage: квоты 0 — без ограничения; Current — счетчики, по которым проверяются квоты;
+// Days — запросы по суткам, текущие сутки в них отстают на интервал переноса счетчиков в БД
+type APIKeyUsage struct {
+	Key          string             `json:"key"`
+	DailyQuota   int64              `json:"daily_quota"`
+	MonthlyQuota int64              `json:"monthly_quota"`
+	Current      APIKeyUsageCurrent `json:"current"`
+	Total        int64              `json:"total"`
+	Days         []UsageDay         `json:"days"`
+}
+
+// APIKeyUsageCurrent: Error — счетчики недоступны
+type APIKeyUsageCurrent struct {
+	Today     int64  `json:"today"`
+	ThisMonth int64  `json:"this_month"`
+	Error     string `json:"error,omitempty"`
+}
+
+// UsageDay: Checks — записанные проверки координат, Alerts — проверки с алертом, Webhooks — вебхуки,
+// поставленные в очередь доставки
+type UsageDay struct {
+	Day      string `json:"day"`
+	Requests int64  `json:"requests"`
+	Checks   int64  `json:"checks"`
+	Alerts   int64  `json:"alerts"`
+	Webhooks int64  `json:"webhooks"`
+}
+
+// UsageExport — выгрузка использования для биллинга: строка на ключ и сутки с использованием
+type UsageExport struct {
+	From string           `json:"from"`
+	To   string           `json:"to"`
+	Rows []UsageExportRow `json:"rows"`
+}
+
+type UsageExportRow struct {
+	APIKey   string `json:"api_key"`
+	Day      string `json:"day"`
+	Requests int64  `json:"requests"`
+	Checks   int64  `json:"checks"`
+	Alerts   int64  `json:"alerts"`
+	Webhooks int64  `json:"webhooks"`
+}
+
+// WorkerLocks — блокировки периодических задач глазами реплики Instance, обработавшей запрос
+type WorkerLocks struct {
+	Instance string       `json:"instance"`
+	Enabled  bool         `json:"enabled"`
+	Locks    []WorkerLock `json:"locks"`
+}
+
+type WorkerLock struct {
+	Name      string     `json:"name"`
+	Held      bool       `json:"held"`
+	HeldSince *time.Time `json:"held_since,omitempty"`
+	Acquired  int64      `json:"acquired"`
+	Lost      int64      `json:"lost"`
+	Runs      int64      `json:"runs"`
+	Skipped   int64      `json:"skipped"`
+	Errors    int64      `json:"errors"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+type OperatorCreateRequest struct {
+	Username    string `json:"username"`
+	Password    string `json:"password,omitempty"`
+	OIDCSubject string `json:"oidc_subject,omitempty"`
+	// Role — editor (по умолчанию) или publisher
+	Role string `json:"role,omitempty"`
+}
+
+type Token struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type Liveness struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type Readiness struct {
+	// Status — ready, degraded или not ready
+	Status    string                      `json:"status"`
+	Degraded  bool                        `json:"degraded"`
+	Timestamp time.Time                   `json:"timestamp"`
+	Checks    map[string]DependencyStatus `json:"checks"`
+}
+
+type DependencyStatus struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type AlertEvent struct {
+	Type      string     `json:"type"`
+	UserID    string     `json:"user_id"`
+	CheckID   int        `json:"check_id,omitempty"`
+	Incidents []Incident `json:"incidents"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// AlertChannel — канал доставки алертов пользователю
+type AlertChannel string
+
+const (
+	// AlertChannelStream — SSE-поток, см. Client.StreamAlerts
+	AlertChannelStream AlertChannel = "stream"
+	// AlertChannelWebhook — событие вебхука user.alert
+	AlertChannelWebhook AlertChannel = "webhook"
+)
+
+// QuietHours — окно тишины по местному времени пользователя ("22:00"); Start позже End — окно через полночь.
+// Days — дни начала окна ("mon"..."sun"), пустой — каждый день
+type QuietHours struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Days  []string `json:"days,omitempty"`
+}
+
+// UserPreferences — настройки алертов пользователя. При сохранении незаданные поля получают значения по умолчанию
+type UserPreferences struct {
+	UserID      string         `json:"user_id,omitempty"`
+	MinSeverity Severity       `json:"min_severity,omitempty"`
+	QuietHours  []QuietHours   `json:"quiet_hours,omitempty"`
+	Timezone    string         `json:"timezone,omitempty"`
+	Channels    []AlertChannel `json:"channels,omitempty"`
+	// MaxAlertsPerHour 0 — без ограничения
+	MaxAlertsPerHour int        `json:"max_alerts_per_hour,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// Group — группа пользователей; Webhook nil, пока получатель группы не зарегистрирован
+type Group struct {
+	ID          int           `json:"group_id"`
+	Name        string        `json:"name"`
+	Descr       string        `json:"descr,omitempty"`
+	Managers    []string      `json:"managers"`
+	MemberCount int           `json:"member_count"`
+	Webhook     *GroupWebhook `json:"webhook,omitempty"`
+	CreatedBy   string        `json:"created_by,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+type GroupWebhook struct {
+	EndpointID int    `json:"endpoint_id"`
+	URL        string `json:"url"`
+	HasSecret  bool   `json:"has_secret"`
+	Enabled    bool   `json:"enabled"`
+}
+
+type GroupCreateRequest struct {
+	Name     string   `json:"name"`
+	Descr    string   `json:"descr,omitempty"`
+	Managers []string `json:"managers,omitempty"`
+	UserIDs  []string `json:"user_ids,omitempty"`
+}
+
+// GroupPatchRequest — nil-поля не меняются; Managers заменяет список менеджеров целиком
+type GroupPatchRequest struct {
+	Name     *string   `json:"name,omitempty"`
+	Descr    *string   `json:"descr,omitempty"`
+	Managers *[]string `json:"managers,omitempty"`
+}
+
+type GroupStatus struct {
+	GroupID         int                 `json:"group_id"`
+	Name            string              `json:"name"`
+	MembersTotal    int                 `json:"members_total"`
+	MembersInDanger []GroupMemberStatus `json:"members_in_danger"`
+}
+
+type GroupMemberStatus struct {
+	UserID string            `json:"user_id"`
+	Zones  []GroupMemberZone `json:"zones"`
+}
+
+type GroupMemberZone struct {
+	IncidentID int       `json:"incident_id"`
+	Name       string    `json:"name"`
+	Severity   Severity  `json:"severity"`
+	EnteredAt  time.Time `json:"entered_at"`
+}
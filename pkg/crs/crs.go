@@ -0,0 +1,134 @@
+// Package crs переводит координаты из поддерживаемых систем координат в WGS84 (EPSG:4326)
+package crs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnsupported = errors.New("unsupported crs")
+	ErrOutOfBounds = errors.New("coordinates are outside the crs bounds")
+)
+
+const (
+	WGS84       = "EPSG:4326"
+	WebMercator = "EPSG:3857"
+)
+
+// параметры эллипсоида WGS84
+const (
+	semiMajorAxis = 6378137.0
+	flattening    = 1 / 298.257223563
+)
+
+// maxMercator — граница проекции Web Mercator по обеим осям, метры
+const maxMercator = 20037508.342789244
+
+// ToWGS84 переводит точку из системы code в широту и долготу WGS84.
+// x — восточная координата (долгота, easting), y — северная (широта, northing).
+// Поддерживаются EPSG:4326, EPSG:3857 и зоны UTM на WGS84 (EPSG:326NN — север, EPSG:327NN — юг);
+// пустой code означает WGS84
+func ToWGS84(code string, x, y float64) (lat, lng float64, err error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	switch {
+	case code == "" || code == WGS84:
+		return y, x, nil
+	case code == WebMercator:
+		return fromWebMercator(x, y)
+	}
+
+	zone, south, ok := parseUTM(code)
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %q", ErrUnsupported, code)
+	}
+	return fromUTM(zone, south, x, y)
+}
+
+// Supported сообщает, поддерживается ли система координат
+func Supported(code string) bool {
+	_, _, err := ToWGS84(code, 0, 0)
+	return !errors.Is(err, ErrUnsupported)
+}
+
+func fromWebMercator(x, y float64) (float64, float64, error) {
+	if math.Abs(x) > maxMercator || math.Abs(y) > maxMercator {
+		return 0, 0, ErrOutOfBounds
+	}
+
+	lng := x / semiMajorAxis * 180 / math.Pi
+	lat := (2*math.Atan(math.Exp(y/semiMajorAxis)) - math.Pi/2) * 180 / math.Pi
+
+	return lat, lng, nil
+}
+
+func parseUTM(code string) (zone int, south bool, ok bool) {
+	num, found := strings.CutPrefix(code, "EPSG:")
+	if !found || len(num) != 5 {
+		return 0, false, false
+	}
+
+	switch num[:3] {
+	case "326":
+	case "327":
+		south = true
+	default:
+		return 0, false, false
+	}
+
+	zone, err := strconv.Atoi(num[3:])
+	if err != nil || zone < 1 || zone > 60 {
+		return 0, false, false
+	}
+
+	return zone, south, true
+}
+
+// fromUTM — обратная поперечная проекция Меркатора (формулы Снайдера), точность порядка миллиметров внутри зоны
+func fromUTM(zone int, south bool, easting, northing float64) (float64, float64, error) {
+	if easting <= 0 || easting >= 1_000_000 || northing < 0 || northing > 10_000_000 {
+		return 0, 0, ErrOutOfBounds
+	}
+
+	const k0 = 0.9996
+	e2 := flattening * (2 - flattening)
+	ep2 := e2 / (1 - e2)
+
+	x := easting - 500_000
+	y := northing
+	if south {
+		y -= 10_000_000
+	}
+
+	m := y / k0
+	mu := m / (semiMajorAxis * (1 - e2/4 - 3*e2*e2/64 - 5*e2*e2*e2/256))
+
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+	phi1 := mu +
+		(3*e1/2-27*math.Pow(e1, 3)/32)*math.Sin(2*mu) +
+		(21*e1*e1/16-55*math.Pow(e1, 4)/32)*math.Sin(4*mu) +
+		(151*math.Pow(e1, 3)/96)*math.Sin(6*mu) +
+		(1097*math.Pow(e1, 4)/512)*math.Sin(8*mu)
+
+	sinPhi, cosPhi, tanPhi := math.Sin(phi1), math.Cos(phi1), math.Tan(phi1)
+	n1 := semiMajorAxis / math.Sqrt(1-e2*sinPhi*sinPhi)
+	t1 := tanPhi * tanPhi
+	c1 := ep2 * cosPhi * cosPhi
+	r1 := semiMajorAxis * (1 - e2) / math.Pow(1-e2*sinPhi*sinPhi, 1.5)
+	d := x / (n1 * k0)
+
+	lat := phi1 - (n1*tanPhi/r1)*(d*d/2-
+		(5+3*t1+10*c1-4*c1*c1-9*ep2)*math.Pow(d, 4)/24+
+		(61+90*t1+298*c1+45*t1*t1-252*ep2-3*c1*c1)*math.Pow(d, 6)/720)
+
+	lon0 := float64((zone-1)*6-180+3) * math.Pi / 180
+	lng := lon0 + (d-
+		(1+2*t1+c1)*math.Pow(d, 3)/6+
+		(5-2*c1+28*t1-3*c1*c1+8*ep2+24*t1*t1)*math.Pow(d, 5)/120)/cosPhi
+
+	return lat * 180 / math.Pi, lng * 180 / math.Pi, nil
+}
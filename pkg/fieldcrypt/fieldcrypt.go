@@ -0,0 +1,151 @@
+// Package fieldcrypt шифрует отдельные поля записей AES-256-GCM с набором ключей для ротации
+// и строит детерминированные HMAC-индексы для поиска по зашифрованному значению
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownKey = errors.New("unknown encryption key")
+	ErrMalformed  = errors.New("malformed ciphertext")
+)
+
+// KeySize — длина ключа AES-256
+const KeySize = 32
+
+// indexLabel разводит ключ шифрования и ключ HMAC-индекса, выведенный из него
+const indexLabel = "fieldcrypt blind index"
+
+type key struct {
+	aead     cipher.AEAD
+	indexKey []byte
+}
+
+// Keyring — ключи по номерам: активный шифрует новые значения, остальные нужны,
+// пока записи под ними не перешифрованы
+type Keyring struct {
+	active int
+	// ids — номера ключей, активный первым
+	ids  []int
+	keys map[int]key
+}
+
+// Parse разбирает ключи вида "2:<base64 32 байт>", первый становится активным.
+// Номер ключа — от 1 до 32767, он хранится рядом с шифротекстом
+func Parse(specs []string) (*Keyring, error) {
+	if len(specs) == 0 {
+		return nil, errors.New("no encryption keys")
+	}
+
+	k := &Keyring{keys: make(map[int]key, len(specs))}
+	for _, spec := range specs {
+		idPart, secretPart, ok := strings.Cut(strings.TrimSpace(spec), ":")
+		if !ok {
+			return nil, fmt.Errorf("key %q: expected ID:BASE64", spec)
+		}
+
+		id, err := strconv.Atoi(idPart)
+		if err != nil || id < 1 || id > math.MaxInt16 {
+			return nil, fmt.Errorf("key %q: ID must be between 1 and %d", idPart, math.MaxInt16)
+		}
+		if _, dup := k.keys[id]; dup {
+			return nil, fmt.Errorf("key %d: duplicate ID", id)
+		}
+
+		secret, err := base64.StdEncoding.DecodeString(secretPart)
+		if err != nil || len(secret) != KeySize {
+			return nil, fmt.Errorf("key %d: must be %d bytes in base64", id, KeySize)
+		}
+
+		block, err := aes.NewCipher(secret)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", id, err)
+		}
+
+		k.keys[id] = key{aead: aead, indexKey: mac(secret, []byte(indexLabel))}
+		k.ids = append(k.ids, id)
+	}
+	k.active = k.ids[0]
+
+	return k, nil
+}
+
+// Active возвращает номер ключа, которым шифруются новые значения
+func (k *Keyring) Active() int {
+	return k.active
+}
+
+// Seal шифрует plaintext активным ключом. aad не шифруется, но привязывается к шифротексту:
+// Open с другим aad вернет ошибку
+func (k *Keyring) Seal(plaintext, aad []byte) (keyID int, sealed []byte, err error) {
+	aead := k.keys[k.active].aead
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return 0, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return k.active, aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// Open расшифровывает значение, зашифрованное ключом keyID
+func (k *Keyring) Open(keyID int, sealed, aad []byte) ([]byte, error) {
+	kk, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %d", ErrUnknownKey, keyID)
+	}
+
+	size := kk.aead.NonceSize()
+	if len(sealed) < size+kk.aead.Overhead() {
+		return nil, ErrMalformed
+	}
+
+	plaintext, err := kk.aead.Open(nil, sealed[:size], sealed[size:], aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt with key %d: %w", keyID, err)
+	}
+
+	return plaintext, nil
+}
+
+// Index возвращает HMAC-индекс значения под активным ключом: одинаковые значения дают одинаковый индекс,
+// поэтому по нему можно искать и группировать, не раскрывая значение
+func (k *Keyring) Index(value string) string {
+	return k.index(k.active, value)
+}
+
+// Indexes возвращает индексы значения под всеми ключами, активный первым: до перешифровки записи
+// под старыми ключами хранят старый индекс
+func (k *Keyring) Indexes(value string) []string {
+	indexes := make([]string, len(k.ids))
+	for i, id := range k.ids {
+		indexes[i] = k.index(id, value)
+	}
+	return indexes
+}
+
+func (k *Keyring) index(keyID int, value string) string {
+	return "k" + strconv.Itoa(keyID) + ":" + hex.EncodeToString(mac(k.keys[keyID].indexKey, []byte(value)))
+}
+
+func mac(secret, data []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(data)
+	return h.Sum(nil)
+}
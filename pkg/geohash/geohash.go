@@ -0,0 +1,92 @@
+// Package geohash кодирует координаты WGS84 в геохеш и обратно
+package geohash
+
+import "strings"
+
+const alphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxLength — длина геохеша, точнее которой float64 координаты не различаются
+const MaxLength = 12
+
+// Encode возвращает геохеш ячейки длиной length символов, в которую попадает точка
+func Encode(lat, lng float64, length int) string {
+	minLat, maxLat := -90.0, 90.0
+	minLng, maxLng := -180.0, 180.0
+
+	var sb strings.Builder
+	sb.Grow(length)
+
+	even := true
+	bit, ch := 0, 0
+	for sb.Len() < length {
+		// биты долготы и широты чередуются, начиная с долготы
+		if even {
+			mid := (minLng + maxLng) / 2
+			if lng >= mid {
+				ch |= 1 << (4 - bit)
+				minLng = mid
+			} else {
+				maxLng = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if lat >= mid {
+				ch |= 1 << (4 - bit)
+				minLat = mid
+			} else {
+				maxLat = mid
+			}
+		}
+		even = !even
+
+		if bit < 4 {
+			bit++
+			continue
+		}
+		sb.WriteByte(alphabet[ch])
+		bit, ch = 0, 0
+	}
+
+	return sb.String()
+}
+
+// Bounds возвращает границы ячейки геохеша; ok false — в геохеше есть недопустимые символы
+func Bounds(hash string) (minLat, maxLat, minLng, maxLng float64, ok bool) {
+	minLat, maxLat = -90.0, 90.0
+	minLng, maxLng = -180.0, 180.0
+
+	even := true
+	for i := 0; i < len(hash); i++ {
+		ch := strings.IndexByte(alphabet, hash[i])
+		if ch < 0 {
+			return 0, 0, 0, 0, false
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := ch&(1<<bit) != 0
+			if even {
+				mid := (minLng + maxLng) / 2
+				if set {
+					minLng = mid
+				} else {
+					maxLng = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if set {
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+
+	return minLat, maxLat, minLng, maxLng, true
+}
+
+// Center возвращает центр ячейки геохеша длиной length, в которую попадает точка
+func Center(lat, lng float64, length int) (float64, float64) {
+	minLat, maxLat, minLng, maxLng, _ := Bounds(Encode(lat, lng, length))
+	return (minLat + maxLat) / 2, (minLng + maxLng) / 2
+}
@@ -0,0 +1,69 @@
+package geohash
+
+import "testing"
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lng float64
+		length   int
+		want     string
+	}{
+		{name: "wikipedia example", lat: 42.6, lng: -5.6, length: 5, want: "ezs42"},
+		{name: "long hash", lat: 57.64911, lng: 10.40744, length: 11, want: "u4pruydqqvj"},
+		{name: "zero point", lat: 0, lng: 0, length: 4, want: "s000"},
+		{name: "just below zero", lat: -1e-9, lng: -1e-9, length: 4, want: "7zzz"},
+		// границы ±90/±180 попадают в крайние ячейки, а не за пределы сетки
+		{name: "north east corner", lat: 90, lng: 180, length: 6, want: "zzzzzz"},
+		{name: "south west corner", lat: -90, lng: -180, length: 6, want: "000000"},
+		{name: "north west corner", lat: 90, lng: -180, length: 6, want: "bpbpbp"},
+		{name: "south east corner", lat: -90, lng: 180, length: 6, want: "pbpbpb"},
+		{name: "empty", lat: 10, lng: 10, length: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Encode(tt.lat, tt.lng, tt.length); got != tt.want {
+				t.Errorf("Encode(%v, %v, %d) = %q, want %q", tt.lat, tt.lng, tt.length, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCenter(t *testing.T) {
+	tests := []struct {
+		name             string
+		lat, lng         float64
+		length           int
+		wantLat, wantLng float64
+	}{
+		{name: "wikipedia example", lat: 42.6, lng: -5.6, length: 5, wantLat: 42.60498046875, wantLng: -5.60302734375},
+		{name: "whole world", lat: 12.3, lng: 45.6, length: 0, wantLat: 0, wantLng: 0},
+		{name: "north east corner", lat: 90, lng: 180, length: 1, wantLat: 67.5, wantLng: 157.5},
+		{name: "south west corner", lat: -90, lng: -180, length: 1, wantLat: -67.5, wantLng: -157.5},
+		{name: "north east corner, two chars", lat: 90, lng: 180, length: 2, wantLat: 87.1875, wantLng: 174.375},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lng := Center(tt.lat, tt.lng, tt.length)
+			if lat != tt.wantLat || lng != tt.wantLng {
+				t.Errorf("Center(%v, %v, %d) = (%v, %v), want (%v, %v)", tt.lat, tt.lng, tt.length, lat, lng, tt.wantLat, tt.wantLng)
+			}
+		})
+	}
+}
+
+func TestBounds(t *testing.T) {
+	if _, _, _, _, ok := Bounds("ezs4a"); ok {
+		t.Error("Bounds(\"ezs4a\") ok = true, want false: a is not in the alphabet")
+	}
+
+	minLat, maxLat, minLng, maxLng, ok := Bounds("ezs42")
+	if !ok {
+		t.Fatal("Bounds(\"ezs42\") ok = false")
+	}
+	if minLat > 42.6 || maxLat < 42.6 || minLng > -5.6 || maxLng < -5.6 {
+		t.Errorf("Bounds(\"ezs42\") = [%v, %v] x [%v, %v], want the cell around (42.6, -5.6)", minLat, maxLat, minLng, maxLng)
+	}
+}
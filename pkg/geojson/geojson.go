@@ -0,0 +1,351 @@
+// Package geojson разбирает и проверяет форму зоны в GeoJSON (RFC 7946):
+// Point с радиусом, Polygon или MultiPolygon, а также Feature с одной из этих геометрий
+package geojson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrInvalid = errors.New("invalid geojson")
+
+// maxVertices ограничивает размер формы: проверка самопересечений квадратична по числу вершин
+const maxVertices = 2000
+
+// Position — точка [долгота, широта]
+type Position = [2]float64
+
+// MultiPolygon — полигоны, каждый из колец: первое внешнее (против часовой стрелки), остальные — дыры (по часовой)
+type MultiPolygon [][][]Position
+
+// Shape — разобранная форма зоны
+type Shape struct {
+	// Center и Radius заданы для Point
+	Center Position
+	Radius float64
+	// Polygons заданы для Polygon и MultiPolygon
+	Polygons MultiPolygon
+}
+
+type object struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometry    *object         `json:"geometry"`
+	Features    []object        `json:"features"`
+	Properties  struct {
+		Radius float64 `json:"radius_m"`
+	} `json:"properties"`
+	// Radius — член верхнего уровня для Point без Feature
+	Radius float64 `json:"radius_m"`
+}
+
+// Parse разбирает и проверяет GeoJSON-форму зоны. Радиус Point берется из properties.radius_m
+// у Feature или из radius_m на верхнем уровне геометрии
+func Parse(data []byte) (*Shape, error) {
+	var obj object
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	radius := obj.Radius
+	if obj.Type == "Feature" {
+		if obj.Geometry == nil {
+			return nil, fmt.Errorf("%w: feature without geometry", ErrInvalid)
+		}
+		radius = obj.Properties.Radius
+		obj = *obj.Geometry
+		if obj.Radius != 0 {
+			radius = obj.Radius
+		}
+	}
+
+	switch obj.Type {
+	case "Point":
+		var coords []float64
+		if err := json.Unmarshal(obj.Coordinates, &coords); err != nil {
+			return nil, fmt.Errorf("%w: point coordinates: %v", ErrInvalid, err)
+		}
+		pos, err := toPosition(coords)
+		if err != nil {
+			return nil, err
+		}
+		if radius <= 0 {
+			return nil, fmt.Errorf("%w: point requires radius_m > 0", ErrInvalid)
+		}
+		return &Shape{Center: pos, Radius: radius}, nil
+
+	case "Polygon":
+		var coords [][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &coords); err != nil {
+			return nil, fmt.Errorf("%w: polygon coordinates: %v", ErrInvalid, err)
+		}
+		return parsePolygons([][][][]float64{coords}, true)
+
+	case "MultiPolygon":
+		var coords [][][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &coords); err != nil {
+			return nil, fmt.Errorf("%w: multipolygon coordinates: %v", ErrInvalid, err)
+		}
+		return parsePolygons(coords, true)
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported type %q, expected Point, Polygon or MultiPolygon", ErrInvalid, obj.Type)
+	}
+}
+
+// ParseBoundaries разбирает границы регионов: FeatureCollection, Feature, Polygon или MultiPolygon,
+// объединяя все полигоны. В отличие от Parse, ориентация колец, самопересечения и число вершин
+// не проверяются: границы стран из внешних источников часто им не соответствуют, а для проверки
+// попадания точки это не важно
+func ParseBoundaries(data []byte) (MultiPolygon, error) {
+	var obj object
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return boundaries(obj)
+}
+
+func boundaries(obj object) (MultiPolygon, error) {
+	switch obj.Type {
+	case "FeatureCollection":
+		var all MultiPolygon
+		for i, f := range obj.Features {
+			polygons, err := boundaries(f)
+			if err != nil {
+				return nil, fmt.Errorf("feature %d: %w", i, err)
+			}
+			all = append(all, polygons...)
+		}
+		if len(all) == 0 {
+			return nil, fmt.Errorf("%w: no polygons in feature collection", ErrInvalid)
+		}
+		return all, nil
+
+	case "Feature":
+		if obj.Geometry == nil {
+			return nil, fmt.Errorf("%w: feature without geometry", ErrInvalid)
+		}
+		return boundaries(*obj.Geometry)
+
+	case "Polygon":
+		var coords [][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &coords); err != nil {
+			return nil, fmt.Errorf("%w: polygon coordinates: %v", ErrInvalid, err)
+		}
+		shape, err := parsePolygons([][][][]float64{coords}, false)
+		if err != nil {
+			return nil, err
+		}
+		return shape.Polygons, nil
+
+	case "MultiPolygon":
+		var coords [][][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &coords); err != nil {
+			return nil, fmt.Errorf("%w: multipolygon coordinates: %v", ErrInvalid, err)
+		}
+		shape, err := parsePolygons(coords, false)
+		if err != nil {
+			return nil, err
+		}
+		return shape.Polygons, nil
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported type %q, expected FeatureCollection, Feature, Polygon or MultiPolygon", ErrInvalid, obj.Type)
+	}
+}
+
+// Marshal кодирует полигоны как GeoJSON MultiPolygon
+func (mp MultiPolygon) Marshal() ([]byte, error) {
+	return json.Marshal(struct {
+		Type        string       `json:"type"`
+		Coordinates MultiPolygon `json:"coordinates"`
+	}{"MultiPolygon", mp})
+}
+
+// Unmarshal разбирает GeoJSON MultiPolygon, сохраненный Marshal, без повторной проверки
+func Unmarshal(data []byte) (MultiPolygon, error) {
+	var obj struct {
+		Coordinates MultiPolygon `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	return obj.Coordinates, nil
+}
+
+func toPosition(coords []float64) (Position, error) {
+	// третья координата (высота) допускается стандартом и игнорируется
+	if len(coords) < 2 || len(coords) > 3 {
+		return Position{}, fmt.Errorf("%w: position must have 2 or 3 numbers", ErrInvalid)
+	}
+	lng, lat := coords[0], coords[1]
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return Position{}, fmt.Errorf("%w: position [%v, %v] is out of range", ErrInvalid, lng, lat)
+	}
+	return Position{lng, lat}, nil
+}
+
+// parsePolygons в строгом режиме дополнительно проверяет ориентацию колец, самопересечения и число вершин
+func parsePolygons(coords [][][][]float64, strict bool) (*Shape, error) {
+	if len(coords) == 0 {
+		return nil, fmt.Errorf("%w: no polygons", ErrInvalid)
+	}
+
+	vertices := 0
+	polygons := make(MultiPolygon, len(coords))
+	for p, polygon := range coords {
+		if len(polygon) == 0 {
+			return nil, fmt.Errorf("%w: polygon %d has no rings", ErrInvalid, p)
+		}
+
+		polygons[p] = make([][]Position, len(polygon))
+		for r, ring := range polygon {
+			vertices += len(ring)
+			if strict && vertices > maxVertices {
+				return nil, fmt.Errorf("%w: too many vertices, max %d", ErrInvalid, maxVertices)
+			}
+
+			positions := make([]Position, len(ring))
+			for i, c := range ring {
+				pos, err := toPosition(c)
+				if err != nil {
+					return nil, err
+				}
+				positions[i] = pos
+			}
+
+			if err := validateRing(positions, r == 0, strict); err != nil {
+				return nil, fmt.Errorf("%w: polygon %d ring %d: %v", ErrInvalid, p, r, err)
+			}
+			polygons[p][r] = positions
+		}
+
+		if !strict {
+			continue
+		}
+		if err := validateRingsDisjoint(polygons[p]); err != nil {
+			return nil, fmt.Errorf("%w: polygon %d: %v", ErrInvalid, p, err)
+		}
+	}
+
+	return &Shape{Polygons: polygons}, nil
+}
+
+// ExteriorRing замыкает кольцо и при необходимости разворачивает его против часовой стрелки:
+// так приводятся кольца из форматов без требований к ориентации (CAP, GeoRSS)
+func ExteriorRing(ring []Position) ([]Position, error) {
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		ring = append(ring[:len(ring):len(ring)], ring[0])
+	}
+	if len(ring) < 4 {
+		return nil, fmt.Errorf("%w: ring must have at least 3 distinct positions", ErrInvalid)
+	}
+	for _, pos := range ring {
+		if _, err := toPosition([]float64{pos[0], pos[1]}); err != nil {
+			return nil, err
+		}
+	}
+
+	area := signedArea(ring)
+	if area == 0 {
+		return nil, fmt.Errorf("%w: ring has zero area", ErrInvalid)
+	}
+	if area < 0 {
+		reversed := make([]Position, len(ring))
+		for i, pos := range ring {
+			reversed[len(ring)-1-i] = pos
+		}
+		ring = reversed
+	}
+
+	return ring, nil
+}
+
+func validateRing(ring []Position, exterior, strict bool) error {
+	if len(ring) < 4 {
+		return errors.New("ring must have at least 4 positions")
+	}
+	if ring[0] != ring[len(ring)-1] {
+		return errors.New("ring is not closed: first and last positions differ")
+	}
+	if !strict {
+		return nil
+	}
+
+	area := signedArea(ring)
+	switch {
+	case area == 0:
+		return errors.New("ring has zero area")
+	case exterior && area < 0:
+		return errors.New("exterior ring must be counterclockwise")
+	case !exterior && area > 0:
+		return errors.New("hole must be clockwise")
+	}
+
+	n := len(ring) - 1
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			// соседние отрезки делят вершину, первый и последний тоже
+			if j == i+1 || (i == 0 && j == n-1) {
+				continue
+			}
+			if segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				return fmt.Errorf("ring self-intersects between edges %d and %d", i, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRingsDisjoint проверяет, что границы колец одного полигона не пересекаются
+func validateRingsDisjoint(rings [][]Position) error {
+	for a := 0; a < len(rings); a++ {
+		for b := a + 1; b < len(rings); b++ {
+			for i := 0; i+1 < len(rings[a]); i++ {
+				for j := 0; j+1 < len(rings[b]); j++ {
+					if segmentsIntersect(rings[a][i], rings[a][i+1], rings[b][j], rings[b][j+1]) {
+						return fmt.Errorf("rings %d and %d intersect", a, b)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// signedArea — удвоенная площадь кольца на плоскости долгота/широта: > 0 против часовой стрелки
+func signedArea(ring []Position) float64 {
+	var sum float64
+	for i := 0; i+1 < len(ring); i++ {
+		sum += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return sum
+}
+
+func segmentsIntersect(p1, p2, q1, q2 Position) bool {
+	d1 := cross(q1, q2, p1)
+	d2 := cross(q1, q2, p2)
+	d3 := cross(p1, p2, q1)
+	d4 := cross(p1, p2, q2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	return (d1 == 0 && onSegment(q1, q2, p1)) ||
+		(d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) ||
+		(d4 == 0 && onSegment(p1, p2, q2))
+}
+
+func cross(a, b, c Position) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+func onSegment(a, b, p Position) bool {
+	return math.Min(a[0], b[0]) <= p[0] && p[0] <= math.Max(a[0], b[0]) &&
+		math.Min(a[1], b[1]) <= p[1] && p[1] <= math.Max(a[1], b[1])
+}
@@ -0,0 +1,125 @@
+package geojson
+
+import "math"
+
+const earthRadiusM = 6371000
+
+// Contains сообщает, лежит ли точка внутри какого-либо полигона (с учетом дыр).
+// Ребра считаются прямыми на плоскости долгота/широта, что верно для зон размером в километры
+func (mp MultiPolygon) Contains(lat, lng float64) bool {
+	p := Position{lng, lat}
+	for _, polygon := range mp {
+		if !ringContains(polygon[0], p) {
+			continue
+		}
+
+		inHole := false
+		for _, hole := range polygon[1:] {
+			if ringContains(hole, p) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// BoundaryDistance возвращает расстояние в метрах от точки до ближайшей границы полигонов
+func (mp MultiPolygon) BoundaryDistance(lat, lng float64) float64 {
+	// локальная равнопромежуточная проекция с центром в точке
+	kx := math.Cos(lat*math.Pi/180) * earthRadiusM * math.Pi / 180
+	ky := earthRadiusM * math.Pi / 180
+	project := func(pos Position) (float64, float64) {
+		return (pos[0] - lng) * kx, (pos[1] - lat) * ky
+	}
+
+	best := math.Inf(1)
+	for _, polygon := range mp {
+		for _, ring := range polygon {
+			for i := 0; i+1 < len(ring); i++ {
+				ax, ay := project(ring[i])
+				bx, by := project(ring[i+1])
+				best = math.Min(best, distanceToSegment(ax, ay, bx, by))
+			}
+		}
+	}
+	return best
+}
+
+// BoundingCircle возвращает круг, охватывающий все полигоны: центр — среднее вершин внешних колец
+func (mp MultiPolygon) BoundingCircle() (lat, lng, radius float64) {
+	var n int
+	for _, polygon := range mp {
+		// последняя вершина кольца повторяет первую
+		for _, pos := range polygon[0][:len(polygon[0])-1] {
+			lng += pos[0]
+			lat += pos[1]
+			n++
+		}
+	}
+	lat /= float64(n)
+	lng /= float64(n)
+
+	for _, polygon := range mp {
+		for _, pos := range polygon[0] {
+			radius = math.Max(radius, haversine(lat, lng, pos[1], pos[0]))
+		}
+	}
+
+	return lat, lng, math.Ceil(radius)
+}
+
+// Circle приближает круг многоугольником из segments вершин: так круглую зону можно объединить с полигонами
+func Circle(lat, lng, radius float64, segments int) MultiPolygon {
+	dLat := radius / earthRadiusM * 180 / math.Pi
+	dLng := dLat / math.Cos(lat*math.Pi/180)
+
+	ring := make([]Position, 0, segments+1)
+	for i := 0; i < segments; i++ {
+		// обход против часовой стрелки, как требуется для внешнего кольца
+		a := 2 * math.Pi * float64(i) / float64(segments)
+		ring = append(ring, Position{lng + dLng*math.Cos(a), lat + dLat*math.Sin(a)})
+	}
+	ring = append(ring, ring[0])
+
+	return MultiPolygon{{ring}}
+}
+
+// ringContains — проверка четности пересечений луча с ребрами кольца
+func ringContains(ring []Position, p Position) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a[1] > p[1]) != (b[1] > p[1]) &&
+			p[0] < (b[0]-a[0])*(p[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// distanceToSegment — расстояние от начала координат до отрезка AB на плоскости
+func distanceToSegment(ax, ay, bx, by float64) float64 {
+	dx, dy := bx-ax, by-ay
+	lenSq := dx*dx + dy*dy
+
+	t := 0.0
+	if lenSq > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lenSq))
+	}
+
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
+
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLon := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return earthRadiusM * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
@@ -0,0 +1,10 @@
+// Package h3cell переводит координаты WGS84 в ячейки H3 и обратно. Ячейки считает библиотека H3 на C,
+// поэтому без cgo пакет собирается заглушкой: Supported == false, а FromLatLng и Center не вызываются
+package h3cell
+
+import "strconv"
+
+// String возвращает индекс ячейки в принятой в H3 шестнадцатеричной записи
+func String(cell int64) string {
+	return strconv.FormatUint(uint64(cell), 16)
+}
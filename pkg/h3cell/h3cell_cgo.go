@@ -0,0 +1,19 @@
+//go:build cgo
+
+package h3cell
+
+import h3 "github.com/uber/h3-go/v4"
+
+// Supported сообщает, собран ли пакет с библиотекой H3
+const Supported = true
+
+// FromLatLng возвращает ячейку разрешения resolution, в которую попадает точка
+func FromLatLng(lat, lng float64, resolution int) int64 {
+	return int64(h3.LatLngToCell(h3.NewLatLng(lat, lng), resolution))
+}
+
+// Center возвращает координаты центра ячейки
+func Center(cell int64) (lat, lng float64) {
+	center := h3.Cell(cell).LatLng()
+	return center.Lat, center.Lng
+}
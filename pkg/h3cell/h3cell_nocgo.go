@@ -0,0 +1,14 @@
+//go:build !cgo
+
+package h3cell
+
+// Supported сообщает, собран ли пакет с библиотекой H3
+const Supported = false
+
+func FromLatLng(lat, lng float64, resolution int) int64 {
+	panic("h3cell: built without cgo")
+}
+
+func Center(cell int64) (lat, lng float64) {
+	panic("h3cell: built without cgo")
+}
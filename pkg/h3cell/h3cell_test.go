@@ -0,0 +1,18 @@
+//go:build cgo
+
+package h3cell
+
+import "testing"
+
+func TestFromLatLng(t *testing.T) {
+	// ячейка из документации H3: https://h3geo.org/docs/api/indexing
+	cell := FromLatLng(37.3615593, -122.0553238, 7)
+	if got := String(cell); got != "87283472bffffff" {
+		t.Fatalf("String(FromLatLng()) = %s, want 87283472bffffff", got)
+	}
+
+	lat, lng := Center(cell)
+	if back := FromLatLng(lat, lng, 7); back != cell {
+		t.Errorf("FromLatLng(Center()) = %s, want %s", String(back), String(cell))
+	}
+}
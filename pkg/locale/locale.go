@@ -0,0 +1,69 @@
+// Package locale разбирает языковые теги BCP 47 и подбирает перевод по предпочтениям клиента
+package locale
+
+import (
+	"golang.org/x/text/language"
+)
+
+// anyLanguage — так x/text разбирает "*" из Accept-Language
+var anyLanguage = language.Make("mul")
+
+// Canonical приводит тег к канонической форме (EN-us → en-US); ok false — тег некорректен
+func Canonical(tag string) (string, bool) {
+	t, err := language.Parse(tag)
+	if err != nil || t == language.Und {
+		return "", false
+	}
+	return t.String(), true
+}
+
+// ParseAcceptLanguage возвращает языки из заголовка Accept-Language по убыванию веса.
+// Языки с q=0, "*" и некорректный заголовок пропускаются
+func ParseAcceptLanguage(header string) []string {
+	if header == "" {
+		return nil
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	locales := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t == language.Und || t == anyLanguage {
+			continue
+		}
+		locales = append(locales, t.String())
+	}
+	return locales
+}
+
+// Match выбирает из available язык для первого подходящего из preferred: сначала точное совпадение,
+// затем тот же язык без учета региона и письменности (pt-BR подходит к pt и pt-PT).
+// ok false — ни один язык не подошел, и клиент получает исходный текст
+func Match(preferred, available []string) (string, bool) {
+	for _, p := range preferred {
+		for _, a := range available {
+			if a == p {
+				return a, true
+			}
+		}
+
+		want, err := language.Parse(p)
+		if err != nil {
+			continue
+		}
+		wantBase, _ := want.Base()
+		for _, a := range available {
+			t, err := language.Parse(a)
+			if err != nil {
+				continue
+			}
+			if base, _ := t.Base(); base == wantBase {
+				return a, true
+			}
+		}
+	}
+	return "", false
+}
@@ -1,15 +1,19 @@
 package logger
 
 import (
+	"context"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/go-chi/chi"
 	"go.uber.org/zap"
 )
 
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
+	bytes      int
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
@@ -17,7 +21,47 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-func Log(l *zap.Logger) func(next http.Handler) http.Handler {
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += n
+	return n, err
+}
+
+// Unwrap дает http.ResponseController доступ к Flush исходного writer (нужно для SSE)
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Sampling ограничивает запись успешных (2xx) запросов: в каждую секунду по каждому маршруту
+// пишутся первые Initial запросов, затем каждый Thereafter-й; Thereafter 0 — остальные не пишутся.
+// Нулевой Sampling пишет все запросы. Ответы не 2xx пишутся всегда
+type Sampling struct {
+	Initial    int
+	Thereafter int
+}
+
+type accessFieldsKey struct{}
+
+type accessFields struct {
+	mu     sync.Mutex
+	fields []zap.Field
+}
+
+// AddAccessFields дописывает поля в строку access log текущего запроса: так обработчики
+// и middleware, работающие после Log, сообщают, например, пользователя или API-ключ
+func AddAccessFields(ctx context.Context, fields ...zap.Field) {
+	af, ok := ctx.Value(accessFieldsKey{}).(*accessFields)
+	if !ok {
+		return
+	}
+	af.mu.Lock()
+	af.fields = append(af.fields, fields...)
+	af.mu.Unlock()
+}
+
+func Log(l *zap.Logger, sampling Sampling) func(next http.Handler) http.Handler {
+	sampler := newRouteSampler(sampling)
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -26,20 +70,89 @@ func Log(l *zap.Logger) func(next http.Handler) http.Handler {
 				ResponseWriter: w,
 				statusCode:     http.StatusOK,
 			}
+			af := &accessFields{}
 
-			next.ServeHTTP(wrapped, r)
+			next.ServeHTTP(wrapped, r.WithContext(context.WithValue(r.Context(), accessFieldsKey{}, af)))
 
 			duration := time.Since(start)
 
-			l.Info("HTTP req",
+			// шаблон маршрута вместо пути, чтобы запросы к разным объектам группировались
+			route := r.URL.Path
+			rctx := chi.RouteContext(r.Context())
+			if rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+
+			success := wrapped.statusCode >= 200 && wrapped.statusCode < 300
+			if success && !sampler.sample(route, start) {
+				return
+			}
+
+			fields := []zap.Field{
 				zap.String("method", r.Method),
+				zap.String("route", route),
 				zap.String("path", r.URL.Path),
 				zap.String("query", r.URL.RawQuery),
-				zap.Duration("duration", duration),
 				zap.Int("status", wrapped.statusCode),
+				zap.Int("bytes", wrapped.bytes),
+				zap.Duration("duration", duration),
 				zap.String("ip", r.RemoteAddr),
-			)
+			}
+			if rctx != nil {
+				if userID := rctx.URLParam("user_id"); userID != "" {
+					fields = append(fields, zap.String("user_id", userID))
+				}
+			}
+			af.mu.Lock()
+			fields = append(fields, af.fields...)
+			af.mu.Unlock()
 
+			l.Info("HTTP req", fields...)
 		})
 	}
 }
+
+// routeSampler считает успешные запросы каждого маршрута в пределах текущей секунды
+type routeSampler struct {
+	Sampling
+
+	mu     sync.Mutex
+	counts map[string]*routeCount
+}
+
+type routeCount struct {
+	second int64
+	n      int
+}
+
+func newRouteSampler(sampling Sampling) *routeSampler {
+	return &routeSampler{
+		Sampling: sampling,
+		counts:   make(map[string]*routeCount),
+	}
+}
+
+func (s *routeSampler) sample(route string, at time.Time) bool {
+	if s.Initial <= 0 && s.Thereafter <= 0 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counts[route]
+	if !ok {
+		c = &routeCount{}
+		s.counts[route] = c
+	}
+	if second := at.Unix(); c.second != second {
+		c.second = second
+		c.n = 0
+	}
+	c.n++
+
+	if c.n <= s.Initial {
+		return true
+	}
+	return s.Thereafter > 0 && (c.n-s.Initial)%s.Thereafter == 0
+}
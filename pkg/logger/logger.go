@@ -1,18 +1,20 @@
 package logger
 
 import (
+	"fmt"
 	"os"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-func New(level string) (*zap.Logger, error) {
-	var l zapcore.Level
-	if err := l.Set(level); err != nil {
-		return nil, err
-	}
+// ParseLevel возвращает уровень, который можно менять у уже созданного логгера
+func ParseLevel(level string) (zap.AtomicLevel, error) {
+	return zap.ParseAtomicLevel(level)
+}
 
+func New(l zap.AtomicLevel) (*zap.Logger, error) {
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.TimeKey = "timestamp"
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
@@ -31,14 +33,9 @@ func New(level string) (*zap.Logger, error) {
 	return logger, nil
 }
 
-func NewDevelopment(level string) (*zap.Logger, error) {
-	var l zapcore.Level
-	if err := l.Set(level); err != nil {
-		return nil, err
-	}
-
+func NewDevelopment(l zap.AtomicLevel) (*zap.Logger, error) {
 	config := zap.NewDevelopmentConfig()
-	config.Level = zap.NewAtomicLevelAt(l)
+	config.Level = l
 	config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	config.EncoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout("15:04:05.000")
 	config.EncoderConfig.EncodeCaller = zapcore.ShortCallerEncoder
@@ -46,12 +43,7 @@ func NewDevelopment(level string) (*zap.Logger, error) {
 	return config.Build()
 }
 
-func NewPretty(level string) (*zap.Logger, error) {
-	var l zapcore.Level
-	if err := l.Set(level); err != nil {
-		return nil, err
-	}
-
+func NewPretty(l zap.AtomicLevel) (*zap.Logger, error) {
 	encoderConfig := zapcore.EncoderConfig{
 		TimeKey:        "ts",
 		LevelKey:       "level",
@@ -74,3 +66,86 @@ func NewPretty(level string) (*zap.Logger, error) {
 
 	return zap.New(core, zap.AddCaller()), nil
 }
+
+// Options — формат и назначение логов для Build
+type Options struct {
+	// Format — json или console
+	Format string
+	// Outputs — stdout, stderr или пути к файлам; пустой — stdout
+	Outputs []string
+	// Development — режим разработки: DPanic паникует, стектрейсы с уровня Warn
+	Development bool
+
+	// ротация файлов: размер файла, число и возраст старых файлов (0 — не ограничены)
+	FileMaxSizeMB  int
+	FileMaxBackups int
+	FileMaxAgeDays int
+	FileCompress   bool
+}
+
+// Build собирает логгер по Options: каждый выход получает свой core, файлы ротируются lumberjack.
+// Цвет уровней в console-формате включается только для stdout и stderr
+func Build(l zap.AtomicLevel, opts Options) (*zap.Logger, error) {
+	outputs := opts.Outputs
+	if len(outputs) == 0 {
+		outputs = []string{"stdout"}
+	}
+
+	cores := make([]zapcore.Core, 0, len(outputs))
+	for _, output := range outputs {
+		var (
+			sink     zapcore.WriteSyncer
+			terminal bool
+		)
+		switch output {
+		case "stdout":
+			sink, terminal = zapcore.Lock(os.Stdout), true
+		case "stderr":
+			sink, terminal = zapcore.Lock(os.Stderr), true
+		default:
+			sink = zapcore.AddSync(&lumberjack.Logger{
+				Filename:   output,
+				MaxSize:    opts.FileMaxSizeMB,
+				MaxBackups: opts.FileMaxBackups,
+				MaxAge:     opts.FileMaxAgeDays,
+				Compress:   opts.FileCompress,
+			})
+		}
+
+		encoder, err := newEncoder(opts.Format, terminal)
+		if err != nil {
+			return nil, err
+		}
+		cores = append(cores, zapcore.NewCore(encoder, sink, l))
+	}
+
+	zapOpts := []zap.Option{zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)}
+	if opts.Development {
+		zapOpts = []zap.Option{zap.AddCaller(), zap.AddStacktrace(zapcore.WarnLevel), zap.Development()}
+	}
+
+	return zap.New(zapcore.NewTee(cores...), zapOpts...), nil
+}
+
+func newEncoder(format string, terminal bool) (zapcore.Encoder, error) {
+	switch format {
+	case "json":
+		encoderConfig := zap.NewProductionEncoderConfig()
+		encoderConfig.TimeKey = "timestamp"
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoderConfig.LevelKey = "level"
+		encoderConfig.MessageKey = "message"
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	case "console":
+		encoderConfig := zap.NewDevelopmentEncoderConfig()
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		if terminal {
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
+		encoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout("15:04:05.000")
+		encoderConfig.EncodeCaller = zapcore.ShortCallerEncoder
+		return zapcore.NewConsoleEncoder(encoderConfig), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q (must be json or console)", format)
+	}
+}
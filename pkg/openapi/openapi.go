@@ -0,0 +1,377 @@
+// Package openapi переводит спецификацию Swagger 2.0, которую генерирует swag по аннотациям ручек,
+// в OpenAPI 3.0 для генераторов клиентских SDK
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const Version = "3.0.3"
+
+const defaultMediaType = "application/json"
+
+// поля параметра Swagger 2.0, которые в OpenAPI 3 переезжают в schema
+var parameterSchemaKeys = []string{
+	"type", "format", "items", "default", "enum",
+	"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum",
+	"minLength", "maxLength", "pattern", "minItems", "maxItems", "uniqueItems", "multipleOf",
+}
+
+// FromSwagger2 конвертирует документ Swagger 2.0. trimSchemaPrefix убирается из имен схем:
+// swag при совпадении коротких имен называет модели полным путем пакета
+func FromSwagger2(doc []byte, trimSchemaPrefix string) ([]byte, error) {
+	var src map[string]any
+	if err := json.Unmarshal(doc, &src); err != nil {
+		return nil, fmt.Errorf("failed to parse swagger document: %w", err)
+	}
+	if v, _ := src["swagger"].(string); v != "2.0" {
+		return nil, fmt.Errorf("unsupported swagger version %q", v)
+	}
+
+	c := converter{
+		trimPrefix: trimSchemaPrefix,
+		consumes:   stringList(src["consumes"]),
+		produces:   stringList(src["produces"]),
+	}
+
+	out := map[string]any{
+		"openapi": Version,
+		"info":    src["info"],
+		"servers": []any{map[string]any{"url": c.serverURL(src)}},
+	}
+	if tags, ok := src["tags"]; ok {
+		out["tags"] = tags
+	}
+	if security, ok := src["security"]; ok {
+		out["security"] = security
+	}
+
+	paths := map[string]any{}
+	srcPaths, _ := src["paths"].(map[string]any)
+	for path, item := range srcPaths {
+		paths[path] = c.pathItem(asMap(item))
+	}
+	out["paths"] = paths
+
+	components := map[string]any{}
+	if defs := asMap(src["definitions"]); len(defs) > 0 {
+		schemas := make(map[string]any, len(defs))
+		for name, schema := range defs {
+			schemas[c.schemaName(name)] = c.schema(schema)
+		}
+		components["schemas"] = schemas
+	}
+	if defs := asMap(src["securityDefinitions"]); len(defs) > 0 {
+		schemes := make(map[string]any, len(defs))
+		for name, def := range defs {
+			schemes[name] = securityScheme(asMap(def))
+		}
+		components["securitySchemes"] = schemes
+	}
+	if len(components) > 0 {
+		out["components"] = components
+	}
+
+	return json.MarshalIndent(out, "", "    ")
+}
+
+type converter struct {
+	trimPrefix string
+	consumes   []string
+	produces   []string
+}
+
+func (c converter) serverURL(src map[string]any) string {
+	basePath, _ := src["basePath"].(string)
+	if basePath == "" {
+		basePath = "/"
+	}
+	host, _ := src["host"].(string)
+	if host == "" {
+		return basePath
+	}
+	scheme := "http"
+	if schemes := stringList(src["schemes"]); len(schemes) > 0 {
+		scheme = schemes[0]
+	}
+	return scheme + "://" + host + strings.TrimSuffix(basePath, "/")
+}
+
+func (c converter) pathItem(item map[string]any) map[string]any {
+	out := make(map[string]any, len(item))
+	for key, value := range item {
+		switch key {
+		case "parameters":
+			params, _ := c.parameters(value, c.consumes)
+			out[key] = params
+		case "get", "put", "post", "delete", "options", "head", "patch":
+			out[key] = c.operation(asMap(value))
+		default:
+			out[key] = value
+		}
+	}
+	return out
+}
+
+func (c converter) operation(op map[string]any) map[string]any {
+	consumes := c.consumes
+	if v := stringList(op["consumes"]); len(v) > 0 {
+		consumes = v
+	}
+	produces := c.produces
+	if v := stringList(op["produces"]); len(v) > 0 {
+		produces = v
+	}
+
+	out := make(map[string]any, len(op))
+	for key, value := range op {
+		switch key {
+		case "consumes", "produces", "schemes":
+		case "parameters":
+			params, body := c.parameters(value, consumes)
+			if len(params) > 0 {
+				out["parameters"] = params
+			}
+			if body != nil {
+				out["requestBody"] = body
+			}
+		case "responses":
+			responses := map[string]any{}
+			for code, resp := range asMap(value) {
+				responses[code] = c.response(asMap(resp), produces)
+			}
+			out[key] = responses
+		default:
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// parameters делит параметры на обычные и тело запроса: body и formData в OpenAPI 3 — requestBody
+func (c converter) parameters(value any, consumes []string) ([]any, map[string]any) {
+	var (
+		params   []any
+		body     map[string]any
+		form     = map[string]any{}
+		required []string
+		hasFile  bool
+	)
+
+	list, _ := value.([]any)
+	for _, p := range list {
+		param := asMap(p)
+		switch param["in"] {
+		case "body":
+			body = map[string]any{
+				"content": c.content(consumes, c.schema(param["schema"])),
+			}
+			if desc, ok := param["description"]; ok {
+				body["description"] = desc
+			}
+			if req, _ := param["required"].(bool); req {
+				body["required"] = true
+			}
+
+		case "formData":
+			name, _ := param["name"].(string)
+			prop := c.parameterSchema(param)
+			if prop["type"] == "file" {
+				prop = map[string]any{"type": "string", "format": "binary"}
+				hasFile = true
+			}
+			if desc, ok := param["description"]; ok {
+				prop["description"] = desc
+			}
+			form[name] = prop
+			if req, _ := param["required"].(bool); req {
+				required = append(required, name)
+			}
+
+		default:
+			converted := map[string]any{"schema": c.parameterSchema(param)}
+			for _, key := range []string{"name", "in", "description", "required", "allowEmptyValue"} {
+				if v, ok := param[key]; ok {
+					converted[key] = v
+				}
+			}
+			if param["collectionFormat"] == "multi" {
+				converted["explode"] = true
+			}
+			params = append(params, converted)
+		}
+	}
+
+	if len(form) > 0 {
+		mediaType := "application/x-www-form-urlencoded"
+		if hasFile || contains(consumes, "multipart/form-data") {
+			mediaType = "multipart/form-data"
+		}
+		schema := map[string]any{"type": "object", "properties": form}
+		if len(required) > 0 {
+			sort.Strings(required)
+			schema["required"] = required
+		}
+		body = map[string]any{
+			"required": len(required) > 0,
+			"content":  map[string]any{mediaType: map[string]any{"schema": schema}},
+		}
+	}
+
+	return params, body
+}
+
+func (c converter) parameterSchema(param map[string]any) map[string]any {
+	schema := map[string]any{}
+	for _, key := range parameterSchemaKeys {
+		if v, ok := param[key]; ok {
+			schema[key] = c.schema(v)
+		}
+	}
+	return schema
+}
+
+func (c converter) response(resp map[string]any, produces []string) map[string]any {
+	out := map[string]any{}
+	desc, _ := resp["description"].(string)
+	out["description"] = desc
+
+	if schema, ok := resp["schema"]; ok {
+		out["content"] = c.content(produces, c.schema(schema))
+	}
+	if headers := asMap(resp["headers"]); len(headers) > 0 {
+		converted := make(map[string]any, len(headers))
+		for name, h := range headers {
+			header := asMap(h)
+			schema := map[string]any{"schema": c.parameterSchema(header)}
+			if d, ok := header["description"]; ok {
+				schema["description"] = d
+			}
+			converted[name] = schema
+		}
+		out["headers"] = converted
+	}
+	return out
+}
+
+func (c converter) content(mediaTypes []string, schema any) map[string]any {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{defaultMediaType}
+	}
+	content := make(map[string]any, len(mediaTypes))
+	for _, mt := range mediaTypes {
+		content[mt] = map[string]any{"schema": schema}
+	}
+	return content
+}
+
+// schema рекурсивно переписывает ссылки на definitions и расширения Swagger 2.0
+func (c converter) schema(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			switch key {
+			case "$ref":
+				ref, _ := value.(string)
+				if name, ok := strings.CutPrefix(ref, "#/definitions/"); ok {
+					ref = "#/components/schemas/" + c.schemaName(name)
+				}
+				out[key] = ref
+			case "x-nullable":
+				out["nullable"] = value
+			case "type":
+				if value == "file" {
+					out["type"] = "string"
+					out["format"] = "binary"
+					continue
+				}
+				out[key] = value
+			default:
+				out[key] = c.schema(value)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			out[i] = c.schema(value)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (c converter) schemaName(name string) string {
+	if c.trimPrefix == "" {
+		return name
+	}
+	return strings.TrimPrefix(name, c.trimPrefix)
+}
+
+func securityScheme(def map[string]any) map[string]any {
+	out := map[string]any{}
+	if desc, ok := def["description"]; ok {
+		out["description"] = desc
+	}
+
+	switch def["type"] {
+	case "basic":
+		out["type"] = "http"
+		out["scheme"] = "basic"
+	case "oauth2":
+		out["type"] = "oauth2"
+		flow := map[string]any{"scopes": def["scopes"]}
+		if flow["scopes"] == nil {
+			flow["scopes"] = map[string]any{}
+		}
+		if v, ok := def["authorizationUrl"]; ok {
+			flow["authorizationUrl"] = v
+		}
+		if v, ok := def["tokenUrl"]; ok {
+			flow["tokenUrl"] = v
+		}
+		flows := map[string]string{
+			"implicit":    "implicit",
+			"password":    "password",
+			"application": "clientCredentials",
+			"accessCode":  "authorizationCode",
+		}
+		name, _ := def["flow"].(string)
+		out["flows"] = map[string]any{flows[name]: flow}
+	default:
+		out["type"] = def["type"]
+		out["name"] = def["name"]
+		out["in"] = def["in"]
+	}
+	return out
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func stringList(v any) []string {
+	list, _ := v.([]any)
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
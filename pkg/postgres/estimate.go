@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// EstimateRows возвращает оценку планировщика числа строк запроса без его выполнения. Оценка строится
+// по pg_class.reltuples и статистике столбцов, обновляемым ANALYZE/autovacuum, поэтому может заметно
+// отличаться от точного COUNT(*), зато не зависит от размера таблицы
+func EstimateRows(ctx context.Context, q Querier, sql string, args ...any) (int, error) {
+	var plan string
+	if err := q.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+sql, args...).Scan(&plan); err != nil {
+		return 0, fmt.Errorf("failed to explain query: %w", err)
+	}
+
+	var explain []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &explain); err != nil {
+		return 0, fmt.Errorf("failed to parse query plan: %w", err)
+	}
+	if len(explain) == 0 {
+		return 0, fmt.Errorf("empty query plan")
+	}
+
+	return int(explain[0].Plan.Rows), nil
+}
@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockNamespace отделяет ключи блокировок воркеров от остальных advisory-блокировок сервиса
+const lockNamespace = "geonotify.lock:"
+
+// LockStat — состояние распределенной блокировки в этом процессе
+type LockStat struct {
+	Name string
+	// Held — процесс сейчас владеет блокировкой, HeldSince — с какого момента
+	Held      bool
+	HeldSince time.Time
+	// Acquired — сколько раз процесс захватывал блокировку, Lost — сколько раз терял ее из-за обрыва соединения
+	Acquired int64
+	Lost     int64
+	// Runs — запуски задачи под блокировкой, Skipped — запуски, пропущенные, пока блокировкой владел другой процесс
+	Runs    int64
+	Skipped int64
+	Errors  int64
+	// LastError — последняя ошибка захвата или проверки блокировки
+	LastError string
+}
+
+// Locks — распределенные блокировки периодических задач на сессионных advisory-блокировках Postgres.
+// Владелец держит блокировку на отдельном соединении пула, пока оно живо: при падении процесса Postgres
+// снимает блокировку вместе с сессией, и ее захватывает следующая реплика. Через PgBouncer в режиме
+// транзакций не работает — сессия там не закреплена за клиентом
+type Locks struct {
+	pool *pgxpool.Pool
+	// onChange вызывается при захвате и потере блокировки; err — причина потери
+	onChange func(name string, held bool, err error)
+
+	mu    sync.Mutex
+	locks map[string]*Lock
+}
+
+func NewLocks(pool *pgxpool.Pool, onChange func(name string, held bool, err error)) *Locks {
+	return &Locks{
+		pool:     pool,
+		onChange: onChange,
+		locks:    make(map[string]*Lock),
+	}
+}
+
+// Lock возвращает блокировку с именем name; повторный вызов с тем же именем возвращает ту же блокировку
+func (l *Locks) Lock(name string) *Lock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock, ok := l.locks[name]
+	if !ok {
+		lock = &Lock{locks: l, stat: LockStat{Name: name}}
+		l.locks[name] = lock
+	}
+	return lock
+}
+
+// Snapshot возвращает состояние всех блокировок по имени
+func (l *Locks) Snapshot() []LockStat {
+	l.mu.Lock()
+	locks := make([]*Lock, 0, len(l.locks))
+	for _, lock := range l.locks {
+		locks = append(locks, lock)
+	}
+	l.mu.Unlock()
+
+	stats := make([]LockStat, len(locks))
+	for i, lock := range locks {
+		stats[i] = lock.Stat()
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// ReleaseAll отпускает все захваченные блокировки, чтобы при остановке их сразу подхватили другие реплики
+func (l *Locks) ReleaseAll(ctx context.Context) {
+	l.mu.Lock()
+	locks := make([]*Lock, 0, len(l.locks))
+	for _, lock := range l.locks {
+		locks = append(locks, lock)
+	}
+	l.mu.Unlock()
+
+	for _, lock := range locks {
+		lock.Release(ctx)
+	}
+}
+
+type Lock struct {
+	locks *Locks
+
+	mu   sync.Mutex
+	conn *pgxpool.Conn
+	stat LockStat
+}
+
+// Hold сообщает, можно ли выполнить задачу в этом процессе: он уже владеет блокировкой
+// или только что ее захватил. Вызывается перед каждым запуском задачи
+func (l *Lock) Hold(ctx context.Context) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	held, err := l.hold(ctx)
+	if err != nil {
+		l.stat.Errors++
+		l.stat.LastError = err.Error()
+	}
+	if held {
+		l.stat.Runs++
+	} else {
+		l.stat.Skipped++
+	}
+	return held
+}
+
+func (l *Lock) hold(ctx context.Context) (bool, error) {
+	if l.conn != nil {
+		err := l.conn.Ping(ctx)
+		if err == nil {
+			return true, nil
+		}
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+
+		// сессия оборвалась, и Postgres уже снял блокировку: ее могла захватить другая реплика
+		l.drop(ctx)
+		l.stat.Lost++
+		l.notify(false, err)
+	}
+
+	conn, err := l.locks.pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var acquired bool
+	err = conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1));`, lockNamespace+l.stat.Name).Scan(&acquired)
+	if err != nil || !acquired {
+		conn.Release()
+		return false, err
+	}
+
+	l.conn = conn
+	l.stat.Held = true
+	l.stat.HeldSince = time.Now()
+	l.stat.Acquired++
+	l.notify(true, nil)
+	return true, nil
+}
+
+// Release отпускает блокировку, если процесс ею владеет
+func (l *Lock) Release(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return
+	}
+
+	_, err := l.conn.Exec(ctx, `SELECT pg_advisory_unlock(hashtext($1));`, lockNamespace+l.stat.Name)
+	if err != nil {
+		// соединение закрывается, чтобы Postgres снял блокировку вместе с сессией
+		l.conn.Conn().Close(ctx)
+	}
+	l.conn.Release()
+	l.conn = nil
+	l.stat.Held = false
+	l.stat.HeldSince = time.Time{}
+}
+
+func (l *Lock) Stat() LockStat {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.stat
+}
+
+func (l *Lock) drop(ctx context.Context) {
+	l.conn.Conn().Close(ctx)
+	l.conn.Release()
+	l.conn = nil
+	l.stat.Held = false
+	l.stat.HeldSince = time.Time{}
+}
+
+func (l *Lock) notify(held bool, err error) {
+	if l.locks.onChange != nil {
+		l.locks.onChange(l.stat.Name, held, err)
+	}
+}
@@ -7,29 +7,42 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var namedRegexp = regexp.MustCompile(`@(\w+)`)
 
-func QueryNamed(ctx context.Context, pool *pgxpool.Pool, query string, args map[string]interface{}) (pgx.Rows, error) {
-	query, positionalArgs := convertNamedQuery(query, args)
+func QueryNamed(ctx context.Context, q Querier, query string, args map[string]interface{}) (pgx.Rows, error) {
+	query, positionalArgs, err := convertNamedQuery(query, args)
+	if err != nil {
+		return nil, err
+	}
 
-	return pool.Query(ctx, query, positionalArgs...)
+	return q.Query(ctx, query, positionalArgs...)
 }
 
-func QueryRowNamed(ctx context.Context, pool *pgxpool.Pool, query string, args map[string]interface{}) pgx.Row {
-	query, positionalArgs := convertNamedQuery(query, args)
-	return pool.QueryRow(ctx, query, positionalArgs...)
+func QueryRowNamed(ctx context.Context, q Querier, query string, args map[string]interface{}) pgx.Row {
+	query, positionalArgs, err := convertNamedQuery(query, args)
+	if err != nil {
+		return errRow{err: err}
+	}
+	return q.QueryRow(ctx, query, positionalArgs...)
 }
 
-func ExecNamed(ctx context.Context, pool *pgxpool.Pool, query string, args map[string]interface{}) (pgconn.CommandTag, error) {
-	query, positionalArgs := convertNamedQuery(query, args)
-	return pool.Exec(ctx, query, positionalArgs...)
+func ExecNamed(ctx context.Context, q Querier, query string, args map[string]interface{}) (pgconn.CommandTag, error) {
+	query, positionalArgs, err := convertNamedQuery(query, args)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return q.Exec(ctx, query, positionalArgs...)
 }
 
-func convertNamedQuery(query string, args map[string]interface{}) (string, []interface{}) {
-	var positionalArgs []interface{}
+// convertNamedQuery заменяет @name на $N. Параметр без значения в args — ошибка,
+// иначе опечатка в имени молча превратилась бы в NULL
+func convertNamedQuery(query string, args map[string]interface{}) (string, []interface{}, error) {
+	var (
+		positionalArgs []interface{}
+		missing        []string
+	)
 	argIndex := make(map[string]int)
 
 	convertedQuery := namedRegexp.ReplaceAllStringFunc(query, func(match string) string {
@@ -39,10 +52,28 @@ func convertNamedQuery(query string, args map[string]interface{}) (string, []int
 			return fmt.Sprintf("$%d", idx)
 		}
 
-		positionalArgs = append(positionalArgs, args[paramName])
+		value, ok := args[paramName]
+		if !ok {
+			missing = append(missing, paramName)
+		}
+
+		positionalArgs = append(positionalArgs, value)
 		argIndex[paramName] = len(positionalArgs)
 		return fmt.Sprintf("$%d", len(positionalArgs))
 	})
 
-	return convertedQuery, positionalArgs
+	if len(missing) > 0 {
+		return "", nil, fmt.Errorf("missing named query arguments: %v", missing)
+	}
+
+	return convertedQuery, positionalArgs, nil
+}
+
+// errRow возвращает ошибку подготовки запроса из Scan, как это делает pgx.Row
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(dest ...any) error {
+	return r.err
 }
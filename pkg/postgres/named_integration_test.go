@@ -0,0 +1,76 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4otis/geonotify-service/internal/testenv"
+)
+
+var pg *testenv.Postgres
+
+func TestMain(m *testing.M) {
+	testenv.Main(m, func(ctx context.Context) (func(), error) {
+		var err error
+		pg, err = testenv.StartPostgres(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return pg.Close, nil
+	})
+}
+
+func TestNamedQueries(t *testing.T) {
+	ctx := context.Background()
+	// временная таблица видна одному соединению пула, поэтому таблица обычная
+	if _, err := pg.Pool.Exec(ctx, `CREATE TABLE IF NOT EXISTS named_test (id INT, a TEXT, b TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	pg.Reset(t, "named_test")
+
+	tag, err := ExecNamed(ctx, pg.Pool, `INSERT INTO named_test (id, a, b) VALUES (@id, @v, @v), (@id + 1, @v, NULL)`,
+		map[string]interface{}{"id": 1, "v": "x"})
+	if err != nil {
+		t.Fatalf("ExecNamed() error = %v", err)
+	}
+	if tag.RowsAffected() != 2 {
+		t.Errorf("ExecNamed() rows = %d, want 2", tag.RowsAffected())
+	}
+
+	var n int
+	err = QueryRowNamed(ctx, pg.Pool, `SELECT COUNT(*) FROM named_test WHERE a = @v AND b IS NOT DISTINCT FROM @b`,
+		map[string]interface{}{"v": "x", "b": nil}).Scan(&n)
+	if err != nil {
+		t.Fatalf("QueryRowNamed() error = %v", err)
+	}
+	if n != 1 {
+		t.Errorf("QueryRowNamed() = %d, want 1", n)
+	}
+
+	rows, err := QueryNamed(ctx, pg.Pool, `SELECT id FROM named_test WHERE a = @v ORDER BY id`, map[string]interface{}{"v": "x"})
+	if err != nil {
+		t.Fatalf("QueryNamed() error = %v", err)
+	}
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("QueryNamed() ids = %v, want [1 2]", ids)
+	}
+
+	// ошибка подготовки возвращается из Scan, запрос не уходит в БД
+	err = QueryRowNamed(ctx, pg.Pool, `SELECT @missing`, nil).Scan(&n)
+	if err == nil {
+		t.Error("QueryRowNamed() with missing arg error = nil")
+	}
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvertNamedQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     map[string]interface{}
+		want     string
+		wantArgs []interface{}
+		wantErr  string
+	}{
+		{
+			name:     "positional in order of appearance",
+			query:    `SELECT * FROM incidents WHERE id = @id AND status = @status`,
+			args:     map[string]interface{}{"status": "published", "id": 7},
+			want:     `SELECT * FROM incidents WHERE id = $1 AND status = $2`,
+			wantArgs: []interface{}{7, "published"},
+		},
+		{
+			name:     "repeated name reuses placeholder",
+			query:    `UPDATE incidents SET created_by = @by, updated_by = @by WHERE id = @id`,
+			args:     map[string]interface{}{"by": "alice", "id": 1},
+			want:     `UPDATE incidents SET created_by = $1, updated_by = $1 WHERE id = $2`,
+			wantArgs: []interface{}{"alice", 1},
+		},
+		{
+			name:     "nil value is passed",
+			query:    `INSERT INTO incidents (expires_at) VALUES (@expires_at)`,
+			args:     map[string]interface{}{"expires_at": nil},
+			want:     `INSERT INTO incidents (expires_at) VALUES ($1)`,
+			wantArgs: []interface{}{nil},
+		},
+		{
+			name:     "extra args are ignored",
+			query:    `SELECT @a`,
+			args:     map[string]interface{}{"a": 1, "b": 2},
+			want:     `SELECT $1`,
+			wantArgs: []interface{}{1},
+		},
+		{
+			name:     "jsonb operators are not params",
+			query:    `SELECT id FROM webhooks WHERE payload @> @filter`,
+			args:     map[string]interface{}{"filter": `{"event":"location.alert"}`},
+			want:     `SELECT id FROM webhooks WHERE payload @> $1`,
+			wantArgs: []interface{}{`{"event":"location.alert"}`},
+		},
+		{
+			name:  "no params",
+			query: `SELECT 1`,
+			want:  `SELECT 1`,
+		},
+		{
+			name:    "missing args are listed",
+			query:   `SELECT @a, @b, @c`,
+			args:    map[string]interface{}{"b": 1},
+			wantErr: "missing named query arguments: [a c]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotArgs, err := convertNamedQuery(tt.query, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("convertNamedQuery() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertNamedQuery() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("convertNamedQuery() query = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("convertNamedQuery() args = %#v, want %#v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
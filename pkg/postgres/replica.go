@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Replica — пул read-only реплики. Пока реплика недоступна, чтения идут в основной пул,
+// а реплика пробуется снова не раньше чем через retryAfter после последнего сбоя
+type Replica struct {
+	pool       *pgxpool.Pool
+	retryAfter time.Duration
+	// onChange вызывается при переходе реплики между доступна/недоступна
+	onChange func(available bool, err error)
+
+	mu        sync.Mutex
+	down      bool
+	downUntil time.Time
+}
+
+func NewReplica(pool *pgxpool.Pool, retryAfter time.Duration, onChange func(available bool, err error)) *Replica {
+	return &Replica{
+		pool:       pool,
+		retryAfter: retryAfter,
+		onChange:   onChange,
+	}
+}
+
+func (r *Replica) Close() {
+	r.pool.Close()
+}
+
+// ReadConn возвращает соединение для чтений, допускающих отставание реплики: открытая транзакция
+// из контекста (она должна видеть свои записи), иначе реплика с откатом на основной пул.
+// replica nil — реплика не настроена
+func ReadConn(ctx context.Context, pool *pgxpool.Pool, replica *Replica) Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	if replica == nil || !replica.available() {
+		return pool
+	}
+	return &replicaConn{replica: replica, primary: pool}
+}
+
+// available — после паузы через реплику снова идет пробное чтение
+func (r *Replica) available() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return !r.down || !time.Now().Before(r.downUntil)
+}
+
+// result учитывает итог чтения с реплики и сообщает, нужно ли повторить его на основном пуле
+func (r *Replica) result(err error) (retry bool) {
+	if err != nil && !isConnError(err) {
+		err = nil
+	}
+
+	down := err != nil
+	r.mu.Lock()
+	changed := r.down != down
+	r.down = down
+	if down {
+		r.downUntil = time.Now().Add(r.retryAfter)
+	}
+	r.mu.Unlock()
+
+	if changed && r.onChange != nil {
+		r.onChange(!down, err)
+	}
+	return down
+}
+
+func isConnError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return pgconn.SafeToRetry(err) || errors.As(err, &connectErr) || errors.As(err, &netErr)
+}
+
+// replicaConn читает с реплики и повторяет чтение на основном пуле при ошибке соединения.
+// Запись через него уходит в основной пул
+type replicaConn struct {
+	replica *Replica
+	primary *pgxpool.Pool
+}
+
+func (c *replicaConn) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := c.replica.pool.Query(ctx, sql, args...)
+	if c.replica.result(err) {
+		return c.primary.Query(ctx, sql, args...)
+	}
+	return rows, err
+}
+
+func (c *replicaConn) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return &replicaRow{conn: c, ctx: ctx, sql: sql, args: args}
+}
+
+func (c *replicaConn) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return c.primary.Exec(ctx, sql, args...)
+}
+
+func (c *replicaConn) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return c.primary.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+// replicaRow откладывает запрос до Scan, как pgx.Row, чтобы повторить его на основном пуле
+type replicaRow struct {
+	conn *replicaConn
+	ctx  context.Context
+	sql  string
+	args []any
+}
+
+func (r *replicaRow) Scan(dest ...any) error {
+	err := r.conn.replica.pool.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+	if r.conn.replica.result(err) {
+		return r.conn.primary.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+	}
+	return err
+}
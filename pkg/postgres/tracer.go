@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// QueryBucketsMs — верхние границы корзин гистограммы длительности запросов, мс
+var QueryBucketsMs = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// maxTracedStatements ограничивает число различных запросов в статистике: запросы сверх лимита
+// (например, собранные через fmt.Sprintf с разными значениями) учитываются под otherStatement
+const (
+	maxTracedStatements = 500
+	otherStatement      = "<other>"
+)
+
+// QueryStat — накопленная статистика одного нормализованного запроса
+type QueryStat struct {
+	SQL    string
+	Calls  int64
+	Errors int64
+	Total  time.Duration
+	Max    time.Duration
+	// Buckets[i] — число запросов не дольше QueryBucketsMs[i]; последний элемент — дольше всех границ
+	Buckets []int64
+}
+
+// QueryTracer считает длительность запросов по нормализованному SQL и сообщает о медленных
+// через onSlow. Подключается к пулу через pgx.ConnConfig.Tracer
+type QueryTracer struct {
+	slow   time.Duration
+	onSlow func(sql string, duration time.Duration, err error)
+
+	mu    sync.Mutex
+	stats map[string]*QueryStat
+}
+
+// NewQueryTracer: slow 0 — медленные запросы не отслеживаются
+func NewQueryTracer(slow time.Duration, onSlow func(sql string, duration time.Duration, err error)) *QueryTracer {
+	return &QueryTracer{
+		slow:   slow,
+		onSlow: onSlow,
+		stats:  make(map[string]*QueryStat),
+	}
+}
+
+type traceKey struct{}
+
+type traceStart struct {
+	sql   string
+	start time.Time
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, traceKey{}, traceStart{sql: data.SQL, start: time.Now()})
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	t.end(ctx, data.Err)
+}
+
+func (t *QueryTracer) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	sql := "COPY " + data.TableName.Sanitize() + " (" + strings.Join(data.ColumnNames, ", ") + ") FROM STDIN"
+	return context.WithValue(ctx, traceKey{}, traceStart{sql: sql, start: time.Now()})
+}
+
+func (t *QueryTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	t.end(ctx, data.Err)
+}
+
+func (t *QueryTracer) end(ctx context.Context, err error) {
+	start, ok := ctx.Value(traceKey{}).(traceStart)
+	if !ok {
+		return
+	}
+	duration := time.Since(start.start)
+	sql := NormalizeSQL(start.sql)
+
+	t.record(sql, duration, err)
+
+	if t.slow > 0 && duration >= t.slow && t.onSlow != nil {
+		t.onSlow(sql, duration, err)
+	}
+}
+
+func (t *QueryTracer) record(sql string, duration time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stat, ok := t.stats[sql]
+	if !ok {
+		if len(t.stats) >= maxTracedStatements {
+			sql = otherStatement
+			stat = t.stats[sql]
+		}
+		if stat == nil {
+			stat = &QueryStat{SQL: sql, Buckets: make([]int64, len(QueryBucketsMs)+1)}
+			t.stats[sql] = stat
+		}
+	}
+
+	stat.Calls++
+	if err != nil {
+		stat.Errors++
+	}
+	stat.Total += duration
+	if duration > stat.Max {
+		stat.Max = duration
+	}
+
+	ms := float64(duration) / float64(time.Millisecond)
+	bucket := sort.SearchFloat64s(QueryBucketsMs, ms)
+	stat.Buckets[bucket]++
+}
+
+// Snapshot возвращает копию статистики, начиная с запросов с наибольшим суммарным временем
+func (t *QueryTracer) Snapshot() []QueryStat {
+	t.mu.Lock()
+	stats := make([]QueryStat, 0, len(t.stats))
+	for _, stat := range t.stats {
+		s := *stat
+		s.Buckets = append([]int64(nil), stat.Buckets...)
+		stats = append(stats, s)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Total > stats[j].Total
+	})
+	return stats
+}
+
+// Reset очищает накопленную статистику
+func (t *QueryTracer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stats = make(map[string]*QueryStat)
+}
+
+var (
+	sqlStringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+	// число, не являющееся частью идентификатора или параметра $N
+	sqlNumberLiteral = regexp.MustCompile(`(^|[^\w$.])\d+(?:\.\d+)?\b`)
+	sqlWhitespace    = regexp.MustCompile(`\s+`)
+)
+
+// NormalizeSQL приводит запрос к виду без значений: строковые и числовые литералы заменяются
+// на ?, пробелы сжимаются. Так запросы, собранные с разными значениями, попадают в одну строку
+// статистики, а в лог не попадают данные
+func NormalizeSQL(sql string) string {
+	sql = sqlStringLiteral.ReplaceAllString(sql, "?")
+	sql = sqlNumberLiteral.ReplaceAllString(sql, "${1}?")
+	return strings.TrimSpace(sqlWhitespace.ReplaceAllString(sql, " "))
+}
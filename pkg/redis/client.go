@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
-	"github.com/go-redis/redis/v8"
+	"github.com/redis/go-redis/v9"
 )
 
 var (
@@ -15,14 +18,36 @@ var (
 
 type Client struct {
 	client *redis.Client
-	ctx    context.Context
+
+	down     atomic.Bool
+	onChange atomic.Pointer[func(available bool)]
+}
+
+// PoolOptions — пул соединений клиента; нулевые поля — значение из URL или по умолчанию go-redis
+type PoolOptions struct {
+	PoolSize        int
+	MinIdleConns    int
+	PoolTimeout     time.Duration
+	ConnMaxLifetime time.Duration
 }
 
-func NewClient(ctx context.Context, url string) (*Client, error) {
+func NewClient(ctx context.Context, url string, pool PoolOptions) (*Client, error) {
 	opts, err := redis.ParseURL(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse redis URL: %w", err)
 	}
+	if pool.PoolSize > 0 {
+		opts.PoolSize = pool.PoolSize
+	}
+	if pool.MinIdleConns > 0 {
+		opts.MinIdleConns = pool.MinIdleConns
+	}
+	if pool.PoolTimeout > 0 {
+		opts.PoolTimeout = pool.PoolTimeout
+	}
+	if pool.ConnMaxLifetime > 0 {
+		opts.ConnMaxLifetime = pool.ConnMaxLifetime
+	}
 
 	client := redis.NewClient(opts)
 
@@ -32,29 +57,30 @@ func NewClient(ctx context.Context, url string) (*Client, error) {
 
 	return &Client{
 		client: client,
-		ctx:    ctx,
 	}, nil
 }
 
-func (c *Client) Set(key string, value interface{}, ttl time.Duration) error {
+func (c *Client) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	data, err := json.Marshal(value)
 	if err != nil {
 		return fmt.Errorf("failed to marshal value: %w", err)
 	}
 
-	if err := c.client.Set(c.ctx, key, data, ttl).Err(); err != nil {
+	if err := c.client.Set(ctx, key, data, ttl).Err(); err != nil {
+		c.observe(err)
 		return fmt.Errorf("failed to set key %s: %w", key, err)
 	}
 
 	return nil
 }
 
-func (c *Client) Get(key string, dest interface{}) error {
-	data, err := c.client.Get(c.ctx, key).Bytes()
+func (c *Client) Get(ctx context.Context, key string, dest interface{}) error {
+	data, err := c.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
 		return ErrNotFound
 	}
 	if err != nil {
+		c.observe(err)
 		return fmt.Errorf("failed to get key %s: %w", key, err)
 	}
 
@@ -65,32 +91,52 @@ func (c *Client) Get(key string, dest interface{}) error {
 	return nil
 }
 
-func (c *Client) Delete(key string) error {
-	if err := c.client.Del(c.ctx, key).Err(); err != nil {
+func (c *Client) Delete(ctx context.Context, key string) error {
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		c.observe(err)
 		return fmt.Errorf("failed to delete key %s: %w", key, err)
 	}
 	return nil
 }
 
-func (c *Client) LPush(queue string, value interface{}) error {
+// TTL возвращает оставшееся время жизни ключа; 0 — ключ без срока, ErrNotFound — ключа нет
+func (c *Client) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := c.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get TTL of key %s: %w", key, err)
+	}
+
+	switch ttl {
+	case -2:
+		return 0, ErrNotFound
+	case -1:
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (c *Client) LPush(ctx context.Context, queue string, value interface{}) error {
 	data, err := json.Marshal(value)
 	if err != nil {
 		return fmt.Errorf("failed to marshal value: %w", err)
 	}
 
-	if err := c.client.LPush(c.ctx, queue, data).Err(); err != nil {
+	if err := c.client.LPush(ctx, queue, data).Err(); err != nil {
+		c.observe(err)
 		return fmt.Errorf("failed to push to queue %s: %w", queue, err)
 	}
 
 	return nil
 }
 
-func (c *Client) BRPop(queue string, timeout time.Duration) (string, []byte, error) {
-	result, err := c.client.BRPop(c.ctx, timeout, queue).Result()
+func (c *Client) BRPop(ctx context.Context, queue string, timeout time.Duration) (string, []byte, error) {
+	result, err := c.client.BRPop(ctx, timeout, queue).Result()
 	if err == redis.Nil {
 		return "", nil, ErrNotFound
 	}
 	if err != nil {
+		c.observe(err)
 		return "", nil, fmt.Errorf("failed to BRPop from queue %s: %w", queue, err)
 	}
 
@@ -101,13 +147,22 @@ func (c *Client) BRPop(queue string, timeout time.Duration) (string, []byte, err
 	return result[0], []byte(result[1]), nil
 }
 
-func (c *Client) ZAdd(queue string, score float64, member interface{}) error {
+func (c *Client) LLen(ctx context.Context, queue string) (int64, error) {
+	n, err := c.client.LLen(ctx, queue).Result()
+	if err != nil {
+		c.observe(err)
+		return 0, fmt.Errorf("failed to LLen queue %s: %w", queue, err)
+	}
+	return n, nil
+}
+
+func (c *Client) ZAdd(ctx context.Context, queue string, score float64, member interface{}) error {
 	data, err := json.Marshal(member)
 	if err != nil {
 		return fmt.Errorf("failed to marshal member: %w", err)
 	}
 
-	if err := c.client.ZAdd(c.ctx, queue, &redis.Z{
+	if err := c.client.ZAdd(ctx, queue, redis.Z{
 		Score:  score,
 		Member: data,
 	}).Err(); err != nil {
@@ -117,7 +172,7 @@ func (c *Client) ZAdd(queue string, score float64, member interface{}) error {
 	return nil
 }
 
-func (c *Client) ZRangeByScore(queue string, min, max string, offset, count int64) ([][]byte, error) {
+func (c *Client) ZRangeByScore(ctx context.Context, queue string, min, max string, offset, count int64) ([][]byte, error) {
 	opt := &redis.ZRangeBy{
 		Min:    min,
 		Max:    max,
@@ -125,7 +180,7 @@ func (c *Client) ZRangeByScore(queue string, min, max string, offset, count int6
 		Count:  count,
 	}
 
-	members, err := c.client.ZRangeByScore(c.ctx, queue, opt).Result()
+	members, err := c.client.ZRangeByScore(ctx, queue, opt).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to ZRangeByScore from queue %s: %w", queue, err)
 	}
@@ -138,23 +193,299 @@ func (c *Client) ZRangeByScore(queue string, min, max string, offset, count int6
 	return result, nil
 }
 
-func (c *Client) ZRem(queue string, member interface{}) error {
+func (c *Client) ZCount(ctx context.Context, queue string, min, max string) (int64, error) {
+	n, err := c.client.ZCount(ctx, queue, min, max).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to ZCount queue %s: %w", queue, err)
+	}
+	return n, nil
+}
+
+// ZRem удаляет участника и сообщает, был ли он в множестве
+func (c *Client) ZRem(ctx context.Context, queue string, member interface{}) (bool, error) {
 	data, err := json.Marshal(member)
 	if err != nil {
-		return fmt.Errorf("failed to marshal member: %w", err)
+		return false, fmt.Errorf("failed to marshal member: %w", err)
+	}
+
+	removed, err := c.client.ZRem(ctx, queue, data).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to ZRem from queue %s: %w", queue, err)
+	}
+
+	return removed > 0, nil
+}
+
+// XAdd добавляет запись в стрим; maxLen > 0 ограничивает его длину приблизительно (MAXLEN ~)
+func (c *Client) XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+	id, err := c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: maxLen > 0,
+		Values: values,
+	}).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to XAdd to stream %s: %w", stream, err)
+	}
+
+	return id, nil
+}
+
+type StreamMessage struct {
+	ID     string
+	Values map[string]interface{}
+}
+
+// EnsureGroup создает группу потребителей (и сам стрим), если их еще нет
+func (c *Client) EnsureGroup(ctx context.Context, stream, group string) error {
+	err := c.client.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create group %s on stream %s: %w", group, stream, err)
+	}
+
+	return nil
+}
+
+// ReadGroup читает новые сообщения группы; при пустом стриме возвращает nil после block
+func (c *Client) ReadGroup(ctx context.Context, stream, group, consumer string, count int64, block time.Duration) ([]StreamMessage, error) {
+	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{stream, ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to XReadGroup from stream %s: %w", stream, err)
+	}
+
+	var messages []StreamMessage
+	for _, s := range streams {
+		for _, m := range s.Messages {
+			messages = append(messages, StreamMessage{ID: m.ID, Values: m.Values})
+		}
+	}
+
+	return messages, nil
+}
+
+// ClaimStale забирает сообщения, которые другие потребители не подтвердили дольше minIdle
+func (c *Client) ClaimStale(ctx context.Context, stream, group, consumer string, minIdle time.Duration, count int64) ([]StreamMessage, error) {
+	claimed, _, err := c.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Start:    "0-0",
+		Count:    count,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to XAutoClaim from stream %s: %w", stream, err)
+	}
+
+	messages := make([]StreamMessage, len(claimed))
+	for i, m := range claimed {
+		messages[i] = StreamMessage{ID: m.ID, Values: m.Values}
+	}
+
+	return messages, nil
+}
+
+func (c *Client) Ack(ctx context.Context, stream, group string, ids ...string) error {
+	if err := c.client.XAck(ctx, stream, group, ids...).Err(); err != nil {
+		return fmt.Errorf("failed to XAck on stream %s: %w", stream, err)
+	}
+
+	return nil
+}
+
+func (c *Client) Publish(ctx context.Context, channel string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
 	}
 
-	if err := c.client.ZRem(c.ctx, queue, data).Err(); err != nil {
-		return fmt.Errorf("failed to ZRem from queue %s: %w", queue, err)
+	if err := c.client.Publish(ctx, channel, data).Err(); err != nil {
+		c.observe(err)
+		return fmt.Errorf("failed to publish to channel %s: %w", channel, err)
 	}
 
 	return nil
 }
 
+// Subscribe подписывается на канал; сообщения приходят в возвращаемый канал до вызова unsubscribe
+func (c *Client) Subscribe(ctx context.Context, channel string) (<-chan []byte, func() error, error) {
+	pubsub := c.client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, nil, fmt.Errorf("failed to subscribe to channel %s: %w", channel, err)
+	}
+
+	out := make(chan []byte)
+	done := make(chan struct{})
+	go func() {
+		defer close(out)
+		for msg := range pubsub.Channel() {
+			select {
+			case out <- []byte(msg.Payload):
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	unsubscribe := func() error {
+		close(done)
+		return pubsub.Close()
+	}
+
+	return out, unsubscribe, nil
+}
+
+// Incr увеличивает счетчик key и продлевает ему срок жизни до ttl
+func (c *Client) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		c.observe(err)
+		return 0, fmt.Errorf("failed to increment %s: %w", key, err)
+	}
+
+	return incr.Val(), nil
+}
+
+// Script — Lua-скрипт, который Redis выполняет атомарно; запускается по SHA1 с загрузкой при первом вызове
+type Script = redis.Script
+
+func NewScript(src string) *Script {
+	return redis.NewScript(src)
+}
+
+// RunInts выполняет скрипт, возвращающий массив целых чисел
+func (c *Client) RunInts(ctx context.Context, script *Script, keys []string, args ...interface{}) ([]int64, error) {
+	values, err := script.Run(ctx, c.client, keys, args...).Int64Slice()
+	if err != nil {
+		c.observe(err)
+		return nil, fmt.Errorf("failed to run script: %w", err)
+	}
+	return values, nil
+}
+
+// RunStrings выполняет скрипт, возвращающий массив строк
+func (c *Client) RunStrings(ctx context.Context, script *Script, keys []string, args ...interface{}) ([]string, error) {
+	values, err := script.Run(ctx, c.client, keys, args...).StringSlice()
+	if err != nil {
+		c.observe(err)
+		return nil, fmt.Errorf("failed to run script: %w", err)
+	}
+	return values, nil
+}
+
+// GetInts читает целочисленные счетчики; отсутствующий ключ — 0
+func (c *Client) GetInts(ctx context.Context, keys ...string) ([]int64, error) {
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		c.observe(err)
+		return nil, fmt.Errorf("failed to get counters: %w", err)
+	}
+
+	counters := make([]int64, len(values))
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if counters[i], err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, fmt.Errorf("counter %s is not an integer: %w", keys[i], err)
+		}
+	}
+	return counters, nil
+}
+
+// HIncrBy увеличивает поля хэша key на значения из fields одним обращением
+func (c *Client) HIncrBy(ctx context.Context, key string, fields map[string]int64) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for field, incr := range fields {
+			pipe.HIncrBy(ctx, key, field, incr)
+		}
+		return nil
+	})
+	if err != nil {
+		c.observe(err)
+		return fmt.Errorf("failed to increment fields of %s: %w", key, err)
+	}
+	return nil
+}
+
+func (c *Client) GeoAdd(ctx context.Context, key, member string, lat, lng float64) error {
+	err := c.client.GeoAdd(ctx, key, &redis.GeoLocation{
+		Name:      member,
+		Latitude:  lat,
+		Longitude: lng,
+	}).Err()
+	if err != nil {
+		c.observe(err)
+		return fmt.Errorf("failed to GeoAdd to %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// GeoRemove удаляет участника из гео-индекса; отсутствующий участник ошибкой не считается
+func (c *Client) GeoRemove(ctx context.Context, key, member string) error {
+	// гео-индекс — обычное упорядоченное множество, в отличие от ZRem участник не сериализуется в JSON
+	if err := c.client.ZRem(ctx, key, member).Err(); err != nil {
+		c.observe(err)
+		return fmt.Errorf("failed to GeoRemove from %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// GeoRadius возвращает участников в радиусе radiusM метров от точки
+func (c *Client) GeoRadius(ctx context.Context, key string, lat, lng, radiusM float64) ([]string, error) {
+	locations, err := c.client.GeoRadius(ctx, key, lng, lat, &redis.GeoRadiusQuery{
+		Radius: radiusM,
+		Unit:   "m",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to GeoRadius on %s: %w", key, err)
+	}
+
+	members := make([]string, len(locations))
+	for i, l := range locations {
+		members[i] = l.Name
+	}
+
+	return members, nil
+}
+
+// Pool возвращает действующие настройки пула, с подставленными значениями по умолчанию
+func (c *Client) Pool() PoolOptions {
+	opts := c.client.Options()
+	return PoolOptions{
+		PoolSize:        opts.PoolSize,
+		MinIdleConns:    opts.MinIdleConns,
+		PoolTimeout:     opts.PoolTimeout,
+		ConnMaxLifetime: opts.ConnMaxLifetime,
+	}
+}
+
 func (c *Client) Close() error {
 	return c.client.Close()
 }
 
-func (c *Client) HealthCheck() error {
-	return c.client.Ping(c.ctx).Err()
+func (c *Client) HealthCheck(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
 }
@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"time"
+)
+
+// Available сообщает, доступен ли Redis по последним наблюдениям. Пока Redis
+// недоступен, вызывающий код может пропускать необязательные операции, не дожидаясь таймаутов
+func (c *Client) Available() bool {
+	return !c.down.Load()
+}
+
+// MonitorHealth пингует Redis каждые interval до отмены ctx и вызывает onChange
+// при смене состояния доступности
+func (c *Client) MonitorHealth(ctx context.Context, interval time.Duration, onChange func(available bool)) {
+	c.onChange.Store(&onChange)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pingCtx, cancel := context.WithTimeout(ctx, interval)
+			err := c.client.Ping(pingCtx).Err()
+			cancel()
+			if ctx.Err() != nil {
+				return
+			}
+
+			c.setAvailable(err == nil)
+		}
+	}
+}
+
+// observe помечает Redis недоступным при сетевой ошибке команды, не дожидаясь пинга
+func (c *Client) observe(err error) {
+	if err == nil || !isConnError(err) {
+		return
+	}
+
+	c.setAvailable(false)
+}
+
+func (c *Client) setAvailable(available bool) {
+	wasDown := c.down.Swap(!available)
+	if wasDown != available {
+		return
+	}
+
+	if onChange := c.onChange.Load(); onChange != nil && *onChange != nil {
+		(*onChange)(available)
+	}
+}
+
+func isConnError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF)
+}
@@ -0,0 +1,40 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Window — повторяющееся окно активности: начинается по cron-выражению и длится Duration
+type Window struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+func Parse(expr string, duration time.Duration) (*Window, error) {
+	s, err := parser.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+
+	if duration <= 0 {
+		return nil, fmt.Errorf("window duration must be positive")
+	}
+
+	return &Window{schedule: s, duration: duration}, nil
+}
+
+// ActiveAt сообщает, попадает ли t в одно из окон активности
+func (w *Window) ActiveAt(t time.Time) bool {
+	start := w.schedule.Next(t.Add(-w.duration))
+	return !start.After(t)
+}
+
+// NextActivation возвращает начало ближайшего окна строго после t
+func (w *Window) NextActivation(t time.Time) time.Time {
+	return w.schedule.Next(t)
+}